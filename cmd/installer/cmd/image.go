@@ -85,7 +85,7 @@ func runImageCmd() (err error) {
 
 	if options.ConfigSource == "" {
 		switch p.Name() {
-		case "aws", "azure", "digital-ocean", "gcp":
+		case "aws", "azure", "digital-ocean", "gcp", "hcloud", "nocloud", "oracle":
 			options.ConfigSource = constants.ConfigNone
 		case "vmware":
 			options.ConfigSource = constants.ConfigGuestInfo
@@ -140,10 +140,34 @@ func finalize(platform runtime.Platform, img string) (err error) {
 		if err = tar(fmt.Sprintf("gcp-%s.tar.gz", stdruntime.GOARCH), file, dir); err != nil {
 			return err
 		}
+	case "hcloud", "nocloud":
+		file = filepath.Join(outputArg, fmt.Sprintf("%s-%s.raw", platform.Name(), stdruntime.GOARCH))
+
+		if err = os.Rename(img, file); err != nil {
+			return err
+		}
+
+		log.Println("compressing image")
+
+		if err = xz(file); err != nil {
+			return err
+		}
 	case "openstack":
 		if err = tar(fmt.Sprintf("openstack-%s.tar.gz", stdruntime.GOARCH), file, dir); err != nil {
 			return err
 		}
+	case "oracle":
+		file = filepath.Join(outputArg, fmt.Sprintf("oracle-%s.qcow2", stdruntime.GOARCH))
+
+		if err = qemuimg.Convert("raw", "qcow2", "compat=1.1", img, file); err != nil {
+			return err
+		}
+
+		log.Println("compressing image")
+
+		if err = xz(file); err != nil {
+			return err
+		}
 	case "vmware":
 		if err = ova.CreateOVAFromRAW(name, img, outputArg); err != nil {
 			return err
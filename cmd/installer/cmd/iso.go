@@ -16,6 +16,8 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/talos-systems/talos/cmd/installer/pkg"
+	"github.com/talos-systems/talos/pkg/copy"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
 )
 
 var cfg = []byte(`set default=0
@@ -33,6 +35,8 @@ menuentry "Talos ISO" {
 	initrd /boot/initramfs.xz
 }`)
 
+var imageCacheArg string
+
 // isoCmd represents the iso command.
 var isoCmd = &cobra.Command{
 	Use:   "iso",
@@ -48,6 +52,7 @@ var isoCmd = &cobra.Command{
 func init() {
 	isoCmd.Flags().StringVar(&outputArg, "output", "/out", "The output path")
 	isoCmd.Flags().BoolVar(&tarToStdout, "tar-to-stdout", false, "Tar output and send to stdout")
+	isoCmd.Flags().StringVar(&imageCacheArg, "image-cache", "", "The path to the OCI image layout to bundle into the ISO as the image cache")
 	rootCmd.AddCommand(isoCmd)
 }
 
@@ -89,6 +94,14 @@ func runISOCmd() error {
 		}
 	}
 
+	if imageCacheArg != "" {
+		log.Printf("copying image cache from %s", imageCacheArg)
+
+		if err := copy.Dir(imageCacheArg, filepath.Join("/mnt", constants.ImageCacheISOPath)); err != nil {
+			return err
+		}
+	}
+
 	log.Println("creating grub.cfg")
 
 	cfgPath := "/mnt/boot/grub/grub.cfg"
@@ -37,9 +37,16 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&options.Disk, "disk", "", "The path to the disk to install to")
 	rootCmd.PersistentFlags().StringVar(&options.Platform, "platform", "", "The value of "+constants.KernelParamPlatform)
 	rootCmd.PersistentFlags().StringVar(&options.Board, "board", constants.BoardNone, "The value of "+constants.KernelParamBoard)
+	rootCmd.PersistentFlags().StringVar(&options.BoardOverlay, "board-overlay", "", "The path to the board overlay directory (overrides --board)")
 	rootCmd.PersistentFlags().StringArrayVar(&options.ExtraKernelArgs, "extra-kernel-arg", []string{}, "Extra argument to pass to the kernel")
 	rootCmd.PersistentFlags().BoolVar(&options.Bootloader, "bootloader", true, "Install a booloader to the specified disk")
+	rootCmd.PersistentFlags().StringVar(&options.BootloaderType, "bootloader-type", constants.BootloaderGRUB, "The bootloader to install (grub or sd-boot)")
+	rootCmd.PersistentFlags().StringArrayVar(&options.Consoles, "console", []string{}, "Console device to set in the boot entries (e.g. ttyS0,115200)")
 	rootCmd.PersistentFlags().BoolVar(&options.Upgrade, "upgrade", false, "Indicates that the install is being performed by an upgrade")
 	rootCmd.PersistentFlags().BoolVar(&options.Force, "force", false, "Indicates that the install should forcefully format the partition")
+	rootCmd.PersistentFlags().BoolVar(&options.ConfigCheckWarnOnly, "config-check-warn-only", false, "Only warn if the machine config is not compatible with the Talos version being installed on upgrade")
 	rootCmd.PersistentFlags().BoolVar(&options.Zero, "zero", false, "Indicates that the install should write zeros to the disk before installing")
+	rootCmd.PersistentFlags().BoolVar(&options.SecureBoot, "secureboot", false, "Install signed systemd-boot and Unified Kernel Images for SecureBoot (requires sd-boot bootloader type)")
+	rootCmd.PersistentFlags().StringVar(&options.SecureBootKey, "secureboot-key", "", "The path to the SecureBoot signing key")
+	rootCmd.PersistentFlags().StringVar(&options.SecureBootCert, "secureboot-cert", "", "The path to the SecureBoot signing certificate")
 }
@@ -0,0 +1,64 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package install
+
+import (
+	"log"
+	"strings"
+
+	"github.com/talos-systems/go-procfs/procfs"
+
+	"github.com/talos-systems/talos/pkg/machinery/kernel"
+)
+
+// bootloaderKernelArgs are set for each boot entry, so they are never carried over from the previous command line.
+var bootloaderKernelArgs = []string{"BOOT_IMAGE", "initrd"}
+
+// mergeKernelArgs finalizes the kernel command line.
+//
+// On upgrade, the arguments of the previous command line are carried over unless the argument with the same key
+// is already set, so that the extra kernel arguments applied earlier survive the upgrade.
+// Extra kernel arguments with the `-` prefix are removed from the command line.
+func (i *Installer) mergeKernelArgs() error {
+	if i.previousCmdline != "" {
+		var carried []string
+
+		for _, arg := range procfs.NewCmdline(i.previousCmdline).Strings() {
+			key := kernel.ArgKey(arg)
+
+			if i.cmdline.Get(key) != nil || isBootloaderKernelArg(key) {
+				continue
+			}
+
+			carried = append(carried, arg)
+		}
+
+		if len(carried) > 0 {
+			log.Printf("carrying over kernel args from the previous boot entry: %s", strings.Join(carried, " "))
+
+			if err := i.cmdline.AppendAll(carried); err != nil {
+				return err
+			}
+		}
+	}
+
+	_, removed := kernel.SplitRemovedArgs(i.options.ExtraKernelArgs)
+
+	if len(removed) > 0 {
+		i.cmdline = procfs.NewCmdline(strings.Join(kernel.RemoveArgs(i.cmdline.Strings(), removed), " "))
+	}
+
+	return nil
+}
+
+func isBootloaderKernelArg(key string) bool {
+	for _, arg := range bootloaderKernelArgs {
+		if arg == key {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,58 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package install
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
+	"github.com/talos-systems/talos/pkg/machinery/config"
+	"github.com/talos-systems/talos/pkg/machinery/config/configloader"
+	"github.com/talos-systems/talos/pkg/version"
+)
+
+// CheckMachineConfig verifies that the machine config persisted on the node is compatible with the Talos version being installed.
+//
+// The config should be loadable with the config schema of this version of Talos and pass the validation,
+// otherwise the next boot would fail. If warnOnly is set, the failures are logged as warnings.
+// The check is skipped if the config is not found (e.g. it wasn't provided by the older version of Talos).
+func CheckMachineConfig(path string, mode runtime.Mode, warnOnly bool) error {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		log.Printf("machine config %q not found, skipping config compatibility check", path)
+
+		return nil
+	}
+
+	err := checkMachineConfig(path, mode)
+	if err == nil {
+		return nil
+	}
+
+	err = fmt.Errorf("machine config is not compatible with Talos %s: %w", version.Tag, err)
+
+	if warnOnly {
+		log.Printf("WARNING: %s", err)
+
+		return nil
+	}
+
+	return err
+}
+
+func checkMachineConfig(path string, mode runtime.Mode) error {
+	cfg, err := configloader.NewFromFile(path)
+	if err != nil {
+		return err
+	}
+
+	warnings, err := cfg.Validate(mode, config.WithLocal())
+	for _, w := range warnings {
+		log.Printf("WARNING: machine config: %s", w)
+	}
+
+	return err
+}
@@ -0,0 +1,67 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package install_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/cmd/installer/pkg/install"
+	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1/bundle"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+)
+
+func TestCheckMachineConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "talos")
+	require.NoError(t, err)
+
+	defer os.RemoveAll(dir) //nolint:errcheck
+
+	b, err := bundle.NewConfigBundle(
+		bundle.WithInputOptions(
+			&bundle.InputOptions{
+				ClusterName: "talos-default",
+				Endpoint:    "https://10.5.0.1:6443",
+				KubeVersion: constants.DefaultKubernetesVersion,
+			},
+		),
+		bundle.WithVerbose(false),
+	)
+	require.NoError(t, err)
+
+	valid, err := b.JoinCfg.Bytes()
+	require.NoError(t, err)
+
+	validPath := filepath.Join(dir, "valid.yaml")
+	require.NoError(t, ioutil.WriteFile(validPath, valid, 0o600))
+
+	b.JoinCfg.MachineConfig.MachineInstall = &v1alpha1.InstallConfig{
+		InstallDisk:           "/dev/sda",
+		InstallBootloaderType: "lilo",
+	}
+
+	invalid, err := b.JoinCfg.Bytes()
+	require.NoError(t, err)
+
+	invalidPath := filepath.Join(dir, "invalid.yaml")
+	require.NoError(t, ioutil.WriteFile(invalidPath, invalid, 0o600))
+
+	assert.NoError(t, install.CheckMachineConfig(filepath.Join(dir, "missing.yaml"), runtime.ModeMetal, false))
+	assert.NoError(t, install.CheckMachineConfig(validPath, runtime.ModeContainer, false))
+
+	err = install.CheckMachineConfig(invalidPath, runtime.ModeMetal, false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "machine config is not compatible with Talos")
+	assert.Contains(t, err.Error(), "invalid bootloader type \"lilo\"")
+
+	assert.NoError(t, install.CheckMachineConfig(invalidPath, runtime.ModeMetal, true))
+}
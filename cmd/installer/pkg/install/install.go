@@ -7,7 +7,9 @@ package install
 import (
 	"fmt"
 	"log"
+	"os"
 	"path/filepath"
+	goruntime "runtime"
 
 	"github.com/talos-systems/go-blockdevice/blockdevice"
 	"github.com/talos-systems/go-procfs/procfs"
@@ -18,7 +20,9 @@ import (
 	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime/v1alpha1/bootloader"
 	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime/v1alpha1/bootloader/adv"
 	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime/v1alpha1/bootloader/grub"
+	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime/v1alpha1/bootloader/sdboot"
 	"github.com/talos-systems/talos/internal/pkg/mount"
+	"github.com/talos-systems/talos/internal/pkg/secureboot"
 	"github.com/talos-systems/talos/pkg/machinery/constants"
 	"github.com/talos-systems/talos/pkg/machinery/kernel"
 	"github.com/talos-systems/talos/pkg/version"
@@ -30,11 +34,79 @@ type Options struct {
 	Disk            string
 	Platform        string
 	Board           string
+	BoardOverlay    string
 	ExtraKernelArgs []string
 	Bootloader      bool
 	Upgrade         bool
 	Force           bool
 	Zero            bool
+	SecureBoot      bool
+	SecureBootKey   string
+	SecureBootCert  string
+	BootloaderType  string
+	Consoles        []string
+	KernelPath      string
+	InitramfsPath   string
+	UKIStubPath     string
+	OSReleasePath   string
+
+	// MachineConfigPath is the path to the persisted machine config checked on upgrade.
+	MachineConfigPath   string
+	ConfigCheckWarnOnly bool
+}
+
+// board returns the board the install is for, or nil if the install is not for a specific board.
+//
+// The board defined by the overlay directory takes precedence over the board name.
+func (o *Options) board() (runtime.Board, error) {
+	switch {
+	case o.BoardOverlay != "":
+		return board.NewBoardFromOverlay(o.BoardOverlay)
+	case o.Board != "" && o.Board != constants.BoardNone:
+		return board.NewBoard(o.Board)
+	default:
+		return nil, nil
+	}
+}
+
+func (o *Options) kernelPath() string {
+	if o.KernelPath != "" {
+		return o.KernelPath
+	}
+
+	return constants.KernelAssetPath
+}
+
+func (o *Options) ukiStubPath() string {
+	if o.UKIStubPath != "" {
+		return o.UKIStubPath
+	}
+
+	return constants.UKIStubPath
+}
+
+func (o *Options) osReleasePath() string {
+	if o.OSReleasePath != "" {
+		return o.OSReleasePath
+	}
+
+	return "/etc/os-release"
+}
+
+func (o *Options) machineConfigPath() string {
+	if o.MachineConfigPath != "" {
+		return o.MachineConfigPath
+	}
+
+	return constants.ConfigPath
+}
+
+func (o *Options) initramfsPath() string {
+	if o.InitramfsPath != "" {
+		return o.InitramfsPath
+	}
+
+	return constants.InitramfsAssetPath
 }
 
 // Install installs Talos.
@@ -53,10 +125,36 @@ func Install(p runtime.Platform, seq runtime.Sequence, opts *Options) (err error
 		return err
 	}
 
-	if err = cmdline.AppendAll(opts.ExtraKernelArgs, procfs.WithOverwriteArgs("console")); err != nil {
+	if len(opts.Consoles) > 0 {
+		consoleArgs := make([]string, 0, len(opts.Consoles))
+
+		for _, console := range opts.Consoles {
+			consoleArgs = append(consoleArgs, "console="+console)
+		}
+
+		if err = cmdline.AppendAll(consoleArgs, procfs.WithOverwriteArgs("console")); err != nil {
+			return err
+		}
+	}
+
+	extraKernelArgs, _ := kernel.SplitRemovedArgs(opts.ExtraKernelArgs)
+
+	if err = cmdline.AppendAll(extraKernelArgs, procfs.WithOverwriteArgs("console")); err != nil {
 		return err
 	}
 
+	if seq != runtime.SequenceNoop {
+		if err = Preflight(opts.Disk, opts.Force); err != nil {
+			return err
+		}
+	}
+
+	if seq == runtime.SequenceUpgrade {
+		if err = CheckMachineConfig(opts.machineConfigPath(), p.Mode(), opts.ConfigCheckWarnOnly); err != nil {
+			return err
+		}
+	}
+
 	i, err := NewInstaller(cmdline, seq, opts)
 	if err != nil {
 		return err
@@ -79,7 +177,9 @@ type Installer struct {
 	manifest   *Manifest
 	bootloader bootloader.Bootloader
 
-	bootPartitionFound bool
+	bootPartitionFound  bool
+	secureBootInstalled bool
+	previousCmdline     string
 
 	Current string
 	Next    string
@@ -87,18 +187,50 @@ type Installer struct {
 
 // NewInstaller initializes and returns an Installer.
 func NewInstaller(cmdline *procfs.Cmdline, seq runtime.Sequence, opts *Options) (i *Installer, err error) {
+	if opts.SecureBoot {
+		if goruntime.GOARCH != "amd64" {
+			return nil, fmt.Errorf("SecureBoot is not supported on %s", goruntime.GOARCH)
+		}
+
+		if opts.SecureBootKey == "" || opts.SecureBootCert == "" {
+			return nil, fmt.Errorf("SecureBoot requires signing key and certificate")
+		}
+
+		// GRUB is not signed, so only systemd-boot and the Unified Kernel Images can be booted with SecureBoot
+		if opts.BootloaderType != constants.BootloaderSDBoot {
+			return nil, fmt.Errorf("SecureBoot requires bootloader type %q", constants.BootloaderSDBoot)
+		}
+	}
+
 	i = &Installer{
 		cmdline: cmdline,
 		options: opts,
-		bootloader: &grub.Grub{
+	}
+
+	switch opts.BootloaderType {
+	case "", constants.BootloaderGRUB:
+		i.bootloader = &grub.Grub{
 			BootDisk: opts.Disk,
-		},
+		}
+	case constants.BootloaderSDBoot:
+		if goruntime.GOARCH != "amd64" {
+			return nil, fmt.Errorf("systemd-boot is not supported on %s", goruntime.GOARCH)
+		}
+
+		i.bootloader = &sdboot.SDBoot{}
+	default:
+		return nil, fmt.Errorf("unknown bootloader type %q", opts.BootloaderType)
 	}
 
 	if err = i.probeBootPartition(); err != nil {
 		return nil, err
 	}
 
+	// unsigned images can't be booted once the signing certificate is enrolled
+	if opts.Upgrade && i.secureBootInstalled && !opts.SecureBoot {
+		return nil, fmt.Errorf("installation is signed for SecureBoot, signing key and certificate are required for the upgrade (.machine.install.secureboot)")
+	}
+
 	i.manifest, err = NewManifest(i.Next, seq, i.bootPartitionFound, i.options)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create installation manifest: %w", err)
@@ -120,31 +252,49 @@ func (i *Installer) probeBootPartition() error {
 
 		defer dev.Close() //nolint:errcheck
 
-		if part, err := dev.GetPartition(constants.BootPartitionLabel); err != nil {
+		if _, err := dev.GetPartition(constants.BootPartitionLabel); err != nil {
 			i.bootPartitionFound = false
 		} else {
 			i.bootPartitionFound = true
 
-			// mount the boot partition temporarily to find the bootloader labels
+			// mount the boot partitions temporarily to find the bootloader labels
 			mountpoints := mount.NewMountPoints()
 
-			partPath, err := part.Path()
-			if err != nil {
-				return err
-			}
+			// EFI partition is mounted inside the boot partition, so the order matters
+			for _, p := range []struct {
+				label  string
+				target string
+			}{
+				{constants.BootPartitionLabel, constants.BootMountPoint},
+				{constants.EFIPartitionLabel, constants.EFIMountPoint},
+			} {
+				part, err := dev.GetPartition(p.label)
+				if err != nil {
+					continue
+				}
 
-			fsType, err := part.Filesystem()
-			if err != nil {
-				return err
-			}
+				partPath, err := part.Path()
+				if err != nil {
+					return err
+				}
+
+				fsType, err := part.Filesystem()
+				if err != nil {
+					return err
+				}
 
-			mountpoint := mount.NewMountPoint(partPath, constants.BootMountPoint, fsType, unix.MS_NOATIME|unix.MS_RDONLY, "")
-			mountpoints.Set(constants.BootPartitionLabel, mountpoint)
+				mountpoint := mount.NewMountPoint(partPath, p.target, fsType, unix.MS_NOATIME|unix.MS_RDONLY, "")
+				mountpoints.Set(p.label, mountpoint)
+			}
 
 			if err := mount.Mount(mountpoints); err != nil {
-				log.Printf("warning: failed to mount boot partition %q: %s", partPath, err)
+				log.Printf("warning: failed to mount boot partitions: %s", err)
 			} else {
 				defer mount.Unmount(mountpoints) //nolint:errcheck
+
+				if _, err := os.Stat(sdboot.SecureBootCertificatePath); err == nil {
+					i.secureBootInstalled = true
+				}
 			}
 		}
 	}
@@ -152,9 +302,20 @@ func (i *Installer) probeBootPartition() error {
 	var err error
 
 	// anyways run the Labels() to get the defaults initialized
-	i.Current, i.Next, err = i.bootloader.Labels()
+	//
+	// labels are read from the installed bootloader, as it might be different from the one being installed
+	i.Current, i.Next, err = bootloader.Probe(i.options.Disk).Labels()
+	if err != nil {
+		return err
+	}
+
+	if i.options.Upgrade && i.bootPartitionFound {
+		if i.previousCmdline, err = bootloader.DefaultCmdline(); err != nil {
+			log.Printf("warning: failed to read the kernel command line of the current boot entry: %s", err)
+		}
+	}
 
-	return err
+	return nil
 }
 
 // Install fetches the necessary data locations and copies or extracts
@@ -162,19 +323,21 @@ func (i *Installer) probeBootPartition() error {
 //
 //nolint:gocyclo,cyclop
 func (i *Installer) Install(seq runtime.Sequence) (err error) {
-	if i.options.Board != constants.BoardNone {
-		var b runtime.Board
-
-		b, err = board.NewBoard(i.options.Board)
-		if err != nil {
-			return err
-		}
+	b, err := i.options.board()
+	if err != nil {
+		return err
+	}
 
+	if b != nil {
 		i.cmdline.Append(constants.KernelParamBoard, b.Name())
 
 		i.cmdline.SetAll(b.KernelArgs().Strings())
 	}
 
+	if err = i.mergeKernelArgs(); err != nil {
+		return err
+	}
+
 	if err = i.manifest.Execute(); err != nil {
 		return err
 	}
@@ -255,10 +418,56 @@ func (i *Installer) Install(seq runtime.Sequence) (err error) {
 		return nil
 	}
 
+	// UKI embeds the initramfs, so the command line is captured before the initrd argument is added
+	ukiCmdline := i.cmdline.String()
+
+	if i.options.BootloaderType == constants.BootloaderSDBoot {
+		if err = i.installSDBoot(ukiCmdline, seq); err != nil {
+			return fmt.Errorf("failed to install systemd-boot: %w", err)
+		}
+	} else {
+		if err = i.installGRUB(seq); err != nil {
+			return err
+		}
+	}
+
+	if b != nil {
+		log.Printf("installing board payloads for %q", b.Name())
+
+		if err = b.Install(i.options.Disk); err != nil {
+			return err
+		}
+	}
+
+	if seq == runtime.SequenceUpgrade {
+		var meta *bootloader.Meta
+
+		if meta, err = bootloader.NewMeta(); err != nil {
+			return err
+		}
+
+		//nolint:errcheck
+		defer meta.Close()
+
+		if ok := meta.LegacyADV.SetTag(adv.Upgrade, i.Current); !ok {
+			return fmt.Errorf("failed to set upgrade tag: %q", i.Current)
+		}
+
+		if err = meta.Write(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// installGRUB writes GRUB config with the boot entries for the next and the current labels and installs GRUB.
+func (i *Installer) installGRUB(seq runtime.Sequence) error {
 	i.cmdline.Append("initrd", filepath.Join("/", i.Next, constants.InitramfsAsset))
 
 	grubcfg := &grub.Cfg{
 		Default: i.Next,
+		Serial:  grub.SerialFromConsoles(i.options.Consoles),
 		Labels: []*grub.Label{
 			{
 				Root:   i.Next,
@@ -280,43 +489,58 @@ func (i *Installer) Install(seq runtime.Sequence) (err error) {
 		})
 	}
 
-	if err = i.bootloader.Install(i.Current, grubcfg, seq); err != nil {
+	// systemd-boot loader config is left on the EFI partition when switching back to GRUB
+	if err := os.Remove(sdboot.LoaderConfig); err != nil && !os.IsNotExist(err) {
 		return err
 	}
 
-	if i.options.Board != constants.BoardNone {
-		var b runtime.Board
+	return i.bootloader.Install(i.Current, grubcfg, seq)
+}
 
-		b, err = board.NewBoard(i.options.Board)
-		if err != nil {
-			return err
-		}
+// installSDBoot builds the Unified Kernel Image for the next boot label and installs systemd-boot.
+//
+// The Unified Kernel Image of the current label is kept as the fallback entry.
+func (i *Installer) installSDBoot(cmdline string, seq runtime.Sequence) error {
+	if err := i.buildUKI(sdboot.UKIPath(i.Next), cmdline); err != nil {
+		return err
+	}
 
-		log.Printf("installing U-Boot for %q", b.Name())
+	if err := i.bootloader.Install(i.Current, &sdboot.Cfg{Default: i.Next, Timeout: 3}, seq); err != nil {
+		return err
+	}
 
-		if err = b.Install(i.options.Disk); err != nil {
-			return err
-		}
+	if !i.options.SecureBoot {
+		return nil
 	}
 
-	if seq == runtime.SequenceUpgrade {
-		var meta *bootloader.Meta
+	if err := secureboot.Sign(sdboot.BootloaderPath, i.options.SecureBootKey, i.options.SecureBootCert); err != nil {
+		return err
+	}
 
-		if meta, err = bootloader.NewMeta(); err != nil {
-			return err
-		}
+	return secureboot.WriteCertificateDER(i.options.SecureBootCert, sdboot.SecureBootCertificatePath)
+}
 
-		//nolint:errcheck
-		defer meta.Close()
+// buildUKI builds the Unified Kernel Image, the image is signed if SecureBoot is enabled.
+func (i *Installer) buildUKI(ukiPath, cmdline string) error {
+	if err := os.MkdirAll(filepath.Dir(ukiPath), 0o700); err != nil {
+		return err
+	}
 
-		if ok := meta.LegacyADV.SetTag(adv.Upgrade, i.Current); !ok {
-			return fmt.Errorf("failed to set upgrade tag: %q", i.Current)
-		}
+	uki := &secureboot.UKI{
+		Stub:      i.options.ukiStubPath(),
+		Kernel:    i.options.kernelPath(),
+		Initrd:    i.options.initramfsPath(),
+		OSRelease: i.options.osReleasePath(),
+		Cmdline:   cmdline,
+	}
 
-		if err = meta.Write(); err != nil {
-			return err
-		}
+	if err := uki.Build(ukiPath); err != nil {
+		return err
 	}
 
-	return nil
+	if !i.options.SecureBoot {
+		return nil
+	}
+
+	return secureboot.Sign(ukiPath, i.options.SecureBootKey, i.options.SecureBootCert)
 }
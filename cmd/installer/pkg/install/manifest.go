@@ -19,7 +19,6 @@ import (
 	"github.com/talos-systems/go-retry/retry"
 
 	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
-	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime/v1alpha1/board"
 	"github.com/talos-systems/talos/internal/pkg/mount"
 	"github.com/talos-systems/talos/internal/pkg/partition"
 	"github.com/talos-systems/talos/pkg/machinery/constants"
@@ -56,14 +55,12 @@ func NewManifest(label string, sequence runtime.Sequence, bootPartitionFound boo
 		Targets: map[string][]*Target{},
 	}
 
-	if opts.Board != constants.BoardNone {
-		var b runtime.Board
-
-		b, err = board.NewBoard(opts.Board)
-		if err != nil {
-			return nil, err
-		}
+	b, err := opts.board()
+	if err != nil {
+		return nil, err
+	}
 
+	if b != nil {
 		manifest.PartitionOptions = b.PartitionOptions()
 	}
 
@@ -108,7 +105,10 @@ func NewManifest(label string, sequence runtime.Sequence, bootPartitionFound boo
 		manifest.Targets[opts.Disk] = []*Target{}
 	}
 
-	efiTarget := EFITarget(opts.Disk, nil)
+	efiTarget := EFITarget(opts.Disk, &Target{
+		// systemd-boot keeps Unified Kernel Images of both labels in the EFI partition
+		PreserveContents: bootPartitionFound && opts.BootloaderType == constants.BootloaderSDBoot,
+	})
 	biosTarget := BIOSTarget(opts.Disk, nil)
 
 	var bootTarget *Target
@@ -118,11 +118,11 @@ func NewManifest(label string, sequence runtime.Sequence, bootPartitionFound boo
 			PreserveContents: bootPartitionFound,
 			Assets: []*Asset{
 				{
-					Source:      constants.KernelAssetPath,
+					Source:      opts.kernelPath(),
 					Destination: filepath.Join(constants.BootMountPoint, label, constants.KernelAsset),
 				},
 				{
-					Source:      constants.InitramfsAssetPath,
+					Source:      opts.initramfsPath(),
 					Destination: filepath.Join(constants.BootMountPoint, label, constants.InitramfsAsset),
 				},
 			},
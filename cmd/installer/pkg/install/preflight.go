@@ -0,0 +1,315 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package install
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+
+	"github.com/talos-systems/talos/cmd/installer/pkg"
+	"github.com/talos-systems/talos/internal/pkg/partition"
+)
+
+// MinimumDiskSize is the minimum size of the install target disk.
+const MinimumDiskSize = pkg.RAWDiskSize * partition.MiB
+
+// Preflight check names.
+const (
+	PreflightCheckSize       = "size"
+	PreflightCheckInUse      = "inUse"
+	PreflightCheckSignatures = "signatures"
+)
+
+// PreflightFailure describes the failed preflight check.
+type PreflightFailure struct {
+	Check string
+	Err   error
+}
+
+// PreflightError is returned when the install target disk fails the preflight checks.
+type PreflightError struct {
+	Disk     string
+	Failures []PreflightFailure
+}
+
+// Error implements error interface.
+func (e *PreflightError) Error() string {
+	failures := make([]string, 0, len(e.Failures))
+
+	for _, failure := range e.Failures {
+		failures = append(failures, fmt.Sprintf("%s: %s", failure.Check, failure.Err))
+	}
+
+	return fmt.Sprintf("target disk %q failed preflight checks: %s", e.Disk, strings.Join(failures, "; "))
+}
+
+// Preflight verifies that the disk can be used as the install target before any changes are made to it.
+//
+// The disk should be big enough to hold Talos partitions, none of its partitions should be mounted or
+// held by other devices (e.g. device mapper). Disks with the foreign RAID or LVM signatures are rejected
+// unless force is set.
+func Preflight(disk string, force bool) error {
+	if disk == "" {
+		return errors.New("missing disk")
+	}
+
+	devices, err := diskDevices(disk)
+	if err != nil {
+		return err
+	}
+
+	preflightErr := &PreflightError{
+		Disk: disk,
+	}
+
+	fail := func(check string, err error) {
+		preflightErr.Failures = append(preflightErr.Failures, PreflightFailure{
+			Check: check,
+			Err:   err,
+		})
+	}
+
+	size, err := deviceSize(disk)
+	if err != nil {
+		return err
+	}
+
+	if size < MinimumDiskSize {
+		fail(PreflightCheckSize, fmt.Errorf("disk size %s is less than the minimum %s", humanize.IBytes(uint64(size)), humanize.IBytes(MinimumDiskSize)))
+	}
+
+	mounts, err := activeMounts()
+	if err != nil {
+		return err
+	}
+
+	for _, device := range devices {
+		if mountpoint, ok := mounts[device]; ok {
+			fail(PreflightCheckInUse, fmt.Errorf("%s is mounted at %q", device, mountpoint))
+		}
+
+		holders, err := deviceHolders(device)
+		if err != nil {
+			return err
+		}
+
+		if len(holders) > 0 {
+			fail(PreflightCheckInUse, fmt.Errorf("%s is held by %s", device, strings.Join(holders, ", ")))
+		}
+
+		if force {
+			continue
+		}
+
+		signature, err := probeDeviceSignature(device)
+		if err != nil {
+			return err
+		}
+
+		if signature != "" {
+			fail(PreflightCheckSignatures, fmt.Errorf("%s contains %s signature, use force to overwrite", device, signature))
+		}
+	}
+
+	if len(preflightErr.Failures) > 0 {
+		return preflightErr
+	}
+
+	return nil
+}
+
+// diskDevices returns the disk itself and all its partitions.
+func diskDevices(disk string) ([]string, error) {
+	path, err := filepath.EvalSymlinks(disk)
+	if err != nil {
+		return nil, err
+	}
+
+	devices := []string{path}
+
+	entries, err := ioutil.ReadDir(filepath.Join("/sys/class/block", filepath.Base(path)))
+	if err != nil {
+		if os.IsNotExist(err) {
+			// not a block device (e.g. a disk image file)
+			return devices, nil
+		}
+
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		if _, err = os.Stat(filepath.Join("/sys/class/block", filepath.Base(path), entry.Name(), "partition")); err == nil {
+			devices = append(devices, filepath.Join(filepath.Dir(path), entry.Name()))
+		}
+	}
+
+	return devices, nil
+}
+
+func deviceSize(device string) (int64, error) {
+	f, err := os.Open(device)
+	if err != nil {
+		return 0, err
+	}
+
+	defer f.Close() //nolint:errcheck
+
+	return f.Seek(0, io.SeekEnd)
+}
+
+// deviceHolders returns the devices (device mapper, md) built on top of the device.
+func deviceHolders(device string) ([]string, error) {
+	entries, err := ioutil.ReadDir(filepath.Join("/sys/class/block", filepath.Base(device), "holders"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	holders := make([]string, 0, len(entries))
+
+	for _, entry := range entries {
+		holders = append(holders, entry.Name())
+	}
+
+	return holders, nil
+}
+
+// activeMounts returns mount points by the mount source across all mount namespaces.
+func activeMounts() (map[string]string, error) {
+	matches, err := filepath.Glob("/proc/*/mountinfo")
+	if err != nil {
+		return nil, err
+	}
+
+	mounts := map[string]string{}
+
+	for _, path := range matches {
+		f, err := os.Open(path)
+		if err != nil {
+			// ignore error in case process got removed
+			continue
+		}
+
+		parseMountInfo(f, mounts)
+
+		f.Close() //nolint:errcheck
+	}
+
+	return mounts, nil
+}
+
+// parseMountInfo parses mountinfo lines like:
+//
+//	36 35 98:0 /mnt1 /mnt2 rw,noatime master:1 - ext3 /dev/root rw,errors=continue
+func parseMountInfo(r io.Reader, mounts map[string]string) {
+	scanner := bufio.NewScanner(r)
+
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+
+		if len(fields) < 5 {
+			continue
+		}
+
+		for i := 5; i < len(fields)-2; i++ {
+			if fields[i] == "-" {
+				mounts[fields[i+2]] = fields[4]
+
+				break
+			}
+		}
+	}
+}
+
+func probeDeviceSignature(device string) (string, error) {
+	f, err := os.Open(device)
+	if err != nil {
+		return "", err
+	}
+
+	defer f.Close() //nolint:errcheck
+
+	size, err := f.Seek(0, io.SeekEnd)
+	if err != nil {
+		return "", err
+	}
+
+	return probeSignature(f, size)
+}
+
+const (
+	sectorSize = 512
+
+	lvmLabelSectors = 4
+
+	mdMagic = 0xa92b4efc
+)
+
+// probeSignature looks for the LVM physical volume label and Linux RAID (md) superblocks.
+func probeSignature(r io.ReaderAt, size int64) (string, error) {
+	buf := make([]byte, sectorSize)
+
+	// LVM label is in one of the first four sectors
+	for sector := int64(0); sector < lvmLabelSectors; sector++ {
+		ok, err := readAt(r, buf, sector*sectorSize, size)
+		if err != nil {
+			return "", err
+		}
+
+		if ok && bytes.Equal(buf[:8], []byte("LABELONE")) && bytes.Equal(buf[24:32], []byte("LVM2 001")) {
+			return "LVM2 physical volume", nil
+		}
+	}
+
+	mdOffsets := []int64{
+		// version 0.90: 64 KiB from the end, aligned to 64 KiB
+		(size &^ (64*1024 - 1)) - 64*1024,
+		// version 1.0: 8 KiB from the end, aligned to 4 KiB
+		(size - 8*1024) &^ (4*1024 - 1),
+		// version 1.1: start of the device
+		0,
+		// version 1.2: 4 KiB from the start of the device
+		4 * 1024,
+	}
+
+	for _, offset := range mdOffsets {
+		ok, err := readAt(r, buf[:4], offset, size)
+		if err != nil {
+			return "", err
+		}
+
+		if ok && binary.LittleEndian.Uint32(buf[:4]) == mdMagic {
+			return "Linux RAID member", nil
+		}
+	}
+
+	return "", nil
+}
+
+// readAt reads len(buf) bytes at offset, it returns false if the range is outside of the device.
+func readAt(r io.ReaderAt, buf []byte, offset, size int64) (bool, error) {
+	if offset < 0 || offset+int64(len(buf)) > size {
+		return false, nil
+	}
+
+	if _, err := r.ReadAt(buf, offset); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
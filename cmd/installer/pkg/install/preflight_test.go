@@ -0,0 +1,110 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package install_test
+
+import (
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/cmd/installer/pkg/install"
+)
+
+func TestPreflight(t *testing.T) {
+	lvmLabel := make([]byte, 32)
+	copy(lvmLabel, "LABELONE")
+	copy(lvmLabel[24:], "LVM2 001")
+
+	mdSuperblock := make([]byte, 4)
+	binary.LittleEndian.PutUint32(mdSuperblock, 0xa92b4efc)
+
+	for _, tt := range []struct {
+		name   string
+		size   int64
+		offset int64
+		data   []byte
+		force  bool
+
+		expectedCheck string
+	}{
+		{
+			name: "empty",
+			size: install.MinimumDiskSize,
+		},
+		{
+			name:          "too small",
+			size:          install.MinimumDiskSize - 1,
+			expectedCheck: install.PreflightCheckSize,
+		},
+		{
+			name:          "lvm",
+			size:          install.MinimumDiskSize,
+			offset:        512,
+			data:          lvmLabel,
+			expectedCheck: install.PreflightCheckSignatures,
+		},
+		{
+			name:   "lvm force",
+			size:   install.MinimumDiskSize,
+			offset: 512,
+			data:   lvmLabel,
+			force:  true,
+		},
+		{
+			name:          "md 1.2",
+			size:          install.MinimumDiskSize,
+			offset:        4096,
+			data:          mdSuperblock,
+			expectedCheck: install.PreflightCheckSignatures,
+		},
+		{
+			name:          "md 0.90",
+			size:          install.MinimumDiskSize,
+			offset:        install.MinimumDiskSize - 64*1024,
+			data:          mdSuperblock,
+			expectedCheck: install.PreflightCheckSignatures,
+		},
+	} {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			disk := filepath.Join(t.TempDir(), "disk.raw")
+
+			f, err := os.Create(disk)
+			require.NoError(t, err)
+
+			require.NoError(t, f.Truncate(tt.size))
+
+			if tt.data != nil {
+				_, err = f.WriteAt(tt.data, tt.offset)
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, f.Close())
+
+			err = install.Preflight(disk, tt.force)
+
+			if tt.expectedCheck == "" {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			var preflightErr *install.PreflightError
+
+			require.True(t, errors.As(err, &preflightErr))
+			assert.Equal(t, disk, preflightErr.Disk)
+			require.Len(t, preflightErr.Failures, 1)
+			assert.Equal(t, tt.expectedCheck, preflightErr.Failures[0].Check)
+		})
+	}
+
+	assert.Error(t, install.Preflight("", false))
+}
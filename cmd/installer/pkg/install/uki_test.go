@@ -0,0 +1,75 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package install
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/internal/pkg/secureboot"
+	"github.com/talos-systems/talos/internal/pkg/secureboot/secureboottest"
+)
+
+func ukiOptions(t *testing.T, dir string) *Options {
+	opts := &Options{
+		KernelPath:    filepath.Join(dir, "vmlinuz"),
+		InitramfsPath: filepath.Join(dir, "initramfs.xz"),
+		UKIStubPath:   filepath.Join(dir, "stub.efi"),
+		OSReleasePath: filepath.Join(dir, "os-release"),
+	}
+
+	secureboottest.WriteStub(t, opts.UKIStubPath)
+
+	require.NoError(t, ioutil.WriteFile(opts.KernelPath, []byte("kernel"), 0o644))
+	require.NoError(t, ioutil.WriteFile(opts.InitramfsPath, []byte("initramfs"), 0o644))
+	require.NoError(t, ioutil.WriteFile(opts.OSReleasePath, []byte("NAME=\"Talos\"\n"), 0o644))
+
+	return opts
+}
+
+func TestBuildUKI(t *testing.T) {
+	secureboottest.RequireTool(t, "objcopy")
+
+	dir := t.TempDir()
+
+	i := &Installer{options: ukiOptions(t, dir)}
+
+	ukiPath := filepath.Join(dir, "EFI", "Linux", "A.efi")
+
+	require.NoError(t, i.buildUKI(ukiPath, "talos.platform=metal"))
+
+	cmdline, err := secureboot.ReadCmdline(ukiPath)
+	require.NoError(t, err)
+	assert.Equal(t, "talos.platform=metal", cmdline)
+
+	// the stub is missing
+	require.NoError(t, os.Remove(i.options.UKIStubPath))
+
+	assert.Error(t, i.buildUKI(ukiPath, "talos.platform=metal"))
+}
+
+func TestBuildUKISecureBoot(t *testing.T) {
+	secureboottest.RequireTool(t, "objcopy")
+	secureboottest.RequireTool(t, "sbsign")
+
+	dir := t.TempDir()
+
+	opts := ukiOptions(t, dir)
+	opts.SecureBoot = true
+	opts.SecureBootKey, opts.SecureBootCert = secureboottest.WriteSigningKey(t, dir)
+
+	i := &Installer{options: opts}
+
+	ukiPath := filepath.Join(dir, "EFI", "Linux", "A.efi")
+
+	require.NoError(t, i.buildUKI(ukiPath, "talos.platform=metal"))
+
+	secureboottest.AssertSigned(t, ukiPath)
+}
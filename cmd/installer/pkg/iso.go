@@ -8,15 +8,22 @@ import (
 	"fmt"
 
 	"github.com/talos-systems/go-cmd/pkg/cmd"
+
+	"github.com/talos-systems/talos/pkg/machinery/constants"
 )
 
 // CreateISO creates an iso by invoking the `grub-mkrescue` command.
+//
+// ISO file system is labeled, so that the image cache bundled into the ISO can be found on boot.
 func CreateISO(iso, dir string) (err error) {
 	_, err = cmd.Run(
 		"grub-mkrescue",
 		"--compress=xz",
 		"--output="+iso,
 		dir,
+		"--",
+		"-volid",
+		constants.ISOFilesystemLabel,
 	)
 
 	if err != nil {
@@ -51,7 +51,7 @@ const ovfTpl = `<?xml version="1.0" encoding="UTF-8"?>
     <OperatingSystemSection ovf:id="101" vmw:osType="otherLinux64Guest">
       <Info>The kind of installed guest operating system</Info>
     </OperatingSystemSection>
-    <VirtualHardwareSection>
+    <VirtualHardwareSection ovf:transport="com.vmware.guestInfo">
       <Info>Virtual hardware requirements</Info>
       <System>
         <vssd:ElementName>Virtual Hardware Family</vssd:ElementName>
@@ -126,6 +126,14 @@ const ovfTpl = `<?xml version="1.0" encoding="UTF-8"?>
       <vmw:Config ovf:required="false" vmw:key="powerOpInfo.suspendType" vmw:value="soft"/>
       <vmw:ExtraConfig ovf:required="false" vmw:key="nvram" vmw:value="talos.nvram"/>
     </VirtualHardwareSection>
+    <ProductSection ovf:required="false">
+      <Info>Talos configuration</Info>
+      <Product>Talos</Product>
+      <Property ovf:key="talos.config" ovf:type="string" ovf:userConfigurable="true" ovf:value="">
+        <Label>Machine configuration</Label>
+        <Description>Base64 encoded (optionally gzip compressed) machine configuration</Description>
+      </Property>
+    </ProductSection>
   </VirtualSystem>
 </Envelope>
 `
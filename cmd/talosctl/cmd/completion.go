@@ -16,12 +16,15 @@ import (
 // completionCmd represents the completion command.
 var completionCmd = &cobra.Command{
 	Use:   "completion SHELL",
-	Short: "Output shell completion code for the specified shell (bash or zsh)",
-	Long: `Output shell completion code for the specified shell (bash or zsh).
+	Short: "Output shell completion code for the specified shell (bash, fish or zsh)",
+	Long: `Output shell completion code for the specified shell (bash, fish or zsh).
 The shell code must be evaluated to provide interactive
 completion of talosctl commands.  This can be done by sourcing it from
 the .bash_profile.
 
+Context names, node names (including the cluster members discovered by the nodes), resource types
+and service names are completed dynamically via the Talos API.
+
 Note for zsh users: [1] zsh completions are only supported in versions of zsh >= 5.2`,
 	Example: `# Installing bash completion on macOS using homebrew
 ## If running Bash 3.2 included with macOS
@@ -47,8 +50,12 @@ Note for zsh users: [1] zsh completions are only supported in versions of zsh >=
 # Load the talosctl completion code for zsh[1] into the current shell
 	source <(talosctl completion zsh)
 # Set the talosctl completion code for zsh[1] to autoload on startup
-talosctl completion zsh > "${fpath[1]}/_talosctl"`,
-	ValidArgs: []string{"bash", "zsh"},
+talosctl completion zsh > "${fpath[1]}/_talosctl"
+# Load the talosctl completion code for fish into the current shell
+	talosctl completion fish | source
+# Set the talosctl completion code for fish to autoload on startup
+	talosctl completion fish > ~/.config/fish/completions/talosctl.fish`,
+	ValidArgs: []string{"bash", "fish", "zsh"},
 	Args:      cobra.ExactValidArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if len(args) != 1 {
@@ -59,6 +66,8 @@ talosctl completion zsh > "${fpath[1]}/_talosctl"`,
 		switch args[0] {
 		case "bash":
 			return rootCmd.GenBashCompletion(os.Stdout)
+		case "fish":
+			return rootCmd.GenFishCompletion(os.Stdout, true)
 		case "zsh":
 			err := rootCmd.GenZshCompletion(os.Stdout)
 			// cobra does not hook the completion, so let's do it manually
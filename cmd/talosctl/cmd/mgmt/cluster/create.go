@@ -19,7 +19,6 @@ import (
 	"time"
 
 	humanize "github.com/dustin/go-humanize"
-	jsonpatch "github.com/evanphx/json-patch"
 	"github.com/spf13/cobra"
 	"github.com/talos-systems/go-blockdevice/blockdevice/encryption"
 	talosnet "github.com/talos-systems/net"
@@ -32,6 +31,7 @@ import (
 	"github.com/talos-systems/talos/pkg/images"
 	clientconfig "github.com/talos-systems/talos/pkg/machinery/client/config"
 	"github.com/talos-systems/talos/pkg/machinery/config"
+	"github.com/talos-systems/talos/pkg/machinery/config/configpatcher"
 	"github.com/talos-systems/talos/pkg/machinery/config/encoder"
 	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
 	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1/bundle"
@@ -69,6 +69,7 @@ var (
 	applyConfigEnabled        bool
 	bootloaderEnabled         bool
 	uefiEnabled               bool
+	tpm2Enabled               bool
 	configDebug               bool
 	networkCIDR               string
 	networkMTU                int
@@ -82,6 +83,9 @@ var (
 	clusterCpus               string
 	clusterMemory             int
 	clusterDiskSize           int
+	workersCpus               string
+	workersMemory             int
+	workersDiskSize           int
 	clusterDisks              []string
 	targetArch                string
 	clusterWait               bool
@@ -94,6 +98,8 @@ var (
 	cniCacheDir               string
 	cniBundleURL              string
 	ports                     string
+	dockerMounts              []string
+	dockerNodeImages          []string
 	dockerHostIP              string
 	withInitNode              bool
 	customCNIUrl              string
@@ -104,7 +110,11 @@ var (
 	encryptStatePartition     bool
 	encryptEphemeralPartition bool
 	useVIP                    bool
-	configPatch               string
+	configPatch               []string
+	configPatchControlPlane   []string
+	configPatchWorker         []string
+	clusterSpecPath           string
+	loadedClusterSpec         *clusterSpec
 )
 
 // createCmd represents the cluster up command.
@@ -114,6 +124,19 @@ var createCmd = &cobra.Command{
 	Long:  ``,
 	Args:  cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if clusterSpecPath != "" {
+			spec, err := loadClusterSpec(clusterSpecPath)
+			if err != nil {
+				return err
+			}
+
+			if err = spec.ApplyFlags(cmd.Flags()); err != nil {
+				return err
+			}
+
+			loadedClusterSpec = spec
+		}
+
 		return cli.WithContext(context.Background(), create)
 	},
 }
@@ -124,13 +147,28 @@ func create(ctx context.Context) (err error) {
 		return fmt.Errorf("number of masters can't be less than 1")
 	}
 
-	nanoCPUs, err := parseCPUShare()
+	nanoCPUs, err := parseCPUShare(clusterCpus)
 	if err != nil {
 		return fmt.Errorf("error parsing --cpus: %s", err)
 	}
 
+	workersNanoCPUs := nanoCPUs
+
+	if workersCpus != "" {
+		workersNanoCPUs, err = parseCPUShare(workersCpus)
+		if err != nil {
+			return fmt.Errorf("error parsing --cpus-workers: %s", err)
+		}
+	}
+
 	memory := int64(clusterMemory) * 1024 * 1024
 
+	workersMemoryBytes := memory
+
+	if workersMemory > 0 {
+		workersMemoryBytes = int64(workersMemory) * 1024 * 1024
+	}
+
 	// Validate CIDR range and allocate IPs
 	fmt.Println("validating CIDR and reserving IPs")
 
@@ -247,6 +285,7 @@ func create(ctx context.Context) (err error) {
 		provision.WithDockerPortsHostIP(dockerHostIP),
 		provision.WithBootlader(bootloaderEnabled),
 		provision.WithUEFI(uefiEnabled),
+		provision.WithTPM2(tpm2Enabled),
 		provision.WithTargetArch(targetArch),
 	}
 	configBundleOpts := []bundle.Option{}
@@ -260,11 +299,28 @@ func create(ctx context.Context) (err error) {
 		provisionOptions = append(provisionOptions, provision.WithDockerPorts(portList))
 	}
 
-	disks, err := getDisks()
+	if len(dockerMounts) > 0 {
+		if provisionerName != "docker" {
+			return fmt.Errorf("docker-mount flag only supported with docker provisioner")
+		}
+
+		provisionOptions = append(provisionOptions, provision.WithDockerMounts(dockerMounts))
+	}
+
+	disks, err := getDisks(clusterDiskSize)
 	if err != nil {
 		return err
 	}
 
+	workersDisks := disks
+
+	if workersDiskSize > 0 {
+		workersDisks, err = getDisks(workersDiskSize)
+		if err != nil {
+			return err
+		}
+	}
+
 	if inputDir != "" {
 		configBundleOpts = append(configBundleOpts, bundle.WithExistingConfigs(inputDir))
 	} else {
@@ -414,16 +470,34 @@ func create(ctx context.Context) (err error) {
 		)
 	}
 
-	var jsonPatch jsonpatch.Patch
+	for _, patchSet := range []struct {
+		flag    string
+		patches []string
+		option  func([]configpatcher.Patch) bundle.Option
+	}{
+		{"--config-patch", configPatch, bundle.WithPatch},
+		{"--config-patch-control-plane", configPatchControlPlane, bundle.WithPatchControlPlane},
+		{"--config-patch-worker", configPatchWorker, bundle.WithPatchJoin},
+	} {
+		var patches []configpatcher.Patch
 
-	if configPatch != "" {
-		jsonPatch, err = jsonpatch.DecodePatch([]byte(configPatch))
+		patches, err = configpatcher.LoadPatches(patchSet.patches)
 		if err != nil {
-			return fmt.Errorf("error parsing config JSON patch: %w", err)
+			return fmt.Errorf("error parsing %s: %w", patchSet.flag, err)
 		}
+
+		configBundleOpts = append(configBundleOpts, patchSet.option(patches))
 	}
 
-	configBundleOpts = append(configBundleOpts, bundle.WithJSONPatch(jsonPatch))
+	if loadedClusterSpec != nil {
+		var specBundleOpts []bundle.Option
+
+		if specBundleOpts, err = loadedClusterSpec.BundleOptions(); err != nil {
+			return fmt.Errorf("error parsing cluster spec config patches: %w", err)
+		}
+
+		configBundleOpts = append(configBundleOpts, specBundleOpts...)
+	}
 
 	configBundle, err := bundle.NewConfigBundle(configBundleOpts...)
 	if err != nil {
@@ -517,14 +591,24 @@ func create(ctx context.Context) (err error) {
 				Name:                name,
 				Type:                machine.TypeJoin,
 				IPs:                 nodeIPs,
-				Memory:              memory,
-				NanoCPUs:            nanoCPUs,
-				Disks:               disks,
+				Memory:              workersMemoryBytes,
+				NanoCPUs:            workersNanoCPUs,
+				Disks:               workersDisks,
 				Config:              cfg,
 				SkipInjectingConfig: skipInjectingConfig,
 			})
 	}
 
+	if len(dockerNodeImages) > 0 {
+		if provisionerName != "docker" {
+			return fmt.Errorf("docker-node-image flag only supported with docker provisioner")
+		}
+
+		if err = setNodeImages(request.Nodes, dockerNodeImages); err != nil {
+			return err
+		}
+	}
+
 	cluster, err := provisioner.Create(ctx, request, provisionOptions...)
 	if err != nil {
 		return err
@@ -650,10 +734,10 @@ func mergeKubeconfig(ctx context.Context, clusterAccess *access.Adapter) error {
 	return merger.Write(kubeconfigPath)
 }
 
-func parseCPUShare() (int64, error) {
-	cpu, ok := new(big.Rat).SetString(clusterCpus)
+func parseCPUShare(cpus string) (int64, error) {
+	cpu, ok := new(big.Rat).SetString(cpus)
 	if !ok {
-		return 0, fmt.Errorf("failed to parsing as a rational number: %s", clusterCpus)
+		return 0, fmt.Errorf("failed to parsing as a rational number: %s", cpus)
 	}
 
 	nano := cpu.Mul(cpu, big.NewRat(1e9, 1))
@@ -664,11 +748,11 @@ func parseCPUShare() (int64, error) {
 	return nano.Num().Int64(), nil
 }
 
-func getDisks() ([]*provision.Disk, error) {
+func getDisks(diskSize int) ([]*provision.Disk, error) {
 	// should have at least a single primary disk
 	disks := []*provision.Disk{
 		{
-			Size: uint64(clusterDiskSize) * 1024 * 1024,
+			Size: uint64(diskSize) * 1024 * 1024,
 		},
 	}
 
@@ -721,6 +805,33 @@ func getDisks() ([]*provision.Disk, error) {
 	return disks, nil
 }
 
+// setNodeImages applies the per-node image overrides in format <node name>=<image>.
+func setNodeImages(nodes provision.NodeRequests, specs []string) error {
+	for _, spec := range specs {
+		components := strings.SplitN(spec, "=", 2)
+		if len(components) != 2 || components[1] == "" {
+			return fmt.Errorf("invalid node image spec: %q", spec)
+		}
+
+		found := false
+
+		for i := range nodes {
+			if nodes[i].Name == components[0] {
+				nodes[i].Image = components[1]
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			return fmt.Errorf("node %q not found for image override", components[0])
+		}
+	}
+
+	return nil
+}
+
 func trimVersion(version string) string {
 	// remove anything extra after semantic version core, `v0.3.2-1-abcd` -> `v0.3.2`
 	return regexp.MustCompile(`(-\d+(-g[0-9a-f]+)?(-dirty)?)$`).ReplaceAllString(version, "")
@@ -742,13 +853,14 @@ func init() {
 	createCmd.Flags().BoolVar(&applyConfigEnabled, "with-apply-config", false, "enable apply config when the VM is starting in maintenance mode")
 	createCmd.Flags().BoolVar(&bootloaderEnabled, "with-bootloader", true, "enable bootloader to load kernel and initramfs from disk image after install")
 	createCmd.Flags().BoolVar(&uefiEnabled, "with-uefi", false, "enable UEFI on x86_64 architecture (always enabled for arm64)")
+	createCmd.Flags().BoolVar(&tpm2Enabled, "with-tpm2", false, "enable TPM2 emulation support using swtpm (QEMU provisioner only)")
 	createCmd.Flags().StringSliceVar(&registryMirrors, "registry-mirror", []string{}, "list of registry mirrors to use in format: <registry host>=<mirror URL>")
 	createCmd.Flags().StringSliceVar(&registryInsecure, "registry-insecure-skip-verify", []string{}, "list of registry hostnames to skip TLS verification for")
 	createCmd.Flags().BoolVar(&configDebug, "with-debug", false, "enable debug in Talos config to send service logs to the console")
 	createCmd.Flags().IntVar(&networkMTU, "mtu", 1500, "MTU of the cluster network")
 	createCmd.Flags().StringVar(&networkCIDR, "cidr", "10.5.0.0/24", "CIDR of the cluster network (IPv4, ULA network for IPv6 is derived in automated way)")
 	createCmd.Flags().BoolVar(&networkIPv4, "ipv4", true, "enable IPv4 network in the cluster")
-	createCmd.Flags().BoolVar(&networkIPv6, "ipv6", false, "enable IPv6 network in the cluster (QEMU provisioner only)")
+	createCmd.Flags().BoolVar(&networkIPv6, "ipv6", false, "enable IPv6 network in the cluster")
 	createCmd.Flags().StringVar(&wireguardCIDR, "wireguard-cidr", "", "CIDR of the wireguard network")
 	createCmd.Flags().StringSliceVar(&nameservers, "nameservers", []string{"8.8.8.8", "1.1.1.1", "2001:4860:4860::8888", "2606:4700:4700::1111"}, "list of nameservers to use")
 	createCmd.Flags().IntVar(&workers, "workers", 1, "the number of workers to create")
@@ -756,6 +868,9 @@ func init() {
 	createCmd.Flags().StringVar(&clusterCpus, "cpus", "2.0", "the share of CPUs as fraction (each container/VM)")
 	createCmd.Flags().IntVar(&clusterMemory, "memory", 2048, "the limit on memory usage in MB (each container/VM)")
 	createCmd.Flags().IntVar(&clusterDiskSize, "disk", 6*1024, "default limit on disk size in MB (each VM)")
+	createCmd.Flags().StringVar(&workersCpus, "cpus-workers", "", "the share of CPUs as fraction for workers (defaults to --cpus)")
+	createCmd.Flags().IntVar(&workersMemory, "memory-workers", 0, "the limit on memory usage in MB for workers (defaults to --memory)")
+	createCmd.Flags().IntVar(&workersDiskSize, "disk-workers", 0, "the limit on disk size in MB for workers (defaults to --disk)")
 	createCmd.Flags().StringSliceVar(&clusterDisks, "user-disk", []string{}, "list of disks to create for each VM in format: <mount_point1>:<size1>:<mount_point2>:<size2>")
 	createCmd.Flags().StringVar(&targetArch, "arch", stdruntime.GOARCH, "cluster architecture")
 	createCmd.Flags().BoolVar(&clusterWait, "wait", true, "wait for the cluster to be ready before returning")
@@ -773,9 +888,11 @@ func init() {
 		"exposed-ports",
 		"p",
 		"",
-		"Comma-separated list of ports/protocols to expose on init node. Ex -p <hostPort>:<containerPort>/<protocol (tcp or udp)> (Docker provisioner only)",
+		"Comma-separated list of ports/protocols to expose on init node. Ex -p [<hostIP>:][<hostPort>:]<containerPort>/<protocol (tcp or udp)> (Docker provisioner only)",
 	)
 	createCmd.Flags().StringVar(&dockerHostIP, "docker-host-ip", "0.0.0.0", "Host IP to forward exposed ports to (Docker provisioner only)")
+	createCmd.Flags().StringSliceVar(&dockerMounts, "docker-mount", []string{}, "list of host paths to mount into each node in format: <host path>:<node path>[:ro] (Docker provisioner only)")
+	createCmd.Flags().StringSliceVar(&dockerNodeImages, "docker-node-image", []string{}, "list of per-node image overrides in format: <node name>=<image> (Docker provisioner only)")
 	createCmd.Flags().BoolVar(&withInitNode, "with-init-node", false, "create the cluster with an init node")
 	createCmd.Flags().StringVar(&customCNIUrl, "custom-cni-url", "", "install custom CNI from the URL (Talos cluster)")
 	createCmd.Flags().StringVar(&dnsDomain, "dns-domain", "cluster.local", "the dns domain to use for cluster")
@@ -786,6 +903,9 @@ func init() {
 	createCmd.Flags().BoolVar(&encryptEphemeralPartition, "encrypt-ephemeral", false, "enable ephemeral partition encryption")
 	createCmd.Flags().StringVar(&talosVersion, "talos-version", "", "the desired Talos version to generate config for (if not set, defaults to image version)")
 	createCmd.Flags().BoolVar(&useVIP, "use-vip", false, "use a virtual IP for the controlplane endpoint instead of the loadbalancer")
-	createCmd.Flags().StringVar(&configPatch, "config-patch", "", "patch generated machineconfigs")
+	createCmd.Flags().StringArrayVar(&configPatch, "config-patch", nil, "patch generated machineconfigs (applied to all node types), use @file to read a patch from file")
+	createCmd.Flags().StringArrayVar(&configPatchControlPlane, "config-patch-control-plane", nil, "patch generated machineconfigs (applied to 'init' and 'controlplane' types)")
+	createCmd.Flags().StringArrayVar(&configPatchWorker, "config-patch-worker", nil, "patch generated machineconfigs (applied to 'join' type)")
+	createCmd.Flags().StringVar(&clusterSpecPath, "config", "", "the path to the declarative cluster spec, flags set explicitly override the spec")
 	Cmd.AddCommand(createCmd)
 }
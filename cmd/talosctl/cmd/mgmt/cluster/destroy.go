@@ -10,9 +10,14 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/talos-systems/talos/pkg/cli"
+	"github.com/talos-systems/talos/pkg/provision"
 	"github.com/talos-systems/talos/pkg/provision/providers"
 )
 
+var destroyCmdFlags struct {
+	force bool
+}
+
 // destroyCmd represents the cluster destroy command.
 var destroyCmd = &cobra.Command{
 	Use:   "destroy",
@@ -37,9 +42,11 @@ func destroy(ctx context.Context) error {
 		return err
 	}
 
-	return provisioner.Destroy(ctx, cluster)
+	return provisioner.Destroy(ctx, cluster, provision.WithForceDestroy(destroyCmdFlags.force))
 }
 
 func init() {
+	destroyCmd.Flags().BoolVar(&destroyCmdFlags.force, "force", false, "remove cluster state even if some of the cluster resources failed to be destroyed")
+
 	Cmd.AddCommand(destroyCmd)
 }
@@ -0,0 +1,203 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/talos-systems/talos/cmd/talosctl/cmd/talos"
+	"github.com/talos-systems/talos/cmd/talosctl/pkg/talos/helpers"
+	"github.com/talos-systems/talos/pkg/cli"
+	"github.com/talos-systems/talos/pkg/cluster/archive"
+	"github.com/talos-systems/talos/pkg/machinery/client"
+	clientconfig "github.com/talos-systems/talos/pkg/machinery/client/config"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1/generate"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1/machine"
+	"github.com/talos-systems/talos/pkg/version"
+)
+
+var exportCmdFlags struct {
+	output     string
+	signingKey string
+	recipient  string
+}
+
+// exportCmd represents the cluster export command.
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export cluster definition into a signed archive for disaster recovery",
+	Long: `Export collects machine configuration (with secrets redacted) and versions of every node in the current context,
+the cluster secrets bundle and the talosconfig context into a single archive.
+
+Secrets bundle and talosconfig are encrypted to the operator RSA public key,
+the archive manifest is signed with the ed25519 signing key.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return talos.WithClient(exportCluster)
+	},
+}
+
+//nolint:gocyclo,cyclop
+func exportCluster(ctx context.Context, c *client.Client) error {
+	signingKeyData, err := ioutil.ReadFile(exportCmdFlags.signingKey)
+	if err != nil {
+		return fmt.Errorf("error reading signing key: %w", err)
+	}
+
+	signingKey, err := archive.ParseSigningKey(signingKeyData)
+	if err != nil {
+		return err
+	}
+
+	recipientData, err := ioutil.ReadFile(exportCmdFlags.recipient)
+	if err != nil {
+		return fmt.Errorf("error reading recipient key: %w", err)
+	}
+
+	recipient, err := archive.ParseRecipient(recipientData)
+	if err != nil {
+		return err
+	}
+
+	talosconfig, contextName, err := currentTalosconfig()
+	if err != nil {
+		return err
+	}
+
+	if len(client.NodesFromContext(ctx)) < 1 {
+		return fmt.Errorf("nodes are not set for the command")
+	}
+
+	a := &archive.Archive{
+		Manifest: archive.Manifest{
+			Context:       contextName,
+			CreatedAt:     time.Now().UTC(),
+			ClientVersion: version.Tag,
+		},
+		Configs: map[string][]byte{},
+		Secrets: archive.Secrets{
+			Talosconfig: talosconfig,
+		},
+	}
+
+	if err = client.ForEachNodeSequential(ctx, func(nodeCtx context.Context, node string) error {
+		member := archive.Member{
+			Node: node,
+		}
+
+		versionResp, err := c.Version(nodeCtx)
+		if err != nil {
+			return fmt.Errorf("error getting version: %w", err)
+		}
+
+		for _, msg := range versionResp.GetMessages() {
+			member.Hostname = msg.GetMetadata().GetHostname()
+			member.TalosVersion = msg.GetVersion().GetTag()
+		}
+
+		cfg, err := helpers.MachineConfig(nodeCtx, c)
+		if err != nil {
+			return fmt.Errorf("error getting machine config: %w", err)
+		}
+
+		member.MachineType = cfg.Machine().Type().String()
+
+		if member.Hostname == "" {
+			member.Hostname = node
+		}
+
+		if a.Manifest.ClusterName == "" {
+			a.Manifest.ClusterName = cfg.Cluster().Name()
+		}
+
+		if a.Secrets.Bundle == nil && cfg.Machine().Type() != machine.TypeJoin {
+			a.Secrets.Bundle = archive.NewBundle(generate.NewSecretsBundleFromConfig(generate.NewClock(), cfg))
+		}
+
+		archive.Redact(cfg)
+
+		if a.Configs[node], err = cfg.Bytes(); err != nil {
+			return err
+		}
+
+		a.Manifest.Members = append(a.Manifest.Members, member)
+
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if a.Secrets.Bundle == nil {
+		return fmt.Errorf("secrets bundle can only be exported from a control plane node, please include one in the list of nodes")
+	}
+
+	out, err := os.OpenFile(exportCmdFlags.output, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+
+	//nolint:errcheck
+	defer out.Close()
+
+	if err = a.Write(out, signingKey, recipient); err != nil {
+		return err
+	}
+
+	if err = out.Close(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "exported cluster %q (%d nodes) to %s\n", a.Manifest.ClusterName, len(a.Manifest.Members), exportCmdFlags.output)
+
+	return nil
+}
+
+// currentTalosconfig returns talosconfig which contains only the current context.
+func currentTalosconfig() (string, string, error) {
+	cfg, err := clientconfig.Open(talos.Talosconfig)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to open config file %q: %w", talos.Talosconfig, err)
+	}
+
+	contextName := cfg.Context
+	if talos.Cmdcontext != "" {
+		contextName = talos.Cmdcontext
+	}
+
+	configContext, ok := cfg.Contexts[contextName]
+	if !ok {
+		return "", "", fmt.Errorf("context %q is not defined in %q", contextName, talos.Talosconfig)
+	}
+
+	exported := &clientconfig.Config{
+		Context: contextName,
+		Contexts: map[string]*clientconfig.Context{
+			contextName: configContext,
+		},
+	}
+
+	data, err := exported.Bytes()
+	if err != nil {
+		return "", "", err
+	}
+
+	return string(data), contextName, nil
+}
+
+func init() {
+	exportCmd.Flags().StringVarP(&exportCmdFlags.output, "output", "o", "cluster.tar.gz", "path to the output archive")
+	exportCmd.Flags().StringVar(&exportCmdFlags.signingKey, "signing-key", "", "path to the PEM encoded ed25519 private key to sign the archive")
+	exportCmd.Flags().StringVar(&exportCmdFlags.recipient, "recipient", "", "path to the PEM encoded RSA public key of the operator to encrypt the secrets to")
+	cli.Should(exportCmd.MarkFlagRequired("signing-key"))
+	cli.Should(exportCmd.MarkFlagRequired("recipient"))
+
+	Cmd.AddCommand(exportCmd)
+}
@@ -0,0 +1,152 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package cluster
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/talos-systems/talos/cmd/talosctl/cmd/talos"
+	"github.com/talos-systems/talos/pkg/cli"
+	"github.com/talos-systems/talos/pkg/cluster/archive"
+	clientconfig "github.com/talos-systems/talos/pkg/machinery/client/config"
+)
+
+var importCmdFlags struct {
+	identity  string
+	signer    string
+	outputDir string
+}
+
+// importCmd represents the cluster import command.
+var importCmd = &cobra.Command{
+	Use:   "import <archive>",
+	Short: "Import cluster archive created with 'talosctl cluster export'",
+	Long: `Import verifies the archive signature, decrypts the secrets with the operator RSA private key
+and merges the exported context into the talosconfig.
+
+If the output directory is specified, member configs (with secrets redacted), the manifest
+and the secrets bundle are written into it.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return importCluster(args[0])
+	},
+}
+
+//nolint:gocyclo
+func importCluster(path string) error {
+	identityData, err := ioutil.ReadFile(importCmdFlags.identity)
+	if err != nil {
+		return fmt.Errorf("error reading identity: %w", err)
+	}
+
+	identity, err := archive.ParseIdentity(identityData)
+	if err != nil {
+		return err
+	}
+
+	signerData, err := ioutil.ReadFile(importCmdFlags.signer)
+	if err != nil {
+		return fmt.Errorf("error reading signer key: %w", err)
+	}
+
+	signer, err := archive.ParseSignerKey(signerData)
+	if err != nil {
+		return err
+	}
+
+	in, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+
+	//nolint:errcheck
+	defer in.Close()
+
+	a, err := archive.Read(in, signer, identity)
+	if err != nil {
+		return err
+	}
+
+	exported, err := clientconfig.FromString(a.Secrets.Talosconfig)
+	if err != nil {
+		return fmt.Errorf("error parsing exported talosconfig: %w", err)
+	}
+
+	c, err := clientconfig.Open(talos.Talosconfig)
+	if err != nil {
+		return fmt.Errorf("error reading config: %w", err)
+	}
+
+	renames := c.Merge(exported)
+	for _, rename := range renames {
+		fmt.Printf("renamed talosconfig context %s\n", rename.String())
+	}
+
+	if err = c.Save(talos.Talosconfig); err != nil {
+		return fmt.Errorf("error writing config: %w", err)
+	}
+
+	if importCmdFlags.outputDir != "" {
+		if err = writeArchive(a, importCmdFlags.outputDir); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("imported cluster %q exported at %s\n\n", a.Manifest.ClusterName, a.Manifest.CreatedAt.Format("2006-01-02 15:04:05 MST"))
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "NODE\tHOSTNAME\tTYPE\tTALOS")
+
+	for _, member := range a.Manifest.Members {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", member.Node, member.Hostname, member.MachineType, member.TalosVersion)
+	}
+
+	return w.Flush()
+}
+
+func writeArchive(a *archive.Archive, dir string) error {
+	if err := os.MkdirAll(filepath.Join(dir, "configs"), 0o700); err != nil {
+		return err
+	}
+
+	manifest, err := yaml.Marshal(a.Manifest)
+	if err != nil {
+		return err
+	}
+
+	if err = ioutil.WriteFile(filepath.Join(dir, "manifest.yaml"), manifest, 0o600); err != nil {
+		return err
+	}
+
+	for node, cfg := range a.Configs {
+		if err = ioutil.WriteFile(filepath.Join(dir, "configs", node+".yaml"), cfg, 0o600); err != nil {
+			return err
+		}
+	}
+
+	secrets, err := yaml.Marshal(a.Secrets.Bundle)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(filepath.Join(dir, "secrets.yaml"), secrets, 0o600)
+}
+
+func init() {
+	importCmd.Flags().StringVar(&importCmdFlags.identity, "identity", "", "path to the PEM encoded RSA private key the secrets were encrypted to")
+	importCmd.Flags().StringVar(&importCmdFlags.signer, "signer", "", "path to the PEM encoded ed25519 public key to verify the archive signature")
+	importCmd.Flags().StringVar(&importCmdFlags.outputDir, "output-dir", "", "directory to write decrypted archive contents to")
+	cli.Should(importCmd.MarkFlagRequired("identity"))
+	cli.Should(importCmd.MarkFlagRequired("signer"))
+
+	Cmd.AddCommand(importCmd)
+}
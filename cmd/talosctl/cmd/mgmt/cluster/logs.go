@@ -0,0 +1,59 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package cluster
+
+import (
+	"context"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/talos-systems/talos/pkg/cli"
+	"github.com/talos-systems/talos/pkg/provision/providers"
+)
+
+var logsCmdFlags struct {
+	follow    bool
+	tailLines int32
+}
+
+// logsCmd represents the cluster logs command.
+var logsCmd = &cobra.Command{
+	Use:   "logs <node>",
+	Short: "Retrieve console logs of a node in the local provisioned cluster",
+	Long: `Retrieve console logs of a node in the local provisioned cluster.
+
+Logs are available even if the node doesn't respond to the API, e.g. when the node fails to boot.
+Node names are listed with 'talosctl cluster show'.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return cli.WithContext(context.Background(), func(ctx context.Context) error {
+			return logs(ctx, args[0])
+		})
+	},
+}
+
+func logs(ctx context.Context, nodeName string) error {
+	provisioner, err := providers.Factory(ctx, provisionerName)
+	if err != nil {
+		return err
+	}
+
+	defer provisioner.Close() //nolint:errcheck
+
+	cluster, err := provisioner.Reflect(ctx, clusterName, stateDir)
+	if err != nil {
+		return err
+	}
+
+	return provisioner.NodeLogs(ctx, cluster, nodeName, logsCmdFlags.tailLines, logsCmdFlags.follow, os.Stdout)
+}
+
+func init() {
+	logsCmd.Flags().BoolVarP(&logsCmdFlags.follow, "follow", "f", false, "specify if the logs should be streamed")
+	logsCmd.Flags().Int32VarP(&logsCmdFlags.tailLines, "tail", "", -1, "lines of log file to display (default is to show from the beginning)")
+
+	Cmd.AddCommand(logsCmd)
+}
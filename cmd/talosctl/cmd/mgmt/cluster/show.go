@@ -77,7 +77,7 @@ func showCluster(cluster provision.Cluster) error {
 
 	w = tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
 
-	fmt.Fprintf(w, "NAME\tTYPE\tIP\tCPU\tRAM\tDISK\n")
+	fmt.Fprintf(w, "NAME\tTYPE\tIP\tCPU\tRAM\tDISK\tSTATE\n")
 
 	nodes := cluster.Info().Nodes
 	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Name < nodes[j].Name })
@@ -98,18 +98,24 @@ func showCluster(cluster provision.Cluster) error {
 			disk = humanize.Bytes(node.DiskSize)
 		}
 
+		state := "-"
+		if node.State != "" {
+			state = node.State
+		}
+
 		ips := make([]string, len(node.IPs))
 		for i := range ips {
 			ips[i] = node.IPs[i].String()
 		}
 
-		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
 			node.Name,
 			node.Type,
 			strings.Join(ips, ","),
 			cpus,
 			mem,
 			disk,
+			state,
 		)
 	}
 
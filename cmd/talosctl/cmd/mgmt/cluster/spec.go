@@ -0,0 +1,278 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package cluster
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/url"
+	"strconv"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/hashicorp/go-multierror"
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
+
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1/bundle"
+)
+
+// clusterSpec is the declarative description of the cluster loaded with `--config`.
+//
+// Spec fields map to the flags of `talosctl cluster create`, flags set explicitly
+// take precedence over the spec, unset fields keep the flag defaults.
+type clusterSpec struct {
+	Name              string            `yaml:"name,omitempty"`
+	Provisioner       string            `yaml:"provisioner,omitempty"`
+	Image             string            `yaml:"image,omitempty"`
+	InstallImage      string            `yaml:"installImage,omitempty"`
+	KubernetesVersion string            `yaml:"kubernetesVersion,omitempty"`
+	TalosVersion      string            `yaml:"talosVersion,omitempty"`
+	ControlPlane      nodeClassSpec     `yaml:"controlPlane,omitempty"`
+	Workers           nodeClassSpec     `yaml:"workers,omitempty"`
+	Network           networkSpec       `yaml:"network,omitempty"`
+	RegistryMirrors   map[string]string `yaml:"registryMirrors,omitempty"`
+	// ConfigPatches are JSON patch (RFC6902) operations applied to every generated config.
+	ConfigPatches []interface{} `yaml:"configPatches,omitempty"`
+}
+
+// nodeClassSpec describes the nodes of the class (controlplane or workers).
+type nodeClassSpec struct {
+	Count *int `yaml:"count,omitempty"`
+	// CPUs is the share of CPUs as fraction.
+	CPUs string `yaml:"cpus,omitempty"`
+	// Memory in MiB.
+	Memory int `yaml:"memory,omitempty"`
+	// Disk is the size of the system disk in MiB (VM only).
+	Disk int `yaml:"disk,omitempty"`
+	// ConfigPatches are JSON patch (RFC6902) operations applied to the configs of the class.
+	ConfigPatches []interface{} `yaml:"configPatches,omitempty"`
+}
+
+// networkSpec describes the cluster network.
+type networkSpec struct {
+	CIDR        string   `yaml:"cidr,omitempty"`
+	MTU         int      `yaml:"mtu,omitempty"`
+	IPv4        *bool    `yaml:"ipv4,omitempty"`
+	IPv6        *bool    `yaml:"ipv6,omitempty"`
+	Nameservers []string `yaml:"nameservers,omitempty"`
+}
+
+// loadClusterSpec loads and validates the cluster spec, unknown fields are rejected.
+func loadClusterSpec(path string) (*clusterSpec, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var spec clusterSpec
+
+	decoder := yaml.NewDecoder(bytes.NewReader(b))
+	decoder.KnownFields(true)
+
+	if err = decoder.Decode(&spec); err != nil {
+		return nil, fmt.Errorf("error parsing cluster spec %q: %w", path, err)
+	}
+
+	if err = spec.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid cluster spec %q: %w", path, err)
+	}
+
+	return &spec, nil
+}
+
+// Validate validates the cluster spec.
+//
+//nolint:gocyclo
+func (spec *clusterSpec) Validate() error {
+	var result *multierror.Error
+
+	switch spec.Provisioner {
+	case "", "docker", "qemu", "firecracker":
+	default:
+		result = multierror.Append(result, fmt.Errorf("unsupported provisioner %q", spec.Provisioner))
+	}
+
+	if spec.ControlPlane.Count != nil && *spec.ControlPlane.Count < 1 {
+		result = multierror.Append(result, fmt.Errorf("controlPlane.count can't be less than 1"))
+	}
+
+	if spec.Workers.Count != nil && *spec.Workers.Count < 0 {
+		result = multierror.Append(result, fmt.Errorf("workers.count can't be negative"))
+	}
+
+	for _, class := range []struct {
+		name string
+		spec nodeClassSpec
+	}{
+		{"controlPlane", spec.ControlPlane},
+		{"workers", spec.Workers},
+	} {
+		nodeClass := class.spec
+
+		if nodeClass.CPUs != "" {
+			if _, err := parseCPUShare(nodeClass.CPUs); err != nil {
+				result = multierror.Append(result, fmt.Errorf("invalid %s.cpus %q", class.name, nodeClass.CPUs))
+			}
+		}
+
+		if nodeClass.Memory < 0 {
+			result = multierror.Append(result, fmt.Errorf("%s.memory can't be negative", class.name))
+		}
+
+		if nodeClass.Disk < 0 {
+			result = multierror.Append(result, fmt.Errorf("%s.disk can't be negative", class.name))
+		}
+
+		if _, err := decodeSpecPatch(nodeClass.ConfigPatches); err != nil {
+			result = multierror.Append(result, fmt.Errorf("invalid %s.configPatches: %w", class.name, err))
+		}
+	}
+
+	if spec.Network.CIDR != "" {
+		if _, _, err := net.ParseCIDR(spec.Network.CIDR); err != nil {
+			result = multierror.Append(result, fmt.Errorf("invalid network.cidr %q", spec.Network.CIDR))
+		}
+	}
+
+	if spec.Network.MTU < 0 {
+		result = multierror.Append(result, fmt.Errorf("network.mtu can't be negative"))
+	}
+
+	for _, nameserver := range spec.Network.Nameservers {
+		if net.ParseIP(nameserver) == nil {
+			result = multierror.Append(result, fmt.Errorf("invalid network.nameservers IP %q", nameserver))
+		}
+	}
+
+	for registry, mirror := range spec.RegistryMirrors {
+		if u, err := url.Parse(mirror); err != nil || u.Scheme == "" || u.Host == "" {
+			result = multierror.Append(result, fmt.Errorf("invalid registryMirrors URL %q for %q", mirror, registry))
+		}
+	}
+
+	if _, err := decodeSpecPatch(spec.ConfigPatches); err != nil {
+		result = multierror.Append(result, fmt.Errorf("invalid configPatches: %w", err))
+	}
+
+	return result.ErrorOrNil()
+}
+
+// ApplyFlags sets the flags from the spec, flags changed on the command line are kept.
+//
+//nolint:gocyclo
+func (spec *clusterSpec) ApplyFlags(flags *pflag.FlagSet) error {
+	values := map[string][]string{}
+
+	set := func(name, value string) {
+		if value != "" {
+			values[name] = append(values[name], value)
+		}
+	}
+
+	setInt := func(name string, value *int) {
+		if value != nil {
+			set(name, strconv.Itoa(*value))
+		}
+	}
+
+	setBool := func(name string, value *bool) {
+		if value != nil {
+			set(name, strconv.FormatBool(*value))
+		}
+	}
+
+	positive := func(value int) *int {
+		if value > 0 {
+			return &value
+		}
+
+		return nil
+	}
+
+	set("name", spec.Name)
+	set("provisioner", spec.Provisioner)
+	set("image", spec.Image)
+	set("install-image", spec.InstallImage)
+	set("kubernetes-version", spec.KubernetesVersion)
+	set("talos-version", spec.TalosVersion)
+
+	setInt("masters", spec.ControlPlane.Count)
+	set("cpus", spec.ControlPlane.CPUs)
+	setInt("memory", positive(spec.ControlPlane.Memory))
+	setInt("disk", positive(spec.ControlPlane.Disk))
+
+	setInt("workers", spec.Workers.Count)
+	set("cpus-workers", spec.Workers.CPUs)
+	setInt("memory-workers", positive(spec.Workers.Memory))
+	setInt("disk-workers", positive(spec.Workers.Disk))
+
+	set("cidr", spec.Network.CIDR)
+	setInt("mtu", positive(spec.Network.MTU))
+	setBool("ipv4", spec.Network.IPv4)
+	setBool("ipv6", spec.Network.IPv6)
+
+	for _, nameserver := range spec.Network.Nameservers {
+		set("nameservers", nameserver)
+	}
+
+	for registry, mirror := range spec.RegistryMirrors {
+		set("registry-mirror", registry+"="+mirror)
+	}
+
+	for name, vals := range values {
+		if flags.Changed(name) {
+			continue
+		}
+
+		for _, value := range vals {
+			if err := flags.Set(name, value); err != nil {
+				return fmt.Errorf("error setting %q from the cluster spec: %w", name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// BundleOptions returns the config bundle options applying the spec config patches.
+func (spec *clusterSpec) BundleOptions() ([]bundle.Option, error) {
+	patch, err := decodeSpecPatch(spec.ConfigPatches)
+	if err != nil {
+		return nil, err
+	}
+
+	patchControlPlane, err := decodeSpecPatch(spec.ControlPlane.ConfigPatches)
+	if err != nil {
+		return nil, err
+	}
+
+	patchWorkers, err := decodeSpecPatch(spec.Workers.ConfigPatches)
+	if err != nil {
+		return nil, err
+	}
+
+	return []bundle.Option{
+		bundle.WithJSONPatch(patch),
+		bundle.WithJSONPatchControlPlane(patchControlPlane),
+		bundle.WithJSONPatchJoin(patchWorkers),
+	}, nil
+}
+
+// decodeSpecPatch converts the YAML patch operations into the JSON patch.
+func decodeSpecPatch(operations []interface{}) (jsonpatch.Patch, error) {
+	if len(operations) == 0 {
+		return nil, nil
+	}
+
+	b, err := json.Marshal(operations)
+	if err != nil {
+		return nil, err
+	}
+
+	return jsonpatch.DecodePatch(b)
+}
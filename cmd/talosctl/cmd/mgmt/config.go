@@ -20,6 +20,7 @@ import (
 	"github.com/talos-systems/talos/cmd/talosctl/pkg/mgmt/helpers"
 	"github.com/talos-systems/talos/pkg/images"
 	"github.com/talos-systems/talos/pkg/machinery/config"
+	"github.com/talos-systems/talos/pkg/machinery/config/configpatcher"
 	"github.com/talos-systems/talos/pkg/machinery/config/encoder"
 	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1/bundle"
 	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1/generate"
@@ -37,6 +38,7 @@ var genConfigCmdFlags struct {
 	installImage      string
 	outputDir         string
 	configPatch       string
+	workerClasses     []string
 	registryMirrors   []string
 	persistConfig     bool
 	withExamples      bool
@@ -173,6 +175,22 @@ func genV1Alpha1Config(args []string) error {
 		configBundleOpts = append(configBundleOpts, bundle.WithJSONPatch(jsonPatch))
 	}
 
+	for _, workerClass := range genConfigCmdFlags.workerClasses {
+		components := strings.SplitN(workerClass, "=", 2)
+		if len(components) != 2 {
+			return fmt.Errorf("invalid worker class spec: %q", workerClass)
+		}
+
+		var patches []configpatcher.Patch
+
+		patches, err = configpatcher.LoadPatches([]string{components[1]})
+		if err != nil {
+			return fmt.Errorf("error loading worker class %q patch: %w", components[0], err)
+		}
+
+		configBundleOpts = append(configBundleOpts, bundle.WithWorkerClass(components[0], patches))
+	}
+
 	configBundle, err := bundle.NewConfigBundle(configBundleOpts...)
 	if err != nil {
 		return fmt.Errorf("failed to generate config bundle: %w", err)
@@ -221,6 +239,7 @@ func init() {
 	genConfigCmd.Flags().StringVar(&genConfigCmdFlags.kubernetesVersion, "kubernetes-version", "", "desired kubernetes version to run")
 	genConfigCmd.Flags().StringVarP(&genConfigCmdFlags.outputDir, "output-dir", "o", "", "destination to output generated files")
 	genConfigCmd.Flags().StringVar(&genConfigCmdFlags.configPatch, "config-patch", "", "patch generated machineconfigs")
+	genConfigCmd.Flags().StringArrayVar(&genConfigCmdFlags.workerClasses, "worker-class", nil, "generate additional worker config for the class in format: <class>=<patch>, use @file to read a patch from file")
 	genConfigCmd.Flags().StringSliceVar(&genConfigCmdFlags.registryMirrors, "registry-mirror", []string{}, "list of registry mirrors to use in format: <registry host>=<mirror URL>")
 	genConfigCmd.Flags().BoolVarP(&genConfigCmdFlags.persistConfig, "persist", "p", true, "the desired persist value for configs")
 	genConfigCmd.Flags().BoolVarP(&genConfigCmdFlags.withExamples, "with-examples", "", true, "renders all machine configs with the commented examples")
@@ -0,0 +1,133 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package mgmt
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/talos-systems/talos/cmd/talosctl/pkg/mgmt/helpers"
+	"github.com/talos-systems/talos/pkg/cli"
+	"github.com/talos-systems/talos/pkg/imager"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+)
+
+var genImageCmdFlags struct {
+	profile   string
+	outputDir string
+
+	platform        string
+	arch            string
+	board           string
+	boardOverlay    string
+	kernel          string
+	initramfs       string
+	extensions      []string
+	extraKernelArgs []string
+	configSource    string
+	imageFormat     string
+	imageCache      string
+	pxeBaseURL      string
+}
+
+// genImageCmd represents the gen image command.
+var genImageCmd = &cobra.Command{
+	Use:   "image <iso|image|pxe>",
+	Short: "Builds Talos installation media",
+	Long: `Builds Talos installation media from the kernel, the initramfs, the extensions and the kernel args.
+
+Output kinds:
+
+  iso    bootable ISO (requires grub-mkrescue)
+  image  disk image for the platform (requires root privileges to attach loop devices, qemu-img for qcow2, vhd and ova formats)
+  pxe    kernel, initramfs, kernel command line and iPXE script for PXE boot
+
+Extensions are (compressed) cpio archives appended to the initramfs.
+
+Single board computers are supported with the board overlays: the overlay directory contains overlay.yaml
+which lists the kernel args, the u-boot payloads written to the disk and the files (DTBs, firmware blobs)
+copied to the EFI partition.
+
+The build is described by the profile, which can be loaded with --profile instead of the flags.
+The output directory contains the artifacts and the manifest.yaml with the profile, the kernel command line
+and the checksums of the inputs and the artifacts; the manifest can be passed as --profile to repeat the build.`,
+	Args: cobra.RangeArgs(0, 1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var prof imager.Profile
+
+		if genImageCmdFlags.profile != "" {
+			if len(args) > 0 {
+				return fmt.Errorf("output kind can't be set with --profile")
+			}
+
+			p, err := imager.LoadProfile(genImageCmdFlags.profile)
+			if err != nil {
+				return err
+			}
+
+			prof = *p
+		} else {
+			if len(args) != 1 {
+				return fmt.Errorf("output kind is required")
+			}
+
+			prof = imager.Profile{
+				Platform:        genImageCmdFlags.platform,
+				Arch:            genImageCmdFlags.arch,
+				Board:           genImageCmdFlags.board,
+				BoardOverlay:    genImageCmdFlags.boardOverlay,
+				Kernel:          genImageCmdFlags.kernel,
+				Initramfs:       genImageCmdFlags.initramfs,
+				Extensions:      genImageCmdFlags.extensions,
+				ExtraKernelArgs: genImageCmdFlags.extraKernelArgs,
+				ConfigSource:    genImageCmdFlags.configSource,
+				Output: imager.Output{
+					Kind:        imager.OutputKind(args[0]),
+					ImageFormat: imager.ImageFormat(genImageCmdFlags.imageFormat),
+					ImageCache:  genImageCmdFlags.imageCache,
+					PXEBaseURL:  genImageCmdFlags.pxeBaseURL,
+				},
+			}
+		}
+
+		img, err := imager.New(prof)
+		if err != nil {
+			return err
+		}
+
+		return cli.WithContext(context.Background(), func(ctx context.Context) error {
+			manifest, err := img.Execute(ctx, genImageCmdFlags.outputDir)
+			if err != nil {
+				return err
+			}
+
+			for _, artifact := range manifest.Artifacts {
+				fmt.Printf("created %s (sha256 %s)\n", artifact.Path, artifact.SHA256)
+			}
+
+			return nil
+		})
+	},
+}
+
+func init() {
+	genCmd.AddCommand(genImageCmd)
+	genImageCmd.Flags().StringVar(&genImageCmdFlags.profile, "profile", "", "the profile (or the manifest of the earlier build) to build the media from")
+	genImageCmd.Flags().StringVarP(&genImageCmdFlags.outputDir, "output-dir", "o", "_out/image", "destination to output the artifacts and the manifest")
+	genImageCmd.Flags().StringVar(&genImageCmdFlags.platform, "platform", "metal", "the value of "+constants.KernelParamPlatform)
+	genImageCmd.Flags().StringVar(&genImageCmdFlags.arch, "arch", "", "the architecture of the kernel and the initramfs (defaults to the host architecture)")
+	genImageCmd.Flags().StringVar(&genImageCmdFlags.board, "board", constants.BoardNone, "the single board computer to build the metal disk image for")
+	genImageCmd.Flags().StringVar(&genImageCmdFlags.boardOverlay, "board-overlay", "", "the path to the board overlay directory (overrides --board)")
+	genImageCmd.Flags().StringVar(&genImageCmdFlags.kernel, "kernel", helpers.ArtifactPath(constants.KernelAssetWithArch), "the compressed kernel image to use")
+	genImageCmd.Flags().StringVar(&genImageCmdFlags.initramfs, "initramfs", helpers.ArtifactPath(constants.InitramfsAssetWithArch), "the initramfs to use")
+	genImageCmd.Flags().StringArrayVar(&genImageCmdFlags.extensions, "extension", nil, "the cpio archive to append to the initramfs")
+	genImageCmd.Flags().StringArrayVar(&genImageCmdFlags.extraKernelArgs, "extra-kernel-arg", nil, "extra argument to pass to the kernel (prefix with - to remove the argument)")
+	genImageCmd.Flags().StringVar(&genImageCmdFlags.configSource, "config", "", "the value of "+constants.KernelParamConfig+" (defaults to the platform default)")
+	genImageCmd.Flags().StringVar(&genImageCmdFlags.imageFormat, "image-format", "", "the disk image format: raw, qcow2, vhd or ova (defaults to the platform default)")
+	genImageCmd.Flags().StringVar(&genImageCmdFlags.imageCache, "image-cache", "", "the path to the OCI image layout to bundle into the ISO as the image cache")
+	genImageCmd.Flags().StringVar(&genImageCmdFlags.pxeBaseURL, "pxe-base-url", "", "the URL the PXE artifacts are served from (defaults to the iPXE script location)")
+}
@@ -0,0 +1,90 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package mgmt
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/talos-systems/talos/pkg/machinery/config/configlayers"
+	"github.com/talos-systems/talos/pkg/machinery/config/configloader"
+)
+
+var genLayeredCmdFlags struct {
+	outputDir string
+	nodes     []string
+}
+
+// genLayeredCmd represents the gen layered command.
+var genLayeredCmd = &cobra.Command{
+	Use:   "layered <layers file>",
+	Short: "Renders machine configs for the nodes of the layered config",
+	Long: `Renders machine configs for the nodes of the layered config.
+
+Layered config file lists the base machine config document, role overlays and node overlays:
+
+  base: base.yaml
+  roles:
+    controlplane:
+      - controlplane.yaml
+  nodes:
+    10.5.0.2:
+      role: controlplane
+      overlays:
+        - nodes/10.5.0.2.yaml
+
+Each node config is rendered by merging the base, the role overlays and the node overlays in order.
+Rendered configs are written as <node>.yaml to the output directory.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		layers, err := configlayers.Load(args[0])
+		if err != nil {
+			return err
+		}
+
+		nodes := genLayeredCmdFlags.nodes
+		if len(nodes) == 0 {
+			nodes = layers.NodeNames()
+		}
+
+		if err = os.MkdirAll(genLayeredCmdFlags.outputDir, os.ModePerm); err != nil {
+			return err
+		}
+
+		for _, node := range nodes {
+			var rendered []byte
+
+			rendered, err = layers.Render(node)
+			if err != nil {
+				return err
+			}
+
+			// make sure rendered config is still a valid config document
+			if _, err = configloader.NewFromBytes(rendered); err != nil {
+				return fmt.Errorf("error loading rendered config for node %q: %w", node, err)
+			}
+
+			path := filepath.Join(genLayeredCmdFlags.outputDir, node+".yaml")
+
+			if err = ioutil.WriteFile(path, rendered, 0o600); err != nil {
+				return err
+			}
+
+			fmt.Printf("created %s\n", path)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	genCmd.AddCommand(genLayeredCmd)
+	genLayeredCmd.Flags().StringVarP(&genLayeredCmdFlags.outputDir, "output-dir", "o", ".", "destination to output rendered configs")
+	genLayeredCmd.Flags().StringSliceVar(&genLayeredCmdFlags.nodes, "node", nil, "render only the specified nodes (defaults to all nodes)")
+}
@@ -6,17 +6,20 @@ package mgmt
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/spf13/cobra"
-	"github.com/talos-systems/go-loadbalancer/loadbalancer"
 
 	"github.com/talos-systems/talos/pkg/machinery/constants"
+	"github.com/talos-systems/talos/pkg/provision/loadbalancer"
 )
 
 var loadbalancerLaunchCmdFlags struct {
 	addr             string
 	upstreams        []string
 	apidOnlyInitNode bool
+
+	healthCheckInterval time.Duration
 }
 
 // loadbalancerLaunchCmd represents the loadbalancer-launch command.
@@ -35,7 +38,11 @@ var loadbalancerLaunchCmd = &cobra.Command{
 				upstreams[i] = fmt.Sprintf("%s:%d", loadbalancerLaunchCmdFlags.upstreams[i], port)
 			}
 
-			if err := lb.AddRoute(fmt.Sprintf("%s:%d", loadbalancerLaunchCmdFlags.addr, port), upstreams); err != nil {
+			// kube-apiserver readiness endpoint is used, so that restarting control plane nodes are removed from the rotation
+			if err := lb.AddRoute(fmt.Sprintf("%s:%d", loadbalancerLaunchCmdFlags.addr, port), upstreams,
+				loadbalancer.WithHealthCheck(loadbalancer.HTTPSHealthCheck("/readyz")),
+				loadbalancer.WithHealthCheckInterval(loadbalancerLaunchCmdFlags.healthCheckInterval),
+			); err != nil {
 				return err
 			}
 		}
@@ -47,5 +54,6 @@ var loadbalancerLaunchCmd = &cobra.Command{
 func init() {
 	loadbalancerLaunchCmd.Flags().StringVar(&loadbalancerLaunchCmdFlags.addr, "loadbalancer-addr", "localhost", "load balancer listen address (IP or host)")
 	loadbalancerLaunchCmd.Flags().StringSliceVar(&loadbalancerLaunchCmdFlags.upstreams, "loadbalancer-upstreams", []string{}, "load balancer upstreams (nodes to proxy to)")
+	loadbalancerLaunchCmd.Flags().DurationVar(&loadbalancerLaunchCmdFlags.healthCheckInterval, "loadbalancer-health-check-interval", 5*time.Second, "interval between upstream health checks")
 	addCommand(loadbalancerLaunchCmd)
 }
@@ -0,0 +1,224 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package mgmt
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/talos-systems/talos/cmd/talosctl/pkg/mgmt/helpers"
+	"github.com/talos-systems/talos/pkg/cli"
+	"github.com/talos-systems/talos/pkg/cluster/kubeadm"
+	"github.com/talos-systems/talos/pkg/images"
+	"github.com/talos-systems/talos/pkg/machinery/config/configloader"
+	"github.com/talos-systems/talos/pkg/machinery/config/encoder"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1/generate"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1/machine"
+)
+
+// migrateCmd represents the migrate command.
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Migrate existing clusters and machine configs to Talos",
+	Long:  ``,
+}
+
+var migrateKubeadmCmdFlags struct {
+	kubeconfig     string
+	pkiDir         string
+	certificateKey string
+	outputDir      string
+	installDisk    string
+	installImage   string
+}
+
+// migrateKubeadmCmd represents the migrate kubeadm command.
+var migrateKubeadmCmd = &cobra.Command{
+	Use:   "kubeadm",
+	Short: "Generates Talos machine configs and the migration plan for a kubeadm cluster",
+	Long: `Inspects the kubeadm cluster (kubeadm-config, cluster-info, nodes) and generates Talos machine configs
+which reuse the cluster CAs, so that Talos control plane nodes can join the existing control plane.
+
+The cluster PKI is loaded either from the copy of /etc/kubernetes/pki of a kubeadm control plane node (--pki-dir),
+or from the kubeadm-certs secret uploaded with 'kubeadm init phase upload-certs --upload-certs' (--certificate-key).
+
+Generated files: controlplane.yaml, join.yaml, talosconfig and migration-plan.md with the step-by-step
+node replacement plan.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if migrateKubeadmCmdFlags.pkiDir == "" && migrateKubeadmCmdFlags.certificateKey == "" {
+			return fmt.Errorf("either --pki-dir or --certificate-key is required")
+		}
+
+		return cli.WithContext(context.Background(), migrateKubeadm)
+	},
+}
+
+func migrateKubeadm(ctx context.Context) error {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = migrateKubeadmCmdFlags.kubeconfig
+
+	restConfig, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, &clientcmd.ConfigOverrides{}).ClientConfig()
+	if err != nil {
+		return fmt.Errorf("error loading kubeconfig: %w", err)
+	}
+
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return fmt.Errorf("error building Kubernetes client: %w", err)
+	}
+
+	cluster, err := kubeadm.Inspect(ctx, clientset)
+	if err != nil {
+		return err
+	}
+
+	var pki *kubeadm.PKI
+
+	if migrateKubeadmCmdFlags.pkiDir != "" {
+		pki, err = kubeadm.LoadPKIFromDir(migrateKubeadmCmdFlags.pkiDir)
+	} else {
+		pki, err = kubeadm.LoadPKIFromSecret(ctx, clientset, migrateKubeadmCmdFlags.certificateKey)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	secrets, err := pki.SecretsBundle(generate.NewClock())
+	if err != nil {
+		return err
+	}
+
+	input, err := cluster.Input(secrets,
+		generate.WithInstallDisk(migrateKubeadmCmdFlags.installDisk),
+		generate.WithInstallImage(migrateKubeadmCmdFlags.installImage),
+	)
+	if err != nil {
+		return err
+	}
+
+	configBundle := &v1alpha1.ConfigBundle{}
+
+	if configBundle.ControlPlaneCfg, err = generate.Config(machine.TypeControlPlane, input); err != nil {
+		return err
+	}
+
+	if configBundle.JoinCfg, err = generate.Config(machine.TypeJoin, input); err != nil {
+		return err
+	}
+
+	if configBundle.TalosCfg, err = generate.Talosconfig(input); err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(migrateKubeadmCmdFlags.outputDir, os.ModePerm); err != nil {
+		return err
+	}
+
+	if err = configBundle.Write(migrateKubeadmCmdFlags.outputDir, encoder.CommentsAll, machine.TypeControlPlane, machine.TypeJoin); err != nil {
+		return err
+	}
+
+	talosconfigPath := filepath.Join(migrateKubeadmCmdFlags.outputDir, "talosconfig")
+
+	if err = configBundle.TalosConfig().Save(talosconfigPath); err != nil {
+		return err
+	}
+
+	fmt.Printf("created %s\n", talosconfigPath)
+
+	plan, err := cluster.Plan(kubeadm.PlanOptions{
+		ControlPlaneConfig: filepath.Join(migrateKubeadmCmdFlags.outputDir, "controlplane.yaml"),
+		WorkerConfig:       filepath.Join(migrateKubeadmCmdFlags.outputDir, "join.yaml"),
+		Talosconfig:        talosconfigPath,
+	})
+	if err != nil {
+		return err
+	}
+
+	planPath := filepath.Join(migrateKubeadmCmdFlags.outputDir, "migration-plan.md")
+
+	if err = ioutil.WriteFile(planPath, plan, 0o644); err != nil {
+		return err
+	}
+
+	fmt.Printf("created %s\n", planPath)
+
+	return nil
+}
+
+var migrateConfigCmdFlags struct {
+	config string
+	output string
+}
+
+// migrateConfigCmd represents the migrate config command.
+var migrateConfigCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Migrate the deprecated fields of the machine config to their replacements",
+	Long: `Moves the values of the deprecated machine config fields to their replacements where possible.
+
+Deprecated fields which can't be migrated automatically are reported as warnings and left untouched.
+The migrated config is written to the output file (--output), or to stdout if not specified.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := configloader.NewFromFile(migrateConfigCmdFlags.config)
+		if err != nil {
+			return err
+		}
+
+		v1alpha1Config, ok := cfg.(*v1alpha1.Config)
+		if !ok {
+			return fmt.Errorf("unsupported config type %T", cfg)
+		}
+
+		for _, deprecation := range v1alpha1Config.MigrateDeprecated() {
+			fmt.Fprintf(os.Stderr, "migrated %q to %q\n", deprecation.Path, deprecation.Replacement)
+		}
+
+		for _, deprecation := range v1alpha1Config.Deprecations() {
+			cli.Warning("%s", deprecation)
+		}
+
+		out, err := v1alpha1Config.Bytes()
+		if err != nil {
+			return err
+		}
+
+		if migrateConfigCmdFlags.output == "" {
+			_, err = os.Stdout.Write(out)
+
+			return err
+		}
+
+		return ioutil.WriteFile(migrateConfigCmdFlags.output, out, 0o600)
+	},
+}
+
+func init() {
+	addCommand(migrateCmd)
+	migrateCmd.AddCommand(migrateKubeadmCmd)
+	migrateCmd.AddCommand(migrateConfigCmd)
+
+	migrateConfigCmd.Flags().StringVarP(&migrateConfigCmdFlags.config, "config", "c", "", "the path of the config file")
+	migrateConfigCmd.Flags().StringVarP(&migrateConfigCmdFlags.output, "output", "o", "", "the path of the migrated config file (defaults to stdout)")
+	cli.Should(migrateConfigCmd.MarkFlagRequired("config"))
+
+	migrateKubeadmCmd.Flags().StringVar(&migrateKubeadmCmdFlags.kubeconfig, "kubeconfig", "", "path to the kubeconfig of the kubeadm cluster (defaults to $KUBECONFIG or ~/.kube/config)")
+	migrateKubeadmCmd.Flags().StringVar(&migrateKubeadmCmdFlags.pkiDir, "pki-dir", "", "path to the copy of /etc/kubernetes/pki of a kubeadm control plane node")
+	migrateKubeadmCmd.Flags().StringVar(&migrateKubeadmCmdFlags.certificateKey, "certificate-key", "", "key to decrypt the kubeadm-certs secret (printed by 'kubeadm init phase upload-certs --upload-certs')")
+	migrateKubeadmCmd.Flags().StringVarP(&migrateKubeadmCmdFlags.outputDir, "output-dir", "o", ".", "destination to output generated files")
+	migrateKubeadmCmd.Flags().StringVar(&migrateKubeadmCmdFlags.installDisk, "install-disk", "/dev/sda", "the disk to install to")
+	migrateKubeadmCmd.Flags().StringVar(&migrateKubeadmCmdFlags.installImage, "install-image", helpers.DefaultImage(images.DefaultInstallerImageRepository), "the image used to perform an installation")
+}
@@ -12,27 +12,31 @@ import (
 	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
 	"github.com/talos-systems/talos/pkg/cli"
 	"github.com/talos-systems/talos/pkg/machinery/config"
+	"github.com/talos-systems/talos/pkg/machinery/config/configlayers"
 	"github.com/talos-systems/talos/pkg/machinery/config/configloader"
 )
 
 var (
-	validateConfigArg string
-	validateModeArg   string
-	validateStrictArg bool
+	validateConfigArg  string
+	validateModeArg    string
+	validateStrictArg  bool
+	validateVersionArg string
+	validateLayersArg  string
+	validateNodeArg    string
 )
 
 // validateCmd reads in a userData file and attempts to parse it.
 var validateCmd = &cobra.Command{
 	Use:   "validate",
 	Short: "Validate config",
-	Long:  ``,
-	Args:  cobra.NoArgs,
-	RunE: func(cmd *cobra.Command, args []string) error {
-		cfg, err := configloader.NewFromFile(validateConfigArg)
-		if err != nil {
-			return err
-		}
+	Long: `Validate the machine config for the specified runtime mode without connecting to the node.
 
+Besides the config validation, the versions of the install image and Kubernetes components are checked
+to be compatible with each other and to be supported by the Talos version (--against-version,
+or the version of the install image if not specified).
+The command exits with non-zero exit code if the config is not valid.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
 		mode, err := runtime.ParseMode(validateModeArg)
 		if err != nil {
 			return err
@@ -43,22 +47,113 @@ var validateCmd = &cobra.Command{
 			opts = append(opts, config.WithStrict())
 		}
 
-		warnings, err := cfg.Validate(mode, opts...)
-		for _, w := range warnings {
-			cli.Warning("%s", w)
+		target := "the current Talos version"
+
+		var versionContract *config.VersionContract
+
+		if validateVersionArg != "" {
+			versionContract, err = config.ParseContractFromVersion(validateVersionArg)
+			if err != nil {
+				return fmt.Errorf("invalid against-version: %w", err)
+			}
+
+			opts = append(opts, config.WithVersionContract(versionContract))
+			target = fmt.Sprintf("Talos %s", versionContract)
+		}
+
+		if validateLayersArg == "" {
+			var cfg config.Provider
+
+			cfg, err = configloader.NewFromFile(validateConfigArg)
+			if err != nil {
+				return err
+			}
+
+			if err = validateConfig(cfg, mode, versionContract, opts); err != nil {
+				return err
+			}
+
+			fmt.Printf("%s is valid for %s mode (%s)\n", validateConfigArg, validateModeArg, target)
+
+			return nil
 		}
+
+		layers, err := configlayers.Load(validateLayersArg)
 		if err != nil {
 			return err
 		}
 
-		fmt.Printf("%s is valid for %s mode\n", validateConfigArg, validateModeArg)
+		nodes := layers.NodeNames()
+		if validateNodeArg != "" {
+			nodes = []string{validateNodeArg}
+		}
+
+		var failed bool
+
+		for _, node := range nodes {
+			if err = validateLayeredConfig(layers, node, mode, versionContract, opts); err != nil {
+				cli.Warning("node %q: %s", node, err)
+
+				failed = true
+
+				continue
+			}
+
+			fmt.Printf("node %q is valid for %s mode (%s)\n", node, validateModeArg, target)
+		}
+
+		if failed {
+			return fmt.Errorf("some of the node configs are not valid")
+		}
 
 		return nil
 	},
 }
 
+func validateLayeredConfig(layers *configlayers.Layers, node string, mode runtime.Mode, versionContract *config.VersionContract, opts []config.ValidationOption) error {
+	rendered, err := layers.Render(node)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := configloader.NewFromBytes(rendered)
+	if err != nil {
+		return err
+	}
+
+	return validateConfig(cfg, mode, versionContract, opts)
+}
+
+func validateConfig(cfg config.Provider, mode runtime.Mode, versionContract *config.VersionContract, opts []config.ValidationOption) error {
+	warnings, err := cfg.Validate(mode, opts...)
+	for _, w := range warnings {
+		cli.Warning("%s", w)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	warnings, err = config.CheckVersionCompatibility(cfg, versionContract)
+	for _, w := range warnings {
+		cli.Warning("%s", w)
+	}
+
+	if err != nil {
+		return fmt.Errorf("version compatibility check failed: %w", err)
+	}
+
+	if validateStrictArg && len(warnings) > 0 {
+		return fmt.Errorf("version compatibility check returned warnings in strict mode")
+	}
+
+	return nil
+}
+
 func init() {
 	validateCmd.Flags().StringVarP(&validateConfigArg, "config", "c", "", "the path of the config file")
+	validateCmd.Flags().StringVar(&validateLayersArg, "layers", "", "the path of the layered config file (validates the rendered config of each node)")
+	validateCmd.Flags().StringVar(&validateNodeArg, "node", "", "validate only the specified node of the layered config")
 	validateCmd.Flags().StringVarP(
 		&validateModeArg,
 		"mode",
@@ -68,5 +163,6 @@ func init() {
 	)
 	cli.Should(validateCmd.MarkFlagRequired("mode"))
 	validateCmd.Flags().BoolVarP(&validateStrictArg, "strict", "", false, "treat validation warnings as errors")
+	validateCmd.Flags().StringVar(&validateVersionArg, "against-version", "", "validate the config against the feature set of the specified Talos version (e.g. v0.9)")
 	addCommand(validateCmd)
 }
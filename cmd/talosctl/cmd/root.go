@@ -13,6 +13,7 @@ import (
 
 	"github.com/talos-systems/talos/cmd/talosctl/cmd/mgmt"
 	"github.com/talos-systems/talos/cmd/talosctl/cmd/talos"
+	"github.com/talos-systems/talos/pkg/cli"
 	"github.com/talos-systems/talos/pkg/machinery/client/config"
 )
 
@@ -35,10 +36,14 @@ func Execute() error {
 	}
 
 	rootCmd.PersistentFlags().StringVar(&talos.Talosconfig, "talosconfig", defaultTalosConfig, "The path to the Talos configuration file")
-	rootCmd.PersistentFlags().StringVar(&talos.Cmdcontext, "context", "", "Context to be used in command")
+	rootCmd.PersistentFlags().StringVar(&talos.Cmdcontext, "context", "", "Context to be used in command (read-only commands accept a comma-separated list of contexts)")
+	rootCmd.PersistentFlags().BoolVar(&talos.AllContexts, "all-contexts", false, "Run read-only command against all contexts in the Talos configuration")
 	rootCmd.PersistentFlags().StringSliceVarP(&talos.Nodes, "nodes", "n", []string{}, "target the specified nodes")
 	rootCmd.PersistentFlags().StringSliceVarP(&talos.Endpoints, "endpoints", "e", []string{}, "override default endpoints in Talos configuration")
 
+	cli.Should(rootCmd.RegisterFlagCompletionFunc("context", talos.CompleteContexts))
+	cli.Should(rootCmd.RegisterFlagCompletionFunc("nodes", talos.CompleteNodes))
+
 	cmd, err := rootCmd.ExecuteC()
 	if err != nil {
 		fmt.Fprintln(os.Stderr, err.Error())
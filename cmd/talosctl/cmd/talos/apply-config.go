@@ -5,28 +5,45 @@
 package talos
 
 import (
+	"bufio"
 	"context"
 	"crypto/tls"
 	"fmt"
 	"io/ioutil"
+	"net"
+	"os"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/mattn/go-isatty"
 	"github.com/spf13/cobra"
 	"github.com/talos-systems/crypto/x509"
+	"google.golang.org/grpc/status"
 
+	"github.com/talos-systems/talos/cmd/talosctl/pkg/talos/helpers"
+	"github.com/talos-systems/talos/cmd/talosctl/pkg/talos/rolling"
 	"github.com/talos-systems/talos/internal/pkg/tui/installer"
 	"github.com/talos-systems/talos/pkg/cli"
 	machineapi "github.com/talos-systems/talos/pkg/machinery/api/machine"
 	"github.com/talos-systems/talos/pkg/machinery/client"
+	"github.com/talos-systems/talos/pkg/machinery/config/configlayers"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
 )
 
 var applyConfigCmdFlags struct {
-	certFingerprints []string
-	filename         string
-	insecure         bool
-	interactive      bool
-	onReboot         bool
-	immediate        bool
+	certFingerprints     []string
+	filename             string
+	expectedChecksum     string
+	layers               string
+	insecure             bool
+	skipFingerprintCheck bool
+	interactive          bool
+	onReboot             bool
+	immediate            bool
+	rolling              bool
+	healthCheck          bool
+	timeout              time.Duration
 }
 
 // applyConfigCmd represents the applyConfiguration command.
@@ -34,11 +51,25 @@ var applyConfigCmd = &cobra.Command{
 	Use:     "apply-config",
 	Aliases: []string{"apply"},
 	Short:   "Apply a new configuration to a node",
-	Long:    ``,
-	Args:    cobra.MaximumNArgs(1),
+	Long: `Apply a new configuration to a node.
+
+The config is applied via the insecure maintenance service (--insecure) only while the node
+runs in maintenance mode without a config, and the maintenance service accepts the config only once.
+
+The certificate fingerprint of the node in insecure mode is verified against the fingerprints set
+with --cert-fingerprint, or displayed for the operator to confirm that it matches the fingerprint
+printed on the node console. Without a terminal, either --cert-fingerprint or --skip-fingerprint-check
+should be set.
+
+With '--rolling' flag, the config is applied to the nodes one by one. With '--health-check' flag,
+talosctl waits for the node to come back (if the config is applied with a reboot) and for the services
+of the node to become healthy before proceeding to the next node. For control plane nodes, etcd should be
+healthy and have the quorum. The rollout is aborted on the first failure.`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		var (
 			cfgBytes []byte
+			layers   *configlayers.Layers
 			e        error
 		)
 
@@ -54,7 +85,16 @@ var applyConfigCmd = &cobra.Command{
 			}
 		}
 
-		if applyConfigCmdFlags.filename != "" {
+		switch {
+		case applyConfigCmdFlags.layers != "":
+			if applyConfigCmdFlags.filename != "" || applyConfigCmdFlags.interactive {
+				return fmt.Errorf("--layers can't be used with --file or --interactive")
+			}
+
+			if layers, e = configlayers.Load(applyConfigCmdFlags.layers); e != nil {
+				return e
+			}
+		case applyConfigCmdFlags.filename != "":
 			cfgBytes, e = ioutil.ReadFile(applyConfigCmdFlags.filename)
 			if e != nil {
 				return fmt.Errorf("failed to read configuration from %q: %w", applyConfigCmdFlags.filename, e)
@@ -63,10 +103,18 @@ var applyConfigCmd = &cobra.Command{
 			if len(cfgBytes) < 1 {
 				return fmt.Errorf("no configuration data read")
 			}
-		} else if !applyConfigCmdFlags.interactive {
+		case !applyConfigCmdFlags.interactive:
 			return fmt.Errorf("no filename supplied for configuration")
 		}
 
+		if applyConfigCmdFlags.rolling && (applyConfigCmdFlags.insecure || applyConfigCmdFlags.interactive) {
+			return fmt.Errorf("--rolling can't be used with --insecure or --interactive")
+		}
+
+		if applyConfigCmdFlags.healthCheck && !applyConfigCmdFlags.rolling {
+			return fmt.Errorf("--health-check requires --rolling")
+		}
+
 		withClient := func(f func(context.Context, *client.Client) error) error {
 			if applyConfigCmdFlags.insecure {
 				ctx := context.Background()
@@ -75,8 +123,14 @@ var applyConfigCmd = &cobra.Command{
 					return fmt.Errorf("insecure mode requires one and only one node, got %d", len(Nodes))
 				}
 
+				fingerprints, err := maintenanceFingerprints(Nodes[0])
+				if err != nil {
+					return err
+				}
+
 				c, err := client.New(ctx, client.WithTLSConfig(&tls.Config{
 					InsecureSkipVerify: true,
+					VerifyConnection:   x509.MatchSPKIFingerprints(fingerprints...),
 				}), client.WithEndpoints(Nodes...))
 				if err != nil {
 					return err
@@ -85,28 +139,6 @@ var applyConfigCmd = &cobra.Command{
 				//nolint:errcheck
 				defer c.Close()
 
-				tlsConfig := &tls.Config{
-					InsecureSkipVerify: true,
-				}
-
-				if len(applyConfigCmdFlags.certFingerprints) > 0 {
-					fingerprints := make([]x509.Fingerprint, len(applyConfigCmdFlags.certFingerprints))
-
-					for i, stringFingerprint := range applyConfigCmdFlags.certFingerprints {
-						fingerprints[i], err = x509.ParseFingerprint(stringFingerprint)
-						if err != nil {
-							return fmt.Errorf("error parsing certificate fingerprint %q: %v", stringFingerprint, err)
-						}
-					}
-
-					tlsConfig.VerifyConnection = x509.MatchSPKIFingerprints(fingerprints...)
-				}
-
-				c, err = client.New(ctx, client.WithTLSConfig(tlsConfig), client.WithEndpoints(Nodes...))
-				if err != nil {
-					return err
-				}
-
 				return f(ctx, c)
 			}
 
@@ -116,7 +148,7 @@ var applyConfigCmd = &cobra.Command{
 		return withClient(func(ctx context.Context, c *client.Client) error {
 			if applyConfigCmdFlags.interactive {
 				install := installer.NewInstaller()
-				node := Nodes[0]
+				node := targetNodes(ctx)[0]
 
 				if len(Endpoints) > 0 {
 					return WithClientNoNodes(func(bootstrapCtx context.Context, bootstrapClient *client.Client) error {
@@ -149,32 +181,215 @@ var applyConfigCmd = &cobra.Command{
 				return install.Run(conn)
 			}
 
-			resp, err := c.ApplyConfiguration(ctx, &machineapi.ApplyConfigurationRequest{
-				Data:      cfgBytes,
-				OnReboot:  applyConfigCmdFlags.onReboot,
-				Immediate: applyConfigCmdFlags.immediate,
-			})
-			for _, m := range resp.GetMessages() {
-				for _, w := range m.GetWarnings() {
-					cli.Warning("%s", w)
+			if applyConfigCmdFlags.rolling {
+				render := func(string) ([]byte, error) {
+					return cfgBytes, nil
 				}
+
+				if layers != nil {
+					render = layers.Render
+				}
+
+				return applyRolling(ctx, c, render)
 			}
-			if err != nil {
-				return fmt.Errorf("error applying new configuration: %s", err)
+
+			if layers != nil {
+				return applyLayeredConfig(ctx, c, layers)
 			}
 
-			return nil
+			return applyConfig(ctx, c, cfgBytes)
 		})
 	},
 }
 
+func applyConfig(ctx context.Context, c *client.Client, cfgBytes []byte) error {
+	resp, err := c.ApplyConfiguration(ctx, &machineapi.ApplyConfigurationRequest{
+		Data:             cfgBytes,
+		OnReboot:         applyConfigCmdFlags.onReboot,
+		Immediate:        applyConfigCmdFlags.immediate,
+		ExpectedChecksum: applyConfigCmdFlags.expectedChecksum,
+	})
+	for _, m := range resp.GetMessages() {
+		for _, w := range m.GetWarnings() {
+			cli.Warning("%s", w)
+		}
+	}
+
+	if err != nil {
+		if helpers.IsConfigConflict(err) {
+			return fmt.Errorf("error applying new configuration: %s (fetch the current config and try again)", status.Convert(err).Message())
+		}
+
+		return fmt.Errorf("error applying new configuration: %s", err)
+	}
+
+	return nil
+}
+
+// maintenanceFingerprints returns the certificate fingerprints accepted for the insecure connection to the node.
+//
+// If the fingerprints are not set with --cert-fingerprint, the fingerprint of the node certificate
+// is displayed for the operator to verify it against the one printed on the node console.
+// The check is skipped only if explicitly requested with --skip-fingerprint-check.
+func maintenanceFingerprints(node string) ([]x509.Fingerprint, error) {
+	if len(applyConfigCmdFlags.certFingerprints) > 0 && applyConfigCmdFlags.skipFingerprintCheck {
+		return nil, fmt.Errorf("--cert-fingerprint and --skip-fingerprint-check can't be used together")
+	}
+
+	if len(applyConfigCmdFlags.certFingerprints) > 0 {
+		fingerprints := make([]x509.Fingerprint, len(applyConfigCmdFlags.certFingerprints))
+
+		for i, stringFingerprint := range applyConfigCmdFlags.certFingerprints {
+			var err error
+
+			fingerprints[i], err = x509.ParseFingerprint(stringFingerprint)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing certificate fingerprint %q: %v", stringFingerprint, err)
+			}
+		}
+
+		return fingerprints, nil
+	}
+
+	fingerprint, err := serverFingerprint(node)
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Fprintf(os.Stderr, "node %s certificate fingerprint: %s\n", node, fingerprint)
+
+	if applyConfigCmdFlags.skipFingerprintCheck {
+		cli.Warning("certificate fingerprint is not verified")
+
+		return []x509.Fingerprint{fingerprint}, nil
+	}
+
+	if !isatty.IsTerminal(os.Stdin.Fd()) {
+		return nil, fmt.Errorf("certificate fingerprint of node %s can't be confirmed without a terminal: use --cert-fingerprint to verify it, or --skip-fingerprint-check to skip the check", node)
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+
+	for {
+		fmt.Fprint(os.Stderr, "Does it match the fingerprint printed on the node console? [y/n]: ")
+
+		response, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+
+		switch strings.ToLower(strings.TrimSpace(response)) {
+		case "yes", "y":
+			// pin the verified fingerprint, so that the config is sent to the same node
+			return []x509.Fingerprint{fingerprint}, nil
+		case "no", "n":
+			return nil, fmt.Errorf("certificate fingerprint of node %s was not confirmed", node)
+		}
+	}
+}
+
+// serverFingerprint connects to the maintenance service of the node and returns the fingerprint of its certificate.
+func serverFingerprint(node string) (x509.Fingerprint, error) {
+	conn, err := tls.Dial("tcp", net.JoinHostPort(node, strconv.Itoa(constants.ApidPort)), &tls.Config{
+		InsecureSkipVerify: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to node %s: %w", node, err)
+	}
+
+	//nolint:errcheck
+	defer conn.Close()
+
+	peerCertificates := conn.ConnectionState().PeerCertificates
+	if len(peerCertificates) == 0 {
+		return nil, fmt.Errorf("node %s didn't present a certificate", node)
+	}
+
+	return x509.SPKIFingerprint(peerCertificates[0]), nil
+}
+
+// targetNodes returns the list of nodes the config is applied to.
+//
+// Insecure mode connects to the node from the flags directly, otherwise nodes come
+// from the request context (flags or talosconfig).
+func targetNodes(ctx context.Context) []string {
+	if applyConfigCmdFlags.insecure {
+		return Nodes
+	}
+
+	return client.NodesFromContext(ctx)
+}
+
+// applyLayeredConfig renders the layered config for each of the target nodes and applies it.
+func applyLayeredConfig(ctx context.Context, c *client.Client, layers *configlayers.Layers) error {
+	apply := func(nodeCtx context.Context, node string) error {
+		cfgBytes, err := layers.Render(node)
+		if err != nil {
+			return err
+		}
+
+		return applyConfig(nodeCtx, c, cfgBytes)
+	}
+
+	// insecure mode talks to the single node directly
+	if applyConfigCmdFlags.insecure {
+		return apply(ctx, Nodes[0])
+	}
+
+	if len(client.NodesFromContext(ctx)) < 1 {
+		return fmt.Errorf("nodes are not set for the command")
+	}
+
+	return client.ForEachNode(ctx, apply)
+}
+
+// applyRolling applies the config to the nodes one by one.
+//
+// The config for each node is rendered with the render function.
+func applyRolling(ctx context.Context, c *client.Client, render func(node string) ([]byte, error)) error {
+	rollout := rolling.Rollout{
+		Apply: func(ctx context.Context, node string) error {
+			cfgBytes, err := render(node)
+			if err != nil {
+				return err
+			}
+
+			return applyConfig(client.WithNodes(ctx, node), c, cfgBytes)
+		},
+		Timeout:  applyConfigCmdFlags.timeout,
+		Interval: 5 * time.Second,
+		Out:      os.Stdout,
+	}
+
+	if applyConfigCmdFlags.healthCheck {
+		rollout.Healthy = func(ctx context.Context, node string) error {
+			return checkNodeHealthy(client.WithNodes(ctx, node), c)
+		}
+
+		// config applied with neither --on-reboot nor --immediate reboots the node
+		if !applyConfigCmdFlags.onReboot && !applyConfigCmdFlags.immediate {
+			rollout.BootID = func(ctx context.Context, node string) (string, error) {
+				return readBootID(client.WithNodes(ctx, node), c)
+			}
+		}
+	}
+
+	return rollout.Run(ctx, client.NodesFromContext(ctx))
+}
+
 func init() {
 	applyConfigCmd.Flags().StringVarP(&applyConfigCmdFlags.filename, "file", "f", "", "the filename of the updated configuration")
+	applyConfigCmd.Flags().StringVar(&applyConfigCmdFlags.layers, "layers", "", "the filename of the layered configuration, the config is rendered for each of the nodes")
 	applyConfigCmd.Flags().BoolVarP(&applyConfigCmdFlags.insecure, "insecure", "i", false, "apply the config using the insecure (encrypted with no auth) maintenance service")
-	applyConfigCmd.Flags().StringSliceVar(&applyConfigCmdFlags.certFingerprints, "cert-fingerprint", nil, "list of server certificate fingeprints to accept (defaults to no check)")
+	applyConfigCmd.Flags().StringSliceVar(&applyConfigCmdFlags.certFingerprints, "cert-fingerprint", nil, "list of server certificate fingeprints to accept (defaults to the interactive check of the node certificate fingerprint)")
+	applyConfigCmd.Flags().BoolVar(&applyConfigCmdFlags.skipFingerprintCheck, "skip-fingerprint-check", false, "don't verify the server certificate fingerprint in insecure mode")
 	applyConfigCmd.Flags().BoolVar(&applyConfigCmdFlags.interactive, "interactive", false, "apply the config using text based interactive mode")
 	applyConfigCmd.Flags().BoolVar(&applyConfigCmdFlags.onReboot, "on-reboot", false, "apply the config on reboot")
+	applyConfigCmd.Flags().StringVar(&applyConfigCmdFlags.expectedChecksum, "expected-checksum", "", "apply the config only if the checksum of the active (or staged) config matches (see 'talosctl get driftstatuses')")
 	applyConfigCmd.Flags().BoolVar(&applyConfigCmdFlags.immediate, "immediate", false, "apply the config immediately (without a reboot)")
+	applyConfigCmd.Flags().BoolVar(&applyConfigCmdFlags.rolling, "rolling", false, "apply the config to the nodes one by one")
+	applyConfigCmd.Flags().BoolVar(&applyConfigCmdFlags.healthCheck, "health-check", false, "wait for the node to become healthy before proceeding to the next node (requires --rolling)")
+	applyConfigCmd.Flags().DurationVar(&applyConfigCmdFlags.timeout, "timeout", 30*time.Minute, "time to wait for each node to become healthy")
 
 	// deprecated, to be removed in 0.10
 	applyConfigCmd.Flags().BoolVar(&applyConfigCmdFlags.onReboot, "no-reboot", false, "apply the config only after the reboot")
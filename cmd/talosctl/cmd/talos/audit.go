@@ -0,0 +1,96 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package talos
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+
+	"github.com/talos-systems/talos/pkg/cli"
+	"github.com/talos-systems/talos/pkg/machinery/client"
+)
+
+var auditCmdFlags struct {
+	tailEntries int32
+	verbose     bool
+}
+
+// auditCmd represents the audit command.
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Show the audit log of the machine API calls",
+	Long: `Show the audit log of the mutating machine API calls (apply config, reboot, upgrade, etc.):
+when the call was made, by which client and from which address, and whether it succeeded.
+
+Client is identified by the subject of the client certificate.
+The log is kept in the STATE partition, only the most recent entries are kept.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if auditCmdFlags.tailEntries < 0 {
+			return fmt.Errorf("number of entries should not be negative")
+		}
+
+		return WithClient(func(ctx context.Context, c *client.Client) error {
+			var remotePeer peer.Peer
+
+			resp, err := c.AuditLog(ctx, auditCmdFlags.tailEntries, grpc.Peer(&remotePeer))
+			if err != nil {
+				if resp == nil {
+					return fmt.Errorf("error reading audit log: %w", err)
+				}
+
+				cli.Warning("%s", err)
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+
+			if auditCmdFlags.verbose {
+				fmt.Fprintln(w, "NODE\tTIME\tCLIENT\tPEER\tMETHOD\tERROR\tREQUEST")
+			} else {
+				fmt.Fprintln(w, "NODE\tTIME\tCLIENT\tPEER\tMETHOD\tERROR")
+			}
+
+			defaultNode := client.AddrFromPeer(&remotePeer)
+
+			for _, msg := range resp.Messages {
+				node := defaultNode
+
+				if msg.Metadata != nil {
+					node = msg.Metadata.Hostname
+				}
+
+				for _, entry := range msg.Entries {
+					errorMessage := entry.Error
+					if errorMessage == "" {
+						errorMessage = "-"
+					}
+
+					fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s", node, entry.Time.AsTime().Local().Format(time.RFC3339), entry.Client, entry.Peer, entry.Method, errorMessage)
+
+					if auditCmdFlags.verbose {
+						fmt.Fprintf(w, "\t%s", entry.Request)
+					}
+
+					fmt.Fprintln(w)
+				}
+			}
+
+			return w.Flush()
+		})
+	},
+}
+
+func init() {
+	auditCmd.Flags().Int32Var(&auditCmdFlags.tailEntries, "tail", 0, "number of the most recent entries to show (all entries if not set)")
+	auditCmd.Flags().BoolVarP(&auditCmdFlags.verbose, "verbose", "v", false, "show the request parameters")
+	addCommand(auditCmd)
+}
@@ -0,0 +1,132 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package talos
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+
+	"github.com/talos-systems/talos/pkg/cli"
+	machineapi "github.com/talos-systems/talos/pkg/machinery/api/machine"
+	"github.com/talos-systems/talos/pkg/machinery/client"
+)
+
+var cgroupsCmdFlags struct {
+	depth int
+}
+
+// cgroupsCmd represents the cgroups command.
+var cgroupsCmd = &cobra.Command{
+	Use:   "cgroups [<path>]",
+	Short: "Show cgroups resource usage and limits",
+	Long: `Show CPU, memory and IO usage and limits of the cgroups as a tree.
+
+Path is relative to the cgroup hierarchy root (e.g. '/kubepods'), by default whole hierarchy is displayed.
+CPU-LIMIT is the CPU bandwidth limit in CPU cores, CPU-WEIGHT is cpu.weight (cgroups v2) or cpu.shares (cgroups v1).`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return WithClient(func(ctx context.Context, c *client.Client) error {
+			req := &machineapi.CgroupsRequest{
+				MaxDepth: uint32(cgroupsCmdFlags.depth),
+			}
+
+			if len(args) > 0 {
+				req.Path = args[0]
+			}
+
+			var remotePeer peer.Peer
+
+			resp, err := c.Cgroups(ctx, req, grpc.Peer(&remotePeer))
+			if err != nil {
+				if resp == nil {
+					return fmt.Errorf("error getting cgroups: %w", err)
+				}
+
+				cli.Warning("%s", err)
+			}
+
+			return cgroupsRender(&remotePeer, resp)
+		})
+	},
+}
+
+func cgroupsRender(remotePeer *peer.Peer, resp *machineapi.CgroupsResponse) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+
+	fmt.Fprintln(w, "NODE\tNAME\tPROCS\tCPU-TIME\tCPU-LIMIT\tCPU-WEIGHT\tMEMORY\tMEMORY-LIMIT\tIO-READ\tIO-WRITE")
+
+	defaultNode := client.AddrFromPeer(remotePeer)
+
+	for _, msg := range resp.Messages {
+		node := defaultNode
+
+		if msg.Metadata != nil {
+			node = msg.Metadata.Hostname
+		}
+
+		if len(msg.Cgroups) == 0 {
+			continue
+		}
+
+		base := msg.Cgroups[0].Path
+
+		for _, cgroup := range msg.Cgroups {
+			name := cgroup.Path
+
+			if depth := cgroupDepth(base, cgroup.Path); depth > 0 {
+				name = strings.Repeat("  ", depth-1) + `\_ ` + path.Base(cgroup.Path)
+			}
+
+			cpuLimit := "max"
+			if cgroup.CpuQuota > 0 && cgroup.CpuPeriod > 0 {
+				cpuLimit = fmt.Sprintf("%.2f", float64(cgroup.CpuQuota)/float64(cgroup.CpuPeriod))
+			}
+
+			memoryLimit := "max"
+			if cgroup.MemoryLimit > 0 {
+				memoryLimit = humanize.IBytes(cgroup.MemoryLimit)
+			}
+
+			fmt.Fprintf(w, "%s\t%s\t%d\t%.2f\t%s\t%d\t%s\t%s\t%s\t%s\n",
+				node,
+				name,
+				cgroup.Processes,
+				float64(cgroup.CpuUsage)/1e9,
+				cpuLimit,
+				cgroup.CpuWeight,
+				humanize.IBytes(cgroup.MemoryUsage),
+				memoryLimit,
+				humanize.IBytes(cgroup.IoReadBytes),
+				humanize.IBytes(cgroup.IoWriteBytes),
+			)
+		}
+	}
+
+	return w.Flush()
+}
+
+// cgroupDepth returns the depth of the cgroup path relative to the base path.
+func cgroupDepth(base, p string) int {
+	rel := strings.Trim(strings.TrimPrefix(p, base), "/")
+	if rel == "" {
+		return 0
+	}
+
+	return strings.Count(rel, "/") + 1
+}
+
+func init() {
+	cgroupsCmd.Flags().IntVarP(&cgroupsCmdFlags.depth, "depth", "d", 0, "maximum depth of the cgroups to display (0 means no limit)")
+	addCommand(cgroupsCmd)
+}
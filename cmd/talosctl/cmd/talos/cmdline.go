@@ -0,0 +1,74 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package talos
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+
+	"github.com/talos-systems/talos/pkg/cli"
+	machineapi "github.com/talos-systems/talos/pkg/machinery/api/machine"
+	"github.com/talos-systems/talos/pkg/machinery/client"
+)
+
+// cmdlineCmd represents the cmdline command.
+var cmdlineCmd = &cobra.Command{
+	Use:   "cmdline",
+	Short: "Show kernel command line",
+	Long: `Show the kernel command line of the running kernel (CURRENT) and the one configured for the next boot (NEXT).
+
+Kernel command line for the next boot is not shown if Talos is not installed.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return WithClient(func(ctx context.Context, c *client.Client) error {
+			var remotePeer peer.Peer
+
+			resp, err := c.KernelCmdline(ctx, grpc.Peer(&remotePeer))
+			if err != nil {
+				if resp == nil {
+					return fmt.Errorf("error getting kernel command line: %w", err)
+				}
+
+				cli.Warning("%s", err)
+			}
+
+			return cmdlineRender(&remotePeer, resp)
+		})
+	},
+}
+
+func cmdlineRender(remotePeer *peer.Peer, resp *machineapi.KernelCmdlineResponse) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+
+	fmt.Fprintln(w, "NODE\tBOOT\tCMDLINE")
+
+	defaultNode := client.AddrFromPeer(remotePeer)
+
+	for _, msg := range resp.Messages {
+		node := defaultNode
+
+		if msg.Metadata != nil {
+			node = msg.Metadata.Hostname
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\n", node, "CURRENT", msg.Current)
+
+		if msg.Next != "" {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", node, "NEXT", msg.Next)
+		}
+	}
+
+	return w.Flush()
+}
+
+func init() {
+	addCommand(cmdlineCmd)
+}
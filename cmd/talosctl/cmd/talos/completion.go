@@ -0,0 +1,201 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package talos
+
+import (
+	"context"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/talos-systems/os-runtime/pkg/resource"
+	"github.com/talos-systems/os-runtime/pkg/resource/meta"
+
+	"github.com/talos-systems/talos/pkg/machinery/client"
+	"github.com/talos-systems/talos/pkg/machinery/client/config"
+	"github.com/talos-systems/talos/pkg/resources/cluster"
+)
+
+// completionTimeout limits the time spent in the live API lookups for the shell completion.
+const completionTimeout = 3 * time.Second
+
+// withCompletionClient runs the API lookup for the shell completion.
+//
+// Completion should never fail loudly, so the errors are ignored: the lookup returns
+// whatever was collected before the error.
+func withCompletionClient(action func(context.Context, *client.Client) error) {
+	//nolint:errcheck
+	withClientNoNodes(Cmdcontext, func(ctx context.Context, c *client.Client) error {
+		ctx, cancel := context.WithTimeout(ctx, completionTimeout)
+		defer cancel()
+
+		if len(Nodes) > 0 {
+			ctx = client.WithNodes(ctx, Nodes...)
+		}
+
+		return action(ctx, c)
+	})
+}
+
+// completionResult builds the sorted list of unique completions matching the prefix.
+func completionResult(toComplete string, candidates []string) ([]string, cobra.ShellCompDirective) {
+	seen := map[string]struct{}{}
+	result := []string{}
+
+	for _, candidate := range candidates {
+		if candidate == "" || !strings.HasPrefix(candidate, toComplete) {
+			continue
+		}
+
+		if _, ok := seen[candidate]; ok {
+			continue
+		}
+
+		seen[candidate] = struct{}{}
+
+		result = append(result, candidate)
+	}
+
+	sort.Strings(result)
+
+	return result, cobra.ShellCompDirectiveNoFileComp
+}
+
+// CompleteContexts completes the context names from the Talos configuration.
+func CompleteContexts(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	cfg, err := config.Open(Talosconfig)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	contexts := make([]string, 0, len(cfg.Contexts))
+
+	for contextName := range cfg.Contexts {
+		contexts = append(contexts, contextName)
+	}
+
+	return completionResult(toComplete, contexts)
+}
+
+// CompleteNodes completes the node names: the nodes from the Talos configuration context
+// and the hostnames and addresses of the cluster members discovered by the nodes.
+func CompleteNodes(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	var nodes []string
+
+	if cfg, err := config.Open(Talosconfig); err == nil {
+		contextName := Cmdcontext
+		if contextName == "" {
+			contextName = cfg.Context
+		}
+
+		if configContext, ok := cfg.Contexts[contextName]; ok {
+			nodes = append(nodes, configContext.Nodes...)
+		}
+	}
+
+	withCompletionClient(func(ctx context.Context, c *client.Client) error {
+		return forEachCompletionResource(ctx, c, cluster.NamespaceName, cluster.MemberType, func(r resource.Resource) {
+			spec, ok := r.(*resource.Any).Value().(map[string]interface{})
+			if !ok {
+				return
+			}
+
+			if hostname, ok := spec["hostname"].(string); ok {
+				nodes = append(nodes, hostname)
+			}
+
+			addresses, _ := spec["addresses"].([]interface{}) //nolint:errcheck
+
+			for _, address := range addresses {
+				if address, ok := address.(string); ok {
+					nodes = append(nodes, address)
+				}
+			}
+		})
+	})
+
+	return completionResult(toComplete, nodes)
+}
+
+// completeResourceTypes completes the resource types and aliases registered on the node.
+func completeResourceTypes(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var types []string
+
+	withCompletionClient(func(ctx context.Context, c *client.Client) error {
+		return forEachCompletionResource(ctx, c, meta.NamespaceName, meta.ResourceDefinitionType, func(r resource.Resource) {
+			types = append(types, strings.ToLower(r.Metadata().ID()))
+
+			spec, ok := r.(*resource.Any).Value().(map[string]interface{})
+			if !ok {
+				return
+			}
+
+			aliases, _ := spec["aliases"].([]interface{}) //nolint:errcheck
+
+			for _, alias := range aliases {
+				if alias, ok := alias.(string); ok {
+					types = append(types, alias)
+				}
+			}
+		})
+	})
+
+	return completionResult(toComplete, types)
+}
+
+// completeServiceIDs completes the IDs of the services running on the nodes.
+func completeServiceIDs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	// Kubernetes containers are not services
+	if len(args) > 0 || kubernetes {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var services []string
+
+	withCompletionClient(func(ctx context.Context, c *client.Client) error {
+		resp, err := c.ServiceList(ctx)
+		if resp == nil {
+			return err
+		}
+
+		for _, msg := range resp.Messages {
+			for _, svc := range msg.Services {
+				services = append(services, svc.Id)
+			}
+		}
+
+		return nil
+	})
+
+	return completionResult(toComplete, services)
+}
+
+func forEachCompletionResource(ctx context.Context, c *client.Client, namespace, resourceType string, callback func(r resource.Resource)) error {
+	listClient, err := c.Resources.List(ctx, namespace, resourceType)
+	if err != nil {
+		return err
+	}
+
+	for {
+		msg, err := listClient.Recv()
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+
+			return err
+		}
+
+		if msg.Resource != nil {
+			callback(msg.Resource)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package talos
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+
+	"github.com/talos-systems/talos/internal/pkg/revocation"
+	"github.com/talos-systems/talos/pkg/cli"
+	"github.com/talos-systems/talos/pkg/machinery/client"
+	clientconfig "github.com/talos-systems/talos/pkg/machinery/client/config"
+)
+
+var configRevokeCmdFlags struct {
+	fromContext string
+}
+
+// configRevokeCmd represents the config revoke command.
+var configRevokeCmd = &cobra.Command{
+	Use:   "revoke [<serial>]",
+	Short: "Revoke a client certificate",
+	Long: `Revoke a client certificate, so that it can't be used to access the machine API anymore.
+
+Certificate is added to the revocation list maintained by trustd, apid rejects the revoked certificates
+once it refreshes the revocation lists (every minute). Revocation list is kept on each control plane node,
+so the certificate should be revoked on all control plane nodes:
+
+    talosctl -n <control plane nodes> config revoke <serial>
+
+Serial number is either decimal, hex with the '0x' prefix or colon-separated hex (as printed by 'openssl x509 -text').
+With '--from-context', the certificate of the talosconfig context is revoked.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var (
+			serial *big.Int
+			err    error
+		)
+
+		switch {
+		case len(args) == 1 && configRevokeCmdFlags.fromContext == "":
+			serial, err = revocation.ParseSerialNumber(args[0])
+		case len(args) == 0 && configRevokeCmdFlags.fromContext != "":
+			serial, err = contextCertificateSerial(configRevokeCmdFlags.fromContext)
+		default:
+			return fmt.Errorf("either serial number or --from-context should be specified")
+		}
+
+		if err != nil {
+			return err
+		}
+
+		return WithClient(func(ctx context.Context, c *client.Client) error {
+			var remotePeer peer.Peer
+
+			resp, err := c.RevokeCertificate(ctx, serial.String(), grpc.Peer(&remotePeer))
+			if err != nil {
+				if resp == nil {
+					return fmt.Errorf("error revoking certificate: %w", err)
+				}
+
+				cli.Warning("%s", err)
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+			fmt.Fprintln(w, "NODE\tREVOKED SERIAL")
+
+			defaultNode := client.AddrFromPeer(&remotePeer)
+
+			for _, msg := range resp.Messages {
+				node := defaultNode
+
+				if msg.Metadata != nil {
+					node = msg.Metadata.Hostname
+				}
+
+				fmt.Fprintf(w, "%s\t%s\n", node, serial)
+			}
+
+			return w.Flush()
+		})
+	},
+}
+
+// contextCertificateSerial returns the serial number of the client certificate of the talosconfig context.
+func contextCertificateSerial(contextName string) (*big.Int, error) {
+	c, err := clientconfig.Open(Talosconfig)
+	if err != nil {
+		return nil, fmt.Errorf("error reading config: %w", err)
+	}
+
+	configContext, ok := c.Contexts[contextName]
+	if !ok {
+		return nil, fmt.Errorf("context %q is not defined", contextName)
+	}
+
+	crtBytes, err := base64.StdEncoding.DecodeString(configContext.Crt)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding certificate: %w", err)
+	}
+
+	block, _ := pem.Decode(crtBytes)
+	if block == nil {
+		return nil, fmt.Errorf("error decoding certificate of context %q", contextName)
+	}
+
+	crt, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing certificate: %w", err)
+	}
+
+	return crt.SerialNumber, nil
+}
+
+func init() {
+	configRevokeCmd.Flags().StringVar(&configRevokeCmdFlags.fromContext, "from-context", "", "revoke the client certificate of the talosconfig context")
+	configCmd.AddCommand(configRevokeCmd)
+}
@@ -0,0 +1,114 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package talos
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/talos-systems/talos/pkg/cluster"
+	"github.com/talos-systems/talos/pkg/cluster/sonobuoy"
+	"github.com/talos-systems/talos/pkg/machinery/client"
+)
+
+// Conformance test modes.
+const (
+	conformanceModeQuick     = "quick"
+	conformanceModeCertified = "certified"
+)
+
+var conformanceKubernetesCmdFlags struct {
+	mode          string
+	resultsPath   string
+	forceEndpoint string
+	timeout       time.Duration
+}
+
+// conformanceCmd represents the conformance command.
+var conformanceCmd = &cobra.Command{
+	Use:   "conformance",
+	Short: "Run conformance tests",
+	Long:  ``,
+}
+
+// conformanceKubernetesCmd represents the conformance kubernetes command.
+var conformanceKubernetesCmd = &cobra.Command{
+	Use:     "kubernetes",
+	Aliases: []string{"k8s"},
+	Short:   "Run Kubernetes conformance tests",
+	Long: `Run Kubernetes conformance tests against the cluster with sonobuoy.
+
+In the 'quick' mode, a smoke test suite is run: test workloads are deployed, and DNS and service connectivity
+are checked. Persistent volume provisioning is checked as well if the cluster has the default storage class.
+In the 'certified' mode, the full Kubernetes conformance suite is run (this takes a couple of hours).
+
+Command exits with non-zero status if any of the tests fails, so it can be used as a CI gate.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return WithClient(func(ctx context.Context, c *client.Client) error {
+			clientProvider := &cluster.ConfigClientProvider{
+				DefaultClient: c,
+			}
+			defer clientProvider.Close() //nolint:errcheck
+
+			state := struct {
+				cluster.K8sProvider
+			}{
+				K8sProvider: &cluster.KubernetesClient{
+					ClientProvider: clientProvider,
+					ForceEndpoint:  conformanceKubernetesCmdFlags.forceEndpoint,
+				},
+			}
+
+			var options *sonobuoy.Options
+
+			switch conformanceKubernetesCmdFlags.mode {
+			case conformanceModeQuick:
+				withStorage, err := sonobuoy.HasDefaultStorageClass(ctx, &state)
+				if err != nil {
+					return err
+				}
+
+				if !withStorage {
+					fmt.Fprintln(os.Stderr, "no default storage class found, skipping storage tests")
+				}
+
+				options = sonobuoy.QuickOptions(withStorage)
+			case conformanceModeCertified:
+				options = sonobuoy.CertifiedOptions()
+			default:
+				return fmt.Errorf("unsupported conformance mode %q", conformanceKubernetesCmdFlags.mode)
+			}
+
+			kubernetesVersion, err := sonobuoy.DetectKubernetesVersion(ctx, &state)
+			if err != nil {
+				return err
+			}
+
+			options.KubernetesVersion = kubernetesVersion
+			options.ResultsPath = conformanceKubernetesCmdFlags.resultsPath
+			options.UseSpinner = true
+
+			if conformanceKubernetesCmdFlags.timeout != 0 {
+				options.RunTimeout = conformanceKubernetesCmdFlags.timeout
+			}
+
+			return sonobuoy.Run(ctx, &state, options)
+		})
+	},
+}
+
+func init() {
+	conformanceKubernetesCmd.Flags().StringVar(&conformanceKubernetesCmdFlags.mode, "mode", conformanceModeQuick, "conformance test mode: [quick, certified]")
+	conformanceKubernetesCmd.Flags().StringVar(&conformanceKubernetesCmdFlags.resultsPath, "results-path", "", "directory to save the sonobuoy results tarball to")
+	conformanceKubernetesCmd.Flags().StringVar(&conformanceKubernetesCmdFlags.forceEndpoint, "k8s-endpoint", "", "use endpoint instead of kubeconfig default")
+	conformanceKubernetesCmd.Flags().DurationVar(&conformanceKubernetesCmdFlags.timeout, "timeout", 0, "test run timeout (defaults to the mode default)")
+	conformanceCmd.AddCommand(conformanceKubernetesCmd)
+	addCommand(conformanceCmd)
+}
@@ -15,72 +15,145 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/talos-systems/talos/cmd/talosctl/pkg/talos/helpers"
+	"github.com/talos-systems/talos/pkg/archiver"
 	"github.com/talos-systems/talos/pkg/machinery/client"
 )
 
 // cpCmd represents the cp command.
 var cpCmd = &cobra.Command{
-	Use:     "copy <src-path> -|<local-path>",
+	Use:     "copy <src-path> <dest-path>",
 	Aliases: []string{"cp"},
-	Short:   "Copy data out from the node",
-	Long: `Creates an .tar.gz archive at the node starting at <src-path> and
+	Short:   "Copy data out from the node or upload data to the node",
+	Long: `Copies data between the node and the local filesystem.
+
+When copying out of the node, <src-path> is a path on the node and <dest-path> is either
+'-' or <local-path>. Talos creates an .tar.gz archive at the node starting at <src-path> and
 streams it back to the client.
 
 If '-' is given for <local-path>, archive is written to stdout.
 Otherwise archive is extracted to <local-path> which should be an empty directory or
 talosctl creates a directory if <local-path> doesn't exist. Command doesn't preserve
 ownership and access mode for the files in extract mode, while  streamed .tar archive
-captures ownership and permission bits.`,
+captures ownership and permission bits.
+
+If <dest-path> is given as '<node>:<dest-path>' (or ':<dest-path>' to use the default node),
+<src-path> (a local file or a directory) is archived and uploaded to the node, and extracted
+under <dest-path>. If '-' is given as the source, .tar.gz archive is read from stdin.
+Destination path should be under /var. Data is always transferred compressed with gzip.
+
+Source path might be given as '<node>:<src-path>' as well, which overrides the
+node(s) set with --nodes flag.`,
+	Example: `  talosctl cp 172.20.0.2:/var/log/containers ./containers
+  talosctl cp ./tools 172.20.0.2:/var/tools`,
 	Args: cobra.ExactArgs(2),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return WithClient(func(ctx context.Context, c *client.Client) error {
-			if err := helpers.FailIfMultiNodes(ctx, "copy"); err != nil {
-				return err
-			}
+			if node, remotePath, ok := helpers.ParseRemotePath(args[1]); ok {
+				if node != "" {
+					ctx = client.WithNodes(ctx, node)
+				}
 
-			r, errCh, err := c.Copy(ctx, args[0])
-			if err != nil {
-				return fmt.Errorf("error copying: %w", err)
+				return copyIn(ctx, c, args[0], remotePath)
 			}
 
-			var wg sync.WaitGroup
+			remotePath := args[0]
 
-			wg.Add(1)
-			go func() {
-				defer wg.Done()
-				for err := range errCh {
-					fmt.Fprintln(os.Stderr, err.Error())
+			if node, path, ok := helpers.ParseRemotePath(args[0]); ok {
+				if node != "" {
+					ctx = client.WithNodes(ctx, node)
 				}
-			}()
 
-			defer wg.Wait()
+				remotePath = path
+			}
+
+			return copyOut(ctx, c, remotePath, args[1])
+		})
+	},
+}
+
+func copyOut(ctx context.Context, c *client.Client, remotePath, localPath string) error {
+	if err := helpers.FailIfMultiNodes(ctx, "copy"); err != nil {
+		return err
+	}
 
-			localPath := args[1]
+	r, errCh, err := c.Copy(ctx, remotePath)
+	if err != nil {
+		return fmt.Errorf("error copying: %w", err)
+	}
 
-			if localPath == "-" {
-				_, err = io.Copy(os.Stdout, r)
+	var wg sync.WaitGroup
 
-				return err
-			}
+	wg.Add(1)
 
-			localPath = filepath.Clean(localPath)
+	go func() {
+		defer wg.Done()
 
-			fi, err := os.Stat(localPath)
-			if err == nil && !fi.IsDir() {
-				return fmt.Errorf("local path %q should be a directory", args[1])
-			}
-			if err != nil {
-				if !os.IsNotExist(err) {
-					return fmt.Errorf("failed to stat local path: %w", err)
-				}
-				if err = os.MkdirAll(localPath, 0o777); err != nil {
-					return fmt.Errorf("error creating local path %q: %w", localPath, err)
-				}
-			}
+		for err := range errCh {
+			fmt.Fprintln(os.Stderr, err.Error())
+		}
+	}()
 
-			return helpers.ExtractTarGz(localPath, r)
-		})
-	},
+	defer wg.Wait()
+
+	if localPath == "-" {
+		_, err = io.Copy(os.Stdout, r)
+
+		return err
+	}
+
+	localPath = filepath.Clean(localPath)
+
+	fi, err := os.Stat(localPath)
+	if err == nil && !fi.IsDir() {
+		return fmt.Errorf("local path %q should be a directory", localPath)
+	}
+
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return fmt.Errorf("failed to stat local path: %w", err)
+		}
+
+		if err = os.MkdirAll(localPath, 0o777); err != nil {
+			return fmt.Errorf("error creating local path %q: %w", localPath, err)
+		}
+	}
+
+	return helpers.ExtractTarGz(localPath, r)
+}
+
+func copyIn(ctx context.Context, c *client.Client, localPath, remotePath string) error {
+	if err := helpers.FailIfMultiNodes(ctx, "copy"); err != nil {
+		return err
+	}
+
+	var r io.Reader
+
+	if localPath == "-" {
+		r = os.Stdin
+	} else {
+		localPath = filepath.Clean(localPath)
+
+		if _, err := os.Stat(localPath); err != nil {
+			return fmt.Errorf("failed to stat local path: %w", err)
+		}
+
+		pr, pw := io.Pipe()
+
+		go func() {
+			pw.CloseWithError(archiver.TarGz(ctx, localPath, pw)) //nolint:errcheck
+		}()
+
+		//nolint:errcheck
+		defer pr.Close()
+
+		r = pr
+	}
+
+	if _, err := c.CopyIn(ctx, remotePath, r); err != nil {
+		return fmt.Errorf("error copying: %w", err)
+	}
+
+	return nil
 }
 
 func init() {
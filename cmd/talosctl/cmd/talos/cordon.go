@@ -0,0 +1,112 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package talos
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+
+	"github.com/talos-systems/talos/pkg/cli"
+	"github.com/talos-systems/talos/pkg/machinery/client"
+)
+
+// cordonCmd represents the cordon command.
+var cordonCmd = &cobra.Command{
+	Use:   "cordon",
+	Short: "Mark the Kubernetes node as unschedulable",
+	Long: `Mark the Kubernetes node of the Talos node as unschedulable.
+
+Node is cordoned using the kubelet credentials of the node, and it's uncordoned by Talos once it boots back
+after the reboot or the upgrade.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return WithClient(func(ctx context.Context, c *client.Client) error {
+			var remotePeer peer.Peer
+
+			resp, err := c.Cordon(ctx, grpc.Peer(&remotePeer))
+			if err != nil {
+				if resp == nil {
+					return fmt.Errorf("error cordoning node: %w", err)
+				}
+
+				cli.Warning("%s", err)
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+			fmt.Fprintln(w, "NODE\tKUBERNETES NODE")
+
+			defaultNode := client.AddrFromPeer(&remotePeer)
+
+			for _, msg := range resp.Messages {
+				node := defaultNode
+
+				if msg.Metadata != nil {
+					node = msg.Metadata.Hostname
+				}
+
+				fmt.Fprintf(w, "%s\t%s\n", node, msg.NodeName)
+			}
+
+			return w.Flush()
+		})
+	},
+}
+
+var uncordonCmdFlags struct {
+	force bool
+}
+
+// uncordonCmd represents the uncordon command.
+var uncordonCmd = &cobra.Command{
+	Use:   "uncordon",
+	Short: "Mark the Kubernetes node as schedulable",
+	Long: `Mark the Kubernetes node of the Talos node as schedulable.
+
+Only the nodes cordoned by Talos are uncordoned, unless '--force' is set.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return WithClient(func(ctx context.Context, c *client.Client) error {
+			var remotePeer peer.Peer
+
+			resp, err := c.Uncordon(ctx, uncordonCmdFlags.force, grpc.Peer(&remotePeer))
+			if err != nil {
+				if resp == nil {
+					return fmt.Errorf("error uncordoning node: %w", err)
+				}
+
+				cli.Warning("%s", err)
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+			fmt.Fprintln(w, "NODE\tKUBERNETES NODE")
+
+			defaultNode := client.AddrFromPeer(&remotePeer)
+
+			for _, msg := range resp.Messages {
+				node := defaultNode
+
+				if msg.Metadata != nil {
+					node = msg.Metadata.Hostname
+				}
+
+				fmt.Fprintf(w, "%s\t%s\n", node, msg.NodeName)
+			}
+
+			return w.Flush()
+		})
+	},
+}
+
+func init() {
+	uncordonCmd.Flags().BoolVar(&uncordonCmdFlags.force, "force", false, "uncordon the node even if it was not cordoned by Talos")
+	addCommand(cordonCmd)
+	addCommand(uncordonCmd)
+}
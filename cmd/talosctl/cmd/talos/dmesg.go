@@ -9,25 +9,43 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"time"
 
 	"github.com/spf13/cobra"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	machineapi "github.com/talos-systems/talos/pkg/machinery/api/machine"
 	"github.com/talos-systems/talos/pkg/machinery/client"
 )
 
-var dmesgTail bool
+var dmesgCmdFlags struct {
+	tail       bool
+	priority   string
+	facilities []string
+	lines      uint32
+	since      time.Duration
+}
 
 // dmesgCmd represents the dmesg command.
 var dmesgCmd = &cobra.Command{
 	Use:   "dmesg",
 	Short: "Retrieve kernel logs",
-	Long:  ``,
-	Args:  cobra.NoArgs,
+	Long: `Retrieve kernel logs of the node.
+
+Messages might be filtered by the priority (e.g. '--priority warning' shows warnings and more severe messages),
+by the facility and by the time they were logged.`,
+	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return WithClient(func(ctx context.Context, c *client.Client) error {
-			stream, err := c.Dmesg(ctx, follow, dmesgTail)
+			stream, err := c.MachineClient.Dmesg(ctx, &machineapi.DmesgRequest{
+				Follow:       follow,
+				Tail:         dmesgCmdFlags.tail,
+				Priority:     dmesgCmdFlags.priority,
+				Facilities:   dmesgCmdFlags.facilities,
+				TailLines:    dmesgCmdFlags.lines,
+				SinceSeconds: uint32((dmesgCmdFlags.since + time.Second - 1) / time.Second),
+			})
 			if err != nil {
 				return fmt.Errorf("error getting dmesg: %w", err)
 			}
@@ -66,5 +84,9 @@ var dmesgCmd = &cobra.Command{
 func init() {
 	addCommand(dmesgCmd)
 	dmesgCmd.Flags().BoolVarP(&follow, "follow", "f", false, "specify if the kernel log should be streamed")
-	dmesgCmd.Flags().BoolVarP(&dmesgTail, "tail", "", false, "specify if only new messages should be sent (makes sense only when combined with --follow)")
+	dmesgCmd.Flags().BoolVarP(&dmesgCmdFlags.tail, "tail", "", false, "specify if only new messages should be sent (makes sense only when combined with --follow)")
+	dmesgCmd.Flags().StringVar(&dmesgCmdFlags.priority, "priority", "", "show only messages with this priority or more severe (emerg, alert, crit, err, warning, notice, info, debug)")
+	dmesgCmd.Flags().StringSliceVar(&dmesgCmdFlags.facilities, "facility", nil, "show only messages of the facilities (e.g. kern, daemon)")
+	dmesgCmd.Flags().Uint32Var(&dmesgCmdFlags.lines, "lines", 0, "number of the last messages to show (default is to show all messages since boot)")
+	dmesgCmd.Flags().DurationVar(&dmesgCmdFlags.since, "since", 0, "show only messages logged within the duration (e.g. 10m)")
 }
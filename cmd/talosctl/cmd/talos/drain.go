@@ -0,0 +1,61 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package talos
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/pflag"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/talos-systems/talos/pkg/cluster"
+	"github.com/talos-systems/talos/pkg/machinery/client"
+)
+
+// drainFlags are the flags of the commands which drain the nodes before acting on them.
+type drainFlags struct {
+	drain      bool
+	kubeconfig string
+}
+
+func (f *drainFlags) addFlags(flags *pflag.FlagSet, action string) {
+	flags.BoolVar(&f.drain, "drain", false, fmt.Sprintf("cordon the node and evict the pods respecting PodDisruptionBudgets before %s it", action))
+	flags.StringVar(&f.kubeconfig, "drain-kubeconfig", "", "the kubeconfig used to drain the nodes (defaults to the admin kubeconfig fetched from the control plane node via Talos API)")
+}
+
+// forEachDrainedNode drains the nodes one by one, running the action on each of the nodes once it's drained.
+//
+// Context passed to the action targets the single node.
+func forEachDrainedNode(ctx context.Context, c *client.Client, flags *drainFlags, action func(ctx context.Context) error) error {
+	clientProvider := &cluster.ConfigClientProvider{
+		DefaultClient: c,
+	}
+	defer clientProvider.Close() //nolint:errcheck
+
+	drainer := &cluster.Drainer{
+		K8sProvider: &cluster.KubernetesClient{
+			ClientProvider: clientProvider,
+		},
+		Kubeconfig: flags.kubeconfig,
+		Out:        os.Stdout,
+	}
+
+	// admin kubeconfig is fetched from the endpoint (control plane node), not from the target nodes
+	k8sCtx := metadata.NewOutgoingContext(ctx, metadata.MD{})
+
+	for _, node := range client.NodesFromContext(ctx) {
+		if err := drainer.Drain(k8sCtx, c, node); err != nil {
+			return fmt.Errorf("node %q: %w", node, err)
+		}
+
+		if err := action(client.WithNodes(ctx, node)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -13,6 +13,7 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
+	"google.golang.org/grpc/status"
 	"gopkg.in/yaml.v3"
 	cmdutil "k8s.io/kubectl/pkg/cmd/util"
 	"k8s.io/kubectl/pkg/cmd/util/editor"
@@ -62,6 +63,11 @@ or 'notepad' for Windows.`,
 					return nil
 				}
 
+				body, err := yaml.Marshal(msg.Resource.Spec())
+				if err != nil {
+					return err
+				}
+
 				for {
 					var (
 						buf bytes.Buffer
@@ -75,11 +81,16 @@ or 'notepad' for Windows.`,
 						id = metadata.ID()
 					}
 
+					checksum, err := helpers.MachineConfigChecksum(body)
+					if err != nil {
+						return err
+					}
+
 					if runtime.GOOS == "windows" {
 						w = crlf.NewCRLFWriter(w)
 					}
 
-					_, err := w.Write([]byte(
+					_, err = w.Write([]byte(
 						fmt.Sprintf(
 							"# Editing %s/%s at node %s\n", msg.Resource.Metadata().Type(), id, msg.Metadata.GetHostname(),
 						),
@@ -99,17 +110,12 @@ or 'notepad' for Windows.`,
 						}
 					}
 
-					body, err := yaml.Marshal(msg.Resource.Spec())
-					if err != nil {
-						return err
-					}
-
 					_, err = w.Write(body)
 					if err != nil {
 						return err
 					}
 
-					edited, _, err := edit.LaunchTempFile(fmt.Sprintf("%s-%s-edit-", resourceType, id), ".yaml", &buf)
+					edited, editedPath, err := edit.LaunchTempFile(fmt.Sprintf("%s-%s-edit-", resourceType, id), ".yaml", &buf)
 					if err != nil {
 						return err
 					}
@@ -132,13 +138,23 @@ or 'notepad' for Windows.`,
 					}
 
 					resp, err := c.ApplyConfiguration(parentCtx, &machine.ApplyConfigurationRequest{
-						Data:      edited,
-						Immediate: editCmdFlags.immediate,
-						OnReboot:  editCmdFlags.onReboot,
+						Data:             edited,
+						Immediate:        editCmdFlags.immediate,
+						OnReboot:         editCmdFlags.onReboot,
+						ExpectedChecksum: checksum,
 					})
 					if err != nil {
 						lastError = err.Error()
 
+						if helpers.IsConfigConflict(err) {
+							// reload the config modified by someone else, the edits are kept in the temporary file
+							lastError = fmt.Sprintf("%s\nThe config was reloaded, your changes were saved to %s", status.Convert(err).Message(), editedPath)
+
+							if body, err = helpers.MachineConfigBytes(parentCtx, c); err != nil {
+								return err
+							}
+						}
+
 						continue
 					}
 					for _, m := range resp.GetMessages() {
@@ -153,14 +169,12 @@ or 'notepad' for Windows.`,
 				return nil
 			}
 
-			for _, node := range Nodes {
-				nodeCtx := client.WithNodes(ctx, node)
-				if err := helpers.ForEachResource(nodeCtx, c, editFn, editCmdFlags.namespace, args...); err != nil {
-					return err
-				}
-			}
+			// editing is interactive, so nodes are processed one by one
+			return client.ForEachNodeSequential(ctx, func(nodeCtx context.Context, node string) error {
+				lastError = ""
 
-			return nil
+				return helpers.ForEachResource(nodeCtx, c, editFn, editCmdFlags.namespace, args...)
+			})
 		})
 	},
 }
@@ -81,6 +81,10 @@ var eventsCmd = &cobra.Command{
 						args = []interface{}{msg.GetTask(), msg.GetAction().String()}
 					case *machine.ServiceStateEvent:
 						args = []interface{}{msg.GetService(), fmt.Sprintf("%s: %s", msg.GetAction(), msg.GetMessage())}
+					case *machine.InstallPreflightEvent:
+						args = []interface{}{msg.GetDisk(), fmt.Sprintf("%s: %s", msg.GetCheck(), msg.GetMessage())}
+					case *machine.NodeDeletedEvent:
+						args = []interface{}{msg.GetNode(), "deleted"}
 					default:
 						// We haven't implemented the handling of this event yet.
 						continue
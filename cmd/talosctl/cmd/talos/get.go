@@ -32,10 +32,24 @@ var getCmd = &cobra.Command{
 	Use:     "get <type> [<id>]",
 	Aliases: []string{"g"},
 	Short:   "Get a specific resource or list of resources.",
-	Long:    ``,
-	Args:    cobra.RangeArgs(1, 2),
+	Long: `Get a specific resource or list of resources.
+
+Output modes:
+
+  table                 resource summary as defined by the resource definition (default)
+  yaml, json            full resource including metadata: owner, version, phase, last update time and finalizers
+  jsonpath=<template>   result of the JSONPath template for each resource, e.g. 'jsonpath={.spec.address}'`,
+	Args:              cobra.RangeArgs(1, 2),
+	ValidArgsFunction: completeResourceTypes,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return WithClient(func(ctx context.Context, c *client.Client) error {
+		withClient := WithClientAllContexts
+
+		if getCmdFlags.watch {
+			// watching is a long-running operation, so it can't be fanned out sequentially
+			withClient = WithClient
+		}
+
+		return withClient(func(ctx context.Context, c *client.Client) error {
 			out, err := output.NewWriter(getCmdFlags.output)
 			if err != nil {
 				return err
@@ -84,7 +98,7 @@ var getCmd = &cobra.Command{
 					}
 
 					if msg.Resource != nil {
-						if err := out.WriteResource(msg.Metadata.GetHostname(), msg.Resource, msg.EventType); err != nil {
+						if err := out.WriteResource(msg.Metadata.GetHostname(), msg.Resource, msg.Updated, msg.EventType); err != nil {
 							return err
 						}
 
@@ -107,7 +121,7 @@ var getCmd = &cobra.Command{
 				}
 
 				if msg.Resource != nil {
-					if err := out.WriteResource(msg.Metadata.GetHostname(), msg.Resource, 0); err != nil {
+					if err := out.WriteResource(msg.Metadata.GetHostname(), msg.Resource, msg.Updated, 0); err != nil {
 						return err
 					}
 				}
@@ -122,7 +136,7 @@ var getCmd = &cobra.Command{
 
 func init() {
 	getCmd.Flags().StringVar(&getCmdFlags.namespace, "namespace", "", "resource namespace (default is to use default namespace per resource)")
-	getCmd.Flags().StringVarP(&getCmdFlags.output, "output", "o", "table", "output mode (table, yaml)")
+	getCmd.Flags().StringVarP(&getCmdFlags.output, "output", "o", "table", "output mode (table, yaml, json, jsonpath=<template>)")
 	getCmd.Flags().BoolVarP(&getCmdFlags.watch, "watch", "w", false, "watch resource changes")
 	addCommand(getCmd)
 }
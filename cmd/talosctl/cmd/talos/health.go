@@ -88,7 +88,7 @@ var healthCmd = &cobra.Command{
 
 func runHealth() error {
 	if healthCmdFlags.runOnServer {
-		return WithClient(healthOnServer)
+		return WithClientAllContexts(healthOnServer)
 	}
 
 	return WithClientNoNodes(healthOnClient)
@@ -0,0 +1,158 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package talos
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/talos-systems/talos/pkg/cli"
+	"github.com/talos-systems/talos/pkg/machinery/api/common"
+	"github.com/talos-systems/talos/pkg/machinery/client"
+)
+
+var imageCmdFlags struct {
+	namespace string
+}
+
+// imageCmd represents the image command.
+var imageCmd = &cobra.Command{
+	Use:   "image",
+	Short: "Manage container images on the node",
+	Long: `Manage container images in the containerd namespaces of the node.
+
+Images in the 'cri' namespace are used by the Kubernetes pods, images in the 'system' namespace are used by the Talos system services.`,
+}
+
+// imageListCmd represents the image list command.
+var imageListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List images on the node",
+	Long:    ``,
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		namespace, err := imageNamespace()
+		if err != nil {
+			return err
+		}
+
+		return WithClient(func(ctx context.Context, c *client.Client) error {
+			stream, err := c.ImageList(ctx, namespace)
+			if err != nil {
+				return fmt.Errorf("error listing images: %w", err)
+			}
+
+			defaultNode := client.RemotePeer(stream.Context())
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+			fmt.Fprintln(w, "NODE\tIMAGE\tDIGEST\tSIZE\tCREATED")
+
+			for {
+				msg, err := stream.Recv()
+				if err != nil {
+					if err == io.EOF || status.Code(err) == codes.Canceled {
+						return w.Flush()
+					}
+
+					return fmt.Errorf("error streaming results: %w", err)
+				}
+
+				node := defaultNode
+
+				if msg.Metadata != nil {
+					node = msg.Metadata.Hostname
+				}
+
+				if msg.Metadata != nil && msg.Metadata.Error != "" {
+					fmt.Fprintf(os.Stderr, "%s: %s\n", node, msg.Metadata.Error)
+
+					continue
+				}
+
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+					node,
+					msg.Name,
+					msg.Digest,
+					humanize.Bytes(uint64(msg.Size)),
+					humanize.Time(msg.CreatedAt.AsTime()),
+				)
+			}
+		})
+	},
+}
+
+// imagePullCmd represents the image pull command.
+var imagePullCmd = &cobra.Command{
+	Use:   "pull <image>",
+	Short: "Pull an image to the node",
+	Long: `Pull an image to the node using the registry mirrors and authentication configured in the machine config.
+
+Pulling images in advance allows to pre-warm the nodes with large images and to verify the registry configuration.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		namespace, err := imageNamespace()
+		if err != nil {
+			return err
+		}
+
+		return WithClient(func(ctx context.Context, c *client.Client) error {
+			var remotePeer peer.Peer
+
+			resp, err := c.ImagePull(ctx, namespace, args[0], grpc.Peer(&remotePeer))
+			if err != nil {
+				if resp == nil {
+					return fmt.Errorf("error pulling image: %w", err)
+				}
+
+				cli.Warning("%s", err)
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+			fmt.Fprintln(w, "NODE\tIMAGE\tDIGEST")
+
+			defaultNode := client.AddrFromPeer(&remotePeer)
+
+			for _, msg := range resp.Messages {
+				node := defaultNode
+
+				if msg.Metadata != nil {
+					node = msg.Metadata.Hostname
+				}
+
+				fmt.Fprintf(w, "%s\t%s\t%s\n", node, msg.Name, msg.Digest)
+			}
+
+			return w.Flush()
+		})
+	},
+}
+
+func imageNamespace() (common.ContainerdNamespace, error) {
+	switch imageCmdFlags.namespace {
+	case "cri":
+		return common.ContainerdNamespace_NS_CRI, nil
+	case "system":
+		return common.ContainerdNamespace_NS_SYSTEM, nil
+	default:
+		return 0, fmt.Errorf("unsupported namespace %q, supported namespaces: cri, system", imageCmdFlags.namespace)
+	}
+}
+
+func init() {
+	imageCmd.PersistentFlags().StringVar(&imageCmdFlags.namespace, "namespace", "cri", "containerd namespace to use (cri or system)")
+	imageCmd.AddCommand(imageListCmd, imagePullCmd)
+	addCommand(imageCmd)
+}
@@ -9,10 +9,12 @@ import (
 	"context"
 	"fmt"
 	"io/ioutil"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/mattn/go-isatty"
 	"github.com/spf13/cobra"
@@ -24,9 +26,12 @@ import (
 )
 
 var (
-	force            bool
-	forceContextName string
-	merge            bool
+	force               bool
+	forceContextName    string
+	contextNameTemplate string
+	certTTL             time.Duration
+	portForwardAddress  string
+	merge               bool
 )
 
 // kubeconfigCmd represents the kubeconfig command.
@@ -35,7 +40,16 @@ var kubeconfigCmd = &cobra.Command{
 	Short: "Download the admin kubeconfig from the node",
 	Long: `Download the admin kubeconfig from the node.
 If merge flag is defined, config will be merged with ~/.kube/config or [local-path] if specified.
-Otherwise kubeconfig will be written to PWD or [local-path] if specified.`,
+Otherwise kubeconfig will be written to PWD or [local-path] if specified.
+
+Context name for the merged kubeconfig can be set with --force-context-name or rendered
+from the Go template --context-name-template, fields .Context, .Cluster and .User are available.
+On context name conflict the existing context is kept and the new one is renamed (or overwritten with --force).
+
+Short-lived admin certificate can be requested with --cert-ttl.
+
+If the Kubernetes API endpoint is not reachable directly, use --port-forward-address to point kubeconfig
+to the local address of 'talosctl port-forward-k8s' which tunnels Kubernetes API via Talos API.`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return WithClient(func(ctx context.Context, c *client.Client) error {
@@ -93,7 +107,13 @@ Otherwise kubeconfig will be written to PWD or [local-path] if specified.`,
 				}
 			}
 
-			r, errCh, err := c.KubeconfigRaw(ctx)
+			var opts []client.KubeconfigOptionFunc
+
+			if certTTL > 0 {
+				opts = append(opts, client.WithKubeconfigCertTTL(certTTL))
+			}
+
+			r, errCh, err := c.KubeconfigRaw(ctx, opts...)
 			if err != nil {
 				return fmt.Errorf("error copying: %w", err)
 			}
@@ -116,6 +136,12 @@ Otherwise kubeconfig will be written to PWD or [local-path] if specified.`,
 				return err
 			}
 
+			if portForwardAddress != "" {
+				if data, err = rewriteKubernetesEndpoint(data, portForwardAddress); err != nil {
+					return err
+				}
+			}
+
 			if merge {
 				return extractAndMerge(data, localPath)
 			}
@@ -139,9 +165,10 @@ func extractAndMerge(data []byte, localPath string) error {
 	interactive := isatty.IsTerminal(os.Stdout.Fd())
 
 	err = merger.Merge(config, kubeconfig.MergeOptions{
-		ActivateContext:  true,
-		ForceContextName: forceContextName,
-		OutputWriter:     os.Stdout,
+		ActivateContext:     true,
+		ForceContextName:    forceContextName,
+		ContextNameTemplate: contextNameTemplate,
+		OutputWriter:        os.Stdout,
 		ConflictHandler: func(component kubeconfig.ConfigComponent, name string) (kubeconfig.ConflictDecision, error) {
 			if force {
 				return kubeconfig.OverwriteDecision, nil
@@ -162,6 +189,31 @@ func extractAndMerge(data []byte, localPath string) error {
 	return merger.Write(localPath)
 }
 
+// rewriteKubernetesEndpoint points the clusters in the kubeconfig to the address keeping the TLS server name.
+func rewriteKubernetesEndpoint(data []byte, address string) ([]byte, error) {
+	config, err := clientcmd.Load(data)
+	if err != nil {
+		return nil, err
+	}
+
+	for name, cluster := range config.Clusters {
+		var u *url.URL
+
+		if u, err = url.Parse(cluster.Server); err != nil {
+			return nil, fmt.Errorf("error parsing server URL of cluster %q: %w", name, err)
+		}
+
+		if cluster.TLSServerName == "" {
+			cluster.TLSServerName = u.Hostname()
+		}
+
+		u.Host = address
+		cluster.Server = u.String()
+	}
+
+	return clientcmd.Write(*config)
+}
+
 func askOverwriteOrRename(prompt string) (kubeconfig.ConflictDecision, error) {
 	reader := bufio.NewReader(os.Stdin)
 
@@ -185,6 +237,9 @@ func askOverwriteOrRename(prompt string) (kubeconfig.ConflictDecision, error) {
 func init() {
 	kubeconfigCmd.Flags().BoolVarP(&force, "force", "f", false, "Force overwrite of kubeconfig if already present, force overwrite on kubeconfig merge")
 	kubeconfigCmd.Flags().StringVar(&forceContextName, "force-context-name", "", "Force context name for kubeconfig merge")
+	kubeconfigCmd.Flags().StringVar(&contextNameTemplate, "context-name-template", "", "Go template for the context name on kubeconfig merge, e.g. 'admin@{{ .Cluster }}'")
+	kubeconfigCmd.Flags().DurationVar(&certTTL, "cert-ttl", 0, "Lifetime of the admin certificate, should not exceed the configured admin kubeconfig certificate lifetime (0 means default)")
+	kubeconfigCmd.Flags().StringVar(&portForwardAddress, "port-forward-address", "", "Point kubeconfig to the local address of 'talosctl port-forward-k8s' instead of the Kubernetes API endpoint")
 	kubeconfigCmd.Flags().BoolVarP(&merge, "merge", "m", true, "Merge with existing kubeconfig")
 	addCommand(kubeconfigCmd)
 }
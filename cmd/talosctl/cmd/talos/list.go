@@ -18,6 +18,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"github.com/talos-systems/talos/pkg/archiver"
 	machineapi "github.com/talos-systems/talos/pkg/machinery/api/machine"
 	"github.com/talos-systems/talos/pkg/machinery/client"
 )
@@ -37,8 +38,14 @@ var lsCmd = &cobra.Command{
 	Use:     "list [path]",
 	Aliases: []string{"ls"},
 	Short:   "Retrieve a directory listing",
-	Long:    ``,
-	Args:    cobra.MaximumNArgs(1),
+	Long: `Retrieve a directory listing.
+
+Path might be a shell pattern, pattern component '**' matches any number of directories:
+
+    talosctl ls -l '/var/lib/kubelet/**/*.log'
+
+Patterns should be quoted to prevent expansion by the local shell.`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return WithClient(func(ctx context.Context, c *client.Client) error {
 			rootDir := "/"
@@ -47,6 +54,22 @@ var lsCmd = &cobra.Command{
 				rootDir = args[0]
 			}
 
+			// `ls /var/log/*.log` is a listing of /var/log filtered by the pattern
+			var patterns []string
+
+			if root, pattern := archiver.SplitPattern(rootDir); pattern != "" {
+				rootDir, patterns = root, []string{pattern}
+				recurse = true
+
+				if !cmd.Flags().Changed("depth") {
+					recursionDepth = int32(archiver.PatternDepth(pattern))
+
+					if recursionDepth < 0 {
+						recursionDepth = 0
+					}
+				}
+			}
+
 			// handle all variants: --type=f,l; -tfl; etc
 			var reqTypes []machineapi.ListRequest_Type
 			for _, typ := range types {
@@ -70,6 +93,7 @@ var lsCmd = &cobra.Command{
 				Recurse:        recurse,
 				RecursionDepth: recursionDepth,
 				Types:          reqTypes,
+				Patterns:       patterns,
 			})
 			if err != nil {
 				return fmt.Errorf("error fetching logs: %s", err)
@@ -128,7 +152,7 @@ var lsCmd = &cobra.Command{
 			}
 
 			w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-			fmt.Fprintln(w, "NODE\tMODE\tSIZE(B)\tLASTMOD\tNAME")
+			fmt.Fprintln(w, "NODE\tMODE\tUID\tGID\tSIZE(B)\tLASTMOD\tNAME")
 			for {
 				info, err := stream.Recv()
 				if err != nil {
@@ -180,9 +204,11 @@ var lsCmd = &cobra.Command{
 					}
 				}
 
-				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+				fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%s\t%s\t%s\n",
 					node,
 					os.FileMode(info.Mode).String(),
+					info.Uid,
+					info.Gid,
 					size,
 					timestampFormatted,
 					display,
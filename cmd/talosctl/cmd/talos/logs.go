@@ -31,10 +31,11 @@ var (
 
 // logsCmd represents the logs command.
 var logsCmd = &cobra.Command{
-	Use:   "logs <service name>",
-	Short: "Retrieve logs for a service",
-	Long:  ``,
-	Args:  cobra.ExactArgs(1),
+	Use:               "logs <service name>",
+	Short:             "Retrieve logs for a service",
+	Long:              ``,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeServiceIDs,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return WithClient(func(ctx context.Context, c *client.Client) error {
 			var (
@@ -0,0 +1,175 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package talos
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+
+	"github.com/talos-systems/talos/pkg/cli"
+	machineapi "github.com/talos-systems/talos/pkg/machinery/api/machine"
+	"github.com/talos-systems/talos/pkg/machinery/client"
+)
+
+var netstatCmdFlags struct {
+	listening bool
+	all       bool
+	tcp       bool
+	udp       bool
+	ipv4      bool
+	ipv6      bool
+	pods      bool
+}
+
+// netstatCmd represents the netstat command.
+var netstatCmd = &cobra.Command{
+	Use:     "netstat",
+	Aliases: []string{"ss"},
+	Short:   "Show network connections and sockets",
+	Long: `Show TCP and UDP sockets of the node with the owning processes.
+
+By default only connected sockets of the host network namespace are displayed.
+With --pods, sockets of the pod network namespaces are displayed as well,
+and the sockets are attributed to the Kubernetes pods.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return WithClient(func(ctx context.Context, c *client.Client) error {
+			req := &machineapi.NetstatRequest{
+				Filter: machineapi.NetstatRequest_CONNECTED,
+				L4Proto: &machineapi.NetstatRequest_L4Proto{
+					Tcp:  netstatCmdFlags.tcp,
+					Tcp6: netstatCmdFlags.tcp,
+					Udp:  netstatCmdFlags.udp,
+					Udp6: netstatCmdFlags.udp,
+				},
+				Pods: netstatCmdFlags.pods,
+			}
+
+			switch {
+			case netstatCmdFlags.all:
+				req.Filter = machineapi.NetstatRequest_ALL
+			case netstatCmdFlags.listening:
+				req.Filter = machineapi.NetstatRequest_LISTENING
+			}
+
+			if !netstatCmdFlags.tcp && !netstatCmdFlags.udp {
+				req.L4Proto = &machineapi.NetstatRequest_L4Proto{
+					Tcp:  true,
+					Tcp6: true,
+					Udp:  true,
+					Udp6: true,
+				}
+			}
+
+			if netstatCmdFlags.ipv4 != netstatCmdFlags.ipv6 {
+				req.L4Proto.Tcp = req.L4Proto.Tcp && netstatCmdFlags.ipv4
+				req.L4Proto.Udp = req.L4Proto.Udp && netstatCmdFlags.ipv4
+				req.L4Proto.Tcp6 = req.L4Proto.Tcp6 && netstatCmdFlags.ipv6
+				req.L4Proto.Udp6 = req.L4Proto.Udp6 && netstatCmdFlags.ipv6
+			}
+
+			var remotePeer peer.Peer
+
+			resp, err := c.Netstat(ctx, req, grpc.Peer(&remotePeer))
+			if err != nil {
+				if resp == nil {
+					return fmt.Errorf("error getting netstat: %w", err)
+				}
+
+				cli.Warning("%s", err)
+			}
+
+			return netstatRender(&remotePeer, resp)
+		})
+	},
+}
+
+func netstatRender(remotePeer *peer.Peer, resp *machineapi.NetstatResponse) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+
+	header := "NODE\tPROTO\tRECV-Q\tSEND-Q\tLOCAL ADDRESS\tFOREIGN ADDRESS\tSTATE\tPID/PROGRAM"
+	if netstatCmdFlags.pods {
+		header += "\tPOD"
+	}
+
+	fmt.Fprintln(w, header)
+
+	defaultNode := client.AddrFromPeer(remotePeer)
+
+	for _, msg := range resp.Messages {
+		node := defaultNode
+
+		if msg.Metadata != nil {
+			node = msg.Metadata.Hostname
+		}
+
+		for _, record := range msg.Connectrecord {
+			program := "-"
+
+			if record.Process != nil {
+				program = fmt.Sprintf("%d/%s", record.Process.Pid, record.Process.Name)
+			}
+
+			state := ""
+			if strings.HasPrefix(record.L4Proto, "tcp") {
+				state = record.State.String()
+			}
+
+			line := fmt.Sprintf("%s\t%s\t%d\t%d\t%s\t%s\t%s\t%s",
+				node,
+				record.L4Proto,
+				record.Rxqueue,
+				record.Txqueue,
+				netstatAddress(record.Localip, record.Localport),
+				netstatAddress(record.Remoteip, record.Remoteport),
+				state,
+				program,
+			)
+
+			if netstatCmdFlags.pods {
+				pod := record.Pod
+				if pod == "" {
+					pod = "-"
+				}
+
+				line += "\t" + pod
+			}
+
+			fmt.Fprintln(w, line)
+		}
+	}
+
+	return w.Flush()
+}
+
+func netstatAddress(ip string, port uint32) string {
+	portStr := "*"
+
+	if port != 0 {
+		portStr = strconv.Itoa(int(port))
+	}
+
+	return net.JoinHostPort(ip, portStr)
+}
+
+func init() {
+	netstatCmd.Flags().BoolVarP(&netstatCmdFlags.listening, "listening", "l", false, "display listening server sockets")
+	netstatCmd.Flags().BoolVarP(&netstatCmdFlags.all, "all", "a", false, "display all sockets states (default: connected)")
+	netstatCmd.Flags().BoolVarP(&netstatCmdFlags.tcp, "tcp", "t", false, "display only TCP sockets")
+	netstatCmd.Flags().BoolVarP(&netstatCmdFlags.udp, "udp", "u", false, "display only UDP sockets")
+	netstatCmd.Flags().BoolVarP(&netstatCmdFlags.ipv4, "ipv4", "4", false, "display only IPv4 sockets")
+	netstatCmd.Flags().BoolVarP(&netstatCmdFlags.ipv6, "ipv6", "6", false, "display only IPv6 sockets")
+	netstatCmd.Flags().BoolVarP(&netstatCmdFlags.pods, "pods", "k", false, "show sockets used by Kubernetes pods")
+	addCommand(netstatCmd)
+}
@@ -7,11 +7,10 @@ package output
 import (
 	"encoding/json"
 	"os"
-	"strings"
+	"time"
 
 	"github.com/talos-systems/os-runtime/pkg/resource"
 	"github.com/talos-systems/os-runtime/pkg/state"
-	"gopkg.in/yaml.v3"
 )
 
 // JSON outputs resources in JSON format.
@@ -32,30 +31,12 @@ func (j *JSON) WriteHeader(definition resource.Resource, withEvents bool) error
 }
 
 // WriteResource implements output.Writer interface.
-func (j *JSON) WriteResource(node string, r resource.Resource, event state.EventType) error {
-	out, err := resource.MarshalYAML(r)
+func (j *JSON) WriteResource(node string, r resource.Resource, updated time.Time, event state.EventType) error {
+	data, err := resourceData(node, r, updated, j.withEvents, event)
 	if err != nil {
 		return err
 	}
 
-	yamlBytes, err := yaml.Marshal(out)
-	if err != nil {
-		return err
-	}
-
-	var data map[string]interface{}
-
-	err = yaml.Unmarshal(yamlBytes, &data)
-	if err != nil {
-		return err
-	}
-
-	data["node"] = node
-
-	if j.withEvents {
-		data["event"] = strings.ToLower(event.String())
-	}
-
 	enc := json.NewEncoder(os.Stdout)
 	enc.SetIndent("", "    ")
 
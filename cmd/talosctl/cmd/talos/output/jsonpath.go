@@ -0,0 +1,71 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package output
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/talos-systems/os-runtime/pkg/resource"
+	"github.com/talos-systems/os-runtime/pkg/state"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// JSONPath outputs the result of the JSONPath template for each resource.
+type JSONPath struct {
+	jsonPath   *jsonpath.JSONPath
+	withEvents bool
+}
+
+// NewJSONPath initializes JSONPath resource output.
+//
+// Template might be specified either as a full template (`{.spec.addresses[0]}`)
+// or as a bare expression (`.spec.addresses[0]`).
+func NewJSONPath(template string) (*JSONPath, error) {
+	if !strings.Contains(template, "{") {
+		template = "{" + template + "}"
+	}
+
+	jsonPath := jsonpath.New("output")
+	jsonPath.AllowMissingKeys(true)
+
+	if err := jsonPath.Parse(template); err != nil {
+		return nil, fmt.Errorf("error parsing jsonpath template %q: %w", template, err)
+	}
+
+	return &JSONPath{
+		jsonPath: jsonPath,
+	}, nil
+}
+
+// WriteHeader implements output.Writer interface.
+func (j *JSONPath) WriteHeader(definition resource.Resource, withEvents bool) error {
+	j.withEvents = withEvents
+
+	return nil
+}
+
+// WriteResource implements output.Writer interface.
+func (j *JSONPath) WriteResource(node string, r resource.Resource, updated time.Time, event state.EventType) error {
+	data, err := resourceData(node, r, updated, j.withEvents, event)
+	if err != nil {
+		return err
+	}
+
+	if err = j.jsonPath.Execute(os.Stdout, data); err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(os.Stdout)
+
+	return err
+}
+
+// Flush implements output.Writer interface.
+func (j *JSONPath) Flush() error {
+	return nil
+}
@@ -7,6 +7,8 @@ package output
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/talos-systems/os-runtime/pkg/resource"
 	"github.com/talos-systems/os-runtime/pkg/state"
@@ -15,12 +17,18 @@ import (
 // Writer interface.
 type Writer interface {
 	WriteHeader(definition resource.Resource, withEvents bool) error
-	WriteResource(node string, r resource.Resource, event state.EventType) error
+	WriteResource(node string, r resource.Resource, updated time.Time, event state.EventType) error
 	Flush() error
 }
 
 // NewWriter builds writer from type.
+//
+// Format `jsonpath=<template>` outputs the result of the JSONPath template for each resource.
 func NewWriter(format string) (Writer, error) {
+	if strings.HasPrefix(format, "jsonpath=") {
+		return NewJSONPath(strings.TrimPrefix(format, "jsonpath="))
+	}
+
 	switch format {
 	case "table":
 		return NewTable(), nil
@@ -0,0 +1,79 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package output
+
+import (
+	"strings"
+	"time"
+
+	"github.com/talos-systems/os-runtime/pkg/resource"
+	"github.com/talos-systems/os-runtime/pkg/state"
+	"gopkg.in/yaml.v3"
+)
+
+// resourceMetadata is the resource metadata as presented in the output.
+type resourceMetadata struct {
+	Namespace  string   `yaml:"namespace"`
+	Type       string   `yaml:"type"`
+	ID         string   `yaml:"id"`
+	Version    string   `yaml:"version"`
+	Owner      string   `yaml:"owner"`
+	Phase      string   `yaml:"phase"`
+	Updated    string   `yaml:"updated,omitempty"`
+	Finalizers []string `yaml:"finalizers"`
+}
+
+// resourceOutput is the resource as presented in the output.
+type resourceOutput struct {
+	Metadata resourceMetadata `yaml:"metadata"`
+	Spec     interface{}      `yaml:"spec"`
+}
+
+func newResourceOutput(r resource.Resource, updated time.Time) *resourceOutput {
+	out := &resourceOutput{
+		Metadata: resourceMetadata{
+			Namespace:  r.Metadata().Namespace(),
+			Type:       r.Metadata().Type(),
+			ID:         r.Metadata().ID(),
+			Version:    r.Metadata().Version().String(),
+			Owner:      r.Metadata().Owner(),
+			Phase:      r.Metadata().Phase().String(),
+			Finalizers: []string{},
+		},
+		Spec: r.Spec(),
+	}
+
+	if !updated.IsZero() {
+		out.Metadata.Updated = updated.Format(time.RFC3339)
+	}
+
+	for _, fin := range *r.Metadata().Finalizers() {
+		out.Metadata.Finalizers = append(out.Metadata.Finalizers, fin)
+	}
+
+	return out
+}
+
+// resourceData converts the resource to the generic form suitable for JSON encoding and JSONPath queries.
+func resourceData(node string, r resource.Resource, updated time.Time, withEvents bool, event state.EventType) (map[string]interface{}, error) {
+	yamlBytes, err := yaml.Marshal(newResourceOutput(r, updated))
+	if err != nil {
+		return nil, err
+	}
+
+	var data map[string]interface{}
+
+	if err = yaml.Unmarshal(yamlBytes, &data); err != nil {
+		return nil, err
+	}
+
+	data["node"] = node
+
+	if withEvents {
+		data["event"] = strings.ToLower(event.String())
+	}
+
+	return data, nil
+}
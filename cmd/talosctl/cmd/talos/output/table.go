@@ -10,6 +10,7 @@ import (
 	"os"
 	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/talos-systems/os-runtime/pkg/resource"
 	"github.com/talos-systems/os-runtime/pkg/state"
@@ -77,7 +78,7 @@ func (table *Table) WriteHeader(definition resource.Resource, withEvents bool) e
 }
 
 // WriteResource implements output.Writer interface.
-func (table *Table) WriteResource(node string, r resource.Resource, event state.EventType) error {
+func (table *Table) WriteResource(node string, r resource.Resource, updated time.Time, event state.EventType) error {
 	values := []string{r.Metadata().Namespace(), table.displayType, r.Metadata().ID(), r.Metadata().Version().String()}
 
 	if table.withEvents {
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/talos-systems/os-runtime/pkg/resource"
 	"github.com/talos-systems/os-runtime/pkg/state"
@@ -33,12 +34,7 @@ func (y *YAML) WriteHeader(definition resource.Resource, withEvents bool) error
 }
 
 // WriteResource implements output.Writer interface.
-func (y *YAML) WriteResource(node string, r resource.Resource, event state.EventType) error {
-	out, err := resource.MarshalYAML(r)
-	if err != nil {
-		return err
-	}
-
+func (y *YAML) WriteResource(node string, r resource.Resource, updated time.Time, event state.EventType) error {
 	if y.needDashes {
 		fmt.Fprintln(os.Stdout, "---")
 	}
@@ -51,7 +47,7 @@ func (y *YAML) WriteResource(node string, r resource.Resource, event state.Event
 		fmt.Fprintf(os.Stdout, "event: %s\n", strings.ToLower(event.String()))
 	}
 
-	return yaml.NewEncoder(os.Stdout).Encode(out)
+	return yaml.NewEncoder(os.Stdout).Encode(newResourceOutput(r, updated))
 }
 
 // Flush implements output.Writer interface.
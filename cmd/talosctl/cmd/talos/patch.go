@@ -14,6 +14,7 @@ import (
 
 	jsonpatch "github.com/evanphx/json-patch"
 	"github.com/spf13/cobra"
+	"google.golang.org/grpc/status"
 	"gopkg.in/yaml.v3"
 	cmdutil "k8s.io/kubectl/pkg/cmd/util"
 
@@ -25,6 +26,9 @@ import (
 	"github.com/talos-systems/talos/pkg/resources/config"
 )
 
+// patchConflictAttempts is the number of attempts to patch the config modified concurrently.
+const patchConflictAttempts = 3
+
 var patchCmdFlags struct {
 	namespace string
 	patch     string
@@ -81,45 +85,69 @@ var patchCmd = &cobra.Command{
 					return err
 				}
 
-				patched, err := configpatcher.JSON6902(body, patch)
-				if err != nil {
-					return err
-				}
+				for attempt := 1; ; attempt++ {
+					var checksum string
 
-				resp, err := c.ApplyConfiguration(ctx, &machine.ApplyConfigurationRequest{
-					Data:      patched,
-					Immediate: patchCmdFlags.immediate,
-					OnReboot:  patchCmdFlags.onReboot,
-				})
+					checksum, err = helpers.MachineConfigChecksum(body)
+					if err != nil {
+						return err
+					}
 
-				if bytes.Equal(
-					bytes.TrimSpace(cmdutil.StripComments(patched)),
-					bytes.TrimSpace(cmdutil.StripComments(body)),
-				) {
-					fmt.Println("Apply was skipped: no changes detected.")
+					var patched []byte
 
-					return nil
-				}
+					patched, err = configpatcher.JSON6902(body, patch)
+					if err != nil {
+						return err
+					}
 
-				fmt.Printf("patched %s at the node %s\n", args[0], msg.Metadata.GetHostname())
+					if bytes.Equal(
+						bytes.TrimSpace(cmdutil.StripComments(patched)),
+						bytes.TrimSpace(cmdutil.StripComments(body)),
+					) {
+						fmt.Println("Apply was skipped: no changes detected.")
 
-				for _, m := range resp.GetMessages() {
-					for _, w := range m.GetWarnings() {
-						cli.Warning("%s", w)
+						return nil
 					}
-				}
 
-				return err
-			}
+					var resp *machine.ApplyConfigurationResponse
 
-			for _, node := range Nodes {
-				nodeCtx := client.WithNodes(ctx, node)
-				if err := helpers.ForEachResource(nodeCtx, c, patchFn, patchCmdFlags.namespace, args...); err != nil {
-					return err
+					resp, err = c.ApplyConfiguration(parentCtx, &machine.ApplyConfigurationRequest{
+						Data:             patched,
+						Immediate:        patchCmdFlags.immediate,
+						OnReboot:         patchCmdFlags.onReboot,
+						ExpectedChecksum: checksum,
+					})
+
+					if helpers.IsConfigConflict(err) && attempt < patchConflictAttempts {
+						// config was modified concurrently, patch the fresh config
+						fmt.Fprintf(os.Stderr, "%s: %s, retrying\n", msg.Metadata.GetHostname(), status.Convert(err).Message())
+
+						if body, err = helpers.MachineConfigBytes(parentCtx, c); err != nil {
+							return err
+						}
+
+						continue
+					}
+
+					if err != nil {
+						return err
+					}
+
+					fmt.Printf("patched %s at the node %s\n", args[0], msg.Metadata.GetHostname())
+
+					for _, m := range resp.GetMessages() {
+						for _, w := range m.GetWarnings() {
+							cli.Warning("%s", w)
+						}
+					}
+
+					return nil
 				}
 			}
 
-			return nil
+			return client.ForEachNode(ctx, func(nodeCtx context.Context, node string) error {
+				return helpers.ForEachResource(nodeCtx, c, patchFn, patchCmdFlags.namespace, args...)
+			})
 		})
 	},
 }
@@ -0,0 +1,82 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package talos
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+
+	"github.com/talos-systems/talos/pkg/cli"
+	"github.com/talos-systems/talos/pkg/machinery/client"
+)
+
+var pauseReconcileCmdFlags struct {
+	duration time.Duration
+}
+
+// pauseReconcileCmd represents the pause-reconcile command.
+var pauseReconcileCmd = &cobra.Command{
+	Use:   "pause-reconcile",
+	Short: "Pause automatic reconciliation of the cluster state",
+	Long: `Pause automatic reconciliation of the cluster state (re-applying bootstrap manifests,
+re-rendering control plane static pods) on the node for the specified duration.
+
+Reconciliation can also be paused with the machine config via '.machine.reconcile'.
+Zero duration resumes reconciliation.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if pauseReconcileCmdFlags.duration < 0 {
+			return fmt.Errorf("duration should not be negative")
+		}
+
+		return WithClient(func(ctx context.Context, c *client.Client) error {
+			var remotePeer peer.Peer
+
+			resp, err := c.PauseReconcile(ctx, pauseReconcileCmdFlags.duration, grpc.Peer(&remotePeer))
+			if err != nil {
+				if resp == nil {
+					return fmt.Errorf("error pausing reconciliation: %w", err)
+				}
+
+				cli.Warning("%s", err)
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+			fmt.Fprintln(w, "NODE\tPAUSED UNTIL")
+
+			defaultNode := client.AddrFromPeer(&remotePeer)
+
+			for _, msg := range resp.Messages {
+				node := defaultNode
+
+				if msg.Metadata != nil {
+					node = msg.Metadata.Hostname
+				}
+
+				pausedUntil := "resumed"
+
+				if msg.PausedUntil != nil {
+					pausedUntil = msg.PausedUntil.AsTime().Local().Format(time.RFC3339)
+				}
+
+				fmt.Fprintf(w, "%s\t%s\n", node, pausedUntil)
+			}
+
+			return w.Flush()
+		})
+	},
+}
+
+func init() {
+	pauseReconcileCmd.Flags().DurationVar(&pauseReconcileCmdFlags.duration, "duration", time.Hour, "duration of the pause, zero duration resumes reconciliation")
+	addCommand(pauseReconcileCmd)
+}
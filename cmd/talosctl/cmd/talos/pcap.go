@@ -0,0 +1,137 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package talos
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+
+	"github.com/talos-systems/talos/cmd/talosctl/pkg/talos/helpers"
+	"github.com/talos-systems/talos/cmd/talosctl/pkg/talos/pcap"
+	machineapi "github.com/talos-systems/talos/pkg/machinery/api/machine"
+	"github.com/talos-systems/talos/pkg/machinery/client"
+)
+
+var pcapCmdFlags struct {
+	iface       string
+	filter      string
+	promiscuous bool
+	snapLen     int
+	duration    time.Duration
+	count       int
+	output      string
+}
+
+// pcapCmd represents the pcap command.
+var pcapCmd = &cobra.Command{
+	Use:     "pcap",
+	Aliases: []string{"tcpdump"},
+	Short:   "Capture the network packets on the node",
+	Long: `Capture the network packets on the node and write them in pcap format.
+
+Output can be analyzed with Wireshark or tcpdump (e.g. 'talosctl pcap -i eth0 -o - | tcpdump -r -').
+
+Filter expression is compiled by talosctl to the BPF program which is executed by the node kernel.
+Filter syntax is a subset of pcap-filter(7) syntax: 'ip', 'ip6', 'arp', 'tcp', 'udp', 'icmp', 'icmp6',
+'[proto] [src|dst] host <ip>', '[proto] [src|dst] net <cidr>', '[tcp|udp] [src|dst] port <port>'
+primitives combined with 'and', 'or', 'not' and parentheses.
+
+Capture stops when the duration or packet count limit is reached (enforced on the node), or on Ctrl-C.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		filter, err := pcap.CompileFilter(pcapCmdFlags.filter)
+		if err != nil {
+			return fmt.Errorf("error compiling filter %q: %w", pcapCmdFlags.filter, err)
+		}
+
+		req := &machineapi.PacketCaptureRequest{
+			Interface:   pcapCmdFlags.iface,
+			Promiscuous: pcapCmdFlags.promiscuous,
+			SnapLen:     uint32(pcapCmdFlags.snapLen),
+			Duration:    uint32((pcapCmdFlags.duration + time.Second - 1) / time.Second),
+			PacketCount: uint32(pcapCmdFlags.count),
+		}
+
+		for _, insn := range filter {
+			req.BpfFilter = append(req.BpfFilter, &machineapi.BPFInstruction{
+				Op: uint32(insn.Op),
+				Jt: uint32(insn.Jt),
+				Jf: uint32(insn.Jf),
+				K:  insn.K,
+			})
+		}
+
+		var out io.Writer
+
+		if pcapCmdFlags.output == "-" {
+			if isatty.IsTerminal(os.Stdout.Fd()) {
+				return fmt.Errorf("refusing to write pcap data to the terminal, redirect the output or use --output")
+			}
+
+			out = os.Stdout
+		} else {
+			f, err := os.Create(pcapCmdFlags.output)
+			if err != nil {
+				return err
+			}
+
+			defer f.Close() //nolint:errcheck
+
+			out = f
+		}
+
+		return WithClient(func(ctx context.Context, c *client.Client) error {
+			if err := helpers.FailIfMultiNodes(ctx, "pcap"); err != nil {
+				return err
+			}
+
+			r, errCh, err := c.PacketCapture(ctx, req)
+			if err != nil {
+				return fmt.Errorf("error starting packet capture: %w", err)
+			}
+
+			defer r.Close() //nolint:errcheck
+
+			var wg sync.WaitGroup
+
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				for err := range errCh {
+					fmt.Fprintln(os.Stderr, err.Error())
+				}
+			}()
+
+			defer wg.Wait()
+
+			_, err = io.Copy(out, r)
+			if err != nil {
+				return fmt.Errorf("error capturing packets: %w", err)
+			}
+
+			return r.Close()
+		})
+	},
+}
+
+func init() {
+	pcapCmd.Flags().StringVarP(&pcapCmdFlags.iface, "interface", "i", "eth0", "interface name to capture packets on")
+	pcapCmd.Flags().StringVarP(&pcapCmdFlags.filter, "filter", "f", "", "filter expression (pcap-filter(7) subset)")
+	pcapCmd.Flags().BoolVarP(&pcapCmdFlags.promiscuous, "promiscuous", "p", false, "put interface into promiscuous mode")
+	pcapCmd.Flags().IntVarP(&pcapCmdFlags.snapLen, "snaplen", "s", 0, "maximum number of bytes captured for each packet (default 65536)")
+	pcapCmd.Flags().DurationVarP(&pcapCmdFlags.duration, "duration", "d", 0, "duration of the capture (default: until interrupted)")
+	pcapCmd.Flags().IntVarP(&pcapCmdFlags.count, "count", "c", 0, "number of packets to capture (default: unlimited)")
+	pcapCmd.Flags().StringVarP(&pcapCmdFlags.output, "output", "o", "-", "output file path, '-' for stdout")
+	addCommand(pcapCmd)
+}
@@ -0,0 +1,102 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package talos
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/talos-systems/talos/cmd/talosctl/pkg/talos/helpers"
+	"github.com/talos-systems/talos/pkg/machinery/client"
+)
+
+var portForwardK8sCmdFlags struct {
+	listenAddress string
+}
+
+// portForwardK8sCmd represents the port-forward-k8s command.
+var portForwardK8sCmd = &cobra.Command{
+	Use:   "port-forward-k8s",
+	Short: "Forward the local port to the Kubernetes API server via Talos API",
+	Long: `Forward the local port to the Kubernetes API server of the control plane node via Talos API.
+
+Each connection to the local port is tunneled over the Talos API to the Kubernetes API server
+running on the node, so that Kubernetes API is accessible when only Talos API is reachable.
+
+Kubeconfig for the forwarded port can be generated with 'talosctl kubeconfig --port-forward-address'.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return WithClient(func(ctx context.Context, c *client.Client) error {
+			if err := helpers.FailIfMultiNodes(ctx, "port-forward-k8s"); err != nil {
+				return err
+			}
+
+			listener, err := net.Listen("tcp", portForwardK8sCmdFlags.listenAddress)
+			if err != nil {
+				return fmt.Errorf("error listening on %q: %w", portForwardK8sCmdFlags.listenAddress, err)
+			}
+
+			go func() {
+				<-ctx.Done()
+
+				listener.Close() //nolint:errcheck
+			}()
+
+			fmt.Fprintf(os.Stderr, "forwarding %s to the Kubernetes API server, press Ctrl+C to stop\n", listener.Addr())
+
+			for {
+				var conn net.Conn
+
+				conn, err = listener.Accept()
+				if err != nil {
+					if ctx.Err() != nil {
+						return nil
+					}
+
+					return fmt.Errorf("error accepting connection: %w", err)
+				}
+
+				go forwardKubernetesConn(ctx, c, conn)
+			}
+		})
+	},
+}
+
+// forwardKubernetesConn copies the data between the local connection and the tunnel until either side is closed.
+func forwardKubernetesConn(ctx context.Context, c *client.Client, conn net.Conn) {
+	//nolint:errcheck
+	defer conn.Close()
+
+	tunnel, err := c.KubernetesTunnel(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error opening tunnel for %s: %s\n", conn.RemoteAddr(), err)
+
+		return
+	}
+
+	//nolint:errcheck
+	defer tunnel.Close()
+
+	go func() {
+		io.Copy(tunnel, conn) //nolint:errcheck
+
+		tunnel.CloseWrite() //nolint:errcheck
+	}()
+
+	// connection is closed once the Kubernetes API server side is done, which also stops the copy above
+	if _, err = io.Copy(conn, tunnel); err != nil {
+		fmt.Fprintf(os.Stderr, "error forwarding connection for %s: %s\n", conn.RemoteAddr(), err)
+	}
+}
+
+func init() {
+	portForwardK8sCmd.Flags().StringVar(&portForwardK8sCmdFlags.listenAddress, "listen-address", "127.0.0.1:6443", "local address to listen on")
+	addCommand(portForwardK8sCmd)
+}
@@ -7,9 +7,12 @@ package talos
 import (
 	"context"
 	"fmt"
+	"os"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
+	"text/tabwriter"
 	"time"
 
 	"github.com/dustin/go-humanize"
@@ -21,6 +24,7 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/peer"
 
+	"github.com/talos-systems/talos/cmd/talosctl/pkg/talos/helpers"
 	"github.com/talos-systems/talos/pkg/cli"
 	machineapi "github.com/talos-systems/talos/pkg/machinery/api/machine"
 	"github.com/talos-systems/talos/pkg/machinery/client"
@@ -29,20 +33,35 @@ import (
 var (
 	sortMethod     string
 	watchProcesses bool
+	processesTree  bool
 )
 
 // processesCmd represents the processes command.
 var processesCmd = &cobra.Command{
-	Use:     "processes",
+	Use:     "processes [pid]",
 	Aliases: []string{"p", "ps"},
 	Short:   "List running processes",
-	Long:    ``,
-	Args:    cobra.NoArgs,
+	Long: `List running processes.
+
+If the PID is given, detailed information about the process is displayed instead.`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return WithClient(func(ctx context.Context, c *client.Client) error {
 			var err error
 
 			switch {
+			case len(args) > 0:
+				var pid int
+
+				if pid, err = strconv.Atoi(args[0]); err != nil {
+					return fmt.Errorf("invalid PID %q: %w", args[0], err)
+				}
+
+				if err = helpers.FailIfMultiNodes(ctx, "processes"); err != nil {
+					return err
+				}
+
+				return processDetailsOutput(ctx, c, int32(pid))
 			case watchProcesses:
 				if err = ui.Init(); err != nil {
 					return fmt.Errorf("failed to initialize termui: %w", err)
@@ -67,8 +86,9 @@ var processesCmd = &cobra.Command{
 }
 
 func init() {
-	processesCmd.Flags().StringVarP(&sortMethod, "sort", "s", "rss", "Column to sort output by. [rss|cpu]")
+	processesCmd.Flags().StringVarP(&sortMethod, "sort", "s", "rss", "Column to sort output by. [rss|cpu|fds]")
 	processesCmd.Flags().BoolVarP(&watchProcesses, "watch", "w", false, "Stream running processes")
+	processesCmd.Flags().BoolVarP(&processesTree, "tree", "t", false, "Display processes as a tree (siblings are sorted)")
 	addCommand(processesCmd)
 }
 
@@ -169,6 +189,61 @@ var cpu = func(p1, p2 *machineapi.ProcessInfo) bool {
 	return p1.CpuTime > p2.CpuTime
 }
 
+var fds = func(p1, p2 *machineapi.ProcessInfo) bool {
+	// Reverse sort ( Descending )
+	return p1.OpenFiles > p2.OpenFiles
+}
+
+// processTree orders sorted processes depth-first by the parent-child relationship.
+//
+// Sort order is preserved for the siblings, processes with the parent not in the list are roots.
+func processTree(procs []*machineapi.ProcessInfo) (ordered []*machineapi.ProcessInfo, depths []int) {
+	present := make(map[int32]struct{}, len(procs))
+	children := make(map[int32][]*machineapi.ProcessInfo, len(procs))
+
+	for _, p := range procs {
+		present[p.Pid] = struct{}{}
+	}
+
+	var roots []*machineapi.ProcessInfo
+
+	for _, p := range procs {
+		if _, ok := present[p.Ppid]; ok && p.Ppid != p.Pid {
+			children[p.Ppid] = append(children[p.Ppid], p)
+		} else {
+			roots = append(roots, p)
+		}
+	}
+
+	var walk func(p *machineapi.ProcessInfo, depth int)
+
+	walk = func(p *machineapi.ProcessInfo, depth int) {
+		ordered = append(ordered, p)
+		depths = append(depths, depth)
+
+		for _, child := range children[p.Pid] {
+			walk(child, depth+1)
+		}
+	}
+
+	for _, p := range roots {
+		walk(p, 0)
+	}
+
+	return ordered, depths
+}
+
+func processArgs(p *machineapi.ProcessInfo) string {
+	switch {
+	case p.Executable == "":
+		return p.Command
+	case p.Args != "" && strings.Fields(p.Args)[0] == filepath.Base(strings.Fields(p.Executable)[0]):
+		return strings.Replace(p.Args, strings.Fields(p.Args)[0], p.Executable, 1)
+	default:
+		return p.Args
+	}
+}
+
 func processesOutput(ctx context.Context, c *client.Client) (output string, err error) {
 	var remotePeer peer.Peer
 
@@ -185,7 +260,7 @@ func processesOutput(ctx context.Context, c *client.Client) (output string, err
 
 	s := []string{}
 
-	s = append(s, "NODE | PID | STATE | THREADS | CPU-TIME | VIRTMEM | RESMEM | COMMAND")
+	s = append(s, "NODE | PID | STATE | THREADS | CPU-TIME | VIRTMEM | RESMEM | FDS | COMMAND")
 
 	for _, msg := range resp.Messages {
 		procs := msg.Processes
@@ -193,20 +268,23 @@ func processesOutput(ctx context.Context, c *client.Client) (output string, err
 		switch sortMethod {
 		case "cpu":
 			by(cpu).sort(procs)
+		case "fds":
+			by(fds).sort(procs)
 		default:
 			by(rss).sort(procs)
 		}
 
-		var args string
+		depths := make([]int, len(procs))
 
-		for _, p := range procs {
-			switch {
-			case p.Executable == "":
-				args = p.Command
-			case p.Args != "" && strings.Fields(p.Args)[0] == filepath.Base(strings.Fields(p.Executable)[0]):
-				args = strings.Replace(p.Args, strings.Fields(p.Args)[0], p.Executable, 1)
-			default:
-				args = p.Args
+		if processesTree {
+			procs, depths = processTree(procs)
+		}
+
+		for i, p := range procs {
+			args := processArgs(p)
+
+			if depths[i] > 0 {
+				args = strings.Repeat("  ", depths[i]-1) + `\_ ` + args
 			}
 
 			node := defaultNode
@@ -216,10 +294,54 @@ func processesOutput(ctx context.Context, c *client.Client) (output string, err
 			}
 
 			s = append(s,
-				fmt.Sprintf("%12s | %6d | %1s | %4d | %8.2f | %7s | %7s | %s",
-					node, p.Pid, p.State, p.Threads, p.CpuTime, humanize.Bytes(p.VirtualMemory), humanize.Bytes(p.ResidentMemory), args))
+				fmt.Sprintf("%12s | %6d | %1s | %4d | %8.2f | %7s | %7s | %5d | %s",
+					node, p.Pid, p.State, p.Threads, p.CpuTime, humanize.Bytes(p.VirtualMemory), humanize.Bytes(p.ResidentMemory), p.OpenFiles, args))
 		}
 	}
 
 	return columnize.SimpleFormat(s), err
 }
+
+func processDetailsOutput(ctx context.Context, c *client.Client, pid int32) error {
+	resp, err := c.ProcessDetails(ctx, pid)
+	if err != nil {
+		if resp == nil {
+			return fmt.Errorf("error getting process details: %w", err)
+		}
+
+		cli.Warning("%s", err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+
+	for _, msg := range resp.Messages {
+		p := msg.Process
+
+		fmt.Fprintf(w, "PID\t%d\n", p.Pid)
+		fmt.Fprintf(w, "PPID\t%d\n", p.Ppid)
+		fmt.Fprintf(w, "STATE\t%s\n", p.State)
+		fmt.Fprintf(w, "UID/GID\t%d/%d\n", msg.Uid, msg.Gid)
+		fmt.Fprintf(w, "THREADS\t%d\n", p.Threads)
+		fmt.Fprintf(w, "CPU-TIME\t%.2f\n", p.CpuTime)
+		fmt.Fprintf(w, "VIRTMEM\t%s\n", humanize.Bytes(p.VirtualMemory))
+		fmt.Fprintf(w, "RESMEM\t%s\n", humanize.Bytes(p.ResidentMemory))
+		fmt.Fprintf(w, "CGROUP\t%s\n", p.Cgroup)
+		fmt.Fprintf(w, "CWD\t%s\n", msg.Cwd)
+		fmt.Fprintf(w, "COMMAND\t%s\n", processArgs(p))
+
+		children := make([]string, 0, len(msg.Children))
+
+		for _, child := range msg.Children {
+			children = append(children, strconv.Itoa(int(child)))
+		}
+
+		fmt.Fprintf(w, "CHILDREN\t%s\n", strings.Join(children, " "))
+		fmt.Fprintf(w, "OPEN FILES\t%d\n", p.OpenFiles)
+
+		for _, target := range msg.OpenFiles {
+			fmt.Fprintf(w, "\t%s\n", target)
+		}
+	}
+
+	return w.Flush()
+}
@@ -21,8 +21,11 @@ import (
 var readCmd = &cobra.Command{
 	Use:   "read <path>",
 	Short: "Read a file on the machine",
-	Long:  ``,
-	Args:  cobra.ExactArgs(1),
+	Long: `Read a file on the machine.
+
+Path might be a shell pattern (quoted to prevent expansion by the local shell),
+in that case it should match exactly one regular file.`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return WithClient(func(ctx context.Context, c *client.Client) error {
 			if err := helpers.FailIfMultiNodes(ctx, "read"); err != nil {
@@ -7,29 +7,92 @@ package talos
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/talos-systems/talos/pkg/machinery/api/machine"
 	"github.com/talos-systems/talos/pkg/machinery/client"
 )
 
+var rebootCmdFlags struct {
+	mode    string
+	drain   drainFlags
+	wait    bool
+	timeout time.Duration
+}
+
 // rebootCmd represents the reboot command.
 var rebootCmd = &cobra.Command{
 	Use:   "reboot",
 	Short: "Reboot a node",
-	Long:  ``,
-	Args:  cobra.NoArgs,
+	Long: `Reboot a node.
+
+By default the node is rebooted via kexec if the machine has kexec enabled, falling back to a firmware reboot.
+Mode "powercycle" always goes through the firmware reboot.
+
+With '--drain' flag, nodes are rebooted one by one: the Kubernetes node is cordoned via Talos API,
+and the pods are evicted respecting PodDisruptionBudgets before the reboot.
+The reboot is aborted if the pods can't be evicted. Talos uncordons the node once it boots back.`,
+	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		mode, ok := machine.RebootRequest_Mode_value[strings.ToUpper(rebootCmdFlags.mode)]
+		if !ok {
+			return fmt.Errorf("unknown reboot mode %q", rebootCmdFlags.mode)
+		}
+
+		opts := []client.RebootOptionFunc{
+			client.WithRebootMode(machine.RebootRequest_Mode(mode)),
+		}
+
 		return WithClient(func(ctx context.Context, c *client.Client) error {
-			if err := c.Reboot(ctx); err != nil {
-				return fmt.Errorf("error executing reboot: %s", err)
+			reboot := func(ctx context.Context) error {
+				if !rebootCmdFlags.wait {
+					if err := c.Reboot(ctx, opts...); err != nil {
+						return fmt.Errorf("error executing reboot: %s", err)
+					}
+
+					return nil
+				}
+
+				return rebootAndWait(ctx, c, opts)
 			}
 
-			return nil
+			if rebootCmdFlags.drain.drain {
+				return forEachDrainedNode(ctx, c, &rebootCmdFlags.drain, reboot)
+			}
+
+			return reboot(ctx)
 		})
 	},
 }
 
+func rebootAndWait(ctx context.Context, c *client.Client, opts []client.RebootOptionFunc) error {
+	var req machine.RebootRequest
+
+	for _, opt := range opts {
+		opt(&req)
+	}
+
+	return runAndWait(ctx, c, func(ctx context.Context, c *client.Client) (string, error) {
+		resp, err := c.MachineClient.Reboot(ctx, &req)
+		if err != nil {
+			return "", fmt.Errorf("error executing reboot: %s", err)
+		}
+
+		if len(resp.GetMessages()) == 0 {
+			return "", fmt.Errorf("empty reboot response")
+		}
+
+		return resp.GetMessages()[0].GetActorId(), nil
+	}, nil, rebootCmdFlags.timeout)
+}
+
 func init() {
+	rebootCmd.Flags().StringVarP(&rebootCmdFlags.mode, "mode", "m", "default", "select the reboot mode: \"default\", \"powercycle\" (skips kexec)")
+	rebootCmdFlags.drain.addFlags(rebootCmd.Flags(), "rebooting")
+	rebootCmd.Flags().BoolVar(&rebootCmdFlags.wait, "wait", false, "wait for the node to come back after the reboot")
+	rebootCmd.Flags().DurationVar(&rebootCmdFlags.timeout, "timeout", 15*time.Minute, "time to wait for the node to come back after the reboot")
 	addCommand(rebootCmd)
 }
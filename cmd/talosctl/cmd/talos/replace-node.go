@@ -0,0 +1,346 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package talos
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/talos-systems/talos/cmd/talosctl/pkg/talos/helpers"
+	"github.com/talos-systems/talos/pkg/cli"
+	"github.com/talos-systems/talos/pkg/cluster"
+	"github.com/talos-systems/talos/pkg/cluster/replace"
+	"github.com/talos-systems/talos/pkg/machinery/api/machine"
+	"github.com/talos-systems/talos/pkg/machinery/client"
+	machinetype "github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1/machine"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+	clusterres "github.com/talos-systems/talos/pkg/resources/cluster"
+)
+
+// Fencing modes for the replaced node.
+const (
+	fenceReset  = "reset"
+	fenceCordon = "cordon"
+	fenceNone   = "none"
+)
+
+var replaceNodeCmdFlags struct {
+	outputDir        string
+	fence            string
+	controlPlaneNode string
+}
+
+// replaceNodeCmd represents the replace-node command.
+var replaceNodeCmd = &cobra.Command{
+	Use:   "replace-node",
+	Short: "Capture the state of a node and generate the config for its replacement",
+	Long: `Replace-node captures the identity, Kubernetes node name, labels, taints and etcd membership
+of the node specified with --nodes into the output directory, generates the machine config for the
+replacement hardware which registers under the same Kubernetes node name and labels, and fences
+the old node.
+
+Fencing modes:
+  reset   gracefully reset the old node: cordon and drain it, leave etcd and wipe the disk
+  cordon  cordon the Kubernetes node and remove the etcd member via --control-plane-node, for nodes which can't be reset
+  none    only capture the state and generate the config
+
+Kubernetes Node object is kept, so that the workloads pinned to the node by name are scheduled
+back once the replacement registers.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(Nodes) != 1 {
+			return fmt.Errorf("exactly one node to replace should be specified with --nodes")
+		}
+
+		switch replaceNodeCmdFlags.fence {
+		case fenceReset, fenceCordon, fenceNone:
+		default:
+			return fmt.Errorf("unsupported fence mode %q, expected one of %q", replaceNodeCmdFlags.fence, []string{fenceReset, fenceCordon, fenceNone})
+		}
+
+		return WithClientNoNodes(replaceNode)
+	},
+}
+
+//nolint:gocyclo,cyclop
+func replaceNode(ctx context.Context, c *client.Client) error {
+	oldNode := Nodes[0]
+	nodeCtx := client.WithNodes(ctx, oldNode)
+
+	cfg, err := helpers.MachineConfig(nodeCtx, c)
+	if err != nil {
+		return fmt.Errorf("error fetching machine config: %w", err)
+	}
+
+	controlPlane := cfg.Machine().Type() != machinetype.TypeJoin
+
+	escrow := &replace.Escrow{
+		Node:        oldNode,
+		MachineType: cfg.Machine().Type().String(),
+		CapturedAt:  time.Now().UTC(),
+	}
+
+	versionResp, err := c.Version(nodeCtx)
+	if err != nil {
+		return fmt.Errorf("error fetching hostname: %w", err)
+	}
+
+	var hostname string
+
+	for _, msg := range versionResp.GetMessages() {
+		hostname = msg.GetMetadata().GetHostname()
+	}
+
+	if escrow.Identity, err = fetchIdentity(nodeCtx, c); err != nil {
+		cli.Warning("failed to fetch node identity: %s", err)
+	}
+
+	// kubeconfig is served by control plane nodes only
+	k8sCtx := ctx
+
+	switch {
+	case replaceNodeCmdFlags.controlPlaneNode != "":
+		k8sCtx = client.WithNodes(ctx, replaceNodeCmdFlags.controlPlaneNode)
+	case controlPlane:
+		k8sCtx = nodeCtx
+	}
+
+	clientProvider := &cluster.ConfigClientProvider{
+		DefaultClient: c,
+	}
+	defer clientProvider.Close() //nolint:errcheck
+
+	kubeClient := &cluster.KubernetesClient{
+		ClientProvider: clientProvider,
+	}
+
+	clientset, err := kubeClient.K8sClient(k8sCtx)
+	if err != nil {
+		return fmt.Errorf("error building Kubernetes client: %w", err)
+	}
+
+	node, err := findKubernetesNode(ctx, clientset.CoreV1().Nodes(), hostname, oldNode)
+	if err != nil {
+		return err
+	}
+
+	escrow.NodeName = node.Name
+	escrow.Labels = node.Labels
+	escrow.Taints = replace.FormatTaints(node.Spec.Taints)
+
+	if controlPlane {
+		members, err := c.EtcdMemberList(nodeCtx, &machine.EtcdMemberListRequest{QueryLocal: true})
+		if err != nil {
+			cli.Warning("failed to fetch etcd members: %s", err)
+		} else {
+			for _, msg := range members.GetMessages() {
+				for _, member := range msg.GetMembers() {
+					if member == hostname {
+						escrow.EtcdMember = member
+					}
+				}
+			}
+		}
+	}
+
+	labels, skipped := replace.KubeletLabels(node.Labels)
+
+	replace.ApplyEscrow(cfg, escrow, labels)
+
+	configBytes, err := cfg.Bytes()
+	if err != nil {
+		return err
+	}
+
+	if err = writeEscrow(nodeCtx, c, escrow, configBytes); err != nil {
+		return err
+	}
+
+	fmt.Printf("captured node %q into %s\n", escrow.NodeName, replaceNodeCmdFlags.outputDir)
+
+	if len(skipped) > 0 {
+		keys := make([]string, 0, len(skipped))
+
+		for key := range skipped {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		cli.Warning("labels %q can't be set by the kubelet, re-apply them once the replacement joins", keys)
+	}
+
+	switch replaceNodeCmdFlags.fence {
+	case fenceReset:
+		fmt.Printf("resetting node %s\n", oldNode)
+
+		if err = c.ResetGeneric(nodeCtx, &machine.ResetRequest{
+			Graceful: true,
+		}); err != nil {
+			return fmt.Errorf("error resetting node: %w", err)
+		}
+	case fenceCordon:
+		helper, err := kubeClient.K8sHelper(k8sCtx)
+		if err != nil {
+			return err
+		}
+
+		if err = helper.Cordon(ctx, escrow.NodeName); err != nil {
+			return fmt.Errorf("error cordoning node: %w", err)
+		}
+
+		if escrow.EtcdMember != "" {
+			if replaceNodeCmdFlags.controlPlaneNode == "" {
+				return fmt.Errorf("--control-plane-node is required to remove etcd member %q", escrow.EtcdMember)
+			}
+
+			if err = c.EtcdRemoveMember(client.WithNodes(ctx, replaceNodeCmdFlags.controlPlaneNode), &machine.EtcdRemoveMemberRequest{
+				Member: escrow.EtcdMember,
+			}); err != nil {
+				return fmt.Errorf("error removing etcd member: %w", err)
+			}
+		}
+	case fenceNone:
+	}
+
+	fmt.Printf("apply %s to the replacement machine, and uncordon node %q once it joins\n",
+		filepath.Join(replaceNodeCmdFlags.outputDir, "config.yaml"), escrow.NodeName)
+
+	return nil
+}
+
+func fetchIdentity(ctx context.Context, c *client.Client) (*clusterres.IdentitySpec, error) {
+	responses, err := c.Resources.Get(ctx, clusterres.NamespaceName, clusterres.IdentityType, clusterres.LocalIdentity)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, resp := range responses {
+		if resp.Resource == nil {
+			continue
+		}
+
+		body, err := yaml.Marshal(resp.Resource.Spec())
+		if err != nil {
+			return nil, err
+		}
+
+		var spec clusterres.IdentitySpec
+
+		if err = yaml.Unmarshal(body, &spec); err != nil {
+			return nil, err
+		}
+
+		return &spec, nil
+	}
+
+	return nil, fmt.Errorf("identity resource not found")
+}
+
+type nodeGetter interface {
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*corev1.Node, error)
+	List(ctx context.Context, opts metav1.ListOptions) (*corev1.NodeList, error)
+}
+
+// findKubernetesNode looks up the node by hostname, falling back to the node addresses.
+func findKubernetesNode(ctx context.Context, nodes nodeGetter, hostname, address string) (*corev1.Node, error) {
+	node, err := nodes.Get(ctx, hostname, metav1.GetOptions{})
+	if err == nil {
+		return node, nil
+	}
+
+	if !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("error fetching Kubernetes node: %w", err)
+	}
+
+	list, err := nodes.List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing Kubernetes nodes: %w", err)
+	}
+
+	for i := range list.Items {
+		node := &list.Items[i]
+
+		if strings.HasPrefix(node.Name, hostname+".") {
+			return node, nil
+		}
+
+		for _, addr := range node.Status.Addresses {
+			if addr.Address == address {
+				return node, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("kubernetes node for %q (%s) not found", hostname, address)
+}
+
+// writeEscrow saves the captured state, identity key and the replacement config into the output directory.
+func writeEscrow(ctx context.Context, c *client.Client, escrow *replace.Escrow, config []byte) error {
+	dir := replaceNodeCmdFlags.outputDir
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+
+	escrowBytes, err := yaml.Marshal(escrow)
+	if err != nil {
+		return err
+	}
+
+	if err = ioutil.WriteFile(filepath.Join(dir, "escrow.yaml"), escrowBytes, 0o600); err != nil {
+		return err
+	}
+
+	if err = ioutil.WriteFile(filepath.Join(dir, "config.yaml"), config, 0o600); err != nil {
+		return err
+	}
+
+	// identity private key is kept for the audit trail, the replacement generates its own identity
+	r, errCh, err := c.Read(ctx, constants.MachineIdentityPath)
+	if err != nil {
+		cli.Warning("failed to read node identity key: %s", err)
+
+		return nil
+	}
+
+	defer r.Close() //nolint:errcheck
+
+	go func() {
+		for err := range errCh {
+			cli.Warning("failed to read node identity key: %s", err)
+		}
+	}()
+
+	key, err := ioutil.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("error reading node identity key: %w", err)
+	}
+
+	if len(key) == 0 {
+		return nil
+	}
+
+	return ioutil.WriteFile(filepath.Join(dir, "identity.pem"), key, 0o600)
+}
+
+func init() {
+	replaceNodeCmd.Flags().StringVarP(&replaceNodeCmdFlags.outputDir, "output-dir", "o", "replacement", "directory to write the captured state and the replacement config to")
+	replaceNodeCmd.Flags().StringVar(&replaceNodeCmdFlags.fence, "fence", fenceReset, fmt.Sprintf("how to fence the replaced node, one of %q", []string{fenceReset, fenceCordon, fenceNone}))
+	replaceNodeCmd.Flags().StringVar(&replaceNodeCmdFlags.controlPlaneNode, "control-plane-node", "", "control plane node to access Kubernetes API and etcd through (defaults to the replaced node for control plane nodes)")
+	addCommand(replaceNodeCmd)
+}
@@ -17,6 +17,7 @@ import (
 var resetCmdFlags struct {
 	graceful           bool
 	reboot             bool
+	deleteNode         bool
 	systemLabelsToWipe []string
 }
 
@@ -40,6 +41,7 @@ var resetCmd = &cobra.Command{
 			if err := c.ResetGeneric(ctx, &machine.ResetRequest{
 				Graceful:               resetCmdFlags.graceful,
 				Reboot:                 resetCmdFlags.reboot,
+				DeleteNode:             resetCmdFlags.deleteNode,
 				SystemPartitionsToWipe: systemPartitionsToWipe,
 			}); err != nil {
 				return fmt.Errorf("error executing reset: %s", err)
@@ -53,6 +55,7 @@ var resetCmd = &cobra.Command{
 func init() {
 	resetCmd.Flags().BoolVar(&resetCmdFlags.graceful, "graceful", true, "if true, attempt to cordon/drain node and leave etcd (if applicable)")
 	resetCmd.Flags().BoolVar(&resetCmdFlags.reboot, "reboot", false, "if true, reboot the node after resetting instead of shutting down")
+	resetCmd.Flags().BoolVar(&resetCmdFlags.deleteNode, "delete-node", false, "if true, delete the Kubernetes node before wiping (requires --graceful)")
 	resetCmd.Flags().StringSliceVar(&resetCmdFlags.systemLabelsToWipe, "system-labels-to-wipe", nil, "if set, just wipe selected system disk partitions by label but keep other partitions intact")
 	addCommand(resetCmd)
 }
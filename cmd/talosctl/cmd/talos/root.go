@@ -7,6 +7,8 @@ package talos
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/spf13/cobra"
 
@@ -23,12 +25,21 @@ var (
 	Endpoints   []string
 	Nodes       []string
 	Cmdcontext  string
+	AllContexts bool
 )
 
 // WithClientNoNodes wraps common code to initialize Talos client and provide cancellable context.
 //
 // WithClientNoNodes doesn't set any node information on request context.
 func WithClientNoNodes(action func(context.Context, *client.Client) error) error {
+	if AllContexts || strings.Contains(Cmdcontext, ",") {
+		return fmt.Errorf("multiple contexts are only supported for read-only commands")
+	}
+
+	return withClientNoNodes(Cmdcontext, action)
+}
+
+func withClientNoNodes(contextName string, action func(context.Context, *client.Client) error) error {
 	return cli.WithContext(context.Background(), func(ctx context.Context) error {
 		cfg, err := config.Open(Talosconfig)
 		if err != nil {
@@ -39,8 +50,8 @@ func WithClientNoNodes(action func(context.Context, *client.Client) error) error
 			client.WithConfig(cfg),
 		}
 
-		if Cmdcontext != "" {
-			opts = append(opts, client.WithContextName(Cmdcontext))
+		if contextName != "" {
+			opts = append(opts, client.WithContextName(contextName))
 		}
 
 		if len(Endpoints) > 0 {
@@ -61,26 +72,110 @@ func WithClientNoNodes(action func(context.Context, *client.Client) error) error
 
 // WithClient builds upon WithClientNoNodes to provide set of nodes on request context based on config & flags.
 func WithClient(action func(context.Context, *client.Client) error) error {
-	return WithClientNoNodes(func(ctx context.Context, c *client.Client) error {
-		if len(Nodes) < 1 {
+	if AllContexts || strings.Contains(Cmdcontext, ",") {
+		return fmt.Errorf("multiple contexts are only supported for read-only commands")
+	}
+
+	return withClient(Cmdcontext, action)
+}
+
+func withClient(contextName string, action func(context.Context, *client.Client) error) error {
+	return withClientNoNodes(contextName, func(ctx context.Context, c *client.Client) error {
+		nodes := Nodes
+
+		if len(nodes) < 1 {
 			configContext := c.GetConfigContext()
 			if configContext == nil {
 				return fmt.Errorf("failed to resolve config context")
 			}
 
-			Nodes = configContext.Nodes
+			nodes = configContext.Nodes
 		}
 
-		if len(Nodes) < 1 {
+		if len(nodes) < 1 {
 			return fmt.Errorf("nodes are not set for the command: please use `--nodes` flag or configuration file to set the nodes to run the command against")
 		}
 
-		ctx = client.WithNodes(ctx, Nodes...)
+		ctx = client.WithNodes(ctx, nodes...)
 
 		return action(ctx, c)
 	})
 }
 
+// WithClientAllContexts builds upon WithClient to run the action against every context
+// selected with `--context ctx1,ctx2` or `--all-contexts` flags.
+//
+// Output of each context is prefixed with the context name section header.
+// WithClientAllContexts should be used only for read-only commands.
+func WithClientAllContexts(action func(context.Context, *client.Client) error) error {
+	contexts, err := selectedContexts()
+	if err != nil {
+		return err
+	}
+
+	if len(contexts) == 1 {
+		return withClient(contexts[0], action)
+	}
+
+	var failed []string
+
+	for _, contextName := range contexts {
+		fmt.Printf("=== CONTEXT %s ===\n", contextName)
+
+		if err = withClient(contextName, action); err != nil {
+			cli.Warning("%s", err)
+
+			failed = append(failed, contextName)
+		}
+
+		fmt.Println()
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("command failed for contexts: %s", strings.Join(failed, ", "))
+	}
+
+	return nil
+}
+
+// selectedContexts returns the list of contexts to run the command against.
+//
+// Nodes and endpoints set with the flags belong to a single context, so they can't be used with multiple contexts.
+func selectedContexts() ([]string, error) {
+	var contexts []string
+
+	if AllContexts {
+		if Cmdcontext != "" {
+			return nil, fmt.Errorf("--context and --all-contexts flags are mutually exclusive")
+		}
+
+		cfg, err := config.Open(Talosconfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open config file %q: %w", Talosconfig, err)
+		}
+
+		for contextName := range cfg.Contexts {
+			contexts = append(contexts, contextName)
+		}
+
+		if len(contexts) == 0 {
+			return nil, fmt.Errorf("no contexts found in config file %q", Talosconfig)
+		}
+
+		sort.Strings(contexts)
+	} else {
+		for _, contextName := range strings.Split(Cmdcontext, ",") {
+			contexts = append(contexts, strings.TrimSpace(contextName))
+		}
+	}
+
+	if len(contexts) > 1 && (len(Nodes) > 0 || len(Endpoints) > 0) {
+		return nil, fmt.Errorf("--nodes and --endpoints flags can't be used with multiple contexts")
+	}
+
+	return contexts, nil
+}
+
 // Commands is a list of commands published by the package.
 var Commands []*cobra.Command
 
@@ -0,0 +1,132 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package talos
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/pkg/machinery/client/config"
+)
+
+func TestSelectedContexts(t *testing.T) {
+	dir := t.TempDir()
+
+	talosconfig := filepath.Join(dir, "talosconfig")
+
+	require.NoError(t, (&config.Config{
+		Context: "prod",
+		Contexts: map[string]*config.Context{
+			"prod":    {Endpoints: []string{"10.5.0.2"}},
+			"staging": {Endpoints: []string{"10.6.0.2"}},
+			"dev":     {Endpoints: []string{"10.7.0.2"}},
+		},
+	}).Save(talosconfig))
+
+	emptyTalosconfig := filepath.Join(dir, "empty")
+
+	require.NoError(t, (&config.Config{
+		Contexts: map[string]*config.Context{},
+	}).Save(emptyTalosconfig))
+
+	savedTalosconfig, savedCmdcontext, savedAllContexts, savedNodes, savedEndpoints := Talosconfig, Cmdcontext, AllContexts, Nodes, Endpoints
+
+	defer func() {
+		Talosconfig, Cmdcontext, AllContexts, Nodes, Endpoints = savedTalosconfig, savedCmdcontext, savedAllContexts, savedNodes, savedEndpoints
+	}()
+
+	for _, tt := range []struct {
+		name        string
+		talosconfig string
+		context     string
+		allContexts bool
+		nodes       []string
+		endpoints   []string
+
+		expected      []string
+		expectedError string
+	}{
+		{
+			name:     "current context",
+			expected: []string{""},
+		},
+		{
+			name:     "single context",
+			context:  "staging",
+			expected: []string{"staging"},
+		},
+		{
+			name:      "single context with nodes and endpoints",
+			context:   "staging",
+			nodes:     []string{"10.6.0.3"},
+			endpoints: []string{"10.6.0.2"},
+			expected:  []string{"staging"},
+		},
+		{
+			name:     "multiple contexts",
+			context:  "prod, staging",
+			expected: []string{"prod", "staging"},
+		},
+		{
+			name:          "multiple contexts with nodes",
+			context:       "prod,staging",
+			nodes:         []string{"10.5.0.3"},
+			expectedError: "--nodes and --endpoints flags can't be used with multiple contexts",
+		},
+		{
+			name:          "multiple contexts with endpoints",
+			context:       "prod,staging",
+			endpoints:     []string{"10.5.0.2"},
+			expectedError: "--nodes and --endpoints flags can't be used with multiple contexts",
+		},
+		{
+			name:        "all contexts",
+			talosconfig: talosconfig,
+			allContexts: true,
+			expected:    []string{"dev", "prod", "staging"},
+		},
+		{
+			name:          "all contexts with nodes",
+			talosconfig:   talosconfig,
+			allContexts:   true,
+			nodes:         []string{"10.5.0.3"},
+			expectedError: "--nodes and --endpoints flags can't be used with multiple contexts",
+		},
+		{
+			name:          "all contexts with context",
+			talosconfig:   talosconfig,
+			context:       "prod",
+			allContexts:   true,
+			expectedError: "--context and --all-contexts flags are mutually exclusive",
+		},
+		{
+			name:          "all contexts without contexts",
+			talosconfig:   emptyTalosconfig,
+			allContexts:   true,
+			expectedError: "no contexts found in config file",
+		},
+	} {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			Talosconfig, Cmdcontext, AllContexts, Nodes, Endpoints = tt.talosconfig, tt.context, tt.allContexts, tt.nodes, tt.endpoints
+
+			contexts, err := selectedContexts()
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, contexts)
+		})
+	}
+}
@@ -0,0 +1,93 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package talos
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/talos-systems/talos/pkg/cluster"
+	k8s "github.com/talos-systems/talos/pkg/cluster/kubernetes"
+	"github.com/talos-systems/talos/pkg/machinery/client"
+)
+
+var rotateSecretsCmdFlags struct {
+	rotateOptions        k8s.RotateOptions
+	controlPlaneEndpoint string
+}
+
+// rotateSecretsCmd represents the rotate-secrets command.
+var rotateSecretsCmd = &cobra.Command{
+	Use:   "rotate-secrets",
+	Short: "Rotate the bootstrap token and the encryption secret in the Talos cluster.",
+	Long: `Command generates the new Kubernetes bootstrap token and the new key for the encryption of secret data at rest,
+and updates the machine config of all the nodes discovered via the Kubernetes API.
+
+The bootstrap token is updated on all the nodes, then the previous token is removed.
+
+The encryption key is rotated on the control plane nodes one node at a time, waiting for kube-apiserver to be restarted
+after each update:
+  1. the new key is added as the decryption-only key
+  2. the new key becomes the encryption key, the previous key is kept for decryption
+  3. all the secrets are re-encrypted with the new key
+  4. the previous key is removed
+
+The command can be run against any control plane node, and it should be run again if it was interrupted:
+the encryption key rotation is resumed with the key found in the node configs, and if the nodes have different
+bootstrap tokens, all of them are replaced with the new token.
+If the command was interrupted after all the nodes were updated, but before the previous bootstrap token was removed,
+remove the printed token with 'kubectl -n kube-system delete secret bootstrap-token-<id>'.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if !rotateSecretsCmdFlags.rotateOptions.BootstrapToken && !rotateSecretsCmdFlags.rotateOptions.EncryptionSecret {
+			return fmt.Errorf("nothing to rotate: both --bootstrap-token and --encryption-secret are disabled")
+		}
+
+		return WithClient(rotateSecrets)
+	},
+}
+
+func rotateSecrets(ctx context.Context, c *client.Client) error {
+	clientProvider := &cluster.ConfigClientProvider{
+		DefaultClient: c,
+	}
+	defer clientProvider.Close() //nolint:errcheck
+
+	state := struct {
+		cluster.ClientProvider
+		cluster.K8sProvider
+	}{
+		ClientProvider: clientProvider,
+		K8sProvider: &cluster.KubernetesClient{
+			ClientProvider: clientProvider,
+			ForceEndpoint:  rotateSecretsCmdFlags.controlPlaneEndpoint,
+		},
+	}
+
+	nodes := client.NodesFromContext(ctx)
+	if len(nodes) < 1 {
+		return fmt.Errorf("nodes are not set for the command")
+	}
+
+	selfHosted, err := k8s.IsSelfHostedControlPlane(ctx, &state, nodes[0])
+	if err != nil {
+		return fmt.Errorf("error checking self-hosted status: %w", err)
+	}
+
+	if selfHosted {
+		return fmt.Errorf("secrets rotation is not supported for the self-hosted control plane, convert it to the static pods first with 'talosctl convert-k8s'")
+	}
+
+	return k8s.RotateSecrets(ctx, &state, rotateSecretsCmdFlags.rotateOptions)
+}
+
+func init() {
+	rotateSecretsCmd.Flags().BoolVar(&rotateSecretsCmdFlags.rotateOptions.BootstrapToken, "bootstrap-token", true, "rotate the Kubernetes bootstrap token")
+	rotateSecretsCmd.Flags().BoolVar(&rotateSecretsCmdFlags.rotateOptions.EncryptionSecret, "encryption-secret", true, "rotate the key for the encryption of secret data at rest")
+	rotateSecretsCmd.Flags().StringVar(&rotateSecretsCmdFlags.controlPlaneEndpoint, "endpoint", "", "the cluster control plane endpoint")
+	addCommand(rotateSecretsCmd)
+}
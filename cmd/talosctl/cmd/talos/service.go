@@ -20,6 +20,10 @@ import (
 	"github.com/talos-systems/talos/pkg/machinery/client"
 )
 
+var serviceCmdFlags struct {
+	graph string
+}
+
 // serviceCmd represents the service command.
 var serviceCmd = &cobra.Command{
 	Use:     "service [<id> [start|stop|restart|status]]",
@@ -27,8 +31,20 @@ var serviceCmd = &cobra.Command{
 	Short:   "Retrieve the state of a service (or all services), control service state",
 	Long: `Service control command. If run without arguments, lists all the services and their state.
 If service ID is specified, default action 'status' is executed which shows status of a single list service.
-With actions 'start', 'stop', 'restart', service state is updated respectively.`,
+With actions 'start', 'stop', 'restart', service state is updated respectively.
+
+With '--graph' flag, services and their dependencies are printed as a graphviz graph (or as JSON with '--graph=json'),
+which helps to find out which service or condition the service is waiting for:
+
+  talosctl services --graph | dot -Tpng > services.png`,
 	Args: cobra.MaximumNArgs(2),
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 1 {
+			return completionResult(toComplete, []string{"start", "stop", "restart", "status"})
+		}
+
+		return completeServiceIDs(cmd, args, toComplete)
+	},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		action := "status"
 		serviceID := ""
@@ -42,6 +58,14 @@ With actions 'start', 'stop', 'restart', service state is updated respectively.`
 		}
 
 		return WithClient(func(ctx context.Context, c *client.Client) error {
+			if serviceCmdFlags.graph != "" {
+				if serviceID != "" {
+					return fmt.Errorf("service ID can't be used with --graph")
+				}
+
+				return serviceGraph(ctx, c, serviceCmdFlags.graph, os.Stdout)
+			}
+
 			switch action {
 			case "status":
 				if serviceID == "" {
@@ -274,4 +298,7 @@ func (svc serviceInfoWrapper) HealthStatus() string {
 
 func init() {
 	addCommand(serviceCmd)
+
+	serviceCmd.Flags().StringVar(&serviceCmdFlags.graph, "graph", "", "print services and their dependencies as a graph (dot, json)")
+	serviceCmd.Flags().Lookup("graph").NoOptDefVal = "dot"
 }
@@ -0,0 +1,152 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package talos
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/emicklei/dot"
+	"gopkg.in/yaml.v3"
+
+	"github.com/talos-systems/talos/cmd/talosctl/pkg/talos/helpers"
+	"github.com/talos-systems/talos/pkg/machinery/client"
+	"github.com/talos-systems/talos/pkg/resources/v1alpha1"
+)
+
+// serviceNode is a service with its dependencies as reported by the node.
+type serviceNode struct {
+	Node      string   `json:"node,omitempty"`
+	ID        string   `json:"id"`
+	State     string   `json:"state"`
+	Running   bool     `json:"running"`
+	Healthy   bool     `json:"healthy"`
+	Message   string   `json:"message,omitempty"`
+	DependsOn []string `json:"dependsOn"`
+}
+
+func serviceGraph(ctx context.Context, c *client.Client, format string, w io.Writer) error {
+	var services []serviceNode
+
+	if err := helpers.ForEachResource(ctx, c, func(ctx context.Context, msg client.ResourceResponse) error {
+		if msg.Resource == nil {
+			return nil
+		}
+
+		body, err := yaml.Marshal(msg.Resource.Spec())
+		if err != nil {
+			return err
+		}
+
+		var spec v1alpha1.ServiceSpec
+
+		if err = yaml.Unmarshal(body, &spec); err != nil {
+			return fmt.Errorf("error decoding service %q: %w", msg.Resource.Metadata().ID(), err)
+		}
+
+		services = append(services, serviceNode{
+			Node:      msg.Metadata.GetHostname(),
+			ID:        msg.Resource.Metadata().ID(),
+			State:     spec.State,
+			Running:   spec.Running,
+			Healthy:   spec.Healthy,
+			Message:   spec.Message,
+			DependsOn: spec.DependsOn,
+		})
+
+		return nil
+	}, v1alpha1.NamespaceName, string(v1alpha1.ServiceType)); err != nil {
+		return fmt.Errorf("error listing services: %w", err)
+	}
+
+	sort.Slice(services, func(i, j int) bool {
+		if services[i].Node != services[j].Node {
+			return services[i].Node < services[j].Node
+		}
+
+		return services[i].ID < services[j].ID
+	})
+
+	switch format {
+	case "dot":
+		serviceDotGraph(services).Write(w)
+
+		return nil
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+
+		return enc.Encode(services)
+	default:
+		return fmt.Errorf("unsupported graph format %q, supported: dot, json", format)
+	}
+}
+
+// serviceDotGraph builds the graph with edges pointing from the service to its dependencies.
+func serviceDotGraph(services []serviceNode) *dot.Graph {
+	graph := dot.NewGraph(dot.Directed)
+
+	subgraphs := map[string]*dot.Graph{}
+
+	nodeGraph := func(node string) *dot.Graph {
+		if node == "" {
+			return graph
+		}
+
+		if _, ok := subgraphs[node]; !ok {
+			subgraphs[node] = graph.Subgraph(node, dot.ClusterOption{})
+		}
+
+		return subgraphs[node]
+	}
+
+	nodeID := func(node, id string) string {
+		if node == "" {
+			return id
+		}
+
+		return node + "/" + id
+	}
+
+	for _, svc := range services {
+		label := fmt.Sprintf("%s\n%s", svc.ID, svc.State)
+
+		if !svc.Healthy && svc.Message != "" {
+			label += "\n" + svc.Message
+		}
+
+		nodeGraph(svc.Node).Node(nodeID(svc.Node, svc.ID)).
+			Box().
+			Label(label).
+			Attr("style", "filled").
+			Attr("fillcolor", svc.color())
+	}
+
+	for _, svc := range services {
+		g := nodeGraph(svc.Node)
+
+		for _, dependency := range svc.DependsOn {
+			g.Edge(g.Node(nodeID(svc.Node, svc.ID)), g.Node(nodeID(svc.Node, dependency)))
+		}
+	}
+
+	return graph
+}
+
+func (svc serviceNode) color() string {
+	switch {
+	case svc.Healthy, svc.State == "Finished", svc.State == "Skipped":
+		return "palegreen"
+	case svc.State == "Failed":
+		return "salmon"
+	case svc.Running:
+		return "lightblue"
+	default:
+		return "khaki"
+	}
+}
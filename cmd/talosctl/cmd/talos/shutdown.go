@@ -13,6 +13,11 @@ import (
 	"github.com/talos-systems/talos/pkg/machinery/client"
 )
 
+var shutdownCmdFlags struct {
+	force bool
+	drain bool
+}
+
 // shutdownCmd represents the shutdown command.
 var shutdownCmd = &cobra.Command{
 	Use:   "shutdown",
@@ -20,8 +25,18 @@ var shutdownCmd = &cobra.Command{
 	Long:  ``,
 	Args:  cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		var opts []client.ShutdownOptionFunc
+
+		if shutdownCmdFlags.force {
+			opts = append(opts, client.WithShutdownForce())
+		}
+
+		if shutdownCmdFlags.drain {
+			opts = append(opts, client.WithShutdownDrain())
+		}
+
 		return WithClient(func(ctx context.Context, c *client.Client) error {
-			if err := c.Shutdown(ctx); err != nil {
+			if err := c.Shutdown(ctx, opts...); err != nil {
 				return fmt.Errorf("error executing shutdown: %s", err)
 			}
 
@@ -31,5 +46,7 @@ var shutdownCmd = &cobra.Command{
 }
 
 func init() {
+	shutdownCmd.Flags().BoolVar(&shutdownCmdFlags.force, "force", false, "power off immediately without stopping pods and services gracefully")
+	shutdownCmd.Flags().BoolVar(&shutdownCmdFlags.drain, "drain", false, "cordon and drain the node before shutting it down")
 	addCommand(shutdownCmd)
 }
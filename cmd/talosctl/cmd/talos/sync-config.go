@@ -0,0 +1,66 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package talos
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+
+	"github.com/talos-systems/talos/pkg/cli"
+	machineapi "github.com/talos-systems/talos/pkg/machinery/api/machine"
+	"github.com/talos-systems/talos/pkg/machinery/client"
+)
+
+// syncConfigCmd represents the sync-config command.
+var syncConfigCmd = &cobra.Command{
+	Use:   "sync-config",
+	Short: "Overwrite the stored machine config with the active config",
+	Long: `Overwrite the machine config stored on disk with the config which is currently active on the node.
+
+Config drift status can be inspected with 'talosctl get driftstatuses'.
+Any config staged with 'talosctl apply-config --on-reboot' is discarded.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return WithClient(func(ctx context.Context, c *client.Client) error {
+			var remotePeer peer.Peer
+
+			resp, err := c.SyncConfiguration(ctx, &machineapi.SyncConfigurationRequest{}, grpc.Peer(&remotePeer))
+			if err != nil {
+				if resp == nil {
+					return fmt.Errorf("error syncing config: %w", err)
+				}
+
+				cli.Warning("%s", err)
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+			fmt.Fprintln(w, "NODE\tPREVIOUS\tCURRENT")
+
+			defaultNode := client.AddrFromPeer(&remotePeer)
+
+			for _, msg := range resp.Messages {
+				node := defaultNode
+
+				if msg.Metadata != nil {
+					node = msg.Metadata.Hostname
+				}
+
+				fmt.Fprintf(w, "%s\t%s\t%s\n", node, msg.PreviousChecksum, msg.Checksum)
+			}
+
+			return w.Flush()
+		})
+	},
+}
+
+func init() {
+	addCommand(syncConfigCmd)
+}
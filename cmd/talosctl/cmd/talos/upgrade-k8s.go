@@ -56,7 +56,12 @@ func upgradeKubernetes(ctx context.Context, c *client.Client) error {
 		},
 	}
 
-	selfHosted, err := k8s.IsSelfHostedControlPlane(ctx, &state, Nodes[0])
+	nodes := client.NodesFromContext(ctx)
+	if len(nodes) < 1 {
+		return fmt.Errorf("nodes are not set for the command")
+	}
+
+	selfHosted, err := k8s.IsSelfHostedControlPlane(ctx, &state, nodes[0])
 	if err != nil {
 		return fmt.Errorf("error checking self-hosted status: %w", err)
 	}
@@ -8,31 +8,71 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 	"text/tabwriter"
 	"time"
 
 	"github.com/spf13/cobra"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/peer"
 
 	"github.com/talos-systems/talos/pkg/cli"
+	"github.com/talos-systems/talos/pkg/cluster"
+	clusterupgrade "github.com/talos-systems/talos/pkg/cluster/upgrade"
 	"github.com/talos-systems/talos/pkg/machinery/client"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1/machine"
 )
 
 var (
-	upgradeImage string
-	preserve     bool
-	stage        bool
+	upgradeImage   string
+	preserve       bool
+	stage          bool
+	upgradeWait    bool
+	upgradeTimeout time.Duration
+	upgradeDrain   drainFlags
+
+	upgradeAll         bool
+	upgradeConcurrency int
 )
 
 // upgradeCmd represents the processes command.
 var upgradeCmd = &cobra.Command{
 	Use:   "upgrade",
 	Short: "Upgrade Talos on the target node",
-	Long:  ``,
-	Args:  cobra.NoArgs,
+	Long: `Upgrade Talos on the target node.
+
+With '--wait' flag, nodes are upgraded one by one: talosctl streams the progress of the upgrade
+and waits for the node to come back and report the new version before proceeding to the next node.
+
+With '--drain' flag, nodes are upgraded one by one: the Kubernetes node is cordoned via Talos API,
+and the pods are evicted respecting PodDisruptionBudgets before the upgrade.
+The upgrade is aborted if the pods can't be evicted.
+
+With '--all' flag, all the nodes of the cluster (as registered in Kubernetes) are upgraded:
+control plane nodes go first one by one, and only while all the control plane nodes are healthy,
+so that etcd keeps the quorum; worker nodes are upgraded next in batches of '--concurrency' nodes.
+Each node is expected to come back with the new version and become healthy before the upgrade proceeds.`,
+	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return upgrade()
+		return WithClient(func(ctx context.Context, c *client.Client) error {
+			if upgradeAll {
+				return upgradeCluster(ctx, c)
+			}
+
+			action := upgrade
+			if upgradeWait {
+				action = upgradeAndWait
+			}
+
+			if upgradeDrain.drain {
+				return forEachDrainedNode(ctx, c, &upgradeDrain, func(ctx context.Context) error {
+					return action(ctx, c)
+				})
+			}
+
+			return action(ctx, c)
+		})
 	},
 }
 
@@ -40,40 +80,143 @@ func init() {
 	upgradeCmd.Flags().StringVarP(&upgradeImage, "image", "i", "", "the container image to use for performing the install")
 	upgradeCmd.Flags().BoolVarP(&preserve, "preserve", "p", false, "preserve data")
 	upgradeCmd.Flags().BoolVarP(&stage, "stage", "s", false, "stage the upgrade to perform it after a reboot")
-	upgradeCmd.Flags().BoolVarP(&force, "force", "f", false, "force the upgrade (skip checks on etcd health and members, might lead to data loss)")
+	upgradeCmd.Flags().BoolVarP(&force, "force", "f", false, "force the upgrade (skip checks on etcd health and members, might lead to data loss; machine config compatibility failures are reported as warnings)")
+	upgradeCmd.Flags().BoolVar(&upgradeWait, "wait", false, "wait for the upgrade to finish and the node to report the new version")
+	upgradeCmd.Flags().DurationVar(&upgradeTimeout, "timeout", 30*time.Minute, "time to wait for the upgrade of each node to finish")
+	upgradeCmd.Flags().BoolVar(&upgradeAll, "all", false, "upgrade all the nodes of the cluster: control plane nodes one by one, then worker nodes in batches")
+	upgradeCmd.Flags().IntVar(&upgradeConcurrency, "concurrency", 1, "number of worker nodes upgraded at once (with --all)")
+	upgradeDrain.addFlags(upgradeCmd.Flags(), "upgrading")
 	addCommand(upgradeCmd)
 }
 
-func upgrade() error {
-	return WithClient(func(ctx context.Context, c *client.Client) error {
-		var remotePeer peer.Peer
+func upgrade(ctx context.Context, c *client.Client) error {
+	var remotePeer peer.Peer
 
-		// TODO: See if we can validate version and prevent starting upgrades to
-		// an unknown version
-		resp, err := c.Upgrade(ctx, upgradeImage, preserve, stage, force, grpc.Peer(&remotePeer))
-		if err != nil {
-			if resp == nil {
-				return fmt.Errorf("error performing upgrade: %s", err)
-			}
+	// TODO: See if we can validate version and prevent starting upgrades to
+	// an unknown version
+	resp, err := c.Upgrade(ctx, upgradeImage, preserve, stage, force, grpc.Peer(&remotePeer))
+	if err != nil {
+		if resp == nil {
+			return fmt.Errorf("error performing upgrade: %s", err)
+		}
+
+		cli.Warning("%s", err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "NODE\tACK\tSTARTED")
+
+	defaultNode := client.AddrFromPeer(&remotePeer)
+
+	for _, msg := range resp.Messages {
+		node := defaultNode
+
+		if msg.Metadata != nil {
+			node = msg.Metadata.Hostname
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t\n", node, msg.Ack, time.Now())
+	}
+
+	return w.Flush()
+}
+
+func upgradeAndWait(ctx context.Context, c *client.Client) error {
+	return runAndWait(ctx, c, upgradeActor, checkUpgradedVersion, upgradeTimeout)
+}
+
+func upgradeActor(ctx context.Context, c *client.Client) (string, error) {
+	resp, err := c.Upgrade(ctx, upgradeImage, preserve, stage, force)
+	if err != nil {
+		return "", fmt.Errorf("error performing upgrade: %s", err)
+	}
+
+	if len(resp.GetMessages()) == 0 {
+		return "", fmt.Errorf("empty upgrade response")
+	}
 
-			cli.Warning("%s", err)
+	return resp.GetMessages()[0].GetActorId(), nil
+}
+
+// upgradeCluster upgrades all the nodes of the cluster, nodes are discovered via Kubernetes API.
+func upgradeCluster(ctx context.Context, c *client.Client) error {
+	clientProvider := &cluster.ConfigClientProvider{
+		DefaultClient: c,
+	}
+	defer clientProvider.Close() //nolint:errcheck
+
+	k8sProvider := &cluster.KubernetesClient{
+		ClientProvider: clientProvider,
+	}
+
+	// admin kubeconfig is fetched from the endpoint (control plane node), node actions target the nodes explicitly
+	ctx = metadata.NewOutgoingContext(ctx, metadata.MD{})
+
+	kubeHelper, err := k8sProvider.K8sHelper(ctx)
+	if err != nil {
+		return fmt.Errorf("error building Kubernetes client: %w", err)
+	}
+
+	options := &clusterupgrade.Options{
+		Concurrency: upgradeConcurrency,
+		Upgrade: func(ctx context.Context, node string) error {
+			return runAndWaitNode(client.WithNodes(ctx, node), c, node, upgradeActor, checkUpgradedVersion, upgradeTimeout)
+		},
+		Health: func(ctx context.Context, node string) error {
+			return waitNodeHealthy(client.WithNodes(ctx, node), c, node, upgradeTimeout)
+		},
+		Out: os.Stdout,
+	}
+
+	if options.ControlPlaneNodes, err = kubeHelper.NodeIPs(ctx, machine.TypeControlPlane); err != nil {
+		return fmt.Errorf("error listing control plane nodes: %w", err)
+	}
+
+	if options.WorkerNodes, err = kubeHelper.NodeIPs(ctx, machine.TypeJoin); err != nil {
+		return fmt.Errorf("error listing worker nodes: %w", err)
+	}
+
+	if upgradeDrain.drain {
+		drainer := &cluster.Drainer{
+			K8sProvider: k8sProvider,
+			Kubeconfig:  upgradeDrain.kubeconfig,
+			Out:         os.Stdout,
+		}
+
+		options.Drain = func(ctx context.Context, node string) error {
+			return drainer.Drain(ctx, c, node)
 		}
+	}
 
-		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-		fmt.Fprintln(w, "NODE\tACK\tSTARTED")
+	return clusterupgrade.Run(ctx, options)
+}
 
-		defaultNode := client.AddrFromPeer(&remotePeer)
+// checkUpgradedVersion verifies that the node reports the version of the installer image.
+func checkUpgradedVersion(ctx context.Context, c *client.Client, node string) error {
+	resp, err := c.Version(ctx)
+	if err != nil {
+		return err
+	}
 
-		for _, msg := range resp.Messages {
-			node := defaultNode
+	if len(resp.GetMessages()) == 0 {
+		return fmt.Errorf("empty version response")
+	}
 
-			if msg.Metadata != nil {
-				node = msg.Metadata.Hostname
-			}
+	version := resp.GetMessages()[0].GetVersion().GetTag()
 
-			fmt.Fprintf(w, "%s\t%s\t%s\t\n", node, msg.Ack, time.Now())
+	// image reference might not have a tag (e.g. it's pinned by the digest)
+	expected := ""
+	if name := upgradeImage[strings.LastIndex(upgradeImage, "/")+1:]; !strings.Contains(name, "@") {
+		if idx := strings.LastIndex(name, ":"); idx != -1 {
+			expected = name[idx+1:]
 		}
+	}
+
+	if expected != "" && version != expected {
+		return fmt.Errorf("node reports version %q, expected %q", version, expected)
+	}
+
+	fmt.Printf("%s: upgraded to %s\n", node, version)
 
-		return w.Flush()
-	})
+	return nil
 }
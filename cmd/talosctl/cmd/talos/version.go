@@ -43,7 +43,7 @@ var versionCmd = &cobra.Command{
 
 		fmt.Println("Server:")
 
-		return WithClient(func(ctx context.Context, c *client.Client) error {
+		return WithClientAllContexts(func(ctx context.Context, c *client.Client) error {
 			var remotePeer peer.Peer
 
 			resp, err := c.Version(ctx, grpc.Peer(&remotePeer))
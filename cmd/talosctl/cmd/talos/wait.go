@@ -0,0 +1,187 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package talos
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/talos-systems/go-retry/retry"
+
+	"github.com/talos-systems/talos/pkg/machinery/api/machine"
+	"github.com/talos-systems/talos/pkg/machinery/client"
+)
+
+// actorFunc starts a long-running operation on a single node and returns the actor ID of the operation.
+type actorFunc func(ctx context.Context, c *client.Client) (string, error)
+
+// checkFunc verifies the node state once it is back after the reboot.
+type checkFunc func(ctx context.Context, c *client.Client, node string) error
+
+// runAndWait runs the operation node by node, follows the progress of the operation
+// via the events of the operation actor and waits for the node to come back after the reboot.
+func runAndWait(ctx context.Context, c *client.Client, action actorFunc, check checkFunc, timeout time.Duration) error {
+	for _, node := range client.NodesFromContext(ctx) {
+		if err := runAndWaitNode(client.WithNodes(ctx, node), c, node, action, check, timeout); err != nil {
+			return fmt.Errorf("node %q: %w", node, err)
+		}
+	}
+
+	return nil
+}
+
+//nolint:gocyclo
+func runAndWaitNode(ctx context.Context, c *client.Client, node string, action actorFunc, check checkFunc, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	bootIDBefore, err := readBootID(ctx, c)
+	if err != nil {
+		return fmt.Errorf("error reading boot ID: %w", err)
+	}
+
+	actorID, err := action(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	// stream the progress of the operation until the node goes down
+	var sequenceErr error
+
+	watchCtx, watchCancel := context.WithCancel(ctx)
+	defer watchCancel()
+
+	//nolint:errcheck
+	c.EventsWatch(watchCtx, func(ch <-chan client.Event) {
+		for event := range ch {
+			switch msg := event.Payload.(type) {
+			case *machine.SequenceEvent:
+				if msg.GetError() != nil {
+					sequenceErr = fmt.Errorf("sequence %s failed: %s", msg.GetSequence(), msg.GetError().GetMessage())
+
+					watchCancel()
+
+					return
+				}
+
+				fmt.Printf("%s: sequence %s: %s\n", node, msg.GetSequence(), strings.ToLower(msg.GetAction().String()))
+			case *machine.PhaseEvent:
+				fmt.Printf("%s: phase %s: %s\n", node, msg.GetPhase(), strings.ToLower(msg.GetAction().String()))
+			case *machine.TaskEvent:
+				fmt.Printf("%s: task %s: %s\n", node, msg.GetTask(), strings.ToLower(msg.GetAction().String()))
+			}
+		}
+	}, client.WithTailEvents(-1), client.WithActorID(actorID))
+
+	if sequenceErr != nil {
+		return sequenceErr
+	}
+
+	fmt.Printf("%s: waiting for the node to come back\n", node)
+
+	return retry.Constant(timeout, retry.WithUnits(5*time.Second)).RetryWithContext(ctx, func(ctx context.Context) error {
+		reqCtx, reqCtxCancel := context.WithTimeout(ctx, 5*time.Second)
+		defer reqCtxCancel()
+
+		bootID, err := readBootID(reqCtx, c)
+		if err != nil {
+			// API is unavailable while the node reboots
+			return retry.ExpectedError(err)
+		}
+
+		if bootID == bootIDBefore {
+			return retry.ExpectedError(fmt.Errorf("node hasn't rebooted yet"))
+		}
+
+		if check != nil {
+			return check(reqCtx, c, node)
+		}
+
+		fmt.Printf("%s: node is back\n", node)
+
+		return nil
+	})
+}
+
+func readBootID(ctx context.Context, c *client.Client) (string, error) {
+	r, errCh, err := c.Read(ctx, "/proc/sys/kernel/random/boot_id")
+	if err != nil {
+		return "", err
+	}
+
+	defer r.Close() //nolint:errcheck
+
+	body, err := ioutil.ReadAll(r)
+	if err != nil {
+		return "", err
+	}
+
+	for err = range errCh {
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return strings.TrimSpace(string(body)), nil
+}
+
+// waitNodeHealthy waits for the node to pass checkNodeHealthy.
+func waitNodeHealthy(ctx context.Context, c *client.Client, node string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	return retry.Constant(timeout, retry.WithUnits(5*time.Second)).RetryWithContext(ctx, func(ctx context.Context) error {
+		reqCtx, reqCtxCancel := context.WithTimeout(ctx, 10*time.Second)
+		defer reqCtxCancel()
+
+		if err := checkNodeHealthy(reqCtx, c); err != nil {
+			return retry.ExpectedError(err)
+		}
+
+		fmt.Printf("%s: node is healthy\n", node)
+
+		return nil
+	})
+}
+
+// checkNodeHealthy verifies that the services of the node are running and healthy.
+//
+// Control plane nodes (nodes running etcd) are expected to have healthy etcd: etcd health check
+// does a linearizable read, so it only passes if the cluster has the quorum.
+func checkNodeHealthy(ctx context.Context, c *client.Client) error {
+	resp, err := c.ServiceList(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(resp.GetMessages()) == 0 {
+		return fmt.Errorf("empty service list response")
+	}
+
+	for _, svc := range resp.GetMessages()[0].GetServices() {
+		switch svc.GetState() {
+		case "Running":
+		case "Finished", "Skipped":
+			continue
+		default:
+			return fmt.Errorf("service %q is not running: %s", svc.GetId(), svc.GetState())
+		}
+
+		health := svc.GetHealth()
+
+		if svc.GetId() == "etcd" && !health.GetHealthy() {
+			return fmt.Errorf("etcd is not healthy: %s", health.GetLastMessage())
+		}
+
+		if !health.GetUnknown() && !health.GetHealthy() {
+			return fmt.Errorf("service %q is not healthy: %s", svc.GetId(), health.GetLastMessage())
+		}
+	}
+
+	return nil
+}
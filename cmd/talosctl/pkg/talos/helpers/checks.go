@@ -8,17 +8,12 @@ import (
 	"context"
 	"fmt"
 
-	"google.golang.org/grpc/metadata"
+	"github.com/talos-systems/talos/pkg/machinery/client"
 )
 
 // FailIfMultiNodes checks if ctx contains multi-node request metadata.
 func FailIfMultiNodes(ctx context.Context, command string) error {
-	md, ok := metadata.FromOutgoingContext(ctx)
-	if !ok {
-		return nil
-	}
-
-	if len(md.Get("nodes")) <= 1 {
+	if len(client.NodesFromContext(ctx)) <= 1 {
 		return nil
 	}
 
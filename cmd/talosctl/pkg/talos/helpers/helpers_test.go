@@ -37,3 +37,28 @@ func TestExtractFileFromTarGz(t *testing.T) {
 	_, err = helpers.ExtractFileFromTarGz("void", file)
 	assert.Error(t, err)
 }
+
+func TestParseRemotePath(t *testing.T) {
+	for _, tt := range []struct {
+		arg  string
+		node string
+		path string
+		ok   bool
+	}{
+		{"172.20.0.2:/var/log", "172.20.0.2", "/var/log", true},
+		{":/var/log", "", "/var/log", true},
+		{"[fd00::1]:/var/log", "fd00::1", "/var/log", true},
+		{"worker-1:/", "worker-1", "/", true},
+		{"/var/log", "", "", false},
+		{"./foo", "", "", false},
+		{"C:/tmp/foo", "", "", false},
+		{"c:/tmp/foo", "", "", false},
+		{"n1:/tmp/foo", "n1", "/tmp/foo", true},
+	} {
+		node, path, ok := helpers.ParseRemotePath(tt.arg)
+
+		assert.Equal(t, tt.ok, ok, tt.arg)
+		assert.Equal(t, tt.node, node, tt.arg)
+		assert.Equal(t, tt.path, path, tt.arg)
+	}
+}
@@ -0,0 +1,38 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package helpers
+
+import (
+	"strings"
+)
+
+// ParseRemotePath splits `<node>:<path>` notation into the node and the path.
+//
+// Path should be absolute, node might be empty (`:/var/foo`) which means
+// the default node(s) should be used. IPv6 node addresses might be enclosed in brackets.
+// Single letter nodes are not accepted, as they are Windows drive letters (`C:/tmp/foo`).
+// If the argument doesn't follow the notation, ok is false.
+func ParseRemotePath(arg string) (node, path string, ok bool) {
+	idx := strings.Index(arg, ":/")
+	if idx < 0 {
+		return "", "", false
+	}
+
+	node, path = arg[:idx], arg[idx+1:]
+
+	if len(node) == 1 && isLetter(node[0]) {
+		return "", "", false
+	}
+
+	if strings.HasPrefix(node, "[") && strings.HasSuffix(node, "]") {
+		node = node[1 : len(node)-1]
+	}
+
+	return node, path, true
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
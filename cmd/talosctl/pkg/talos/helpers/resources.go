@@ -12,8 +12,13 @@ import (
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"gopkg.in/yaml.v3"
 
 	"github.com/talos-systems/talos/pkg/machinery/client"
+	talosconfig "github.com/talos-systems/talos/pkg/machinery/config"
+	"github.com/talos-systems/talos/pkg/machinery/config/configloader"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
+	"github.com/talos-systems/talos/pkg/resources/config"
 )
 
 // ForEachResource get resources from the controller runtime and run callback using each element.
@@ -72,3 +77,59 @@ func ForEachResource(ctx context.Context, c *client.Client, callback func(ctx co
 
 	return nil
 }
+
+// MachineConfig fetches current machine configuration of the node.
+func MachineConfig(ctx context.Context, c *client.Client) (*v1alpha1.Config, error) {
+	body, err := MachineConfigBytes(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+
+	provider, err := configloader.NewFromBytes(body)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, ok := provider.(*v1alpha1.Config)
+	if !ok {
+		return nil, fmt.Errorf("unexpected config type %T", provider)
+	}
+
+	return cfg, nil
+}
+
+// MachineConfigBytes fetches current machine configuration of the node as YAML.
+func MachineConfigBytes(ctx context.Context, c *client.Client) ([]byte, error) {
+	responses, err := c.Resources.Get(ctx, config.NamespaceName, config.MachineConfigType, config.V1Alpha1ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, resp := range responses {
+		if resp.Resource == nil {
+			continue
+		}
+
+		return yaml.Marshal(resp.Resource.Spec())
+	}
+
+	return nil, fmt.Errorf("machine config resource not found")
+}
+
+// MachineConfigChecksum returns the checksum of the machine configuration fetched from the node.
+//
+// Checksum is passed to the ApplyConfiguration as the expected checksum, so that the config is not applied
+// if it was modified concurrently.
+func MachineConfigChecksum(body []byte) (string, error) {
+	provider, err := configloader.NewFromBytes(body)
+	if err != nil {
+		return "", err
+	}
+
+	return talosconfig.Checksum(provider)
+}
+
+// IsConfigConflict returns true if the config was not applied because it was modified concurrently.
+func IsConfigConflict(err error) bool {
+	return status.Code(err) == codes.Aborted
+}
@@ -0,0 +1,608 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package pcap implements compilation of packet capture filter expressions into BPF programs.
+package pcap
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/bpf"
+)
+
+// SnapLen is the value returned by the compiled filter for matching packets.
+const SnapLen = 262144
+
+// Ethernet frame offsets and constants.
+const (
+	etherTypeOffset = 12
+	etherHeaderLen  = 14
+
+	etherTypeIPv4 = 0x0800
+	etherTypeIPv6 = 0x86dd
+	etherTypeARP  = 0x0806
+
+	ipv4ProtoOffset = etherHeaderLen + 9
+	ipv4FragOffset  = etherHeaderLen + 6
+	ipv4SrcOffset   = etherHeaderLen + 12
+	ipv4DstOffset   = etherHeaderLen + 16
+
+	ipv6NextHeaderOffset = etherHeaderLen + 6
+	ipv6SrcOffset        = etherHeaderLen + 8
+	ipv6DstOffset        = etherHeaderLen + 24
+	ipv6HeaderLen        = 40
+
+	protoICMP   = 1
+	protoTCP    = 6
+	protoUDP    = 17
+	protoICMPv6 = 58
+)
+
+// CompileFilter compiles the filter expression into the BPF program.
+//
+// Filter expression syntax is a subset of pcap-filter(7) syntax which supports
+// protocol primitives (`ip`, `ip6`, `arp`, `tcp`, `udp`, `icmp`, `icmp6`),
+// `host`, `net` and `port` primitives with optional protocol and direction (`src`, `dst`) qualifiers,
+// and `and` (`&&`), `or` (`||`), `not` (`!`) operators with parentheses.
+//
+// Program assumes Ethernet framing. Empty expression compiles to empty program (no filtering).
+func CompileFilter(expr string) ([]bpf.RawInstruction, error) {
+	tokens, err := tokenize(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+
+	p := &parser{tokens: tokens}
+
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if !p.done() {
+		return nil, fmt.Errorf("unexpected %q", p.peek())
+	}
+
+	var g generator
+
+	accept, reject := g.newLabel(), g.newLabel()
+
+	g.gen(root, accept, reject)
+
+	g.place(accept)
+	g.emit(bpf.RetConstant{Val: SnapLen})
+	g.place(reject)
+	g.emit(bpf.RetConstant{Val: 0})
+
+	return g.assemble()
+}
+
+// node is a filter expression tree node.
+type node interface{}
+
+type andNode []node
+
+type orNode []node
+
+type notNode struct {
+	child node
+}
+
+// testNode loads a value and compares it with the constant.
+type testNode struct {
+	loads []bpf.Instruction
+	cond  bpf.JumpTest
+	val   uint32
+}
+
+func loadTest(off uint32, size int, cond bpf.JumpTest, val uint32) testNode {
+	return testNode{
+		loads: []bpf.Instruction{bpf.LoadAbsolute{Off: off, Size: size}},
+		cond:  cond,
+		val:   val,
+	}
+}
+
+func etherTypeTest(etherType uint32) node {
+	return loadTest(etherTypeOffset, 2, bpf.JumpEqual, etherType)
+}
+
+func ipv4ProtoTest(proto uint32) node {
+	return andNode{etherTypeTest(etherTypeIPv4), loadTest(ipv4ProtoOffset, 1, bpf.JumpEqual, proto)}
+}
+
+func ipv6ProtoTest(proto uint32) node {
+	return andNode{etherTypeTest(etherTypeIPv6), loadTest(ipv6NextHeaderOffset, 1, bpf.JumpEqual, proto)}
+}
+
+func tokenize(expr string) ([]string, error) {
+	var tokens []string
+
+	for i := 0; i < len(expr); {
+		c := expr[i]
+
+		switch {
+		case c == ' ' || c == '\t' || c == '\n':
+			i++
+		case c == '(' || c == ')':
+			tokens = append(tokens, string(c))
+			i++
+		case c == '!':
+			tokens = append(tokens, "not")
+			i++
+		case strings.HasPrefix(expr[i:], "&&"):
+			tokens = append(tokens, "and")
+			i += 2
+		case strings.HasPrefix(expr[i:], "||"):
+			tokens = append(tokens, "or")
+			i += 2
+		case isWordChar(c):
+			j := i
+			for j < len(expr) && isWordChar(expr[j]) {
+				j++
+			}
+
+			tokens = append(tokens, expr[i:j])
+			i = j
+		default:
+			return nil, fmt.Errorf("unexpected character %q", c)
+		}
+	}
+
+	return tokens, nil
+}
+
+func isWordChar(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') ||
+		c == '.' || c == ':' || c == '/' || c == '-' || c == '_'
+}
+
+type parser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *parser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *parser) peek() string {
+	if p.done() {
+		return ""
+	}
+
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() string {
+	token := p.peek()
+	p.pos++
+
+	return token
+}
+
+func (p *parser) parseOr() (node, error) {
+	n, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	result := orNode{n}
+
+	for p.peek() == "or" {
+		p.next()
+
+		if n, err = p.parseAnd(); err != nil {
+			return nil, err
+		}
+
+		result = append(result, n)
+	}
+
+	if len(result) == 1 {
+		return result[0], nil
+	}
+
+	return result, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	n, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+
+	result := andNode{n}
+
+	for p.peek() == "and" {
+		p.next()
+
+		if n, err = p.parseNot(); err != nil {
+			return nil, err
+		}
+
+		result = append(result, n)
+	}
+
+	if len(result) == 1 {
+		return result[0], nil
+	}
+
+	return result, nil
+}
+
+func (p *parser) parseNot() (node, error) {
+	switch p.peek() {
+	case "not":
+		p.next()
+
+		n, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+
+		return notNode{n}, nil
+	case "(":
+		p.next()
+
+		n, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		if p.next() != ")" {
+			return nil, fmt.Errorf("missing closing parenthesis")
+		}
+
+		return n, nil
+	default:
+		return p.parsePrimitive()
+	}
+}
+
+//nolint:gocyclo,cyclop
+func (p *parser) parsePrimitive() (node, error) {
+	var proto, dir, typ string
+
+	if token := p.peek(); isProto(token) {
+		proto = p.next()
+	}
+
+	if token := p.peek(); token == "src" || token == "dst" {
+		dir = p.next()
+	}
+
+	if token := p.peek(); token == "host" || token == "net" || token == "port" {
+		typ = p.next()
+	}
+
+	if typ == "" {
+		switch {
+		case dir != "":
+			// `src 10.0.0.1` is a shorthand for `src host 10.0.0.1`
+			typ = "host"
+		case proto != "":
+			return protoPrimitive(proto), nil
+		case p.done():
+			return nil, fmt.Errorf("unexpected end of expression")
+		default:
+			return nil, fmt.Errorf("unexpected %q", p.peek())
+		}
+	}
+
+	if p.done() {
+		return nil, fmt.Errorf("missing %s value", typ)
+	}
+
+	value := p.next()
+
+	switch typ {
+	case "host":
+		ip := net.ParseIP(value)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid host address %q", value)
+		}
+
+		return netPrimitive(proto, dir, ip, net.CIDRMask(len(ipBytes(ip))*8, len(ipBytes(ip))*8))
+	case "net":
+		_, network, err := net.ParseCIDR(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid network %q", value)
+		}
+
+		return netPrimitive(proto, dir, network.IP, network.Mask)
+	case "port":
+		port, err := strconv.ParseUint(value, 10, 16)
+		if err != nil {
+			return nil, fmt.Errorf("invalid port %q", value)
+		}
+
+		return portPrimitive(proto, dir, uint32(port))
+	}
+
+	panic("unreachable")
+}
+
+func isProto(token string) bool {
+	switch token {
+	case "ip", "ip6", "arp", "tcp", "udp", "icmp", "icmp6":
+		return true
+	default:
+		return false
+	}
+}
+
+func protoPrimitive(proto string) node {
+	switch proto {
+	case "ip":
+		return etherTypeTest(etherTypeIPv4)
+	case "ip6":
+		return etherTypeTest(etherTypeIPv6)
+	case "arp":
+		return etherTypeTest(etherTypeARP)
+	case "tcp":
+		return orNode{ipv4ProtoTest(protoTCP), ipv6ProtoTest(protoTCP)}
+	case "udp":
+		return orNode{ipv4ProtoTest(protoUDP), ipv6ProtoTest(protoUDP)}
+	case "icmp":
+		return ipv4ProtoTest(protoICMP)
+	case "icmp6":
+		return ipv6ProtoTest(protoICMPv6)
+	}
+
+	panic("unreachable")
+}
+
+func ipBytes(ip net.IP) net.IP {
+	if ip4 := ip.To4(); ip4 != nil {
+		return ip4
+	}
+
+	return ip
+}
+
+func netPrimitive(proto, dir string, ip net.IP, mask net.IPMask) (node, error) {
+	ip = ipBytes(ip)
+
+	var etherType uint32
+
+	srcOffset, dstOffset := uint32(ipv4SrcOffset), uint32(ipv4DstOffset)
+
+	switch len(ip) {
+	case net.IPv4len:
+		if proto != "" && proto != "ip" {
+			return nil, fmt.Errorf("protocol %q can't be used with IPv4 address", proto)
+		}
+
+		etherType = etherTypeIPv4
+	case net.IPv6len:
+		if proto != "" && proto != "ip6" {
+			return nil, fmt.Errorf("protocol %q can't be used with IPv6 address", proto)
+		}
+
+		etherType = etherTypeIPv6
+		srcOffset, dstOffset = ipv6SrcOffset, ipv6DstOffset
+	}
+
+	addressTest := func(offset uint32) node {
+		var tests andNode
+
+		for i := 0; i < len(ip); i += 4 {
+			m := binary.BigEndian.Uint32(mask[i : i+4])
+			if m == 0 {
+				continue
+			}
+
+			loads := []bpf.Instruction{bpf.LoadAbsolute{Off: offset + uint32(i), Size: 4}}
+
+			if m != 0xffffffff {
+				loads = append(loads, bpf.ALUOpConstant{Op: bpf.ALUOpAnd, Val: m})
+			}
+
+			tests = append(tests, testNode{
+				loads: loads,
+				cond:  bpf.JumpEqual,
+				val:   binary.BigEndian.Uint32(ip[i:i+4]) & m,
+			})
+		}
+
+		return tests
+	}
+
+	return andNode{etherTypeTest(etherType), directional(dir, addressTest(srcOffset), addressTest(dstOffset))}, nil
+}
+
+func portPrimitive(proto, dir string, port uint32) (node, error) {
+	var protos []uint32
+
+	switch proto {
+	case "":
+		protos = []uint32{protoTCP, protoUDP}
+	case "tcp":
+		protos = []uint32{protoTCP}
+	case "udp":
+		protos = []uint32{protoUDP}
+	default:
+		return nil, fmt.Errorf("protocol %q can't be used with port", proto)
+	}
+
+	var ipv4Protos, ipv6Protos orNode
+
+	for _, p := range protos {
+		ipv4Protos = append(ipv4Protos, loadTest(ipv4ProtoOffset, 1, bpf.JumpEqual, p))
+		ipv6Protos = append(ipv6Protos, loadTest(ipv6NextHeaderOffset, 1, bpf.JumpEqual, p))
+	}
+
+	// IPv4 header has variable length, so ports are loaded relative to the header length in X
+	ipv4PortTest := func(offset uint32) node {
+		return testNode{
+			loads: []bpf.Instruction{
+				bpf.LoadMemShift{Off: etherHeaderLen},
+				bpf.LoadIndirect{Off: etherHeaderLen + offset, Size: 2},
+			},
+			cond: bpf.JumpEqual,
+			val:  port,
+		}
+	}
+
+	ipv6PortTest := func(offset uint32) node {
+		return loadTest(etherHeaderLen+ipv6HeaderLen+offset, 2, bpf.JumpEqual, port)
+	}
+
+	return orNode{
+		andNode{
+			etherTypeTest(etherTypeIPv4),
+			ipv4Protos,
+			// skip non-first fragments, as they don't have L4 header
+			notNode{loadTest(ipv4FragOffset, 2, bpf.JumpBitsSet, 0x1fff)},
+			directional(dir, ipv4PortTest(0), ipv4PortTest(2)),
+		},
+		andNode{
+			etherTypeTest(etherTypeIPv6),
+			ipv6Protos,
+			directional(dir, ipv6PortTest(0), ipv6PortTest(2)),
+		},
+	}, nil
+}
+
+func directional(dir string, src, dst node) node {
+	switch dir {
+	case "src":
+		return src
+	case "dst":
+		return dst
+	default:
+		return orNode{src, dst}
+	}
+}
+
+type label int
+
+// instruction is either a BPF instruction, a jump to the labels, or a label placement.
+type instruction struct {
+	insn bpf.Instruction
+
+	cond   bpf.JumpTest
+	val    uint32
+	jt, jf label
+
+	label  label
+	isJump bool
+	always bool
+}
+
+type generator struct {
+	instructions []instruction
+	labels       int
+}
+
+func (g *generator) newLabel() label {
+	g.labels++
+
+	return label(g.labels)
+}
+
+func (g *generator) place(l label) {
+	g.instructions = append(g.instructions, instruction{label: l})
+}
+
+func (g *generator) emit(insn bpf.Instruction) {
+	g.instructions = append(g.instructions, instruction{insn: insn})
+}
+
+// gen generates code which jumps to label t if the node matches, and to label f otherwise.
+func (g *generator) gen(n node, t, f label) {
+	switch n := n.(type) {
+	case testNode:
+		for _, insn := range n.loads {
+			g.emit(insn)
+		}
+
+		g.instructions = append(g.instructions, instruction{isJump: true, cond: n.cond, val: n.val, jt: t, jf: f})
+	case notNode:
+		g.gen(n.child, f, t)
+	case andNode:
+		if len(n) == 0 {
+			g.instructions = append(g.instructions, instruction{isJump: true, always: true, jt: t})
+
+			return
+		}
+
+		for _, child := range n[:len(n)-1] {
+			next := g.newLabel()
+
+			g.gen(child, next, f)
+			g.place(next)
+		}
+
+		g.gen(n[len(n)-1], t, f)
+	case orNode:
+		for _, child := range n[:len(n)-1] {
+			next := g.newLabel()
+
+			g.gen(child, t, next)
+			g.place(next)
+		}
+
+		g.gen(n[len(n)-1], t, f)
+	}
+}
+
+func (g *generator) assemble() ([]bpf.RawInstruction, error) {
+	positions := map[label]int{}
+
+	pos := 0
+
+	for _, insn := range g.instructions {
+		if insn.label != 0 {
+			positions[insn.label] = pos
+
+			continue
+		}
+
+		pos++
+	}
+
+	program := make([]bpf.Instruction, 0, pos)
+
+	for _, insn := range g.instructions {
+		switch {
+		case insn.label != 0:
+			continue
+		case insn.isJump:
+			pc := len(program)
+
+			skipTrue, skipFalse := positions[insn.jt]-pc-1, positions[insn.jf]-pc-1
+
+			if insn.always {
+				program = append(program, bpf.Jump{Skip: uint32(skipTrue)})
+
+				continue
+			}
+
+			if skipTrue > 255 || skipFalse > 255 {
+				return nil, fmt.Errorf("filter expression is too complex")
+			}
+
+			program = append(program, bpf.JumpIf{
+				Cond:      insn.cond,
+				Val:       insn.val,
+				SkipTrue:  uint8(skipTrue),
+				SkipFalse: uint8(skipFalse),
+			})
+		default:
+			program = append(program, insn.insn)
+		}
+	}
+
+	return bpf.Assemble(program)
+}
@@ -0,0 +1,152 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package pcap_test
+
+import (
+	"encoding/binary"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/bpf"
+
+	"github.com/talos-systems/talos/cmd/talosctl/pkg/talos/pcap"
+)
+
+type packet struct {
+	src, dst         string
+	proto            byte
+	srcPort, dstPort uint16
+	fragmentOffset   uint16
+}
+
+func (p packet) ethernet() []byte {
+	src, dst := net.ParseIP(p.src), net.ParseIP(p.dst)
+
+	frame := make([]byte, 14)
+
+	var l4 []byte
+
+	if p.proto == 6 || p.proto == 17 {
+		l4 = make([]byte, 8)
+		binary.BigEndian.PutUint16(l4[0:], p.srcPort)
+		binary.BigEndian.PutUint16(l4[2:], p.dstPort)
+	}
+
+	if src.To4() != nil {
+		binary.BigEndian.PutUint16(frame[12:], 0x0800)
+
+		header := make([]byte, 24) // IPv4 header with options
+		header[0] = 0x46
+		binary.BigEndian.PutUint16(header[6:], p.fragmentOffset)
+		header[9] = p.proto
+		copy(header[12:], src.To4())
+		copy(header[16:], dst.To4())
+
+		frame = append(frame, header...)
+	} else {
+		binary.BigEndian.PutUint16(frame[12:], 0x86dd)
+
+		header := make([]byte, 40)
+		header[0] = 0x60
+		header[6] = p.proto
+		copy(header[8:], src)
+		copy(header[24:], dst)
+
+		frame = append(frame, header...)
+	}
+
+	return append(frame, l4...)
+}
+
+func TestCompileFilter(t *testing.T) {
+	tcp4 := packet{src: "10.5.0.2", dst: "10.5.0.3", proto: 6, srcPort: 34567, dstPort: 6443}
+	udp4 := packet{src: "10.5.0.3", dst: "10.5.0.2", proto: 17, srcPort: 53, dstPort: 34567}
+	frag4 := packet{src: "10.5.0.2", dst: "10.5.0.3", proto: 6, srcPort: 34567, dstPort: 6443, fragmentOffset: 10}
+	icmp4 := packet{src: "192.168.1.1", dst: "10.5.0.2", proto: 1}
+	tcp6 := packet{src: "fd00::1", dst: "fd00::2", proto: 6, srcPort: 6443, dstPort: 34567}
+	udp6 := packet{src: "fd00:1::1", dst: "fd00::2", proto: 17, srcPort: 34567, dstPort: 53}
+
+	for _, tt := range []struct {
+		expr     string
+		matching []packet
+		other    []packet
+	}{
+		{"", []packet{tcp4, udp4, icmp4, tcp6, udp6}, nil},
+		{"ip", []packet{tcp4, udp4, icmp4}, []packet{tcp6, udp6}},
+		{"ip6", []packet{tcp6, udp6}, []packet{tcp4, udp4, icmp4}},
+		{"tcp", []packet{tcp4, tcp6}, []packet{udp4, udp6, icmp4}},
+		{"icmp", []packet{icmp4}, []packet{tcp4, tcp6}},
+		{"port 6443", []packet{tcp4, tcp6}, []packet{udp4, udp6, icmp4, frag4}},
+		{"dst port 6443", []packet{tcp4}, []packet{tcp6}},
+		{"udp port 53", []packet{udp4, udp6}, []packet{tcp4, tcp6}},
+		{"tcp and src port 6443", []packet{tcp6}, []packet{tcp4}},
+		{"host 10.5.0.2", []packet{tcp4, udp4, icmp4}, []packet{tcp6, udp6}},
+		{"src host 10.5.0.2", []packet{tcp4}, []packet{udp4, icmp4}},
+		{"dst 10.5.0.2", []packet{udp4, icmp4}, []packet{tcp4}},
+		{"net 192.168.0.0/16", []packet{icmp4}, []packet{tcp4, udp4}},
+		{"net 0.0.0.0/0", []packet{tcp4, icmp4}, []packet{tcp6}},
+		{"host fd00::2", []packet{tcp6, udp6}, []packet{tcp4}},
+		{"src net fd00::/32", []packet{tcp6}, []packet{udp6}},
+		{"not port 6443", []packet{udp4, udp6, icmp4}, []packet{tcp4, tcp6}},
+		{"!(tcp || udp)", []packet{icmp4}, []packet{tcp4, udp6}},
+		{"ip and (port 53 or icmp)", []packet{udp4, icmp4}, []packet{tcp4, udp6}},
+		{"tcp port 6443 and not host 10.5.0.3", []packet{tcp6}, []packet{tcp4, udp4}},
+	} {
+		t.Run(tt.expr, func(t *testing.T) {
+			program, err := pcap.CompileFilter(tt.expr)
+			require.NoError(t, err)
+
+			if tt.expr == "" {
+				assert.Empty(t, program)
+
+				return
+			}
+
+			instructions := make([]bpf.Instruction, len(program))
+
+			for i := range program {
+				instructions[i] = program[i].Disassemble()
+			}
+
+			vm, err := bpf.NewVM(instructions)
+			require.NoError(t, err)
+
+			for _, p := range tt.matching {
+				n, err := vm.Run(p.ethernet())
+				require.NoError(t, err)
+
+				assert.NotZero(t, n, "packet %+v should match", p)
+			}
+
+			for _, p := range tt.other {
+				n, err := vm.Run(p.ethernet())
+				require.NoError(t, err)
+
+				assert.Zero(t, n, "packet %+v shouldn't match", p)
+			}
+		})
+	}
+}
+
+func TestCompileFilterErrors(t *testing.T) {
+	for _, expr := range []string{
+		"port",
+		"port foo",
+		"host 10.5.0.300",
+		"tcp host 10.5.0.2",
+		"ip6 host 10.5.0.2",
+		"icmp port 53",
+		"(tcp",
+		"tcp)",
+		"tcp and",
+		"foo",
+		"port 53 $",
+	} {
+		_, err := pcap.CompileFilter(expr)
+		assert.Error(t, err, "expression %q", expr)
+	}
+}
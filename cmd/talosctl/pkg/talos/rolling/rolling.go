@@ -0,0 +1,112 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package rolling implements rolling operations which are applied to the nodes one by one.
+package rolling
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/talos-systems/go-retry/retry"
+)
+
+// Rollout applies a change to the nodes one by one, waiting for each node to become healthy.
+type Rollout struct {
+	// Apply applies the change to the node.
+	Apply func(ctx context.Context, node string) error
+
+	// BootID reads the boot ID of the node.
+	//
+	// If set, the rollout waits for the node to reboot after the change is applied.
+	BootID func(ctx context.Context, node string) (string, error)
+
+	// Healthy checks the health of the node.
+	//
+	// If not set, the rollout proceeds to the next node right after the change is applied.
+	Healthy func(ctx context.Context, node string) error
+
+	// Timeout is the time to wait for each node to become healthy.
+	Timeout time.Duration
+
+	// Interval is the interval between the checks of the node.
+	Interval time.Duration
+
+	// Out receives the progress messages.
+	Out io.Writer
+}
+
+// Run applies the change to the nodes in order.
+//
+// The rollout is aborted on the first node which fails to apply the change or to become healthy in time,
+// the nodes after it are not touched.
+func (r *Rollout) Run(ctx context.Context, nodes []string) error {
+	if len(nodes) < 1 {
+		return errors.New("nodes are not set for the command")
+	}
+
+	for _, node := range nodes {
+		if err := r.runNode(ctx, node); err != nil {
+			return fmt.Errorf("node %q: %w", node, err)
+		}
+	}
+
+	return nil
+}
+
+func (r *Rollout) runNode(ctx context.Context, node string) error {
+	var (
+		bootIDBefore string
+		err          error
+	)
+
+	if r.Healthy != nil && r.BootID != nil {
+		if bootIDBefore, err = r.BootID(ctx, node); err != nil {
+			return fmt.Errorf("error reading boot ID: %w", err)
+		}
+	}
+
+	fmt.Fprintf(r.Out, "%s: applying config\n", node)
+
+	if err = r.Apply(ctx, node); err != nil {
+		return err
+	}
+
+	if r.Healthy == nil {
+		return nil
+	}
+
+	fmt.Fprintf(r.Out, "%s: waiting for the node to become healthy\n", node)
+
+	ctx, cancel := context.WithTimeout(ctx, r.Timeout)
+	defer cancel()
+
+	return retry.Constant(r.Timeout, retry.WithUnits(r.Interval)).RetryWithContext(ctx, func(ctx context.Context) error {
+		reqCtx, reqCtxCancel := context.WithTimeout(ctx, 10*time.Second)
+		defer reqCtxCancel()
+
+		if bootIDBefore != "" {
+			bootID, err := r.BootID(reqCtx, node)
+			if err != nil {
+				// API is unavailable while the node reboots
+				return retry.ExpectedError(err)
+			}
+
+			if bootID == bootIDBefore {
+				return retry.ExpectedError(errors.New("node hasn't rebooted yet"))
+			}
+		}
+
+		if err := r.Healthy(reqCtx, node); err != nil {
+			return retry.ExpectedError(err)
+		}
+
+		fmt.Fprintf(r.Out, "%s: node is healthy\n", node)
+
+		return nil
+	})
+}
@@ -0,0 +1,225 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package rolling_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/cmd/talosctl/pkg/talos/rolling"
+)
+
+// fakeNode simulates the node which reboots and becomes healthy after the config is applied.
+type fakeNode struct {
+	applyError error
+
+	// number of boot ID reads until the node reboots, negative value means it never reboots
+	rebootAfter int
+	// number of checks until the node becomes healthy, negative value means it never does
+	healthyAfter int
+
+	applied     bool
+	rebooted    bool
+	bootIDReads int
+	checks      int
+}
+
+type fakeCluster struct {
+	nodes map[string]*fakeNode
+	log   []string
+}
+
+func (c *fakeCluster) apply(ctx context.Context, node string) error {
+	c.log = append(c.log, "apply "+node)
+
+	n := c.nodes[node]
+
+	if n.applyError != nil {
+		return n.applyError
+	}
+
+	n.applied = true
+
+	return nil
+}
+
+func (c *fakeCluster) bootID(ctx context.Context, node string) (string, error) {
+	n := c.nodes[node]
+
+	if n.applied {
+		n.bootIDReads++
+
+		if n.rebootAfter >= 0 && n.bootIDReads > n.rebootAfter {
+			n.rebooted = true
+		}
+	}
+
+	if n.rebooted {
+		return "after", nil
+	}
+
+	return "before", nil
+}
+
+func (c *fakeCluster) healthy(ctx context.Context, node string) error {
+	n := c.nodes[node]
+
+	if !n.applied {
+		c.log = append(c.log, "checked before apply "+node)
+	}
+
+	n.checks++
+
+	if n.healthyAfter < 0 || n.checks <= n.healthyAfter {
+		return errors.New("service \"kubelet\" is not running: Waiting")
+	}
+
+	c.log = append(c.log, "healthy "+node)
+
+	return nil
+}
+
+// rebootingCluster also verifies that the health is checked only after the reboot.
+type rebootingCluster struct {
+	*fakeCluster
+}
+
+func (c rebootingCluster) healthy(ctx context.Context, node string) error {
+	if !c.nodes[node].rebooted {
+		c.log = append(c.log, "checked before reboot "+node)
+	}
+
+	return c.fakeCluster.healthy(ctx, node)
+}
+
+func TestRollout(t *testing.T) {
+	nodes := []string{"10.5.0.2", "10.5.0.3", "10.5.0.4"}
+
+	for _, tt := range []struct {
+		name        string
+		nodes       map[string]*fakeNode
+		healthCheck bool
+		reboot      bool
+
+		expectedLog   []string
+		expectedError string
+	}{
+		{
+			name: "no health check",
+			nodes: map[string]*fakeNode{
+				"10.5.0.2": {healthyAfter: -1},
+				"10.5.0.3": {healthyAfter: -1},
+				"10.5.0.4": {healthyAfter: -1},
+			},
+			expectedLog: []string{"apply 10.5.0.2", "apply 10.5.0.3", "apply 10.5.0.4"},
+		},
+		{
+			name: "apply failure",
+			nodes: map[string]*fakeNode{
+				"10.5.0.2": {},
+				"10.5.0.3": {applyError: errors.New("error applying new configuration")},
+				"10.5.0.4": {},
+			},
+			expectedLog:   []string{"apply 10.5.0.2", "apply 10.5.0.3"},
+			expectedError: `node "10.5.0.3": error applying new configuration`,
+		},
+		{
+			name: "health check",
+			nodes: map[string]*fakeNode{
+				"10.5.0.2": {healthyAfter: 2},
+				"10.5.0.3": {},
+				"10.5.0.4": {healthyAfter: 1},
+			},
+			healthCheck: true,
+			expectedLog: []string{"apply 10.5.0.2", "healthy 10.5.0.2", "apply 10.5.0.3", "healthy 10.5.0.3", "apply 10.5.0.4", "healthy 10.5.0.4"},
+		},
+		{
+			name: "unhealthy node",
+			nodes: map[string]*fakeNode{
+				"10.5.0.2": {},
+				"10.5.0.3": {healthyAfter: -1},
+				"10.5.0.4": {},
+			},
+			healthCheck:   true,
+			expectedLog:   []string{"apply 10.5.0.2", "healthy 10.5.0.2", "apply 10.5.0.3"},
+			expectedError: `node "10.5.0.3": `,
+		},
+		{
+			name: "reboot",
+			nodes: map[string]*fakeNode{
+				"10.5.0.2": {rebootAfter: 3, healthyAfter: 1},
+				"10.5.0.3": {rebootAfter: 1},
+				"10.5.0.4": {},
+			},
+			healthCheck: true,
+			reboot:      true,
+			expectedLog: []string{"apply 10.5.0.2", "healthy 10.5.0.2", "apply 10.5.0.3", "healthy 10.5.0.3", "apply 10.5.0.4", "healthy 10.5.0.4"},
+		},
+		{
+			name: "node doesn't reboot",
+			nodes: map[string]*fakeNode{
+				"10.5.0.2": {},
+				"10.5.0.3": {rebootAfter: -1},
+				"10.5.0.4": {},
+			},
+			healthCheck:   true,
+			reboot:        true,
+			expectedLog:   []string{"apply 10.5.0.2", "healthy 10.5.0.2", "apply 10.5.0.3"},
+			expectedError: `node "10.5.0.3": `,
+		},
+	} {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			cluster := &fakeCluster{
+				nodes: tt.nodes,
+			}
+
+			rollout := rolling.Rollout{
+				Apply:    cluster.apply,
+				Timeout:  200 * time.Millisecond,
+				Interval: time.Millisecond,
+				Out:      ioutil.Discard,
+			}
+
+			if tt.healthCheck {
+				rollout.Healthy = cluster.healthy
+			}
+
+			if tt.reboot {
+				rollout.BootID = cluster.bootID
+				rollout.Healthy = rebootingCluster{cluster}.healthy
+			}
+
+			err := rollout.Run(context.Background(), nodes)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.expectedLog, cluster.log)
+		})
+	}
+}
+
+func TestRolloutNoNodes(t *testing.T) {
+	rollout := rolling.Rollout{
+		Apply: func(context.Context, string) error {
+			return nil
+		},
+		Out: ioutil.Discard,
+	}
+
+	assert.EqualError(t, rollout.Run(context.Background(), nil), "nodes are not set for the command")
+}
@@ -73,6 +73,12 @@ func init() {
 	{{ $docVar }}.Fields[{{ $index }}].AddExample("{{ $example.Name }}", {{ $example.Value }})
 	{{ end -}}
 	{{ end -}}
+	{{ if $field.Text.Deprecated -}}
+	{{ $docVar }}.Fields[{{ $index }}].Deprecated = &encoder.Deprecation{
+		Replacement: "{{ $field.Text.Deprecated.Replacement }}",
+		Removal: "{{ $field.Text.Deprecated.Removal }}",
+	}
+	{{ end -}}
 	{{ if $field.Text.Values -}}
 	{{ $docVar }}.Fields[{{ $index }}].Values = []string{
 	{{ range $value := $field.Text.Values -}}
@@ -140,10 +146,16 @@ type Field struct {
 }
 
 type Text struct {
-	Comment     string     `json:"-"`
-	Description string     `json:"description"`
-	Examples    []*Example `json:"examples"`
-	Values      []string   `json:"values"`
+	Comment     string       `json:"-"`
+	Description string       `json:"description"`
+	Examples    []*Example   `json:"examples"`
+	Values      []string     `json:"values"`
+	Deprecated  *Deprecation `json:"deprecated"`
+}
+
+type Deprecation struct {
+	Replacement string `json:"replacement"`
+	Removal     string `json:"removal"`
 }
 
 func in(p string) (string, error) {
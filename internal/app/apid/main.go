@@ -5,10 +5,14 @@
 package apid
 
 import (
+	"context"
+	stdtls "crypto/tls"
 	"flag"
 	"log"
+	"net/http"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/talos-systems/grpc-proxy/proxy"
 	"golang.org/x/sync/errgroup"
@@ -18,7 +22,10 @@ import (
 	apidbackend "github.com/talos-systems/talos/internal/app/apid/pkg/backend"
 	"github.com/talos-systems/talos/internal/app/apid/pkg/director"
 	"github.com/talos-systems/talos/internal/app/apid/pkg/provider"
+	"github.com/talos-systems/talos/internal/pkg/audit"
+	"github.com/talos-systems/talos/internal/pkg/metrics"
 	"github.com/talos-systems/talos/pkg/grpc/factory"
+	"github.com/talos-systems/talos/pkg/grpc/middleware/apiversion"
 	"github.com/talos-systems/talos/pkg/grpc/proxy/backend"
 	"github.com/talos-systems/talos/pkg/machinery/config/configloader"
 	"github.com/talos-systems/talos/pkg/machinery/constants"
@@ -28,6 +35,8 @@ import (
 var (
 	endpoints       *string
 	useK8sEndpoints *bool
+
+	apiRequestDuration = metrics.NewAPIRequestDuration("talos_apid")
 )
 
 // Main is the entrypoint of apid.
@@ -68,6 +77,13 @@ func Main() {
 		log.Fatalf("failed to create OS-level TLS configuration: %v", err)
 	}
 
+	// client certificates revoked via trustd are rejected
+	revocations := provider.NewRevocations(config.Machine().Security().Token(), endpointsProvider)
+
+	go revocations.Run(context.Background())
+
+	serverTLSConfig.VerifyPeerCertificate = revocations.VerifyPeerCertificate
+
 	clientTLSConfig, err := tlsConfig.ClientConfig()
 	if err != nil {
 		log.Fatalf("failed to create client TLS config: %v", err)
@@ -78,21 +94,34 @@ func Main() {
 
 	router := director.NewRouter(backendFactory.Get, localBackend)
 
+	// node names are resolved to the addresses via the cluster members discovered by machined
+	membersResolver := &provider.MembersResolver{
+		SocketPath: constants.MachineSocketPath,
+	}
+
+	go membersResolver.Run(context.Background())
+
+	router.SetResolver(membersResolver)
+
 	// all existing streaming methods
 	for _, methodName := range []string{
 		"/machine.MachineService/Copy",
+		"/machine.MachineService/CopyIn",
 		"/machine.MachineService/DiskUsage",
 		"/machine.MachineService/Dmesg",
 		"/machine.MachineService/EtcdSnapshot",
 		"/machine.MachineService/Events",
+		"/machine.MachineService/ImageList",
 		"/machine.MachineService/Kubeconfig",
 		"/machine.MachineService/List",
 		"/machine.MachineService/Logs",
+		"/machine.MachineService/PacketCapture",
 		"/machine.MachineService/Read",
 		"/resource.ResourceService/List",
 		"/resource.ResourceService/Watch",
 		"/os.OSService/Dmesg",
 		"/cluster.ClusterService/HealthCheck",
+		"/grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo",
 	} {
 		router.RegisterStreamedRegex("^" + regexp.QuoteMeta(methodName) + "$")
 	}
@@ -107,6 +136,11 @@ func Main() {
 			router,
 			factory.Port(constants.ApidPort),
 			factory.WithDefaultLog(),
+			factory.WithUnaryInterceptor(metrics.UnaryServerInterceptor(apiRequestDuration)),
+			factory.WithStreamInterceptor(metrics.StreamServerInterceptor(apiRequestDuration)),
+			factory.WithUnaryInterceptor(apiversion.UnaryInterceptor()),
+			factory.WithStreamInterceptor(apiversion.StreamInterceptor()),
+			factory.WithStreamInterceptor(audit.IdentityStreamInterceptor()),
 			factory.ServerOptions(
 				grpc.Creds(
 					credentials.NewTLS(serverTLSConfig),
@@ -127,6 +161,11 @@ func Main() {
 			factory.Network("unix"),
 			factory.SocketPath(constants.APISocketPath),
 			factory.WithDefaultLog(),
+			factory.WithUnaryInterceptor(metrics.UnaryServerInterceptor(apiRequestDuration)),
+			factory.WithStreamInterceptor(metrics.StreamServerInterceptor(apiRequestDuration)),
+			factory.WithUnaryInterceptor(apiversion.UnaryInterceptor()),
+			factory.WithStreamInterceptor(apiversion.StreamInterceptor()),
+			factory.WithStreamInterceptor(audit.IdentityStreamInterceptor()),
 			factory.ServerOptions(
 				grpc.CustomCodec(proxy.Codec()), //nolint:staticcheck
 				grpc.UnknownServiceHandler(
@@ -138,6 +177,36 @@ func Main() {
 		)
 	})
 
+	if config.Machine().Metrics().Enabled() {
+		// metrics are served over TLS with the machine API certificate, machined metrics are gathered via the local socket
+		metricsTLSConfig := serverTLSConfig.Clone()
+		metricsTLSConfig.ClientAuth = stdtls.NoClientCert
+
+		errGroup.Go(func() error {
+			listener, err := factory.NewListener(factory.Port(config.Machine().Metrics().Port()))
+			if err != nil {
+				return err
+			}
+
+			logger := log.New(log.Writer(), "metrics ", log.Flags())
+
+			mux := http.NewServeMux()
+			mux.Handle(metrics.Path, metrics.Handler(logger, config.Machine().Metrics().BearerToken(),
+				metrics.Default,
+				&metrics.Remote{SocketPath: constants.MachinedMetricsSocketPath},
+			))
+
+			server := &http.Server{
+				Handler:     mux,
+				TLSConfig:   metricsTLSConfig,
+				ReadTimeout: 10 * time.Second,
+				ErrorLog:    logger,
+			}
+
+			return server.ServeTLS(listener, "", "")
+		})
+	}
+
 	if err := errGroup.Wait(); err != nil {
 		log.Fatalf("listen: %v", err)
 	}
@@ -8,6 +8,7 @@ package director
 import (
 	"context"
 	"regexp"
+	"strings"
 
 	"github.com/talos-systems/grpc-proxy/proxy"
 	"google.golang.org/grpc"
@@ -16,16 +17,25 @@ import (
 	"google.golang.org/grpc/status"
 )
 
+// reflectionServicePrefix is the prefix of the gRPC server reflection methods.
+const reflectionServicePrefix = "/grpc.reflection."
+
 // Router wraps grpc-proxy StreamDirector.
 type Router struct {
 	localBackend         proxy.Backend
 	remoteBackendFactory RemoteBackendFactory
+	resolver             Resolver
 	streamedMatchers     []*regexp.Regexp
 }
 
 // RemoteBackendFactory provides backend generation by address (target).
 type RemoteBackendFactory func(target string) (proxy.Backend, error)
 
+// Resolver maps the targets (e.g. node names) to the node addresses.
+type Resolver interface {
+	Resolve(target string) string
+}
+
 // NewRouter builds new Router.
 func NewRouter(backendFactory RemoteBackendFactory, localBackend proxy.Backend) *Router {
 	return &Router{
@@ -34,6 +44,11 @@ func NewRouter(backendFactory RemoteBackendFactory, localBackend proxy.Backend)
 	}
 }
 
+// SetResolver sets the resolver which is used to map targets to the addresses before building remote backends.
+func (r *Router) SetResolver(resolver Resolver) {
+	r.resolver = resolver
+}
+
 // Register is no-op to implement factory.Registrator interface.
 //
 // Actual proxy handler is installed via grpc.UnknownServiceHandler option.
@@ -42,6 +57,11 @@ func (r *Router) Register(srv *grpc.Server) {
 
 // Director implements proxy.StreamDirector function.
 func (r *Router) Director(ctx context.Context, fullMethodName string) (proxy.Mode, []proxy.Backend, error) {
+	// reflection responses can't be aggregated, and the API is the same for all the nodes of the same version
+	if strings.HasPrefix(fullMethodName, reflectionServicePrefix) {
+		return proxy.One2One, []proxy.Backend{r.localBackend}, nil
+	}
+
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
 		return proxy.One2One, []proxy.Backend{r.localBackend}, nil
@@ -68,6 +88,10 @@ func (r *Router) aggregateDirector(targets []string) (proxy.Mode, []proxy.Backen
 	backends := make([]proxy.Backend, len(targets))
 
 	for i, target := range targets {
+		if r.resolver != nil {
+			target = r.resolver.Resolve(target)
+		}
+
 		backends[i], err = r.remoteBackendFactory(target)
 		if err != nil {
 			return proxy.One2Many, nil, status.Error(codes.Internal, err.Error())
@@ -62,6 +62,23 @@ func (suite *DirectorSuite) TestDirectorAggregate() {
 	suite.Assert().NoError(err)
 }
 
+func (suite *DirectorSuite) TestDirectorResolver() {
+	router := director.NewRouter(mockBackendFactory, &mockBackend{})
+	router.SetResolver(mockResolver{
+		"talos-master-1": "172.20.0.2",
+	})
+
+	md := metadata.New(nil)
+	md.Set("nodes", "talos-master-1", "127.0.0.1", "talos-unknown")
+	mode, backends, err := router.Director(metadata.NewIncomingContext(context.Background(), md), "/service.Service/method")
+	suite.Assert().Equal(proxy.One2Many, mode)
+	suite.Assert().Len(backends, 3)
+	suite.Assert().Equal("172.20.0.2", backends[0].(*mockBackend).target)
+	suite.Assert().Equal("127.0.0.1", backends[1].(*mockBackend).target)
+	suite.Assert().Equal("talos-unknown", backends[2].(*mockBackend).target)
+	suite.Assert().NoError(err)
+}
+
 func TestDirectorSuite(t *testing.T) {
 	suite.Run(t, new(DirectorSuite))
 }
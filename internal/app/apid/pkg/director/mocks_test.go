@@ -34,3 +34,13 @@ func (m *mockBackend) BuildError(streaming bool, err error) ([]byte, error) {
 func mockBackendFactory(target string) (proxy.Backend, error) {
 	return &mockBackend{target: target}, nil
 }
+
+type mockResolver map[string]string
+
+func (m mockResolver) Resolve(target string) string {
+	if address, ok := m[target]; ok {
+		return address
+	}
+
+	return target
+}
@@ -0,0 +1,208 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"gopkg.in/yaml.v3"
+
+	"github.com/talos-systems/talos/pkg/machinery/client"
+	"github.com/talos-systems/talos/pkg/resources/cluster"
+)
+
+// membersRefreshInterval is the interval between the refreshes of the cluster members list.
+const membersRefreshInterval = 30 * time.Second
+
+// MembersResolver resolves node names to the node addresses using the cluster members
+// discovered by machined.
+//
+// Members list is refreshed in the background by Run, Resolve uses the last fetched list.
+// Targets which are IP addresses and unknown node names are returned as is.
+type MembersResolver struct {
+	// SocketPath is the path to the machined API socket.
+	SocketPath string
+
+	client *client.Client
+
+	mu        sync.RWMutex
+	addresses map[string]string
+}
+
+// Run refreshes the cluster members list until the context is canceled.
+func (r *MembersResolver) Run(ctx context.Context) {
+	ticker := time.NewTicker(membersRefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := r.refresh(ctx); err != nil {
+			log.Printf("failed to refresh cluster members: %s", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Resolve implements director.Resolver interface.
+func (r *MembersResolver) Resolve(target string) string {
+	if net.ParseIP(target) != nil {
+		return target
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if address, ok := r.addresses[strings.ToLower(target)]; ok {
+		return address
+	}
+
+	return target
+}
+
+func (r *MembersResolver) refresh(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if r.client == nil {
+		var err error
+
+		r.client, err = client.New(ctx, client.WithUnixSocket(r.SocketPath), client.WithGRPCDialOptions(grpc.WithInsecure()))
+		if err != nil {
+			return fmt.Errorf("error creating machined client: %w", err)
+		}
+	}
+
+	listClient, err := r.client.Resources.List(ctx, cluster.NamespaceName, cluster.MemberType)
+	if err != nil {
+		return fmt.Errorf("error listing cluster members: %w", err)
+	}
+
+	var members []cluster.MemberSpec
+
+	for {
+		msg, err := listClient.Recv()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+
+			return fmt.Errorf("error listing cluster members: %w", err)
+		}
+
+		if msg.Resource == nil {
+			continue
+		}
+
+		body, err := yaml.Marshal(msg.Resource.Spec())
+		if err != nil {
+			return err
+		}
+
+		var spec cluster.MemberSpec
+
+		if err = yaml.Unmarshal(body, &spec); err != nil {
+			return err
+		}
+
+		members = append(members, spec)
+	}
+
+	addresses := memberAddresses(members, localFamilies())
+
+	r.mu.Lock()
+	r.addresses = addresses
+	r.mu.Unlock()
+
+	return nil
+}
+
+// memberAddresses builds the map of the member hostnames to the addresses.
+//
+// Address of the address family available on the local node is preferred, as the other one might be unreachable.
+func memberAddresses(members []cluster.MemberSpec, families addressFamilies) map[string]string {
+	addresses := map[string]string{}
+
+	for _, spec := range members {
+		if spec.Hostname == "" || len(spec.Addresses) == 0 {
+			continue
+		}
+
+		address := spec.Addresses[0]
+
+		for _, addr := range spec.Addresses {
+			if families.has(net.ParseIP(addr)) {
+				address = addr
+
+				break
+			}
+		}
+
+		hostname := strings.ToLower(spec.Hostname)
+
+		addresses[hostname] = address
+
+		// allow targeting the node by the short hostname as well
+		if short := strings.SplitN(hostname, ".", 2)[0]; short != hostname {
+			if _, exists := addresses[short]; !exists {
+				addresses[short] = address
+			}
+		}
+	}
+
+	return addresses
+}
+
+// addressFamilies is the set of the address families.
+type addressFamilies struct {
+	ipv4, ipv6 bool
+}
+
+func (families addressFamilies) has(ip net.IP) bool {
+	switch {
+	case ip == nil:
+		return false
+	case ip.To4() != nil:
+		return families.ipv4
+	default:
+		return families.ipv6
+	}
+}
+
+// localFamilies returns the address families of the node global unicast addresses.
+func localFamilies() addressFamilies {
+	var families addressFamilies
+
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return families
+	}
+
+	for _, addr := range addrs {
+		ipNet, ok := addr.(*net.IPNet)
+		if !ok || !ipNet.IP.IsGlobalUnicast() {
+			continue
+		}
+
+		if ipNet.IP.To4() != nil {
+			families.ipv4 = true
+		} else {
+			families.ipv6 = true
+		}
+	}
+
+	return families
+}
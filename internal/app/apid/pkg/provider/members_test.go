@@ -0,0 +1,75 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/talos-systems/talos/pkg/resources/cluster"
+)
+
+func TestMemberAddresses(t *testing.T) {
+	members := []cluster.MemberSpec{
+		{Hostname: "talos-1.example.com", Addresses: []string{"10.5.0.2", "fd00::2"}},
+		{Hostname: "talos-2", Addresses: []string{"fd00::3", "10.5.0.3"}},
+		{Hostname: "talos-3", Addresses: []string{"fd00::4"}},
+		{Hostname: "no-addresses"},
+	}
+
+	for _, tt := range []struct {
+		name     string
+		families addressFamilies
+		expected map[string]string
+	}{
+		{
+			name:     "dual-stack",
+			families: addressFamilies{ipv4: true, ipv6: true},
+			expected: map[string]string{
+				"talos-1.example.com": "10.5.0.2",
+				"talos-1":             "10.5.0.2",
+				"talos-2":             "fd00::3",
+				"talos-3":             "fd00::4",
+			},
+		},
+		{
+			name:     "ipv4",
+			families: addressFamilies{ipv4: true},
+			expected: map[string]string{
+				"talos-1.example.com": "10.5.0.2",
+				"talos-1":             "10.5.0.2",
+				"talos-2":             "10.5.0.3",
+				"talos-3":             "fd00::4",
+			},
+		},
+		{
+			name:     "ipv6",
+			families: addressFamilies{ipv6: true},
+			expected: map[string]string{
+				"talos-1.example.com": "fd00::2",
+				"talos-1":             "fd00::2",
+				"talos-2":             "fd00::3",
+				"talos-3":             "fd00::4",
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, memberAddresses(members, tt.families))
+		})
+	}
+}
+
+func TestResolve(t *testing.T) {
+	r := &MembersResolver{}
+
+	assert.Equal(t, "talos-1", r.Resolve("talos-1"))
+
+	r.addresses = memberAddresses([]cluster.MemberSpec{{Hostname: "Talos-1", Addresses: []string{"10.5.0.2"}}}, addressFamilies{ipv4: true})
+
+	assert.Equal(t, "10.5.0.2", r.Resolve("TALOS-1"))
+	assert.Equal(t, "10.5.0.3", r.Resolve("10.5.0.3"))
+	assert.Equal(t, "talos-2", r.Resolve("talos-2"))
+}
@@ -0,0 +1,110 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package provider
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"log"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/talos-systems/talos/internal/pkg/revocation"
+	"github.com/talos-systems/talos/pkg/grpc/middleware/auth/basic"
+	securityapi "github.com/talos-systems/talos/pkg/machinery/api/security"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+)
+
+// revocationsRefreshInterval is the interval between the refreshes of the revocation lists.
+const revocationsRefreshInterval = time.Minute
+
+// Revocations rejects the revoked client certificates.
+//
+// Revocation lists are fetched from trustd on each endpoint in the background by Run, a certificate is rejected
+// if it's revoked on any of the endpoints. Last fetched list is kept if the endpoint is not reachable.
+type Revocations struct {
+	// Token is the trustd token.
+	Token string
+	// Endpoints provides the trustd endpoints.
+	Endpoints Endpoints
+
+	checker *revocation.Checker
+}
+
+// NewRevocations initializes Revocations.
+func NewRevocations(token string, endpoints Endpoints) *Revocations {
+	return &Revocations{
+		Token:     token,
+		Endpoints: endpoints,
+		checker:   revocation.NewChecker(),
+	}
+}
+
+// Run refreshes the revocation lists until the context is canceled.
+func (r *Revocations) Run(ctx context.Context) {
+	ticker := time.NewTicker(revocationsRefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := r.refresh(ctx); err != nil {
+			log.Printf("failed to refresh revocation lists: %s", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// VerifyPeerCertificate implements tls.Config.VerifyPeerCertificate hook.
+func (r *Revocations) VerifyPeerCertificate(rawCerts [][]byte, verifiedChains [][]*x509.Certificate) error {
+	return r.checker.VerifyPeerCertificate(rawCerts, verifiedChains)
+}
+
+func (r *Revocations) refresh(ctx context.Context) error {
+	endpoints, err := r.Endpoints.GetEndpoints()
+	if err != nil {
+		return fmt.Errorf("error getting endpoints: %w", err)
+	}
+
+	r.checker.Retain(endpoints)
+
+	for _, endpoint := range endpoints {
+		serials, err := r.fetch(ctx, endpoint)
+		if err != nil {
+			log.Printf("failed to fetch revocation list from %q: %s", endpoint, err)
+
+			continue
+		}
+
+		r.checker.Update(endpoint, serials)
+	}
+
+	return nil
+}
+
+func (r *Revocations) fetch(ctx context.Context, endpoint string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	conn, err := basic.NewConnection(net.JoinHostPort(endpoint, strconv.Itoa(constants.TrustdPort)), basic.NewTokenCredentials(r.Token))
+	if err != nil {
+		return nil, err
+	}
+
+	//nolint:errcheck
+	defer conn.Close()
+
+	resp, err := securityapi.NewSecurityServiceClient(conn).RevocationList(ctx, &securityapi.RevocationListRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.SerialNumbers, nil
+}
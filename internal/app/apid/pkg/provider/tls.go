@@ -18,6 +18,7 @@ import (
 	"github.com/talos-systems/crypto/x509"
 	"github.com/talos-systems/net"
 
+	"github.com/talos-systems/talos/internal/pkg/tlspolicy"
 	"github.com/talos-systems/talos/pkg/grpc/gen"
 	"github.com/talos-systems/talos/pkg/machinery/config"
 )
@@ -28,6 +29,7 @@ type TLSConfig struct {
 	lastEndpointList    []string
 	generator           *gen.RemoteGenerator
 	certificateProvider tls.CertificateProvider
+	strict              bool
 }
 
 // NewTLSConfig builds provider from configuration and endpoints.
@@ -60,6 +62,7 @@ func NewTLSConfig(config config.Provider, endpoints Endpoints) (*TLSConfig, erro
 	tlsConfig := &TLSConfig{
 		endpoints:        endpoints,
 		lastEndpointList: endpointList,
+		strict:           config.Machine().Features().StrictTLSEnabled(),
 	}
 
 	tlsConfig.generator, err = gen.NewRemoteGenerator(
@@ -91,11 +94,11 @@ func (tlsConfig *TLSConfig) ServerConfig() (*stdlibtls.Config, error) {
 		return nil, fmt.Errorf("failed to get root CA: %w", err)
 	}
 
-	return tls.New(
+	return tls.New(tlsConfig.options(
 		tls.WithClientAuthType(tls.Mutual),
 		tls.WithCACertPEM(ca),
 		tls.WithServerCertificateProvider(tlsConfig.certificateProvider),
-	)
+	)...)
 }
 
 // ClientConfig generates client-side tls.Config.
@@ -105,11 +108,20 @@ func (tlsConfig *TLSConfig) ClientConfig() (*stdlibtls.Config, error) {
 		return nil, fmt.Errorf("failed to get root CA: %w", err)
 	}
 
-	return tls.New(
+	return tls.New(tlsConfig.options(
 		tls.WithClientAuthType(tls.Mutual),
 		tls.WithCACertPEM(ca),
 		tls.WithClientCertificateProvider(tlsConfig.certificateProvider),
-	)
+	)...)
+}
+
+// options appends the strict TLS policy to the options if it's enabled.
+func (tlsConfig *TLSConfig) options(opts ...tls.ConfigOptionFunc) []tls.ConfigOptionFunc {
+	if tlsConfig.strict {
+		opts = append(opts, tlspolicy.Strict)
+	}
+
+	return opts
 }
 
 func (tlsConfig *TLSConfig) refreshEndpoints() {
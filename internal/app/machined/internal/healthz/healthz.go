@@ -0,0 +1,168 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package healthz implements the node health endpoint.
+package healthz
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+)
+
+// Path is the HTTP path of the health endpoint.
+const Path = "/healthz"
+
+// Stage statuses.
+const (
+	StageRunning  = "running"
+	StageFinished = "finished"
+	StageFailed   = "failed"
+)
+
+const (
+	bootSequence      = "boot"
+	bootstrapSequence = "bootstrap"
+)
+
+// Status is the node health reported by the endpoint.
+type Status struct {
+	Stage       string          `json:"stage"`
+	StageStatus string          `json:"stageStatus"`
+	Ready       bool            `json:"ready"`
+	Services    []ServiceStatus `json:"services"`
+}
+
+// ServiceStatus is the summary of the service state.
+type ServiceStatus struct {
+	ID      string `json:"id"`
+	State   string `json:"state"`
+	Healthy bool   `json:"healthy"`
+}
+
+// Handler serves the node health.
+//
+// The node is ready when the boot sequence is finished, no other sequence (e.g. reboot or upgrade)
+// was started since then, and all the services are healthy.
+type Handler struct {
+	// Services returns the summary of the services state.
+	Services func() []ServiceStatus
+
+	mu          sync.Mutex
+	stage       string
+	stageStatus string
+	booted      bool
+}
+
+// SequenceStarted updates the stage on the sequence start.
+func (h *Handler) SequenceStarted(sequence string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.stage, h.stageStatus = sequence, StageRunning
+
+	// bootstrap runs on a booted node, any other sequence takes the node down
+	if sequence != bootstrapSequence {
+		h.booted = false
+	}
+}
+
+// SequenceFinished updates the stage on the sequence completion.
+func (h *Handler) SequenceFinished(sequence string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.stage == sequence && h.stageStatus == StageFailed {
+		return
+	}
+
+	h.stage, h.stageStatus = sequence, StageFinished
+
+	if sequence == bootSequence {
+		h.booted = true
+	}
+}
+
+// SequenceFailed updates the stage on the sequence failure.
+func (h *Handler) SequenceFailed(sequence string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.stage, h.stageStatus = sequence, StageFailed
+
+	if sequence == bootSequence {
+		h.booted = false
+	}
+}
+
+// Status builds current node health.
+func (h *Handler) Status() Status {
+	h.mu.Lock()
+
+	status := Status{
+		Stage:       h.stage,
+		StageStatus: h.stageStatus,
+		Ready:       h.booted,
+	}
+
+	h.mu.Unlock()
+
+	if h.Services != nil {
+		status.Services = h.Services()
+	}
+
+	for _, svc := range status.Services {
+		if !svc.Healthy {
+			status.Ready = false
+		}
+	}
+
+	return status
+}
+
+// Probe checks that machined is responsive, it is used as the watchdog liveness probe.
+//
+// Liveness doesn't depend on the node health: failed sequences and unhealthy services are reported
+// by the health endpoint, while the watchdog restarts the node only if machined is hung.
+func (h *Handler) Probe(ctx context.Context) error {
+	// the lock is held while processing the sequence events
+	h.mu.Lock()
+	h.mu.Unlock() //nolint:staticcheck
+
+	// service registry lock is held while services are being started and stopped
+	if h.Services != nil {
+		h.Services()
+	}
+
+	return ctx.Err()
+}
+
+// ServeHTTP implements http.Handler.
+//
+// Responds with 200 if the node is ready, and with 503 otherwise.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	status := h.Status()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if status.Ready {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+
+	if req.Method == http.MethodHead {
+		return
+	}
+
+	//nolint:errcheck
+	json.NewEncoder(w).Encode(status)
+}
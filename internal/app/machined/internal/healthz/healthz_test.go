@@ -0,0 +1,97 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package healthz_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/internal/app/machined/internal/healthz"
+)
+
+func check(t *testing.T, h *healthz.Handler, expectedCode int) healthz.Status {
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, healthz.Path, nil))
+
+	assert.Equal(t, expectedCode, rec.Code)
+
+	var status healthz.Status
+
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&status))
+
+	return status
+}
+
+func TestHandler(t *testing.T) {
+	services := []healthz.ServiceStatus{
+		{ID: "apid", State: "Running", Healthy: true},
+		{ID: "kubelet", State: "Running", Healthy: false},
+	}
+
+	h := &healthz.Handler{
+		Services: func() []healthz.ServiceStatus {
+			return services
+		},
+	}
+
+	h.SequenceStarted("boot")
+
+	status := check(t, h, http.StatusServiceUnavailable)
+	assert.Equal(t, "boot", status.Stage)
+	assert.Equal(t, healthz.StageRunning, status.StageStatus)
+	assert.False(t, status.Ready)
+
+	h.SequenceFinished("boot")
+
+	status = check(t, h, http.StatusServiceUnavailable)
+	assert.Equal(t, healthz.StageFinished, status.StageStatus)
+	assert.Len(t, status.Services, 2)
+
+	services[1].Healthy = true
+
+	status = check(t, h, http.StatusOK)
+	assert.True(t, status.Ready)
+
+	// bootstrap keeps the node ready
+	h.SequenceStarted("bootstrap")
+	check(t, h, http.StatusOK)
+
+	h.SequenceStarted("reboot")
+
+	status = check(t, h, http.StatusServiceUnavailable)
+	assert.Equal(t, "reboot", status.Stage)
+
+	h.SequenceFailed("reboot")
+	h.SequenceFinished("reboot")
+
+	status = check(t, h, http.StatusServiceUnavailable)
+	assert.Equal(t, healthz.StageFailed, status.StageStatus)
+}
+
+func TestProbe(t *testing.T) {
+	h := &healthz.Handler{
+		Services: func() []healthz.ServiceStatus {
+			return []healthz.ServiceStatus{{ID: "etcd", State: "Failed"}}
+		},
+	}
+
+	h.SequenceStarted("boot")
+	h.SequenceFailed("boot")
+
+	// node is not ready, but machined is responsive, so the watchdog keeps being petted
+	assert.False(t, h.Status().Ready)
+	assert.NoError(t, h.Probe(context.Background()))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assert.Error(t, h.Probe(ctx))
+}
@@ -58,7 +58,7 @@ func RunInstallerContainer(disk, platform, ref string, reg config.Registries, op
 	if img == nil || err != nil && errdefs.IsNotFound(err) {
 		log.Printf("pulling %q", ref)
 
-		img, err = image.Pull(ctx, reg, client, ref)
+		img, err = image.Pull(ctx, reg, client, ref, image.WithImageCache(options.ImageCache))
 	}
 
 	if err != nil {
@@ -69,6 +69,13 @@ func RunInstallerContainer(disk, platform, ref string, reg config.Registries, op
 		{Type: "bind", Destination: "/dev", Source: "/dev", Options: []string{"rbind", "rshared", "rw"}},
 	}
 
+	if options.Upgrade {
+		// the installer checks the persisted machine config against the Talos version being installed
+		if _, err = os.Stat(constants.ConfigPath); err == nil {
+			mounts = append(mounts, specs.Mount{Type: "bind", Destination: constants.ConfigPath, Source: constants.ConfigPath, Options: []string{"rbind", "ro"}})
+		}
+	}
+
 	// TODO(andrewrynhard): To handle cases when the newer version changes the
 	// platform name, this should be determined in the installer container.
 	config := constants.ConfigNone
@@ -95,8 +102,31 @@ func RunInstallerContainer(disk, platform, ref string, reg config.Registries, op
 		args = append(args, "--board="+*c)
 	}
 
-	for _, arg := range options.ExtraKernelArgs {
-		args = append(args, []string{"--extra-kernel-arg", arg}...)
+	extraKernelArgs := append([]string(nil), options.ExtraKernelArgs...)
+	extraKernelArgs = append(extraKernelArgs, options.VirtualizationKernelArgs...)
+	extraKernelArgs = append(extraKernelArgs, options.CrashDumpKernelArgs...)
+
+	for _, arg := range extraKernelArgs {
+		// removed args are prefixed with `-`, so pass them with `=` to avoid parsing them as flags
+		args = append(args, "--extra-kernel-arg="+arg)
+	}
+
+	// GRUB is the installer default, so the flag is passed only when needed to keep older installer images working
+	if options.BootloaderType != "" && options.BootloaderType != constants.BootloaderGRUB {
+		args = append(args, "--bootloader-type="+options.BootloaderType)
+	}
+
+	for _, console := range options.Consoles {
+		args = append(args, "--console="+console)
+	}
+
+	if options.SecureBootKey != "" {
+		args = append(args, "--secureboot", "--secureboot-key="+options.SecureBootKey, "--secureboot-cert="+options.SecureBootCert)
+	}
+
+	// the flag is passed only when needed to keep older installer images working
+	if options.ConfigCheckWarnOnly {
+		args = append(args, "--config-check-warn-only")
 	}
 
 	specOpts := []oci.SpecOpts{
@@ -169,5 +199,11 @@ func OptionsFromUpgradeRequest(r runtime.Runtime, in *machineapi.UpgradeRequest)
 		WithUpgrade(true),
 		WithForce(!in.GetPreserve()),
 		WithExtraKernelArgs(r.Config().Machine().Install().ExtraKernelArgs()),
+		WithVirtualizationKernelArgs(r.Config().Machine().Virtualization().KernelArgs()),
+		WithCrashDumpKernelArgs(r.Config().Machine().CrashDumps().KernelArgs()),
+		WithBootloaderType(r.Config().Machine().Install().BootloaderType()),
+		WithConsoles(r.Config().Machine().Install().Consoles()),
+		WithSecureBoot(r.Config().Machine().Install().SecureBoot()),
+		WithConfigCheckWarnOnly(in.GetForce()),
 	}
 }
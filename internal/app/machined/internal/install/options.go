@@ -4,6 +4,8 @@
 
 package install
 
+import "github.com/talos-systems/talos/pkg/machinery/config"
+
 // Option is a functional option.
 type Option func(o *Options) error
 
@@ -14,6 +16,18 @@ type Options struct {
 	Upgrade         bool
 	Zero            bool
 	ExtraKernelArgs []string
+	// VirtualizationKernelArgs are the kernel args generated from the machine virtualization config.
+	VirtualizationKernelArgs []string
+	// CrashDumpKernelArgs are the kernel args generated from the machine crash dumps config.
+	CrashDumpKernelArgs []string
+	ImageCache          bool
+	BootloaderType      string
+	Consoles            []string
+	// SecureBootKey and SecureBootCert are the paths to the SecureBoot signing key and certificate in the installer image.
+	SecureBootKey  string
+	SecureBootCert string
+	// ConfigCheckWarnOnly makes the installer only warn if the machine config is not compatible with the Talos version being installed.
+	ConfigCheckWarnOnly bool
 }
 
 // DefaultInstallOptions returns default options.
@@ -87,3 +101,73 @@ func WithExtraKernelArgs(s []string) Option {
 		return nil
 	}
 }
+
+// WithVirtualizationKernelArgs sets the kernel args for the virtualization (KVM) options.
+func WithVirtualizationKernelArgs(s []string) Option {
+	return func(o *Options) error {
+		o.VirtualizationKernelArgs = s
+
+		return nil
+	}
+}
+
+// WithCrashDumpKernelArgs sets the kernel args for the crash dumps (pstore) options.
+func WithCrashDumpKernelArgs(s []string) Option {
+	return func(o *Options) error {
+		o.CrashDumpKernelArgs = s
+
+		return nil
+	}
+}
+
+// WithImageCache enables pulling the installer image via the local image cache.
+func WithImageCache(b bool) Option {
+	return func(o *Options) error {
+		o.ImageCache = b
+
+		return nil
+	}
+}
+
+// WithBootloaderType sets the bootloader type.
+func WithBootloaderType(s string) Option {
+	return func(o *Options) error {
+		o.BootloaderType = s
+
+		return nil
+	}
+}
+
+// WithConfigCheckWarnOnly sets the config check warn only option.
+func WithConfigCheckWarnOnly(b bool) Option {
+	return func(o *Options) error {
+		o.ConfigCheckWarnOnly = b
+
+		return nil
+	}
+}
+
+// WithSecureBoot sets the SecureBoot signing options.
+func WithSecureBoot(secureBoot config.InstallSecureBoot) Option {
+	return func(o *Options) error {
+		if secureBoot == nil {
+			return nil
+		}
+
+		o.SecureBootKey = secureBoot.SigningKey()
+		o.SecureBootCert = secureBoot.SigningCert()
+
+		return nil
+	}
+}
+
+// WithConsoles appends the console devices set in the boot entries.
+func WithConsoles(consoles []config.InstallConsole) Option {
+	return func(o *Options) error {
+		for _, console := range consoles {
+			o.Consoles = append(o.Consoles, console.KernelArg())
+		}
+
+		return nil
+	}
+}
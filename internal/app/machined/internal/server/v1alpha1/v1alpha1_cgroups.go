@@ -0,0 +1,54 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"context"
+	"os"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/talos-systems/talos/internal/pkg/cgroups"
+	"github.com/talos-systems/talos/pkg/machinery/api/machine"
+)
+
+// Cgroups implements the machine.MachineServer interface.
+func (s *Server) Cgroups(ctx context.Context, in *machine.CgroupsRequest) (*machine.CgroupsResponse, error) {
+	version, stats, err := cgroups.Walk(cgroups.DefaultRoot, in.Path, int(in.MaxDepth))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, status.Errorf(codes.NotFound, "cgroup %q not found", in.Path)
+		}
+
+		return nil, err
+	}
+
+	reply := &machine.Cgroups{
+		Version: uint32(version),
+		Cgroups: make([]*machine.Cgroup, 0, len(stats)),
+	}
+
+	for _, stat := range stats {
+		reply.Cgroups = append(reply.Cgroups, &machine.Cgroup{
+			Path:         stat.Path,
+			Processes:    uint32(stat.Processes),
+			CpuUsage:     stat.CPUUsage,
+			CpuQuota:     stat.CPUQuota,
+			CpuPeriod:    stat.CPUPeriod,
+			CpuWeight:    stat.CPUWeight,
+			MemoryUsage:  stat.MemoryUsage,
+			MemoryLimit:  stat.MemoryLimit,
+			IoReadBytes:  stat.IOReadBytes,
+			IoWriteBytes: stat.IOWriteBytes,
+		})
+	}
+
+	return &machine.CgroupsResponse{
+		Messages: []*machine.Cgroups{
+			reply,
+		},
+	}, nil
+}
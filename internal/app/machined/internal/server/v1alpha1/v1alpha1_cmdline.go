@@ -0,0 +1,73 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"context"
+	"io/ioutil"
+	"log"
+	"strings"
+
+	"github.com/golang/protobuf/ptypes/empty"
+
+	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
+	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime/v1alpha1/bootloader"
+	"github.com/talos-systems/talos/internal/pkg/mount"
+	"github.com/talos-systems/talos/pkg/machinery/api/machine"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+)
+
+// KernelCmdline implements the machine.MachineServer interface.
+func (s *Server) KernelCmdline(ctx context.Context, in *empty.Empty) (*machine.KernelCmdlineResponse, error) {
+	current, err := ioutil.ReadFile("/proc/cmdline")
+	if err != nil {
+		return nil, err
+	}
+
+	return &machine.KernelCmdlineResponse{
+		Messages: []*machine.KernelCmdline{
+			{
+				Current: strings.TrimSpace(string(current)),
+				Next:    s.nextKernelCmdline(),
+			},
+		},
+	}, nil
+}
+
+// nextKernelCmdline returns the kernel command line of the default boot entry.
+//
+// Errors are logged, as the command line of the next boot is not always available (e.g. boot partitions are mounted by the upgrade).
+func (s *Server) nextKernelCmdline() string {
+	r := s.Controller.Runtime()
+
+	if r.State().Platform().Mode() == runtime.ModeContainer || !r.State().Machine().Installed() {
+		return ""
+	}
+
+	for _, label := range []string{constants.BootPartitionLabel, constants.EFIPartitionLabel} {
+		label := label
+
+		if err := mount.SystemPartitionMount(r, label); err != nil {
+			log.Printf("error mounting %s partition: %s", label, err)
+
+			return ""
+		}
+
+		defer func() {
+			if err := mount.SystemPartitionUnmount(r, label); err != nil {
+				log.Printf("failed unmounting %s partition: %s", label, err)
+			}
+		}()
+	}
+
+	cmdline, err := bootloader.DefaultCmdline()
+	if err != nil {
+		log.Printf("error reading bootloader config: %s", err)
+
+		return ""
+	}
+
+	return cmdline
+}
@@ -0,0 +1,104 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/namespaces"
+	criconstants "github.com/containerd/cri/pkg/constants"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/talos-systems/talos/internal/pkg/containers/image"
+	"github.com/talos-systems/talos/pkg/machinery/api/common"
+	"github.com/talos-systems/talos/pkg/machinery/api/machine"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+)
+
+// ImageList implements the machine.MachineServer interface.
+func (s *Server) ImageList(in *machine.ImageListRequest, srv machine.MachineService_ImageListServer) error {
+	ctx, client, err := containerdClient(srv.Context(), in.Namespace)
+	if err != nil {
+		return err
+	}
+
+	//nolint:errcheck
+	defer client.Close()
+
+	images, err := client.ImageService().List(ctx)
+	if err != nil {
+		return fmt.Errorf("error listing images: %w", err)
+	}
+
+	for _, img := range images {
+		// image content might be missing for the images which are still being pulled
+		size, _ := containerd.NewImage(client, img).Size(ctx) //nolint:errcheck
+
+		if err = srv.Send(&machine.ImageListResponse{
+			Name:      img.Name,
+			Digest:    img.Target.Digest.String(),
+			Size:      size,
+			CreatedAt: timestamppb.New(img.CreatedAt),
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ImagePull implements the machine.MachineServer interface.
+func (s *Server) ImagePull(ctx context.Context, in *machine.ImagePullRequest) (*machine.ImagePullResponse, error) {
+	if in.Reference == "" {
+		return nil, status.Error(codes.InvalidArgument, "image reference is required")
+	}
+
+	ctx, client, err := containerdClient(ctx, in.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	//nolint:errcheck
+	defer client.Close()
+
+	img, err := image.Pull(ctx, s.Controller.Runtime().Config().Machine().Registries(), client, in.Reference, image.WithEnv(s.Controller.Runtime().Config().Machine().Env()), image.WithImageCache(s.Controller.Runtime().Config().Machine().ImageCache().Enabled()))
+	if err != nil {
+		return nil, err
+	}
+
+	return &machine.ImagePullResponse{
+		Messages: []*machine.ImagePull{
+			{
+				Name:   img.Name(),
+				Digest: img.Target().Digest.String(),
+			},
+		},
+	}, nil
+}
+
+// containerdClient connects to the containerd instance serving the namespace.
+func containerdClient(ctx context.Context, ns common.ContainerdNamespace) (context.Context, *containerd.Client, error) {
+	var namespace, address string
+
+	switch ns {
+	case common.ContainerdNamespace_NS_SYSTEM:
+		namespace, address = constants.SystemContainerdNamespace, constants.SystemContainerdAddress
+	case common.ContainerdNamespace_NS_CRI:
+		namespace, address = criconstants.K8sContainerdNamespace, constants.ContainerdAddress
+	default:
+		return nil, nil, status.Errorf(codes.InvalidArgument, "unsupported containerd namespace %q", ns)
+	}
+
+	client, err := containerd.New(address)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error connecting to containerd: %w", err)
+	}
+
+	return namespaces.WithNamespace(ctx, namespace), client, nil
+}
@@ -0,0 +1,61 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/talos-systems/talos/internal/pkg/metrics"
+)
+
+var (
+	configApplies = metrics.Factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "talos_machined_config_apply_total",
+		Help: "Number of the machine configuration apply requests.",
+	}, []string{"mode", "result"})
+
+	// lastEtcdBackup holds Unix time (in nanoseconds) of the last successful etcd snapshot.
+	lastEtcdBackup int64
+)
+
+func init() {
+	metrics.Default.MustRegister(etcdBackupAgeCollector{
+		desc: prometheus.NewDesc("talos_machined_etcd_backup_age_seconds", "Time since the last successful etcd snapshot.", nil, nil),
+	})
+}
+
+// etcdBackupAgeCollector reports the etcd backup age only once the first snapshot was taken.
+type etcdBackupAgeCollector struct {
+	desc *prometheus.Desc
+}
+
+func (c etcdBackupAgeCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c etcdBackupAgeCollector) Collect(ch chan<- prometheus.Metric) {
+	ts := atomic.LoadInt64(&lastEtcdBackup)
+	if ts == 0 {
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, time.Since(time.Unix(0, ts)).Seconds())
+}
+
+func recordConfigApply(mode string, err error) {
+	result := "success"
+	if err != nil {
+		result = "failure"
+	}
+
+	configApplies.WithLabelValues(mode, result).Inc()
+}
+
+func recordEtcdBackup() {
+	atomic.StoreInt64(&lastEtcdBackup, time.Now().UnixNano())
+}
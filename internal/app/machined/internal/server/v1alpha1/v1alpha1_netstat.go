@@ -0,0 +1,292 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	criconstants "github.com/containerd/cri/pkg/constants"
+	"github.com/prometheus/procfs"
+
+	"github.com/talos-systems/talos/internal/pkg/containers"
+	"github.com/talos-systems/talos/pkg/machinery/api/common"
+	"github.com/talos-systems/talos/pkg/machinery/api/machine"
+)
+
+// netstatNamespace is a network namespace which socket tables are read.
+type netstatNamespace struct {
+	fs  procfs.FS
+	pod string
+}
+
+// Netstat implements the machine.MachineServer interface.
+//
+//nolint:gocyclo
+func (s *Server) Netstat(ctx context.Context, req *machine.NetstatRequest) (*machine.NetstatResponse, error) {
+	if req.L4Proto == nil {
+		req.L4Proto = &machine.NetstatRequest_L4Proto{
+			Tcp:  true,
+			Tcp6: true,
+			Udp:  true,
+			Udp6: true,
+		}
+	}
+
+	hostFS, err := procfs.NewDefaultFS()
+	if err != nil {
+		return nil, err
+	}
+
+	namespaces := []netstatNamespace{
+		{
+			fs: hostFS,
+		},
+	}
+
+	// containerPods maps container IDs to the pod names
+	var containerPods map[string]string
+
+	if req.Pods {
+		var podNamespaces []netstatNamespace
+
+		podNamespaces, containerPods, err = netstatPods(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		namespaces = append(namespaces, podNamespaces...)
+	}
+
+	processes, err := netstatSocketOwners()
+	if err != nil {
+		return nil, err
+	}
+
+	var records []*machine.ConnectRecord
+
+	for _, ns := range namespaces {
+		for _, table := range []struct {
+			enabled bool
+			l4proto string
+			read    func() (procfs.NetIPSocket, error)
+		}{
+			{req.L4Proto.Tcp, "tcp", func() (procfs.NetIPSocket, error) { t, err := ns.fs.NetTCP(); return procfs.NetIPSocket(t), err }},
+			{req.L4Proto.Tcp6, "tcp6", func() (procfs.NetIPSocket, error) { t, err := ns.fs.NetTCP6(); return procfs.NetIPSocket(t), err }},
+			{req.L4Proto.Udp, "udp", func() (procfs.NetIPSocket, error) { t, err := ns.fs.NetUDP(); return procfs.NetIPSocket(t), err }},
+			{req.L4Proto.Udp6, "udp6", func() (procfs.NetIPSocket, error) { t, err := ns.fs.NetUDP6(); return procfs.NetIPSocket(t), err }},
+		} {
+			if !table.enabled {
+				continue
+			}
+
+			sockets, err := table.read()
+			if err != nil {
+				if os.IsNotExist(err) {
+					// e.g. IPv6 is disabled
+					continue
+				}
+
+				return nil, fmt.Errorf("error reading %s socket table: %w", table.l4proto, err)
+			}
+
+			for _, socket := range sockets {
+				record := &machine.ConnectRecord{
+					L4Proto:    table.l4proto,
+					Localip:    socket.LocalAddr.String(),
+					Localport:  uint32(socket.LocalPort),
+					Remoteip:   socket.RemAddr.String(),
+					Remoteport: uint32(socket.RemPort),
+					State:      machine.ConnectRecord_State(socket.St),
+					Txqueue:    socket.TxQueue,
+					Rxqueue:    socket.RxQueue,
+					Uid:        uint32(socket.UID),
+					Inode:      socket.Inode,
+				}
+
+				if !netstatFilter(req.Filter, record) {
+					continue
+				}
+
+				record.Process = processes[record.Inode]
+				record.Pod = ns.pod
+
+				if record.Pod == "" && record.Process != nil {
+					record.Pod = netstatCgroupPod(containerPods, record.Process.Cgroup)
+				}
+
+				records = append(records, record)
+			}
+		}
+	}
+
+	return &machine.NetstatResponse{
+		Messages: []*machine.Netstat{
+			{
+				Connectrecord: records,
+			},
+		},
+	}, nil
+}
+
+// netstatPods returns network namespaces of the pods which are not in the host network namespace,
+// and a mapping of container IDs to pod names.
+func netstatPods(ctx context.Context) ([]netstatNamespace, map[string]string, error) {
+	inspector, err := getContainerInspector(ctx, criconstants.K8sContainerdNamespace, common.ContainerDriver_CRI)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	//nolint:errcheck
+	defer inspector.Close()
+
+	pods, err := inspector.Pods()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error listing pods: %w", err)
+	}
+
+	hostNetNS, err := os.Readlink("/proc/self/ns/net")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var namespaces []netstatNamespace
+
+	containerPods := map[string]string{}
+
+	for _, pod := range pods {
+		for _, container := range pod.Containers {
+			containerPods[container.ID] = pod.Name
+
+			if !container.IsPodSandbox || container.Pid == 0 {
+				continue
+			}
+
+			namespace, err := netstatPodNamespace(pod, container, hostNetNS)
+			if err != nil {
+				// sandbox might have terminated
+				continue
+			}
+
+			if namespace != nil {
+				namespaces = append(namespaces, *namespace)
+			}
+		}
+	}
+
+	return namespaces, containerPods, nil
+}
+
+func netstatPodNamespace(pod *containers.Pod, sandbox *containers.Container, hostNetNS string) (*netstatNamespace, error) {
+	procPath := fmt.Sprintf("/proc/%d", sandbox.Pid)
+
+	netNS, err := os.Readlink(procPath + "/ns/net")
+	if err != nil {
+		return nil, err
+	}
+
+	if netNS == hostNetNS {
+		// host network pod, sockets are attributed via cgroups
+		return nil, nil
+	}
+
+	fs, err := procfs.NewFS(procPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &netstatNamespace{
+		fs:  fs,
+		pod: pod.Name,
+	}, nil
+}
+
+// netstatSocketOwners maps socket inodes to the processes which have the socket open.
+func netstatSocketOwners() (map[uint64]*machine.ConnectRecord_Process, error) {
+	procs, err := procfs.AllProcs()
+	if err != nil {
+		return nil, err
+	}
+
+	owners := map[uint64]*machine.ConnectRecord_Process{}
+
+	for _, proc := range procs {
+		// due to race condition, reading process info might fail if process has already terminated
+		targets, err := proc.FileDescriptorTargets()
+		if err != nil {
+			continue
+		}
+
+		var process *machine.ConnectRecord_Process
+
+		for _, target := range targets {
+			if !strings.HasPrefix(target, "socket:[") || !strings.HasSuffix(target, "]") {
+				continue
+			}
+
+			inode, err := strconv.ParseUint(target[len("socket:["):len(target)-1], 10, 64)
+			if err != nil {
+				continue
+			}
+
+			if _, exists := owners[inode]; exists {
+				continue
+			}
+
+			if process == nil {
+				process = &machine.ConnectRecord_Process{
+					Pid: uint32(proc.PID),
+				}
+
+				process.Name, _ = proc.Comm() //nolint:errcheck
+
+				if cgroups, err := proc.Cgroups(); err == nil {
+					process.Cgroup = cgroupPath(cgroups)
+				}
+			}
+
+			owners[inode] = process
+		}
+	}
+
+	return owners, nil
+}
+
+// netstatFilter reports whether the record matches the filter.
+//
+// Unconnected UDP sockets are considered to be listening.
+func netstatFilter(filter machine.NetstatRequest_Filter, record *machine.ConnectRecord) bool {
+	listening := record.State == machine.ConnectRecord_LISTEN ||
+		(strings.HasPrefix(record.L4Proto, "udp") && record.State == machine.ConnectRecord_CLOSE && record.Remoteport == 0)
+
+	switch filter {
+	case machine.NetstatRequest_CONNECTED:
+		return !listening
+	case machine.NetstatRequest_LISTENING:
+		return listening
+	case machine.NetstatRequest_ALL:
+		fallthrough
+	default:
+		return true
+	}
+}
+
+// netstatCgroupPod finds the pod by the container ID in the cgroup path of the process.
+func netstatCgroupPod(containerPods map[string]string, cgroup string) string {
+	if cgroup == "" {
+		return ""
+	}
+
+	for id, pod := range containerPods {
+		if strings.Contains(cgroup, id) {
+			return pod
+		}
+	}
+
+	return ""
+}
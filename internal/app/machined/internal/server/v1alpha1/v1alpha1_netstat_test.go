@@ -0,0 +1,107 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/talos-systems/talos/pkg/machinery/api/machine"
+)
+
+func TestNetstatFilter(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		record    *machine.ConnectRecord
+		listening bool
+	}{
+		{
+			name:      "tcp listening",
+			record:    &machine.ConnectRecord{L4Proto: "tcp", State: machine.ConnectRecord_LISTEN},
+			listening: true,
+		},
+		{
+			name:      "tcp6 listening",
+			record:    &machine.ConnectRecord{L4Proto: "tcp6", State: machine.ConnectRecord_LISTEN},
+			listening: true,
+		},
+		{
+			name:   "tcp established",
+			record: &machine.ConnectRecord{L4Proto: "tcp", State: machine.ConnectRecord_ESTABLISHED, Remoteip: "10.5.0.2", Remoteport: 6443},
+		},
+		{
+			name:   "tcp time wait",
+			record: &machine.ConnectRecord{L4Proto: "tcp", State: machine.ConnectRecord_TIME_WAIT, Remoteip: "10.5.0.2", Remoteport: 6443},
+		},
+		{
+			name:   "tcp closed without remote port",
+			record: &machine.ConnectRecord{L4Proto: "tcp", State: machine.ConnectRecord_CLOSE},
+		},
+		{
+			name:      "unconnected udp",
+			record:    &machine.ConnectRecord{L4Proto: "udp", State: machine.ConnectRecord_CLOSE, Remoteip: "0.0.0.0"},
+			listening: true,
+		},
+		{
+			name:      "unconnected udp6",
+			record:    &machine.ConnectRecord{L4Proto: "udp6", State: machine.ConnectRecord_CLOSE, Remoteip: "::"},
+			listening: true,
+		},
+		{
+			name:   "connected udp",
+			record: &machine.ConnectRecord{L4Proto: "udp", State: machine.ConnectRecord_ESTABLISHED, Remoteip: "10.96.0.10", Remoteport: 53},
+		},
+	} {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			assert.True(t, netstatFilter(machine.NetstatRequest_ALL, tt.record))
+			assert.Equal(t, tt.listening, netstatFilter(machine.NetstatRequest_LISTENING, tt.record))
+			assert.Equal(t, !tt.listening, netstatFilter(machine.NetstatRequest_CONNECTED, tt.record))
+		})
+	}
+}
+
+func TestNetstatCgroupPod(t *testing.T) {
+	containerPods := map[string]string{
+		"4ff3ea5c1b0e9b8d7c1f0a6e2b3d4c5f6a7b8c9d0e1f2a3b4c5d6e7f8a9b0c1d": "kube-proxy-8xkqz",
+		"9a8b7c6d5e4f3a2b1c0d9e8f7a6b5c4d3e2f1a0b9c8d7e6f5a4b3c2d1e0f9a8b": "coredns-fcc4c97fb-xtr7p",
+	}
+
+	for _, tt := range []struct {
+		name     string
+		cgroup   string
+		expected string
+	}{
+		{
+			name:     "cgroupfs driver",
+			cgroup:   "/kubepods/besteffort/pod0f5e6a6c-7f0e-4c1d-9d5e-1c2b3a4d5e6f/4ff3ea5c1b0e9b8d7c1f0a6e2b3d4c5f6a7b8c9d0e1f2a3b4c5d6e7f8a9b0c1d",
+			expected: "kube-proxy-8xkqz",
+		},
+		{
+			name:     "systemd driver",
+			cgroup:   "/kubepods.slice/kubepods-burstable.slice/kubepods-burstable-pod1a2b.slice/cri-containerd-9a8b7c6d5e4f3a2b1c0d9e8f7a6b5c4d3e2f1a0b9c8d7e6f5a4b3c2d1e0f9a8b.scope",
+			expected: "coredns-fcc4c97fb-xtr7p",
+		},
+		{
+			name:   "system service",
+			cgroup: "/system/runtime",
+		},
+		{
+			name:   "unknown container",
+			cgroup: "/kubepods/besteffort/pod0f5e6a6c-7f0e-4c1d-9d5e-1c2b3a4d5e6f/0000000000000000000000000000000000000000000000000000000000000000",
+		},
+		{
+			name: "no cgroup",
+		},
+	} {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, netstatCgroupPod(containerPods, tt.cgroup))
+		})
+	}
+}
@@ -0,0 +1,152 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"golang.org/x/sys/unix"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/talos-systems/talos/internal/pkg/pcap"
+	"github.com/talos-systems/talos/pkg/chunker/stream"
+	"github.com/talos-systems/talos/pkg/machinery/api/common"
+	"github.com/talos-systems/talos/pkg/machinery/api/machine"
+)
+
+// pcapMaxFilterLen is the maximum length of BPF program accepted by the kernel.
+const pcapMaxFilterLen = 4096
+
+// pcapMaxSnapLen is the maximum allowed snap length.
+const pcapMaxSnapLen = 262144
+
+// PacketCapture implements the machine.MachineServer interface.
+func (s *Server) PacketCapture(in *machine.PacketCaptureRequest, srv machine.MachineService_PacketCaptureServer) error {
+	if len(in.BpfFilter) > pcapMaxFilterLen {
+		return status.Errorf(codes.InvalidArgument, "BPF filter is too long: %d instructions", len(in.BpfFilter))
+	}
+
+	if in.SnapLen > pcapMaxSnapLen {
+		return status.Errorf(codes.InvalidArgument, "snap length %d exceeds maximum %d", in.SnapLen, pcapMaxSnapLen)
+	}
+
+	filter := make([]unix.SockFilter, 0, len(in.BpfFilter))
+
+	for _, insn := range in.BpfFilter {
+		filter = append(filter, unix.SockFilter{
+			Code: uint16(insn.Op),
+			Jt:   uint8(insn.Jt),
+			Jf:   uint8(insn.Jf),
+			K:    insn.K,
+		})
+	}
+
+	handle, err := pcap.Open(in.Interface,
+		pcap.WithPromiscuous(in.Promiscuous),
+		pcap.WithSnapLen(int(in.SnapLen)),
+		pcap.WithFilter(filter),
+	)
+	if err != nil {
+		return fmt.Errorf("error starting packet capture on %q: %w", in.Interface, err)
+	}
+
+	//nolint:errcheck
+	defer handle.Close()
+
+	ctx, cancel := context.WithCancel(srv.Context())
+	defer cancel()
+
+	// capture context is limited by the duration, while the stream context is not,
+	// so that buffered packets are still sent once the capture stops
+	captureCtx := ctx
+
+	if in.Duration > 0 {
+		var captureCancel context.CancelFunc
+
+		captureCtx, captureCancel = context.WithTimeout(ctx, time.Duration(in.Duration)*time.Second)
+		defer captureCancel()
+	}
+
+	pr, pw := io.Pipe()
+
+	errCh := make(chan error, 1)
+
+	go func() {
+		//nolint:errcheck
+		defer pw.Close()
+
+		errCh <- capturePackets(captureCtx, handle, pw, in.PacketCount)
+	}()
+
+	chunker := stream.NewChunker(ctx, pr)
+	chunkCh := chunker.Read()
+
+	for data := range chunkCh {
+		err := srv.SendMsg(&common.Data{Bytes: data})
+		if err != nil {
+			cancel()
+		}
+	}
+
+	// unblock the capture if the stream was aborted
+	pr.Close() //nolint:errcheck
+
+	captureErr := <-errCh
+	if captureErr != nil && ctx.Err() == nil {
+		return srv.SendMsg(&common.Data{
+			Metadata: &common.Metadata{
+				Error: captureErr.Error(),
+			},
+		})
+	}
+
+	return nil
+}
+
+// capturePackets writes captured packets in pcap format until the context is canceled
+// or packet count limit is reached.
+func capturePackets(ctx context.Context, handle *pcap.Handle, out io.Writer, packetCount uint32) error {
+	bw := bufio.NewWriter(out)
+	w := pcap.NewWriter(bw)
+
+	if err := w.WriteFileHeader(uint32(handle.SnapLen()), handle.LinkType()); err != nil {
+		return err
+	}
+
+	for captured := uint32(0); packetCount == 0 || captured < packetCount; {
+		select {
+		case <-ctx.Done():
+			return bw.Flush()
+		default:
+		}
+
+		data, ci, err := handle.ReadPacket()
+		if err != nil {
+			if err == pcap.ErrTimeout {
+				// no packets, send out what was captured so far
+				if err = bw.Flush(); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			return err
+		}
+
+		if err = w.WritePacket(ci, data); err != nil {
+			return err
+		}
+
+		captured++
+	}
+
+	return bw.Flush()
+}
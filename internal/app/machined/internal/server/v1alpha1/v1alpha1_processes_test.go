@@ -0,0 +1,182 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/talos-systems/talos/pkg/machinery/api/machine"
+)
+
+type fixtureProcess struct {
+	pid     string
+	comm    string
+	exe     string
+	cmdline string
+	stat    string
+	cgroup  string
+	cwd     string
+	fds     []string
+}
+
+// writeProcfs builds a procfs tree in the directory with the processes.
+func writeProcfs(t *testing.T, dir string, processes []fixtureProcess) {
+	for _, p := range processes {
+		procDir := filepath.Join(dir, p.pid)
+
+		require.NoError(t, os.MkdirAll(filepath.Join(procDir, "fd"), 0o755))
+
+		for name, contents := range map[string]string{
+			"comm":    p.comm + "\n",
+			"cmdline": p.cmdline,
+			"stat":    p.stat + "\n",
+			"cgroup":  p.cgroup,
+		} {
+			require.NoError(t, ioutil.WriteFile(filepath.Join(procDir, name), []byte(contents), 0o644))
+		}
+
+		require.NoError(t, os.Symlink(p.exe, filepath.Join(procDir, "exe")))
+		require.NoError(t, os.Symlink(p.cwd, filepath.Join(procDir, "cwd")))
+
+		for i, target := range p.fds {
+			require.NoError(t, os.Symlink(target, filepath.Join(procDir, "fd", strconv.Itoa(i))))
+		}
+	}
+}
+
+func fixtureProcesses() []fixtureProcess {
+	return []fixtureProcess{
+		{
+			pid:     "1",
+			comm:    "init",
+			exe:     "/sbin/init",
+			cmdline: "/sbin/init\x00",
+			stat:    "1 (init) S 0 1 1 0 -1 4194560 5036 0 33 0 150 250 0 0 20 0 12 0 4 1503232000 12800 18446744073709551615 1 1 0 0 0 0 0 0 0 0 0 0 17 2 0 0 0 0 0 0 0 0 0 0 0 0 0",
+			cgroup:  "0::/init\n",
+			cwd:     "/",
+			fds:     []string{"/dev/null", "/dev/console", "socket:[12345]"},
+		},
+		{
+			pid:     "1200",
+			comm:    "containerd",
+			exe:     "/bin/containerd",
+			cmdline: "/bin/containerd\x00--address\x00/system/run/containerd/containerd.sock\x00",
+			stat:    "1200 (containerd) S 1 1200 1200 0 -1 1077936384 3000 0 0 0 20 10 0 0 20 0 15 0 300 762195968 5120 18446744073709551615 1 1 0 0 0 0 0 0 0 0 0 0 17 1 0 0 0 0 0 0 0 0 0 0 0 0 0",
+			cgroup:  "0::/system/runtime\n",
+			cwd:     "/",
+			fds:     []string{"/dev/null"},
+		},
+		{
+			pid:     "1300",
+			comm:    "kube-proxy",
+			exe:     "/usr/local/bin/kube-proxy",
+			cmdline: "/usr/local/bin/kube-proxy\x00--config=/var/lib/kube-proxy/config.conf\x00",
+			stat:    "1300 (kube-proxy) S 1200 1300 1300 0 -1 1077936384 3000 0 0 0 40 20 0 0 20 0 8 0 500 762195968 2560 18446744073709551615 1 1 0 0 0 0 0 0 0 0 0 0 17 1 0 0 0 0 0 0 0 0 0 0 0 0 0",
+			cgroup:  "4:memory:/kubepods/besteffort/pod1/abcdef\n1:name=systemd:/kubepods/besteffort/pod1/abcdef\n",
+			cwd:     "/var/lib",
+		},
+	}
+}
+
+func listProcesses(t *testing.T, dir string) []*machine.ProcessInfo {
+	processes, err := processList(dir)
+	require.NoError(t, err)
+
+	sort.Slice(processes, func(i, j int) bool { return processes[i].Pid < processes[j].Pid })
+
+	return processes
+}
+
+func TestProcessList(t *testing.T) {
+	dir := t.TempDir()
+
+	writeProcfs(t, dir, fixtureProcesses())
+
+	processes := listProcesses(t, dir)
+	require.Len(t, processes, 3)
+
+	initProc := processes[0]
+
+	assert.EqualValues(t, 1, initProc.Pid)
+	assert.EqualValues(t, 0, initProc.Ppid)
+	assert.Equal(t, "S", initProc.State)
+	assert.EqualValues(t, 12, initProc.Threads)
+	assert.Equal(t, 4.0, initProc.CpuTime)
+	assert.EqualValues(t, 1503232000, initProc.VirtualMemory)
+	assert.EqualValues(t, 12800*os.Getpagesize(), initProc.ResidentMemory)
+	assert.Equal(t, "init", initProc.Command)
+	assert.Equal(t, "/sbin/init", initProc.Executable)
+	assert.Equal(t, "/sbin/init", initProc.Args)
+	assert.Equal(t, "/init", initProc.Cgroup)
+	assert.EqualValues(t, 3, initProc.OpenFiles)
+
+	containerd := processes[1]
+
+	assert.EqualValues(t, 1200, containerd.Pid)
+	assert.EqualValues(t, 1, containerd.Ppid)
+	assert.Equal(t, "/bin/containerd --address /system/run/containerd/containerd.sock", containerd.Args)
+	assert.Equal(t, "/system/runtime", containerd.Cgroup)
+	assert.EqualValues(t, 1, containerd.OpenFiles)
+
+	// no unified hierarchy, the first hierarchy is used
+	kubeProxy := processes[2]
+
+	assert.EqualValues(t, 1200, kubeProxy.Ppid)
+	assert.Equal(t, "/kubepods/besteffort/pod1/abcdef", kubeProxy.Cgroup)
+	assert.EqualValues(t, 0, kubeProxy.OpenFiles)
+}
+
+func TestProcessListTerminated(t *testing.T) {
+	dir := t.TempDir()
+
+	writeProcfs(t, dir, fixtureProcesses())
+
+	// process exited while the list is read
+	require.NoError(t, os.Remove(filepath.Join(dir, "1200", "stat")))
+
+	processes := listProcesses(t, dir)
+	require.Len(t, processes, 2)
+
+	assert.EqualValues(t, 1, processes[0].Pid)
+	assert.EqualValues(t, 1300, processes[1].Pid)
+}
+
+func TestProcessDetails(t *testing.T) {
+	dir := t.TempDir()
+
+	writeProcfs(t, dir, fixtureProcesses())
+
+	details, err := processDetails(dir, 1200)
+	require.NoError(t, err)
+
+	assert.EqualValues(t, 1200, details.Process.Pid)
+	assert.Equal(t, "containerd", details.Process.Command)
+	assert.EqualValues(t, os.Getuid(), details.Uid)
+	assert.EqualValues(t, os.Getgid(), details.Gid)
+	assert.Equal(t, "/", details.Cwd)
+	assert.Equal(t, []string{"/dev/null"}, details.OpenFiles)
+	assert.Equal(t, []int32{1300}, details.Children)
+
+	details, err = processDetails(dir, 1300)
+	require.NoError(t, err)
+
+	assert.Equal(t, "/var/lib", details.Cwd)
+	assert.Empty(t, details.OpenFiles)
+	assert.Empty(t, details.Children)
+
+	_, err = processDetails(dir, 4242)
+	require.Error(t, err)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/talos-systems/os-runtime/pkg/state"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"gopkg.in/yaml.v3"
 
 	resourceapi "github.com/talos-systems/talos/pkg/machinery/api/resource"
@@ -26,7 +27,7 @@ type ResourceServer struct {
 	server *Server
 }
 
-func marshalResource(r resource.Resource) (*resourceapi.Resource, error) {
+func (s *ResourceServer) marshalResource(r resource.Resource) (*resourceapi.Resource, error) {
 	md := &resourceapi.Metadata{
 		Namespace: r.Metadata().Namespace(),
 		Type:      r.Metadata().Type(),
@@ -40,6 +41,10 @@ func marshalResource(r resource.Resource) (*resourceapi.Resource, error) {
 		md.Finalizers = append(md.Finalizers, fin)
 	}
 
+	if updated := s.server.Controller.Runtime().State().V1Alpha2().ResourceUpdated(r.Metadata()); !updated.IsZero() {
+		md.Updated = timestamppb.New(updated)
+	}
+
 	spec := &resourceapi.Spec{}
 
 	if r.Spec() != nil {
@@ -159,12 +164,12 @@ func (s *ResourceServer) Get(ctx context.Context, in *resourceapi.GetRequest) (*
 		return nil, err
 	}
 
-	protoD, err := marshalResource(resourceDefinition)
+	protoD, err := s.marshalResource(resourceDefinition)
 	if err != nil {
 		return nil, err
 	}
 
-	protoR, err := marshalResource(r)
+	protoR, err := s.marshalResource(r)
 	if err != nil {
 		return nil, err
 	}
@@ -202,7 +207,7 @@ func (s *ResourceServer) List(in *resourceapi.ListRequest, srv resourceapi.Resou
 		return err
 	}
 
-	protoD, err := marshalResource(resourceDefinition)
+	protoD, err := s.marshalResource(resourceDefinition)
 	if err != nil {
 		return err
 	}
@@ -214,7 +219,7 @@ func (s *ResourceServer) List(in *resourceapi.ListRequest, srv resourceapi.Resou
 	}
 
 	for _, r := range list.Items {
-		protoR, err := marshalResource(r)
+		protoR, err := s.marshalResource(r)
 		if err != nil {
 			return err
 		}
@@ -249,7 +254,7 @@ func (s *ResourceServer) Watch(in *resourceapi.WatchRequest, srv resourceapi.Res
 
 	resources := s.server.Controller.Runtime().State().V1Alpha2().Resources()
 
-	protoD, err := marshalResource(resourceDefinition)
+	protoD, err := s.marshalResource(resourceDefinition)
 	if err != nil {
 		return err
 	}
@@ -278,7 +283,7 @@ func (s *ResourceServer) Watch(in *resourceapi.WatchRequest, srv resourceapi.Res
 	}
 
 	for event := range eventCh {
-		protoR, err := marshalResource(event.Resource)
+		protoR, err := s.marshalResource(event.Resource)
 		if err != nil {
 			return err
 		}
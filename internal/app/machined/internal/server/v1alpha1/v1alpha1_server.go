@@ -18,7 +18,9 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
@@ -37,6 +39,10 @@ import (
 	"go.etcd.io/etcd/client/v3/concurrency"
 	"golang.org/x/sys/unix"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	installer "github.com/talos-systems/talos/cmd/installer/pkg/install"
 	"github.com/talos-systems/talos/internal/app/machined/internal/install"
@@ -44,7 +50,6 @@ import (
 	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime/disk"
 	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime/v1alpha1/bootloader"
 	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime/v1alpha1/bootloader/adv"
-	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime/v1alpha1/bootloader/grub"
 	"github.com/talos-systems/talos/internal/app/machined/pkg/system"
 	networkserver "github.com/talos-systems/talos/internal/app/networkd/pkg/server"
 	storaged "github.com/talos-systems/talos/internal/app/storaged"
@@ -57,18 +62,23 @@ import (
 	"github.com/talos-systems/talos/internal/pkg/kmsg"
 	"github.com/talos-systems/talos/internal/pkg/kubeconfig"
 	"github.com/talos-systems/talos/internal/pkg/mount"
+	"github.com/talos-systems/talos/internal/pkg/revocation"
 	"github.com/talos-systems/talos/pkg/archiver"
 	"github.com/talos-systems/talos/pkg/chunker"
 	"github.com/talos-systems/talos/pkg/chunker/stream"
+	"github.com/talos-systems/talos/pkg/grpc/dialer"
+	"github.com/talos-systems/talos/pkg/kubernetes"
 	"github.com/talos-systems/talos/pkg/machinery/api/cluster"
 	"github.com/talos-systems/talos/pkg/machinery/api/common"
 	"github.com/talos-systems/talos/pkg/machinery/api/inspect"
 	"github.com/talos-systems/talos/pkg/machinery/api/machine"
 	"github.com/talos-systems/talos/pkg/machinery/api/network"
 	"github.com/talos-systems/talos/pkg/machinery/api/resource"
+	securityapi "github.com/talos-systems/talos/pkg/machinery/api/security"
 	"github.com/talos-systems/talos/pkg/machinery/api/storage"
 	timeapi "github.com/talos-systems/talos/pkg/machinery/api/time"
 	"github.com/talos-systems/talos/pkg/machinery/config"
+	"github.com/talos-systems/talos/pkg/machinery/config/configloader"
 	machinetype "github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1/machine"
 	"github.com/talos-systems/talos/pkg/machinery/constants"
 	"github.com/talos-systems/talos/pkg/version"
@@ -92,6 +102,9 @@ type Server struct {
 	Controller runtime.Controller
 
 	server *grpc.Server
+
+	// applyMu serializes the config changes, so that the checksum precondition can't race
+	applyMu sync.Mutex
 }
 
 func (s *Server) checkSupported(feature runtime.ModeCapability) error {
@@ -115,14 +128,41 @@ func (s *Server) Register(obj *grpc.Server) {
 	storage.RegisterStorageServiceServer(obj, &storaged.Server{})
 	timeapi.RegisterTimeServiceServer(obj, &TimeServer{ConfigProvider: s.Controller.Runtime()})
 	network.RegisterNetworkServiceServer(obj, &networkserver.NetworkServer{})
+
+	// register reflection service, so that the clients can discover the API served by this version of Talos
+	reflection.Register(obj)
 }
 
 // ApplyConfiguration implements machine.MachineService.
 //
 //nolint:gocyclo
-func (s *Server) ApplyConfiguration(ctx context.Context, in *machine.ApplyConfigurationRequest) (*machine.ApplyConfigurationResponse, error) {
+func (s *Server) ApplyConfiguration(ctx context.Context, in *machine.ApplyConfigurationRequest) (reply *machine.ApplyConfigurationResponse, err error) {
 	log.Printf("apply config request: immediate %v, on reboot %v", in.Immediate, in.OnReboot)
 
+	mode := "reboot"
+
+	switch {
+	case in.Immediate:
+		mode = "immediate"
+	case in.OnReboot:
+		mode = "on_reboot"
+	}
+
+	defer func() {
+		recordConfigApply(mode, err)
+	}()
+
+	s.applyMu.Lock()
+	defer s.applyMu.Unlock()
+
+	if in.ExpectedChecksum != "" {
+		if err = s.checkConfigChecksum(in.ExpectedChecksum); err != nil {
+			return nil, err
+		}
+	}
+
+	var applied []byte
+
 	applyDynamicConfig := func() ([]byte, error) {
 		cfg, err := s.Controller.Runtime().ValidateConfig(in.GetData())
 		if err != nil {
@@ -153,15 +193,20 @@ func (s *Server) ApplyConfiguration(ctx context.Context, in *machine.ApplyConfig
 			return nil, err
 		}
 
-		if err := ioutil.WriteFile(constants.ConfigPath, in.GetData(), 0o600); err != nil {
+		// store the config with the dynamic config applied, so that it matches the active config
+		if err := ioutil.WriteFile(constants.ConfigPath, cfg, 0o600); err != nil {
 			return nil, err
 		}
+
+		applied = cfg
 	// default (no flags)
 	case !in.OnReboot:
 		if err := s.Controller.Runtime().SetConfig(in.GetData()); err != nil {
 			return nil, err
 		}
 
+		applied = in.GetData()
+
 		go func() {
 			if err := s.Controller.Run(context.Background(), runtime.SequenceApplyConfiguration, in); err != nil {
 				if !runtime.IsRebootError(err) {
@@ -183,15 +228,122 @@ func (s *Server) ApplyConfiguration(ctx context.Context, in *machine.ApplyConfig
 		if err = ioutil.WriteFile(constants.ConfigPath, cfg, 0o600); err != nil {
 			return nil, err
 		}
+
+		applied = cfg
+	}
+
+	appliedCfg, err := configloader.NewFromBytes(applied)
+	if err != nil {
+		return nil, err
+	}
+
+	checksum, err := config.Checksum(appliedCfg)
+	if err != nil {
+		return nil, err
 	}
 
 	return &machine.ApplyConfigurationResponse{
 		Messages: []*machine.ApplyConfiguration{
-			{},
+			{
+				Checksum: checksum,
+			},
+		},
+	}, nil
+}
+
+// checkConfigChecksum implements the optimistic concurrency control for the config changes:
+// the config is applied only if the latest config is the one the client has seen.
+//
+// If the stored config differs from the active config (the config was applied with `--on-reboot`),
+// the stored config is the latest one, so that the concurrent `--on-reboot` changes don't overwrite each other.
+func (s *Server) checkConfigChecksum(expected string) error {
+	cfg := s.Controller.Runtime().Config()
+
+	checksum, err := config.Checksum(cfg)
+	if err != nil {
+		return err
+	}
+
+	if cfg.Persist() {
+		storedChecksum, storedErr := storedConfigChecksum()
+		if storedErr != nil && !errors.Is(storedErr, os.ErrNotExist) {
+			return storedErr
+		}
+
+		if storedChecksum != "" && storedChecksum != checksum {
+			if storedChecksum != expected {
+				return status.Errorf(codes.Aborted,
+					"config was modified concurrently: expected checksum %s, staged config checksum %s (reboot the node or discard the staged config with 'talosctl sync-config')",
+					expected, storedChecksum)
+			}
+
+			return nil
+		}
+	}
+
+	if checksum != expected {
+		return status.Errorf(codes.Aborted, "config was modified concurrently: expected checksum %s, active config checksum %s", expected, checksum)
+	}
+
+	return nil
+}
+
+// SyncConfiguration implements the machine.MachineServer interface.
+//
+// SyncConfiguration overwrites the stored config with the active config.
+func (s *Server) SyncConfiguration(ctx context.Context, in *machine.SyncConfigurationRequest) (*machine.SyncConfigurationResponse, error) {
+	cfg := s.Controller.Runtime().Config()
+
+	if !cfg.Persist() {
+		return nil, status.Error(codes.FailedPrecondition, "config persistence is disabled")
+	}
+
+	b, err := cfg.Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	checksum, err := config.Checksum(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	// previous checksum is left empty if the stored config is missing or can't be parsed
+	previousChecksum, _ := storedConfigChecksum()
+
+	if err = ioutil.WriteFile(constants.ConfigPath, b, 0o600); err != nil {
+		return nil, err
+	}
+
+	log.Printf("stored config is synced with the active config")
+
+	return &machine.SyncConfigurationResponse{
+		Messages: []*machine.SyncConfiguration{
+			{
+				PreviousChecksum: previousChecksum,
+				Checksum:         checksum,
+			},
 		},
 	}, nil
 }
 
+// storedConfigChecksum returns the checksum of the config stored on disk.
+//
+// Stored config is parsed and marshaled back, so that the checksum matches the drift status and the checksum of the active config.
+func storedConfigChecksum() (string, error) {
+	b, err := ioutil.ReadFile(constants.ConfigPath)
+	if err != nil {
+		return "", err
+	}
+
+	stored, err := configloader.NewFromBytes(b)
+	if err != nil {
+		return "", fmt.Errorf("error parsing stored config: %w", err)
+	}
+
+	return config.Checksum(stored)
+}
+
 // GenerateConfiguration implements the machine.MachineServer interface.
 func (s *Server) GenerateConfiguration(ctx context.Context, in *machine.GenerateConfigurationRequest) (reply *machine.GenerateConfigurationResponse, err error) {
 	if s.Controller.Runtime().Config().Machine().Type() == machinetype.TypeJoin {
@@ -201,18 +353,152 @@ func (s *Server) GenerateConfiguration(ctx context.Context, in *machine.Generate
 	return configuration.Generate(ctx, in)
 }
 
+// Cordon implements the machine.MachineServer interface.
+func (s *Server) Cordon(ctx context.Context, in *machine.CordonRequest) (*machine.CordonResponse, error) {
+	nodeName, kubeHelper, err := s.kubeletClient()
+	if err != nil {
+		return nil, err
+	}
+
+	if err = kubeHelper.Cordon(ctx, nodeName); err != nil {
+		return nil, err
+	}
+
+	log.Printf("node %q cordoned via API", nodeName)
+
+	return &machine.CordonResponse{
+		Messages: []*machine.Cordon{
+			{
+				NodeName: nodeName,
+			},
+		},
+	}, nil
+}
+
+// Uncordon implements the machine.MachineServer interface.
+func (s *Server) Uncordon(ctx context.Context, in *machine.UncordonRequest) (*machine.UncordonResponse, error) {
+	nodeName, kubeHelper, err := s.kubeletClient()
+	if err != nil {
+		return nil, err
+	}
+
+	if err = kubeHelper.Uncordon(ctx, nodeName, in.GetForce()); err != nil {
+		return nil, err
+	}
+
+	log.Printf("node %q uncordoned via API", nodeName)
+
+	return &machine.UncordonResponse{
+		Messages: []*machine.Uncordon{
+			{
+				NodeName: nodeName,
+			},
+		},
+	}, nil
+}
+
+// PauseReconcile implements the machine.MachineServer interface.
+func (s *Server) PauseReconcile(ctx context.Context, in *machine.PauseReconcileRequest) (*machine.PauseReconcileResponse, error) {
+	var duration time.Duration
+
+	if in.GetDuration() != nil {
+		if err := in.GetDuration().CheckValid(); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid duration: %s", err)
+		}
+
+		duration = in.GetDuration().AsDuration()
+	}
+
+	if duration < 0 {
+		return nil, status.Error(codes.InvalidArgument, "duration should not be negative")
+	}
+
+	var until time.Time
+
+	if duration > 0 {
+		until = time.Now().Add(duration)
+	}
+
+	if err := s.Controller.Runtime().State().V1Alpha2().SetReconcilePause(until); err != nil {
+		return nil, err
+	}
+
+	reply := &machine.PauseReconcile{}
+
+	if until.IsZero() {
+		log.Printf("reconciliation resumed via API")
+	} else {
+		log.Printf("reconciliation paused via API until %s", until.Format(time.RFC3339))
+
+		reply.PausedUntil = timestamppb.New(until)
+	}
+
+	return &machine.PauseReconcileResponse{
+		Messages: []*machine.PauseReconcile{reply},
+	}, nil
+}
+
+// AuditLog implements the machine.MachineServer interface.
+func (s *Server) AuditLog(ctx context.Context, in *machine.AuditLogRequest) (*machine.AuditLogResponse, error) {
+	if in.GetTailEntries() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "tail entries should not be negative")
+	}
+
+	entries := s.Controller.Runtime().Audit().Entries(int(in.GetTailEntries()))
+
+	reply := &machine.AuditLog{
+		Entries: make([]*machine.AuditLogEntry, 0, len(entries)),
+	}
+
+	for _, entry := range entries {
+		reply.Entries = append(reply.Entries, &machine.AuditLogEntry{
+			Time:    timestamppb.New(entry.Time),
+			Method:  entry.Method,
+			Client:  entry.Client,
+			Peer:    entry.Peer,
+			Request: entry.Request,
+			Error:   entry.Error,
+		})
+	}
+
+	return &machine.AuditLogResponse{
+		Messages: []*machine.AuditLog{reply},
+	}, nil
+}
+
+// kubeletClient returns the Kubernetes node name of the machine and the client using kubelet credentials.
+func (s *Server) kubeletClient() (string, *kubernetes.Client, error) {
+	nodeName, err := s.Controller.Runtime().NodeName()
+	if err != nil {
+		return "", nil, err
+	}
+
+	if _, err = os.Stat(constants.KubeletKubeconfig); err != nil {
+		return "", nil, status.Error(codes.FailedPrecondition, "kubelet is not registered with the Kubernetes API server")
+	}
+
+	kubeHelper, err := kubernetes.NewClientFromKubeletKubeconfig()
+	if err != nil {
+		return "", nil, err
+	}
+
+	return nodeName, kubeHelper, nil
+}
+
 // Reboot implements the machine.MachineServer interface.
 //
 //nolint:dupl
-func (s *Server) Reboot(ctx context.Context, in *empty.Empty) (reply *machine.RebootResponse, err error) {
+func (s *Server) Reboot(ctx context.Context, in *machine.RebootRequest) (reply *machine.RebootResponse, err error) {
 	log.Printf("reboot via API received")
 
 	if err := s.checkSupported(runtime.Reboot); err != nil {
 		return nil, err
 	}
 
+	actorCtx, actorID := newActorContext()
+
 	go func() {
-		if err := s.Controller.Run(context.Background(), runtime.SequenceReboot, in, runtime.WithTakeover()); err != nil {
+		if err := s.Controller.Run(actorCtx, runtime.SequenceReboot, in, runtime.WithTakeover()); err != nil {
 			if !runtime.IsRebootError(err) {
 				log.Println("reboot failed:", err)
 			}
@@ -227,7 +513,9 @@ func (s *Server) Reboot(ctx context.Context, in *empty.Empty) (reply *machine.Re
 
 	reply = &machine.RebootResponse{
 		Messages: []*machine.Reboot{
-			{},
+			{
+				ActorId: actorID,
+			},
 		},
 	}
 
@@ -245,26 +533,29 @@ func (s *Server) Rollback(ctx context.Context, in *machine.RollbackRequest) (*ma
 	}
 
 	if err := func() error {
-		if err := mount.SystemPartitionMount(s.Controller.Runtime(), constants.BootPartitionLabel); err != nil {
-			return fmt.Errorf("error mounting boot partition: %w", err)
-		}
+		// EFI partition holds systemd-boot loader config
+		for _, label := range []string{constants.BootPartitionLabel, constants.EFIPartitionLabel} {
+			label := label
 
-		defer func() {
-			if err := mount.SystemPartitionUnmount(s.Controller.Runtime(), constants.BootPartitionLabel); err != nil {
-				log.Printf("failed unmounting boot partition: %s", err)
+			if err := mount.SystemPartitionMount(s.Controller.Runtime(), label); err != nil {
+				return fmt.Errorf("error mounting %s partition: %w", label, err)
 			}
-		}()
+
+			defer func() {
+				if err := mount.SystemPartitionUnmount(s.Controller.Runtime(), label); err != nil {
+					log.Printf("failed unmounting %s partition: %s", label, err)
+				}
+			}()
+		}
 
 		disk := s.Controller.Runtime().State().Machine().Disk(disk.WithPartitionLabel(constants.BootPartitionLabel))
 		if disk == nil {
 			return fmt.Errorf("boot disk not found")
 		}
 
-		grub := &grub.Grub{
-			BootDisk: disk.Device().Name(),
-		}
+		bootloader := bootloader.Probe(disk.Device().Name())
 
-		_, next, err := grub.Labels()
+		_, next, err := bootloader.Labels()
 		if err != nil {
 			return err
 		}
@@ -273,7 +564,7 @@ func (s *Server) Rollback(ctx context.Context, in *machine.RollbackRequest) (*ma
 			return fmt.Errorf("cannot rollback to %q, label does not exist", next)
 		}
 
-		if err := grub.Default(next); err != nil {
+		if err := bootloader.Default(next); err != nil {
 			return fmt.Errorf("failed to revert bootloader: %v", err)
 		}
 
@@ -335,7 +626,7 @@ func (s *Server) Bootstrap(ctx context.Context, in *machine.BootstrapRequest) (r
 // Shutdown implements the machine.MachineServer interface.
 //
 //nolint:dupl
-func (s *Server) Shutdown(ctx context.Context, in *empty.Empty) (reply *machine.ShutdownResponse, err error) {
+func (s *Server) Shutdown(ctx context.Context, in *machine.ShutdownRequest) (reply *machine.ShutdownResponse, err error) {
 	log.Printf("shutdown via API received")
 
 	if err = s.checkSupported(runtime.Shutdown); err != nil {
@@ -379,7 +670,10 @@ func (s *Server) Upgrade(ctx context.Context, in *machine.UpgradeRequest) (reply
 
 	log.Printf("validating %q", in.GetImage())
 
-	if err = pullAndValidateInstallerImage(ctx, s.Controller.Runtime().Config().Machine().Registries(), in.GetImage()); err != nil {
+	if err = pullAndValidateInstallerImage(ctx, s.Controller.Runtime().Config().Machine().Registries(), in.GetImage(),
+		image.WithEnv(s.Controller.Runtime().Config().Machine().Env()),
+		image.WithImageCache(s.Controller.Runtime().Config().Machine().ImageCache().Enabled()),
+	); err != nil {
 		return nil, fmt.Errorf("error validating installer image %q: %w", in.GetImage(), err)
 	}
 
@@ -408,6 +702,8 @@ func (s *Server) Upgrade(ctx context.Context, in *machine.UpgradeRequest) (reply
 		}
 	}
 
+	actorCtx, actorID := newActorContext()
+
 	if in.GetStage() {
 		meta, err := bootloader.NewMeta()
 		if err != nil {
@@ -443,7 +739,7 @@ func (s *Server) Upgrade(ctx context.Context, in *machine.UpgradeRequest) (reply
 				defer mu.Unlock(ctx) //nolint:errcheck
 			}
 
-			if err := s.Controller.Run(context.Background(), runtime.SequenceStageUpgrade, in); err != nil {
+			if err := s.Controller.Run(actorCtx, runtime.SequenceStageUpgrade, in); err != nil {
 				if !runtime.IsRebootError(err) {
 					log.Println("reboot for staged upgrade failed:", err)
 				}
@@ -461,7 +757,7 @@ func (s *Server) Upgrade(ctx context.Context, in *machine.UpgradeRequest) (reply
 				defer mu.Unlock(ctx) //nolint:errcheck
 			}
 
-			if err := s.Controller.Run(context.Background(), runtime.SequenceUpgrade, in); err != nil {
+			if err := s.Controller.Run(actorCtx, runtime.SequenceUpgrade, in); err != nil {
 				if !runtime.IsRebootError(err) {
 					log.Println("upgrade failed:", err)
 				}
@@ -478,7 +774,8 @@ func (s *Server) Upgrade(ctx context.Context, in *machine.UpgradeRequest) (reply
 	reply = &machine.UpgradeResponse{
 		Messages: []*machine.Upgrade{
 			{
-				Ack: "Upgrade request received",
+				Ack:     "Upgrade request received",
+				ActorId: actorID,
 			},
 		},
 	}
@@ -486,6 +783,15 @@ func (s *Server) Upgrade(ctx context.Context, in *machine.UpgradeRequest) (reply
 	return reply, nil
 }
 
+// newActorContext returns a context for a long-running sequence tagged with a new actor ID.
+//
+// Events published by the sequence carry the actor ID, so that the client can follow the progress.
+func newActorContext() (context.Context, string) {
+	actorID := xid.New().String()
+
+	return context.WithValue(context.Background(), runtime.ActorIDCtxKey{}, actorID), actorID
+}
+
 // ResetOptions implements runtime.ResetOptions interface.
 type ResetOptions struct {
 	*machine.ResetRequest
@@ -559,8 +865,10 @@ func (s *Server) Reset(ctx context.Context, in *machine.ResetRequest) (reply *ma
 		}
 	}
 
+	actorCtx, actorID := newActorContext()
+
 	go func() {
-		if err := s.Controller.Run(context.Background(), runtime.SequenceReset, &opts); err != nil {
+		if err := s.Controller.Run(actorCtx, runtime.SequenceReset, &opts); err != nil {
 			if !runtime.IsRebootError(err) {
 				log.Println("reset failed:", err)
 			}
@@ -575,7 +883,9 @@ func (s *Server) Reset(ctx context.Context, in *machine.ResetRequest) (reply *ma
 
 	reply = &machine.ResetResponse{
 		Messages: []*machine.Reset{
-			{},
+			{
+				ActorId: actorID,
+			},
 		},
 	}
 
@@ -710,6 +1020,116 @@ func (s *Server) Copy(req *machine.CopyRequest, obj machine.MachineService_CopyS
 	return nil
 }
 
+// copyInAllowedPaths is the list of directories which are allowed as CopyIn destinations.
+var copyInAllowedPaths = []string{
+	"/var",
+}
+
+func copyInAllowed(path string) bool {
+	for _, allowedPath := range copyInAllowedPaths {
+		if path == allowedPath || strings.HasPrefix(path, allowedPath+OSPathSeparator) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CopyIn implements the machine.MachineServer interface and extracts .tar.gz archive uploaded
+// by the client into the destination directory on the node.
+//
+//nolint:gocyclo
+func (s *Server) CopyIn(srv machine.MachineService_CopyInServer) error {
+	req, err := srv.Recv()
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Clean(req.RootPath)
+
+	if !filepath.IsAbs(path) {
+		return fmt.Errorf("path is not absolute %v", path)
+	}
+
+	if !copyInAllowed(path) {
+		return fmt.Errorf("destination path %q is not allowed, allowed paths: %s", path, strings.Join(copyInAllowedPaths, ", "))
+	}
+
+	// check the existing part of the destination path after resolving symlinks before creating missing directories
+	existingPath := path
+
+	for {
+		if _, err = os.Lstat(existingPath); err == nil || existingPath == OSPathSeparator {
+			break
+		}
+
+		existingPath = filepath.Dir(existingPath)
+	}
+
+	if existingPath, err = filepath.EvalSymlinks(existingPath); err != nil {
+		return fmt.Errorf("error resolving destination path %q: %w", path, err)
+	}
+
+	if !copyInAllowed(existingPath) {
+		return fmt.Errorf("destination path %q resolves to %q which is not allowed", path, existingPath)
+	}
+
+	if err = os.MkdirAll(path, 0o755); err != nil {
+		return fmt.Errorf("error creating destination directory %q: %w", path, err)
+	}
+
+	resolvedPath, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return fmt.Errorf("error resolving destination path %q: %w", path, err)
+	}
+
+	if !copyInAllowed(resolvedPath) {
+		return fmt.Errorf("destination path %q resolves to %q which is not allowed", path, resolvedPath)
+	}
+
+	path = resolvedPath
+
+	pr, pw := io.Pipe()
+
+	go func() {
+		data := req.Data
+
+		for {
+			if len(data) > 0 {
+				if _, e := pw.Write(data); e != nil {
+					return
+				}
+			}
+
+			msg, e := srv.Recv()
+			if e != nil {
+				if e == io.EOF {
+					e = nil
+				}
+
+				pw.CloseWithError(e) //nolint:errcheck
+
+				return
+			}
+
+			data = msg.Data
+		}
+	}()
+
+	//nolint:errcheck
+	defer pr.Close()
+
+	if err = archiver.UntarGz(srv.Context(), pr, path); err != nil {
+		return fmt.Errorf("error extracting archive to %q: %w", path, err)
+	}
+
+	return srv.SendAndClose(&machine.CopyInResponse{
+		Messages: []*machine.CopyIn{
+			{},
+		},
+	})
+}
+
 // List implements the machine.MachineServer interface.
 //
 //nolint:gocyclo
@@ -759,6 +1179,10 @@ func (s *Server) List(req *machine.ListRequest, obj machine.MachineService_ListS
 		opts = append(opts, archiver.WithFileTypes(types...))
 	}
 
+	if len(req.Patterns) > 0 {
+		opts = append(opts, archiver.WithFnmatchPatterns(req.Patterns...))
+	}
+
 	files, err := archiver.Walker(obj.Context(), req.Root, opts...)
 	if err != nil {
 		return err
@@ -772,7 +1196,7 @@ func (s *Server) List(req *machine.ListRequest, obj machine.MachineService_ListS
 				Error:        fi.Error.Error(),
 			})
 		} else {
-			err = obj.Send(&machine.FileInfo{
+			info := &machine.FileInfo{
 				Name:         fi.FullPath,
 				RelativeName: fi.RelPath,
 				Size:         fi.FileInfo.Size(),
@@ -780,7 +1204,14 @@ func (s *Server) List(req *machine.ListRequest, obj machine.MachineService_ListS
 				Modified:     fi.FileInfo.ModTime().Unix(),
 				IsDir:        fi.FileInfo.IsDir(),
 				Link:         fi.Link,
-			})
+			}
+
+			if st, ok := fi.FileInfo.Sys().(*syscall.Stat_t); ok {
+				info.Uid = st.Uid
+				info.Gid = st.Gid
+			}
+
+			err = obj.Send(info)
 		}
 
 		if err != nil {
@@ -1067,10 +1498,28 @@ func (s *Server) Version(ctx context.Context, in *empty.Empty) (reply *machine.V
 }
 
 // Kubeconfig implements the machine.MachineServer interface.
-func (s *Server) Kubeconfig(empty *empty.Empty, obj machine.MachineService_KubeconfigServer) error {
+func (s *Server) Kubeconfig(req *machine.KubeconfigRequest, obj machine.MachineService_KubeconfigServer) error {
+	clusterConfig := s.Controller.Runtime().Config().Cluster()
+
+	certLifetime := clusterConfig.AdminKubeconfig().CertLifetime()
+
+	if req.GetCertTtl() != nil {
+		if err := req.GetCertTtl().CheckValid(); err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid certificate lifetime: %s", err)
+		}
+
+		ttl := req.GetCertTtl().AsDuration()
+
+		if ttl <= 0 || ttl > certLifetime {
+			return status.Errorf(codes.InvalidArgument, "certificate lifetime should be positive and not exceed %s", certLifetime)
+		}
+
+		certLifetime = ttl
+	}
+
 	var b bytes.Buffer
 
-	if err := kubeconfig.GenerateAdmin(s.Controller.Runtime().Config().Cluster(), &b); err != nil {
+	if err := kubeconfig.GenerateAdminWithCertLifetime(clusterConfig, certLifetime, &b); err != nil {
 		return err
 	}
 
@@ -1194,38 +1643,93 @@ func getContainerInspector(ctx context.Context, namespace string, driver common.
 }
 
 // Read implements the read API.
+//
+// If the path is a shell pattern, it should match exactly one regular file.
 func (s *Server) Read(in *machine.ReadRequest, srv machine.MachineService_ReadServer) (err error) {
-	stat, err := os.Stat(in.Path)
+	ctx, cancel := context.WithCancel(srv.Context())
+	defer cancel()
+
+	path, err := resolveReadPath(ctx, in.Path)
 	if err != nil {
 		return err
 	}
 
-	switch mode := stat.Mode(); {
-	case mode.IsRegular():
-		f, err := os.OpenFile(in.Path, os.O_RDONLY, 0)
+	return readFile(ctx, path, srv)
+}
+
+// resolveReadPath returns the path of the regular file to read.
+//
+// The stream has no file boundaries, so the pattern matching more than one file is rejected.
+func resolveReadPath(ctx context.Context, path string) (string, error) {
+	root, pattern := archiver.SplitPattern(path)
+	if pattern == "" {
+		stat, err := os.Stat(path)
 		if err != nil {
-			return err
+			return "", err
 		}
 
-		defer f.Close() //nolint:errcheck
+		if !stat.Mode().IsRegular() {
+			return "", fmt.Errorf("path must be a regular file")
+		}
 
-		ctx, cancel := context.WithCancel(srv.Context())
-		defer cancel()
+		return path, nil
+	}
 
-		chunker := stream.NewChunker(ctx, f)
-		chunkCh := chunker.Read()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
 
-		for data := range chunkCh {
-			err := srv.SendMsg(&common.Data{Bytes: data})
-			if err != nil {
-				cancel()
-			}
+	files, err := archiver.Walker(ctx, root,
+		archiver.WithMaxRecurseDepth(archiver.PatternDepth(pattern)),
+		archiver.WithFileTypes(archiver.RegularFileType),
+		archiver.WithFnmatchPatterns(pattern),
+	)
+	if err != nil {
+		return "", err
+	}
+
+	var matched string
+
+	for fi := range files {
+		if fi.Error != nil {
+			return "", fi.Error
 		}
 
-		return nil
-	default:
-		return fmt.Errorf("path must be a regular file")
+		if matched != "" {
+			return "", fmt.Errorf("more than one regular file matches %q (%q, %q, ...), use 'ls' to list them", path, matched, fi.FullPath)
+		}
+
+		matched = fi.FullPath
+	}
+
+	if matched == "" {
+		return "", fmt.Errorf("no regular files match %q", path)
+	}
+
+	return matched, nil
+}
+
+func readFile(ctx context.Context, path string, srv machine.MachineService_ReadServer) error {
+	f, err := os.OpenFile(path, os.O_RDONLY, 0)
+	if err != nil {
+		return err
+	}
+
+	defer f.Close() //nolint:errcheck
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	chunker := stream.NewChunker(ctx, f)
+	chunkCh := chunker.Read()
+
+	for data := range chunkCh {
+		err := srv.SendMsg(&common.Data{Bytes: data})
+		if err != nil {
+			cancel()
+		}
 	}
+
+	return ctx.Err()
 }
 
 // Events streams runtime events.
@@ -1253,6 +1757,10 @@ func (s *Server) Events(req *machine.EventsRequest, l machine.MachineService_Eve
 		opts = append(opts, runtime.WithTailDuration(time.Duration(req.TailSeconds)*time.Second))
 	}
 
+	if req.WithActorId != "" {
+		opts = append(opts, runtime.WithActorID(req.WithActorId))
+	}
+
 	if err := s.Controller.Runtime().Events().Watch(func(events <-chan runtime.Event) {
 		errCh <- func() error {
 			for {
@@ -1282,7 +1790,7 @@ func (s *Server) Events(req *machine.EventsRequest, l machine.MachineService_Eve
 	return <-errCh
 }
 
-func pullAndValidateInstallerImage(ctx context.Context, reg config.Registries, ref string) error {
+func pullAndValidateInstallerImage(ctx context.Context, reg config.Registries, ref string, opts ...image.PullOption) error {
 	// Pull down specified installer image early so we can bail if it doesn't exist in the upstream registry
 	containerdctx := namespaces.WithNamespace(ctx, constants.SystemContainerdNamespace)
 
@@ -1291,7 +1799,7 @@ func pullAndValidateInstallerImage(ctx context.Context, reg config.Registries, r
 		return err
 	}
 
-	img, err := image.Pull(containerdctx, reg, client, ref)
+	img, err := image.Pull(containerdctx, reg, client, ref, opts...)
 	if err != nil {
 		return err
 	}
@@ -1486,6 +1994,11 @@ func (s *Server) Restart(ctx context.Context, in *machine.RestartRequest) (*mach
 func (s *Server) Dmesg(req *machine.DmesgRequest, srv machine.MachineService_DmesgServer) error {
 	ctx := srv.Context()
 
+	filter, err := dmesgFilter(req)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
 	var options []kmsg.Option
 
 	if req.Follow {
@@ -1496,6 +2009,15 @@ func (s *Server) Dmesg(req *machine.DmesgRequest, srv machine.MachineService_Dme
 		options = append(options, kmsg.FromTail())
 	}
 
+	var minSequence int64
+
+	if req.TailLines > 0 && !req.Tail {
+		minSequence, err = dmesgTailSequence(ctx, int(req.TailLines), filter)
+		if err != nil {
+			return err
+		}
+	}
+
 	reader, err := kmsg.NewReader(options...)
 	if err != nil {
 		return fmt.Errorf("error opening /dev/kmsg reader: %w", err)
@@ -1523,6 +2045,11 @@ func (s *Server) Dmesg(req *machine.DmesgRequest, srv machine.MachineService_Dme
 				})
 			} else {
 				msg := packet.Message
+
+				if msg.SequenceNumber < minSequence || !filter(msg) {
+					continue
+				}
+
 				err = srv.Send(&common.Data{
 					Bytes: []byte(fmt.Sprintf("%s: %7s: [%s]: %s", msg.Facility, msg.Priority, msg.Timestamp.Format(time.RFC3339Nano), msg.Message)),
 				})
@@ -1535,58 +2062,97 @@ func (s *Server) Dmesg(req *machine.DmesgRequest, srv machine.MachineService_Dme
 	}
 }
 
-// Processes implements the machine.MachineServer interface.
-func (s *Server) Processes(ctx context.Context, in *empty.Empty) (reply *machine.ProcessesResponse, err error) {
-	procs, err := procfs.AllProcs()
-	if err != nil {
-		return nil, err
-	}
-
-	processes := make([]*machine.ProcessInfo, 0, len(procs))
+// dmesgFilter builds kernel log message filter from the request.
+func dmesgFilter(req *machine.DmesgRequest) (func(kmsg.Message) bool, error) {
+	maxPriority := kmsg.Debug
 
-	var (
-		command    string
-		executable string
-		args       []string
-		stats      procfs.ProcStat
-	)
+	if req.Priority != "" {
+		var err error
 
-	for _, proc := range procs {
-		// due to race condition, reading process info might fail if process has already terminated
-		command, err = proc.Comm()
+		maxPriority, err = kmsg.ParsePriority(req.Priority)
 		if err != nil {
-			continue
+			return nil, err
 		}
+	}
+
+	facilities := map[kmsg.Facility]struct{}{}
 
-		executable, err = proc.Executable()
+	for _, name := range req.Facilities {
+		facility, err := kmsg.ParseFacility(name)
 		if err != nil {
-			continue
+			return nil, err
 		}
 
-		args, err = proc.CmdLine()
-		if err != nil {
+		facilities[facility] = struct{}{}
+	}
+
+	var since time.Time
+
+	if req.SinceSeconds > 0 {
+		since = time.Now().Add(-time.Duration(req.SinceSeconds) * time.Second)
+	}
+
+	return func(msg kmsg.Message) bool {
+		if msg.Priority > maxPriority {
+			return false
+		}
+
+		if len(facilities) > 0 {
+			if _, ok := facilities[msg.Facility]; !ok {
+				return false
+			}
+		}
+
+		return since.IsZero() || !msg.Timestamp.Before(since)
+	}, nil
+}
+
+// dmesgTailSequence returns the sequence number of the first message among the last lines messages matching the filter.
+//
+// Kernel log is read once to find the sequence number, so that the messages are not lost
+// if the kernel log is followed afterwards.
+func dmesgTailSequence(ctx context.Context, lines int, filter func(kmsg.Message) bool) (int64, error) {
+	reader, err := kmsg.NewReader()
+	if err != nil {
+		return 0, fmt.Errorf("error opening /dev/kmsg reader: %w", err)
+	}
+	defer reader.Close() //nolint:errcheck
+
+	sequences := make([]int64, 0, lines)
+
+	var next int64
+
+	for packet := range reader.Scan(ctx) {
+		if packet.Err != nil {
+			// errors are reported when the kernel log is read again
 			continue
 		}
 
-		stats, err = proc.Stat()
-		if err != nil {
+		next = packet.Message.SequenceNumber + 1
+
+		if !filter(packet.Message) {
 			continue
 		}
 
-		p := &machine.ProcessInfo{
-			Pid:            int32(proc.PID),
-			Ppid:           int32(stats.PPID),
-			State:          stats.State,
-			Threads:        int32(stats.NumThreads),
-			CpuTime:        stats.CPUTime(),
-			VirtualMemory:  uint64(stats.VirtualMemory()),
-			ResidentMemory: uint64(stats.ResidentMemory()),
-			Command:        command,
-			Executable:     executable,
-			Args:           strings.Join(args, " "),
+		if len(sequences) == lines {
+			sequences = sequences[1:]
 		}
 
-		processes = append(processes, p)
+		sequences = append(sequences, packet.Message.SequenceNumber)
+	}
+
+	if len(sequences) == 0 {
+		return next, nil
+	}
+
+	return sequences[0], nil
+}
+
+// Processes implements the machine.MachineServer interface.
+func (s *Server) Processes(ctx context.Context, in *empty.Empty) (reply *machine.ProcessesResponse, err error) {
+	processes, err := processList(procfs.DefaultMountPoint)
+	if err != nil {
+		return nil, err
 	}
 
 	reply = &machine.ProcessesResponse{
@@ -1600,6 +2166,167 @@ func (s *Server) Processes(ctx context.Context, in *empty.Empty) (reply *machine
 	return reply, nil
 }
 
+// ProcessDetails implements the machine.MachineServer interface.
+func (s *Server) ProcessDetails(ctx context.Context, in *machine.ProcessDetailsRequest) (reply *machine.ProcessDetailsResponse, err error) {
+	details, err := processDetails(procfs.DefaultMountPoint, int(in.Pid))
+	if err != nil {
+		return nil, err
+	}
+
+	return &machine.ProcessDetailsResponse{
+		Messages: []*machine.ProcessDetails{
+			details,
+		},
+	}, nil
+}
+
+// processList reads the process list from procfs mounted at procPath.
+func processList(procPath string) ([]*machine.ProcessInfo, error) {
+	fs, err := procfs.NewFS(procPath)
+	if err != nil {
+		return nil, err
+	}
+
+	procs, err := fs.AllProcs()
+	if err != nil {
+		return nil, err
+	}
+
+	processes := make([]*machine.ProcessInfo, 0, len(procs))
+
+	for _, proc := range procs {
+		p, err := processInfo(proc)
+		if err != nil {
+			// due to race condition, reading process info might fail if process has already terminated
+			continue
+		}
+
+		processes = append(processes, p)
+	}
+
+	return processes, nil
+}
+
+// processDetails reads the details of a single process from procfs mounted at procPath.
+func processDetails(procPath string, pid int) (*machine.ProcessDetails, error) {
+	fs, err := procfs.NewFS(procPath)
+	if err != nil {
+		return nil, err
+	}
+
+	proc, err := fs.Proc(pid)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "process %d not found: %s", pid, err)
+	}
+
+	info, err := processInfo(proc)
+	if err != nil {
+		return nil, err
+	}
+
+	details := &machine.ProcessDetails{
+		Process: info,
+	}
+
+	st, err := os.Stat(filepath.Join(procPath, strconv.Itoa(proc.PID)))
+	if err != nil {
+		return nil, err
+	}
+
+	if stat, ok := st.Sys().(*syscall.Stat_t); ok {
+		details.Uid = stat.Uid
+		details.Gid = stat.Gid
+	}
+
+	if details.Cwd, err = proc.Cwd(); err != nil {
+		return nil, err
+	}
+
+	if details.OpenFiles, err = proc.FileDescriptorTargets(); err != nil {
+		return nil, err
+	}
+
+	procs, err := fs.AllProcs()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, child := range procs {
+		stats, err := child.Stat()
+		if err != nil {
+			continue
+		}
+
+		if stats.PPID == proc.PID {
+			details.Children = append(details.Children, int32(child.PID))
+		}
+	}
+
+	return details, nil
+}
+
+func processInfo(proc procfs.Proc) (*machine.ProcessInfo, error) {
+	command, err := proc.Comm()
+	if err != nil {
+		return nil, err
+	}
+
+	executable, err := proc.Executable()
+	if err != nil {
+		return nil, err
+	}
+
+	args, err := proc.CmdLine()
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := proc.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	openFiles, err := proc.FileDescriptorsLen()
+	if err != nil {
+		return nil, err
+	}
+
+	cgroups, err := proc.Cgroups()
+	if err != nil {
+		return nil, err
+	}
+
+	return &machine.ProcessInfo{
+		Pid:            int32(proc.PID),
+		Ppid:           int32(stats.PPID),
+		State:          stats.State,
+		Threads:        int32(stats.NumThreads),
+		CpuTime:        stats.CPUTime(),
+		VirtualMemory:  uint64(stats.VirtualMemory()),
+		ResidentMemory: uint64(stats.ResidentMemory()),
+		Command:        command,
+		Executable:     executable,
+		Args:           strings.Join(args, " "),
+		Cgroup:         cgroupPath(cgroups),
+		OpenFiles:      int32(openFiles),
+	}, nil
+}
+
+// cgroupPath picks the path in the unified (cgroup v2) hierarchy, falling back to the first hierarchy.
+func cgroupPath(cgroups []procfs.Cgroup) string {
+	for _, cgroup := range cgroups {
+		if cgroup.HierarchyID == 0 {
+			return cgroup.Path
+		}
+	}
+
+	if len(cgroups) > 0 {
+		return cgroups[0].Path
+	}
+
+	return ""
+}
+
 // Memory implements the machine.MachineServer interface.
 func (s *Server) Memory(ctx context.Context, in *empty.Empty) (reply *machine.MemoryResponse, err error) {
 	proc, err := procfs.NewDefaultFS()
@@ -1823,6 +2550,8 @@ func (s *Server) EtcdSnapshot(in *machine.EtcdSnapshotRequest, srv machine.Machi
 		}
 	}
 
+	recordEtcdBackup()
+
 	return nil
 }
 
@@ -1905,6 +2634,44 @@ func (s *Server) RemoveBootkubeInitializedKey(ctx context.Context, in *empty.Emp
 	}, nil
 }
 
+// RevokeCertificate implements the machine.MachineServer interface.
+//
+// Certificate is added to the revocation list of the trustd running on the node.
+func (s *Server) RevokeCertificate(ctx context.Context, in *machine.RevokeCertificateRequest) (*machine.RevokeCertificateResponse, error) {
+	if s.Controller.Runtime().Config().Machine().Type() == machinetype.TypeJoin {
+		return nil, status.Error(codes.FailedPrecondition, "certificates can be revoked only on the control plane nodes")
+	}
+
+	serial, err := revocation.ParseSerialNumber(in.GetSerialNumber())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// trustd accepts the revocations only over the local socket
+	conn, err := grpc.DialContext(ctx, "unix://"+constants.TrustdSocketPath,
+		grpc.WithInsecure(),
+		grpc.WithContextDialer(dialer.DialUnix()),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to trustd: %w", err)
+	}
+
+	//nolint:errcheck
+	defer conn.Close()
+
+	if _, err = securityapi.NewSecurityServiceClient(conn).RevokeCertificate(ctx, &securityapi.RevokeCertificateRequest{
+		SerialNumber: serial.String(),
+	}); err != nil {
+		return nil, fmt.Errorf("error revoking certificate: %w", err)
+	}
+
+	return &machine.RevokeCertificateResponse{
+		Messages: []*machine.RevokeCertificate{
+			{},
+		},
+	}, nil
+}
+
 func upgradeMutex(c *etcd.Client) (*concurrency.Mutex, error) {
 	sess, err := concurrency.NewSession(c.Client,
 		concurrency.WithTTL(MinimumEtcdUpgradeLeaseLockSeconds),
@@ -0,0 +1,79 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveReadPath(t *testing.T) {
+	dir := t.TempDir()
+
+	for _, path := range []string{"pods/a/0.log", "pods/b/0.log", "pods/b/1.log", "kubelet.log"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, filepath.Dir(path)), 0o755))
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, path), []byte(path), 0o644))
+	}
+
+	for _, tt := range []struct {
+		name          string
+		path          string
+		expected      string
+		expectedError string
+	}{
+		{
+			name:     "file",
+			path:     "kubelet.log",
+			expected: "kubelet.log",
+		},
+		{
+			name:          "directory",
+			path:          "pods",
+			expectedError: "path must be a regular file",
+		},
+		{
+			name:     "pattern matching one file",
+			path:     "pods/a/*.log",
+			expected: "pods/a/0.log",
+		},
+		{
+			name:     "pattern matching one file in subdirectories",
+			path:     "**/1.log",
+			expected: "pods/b/1.log",
+		},
+		{
+			name:          "pattern matching several files",
+			path:          "pods/*/0.log",
+			expectedError: "more than one regular file matches",
+		},
+		{
+			name:          "pattern matching directories only",
+			path:          "pods/*",
+			expectedError: "no regular files match",
+		},
+	} {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			path, err := resolveReadPath(context.Background(), filepath.Join(dir, tt.path))
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, filepath.Join(dir, tt.expected), path)
+		})
+	}
+}
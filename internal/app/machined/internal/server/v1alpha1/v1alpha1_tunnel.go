@@ -0,0 +1,113 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"errors"
+	"io"
+	"net"
+	"strconv"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/talos-systems/talos/pkg/machinery/api/common"
+	"github.com/talos-systems/talos/pkg/machinery/api/machine"
+	machinetype "github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1/machine"
+)
+
+// tunnelBufferSize is the size of the chunks read from the tunneled connection.
+const tunnelBufferSize = 32 * 1024
+
+// KubernetesTunnelStream implements the machine.MachineServer interface.
+//
+// The stream is tunneled to the local Kubernetes API server endpoint,
+// so that Kubernetes API is accessible wherever Talos API is.
+func (s *Server) KubernetesTunnelStream(srv machine.MachineService_KubernetesTunnelStreamServer) error {
+	if s.Controller.Runtime().Config().Machine().Type() == machinetype.TypeJoin {
+		return status.Error(codes.FailedPrecondition, "Kubernetes API tunnel is only available on control plane nodes")
+	}
+
+	endpoint := net.JoinHostPort("localhost", strconv.Itoa(s.Controller.Runtime().Config().Cluster().LocalAPIServerPort()))
+
+	var d net.Dialer
+
+	conn, err := d.DialContext(srv.Context(), "tcp", endpoint)
+	if err != nil {
+		return status.Errorf(codes.Unavailable, "error connecting to Kubernetes API server %q: %s", endpoint, err)
+	}
+
+	//nolint:errcheck
+	defer conn.Close()
+
+	errCh := make(chan error, 1)
+
+	go func() {
+		errCh <- tunnelFromStream(srv, conn)
+	}()
+
+	readErr := tunnelToStream(srv, conn)
+
+	// error from the client side takes precedence, as it is the reason the connection was closed
+	select {
+	case err = <-errCh:
+		if err != nil {
+			return err
+		}
+	default:
+	}
+
+	return readErr
+}
+
+// tunnelFromStream writes the data received from the client to the connection.
+func tunnelFromStream(srv machine.MachineService_KubernetesTunnelStreamServer, conn net.Conn) error {
+	for {
+		data, err := srv.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				// client is done sending, but the response might be still in flight
+				if tcpConn, ok := conn.(*net.TCPConn); ok {
+					return tcpConn.CloseWrite()
+				}
+
+				return nil
+			}
+
+			conn.Close() //nolint:errcheck
+
+			return err
+		}
+
+		if _, err = conn.Write(data.Bytes); err != nil {
+			conn.Close() //nolint:errcheck
+
+			return err
+		}
+	}
+}
+
+// tunnelToStream sends the data read from the connection to the client until the connection is closed.
+func tunnelToStream(srv machine.MachineService_KubernetesTunnelStreamServer, conn net.Conn) error {
+	buf := make([]byte, tunnelBufferSize)
+
+	for {
+		n, err := conn.Read(buf)
+
+		if n > 0 {
+			if sendErr := srv.Send(&common.Data{Bytes: append([]byte(nil), buf[:n]...)}); sendErr != nil {
+				return sendErr
+			}
+		}
+
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+
+			return err
+		}
+	}
+}
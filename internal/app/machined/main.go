@@ -148,7 +148,7 @@ func handle(err error) {
 		}
 	}
 
-	if rebootCmd == unix.LINUX_REBOOT_CMD_RESTART {
+	if rebootCmd == unix.LINUX_REBOOT_CMD_RESTART || rebootCmd == unix.LINUX_REBOOT_CMD_KEXEC {
 		for i := 10; i >= 0; i-- {
 			log.Printf("rebooting in %d seconds\n", i)
 			time.Sleep(1 * time.Second)
@@ -171,9 +171,19 @@ func handle(err error) {
 		signal.Notify(exitSignal, syscall.SIGINT, syscall.SIGTERM)
 
 		<-exitSignal
-	} else if unix.Reboot(rebootCmd) == nil {
-		// Wait forever.
-		select {}
+	} else {
+		if rebootCmd == unix.LINUX_REBOOT_CMD_KEXEC {
+			if err = unix.Reboot(rebootCmd); err != nil {
+				log.Printf("kexec failed, falling back to normal reboot: %s", err)
+
+				rebootCmd = unix.LINUX_REBOOT_CMD_RESTART
+			}
+		}
+
+		if unix.Reboot(rebootCmd) == nil {
+			// Wait forever.
+			select {}
+		}
 	}
 }
 
@@ -0,0 +1,146 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/talos-systems/os-runtime/pkg/controller"
+	"github.com/talos-systems/os-runtime/pkg/resource"
+	"github.com/talos-systems/os-runtime/pkg/state"
+
+	"github.com/talos-systems/talos/internal/pkg/identity"
+	"github.com/talos-systems/talos/internal/pkg/kms"
+	talosconfig "github.com/talos-systems/talos/pkg/machinery/config"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+	"github.com/talos-systems/talos/pkg/resources/cluster"
+	"github.com/talos-systems/talos/pkg/resources/config"
+)
+
+// identitySealRetryInterval is the interval between the attempts to reach the KMS plugin.
+const identitySealRetryInterval = 30 * time.Second
+
+// IdentitySealController seals the machine identity private key with the KMS plugin.
+//
+// The identity is loaded on boot before the KMS plugin is running, so the plaintext key
+// is sealed as soon as the plugin becomes reachable.
+type IdentitySealController struct{}
+
+// Name implements controller.Controller interface.
+func (ctrl *IdentitySealController) Name() string {
+	return "cluster.IdentitySealController"
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *IdentitySealController) Inputs() []controller.Input {
+	return []controller.Input{
+		{
+			Namespace: config.NamespaceName,
+			Type:      config.MachineConfigType,
+			ID:        pointer.ToString(config.V1Alpha1ID),
+			Kind:      controller.InputWeak,
+		},
+		{
+			Namespace: cluster.NamespaceName,
+			Type:      cluster.IdentityType,
+			ID:        pointer.ToString(cluster.LocalIdentity),
+			Kind:      controller.InputWeak,
+		},
+	}
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *IdentitySealController) Outputs() []controller.Output {
+	return nil
+}
+
+// Run implements controller.Controller interface.
+func (ctrl *IdentitySealController) Run(ctx context.Context, r controller.Runtime, logger *log.Logger) error {
+	var retryCh <-chan time.Time
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+		case <-retryCh:
+		}
+
+		retryCh = nil
+
+		cfg, err := r.Get(ctx, resource.NewMetadata(config.NamespaceName, config.MachineConfigType, config.V1Alpha1ID, resource.VersionUndefined))
+		if err != nil && !state.IsNotFoundError(err) {
+			return fmt.Errorf("error getting config: %w", err)
+		}
+
+		var cfgProvider talosconfig.Provider
+
+		if cfg != nil {
+			cfgProvider = cfg.(*config.MachineConfig).Config()
+		}
+
+		if cfgProvider == nil || cfgProvider.Machine().Identity().KMS() == nil {
+			continue
+		}
+
+		if _, err = r.Get(ctx, resource.NewMetadata(cluster.NamespaceName, cluster.IdentityType, cluster.LocalIdentity, resource.VersionUndefined)); err != nil {
+			if !state.IsNotFoundError(err) {
+				return fmt.Errorf("error getting node identity: %w", err)
+			}
+
+			// identity is not loaded yet
+			continue
+		}
+
+		if err = ctrl.seal(ctx, logger, cfgProvider.Machine().Identity().KMS()); err != nil {
+			logger.Printf("failed to seal machine identity, retrying in %s: %s", identitySealRetryInterval, err)
+
+			retryCh = time.After(identitySealRetryInterval)
+		}
+	}
+}
+
+func (ctrl *IdentitySealController) seal(ctx context.Context, logger *log.Logger, kmsConfig talosconfig.IdentityKMS) error {
+	id, err := identity.Load(constants.MachineIdentityPath)
+	if err != nil {
+		return err
+	}
+
+	if id.Sealed != nil {
+		return nil
+	}
+
+	client, err := kms.NewClient(kmsConfig.Endpoint(), kmsConfig.Timeout())
+	if err != nil {
+		return err
+	}
+
+	defer client.Close() //nolint:errcheck
+
+	status, err := client.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	if status.Healthz != kms.HealthzOK {
+		return fmt.Errorf("KMS plugin is not healthy: %q", status.Healthz)
+	}
+
+	if err = id.Seal(ctx, client); err != nil {
+		return err
+	}
+
+	if err = id.Save(constants.MachineIdentityPath); err != nil {
+		return err
+	}
+
+	logger.Printf("sealed machine identity with the KMS key %q", id.Sealed.KeyID)
+
+	return nil
+}
@@ -0,0 +1,145 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/talos-systems/os-runtime/pkg/controller"
+	"github.com/talos-systems/os-runtime/pkg/resource"
+
+	"github.com/talos-systems/talos/pkg/resources/cluster"
+)
+
+// MemberController aggregates the affiliates into the cluster members.
+type MemberController struct{}
+
+// Name implements controller.Controller interface.
+func (ctrl *MemberController) Name() string {
+	return "cluster.MemberController"
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *MemberController) Inputs() []controller.Input {
+	return []controller.Input{
+		{
+			Namespace: cluster.NamespaceName,
+			Type:      cluster.AffiliateType,
+			Kind:      controller.InputWeak,
+		},
+	}
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *MemberController) Outputs() []controller.Output {
+	return []controller.Output{
+		{
+			Type: cluster.MemberType,
+			Kind: controller.OutputExclusive,
+		},
+	}
+}
+
+// Run implements controller.Controller interface.
+func (ctrl *MemberController) Run(ctx context.Context, r controller.Runtime, logger *log.Logger) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+		}
+
+		list, err := r.List(ctx, resource.NewMetadata(cluster.NamespaceName, cluster.AffiliateType, "", resource.VersionUndefined))
+		if err != nil {
+			return fmt.Errorf("error listing cluster affiliates: %w", err)
+		}
+
+		affiliates := make([]cluster.AffiliateSpec, 0, len(list.Items))
+
+		for _, res := range list.Items {
+			affiliates = append(affiliates, *res.(*cluster.Affiliate).AffiliateSpec())
+		}
+
+		members := buildMembers(affiliates)
+
+		for id, spec := range members {
+			spec := spec
+
+			if err = r.Modify(ctx, cluster.NewMember(id), func(r resource.Resource) error {
+				*r.(*cluster.Member).MemberSpec() = spec
+
+				return nil
+			}); err != nil {
+				return fmt.Errorf("error updating cluster member: %w", err)
+			}
+		}
+
+		list, err = r.List(ctx, resource.NewMetadata(cluster.NamespaceName, cluster.MemberType, "", resource.VersionUndefined))
+		if err != nil {
+			return fmt.Errorf("error listing cluster members: %w", err)
+		}
+
+		for _, res := range list.Items {
+			if _, ok := members[res.Metadata().ID()]; ok {
+				continue
+			}
+
+			if err = r.Destroy(ctx, res.Metadata()); err != nil {
+				return fmt.Errorf("error cleaning up cluster members: %w", err)
+			}
+		}
+	}
+}
+
+// buildMembers merges the affiliates of the same node into a single member.
+//
+// The first non-empty value of each field wins, and the addresses are merged without duplicates.
+func buildMembers(affiliates []cluster.AffiliateSpec) map[resource.ID]cluster.MemberSpec {
+	members := map[resource.ID]cluster.MemberSpec{}
+
+	for _, affiliate := range affiliates {
+		if affiliate.NodeID == "" {
+			continue
+		}
+
+		member := members[affiliate.NodeID]
+
+		for _, field := range []struct {
+			dst *string
+			src string
+		}{
+			{&member.Hostname, affiliate.Hostname},
+			{&member.MachineType, affiliate.MachineType},
+			{&member.OperatingSystem, affiliate.OperatingSystem},
+			{&member.KubernetesVersion, affiliate.KubernetesVersion},
+		} {
+			if *field.dst == "" {
+				*field.dst = field.src
+			}
+		}
+
+		for _, addr := range affiliate.Addresses {
+			found := false
+
+			for _, existing := range member.Addresses {
+				if existing == addr {
+					found = true
+
+					break
+				}
+			}
+
+			if !found {
+				member.Addresses = append(member.Addresses, addr)
+			}
+		}
+
+		members[affiliate.NodeID] = member
+	}
+
+	return members
+}
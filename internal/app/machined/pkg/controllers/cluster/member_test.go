@@ -0,0 +1,54 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package cluster
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/talos-systems/os-runtime/pkg/resource"
+
+	"github.com/talos-systems/talos/pkg/resources/cluster"
+)
+
+func TestBuildMembers(t *testing.T) {
+	members := buildMembers([]cluster.AffiliateSpec{
+		{
+			NodeID:          "node-1",
+			Hostname:        "talos-cp-1",
+			MachineType:     "init",
+			OperatingSystem: "Talos (v0.10.0)",
+			Addresses:       []string{"10.5.0.2"},
+		},
+		{
+			NodeID:            "node-1",
+			Hostname:          "other",
+			KubernetesVersion: "v1.21.1",
+			Addresses:         []string{"10.5.0.2", "2001:db8::2"},
+		},
+		{
+			NodeID:      "node-2",
+			Hostname:    "talos-worker-1",
+			MachineType: "join",
+		},
+		{
+			Hostname: "unknown",
+		},
+	})
+
+	assert.Equal(t, map[resource.ID]cluster.MemberSpec{
+		"node-1": {
+			Hostname:          "talos-cp-1",
+			MachineType:       "init",
+			OperatingSystem:   "Talos (v0.10.0)",
+			KubernetesVersion: "v1.21.1",
+			Addresses:         []string{"10.5.0.2", "2001:db8::2"},
+		},
+		"node-2": {
+			Hostname:    "talos-worker-1",
+			MachineType: "join",
+		},
+	}, members)
+}
@@ -0,0 +1,168 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package config
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/talos-systems/os-runtime/pkg/controller"
+	"github.com/talos-systems/os-runtime/pkg/resource"
+	"github.com/talos-systems/os-runtime/pkg/state"
+
+	talosconfig "github.com/talos-systems/talos/pkg/machinery/config"
+	"github.com/talos-systems/talos/pkg/machinery/config/configloader"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+	"github.com/talos-systems/talos/pkg/resources/config"
+)
+
+// driftPollInterval is the interval between checks of the stored config.
+//
+// Stored config might be modified outside of the controller runtime, so it is polled.
+const driftPollInterval = time.Minute
+
+// DriftController compares the active machine config with the config stored on disk.
+type DriftController struct {
+	// ConfigPath defaults to constants.ConfigPath.
+	ConfigPath string
+	// PollInterval defaults to driftPollInterval.
+	PollInterval time.Duration
+}
+
+// Name implements controller.Controller interface.
+func (ctrl *DriftController) Name() string {
+	return "config.DriftController"
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *DriftController) Inputs() []controller.Input {
+	return []controller.Input{
+		{
+			Namespace: config.NamespaceName,
+			Type:      config.MachineConfigType,
+			ID:        pointer.ToString(config.V1Alpha1ID),
+			Kind:      controller.InputWeak,
+		},
+	}
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *DriftController) Outputs() []controller.Output {
+	return []controller.Output{
+		{
+			Type: config.DriftStatusType,
+			Kind: controller.OutputExclusive,
+		},
+	}
+}
+
+// Run implements controller.Controller interface.
+func (ctrl *DriftController) Run(ctx context.Context, r controller.Runtime, logger *log.Logger) error {
+	if ctrl.ConfigPath == "" {
+		ctrl.ConfigPath = constants.ConfigPath
+	}
+
+	if ctrl.PollInterval == 0 {
+		ctrl.PollInterval = driftPollInterval
+	}
+
+	ticker := time.NewTicker(ctrl.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+		case <-ticker.C:
+		}
+
+		if err := ctrl.reconcile(ctx, r, logger); err != nil {
+			return err
+		}
+	}
+}
+
+func (ctrl *DriftController) reconcile(ctx context.Context, r controller.Runtime, logger *log.Logger) error {
+	cfg, err := r.Get(ctx, resource.NewMetadata(config.NamespaceName, config.MachineConfigType, config.V1Alpha1ID, resource.VersionUndefined))
+	if err != nil {
+		if !state.IsNotFoundError(err) {
+			return fmt.Errorf("error getting config: %w", err)
+		}
+	}
+
+	// config is not stored on disk, so there is nothing to compare with
+	if cfg == nil || !cfg.(*config.MachineConfig).Config().Persist() {
+		if err = r.Destroy(ctx, config.NewDriftStatus().Metadata()); err != nil && !state.IsNotFoundError(err) {
+			return fmt.Errorf("error cleaning up drift status: %w", err)
+		}
+
+		return nil
+	}
+
+	activeChecksum, err := talosconfig.Checksum(cfg.(*config.MachineConfig).Config())
+	if err != nil {
+		return fmt.Errorf("error marshaling active config: %w", err)
+	}
+
+	spec := config.DriftStatusSpec{
+		ActiveChecksum: activeChecksum,
+	}
+
+	storedChecksum, err := ctrl.storedChecksum()
+	if err != nil {
+		spec.Error = err.Error()
+	} else {
+		spec.StoredChecksum = storedChecksum
+		spec.InSync = spec.StoredChecksum == spec.ActiveChecksum
+	}
+
+	var previous resource.Resource
+
+	previous, err = r.Get(ctx, config.NewDriftStatus().Metadata())
+	if err != nil && !state.IsNotFoundError(err) {
+		return fmt.Errorf("error getting drift status: %w", err)
+	}
+
+	if !spec.InSync && (previous == nil || *previous.(*config.DriftStatus).TypedSpec() != spec) {
+		logger.Printf("stored config doesn't match the active config: active %s, stored %q, error %q", spec.ActiveChecksum, spec.StoredChecksum, spec.Error)
+	}
+
+	if err = r.Modify(ctx, config.NewDriftStatus(), func(r resource.Resource) error {
+		*r.(*config.DriftStatus).TypedSpec() = spec
+
+		return nil
+	}); err != nil {
+		return fmt.Errorf("error updating drift status: %w", err)
+	}
+
+	return nil
+}
+
+// storedChecksum returns the checksum of the normalized stored config.
+//
+// Stored config is parsed and marshaled back, so that formatting and comments don't affect the result.
+func (ctrl *DriftController) storedChecksum() (string, error) {
+	b, err := ioutil.ReadFile(ctrl.ConfigPath)
+	if err != nil {
+		return "", fmt.Errorf("error reading stored config: %w", err)
+	}
+
+	stored, err := configloader.NewFromBytes(b)
+	if err != nil {
+		return "", fmt.Errorf("error parsing stored config: %w", err)
+	}
+
+	checksum, err := talosconfig.Checksum(stored)
+	if err != nil {
+		return "", fmt.Errorf("error marshaling stored config: %w", err)
+	}
+
+	return checksum, nil
+}
@@ -0,0 +1,159 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package config_test
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/talos-systems/go-retry/retry"
+	"github.com/talos-systems/os-runtime/pkg/controller/runtime"
+	"github.com/talos-systems/os-runtime/pkg/state"
+	"github.com/talos-systems/os-runtime/pkg/state/impl/inmem"
+	"github.com/talos-systems/os-runtime/pkg/state/impl/namespaced"
+
+	configctrl "github.com/talos-systems/talos/internal/app/machined/pkg/controllers/config"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
+	"github.com/talos-systems/talos/pkg/resources/config"
+)
+
+type DriftSuite struct {
+	suite.Suite
+
+	state state.State
+
+	runtime *runtime.Runtime
+	wg      sync.WaitGroup
+
+	ctx       context.Context
+	ctxCancel context.CancelFunc
+
+	configPath string
+}
+
+func (suite *DriftSuite) SetupTest() {
+	suite.ctx, suite.ctxCancel = context.WithTimeout(context.Background(), 3*time.Minute)
+
+	suite.state = state.WrapCore(namespaced.NewState(inmem.Build))
+
+	var err error
+
+	logger := log.New(log.Writer(), "controller-runtime: ", log.Flags())
+
+	suite.runtime, err = runtime.NewRuntime(suite.state, logger)
+	suite.Require().NoError(err)
+
+	suite.configPath = filepath.Join(suite.T().TempDir(), "config.yaml")
+
+	suite.Require().NoError(suite.runtime.RegisterController(&configctrl.DriftController{
+		ConfigPath:   suite.configPath,
+		PollInterval: 100 * time.Millisecond,
+	}))
+
+	suite.wg.Add(1)
+
+	go func() {
+		defer suite.wg.Done()
+
+		suite.Assert().NoError(suite.runtime.Run(suite.ctx))
+	}()
+}
+
+func (suite *DriftSuite) assertDriftStatus(check func(*config.DriftStatusSpec) error) {
+	suite.Assert().NoError(retry.Constant(10*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		func() error {
+			r, err := suite.state.Get(suite.ctx, config.NewDriftStatus().Metadata())
+			if err != nil {
+				if state.IsNotFoundError(err) {
+					return retry.ExpectedError(err)
+				}
+
+				return retry.UnexpectedError(err)
+			}
+
+			return check(r.(*config.DriftStatus).TypedSpec())
+		},
+	))
+}
+
+func (suite *DriftSuite) TestDrift() {
+	cfg := &v1alpha1.Config{
+		ConfigVersion: "v1alpha1",
+		ConfigPersist: true,
+		MachineConfig: &v1alpha1.MachineConfig{
+			MachineType: "worker",
+		},
+	}
+
+	b, err := cfg.Bytes()
+	suite.Require().NoError(err)
+
+	// stored config is formatted differently, but it's the same config
+	suite.Require().NoError(ioutil.WriteFile(suite.configPath, append([]byte("# comment\n"), b...), 0o600))
+
+	suite.Require().NoError(suite.state.Create(suite.ctx, config.NewMachineConfig(cfg)))
+
+	suite.assertDriftStatus(func(spec *config.DriftStatusSpec) error {
+		if !spec.InSync {
+			return retry.ExpectedError(fmt.Errorf("config is not in sync: %+v", spec))
+		}
+
+		return nil
+	})
+
+	stored := &v1alpha1.Config{
+		ConfigVersion: "v1alpha1",
+		ConfigPersist: true,
+		MachineConfig: &v1alpha1.MachineConfig{
+			MachineType: "controlplane",
+		},
+	}
+
+	b, err = stored.Bytes()
+	suite.Require().NoError(err)
+
+	suite.Require().NoError(ioutil.WriteFile(suite.configPath, b, 0o600))
+
+	suite.assertDriftStatus(func(spec *config.DriftStatusSpec) error {
+		if spec.InSync {
+			return retry.ExpectedError(fmt.Errorf("config drift is not detected: %+v", spec))
+		}
+
+		if spec.StoredChecksum == "" || spec.Error != "" {
+			return retry.UnexpectedError(fmt.Errorf("unexpected drift status: %+v", spec))
+		}
+
+		return nil
+	})
+
+	suite.Require().NoError(ioutil.WriteFile(suite.configPath, []byte("garbage: ["), 0o600))
+
+	suite.assertDriftStatus(func(spec *config.DriftStatusSpec) error {
+		if spec.Error == "" {
+			return retry.ExpectedError(fmt.Errorf("stored config error is not reported: %+v", spec))
+		}
+
+		return nil
+	})
+}
+
+func (suite *DriftSuite) TearDownTest() {
+	suite.T().Log("tear down")
+
+	suite.ctxCancel()
+
+	suite.wg.Wait()
+}
+
+func TestDriftSuite(t *testing.T) {
+	suite.Run(t, new(DriftSuite))
+}
@@ -8,6 +8,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"path/filepath"
 	"strings"
 
 	"github.com/AlekSi/pointer"
@@ -16,6 +17,7 @@ import (
 	"github.com/talos-systems/os-runtime/pkg/resource"
 	"github.com/talos-systems/os-runtime/pkg/state"
 
+	"github.com/talos-systems/talos/internal/pkg/proxy"
 	"github.com/talos-systems/talos/pkg/images"
 	talosconfig "github.com/talos-systems/talos/pkg/machinery/config"
 	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1/machine"
@@ -133,12 +135,36 @@ func convertVolumes(volumes []talosconfig.VolumeMount) []config.K8sExtraVolume {
 	return result
 }
 
+// appendKMSVolume mounts the directory of the KMS plugin socket into kube-apiserver at the same path,
+// unless it is already mounted via the extra volumes.
+func appendKMSVolume(volumes []config.K8sExtraVolume, kms talosconfig.KMSEncryption) []config.K8sExtraVolume {
+	dir := filepath.Dir(strings.TrimPrefix(kms.Endpoint(), "unix://"))
+
+	for _, volume := range volumes {
+		if filepath.Clean(volume.MountPath) == dir {
+			return volumes
+		}
+	}
+
+	return append(volumes, config.K8sExtraVolume{
+		Name:      "kms-plugin",
+		HostPath:  dir,
+		MountPath: dir,
+	})
+}
+
 func (ctrl *K8sControlPlaneController) manageAPIServerConfig(ctx context.Context, r controller.Runtime, logger *log.Logger, cfgProvider talosconfig.Provider) error {
 	var cloudProvider string
 	if cfgProvider.Cluster().ExternalCloudProvider().Enabled() {
 		cloudProvider = "external"
 	}
 
+	extraVolumes := convertVolumes(cfgProvider.Cluster().APIServer().ExtraVolumes())
+
+	if kms := cfgProvider.Cluster().SecretsEncryption().KMS(); kms != nil {
+		extraVolumes = appendKMSVolume(extraVolumes, kms)
+	}
+
 	return r.Modify(ctx, config.NewK8sControlPlaneAPIServer(), func(r resource.Resource) error {
 		r.(*config.K8sControlPlane).SetAPIServer(config.K8sControlPlaneAPIServerSpec{
 			Image:                cfgProvider.Cluster().APIServer().Image(),
@@ -148,7 +174,7 @@ func (ctrl *K8sControlPlaneController) manageAPIServerConfig(ctx context.Context
 			LocalPort:            cfgProvider.Cluster().LocalAPIServerPort(),
 			ServiceCIDR:          cfgProvider.Cluster().Network().ServiceCIDR(),
 			ExtraArgs:            cfgProvider.Cluster().APIServer().ExtraArgs(),
-			ExtraVolumes:         convertVolumes(cfgProvider.Cluster().APIServer().ExtraVolumes()),
+			ExtraVolumes:         extraVolumes,
 		})
 
 		return nil
@@ -233,6 +259,9 @@ func (ctrl *K8sControlPlaneController) manageManifestsConfig(ctx context.Context
 			FlannelEnabled:  cfgProvider.Cluster().Network().CNI().Name() != constants.CustomCNI,
 			FlannelImage:    images.Flannel,
 			FlannelCNIImage: images.FlannelCNI,
+
+			SyncInterval:       cfgProvider.Cluster().ManifestsSync().Interval(),
+			UnmanagedManifests: cfgProvider.Cluster().ManifestsSync().UnmanagedManifests(),
 		})
 
 		return nil
@@ -241,7 +270,9 @@ func (ctrl *K8sControlPlaneController) manageManifestsConfig(ctx context.Context
 
 func (ctrl *K8sControlPlaneController) manageExtraManifestsConfig(ctx context.Context, r controller.Runtime, logger *log.Logger, cfgProvider talosconfig.Provider) error {
 	return r.Modify(ctx, config.NewK8sExtraManifests(), func(r resource.Resource) error {
-		spec := config.K8sExtraManifestsSpec{}
+		spec := config.K8sExtraManifestsSpec{
+			ProxyEnv: proxy.Env(cfgProvider.Machine().Env()),
+		}
 
 		if cfgProvider.Cluster().Network().CNI().Name() == constants.CustomCNI {
 			for _, url := range cfgProvider.Cluster().Network().CNI().URLs() {
@@ -178,6 +178,40 @@ func (suite *K8sControlPlaneSuite) TestReconcileExtraVolumes() {
 	}, apiServerCfg.ExtraVolumes)
 }
 
+func (suite *K8sControlPlaneSuite) TestReconcileKMSVolume() {
+	u, err := url.Parse("https://foo:6443")
+	suite.Require().NoError(err)
+
+	cfg := config.NewMachineConfig(&v1alpha1.Config{
+		ConfigVersion: "v1alpha1",
+		MachineConfig: &v1alpha1.MachineConfig{},
+		ClusterConfig: &v1alpha1.ClusterConfig{
+			ControlPlane: &v1alpha1.ControlPlaneConfig{
+				Endpoint: &v1alpha1.Endpoint{
+					URL: u,
+				},
+			},
+			SecretsEncryptionConfig: &v1alpha1.SecretsEncryptionConfig{
+				EncryptionProvider: "kms",
+				EncryptionKMS: &v1alpha1.KMSEncryptionConfig{
+					KMSName:     "vault",
+					KMSEndpoint: "unix:///var/run/kms/vault.sock",
+				},
+			},
+		},
+	})
+
+	apiServerCfg := suite.setupMachine(cfg)
+	suite.Assert().Equal([]config.K8sExtraVolume{
+		{
+			Name:      "kms-plugin",
+			HostPath:  "/var/run/kms",
+			MountPath: "/var/run/kms",
+			ReadOnly:  false,
+		},
+	}, apiServerCfg.ExtraVolumes)
+}
+
 func (suite *K8sControlPlaneSuite) TestReconcileExternalCloudProvider() {
 	u, err := url.Parse("https://foo:6443")
 	suite.Require().NoError(err)
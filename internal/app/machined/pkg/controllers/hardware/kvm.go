@@ -0,0 +1,138 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package hardware
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/AlekSi/pointer"
+	"github.com/talos-systems/os-runtime/pkg/controller"
+	"github.com/talos-systems/os-runtime/pkg/resource"
+	"github.com/talos-systems/os-runtime/pkg/state"
+
+	v1alpha1runtime "github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
+	"github.com/talos-systems/talos/pkg/resources/config"
+	"github.com/talos-systems/talos/pkg/resources/hardware"
+)
+
+const sysModulePath = "/sys/module"
+
+// KVMStatusController applies KVM module parameters and reports KVM availability.
+type KVMStatusController struct {
+	V1Alpha1Mode v1alpha1runtime.Mode
+}
+
+// Name implements controller.Controller interface.
+func (ctrl *KVMStatusController) Name() string {
+	return "hardware.KVMStatusController"
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *KVMStatusController) Inputs() []controller.Input {
+	return []controller.Input{
+		{
+			Namespace: config.NamespaceName,
+			Type:      config.MachineConfigType,
+			ID:        pointer.ToString(config.V1Alpha1ID),
+			Kind:      controller.InputWeak,
+		},
+	}
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *KVMStatusController) Outputs() []controller.Output {
+	return []controller.Output{
+		{
+			Type: hardware.KVMStatusType,
+			Kind: controller.OutputExclusive,
+		},
+	}
+}
+
+// Run implements controller.Controller interface.
+func (ctrl *KVMStatusController) Run(ctx context.Context, r controller.Runtime, logger *log.Logger) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+		}
+
+		cfg, err := r.Get(ctx, resource.NewMetadata(config.NamespaceName, config.MachineConfigType, config.V1Alpha1ID, resource.VersionUndefined))
+		if err != nil {
+			if !state.IsNotFoundError(err) {
+				return fmt.Errorf("error getting config: %w", err)
+			}
+		}
+
+		// module parameters are not namespaced, so they are left alone in container mode
+		if cfg != nil && ctrl.V1Alpha1Mode != v1alpha1runtime.ModeContainer {
+			for key, value := range cfg.(*config.MachineConfig).Config().Machine().Virtualization().KVMOptions() {
+				if err = writeModuleParameter("kvm", key, value); err != nil {
+					// parameters which can't be changed at runtime are still applied via kernel args on next install/upgrade
+					logger.Printf("failed to set kvm parameter %q: %s", key, err)
+				}
+			}
+		}
+
+		if err = r.Modify(ctx, hardware.NewKVMStatus(), func(r resource.Resource) error {
+			r.(*hardware.KVMStatus).SetStatus(probeKVM())
+
+			return nil
+		}); err != nil {
+			return fmt.Errorf("error updating objects: %w", err)
+		}
+	}
+}
+
+func probeKVM() hardware.KVMStatusSpec {
+	var status hardware.KVMStatusSpec
+
+	if _, err := os.Stat("/dev/kvm"); err == nil {
+		status.Available = true
+	}
+
+	for _, vendor := range []string{"intel", "amd"} {
+		value, err := readModuleParameter("kvm_"+vendor, "nested")
+		if err != nil {
+			continue
+		}
+
+		status.Vendor = vendor
+		status.Nested = value == "1" || value == "Y"
+
+		break
+	}
+
+	return status
+}
+
+func readModuleParameter(module, parameter string) (string, error) {
+	contents, err := ioutil.ReadFile(filepath.Join(sysModulePath, module, "parameters", parameter))
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(contents)), nil
+}
+
+func writeModuleParameter(module, parameter, value string) error {
+	current, err := readModuleParameter(module, parameter)
+	if err != nil {
+		return err
+	}
+
+	if current == value {
+		return nil
+	}
+
+	return ioutil.WriteFile(filepath.Join(sysModulePath, module, "parameters", parameter), []byte(value), 0o644)
+}
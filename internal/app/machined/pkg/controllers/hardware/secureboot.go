@@ -0,0 +1,86 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package hardware
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/talos-systems/os-runtime/pkg/controller"
+	"github.com/talos-systems/os-runtime/pkg/resource"
+
+	v1alpha1runtime "github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
+	"github.com/talos-systems/talos/internal/pkg/secureboot"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+	"github.com/talos-systems/talos/pkg/resources/hardware"
+)
+
+// SecureBootStatusController reports SecureBoot state of the node.
+type SecureBootStatusController struct {
+	V1Alpha1Mode v1alpha1runtime.Mode
+}
+
+// Name implements controller.Controller interface.
+func (ctrl *SecureBootStatusController) Name() string {
+	return "hardware.SecureBootStatusController"
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *SecureBootStatusController) Inputs() []controller.Input {
+	return nil
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *SecureBootStatusController) Outputs() []controller.Output {
+	return []controller.Output{
+		{
+			Type: hardware.SecureBootStatusType,
+			Kind: controller.OutputExclusive,
+		},
+	}
+}
+
+// Run implements controller.Controller interface.
+//
+// SecureBoot state can't change without a reboot, so it is probed only once.
+func (ctrl *SecureBootStatusController) Run(ctx context.Context, r controller.Runtime, logger *log.Logger) error {
+	var spec hardware.SecureBootStatusSpec
+
+	if ctrl.V1Alpha1Mode != v1alpha1runtime.ModeContainer {
+		status, err := secureboot.ReadStatus(constants.EFIVarsMountPoint)
+		if err != nil {
+			return fmt.Errorf("error reading SecureBoot status: %w", err)
+		}
+
+		spec.Enabled = status.SecureBoot
+		spec.SetupMode = status.SetupMode
+		spec.UKI = status.UKI
+
+		if spec.PCR7, err = secureboot.ReadPCR(secureboot.SecureBootPolicyPCR); err != nil {
+			logger.Printf("failed to read PCR %d: %s", secureboot.SecureBootPolicyPCR, err)
+		}
+
+		if spec.PCR11, err = secureboot.ReadPCR(secureboot.KernelImagePCR); err != nil {
+			logger.Printf("failed to read PCR %d: %s", secureboot.KernelImagePCR, err)
+		}
+
+		if spec.UKI && !spec.Enabled {
+			logger.Printf("booted from a Unified Kernel Image, but SecureBoot is not enforced by the firmware")
+		}
+	}
+
+	if err := r.Modify(ctx, hardware.NewSecureBootStatus(), func(r resource.Resource) error {
+		r.(*hardware.SecureBootStatus).SetStatus(spec)
+
+		return nil
+	}); err != nil {
+		return fmt.Errorf("error updating objects: %w", err)
+	}
+
+	<-ctx.Done()
+
+	return nil
+}
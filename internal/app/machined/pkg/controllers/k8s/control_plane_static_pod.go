@@ -46,6 +46,7 @@ func (ctrl *ControlPlaneStaticPodController) Inputs() []controller.Input {
 			ID:        pointer.ToString(k8s.StaticPodSecretsStaticPodID),
 			Kind:      controller.InputWeak,
 		},
+		reconcileStatusInput,
 	}
 }
 
@@ -70,6 +71,25 @@ func (ctrl *ControlPlaneStaticPodController) Run(ctx context.Context, r controll
 		case <-r.EventCh():
 		}
 
+		paused, err := reconcilePaused(ctx, r)
+		if err != nil {
+			return err
+		}
+
+		// static pods are rendered initially even if the reconciliation is paused
+		if paused {
+			staticPods, err := r.List(ctx, resource.NewMetadata(k8s.ControlPlaneNamespaceName, k8s.StaticPodType, "", resource.VersionUndefined))
+			if err != nil {
+				return fmt.Errorf("error listing static pods: %w", err)
+			}
+
+			if len(staticPods.Items) > 0 {
+				logger.Print("skipped as reconciliation is paused")
+
+				continue
+			}
+		}
+
 		secretsStatusResource, err := r.Get(ctx, resource.NewMetadata(k8s.ControlPlaneNamespaceName, k8s.SecretsStatusType, k8s.StaticPodSecretsStaticPodID, resource.VersionUndefined))
 		if err != nil {
 			if state.IsNotFoundError(err) {
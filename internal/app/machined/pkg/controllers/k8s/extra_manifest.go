@@ -20,6 +20,7 @@ import (
 	"github.com/talos-systems/os-runtime/pkg/resource"
 	"github.com/talos-systems/os-runtime/pkg/state"
 
+	"github.com/talos-systems/talos/internal/pkg/proxy"
 	"github.com/talos-systems/talos/pkg/resources/config"
 	"github.com/talos-systems/talos/pkg/resources/k8s"
 	"github.com/talos-systems/talos/pkg/resources/v1alpha1"
@@ -108,7 +109,7 @@ func (ctrl *ExtraManifestController) Run(ctx context.Context, r controller.Runti
 		for _, manifest := range config.ExtraManifests {
 			var id resource.ID
 
-			id, err = ctrl.download(ctx, r, logger, manifest)
+			id, err = ctrl.download(ctx, r, logger, manifest, config.ProxyEnv)
 			if err != nil {
 				multiErr = multierror.Append(multiErr, err)
 			}
@@ -139,7 +140,7 @@ func (ctrl *ExtraManifestController) Run(ctx context.Context, r controller.Runti
 	}
 }
 
-func (ctrl *ExtraManifestController) download(ctx context.Context, r controller.Runtime, logger *log.Logger, manifest config.ExtraManifest) (id resource.ID, err error) {
+func (ctrl *ExtraManifestController) download(ctx context.Context, r controller.Runtime, logger *log.Logger, manifest config.ExtraManifest, proxyEnv map[string]string) (id resource.ID, err error) {
 	id = fmt.Sprintf("%s-%s", manifest.Priority, manifest.URL)
 
 	var tmpDir string
@@ -157,9 +158,14 @@ func (ctrl *ExtraManifestController) download(ctx context.Context, r controller.
 
 	// Disable netrc since we don't have getent installed, and most likely
 	// never will.
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = proxy.Func(proxyEnv)
+
 	httpGetter := &getter.HttpGetter{
-		Netrc:  false,
-		Client: http.DefaultClient,
+		Netrc: false,
+		Client: &http.Client{
+			Transport: transport,
+		},
 	}
 
 	httpGetter.Header = make(http.Header)
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"log"
 	"sort"
+	"time"
 
 	"github.com/AlekSi/pointer"
 	"github.com/talos-systems/os-runtime/pkg/controller"
@@ -20,6 +21,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/discovery"
 	memory "k8s.io/client-go/discovery/cached"
 	"k8s.io/client-go/dynamic"
@@ -30,12 +32,15 @@ import (
 
 	"github.com/talos-systems/talos/internal/pkg/etcd"
 	"github.com/talos-systems/talos/pkg/machinery/constants"
+	"github.com/talos-systems/talos/pkg/resources/config"
 	"github.com/talos-systems/talos/pkg/resources/k8s"
 	"github.com/talos-systems/talos/pkg/resources/secrets"
 	"github.com/talos-systems/talos/pkg/resources/v1alpha1"
 )
 
 // ManifestApplyController applies manifests via control plane endpoint.
+//
+// Manifests are re-applied periodically with server-side apply, so that the drift of the objects is repaired.
 type ManifestApplyController struct{}
 
 // Name implements controller.Controller interface.
@@ -57,12 +62,19 @@ func (ctrl *ManifestApplyController) Inputs() []controller.Input {
 			Type:      k8s.ManifestType,
 			Kind:      controller.InputWeak,
 		},
+		{
+			Namespace: config.NamespaceName,
+			Type:      config.K8sControlPlaneType,
+			ID:        pointer.ToString(config.K8sManifestsID),
+			Kind:      controller.InputWeak,
+		},
 		{
 			Namespace: v1alpha1.NamespaceName,
 			Type:      v1alpha1.BootstrapStatusType,
 			ID:        pointer.ToString(v1alpha1.BootstrapStatusID),
 			Kind:      controller.InputWeak,
 		},
+		reconcileStatusInput,
 	}
 }
 
@@ -80,11 +92,14 @@ func (ctrl *ManifestApplyController) Outputs() []controller.Output {
 //
 //nolint:gocyclo
 func (ctrl *ManifestApplyController) Run(ctx context.Context, r controller.Runtime, logger *log.Logger) error {
+	var syncCh <-chan time.Time
+
 	for {
 		select {
 		case <-ctx.Done():
 			return nil
 		case <-r.EventCh():
+		case <-syncCh:
 		}
 
 		secretsResources, err := r.Get(ctx, resource.NewMetadata(secrets.NamespaceName, secrets.KubernetesType, secrets.KubernetesID, resource.VersionUndefined))
@@ -113,6 +128,42 @@ func (ctrl *ManifestApplyController) Run(ctx context.Context, r controller.Runti
 			continue
 		}
 
+		paused, err := reconcilePaused(ctx, r)
+		if err != nil {
+			return err
+		}
+
+		// manifests are applied on bootstrap even if the reconciliation is paused
+		if paused {
+			_, err = r.Get(ctx, k8s.NewManifestStatus(k8s.ControlPlaneNamespaceName).Metadata())
+			if err == nil {
+				logger.Print("skipped as reconciliation is paused")
+
+				continue
+			}
+
+			if !state.IsNotFoundError(err) {
+				return fmt.Errorf("error getting manifest status: %w", err)
+			}
+		}
+
+		manifestsConfig, err := r.Get(ctx, resource.NewMetadata(config.NamespaceName, config.K8sControlPlaneType, config.K8sManifestsID, resource.VersionUndefined))
+		if err != nil {
+			if state.IsNotFoundError(err) {
+				continue
+			}
+
+			return fmt.Errorf("error getting manifests config: %w", err)
+		}
+
+		syncConfig := manifestsConfig.(*config.K8sControlPlane).Manifests()
+
+		unmanaged := make(map[string]struct{}, len(syncConfig.UnmanagedManifests))
+
+		for _, id := range syncConfig.UnmanagedManifests {
+			unmanaged[id] = struct{}{}
+		}
+
 		manifests, err := r.List(ctx, resource.NewMetadata(k8s.ControlPlaneNamespaceName, k8s.ManifestType, "", resource.VersionUndefined))
 		if err != nil {
 			return fmt.Errorf("error listing manifests: %w", err)
@@ -122,7 +173,23 @@ func (ctrl *ManifestApplyController) Run(ctx context.Context, r controller.Runti
 			return manifests.Items[i].Metadata().ID() < manifests.Items[j].Metadata().ID()
 		})
 
-		if len(manifests.Items) > 0 {
+		var (
+			managedManifests     []resource.Resource
+			unmanagedManifestIDs []string
+			result               applyResult
+		)
+
+		for _, manifest := range manifests.Items {
+			if _, ok := unmanaged[manifest.Metadata().ID()]; ok {
+				unmanagedManifestIDs = append(unmanagedManifestIDs, manifest.Metadata().ID())
+
+				continue
+			}
+
+			managedManifests = append(managedManifests, manifest)
+		}
+
+		if len(managedManifests) > 0 {
 			var (
 				kubeconfig *rest.Config
 				dc         *discovery.DiscoveryClient
@@ -153,7 +220,9 @@ func (ctrl *ManifestApplyController) Run(ctx context.Context, r controller.Runti
 			}
 
 			if err = ctrl.etcdLock(ctx, logger, func() error {
-				return ctrl.apply(ctx, logger, mapper, dyn, manifests)
+				result, err = ctrl.apply(ctx, logger, mapper, dyn, managedManifests)
+
+				return err
 			}); err != nil {
 				return err
 			}
@@ -162,19 +231,34 @@ func (ctrl *ManifestApplyController) Run(ctx context.Context, r controller.Runti
 		if err = r.Modify(ctx, k8s.NewManifestStatus(k8s.ControlPlaneNamespaceName), func(r resource.Resource) error {
 			status := r.(*k8s.ManifestStatus).Status()
 
-			status.ManifestsApplied = make([]string, 0, len(manifests.Items))
+			status.ManifestsApplied = make([]string, 0, len(managedManifests))
 
-			for _, manifest := range manifests.Items {
+			for _, manifest := range managedManifests {
 				status.ManifestsApplied = append(status.ManifestsApplied, manifest.Metadata().ID())
 			}
 
+			status.ManifestsUnmanaged = unmanagedManifestIDs
+			status.LastSyncTime = time.Now()
+			status.ObjectsCreated = result.created
+			status.ObjectsUpdated = result.updated
+
 			return nil
 		}); err != nil {
 			return fmt.Errorf("error updating manifest status: %w", err)
 		}
+
+		if syncConfig.SyncInterval > 0 {
+			syncCh = time.After(syncConfig.SyncInterval)
+		}
 	}
 }
 
+// applyResult lists the objects changed while applying the manifests.
+type applyResult struct {
+	created []string
+	updated []string
+}
+
 func (ctrl *ManifestApplyController) etcdLock(ctx context.Context, logger *log.Logger, f func() error) error {
 	etcdClient, err := etcd.NewLocalClient()
 	if err != nil {
@@ -206,11 +290,13 @@ func (ctrl *ManifestApplyController) etcdLock(ctx context.Context, logger *log.L
 }
 
 //nolint:gocyclo
-func (ctrl *ManifestApplyController) apply(ctx context.Context, logger *log.Logger, mapper *restmapper.DeferredDiscoveryRESTMapper, dyn dynamic.Interface, manifests resource.List) error {
+func (ctrl *ManifestApplyController) apply(ctx context.Context, logger *log.Logger, mapper *restmapper.DeferredDiscoveryRESTMapper, dyn dynamic.Interface, manifests []resource.Resource) (applyResult, error) {
+	var result applyResult
+
 	// flatten list of objects to be applied
-	objects := make([]*unstructured.Unstructured, 0, len(manifests.Items))
+	objects := make([]*unstructured.Unstructured, 0, len(manifests))
 
-	for _, manifest := range manifests.Items {
+	for _, manifest := range manifests {
 		objects = append(objects, manifest.(*k8s.Manifest).Objects()...)
 	}
 
@@ -255,7 +341,7 @@ func (ctrl *ManifestApplyController) apply(ctx context.Context, logger *log.Logg
 
 		mapping, err := mapper.RESTMapping(obj.GroupVersionKind().GroupKind(), obj.GroupVersionKind().Version)
 		if err != nil {
-			return fmt.Errorf("error creating mapping for object %s: %w", objName, err)
+			return result, fmt.Errorf("error creating mapping for object %s: %w", objName, err)
 		}
 
 		var dr dynamic.ResourceInterface
@@ -267,31 +353,41 @@ func (ctrl *ManifestApplyController) apply(ctx context.Context, logger *log.Logg
 			dr = dyn.Resource(mapping.Resource)
 		}
 
-		_, err = dr.Get(ctx, obj.GetName(), metav1.GetOptions{})
-		if err == nil {
-			// already exists
-			continue
+		existing, err := dr.Get(ctx, obj.GetName(), metav1.GetOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return result, fmt.Errorf("error checking resource existence: %w", err)
 		}
 
-		if !apierrors.IsNotFound(err) {
-			return fmt.Errorf("error checking resource existence: %w", err)
+		exists := err == nil
+
+		data, err := obj.MarshalJSON()
+		if err != nil {
+			return result, fmt.Errorf("error marshaling %s: %w", objName, err)
 		}
 
-		_, err = dr.Create(ctx, obj, metav1.CreateOptions{
+		// server-side apply creates missing objects and reverts the changes of the fields set in the manifest,
+		// fields not set in the manifest (e.g. annotations added by the user) are left untouched
+		applied, err := dr.Patch(ctx, obj.GetName(), types.ApplyPatchType, data, metav1.PatchOptions{
 			FieldManager: "talos",
+			Force:        pointer.ToBool(true),
 		})
 		if err != nil {
-			if apierrors.IsAlreadyExists(err) {
-				// later on we might want to do something here, e.g. do server-side apply, for now do nothing
-			} else {
-				return fmt.Errorf("error creating %s: %w", objName, err)
-			}
-		} else {
+			return result, fmt.Errorf("error applying %s: %w", objName, err)
+		}
+
+		switch {
+		case !exists:
 			logger.Printf("created %s", objName)
+
+			result.created = append(result.created, objName)
+		case applied.GetResourceVersion() != existing.GetResourceVersion():
+			logger.Printf("updated %s", objName)
+
+			result.updated = append(result.updated, objName)
 		}
 	}
 
-	return nil
+	return result, nil
 }
 
 func isNamespace(gvk schema.GroupVersionKind) bool {
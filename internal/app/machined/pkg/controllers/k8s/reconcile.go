@@ -0,0 +1,38 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package k8s
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/AlekSi/pointer"
+	"github.com/talos-systems/os-runtime/pkg/controller"
+	"github.com/talos-systems/os-runtime/pkg/state"
+
+	"github.com/talos-systems/talos/pkg/resources/v1alpha1"
+)
+
+// reconcileStatusInput is the input of the controllers which pause while the reconciliation is paused.
+var reconcileStatusInput = controller.Input{
+	Namespace: v1alpha1.NamespaceName,
+	Type:      v1alpha1.ReconcileStatusType,
+	ID:        pointer.ToString(v1alpha1.ReconcileStatusID),
+	Kind:      controller.InputWeak,
+}
+
+// reconcilePaused returns true if the automatic reconciliation of the cluster state is paused.
+func reconcilePaused(ctx context.Context, r controller.Runtime) (bool, error) {
+	res, err := r.Get(ctx, v1alpha1.NewReconcileStatus().Metadata())
+	if err != nil {
+		if state.IsNotFoundError(err) {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("error getting reconcile status: %w", err)
+	}
+
+	return res.(*v1alpha1.ReconcileStatus).Status().Paused, nil
+}
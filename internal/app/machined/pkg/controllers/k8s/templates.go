@@ -6,16 +6,35 @@ package k8s
 
 // kube-apiserver configuration:
 
-var kubeSystemEncryptionConfigTemplate = []byte(`apiVersion: v1
-kind: EncryptionConfig
+var kubeSystemEncryptionConfigTemplate = []byte(`apiVersion: apiserver.config.k8s.io/v1
+kind: EncryptionConfiguration
 resources:
 - resources:
   - secrets
   providers:
+  {{- range .Root.SecretsEncryptionProviders }}
+  {{- if eq . "aescbc" }}
   - aescbc:
       keys:
-      - name: key1
-        secret: {{ .Root.AESCBCEncryptionSecret }}
+      - name: {{ $.Root.AESCBCEncryptionKeyName }}
+        secret: {{ $.Root.AESCBCEncryptionSecret }}
+      {{- range $.Root.AESCBCAdditionalEncryptionKeys }}
+      - name: {{ .Name }}
+        secret: {{ .Secret }}
+      {{- end }}
+  {{- else if eq . "secretbox" }}
+  - secretbox:
+      keys:
+      - name: {{ $.Root.SecretboxEncryptionKeyName }}
+        secret: {{ $.Root.SecretboxEncryptionSecret }}
+  {{- else if eq . "kms" }}
+  - kms:
+      apiVersion: v2
+      name: {{ $.Root.KMSEncryption.Name }}
+      endpoint: {{ $.Root.KMSEncryption.Endpoint }}
+      timeout: {{ $.Root.KMSEncryption.Timeout }}
+  {{- end }}
+  {{- end }}
   - identity: {}
 `)
 
@@ -0,0 +1,393 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package kubespan
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/talos-systems/os-runtime/pkg/controller"
+	"github.com/talos-systems/os-runtime/pkg/resource"
+	"github.com/talos-systems/os-runtime/pkg/state"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+
+	v1alpha1runtime "github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
+	"github.com/talos-systems/talos/internal/pkg/discovery"
+	talosconfig "github.com/talos-systems/talos/pkg/machinery/config"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+	"github.com/talos-systems/talos/pkg/resources/cluster"
+	"github.com/talos-systems/talos/pkg/resources/config"
+	"github.com/talos-systems/talos/pkg/resources/kubespan"
+	"github.com/talos-systems/talos/pkg/version"
+)
+
+// DiscoveryController publishes the node information to the discovery service, builds KubeSpan peers
+// from the other cluster members and keeps the list of cluster affiliates.
+type DiscoveryController struct {
+	V1Alpha1Mode v1alpha1runtime.Mode
+}
+
+// Name implements controller.Controller interface.
+func (ctrl *DiscoveryController) Name() string {
+	return "kubespan.DiscoveryController"
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *DiscoveryController) Inputs() []controller.Input {
+	return []controller.Input{
+		{
+			Namespace: config.NamespaceName,
+			Type:      config.MachineConfigType,
+			ID:        pointer.ToString(config.V1Alpha1ID),
+			Kind:      controller.InputWeak,
+		},
+		{
+			Namespace: cluster.NamespaceName,
+			Type:      cluster.IdentityType,
+			ID:        pointer.ToString(cluster.LocalIdentity),
+			Kind:      controller.InputWeak,
+		},
+		{
+			Namespace: kubespan.NamespaceName,
+			Type:      kubespan.IdentityType,
+			ID:        pointer.ToString(kubespan.LocalIdentity),
+			Kind:      controller.InputWeak,
+		},
+	}
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *DiscoveryController) Outputs() []controller.Output {
+	return []controller.Output{
+		{
+			Type: kubespan.PeerSpecType,
+			Kind: controller.OutputExclusive,
+		},
+		{
+			Type: cluster.AffiliateType,
+			Kind: controller.OutputExclusive,
+		},
+	}
+}
+
+// Run implements controller.Controller interface.
+//
+//nolint:gocyclo,cyclop
+func (ctrl *DiscoveryController) Run(ctx context.Context, r controller.Runtime, logger *log.Logger) error {
+	ticker := time.NewTicker(discoveryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+		case <-ticker.C:
+		}
+
+		cfg, err := r.Get(ctx, resource.NewMetadata(config.NamespaceName, config.MachineConfigType, config.V1Alpha1ID, resource.VersionUndefined))
+		if err != nil && !state.IsNotFoundError(err) {
+			return fmt.Errorf("error getting config: %w", err)
+		}
+
+		if cfg == nil || !enabled(cfg.(*config.MachineConfig).Config(), ctrl.V1Alpha1Mode) {
+			if err = ctrl.reconcilePeers(ctx, r, nil); err != nil {
+				return err
+			}
+
+			if err = ctrl.reconcileAffiliates(ctx, r, nil); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		nodeIdentity, err := r.Get(ctx, resource.NewMetadata(cluster.NamespaceName, cluster.IdentityType, cluster.LocalIdentity, resource.VersionUndefined))
+		if err != nil {
+			if state.IsNotFoundError(err) {
+				continue
+			}
+
+			return fmt.Errorf("error getting node identity: %w", err)
+		}
+
+		identity, err := r.Get(ctx, resource.NewMetadata(kubespan.NamespaceName, kubespan.IdentityType, kubespan.LocalIdentity, resource.VersionUndefined))
+		if err != nil {
+			if state.IsNotFoundError(err) {
+				continue
+			}
+
+			return fmt.Errorf("error getting KubeSpan identity: %w", err)
+		}
+
+		machineConfig := cfg.(*config.MachineConfig).Config()
+
+		client, err := discovery.NewClient(machineConfig.Machine().Network().KubeSpan().DiscoveryEndpoint(), machineConfig.Cluster().ID(), machineConfig.Cluster().Secret())
+		if err != nil {
+			return fmt.Errorf("error creating discovery client: %w", err)
+		}
+
+		peers, affiliates, err := ctrl.discover(ctx, client, machineConfig, nodeIdentity.(*cluster.Identity).IdentitySpec().NodeID, identity.(*kubespan.Identity).TypedSpec())
+		if err != nil {
+			// keep the last known peers, discovery service might be temporarily unavailable
+			logger.Printf("error exchanging KubeSpan peers with the discovery service: %s", err)
+
+			continue
+		}
+
+		if err = ctrl.reconcilePeers(ctx, r, peers); err != nil {
+			return err
+		}
+
+		if err = ctrl.reconcileAffiliates(ctx, r, affiliates); err != nil {
+			return err
+		}
+	}
+}
+
+//nolint:gocyclo
+func (ctrl *DiscoveryController) discover(ctx context.Context, client *discovery.Client, machineConfig talosconfig.Provider, nodeID string, identity *kubespan.IdentitySpec) (map[resource.ID]kubespan.PeerSpecSpec, map[resource.ID]cluster.AffiliateSpec, error) {
+	_, subnet, err := net.ParseCIDR(identity.Subnet)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error parsing KubeSpan subnet: %w", err)
+	}
+
+	addresses, err := nodeAddresses(subnet)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error listing node addresses: %w", err)
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting hostname: %w", err)
+	}
+
+	meshIP, _, err := net.ParseCIDR(identity.Address)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error parsing KubeSpan address: %w", err)
+	}
+
+	affiliate := &discovery.Affiliate{
+		NodeID:            nodeID,
+		Hostname:          hostname,
+		MachineType:       machineConfig.Machine().Type().String(),
+		OperatingSystem:   fmt.Sprintf("%s (%s)", version.Name, version.Tag),
+		KubernetesVersion: imageTag(machineConfig.Machine().Kubelet().Image()),
+		KubeSpanPublicKey: identity.PublicKey,
+		KubeSpanAddress:   meshIP.String(),
+	}
+
+	for _, addr := range addresses {
+		affiliate.NodeAddresses = append(affiliate.NodeAddresses, addr.String())
+		affiliate.KubeSpanEndpoints = append(affiliate.KubeSpanEndpoints, endpoint(addr))
+	}
+
+	reflectedAddr, err := client.Update(ctx, affiliate, discoveryTTL)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// the address as seen by the discovery service is the public address of the node behind NAT
+	if ip := net.ParseIP(reflectedAddr); ip != nil {
+		reflectedEndpoint := endpoint(ip)
+		known := false
+
+		for _, ep := range affiliate.KubeSpanEndpoints {
+			if ep == reflectedEndpoint {
+				known = true
+
+				break
+			}
+		}
+
+		if !known {
+			affiliate.KubeSpanEndpoints = append(affiliate.KubeSpanEndpoints, reflectedEndpoint)
+
+			if _, err = client.Update(ctx, affiliate, discoveryTTL); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
+	affiliates, err := client.List(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	peers := make(map[resource.ID]kubespan.PeerSpecSpec, len(affiliates))
+	affiliateSpecs := make(map[resource.ID]cluster.AffiliateSpec, len(affiliates)+1)
+
+	affiliateSpecs[nodeID] = affiliateSpec(affiliate)
+
+	for _, other := range affiliates {
+		if other.NodeID != "" && other.NodeID != nodeID {
+			affiliateSpecs[other.NodeID] = affiliateSpec(other)
+		}
+
+		if other.NodeID == nodeID || other.KubeSpanPublicKey == "" || other.KubeSpanPublicKey == identity.PublicKey {
+			continue
+		}
+
+		if _, err = wgtypes.ParseKey(other.KubeSpanPublicKey); err != nil {
+			continue
+		}
+
+		// mesh address is derived from the public key, so it can't be spoofed by the peer
+		peerIP := meshAddress(subnet, other.KubeSpanPublicKey)
+
+		spec := kubespan.PeerSpecSpec{
+			Label:      other.Hostname,
+			Address:    peerIP.String(),
+			AllowedIPs: []string{hostCIDR(peerIP)},
+			Endpoints:  other.KubeSpanEndpoints,
+		}
+
+		for _, addr := range other.NodeAddresses {
+			ip := net.ParseIP(addr)
+			if ip == nil || subnet.Contains(ip) {
+				continue
+			}
+
+			spec.AllowedIPs = append(spec.AllowedIPs, hostCIDR(ip))
+		}
+
+		peers[other.KubeSpanPublicKey] = spec
+	}
+
+	return peers, affiliateSpecs, nil
+}
+
+func (ctrl *DiscoveryController) reconcilePeers(ctx context.Context, r controller.Runtime, peers map[resource.ID]kubespan.PeerSpecSpec) error {
+	for id, spec := range peers {
+		spec := spec
+
+		if err := r.Modify(ctx, kubespan.NewPeerSpec(id), func(r resource.Resource) error {
+			*r.(*kubespan.PeerSpec).TypedSpec() = spec
+
+			return nil
+		}); err != nil {
+			return fmt.Errorf("error updating KubeSpan peer: %w", err)
+		}
+	}
+
+	list, err := r.List(ctx, resource.NewMetadata(kubespan.NamespaceName, kubespan.PeerSpecType, "", resource.VersionUndefined))
+	if err != nil {
+		return fmt.Errorf("error listing KubeSpan peers: %w", err)
+	}
+
+	for _, res := range list.Items {
+		if _, ok := peers[res.Metadata().ID()]; ok {
+			continue
+		}
+
+		if err = r.Destroy(ctx, res.Metadata()); err != nil {
+			return fmt.Errorf("error cleaning up KubeSpan peers: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (ctrl *DiscoveryController) reconcileAffiliates(ctx context.Context, r controller.Runtime, affiliates map[resource.ID]cluster.AffiliateSpec) error {
+	for id, spec := range affiliates {
+		spec := spec
+
+		if err := r.Modify(ctx, cluster.NewAffiliate(id), func(r resource.Resource) error {
+			*r.(*cluster.Affiliate).AffiliateSpec() = spec
+
+			return nil
+		}); err != nil {
+			return fmt.Errorf("error updating cluster affiliate: %w", err)
+		}
+	}
+
+	list, err := r.List(ctx, resource.NewMetadata(cluster.NamespaceName, cluster.AffiliateType, "", resource.VersionUndefined))
+	if err != nil {
+		return fmt.Errorf("error listing cluster affiliates: %w", err)
+	}
+
+	for _, res := range list.Items {
+		if _, ok := affiliates[res.Metadata().ID()]; ok {
+			continue
+		}
+
+		if err = r.Destroy(ctx, res.Metadata()); err != nil {
+			return fmt.Errorf("error cleaning up cluster affiliates: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func affiliateSpec(affiliate *discovery.Affiliate) cluster.AffiliateSpec {
+	return cluster.AffiliateSpec{
+		NodeID:            affiliate.NodeID,
+		Hostname:          affiliate.Hostname,
+		MachineType:       affiliate.MachineType,
+		OperatingSystem:   affiliate.OperatingSystem,
+		KubernetesVersion: affiliate.KubernetesVersion,
+		Addresses:         affiliate.NodeAddresses,
+	}
+}
+
+// imageTag returns the tag of the container image reference.
+func imageTag(image string) string {
+	// skip the registry host:port part
+	name := image[strings.LastIndex(image, "/")+1:]
+
+	if idx := strings.Index(name, "@"); idx >= 0 {
+		name = name[:idx]
+	}
+
+	if idx := strings.LastIndex(name, ":"); idx >= 0 {
+		return name[idx+1:]
+	}
+
+	return ""
+}
+
+// nodeAddresses returns global unicast addresses of the node, excluding the KubeSpan mesh addresses.
+func nodeAddresses(subnet *net.IPNet) ([]net.IP, error) {
+	links, err := net.Interfaces()
+	if err != nil {
+		return nil, err
+	}
+
+	var result []net.IP
+
+	for _, link := range links {
+		if link.Name == constants.KubeSpanLinkName {
+			continue
+		}
+
+		addrs, err := link.Addrs()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, addr := range addrs {
+			ipNet, ok := addr.(*net.IPNet)
+			if !ok || !ipNet.IP.IsGlobalUnicast() || subnet.Contains(ipNet.IP) {
+				continue
+			}
+
+			result = append(result, ipNet.IP)
+		}
+	}
+
+	return result, nil
+}
+
+// endpoint returns Wireguard endpoint of the node for the address.
+func endpoint(ip net.IP) string {
+	return net.JoinHostPort(ip.String(), strconv.Itoa(constants.KubeSpanDefaultPort))
+}
@@ -0,0 +1,151 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package kubespan
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/talos-systems/os-runtime/pkg/controller"
+	"github.com/talos-systems/os-runtime/pkg/resource"
+	"github.com/talos-systems/os-runtime/pkg/state"
+
+	v1alpha1runtime "github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
+	"github.com/talos-systems/talos/internal/app/networkd/pkg/nic"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+	"github.com/talos-systems/talos/pkg/resources/cluster"
+	"github.com/talos-systems/talos/pkg/resources/config"
+	"github.com/talos-systems/talos/pkg/resources/kubespan"
+)
+
+// IdentityController generates the KubeSpan Wireguard key of the node and derives the mesh address.
+//
+// The key is persisted in the STATE partition, so the controller waits for the node identity
+// to be loaded (which happens once STATE is mounted).
+type IdentityController struct {
+	V1Alpha1Mode v1alpha1runtime.Mode
+}
+
+// Name implements controller.Controller interface.
+func (ctrl *IdentityController) Name() string {
+	return "kubespan.IdentityController"
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *IdentityController) Inputs() []controller.Input {
+	return []controller.Input{
+		{
+			Namespace: config.NamespaceName,
+			Type:      config.MachineConfigType,
+			ID:        pointer.ToString(config.V1Alpha1ID),
+			Kind:      controller.InputWeak,
+		},
+		{
+			Namespace: cluster.NamespaceName,
+			Type:      cluster.IdentityType,
+			ID:        pointer.ToString(cluster.LocalIdentity),
+			Kind:      controller.InputWeak,
+		},
+	}
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *IdentityController) Outputs() []controller.Output {
+	return []controller.Output{
+		{
+			Type: kubespan.IdentityType,
+			Kind: controller.OutputExclusive,
+		},
+	}
+}
+
+// Run implements controller.Controller interface.
+//
+//nolint:gocyclo
+func (ctrl *IdentityController) Run(ctx context.Context, r controller.Runtime, logger *log.Logger) error {
+	var retryCh <-chan time.Time
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+		case <-retryCh:
+		}
+
+		retryCh = nil
+
+		cfg, err := r.Get(ctx, resource.NewMetadata(config.NamespaceName, config.MachineConfigType, config.V1Alpha1ID, resource.VersionUndefined))
+		if err != nil && !state.IsNotFoundError(err) {
+			return fmt.Errorf("error getting config: %w", err)
+		}
+
+		_, err = r.Get(ctx, resource.NewMetadata(cluster.NamespaceName, cluster.IdentityType, cluster.LocalIdentity, resource.VersionUndefined))
+		if err != nil && !state.IsNotFoundError(err) {
+			return fmt.Errorf("error getting node identity: %w", err)
+		}
+
+		stateReady := err == nil
+
+		if cfg == nil || !enabled(cfg.(*config.MachineConfig).Config(), ctrl.V1Alpha1Mode) {
+			if err = ctrl.cleanup(ctx, r); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if !stateReady {
+			continue
+		}
+
+		key, err := nic.LoadOrGenerateWireguardKey(constants.KubeSpanKeyPath)
+		if err != nil {
+			logger.Printf("error loading KubeSpan key: %s", err)
+
+			retryCh = time.After(retryInterval)
+
+			continue
+		}
+
+		publicKey := key.PublicKey().String()
+		subnet := meshSubnet(cfg.(*config.MachineConfig).Config().Cluster().ID())
+		address := &net.IPNet{
+			IP:   meshAddress(subnet, publicKey),
+			Mask: subnet.Mask,
+		}
+
+		if err = r.Modify(ctx, kubespan.NewIdentity(kubespan.LocalIdentity), func(r resource.Resource) error {
+			*r.(*kubespan.Identity).TypedSpec() = kubespan.IdentitySpec{
+				PublicKey: publicKey,
+				Address:   address.String(),
+				Subnet:    subnet.String(),
+			}
+
+			return nil
+		}); err != nil {
+			return fmt.Errorf("error updating KubeSpan identity: %w", err)
+		}
+	}
+}
+
+func (ctrl *IdentityController) cleanup(ctx context.Context, r controller.Runtime) error {
+	list, err := r.List(ctx, resource.NewMetadata(kubespan.NamespaceName, kubespan.IdentityType, "", resource.VersionUndefined))
+	if err != nil {
+		return fmt.Errorf("error listing KubeSpan identities: %w", err)
+	}
+
+	for _, res := range list.Items {
+		if err = r.Destroy(ctx, res.Metadata()); err != nil {
+			return fmt.Errorf("error cleaning up KubeSpan identity: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package kubespan provides controllers which build KubeSpan full mesh Wireguard network between the cluster nodes.
+//
+// IdentityController generates the Wireguard key of the node, DiscoveryController exchanges the node
+// information with the other cluster members via the discovery service, and ManagerController configures
+// the Wireguard link and routes traffic to the peers over the mesh.
+package kubespan
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"net"
+	"time"
+
+	v1alpha1runtime "github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
+	talosconfig "github.com/talos-systems/talos/pkg/machinery/config"
+)
+
+const (
+	// retryInterval is the interval between attempts if KubeSpan can't be set up yet.
+	retryInterval = 10 * time.Second
+
+	// discoveryInterval is the interval between updates of the discovery service records.
+	discoveryInterval = 30 * time.Second
+
+	// discoveryTTL is the expiration time of the node record in the discovery service.
+	discoveryTTL = 5 * time.Minute
+
+	// meshSubnetPrefixLength is the prefix length of the KubeSpan mesh subnet.
+	meshSubnetPrefixLength = 64
+)
+
+// enabled returns true if KubeSpan is enabled in the config.
+//
+// Network in container mode is managed by the container runtime, so KubeSpan is not supported.
+func enabled(cfg talosconfig.Provider, mode v1alpha1runtime.Mode) bool {
+	return cfg != nil && mode != v1alpha1runtime.ModeContainer && cfg.Machine().Network().KubeSpan().Enabled()
+}
+
+// meshSubnet derives the unique local IPv6 subnet of the mesh from the cluster ID.
+//
+// Cluster ID is never rotated (unlike the bootstrap token), so the mesh addresses are stable.
+func meshSubnet(clusterID string) *net.IPNet {
+	sum := sha256.Sum256([]byte("kubespan subnet" + clusterID))
+
+	ip := make(net.IP, net.IPv6len)
+	ip[0] = 0xfd
+	copy(ip[1:meshSubnetPrefixLength/8], sum[:])
+
+	return &net.IPNet{
+		IP:   ip,
+		Mask: net.CIDRMask(meshSubnetPrefixLength, 128),
+	}
+}
+
+// meshAddress derives the address of the node in the mesh subnet from the Wireguard public key.
+func meshAddress(subnet *net.IPNet, publicKey string) net.IP {
+	sum := sha256.Sum256([]byte(publicKey))
+
+	ip := make(net.IP, net.IPv6len)
+	copy(ip, subnet.IP)
+	copy(ip[meshSubnetPrefixLength/8:], sum[:])
+
+	return ip
+}
+
+// hostCIDR returns the single-address CIDR for the IP.
+func hostCIDR(ip net.IP) string {
+	if ip4 := ip.To4(); ip4 != nil {
+		return fmt.Sprintf("%s/32", ip4)
+	}
+
+	return fmt.Sprintf("%s/128", ip)
+}
@@ -0,0 +1,42 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package kubespan
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMeshAddressing(t *testing.T) {
+	subnet := meshSubnet("cluster-id")
+
+	ones, bits := subnet.Mask.Size()
+	assert.Equal(t, meshSubnetPrefixLength, ones)
+	assert.Equal(t, 128, bits)
+	assert.Equal(t, byte(0xfd), subnet.IP[0])
+
+	// subnet is stable for the cluster and unique across the clusters
+	assert.Equal(t, subnet.String(), meshSubnet("cluster-id").String())
+	assert.NotEqual(t, subnet.String(), meshSubnet("other-cluster-id").String())
+
+	addr1 := meshAddress(subnet, "key1")
+	addr2 := meshAddress(subnet, "key2")
+
+	assert.True(t, subnet.Contains(addr1))
+	assert.True(t, subnet.Contains(addr2))
+	assert.NotEqual(t, addr1.String(), addr2.String())
+
+	assert.Equal(t, "10.5.0.2/32", hostCIDR(net.ParseIP("10.5.0.2")))
+	assert.Equal(t, "2001:db8::1/128", hostCIDR(net.ParseIP("2001:db8::1")))
+}
+
+func TestImageTag(t *testing.T) {
+	assert.Equal(t, "v1.21.1", imageTag("ghcr.io/talos-systems/kubelet:v1.21.1"))
+	assert.Equal(t, "v1.21.1", imageTag("registry.local:5000/kubelet:v1.21.1"))
+	assert.Equal(t, "v1.21.1", imageTag("kubelet:v1.21.1@sha256:0123456789abcdef"))
+	assert.Equal(t, "", imageTag("registry.local:5000/kubelet"))
+}
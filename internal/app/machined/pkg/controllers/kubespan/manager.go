@@ -0,0 +1,413 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package kubespan
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/talos-systems/os-runtime/pkg/controller"
+	"github.com/talos-systems/os-runtime/pkg/resource"
+	"github.com/talos-systems/os-runtime/pkg/state"
+	"golang.org/x/sys/unix"
+	"golang.zx2c4.com/wireguard/wgctrl"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+
+	"github.com/talos-systems/talos/internal/app/networkd/pkg/nic"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+	"github.com/talos-systems/talos/pkg/resources/kubespan"
+	"github.com/talos-systems/talos/pkg/resources/network"
+)
+
+const (
+	// managerInterval is the interval between checks of the peer state.
+	managerInterval = 15 * time.Second
+
+	// peerKeepaliveInterval keeps NAT mappings open for the peers behind NAT.
+	peerKeepaliveInterval = 25 * time.Second
+
+	// endpointTimeout is the time to wait for the handshake before trying the next endpoint of the peer.
+	endpointTimeout = time.Minute
+
+	// peerUpTimeout is the maximum age of the last handshake for the peer to be considered up.
+	//
+	// Wireguard re-keys every 2 minutes, so the handshake of a healthy peer is never older than that.
+	peerUpTimeout = 3 * time.Minute
+)
+
+// peerState tracks the endpoint currently used for the peer.
+type peerState struct {
+	endpointIdx int
+	endpointSet time.Time
+}
+
+// ManagerController configures KubeSpan Wireguard link and routes the traffic of the peers over it.
+//
+// Traffic to the peer is routed over the mesh only while the peer is up (handshake is recent),
+// otherwise it falls back to the regular network, so a broken mesh link doesn't break the connectivity.
+type ManagerController struct {
+	peers map[string]*peerState
+}
+
+// Name implements controller.Controller interface.
+func (ctrl *ManagerController) Name() string {
+	return "kubespan.ManagerController"
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *ManagerController) Inputs() []controller.Input {
+	return []controller.Input{
+		{
+			Namespace: kubespan.NamespaceName,
+			Type:      kubespan.IdentityType,
+			ID:        pointer.ToString(kubespan.LocalIdentity),
+			Kind:      controller.InputWeak,
+		},
+		{
+			Namespace: kubespan.NamespaceName,
+			Type:      kubespan.PeerSpecType,
+			Kind:      controller.InputWeak,
+		},
+	}
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *ManagerController) Outputs() []controller.Output {
+	return []controller.Output{
+		{
+			Type: network.LinkSpecType,
+			Kind: controller.OutputShared,
+		},
+		{
+			Type: network.AddressSpecType,
+			Kind: controller.OutputShared,
+		},
+		{
+			Type: network.RouteSpecType,
+			Kind: controller.OutputShared,
+		},
+		{
+			Type: network.RoutingRuleSpecType,
+			Kind: controller.OutputShared,
+		},
+	}
+}
+
+// Run implements controller.Controller interface.
+//
+//nolint:gocyclo
+func (ctrl *ManagerController) Run(ctx context.Context, r controller.Runtime, logger *log.Logger) error {
+	ctrl.peers = map[string]*peerState{}
+
+	ticker := time.NewTicker(managerInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+		case <-ticker.C:
+		}
+
+		identity, err := r.Get(ctx, resource.NewMetadata(kubespan.NamespaceName, kubespan.IdentityType, kubespan.LocalIdentity, resource.VersionUndefined))
+		if err != nil && !state.IsNotFoundError(err) {
+			return fmt.Errorf("error getting KubeSpan identity: %w", err)
+		}
+
+		specs := newSpecs()
+
+		if identity != nil {
+			peerList, err := r.List(ctx, resource.NewMetadata(kubespan.NamespaceName, kubespan.PeerSpecType, "", resource.VersionUndefined))
+			if err != nil {
+				return fmt.Errorf("error listing KubeSpan peers: %w", err)
+			}
+
+			peers := make(map[string]kubespan.PeerSpecSpec, len(peerList.Items))
+
+			for _, res := range peerList.Items {
+				peers[res.Metadata().ID()] = *res.(*kubespan.PeerSpec).TypedSpec()
+			}
+
+			upPeers, err := ctrl.configureWireguard(peers)
+			if err != nil {
+				// link might not be created yet
+				logger.Printf("error configuring KubeSpan Wireguard link: %s", err)
+			}
+
+			specs.build(identity.(*kubespan.Identity).TypedSpec(), peers, upPeers)
+		} else {
+			ctrl.peers = map[string]*peerState{}
+		}
+
+		if err = ctrl.apply(ctx, r, specs); err != nil {
+			return err
+		}
+	}
+}
+
+// configureWireguard updates the Wireguard link with the peers and returns the set of peers which are up.
+//
+//nolint:gocyclo
+func (ctrl *ManagerController) configureWireguard(peers map[string]kubespan.PeerSpecSpec) (map[string]struct{}, error) {
+	privateKey, err := nic.LoadOrGenerateWireguardKey(constants.KubeSpanKeyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	wgClient, err := wgctrl.New()
+	if err != nil {
+		return nil, fmt.Errorf("error creating wireguard client: %w", err)
+	}
+
+	defer wgClient.Close() //nolint:errcheck
+
+	device, err := wgClient.Device(constants.KubeSpanLinkName)
+	if err != nil {
+		return nil, fmt.Errorf("error getting wireguard device: %w", err)
+	}
+
+	current := make(map[string]wgtypes.Peer, len(device.Peers))
+
+	for _, peer := range device.Peers {
+		current[peer.PublicKey.String()] = peer
+	}
+
+	now := time.Now()
+	upPeers := map[string]struct{}{}
+
+	listenPort := constants.KubeSpanDefaultPort
+	firewallMark := constants.KubeSpanDefaultFirewallMark
+
+	cfg := wgtypes.Config{
+		PrivateKey:   &privateKey,
+		ListenPort:   &listenPort,
+		FirewallMark: &firewallMark,
+	}
+
+	for publicKey, spec := range peers {
+		key, err := wgtypes.ParseKey(publicKey)
+		if err != nil {
+			continue
+		}
+
+		keepalive := peerKeepaliveInterval
+
+		peerCfg := wgtypes.PeerConfig{
+			PublicKey:                   key,
+			PersistentKeepaliveInterval: &keepalive,
+			ReplaceAllowedIPs:           true,
+		}
+
+		for _, allowedIP := range spec.AllowedIPs {
+			_, ipNet, err := net.ParseCIDR(allowedIP)
+			if err != nil {
+				continue
+			}
+
+			peerCfg.AllowedIPs = append(peerCfg.AllowedIPs, *ipNet)
+		}
+
+		peer, configured := current[publicKey]
+		if configured && now.Sub(peer.LastHandshakeTime) < peerUpTimeout {
+			upPeers[publicKey] = struct{}{}
+		}
+
+		st, ok := ctrl.peers[publicKey]
+		if !ok {
+			st = &peerState{}
+			ctrl.peers[publicKey] = st
+		}
+
+		// peer is not reachable via the current endpoint, try the next one
+		if !configured || (peer.LastHandshakeTime.Before(st.endpointSet) && now.Sub(st.endpointSet) > endpointTimeout) {
+			if len(spec.Endpoints) > 0 {
+				if configured {
+					st.endpointIdx = (st.endpointIdx + 1) % len(spec.Endpoints)
+				}
+
+				if st.endpointIdx >= len(spec.Endpoints) {
+					st.endpointIdx = 0
+				}
+
+				endpoint, err := net.ResolveUDPAddr("udp", spec.Endpoints[st.endpointIdx])
+				if err == nil {
+					peerCfg.Endpoint = endpoint
+					st.endpointSet = now
+				}
+			}
+		}
+
+		cfg.Peers = append(cfg.Peers, peerCfg)
+	}
+
+	for publicKey, peer := range current {
+		if _, ok := peers[publicKey]; ok {
+			continue
+		}
+
+		delete(ctrl.peers, publicKey)
+
+		cfg.Peers = append(cfg.Peers, wgtypes.PeerConfig{
+			PublicKey: peer.PublicKey,
+			Remove:    true,
+		})
+	}
+
+	if err = wgClient.ConfigureDevice(constants.KubeSpanLinkName, cfg); err != nil {
+		return nil, fmt.Errorf("error configuring wireguard device: %w", err)
+	}
+
+	return upPeers, nil
+}
+
+type specs struct {
+	links     map[resource.ID]network.LinkSpecSpec
+	addresses map[resource.ID]network.AddressSpecSpec
+	routes    map[resource.ID]network.RouteSpecSpec
+	rules     map[resource.ID]network.RoutingRuleSpecSpec
+}
+
+func newSpecs() *specs {
+	return &specs{
+		links:     map[resource.ID]network.LinkSpecSpec{},
+		addresses: map[resource.ID]network.AddressSpecSpec{},
+		routes:    map[resource.ID]network.RouteSpecSpec{},
+		rules:     map[resource.ID]network.RoutingRuleSpecSpec{},
+	}
+}
+
+func (s *specs) build(identity *kubespan.IdentitySpec, peers map[string]kubespan.PeerSpecSpec, upPeers map[string]struct{}) {
+	s.links[constants.KubeSpanLinkName] = network.LinkSpecSpec{
+		Name:    constants.KubeSpanLinkName,
+		Logical: true,
+		Kind:    network.LinkKindWireguard,
+		Up:      true,
+		MTU:     constants.KubeSpanLinkMTU,
+	}
+
+	s.addresses[network.AddressID(constants.KubeSpanLinkName, identity.Address)] = network.AddressSpecSpec{
+		Address:  identity.Address,
+		LinkName: constants.KubeSpanLinkName,
+	}
+
+	for _, family := range []string{network.FamilyInet4, network.FamilyInet6} {
+		for _, rule := range []network.RoutingRuleSpecSpec{
+			// Wireguard encapsulated traffic goes via the regular network
+			{
+				Family:   family,
+				FwMark:   constants.KubeSpanDefaultFirewallMark,
+				Table:    unix.RT_TABLE_MAIN,
+				Priority: 32500,
+			},
+			// everything else looks up KubeSpan table first
+			{
+				Family:   family,
+				Table:    constants.KubeSpanDefaultRoutingTable,
+				Priority: 32501,
+			},
+		} {
+			s.rules[network.RoutingRuleID(rule)] = rule
+		}
+	}
+
+	for publicKey, peer := range peers {
+		if _, up := upPeers[publicKey]; !up {
+			continue
+		}
+
+		for _, allowedIP := range peer.AllowedIPs {
+			route := network.RouteSpecSpec{
+				Destination: allowedIP,
+				OutLinkName: constants.KubeSpanLinkName,
+				Table:       constants.KubeSpanDefaultRoutingTable,
+			}
+
+			s.routes[network.RouteID(route.Table, route.OutLinkName, route.Destination, route.Gateway)] = route
+		}
+	}
+}
+
+//nolint:gocyclo
+func (ctrl *ManagerController) apply(ctx context.Context, r controller.Runtime, s *specs) error {
+	for id, spec := range s.links {
+		spec := spec
+
+		if err := r.Modify(ctx, network.NewLinkSpec(id), func(r resource.Resource) error {
+			*r.(*network.LinkSpec).TypedSpec() = spec
+
+			return nil
+		}); err != nil {
+			return fmt.Errorf("error updating link spec: %w", err)
+		}
+	}
+
+	for id, spec := range s.addresses {
+		spec := spec
+
+		if err := r.Modify(ctx, network.NewAddressSpec(id), func(r resource.Resource) error {
+			*r.(*network.AddressSpec).TypedSpec() = spec
+
+			return nil
+		}); err != nil {
+			return fmt.Errorf("error updating address spec: %w", err)
+		}
+	}
+
+	for id, spec := range s.routes {
+		spec := spec
+
+		if err := r.Modify(ctx, network.NewRouteSpec(id), func(r resource.Resource) error {
+			*r.(*network.RouteSpec).TypedSpec() = spec
+
+			return nil
+		}); err != nil {
+			return fmt.Errorf("error updating route spec: %w", err)
+		}
+	}
+
+	for id, spec := range s.rules {
+		spec := spec
+
+		if err := r.Modify(ctx, network.NewRoutingRuleSpec(id), func(r resource.Resource) error {
+			*r.(*network.RoutingRuleSpec).TypedSpec() = spec
+
+			return nil
+		}); err != nil {
+			return fmt.Errorf("error updating routing rule spec: %w", err)
+		}
+	}
+
+	for _, touched := range []struct {
+		resourceType resource.Type
+		ids          func(resource.ID) bool
+	}{
+		{network.LinkSpecType, func(id resource.ID) bool { _, ok := s.links[id]; return ok }},
+		{network.AddressSpecType, func(id resource.ID) bool { _, ok := s.addresses[id]; return ok }},
+		{network.RouteSpecType, func(id resource.ID) bool { _, ok := s.routes[id]; return ok }},
+		{network.RoutingRuleSpecType, func(id resource.ID) bool { _, ok := s.rules[id]; return ok }},
+	} {
+		list, err := r.List(ctx, resource.NewMetadata(network.NamespaceName, touched.resourceType, "", resource.VersionUndefined))
+		if err != nil {
+			return fmt.Errorf("error listing specs: %w", err)
+		}
+
+		for _, res := range list.Items {
+			if res.Metadata().Owner() != ctrl.Name() || touched.ids(res.Metadata().ID()) {
+				continue
+			}
+
+			if err = r.Destroy(ctx, res.Metadata()); err != nil {
+				return fmt.Errorf("error cleaning up specs: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
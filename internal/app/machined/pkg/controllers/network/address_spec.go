@@ -0,0 +1,304 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package network
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"time"
+
+	"github.com/jsimonetti/rtnetlink/rtnl"
+	"github.com/mdlayher/netlink"
+	"github.com/talos-systems/os-runtime/pkg/controller"
+	"github.com/talos-systems/os-runtime/pkg/resource"
+	"golang.org/x/sys/unix"
+
+	"github.com/talos-systems/talos/pkg/resources/network"
+)
+
+// AddressSpecController applies AddressSpecs to the kernel network links.
+//
+// Addresses which are already assigned to the link when the spec shows up (e.g. by DHCP)
+// are adopted: the controller never removes them. Only addresses assigned by the controller are removed
+// when the spec goes away. Both sets are checked against the kernel state on every reconcile,
+// so the addresses removed externally are assigned (and owned) again.
+//
+// Addresses from the machine configuration are always owned, even if networkd has assigned them at boot,
+// so that removing an address from the configuration (which can be applied immediately) removes it from the link.
+type AddressSpecController struct {
+	// addresses assigned by the controller
+	owned map[resource.ID]network.AddressSpecSpec
+	// addresses found assigned to the links
+	adopted map[resource.ID]network.AddressSpecSpec
+}
+
+// Name implements controller.Controller interface.
+func (ctrl *AddressSpecController) Name() string {
+	return "network.AddressSpecController"
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *AddressSpecController) Inputs() []controller.Input {
+	return []controller.Input{
+		{
+			Namespace: network.NamespaceName,
+			Type:      network.AddressSpecType,
+			Kind:      controller.InputWeak,
+		},
+		{
+			Namespace: network.NamespaceName,
+			Type:      network.LinkSpecType,
+			Kind:      controller.InputWeak,
+		},
+	}
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *AddressSpecController) Outputs() []controller.Output {
+	return nil
+}
+
+// Run implements controller.Controller interface.
+func (ctrl *AddressSpecController) Run(ctx context.Context, r controller.Runtime, logger *log.Logger) error {
+	if ctrl.owned == nil {
+		ctrl.owned = map[resource.ID]network.AddressSpecSpec{}
+		ctrl.adopted = map[resource.ID]network.AddressSpecSpec{}
+	}
+
+	var retryCh <-chan time.Time
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+		case <-retryCh:
+		}
+
+		list, err := r.List(ctx, resource.NewMetadata(network.NamespaceName, network.AddressSpecType, "", resource.VersionUndefined))
+		if err != nil {
+			return fmt.Errorf("error listing address specs: %w", err)
+		}
+
+		specs := make(map[resource.ID]network.AddressSpecSpec, len(list.Items))
+		configured := map[resource.ID]struct{}{}
+
+		for _, res := range list.Items {
+			specs[res.Metadata().ID()] = *res.(*network.AddressSpec).TypedSpec()
+
+			if res.Metadata().Owner() == configControllerName {
+				configured[res.Metadata().ID()] = struct{}{}
+			}
+		}
+
+		conn, err := rtnl.Dial(nil)
+		if err != nil {
+			return fmt.Errorf("error dialing rtnetlink: %w", err)
+		}
+
+		pending := ctrl.reconcile(logger, &rtnlAddresses{conn: conn}, specs, configured)
+
+		//nolint:errcheck
+		conn.Close()
+
+		retryCh = nil
+
+		if pending {
+			retryCh = time.After(retryInterval)
+		}
+	}
+}
+
+// addressKernel abstracts the kernel address operations of the controller.
+type addressKernel interface {
+	// assigned checks whether the address is assigned to the link.
+	assigned(spec network.AddressSpecSpec) bool
+	// add assigns the address to the link, exists is set if the address was already assigned.
+	add(spec network.AddressSpecSpec) (exists bool, err error)
+	// remove removes the address from the link.
+	remove(spec network.AddressSpecSpec) error
+}
+
+// reconcile converges the kernel state to the specs, configured is the set of specs from the machine configuration.
+//
+//nolint:gocyclo,cyclop
+func (ctrl *AddressSpecController) reconcile(logger *log.Logger, kernel addressKernel, specs map[resource.ID]network.AddressSpecSpec, configured map[resource.ID]struct{}) (pending bool) {
+	// adopted addresses are forgotten without touching the kernel state,
+	// addresses from the machine configuration are owned below
+	for id, spec := range ctrl.adopted {
+		_, isConfigured := configured[id]
+
+		if newSpec, ok := specs[id]; ok && newSpec == spec && !isConfigured && kernel.assigned(spec) {
+			continue
+		}
+
+		delete(ctrl.adopted, id)
+	}
+
+	// remove addresses which are gone from the specs first, so that address moved to another link
+	// doesn't conflict with the old one
+	for id, spec := range ctrl.owned {
+		if newSpec, ok := specs[id]; ok && newSpec == spec {
+			if kernel.assigned(spec) {
+				continue
+			}
+
+			// address was removed externally, assign it again
+			delete(ctrl.owned, id)
+
+			continue
+		}
+
+		if err := kernel.remove(spec); err != nil {
+			logger.Printf("failed to remove address %q from %q: %s", spec.Address, spec.LinkName, err)
+
+			pending = true
+
+			continue
+		}
+
+		logger.Printf("removed address %q from %q", spec.Address, spec.LinkName)
+
+		delete(ctrl.owned, id)
+	}
+
+	for id, spec := range specs {
+		if _, ok := ctrl.owned[id]; ok {
+			continue
+		}
+
+		if _, ok := ctrl.adopted[id]; ok {
+			continue
+		}
+
+		_, isConfigured := configured[id]
+
+		exists := kernel.assigned(spec)
+
+		if !exists {
+			var err error
+
+			if exists, err = kernel.add(spec); err != nil {
+				logger.Printf("failed to assign address %q to %q: %s", spec.Address, spec.LinkName, err)
+
+				pending = true
+
+				continue
+			}
+		}
+
+		switch {
+		case !exists:
+			logger.Printf("assigned address %q to %q", spec.Address, spec.LinkName)
+
+			ctrl.owned[id] = spec
+		case isConfigured:
+			logger.Printf("address %q is already assigned to %q, taking ownership", spec.Address, spec.LinkName)
+
+			ctrl.owned[id] = spec
+		default:
+			logger.Printf("address %q is already assigned to %q", spec.Address, spec.LinkName)
+
+			ctrl.adopted[id] = spec
+		}
+	}
+
+	return pending
+}
+
+// rtnlAddresses implements addressKernel with rtnetlink.
+type rtnlAddresses struct {
+	conn *rtnl.Conn
+}
+
+func (k *rtnlAddresses) assigned(spec network.AddressSpecSpec) bool {
+	return addressAssigned(k.conn, spec)
+}
+
+func (k *rtnlAddresses) add(spec network.AddressSpecSpec) (bool, error) {
+	return addAddress(k.conn, spec)
+}
+
+func (k *rtnlAddresses) remove(spec network.AddressSpecSpec) error {
+	return deleteAddress(k.conn, spec)
+}
+
+func parseAddress(spec network.AddressSpecSpec) (*net.Interface, *net.IPNet, error) {
+	iface, err := net.InterfaceByName(spec.LinkName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ip, ipNet, err := net.ParseCIDR(spec.Address)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ipNet.IP = ip
+
+	return iface, ipNet, nil
+}
+
+// addressAssigned checks whether the address is assigned to the link in the kernel.
+func addressAssigned(conn *rtnl.Conn, spec network.AddressSpecSpec) bool {
+	iface, addr, err := parseAddress(spec)
+	if err != nil {
+		return false
+	}
+
+	addrs, err := conn.Addrs(iface, 0)
+	if err != nil {
+		return false
+	}
+
+	for _, assigned := range addrs {
+		if assigned.String() == addr.String() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// addAddress assigns the address to the link, exists is set if the address was already assigned.
+func addAddress(conn *rtnl.Conn, spec network.AddressSpecSpec) (exists bool, err error) {
+	iface, addr, err := parseAddress(spec)
+	if err != nil {
+		return false, err
+	}
+
+	err = conn.AddrAdd(iface, addr)
+
+	var opErr *netlink.OpError
+
+	// address might have been assigned by networkd since the check
+	if errors.As(err, &opErr) && os.IsExist(opErr.Err) {
+		return true, nil
+	}
+
+	return false, err
+}
+
+func deleteAddress(conn *rtnl.Conn, spec network.AddressSpecSpec) error {
+	iface, addr, err := parseAddress(spec)
+	if err != nil {
+		// link is gone along with the address
+		return nil //nolint:nilerr
+	}
+
+	err = conn.AddrDel(iface, addr)
+
+	var opErr *netlink.OpError
+
+	if errors.As(err, &opErr) && errors.Is(opErr.Err, unix.EADDRNOTAVAIL) {
+		return nil
+	}
+
+	return err
+}
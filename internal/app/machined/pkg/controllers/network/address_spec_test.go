@@ -0,0 +1,149 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package network
+
+import (
+	"errors"
+	"io/ioutil"
+	"log"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/talos-systems/os-runtime/pkg/resource"
+
+	"github.com/talos-systems/talos/pkg/resources/network"
+)
+
+type fakeAddressKernel struct {
+	addresses map[network.AddressSpecSpec]struct{}
+	failing   map[network.AddressSpecSpec]struct{}
+}
+
+func (k *fakeAddressKernel) assigned(spec network.AddressSpecSpec) bool {
+	_, ok := k.addresses[spec]
+
+	return ok
+}
+
+func (k *fakeAddressKernel) add(spec network.AddressSpecSpec) (bool, error) {
+	if _, ok := k.failing[spec]; ok {
+		return false, errors.New("link is down")
+	}
+
+	if k.assigned(spec) {
+		return true, nil
+	}
+
+	k.addresses[spec] = struct{}{}
+
+	return false, nil
+}
+
+func (k *fakeAddressKernel) remove(spec network.AddressSpecSpec) error {
+	delete(k.addresses, spec)
+
+	return nil
+}
+
+func TestAddressSpecReconcile(t *testing.T) {
+	logger := log.New(ioutil.Discard, "", 0)
+
+	static := network.AddressSpecSpec{Address: "172.20.0.2/24", LinkName: "eth0"}
+	dhcp := network.AddressSpecSpec{Address: "10.5.0.2/24", LinkName: "eth1"}
+	kubespan := network.AddressSpecSpec{Address: "fd00::1/128", LinkName: "kubespan"}
+
+	staticID := network.AddressID(static.LinkName, static.Address)
+	dhcpID := network.AddressID(dhcp.LinkName, dhcp.Address)
+	kubespanID := network.AddressID(kubespan.LinkName, kubespan.Address)
+
+	// static and DHCP addresses are assigned by networkd at boot
+	kernel := &fakeAddressKernel{
+		addresses: map[network.AddressSpecSpec]struct{}{
+			static: {},
+			dhcp:   {},
+		},
+		failing: map[network.AddressSpecSpec]struct{}{
+			kubespan: {},
+		},
+	}
+
+	ctrl := &AddressSpecController{
+		owned:   map[resource.ID]network.AddressSpecSpec{},
+		adopted: map[resource.ID]network.AddressSpecSpec{},
+	}
+
+	specs := map[resource.ID]network.AddressSpecSpec{
+		staticID:   static,
+		dhcpID:     dhcp,
+		kubespanID: kubespan,
+	}
+	configured := map[resource.ID]struct{}{
+		staticID: {},
+	}
+
+	assert.True(t, ctrl.reconcile(logger, kernel, specs, configured))
+
+	assert.Equal(t, map[resource.ID]network.AddressSpecSpec{staticID: static}, ctrl.owned)
+	assert.Equal(t, map[resource.ID]network.AddressSpecSpec{dhcpID: dhcp}, ctrl.adopted)
+
+	// link is up, the address is assigned on retry
+	delete(kernel.failing, kubespan)
+
+	assert.False(t, ctrl.reconcile(logger, kernel, specs, configured))
+
+	assert.Equal(t, map[resource.ID]network.AddressSpecSpec{staticID: static, kubespanID: kubespan}, ctrl.owned)
+	assert.True(t, kernel.assigned(kubespan))
+
+	// owned address removed externally is assigned again
+	assert.NoError(t, kernel.remove(kubespan))
+
+	assert.False(t, ctrl.reconcile(logger, kernel, specs, configured))
+
+	assert.True(t, kernel.assigned(kubespan))
+
+	// addresses removed from the configuration are removed from the links, adopted addresses are kept
+	assert.False(t, ctrl.reconcile(logger, kernel, map[resource.ID]network.AddressSpecSpec{}, map[resource.ID]struct{}{}))
+
+	assert.False(t, kernel.assigned(static))
+	assert.False(t, kernel.assigned(kubespan))
+	assert.True(t, kernel.assigned(dhcp))
+
+	assert.Empty(t, ctrl.owned)
+	assert.Empty(t, ctrl.adopted)
+}
+
+func TestAddressSpecReconcileConfiguredAdopted(t *testing.T) {
+	logger := log.New(ioutil.Discard, "", 0)
+
+	static := network.AddressSpecSpec{Address: "172.20.0.2/24", LinkName: "eth0"}
+	staticID := network.AddressID(static.LinkName, static.Address)
+
+	kernel := &fakeAddressKernel{
+		addresses: map[network.AddressSpecSpec]struct{}{
+			static: {},
+		},
+	}
+
+	ctrl := &AddressSpecController{
+		owned:   map[resource.ID]network.AddressSpecSpec{},
+		adopted: map[resource.ID]network.AddressSpecSpec{},
+	}
+
+	specs := map[resource.ID]network.AddressSpecSpec{
+		staticID: static,
+	}
+
+	// address was adopted before the machine configuration was loaded
+	assert.False(t, ctrl.reconcile(logger, kernel, specs, map[resource.ID]struct{}{}))
+	assert.Equal(t, map[resource.ID]network.AddressSpecSpec{staticID: static}, ctrl.adopted)
+
+	// once the spec comes from the machine configuration, the address is owned
+	assert.False(t, ctrl.reconcile(logger, kernel, specs, map[resource.ID]struct{}{staticID: {}}))
+	assert.Equal(t, map[resource.ID]network.AddressSpecSpec{staticID: static}, ctrl.owned)
+	assert.Empty(t, ctrl.adopted)
+
+	assert.False(t, ctrl.reconcile(logger, kernel, map[resource.ID]network.AddressSpecSpec{}, map[resource.ID]struct{}{}))
+	assert.False(t, kernel.assigned(static))
+}
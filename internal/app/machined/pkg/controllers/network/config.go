@@ -0,0 +1,517 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package network
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+
+	"github.com/AlekSi/pointer"
+	"github.com/talos-systems/os-runtime/pkg/controller"
+	"github.com/talos-systems/os-runtime/pkg/resource"
+	"github.com/talos-systems/os-runtime/pkg/state"
+
+	v1alpha1runtime "github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
+	talosconfig "github.com/talos-systems/talos/pkg/machinery/config"
+	"github.com/talos-systems/talos/pkg/resources/config"
+	"github.com/talos-systems/talos/pkg/resources/network"
+)
+
+// defaultRouteMetric matches the metric networkd uses for static routes.
+const defaultRouteMetric uint32 = 10
+
+// configControllerName is the name of the ConfigController, which owns the specs built from the machine configuration.
+const configControllerName = "network.ConfigController"
+
+// ConfigController builds network specs from the machine configuration.
+type ConfigController struct {
+	V1Alpha1Mode v1alpha1runtime.Mode
+}
+
+// Name implements controller.Controller interface.
+func (ctrl *ConfigController) Name() string {
+	return configControllerName
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *ConfigController) Inputs() []controller.Input {
+	return []controller.Input{
+		{
+			Namespace: config.NamespaceName,
+			Type:      config.MachineConfigType,
+			ID:        pointer.ToString(config.V1Alpha1ID),
+			Kind:      controller.InputWeak,
+		},
+		{
+			Namespace: network.NamespaceName,
+			Type:      network.DeviceSelectionType,
+			Kind:      controller.InputWeak,
+		},
+	}
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *ConfigController) Outputs() []controller.Output {
+	return []controller.Output{
+		{
+			Type: network.LinkSpecType,
+			Kind: controller.OutputShared,
+		},
+		{
+			Type: network.AddressSpecType,
+			Kind: controller.OutputShared,
+		},
+		{
+			Type: network.RouteSpecType,
+			Kind: controller.OutputShared,
+		},
+		{
+			Type: network.ResolverSpecType,
+			Kind: controller.OutputShared,
+		},
+		{
+			Type: network.RoutingRuleSpecType,
+			Kind: controller.OutputShared,
+		},
+	}
+}
+
+// Run implements controller.Controller interface.
+//
+//nolint:gocyclo,cyclop
+func (ctrl *ConfigController) Run(ctx context.Context, r controller.Runtime, logger *log.Logger) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+		}
+
+		cfg, err := r.Get(ctx, resource.NewMetadata(config.NamespaceName, config.MachineConfigType, config.V1Alpha1ID, resource.VersionUndefined))
+		if err != nil {
+			if !state.IsNotFoundError(err) {
+				return fmt.Errorf("error getting config: %w", err)
+			}
+		}
+
+		var specs Specs
+
+		// network in container mode is managed by the container runtime
+		if cfg != nil && ctrl.V1Alpha1Mode != v1alpha1runtime.ModeContainer {
+			var selections map[resource.ID]string
+
+			if selections, err = deviceSelections(ctx, r); err != nil {
+				return err
+			}
+
+			specs = BuildSpecs(logger, cfg.(*config.MachineConfig).Config(), selections)
+		}
+
+		touchedLinks := map[resource.ID]struct{}{}
+		touchedAddresses := map[resource.ID]struct{}{}
+		touchedRoutes := map[resource.ID]struct{}{}
+		touchedResolvers := map[resource.ID]struct{}{}
+		touchedRules := map[resource.ID]struct{}{}
+
+		for id, spec := range specs.Links {
+			spec := spec
+
+			if err = r.Modify(ctx, network.NewLinkSpec(id), func(r resource.Resource) error {
+				*r.(*network.LinkSpec).TypedSpec() = spec
+
+				return nil
+			}); err != nil {
+				return fmt.Errorf("error updating link spec: %w", err)
+			}
+
+			touchedLinks[id] = struct{}{}
+		}
+
+		for id, spec := range specs.Addresses {
+			spec := spec
+
+			if err = r.Modify(ctx, network.NewAddressSpec(id), func(r resource.Resource) error {
+				*r.(*network.AddressSpec).TypedSpec() = spec
+
+				return nil
+			}); err != nil {
+				return fmt.Errorf("error updating address spec: %w", err)
+			}
+
+			touchedAddresses[id] = struct{}{}
+		}
+
+		for id, spec := range specs.Routes {
+			spec := spec
+
+			if err = r.Modify(ctx, network.NewRouteSpec(id), func(r resource.Resource) error {
+				*r.(*network.RouteSpec).TypedSpec() = spec
+
+				return nil
+			}); err != nil {
+				return fmt.Errorf("error updating route spec: %w", err)
+			}
+
+			touchedRoutes[id] = struct{}{}
+		}
+
+		for id, spec := range specs.Rules {
+			spec := spec
+
+			if err = r.Modify(ctx, network.NewRoutingRuleSpec(id), func(r resource.Resource) error {
+				*r.(*network.RoutingRuleSpec).TypedSpec() = spec
+
+				return nil
+			}); err != nil {
+				return fmt.Errorf("error updating routing rule spec: %w", err)
+			}
+
+			touchedRules[id] = struct{}{}
+		}
+
+		if len(specs.Resolvers) > 0 || len(specs.SearchDomains) > 0 || specs.DisableSearchDomain {
+			if err = r.Modify(ctx, network.NewResolverSpec(network.ResolverID), func(r resource.Resource) error {
+				r.(*network.ResolverSpec).TypedSpec().DNSServers = append([]string(nil), specs.Resolvers...)
+				r.(*network.ResolverSpec).TypedSpec().SearchDomains = append([]string(nil), specs.SearchDomains...)
+				r.(*network.ResolverSpec).TypedSpec().DisableSearchDomain = specs.DisableSearchDomain
+
+				return nil
+			}); err != nil {
+				return fmt.Errorf("error updating resolver spec: %w", err)
+			}
+
+			touchedResolvers[network.ResolverID] = struct{}{}
+		}
+
+		// remove any specs which are no longer present in the config
+		for _, touched := range []struct {
+			resourceType resource.Type
+			ids          map[resource.ID]struct{}
+		}{
+			{network.LinkSpecType, touchedLinks},
+			{network.AddressSpecType, touchedAddresses},
+			{network.RouteSpecType, touchedRoutes},
+			{network.ResolverSpecType, touchedResolvers},
+			{network.RoutingRuleSpecType, touchedRules},
+		} {
+			list, err := r.List(ctx, resource.NewMetadata(network.NamespaceName, touched.resourceType, "", resource.VersionUndefined))
+			if err != nil {
+				return fmt.Errorf("error listing specs: %w", err)
+			}
+
+			for _, res := range list.Items {
+				if res.Metadata().Owner() != ctrl.Name() {
+					continue
+				}
+
+				if _, ok := touched.ids[res.Metadata().ID()]; ok {
+					continue
+				}
+
+				if err = r.Destroy(ctx, res.Metadata()); err != nil {
+					return fmt.Errorf("error cleaning up specs: %w", err)
+				}
+			}
+		}
+	}
+}
+
+// Specs is a set of network specs built from the machine configuration.
+type Specs struct {
+	Links     map[resource.ID]network.LinkSpecSpec
+	Addresses map[resource.ID]network.AddressSpecSpec
+	Routes    map[resource.ID]network.RouteSpecSpec
+	Rules     map[resource.ID]network.RoutingRuleSpecSpec
+	Resolvers []string
+
+	SearchDomains       []string
+	DisableSearchDomain bool
+}
+
+// BuildSpecs translates network section of the machine configuration into specs.
+//
+// Bond and Wireguard settings, DHCPv4 and VIPs are still handled by networkd, so
+// only the link state (MTU, up) is managed for bonds, and Wireguard links are skipped.
+// DHCPv6 is handled by the DHCP6Controller.
+// Devices picked by the device selectors are configured once the selector is resolved to the link name.
+//
+//nolint:gocyclo
+func BuildSpecs(logger *log.Logger, cfg talosconfig.Provider, selections map[resource.ID]string) Specs {
+	specs := Specs{
+		Links:     map[resource.ID]network.LinkSpecSpec{},
+		Addresses: map[resource.ID]network.AddressSpecSpec{},
+		Routes:    map[resource.ID]network.RouteSpecSpec{},
+		Rules:     map[resource.ID]network.RoutingRuleSpecSpec{},
+	}
+
+	if cfg == nil {
+		return specs
+	}
+
+	devices := SelectedDevices(cfg, selections)
+
+	for _, device := range devices {
+		if device.Ignore() || device.WireguardConfig() != nil {
+			continue
+		}
+
+		link := network.LinkSpecSpec{
+			Name: device.Interface(),
+			Up:   true,
+			MTU:  uint32(device.MTU()),
+		}
+
+		switch {
+		case device.Bridge() != nil:
+			link.Logical = true
+			link.Kind = network.LinkKindBridge
+			link.BridgeMaster = network.BridgeMasterSpec{
+				STPEnabled:    device.Bridge().STP().Enabled(),
+				VLANFiltering: device.Bridge().VLAN().FilteringEnabled(),
+			}
+		case device.Dummy():
+			link.Logical = true
+			link.Kind = network.LinkKindDummy
+		}
+
+		if device.DHCP() && device.DHCPOptions().SLAAC() {
+			link.SLAAC = true
+			link.SLAACIgnoreDefaultRoute = device.DHCPOptions().IgnoreDefaultRoutes()
+		}
+
+		if device.Ethernet() != nil {
+			link.Ethernet = ethernetSpec(device.Ethernet())
+		}
+
+		specs.Links[link.Name] = link
+
+		if device.CIDR() != "" {
+			specs.addAddress(logger, link.Name, device.CIDR(), device.Routes())
+		} else {
+			// routes of the links without static addressing are installed by networkd,
+			// except for the routes which networkd doesn't support
+			specs.addRoutes(logger, link.Name, policyRoutes(device.Routes()))
+		}
+
+		for _, vlan := range device.Vlans() {
+			vlanLink := network.LinkSpecSpec{
+				Name:       fmt.Sprintf("%s.%d", device.Interface(), vlan.ID()),
+				Logical:    true,
+				Kind:       network.LinkKindVLAN,
+				Up:         true,
+				ParentName: device.Interface(),
+				VLANID:     vlan.ID(),
+			}
+
+			specs.Links[vlanLink.Name] = vlanLink
+
+			for i, cidr := range vlan.Addresses() {
+				var routes []talosconfig.Route
+
+				if i == 0 {
+					routes = vlan.Routes()
+				}
+
+				specs.addAddress(logger, vlanLink.Name, cidr, routes)
+			}
+		}
+	}
+
+	// attach bridge ports once all the links are known
+	for _, device := range devices {
+		if device.Ignore() || device.Bridge() == nil {
+			continue
+		}
+
+		for _, member := range device.Bridge().Interfaces() {
+			link, ok := specs.Links[member]
+			if !ok {
+				link = network.LinkSpecSpec{
+					Name: member,
+					Up:   true,
+				}
+			}
+
+			link.MasterName = device.Interface()
+
+			specs.Links[member] = link
+		}
+	}
+
+	for _, rule := range cfg.Machine().Network().Rules() {
+		specs.addRule(logger, rule)
+	}
+
+	specs.Resolvers = append(specs.Resolvers, cfg.Machine().Network().Resolvers()...)
+	specs.SearchDomains = append(specs.SearchDomains, cfg.Machine().Network().SearchDomains()...)
+	specs.DisableSearchDomain = cfg.Machine().Network().DisableSearchDomain()
+
+	return specs
+}
+
+// offloadFeatures maps offload settings to ethtool feature names, as `ethtool -K` does.
+var offloadFeatures = []struct {
+	setting  func(talosconfig.EthernetConfig) *bool
+	features []string
+}{
+	{
+		setting:  talosconfig.EthernetConfig.TSO,
+		features: []string{"tx-tcp-segmentation", "tx-tcp-ecn-segmentation", "tx-tcp-mangleid-segmentation", "tx-tcp6-segmentation"},
+	},
+	{
+		setting:  talosconfig.EthernetConfig.GRO,
+		features: []string{"rx-gro"},
+	},
+	{
+		setting:  talosconfig.EthernetConfig.LRO,
+		features: []string{"rx-lro"},
+	},
+}
+
+func ethernetSpec(cfg talosconfig.EthernetConfig) network.EthernetSpec {
+	spec := network.EthernetSpec{
+		WakeOnLAN: append([]string(nil), cfg.WakeOnLAN()...),
+		RingsRX:   cfg.RingsRX(),
+		RingsTX:   cfg.RingsTX(),
+	}
+
+	for _, offload := range offloadFeatures {
+		enabled := offload.setting(cfg)
+		if enabled == nil {
+			continue
+		}
+
+		if spec.Features == nil {
+			spec.Features = map[string]bool{}
+		}
+
+		for _, feature := range offload.features {
+			spec.Features[feature] = *enabled
+		}
+	}
+
+	return spec
+}
+
+func (specs *Specs) addAddress(logger *log.Logger, linkName, cidr string, routes []talosconfig.Route) {
+	ip, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		logger.Printf("skipping invalid address %q on %q: %s", cidr, linkName, err)
+
+		return
+	}
+
+	ipNet.IP = ip
+
+	specs.Addresses[network.AddressID(linkName, ipNet.String())] = network.AddressSpecSpec{
+		Address:  ipNet.String(),
+		LinkName: linkName,
+	}
+
+	// static routes are installed along with the static address, same way networkd does it
+	specs.addRoutes(logger, linkName, routes)
+}
+
+func (specs *Specs) addRoutes(logger *log.Logger, linkName string, routes []talosconfig.Route) {
+	for _, route := range routes {
+		_, dst, err := net.ParseCIDR(route.Network())
+		if err != nil {
+			logger.Printf("skipping invalid route %q on %q: %s", route.Network(), linkName, err)
+
+			continue
+		}
+
+		var gateway string
+
+		if gw := net.ParseIP(route.Gateway()); gw != nil && !gw.IsUnspecified() {
+			gateway = gw.String()
+		}
+
+		metric := defaultRouteMetric
+		if route.Metric() != 0 {
+			metric = route.Metric()
+		}
+
+		var source string
+
+		if src := net.ParseIP(route.Source()); src != nil {
+			source = src.String()
+		}
+
+		specs.Routes[network.RouteID(route.Table(), linkName, dst.String(), gateway)] = network.RouteSpecSpec{
+			Destination: dst.String(),
+			Gateway:     gateway,
+			OutLinkName: linkName,
+			Metric:      metric,
+			Source:      source,
+			MTU:         route.MTU(),
+			Table:       route.Table(),
+		}
+	}
+}
+
+// policyRoutes filters the routes which use source, MTU or table settings.
+func policyRoutes(routes []talosconfig.Route) []talosconfig.Route {
+	var result []talosconfig.Route
+
+	for _, route := range routes {
+		if route.Source() != "" || route.MTU() != 0 || route.Table() != 0 {
+			result = append(result, route)
+		}
+	}
+
+	return result
+}
+
+func (specs *Specs) addRule(logger *log.Logger, rule talosconfig.RoutingRule) {
+	spec := network.RoutingRuleSpecSpec{
+		FwMark:   rule.FwMark(),
+		Table:    rule.Table(),
+		Priority: rule.Priority(),
+	}
+
+	var family string
+
+	for _, selector := range []struct {
+		cidr string
+		dst  *string
+	}{
+		{rule.From(), &spec.From},
+		{rule.To(), &spec.To},
+	} {
+		if selector.cidr == "" {
+			continue
+		}
+
+		_, ipNet, err := net.ParseCIDR(selector.cidr)
+		if err != nil {
+			logger.Printf("skipping invalid routing rule from %q to %q: %s", rule.From(), rule.To(), err)
+
+			return
+		}
+
+		*selector.dst = ipNet.String()
+
+		family = network.FamilyInet6
+		if ipNet.IP.To4() != nil {
+			family = network.FamilyInet4
+		}
+	}
+
+	families := []string{family}
+
+	// rules without addresses (e.g. matching only the fwmark) are installed for both address families
+	if family == "" {
+		families = []string{network.FamilyInet4, network.FamilyInet6}
+	}
+
+	for _, family := range families {
+		spec.Family = family
+
+		specs.Rules[network.RoutingRuleID(spec)] = spec
+	}
+}
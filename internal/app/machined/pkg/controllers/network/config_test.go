@@ -0,0 +1,343 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package network_test
+
+import (
+	"log"
+	"testing"
+
+	"github.com/AlekSi/pointer"
+	"github.com/stretchr/testify/assert"
+
+	netctrl "github.com/talos-systems/talos/internal/app/machined/pkg/controllers/network"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
+	"github.com/talos-systems/talos/pkg/resources/network"
+)
+
+func TestBuildSpecs(t *testing.T) {
+	cfg := &v1alpha1.Config{
+		ConfigVersion: "v1alpha1",
+		MachineConfig: &v1alpha1.MachineConfig{
+			MachineNetwork: &v1alpha1.NetworkConfig{
+				NameServers:          []string{"1.1.1.1", "8.8.8.8"},
+				NetworkSearchDomains: []string{"example.com"},
+				NetworkRules: []*v1alpha1.RoutingRule{
+					{
+						RuleFrom:     "192.168.0.0/24",
+						RuleTable:    100,
+						RulePriority: 1000,
+					},
+					{
+						RuleFwMark: 42,
+						RuleTable:  100,
+					},
+				},
+				NetworkInterfaces: []*v1alpha1.Device{
+					{
+						DeviceInterface: "eth0",
+						DeviceCIDR:      "192.168.0.10/24",
+						DeviceMTU:       9000,
+						DeviceEthernet: &v1alpha1.EthernetConfig{
+							EthernetWakeOnLAN: []string{"magic"},
+							EthernetOffload: &v1alpha1.EthernetOffloadConfig{
+								OffloadTSO: pointer.ToBool(false),
+								OffloadGRO: pointer.ToBool(true),
+							},
+							EthernetRings: &v1alpha1.EthernetRingsConfig{
+								RingsRX: 4096,
+							},
+						},
+						DeviceRoutes: []*v1alpha1.Route{
+							{
+								RouteNetwork: "0.0.0.0/0",
+								RouteGateway: "192.168.0.1",
+							},
+							{
+								RouteNetwork: "10.0.0.0/8",
+								RouteGateway: "0.0.0.0",
+								RouteMetric:  100,
+							},
+							{
+								RouteNetwork: "0.0.0.0/0",
+								RouteGateway: "192.168.0.2",
+								RouteSource:  "192.168.0.10",
+								RouteMTU:     1450,
+								RouteTable:   100,
+							},
+						},
+						DeviceVlans: []*v1alpha1.Vlan{
+							{
+								VlanID:        100,
+								VlanCIDR:      "172.16.0.10/16",
+								VlanAddresses: []string{"fd00:100::10/64"},
+								VlanRoutes: []*v1alpha1.Route{
+									{
+										RouteNetwork: "172.20.0.0/16",
+										RouteGateway: "172.16.0.1",
+									},
+								},
+							},
+						},
+					},
+					{
+						DeviceInterface: "br0",
+						DeviceBridge: &v1alpha1.Bridge{
+							BridgedInterfaces: []string{"eth1"},
+							BridgeSTP: &v1alpha1.STP{
+								STPEnabled: true,
+							},
+						},
+					},
+					{
+						DeviceInterface: "dummy0",
+						DeviceDummy:     true,
+					},
+					{
+						DeviceInterface: "eth2",
+						DeviceIgnore:    true,
+						DeviceCIDR:      "10.5.0.1/24",
+					},
+					{
+						DeviceInterface: "eth3",
+						DeviceDHCP:      true,
+						DeviceDHCPOptions: &v1alpha1.DHCPOptions{
+							DHCPIPv6:                pointer.ToBool(true),
+							DHCPSLAAC:               pointer.ToBool(true),
+							DHCPIgnoreDefaultRoutes: true,
+						},
+						DeviceRoutes: []*v1alpha1.Route{
+							{
+								RouteNetwork: "10.10.0.0/16",
+								RouteGateway: "10.0.3.1",
+							},
+							{
+								RouteNetwork: "0.0.0.0/0",
+								RouteGateway: "10.0.3.1",
+								RouteTable:   200,
+							},
+						},
+					},
+					{
+						DeviceInterface: "wg0",
+						DeviceCIDR:      "10.6.0.1/24",
+						DeviceWireguardConfig: &v1alpha1.DeviceWireguardConfig{
+							WireguardPrivateKey: "key",
+						},
+					},
+					{
+						DeviceSelector: &v1alpha1.NetworkDeviceSelector{
+							NetworkDeviceBusPath: "0000:01:00.0",
+						},
+						DeviceCIDR: "10.7.0.10/24",
+					},
+					{
+						DeviceSelector: &v1alpha1.NetworkDeviceSelector{
+							NetworkDeviceKernelDriver: "ixgbe",
+						},
+						DeviceCIDR: "10.8.0.10/24",
+					},
+				},
+			},
+		},
+	}
+
+	// the last device selector is not resolved yet
+	selections := map[string]string{
+		network.DeviceSelectionID(6): "enp1s0",
+	}
+
+	specs := netctrl.BuildSpecs(log.New(log.Writer(), "", log.Flags()), cfg, selections)
+
+	assert.Equal(t, map[string]network.LinkSpecSpec{
+		"eth0": {
+			Name: "eth0",
+			Up:   true,
+			MTU:  9000,
+			Ethernet: network.EthernetSpec{
+				WakeOnLAN: []string{"magic"},
+				Features: map[string]bool{
+					"tx-tcp-segmentation":          false,
+					"tx-tcp-ecn-segmentation":      false,
+					"tx-tcp-mangleid-segmentation": false,
+					"tx-tcp6-segmentation":         false,
+					"rx-gro":                       true,
+				},
+				RingsRX: 4096,
+			},
+		},
+		"eth0.100": {
+			Name:       "eth0.100",
+			Logical:    true,
+			Kind:       network.LinkKindVLAN,
+			Up:         true,
+			ParentName: "eth0",
+			VLANID:     100,
+		},
+		"br0": {
+			Name:    "br0",
+			Logical: true,
+			Kind:    network.LinkKindBridge,
+			Up:      true,
+			BridgeMaster: network.BridgeMasterSpec{
+				STPEnabled: true,
+			},
+		},
+		"eth1": {
+			Name:       "eth1",
+			Up:         true,
+			MasterName: "br0",
+		},
+		"dummy0": {
+			Name:    "dummy0",
+			Logical: true,
+			Kind:    network.LinkKindDummy,
+			Up:      true,
+		},
+		"eth3": {
+			Name:                    "eth3",
+			Up:                      true,
+			SLAAC:                   true,
+			SLAACIgnoreDefaultRoute: true,
+		},
+		"enp1s0": {
+			Name: "enp1s0",
+			Up:   true,
+		},
+	}, specs.Links)
+
+	assert.Equal(t, map[string]network.AddressSpecSpec{
+		"eth0/192.168.0.10/24": {
+			Address:  "192.168.0.10/24",
+			LinkName: "eth0",
+		},
+		"eth0.100/172.16.0.10/16": {
+			Address:  "172.16.0.10/16",
+			LinkName: "eth0.100",
+		},
+		"eth0.100/fd00:100::10/64": {
+			Address:  "fd00:100::10/64",
+			LinkName: "eth0.100",
+		},
+		"enp1s0/10.7.0.10/24": {
+			Address:  "10.7.0.10/24",
+			LinkName: "enp1s0",
+		},
+	}, specs.Addresses)
+
+	assert.Equal(t, map[string]network.RouteSpecSpec{
+		"eth0/0.0.0.0/0/192.168.0.1": {
+			Destination: "0.0.0.0/0",
+			Gateway:     "192.168.0.1",
+			OutLinkName: "eth0",
+			Metric:      10,
+		},
+		"eth0/10.0.0.0/8/": {
+			Destination: "10.0.0.0/8",
+			OutLinkName: "eth0",
+			Metric:      100,
+		},
+		"eth0/0.0.0.0/0/192.168.0.2/100": {
+			Destination: "0.0.0.0/0",
+			Gateway:     "192.168.0.2",
+			OutLinkName: "eth0",
+			Metric:      10,
+			Source:      "192.168.0.10",
+			MTU:         1450,
+			Table:       100,
+		},
+		"eth3/0.0.0.0/0/10.0.3.1/200": {
+			Destination: "0.0.0.0/0",
+			Gateway:     "10.0.3.1",
+			OutLinkName: "eth3",
+			Metric:      10,
+			Table:       200,
+		},
+		"eth0.100/172.20.0.0/16/172.16.0.1": {
+			Destination: "172.20.0.0/16",
+			Gateway:     "172.16.0.1",
+			OutLinkName: "eth0.100",
+			Metric:      10,
+		},
+	}, specs.Routes)
+
+	assert.Equal(t, map[string]network.RoutingRuleSpecSpec{
+		"inet4/1000/192.168.0.0/24//0/100": {
+			Family:   network.FamilyInet4,
+			From:     "192.168.0.0/24",
+			Table:    100,
+			Priority: 1000,
+		},
+		"inet4/0///42/100": {
+			Family: network.FamilyInet4,
+			FwMark: 42,
+			Table:  100,
+		},
+		"inet6/0///42/100": {
+			Family: network.FamilyInet6,
+			FwMark: 42,
+			Table:  100,
+		},
+	}, specs.Rules)
+
+	assert.Equal(t, []string{"1.1.1.1", "8.8.8.8"}, specs.Resolvers)
+	assert.Equal(t, []string{"example.com"}, specs.SearchDomains)
+	assert.False(t, specs.DisableSearchDomain)
+}
+
+func TestDHCP6Links(t *testing.T) {
+	cfg := &v1alpha1.Config{
+		ConfigVersion: "v1alpha1",
+		MachineConfig: &v1alpha1.MachineConfig{
+			MachineNetwork: &v1alpha1.NetworkConfig{
+				NetworkInterfaces: []*v1alpha1.Device{
+					{
+						DeviceInterface: "eth0",
+						DeviceDHCP:      true,
+					},
+					{
+						DeviceInterface: "eth1",
+						DeviceDHCP:      true,
+						DeviceDHCPOptions: &v1alpha1.DHCPOptions{
+							DHCPIPv6:  pointer.ToBool(true),
+							DHCPUIDv6: "00030001525400123456",
+						},
+					},
+					{
+						DeviceInterface: "eth2",
+						DeviceIgnore:    true,
+						DeviceDHCP:      true,
+						DeviceDHCPOptions: &v1alpha1.DHCPOptions{
+							DHCPIPv6: pointer.ToBool(true),
+						},
+					},
+					{
+						DeviceInterface: "eth3",
+						DeviceCIDR:      "fd00::10/64",
+						DeviceDHCPOptions: &v1alpha1.DHCPOptions{
+							DHCPIPv6: pointer.ToBool(true),
+						},
+					},
+					{
+						DeviceSelector: &v1alpha1.NetworkDeviceSelector{
+							NetworkDeviceHardwareAddress: "00:1a:2b:3c:f0:ab",
+						},
+						DeviceDHCP: true,
+						DeviceDHCPOptions: &v1alpha1.DHCPOptions{
+							DHCPIPv6: pointer.ToBool(true),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	links := netctrl.DHCP6Links(cfg, map[string]string{
+		network.DeviceSelectionID(4): "enp2s0",
+	})
+
+	assert.Len(t, links, 2)
+	assert.Equal(t, "00030001525400123456", links["eth1"].DUIDv6())
+	assert.Contains(t, links, "enp2s0")
+}
@@ -0,0 +1,191 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package network
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/talos-systems/os-runtime/pkg/controller"
+	"github.com/talos-systems/os-runtime/pkg/resource"
+	"github.com/talos-systems/os-runtime/pkg/state"
+
+	v1alpha1runtime "github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
+	"github.com/talos-systems/talos/internal/pkg/linkselector"
+	talosconfig "github.com/talos-systems/talos/pkg/machinery/config"
+	"github.com/talos-systems/talos/pkg/resources/config"
+	"github.com/talos-systems/talos/pkg/resources/network"
+)
+
+// DeviceSelectorController resolves network device selectors from the machine configuration to the link names.
+type DeviceSelectorController struct {
+	V1Alpha1Mode v1alpha1runtime.Mode
+}
+
+// Name implements controller.Controller interface.
+func (ctrl *DeviceSelectorController) Name() string {
+	return "network.DeviceSelectorController"
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *DeviceSelectorController) Inputs() []controller.Input {
+	return []controller.Input{
+		{
+			Namespace: config.NamespaceName,
+			Type:      config.MachineConfigType,
+			ID:        pointer.ToString(config.V1Alpha1ID),
+			Kind:      controller.InputWeak,
+		},
+	}
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *DeviceSelectorController) Outputs() []controller.Output {
+	return []controller.Output{
+		{
+			Type: network.DeviceSelectionType,
+			Kind: controller.OutputExclusive,
+		},
+	}
+}
+
+// Run implements controller.Controller interface.
+//
+//nolint:gocyclo
+func (ctrl *DeviceSelectorController) Run(ctx context.Context, r controller.Runtime, logger *log.Logger) error {
+	var retryCh <-chan time.Time
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+		case <-retryCh:
+		}
+
+		cfg, err := r.Get(ctx, resource.NewMetadata(config.NamespaceName, config.MachineConfigType, config.V1Alpha1ID, resource.VersionUndefined))
+		if err != nil {
+			if !state.IsNotFoundError(err) {
+				return fmt.Errorf("error getting config: %w", err)
+			}
+		}
+
+		var devices []talosconfig.Device
+
+		// network in container mode is managed by the container runtime
+		if cfg != nil && ctrl.V1Alpha1Mode != v1alpha1runtime.ModeContainer {
+			devices = cfg.(*config.MachineConfig).Config().Machine().Network().Devices()
+		}
+
+		var links []linkselector.Link
+
+		for _, device := range devices {
+			if device.Selector() != nil {
+				if links, err = linkselector.Discover(); err != nil {
+					return err
+				}
+
+				break
+			}
+		}
+
+		touchedIDs := map[resource.ID]struct{}{}
+
+		// links might show up later (e.g. driver is loaded late, or link speed is not known until the link is up),
+		// so selectors are retried until all of them are resolved
+		pending := false
+
+		for idx, device := range devices {
+			if device.Selector() == nil || device.Ignore() {
+				continue
+			}
+
+			linkName, err := linkselector.Resolve(device.Selector(), links)
+			if err != nil {
+				logger.Printf("failed to resolve device selector for interface %d: %s", idx, err)
+
+				pending = true
+
+				continue
+			}
+
+			id := network.DeviceSelectionID(idx)
+
+			if err = r.Modify(ctx, network.NewDeviceSelection(id), func(r resource.Resource) error {
+				r.(*network.DeviceSelection).TypedSpec().LinkName = linkName
+
+				return nil
+			}); err != nil {
+				return fmt.Errorf("error updating device selection: %w", err)
+			}
+
+			touchedIDs[id] = struct{}{}
+		}
+
+		list, err := r.List(ctx, resource.NewMetadata(network.NamespaceName, network.DeviceSelectionType, "", resource.VersionUndefined))
+		if err != nil {
+			return fmt.Errorf("error listing device selections: %w", err)
+		}
+
+		for _, res := range list.Items {
+			if _, ok := touchedIDs[res.Metadata().ID()]; ok {
+				continue
+			}
+
+			if err = r.Destroy(ctx, res.Metadata()); err != nil {
+				return fmt.Errorf("error cleaning up device selections: %w", err)
+			}
+		}
+
+		retryCh = nil
+
+		if pending {
+			retryCh = time.After(retryInterval)
+		}
+	}
+}
+
+// deviceSelections returns the link names picked by the device selectors, indexed by the DeviceSelection ID.
+func deviceSelections(ctx context.Context, r controller.Runtime) (map[resource.ID]string, error) {
+	list, err := r.List(ctx, resource.NewMetadata(network.NamespaceName, network.DeviceSelectionType, "", resource.VersionUndefined))
+	if err != nil {
+		return nil, fmt.Errorf("error listing device selections: %w", err)
+	}
+
+	selections := make(map[resource.ID]string, len(list.Items))
+
+	for _, res := range list.Items {
+		selections[res.Metadata().ID()] = res.(*network.DeviceSelection).TypedSpec().LinkName
+	}
+
+	return selections, nil
+}
+
+// SelectedDevices returns the network devices from the machine configuration with device selectors
+// replaced with the link names picked by the DeviceSelectorController.
+//
+// Devices with the selectors which are not resolved yet are skipped.
+func SelectedDevices(cfg talosconfig.Provider, selections map[resource.ID]string) []talosconfig.Device {
+	devices := cfg.Machine().Network().Devices()
+	selected := make([]talosconfig.Device, 0, len(devices))
+
+	for idx, device := range devices {
+		if device.Selector() != nil {
+			linkName, ok := selections[network.DeviceSelectionID(idx)]
+			if !ok {
+				continue
+			}
+
+			device = linkselector.WithLinkName(device, linkName)
+		}
+
+		selected = append(selected, device)
+	}
+
+	return selected
+}
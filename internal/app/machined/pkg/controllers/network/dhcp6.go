@@ -0,0 +1,401 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package network
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"net"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/insomniacslk/dhcp/dhcpv6"
+	"github.com/insomniacslk/dhcp/dhcpv6/nclient6"
+	"github.com/jsimonetti/rtnetlink"
+	"github.com/talos-systems/os-runtime/pkg/controller"
+	"github.com/talos-systems/os-runtime/pkg/resource"
+	"github.com/talos-systems/os-runtime/pkg/state"
+	"golang.org/x/sys/unix"
+
+	v1alpha1runtime "github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
+	talosconfig "github.com/talos-systems/talos/pkg/machinery/config"
+	"github.com/talos-systems/talos/pkg/resources/config"
+	"github.com/talos-systems/talos/pkg/resources/network"
+)
+
+const (
+	// dhcp6RequestTimeout limits a single DHCPv6 exchange (solicit + request).
+	dhcp6RequestTimeout = 30 * time.Second
+
+	// dhcp6DefaultRenewInterval is used if the server doesn't provide T1 and address lifetimes.
+	dhcp6DefaultRenewInterval = time.Hour
+)
+
+// DHCP6Controller acquires IPv6 addresses via DHCPv6 on the links which have DHCPv6 enabled.
+//
+// Acquired addresses are published as AddressSpecs, so that AddressSpecController assigns them to the links.
+type DHCP6Controller struct {
+	V1Alpha1Mode v1alpha1runtime.Mode
+
+	leases map[string]*dhcp6Lease
+}
+
+type dhcp6Lease struct {
+	addresses     []string
+	dnsServers    []string
+	searchDomains []string
+
+	renewAt   time.Time
+	expiresAt time.Time
+}
+
+// Name implements controller.Controller interface.
+func (ctrl *DHCP6Controller) Name() string {
+	return "network.DHCP6Controller"
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *DHCP6Controller) Inputs() []controller.Input {
+	return []controller.Input{
+		{
+			Namespace: config.NamespaceName,
+			Type:      config.MachineConfigType,
+			ID:        pointer.ToString(config.V1Alpha1ID),
+			Kind:      controller.InputWeak,
+		},
+		{
+			Namespace: network.NamespaceName,
+			Type:      network.DeviceSelectionType,
+			Kind:      controller.InputWeak,
+		},
+	}
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *DHCP6Controller) Outputs() []controller.Output {
+	return []controller.Output{
+		{
+			Type: network.AddressSpecType,
+			Kind: controller.OutputShared,
+		},
+		{
+			Type: network.ResolverSpecType,
+			Kind: controller.OutputShared,
+		},
+	}
+}
+
+// Run implements controller.Controller interface.
+//
+//nolint:gocyclo,cyclop
+func (ctrl *DHCP6Controller) Run(ctx context.Context, r controller.Runtime, logger *log.Logger) error {
+	if ctrl.leases == nil {
+		ctrl.leases = map[string]*dhcp6Lease{}
+	}
+
+	var renewCh <-chan time.Time
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+		case <-renewCh:
+		}
+
+		cfg, err := r.Get(ctx, resource.NewMetadata(config.NamespaceName, config.MachineConfigType, config.V1Alpha1ID, resource.VersionUndefined))
+		if err != nil {
+			if !state.IsNotFoundError(err) {
+				return fmt.Errorf("error getting config: %w", err)
+			}
+		}
+
+		var links map[string]talosconfig.DHCPOptions
+
+		// network in container mode is managed by the container runtime
+		if cfg != nil && ctrl.V1Alpha1Mode != v1alpha1runtime.ModeContainer {
+			var selections map[resource.ID]string
+
+			if selections, err = deviceSelections(ctx, r); err != nil {
+				return err
+			}
+
+			links = DHCP6Links(cfg.(*config.MachineConfig).Config(), selections)
+		}
+
+		for linkName := range ctrl.leases {
+			if _, ok := links[linkName]; !ok {
+				delete(ctrl.leases, linkName)
+			}
+		}
+
+		now := time.Now()
+
+		var nextRenew time.Time
+
+		for linkName, opts := range links {
+			lease := ctrl.leases[linkName]
+
+			if lease == nil || !now.Before(lease.renewAt) {
+				var newLease *dhcp6Lease
+
+				newLease, err = requestDHCP6Lease(ctx, logger, linkName, opts)
+				if err != nil {
+					logger.Printf("DHCPv6 request on %q failed: %s", linkName, err)
+
+					// keep the previous lease until it expires
+					if lease == nil || !now.Before(lease.expiresAt) {
+						lease = &dhcp6Lease{}
+					}
+
+					lease.renewAt = now.Add(retryInterval)
+				} else {
+					lease = newLease
+
+					logger.Printf("DHCPv6 lease on %q: addresses %q, renew in %s", linkName, lease.addresses, lease.renewAt.Sub(now).Round(time.Second))
+				}
+
+				ctrl.leases[linkName] = lease
+			}
+
+			if nextRenew.IsZero() || lease.renewAt.Before(nextRenew) {
+				nextRenew = lease.renewAt
+			}
+		}
+
+		renewCh = nil
+
+		if !nextRenew.IsZero() {
+			renewCh = time.After(time.Until(nextRenew))
+		}
+
+		if err = ctrl.updateSpecs(ctx, r, links); err != nil {
+			return err
+		}
+	}
+}
+
+func (ctrl *DHCP6Controller) updateSpecs(ctx context.Context, r controller.Runtime, links map[string]talosconfig.DHCPOptions) error {
+	touchedAddresses := map[resource.ID]struct{}{}
+	touchedResolvers := map[resource.ID]struct{}{}
+
+	for linkName, lease := range ctrl.leases {
+		linkName, lease := linkName, lease
+
+		for _, address := range lease.addresses {
+			address := address
+			id := network.AddressID(linkName, address)
+
+			if err := r.Modify(ctx, network.NewAddressSpec(id), func(r resource.Resource) error {
+				*r.(*network.AddressSpec).TypedSpec() = network.AddressSpecSpec{
+					Address:  address,
+					LinkName: linkName,
+				}
+
+				return nil
+			}); err != nil {
+				return fmt.Errorf("error updating address spec: %w", err)
+			}
+
+			touchedAddresses[id] = struct{}{}
+		}
+
+		// resolvers from DHCPv4 are handled by networkd, so DHCPv6 resolvers are used only on IPv6-only links
+		if len(lease.dnsServers) == 0 || links[linkName].IgnoreDNS() || links[linkName].IPv4() {
+			continue
+		}
+
+		id := network.DHCP6ResolverID(linkName)
+
+		if err := r.Modify(ctx, network.NewResolverSpec(id), func(r resource.Resource) error {
+			r.(*network.ResolverSpec).TypedSpec().DNSServers = append([]string(nil), lease.dnsServers...)
+			r.(*network.ResolverSpec).TypedSpec().SearchDomains = append([]string(nil), lease.searchDomains...)
+
+			return nil
+		}); err != nil {
+			return fmt.Errorf("error updating resolver spec: %w", err)
+		}
+
+		touchedResolvers[id] = struct{}{}
+	}
+
+	for _, touched := range []struct {
+		resourceType resource.Type
+		ids          map[resource.ID]struct{}
+	}{
+		{network.AddressSpecType, touchedAddresses},
+		{network.ResolverSpecType, touchedResolvers},
+	} {
+		list, err := r.List(ctx, resource.NewMetadata(network.NamespaceName, touched.resourceType, "", resource.VersionUndefined))
+		if err != nil {
+			return fmt.Errorf("error listing specs: %w", err)
+		}
+
+		for _, res := range list.Items {
+			if res.Metadata().Owner() != ctrl.Name() {
+				continue
+			}
+
+			if _, ok := touched.ids[res.Metadata().ID()]; ok {
+				continue
+			}
+
+			if err = r.Destroy(ctx, res.Metadata()); err != nil {
+				return fmt.Errorf("error cleaning up specs: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// DHCP6Links returns DHCP options for the links which have DHCPv6 enabled in the machine configuration.
+func DHCP6Links(cfg talosconfig.Provider, selections map[resource.ID]string) map[string]talosconfig.DHCPOptions {
+	links := map[string]talosconfig.DHCPOptions{}
+
+	if cfg == nil {
+		return links
+	}
+
+	for _, device := range SelectedDevices(cfg, selections) {
+		if device.Ignore() || !device.DHCP() || !device.DHCPOptions().IPv6() {
+			continue
+		}
+
+		links[device.Interface()] = device.DHCPOptions()
+	}
+
+	return links
+}
+
+func requestDHCP6Lease(ctx context.Context, logger *log.Logger, linkName string, opts talosconfig.DHCPOptions) (*dhcp6Lease, error) {
+	ready, err := isIPv6LinkReady(linkName)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ready {
+		return nil, fmt.Errorf("link-local address is not ready yet")
+	}
+
+	var modifiers []dhcpv6.Modifier
+
+	if opts.DUIDv6() != "" {
+		b, err := hex.DecodeString(opts.DUIDv6())
+		if err != nil {
+			return nil, fmt.Errorf("error decoding DUID: %w", err)
+		}
+
+		duid, err := dhcpv6.DuidFromBytes(b)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing DUID: %w", err)
+		}
+
+		modifiers = append(modifiers, dhcpv6.WithClientID(*duid))
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, dhcp6RequestTimeout)
+	defer cancel()
+
+	client, err := nclient6.New(linkName)
+	if err != nil {
+		return nil, fmt.Errorf("error creating DHCPv6 client: %w", err)
+	}
+
+	//nolint:errcheck
+	defer client.Close()
+
+	advertise, err := client.Solicit(ctx, modifiers...)
+	if err != nil {
+		return nil, fmt.Errorf("solicit failed: %w", err)
+	}
+
+	reply, err := client.Request(ctx, advertise, modifiers...)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+
+	logger.Printf("DHCPv6 REPLY on %q: %s", linkName, reply.Summary())
+
+	return leaseFromReply(reply, time.Now())
+}
+
+func leaseFromReply(reply *dhcpv6.Message, now time.Time) (*dhcp6Lease, error) {
+	iana := reply.Options.OneIANA()
+	if iana == nil {
+		return nil, fmt.Errorf("no IA_NA in the reply")
+	}
+
+	addr := iana.Options.OneAddress()
+	if addr == nil {
+		return nil, fmt.Errorf("no address in the IA_NA")
+	}
+
+	lease := &dhcp6Lease{
+		addresses: []string{(&net.IPNet{IP: addr.IPv6Addr, Mask: net.CIDRMask(128, 128)}).String()},
+		expiresAt: now.Add(addr.ValidLifetime),
+	}
+
+	for _, server := range reply.Options.DNS() {
+		lease.dnsServers = append(lease.dnsServers, server.String())
+	}
+
+	if searchList := reply.Options.DomainSearchList(); searchList != nil {
+		lease.searchDomains = append(lease.searchDomains, searchList.Labels...)
+	}
+
+	// RFC 8415: T1 is the time at which the client contacts the server to extend the lifetimes,
+	// if T1 is not set, client picks it on its own
+	renewInterval := iana.T1
+
+	if renewInterval == 0 {
+		renewInterval = addr.PreferredLifetime / 2
+	}
+
+	if renewInterval == 0 {
+		renewInterval = dhcp6DefaultRenewInterval
+	}
+
+	lease.renewAt = now.Add(renewInterval)
+
+	return lease, nil
+}
+
+// isIPv6LinkReady returns true if the link has a link-local address which is not tentative.
+//
+// DHCPv6 client can't bind to the link-local address while the address is tentative.
+func isIPv6LinkReady(linkName string) (bool, error) {
+	iface, err := net.InterfaceByName(linkName)
+	if err != nil {
+		return false, err
+	}
+
+	conn, err := rtnetlink.Dial(nil)
+	if err != nil {
+		return false, fmt.Errorf("error dialing rtnetlink: %w", err)
+	}
+
+	//nolint:errcheck
+	defer conn.Close()
+
+	addrs, err := conn.Address.List()
+	if err != nil {
+		return false, err
+	}
+
+	for _, addr := range addrs {
+		if addr.Index != uint32(iface.Index) || addr.Family != unix.AF_INET6 {
+			continue
+		}
+
+		if addr.Attributes.Address.IsLinkLocalUnicast() && addr.Flags&unix.IFA_F_TENTATIVE == 0 {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,169 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package network
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/mdlayher/genetlink"
+	"github.com/mdlayher/netlink"
+
+	"github.com/talos-systems/talos/pkg/resources/network"
+)
+
+// ethtool generic netlink family constants (include/uapi/linux/ethtool_netlink.h).
+const (
+	ethtoolGenlName = "ethtool"
+
+	ethtoolMsgWolSet      = 10
+	ethtoolMsgFeaturesSet = 12
+	ethtoolMsgRingsSet    = 16
+
+	ethtoolAHeaderDevName = 2
+
+	ethtoolAWolHeader = 1
+	ethtoolAWolModes  = 2
+
+	ethtoolAFeaturesHeader = 1
+	ethtoolAFeaturesWanted = 3
+
+	ethtoolARingsHeader = 1
+	ethtoolARingsRX     = 6
+	ethtoolARingsTX     = 9
+
+	ethtoolABitsetNoMask = 1
+	ethtoolABitsetBits   = 3
+
+	ethtoolABitsetBitsBit = 1
+
+	ethtoolABitsetBitName  = 2
+	ethtoolABitsetBitValue = 3
+)
+
+// configureEthernet applies ethernet hardware settings via ethtool netlink interface.
+func configureEthernet(spec network.LinkSpecSpec) error {
+	if spec.Ethernet.IsZero() {
+		return nil
+	}
+
+	conn, err := genetlink.Dial(nil)
+	if err != nil {
+		return fmt.Errorf("error dialing genetlink: %w", err)
+	}
+
+	//nolint:errcheck
+	defer conn.Close()
+
+	family, err := conn.GetFamily(ethtoolGenlName)
+	if err != nil {
+		return fmt.Errorf("error getting ethtool genetlink family: %w", err)
+	}
+
+	if len(spec.Ethernet.WakeOnLAN) > 0 {
+		// Wake-on-LAN modes are replaced as a whole (no mask), so that the modes which are not listed are disabled
+		modes := map[string]bool{}
+
+		for _, mode := range spec.Ethernet.WakeOnLAN {
+			if mode != network.WakeOnLANDisabled {
+				modes[mode] = true
+			}
+		}
+
+		if err = ethtoolSet(conn, family, ethtoolMsgWolSet, spec.Name, ethtoolAWolHeader, func(encoder *netlink.AttributeEncoder) {
+			encodeBitset(encoder, ethtoolAWolModes, modes, true)
+		}); err != nil {
+			return fmt.Errorf("error setting Wake-on-LAN: %w", err)
+		}
+	}
+
+	if len(spec.Ethernet.Features) > 0 {
+		if err = ethtoolSet(conn, family, ethtoolMsgFeaturesSet, spec.Name, ethtoolAFeaturesHeader, func(encoder *netlink.AttributeEncoder) {
+			encodeBitset(encoder, ethtoolAFeaturesWanted, spec.Ethernet.Features, false)
+		}); err != nil {
+			return fmt.Errorf("error setting features: %w", err)
+		}
+	}
+
+	if spec.Ethernet.RingsRX != 0 || spec.Ethernet.RingsTX != 0 {
+		if err = ethtoolSet(conn, family, ethtoolMsgRingsSet, spec.Name, ethtoolARingsHeader, func(encoder *netlink.AttributeEncoder) {
+			if spec.Ethernet.RingsRX != 0 {
+				encoder.Uint32(ethtoolARingsRX, spec.Ethernet.RingsRX)
+			}
+
+			if spec.Ethernet.RingsTX != 0 {
+				encoder.Uint32(ethtoolARingsTX, spec.Ethernet.RingsTX)
+			}
+		}); err != nil {
+			return fmt.Errorf("error setting ring sizes: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// ethtoolSet sends ethtool *_SET request for the link, kernel skips the update if nothing changes.
+func ethtoolSet(conn *genetlink.Conn, family genetlink.Family, command uint8, linkName string, headerAttr uint16, encode func(*netlink.AttributeEncoder)) error {
+	encoder := netlink.NewAttributeEncoder()
+
+	encoder.Nested(headerAttr, func(nae *netlink.AttributeEncoder) error {
+		nae.String(ethtoolAHeaderDevName, linkName)
+
+		return nil
+	})
+
+	encode(encoder)
+
+	data, err := encoder.Encode()
+	if err != nil {
+		return err
+	}
+
+	_, err = conn.Execute(genetlink.Message{
+		Header: genetlink.Header{
+			Command: command,
+			Version: family.Version,
+		},
+		Data: data,
+	}, family.ID, netlink.Request|netlink.Acknowledge)
+
+	return err
+}
+
+// encodeBitset encodes verbose ethtool bitset with the bits referenced by name.
+//
+// Without the mask (noMask = false) only the listed bits are changed.
+func encodeBitset(encoder *netlink.AttributeEncoder, attr uint16, bits map[string]bool, noMask bool) {
+	names := make([]string, 0, len(bits))
+
+	for name := range bits {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	encoder.Nested(attr, func(bitset *netlink.AttributeEncoder) error {
+		if noMask {
+			bitset.Flag(ethtoolABitsetNoMask, true)
+		}
+
+		bitset.Nested(ethtoolABitsetBits, func(bitsEncoder *netlink.AttributeEncoder) error {
+			for _, name := range names {
+				name := name
+
+				bitsEncoder.Nested(ethtoolABitsetBitsBit, func(bit *netlink.AttributeEncoder) error {
+					bit.String(ethtoolABitsetBitName, name)
+					bit.Flag(ethtoolABitsetBitValue, bits[name])
+
+					return nil
+				})
+			}
+
+			return nil
+		})
+
+		return nil
+	})
+}
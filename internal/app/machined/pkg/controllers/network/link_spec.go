@@ -0,0 +1,367 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package network
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/jsimonetti/rtnetlink"
+	"github.com/mdlayher/netlink"
+	"github.com/talos-systems/os-runtime/pkg/controller"
+	"github.com/talos-systems/os-runtime/pkg/resource"
+	"golang.org/x/sys/unix"
+
+	"github.com/talos-systems/talos/pkg/resources/network"
+)
+
+// LinkSpecController applies LinkSpecs to the kernel network links.
+type LinkSpecController struct {
+	// logical links created by the controller, removed when the spec goes away
+	created map[string]struct{}
+}
+
+// Name implements controller.Controller interface.
+func (ctrl *LinkSpecController) Name() string {
+	return "network.LinkSpecController"
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *LinkSpecController) Inputs() []controller.Input {
+	return []controller.Input{
+		{
+			Namespace: network.NamespaceName,
+			Type:      network.LinkSpecType,
+			Kind:      controller.InputWeak,
+		},
+	}
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *LinkSpecController) Outputs() []controller.Output {
+	return nil
+}
+
+// Run implements controller.Controller interface.
+func (ctrl *LinkSpecController) Run(ctx context.Context, r controller.Runtime, logger *log.Logger) error {
+	if ctrl.created == nil {
+		ctrl.created = map[string]struct{}{}
+	}
+
+	var retryCh <-chan time.Time
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+		case <-retryCh:
+		}
+
+		list, err := r.List(ctx, resource.NewMetadata(network.NamespaceName, network.LinkSpecType, "", resource.VersionUndefined))
+		if err != nil {
+			return fmt.Errorf("error listing link specs: %w", err)
+		}
+
+		specs := make([]network.LinkSpecSpec, 0, len(list.Items))
+
+		for _, res := range list.Items {
+			specs = append(specs, *res.(*network.LinkSpec).TypedSpec())
+		}
+
+		pending, err := ctrl.reconcile(logger, specs)
+		if err != nil {
+			return err
+		}
+
+		retryCh = nil
+
+		if pending {
+			retryCh = time.After(retryInterval)
+		}
+	}
+}
+
+//nolint:gocyclo,cyclop
+func (ctrl *LinkSpecController) reconcile(logger *log.Logger, specs []network.LinkSpecSpec) (pending bool, err error) {
+	conn, err := rtnetlink.Dial(nil)
+	if err != nil {
+		return false, fmt.Errorf("error dialing rtnetlink: %w", err)
+	}
+
+	//nolint:errcheck
+	defer conn.Close()
+
+	// logical links should be created before the links which refer to them,
+	// VLANs go last as their parent might be a logical link as well
+	sort.SliceStable(specs, func(i, j int) bool {
+		return linkOrder(specs[i]) < linkOrder(specs[j])
+	})
+
+	expected := map[string]struct{}{}
+
+	for _, spec := range specs {
+		expected[spec.Name] = struct{}{}
+
+		if !spec.Logical {
+			continue
+		}
+
+		if _, err = net.InterfaceByName(spec.Name); err == nil {
+			continue
+		}
+
+		if err = createLink(conn, spec); err != nil {
+			logger.Printf("failed to create link %q: %s", spec.Name, err)
+
+			pending = true
+
+			continue
+		}
+
+		logger.Printf("created link %q (%s)", spec.Name, spec.Kind)
+
+		ctrl.created[spec.Name] = struct{}{}
+	}
+
+	for _, spec := range specs {
+		if err = configureLink(conn, spec); err != nil {
+			logger.Printf("failed to configure link %q: %s", spec.Name, err)
+
+			pending = true
+		}
+	}
+
+	// remove logical links which were created by the controller, but are no longer in the specs
+	for name := range ctrl.created {
+		if _, ok := expected[name]; ok {
+			continue
+		}
+
+		iface, err := net.InterfaceByName(name)
+		if err != nil {
+			delete(ctrl.created, name)
+
+			continue
+		}
+
+		if err = conn.Link.Delete(uint32(iface.Index)); err != nil {
+			logger.Printf("failed to remove link %q: %s", name, err)
+
+			pending = true
+
+			continue
+		}
+
+		logger.Printf("removed link %q", name)
+
+		delete(ctrl.created, name)
+	}
+
+	return pending, nil
+}
+
+func linkOrder(spec network.LinkSpecSpec) int {
+	switch {
+	case spec.Logical && spec.Kind != network.LinkKindVLAN:
+		return 0
+	case spec.Logical:
+		return 1
+	default:
+		return 2
+	}
+}
+
+func createLink(conn *rtnetlink.Conn, spec network.LinkSpecSpec) error {
+	attrs := &rtnetlink.LinkAttributes{
+		Name: spec.Name,
+		Info: &rtnetlink.LinkInfo{
+			Kind: spec.Kind,
+		},
+	}
+
+	switch spec.Kind {
+	case network.LinkKindDummy, network.LinkKindBridge, network.LinkKindWireguard:
+	case network.LinkKindVLAN:
+		parent, err := net.InterfaceByName(spec.ParentName)
+		if err != nil {
+			return fmt.Errorf("parent link %q not found: %w", spec.ParentName, err)
+		}
+
+		encoder := netlink.NewAttributeEncoder()
+		encoder.Uint16(unix.IFLA_VLAN_ID, spec.VLANID)
+
+		if attrs.Info.Data, err = encoder.Encode(); err != nil {
+			return err
+		}
+
+		attrs.Type = uint32(parent.Index)
+	default:
+		return fmt.Errorf("unsupported link kind %q", spec.Kind)
+	}
+
+	return conn.Link.New(&rtnetlink.LinkMessage{
+		Family:     unix.AF_UNSPEC,
+		Attributes: attrs,
+	})
+}
+
+//nolint:gocyclo
+func configureLink(conn *rtnetlink.Conn, spec network.LinkSpecSpec) error {
+	iface, err := net.InterfaceByName(spec.Name)
+	if err != nil {
+		return err
+	}
+
+	msg, err := conn.Link.Get(uint32(iface.Index))
+	if err != nil {
+		return err
+	}
+
+	if spec.MasterName != "" {
+		master, err := net.InterfaceByName(spec.MasterName)
+		if err != nil {
+			return fmt.Errorf("master link %q not found: %w", spec.MasterName, err)
+		}
+
+		masterIndex := uint32(master.Index)
+
+		if msg.Attributes == nil || msg.Attributes.Master == nil || *msg.Attributes.Master != masterIndex {
+			if err = conn.Link.Set(&rtnetlink.LinkMessage{
+				Family: msg.Family,
+				Type:   msg.Type,
+				Index:  msg.Index,
+				Attributes: &rtnetlink.LinkAttributes{
+					Master: &masterIndex,
+				},
+			}); err != nil {
+				return fmt.Errorf("error setting master: %w", err)
+			}
+		}
+	}
+
+	if spec.MTU != 0 && (msg.Attributes == nil || msg.Attributes.MTU != spec.MTU) {
+		if err = conn.Link.Set(&rtnetlink.LinkMessage{
+			Family: msg.Family,
+			Type:   msg.Type,
+			Index:  msg.Index,
+			Attributes: &rtnetlink.LinkAttributes{
+				MTU: spec.MTU,
+			},
+		}); err != nil {
+			return fmt.Errorf("error setting MTU: %w", err)
+		}
+	}
+
+	if err = configureEthernet(spec); err != nil {
+		return err
+	}
+
+	if spec.Kind == network.LinkKindBridge {
+		if err = configureBridge(spec); err != nil {
+			return err
+		}
+	}
+
+	if spec.SLAAC {
+		if err = configureSLAAC(spec); err != nil {
+			return err
+		}
+	}
+
+	if spec.Up && msg.Flags&unix.IFF_UP == 0 {
+		if err = conn.Link.Set(&rtnetlink.LinkMessage{
+			Family: msg.Family,
+			Type:   msg.Type,
+			Index:  msg.Index,
+			Flags:  unix.IFF_UP,
+			Change: unix.IFF_UP,
+		}); err != nil {
+			return fmt.Errorf("error bringing link up: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// configureBridge reconciles bridge settings via sysfs.
+//
+// Kernel doesn't apply IFLA_LINKINFO changes to the existing links on RTM_SETLINK,
+// so sysfs is used both for the new and the existing bridges.
+func configureBridge(spec network.LinkSpecSpec) error {
+	options := []struct {
+		name  string
+		value bool
+	}{
+		{"stp_state", spec.BridgeMaster.STPEnabled},
+		{"vlan_filtering", spec.BridgeMaster.VLANFiltering},
+	}
+
+	for _, option := range options {
+		if err := setBridgeOption(spec.Name, option.name, option.value); err != nil {
+			return fmt.Errorf("error setting bridge option %q: %w", option.name, err)
+		}
+	}
+
+	return nil
+}
+
+func setBridgeOption(linkName, option string, value bool) error {
+	return writeLinkOption(filepath.Join("/sys/class/net", linkName, "bridge", option), boolOption(value))
+}
+
+// configureSLAAC enables IPv6 autoconfiguration via sysctls.
+//
+// Router advertisements are accepted even if forwarding is enabled (accept_ra = 2),
+// as Kubernetes nodes usually have forwarding enabled.
+func configureSLAAC(spec network.LinkSpecSpec) error {
+	options := []struct {
+		name  string
+		value string
+	}{
+		{"accept_ra", "2"},
+		{"autoconf", "1"},
+		{"accept_ra_defrtr", boolOption(!spec.SLAACIgnoreDefaultRoute)},
+	}
+
+	for _, option := range options {
+		if err := writeLinkOption(filepath.Join("/proc/sys/net/ipv6/conf", spec.Name, option.name), option.value); err != nil {
+			return fmt.Errorf("error setting IPv6 option %q: %w", option.name, err)
+		}
+	}
+
+	return nil
+}
+
+func boolOption(value bool) string {
+	if value {
+		return "1"
+	}
+
+	return "0"
+}
+
+// writeLinkOption updates sysfs/procfs option if the current value doesn't match.
+func writeLinkOption(path, value string) error {
+	desired := []byte(value)
+
+	current, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	if bytes.Equal(bytes.TrimSpace(current), desired) {
+		return nil
+	}
+
+	return ioutil.WriteFile(path, desired, 0o644)
+}
@@ -0,0 +1,13 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package network provides controllers which translate machine configuration into
+// network specs and converge the kernel network state to match the specs.
+package network
+
+import "time"
+
+// retryInterval is the interval between reconcile attempts while some spec can't be applied yet
+// (e.g. the link it refers to doesn't exist).
+const retryInterval = 10 * time.Second
@@ -0,0 +1,453 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package network
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+
+	"github.com/mdlayher/netlink"
+	"github.com/mdlayher/netlink/nlenc"
+	"golang.org/x/sys/unix"
+
+	"github.com/talos-systems/talos/pkg/resources/network"
+)
+
+// nftables objects managed by Talos.
+const (
+	nftTableName = "talos"
+	nftChainType = "filter"
+)
+
+// netfilter verdicts (include/uapi/linux/netfilter.h).
+const (
+	nfDrop   = 0
+	nfAccept = 1
+)
+
+// conntrack state bits: NF_CT_STATE_BIT(IP_CT_ESTABLISHED) and NF_CT_STATE_BIT(IP_CT_RELATED).
+const (
+	ctStateEstablished = 1 << 1
+	ctStateRelated     = 1 << 2
+)
+
+// DHCP client ports, replies from the servers are not tracked as related connections.
+const (
+	dhcpClientPort   = 68
+	dhcpv6ClientPort = 546
+)
+
+// nftExpr is a single nftables expression in the rule.
+type nftExpr struct {
+	name   string
+	encode func(ae *netlink.AttributeEncoder)
+}
+
+// be32 encodes the value as NLA_BE32 attribute payload, all nftables integer attributes are big-endian.
+func be32(v uint32) []byte {
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, v)
+
+	return b
+}
+
+func be16(v uint16) []byte {
+	b := make([]byte, 2)
+	binary.BigEndian.PutUint16(b, v)
+
+	return b
+}
+
+func nftData(ae *netlink.AttributeEncoder, attr uint16, data []byte) {
+	ae.Nested(attr, func(nae *netlink.AttributeEncoder) error {
+		nae.Bytes(unix.NFTA_DATA_VALUE, data)
+
+		return nil
+	})
+}
+
+func exprMeta(key uint32) nftExpr {
+	return nftExpr{
+		name: "meta",
+		encode: func(ae *netlink.AttributeEncoder) {
+			ae.Bytes(unix.NFTA_META_DREG, be32(unix.NFT_REG_1))
+			ae.Bytes(unix.NFTA_META_KEY, be32(key))
+		},
+	}
+}
+
+func exprCt(key uint32) nftExpr {
+	return nftExpr{
+		name: "ct",
+		encode: func(ae *netlink.AttributeEncoder) {
+			ae.Bytes(unix.NFTA_CT_DREG, be32(unix.NFT_REG_1))
+			ae.Bytes(unix.NFTA_CT_KEY, be32(key))
+		},
+	}
+}
+
+func exprPayload(base, offset, length uint32) nftExpr {
+	return nftExpr{
+		name: "payload",
+		encode: func(ae *netlink.AttributeEncoder) {
+			ae.Bytes(unix.NFTA_PAYLOAD_DREG, be32(unix.NFT_REG_1))
+			ae.Bytes(unix.NFTA_PAYLOAD_BASE, be32(base))
+			ae.Bytes(unix.NFTA_PAYLOAD_OFFSET, be32(offset))
+			ae.Bytes(unix.NFTA_PAYLOAD_LEN, be32(length))
+		},
+	}
+}
+
+func exprBitwise(mask []byte) nftExpr {
+	return nftExpr{
+		name: "bitwise",
+		encode: func(ae *netlink.AttributeEncoder) {
+			ae.Bytes(unix.NFTA_BITWISE_SREG, be32(unix.NFT_REG_1))
+			ae.Bytes(unix.NFTA_BITWISE_DREG, be32(unix.NFT_REG_1))
+			ae.Bytes(unix.NFTA_BITWISE_LEN, be32(uint32(len(mask))))
+			nftData(ae, unix.NFTA_BITWISE_MASK, mask)
+			nftData(ae, unix.NFTA_BITWISE_XOR, make([]byte, len(mask)))
+		},
+	}
+}
+
+func exprCmp(op uint32, data []byte) nftExpr {
+	return nftExpr{
+		name: "cmp",
+		encode: func(ae *netlink.AttributeEncoder) {
+			ae.Bytes(unix.NFTA_CMP_SREG, be32(unix.NFT_REG_1))
+			ae.Bytes(unix.NFTA_CMP_OP, be32(op))
+			nftData(ae, unix.NFTA_CMP_DATA, data)
+		},
+	}
+}
+
+func exprVerdict(verdict uint32) nftExpr {
+	return nftExpr{
+		name: "immediate",
+		encode: func(ae *netlink.AttributeEncoder) {
+			ae.Bytes(unix.NFTA_IMMEDIATE_DREG, be32(unix.NFT_REG_VERDICT))
+			ae.Nested(unix.NFTA_IMMEDIATE_DATA, func(data *netlink.AttributeEncoder) error {
+				data.Nested(unix.NFTA_DATA_VERDICT, func(v *netlink.AttributeEncoder) error {
+					v.Bytes(unix.NFTA_VERDICT_CODE, be32(verdict))
+
+					return nil
+				})
+
+				return nil
+			})
+		},
+	}
+}
+
+// matchL4Proto matches the transport protocol.
+func matchL4Proto(proto uint8) []nftExpr {
+	return []nftExpr{
+		exprMeta(unix.NFT_META_L4PROTO),
+		exprCmp(unix.NFT_CMP_EQ, []byte{proto}),
+	}
+}
+
+// matchDestinationPorts matches the destination port range of TCP or UDP traffic.
+func matchDestinationPorts(portRange network.PortRange) []nftExpr {
+	exprs := []nftExpr{
+		exprPayload(unix.NFT_PAYLOAD_TRANSPORT_HEADER, 2, 2),
+	}
+
+	if portRange.Lo == portRange.Hi {
+		return append(exprs, exprCmp(unix.NFT_CMP_EQ, be16(portRange.Lo)))
+	}
+
+	// ports are big-endian in the packet, so byte-wise comparison matches the numeric one
+	return append(exprs,
+		exprCmp(unix.NFT_CMP_GTE, be16(portRange.Lo)),
+		exprCmp(unix.NFT_CMP_LTE, be16(portRange.Hi)),
+	)
+}
+
+// matchSourceSubnet matches the address family and the source network of the traffic.
+func matchSourceSubnet(subnet *net.IPNet) []nftExpr {
+	var (
+		nfproto uint8
+		offset  uint32
+		ip      = subnet.IP
+	)
+
+	if ip4 := ip.To4(); ip4 != nil {
+		nfproto, offset, ip = unix.NFPROTO_IPV4, 12, ip4
+	} else {
+		nfproto, offset = unix.NFPROTO_IPV6, 8
+	}
+
+	exprs := []nftExpr{
+		exprMeta(unix.NFT_META_NFPROTO),
+		exprCmp(unix.NFT_CMP_EQ, []byte{nfproto}),
+	}
+
+	ones, bits := subnet.Mask.Size()
+	if ones == 0 {
+		return exprs
+	}
+
+	exprs = append(exprs, exprPayload(unix.NFT_PAYLOAD_NETWORK_HEADER, offset, uint32(len(ip))))
+
+	if ones != bits {
+		exprs = append(exprs, exprBitwise(net.CIDRMask(ones, bits)))
+	}
+
+	return append(exprs, exprCmp(unix.NFT_CMP_EQ, ip.Mask(net.CIDRMask(ones, bits))))
+}
+
+// acceptUDPPort accepts the UDP traffic to the port.
+func acceptUDPPort(port uint16) []nftExpr {
+	var exprs []nftExpr
+
+	exprs = append(exprs, matchL4Proto(unix.IPPROTO_UDP)...)
+	exprs = append(exprs, matchDestinationPorts(network.PortRange{Lo: port, Hi: port})...)
+
+	return append(exprs, exprVerdict(nfAccept))
+}
+
+// nftChainRules builds the rules of the chain.
+//
+// Established connections, loopback, ICMP and DHCP client traffic is always accepted.
+// Each rule accepts the traffic to its ports from the source subnets, and drops the rest of the traffic to the ports.
+func nftChainRules(spec network.NfTablesChainSpec) ([][]nftExpr, error) {
+	// conntrack state is loaded to the register in host byte order
+	ctStateMask := nlenc.Uint32Bytes(ctStateEstablished | ctStateRelated)
+
+	loopback := make([]byte, unix.IFNAMSIZ)
+	copy(loopback, "lo")
+
+	rules := [][]nftExpr{
+		{
+			exprCt(unix.NFT_CT_STATE),
+			exprBitwise(ctStateMask),
+			exprCmp(unix.NFT_CMP_NEQ, make([]byte, 4)),
+			exprVerdict(nfAccept),
+		},
+		{
+			exprMeta(unix.NFT_META_IIFNAME),
+			exprCmp(unix.NFT_CMP_EQ, loopback),
+			exprVerdict(nfAccept),
+		},
+		append(matchL4Proto(unix.IPPROTO_ICMP), exprVerdict(nfAccept)),
+		append(matchL4Proto(unix.IPPROTO_ICMPV6), exprVerdict(nfAccept)),
+		acceptUDPPort(dhcpClientPort),
+		acceptUDPPort(dhcpv6ClientPort),
+	}
+
+	for _, rule := range spec.Rules {
+		var proto uint8
+
+		switch rule.Protocol {
+		case "tcp":
+			proto = unix.IPPROTO_TCP
+		case "udp":
+			proto = unix.IPPROTO_UDP
+		default:
+			return nil, fmt.Errorf("rule %q: unsupported protocol %q", rule.Name, rule.Protocol)
+		}
+
+		subnets := make([]*net.IPNet, 0, len(rule.SourceSubnets))
+
+		for _, subnet := range rule.SourceSubnets {
+			_, ipNet, err := net.ParseCIDR(subnet)
+			if err != nil {
+				return nil, fmt.Errorf("rule %q: %w", rule.Name, err)
+			}
+
+			subnets = append(subnets, ipNet)
+		}
+
+		for _, portRange := range rule.PortRanges {
+			for _, subnet := range subnets {
+				var exprs []nftExpr
+
+				exprs = append(exprs, matchSourceSubnet(subnet)...)
+				exprs = append(exprs, matchL4Proto(proto)...)
+				exprs = append(exprs, matchDestinationPorts(portRange)...)
+				exprs = append(exprs, exprVerdict(nfAccept))
+
+				rules = append(rules, exprs)
+			}
+
+			var exprs []nftExpr
+
+			exprs = append(exprs, matchL4Proto(proto)...)
+			exprs = append(exprs, matchDestinationPorts(portRange)...)
+			exprs = append(exprs, exprVerdict(nfDrop))
+
+			rules = append(rules, exprs)
+		}
+	}
+
+	return rules, nil
+}
+
+// nftMessage builds nftables netlink message: struct nfgenmsg followed by the attributes.
+func nftMessage(msgType uint16, flags netlink.HeaderFlags, family uint8, resID uint16, encode func(ae *netlink.AttributeEncoder)) (netlink.Message, error) {
+	// family, version, res_id (big-endian)
+	hdr := []byte{family, unix.NFNETLINK_V0, 0, 0}
+	binary.BigEndian.PutUint16(hdr[2:], resID)
+
+	encoder := netlink.NewAttributeEncoder()
+
+	if encode != nil {
+		encode(encoder)
+	}
+
+	attrs, err := encoder.Encode()
+	if err != nil {
+		return netlink.Message{}, err
+	}
+
+	return netlink.Message{
+		Header: netlink.Header{
+			Type:  netlink.HeaderType(msgType),
+			Flags: flags,
+		},
+		Data: append(hdr, attrs...),
+	}, nil
+}
+
+func nftablesMessage(msg uint16, flags netlink.HeaderFlags, encode func(ae *netlink.AttributeEncoder)) (netlink.Message, error) {
+	return nftMessage(unix.NFNL_SUBSYS_NFTABLES<<8|msg, netlink.Request|netlink.Acknowledge|flags, unix.NFPROTO_INET, 0, encode)
+}
+
+// nftBatch builds the batch which (re)creates Talos nftables table with the chain.
+//
+// Table is flushed by creating it (no-op if it exists) and deleting, so the batch replaces the ruleset atomically.
+// If the spec is nil, the table is removed.
+//
+//nolint:gocyclo
+func nftBatch(chainName string, spec *network.NfTablesChainSpec) ([]netlink.Message, error) {
+	var msgs []netlink.Message
+
+	add := func(msg netlink.Message, err error) error {
+		if err != nil {
+			return err
+		}
+
+		msgs = append(msgs, msg)
+
+		return nil
+	}
+
+	tableName := func(ae *netlink.AttributeEncoder) {
+		ae.String(unix.NFTA_TABLE_NAME, nftTableName)
+	}
+
+	if err := add(nftMessage(unix.NFNL_MSG_BATCH_BEGIN, netlink.Request, unix.AF_UNSPEC, unix.NFNL_SUBSYS_NFTABLES, nil)); err != nil {
+		return nil, err
+	}
+
+	if err := add(nftablesMessage(unix.NFT_MSG_NEWTABLE, netlink.Create, tableName)); err != nil {
+		return nil, err
+	}
+
+	if err := add(nftablesMessage(unix.NFT_MSG_DELTABLE, 0, tableName)); err != nil {
+		return nil, err
+	}
+
+	if spec != nil {
+		policy := uint32(nfAccept)
+		if spec.Policy == network.VerdictDrop {
+			policy = nfDrop
+		}
+
+		rules, err := nftChainRules(*spec)
+		if err != nil {
+			return nil, err
+		}
+
+		if err = add(nftablesMessage(unix.NFT_MSG_NEWTABLE, netlink.Create, tableName)); err != nil {
+			return nil, err
+		}
+
+		if err = add(nftablesMessage(unix.NFT_MSG_NEWCHAIN, netlink.Create, func(ae *netlink.AttributeEncoder) {
+			ae.String(unix.NFTA_CHAIN_TABLE, nftTableName)
+			ae.String(unix.NFTA_CHAIN_NAME, chainName)
+			ae.Nested(unix.NFTA_CHAIN_HOOK, func(hook *netlink.AttributeEncoder) error {
+				hook.Bytes(unix.NFTA_HOOK_HOOKNUM, be32(unix.NF_INET_LOCAL_IN))
+				hook.Bytes(unix.NFTA_HOOK_PRIORITY, be32(0))
+
+				return nil
+			})
+			ae.Bytes(unix.NFTA_CHAIN_POLICY, be32(policy))
+			ae.String(unix.NFTA_CHAIN_TYPE, nftChainType)
+		})); err != nil {
+			return nil, err
+		}
+
+		for _, rule := range rules {
+			rule := rule
+
+			if err = add(nftablesMessage(unix.NFT_MSG_NEWRULE, netlink.Create|netlink.Append, func(ae *netlink.AttributeEncoder) {
+				ae.String(unix.NFTA_RULE_TABLE, nftTableName)
+				ae.String(unix.NFTA_RULE_CHAIN, chainName)
+				ae.Nested(unix.NFTA_RULE_EXPRESSIONS, func(list *netlink.AttributeEncoder) error {
+					for _, expr := range rule {
+						expr := expr
+
+						list.Nested(unix.NFTA_LIST_ELEM, func(elem *netlink.AttributeEncoder) error {
+							elem.String(unix.NFTA_EXPR_NAME, expr.name)
+							elem.Nested(unix.NFTA_EXPR_DATA, func(data *netlink.AttributeEncoder) error {
+								expr.encode(data)
+
+								return nil
+							})
+
+							return nil
+						})
+					}
+
+					return nil
+				})
+			})); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := add(nftMessage(unix.NFNL_MSG_BATCH_END, netlink.Request, unix.AF_UNSPEC, unix.NFNL_SUBSYS_NFTABLES, nil)); err != nil {
+		return nil, err
+	}
+
+	return msgs, nil
+}
+
+// applyNfTables sends the batch to the kernel and waits for all the messages to be acknowledged.
+func applyNfTables(chainName string, spec *network.NfTablesChainSpec) error {
+	msgs, err := nftBatch(chainName, spec)
+	if err != nil {
+		return err
+	}
+
+	conn, err := netlink.Dial(unix.NETLINK_NETFILTER, nil)
+	if err != nil {
+		return fmt.Errorf("error dialing netlink: %w", err)
+	}
+
+	//nolint:errcheck
+	defer conn.Close()
+
+	if _, err = conn.SendMessages(msgs); err != nil {
+		return fmt.Errorf("error sending nftables batch: %w", err)
+	}
+
+	// batch begin and end messages are not acknowledged
+	for pending := len(msgs) - 2; pending > 0; {
+		replies, err := conn.Receive()
+		if err != nil {
+			return fmt.Errorf("error applying nftables batch: %w", err)
+		}
+
+		pending -= len(replies)
+	}
+
+	return nil
+}
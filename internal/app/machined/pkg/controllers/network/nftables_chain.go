@@ -0,0 +1,101 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package network
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"reflect"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/talos-systems/os-runtime/pkg/controller"
+	"github.com/talos-systems/os-runtime/pkg/resource"
+	"github.com/talos-systems/os-runtime/pkg/state"
+
+	"github.com/talos-systems/talos/pkg/resources/network"
+)
+
+// NfTablesChainController applies the ingress firewall NfTablesChain to the host nftables.
+type NfTablesChainController struct {
+	applied *network.NfTablesChainSpec
+}
+
+// Name implements controller.Controller interface.
+func (ctrl *NfTablesChainController) Name() string {
+	return "network.NfTablesChainController"
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *NfTablesChainController) Inputs() []controller.Input {
+	return []controller.Input{
+		{
+			Namespace: network.NamespaceName,
+			Type:      network.NfTablesChainType,
+			ID:        pointer.ToString(network.IngressChainID),
+			Kind:      controller.InputWeak,
+		},
+	}
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *NfTablesChainController) Outputs() []controller.Output {
+	return nil
+}
+
+// Run implements controller.Controller interface.
+//
+//nolint:gocyclo
+func (ctrl *NfTablesChainController) Run(ctx context.Context, r controller.Runtime, logger *log.Logger) error {
+	var retryCh <-chan time.Time
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+		case <-retryCh:
+		}
+
+		retryCh = nil
+
+		chain, err := r.Get(ctx, resource.NewMetadata(network.NamespaceName, network.NfTablesChainType, network.IngressChainID, resource.VersionUndefined))
+		if err != nil && !state.IsNotFoundError(err) {
+			return fmt.Errorf("error getting nftables chain: %w", err)
+		}
+
+		var spec *network.NfTablesChainSpec
+
+		if chain != nil {
+			spec = chain.(*network.NfTablesChain).TypedSpec()
+		}
+
+		// nftables table is not touched until the firewall is configured
+		if (spec == nil && ctrl.applied == nil) || (spec != nil && ctrl.applied != nil && reflect.DeepEqual(*spec, *ctrl.applied)) {
+			continue
+		}
+
+		if err = applyNfTables(network.IngressChainID, spec); err != nil {
+			logger.Printf("failed to apply nftables chain %q: %s", network.IngressChainID, err)
+
+			retryCh = time.After(retryInterval)
+
+			continue
+		}
+
+		if spec == nil {
+			logger.Printf("removed nftables chain %q", network.IngressChainID)
+		} else {
+			logger.Printf("applied nftables chain %q with %d rule(s)", network.IngressChainID, len(spec.Rules))
+		}
+
+		ctrl.applied = nil
+
+		if spec != nil {
+			ctrl.applied = chain.DeepCopy().(*network.NfTablesChain).TypedSpec()
+		}
+	}
+}
@@ -0,0 +1,122 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package network
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/AlekSi/pointer"
+	"github.com/talos-systems/os-runtime/pkg/controller"
+	"github.com/talos-systems/os-runtime/pkg/resource"
+	"github.com/talos-systems/os-runtime/pkg/state"
+
+	v1alpha1runtime "github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
+	talosconfig "github.com/talos-systems/talos/pkg/machinery/config"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
+	"github.com/talos-systems/talos/pkg/resources/config"
+	"github.com/talos-systems/talos/pkg/resources/network"
+)
+
+// NfTablesChainConfigController builds the ingress firewall NfTablesChain from the machine configuration.
+type NfTablesChainConfigController struct {
+	V1Alpha1Mode v1alpha1runtime.Mode
+}
+
+// Name implements controller.Controller interface.
+func (ctrl *NfTablesChainConfigController) Name() string {
+	return "network.NfTablesChainConfigController"
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *NfTablesChainConfigController) Inputs() []controller.Input {
+	return []controller.Input{
+		{
+			Namespace: config.NamespaceName,
+			Type:      config.MachineConfigType,
+			ID:        pointer.ToString(config.V1Alpha1ID),
+			Kind:      controller.InputWeak,
+		},
+	}
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *NfTablesChainConfigController) Outputs() []controller.Output {
+	return []controller.Output{
+		{
+			Type: network.NfTablesChainType,
+			Kind: controller.OutputExclusive,
+		},
+	}
+}
+
+// Run implements controller.Controller interface.
+func (ctrl *NfTablesChainConfigController) Run(ctx context.Context, r controller.Runtime, logger *log.Logger) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+		}
+
+		cfg, err := r.Get(ctx, resource.NewMetadata(config.NamespaceName, config.MachineConfigType, config.V1Alpha1ID, resource.VersionUndefined))
+		if err != nil && !state.IsNotFoundError(err) {
+			return fmt.Errorf("error getting config: %w", err)
+		}
+
+		var firewall talosconfig.Firewall
+
+		// network in container mode is managed by the container runtime
+		if cfg != nil && ctrl.V1Alpha1Mode != v1alpha1runtime.ModeContainer {
+			firewall = cfg.(*config.MachineConfig).Config().Machine().Network().Firewall()
+		}
+
+		if firewall == nil {
+			if err = r.Destroy(ctx, network.NewNfTablesChain(network.IngressChainID).Metadata()); err != nil && !state.IsNotFoundError(err) {
+				return fmt.Errorf("error cleaning up nftables chain: %w", err)
+			}
+
+			continue
+		}
+
+		if err = r.Modify(ctx, network.NewNfTablesChain(network.IngressChainID), func(r resource.Resource) error {
+			*r.(*network.NfTablesChain).TypedSpec() = IngressChainSpec(firewall)
+
+			return nil
+		}); err != nil {
+			return fmt.Errorf("error updating nftables chain: %w", err)
+		}
+	}
+}
+
+// IngressChainSpec builds the ingress chain from the firewall configuration.
+func IngressChainSpec(firewall talosconfig.Firewall) network.NfTablesChainSpec {
+	spec := network.NfTablesChainSpec{
+		Policy: network.VerdictAccept,
+		Rules:  []network.NfTablesRule{},
+	}
+
+	if firewall.DefaultAction() == v1alpha1.FirewallActionBlock {
+		spec.Policy = network.VerdictDrop
+	}
+
+	for _, rule := range firewall.Rules() {
+		nftRule := network.NfTablesRule{
+			Name:          rule.Name(),
+			Protocol:      rule.Protocol(),
+			PortRanges:    make([]network.PortRange, 0, len(rule.Ports())),
+			SourceSubnets: rule.Subnets(),
+		}
+
+		for _, ports := range rule.Ports() {
+			nftRule.PortRanges = append(nftRule.PortRanges, network.PortRange{Lo: ports.Lo, Hi: ports.Hi})
+		}
+
+		spec.Rules = append(spec.Rules, nftRule)
+	}
+
+	return spec
+}
@@ -0,0 +1,58 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package network_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	netctrl "github.com/talos-systems/talos/internal/app/machined/pkg/controllers/network"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
+	"github.com/talos-systems/talos/pkg/resources/network"
+)
+
+func TestIngressChainSpec(t *testing.T) {
+	firewall := &v1alpha1.FirewallConfig{
+		FirewallDefaultAction: v1alpha1.FirewallActionBlock,
+		FirewallRules: []*v1alpha1.FirewallRule{
+			{
+				FirewallRuleName: "etcd",
+				FirewallRulePortSelector: &v1alpha1.FirewallPortSelector{
+					PortSelectorPorts:    []string{"2379-2380", "2381"},
+					PortSelectorProtocol: "tcp",
+				},
+				FirewallRuleIngress: []*v1alpha1.FirewallIngressRule{
+					{
+						IngressSubnet: "10.0.0.0/8",
+					},
+					{
+						IngressSubnet: "fd00::/8",
+					},
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, network.NfTablesChainSpec{
+		Policy: network.VerdictDrop,
+		Rules: []network.NfTablesRule{
+			{
+				Name:     "etcd",
+				Protocol: "tcp",
+				PortRanges: []network.PortRange{
+					{Lo: 2379, Hi: 2380},
+					{Lo: 2381, Hi: 2381},
+				},
+				SourceSubnets: []string{"10.0.0.0/8", "fd00::/8"},
+			},
+		},
+	}, netctrl.IngressChainSpec(firewall))
+
+	assert.Equal(t, network.NfTablesChainSpec{
+		Policy: network.VerdictAccept,
+		Rules:  []network.NfTablesRule{},
+	}, netctrl.IngressChainSpec(&v1alpha1.FirewallConfig{}))
+}
@@ -0,0 +1,98 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package network
+
+import (
+	"encoding/hex"
+	"net"
+	"testing"
+
+	"github.com/mdlayher/netlink"
+	"github.com/mdlayher/netlink/nlenc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+
+	"github.com/talos-systems/talos/pkg/resources/network"
+)
+
+func encodeExpr(t *testing.T, expr nftExpr) string {
+	ae := netlink.NewAttributeEncoder()
+	expr.encode(ae)
+
+	b, err := ae.Encode()
+	require.NoError(t, err)
+
+	return hex.EncodeToString(b)
+}
+
+func TestNftExprEncoding(t *testing.T) {
+	_, subnet, err := net.ParseCIDR("10.5.0.0/24")
+	require.NoError(t, err)
+
+	for _, tt := range []struct {
+		name     string
+		expr     nftExpr
+		expected string
+	}{
+		{
+			name: "meta l4proto",
+			expr: exprMeta(unix.NFT_META_L4PROTO),
+			// DREG = 1, KEY = 16 (both NLA_BE32)
+			expected: "0800010000000001" + "0800020000000010",
+		},
+		{
+			name: "payload dport",
+			expr: exprPayload(unix.NFT_PAYLOAD_TRANSPORT_HEADER, 2, 2),
+			// DREG = 1, BASE = 2, OFFSET = 2, LEN = 2
+			expected: "0800010000000001" + "0800020000000002" + "0800030000000002" + "0800040000000002",
+		},
+		{
+			name: "cmp port",
+			expr: exprCmp(unix.NFT_CMP_EQ, be16(dhcpv6ClientPort)),
+			// SREG = 1, OP = EQ, DATA { VALUE = 0x0222 (padded) }
+			expected: "0800010000000001" + "0800020000000000" + "0c000380" + "060001000222" + "0000",
+		},
+		{
+			name: "bitwise subnet mask",
+			expr: exprBitwise(subnet.Mask),
+			// SREG = 1, DREG = 1, LEN = 4, MASK { VALUE = ffffff00 }, XOR { VALUE = 00000000 }
+			expected: "0800010000000001" + "0800020000000001" + "0800030000000004" +
+				"0c000480" + "08000100ffffff00" + "0c000580" + "0800010000000000",
+		},
+		{
+			name: "verdict accept",
+			expr: exprVerdict(nfAccept),
+			// DREG = NFT_REG_VERDICT, DATA { VERDICT { CODE = 1 } }
+			expected: "0800010000000000" + "10000280" + "0c000280" + "0800010000000001",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, encodeExpr(t, tt.expr))
+		})
+	}
+}
+
+func TestNftChainRulesBase(t *testing.T) {
+	rules, err := nftChainRules(network.NfTablesChainSpec{Policy: network.VerdictDrop})
+	require.NoError(t, err)
+
+	// established/related, loopback, ICMP, ICMPv6, DHCP, DHCPv6
+	require.Len(t, rules, 6)
+
+	// conntrack state is compared in host byte order
+	ctStateMask := nlenc.Uint32Bytes(ctStateEstablished | ctStateRelated)
+	assert.Equal(t, encodeExpr(t, exprBitwise(ctStateMask)), encodeExpr(t, rules[0][1]))
+	assert.Equal(t, uint32(6), nlenc.Uint32(ctStateMask))
+
+	for i, port := range []uint16{dhcpClientPort, dhcpv6ClientPort} {
+		rule := rules[4+i]
+
+		require.Len(t, rule, 5)
+		assert.Equal(t, encodeExpr(t, exprCmp(unix.NFT_CMP_EQ, []byte{unix.IPPROTO_UDP})), encodeExpr(t, rule[1]))
+		assert.Equal(t, encodeExpr(t, exprCmp(unix.NFT_CMP_EQ, be16(port))), encodeExpr(t, rule[3]))
+		assert.Equal(t, encodeExpr(t, exprVerdict(nfAccept)), encodeExpr(t, rule[4]))
+	}
+}
@@ -0,0 +1,151 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package network
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"sort"
+
+	"github.com/talos-systems/os-runtime/pkg/controller"
+	"github.com/talos-systems/os-runtime/pkg/resource"
+
+	"github.com/talos-systems/talos/internal/pkg/resolvconf"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+	"github.com/talos-systems/talos/pkg/resources/network"
+)
+
+// ResolverSpecController writes resolv.conf for the host and for the kubelet from the ResolverSpecs.
+type ResolverSpecController struct {
+	// ResolvConfPath defaults to /etc/resolv.conf.
+	ResolvConfPath string
+	// KubeletResolvConfPath defaults to constants.KubeletResolvConfPath.
+	KubeletResolvConfPath string
+}
+
+// Name implements controller.Controller interface.
+func (ctrl *ResolverSpecController) Name() string {
+	return "network.ResolverSpecController"
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *ResolverSpecController) Inputs() []controller.Input {
+	return []controller.Input{
+		{
+			Namespace: network.NamespaceName,
+			Type:      network.ResolverSpecType,
+			Kind:      controller.InputWeak,
+		},
+	}
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *ResolverSpecController) Outputs() []controller.Output {
+	return nil
+}
+
+// Run implements controller.Controller interface.
+func (ctrl *ResolverSpecController) Run(ctx context.Context, r controller.Runtime, logger *log.Logger) error {
+	if ctrl.ResolvConfPath == "" {
+		ctrl.ResolvConfPath = "/etc/resolv.conf"
+	}
+
+	if ctrl.KubeletResolvConfPath == "" {
+		ctrl.KubeletResolvConfPath = constants.KubeletResolvConfPath
+	}
+
+	var lastHost, lastKubelet []byte
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+		}
+
+		list, err := r.List(ctx, resource.NewMetadata(network.NamespaceName, network.ResolverSpecType, "", resource.VersionUndefined))
+		if err != nil {
+			return fmt.Errorf("error listing resolver specs: %w", err)
+		}
+
+		merged := MergeResolvers(list.Items)
+		if merged.DNSServers == nil {
+			// resolvers discovered by networkd (DHCPv4 or defaults) are left in place
+			continue
+		}
+
+		searchDomains := resolvconf.SearchDomains(merged.SearchDomains, merged.DisableSearchDomain)
+
+		host := resolvconf.Render(merged.DNSServers, searchDomains)
+		kubelet := resolvconf.RenderKubelet(merged.DNSServers, searchDomains)
+
+		if bytes.Equal(host, lastHost) && bytes.Equal(kubelet, lastKubelet) {
+			continue
+		}
+
+		if err = ioutil.WriteFile(ctrl.ResolvConfPath, host, 0o644); err != nil {
+			return fmt.Errorf("error writing %q: %w", ctrl.ResolvConfPath, err)
+		}
+
+		if err = ioutil.WriteFile(ctrl.KubeletResolvConfPath, kubelet, 0o644); err != nil {
+			return fmt.Errorf("error writing %q: %w", ctrl.KubeletResolvConfPath, err)
+		}
+
+		logger.Printf("updated resolvers %q, search domains %q", merged.DNSServers, searchDomains)
+
+		lastHost, lastKubelet = host, kubelet
+	}
+}
+
+// MergeResolvers merges the resolver specs.
+//
+// Nameservers and search domains from the machine configuration take precedence if they are set,
+// otherwise the ones discovered on all the links (via DHCP) are merged in the order of the link names.
+func MergeResolvers(specs []resource.Resource) network.ResolverSpecSpec {
+	var (
+		merged network.ResolverSpecSpec
+		ids    []resource.ID
+		cfg    *network.ResolverSpecSpec
+	)
+
+	byID := make(map[resource.ID]*network.ResolverSpecSpec, len(specs))
+
+	for _, res := range specs {
+		spec := res.(*network.ResolverSpec).TypedSpec()
+
+		if res.Metadata().ID() == network.ResolverID {
+			cfg = spec
+
+			continue
+		}
+
+		byID[res.Metadata().ID()] = spec
+		ids = append(ids, res.Metadata().ID())
+	}
+
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		merged.DNSServers = append(merged.DNSServers, byID[id].DNSServers...)
+		merged.SearchDomains = append(merged.SearchDomains, byID[id].SearchDomains...)
+	}
+
+	if cfg != nil {
+		if len(cfg.DNSServers) > 0 {
+			merged.DNSServers = cfg.DNSServers
+		}
+
+		if len(cfg.SearchDomains) > 0 {
+			merged.SearchDomains = cfg.SearchDomains
+		}
+
+		merged.DisableSearchDomain = cfg.DisableSearchDomain
+	}
+
+	return merged
+}
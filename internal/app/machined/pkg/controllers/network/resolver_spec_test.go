@@ -0,0 +1,57 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package network_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/talos-systems/os-runtime/pkg/resource"
+
+	netctrl "github.com/talos-systems/talos/internal/app/machined/pkg/controllers/network"
+	"github.com/talos-systems/talos/pkg/resources/network"
+)
+
+func resolverSpec(id resource.ID, spec network.ResolverSpecSpec) resource.Resource {
+	r := network.NewResolverSpec(id)
+	*r.TypedSpec() = spec
+
+	return r
+}
+
+func TestMergeResolvers(t *testing.T) {
+	dhcp := []resource.Resource{
+		resolverSpec(network.DHCP6ResolverID("eth1"), network.ResolverSpecSpec{
+			DNSServers:    []string{"2001:db8::2"},
+			SearchDomains: []string{"eth1.example.com"},
+		}),
+		resolverSpec(network.DHCP6ResolverID("eth0"), network.ResolverSpecSpec{
+			DNSServers:    []string{"2001:db8::1"},
+			SearchDomains: []string{"eth0.example.com"},
+		}),
+	}
+
+	assert.Equal(t, network.ResolverSpecSpec{
+		DNSServers:    []string{"2001:db8::1", "2001:db8::2"},
+		SearchDomains: []string{"eth0.example.com", "eth1.example.com"},
+	}, netctrl.MergeResolvers(dhcp))
+
+	// search domains from the configuration override DHCP, nameservers are still merged from DHCP
+	assert.Equal(t, network.ResolverSpecSpec{
+		DNSServers:          []string{"2001:db8::1", "2001:db8::2"},
+		SearchDomains:       []string{"example.org"},
+		DisableSearchDomain: true,
+	}, netctrl.MergeResolvers(append(dhcp, resolverSpec(network.ResolverID, network.ResolverSpecSpec{
+		SearchDomains:       []string{"example.org"},
+		DisableSearchDomain: true,
+	}))))
+
+	assert.Equal(t, network.ResolverSpecSpec{
+		DNSServers:    []string{"1.1.1.1"},
+		SearchDomains: []string{"eth0.example.com", "eth1.example.com"},
+	}, netctrl.MergeResolvers(append(dhcp, resolverSpec(network.ResolverID, network.ResolverSpecSpec{
+		DNSServers: []string{"1.1.1.1"},
+	}))))
+}
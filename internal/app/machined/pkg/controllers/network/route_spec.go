@@ -0,0 +1,388 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package network
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"time"
+
+	"github.com/jsimonetti/rtnetlink"
+	"github.com/mdlayher/netlink"
+	"github.com/talos-systems/os-runtime/pkg/controller"
+	"github.com/talos-systems/os-runtime/pkg/resource"
+	"golang.org/x/sys/unix"
+
+	"github.com/talos-systems/talos/pkg/resources/network"
+)
+
+// RouteSpecController applies RouteSpecs to the kernel routing table.
+//
+// Routes are owned and adopted the same way as the addresses (see AddressSpecController):
+// routes which already exist when the spec shows up (e.g. added by DHCP) are never removed,
+// routes added by the controller and the routes from the machine configuration are removed when the spec goes away.
+type RouteSpecController struct {
+	// routes added by the controller
+	owned map[resource.ID]network.RouteSpecSpec
+	// routes found in the routing table
+	adopted map[resource.ID]network.RouteSpecSpec
+}
+
+// Name implements controller.Controller interface.
+func (ctrl *RouteSpecController) Name() string {
+	return "network.RouteSpecController"
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *RouteSpecController) Inputs() []controller.Input {
+	return []controller.Input{
+		{
+			Namespace: network.NamespaceName,
+			Type:      network.RouteSpecType,
+			Kind:      controller.InputWeak,
+		},
+		{
+			Namespace: network.NamespaceName,
+			Type:      network.AddressSpecType,
+			Kind:      controller.InputWeak,
+		},
+	}
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *RouteSpecController) Outputs() []controller.Output {
+	return nil
+}
+
+// Run implements controller.Controller interface.
+func (ctrl *RouteSpecController) Run(ctx context.Context, r controller.Runtime, logger *log.Logger) error {
+	if ctrl.owned == nil {
+		ctrl.owned = map[resource.ID]network.RouteSpecSpec{}
+		ctrl.adopted = map[resource.ID]network.RouteSpecSpec{}
+	}
+
+	var retryCh <-chan time.Time
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+		case <-retryCh:
+		}
+
+		list, err := r.List(ctx, resource.NewMetadata(network.NamespaceName, network.RouteSpecType, "", resource.VersionUndefined))
+		if err != nil {
+			return fmt.Errorf("error listing route specs: %w", err)
+		}
+
+		specs := make(map[resource.ID]network.RouteSpecSpec, len(list.Items))
+		configured := map[resource.ID]struct{}{}
+
+		for _, res := range list.Items {
+			specs[res.Metadata().ID()] = *res.(*network.RouteSpec).TypedSpec()
+
+			if res.Metadata().Owner() == configControllerName {
+				configured[res.Metadata().ID()] = struct{}{}
+			}
+		}
+
+		conn, err := rtnetlink.Dial(nil)
+		if err != nil {
+			return fmt.Errorf("error dialing rtnetlink: %w", err)
+		}
+
+		pending := ctrl.reconcile(logger, &rtnetlinkRoutes{conn: conn}, specs, configured)
+
+		//nolint:errcheck
+		conn.Close()
+
+		retryCh = nil
+
+		if pending {
+			retryCh = time.After(retryInterval)
+		}
+	}
+}
+
+// routeKernel abstracts the kernel routing table operations of the controller.
+type routeKernel interface {
+	// exists checks whether the route is in the routing table.
+	exists(spec network.RouteSpecSpec) bool
+	// add adds the route, exists is set if the route was already in the routing table.
+	add(spec network.RouteSpecSpec) (exists bool, err error)
+	// remove removes the route.
+	remove(spec network.RouteSpecSpec) error
+}
+
+// reconcile converges the kernel state to the specs, configured is the set of specs from the machine configuration.
+//
+//nolint:gocyclo,cyclop
+func (ctrl *RouteSpecController) reconcile(logger *log.Logger, kernel routeKernel, specs map[resource.ID]network.RouteSpecSpec, configured map[resource.ID]struct{}) (pending bool) {
+	// adopted routes are forgotten without touching the kernel state,
+	// routes from the machine configuration are owned below
+	for id, spec := range ctrl.adopted {
+		_, isConfigured := configured[id]
+
+		if newSpec, ok := specs[id]; ok && newSpec == spec && !isConfigured && kernel.exists(spec) {
+			continue
+		}
+
+		delete(ctrl.adopted, id)
+	}
+
+	for id, spec := range ctrl.owned {
+		if newSpec, ok := specs[id]; ok && newSpec == spec {
+			if kernel.exists(spec) {
+				continue
+			}
+
+			// route was removed externally (or along with the address), add it again
+			delete(ctrl.owned, id)
+
+			continue
+		}
+
+		if err := kernel.remove(spec); err != nil {
+			logger.Printf("failed to remove route %s via %q on %q: %s", spec.Destination, spec.Gateway, spec.OutLinkName, err)
+
+			pending = true
+
+			continue
+		}
+
+		logger.Printf("removed route %s via %q on %q", spec.Destination, spec.Gateway, spec.OutLinkName)
+
+		delete(ctrl.owned, id)
+	}
+
+	for id, spec := range specs {
+		if _, ok := ctrl.owned[id]; ok {
+			continue
+		}
+
+		if _, ok := ctrl.adopted[id]; ok {
+			continue
+		}
+
+		_, isConfigured := configured[id]
+
+		exists := kernel.exists(spec)
+
+		if !exists {
+			var err error
+
+			if exists, err = kernel.add(spec); err != nil {
+				logger.Printf("failed to add route %s via %q on %q: %s", spec.Destination, spec.Gateway, spec.OutLinkName, err)
+
+				pending = true
+
+				continue
+			}
+		}
+
+		switch {
+		case !exists:
+			logger.Printf("added route %s via %q on %q", spec.Destination, spec.Gateway, spec.OutLinkName)
+
+			ctrl.owned[id] = spec
+		case isConfigured:
+			logger.Printf("route %s via %q on %q already exists, taking ownership", spec.Destination, spec.Gateway, spec.OutLinkName)
+
+			ctrl.owned[id] = spec
+		default:
+			logger.Printf("route %s via %q on %q already exists", spec.Destination, spec.Gateway, spec.OutLinkName)
+
+			ctrl.adopted[id] = spec
+		}
+	}
+
+	return pending
+}
+
+// rtnetlinkRoutes implements routeKernel with rtnetlink.
+type rtnetlinkRoutes struct {
+	conn *rtnetlink.Conn
+}
+
+func (k *rtnetlinkRoutes) exists(spec network.RouteSpecSpec) bool {
+	msg, err := routeMessage(spec)
+	if err != nil {
+		return false
+	}
+
+	routes, err := k.conn.Route.List()
+	if err != nil {
+		return false
+	}
+
+	for i := range routes {
+		if routeMatches(msg, &routes[i]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (k *rtnetlinkRoutes) add(spec network.RouteSpecSpec) (bool, error) {
+	return addRoute(k.conn, spec)
+}
+
+func (k *rtnetlinkRoutes) remove(spec network.RouteSpecSpec) error {
+	return deleteRoute(k.conn, spec)
+}
+
+func routeTable(msg *rtnetlink.RouteMessage) uint32 {
+	if msg.Attributes.Table != 0 {
+		return msg.Attributes.Table
+	}
+
+	return uint32(msg.Table)
+}
+
+// routeMatches checks whether the route from the routing table is the route described by the message.
+func routeMatches(msg, route *rtnetlink.RouteMessage) bool {
+	if route.Family != msg.Family || route.DstLength != msg.DstLength || routeTable(route) != routeTable(msg) {
+		return false
+	}
+
+	if route.Attributes.OutIface != msg.Attributes.OutIface || route.Attributes.Priority != msg.Attributes.Priority {
+		return false
+	}
+
+	// kernel omits the destination of the default route
+	if msg.DstLength != 0 && !route.Attributes.Dst.Equal(msg.Attributes.Dst) {
+		return false
+	}
+
+	if msg.Attributes.Gateway == nil {
+		return route.Attributes.Gateway == nil
+	}
+
+	return route.Attributes.Gateway.Equal(msg.Attributes.Gateway)
+}
+
+func routeMessage(spec network.RouteSpecSpec) (*rtnetlink.RouteMessage, error) {
+	iface, err := net.InterfaceByName(spec.OutLinkName)
+	if err != nil {
+		return nil, err
+	}
+
+	_, dst, err := net.ParseCIDR(spec.Destination)
+	if err != nil {
+		return nil, err
+	}
+
+	family := uint8(unix.AF_INET6)
+	dstIP := dst.IP
+
+	if ip4 := dst.IP.To4(); ip4 != nil {
+		family = unix.AF_INET
+		dstIP = ip4
+	}
+
+	ones, _ := dst.Mask.Size()
+
+	msg := &rtnetlink.RouteMessage{
+		Family:    family,
+		DstLength: uint8(ones),
+		Table:     unix.RT_TABLE_MAIN,
+		Protocol:  unix.RTPROT_STATIC,
+		Scope:     unix.RT_SCOPE_LINK,
+		Type:      unix.RTN_UNICAST,
+		Attributes: rtnetlink.RouteAttributes{
+			Dst:      dstIP,
+			OutIface: uint32(iface.Index),
+			Priority: spec.Metric,
+		},
+	}
+
+	// table IDs above 255 don't fit into the header, and are passed as RTA_TABLE
+	if spec.Table != 0 {
+		msg.Table = unix.RT_TABLE_UNSPEC
+		msg.Attributes.Table = spec.Table
+
+		if spec.Table < 256 {
+			msg.Table = uint8(spec.Table)
+		}
+	}
+
+	if spec.Source != "" {
+		src := net.ParseIP(spec.Source)
+		if src == nil {
+			return nil, fmt.Errorf("invalid source %q", spec.Source)
+		}
+
+		if src4 := src.To4(); src4 != nil {
+			src = src4
+		}
+
+		msg.Attributes.Src = src
+	}
+
+	if spec.MTU != 0 {
+		msg.Attributes.Metrics = &rtnetlink.RouteMetrics{
+			MTU: spec.MTU,
+		}
+	}
+
+	if spec.Gateway != "" {
+		gw := net.ParseIP(spec.Gateway)
+		if gw == nil {
+			return nil, fmt.Errorf("invalid gateway %q", spec.Gateway)
+		}
+
+		if gw4 := gw.To4(); gw4 != nil {
+			gw = gw4
+		}
+
+		msg.Scope = unix.RT_SCOPE_UNIVERSE
+		msg.Attributes.Gateway = gw
+	}
+
+	return msg, nil
+}
+
+// addRoute adds the route, exists is set if the route was already in the routing table.
+func addRoute(conn *rtnetlink.Conn, spec network.RouteSpecSpec) (exists bool, err error) {
+	msg, err := routeMessage(spec)
+	if err != nil {
+		return false, err
+	}
+
+	err = conn.Route.Add(msg)
+
+	var opErr *netlink.OpError
+
+	// route might have been added by networkd since the check
+	if errors.As(err, &opErr) && os.IsExist(opErr.Err) {
+		return true, nil
+	}
+
+	return false, err
+}
+
+func deleteRoute(conn *rtnetlink.Conn, spec network.RouteSpecSpec) error {
+	msg, err := routeMessage(spec)
+	if err != nil {
+		// link is gone along with the route
+		return nil //nolint:nilerr
+	}
+
+	err = conn.Route.Delete(msg)
+
+	var opErr *netlink.OpError
+
+	if errors.As(err, &opErr) && errors.Is(opErr.Err, unix.ESRCH) {
+		return nil
+	}
+
+	return err
+}
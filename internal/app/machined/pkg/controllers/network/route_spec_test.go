@@ -0,0 +1,269 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package network
+
+import (
+	"errors"
+	"io/ioutil"
+	"log"
+	"net"
+	"testing"
+
+	"github.com/jsimonetti/rtnetlink"
+	"github.com/stretchr/testify/assert"
+	"github.com/talos-systems/os-runtime/pkg/resource"
+	"golang.org/x/sys/unix"
+
+	"github.com/talos-systems/talos/pkg/resources/network"
+)
+
+type fakeRouteKernel struct {
+	routes  map[network.RouteSpecSpec]struct{}
+	failing map[network.RouteSpecSpec]struct{}
+}
+
+func (k *fakeRouteKernel) exists(spec network.RouteSpecSpec) bool {
+	_, ok := k.routes[spec]
+
+	return ok
+}
+
+func (k *fakeRouteKernel) add(spec network.RouteSpecSpec) (bool, error) {
+	if _, ok := k.failing[spec]; ok {
+		return false, errors.New("network is unreachable")
+	}
+
+	if k.exists(spec) {
+		return true, nil
+	}
+
+	k.routes[spec] = struct{}{}
+
+	return false, nil
+}
+
+func (k *fakeRouteKernel) remove(spec network.RouteSpecSpec) error {
+	delete(k.routes, spec)
+
+	return nil
+}
+
+func routeSpecID(spec network.RouteSpecSpec) resource.ID {
+	return network.RouteID(spec.Table, spec.OutLinkName, spec.Destination, spec.Gateway)
+}
+
+func TestRouteSpecReconcile(t *testing.T) {
+	logger := log.New(ioutil.Discard, "", 0)
+
+	static := network.RouteSpecSpec{Destination: "10.0.0.0/8", Gateway: "172.20.0.1", OutLinkName: "eth0", Metric: defaultRouteMetric}
+	dhcp := network.RouteSpecSpec{Destination: "0.0.0.0/0", Gateway: "10.5.0.1", OutLinkName: "eth1", Metric: 1024}
+	kubespan := network.RouteSpecSpec{Destination: "fd00::/64", OutLinkName: "kubespan", Table: 180}
+
+	staticID, dhcpID, kubespanID := routeSpecID(static), routeSpecID(dhcp), routeSpecID(kubespan)
+
+	// static and DHCP routes are added by networkd at boot
+	kernel := &fakeRouteKernel{
+		routes: map[network.RouteSpecSpec]struct{}{
+			static: {},
+			dhcp:   {},
+		},
+		failing: map[network.RouteSpecSpec]struct{}{
+			kubespan: {},
+		},
+	}
+
+	ctrl := &RouteSpecController{
+		owned:   map[resource.ID]network.RouteSpecSpec{},
+		adopted: map[resource.ID]network.RouteSpecSpec{},
+	}
+
+	specs := map[resource.ID]network.RouteSpecSpec{
+		staticID:   static,
+		dhcpID:     dhcp,
+		kubespanID: kubespan,
+	}
+	configured := map[resource.ID]struct{}{
+		staticID: {},
+	}
+
+	assert.True(t, ctrl.reconcile(logger, kernel, specs, configured))
+
+	assert.Equal(t, map[resource.ID]network.RouteSpecSpec{staticID: static}, ctrl.owned)
+	assert.Equal(t, map[resource.ID]network.RouteSpecSpec{dhcpID: dhcp}, ctrl.adopted)
+
+	// link is up, the route is added on retry
+	delete(kernel.failing, kubespan)
+
+	assert.False(t, ctrl.reconcile(logger, kernel, specs, configured))
+
+	assert.Equal(t, map[resource.ID]network.RouteSpecSpec{staticID: static, kubespanID: kubespan}, ctrl.owned)
+	assert.True(t, kernel.exists(kubespan))
+
+	// owned route removed externally is added again
+	assert.NoError(t, kernel.remove(kubespan))
+
+	assert.False(t, ctrl.reconcile(logger, kernel, specs, configured))
+
+	assert.True(t, kernel.exists(kubespan))
+
+	// adopted route removed externally is added again, and owned
+	assert.NoError(t, kernel.remove(dhcp))
+
+	assert.False(t, ctrl.reconcile(logger, kernel, specs, configured))
+
+	assert.True(t, kernel.exists(dhcp))
+	assert.Equal(t, map[resource.ID]network.RouteSpecSpec{staticID: static, dhcpID: dhcp, kubespanID: kubespan}, ctrl.owned)
+	assert.Empty(t, ctrl.adopted)
+
+	// metric change replaces the route
+	updated := static
+	updated.Metric = 100
+
+	specs[staticID] = updated
+
+	assert.False(t, ctrl.reconcile(logger, kernel, specs, configured))
+
+	assert.False(t, kernel.exists(static))
+	assert.True(t, kernel.exists(updated))
+
+	// routes removed from the specs are removed from the routing table
+	assert.False(t, ctrl.reconcile(logger, kernel, map[resource.ID]network.RouteSpecSpec{}, map[resource.ID]struct{}{}))
+
+	assert.Empty(t, kernel.routes)
+	assert.Empty(t, ctrl.owned)
+}
+
+func TestRouteSpecReconcileAdopted(t *testing.T) {
+	logger := log.New(ioutil.Discard, "", 0)
+
+	dhcp := network.RouteSpecSpec{Destination: "0.0.0.0/0", Gateway: "10.5.0.1", OutLinkName: "eth1", Metric: 1024}
+	dhcpID := routeSpecID(dhcp)
+
+	kernel := &fakeRouteKernel{
+		routes: map[network.RouteSpecSpec]struct{}{
+			dhcp: {},
+		},
+	}
+
+	ctrl := &RouteSpecController{
+		owned:   map[resource.ID]network.RouteSpecSpec{},
+		adopted: map[resource.ID]network.RouteSpecSpec{},
+	}
+
+	assert.False(t, ctrl.reconcile(logger, kernel, map[resource.ID]network.RouteSpecSpec{dhcpID: dhcp}, map[resource.ID]struct{}{}))
+	assert.Equal(t, map[resource.ID]network.RouteSpecSpec{dhcpID: dhcp}, ctrl.adopted)
+
+	// adopted routes are never removed
+	assert.False(t, ctrl.reconcile(logger, kernel, map[resource.ID]network.RouteSpecSpec{}, map[resource.ID]struct{}{}))
+
+	assert.True(t, kernel.exists(dhcp))
+	assert.Empty(t, ctrl.adopted)
+	assert.Empty(t, ctrl.owned)
+}
+
+func TestRouteMatches(t *testing.T) {
+	msg := func(dst, gw string, table uint32) *rtnetlink.RouteMessage {
+		_, ipNet, err := net.ParseCIDR(dst)
+		if err != nil {
+			panic(err)
+		}
+
+		ones, _ := ipNet.Mask.Size()
+
+		m := &rtnetlink.RouteMessage{
+			Family:    unix.AF_INET,
+			DstLength: uint8(ones),
+			Table:     unix.RT_TABLE_MAIN,
+			Attributes: rtnetlink.RouteAttributes{
+				Dst:      ipNet.IP.To4(),
+				OutIface: 2,
+				Priority: 10,
+			},
+		}
+
+		if gw != "" {
+			m.Attributes.Gateway = net.ParseIP(gw).To4()
+		}
+
+		if table != 0 {
+			m.Table = unix.RT_TABLE_UNSPEC
+			m.Attributes.Table = table
+		}
+
+		return m
+	}
+
+	for _, tt := range []struct {
+		name    string
+		msg     *rtnetlink.RouteMessage
+		route   *rtnetlink.RouteMessage
+		matches bool
+	}{
+		{
+			name:    "same",
+			msg:     msg("10.0.0.0/8", "172.20.0.1", 0),
+			route:   msg("10.0.0.0/8", "172.20.0.1", 0),
+			matches: true,
+		},
+		{
+			name: "default route without destination",
+			msg:  msg("0.0.0.0/0", "172.20.0.1", 0),
+			route: func() *rtnetlink.RouteMessage {
+				m := msg("0.0.0.0/0", "172.20.0.1", 0)
+				m.Attributes.Dst = nil
+
+				return m
+			}(),
+			matches: true,
+		},
+		{
+			name: "main table in the attribute",
+			msg:  msg("10.0.0.0/8", "", 0),
+			route: func() *rtnetlink.RouteMessage {
+				m := msg("10.0.0.0/8", "", 0)
+				m.Attributes.Table = unix.RT_TABLE_MAIN
+
+				return m
+			}(),
+			matches: true,
+		},
+		{
+			name:  "different destination",
+			msg:   msg("10.0.0.0/8", "172.20.0.1", 0),
+			route: msg("10.0.0.0/16", "172.20.0.1", 0),
+		},
+		{
+			name:  "different gateway",
+			msg:   msg("10.0.0.0/8", "172.20.0.1", 0),
+			route: msg("10.0.0.0/8", "172.20.0.2", 0),
+		},
+		{
+			name:  "gateway vs link-scoped",
+			msg:   msg("10.0.0.0/8", "", 0),
+			route: msg("10.0.0.0/8", "172.20.0.1", 0),
+		},
+		{
+			name:  "different table",
+			msg:   msg("10.0.0.0/8", "", 0),
+			route: msg("10.0.0.0/8", "", 1000),
+		},
+		{
+			name: "different metric",
+			msg:  msg("10.0.0.0/8", "", 0),
+			route: func() *rtnetlink.RouteMessage {
+				m := msg("10.0.0.0/8", "", 0)
+				m.Attributes.Priority = 1024
+
+				return m
+			}(),
+		},
+	} {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.matches, routeMatches(tt.msg, tt.route))
+		})
+	}
+}
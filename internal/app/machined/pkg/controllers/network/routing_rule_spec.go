@@ -0,0 +1,251 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package network
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"time"
+
+	"github.com/mdlayher/netlink"
+	"github.com/talos-systems/os-runtime/pkg/controller"
+	"github.com/talos-systems/os-runtime/pkg/resource"
+	"golang.org/x/sys/unix"
+
+	"github.com/talos-systems/talos/pkg/resources/network"
+)
+
+// RoutingRuleSpecController applies RoutingRuleSpecs to the kernel routing policy database.
+type RoutingRuleSpecController struct {
+	// rules applied by the controller, removed when the spec goes away
+	applied map[resource.ID]network.RoutingRuleSpecSpec
+}
+
+// Name implements controller.Controller interface.
+func (ctrl *RoutingRuleSpecController) Name() string {
+	return "network.RoutingRuleSpecController"
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *RoutingRuleSpecController) Inputs() []controller.Input {
+	return []controller.Input{
+		{
+			Namespace: network.NamespaceName,
+			Type:      network.RoutingRuleSpecType,
+			Kind:      controller.InputWeak,
+		},
+	}
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *RoutingRuleSpecController) Outputs() []controller.Output {
+	return nil
+}
+
+// Run implements controller.Controller interface.
+func (ctrl *RoutingRuleSpecController) Run(ctx context.Context, r controller.Runtime, logger *log.Logger) error {
+	if ctrl.applied == nil {
+		ctrl.applied = map[resource.ID]network.RoutingRuleSpecSpec{}
+	}
+
+	var retryCh <-chan time.Time
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+		case <-retryCh:
+		}
+
+		list, err := r.List(ctx, resource.NewMetadata(network.NamespaceName, network.RoutingRuleSpecType, "", resource.VersionUndefined))
+		if err != nil {
+			return fmt.Errorf("error listing routing rule specs: %w", err)
+		}
+
+		specs := make(map[resource.ID]network.RoutingRuleSpecSpec, len(list.Items))
+
+		for _, res := range list.Items {
+			specs[res.Metadata().ID()] = *res.(*network.RoutingRuleSpec).TypedSpec()
+		}
+
+		pending, err := ctrl.reconcile(logger, specs)
+		if err != nil {
+			return err
+		}
+
+		retryCh = nil
+
+		if pending {
+			retryCh = time.After(retryInterval)
+		}
+	}
+}
+
+func (ctrl *RoutingRuleSpecController) reconcile(logger *log.Logger, specs map[resource.ID]network.RoutingRuleSpecSpec) (pending bool, err error) {
+	conn, err := netlink.Dial(unix.NETLINK_ROUTE, nil)
+	if err != nil {
+		return false, fmt.Errorf("error dialing netlink: %w", err)
+	}
+
+	//nolint:errcheck
+	defer conn.Close()
+
+	for id, spec := range ctrl.applied {
+		if newSpec, ok := specs[id]; ok && newSpec == spec {
+			continue
+		}
+
+		if err = deleteRule(conn, spec); err != nil {
+			logger.Printf("failed to remove routing rule %q: %s", id, err)
+
+			pending = true
+
+			continue
+		}
+
+		logger.Printf("removed routing rule %q", id)
+
+		delete(ctrl.applied, id)
+	}
+
+	for id, spec := range specs {
+		if _, ok := ctrl.applied[id]; ok {
+			continue
+		}
+
+		if err = addRule(conn, spec); err != nil {
+			logger.Printf("failed to add routing rule %q: %s", id, err)
+
+			pending = true
+
+			continue
+		}
+
+		logger.Printf("added routing rule %q", id)
+
+		ctrl.applied[id] = spec
+	}
+
+	return pending, nil
+}
+
+// ruleMessage encodes struct fib_rule_hdr followed by the rule attributes.
+//
+// rtnetlink package doesn't support routing rules, so the message is encoded manually.
+func ruleMessage(spec network.RoutingRuleSpecSpec) ([]byte, error) {
+	family := uint8(unix.AF_INET)
+	if spec.Family == network.FamilyInet6 {
+		family = unix.AF_INET6
+	}
+
+	// family, dst_len, src_len, tos, table, res1, res2, action, flags (u32)
+	hdr := make([]byte, 12)
+	hdr[0] = family
+	hdr[7] = unix.FR_ACT_TO_TBL
+
+	encoder := netlink.NewAttributeEncoder()
+
+	for _, selector := range []struct {
+		cidr   string
+		lenIdx int
+		attr   uint16
+	}{
+		{spec.To, 1, unix.FRA_DST},
+		{spec.From, 2, unix.FRA_SRC},
+	} {
+		if selector.cidr == "" {
+			continue
+		}
+
+		_, ipNet, err := net.ParseCIDR(selector.cidr)
+		if err != nil {
+			return nil, err
+		}
+
+		ip := ipNet.IP
+		if family == unix.AF_INET {
+			ip = ip.To4()
+		}
+
+		ones, _ := ipNet.Mask.Size()
+
+		hdr[selector.lenIdx] = uint8(ones)
+		encoder.Bytes(selector.attr, ip)
+	}
+
+	// table IDs above 255 don't fit into the header, and are passed as FRA_TABLE
+	if spec.Table < 256 {
+		hdr[4] = uint8(spec.Table)
+	}
+
+	encoder.Uint32(unix.FRA_TABLE, spec.Table)
+
+	if spec.Priority != 0 {
+		encoder.Uint32(unix.FRA_PRIORITY, spec.Priority)
+	}
+
+	if spec.FwMark != 0 {
+		encoder.Uint32(unix.FRA_FWMARK, spec.FwMark)
+	}
+
+	attrs, err := encoder.Encode()
+	if err != nil {
+		return nil, err
+	}
+
+	return append(hdr, attrs...), nil
+}
+
+func addRule(conn *netlink.Conn, spec network.RoutingRuleSpecSpec) error {
+	data, err := ruleMessage(spec)
+	if err != nil {
+		return err
+	}
+
+	_, err = conn.Execute(netlink.Message{
+		Header: netlink.Header{
+			Type:  unix.RTM_NEWRULE,
+			Flags: netlink.Request | netlink.Acknowledge | netlink.Create | netlink.Excl,
+		},
+		Data: data,
+	})
+
+	var opErr *netlink.OpError
+
+	// rule might have been already added
+	if errors.As(err, &opErr) && os.IsExist(opErr.Err) {
+		return nil
+	}
+
+	return err
+}
+
+func deleteRule(conn *netlink.Conn, spec network.RoutingRuleSpecSpec) error {
+	data, err := ruleMessage(spec)
+	if err != nil {
+		return err
+	}
+
+	_, err = conn.Execute(netlink.Message{
+		Header: netlink.Header{
+			Type:  unix.RTM_DELRULE,
+			Flags: netlink.Request | netlink.Acknowledge,
+		},
+		Data: data,
+	})
+
+	var opErr *netlink.OpError
+
+	if errors.As(err, &opErr) && errors.Is(opErr.Err, unix.ENOENT) {
+		return nil
+	}
+
+	return err
+}
@@ -0,0 +1,138 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package network
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/talos-systems/os-runtime/pkg/controller"
+	"github.com/talos-systems/os-runtime/pkg/resource"
+	"golang.zx2c4.com/wireguard/wgctrl"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+
+	"github.com/talos-systems/talos/pkg/resources/network"
+)
+
+// wireguardPollInterval is the interval between polls of the Wireguard peer state.
+//
+// Handshakes and traffic counters don't generate netlink events, so the state is polled.
+const wireguardPollInterval = 15 * time.Second
+
+// WireguardPeerStatusController reports the state of the Wireguard peers as WireguardPeerStatus resources.
+type WireguardPeerStatusController struct{}
+
+// Name implements controller.Controller interface.
+func (ctrl *WireguardPeerStatusController) Name() string {
+	return "network.WireguardPeerStatusController"
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *WireguardPeerStatusController) Inputs() []controller.Input {
+	return nil
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *WireguardPeerStatusController) Outputs() []controller.Output {
+	return []controller.Output{
+		{
+			Type: network.WireguardPeerStatusType,
+			Kind: controller.OutputExclusive,
+		},
+	}
+}
+
+// Run implements controller.Controller interface.
+func (ctrl *WireguardPeerStatusController) Run(ctx context.Context, r controller.Runtime, logger *log.Logger) error {
+	ticker := time.NewTicker(wireguardPollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := ctrl.reconcile(ctx, r); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+		case <-ticker.C:
+		}
+	}
+}
+
+func (ctrl *WireguardPeerStatusController) reconcile(ctx context.Context, r controller.Runtime) error {
+	wgClient, err := wgctrl.New()
+	if err != nil {
+		return fmt.Errorf("error creating wireguard client: %w", err)
+	}
+
+	defer wgClient.Close() //nolint:errcheck
+
+	devices, err := wgClient.Devices()
+	if err != nil {
+		return fmt.Errorf("error listing wireguard devices: %w", err)
+	}
+
+	touchedIDs := map[resource.ID]struct{}{}
+
+	for _, device := range devices {
+		for _, peer := range device.Peers {
+			peer := peer
+			id := network.WireguardPeerID(device.Name, peer.PublicKey.String())
+
+			if err = r.Modify(ctx, network.NewWireguardPeerStatus(id), func(r resource.Resource) error {
+				*r.(*network.WireguardPeerStatus).TypedSpec() = peerStatus(device.Name, &peer)
+
+				return nil
+			}); err != nil {
+				return fmt.Errorf("error updating wireguard peer status: %w", err)
+			}
+
+			touchedIDs[id] = struct{}{}
+		}
+	}
+
+	list, err := r.List(ctx, resource.NewMetadata(network.NamespaceName, network.WireguardPeerStatusType, "", resource.VersionUndefined))
+	if err != nil {
+		return fmt.Errorf("error listing wireguard peer statuses: %w", err)
+	}
+
+	for _, res := range list.Items {
+		if _, touched := touchedIDs[res.Metadata().ID()]; touched {
+			continue
+		}
+
+		if err = r.Destroy(ctx, res.Metadata()); err != nil {
+			return fmt.Errorf("error cleaning up wireguard peer status: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func peerStatus(linkName string, peer *wgtypes.Peer) network.WireguardPeerStatusSpec {
+	status := network.WireguardPeerStatusSpec{
+		LinkName:                    linkName,
+		PublicKey:                   peer.PublicKey.String(),
+		AllowedIPs:                  make([]string, 0, len(peer.AllowedIPs)),
+		LastHandshakeTime:           peer.LastHandshakeTime,
+		PersistentKeepaliveInterval: peer.PersistentKeepaliveInterval,
+		ReceiveBytes:                peer.ReceiveBytes,
+		TransmitBytes:               peer.TransmitBytes,
+	}
+
+	if peer.Endpoint != nil {
+		status.Endpoint = peer.Endpoint.String()
+	}
+
+	for _, ipNet := range peer.AllowedIPs {
+		status.AllowedIPs = append(status.AllowedIPs, ipNet.String())
+	}
+
+	return status
+}
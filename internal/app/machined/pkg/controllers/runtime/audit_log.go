@@ -0,0 +1,198 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/talos-systems/os-runtime/pkg/controller"
+	"github.com/talos-systems/os-runtime/pkg/resource"
+	"github.com/talos-systems/os-runtime/pkg/state"
+
+	"github.com/talos-systems/talos/internal/pkg/audit"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+	"github.com/talos-systems/talos/pkg/resources/cluster"
+)
+
+// AuditLogController persists the audit log of the machine API calls in the STATE partition.
+//
+// Entries recorded before STATE is mounted are kept in memory and persisted once STATE is available,
+// the entries persisted on the previous boots are loaded back into the log.
+type AuditLogController struct {
+	Log *audit.Log
+
+	loaded    bool
+	persisted uint64
+	stored    int
+}
+
+// Name implements controller.Controller interface.
+func (ctrl *AuditLogController) Name() string {
+	return "runtime.AuditLogController"
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *AuditLogController) Inputs() []controller.Input {
+	return []controller.Input{
+		{
+			Namespace: cluster.NamespaceName,
+			Type:      cluster.IdentityType,
+			ID:        pointer.ToString(cluster.LocalIdentity),
+			Kind:      controller.InputWeak,
+		},
+	}
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *AuditLogController) Outputs() []controller.Output {
+	return nil
+}
+
+// Run implements controller.Controller interface.
+//
+// Log is stored in the STATE partition, so the controller waits for the node identity
+// to be loaded (which happens once STATE is mounted).
+func (ctrl *AuditLogController) Run(ctx context.Context, r controller.Runtime, logger *log.Logger) error {
+	var retryCh <-chan time.Time
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+		case <-ctrl.Log.Notify():
+		case <-retryCh:
+		}
+
+		retryCh = nil
+
+		_, err := r.Get(ctx, resource.NewMetadata(cluster.NamespaceName, cluster.IdentityType, cluster.LocalIdentity, resource.VersionUndefined))
+		if err != nil {
+			if !state.IsNotFoundError(err) {
+				return fmt.Errorf("error getting node identity: %w", err)
+			}
+
+			// STATE is not mounted yet
+			continue
+		}
+
+		if !ctrl.loaded {
+			if err = ctrl.load(); err != nil {
+				logger.Printf("error loading audit log: %s", err)
+
+				retryCh = time.After(retryInterval)
+
+				continue
+			}
+
+			ctrl.loaded = true
+		}
+
+		if err = ctrl.persist(); err != nil {
+			logger.Printf("error persisting audit log: %s", err)
+
+			retryCh = time.After(retryInterval)
+		}
+	}
+}
+
+// load restores the entries persisted on the previous boots.
+func (ctrl *AuditLogController) load() error {
+	f, err := os.Open(constants.AuditLogPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	//nolint:errcheck
+	defer f.Close()
+
+	entries, err := audit.ReadEntries(f)
+	if err != nil {
+		return err
+	}
+
+	ctrl.Log.Restore(entries)
+	ctrl.stored = len(entries)
+
+	return nil
+}
+
+// persist appends the new entries to the stored log.
+//
+// Stored log is rewritten with the entries kept in memory once it grows twice above the limit.
+func (ctrl *AuditLogController) persist() error {
+	entries, appended := ctrl.Log.Since(ctrl.persisted)
+	if len(entries) == 0 {
+		return nil
+	}
+
+	if ctrl.stored+len(entries) > 2*constants.MaxAuditLogEntries {
+		var err error
+
+		if appended, err = ctrl.rewrite(); err != nil {
+			return err
+		}
+	} else {
+		f, err := os.OpenFile(constants.AuditLogPath, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+		if err != nil {
+			return err
+		}
+
+		if err = audit.WriteEntries(f, entries); err != nil {
+			f.Close() //nolint:errcheck
+
+			return err
+		}
+
+		if err = f.Close(); err != nil {
+			return err
+		}
+
+		ctrl.stored += len(entries)
+	}
+
+	ctrl.persisted = appended
+
+	return nil
+}
+
+// rewrite replaces the stored log with the entries kept in memory.
+func (ctrl *AuditLogController) rewrite() (uint64, error) {
+	entries, appended := ctrl.Log.Snapshot()
+
+	tmpPath := constants.AuditLogPath + ".tmp"
+
+	f, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return 0, err
+	}
+
+	if err = audit.WriteEntries(f, entries); err != nil {
+		f.Close() //nolint:errcheck
+
+		return 0, err
+	}
+
+	if err = f.Close(); err != nil {
+		return 0, err
+	}
+
+	if err = os.Rename(tmpPath, constants.AuditLogPath); err != nil {
+		return 0, err
+	}
+
+	ctrl.stored = len(entries)
+
+	return appended, nil
+}
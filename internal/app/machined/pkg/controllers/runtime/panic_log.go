@@ -0,0 +1,357 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/talos-systems/os-runtime/pkg/controller"
+	"github.com/talos-systems/os-runtime/pkg/resource"
+	"github.com/talos-systems/os-runtime/pkg/state"
+
+	v1alpha1runtime "github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
+	"github.com/talos-systems/talos/internal/pkg/pstore"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+	"github.com/talos-systems/talos/pkg/resources/cluster"
+	"github.com/talos-systems/talos/pkg/resources/config"
+	"github.com/talos-systems/talos/pkg/resources/runtime"
+	"github.com/talos-systems/talos/pkg/resources/v1alpha1"
+)
+
+// panicLogExt is the extension of the panic log files in the STATE partition.
+const panicLogExt = ".log"
+
+// maxUDPLogSize is the maximum size of the log shipped over UDP, so that the message fits into a single datagram.
+const maxUDPLogSize = 60 * 1024
+
+// PanicLogController collects kernel panic logs of the previous boots from pstore into the STATE partition.
+//
+// Logs are removed from pstore once stored, so that the backend storage (e.g. reserved memory region) is freed.
+// Logs collected on this boot are shipped to the destination if it's configured.
+type PanicLogController struct {
+	V1Alpha1Mode v1alpha1runtime.Mode
+
+	collectedIDs []string
+	shipped      map[string]bool
+}
+
+// Name implements controller.Controller interface.
+func (ctrl *PanicLogController) Name() string {
+	return "runtime.PanicLogController"
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *PanicLogController) Inputs() []controller.Input {
+	return []controller.Input{
+		{
+			Namespace: config.NamespaceName,
+			Type:      config.MachineConfigType,
+			ID:        pointer.ToString(config.V1Alpha1ID),
+			Kind:      controller.InputWeak,
+		},
+		{
+			Namespace: cluster.NamespaceName,
+			Type:      cluster.IdentityType,
+			ID:        pointer.ToString(cluster.LocalIdentity),
+			Kind:      controller.InputWeak,
+		},
+	}
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *PanicLogController) Outputs() []controller.Output {
+	return []controller.Output{
+		{
+			Type: runtime.PanicLogType,
+			Kind: controller.OutputExclusive,
+		},
+	}
+}
+
+// Run implements controller.Controller interface.
+//
+// Logs are stored in the STATE partition, so the controller waits for the node identity
+// to be loaded (which happens once STATE is mounted).
+//
+//nolint:gocyclo
+func (ctrl *PanicLogController) Run(ctx context.Context, r controller.Runtime, logger *log.Logger) error {
+	// pstore is not available in the container
+	if ctrl.V1Alpha1Mode == v1alpha1runtime.ModeContainer {
+		return nil
+	}
+
+	var (
+		retryCh   <-chan time.Time
+		collected bool
+	)
+
+	if ctrl.shipped == nil {
+		ctrl.shipped = map[string]bool{}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+		case <-retryCh:
+		}
+
+		retryCh = nil
+
+		cfg, err := r.Get(ctx, resource.NewMetadata(config.NamespaceName, config.MachineConfigType, config.V1Alpha1ID, resource.VersionUndefined))
+		if err != nil && !state.IsNotFoundError(err) {
+			return fmt.Errorf("error getting config: %w", err)
+		}
+
+		_, err = r.Get(ctx, resource.NewMetadata(cluster.NamespaceName, cluster.IdentityType, cluster.LocalIdentity, resource.VersionUndefined))
+		if err != nil {
+			if !state.IsNotFoundError(err) {
+				return fmt.Errorf("error getting node identity: %w", err)
+			}
+
+			// STATE is not mounted yet
+			continue
+		}
+
+		if !collected {
+			if err = ctrl.collect(logger); err != nil {
+				logger.Printf("error collecting panic logs: %s", err)
+
+				retryCh = time.After(retryInterval)
+
+				continue
+			}
+
+			collected = true
+		}
+
+		if cfg != nil {
+			if destination := cfg.(*config.MachineConfig).Config().Machine().CrashDumps().Destination(); destination != "" {
+				for _, id := range ctrl.collectedIDs {
+					if ctrl.shipped[id] {
+						continue
+					}
+
+					if err = ship(destination, id); err != nil {
+						logger.Printf("error shipping panic log %q: %s", id, err)
+
+						retryCh = time.After(retryInterval)
+
+						continue
+					}
+
+					ctrl.shipped[id] = true
+				}
+			}
+		}
+
+		if err = ctrl.updateResources(ctx, r); err != nil {
+			return err
+		}
+	}
+}
+
+// collect moves the dumps from pstore to the STATE partition and removes the oldest logs above the limit.
+func (ctrl *PanicLogController) collect(logger *log.Logger) error {
+	dumps, err := pstore.ReadDumps(constants.PstoreMountPoint)
+	if err != nil {
+		return fmt.Errorf("error reading pstore: %w", err)
+	}
+
+	if len(dumps) > 0 {
+		if err = os.MkdirAll(constants.PanicLogsPath, 0o700); err != nil {
+			return err
+		}
+	}
+
+	for i := range dumps {
+		dump := &dumps[i]
+		id := dump.ID()
+
+		if err = ioutil.WriteFile(filepath.Join(constants.PanicLogsPath, id+panicLogExt), dump.Log, 0o600); err != nil {
+			return fmt.Errorf("error storing panic log %q: %w", id, err)
+		}
+
+		if err = dump.Remove(constants.PstoreMountPoint); err != nil {
+			return fmt.Errorf("error removing panic log %q from pstore: %w", id, err)
+		}
+
+		logger.Printf("collected %s log of the previous boot %q: %s", dump.Kind, id, dump.Reason())
+
+		ctrl.collectedIDs = append(ctrl.collectedIDs, id)
+	}
+
+	ids, err := storedLogs()
+	if err != nil {
+		return err
+	}
+
+	// IDs start with the timestamp, so the oldest logs come first
+	for len(ids) > constants.MaxPanicLogs {
+		if err = os.Remove(filepath.Join(constants.PanicLogsPath, ids[0]+panicLogExt)); err != nil {
+			return err
+		}
+
+		ids = ids[1:]
+	}
+
+	return nil
+}
+
+func (ctrl *PanicLogController) updateResources(ctx context.Context, r controller.Runtime) error {
+	ids, err := storedLogs()
+	if err != nil {
+		return err
+	}
+
+	touchedIDs := map[resource.ID]struct{}{}
+
+	for _, id := range ids {
+		timestamp, backend, kind, err := pstore.ParseID(id)
+		if err != nil {
+			// not a panic log
+			continue
+		}
+
+		path := filepath.Join(constants.PanicLogsPath, id+panicLogExt)
+
+		contents, err := ioutil.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("error reading panic log %q: %w", id, err)
+		}
+
+		if err = r.Modify(ctx, runtime.NewPanicLog(id), func(r resource.Resource) error {
+			*r.(*runtime.PanicLog).TypedSpec() = runtime.PanicLogSpec{
+				Timestamp: timestamp,
+				Backend:   backend,
+				Kind:      kind,
+				Reason:    pstore.Reason(contents),
+				Path:      path,
+				Size:      len(contents),
+				Shipped:   ctrl.shipped[id],
+			}
+
+			return nil
+		}); err != nil {
+			return fmt.Errorf("error updating panic log: %w", err)
+		}
+
+		touchedIDs[id] = struct{}{}
+	}
+
+	list, err := r.List(ctx, resource.NewMetadata(v1alpha1.NamespaceName, runtime.PanicLogType, "", resource.VersionUndefined))
+	if err != nil {
+		return fmt.Errorf("error listing panic logs: %w", err)
+	}
+
+	for _, res := range list.Items {
+		if _, ok := touchedIDs[res.Metadata().ID()]; !ok {
+			if err = r.Destroy(ctx, res.Metadata()); err != nil {
+				return fmt.Errorf("error cleaning up panic log: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// storedLogs returns sorted IDs of the panic logs in the STATE partition.
+func storedLogs() ([]string, error) {
+	entries, err := ioutil.ReadDir(constants.PanicLogsPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	var ids []string
+
+	for _, entry := range entries {
+		if entry.Mode().IsRegular() && strings.HasSuffix(entry.Name(), panicLogExt) {
+			ids = append(ids, strings.TrimSuffix(entry.Name(), panicLogExt))
+		}
+	}
+
+	sort.Strings(ids)
+
+	return ids, nil
+}
+
+type panicLogMessage struct {
+	Hostname  string    `json:"hostname"`
+	ID        string    `json:"id"`
+	Timestamp time.Time `json:"timestamp"`
+	Backend   string    `json:"backend"`
+	Kind      string    `json:"kind"`
+	Reason    string    `json:"reason"`
+	Log       string    `json:"log"`
+}
+
+// ship sends the panic log as a single JSON message to the destination.
+func ship(destination, id string) error {
+	u, err := url.Parse(destination)
+	if err != nil {
+		return err
+	}
+
+	contents, err := ioutil.ReadFile(filepath.Join(constants.PanicLogsPath, id+panicLogExt))
+	if err != nil {
+		return err
+	}
+
+	msg := panicLogMessage{
+		ID:     id,
+		Reason: pstore.Reason(contents),
+		Log:    string(contents),
+	}
+
+	if msg.Timestamp, msg.Backend, msg.Kind, err = pstore.ParseID(id); err != nil {
+		return err
+	}
+
+	msg.Hostname, _ = os.Hostname() //nolint:errcheck
+
+	// keep the end of the log which has the panic details
+	if u.Scheme == "udp" && len(msg.Log) > maxUDPLogSize {
+		msg.Log = msg.Log[len(msg.Log)-maxUDPLogSize:]
+	}
+
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	conn, err := net.DialTimeout(u.Scheme, u.Host, 10*time.Second)
+	if err != nil {
+		return err
+	}
+
+	defer conn.Close() //nolint:errcheck
+
+	if err = conn.SetWriteDeadline(time.Now().Add(10 * time.Second)); err != nil {
+		return err
+	}
+
+	if _, err = conn.Write(append(payload, '\n')); err != nil {
+		return err
+	}
+
+	return conn.Close()
+}
@@ -0,0 +1,265 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/talos-systems/os-runtime/pkg/controller"
+	"github.com/talos-systems/os-runtime/pkg/resource"
+	"github.com/talos-systems/os-runtime/pkg/state"
+
+	"github.com/talos-systems/talos/internal/pkg/registration"
+	talosconfig "github.com/talos-systems/talos/pkg/machinery/config"
+	"github.com/talos-systems/talos/pkg/resources/cluster"
+	"github.com/talos-systems/talos/pkg/resources/config"
+	"github.com/talos-systems/talos/pkg/resources/runtime"
+	"github.com/talos-systems/talos/pkg/resources/v1alpha1"
+	"github.com/talos-systems/talos/pkg/version"
+)
+
+// RegistrationController registers the node with the external inventory API and sends the heartbeats.
+//
+// Node facts are collected on each config or identity change and on each heartbeat, the node registers
+// again whenever the facts change, so the inventory doesn't need to poll the nodes.
+type RegistrationController struct {
+	Platform string
+
+	endpoint   string
+	registered *registration.Node
+	status     runtime.RegistrationStatusSpec
+}
+
+// Name implements controller.Controller interface.
+func (ctrl *RegistrationController) Name() string {
+	return "runtime.RegistrationController"
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *RegistrationController) Inputs() []controller.Input {
+	return []controller.Input{
+		{
+			Namespace: config.NamespaceName,
+			Type:      config.MachineConfigType,
+			ID:        pointer.ToString(config.V1Alpha1ID),
+			Kind:      controller.InputWeak,
+		},
+		{
+			Namespace: cluster.NamespaceName,
+			Type:      cluster.IdentityType,
+			ID:        pointer.ToString(cluster.LocalIdentity),
+			Kind:      controller.InputWeak,
+		},
+	}
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *RegistrationController) Outputs() []controller.Output {
+	return []controller.Output{
+		{
+			Type: runtime.RegistrationStatusType,
+			Kind: controller.OutputExclusive,
+		},
+	}
+}
+
+// Run implements controller.Controller interface.
+//
+// Node ID is derived from the node identity, so the controller waits for the identity to be loaded.
+//
+//nolint:gocyclo
+func (ctrl *RegistrationController) Run(ctx context.Context, r controller.Runtime, logger *log.Logger) error {
+	var heartbeatCh <-chan time.Time
+
+	ctrl.endpoint, ctrl.registered = "", nil
+
+	for {
+		heartbeat := false
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+		case <-heartbeatCh:
+			heartbeat = true
+		}
+
+		cfg, err := r.Get(ctx, resource.NewMetadata(config.NamespaceName, config.MachineConfigType, config.V1Alpha1ID, resource.VersionUndefined))
+		if err != nil && !state.IsNotFoundError(err) {
+			return fmt.Errorf("error getting config: %w", err)
+		}
+
+		var cfgProvider talosconfig.Provider
+
+		if cfg != nil {
+			cfgProvider = cfg.(*config.MachineConfig).Config()
+		}
+
+		if cfgProvider == nil || cfgProvider.Machine().Registration().Endpoint() == "" {
+			heartbeatCh = nil
+			ctrl.endpoint, ctrl.registered = "", nil
+
+			if err = ctrl.cleanup(ctx, r); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		identity, err := r.Get(ctx, resource.NewMetadata(cluster.NamespaceName, cluster.IdentityType, cluster.LocalIdentity, resource.VersionUndefined))
+		if err != nil {
+			if !state.IsNotFoundError(err) {
+				return fmt.Errorf("error getting node identity: %w", err)
+			}
+
+			// STATE is not mounted yet
+			continue
+		}
+
+		registrationConfig := cfgProvider.Machine().Registration()
+
+		if ctrl.endpoint != registrationConfig.Endpoint() {
+			ctrl.endpoint, ctrl.registered = registrationConfig.Endpoint(), nil
+		}
+
+		node, err := nodeFacts(cfgProvider, ctrl.Platform, identity.(*cluster.Identity).IdentitySpec())
+		if err != nil {
+			return fmt.Errorf("error collecting node facts: %w", err)
+		}
+
+		// nothing changed since the last registration, keep the heartbeat schedule
+		if !heartbeat && heartbeatCh != nil && ctrl.registered != nil && ctrl.registered.Equal(node) {
+			continue
+		}
+
+		if err = ctrl.sync(ctx, registrationConfig, node, logger); err != nil {
+			logger.Printf("error registering with the inventory %q: %s", ctrl.endpoint, err)
+
+			ctrl.status.Registered = false
+			ctrl.status.LastError = err.Error()
+
+			heartbeatCh = time.After(retryInterval)
+		} else {
+			ctrl.status.Registered = true
+			ctrl.status.LastError = ""
+
+			heartbeatCh = time.After(registrationConfig.HeartbeatInterval())
+		}
+
+		ctrl.status.Endpoint = ctrl.endpoint
+		ctrl.status.NodeID = node.NodeID
+
+		if err = r.Modify(ctx, runtime.NewRegistrationStatus(runtime.RegistrationStatusID), func(r resource.Resource) error {
+			*r.(*runtime.RegistrationStatus).TypedSpec() = ctrl.status
+
+			return nil
+		}); err != nil {
+			return fmt.Errorf("error updating registration status: %w", err)
+		}
+	}
+}
+
+// sync registers the node if the facts changed since the last registration, and sends the heartbeat otherwise.
+func (ctrl *RegistrationController) sync(ctx context.Context, registrationConfig talosconfig.Registration, node *registration.Node, logger *log.Logger) error {
+	client, err := registration.NewClient(registrationConfig.Endpoint(), registrationConfig.Token())
+	if err != nil {
+		return err
+	}
+
+	if ctrl.registered != nil && ctrl.registered.Equal(node) {
+		err = client.Heartbeat(ctx, node.NodeID)
+
+		switch {
+		case err == nil:
+			ctrl.status.LastHeartbeat = time.Now()
+
+			return nil
+		case errors.Is(err, registration.ErrNotRegistered):
+			logger.Printf("node %q is not known to the inventory, registering again", node.NodeID)
+		default:
+			return err
+		}
+	}
+
+	ctrl.registered = nil
+
+	if err = client.Register(ctx, node); err != nil {
+		return err
+	}
+
+	logger.Printf("registered node %q with the inventory %q", node.NodeID, ctrl.endpoint)
+
+	ctrl.registered = node
+	ctrl.status.LastRegistration = time.Now()
+	ctrl.status.LastHeartbeat = ctrl.status.LastRegistration
+
+	return nil
+}
+
+func (ctrl *RegistrationController) cleanup(ctx context.Context, r controller.Runtime) error {
+	list, err := r.List(ctx, resource.NewMetadata(v1alpha1.NamespaceName, runtime.RegistrationStatusType, "", resource.VersionUndefined))
+	if err != nil {
+		return fmt.Errorf("error listing registration statuses: %w", err)
+	}
+
+	for _, res := range list.Items {
+		if err = r.Destroy(ctx, res.Metadata()); err != nil {
+			return fmt.Errorf("error cleaning up registration status: %w", err)
+		}
+	}
+
+	ctrl.status = runtime.RegistrationStatusSpec{}
+
+	return nil
+}
+
+// nodeFacts collects the facts reported to the inventory.
+func nodeFacts(cfg talosconfig.Provider, platform string, identity *cluster.IdentitySpec) (*registration.Node, error) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return nil, fmt.Errorf("error getting hostname: %w", err)
+	}
+
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return nil, fmt.Errorf("error listing node addresses: %w", err)
+	}
+
+	return &registration.Node{
+		NodeID:      identity.NodeID,
+		SystemUUID:  identity.SystemUUID,
+		Hostname:    hostname,
+		MachineType: cfg.Machine().Type().String(),
+		Platform:    platform,
+		Version:     version.Tag,
+		Addresses:   globalAddresses(addrs),
+	}, nil
+}
+
+// globalAddresses returns the sorted global unicast addresses, so that the facts can be compared.
+func globalAddresses(addrs []net.Addr) []string {
+	result := []string{}
+
+	for _, addr := range addrs {
+		ipNet, ok := addr.(*net.IPNet)
+		if !ok || !ipNet.IP.IsGlobalUnicast() {
+			continue
+		}
+
+		result = append(result, ipNet.IP.String())
+	}
+
+	sort.Strings(result)
+
+	return result
+}
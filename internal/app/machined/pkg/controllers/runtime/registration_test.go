@@ -0,0 +1,30 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGlobalAddresses(t *testing.T) {
+	var addrs []net.Addr
+
+	for _, cidr := range []string{"127.0.0.1/8", "fd00::2/64", "172.20.0.2/24", "fe80::1/64", "::1/128", "10.5.0.2/24"} {
+		ip, ipNet, err := net.ParseCIDR(cidr)
+		assert.NoError(t, err)
+
+		ipNet.IP = ip
+
+		addrs = append(addrs, ipNet)
+	}
+
+	addrs = append(addrs, &net.IPAddr{IP: net.ParseIP("192.168.0.1")})
+
+	assert.Equal(t, []string{"10.5.0.2", "172.20.0.2", "fd00::2"}, globalAddresses(addrs))
+	assert.Equal(t, []string{}, globalAddresses(nil))
+}
@@ -0,0 +1,11 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package runtime contains controllers which manage the node runtime state.
+package runtime
+
+import "time"
+
+// retryInterval is the interval between attempts if the operation failed.
+const retryInterval = 30 * time.Second
@@ -0,0 +1,117 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/AlekSi/pointer"
+	"github.com/talos-systems/os-runtime/pkg/controller"
+	"github.com/talos-systems/os-runtime/pkg/resource"
+	"github.com/talos-systems/os-runtime/pkg/state"
+
+	"github.com/talos-systems/talos/internal/pkg/tlspolicy"
+	talosconfig "github.com/talos-systems/talos/pkg/machinery/config"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1/machine"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+	"github.com/talos-systems/talos/pkg/resources/config"
+	"github.com/talos-systems/talos/pkg/resources/runtime"
+)
+
+// TLSPolicyController reports the TLS policy of the Talos API endpoints.
+//
+// apid and trustd apply the policy from the machine configuration they are started with.
+type TLSPolicyController struct{}
+
+// Name implements controller.Controller interface.
+func (ctrl *TLSPolicyController) Name() string {
+	return "runtime.TLSPolicyController"
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *TLSPolicyController) Inputs() []controller.Input {
+	return []controller.Input{
+		{
+			Namespace: config.NamespaceName,
+			Type:      config.MachineConfigType,
+			ID:        pointer.ToString(config.V1Alpha1ID),
+			Kind:      controller.InputWeak,
+		},
+	}
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *TLSPolicyController) Outputs() []controller.Output {
+	return []controller.Output{
+		{
+			Type: runtime.TLSPolicyStatusType,
+			Kind: controller.OutputExclusive,
+		},
+	}
+}
+
+// Run implements controller.Controller interface.
+func (ctrl *TLSPolicyController) Run(ctx context.Context, r controller.Runtime, logger *log.Logger) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+		}
+
+		cfg, err := r.Get(ctx, resource.NewMetadata(config.NamespaceName, config.MachineConfigType, config.V1Alpha1ID, resource.VersionUndefined))
+		if err != nil {
+			if !state.IsNotFoundError(err) {
+				return fmt.Errorf("error getting config: %w", err)
+			}
+
+			if err = r.Destroy(ctx, runtime.NewTLSPolicyStatus(runtime.TLSPolicyStatusID).Metadata()); err != nil && !state.IsNotFoundError(err) {
+				return fmt.Errorf("error cleaning up TLS policy status: %w", err)
+			}
+
+			continue
+		}
+
+		spec := tlsPolicyStatus(cfg.(*config.MachineConfig).Config())
+
+		if err = r.Modify(ctx, runtime.NewTLSPolicyStatus(runtime.TLSPolicyStatusID), func(r resource.Resource) error {
+			*r.(*runtime.TLSPolicyStatus).TypedSpec() = spec
+
+			return nil
+		}); err != nil {
+			return fmt.Errorf("error updating TLS policy status: %w", err)
+		}
+	}
+}
+
+// tlsPolicyStatus builds the TLS policy status from the machine configuration.
+func tlsPolicyStatus(cfg talosconfig.Provider) runtime.TLSPolicyStatusSpec {
+	endpoints := []string{fmt.Sprintf("apid:%d", constants.ApidPort)}
+
+	if cfg.Machine().Type() != machine.TypeJoin {
+		endpoints = append(endpoints, fmt.Sprintf("trustd:%d", constants.TrustdPort))
+	}
+
+	if cfg.Machine().Metrics().Enabled() {
+		endpoints = append(endpoints, fmt.Sprintf("metrics:%d", cfg.Machine().Metrics().Port()))
+	}
+
+	if !cfg.Machine().Features().StrictTLSEnabled() {
+		return runtime.TLSPolicyStatusSpec{
+			MinVersion: tlspolicy.VersionName(tlspolicy.DefaultMinVersion),
+			Endpoints:  endpoints,
+		}
+	}
+
+	return runtime.TLSPolicyStatusSpec{
+		Strict:       true,
+		MinVersion:   tlspolicy.VersionName(tlspolicy.MinVersion),
+		CipherSuites: tlspolicy.CipherSuiteNames(),
+		Curves:       tlspolicy.CurveNames(),
+		Endpoints:    endpoints,
+	}
+}
@@ -0,0 +1,40 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
+	"github.com/talos-systems/talos/pkg/resources/runtime"
+)
+
+func TestTLSPolicyStatus(t *testing.T) {
+	cfg := &v1alpha1.Config{
+		MachineConfig: &v1alpha1.MachineConfig{
+			MachineType: "worker",
+		},
+	}
+
+	assert.Equal(t, runtime.TLSPolicyStatusSpec{
+		MinVersion: "TLS 1.2",
+		Endpoints:  []string{"apid:50000"},
+	}, tlsPolicyStatus(cfg))
+
+	cfg.MachineConfig.MachineType = "controlplane"
+	cfg.MachineConfig.MachineFeatures = &v1alpha1.FeaturesConfig{
+		FeaturesStrictTLS: true,
+	}
+
+	assert.Equal(t, runtime.TLSPolicyStatusSpec{
+		Strict:       true,
+		MinVersion:   "TLS 1.3",
+		CipherSuites: []string{"TLS_AES_256_GCM_SHA384", "TLS_AES_128_GCM_SHA256"},
+		Curves:       []string{"P-384", "P-256"},
+		Endpoints:    []string{"apid:50000", "trustd:50001"},
+	}, tlsPolicyStatus(cfg))
+}
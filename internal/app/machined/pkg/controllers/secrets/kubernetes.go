@@ -50,9 +50,9 @@ func (ctrl *KubernetesController) Inputs() []controller.Input {
 			Kind:      controller.InputWeak,
 		},
 		{
-			Namespace: v1alpha1.NamespaceName,
-			Type:      v1alpha1.ServiceType,
-			ID:        pointer.ToString("networkd"),
+			Namespace: secrets.NamespaceName,
+			Type:      secrets.CertSANType,
+			ID:        pointer.ToString(secrets.CertSANKubernetesID),
 			Kind:      controller.InputWeak,
 		},
 		{
@@ -104,8 +104,8 @@ func (ctrl *KubernetesController) Run(ctx context.Context, r controller.Runtime,
 
 		k8sRoot := k8sRootRes.(*secrets.Root).KubernetesSpec()
 
-		// wait for networkd to be healthy as it might change IPs/hostname
-		networkdResource, err := r.Get(ctx, resource.NewMetadata(v1alpha1.NamespaceName, v1alpha1.ServiceType, "networkd", resource.VersionUndefined))
+		// cert SANs are built once networkd is healthy, as it might change IPs/hostname
+		certSANRes, err := r.Get(ctx, resource.NewMetadata(secrets.NamespaceName, secrets.CertSANType, secrets.CertSANKubernetesID, resource.VersionUndefined))
 		if err != nil {
 			if state.IsNotFoundError(err) {
 				continue
@@ -114,9 +114,7 @@ func (ctrl *KubernetesController) Run(ctx context.Context, r controller.Runtime,
 			return err
 		}
 
-		if !networkdResource.(*v1alpha1.Service).Healthy() {
-			continue
-		}
+		certSANs := certSANRes.(*secrets.CertSAN).TypedSpec()
 
 		// wait for time sync as certs depend on current time
 		timeSyncResource, err := r.Get(ctx, resource.NewMetadata(v1alpha1.NamespaceName, timeresource.StatusType, timeresource.StatusID, resource.VersionUndefined))
@@ -133,36 +131,22 @@ func (ctrl *KubernetesController) Run(ctx context.Context, r controller.Runtime,
 		}
 
 		if err = r.Modify(ctx, secrets.NewKubernetes(), func(r resource.Resource) error {
-			return ctrl.updateSecrets(k8sRoot, r.(*secrets.Kubernetes).Certs())
+			return ctrl.updateSecrets(k8sRoot, certSANs, r.(*secrets.Kubernetes).Certs())
 		}); err != nil {
 			return err
 		}
 	}
 }
 
-func (ctrl *KubernetesController) updateSecrets(k8sRoot *secrets.RootKubernetesSpec, k8sSecrets *secrets.KubernetesCertsSpec) error {
-	urls := []string{k8sRoot.Endpoint.Hostname()}
-	urls = append(urls, k8sRoot.CertSANs...)
-	altNames := altNamesFromURLs(urls)
-
-	altNames.IPs = append(altNames.IPs, k8sRoot.APIServerIPs...)
-
-	// Add kubernetes default svc with cluster domain to AltNames
-	altNames.DNSNames = append(altNames.DNSNames,
-		"kubernetes",
-		"kubernetes.default",
-		"kubernetes.default.svc",
-		"kubernetes.default.svc."+k8sRoot.DNSDomain,
-	)
-
+func (ctrl *KubernetesController) updateSecrets(k8sRoot *secrets.RootKubernetesSpec, certSANs *secrets.CertSANSpec, k8sSecrets *secrets.KubernetesCertsSpec) error {
 	ca, err := x509.NewCertificateAuthorityFromCertificateAndKey(k8sRoot.CA)
 	if err != nil {
 		return fmt.Errorf("failed to parse CA certificate: %w", err)
 	}
 
 	apiServer, err := x509.NewKeyPair(ca,
-		x509.IPAddresses(altNames.IPs),
-		x509.DNSNames(altNames.DNSNames),
+		x509.IPAddresses(certSANs.IPs),
+		x509.DNSNames(certSANs.DNSNames),
 		x509.CommonName("kube-apiserver"),
 		x509.Organization("kube-master"),
 		x509.NotAfter(time.Now().Add(KubernetesCertificateValidityDuration)),
@@ -0,0 +1,280 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	talosnet "github.com/talos-systems/net"
+	"github.com/talos-systems/os-runtime/pkg/controller"
+	"github.com/talos-systems/os-runtime/pkg/resource"
+	"github.com/talos-systems/os-runtime/pkg/state"
+
+	talosconfig "github.com/talos-systems/talos/pkg/machinery/config"
+	"github.com/talos-systems/talos/pkg/resources/config"
+	"github.com/talos-systems/talos/pkg/resources/secrets"
+	"github.com/talos-systems/talos/pkg/resources/v1alpha1"
+)
+
+// KubernetesCertSANsRefreshInterval is the interval between the checks of the node addresses.
+const KubernetesCertSANsRefreshInterval = 30 * time.Second
+
+// KubernetesCertSANsController manages secrets.CertSAN of kube-apiserver certificate.
+//
+// Certificate SANs include the cluster endpoint, configured cert SANs, the addresses of the node and the VIPs,
+// so that the certificate is regenerated when the node addresses change.
+type KubernetesCertSANsController struct{}
+
+// Name implements controller.Controller interface.
+func (ctrl *KubernetesCertSANsController) Name() string {
+	return "secrets.KubernetesCertSANsController"
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *KubernetesCertSANsController) Inputs() []controller.Input {
+	return []controller.Input{
+		{
+			Namespace: secrets.NamespaceName,
+			Type:      secrets.RootType,
+			ID:        pointer.ToString(secrets.RootKubernetesID),
+			Kind:      controller.InputWeak,
+		},
+		{
+			Namespace: config.NamespaceName,
+			Type:      config.MachineConfigType,
+			ID:        pointer.ToString(config.V1Alpha1ID),
+			Kind:      controller.InputWeak,
+		},
+		{
+			Namespace: v1alpha1.NamespaceName,
+			Type:      v1alpha1.ServiceType,
+			ID:        pointer.ToString("networkd"),
+			Kind:      controller.InputWeak,
+		},
+	}
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *KubernetesCertSANsController) Outputs() []controller.Output {
+	return []controller.Output{
+		{
+			Type: secrets.CertSANType,
+			Kind: controller.OutputExclusive,
+		},
+	}
+}
+
+// Run implements controller.Controller interface.
+//
+//nolint:gocyclo
+func (ctrl *KubernetesCertSANsController) Run(ctx context.Context, r controller.Runtime, logger *log.Logger) error {
+	// node addresses are not exposed as resources, so they are polled
+	refreshTicker := time.NewTicker(KubernetesCertSANsRefreshInterval)
+	defer refreshTicker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+		case <-refreshTicker.C:
+		}
+
+		k8sRootRes, err := r.Get(ctx, resource.NewMetadata(secrets.NamespaceName, secrets.RootType, secrets.RootKubernetesID, resource.VersionUndefined))
+		if err != nil {
+			if state.IsNotFoundError(err) {
+				if err = ctrl.teardownAll(ctx, r); err != nil {
+					return fmt.Errorf("error destroying resources: %w", err)
+				}
+
+				continue
+			}
+
+			return fmt.Errorf("error getting root k8s secrets: %w", err)
+		}
+
+		k8sRoot := k8sRootRes.(*secrets.Root).KubernetesSpec()
+
+		cfg, err := r.Get(ctx, resource.NewMetadata(config.NamespaceName, config.MachineConfigType, config.V1Alpha1ID, resource.VersionUndefined))
+		if err != nil {
+			if state.IsNotFoundError(err) {
+				continue
+			}
+
+			return fmt.Errorf("error getting config: %w", err)
+		}
+
+		cfgProvider := cfg.(*config.MachineConfig).Config()
+
+		// wait for networkd to be healthy as it might change IPs/hostname
+		networkdResource, err := r.Get(ctx, resource.NewMetadata(v1alpha1.NamespaceName, v1alpha1.ServiceType, "networkd", resource.VersionUndefined))
+		if err != nil {
+			if state.IsNotFoundError(err) {
+				continue
+			}
+
+			return err
+		}
+
+		if !networkdResource.(*v1alpha1.Service).Healthy() {
+			continue
+		}
+
+		nodeIPs, err := talosnet.IPAddrs()
+		if err != nil {
+			return fmt.Errorf("error listing node addresses: %w", err)
+		}
+
+		certSANs := kubernetesCertSANs(k8sRoot, cfgProvider, nodeIPs)
+
+		// don't touch the resource if SANs haven't changed, as any change regenerates the certificate
+		existing, err := r.Get(ctx, secrets.NewCertSAN(secrets.CertSANKubernetesID).Metadata())
+		if err != nil && !state.IsNotFoundError(err) {
+			return fmt.Errorf("error getting cert SANs: %w", err)
+		}
+
+		if err == nil && reflect.DeepEqual(existing.(*secrets.CertSAN).TypedSpec(), certSANs) {
+			continue
+		}
+
+		logger.Printf("kube-apiserver certificate SANs: IPs %q, DNS names %q", certSANs.IPs, certSANs.DNSNames)
+
+		if err = r.Modify(ctx, secrets.NewCertSAN(secrets.CertSANKubernetesID), func(r resource.Resource) error {
+			*r.(*secrets.CertSAN).TypedSpec() = *certSANs
+
+			return nil
+		}); err != nil {
+			return fmt.Errorf("error updating cert SANs: %w", err)
+		}
+	}
+}
+
+// kubernetesCertSANs builds the sorted list of kube-apiserver certificate SANs.
+//
+// Node addresses from the pod and service subnets are skipped, as they are assigned to the CNI and kube-proxy
+// interfaces (e.g. every service IP is assigned to kube-ipvs0 in IPVS mode).
+func kubernetesCertSANs(k8sRoot *secrets.RootKubernetesSpec, cfgProvider talosconfig.Provider, nodeIPs []net.IP) *secrets.CertSANSpec {
+	urls := []string{k8sRoot.Endpoint.Hostname()}
+	urls = append(urls, k8sRoot.CertSANs...)
+	altNames := altNamesFromURLs(urls)
+
+	altNames.IPs = append(altNames.IPs, k8sRoot.APIServerIPs...)
+
+	for _, device := range cfgProvider.Machine().Network().Devices() {
+		vips := []talosconfig.VIPConfig{device.VIPConfig()}
+
+		for _, vlan := range device.Vlans() {
+			vips = append(vips, vlan.VIPConfig())
+		}
+
+		for _, vip := range vips {
+			if vip == nil {
+				continue
+			}
+
+			if ip := net.ParseIP(vip.IP()); ip != nil {
+				altNames.IPs = append(altNames.IPs, ip)
+			}
+		}
+	}
+
+	var clusterSubnets []*net.IPNet
+
+	for _, cidr := range strings.Split(cfgProvider.Cluster().Network().PodCIDR()+","+cfgProvider.Cluster().Network().ServiceCIDR(), ",") {
+		if _, subnet, err := net.ParseCIDR(strings.TrimSpace(cidr)); err == nil {
+			clusterSubnets = append(clusterSubnets, subnet)
+		}
+	}
+
+nodeIPs:
+	for _, ip := range nodeIPs {
+		for _, subnet := range clusterSubnets {
+			if subnet.Contains(ip) {
+				continue nodeIPs
+			}
+		}
+
+		altNames.IPs = append(altNames.IPs, ip)
+	}
+
+	// Add kubernetes default svc with cluster domain to AltNames
+	altNames.DNSNames = append(altNames.DNSNames,
+		"kubernetes",
+		"kubernetes.default",
+		"kubernetes.default.svc",
+		"kubernetes.default.svc."+k8sRoot.DNSDomain,
+	)
+
+	return &secrets.CertSANSpec{
+		IPs:      uniqueIPs(altNames.IPs),
+		DNSNames: uniqueStrings(altNames.DNSNames),
+	}
+}
+
+func uniqueIPs(ips []net.IP) []net.IP {
+	result := make([]net.IP, 0, len(ips))
+
+	for _, ip := range ips {
+		result = append(result, ip.To16())
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return bytes.Compare(result[i], result[j]) < 0
+	})
+
+	unique := make([]net.IP, 0, len(result))
+
+	for _, ip := range result {
+		if len(unique) > 0 && ip.Equal(unique[len(unique)-1]) {
+			continue
+		}
+
+		unique = append(unique, ip)
+	}
+
+	return unique
+}
+
+func uniqueStrings(values []string) []string {
+	result := append([]string(nil), values...)
+
+	sort.Strings(result)
+
+	unique := make([]string, 0, len(result))
+
+	for _, value := range result {
+		if len(unique) > 0 && value == unique[len(unique)-1] {
+			continue
+		}
+
+		unique = append(unique, value)
+	}
+
+	return unique
+}
+
+func (ctrl *KubernetesCertSANsController) teardownAll(ctx context.Context, r controller.Runtime) error {
+	list, err := r.List(ctx, resource.NewMetadata(secrets.NamespaceName, secrets.CertSANType, "", resource.VersionUndefined))
+	if err != nil {
+		return err
+	}
+
+	for _, res := range list.Items {
+		if err = r.Destroy(ctx, res.Metadata()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -16,6 +16,7 @@ import (
 
 	talosconfig "github.com/talos-systems/talos/pkg/machinery/config"
 	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1/machine"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
 	"github.com/talos-systems/talos/pkg/resources/config"
 	"github.com/talos-systems/talos/pkg/resources/secrets"
 )
@@ -153,6 +154,49 @@ func (ctrl *RootController) updateK8sSecrets(cfgProvider talosconfig.Provider, k
 	k8sSecrets.ServiceAccount = cfgProvider.Cluster().ServiceAccount()
 
 	k8sSecrets.AESCBCEncryptionSecret = cfgProvider.Cluster().AESCBCEncryptionSecret()
+	k8sSecrets.AESCBCEncryptionKeyName = cfgProvider.Cluster().AESCBCEncryptionKeyName()
+
+	k8sSecrets.AESCBCAdditionalEncryptionKeys = nil
+
+	for _, key := range cfgProvider.Cluster().AESCBCAdditionalEncryptionKeys() {
+		k8sSecrets.AESCBCAdditionalEncryptionKeys = append(k8sSecrets.AESCBCAdditionalEncryptionKeys, secrets.AESCBCEncryptionKey{
+			Name:   key.Name(),
+			Secret: key.Secret(),
+		})
+	}
+
+	secretsEncryption := cfgProvider.Cluster().SecretsEncryption()
+
+	// the selected provider goes first, as it encrypts the data, aescbc is always kept for decryption
+	k8sSecrets.SecretsEncryptionProviders = []string{secretsEncryption.Provider()}
+	k8sSecrets.SecretboxEncryptionKeyName = ""
+	k8sSecrets.SecretboxEncryptionSecret = ""
+	k8sSecrets.KMSEncryption = nil
+
+	if secretsEncryption.Provider() != constants.SecretsEncryptionProviderAESCBC {
+		k8sSecrets.SecretsEncryptionProviders = append(k8sSecrets.SecretsEncryptionProviders, constants.SecretsEncryptionProviderAESCBC)
+	}
+
+	if secretbox := secretsEncryption.Secretbox(); secretbox != nil {
+		k8sSecrets.SecretboxEncryptionKeyName = secretbox.Name()
+		k8sSecrets.SecretboxEncryptionSecret = secretbox.Secret()
+
+		if secretsEncryption.Provider() != constants.SecretsEncryptionProviderSecretbox {
+			k8sSecrets.SecretsEncryptionProviders = append(k8sSecrets.SecretsEncryptionProviders, constants.SecretsEncryptionProviderSecretbox)
+		}
+	}
+
+	if kms := secretsEncryption.KMS(); kms != nil {
+		k8sSecrets.KMSEncryption = &secrets.KMSEncryption{
+			Name:     kms.Name(),
+			Endpoint: kms.Endpoint(),
+			Timeout:  kms.Timeout(),
+		}
+
+		if secretsEncryption.Provider() != constants.SecretsEncryptionProviderKMS {
+			k8sSecrets.SecretsEncryptionProviders = append(k8sSecrets.SecretsEncryptionProviders, constants.SecretsEncryptionProviderKMS)
+		}
+	}
 
 	k8sSecrets.BootstrapTokenID = cfgProvider.Cluster().Token().ID()
 	k8sSecrets.BootstrapTokenSecret = cfgProvider.Cluster().Token().Secret()
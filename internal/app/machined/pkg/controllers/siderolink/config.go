@@ -0,0 +1,99 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package siderolink
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/AlekSi/pointer"
+	"github.com/talos-systems/go-procfs/procfs"
+	"github.com/talos-systems/os-runtime/pkg/controller"
+	"github.com/talos-systems/os-runtime/pkg/resource"
+	"github.com/talos-systems/os-runtime/pkg/state"
+
+	v1alpha1runtime "github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
+	talosconfig "github.com/talos-systems/talos/pkg/machinery/config"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+	"github.com/talos-systems/talos/pkg/resources/config"
+	"github.com/talos-systems/talos/pkg/resources/siderolink"
+)
+
+// ConfigController builds SideroLink configuration from the machine configuration and the kernel arguments.
+//
+// Configuration resource is always created (with empty API endpoint if SideroLink is disabled),
+// so that the services waiting for the tunnel know whether to wait.
+type ConfigController struct {
+	V1Alpha1Mode v1alpha1runtime.Mode
+	Cmdline      *procfs.Cmdline
+}
+
+// Name implements controller.Controller interface.
+func (ctrl *ConfigController) Name() string {
+	return "siderolink.ConfigController"
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *ConfigController) Inputs() []controller.Input {
+	return []controller.Input{
+		{
+			Namespace: config.NamespaceName,
+			Type:      config.MachineConfigType,
+			ID:        pointer.ToString(config.V1Alpha1ID),
+			Kind:      controller.InputWeak,
+		},
+	}
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *ConfigController) Outputs() []controller.Output {
+	return []controller.Output{
+		{
+			Type: siderolink.ConfigType,
+			Kind: controller.OutputExclusive,
+		},
+	}
+}
+
+// Run implements controller.Controller interface.
+func (ctrl *ConfigController) Run(ctx context.Context, r controller.Runtime, logger *log.Logger) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+		}
+
+		cfg, err := r.Get(ctx, resource.NewMetadata(config.NamespaceName, config.MachineConfigType, config.V1Alpha1ID, resource.VersionUndefined))
+		if err != nil && !state.IsNotFoundError(err) {
+			return fmt.Errorf("error getting config: %w", err)
+		}
+
+		var cfgProvider talosconfig.Provider
+
+		if cfg != nil {
+			cfgProvider = cfg.(*config.MachineConfig).Config()
+		}
+
+		var kernelParam string
+
+		if ctrl.Cmdline != nil {
+			if val := ctrl.Cmdline.Get(constants.KernelParamSideroLink).First(); val != nil {
+				kernelParam = *val
+			}
+		}
+
+		endpoint := apiEndpoint(cfgProvider, ctrl.V1Alpha1Mode, kernelParam)
+
+		if err = r.Modify(ctx, siderolink.NewConfig(siderolink.ConfigID), func(r resource.Resource) error {
+			r.(*siderolink.Config).TypedSpec().APIEndpoint = endpoint
+
+			return nil
+		}); err != nil {
+			return fmt.Errorf("error updating SideroLink config: %w", err)
+		}
+	}
+}
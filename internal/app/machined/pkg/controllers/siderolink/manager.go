@@ -0,0 +1,292 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package siderolink
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/talos-systems/os-runtime/pkg/controller"
+	"github.com/talos-systems/os-runtime/pkg/resource"
+	"github.com/talos-systems/os-runtime/pkg/state"
+	"golang.zx2c4.com/wireguard/wgctrl"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+
+	"github.com/talos-systems/talos/internal/pkg/identity"
+	siderolinkapi "github.com/talos-systems/talos/internal/pkg/siderolink"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+	"github.com/talos-systems/talos/pkg/resources/network"
+	"github.com/talos-systems/talos/pkg/resources/siderolink"
+)
+
+// ManagerController registers the node with the management server and configures SideroLink Wireguard link.
+//
+// Wireguard key is generated on each start of the controller and the node registers again,
+// so the tunnel settings are never persisted.
+type ManagerController struct {
+	privateKey  wgtypes.Key
+	endpoint    string
+	provisioned *siderolinkapi.ProvisionResponse
+}
+
+// Name implements controller.Controller interface.
+func (ctrl *ManagerController) Name() string {
+	return "siderolink.ManagerController"
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *ManagerController) Inputs() []controller.Input {
+	return []controller.Input{
+		{
+			Namespace: siderolink.NamespaceName,
+			Type:      siderolink.ConfigType,
+			ID:        pointer.ToString(siderolink.ConfigID),
+			Kind:      controller.InputWeak,
+		},
+	}
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *ManagerController) Outputs() []controller.Output {
+	return []controller.Output{
+		{
+			Type: network.LinkSpecType,
+			Kind: controller.OutputShared,
+		},
+		{
+			Type: network.AddressSpecType,
+			Kind: controller.OutputShared,
+		},
+		{
+			Type: siderolink.StatusType,
+			Kind: controller.OutputExclusive,
+		},
+	}
+}
+
+// Run implements controller.Controller interface.
+//
+//nolint:gocyclo
+func (ctrl *ManagerController) Run(ctx context.Context, r controller.Runtime, logger *log.Logger) error {
+	var err error
+
+	if ctrl.privateKey, err = wgtypes.GeneratePrivateKey(); err != nil {
+		return fmt.Errorf("error generating SideroLink key: %w", err)
+	}
+
+	ctrl.endpoint, ctrl.provisioned = "", nil
+
+	var retryCh <-chan time.Time
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+		case <-retryCh:
+		}
+
+		retryCh = nil
+
+		var cfg resource.Resource
+
+		cfg, err = r.Get(ctx, resource.NewMetadata(siderolink.NamespaceName, siderolink.ConfigType, siderolink.ConfigID, resource.VersionUndefined))
+		if err != nil && !state.IsNotFoundError(err) {
+			return fmt.Errorf("error getting SideroLink config: %w", err)
+		}
+
+		endpoint := ""
+
+		if cfg != nil {
+			endpoint = cfg.(*siderolink.Config).TypedSpec().APIEndpoint
+		}
+
+		if endpoint == "" {
+			ctrl.endpoint, ctrl.provisioned = "", nil
+
+			if err = ctrl.cleanup(ctx, r); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if ctrl.provisioned == nil || ctrl.endpoint != endpoint {
+			ctrl.endpoint = endpoint
+
+			if ctrl.provisioned, err = ctrl.provision(ctx, endpoint); err != nil {
+				logger.Printf("error registering with the SideroLink management server: %s", err)
+
+				retryCh = time.After(retryInterval)
+
+				continue
+			}
+		}
+
+		if err = ctrl.apply(ctx, r); err != nil {
+			return err
+		}
+
+		up := true
+
+		if err = ctrl.configureWireguard(); err != nil {
+			// link might not be created yet
+			logger.Printf("error configuring SideroLink Wireguard link: %s", err)
+
+			up = false
+			retryCh = time.After(retryInterval)
+		}
+
+		if err = r.Modify(ctx, siderolink.NewStatus(siderolink.StatusID), func(r resource.Resource) error {
+			*r.(*siderolink.Status).TypedSpec() = siderolink.StatusSpec{
+				NodeAddress:    ctrl.provisioned.NodeAddressPrefix,
+				ServerAddress:  ctrl.provisioned.ServerAddress,
+				ServerEndpoint: ctrl.provisioned.ServerEndpoint,
+				PublicKey:      ctrl.privateKey.PublicKey().String(),
+				Up:             up,
+			}
+
+			return nil
+		}); err != nil {
+			return fmt.Errorf("error updating SideroLink status: %w", err)
+		}
+	}
+}
+
+func (ctrl *ManagerController) provision(ctx context.Context, endpoint string) (*siderolinkapi.ProvisionResponse, error) {
+	client, err := siderolinkapi.NewClient(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, provisionTimeout)
+	defer cancel()
+
+	return client.Provision(ctx, &siderolinkapi.ProvisionRequest{
+		NodeUUID:      identity.SystemUUID(),
+		NodePublicKey: ctrl.privateKey.PublicKey().String(),
+	})
+}
+
+// configureWireguard sets up the management server as the only peer of the link.
+func (ctrl *ManagerController) configureWireguard() error {
+	serverKey, err := wgtypes.ParseKey(ctrl.provisioned.ServerPublicKey)
+	if err != nil {
+		return fmt.Errorf("error parsing server public key: %w", err)
+	}
+
+	serverEndpoint, err := net.ResolveUDPAddr("udp", ctrl.provisioned.ServerEndpoint)
+	if err != nil {
+		return fmt.Errorf("error resolving server endpoint: %w", err)
+	}
+
+	wgClient, err := wgctrl.New()
+	if err != nil {
+		return fmt.Errorf("error creating wireguard client: %w", err)
+	}
+
+	defer wgClient.Close() //nolint:errcheck
+
+	keepalive := serverKeepaliveInterval
+	firewallMark := constants.SideroLinkDefaultFirewallMark
+
+	if err = wgClient.ConfigureDevice(constants.SideroLinkName, wgtypes.Config{
+		PrivateKey:   &ctrl.privateKey,
+		FirewallMark: &firewallMark,
+		ReplacePeers: true,
+		Peers: []wgtypes.PeerConfig{
+			{
+				PublicKey:                   serverKey,
+				Endpoint:                    serverEndpoint,
+				PersistentKeepaliveInterval: &keepalive,
+				ReplaceAllowedIPs:           true,
+				AllowedIPs:                  []net.IPNet{hostNet(net.ParseIP(ctrl.provisioned.ServerAddress))},
+			},
+		},
+	}); err != nil {
+		return fmt.Errorf("error configuring wireguard device: %w", err)
+	}
+
+	return nil
+}
+
+func (ctrl *ManagerController) apply(ctx context.Context, r controller.Runtime) error {
+	if err := r.Modify(ctx, network.NewLinkSpec(constants.SideroLinkName), func(r resource.Resource) error {
+		*r.(*network.LinkSpec).TypedSpec() = network.LinkSpecSpec{
+			Name:    constants.SideroLinkName,
+			Logical: true,
+			Kind:    network.LinkKindWireguard,
+			Up:      true,
+			MTU:     constants.SideroLinkMTU,
+		}
+
+		return nil
+	}); err != nil {
+		return fmt.Errorf("error updating link spec: %w", err)
+	}
+
+	addressID := network.AddressID(constants.SideroLinkName, ctrl.provisioned.NodeAddressPrefix)
+
+	if err := r.Modify(ctx, network.NewAddressSpec(addressID), func(r resource.Resource) error {
+		*r.(*network.AddressSpec).TypedSpec() = network.AddressSpecSpec{
+			Address:  ctrl.provisioned.NodeAddressPrefix,
+			LinkName: constants.SideroLinkName,
+		}
+
+		return nil
+	}); err != nil {
+		return fmt.Errorf("error updating address spec: %w", err)
+	}
+
+	// the address might change if the node registered again
+	return ctrl.cleanupSpecs(ctx, r, network.AddressSpecType, addressID)
+}
+
+func (ctrl *ManagerController) cleanup(ctx context.Context, r controller.Runtime) error {
+	if err := ctrl.cleanupSpecs(ctx, r, network.LinkSpecType, ""); err != nil {
+		return err
+	}
+
+	if err := ctrl.cleanupSpecs(ctx, r, network.AddressSpecType, ""); err != nil {
+		return err
+	}
+
+	list, err := r.List(ctx, resource.NewMetadata(siderolink.NamespaceName, siderolink.StatusType, "", resource.VersionUndefined))
+	if err != nil {
+		return fmt.Errorf("error listing SideroLink statuses: %w", err)
+	}
+
+	for _, res := range list.Items {
+		if err = r.Destroy(ctx, res.Metadata()); err != nil {
+			return fmt.Errorf("error cleaning up SideroLink status: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// cleanupSpecs removes the network specs owned by the controller except for the one with keepID.
+func (ctrl *ManagerController) cleanupSpecs(ctx context.Context, r controller.Runtime, resourceType resource.Type, keepID resource.ID) error {
+	list, err := r.List(ctx, resource.NewMetadata(network.NamespaceName, resourceType, "", resource.VersionUndefined))
+	if err != nil {
+		return fmt.Errorf("error listing specs: %w", err)
+	}
+
+	for _, res := range list.Items {
+		if res.Metadata().Owner() != ctrl.Name() || res.Metadata().ID() == keepID {
+			continue
+		}
+
+		if err = r.Destroy(ctx, res.Metadata()); err != nil {
+			return fmt.Errorf("error cleaning up specs: %w", err)
+		}
+	}
+
+	return nil
+}
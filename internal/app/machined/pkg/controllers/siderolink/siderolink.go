@@ -0,0 +1,61 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package siderolink provides controllers which manage SideroLink: Wireguard tunnel to the management server.
+//
+// ConfigController resolves the management server API URL from the machine configuration or the kernel
+// arguments, and ManagerController registers the node with the management server and configures the tunnel.
+package siderolink
+
+import (
+	"net"
+	"time"
+
+	v1alpha1runtime "github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
+	talosconfig "github.com/talos-systems/talos/pkg/machinery/config"
+)
+
+const (
+	// retryInterval is the interval between attempts if the tunnel can't be set up yet.
+	retryInterval = 10 * time.Second
+
+	// provisionTimeout is the timeout of the single provisioning API request.
+	provisionTimeout = 30 * time.Second
+
+	// serverKeepaliveInterval keeps NAT mappings open for the node behind NAT.
+	serverKeepaliveInterval = 25 * time.Second
+)
+
+// apiEndpoint returns the management server API URL.
+//
+// Machine configuration takes precedence over the kernel argument. Network in container mode
+// is managed by the container runtime, so SideroLink is not supported.
+func apiEndpoint(cfg talosconfig.Provider, mode v1alpha1runtime.Mode, kernelParam string) string {
+	if mode == v1alpha1runtime.ModeContainer {
+		return ""
+	}
+
+	if cfg != nil {
+		if url := cfg.Machine().Network().SideroLink().APIURL(); url != "" {
+			return url
+		}
+	}
+
+	return kernelParam
+}
+
+// hostNet returns the single-address network for the IP.
+func hostNet(ip net.IP) net.IPNet {
+	if ip4 := ip.To4(); ip4 != nil {
+		return net.IPNet{
+			IP:   ip4,
+			Mask: net.CIDRMask(32, 32),
+		}
+	}
+
+	return net.IPNet{
+		IP:   ip,
+		Mask: net.CIDRMask(128, 128),
+	}
+}
@@ -0,0 +1,45 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package siderolink
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	v1alpha1runtime "github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
+)
+
+func TestAPIEndpoint(t *testing.T) {
+	cfg := &v1alpha1.Config{
+		MachineConfig: &v1alpha1.MachineConfig{
+			MachineNetwork: &v1alpha1.NetworkConfig{
+				NetworkSideroLink: &v1alpha1.SideroLink{
+					SideroLinkAPIURL: "https://config.example.com/",
+				},
+			},
+		},
+	}
+
+	emptyCfg := &v1alpha1.Config{
+		MachineConfig: &v1alpha1.MachineConfig{},
+	}
+
+	assert.Equal(t, "", apiEndpoint(nil, v1alpha1runtime.ModeMetal, ""))
+	assert.Equal(t, "https://kernel.example.com/", apiEndpoint(nil, v1alpha1runtime.ModeMetal, "https://kernel.example.com/"))
+	assert.Equal(t, "https://kernel.example.com/", apiEndpoint(emptyCfg, v1alpha1runtime.ModeMetal, "https://kernel.example.com/"))
+	assert.Equal(t, "https://config.example.com/", apiEndpoint(cfg, v1alpha1runtime.ModeMetal, "https://kernel.example.com/"))
+	assert.Equal(t, "", apiEndpoint(cfg, v1alpha1runtime.ModeContainer, "https://kernel.example.com/"))
+}
+
+func TestHostNet(t *testing.T) {
+	ipNet := hostNet(net.ParseIP("10.5.0.1"))
+	assert.Equal(t, "10.5.0.1/32", ipNet.String())
+
+	ipNet = hostNet(net.ParseIP("fdae:41e4:649b:9303::1"))
+	assert.Equal(t, "fdae:41e4:649b:9303::1/128", ipNet.String())
+}
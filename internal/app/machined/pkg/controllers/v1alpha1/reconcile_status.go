@@ -0,0 +1,184 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/talos-systems/os-runtime/pkg/controller"
+	"github.com/talos-systems/os-runtime/pkg/resource"
+	"github.com/talos-systems/os-runtime/pkg/state"
+
+	talosconfig "github.com/talos-systems/talos/pkg/machinery/config"
+	"github.com/talos-systems/talos/pkg/resources/config"
+	"github.com/talos-systems/talos/pkg/resources/v1alpha1"
+)
+
+// ReconcileStatusController manages v1alpha1.ReconcileStatus.
+//
+// Automatic reconciliation of the cluster state is paused via the machine config (indefinitely
+// or during the maintenance windows), or via the API until the specified time.
+type ReconcileStatusController struct{}
+
+// Name implements controller.Controller interface.
+func (ctrl *ReconcileStatusController) Name() string {
+	return "v1alpha1.ReconcileStatusController"
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *ReconcileStatusController) Inputs() []controller.Input {
+	return []controller.Input{
+		{
+			Namespace: config.NamespaceName,
+			Type:      config.MachineConfigType,
+			ID:        pointer.ToString(config.V1Alpha1ID),
+			Kind:      controller.InputWeak,
+		},
+		{
+			Namespace: v1alpha1.NamespaceName,
+			Type:      v1alpha1.ReconcilePauseType,
+			ID:        pointer.ToString(v1alpha1.ReconcilePauseID),
+			Kind:      controller.InputWeak,
+		},
+	}
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *ReconcileStatusController) Outputs() []controller.Output {
+	return []controller.Output{
+		{
+			Type: v1alpha1.ReconcileStatusType,
+			Kind: controller.OutputExclusive,
+		},
+	}
+}
+
+// Run implements controller.Controller interface.
+//
+//nolint:gocyclo
+func (ctrl *ReconcileStatusController) Run(ctx context.Context, r controller.Runtime, logger *log.Logger) error {
+	var (
+		wakeupCh  <-chan time.Time
+		wasPaused bool
+	)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+		case <-wakeupCh:
+		}
+
+		var reconcileConfig talosconfig.Reconcile
+
+		cfg, err := r.Get(ctx, resource.NewMetadata(config.NamespaceName, config.MachineConfigType, config.V1Alpha1ID, resource.VersionUndefined))
+		if err != nil {
+			if !state.IsNotFoundError(err) {
+				return fmt.Errorf("error getting config: %w", err)
+			}
+		} else {
+			reconcileConfig = cfg.(*config.MachineConfig).Config().Machine().Reconcile()
+		}
+
+		var pauseUntil time.Time
+
+		pause, err := r.Get(ctx, v1alpha1.NewReconcilePause().Metadata())
+		if err != nil {
+			if !state.IsNotFoundError(err) {
+				return fmt.Errorf("error getting reconcile pause: %w", err)
+			}
+		} else {
+			pauseUntil = pause.(*v1alpha1.ReconcilePause).Pause().Until
+		}
+
+		status, next := reconcileStatus(time.Now(), reconcileConfig, pauseUntil)
+
+		if status.Paused != wasPaused {
+			if status.Paused {
+				logger.Printf("reconciliation is paused: %s", status.Reason)
+			} else {
+				logger.Printf("reconciliation is resumed")
+			}
+
+			wasPaused = status.Paused
+		}
+
+		if err = r.Modify(ctx, v1alpha1.NewReconcileStatus(), func(r resource.Resource) error {
+			*r.(*v1alpha1.ReconcileStatus).Status() = status
+
+			return nil
+		}); err != nil {
+			return fmt.Errorf("error updating reconcile status: %w", err)
+		}
+
+		wakeupCh = nil
+
+		if !next.IsZero() {
+			wakeupCh = time.After(time.Until(next))
+		}
+	}
+}
+
+// reconcileStatus returns the status of the reconciliation at the specified moment,
+// and the next moment the status might change (zero if it doesn't change).
+func reconcileStatus(now time.Time, cfg talosconfig.Reconcile, pauseUntil time.Time) (v1alpha1.ReconcileStatusSpec, time.Time) {
+	if cfg != nil && cfg.Paused() {
+		return v1alpha1.ReconcileStatusSpec{
+			Paused: true,
+			Reason: "paused in the machine config",
+		}, time.Time{}
+	}
+
+	var (
+		status  v1alpha1.ReconcileStatusSpec
+		reasons []string
+		next    time.Time
+	)
+
+	pauseTill := func(until time.Time, reason string) {
+		status.Paused = true
+
+		if until.After(status.Until) {
+			status.Until = until
+		}
+
+		reasons = append(reasons, reason)
+	}
+
+	wakeupAt := func(t time.Time) {
+		if next.IsZero() || t.Before(next) {
+			next = t
+		}
+	}
+
+	if now.Before(pauseUntil) {
+		pauseTill(pauseUntil, "paused via API")
+		wakeupAt(pauseUntil)
+	}
+
+	if cfg != nil {
+		for _, window := range cfg.MaintenanceWindows() {
+			start, end := window.Start(), window.Start().Add(window.Duration())
+
+			switch {
+			case now.Before(start):
+				wakeupAt(start)
+			case now.Before(end):
+				pauseTill(end, fmt.Sprintf("maintenance window %s", window.Start().Format(time.RFC3339)))
+				wakeupAt(end)
+			}
+		}
+	}
+
+	status.Reason = strings.Join(reasons, ", ")
+
+	return status, next
+}
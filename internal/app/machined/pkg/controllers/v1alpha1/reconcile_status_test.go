@@ -0,0 +1,154 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package v1alpha1_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/talos-systems/go-retry/retry"
+	"github.com/talos-systems/os-runtime/pkg/controller/runtime"
+	"github.com/talos-systems/os-runtime/pkg/state"
+	"github.com/talos-systems/os-runtime/pkg/state/impl/inmem"
+	"github.com/talos-systems/os-runtime/pkg/state/impl/namespaced"
+
+	v1alpha1ctrl "github.com/talos-systems/talos/internal/app/machined/pkg/controllers/v1alpha1"
+	v1alpha1cfg "github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
+	"github.com/talos-systems/talos/pkg/resources/config"
+	"github.com/talos-systems/talos/pkg/resources/v1alpha1"
+)
+
+type ReconcileStatusSuite struct {
+	suite.Suite
+
+	state state.State
+
+	runtime *runtime.Runtime
+	wg      sync.WaitGroup
+
+	ctx       context.Context
+	ctxCancel context.CancelFunc
+}
+
+func (suite *ReconcileStatusSuite) SetupTest() {
+	suite.ctx, suite.ctxCancel = context.WithTimeout(context.Background(), 3*time.Minute)
+
+	suite.state = state.WrapCore(namespaced.NewState(inmem.Build))
+
+	var err error
+
+	logger := log.New(log.Writer(), "controller-runtime: ", log.Flags())
+
+	suite.runtime, err = runtime.NewRuntime(suite.state, logger)
+	suite.Require().NoError(err)
+
+	suite.Require().NoError(suite.runtime.RegisterController(&v1alpha1ctrl.ReconcileStatusController{}))
+
+	suite.wg.Add(1)
+
+	go func() {
+		defer suite.wg.Done()
+
+		suite.Assert().NoError(suite.runtime.Run(suite.ctx))
+	}()
+}
+
+func (suite *ReconcileStatusSuite) assertReconcileStatus(check func(*v1alpha1.ReconcileStatusSpec) error) {
+	suite.Assert().NoError(retry.Constant(10*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		func() error {
+			r, err := suite.state.Get(suite.ctx, v1alpha1.NewReconcileStatus().Metadata())
+			if err != nil {
+				if state.IsNotFoundError(err) {
+					return retry.ExpectedError(err)
+				}
+
+				return retry.UnexpectedError(err)
+			}
+
+			return check(r.(*v1alpha1.ReconcileStatus).Status())
+		},
+	))
+}
+
+func (suite *ReconcileStatusSuite) TestMaintenanceWindow() {
+	cfg := &v1alpha1cfg.Config{
+		ConfigVersion: "v1alpha1",
+		MachineConfig: &v1alpha1cfg.MachineConfig{
+			MachineType: "controlplane",
+			MachineReconcile: &v1alpha1cfg.ReconcileConfig{
+				ReconcileMaintenanceWindows: []*v1alpha1cfg.MaintenanceWindowConfig{
+					{
+						WindowStart:    time.Now().Add(-time.Second).Format(time.RFC3339),
+						WindowDuration: 3 * time.Second,
+					},
+				},
+			},
+		},
+	}
+
+	suite.Require().NoError(suite.state.Create(suite.ctx, config.NewMachineConfig(cfg)))
+
+	suite.assertReconcileStatus(func(spec *v1alpha1.ReconcileStatusSpec) error {
+		if !spec.Paused {
+			return retry.ExpectedError(fmt.Errorf("reconciliation is not paused: %+v", spec))
+		}
+
+		return nil
+	})
+
+	// status changes once the window is over
+	suite.assertReconcileStatus(func(spec *v1alpha1.ReconcileStatusSpec) error {
+		if spec.Paused {
+			return retry.ExpectedError(fmt.Errorf("reconciliation is still paused: %+v", spec))
+		}
+
+		return nil
+	})
+}
+
+func (suite *ReconcileStatusSuite) TestAPIPause() {
+	suite.assertReconcileStatus(func(spec *v1alpha1.ReconcileStatusSpec) error {
+		if spec.Paused {
+			return retry.UnexpectedError(fmt.Errorf("reconciliation is paused: %+v", spec))
+		}
+
+		return nil
+	})
+
+	until := time.Now().Add(time.Hour)
+
+	pause := v1alpha1.NewReconcilePause()
+	pause.Pause().Until = until
+
+	suite.Require().NoError(suite.state.Create(suite.ctx, pause))
+
+	suite.assertReconcileStatus(func(spec *v1alpha1.ReconcileStatusSpec) error {
+		if !spec.Paused {
+			return retry.ExpectedError(fmt.Errorf("reconciliation is not paused: %+v", spec))
+		}
+
+		suite.Assert().Equal("paused via API", spec.Reason)
+		suite.Assert().True(until.Equal(spec.Until))
+
+		return nil
+	})
+}
+
+func (suite *ReconcileStatusSuite) TearDownTest() {
+	suite.T().Log("tear down")
+
+	suite.ctxCancel()
+
+	suite.wg.Wait()
+}
+
+func TestReconcileStatusSuite(t *testing.T) {
+	suite.Run(t, new(ReconcileStatusSuite))
+}
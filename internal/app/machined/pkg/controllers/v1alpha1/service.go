@@ -11,9 +11,9 @@ import (
 
 	"github.com/talos-systems/os-runtime/pkg/controller"
 	"github.com/talos-systems/os-runtime/pkg/resource"
-	"github.com/talos-systems/os-runtime/pkg/state"
 
 	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
+	"github.com/talos-systems/talos/internal/app/machined/pkg/system/events"
 	"github.com/talos-systems/talos/pkg/machinery/api/machine"
 	"github.com/talos-systems/talos/pkg/resources/v1alpha1"
 )
@@ -21,6 +21,9 @@ import (
 // ServiceController manages v1alpha1.Service based on services subsystem state.
 type ServiceController struct {
 	V1Alpha1Events runtime.Watcher
+
+	// V1Alpha1DependsOn returns the list of services the service depends on.
+	V1Alpha1DependsOn func(id string) []string
 }
 
 // Name implements controller.Controller interface.
@@ -72,22 +75,22 @@ func (ctrl *ServiceController) Run(ctx context.Context, r controller.Runtime, lo
 			if msg, ok := event.Payload.(*machine.ServiceStateEvent); ok {
 				service := v1alpha1.NewService(msg.Service)
 
-				switch msg.Action { //nolint:exhaustive
-				case machine.ServiceStateEvent_RUNNING:
-					if err := r.Modify(ctx, service, func(r resource.Resource) error {
-						svc := r.(*v1alpha1.Service) //nolint:errcheck,forcetypeassert
+				if err := r.Modify(ctx, service, func(r resource.Resource) error {
+					svc := r.(*v1alpha1.Service) //nolint:errcheck,forcetypeassert
 
-						svc.SetRunning(true)
-						svc.SetHealthy(msg.GetHealth().GetHealthy() && !msg.GetHealth().GetUnknown())
+					running := msg.Action == machine.ServiceStateEvent_RUNNING
 
-						return nil
-					}); err != nil {
-						logger.Printf("failed creating service resource %s: %s", service, err)
-					}
-				default:
-					if err := r.Destroy(ctx, service.Metadata()); err != nil && !state.IsNotFoundError(err) {
-						logger.Printf("failed destroying service resource %s: %s", service, err)
+					svc.SetState(events.ServiceState(msg.Action).String(), msg.Message)
+					svc.SetRunning(running)
+					svc.SetHealthy(running && msg.GetHealth().GetHealthy() && !msg.GetHealth().GetUnknown())
+
+					if ctrl.V1Alpha1DependsOn != nil {
+						svc.SetDependsOn(ctrl.V1Alpha1DependsOn(msg.Service))
 					}
+
+					return nil
+				}); err != nil {
+					logger.Printf("failed updating service resource %s: %s", service, err)
 				}
 			}
 		}
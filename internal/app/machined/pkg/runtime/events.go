@@ -5,6 +5,7 @@
 package runtime
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -20,6 +21,20 @@ type Event struct {
 	TypeURL string
 	ID      xid.ID
 	Payload proto.Message
+	ActorID string
+}
+
+// ActorIDCtxKey is the context key used to pass the actor ID.
+//
+// Actor ID identifies the long-running operation (e.g. upgrade) which
+// caused the event to be published.
+type ActorIDCtxKey struct{}
+
+// ActorIDFromContext returns the actor ID attached to the context, if any.
+func ActorIDFromContext(ctx context.Context) string {
+	actorID, _ := ctx.Value(ActorIDCtxKey{}).(string) //nolint:errcheck
+
+	return actorID
 }
 
 // WatchFunc defines the watcher callback function.
@@ -37,6 +52,8 @@ type WatchOptions struct {
 	TailID xid.ID
 	// Start at timestamp Now() - TailDuration.
 	TailDuration time.Duration
+	// Return only the events published by the actor with the specified ID.
+	ActorID string
 }
 
 // WatchOptionFunc defines the options for the watcher.
@@ -83,6 +100,15 @@ func WithTailDuration(dur time.Duration) WatchOptionFunc {
 	}
 }
 
+// WithActorID sets up Watcher to return only events with the specified actor ID.
+func WithActorID(actorID string) WatchOptionFunc {
+	return func(opts *WatchOptions) error {
+		opts.ActorID = actorID
+
+		return nil
+	}
+}
+
 // Watcher defines a runtime event watcher.
 type Watcher interface {
 	Watch(WatchFunc, ...WatchOptionFunc) error
@@ -90,7 +116,7 @@ type Watcher interface {
 
 // Publisher defines a runtime event publisher.
 type Publisher interface {
-	Publish(proto.Message)
+	Publish(context.Context, proto.Message)
 }
 
 // EventStream defines the runtime event stream.
@@ -111,6 +137,7 @@ func (event *Event) ToMachineEvent() (*machine.Event, error) {
 			TypeUrl: event.TypeURL,
 			Value:   value,
 		},
-		Id: event.ID.String(),
+		Id:      event.ID.String(),
+		ActorId: event.ActorID,
 	}, nil
 }
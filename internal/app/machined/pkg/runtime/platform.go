@@ -20,3 +20,15 @@ type Platform interface {
 	ExternalIPs(context.Context) ([]net.IP, error)
 	KernelArgs() procfs.Parameters
 }
+
+// AddressReporter is implemented by the platforms which report the node addresses
+// back to the hypervisor or the cloud.
+type AddressReporter interface {
+	ReportAddresses(context.Context, []net.IP) error
+}
+
+// ReadinessReporter is implemented by the platforms which report the node readiness
+// back to the cloud.
+type ReadinessReporter interface {
+	ReportReadiness(ctx context.Context, ready bool) error
+}
@@ -5,6 +5,7 @@
 package runtime
 
 import (
+	"github.com/talos-systems/talos/internal/pkg/audit"
 	"github.com/talos-systems/talos/pkg/machinery/config"
 )
 
@@ -17,5 +18,6 @@ type Runtime interface {
 	State() State
 	Events() EventStream
 	Logging() LoggingManager
+	Audit() *audit.Log
 	NodeName() (string, error)
 }
@@ -95,6 +95,7 @@ func ParseSequence(s string) (seq Sequence, err error) {
 type ResetOptions interface {
 	GetGraceful() bool
 	GetReboot() bool
+	GetDeleteNode() bool
 	GetSystemDiskTargets() []PartitionTarget
 }
 
@@ -112,9 +113,9 @@ type Sequencer interface {
 	Bootstrap(Runtime) []Phase
 	Initialize(Runtime) []Phase
 	Install(Runtime) []Phase
-	Reboot(Runtime) []Phase
+	Reboot(Runtime, *machine.RebootRequest) []Phase
 	Reset(Runtime, ResetOptions) []Phase
-	Shutdown(Runtime) []Phase
+	Shutdown(Runtime, *machine.ShutdownRequest) []Phase
 	StageUpgrade(Runtime, *machine.UpgradeRequest) []Phase
 	Upgrade(Runtime, *machine.UpgradeRequest) []Phase
 }
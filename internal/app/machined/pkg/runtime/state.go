@@ -5,12 +5,16 @@
 package runtime
 
 import (
+	"time"
+
 	"github.com/talos-systems/go-blockdevice/blockdevice/probe"
+	"github.com/talos-systems/os-runtime/pkg/resource"
 	"github.com/talos-systems/os-runtime/pkg/state"
 	"github.com/talos-systems/os-runtime/pkg/state/registry"
 
 	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime/disk"
 	"github.com/talos-systems/talos/pkg/machinery/config"
+	"github.com/talos-systems/talos/pkg/resources/cluster"
 )
 
 // State defines the state.
@@ -35,6 +39,8 @@ type MachineState interface {
 	IsInstallStaged() bool
 	StagedInstallImageRef() string
 	StagedInstallOptions() []byte
+	KexecPrepared(bool)
+	IsKexecPrepared() bool
 }
 
 // ClusterState defines the cluster state.
@@ -43,9 +49,12 @@ type ClusterState interface{}
 // V1Alpha2State defines the next generation (v2) interface binding into v1 runtime.
 type V1Alpha2State interface {
 	Resources() state.State
+	ResourceUpdated(*resource.Metadata) time.Time
 
 	NamespaceRegistry() *registry.NamespaceRegistry
 	ResourceRegistry() *registry.ResourceRegistry
 
 	SetConfig(config.Provider) error
+	SetIdentity(cluster.IdentitySpec) error
+	SetReconcilePause(until time.Time) error
 }
@@ -8,12 +8,14 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 
 	"github.com/talos-systems/go-procfs/procfs"
 
 	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
 	bananapim64 "github.com/talos-systems/talos/internal/app/machined/pkg/runtime/v1alpha1/board/bananapi_m64"
 	libretechallh3cch5 "github.com/talos-systems/talos/internal/app/machined/pkg/runtime/v1alpha1/board/libretech_all_h3_cc_h5"
+	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime/v1alpha1/board/overlay"
 	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime/v1alpha1/board/rock64"
 	rockpi4 "github.com/talos-systems/talos/internal/app/machined/pkg/runtime/v1alpha1/board/rockpi4"
 	rpi4 "github.com/talos-systems/talos/internal/app/machined/pkg/runtime/v1alpha1/board/rpi_4"
@@ -40,10 +42,22 @@ func CurrentBoard() (b runtime.Board, err error) {
 }
 
 // NewBoard initializes and returns a runtime.Board.
+//
+// Boards which are not built in are loaded from the overlays in constants.BoardOverlaysPath.
 func NewBoard(board string) (b runtime.Board, err error) {
 	return newBoard(board)
 }
 
+// NewBoardFromOverlay initializes and returns a runtime.Board defined by the overlay directory.
+func NewBoardFromOverlay(dir string) (b runtime.Board, err error) {
+	o, err := overlay.Load(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return o, nil
+}
+
 func newBoard(board string) (b runtime.Board, err error) {
 	switch board {
 	case constants.BoardLibretechAllH3CCH5:
@@ -57,7 +71,13 @@ func newBoard(board string) (b runtime.Board, err error) {
 	case constants.BoardRockpi4:
 		b = &rockpi4.Rockpi4{}
 	default:
-		return nil, fmt.Errorf("unsupported board: %q", board)
+		dir := filepath.Join(constants.BoardOverlaysPath, filepath.Base(board))
+
+		if _, err = os.Stat(filepath.Join(dir, overlay.SpecName)); err != nil {
+			return nil, fmt.Errorf("unsupported board: %q", board)
+		}
+
+		return NewBoardFromOverlay(dir)
 	}
 
 	return b, nil
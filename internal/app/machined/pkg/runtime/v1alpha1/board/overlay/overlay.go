@@ -0,0 +1,248 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package overlay implements the board defined by the overlay directory.
+//
+// The overlay directory contains the `overlay.yaml` spec along with the board
+// payloads (u-boot, DTBs, firmware blobs), so the boards can be supported
+// without the board specific code:
+//
+//	name: rock64
+//	kernelArgs:
+//	  - console=tty0
+//	  - console=ttyS2,115200n8
+//	partitionsOffset: 20480
+//	payloads:
+//	  - source: u-boot/u-boot-rockchip.bin
+//	    offset: 32768
+//	files:
+//	  - source: dtb/rockchip/rk3328-rock64.dtb
+//	    destination: dtb/rockchip/rk3328-rock64.dtb
+package overlay
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/talos-systems/go-procfs/procfs"
+	"golang.org/x/sys/unix"
+	"gopkg.in/yaml.v3"
+
+	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
+	"github.com/talos-systems/talos/pkg/copy"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+	"github.com/talos-systems/talos/pkg/safepath"
+)
+
+// SpecName is the name of the overlay spec in the overlay directory.
+const SpecName = "overlay.yaml"
+
+// Spec describes the board.
+type Spec struct {
+	// Name is the value of the `talos.board` kernel argument.
+	Name string `yaml:"name"`
+	// KernelArgs are set on the kernel command line, overwriting the arguments with the same key.
+	KernelArgs []string `yaml:"kernelArgs,omitempty"`
+	// PartitionsOffset is the offset (in sectors) of the first partition, space before it is used by the payloads.
+	PartitionsOffset uint64 `yaml:"partitionsOffset,omitempty"`
+	// Payloads are written to the disk at the offset (e.g. u-boot).
+	Payloads []Payload `yaml:"payloads,omitempty"`
+	// Files are copied to the EFI partition (e.g. DTBs, firmware blobs).
+	Files []File `yaml:"files,omitempty"`
+}
+
+// Payload is a raw payload written to the disk.
+type Payload struct {
+	// Source is the path relative to the overlay directory.
+	Source string `yaml:"source"`
+	// Offset in bytes from the start of the disk.
+	Offset int64 `yaml:"offset"`
+}
+
+// File is a file or a directory copied to the EFI partition.
+type File struct {
+	// Source is the path relative to the overlay directory.
+	Source string `yaml:"source"`
+	// Destination is the path relative to the EFI partition root.
+	Destination string `yaml:"destination"`
+}
+
+// Overlay is the board defined by the overlay directory.
+type Overlay struct {
+	dir  string
+	spec Spec
+}
+
+// Load loads the overlay from the directory.
+func Load(dir string) (*Overlay, error) {
+	b, err := ioutil.ReadFile(filepath.Join(dir, SpecName))
+	if err != nil {
+		return nil, err
+	}
+
+	o := &Overlay{
+		dir: dir,
+	}
+
+	if err = yaml.Unmarshal(b, &o.spec); err != nil {
+		return nil, fmt.Errorf("error parsing overlay %q: %w", dir, err)
+	}
+
+	if err = o.spec.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid overlay %q: %w", dir, err)
+	}
+
+	return o, nil
+}
+
+// Validate validates the overlay spec.
+func (s *Spec) Validate() error {
+	var result *multierror.Error
+
+	if s.Name == "" || s.Name == constants.BoardNone {
+		result = multierror.Append(result, errors.New("board name is required"))
+	}
+
+	for _, arg := range s.KernelArgs {
+		if arg == "" || strings.ContainsAny(arg, " \t\n") {
+			result = multierror.Append(result, fmt.Errorf("invalid kernel arg %q", arg))
+		}
+	}
+
+	for _, payload := range s.Payloads {
+		if payload.Source == "" {
+			result = multierror.Append(result, errors.New("payload source is required"))
+		}
+
+		if payload.Offset < 0 || (s.PartitionsOffset > 0 && uint64(payload.Offset) >= s.PartitionsOffset*512) {
+			result = multierror.Append(result, fmt.Errorf("payload %q offset %d overlaps the partitions", payload.Source, payload.Offset))
+		}
+	}
+
+	for _, file := range s.Files {
+		if file.Source == "" {
+			result = multierror.Append(result, errors.New("file source is required"))
+		}
+	}
+
+	return result.ErrorOrNil()
+}
+
+// Spec returns the overlay spec.
+func (o *Overlay) Spec() Spec {
+	return o.spec
+}
+
+// Name implements the runtime.Board.
+func (o *Overlay) Name() string {
+	return o.spec.Name
+}
+
+// Install implements the runtime.Board.
+//
+// EFI partition should be mounted.
+func (o *Overlay) Install(disk string) error {
+	if len(o.spec.Payloads) > 0 {
+		if err := o.writePayloads(disk); err != nil {
+			return err
+		}
+	}
+
+	for _, file := range o.spec.Files {
+		src := filepath.Join(o.dir, safepath.CleanPath(file.Source))
+		dst := filepath.Join(constants.EFIMountPoint, safepath.CleanPath(file.Destination))
+
+		info, err := os.Stat(src)
+		if err != nil {
+			return err
+		}
+
+		log.Printf("copying %s to %s", src, dst)
+
+		if info.IsDir() {
+			err = copy.Dir(src, dst)
+		} else {
+			if err = os.MkdirAll(filepath.Dir(dst), 0o700); err != nil {
+				return err
+			}
+
+			err = copy.File(src, dst)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (o *Overlay) writePayloads(disk string) error {
+	f, err := os.OpenFile(disk, os.O_RDWR|unix.O_CLOEXEC, 0o666)
+	if err != nil {
+		return err
+	}
+
+	defer f.Close() //nolint:errcheck
+
+	for _, payload := range o.spec.Payloads {
+		src := filepath.Join(o.dir, safepath.CleanPath(payload.Source))
+
+		b, err := ioutil.ReadFile(src)
+		if err != nil {
+			return err
+		}
+
+		log.Printf("writing %s at offset %d", src, payload.Offset)
+
+		if _, err = f.WriteAt(b, payload.Offset); err != nil {
+			return err
+		}
+	}
+
+	// NB: In the case that the block device is a loopback device, we sync here
+	// to ensure that the file is written before the loopback device is
+	// unmounted.
+	return f.Sync()
+}
+
+// KernelArgs implements the runtime.Board.
+func (o *Overlay) KernelArgs() procfs.Parameters {
+	var params procfs.Parameters
+
+	index := map[string]*procfs.Parameter{}
+
+	for _, arg := range o.spec.KernelArgs {
+		kv := strings.SplitN(arg, "=", 2)
+
+		param, ok := index[kv[0]]
+		if !ok {
+			param = procfs.NewParameter(kv[0])
+			index[kv[0]] = param
+
+			params = append(params, param)
+		}
+
+		if len(kv) == 2 {
+			param.Append(kv[1])
+		}
+	}
+
+	return params
+}
+
+// PartitionOptions implements the runtime.Board.
+func (o *Overlay) PartitionOptions() *runtime.PartitionOptions {
+	if o.spec.PartitionsOffset == 0 {
+		return nil
+	}
+
+	return &runtime.PartitionOptions{PartitionsOffset: o.spec.PartitionsOffset}
+}
@@ -0,0 +1,92 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package overlay_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/talos-systems/go-procfs/procfs"
+
+	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
+	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime/v1alpha1/board/overlay"
+)
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, overlay.SpecName), []byte(`name: rock64
+kernelArgs:
+  - console=tty0
+  - console=ttyS2,115200n8
+  - sysctl.kernel.kexec_load_disabled=1
+partitionsOffset: 20480
+payloads:
+  - source: u-boot/u-boot-rockchip.bin
+    offset: 32768
+files:
+  - source: dtb/rockchip/rk3328-rock64.dtb
+    destination: dtb/rockchip/rk3328-rock64.dtb
+`), 0o644))
+
+	o, err := overlay.Load(dir)
+	require.NoError(t, err)
+
+	assert.Equal(t, "rock64", o.Name())
+	assert.Equal(t, procfs.Parameters{
+		procfs.NewParameter("console").Append("tty0").Append("ttyS2,115200n8"),
+		procfs.NewParameter("sysctl.kernel.kexec_load_disabled").Append("1"),
+	}, o.KernelArgs())
+	assert.Equal(t, &runtime.PartitionOptions{PartitionsOffset: 20480}, o.PartitionOptions())
+}
+
+func TestWritePayloads(t *testing.T) {
+	dir := t.TempDir()
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, overlay.SpecName), []byte(`name: board
+payloads:
+  - source: ../../u-boot.bin
+    offset: 4
+`), 0o644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "u-boot.bin"), []byte("uboot"), 0o644))
+
+	disk := filepath.Join(t.TempDir(), "disk.raw")
+	require.NoError(t, ioutil.WriteFile(disk, make([]byte, 16), 0o644))
+
+	o, err := overlay.Load(dir)
+	require.NoError(t, err)
+
+	assert.Nil(t, o.PartitionOptions())
+
+	// payload paths are confined to the overlay directory
+	require.NoError(t, o.Install(disk))
+
+	b, err := ioutil.ReadFile(disk)
+	require.NoError(t, err)
+	assert.Equal(t, append(append(make([]byte, 4), "uboot"...), make([]byte, 7)...), b)
+}
+
+func TestValidate(t *testing.T) {
+	spec := overlay.Spec{
+		KernelArgs:       []string{"console=tty0 console=ttyS0"},
+		PartitionsOffset: 2048,
+		Payloads: []overlay.Payload{
+			{
+				Source: "u-boot.bin",
+				Offset: 2048 * 512,
+			},
+		},
+		Files: []overlay.File{
+			{
+				Destination: "config.txt",
+			},
+		},
+	}
+
+	assert.EqualError(t, spec.Validate(), "4 errors occurred:\n\t* board name is required\n\t* invalid kernel arg \"console=tty0 console=ttyS0\"\n\t* payload \"u-boot.bin\" offset 1048576 overlaps the partitions\n\t* file source is required\n\n")
+}
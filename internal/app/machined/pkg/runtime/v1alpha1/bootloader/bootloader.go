@@ -5,7 +5,12 @@
 package bootloader
 
 import (
+	"os"
+
 	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
+	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime/v1alpha1/bootloader/grub"
+	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime/v1alpha1/bootloader/sdboot"
+	"github.com/talos-systems/talos/internal/pkg/secureboot"
 )
 
 // Bootloader describes a bootloader.
@@ -14,3 +19,42 @@ type Bootloader interface {
 	Install(string, interface{}, runtime.Sequence) error
 	Default(string) error
 }
+
+// Probe returns the bootloader installed to the boot disk.
+//
+// systemd-boot is detected by its loader config, so both BOOT and EFI partitions should be mounted.
+func Probe(bootDisk string) Bootloader {
+	if _, err := os.Stat(sdboot.LoaderConfig); err == nil {
+		return &sdboot.SDBoot{}
+	}
+
+	return &grub.Grub{
+		BootDisk: bootDisk,
+	}
+}
+
+// DefaultCmdline returns the kernel command line of the default boot entry.
+//
+// Both BOOT and EFI partitions should be mounted.
+func DefaultCmdline() (string, error) {
+	if _, err := os.Stat(sdboot.LoaderConfig); err == nil {
+		cfg, err := sdboot.Read(sdboot.LoaderConfig)
+		if err != nil {
+			return "", err
+		}
+
+		return secureboot.ReadCmdline(sdboot.UKIPath(cfg.Default))
+	}
+
+	grubcfg, err := grub.Read(grub.GrubConfig)
+	if err != nil {
+		return "", err
+	}
+
+	label, err := grubcfg.DefaultLabel()
+	if err != nil {
+		return "", err
+	}
+
+	return label.Append, nil
+}
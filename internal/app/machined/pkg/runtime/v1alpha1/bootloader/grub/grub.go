@@ -15,6 +15,7 @@ import (
 	"path/filepath"
 	"regexp"
 	goruntime "runtime"
+	"strconv"
 	"strings"
 	"text/template"
 
@@ -28,9 +29,16 @@ import (
 type Cfg struct {
 	Default  string
 	Fallback string
+	Serial   *Serial
 	Labels   []*Label
 }
 
+// Serial represents the serial port used as the GRUB terminal.
+type Serial struct {
+	Unit  int
+	Speed int
+}
+
 // Label reprsents a label in the cfg file.
 type Label struct {
 	Root   string
@@ -47,8 +55,14 @@ set timeout=3
 
 insmod all_video
 
+{{ with .Serial -}}
+serial --unit={{ .Unit }}{{ with .Speed }} --speed={{ . }}{{ end }}
+terminal_input serial console
+terminal_output serial console
+{{- else -}}
 terminal_input console
 terminal_output console
+{{- end }}
 
 {{ range $label := .Labels -}}
 menuentry "{{ $label.Root }}" {
@@ -186,12 +200,123 @@ func (g *Grub) Default(label string) (err error) {
 	return ioutil.WriteFile(GrubConfig, b, 0o600)
 }
 
-func writeCfg(path string, grubcfg *Cfg) (err error) {
-	b := []byte{}
-	wr := bytes.NewBuffer(b)
+var (
+	defaultRegexp   = regexp.MustCompile(`^set default="(.*)"$`)
+	fallbackRegexp  = regexp.MustCompile(`^set fallback="(.*)"$`)
+	serialRegexp    = regexp.MustCompile(`^serial --unit=(\d+)(?: --speed=(\d+))?$`)
+	menuEntryRegexp = regexp.MustCompile(`^menuentry "(.*)" {$`)
+	linuxRegexp     = regexp.MustCompile(`^linux (\S+)\s*(.*)$`)
+	initrdRegexp    = regexp.MustCompile(`^initrd (\S+)$`)
+)
+
+// Read reads the grub config written by Install.
+func Read(path string) (*Cfg, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return Parse(b)
+}
+
+// Parse parses the grub config written by Install.
+func Parse(b []byte) (*Cfg, error) {
+	grubcfg := &Cfg{}
+
+	var label *Label
+
+	for _, line := range strings.Split(string(b), "\n") {
+		line = strings.TrimSpace(line)
+
+		switch {
+		case defaultRegexp.MatchString(line):
+			grubcfg.Default = defaultRegexp.FindStringSubmatch(line)[1]
+		case fallbackRegexp.MatchString(line):
+			grubcfg.Fallback = fallbackRegexp.FindStringSubmatch(line)[1]
+		case serialRegexp.MatchString(line):
+			matches := serialRegexp.FindStringSubmatch(line)
+
+			grubcfg.Serial = &Serial{}
+			grubcfg.Serial.Unit, _ = strconv.Atoi(matches[1])
+
+			if matches[2] != "" {
+				grubcfg.Serial.Speed, _ = strconv.Atoi(matches[2])
+			}
+		case menuEntryRegexp.MatchString(line):
+			label = &Label{
+				Root: menuEntryRegexp.FindStringSubmatch(line)[1],
+			}
+
+			grubcfg.Labels = append(grubcfg.Labels, label)
+		case label != nil && linuxRegexp.MatchString(line):
+			matches := linuxRegexp.FindStringSubmatch(line)
+
+			label.Kernel, label.Append = matches[1], matches[2]
+		case label != nil && initrdRegexp.MatchString(line):
+			label.Initrd = initrdRegexp.FindStringSubmatch(line)[1]
+		case line == "}":
+			label = nil
+		}
+	}
+
+	if grubcfg.Default == "" {
+		return nil, fmt.Errorf("failed to find default")
+	}
+
+	return grubcfg, nil
+}
+
+// DefaultLabel returns the label booted by default.
+func (c *Cfg) DefaultLabel() (*Label, error) {
+	for _, label := range c.Labels {
+		if label.Root == c.Default {
+			return label, nil
+		}
+	}
+
+	return nil, fmt.Errorf("menuentry %q not found", c.Default)
+}
+
+// Render renders the grub config.
+func (c *Cfg) Render() ([]byte, error) {
+	wr := bytes.NewBuffer(nil)
 	t := template.Must(template.New("grub").Parse(grubCfgTpl))
 
-	if err = t.Execute(wr, grubcfg); err != nil {
+	if err := t.Execute(wr, c); err != nil {
+		return nil, err
+	}
+
+	return wr.Bytes(), nil
+}
+
+// SerialFromConsoles returns the serial port settings for the first serial console (`ttyS<N>[,<speed>]`).
+//
+// If none of the consoles is a serial console, nil is returned.
+func SerialFromConsoles(consoles []string) *Serial {
+	for _, console := range consoles {
+		matches := serialConsoleRegexp.FindStringSubmatch(console)
+		if matches == nil {
+			continue
+		}
+
+		serial := &Serial{}
+		serial.Unit, _ = strconv.Atoi(matches[1])
+
+		if matches[2] != "" {
+			serial.Speed, _ = strconv.Atoi(matches[2])
+		}
+
+		return serial
+	}
+
+	return nil
+}
+
+var serialConsoleRegexp = regexp.MustCompile(`^ttyS(\d+)(?:,(\d+)\S*)?$`)
+
+func writeCfg(path string, grubcfg *Cfg) (err error) {
+	b, err := grubcfg.Render()
+	if err != nil {
 		return err
 	}
 
@@ -202,5 +327,5 @@ func writeCfg(path string, grubcfg *Cfg) (err error) {
 
 	log.Printf("writing %s to disk", path)
 
-	return ioutil.WriteFile(path, wr.Bytes(), 0o600)
+	return ioutil.WriteFile(path, b, 0o600)
 }
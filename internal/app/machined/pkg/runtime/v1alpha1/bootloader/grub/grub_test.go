@@ -0,0 +1,103 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package grub_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime/v1alpha1/bootloader/grub"
+)
+
+const sampleCfg = `set default="B"
+set fallback="A"
+set timeout=3
+
+insmod all_video
+
+terminal_input console
+terminal_output console
+
+menuentry "B" {
+  set gfxmode=auto
+  set gfxpayload=text
+  linux /B/vmlinuz init_on_alloc=1 talos.platform=metal
+  initrd /B/initramfs.xz
+}
+menuentry "A" {
+  set gfxmode=auto
+  set gfxpayload=text
+  linux /A/vmlinuz talos.platform=metal
+  initrd /A/initramfs.xz
+}
+`
+
+func TestParse(t *testing.T) {
+	cfg, err := grub.Parse([]byte(sampleCfg))
+	require.NoError(t, err)
+
+	assert.Equal(t, &grub.Cfg{
+		Default:  "B",
+		Fallback: "A",
+		Labels: []*grub.Label{
+			{
+				Root:   "B",
+				Kernel: "/B/vmlinuz",
+				Initrd: "/B/initramfs.xz",
+				Append: "init_on_alloc=1 talos.platform=metal",
+			},
+			{
+				Root:   "A",
+				Kernel: "/A/vmlinuz",
+				Initrd: "/A/initramfs.xz",
+				Append: "talos.platform=metal",
+			},
+		},
+	}, cfg)
+
+	label, err := cfg.DefaultLabel()
+	require.NoError(t, err)
+	assert.Equal(t, "/B/vmlinuz", label.Kernel)
+
+	_, err = grub.Parse([]byte("set timeout=3\n"))
+	assert.Error(t, err)
+}
+
+func TestRenderSerial(t *testing.T) {
+	cfg := &grub.Cfg{
+		Default: "A",
+		Serial: &grub.Serial{
+			Unit:  1,
+			Speed: 115200,
+		},
+		Labels: []*grub.Label{
+			{
+				Root:   "A",
+				Kernel: "/A/vmlinuz",
+				Initrd: "/A/initramfs.xz",
+				Append: "console=tty0 console=ttyS1,115200n8 talos.platform=metal",
+			},
+		},
+	}
+
+	b, err := cfg.Render()
+	require.NoError(t, err)
+
+	assert.Contains(t, string(b), "serial --unit=1 --speed=115200\nterminal_input serial console\nterminal_output serial console\n")
+
+	parsed, err := grub.Parse(b)
+	require.NoError(t, err)
+
+	assert.Equal(t, cfg, parsed)
+}
+
+func TestSerialFromConsoles(t *testing.T) {
+	assert.Nil(t, grub.SerialFromConsoles(nil))
+	assert.Nil(t, grub.SerialFromConsoles([]string{"tty0", "ttyAMA0,115200"}))
+	assert.Equal(t, &grub.Serial{Unit: 0}, grub.SerialFromConsoles([]string{"tty0", "ttyS0"}))
+	assert.Equal(t, &grub.Serial{Unit: 2, Speed: 9600}, grub.SerialFromConsoles([]string{"ttyS2,9600n8", "ttyS0,115200"}))
+}
@@ -14,7 +14,6 @@ import (
 	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime/v1alpha1/bootloader/adv"
 	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime/v1alpha1/bootloader/adv/syslinux"
 	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime/v1alpha1/bootloader/adv/talos"
-	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime/v1alpha1/bootloader/grub"
 	"github.com/talos-systems/talos/pkg/machinery/constants"
 )
 
@@ -112,9 +111,7 @@ func (m *Meta) Revert() (err error) {
 		return m.Write()
 	}
 
-	g := &grub.Grub{}
-
-	if err = g.Default(label); err != nil {
+	if err = Probe("").Default(label); err != nil {
 		return err
 	}
 
@@ -0,0 +1,27 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package sdboot
+
+import "github.com/talos-systems/talos/pkg/machinery/constants"
+
+const (
+	// BootA is a bootloader label.
+	BootA = "A"
+
+	// BootB is a bootloader label.
+	BootB = "B"
+
+	// LoaderConfig is the path to the systemd-boot loader config.
+	LoaderConfig = constants.EFIMountPoint + "/loader/loader.conf"
+
+	// UKIDir is the path to the Unified Kernel Images discovered by systemd-boot.
+	UKIDir = constants.EFIMountPoint + "/EFI/Linux"
+
+	// BootloaderPath is the removable media boot path systemd-boot is installed to.
+	BootloaderPath = constants.EFIMountPoint + "/EFI/BOOT/BOOTX64.EFI"
+
+	// SecureBootCertificatePath is the path to the SecureBoot signing certificate to be enrolled into the `db`.
+	SecureBootCertificatePath = constants.EFIMountPoint + "/keys/db.der"
+)
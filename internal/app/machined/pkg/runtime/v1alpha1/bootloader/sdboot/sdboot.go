@@ -0,0 +1,197 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package sdboot implements systemd-boot bootloader.
+//
+// Each boot label is a Unified Kernel Image in the EFI partition, the default
+// label is set in the systemd-boot loader config.
+package sdboot
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+)
+
+const ukiPrefix, ukiSuffix = "Talos-", ".efi"
+
+// Cfg represents the loader config.
+type Cfg struct {
+	Default string
+	Timeout int
+}
+
+// SDBoot represents the systemd-boot bootloader.
+type SDBoot struct{}
+
+// UKIPath returns the path to the Unified Kernel Image of the label.
+func UKIPath(label string) string {
+	return filepath.Join(UKIDir, ukiPrefix+label+ukiSuffix)
+}
+
+// Labels implements the Bootloader interface.
+func (s *SDBoot) Labels() (current, next string, err error) {
+	cfg, err := Read(LoaderConfig)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", BootA, nil
+		}
+
+		return "", "", err
+	}
+
+	switch cfg.Default {
+	case BootA:
+		return BootA, BootB, nil
+	case BootB:
+		return BootB, BootA, nil
+	default:
+		return "", "", fmt.Errorf("unknown systemd-boot entry: %q", cfg.Default)
+	}
+}
+
+// Install implements the Bootloader interface. It installs systemd-boot
+// and sets the default entry.
+//
+// Unified Kernel Image of the default label should be already built,
+// images of the labels other than default and fallback are removed.
+func (s *SDBoot) Install(fallback string, config interface{}, sequence runtime.Sequence) error {
+	cfg, ok := config.(*Cfg)
+	if !ok {
+		return errors.New("expected a systemd-boot config")
+	}
+
+	if _, err := os.Stat(UKIPath(cfg.Default)); err != nil {
+		return fmt.Errorf("unified kernel image of %q is missing: %w", cfg.Default, err)
+	}
+
+	entries, err := ioutil.ReadDir(UKIDir)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if name := entry.Name(); name != filepath.Base(UKIPath(cfg.Default)) && name != filepath.Base(UKIPath(fallback)) {
+			log.Printf("removing stale unified kernel image %q", name)
+
+			if err = os.Remove(filepath.Join(UKIDir, name)); err != nil {
+				return err
+			}
+		}
+	}
+
+	log.Printf("installing systemd-boot to %s", BootloaderPath)
+
+	if err = copyFile(constants.SDBootPath, BootloaderPath); err != nil {
+		return fmt.Errorf("failed to install systemd-boot: %w", err)
+	}
+
+	return Write(LoaderConfig, cfg)
+}
+
+// Default implements the bootloader interface.
+func (s *SDBoot) Default(label string) error {
+	cfg, err := Read(LoaderConfig)
+	if err != nil {
+		return err
+	}
+
+	cfg.Default = label
+
+	return Write(LoaderConfig, cfg)
+}
+
+// Read reads the loader config written by Install.
+func Read(path string) (*Cfg, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return Parse(b)
+}
+
+// Parse parses the loader config written by Install.
+func Parse(b []byte) (*Cfg, error) {
+	cfg := &Cfg{}
+
+	for _, line := range strings.Split(string(b), "\n") {
+		fields := strings.Fields(line)
+
+		if len(fields) != 2 {
+			continue
+		}
+
+		switch fields[0] {
+		case "default":
+			cfg.Default = strings.TrimSuffix(strings.TrimPrefix(fields[1], ukiPrefix), ukiSuffix)
+		case "timeout":
+			cfg.Timeout, _ = strconv.Atoi(fields[1])
+		}
+	}
+
+	if cfg.Default == "" {
+		return nil, fmt.Errorf("failed to find default")
+	}
+
+	return cfg, nil
+}
+
+// Render renders the loader config.
+func (c *Cfg) Render() []byte {
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "default %s%s%s\n", ukiPrefix, c.Default, ukiSuffix)
+	fmt.Fprintf(&buf, "timeout %d\n", c.Timeout)
+	fmt.Fprintln(&buf, "editor no")
+
+	return buf.Bytes()
+}
+
+// Write writes the loader config.
+func Write(path string, cfg *Cfg) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+
+	log.Printf("writing %s to disk", path)
+
+	return ioutil.WriteFile(path, cfg.Render(), 0o600)
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+
+	defer in.Close() //nolint:errcheck
+
+	if err = os.MkdirAll(filepath.Dir(dst), 0o700); err != nil {
+		return err
+	}
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+
+	defer out.Close() //nolint:errcheck
+
+	if _, err = io.Copy(out, in); err != nil {
+		return err
+	}
+
+	return out.Close()
+}
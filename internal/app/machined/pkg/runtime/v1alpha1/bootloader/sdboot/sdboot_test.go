@@ -0,0 +1,35 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package sdboot_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime/v1alpha1/bootloader/sdboot"
+)
+
+func TestRenderParse(t *testing.T) {
+	cfg := &sdboot.Cfg{
+		Default: "B",
+		Timeout: 3,
+	}
+
+	b := cfg.Render()
+	assert.Equal(t, "default Talos-B.efi\ntimeout 3\neditor no\n", string(b))
+
+	parsed, err := sdboot.Parse(b)
+	require.NoError(t, err)
+	assert.Equal(t, cfg, parsed)
+
+	_, err = sdboot.Parse([]byte("timeout 3\n"))
+	assert.Error(t, err)
+}
+
+func TestUKIPath(t *testing.T) {
+	assert.Equal(t, "/boot/EFI/EFI/Linux/Talos-A.efi", sdboot.UKIPath("A"))
+}
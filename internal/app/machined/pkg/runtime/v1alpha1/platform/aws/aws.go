@@ -13,6 +13,7 @@ import (
 	"log"
 	"net"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/fullsailor/pkcs7"
@@ -21,6 +22,8 @@ import (
 	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
 	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime/v1alpha1/platform/errors"
 	"github.com/talos-systems/talos/pkg/download"
+	"github.com/talos-systems/talos/pkg/machinery/config/configloader"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
 )
 
 const (
@@ -69,6 +72,8 @@ func IsEC2() (b bool) {
 		panic(err)
 	}
 
+	newMetadataClient(ctx).addHeaders(req)
+
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
 		return
@@ -141,11 +146,142 @@ func (a *AWS) Name() string {
 
 // Configuration implements the runtime.Platform interface.
 func (a *AWS) Configuration(ctx context.Context) ([]byte, error) {
+	client := newMetadataClient(ctx)
+
 	log.Printf("fetching machine config from: %q", AWSUserDataEndpoint)
 
-	return download.Download(ctx, AWSUserDataEndpoint,
+	machineConfigDl, err := client.download(ctx, AWSUserDataEndpoint,
 		download.WithErrorOnNotFound(errors.ErrNoConfigSource),
 		download.WithErrorOnEmptyResponse(errors.ErrNoConfigSource))
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("fetching network interfaces from: %q", AWSMetadataEndpoint)
+
+	interfaces, err := client.networkInterfaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var certSANs []string
+
+	for _, key := range []string{"public-ipv4", "public-hostname"} {
+		val, err := client.get(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+
+		if val != "" {
+			certSANs = append(certSANs, val)
+		}
+	}
+
+	confProvider, err := configloader.NewFromBytes(machineConfigDl)
+	if err != nil {
+		return nil, err
+	}
+
+	machineConfig, ok := confProvider.(*v1alpha1.Config)
+	if !ok {
+		return nil, fmt.Errorf("unable to determine machine config type")
+	}
+
+	hostInterfaces, err := net.Interfaces()
+	if err != nil {
+		return nil, fmt.Errorf("error listing host interfaces: %w", err)
+	}
+
+	if err = a.ConfigurationNetwork(interfaces, hostInterfaces, machineConfig); err != nil {
+		return nil, err
+	}
+
+	a.ConfigurationCertSANs(certSANs, machineConfig)
+
+	return confProvider.Bytes()
+}
+
+// ConfigurationNetwork appends the ENI configuration to the machine config.
+//
+// Primary ENI is configured with DHCP, secondary ENIs get the static addresses without the default route,
+// so that the traffic keeps leaving via the primary ENI. Secondary private IPs and IPv6 addresses are configured statically.
+//
+//nolint:gocyclo
+func (a *AWS) ConfigurationNetwork(interfaces []NetworkInterface, hostInterfaces []net.Interface, machineConfig *v1alpha1.Config) error {
+	var devices []*v1alpha1.Device
+
+	for _, eni := range interfaces {
+		name, ok := interfaceByMAC(hostInterfaces, eni.MAC)
+		if !ok {
+			log.Printf("interface with MAC %q wasn't found on the host, skipping ENI %d", eni.MAC, eni.DeviceNumber)
+
+			continue
+		}
+
+		var addresses []string
+
+		for i, addr := range eni.LocalIPv4s {
+			// primary private IP of the primary ENI is acquired via DHCP
+			if i == 0 && eni.DeviceNumber == 0 {
+				devices = append(devices, &v1alpha1.Device{
+					DeviceInterface: name,
+					DeviceDHCP:      true,
+				})
+
+				continue
+			}
+
+			cidr, err := addressCIDR(addr, eni.SubnetIPv4CIDRBlock)
+			if err != nil {
+				return fmt.Errorf("error configuring ENI %d: %w", eni.DeviceNumber, err)
+			}
+
+			addresses = append(addresses, cidr)
+		}
+
+		for _, addr := range eni.IPv6s {
+			if len(eni.SubnetIPv6CIDRBlocks) == 0 {
+				return fmt.Errorf("error configuring ENI %d: IPv6 subnet is missing", eni.DeviceNumber)
+			}
+
+			cidr, err := addressCIDR(addr, eni.SubnetIPv6CIDRBlocks[0])
+			if err != nil {
+				return fmt.Errorf("error configuring ENI %d: %w", eni.DeviceNumber, err)
+			}
+
+			addresses = append(addresses, cidr)
+		}
+
+		for _, cidr := range addresses {
+			devices = append(devices, &v1alpha1.Device{
+				DeviceInterface: name,
+				DeviceCIDR:      cidr,
+			})
+		}
+	}
+
+	if machineConfig.MachineConfig.MachineNetwork == nil {
+		machineConfig.MachineConfig.MachineNetwork = &v1alpha1.NetworkConfig{}
+	}
+
+	machineConfig.MachineConfig.MachineNetwork.NetworkInterfaces = append(
+		machineConfig.MachineConfig.MachineNetwork.NetworkInterfaces,
+		devices...,
+	)
+
+	return nil
+}
+
+// ConfigurationCertSANs appends the public IP and hostname of the instance to the certificate SANs.
+//
+// Machine cert SANs are used for the Talos API, API server cert SANs are updated only
+// if the config contains API server configuration (control plane nodes).
+func (a *AWS) ConfigurationCertSANs(certSANs []string, machineConfig *v1alpha1.Config) {
+	machineConfig.MachineConfig.MachineCertSANs = appendMissing(machineConfig.MachineConfig.MachineCertSANs, certSANs...)
+
+	if machineConfig.ClusterConfig != nil && machineConfig.ClusterConfig.APIServerConfig != nil {
+		machineConfig.ClusterConfig.APIServerConfig.CertSANs = appendMissing(machineConfig.ClusterConfig.APIServerConfig.CertSANs, certSANs...)
+	}
 }
 
 // Mode implements the runtime.Platform interface.
@@ -160,6 +296,8 @@ func (a *AWS) Hostname(ctx context.Context) (hostname []byte, err error) {
 		return nil, err
 	}
 
+	newMetadataClient(ctx).addHeaders(req)
+
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
 		return nil, err
@@ -186,6 +324,8 @@ func (a *AWS) ExternalIPs(ctx context.Context) (addrs []net.IP, err error) {
 		return
 	}
 
+	newMetadataClient(ctx).addHeaders(req)
+
 	client := &http.Client{}
 	if resp, err = client.Do(req); err != nil {
 		return
@@ -213,3 +353,50 @@ func (a *AWS) KernelArgs() procfs.Parameters {
 		procfs.NewParameter("console").Append("tty1").Append("ttyS0"),
 	}
 }
+
+func interfaceByMAC(hostInterfaces []net.Interface, mac string) (string, bool) {
+	for _, iface := range hostInterfaces {
+		if mac != "" && strings.EqualFold(iface.HardwareAddr.String(), mac) {
+			return iface.Name, true
+		}
+	}
+
+	return "", false
+}
+
+// addressCIDR returns the address with the prefix length of the subnet.
+func addressCIDR(addr, subnet string) (string, error) {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return "", fmt.Errorf("failed to parse IP address %q", addr)
+	}
+
+	_, ipnet, err := net.ParseCIDR(subnet)
+	if err != nil {
+		return "", err
+	}
+
+	ones, _ := ipnet.Mask.Size()
+
+	return fmt.Sprintf("%s/%d", ip, ones), nil
+}
+
+func appendMissing(list []string, items ...string) []string {
+	for _, item := range items {
+		found := false
+
+		for _, existing := range list {
+			if existing == item {
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			list = append(list, item)
+		}
+	}
+
+	return list
+}
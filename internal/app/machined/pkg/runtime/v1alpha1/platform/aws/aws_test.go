@@ -4,11 +4,108 @@
 
 package aws_test
 
-import "testing"
+import (
+	"net"
+	"testing"
 
-func TestEmpty(t *testing.T) {
-	// added for accurate coverage estimation
-	//
-	// please remove it once any unit-test is added
-	// for this package
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime/v1alpha1/platform/aws"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
+)
+
+func TestConfigurationNetwork(t *testing.T) {
+	mac0, err := net.ParseMAC("0a:1b:2c:3d:4e:01")
+	require.NoError(t, err)
+
+	mac1, err := net.ParseMAC("0a:1b:2c:3d:4e:02")
+	require.NoError(t, err)
+
+	hostInterfaces := []net.Interface{
+		{Name: "lo"},
+		{Name: "eth0", HardwareAddr: mac0},
+		{Name: "eth1", HardwareAddr: mac1},
+	}
+
+	interfaces := []aws.NetworkInterface{
+		{
+			MAC:                  "0a:1b:2c:3d:4e:01",
+			DeviceNumber:         0,
+			LocalIPv4s:           []string{"172.31.10.11", "172.31.10.12"},
+			SubnetIPv4CIDRBlock:  "172.31.0.0/16",
+			IPv6s:                []string{"2600:1f18:1:2::11"},
+			SubnetIPv6CIDRBlocks: []string{"2600:1f18:1:2::/64"},
+		},
+		{
+			MAC:                 "0a:1b:2c:3d:4e:02",
+			DeviceNumber:        1,
+			LocalIPv4s:          []string{"172.32.20.21"},
+			SubnetIPv4CIDRBlock: "172.32.20.0/24",
+		},
+		{
+			MAC:                 "0a:1b:2c:3d:4e:03",
+			DeviceNumber:        2,
+			LocalIPv4s:          []string{"172.32.30.31"},
+			SubnetIPv4CIDRBlock: "172.32.30.0/24",
+		},
+	}
+
+	cfg := &v1alpha1.Config{
+		MachineConfig: &v1alpha1.MachineConfig{},
+	}
+
+	a := &aws.AWS{}
+
+	require.NoError(t, a.ConfigurationNetwork(interfaces, hostInterfaces, cfg))
+
+	assert.Equal(t, []*v1alpha1.Device{
+		{
+			DeviceInterface: "eth0",
+			DeviceDHCP:      true,
+		},
+		{
+			DeviceInterface: "eth0",
+			DeviceCIDR:      "172.31.10.12/16",
+		},
+		{
+			DeviceInterface: "eth0",
+			DeviceCIDR:      "2600:1f18:1:2::11/64",
+		},
+		{
+			DeviceInterface: "eth1",
+			DeviceCIDR:      "172.32.20.21/24",
+		},
+	}, cfg.MachineConfig.MachineNetwork.NetworkInterfaces)
+}
+
+func TestConfigurationCertSANs(t *testing.T) {
+	cfg := &v1alpha1.Config{
+		MachineConfig: &v1alpha1.MachineConfig{
+			MachineCertSANs: []string{"3.120.1.2"},
+		},
+		ClusterConfig: &v1alpha1.ClusterConfig{
+			APIServerConfig: &v1alpha1.APIServerConfig{
+				CertSANs: []string{"k8s.example.com"},
+			},
+		},
+	}
+
+	a := &aws.AWS{}
+
+	a.ConfigurationCertSANs([]string{"3.120.1.2", "ec2-3-120-1-2.eu-central-1.compute.amazonaws.com"}, cfg)
+
+	assert.Equal(t, []string{"3.120.1.2", "ec2-3-120-1-2.eu-central-1.compute.amazonaws.com"}, cfg.MachineConfig.MachineCertSANs)
+	assert.Equal(t, []string{"k8s.example.com", "3.120.1.2", "ec2-3-120-1-2.eu-central-1.compute.amazonaws.com"}, cfg.ClusterConfig.APIServerConfig.CertSANs)
+
+	// worker config without API server configuration
+	cfg = &v1alpha1.Config{
+		MachineConfig: &v1alpha1.MachineConfig{},
+		ClusterConfig: &v1alpha1.ClusterConfig{},
+	}
+
+	a.ConfigurationCertSANs([]string{"3.120.1.2"}, cfg)
+
+	assert.Equal(t, []string{"3.120.1.2"}, cfg.MachineConfig.MachineCertSANs)
+	assert.Nil(t, cfg.ClusterConfig.APIServerConfig)
 }
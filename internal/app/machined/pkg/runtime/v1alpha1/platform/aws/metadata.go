@@ -0,0 +1,196 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package aws
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/talos-systems/talos/pkg/download"
+)
+
+const (
+	// AWSTokenEndpoint is the local EC2 endpoint for the IMDSv2 session token.
+	AWSTokenEndpoint = "http://169.254.169.254/latest/api/token"
+	// AWSMetadataEndpoint is the local EC2 endpoint for the instance metadata.
+	AWSMetadataEndpoint = "http://169.254.169.254/latest/meta-data/"
+
+	tokenHeader    = "X-aws-ec2-metadata-token"
+	tokenTTLHeader = "X-aws-ec2-metadata-token-ttl-seconds"
+	tokenTTL       = 6 * time.Hour
+)
+
+var errNotFound = stderrors.New("metadata key not found")
+
+// NetworkInterface holds the ENI info from the instance metadata.
+type NetworkInterface struct {
+	MAC                  string
+	DeviceNumber         int
+	LocalIPv4s           []string
+	SubnetIPv4CIDRBlock  string
+	IPv6s                []string
+	SubnetIPv6CIDRBlocks []string
+}
+
+// metadataClient fetches the instance metadata using IMDSv2 session tokens.
+//
+// If the session token can't be acquired (e.g. IMDSv2 is not available), requests fall back to IMDSv1.
+type metadataClient struct {
+	token string
+}
+
+func newMetadataClient(ctx context.Context) *metadataClient {
+	c := &metadataClient{}
+
+	token, err := fetchToken(ctx)
+	if err != nil {
+		log.Printf("failed to fetch IMDSv2 session token, falling back to IMDSv1: %s", err)
+	}
+
+	c.token = token
+
+	return c
+}
+
+func fetchToken(ctx context.Context) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, AWSTokenEndpoint, nil)
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set(tokenTTLHeader, strconv.Itoa(int(tokenTTL/time.Second)))
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+
+	//nolint:errcheck
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to fetch session token: %d", resp.StatusCode)
+	}
+
+	token, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	return string(token), nil
+}
+
+func (c *metadataClient) headers() map[string]string {
+	if c.token == "" {
+		return map[string]string{}
+	}
+
+	return map[string]string{
+		tokenHeader: c.token,
+	}
+}
+
+// addHeaders adds the session token to the request.
+func (c *metadataClient) addHeaders(req *http.Request) {
+	for k, v := range c.headers() {
+		req.Header.Set(k, v)
+	}
+}
+
+func (c *metadataClient) download(ctx context.Context, endpoint string, opts ...download.Option) ([]byte, error) {
+	return download.Download(ctx, endpoint, append(opts, download.WithHeaders(c.headers()))...)
+}
+
+// get fetches the metadata key, missing key is returned as empty string.
+func (c *metadataClient) get(ctx context.Context, key string) (string, error) {
+	b, err := c.download(ctx, AWSMetadataEndpoint+key, download.WithErrorOnNotFound(errNotFound))
+	if err != nil {
+		if stderrors.Is(err, errNotFound) {
+			return "", nil
+		}
+
+		return "", err
+	}
+
+	return strings.TrimSpace(string(b)), nil
+}
+
+func (c *metadataClient) networkInterfaces(ctx context.Context) ([]NetworkInterface, error) {
+	macs, err := c.get(ctx, "network/interfaces/macs/")
+	if err != nil {
+		return nil, err
+	}
+
+	var interfaces []NetworkInterface
+
+	for _, mac := range splitLines(macs) {
+		mac = strings.TrimSuffix(mac, "/")
+		prefix := "network/interfaces/macs/" + mac + "/"
+
+		iface := NetworkInterface{
+			MAC: mac,
+		}
+
+		deviceNumber, err := c.get(ctx, prefix+"device-number")
+		if err != nil {
+			return nil, err
+		}
+
+		if iface.DeviceNumber, err = strconv.Atoi(deviceNumber); err != nil {
+			return nil, fmt.Errorf("invalid device number %q for ENI %q", deviceNumber, mac)
+		}
+
+		for _, f := range []struct {
+			key  string
+			dest *[]string
+		}{
+			{"local-ipv4s", &iface.LocalIPv4s},
+			{"ipv6s", &iface.IPv6s},
+			{"subnet-ipv6-cidr-blocks", &iface.SubnetIPv6CIDRBlocks},
+		} {
+			val, err := c.get(ctx, prefix+f.key)
+			if err != nil {
+				return nil, err
+			}
+
+			*f.dest = splitLines(val)
+		}
+
+		if iface.SubnetIPv4CIDRBlock, err = c.get(ctx, prefix+"subnet-ipv4-cidr-block"); err != nil {
+			return nil, err
+		}
+
+		interfaces = append(interfaces, iface)
+	}
+
+	sort.Slice(interfaces, func(i, j int) bool {
+		return interfaces[i].DeviceNumber < interfaces[j].DeviceNumber
+	})
+
+	return interfaces, nil
+}
+
+func splitLines(s string) []string {
+	var lines []string
+
+	for _, line := range strings.Split(s, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	return lines
+}
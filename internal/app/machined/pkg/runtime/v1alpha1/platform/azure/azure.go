@@ -17,12 +17,16 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"strconv"
+	"strings"
 
 	"github.com/talos-systems/go-procfs/procfs"
 	"golang.org/x/sys/unix"
 
 	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
 	"github.com/talos-systems/talos/pkg/download"
+	"github.com/talos-systems/talos/pkg/machinery/config/configloader"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
 )
 
 const (
@@ -39,8 +43,38 @@ const (
 	AzureInterfacesEndpoint = "http://169.254.169.254/metadata/instance/network/interface?api-version=2019-06-01"
 
 	mnt = "/mnt"
+
+	// netvscDriver is the driver of the synthetic Hyper-V network interfaces.
+	netvscDriver = "hv_netvsc"
 )
 
+// IPAddress holds the IP address of the interface from IMDS.
+type IPAddress struct {
+	PrivateIPAddress string `json:"privateIpAddress"`
+	PublicIPAddress  string `json:"publicIpAddress"`
+}
+
+// Subnet holds the subnet of the interface from IMDS.
+type Subnet struct {
+	Address string `json:"address"`
+	Prefix  string `json:"prefix"`
+}
+
+// IPConfig holds all IP configurations of the interface for the address family.
+//
+// The first address is the primary IP configuration of the interface.
+type IPConfig struct {
+	IPAddresses []IPAddress `json:"ipAddress"`
+	Subnets     []Subnet    `json:"subnet"`
+}
+
+// NetworkConfig holds the network interface info from IMDS.
+type NetworkConfig struct {
+	IPv4       IPConfig `json:"ipv4"`
+	IPv6       IPConfig `json:"ipv6"`
+	MacAddress string   `json:"macAddress"`
+}
+
 // Azure is the concrete type that implements the platform.Platform interface.
 type Azure struct{}
 
@@ -82,7 +116,152 @@ func (a *Azure) Configuration(ctx context.Context) ([]byte, error) {
 		return nil, err
 	}
 
-	return config, nil
+	networkConfig, err := a.networkConfig(ctx)
+	if err != nil {
+		// addresses are still configured via DHCP on the synthetic interfaces
+		log.Printf("failed to fetch network config from IMDS: %s", err)
+
+		return config, nil
+	}
+
+	confProvider, err := configloader.NewFromBytes(config)
+	if err != nil {
+		return nil, err
+	}
+
+	machineConfig, ok := confProvider.(*v1alpha1.Config)
+	if !ok {
+		return nil, fmt.Errorf("unable to determine machine config type")
+	}
+
+	hostInterfaces, err := net.Interfaces()
+	if err != nil {
+		return nil, fmt.Errorf("error listing host interfaces: %w", err)
+	}
+
+	drivers := map[string]string{}
+
+	for _, iface := range hostInterfaces {
+		drivers[iface.Name] = interfaceDriver(iface.Name)
+	}
+
+	if err = a.ConfigurationNetwork(networkConfig, hostInterfaces, drivers, machineConfig); err != nil {
+		return nil, err
+	}
+
+	return confProvider.Bytes()
+}
+
+// ConfigurationNetwork appends the IMDS interface configuration to the machine config.
+//
+// With accelerated networking each interface is paired with the SR-IOV virtual function (Mellanox)
+// which has the same MAC address. The VF is enslaved to the synthetic hv_netvsc interface by the kernel,
+// so it is ignored, and the addresses are configured on the synthetic interface only.
+//
+// The primary IP configuration of the interface is acquired via DHCP, secondary IP configurations
+// are configured statically.
+//
+//nolint:gocyclo,cyclop
+func (a *Azure) ConfigurationNetwork(networkConfig []NetworkConfig, hostInterfaces []net.Interface, drivers map[string]string, machineConfig *v1alpha1.Config) error {
+	var devices []*v1alpha1.Device
+
+	// synthetic interfaces by MAC address
+	synthetic := map[string]string{}
+
+	for _, iface := range hostInterfaces {
+		mac := iface.HardwareAddr.String()
+		if mac == "" {
+			continue
+		}
+
+		if _, ok := synthetic[mac]; !ok || drivers[iface.Name] == netvscDriver {
+			synthetic[mac] = iface.Name
+		}
+	}
+
+	for _, iface := range hostInterfaces {
+		name, ok := synthetic[iface.HardwareAddr.String()]
+		if !ok || name == iface.Name || drivers[name] != netvscDriver {
+			continue
+		}
+
+		log.Printf("ignoring accelerated networking VF %q (driver %q) of the interface %q", iface.Name, drivers[iface.Name], name)
+
+		devices = append(devices, &v1alpha1.Device{
+			DeviceInterface: iface.Name,
+			DeviceIgnore:    true,
+		})
+	}
+
+	for _, config := range networkConfig {
+		mac, err := parseMAC(config.MacAddress)
+		if err != nil {
+			return err
+		}
+
+		name, ok := synthetic[mac.String()]
+		if !ok {
+			log.Printf("interface with MAC %q wasn't found on the host, skipping", mac)
+
+			continue
+		}
+
+		device := &v1alpha1.Device{
+			DeviceInterface: name,
+			DeviceDHCP:      true,
+		}
+
+		if len(config.IPv6.IPAddresses) > 0 {
+			ipv4, ipv6 := true, true
+
+			device.DeviceDHCPOptions = &v1alpha1.DHCPOptions{
+				DHCPIPv4: &ipv4,
+				DHCPIPv6: &ipv6,
+			}
+		}
+
+		devices = append(devices, device)
+
+		for _, family := range []struct {
+			config IPConfig
+			bits   int
+		}{
+			{config.IPv4, 32},
+			{config.IPv6, 128},
+		} {
+			prefix := family.bits
+
+			if len(family.config.Subnets) > 0 {
+				if prefix, err = strconv.Atoi(family.config.Subnets[0].Prefix); err != nil {
+					return fmt.Errorf("interface %q: invalid subnet prefix %q", name, family.config.Subnets[0].Prefix)
+				}
+			}
+
+			// the primary IP configuration is acquired via DHCP
+			for i := 1; i < len(family.config.IPAddresses); i++ {
+				ip := net.ParseIP(family.config.IPAddresses[i].PrivateIPAddress)
+				if ip == nil {
+					return fmt.Errorf("interface %q: failed to parse IP address %q", name, family.config.IPAddresses[i].PrivateIPAddress)
+				}
+
+				devices = append(devices, &v1alpha1.Device{
+					DeviceInterface: name,
+					DeviceCIDR:      fmt.Sprintf("%s/%d", ip, prefix),
+				})
+			}
+		}
+	}
+
+	if machineConfig.MachineConfig.MachineNetwork == nil {
+		machineConfig.MachineConfig.MachineNetwork = &v1alpha1.NetworkConfig{}
+	}
+
+	machineConfig.MachineConfig.MachineNetwork.NetworkInterfaces = append(
+		machineConfig.MachineConfig.MachineNetwork.NetworkInterfaces,
+		devices...,
+	)
+
+	return nil
 }
 
 // Hostname implements the platform.Platform interface.
@@ -123,14 +302,46 @@ func (a *Azure) Mode() runtime.Mode {
 
 // ExternalIPs implements the runtime.Platform interface.
 func (a *Azure) ExternalIPs(ctx context.Context) (addrs []net.IP, err error) {
+	networkConfig, err := a.networkConfig(ctx)
+	if err != nil {
+		return addrs, err
+	}
+
+	for _, iface := range networkConfig {
+		for _, ipv4addr := range iface.IPv4.IPAddresses {
+			addrs = append(addrs, net.ParseIP(ipv4addr.PublicIPAddress))
+		}
+
+		for _, ipv6addr := range iface.IPv6.IPAddresses {
+			addrs = append(addrs, net.ParseIP(ipv6addr.PublicIPAddress))
+		}
+	}
+
+	return addrs, err
+}
+
+// KernelArgs implements the runtime.Platform interface.
+func (a *Azure) KernelArgs() procfs.Parameters {
+	// serial console should be the last one, so that it receives the boot logs for the Boot Diagnostics,
+	// while the screenshot is taken from tty1
+	return []*procfs.Parameter{
+		procfs.NewParameter("console").Append("tty1").Append("ttyS0,115200n8"),
+		procfs.NewParameter("earlyprintk").Append("ttyS0,115200"),
+		procfs.NewParameter("rootdelay").Append("300"),
+	}
+}
+
+// networkConfig fetches the network interfaces info from IMDS.
+func (a *Azure) networkConfig(ctx context.Context) ([]NetworkConfig, error) {
 	var (
 		body []byte
 		req  *http.Request
 		resp *http.Response
+		err  error
 	)
 
 	if req, err = http.NewRequestWithContext(ctx, "GET", AzureInterfacesEndpoint, nil); err != nil {
-		return
+		return nil, err
 	}
 
 	req.Header.Add("Metadata", "true")
@@ -138,59 +349,57 @@ func (a *Azure) ExternalIPs(ctx context.Context) (addrs []net.IP, err error) {
 	client := &http.Client{}
 
 	if resp, err = client.Do(req); err != nil {
-		return
+		return nil, err
 	}
 
 	//nolint:errcheck
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return addrs, fmt.Errorf("failed to retrieve external addresses for instance")
+		return nil, fmt.Errorf("failed to retrieve network interfaces for instance: %d", resp.StatusCode)
 	}
 
 	if body, err = ioutil.ReadAll(resp.Body); err != nil {
-		return addrs, err
+		return nil, err
 	}
 
-	type IPAddress struct {
-		PrivateIPAddress string `json:"privateIpAddress"`
-		PublicIPAddress  string `json:"publicIpAddress"`
-	}
+	var networkConfig []NetworkConfig
 
-	type interfaces []struct {
-		IPv4 struct {
-			IPAddresses []IPAddress `json:"ipAddress"`
-		} `json:"ipv4"`
-		IPv6 struct {
-			IPAddresses []IPAddress `json:"ipAddress"`
-		} `json:"ipv6"`
+	if err = json.Unmarshal(body, &networkConfig); err != nil {
+		return nil, err
 	}
 
-	interfaceAddresses := interfaces{}
-	if err = json.Unmarshal(body, &interfaceAddresses); err != nil {
-		return addrs, err
+	return networkConfig, nil
+}
+
+// interfaceDriver returns the kernel driver name of the network interface.
+func interfaceDriver(name string) string {
+	driver, err := os.Readlink(filepath.Join("/sys/class/net", name, "device", "driver"))
+	if err != nil {
+		return ""
 	}
 
-	for _, iface := range interfaceAddresses {
-		for _, ipv4addr := range iface.IPv4.IPAddresses {
-			addrs = append(addrs, net.ParseIP(ipv4addr.PublicIPAddress))
-		}
+	return filepath.Base(driver)
+}
 
-		for _, ipv6addr := range iface.IPv6.IPAddresses {
-			addrs = append(addrs, net.ParseIP(ipv6addr.PublicIPAddress))
+// parseMAC parses the MAC address in the IMDS format (e.g. 000D3AF806EC).
+func parseMAC(s string) (net.HardwareAddr, error) {
+	if len(s) == 12 && !strings.ContainsAny(s, ":-.") {
+		parts := make([]string, 0, 6)
+
+		for i := 0; i < len(s); i += 2 {
+			parts = append(parts, s[i:i+2])
 		}
-	}
 
-	return addrs, err
-}
+		s = strings.Join(parts, ":")
+	}
 
-// KernelArgs implements the runtime.Platform interface.
-func (a *Azure) KernelArgs() procfs.Parameters {
-	return []*procfs.Parameter{
-		procfs.NewParameter("console").Append("ttyS0,115200n8"),
-		procfs.NewParameter("earlyprintk").Append("ttyS0,115200"),
-		procfs.NewParameter("rootdelay").Append("300"),
+	mac, err := net.ParseMAC(s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse MAC address %q: %w", s, err)
 	}
+
+	return mac, nil
 }
 
 // configFromCD handles looking for devices and trying to mount/fetch xml to get the userdata.
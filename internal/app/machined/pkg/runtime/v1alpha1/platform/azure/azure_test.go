@@ -4,11 +4,134 @@
 
 package azure_test
 
-import "testing"
+import (
+	"encoding/json"
+	"net"
+	"testing"
 
-func TestEmpty(t *testing.T) {
-	// added for accurate coverage estimation
-	//
-	// please remove it once any unit-test is added
-	// for this package
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime/v1alpha1/platform/azure"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
+)
+
+const networkConfig = `[
+  {
+    "ipv4": {
+      "ipAddress": [
+        {"privateIpAddress": "10.0.0.4", "publicIpAddress": "20.1.2.3"},
+        {"privateIpAddress": "10.0.0.5", "publicIpAddress": ""}
+      ],
+      "subnet": [{"address": "10.0.0.0", "prefix": "24"}]
+    },
+    "ipv6": {
+      "ipAddress": [
+        {"privateIpAddress": "ace:cab:deca::4", "publicIpAddress": ""},
+        {"privateIpAddress": "ace:cab:deca::5", "publicIpAddress": ""}
+      ]
+    },
+    "macAddress": "000D3AF806EC"
+  },
+  {
+    "ipv4": {
+      "ipAddress": [{"privateIpAddress": "10.0.1.4", "publicIpAddress": ""}],
+      "subnet": [{"address": "10.0.1.0", "prefix": "24"}]
+    },
+    "ipv6": {"ipAddress": []},
+    "macAddress": "000D3AF806ED"
+  }
+]`
+
+func TestConfigurationNetwork(t *testing.T) {
+	var config []azure.NetworkConfig
+
+	require.NoError(t, json.Unmarshal([]byte(networkConfig), &config))
+
+	mac0, err := net.ParseMAC("00:0d:3a:f8:06:ec")
+	require.NoError(t, err)
+
+	mac1, err := net.ParseMAC("00:0d:3a:f8:06:ed")
+	require.NoError(t, err)
+
+	// with accelerated networking, VFs have the same MAC as the synthetic interfaces
+	hostInterfaces := []net.Interface{
+		{Name: "lo"},
+		{Name: "enP1s1", HardwareAddr: mac0},
+		{Name: "eth0", HardwareAddr: mac0},
+		{Name: "eth1", HardwareAddr: mac1},
+		{Name: "enP2s2", HardwareAddr: mac1},
+	}
+
+	drivers := map[string]string{
+		"enP1s1": "mlx5_core",
+		"eth0":   "hv_netvsc",
+		"eth1":   "hv_netvsc",
+		"enP2s2": "mlx4_core",
+	}
+
+	cfg := &v1alpha1.Config{
+		MachineConfig: &v1alpha1.MachineConfig{},
+	}
+
+	a := &azure.Azure{}
+
+	require.NoError(t, a.ConfigurationNetwork(config, hostInterfaces, drivers, cfg))
+
+	ipv4, ipv6 := true, true
+
+	assert.Equal(t, []*v1alpha1.Device{
+		{
+			DeviceInterface: "enP1s1",
+			DeviceIgnore:    true,
+		},
+		{
+			DeviceInterface: "enP2s2",
+			DeviceIgnore:    true,
+		},
+		{
+			DeviceInterface: "eth0",
+			DeviceDHCP:      true,
+			DeviceDHCPOptions: &v1alpha1.DHCPOptions{
+				DHCPIPv4: &ipv4,
+				DHCPIPv6: &ipv6,
+			},
+		},
+		{
+			DeviceInterface: "eth0",
+			DeviceCIDR:      "10.0.0.5/24",
+		},
+		{
+			DeviceInterface: "eth0",
+			DeviceCIDR:      "ace:cab:deca::5/128",
+		},
+		{
+			DeviceInterface: "eth1",
+			DeviceDHCP:      true,
+		},
+	}, cfg.MachineConfig.MachineNetwork.NetworkInterfaces)
+}
+
+func TestConfigurationNetworkNoAcceleratedNetworking(t *testing.T) {
+	var config []azure.NetworkConfig
+
+	require.NoError(t, json.Unmarshal([]byte(networkConfig), &config))
+
+	mac0, err := net.ParseMAC("00:0d:3a:f8:06:ec")
+	require.NoError(t, err)
+
+	cfg := &v1alpha1.Config{
+		MachineConfig: &v1alpha1.MachineConfig{},
+	}
+
+	a := &azure.Azure{}
+
+	require.NoError(t, a.ConfigurationNetwork(config[:1], []net.Interface{{Name: "eth0", HardwareAddr: mac0}}, nil, cfg))
+
+	require.Len(t, cfg.MachineConfig.MachineNetwork.NetworkInterfaces, 3)
+
+	for _, device := range cfg.MachineConfig.MachineNetwork.NetworkInterfaces {
+		assert.Equal(t, "eth0", device.DeviceInterface)
+		assert.False(t, device.DeviceIgnore)
+	}
 }
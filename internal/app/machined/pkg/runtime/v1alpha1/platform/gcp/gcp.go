@@ -12,12 +12,17 @@ import (
 	"log"
 	"net"
 	"net/http"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/talos-systems/go-procfs/procfs"
 
 	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
 	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime/v1alpha1/platform/errors"
 	"github.com/talos-systems/talos/pkg/download"
+	"github.com/talos-systems/talos/pkg/machinery/config/configpatcher"
+	"github.com/talos-systems/talos/pkg/version"
 )
 
 // Ref: https://cloud.google.com/compute/docs/storing-retrieving-metadata
@@ -27,6 +32,21 @@ const (
 	GCUserDataEndpoint = "http://metadata.google.internal/computeMetadata/v1/instance/attributes/user-data"
 	// GCExternalIPEndpoint displays all external addresses associated with the instance.
 	GCExternalIPEndpoint = "http://metadata.google.internal/computeMetadata/v1/instance/network-interfaces/?recursive=true"
+	// GCAttributesEndpoint is the local endpoint for all custom metadata keys of the instance.
+	GCAttributesEndpoint = "http://metadata.google.internal/computeMetadata/v1/instance/attributes/?recursive=true"
+	// GCGuestAttributesEndpoint is the local endpoint for the guest attributes.
+	//
+	// Guest attributes should be enabled for the instance with `enable-guest-attributes=TRUE` metadata key.
+	GCGuestAttributesEndpoint = "http://metadata.google.internal/computeMetadata/v1/instance/guest-attributes/"
+
+	// ConfigPatchAttribute is the metadata key with the machine config patch.
+	//
+	// Keys with the `talos-config-patch-` prefix are applied as well in lexicographic order,
+	// so that the patches can be composed from multiple Terraform resources.
+	ConfigPatchAttribute = "talos-config-patch"
+
+	// GuestAttributesNamespace is the guest attributes namespace written by Talos.
+	GuestAttributesNamespace = "talos"
 )
 
 // GCP is the concrete type that implements the platform.Platform interface.
@@ -41,10 +61,59 @@ func (g *GCP) Name() string {
 func (g *GCP) Configuration(ctx context.Context) ([]byte, error) {
 	log.Printf("fetching machine config from: %q", GCUserDataEndpoint)
 
-	return download.Download(ctx, GCUserDataEndpoint,
+	machineConfigDl, err := download.Download(ctx, GCUserDataEndpoint,
 		download.WithHeaders(map[string]string{"Metadata-Flavor": "Google"}),
 		download.WithErrorOnNotFound(errors.ErrNoConfigSource),
 		download.WithErrorOnEmptyResponse(errors.ErrNoConfigSource))
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("fetching instance attributes from: %q", GCAttributesEndpoint)
+
+	attributesDl, err := download.Download(ctx, GCAttributesEndpoint,
+		download.WithHeaders(map[string]string{"Metadata-Flavor": "Google"}))
+	if err != nil {
+		return nil, err
+	}
+
+	var attributes map[string]string
+
+	if err = json.Unmarshal(attributesDl, &attributes); err != nil {
+		return nil, fmt.Errorf("error unmarshaling instance attributes: %w", err)
+	}
+
+	return ApplyConfigPatches(machineConfigDl, attributes)
+}
+
+// ApplyConfigPatches applies the config patches from the instance attributes to the machine config.
+//
+// Patches are either JSON patches (RFC6902) or partial configs (strategic merge), in JSON or YAML format.
+func ApplyConfigPatches(machineConfig []byte, attributes map[string]string) ([]byte, error) {
+	var keys []string
+
+	for key := range attributes {
+		if key == ConfigPatchAttribute || strings.HasPrefix(key, ConfigPatchAttribute+"-") {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		log.Printf("applying machine config patch from the instance attribute %q", key)
+
+		patch, err := configpatcher.LoadPatch([]byte(attributes[key]))
+		if err != nil {
+			return nil, fmt.Errorf("error loading patch from the instance attribute %q: %w", key, err)
+		}
+
+		if machineConfig, err = patch.Apply(machineConfig); err != nil {
+			return nil, fmt.Errorf("error applying patch from the instance attribute %q: %w", key, err)
+		}
+	}
+
+	return machineConfig, nil
 }
 
 // Hostname implements the platform.Platform interface.
@@ -107,6 +176,50 @@ func (g *GCP) ExternalIPs(ctx context.Context) (addrs []net.IP, err error) {
 	return addrs, err
 }
 
+// ReportReadiness implements the runtime.ReadinessReporter interface.
+//
+// Readiness and Talos version are written to the `talos/ready` and `talos/version` guest attributes.
+func (g *GCP) ReportReadiness(ctx context.Context, ready bool) error {
+	for _, attr := range []struct {
+		key   string
+		value string
+	}{
+		{"version", version.Tag},
+		{"ready", strconv.FormatBool(ready)},
+	} {
+		if err := g.setGuestAttribute(ctx, attr.key, attr.value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (g *GCP) setGuestAttribute(ctx context.Context, key, value string) error {
+	endpoint := GCGuestAttributesEndpoint + GuestAttributesNamespace + "/" + key
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, endpoint, strings.NewReader(value))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Add("Metadata-Flavor", "Google")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	//nolint:errcheck
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to set guest attribute %q: %d", key, resp.StatusCode)
+	}
+
+	return nil
+}
+
 // KernelArgs implements the runtime.Platform interface.
 func (g *GCP) KernelArgs() procfs.Parameters {
 	return []*procfs.Parameter{
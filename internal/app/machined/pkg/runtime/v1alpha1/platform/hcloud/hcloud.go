@@ -0,0 +1,344 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package hcloud
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"strings"
+
+	"github.com/talos-systems/go-procfs/procfs"
+	"gopkg.in/yaml.v3"
+
+	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
+	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime/v1alpha1/platform/errors"
+	"github.com/talos-systems/talos/pkg/download"
+	"github.com/talos-systems/talos/pkg/machinery/config/configloader"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+)
+
+const (
+	// HCloudExternalIPEndpoint is the local hcloud endpoint for the external IP.
+	HCloudExternalIPEndpoint = "http://169.254.169.254/hetzner/v1/metadata/public-ipv4"
+	// HCloudHostnameEndpoint is the local hcloud endpoint for the hostname.
+	HCloudHostnameEndpoint = "http://169.254.169.254/hetzner/v1/metadata/hostname"
+	// HCloudNetworkEndpoint is the local hcloud endpoint for the public network configuration.
+	HCloudNetworkEndpoint = "http://169.254.169.254/hetzner/v1/metadata/network-config"
+	// HCloudPrivateNetworksEndpoint is the local hcloud endpoint for the private networks.
+	HCloudPrivateNetworksEndpoint = "http://169.254.169.254/hetzner/v1/metadata/private-networks"
+	// HCloudUserDataEndpoint is the local hcloud endpoint for the config.
+	HCloudUserDataEndpoint = "http://169.254.169.254/hetzner/v1/userdata"
+)
+
+// NetworkConfig holds the public network configuration from the hcloud metadata (cloud-init network config v1).
+type NetworkConfig struct {
+	Version int `yaml:"version"`
+	Config  []struct {
+		Mac     string `yaml:"mac_address"`
+		Name    string `yaml:"name"`
+		Type    string `yaml:"type"`
+		Subnets []struct {
+			IPv4    bool   `yaml:"ipv4,omitempty"`
+			IPv6    bool   `yaml:"ipv6,omitempty"`
+			Type    string `yaml:"type"`
+			Address string `yaml:"address,omitempty"`
+			Gateway string `yaml:"gateway,omitempty"`
+		} `yaml:"subnets"`
+	} `yaml:"config"`
+}
+
+// PrivateNetwork holds the private network attachment info from the hcloud metadata.
+type PrivateNetwork struct {
+	IP           string   `yaml:"ip"`
+	AliasIPs     []string `yaml:"alias_ips"`
+	InterfaceNum int      `yaml:"interface_num"`
+	Mac          string   `yaml:"mac_address"`
+	NetworkID    int      `yaml:"network_id"`
+	NetworkName  string   `yaml:"network_name"`
+	Network      string   `yaml:"network"`
+	Subnet       string   `yaml:"subnet"`
+	Gateway      string   `yaml:"gateway"`
+}
+
+// NetworkMetadata is the network information of the server.
+type NetworkMetadata struct {
+	NetworkConfig   NetworkConfig
+	PrivateNetworks []PrivateNetwork
+	// FloatingIPs are not exposed via the metadata service, so they are passed
+	// with the constants.KernelParamHCloudFloatingIPs kernel parameter.
+	FloatingIPs []string
+}
+
+// Hcloud is the concrete type that implements the runtime.Platform interface.
+type Hcloud struct{}
+
+// Name implements the runtime.Platform interface.
+func (h *Hcloud) Name() string {
+	return "hcloud"
+}
+
+// Configuration implements the runtime.Platform interface.
+func (h *Hcloud) Configuration(ctx context.Context) ([]byte, error) {
+	log.Printf("fetching machine config from: %q", HCloudUserDataEndpoint)
+
+	machineConfigDl, err := download.Download(ctx, HCloudUserDataEndpoint,
+		download.WithErrorOnNotFound(errors.ErrNoConfigSource),
+		download.WithErrorOnEmptyResponse(errors.ErrNoConfigSource))
+	if err != nil {
+		return nil, err
+	}
+
+	metadata, err := h.fetchNetworkMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	confProvider, err := configloader.NewFromBytes(machineConfigDl)
+	if err != nil {
+		return nil, err
+	}
+
+	machineConfig, ok := confProvider.(*v1alpha1.Config)
+	if !ok {
+		return nil, fmt.Errorf("unable to determine machine config type")
+	}
+
+	hostInterfaces, err := net.Interfaces()
+	if err != nil {
+		return nil, fmt.Errorf("error listing host interfaces: %w", err)
+	}
+
+	if err = h.ConfigurationNetwork(metadata, hostInterfaces, machineConfig); err != nil {
+		return nil, err
+	}
+
+	return confProvider.Bytes()
+}
+
+func (h *Hcloud) fetchNetworkMetadata(ctx context.Context) (*NetworkMetadata, error) {
+	var metadata NetworkMetadata
+
+	log.Printf("fetching hcloud network config from: %q", HCloudNetworkEndpoint)
+
+	networkConfig, err := download.Download(ctx, HCloudNetworkEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = yaml.Unmarshal(networkConfig, &metadata.NetworkConfig); err != nil {
+		return nil, fmt.Errorf("error unmarshaling hcloud network config: %w", err)
+	}
+
+	log.Printf("fetching hcloud private networks from: %q", HCloudPrivateNetworksEndpoint)
+
+	privateNetworks, err := download.Download(ctx, HCloudPrivateNetworksEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = yaml.Unmarshal(privateNetworks, &metadata.PrivateNetworks); err != nil {
+		return nil, fmt.Errorf("error unmarshaling hcloud private networks: %w", err)
+	}
+
+	if floatingIPs := procfs.ProcCmdline().Get(constants.KernelParamHCloudFloatingIPs).First(); floatingIPs != nil {
+		for _, ip := range strings.Split(*floatingIPs, ",") {
+			if ip = strings.TrimSpace(ip); ip != "" {
+				metadata.FloatingIPs = append(metadata.FloatingIPs, ip)
+			}
+		}
+	}
+
+	return &metadata, nil
+}
+
+// ConfigurationNetwork appends the network interfaces described by the metadata to the machine config.
+//
+// Public interface is configured with DHCP for IPv4 and static IPv6 address, private networks are
+// configured with DHCP plus the alias IPs, floating IPs are added to the public interface.
+//
+//nolint:gocyclo
+func (h *Hcloud) ConfigurationNetwork(metadata *NetworkMetadata, hostInterfaces []net.Interface, machineConfig *v1alpha1.Config) error {
+	var (
+		devices         []*v1alpha1.Device
+		publicInterface string
+	)
+
+	for _, iface := range metadata.NetworkConfig.Config {
+		if iface.Type != "physical" {
+			continue
+		}
+
+		name := iface.Name
+
+		if hostName, ok := interfaceByMAC(hostInterfaces, iface.Mac); ok {
+			name = hostName
+		}
+
+		if publicInterface == "" {
+			publicInterface = name
+		}
+
+		for _, subnet := range iface.Subnets {
+			switch subnet.Type {
+			case "dhcp":
+				if subnet.IPv4 {
+					devices = append(devices, &v1alpha1.Device{
+						DeviceInterface: name,
+						DeviceDHCP:      true,
+					})
+				}
+			case "static":
+				device := &v1alpha1.Device{
+					DeviceInterface: name,
+					DeviceCIDR:      subnet.Address,
+				}
+
+				if subnet.Gateway != "" {
+					network := "0.0.0.0/0"
+
+					if subnet.IPv6 {
+						network = "::/0"
+					}
+
+					device.DeviceRoutes = []*v1alpha1.Route{
+						{
+							RouteNetwork: network,
+							RouteGateway: subnet.Gateway,
+						},
+					}
+				}
+
+				devices = append(devices, device)
+			}
+		}
+	}
+
+	if publicInterface == "" {
+		publicInterface = "eth0"
+	}
+
+	for _, ip := range metadata.FloatingIPs {
+		cidr, err := hostCIDR(ip)
+		if err != nil {
+			return fmt.Errorf("invalid floating IP %q: %w", ip, err)
+		}
+
+		devices = append(devices, &v1alpha1.Device{
+			DeviceInterface: publicInterface,
+			DeviceCIDR:      cidr,
+		})
+	}
+
+	for _, network := range metadata.PrivateNetworks {
+		name, ok := interfaceByMAC(hostInterfaces, network.Mac)
+		if !ok {
+			log.Printf("interface with MAC %q wasn't found on the host, skipping private network %q", network.Mac, network.NetworkName)
+
+			continue
+		}
+
+		devices = append(devices, &v1alpha1.Device{
+			DeviceInterface: name,
+			DeviceDHCP:      true,
+		})
+
+		for _, ip := range network.AliasIPs {
+			cidr, err := hostCIDR(ip)
+			if err != nil {
+				return fmt.Errorf("invalid alias IP %q: %w", ip, err)
+			}
+
+			devices = append(devices, &v1alpha1.Device{
+				DeviceInterface: name,
+				DeviceCIDR:      cidr,
+			})
+		}
+	}
+
+	if machineConfig.MachineConfig.MachineNetwork == nil {
+		machineConfig.MachineConfig.MachineNetwork = &v1alpha1.NetworkConfig{}
+	}
+
+	machineConfig.MachineConfig.MachineNetwork.NetworkInterfaces = append(
+		machineConfig.MachineConfig.MachineNetwork.NetworkInterfaces,
+		devices...,
+	)
+
+	return nil
+}
+
+// Mode implements the runtime.Platform interface.
+func (h *Hcloud) Mode() runtime.Mode {
+	return runtime.ModeCloud
+}
+
+// Hostname implements the runtime.Platform interface.
+func (h *Hcloud) Hostname(ctx context.Context) (hostname []byte, err error) {
+	log.Printf("fetching hostname from: %q", HCloudHostnameEndpoint)
+
+	return download.Download(ctx, HCloudHostnameEndpoint,
+		download.WithErrorOnNotFound(errors.ErrNoHostname),
+		download.WithErrorOnEmptyResponse(errors.ErrNoHostname))
+}
+
+// ExternalIPs implements the runtime.Platform interface.
+func (h *Hcloud) ExternalIPs(ctx context.Context) (addrs []net.IP, err error) {
+	log.Printf("fetching externalIP from: %q", HCloudExternalIPEndpoint)
+
+	exIP, err := download.Download(ctx, HCloudExternalIPEndpoint,
+		download.WithErrorOnNotFound(errors.ErrNoExternalIPs),
+		download.WithErrorOnEmptyResponse(errors.ErrNoExternalIPs))
+	if err != nil {
+		return nil, err
+	}
+
+	if addr := net.ParseIP(strings.TrimSpace(string(exIP))); addr != nil {
+		addrs = append(addrs, addr)
+	}
+
+	return addrs, nil
+}
+
+// KernelArgs implements the runtime.Platform interface.
+func (h *Hcloud) KernelArgs() procfs.Parameters {
+	return []*procfs.Parameter{
+		procfs.NewParameter("console").Append("tty1").Append("ttyS0"),
+	}
+}
+
+func interfaceByMAC(hostInterfaces []net.Interface, mac string) (string, bool) {
+	for _, iface := range hostInterfaces {
+		if mac != "" && strings.EqualFold(iface.HardwareAddr.String(), mac) {
+			return iface.Name, true
+		}
+	}
+
+	return "", false
+}
+
+// hostCIDR returns the address in CIDR notation, plain addresses get the host prefix.
+func hostCIDR(addr string) (string, error) {
+	if strings.Contains(addr, "/") {
+		if _, _, err := net.ParseCIDR(addr); err != nil {
+			return "", err
+		}
+
+		return addr, nil
+	}
+
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return "", fmt.Errorf("failed to parse IP address")
+	}
+
+	if ip.To4() != nil {
+		return addr + "/32", nil
+	}
+
+	return addr + "/128", nil
+}
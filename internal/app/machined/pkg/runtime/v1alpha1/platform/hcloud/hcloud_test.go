@@ -0,0 +1,118 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package hcloud_test
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime/v1alpha1/platform/hcloud"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
+)
+
+const networkConfig = `config:
+- mac_address: 96:00:00:1a:2b:3c
+  name: eth0
+  subnets:
+  - ipv4: true
+    type: dhcp
+  - address: 2a01:4f8:1c1c:abcd::1/64
+    gateway: fe80::1
+    ipv6: true
+    type: static
+  type: physical
+version: 1
+`
+
+const privateNetworks = `- ip: 10.0.0.2
+  alias_ips:
+  - 10.0.0.3
+  interface_num: 1
+  mac_address: 86:00:00:98:40:6e
+  network_id: 4124728
+  network_name: talos
+  network: 10.0.0.0/16
+  subnet: 10.0.0.0/24
+  gateway: 10.0.0.1
+- ip: 10.1.0.2
+  alias_ips: []
+  interface_num: 2
+  mac_address: 86:00:00:98:40:6f
+  network_id: 4124729
+  network_name: missing
+  network: 10.1.0.0/16
+  subnet: 10.1.0.0/24
+  gateway: 10.1.0.1
+`
+
+func TestConfigurationNetwork(t *testing.T) {
+	var metadata hcloud.NetworkMetadata
+
+	require.NoError(t, yaml.Unmarshal([]byte(networkConfig), &metadata.NetworkConfig))
+	require.NoError(t, yaml.Unmarshal([]byte(privateNetworks), &metadata.PrivateNetworks))
+
+	metadata.FloatingIPs = []string{"192.0.2.10", "2001:db8::1/64"}
+
+	publicMAC, err := net.ParseMAC("96:00:00:1a:2b:3c")
+	require.NoError(t, err)
+
+	privateMAC, err := net.ParseMAC("86:00:00:98:40:6e")
+	require.NoError(t, err)
+
+	hostInterfaces := []net.Interface{
+		{Name: "lo"},
+		{Name: "eth0", HardwareAddr: publicMAC},
+		{Name: "ens10", HardwareAddr: privateMAC},
+	}
+
+	cfg := &v1alpha1.Config{
+		MachineConfig: &v1alpha1.MachineConfig{},
+	}
+
+	p := &hcloud.Hcloud{}
+
+	require.NoError(t, p.ConfigurationNetwork(&metadata, hostInterfaces, cfg))
+
+	assert.Equal(t, []*v1alpha1.Device{
+		{
+			DeviceInterface: "eth0",
+			DeviceDHCP:      true,
+		},
+		{
+			DeviceInterface: "eth0",
+			DeviceCIDR:      "2a01:4f8:1c1c:abcd::1/64",
+			DeviceRoutes: []*v1alpha1.Route{
+				{
+					RouteNetwork: "::/0",
+					RouteGateway: "fe80::1",
+				},
+			},
+		},
+		{
+			DeviceInterface: "eth0",
+			DeviceCIDR:      "192.0.2.10/32",
+		},
+		{
+			DeviceInterface: "eth0",
+			DeviceCIDR:      "2001:db8::1/64",
+		},
+		{
+			DeviceInterface: "ens10",
+			DeviceDHCP:      true,
+		},
+		{
+			DeviceInterface: "ens10",
+			DeviceCIDR:      "10.0.0.3/32",
+		},
+	}, cfg.MachineConfig.MachineNetwork.NetworkInterfaces)
+
+	metadata.FloatingIPs = []string{"192.0.2"}
+
+	assert.EqualError(t, p.ConfigurationNetwork(&metadata, hostInterfaces, cfg), "invalid floating IP \"192.0.2\": failed to parse IP address")
+}
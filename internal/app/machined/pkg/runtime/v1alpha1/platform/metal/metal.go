@@ -10,13 +10,11 @@ import (
 	"io/ioutil"
 	"log"
 	"net"
-	"net/url"
 	"path/filepath"
 
 	"github.com/talos-systems/go-blockdevice/blockdevice/filesystem"
 	"github.com/talos-systems/go-blockdevice/blockdevice/probe"
 	"github.com/talos-systems/go-procfs/procfs"
-	"github.com/talos-systems/go-smbios/smbios"
 	"golang.org/x/sys/unix"
 
 	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
@@ -44,46 +42,31 @@ func (m *Metal) Configuration(ctx context.Context) ([]byte, error) {
 		return nil, errors.ErrNoConfigSource
 	}
 
-	log.Printf("fetching machine config from: %q", *option)
+	if *option == constants.MetalConfigISOLabel {
+		return readConfigFromISO()
+	}
 
-	u, err := url.Parse(*option)
+	downloadURL, err := PopulateURL(*option, Variables)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to populate config URL %q: %w", *option, err)
 	}
 
-	values := u.Query()
-
-	if len(values) > 0 {
-		for key := range values {
-			switch key {
-			case "uuid":
-				s, err := smbios.New()
-				if err != nil {
-					return nil, err
-				}
-
-				uuid, err := s.SystemInformation().UUID()
-				if err != nil {
-					return nil, err
-				}
-
-				values.Set("uuid", uuid.String())
-			default:
-				log.Printf("unsupported query parameter: %q", key)
-			}
-		}
-
-		u.RawQuery = values.Encode()
+	var headers []string
 
-		*option = u.String()
+	if param := procfs.ProcCmdline().Get(constants.KernelParamConfigHeader); param != nil {
+		for i := 0; param.Get(i) != nil; i++ {
+			headers = append(headers, *param.Get(i))
+		}
 	}
 
-	switch *option {
-	case constants.MetalConfigISOLabel:
-		return readConfigFromISO()
-	default:
-		return download.Download(ctx, *option)
+	populatedHeaders, err := PopulateHeaders(headers, Variables)
+	if err != nil {
+		return nil, fmt.Errorf("failed to populate config headers: %w", err)
 	}
+
+	log.Printf("fetching machine config from: %q", downloadURL)
+
+	return download.Download(ctx, downloadURL, download.WithHeaders(populatedHeaders))
 }
 
 // Hostname implements the platform.Platform interface.
@@ -4,11 +4,94 @@
 
 package metal_test
 
-import "testing"
+import (
+	"errors"
+	"testing"
 
-func TestEmpty(t *testing.T) {
-	// added for accurate coverage estimation
-	//
-	// please remove it once any unit-test is added
-	// for this package
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime/v1alpha1/platform/metal"
+)
+
+func testVariables() map[string]metal.Variable {
+	return map[string]metal.Variable{
+		"uuid": func() (string, error) {
+			return "0000-0000", nil
+		},
+		"serial": func() (string, error) {
+			return "SN 42", nil
+		},
+		"mac": func() (string, error) {
+			return "", errors.New("no links")
+		},
+	}
+}
+
+func TestPopulateURL(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		url      string
+		expected string
+		err      string
+	}{
+		{
+			name:     "no variables",
+			url:      "https://example.com/config.yaml",
+			expected: "https://example.com/config.yaml",
+		},
+		{
+			name:     "path and query",
+			url:      "https://example.com/configs/${serial}.yaml?uuid=${uuid}",
+			expected: "https://example.com/configs/SN%2042.yaml?uuid=0000-0000",
+		},
+		{
+			name:     "legacy uuid",
+			url:      "http://example.com/config?uuid=",
+			expected: "http://example.com/config?uuid=0000-0000",
+		},
+		{
+			name: "unsupported variable",
+			url:  "https://example.com/${hostname}",
+			err:  "unsupported variable \"${hostname}\"",
+		},
+		{
+			name: "failed variable",
+			url:  "https://example.com/${mac}",
+			err:  "failed to resolve variable \"${mac}\": no links",
+		},
+		{
+			name: "unsupported scheme",
+			url:  "tftp://example.com/config.yaml",
+			err:  "unsupported config URL scheme \"tftp\"",
+		},
+	} {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := metal.PopulateURL(tt.url, testVariables())
+
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, u)
+		})
+	}
+}
+
+func TestPopulateHeaders(t *testing.T) {
+	headers, err := metal.PopulateHeaders([]string{"X-Machine-UUID:${uuid}", "X-Serial: ${serial}"}, testVariables())
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{
+		"X-Machine-UUID": "0000-0000",
+		"X-Serial":       "SN 42",
+	}, headers)
+
+	_, err = metal.PopulateHeaders([]string{"X-Machine-UUID"}, testVariables())
+	assert.EqualError(t, err, "invalid header \"X-Machine-UUID\"")
 }
@@ -0,0 +1,171 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package metal
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/talos-systems/go-smbios/smbios"
+)
+
+// Variable is a machine property substituted in the config URL and headers.
+type Variable func() (string, error)
+
+// Variables which can be used as `${name}` in the config URL and headers.
+var Variables = map[string]Variable{
+	"uuid":   uuidVariable,
+	"serial": serialVariable,
+	"mac":    macVariable,
+}
+
+var variableRe = regexp.MustCompile(`\$\{([a-z]+)\}`)
+
+// PopulateVariables substitutes `${name}` variables in s.
+//
+// Each variable is resolved once, escape is applied to the values.
+func PopulateVariables(s string, variables map[string]Variable, escape func(string) string) (string, error) {
+	var err error
+
+	resolved := map[string]string{}
+
+	result := variableRe.ReplaceAllStringFunc(s, func(match string) string {
+		name := variableRe.FindStringSubmatch(match)[1]
+
+		if value, ok := resolved[name]; ok {
+			return value
+		}
+
+		variable, ok := variables[name]
+		if !ok {
+			if err == nil {
+				err = fmt.Errorf("unsupported variable %q", match)
+			}
+
+			return match
+		}
+
+		value, e := variable()
+		if e != nil {
+			if err == nil {
+				err = fmt.Errorf("failed to resolve variable %q: %w", match, e)
+			}
+
+			return match
+		}
+
+		if escape != nil {
+			value = escape(value)
+		}
+
+		resolved[name] = value
+
+		return value
+	})
+
+	return result, err
+}
+
+// PopulateURL substitutes the variables in the config URL.
+//
+// For the backwards compatibility `?uuid=` query parameter is set to the machine UUID.
+func PopulateURL(downloadURL string, variables map[string]Variable) (string, error) {
+	downloadURL, err := PopulateVariables(downloadURL, variables, url.PathEscape)
+	if err != nil {
+		return "", err
+	}
+
+	u, err := url.Parse(downloadURL)
+	if err != nil {
+		return "", err
+	}
+
+	switch u.Scheme {
+	case "http", "https", "file":
+	default:
+		return "", fmt.Errorf("unsupported config URL scheme %q", u.Scheme)
+	}
+
+	values := u.Query()
+
+	if _, ok := values["uuid"]; ok && values.Get("uuid") == "" && variables["uuid"] != nil {
+		var uuid string
+
+		if uuid, err = variables["uuid"](); err != nil {
+			return "", err
+		}
+
+		values.Set("uuid", uuid)
+
+		u.RawQuery = values.Encode()
+	}
+
+	return u.String(), nil
+}
+
+// PopulateHeaders parses the `Name:value` headers substituting the variables in the values.
+func PopulateHeaders(headers []string, variables map[string]Variable) (map[string]string, error) {
+	result := make(map[string]string, len(headers))
+
+	for _, header := range headers {
+		kv := strings.SplitN(header, ":", 2)
+		if len(kv) != 2 || strings.TrimSpace(kv[0]) == "" {
+			return nil, fmt.Errorf("invalid header %q", header)
+		}
+
+		value, err := PopulateVariables(strings.TrimSpace(kv[1]), variables, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		result[strings.TrimSpace(kv[0])] = value
+	}
+
+	return result, nil
+}
+
+func uuidVariable() (string, error) {
+	s, err := smbios.New()
+	if err != nil {
+		return "", err
+	}
+
+	uuid, err := s.SystemInformation().UUID()
+	if err != nil {
+		return "", err
+	}
+
+	return uuid.String(), nil
+}
+
+func serialVariable() (string, error) {
+	s, err := smbios.New()
+	if err != nil {
+		return "", err
+	}
+
+	return s.SystemInformation().SerialNumber(), nil
+}
+
+// macVariable returns the hardware address of the first non-loopback link.
+func macVariable() (string, error) {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return "", err
+	}
+
+	for _, iface := range ifaces {
+		if iface.Flags&net.FlagLoopback != 0 || len(iface.HardwareAddr) == 0 {
+			continue
+		}
+
+		return iface.HardwareAddr.String(), nil
+	}
+
+	return "", fmt.Errorf("no link with the hardware address found")
+}
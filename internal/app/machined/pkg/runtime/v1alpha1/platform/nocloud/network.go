@@ -0,0 +1,806 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package nocloud
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
+)
+
+// NetworkConfig holds the cloud-init network configuration.
+//
+// Both the version 1 and version 2 (netplan) formats are supported, the configuration
+// might be wrapped into the top-level `network` key.
+//
+// Ref: https://cloudinit.readthedocs.io/en/latest/topics/network-config.html
+type NetworkConfig struct {
+	Network *NetworkConfig `yaml:"network,omitempty"`
+
+	Version int `yaml:"version"`
+
+	// version 1
+	Config []ConfigV1 `yaml:"config,omitempty"`
+
+	// version 2
+	Ethernets map[string]EthernetV2 `yaml:"ethernets,omitempty"`
+	Bonds     map[string]BondV2     `yaml:"bonds,omitempty"`
+	VLANs     map[string]VLANV2     `yaml:"vlans,omitempty"`
+}
+
+// ConfigV1 is an entry of the version 1 network configuration.
+type ConfigV1 struct {
+	Type string `yaml:"type"`
+	Name string `yaml:"name,omitempty"`
+	MAC  string `yaml:"mac_address,omitempty"`
+	MTU  int    `yaml:"mtu,omitempty"`
+
+	Subnets []SubnetV1 `yaml:"subnets,omitempty"`
+
+	// bond
+	BondInterfaces []string          `yaml:"bond_interfaces,omitempty"`
+	Params         map[string]string `yaml:"params,omitempty"`
+
+	// vlan
+	VLANLink string `yaml:"vlan_link,omitempty"`
+	VLANID   uint16 `yaml:"vlan_id,omitempty"`
+
+	// nameserver
+	Address StringList `yaml:"address,omitempty"`
+	Search  []string   `yaml:"search,omitempty"`
+
+	// route
+	RouteV1 `yaml:",inline"`
+}
+
+// SubnetV1 is the subnet of the version 1 interface configuration.
+type SubnetV1 struct {
+	Type           string    `yaml:"type"`
+	Address        string    `yaml:"address,omitempty"`
+	Netmask        string    `yaml:"netmask,omitempty"`
+	Gateway        string    `yaml:"gateway,omitempty"`
+	DNSNameservers []string  `yaml:"dns_nameservers,omitempty"`
+	DNSSearch      []string  `yaml:"dns_search,omitempty"`
+	Routes         []RouteV1 `yaml:"routes,omitempty"`
+}
+
+// RouteV1 is the route of the version 1 configuration.
+type RouteV1 struct {
+	Destination string `yaml:"destination,omitempty"`
+	Network     string `yaml:"network,omitempty"`
+	Netmask     string `yaml:"netmask,omitempty"`
+	Gateway     string `yaml:"gateway,omitempty"`
+	Metric      uint32 `yaml:"metric,omitempty"`
+}
+
+// CommonV2 holds the settings common for all version 2 interface types.
+type CommonV2 struct {
+	DHCP4       bool          `yaml:"dhcp4,omitempty"`
+	DHCP6       bool          `yaml:"dhcp6,omitempty"`
+	Addresses   []string      `yaml:"addresses,omitempty"`
+	Gateway4    string        `yaml:"gateway4,omitempty"`
+	Gateway6    string        `yaml:"gateway6,omitempty"`
+	MTU         int           `yaml:"mtu,omitempty"`
+	Nameservers NameserversV2 `yaml:"nameservers,omitempty"`
+	Routes      []RouteV2     `yaml:"routes,omitempty"`
+}
+
+// EthernetV2 is the version 2 ethernet interface configuration.
+type EthernetV2 struct {
+	Match struct {
+		Name       string `yaml:"name,omitempty"`
+		MACAddress string `yaml:"macaddress,omitempty"`
+	} `yaml:"match,omitempty"`
+	SetName string `yaml:"set-name,omitempty"`
+
+	CommonV2 `yaml:",inline"`
+}
+
+// BondV2 is the version 2 bond interface configuration.
+type BondV2 struct {
+	Interfaces []string          `yaml:"interfaces,omitempty"`
+	Parameters map[string]string `yaml:"parameters,omitempty"`
+
+	CommonV2 `yaml:",inline"`
+}
+
+// VLANV2 is the version 2 VLAN interface configuration.
+type VLANV2 struct {
+	ID   uint16 `yaml:"id"`
+	Link string `yaml:"link"`
+
+	CommonV2 `yaml:",inline"`
+}
+
+// NameserversV2 is the version 2 DNS configuration.
+type NameserversV2 struct {
+	Addresses []string `yaml:"addresses,omitempty"`
+	Search    []string `yaml:"search,omitempty"`
+}
+
+// RouteV2 is the version 2 route.
+type RouteV2 struct {
+	To     string `yaml:"to"`
+	Via    string `yaml:"via"`
+	Metric uint32 `yaml:"metric,omitempty"`
+}
+
+// StringList is a list of strings which might be specified as a single string in YAML.
+type StringList []string
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (l *StringList) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+
+	if err := unmarshal(&s); err == nil {
+		*l = StringList{s}
+
+		return nil
+	}
+
+	var list []string
+
+	if err := unmarshal(&list); err != nil {
+		return err
+	}
+
+	*l = list
+
+	return nil
+}
+
+// link is the intermediate representation of the interface configuration.
+type link struct {
+	name      string
+	mtu       int
+	dhcp4     bool
+	dhcp6     bool
+	addresses []string
+	routes    []*v1alpha1.Route
+	bond      *v1alpha1.Bond
+	vlans     []*v1alpha1.Vlan
+}
+
+// networkBuilder translates the cloud-init network config into the Talos machine config.
+type networkBuilder struct {
+	hostInterfaces []net.Interface
+
+	links       []*link
+	nameservers []string
+	search      []string
+}
+
+func (b *networkBuilder) link(name string) *link {
+	for _, l := range b.links {
+		if l.name == name {
+			return l
+		}
+	}
+
+	l := &link{name: name}
+	b.links = append(b.links, l)
+
+	return l
+}
+
+func (b *networkBuilder) addDNS(nameservers, search []string) {
+	for _, ns := range nameservers {
+		if !contains(b.nameservers, ns) {
+			b.nameservers = append(b.nameservers, ns)
+		}
+	}
+
+	for _, s := range search {
+		if !contains(b.search, s) {
+			b.search = append(b.search, s)
+		}
+	}
+}
+
+// interfaceName returns the host interface name for the MAC address falling back to the name.
+func (b *networkBuilder) interfaceName(name, mac string) string {
+	if mac != "" {
+		for _, iface := range b.hostInterfaces {
+			if strings.EqualFold(iface.HardwareAddr.String(), mac) {
+				return iface.Name
+			}
+		}
+	}
+
+	return name
+}
+
+// ApplyNetworkConfig translates the cloud-init network configuration into the machine config network interfaces.
+//
+// Interfaces are matched to the host interfaces by the MAC address, bond members and VLANs are attached
+// to the bond and parent interfaces, nameservers and search domains are used only if the machine config doesn't set them.
+func ApplyNetworkConfig(networkConfig *NetworkConfig, hostInterfaces []net.Interface, machineConfig *v1alpha1.Config) error {
+	for networkConfig.Network != nil {
+		networkConfig = networkConfig.Network
+	}
+
+	b := &networkBuilder{
+		hostInterfaces: hostInterfaces,
+	}
+
+	var err error
+
+	switch networkConfig.Version {
+	case 1:
+		err = b.applyV1(networkConfig.Config)
+	case 2:
+		err = b.applyV2(networkConfig)
+	default:
+		err = fmt.Errorf("unsupported network config version %d", networkConfig.Version)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if machineConfig.MachineConfig.MachineNetwork == nil {
+		machineConfig.MachineConfig.MachineNetwork = &v1alpha1.NetworkConfig{}
+	}
+
+	network := machineConfig.MachineConfig.MachineNetwork
+
+	network.NetworkInterfaces = append(network.NetworkInterfaces, b.devices()...)
+
+	if len(network.NameServers) == 0 {
+		network.NameServers = b.nameservers
+	}
+
+	if len(network.NetworkSearchDomains) == 0 {
+		network.NetworkSearchDomains = b.search
+	}
+
+	return nil
+}
+
+//nolint:gocyclo,cyclop
+func (b *networkBuilder) applyV1(config []ConfigV1) error {
+	names := map[string]string{}
+
+	// physical interfaces first, as bonds and VLANs reference them by name
+	for _, entry := range config {
+		if entry.Type == "physical" {
+			names[entry.Name] = b.interfaceName(entry.Name, entry.MAC)
+		}
+	}
+
+	resolve := func(name string) string {
+		if resolved, ok := names[name]; ok {
+			return resolved
+		}
+
+		return name
+	}
+
+	var globalRoutes []RouteV1
+
+	for _, entry := range config {
+		switch entry.Type {
+		case "physical":
+			l := b.link(resolve(entry.Name))
+			l.mtu = entry.MTU
+
+			if err := b.applySubnetsV1(l, entry.Subnets); err != nil {
+				return err
+			}
+		case "bond":
+			l := b.link(entry.Name)
+			l.mtu = entry.MTU
+
+			bond, err := bondV1(entry.Params)
+			if err != nil {
+				return fmt.Errorf("bond %q: %w", entry.Name, err)
+			}
+
+			for _, member := range entry.BondInterfaces {
+				bond.BondInterfaces = append(bond.BondInterfaces, resolve(member))
+			}
+
+			l.bond = bond
+
+			if err = b.applySubnetsV1(l, entry.Subnets); err != nil {
+				return err
+			}
+		case "vlan":
+			vlanLink := &link{name: entry.Name}
+
+			if err := b.applySubnetsV1(vlanLink, entry.Subnets); err != nil {
+				return err
+			}
+
+			parent := b.link(resolve(entry.VLANLink))
+			parent.vlans = append(parent.vlans, vlanLink.vlan(entry.VLANID))
+		case "nameserver":
+			b.addDNS(entry.Address, entry.Search)
+		case "route":
+			globalRoutes = append(globalRoutes, entry.RouteV1)
+		default:
+			return fmt.Errorf("unsupported network config entry type %q", entry.Type)
+		}
+	}
+
+	for _, route := range globalRoutes {
+		r, err := routeV1(route)
+		if err != nil {
+			return err
+		}
+
+		l := b.linkForGateway(r.RouteGateway)
+		if l == nil {
+			return fmt.Errorf("no interface found for the route %q via %q", r.RouteNetwork, r.RouteGateway)
+		}
+
+		l.routes = append(l.routes, r)
+	}
+
+	return nil
+}
+
+func (b *networkBuilder) applySubnetsV1(l *link, subnets []SubnetV1) error {
+	for _, subnet := range subnets {
+		switch subnet.Type {
+		case "dhcp", "dhcp4":
+			l.dhcp4 = true
+		case "dhcp6":
+			l.dhcp6 = true
+		case "static", "static6":
+			cidr, err := subnetCIDR(subnet.Address, subnet.Netmask)
+			if err != nil {
+				return fmt.Errorf("interface %q: %w", l.name, err)
+			}
+
+			l.addresses = append(l.addresses, cidr)
+
+			if subnet.Gateway != "" {
+				l.routes = append(l.routes, defaultRoute(subnet.Gateway))
+			}
+		case "manual":
+		default:
+			return fmt.Errorf("interface %q: unsupported subnet type %q", l.name, subnet.Type)
+		}
+
+		for _, route := range subnet.Routes {
+			r, err := routeV1(route)
+			if err != nil {
+				return fmt.Errorf("interface %q: %w", l.name, err)
+			}
+
+			l.routes = append(l.routes, r)
+		}
+
+		b.addDNS(subnet.DNSNameservers, subnet.DNSSearch)
+	}
+
+	return nil
+}
+
+//nolint:gocyclo
+func (b *networkBuilder) applyV2(networkConfig *NetworkConfig) error {
+	names := map[string]string{}
+
+	for _, id := range sortedKeys(networkConfig.Ethernets) {
+		eth := networkConfig.Ethernets[id]
+
+		name := id
+
+		switch {
+		case eth.SetName != "":
+			name = eth.SetName
+		case eth.Match.MACAddress != "":
+			name = b.interfaceName(id, eth.Match.MACAddress)
+		case eth.Match.Name != "" && !strings.ContainsAny(eth.Match.Name, "*?["):
+			name = eth.Match.Name
+		}
+
+		names[id] = name
+	}
+
+	resolve := func(name string) string {
+		if resolved, ok := names[name]; ok {
+			return resolved
+		}
+
+		return name
+	}
+
+	for _, id := range sortedKeys(networkConfig.Ethernets) {
+		if err := b.applyCommonV2(b.link(resolve(id)), networkConfig.Ethernets[id].CommonV2); err != nil {
+			return err
+		}
+	}
+
+	for _, id := range sortedKeys(networkConfig.Bonds) {
+		bondConfig := networkConfig.Bonds[id]
+
+		bond, err := bondV2(bondConfig.Parameters)
+		if err != nil {
+			return fmt.Errorf("bond %q: %w", id, err)
+		}
+
+		for _, member := range bondConfig.Interfaces {
+			bond.BondInterfaces = append(bond.BondInterfaces, resolve(member))
+		}
+
+		l := b.link(id)
+		l.bond = bond
+
+		if err = b.applyCommonV2(l, bondConfig.CommonV2); err != nil {
+			return err
+		}
+	}
+
+	for _, id := range sortedKeys(networkConfig.VLANs) {
+		vlanConfig := networkConfig.VLANs[id]
+
+		vlanLink := &link{name: id}
+
+		if err := b.applyCommonV2(vlanLink, vlanConfig.CommonV2); err != nil {
+			return err
+		}
+
+		parent := b.link(resolve(vlanConfig.Link))
+		parent.vlans = append(parent.vlans, vlanLink.vlan(vlanConfig.ID))
+	}
+
+	return nil
+}
+
+func (b *networkBuilder) applyCommonV2(l *link, common CommonV2) error {
+	l.dhcp4 = common.DHCP4
+	l.dhcp6 = common.DHCP6
+	l.mtu = common.MTU
+
+	for _, addr := range common.Addresses {
+		if _, _, err := net.ParseCIDR(addr); err != nil {
+			return fmt.Errorf("interface %q: %w", l.name, err)
+		}
+
+		l.addresses = append(l.addresses, addr)
+	}
+
+	for _, gw := range []string{common.Gateway4, common.Gateway6} {
+		if gw != "" {
+			l.routes = append(l.routes, defaultRoute(gw))
+		}
+	}
+
+	for _, route := range common.Routes {
+		network := route.To
+
+		if network == "default" {
+			network = defaultRoute(route.Via).RouteNetwork
+		}
+
+		if _, _, err := net.ParseCIDR(network); err != nil {
+			return fmt.Errorf("interface %q: %w", l.name, err)
+		}
+
+		l.routes = append(l.routes, &v1alpha1.Route{
+			RouteNetwork: network,
+			RouteGateway: route.Via,
+			RouteMetric:  route.Metric,
+		})
+	}
+
+	b.addDNS(common.Nameservers.Addresses, common.Nameservers.Search)
+
+	return nil
+}
+
+// linkForGateway returns the link which has the address in the same subnet as the gateway.
+func (b *networkBuilder) linkForGateway(gateway string) *link {
+	gw := net.ParseIP(gateway)
+	if gw == nil {
+		return nil
+	}
+
+	for _, l := range b.links {
+		for _, addr := range l.addresses {
+			if _, ipnet, err := net.ParseCIDR(addr); err == nil && ipnet.Contains(gw) {
+				return l
+			}
+		}
+	}
+
+	// fall back to the first link with DHCP
+	for _, l := range b.links {
+		if (l.dhcp4 && gw.To4() != nil) || (l.dhcp6 && gw.To4() == nil) {
+			return l
+		}
+	}
+
+	return nil
+}
+
+func (l *link) vlan(id uint16) *v1alpha1.Vlan {
+	return &v1alpha1.Vlan{
+		VlanID:        id,
+		VlanAddresses: l.addresses,
+		VlanRoutes:    l.routes,
+		VlanDHCP:      l.dhcp4,
+	}
+}
+
+// devices returns the machine config devices for the links.
+//
+// Additional addresses are returned as the extra devices with the same interface name,
+// they are merged by networkd. Bond members are configured by the bond.
+func (b *networkBuilder) devices() []*v1alpha1.Device {
+	var (
+		devices     []*v1alpha1.Device
+		bondMembers []string
+	)
+
+	for _, l := range b.links {
+		if l.bond != nil {
+			bondMembers = append(bondMembers, l.bond.BondInterfaces...)
+		}
+	}
+
+	for _, l := range b.links {
+		if contains(bondMembers, l.name) {
+			continue
+		}
+
+		device := &v1alpha1.Device{
+			DeviceInterface: l.name,
+			DeviceMTU:       l.mtu,
+			DeviceBond:      l.bond,
+			DeviceVlans:     l.vlans,
+			DeviceRoutes:    l.routes,
+		}
+
+		addresses := l.addresses
+
+		switch {
+		case l.dhcp4 || l.dhcp6:
+			device.DeviceDHCP = true
+
+			if l.dhcp6 {
+				dhcp4, dhcp6 := l.dhcp4, l.dhcp6
+
+				device.DeviceDHCPOptions = &v1alpha1.DHCPOptions{
+					DHCPIPv4: &dhcp4,
+					DHCPIPv6: &dhcp6,
+				}
+			}
+		case len(addresses) > 0:
+			device.DeviceCIDR = addresses[0]
+			addresses = addresses[1:]
+		}
+
+		devices = append(devices, device)
+
+		for _, addr := range addresses {
+			devices = append(devices, &v1alpha1.Device{
+				DeviceInterface: l.name,
+				DeviceMTU:       l.mtu,
+				DeviceBond:      l.bond,
+				DeviceCIDR:      addr,
+			})
+		}
+	}
+
+	return devices
+}
+
+func bondV1(params map[string]string) (*v1alpha1.Bond, error) {
+	// version 1 params are prefixed with "bond-", e.g. bond-mode
+	trimmed := make(map[string]string, len(params))
+
+	for k, v := range params {
+		trimmed[strings.TrimPrefix(k, "bond-")] = v
+	}
+
+	return bondFromParams(trimmed, map[string]string{
+		"mode":             "mode",
+		"xmit_hash_policy": "xmit-hash-policy",
+		"xmit-hash-policy": "xmit-hash-policy",
+		"lacp-rate":        "lacp-rate",
+		"lacp_rate":        "lacp-rate",
+		"miimon":           "miimon",
+		"updelay":          "updelay",
+		"downdelay":        "downdelay",
+		"primary":          "primary",
+		"min-links":        "min-links",
+	})
+}
+
+func bondV2(params map[string]string) (*v1alpha1.Bond, error) {
+	return bondFromParams(params, map[string]string{
+		"mode":                 "mode",
+		"transmit-hash-policy": "xmit-hash-policy",
+		"lacp-rate":            "lacp-rate",
+		"mii-monitor-interval": "miimon",
+		"up-delay":             "updelay",
+		"down-delay":           "downdelay",
+		"primary":              "primary",
+		"min-links":            "min-links",
+	})
+}
+
+// bondFromParams builds the bond config from the parameters, aliases map parameter names to the canonical ones.
+//
+//nolint:gocyclo
+func bondFromParams(params, aliases map[string]string) (*v1alpha1.Bond, error) {
+	bond := &v1alpha1.Bond{}
+
+	for _, key := range sortedKeys(params) {
+		value := params[key]
+
+		canonical, ok := aliases[key]
+		if !ok {
+			// unknown parameters are not fatal, as cloud-init supports much more than Talos
+			continue
+		}
+
+		var err error
+
+		switch canonical {
+		case "mode":
+			bond.BondMode = value
+		case "xmit-hash-policy":
+			bond.BondHashPolicy = value
+		case "lacp-rate":
+			bond.BondLACPRate = value
+		case "primary":
+			bond.BondPrimary = value
+		case "miimon":
+			bond.BondMIIMon, err = parseUint32(value)
+		case "updelay":
+			bond.BondUpDelay, err = parseUint32(value)
+		case "downdelay":
+			bond.BondDownDelay, err = parseUint32(value)
+		case "min-links":
+			bond.BondMinLinks, err = parseUint32(value)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid bond parameter %q: %w", key, err)
+		}
+	}
+
+	return bond, nil
+}
+
+func routeV1(route RouteV1) (*v1alpha1.Route, error) {
+	network := route.Destination
+	if network == "" {
+		network = route.Network
+	}
+
+	if network == "" {
+		return nil, fmt.Errorf("route destination is not set")
+	}
+
+	if network == "default" {
+		network = defaultRoute(route.Gateway).RouteNetwork
+	}
+
+	if !strings.Contains(network, "/") {
+		cidr, err := subnetCIDR(network, route.Netmask)
+		if err != nil {
+			return nil, err
+		}
+
+		_, ipnet, _ := net.ParseCIDR(cidr) //nolint:errcheck
+		network = ipnet.String()
+	}
+
+	if _, _, err := net.ParseCIDR(network); err != nil {
+		return nil, err
+	}
+
+	return &v1alpha1.Route{
+		RouteNetwork: network,
+		RouteGateway: route.Gateway,
+		RouteMetric:  route.Metric,
+	}, nil
+}
+
+func defaultRoute(gateway string) *v1alpha1.Route {
+	network := "0.0.0.0/0"
+
+	if ip := net.ParseIP(gateway); ip != nil && ip.To4() == nil {
+		network = "::/0"
+	}
+
+	return &v1alpha1.Route{
+		RouteNetwork: network,
+		RouteGateway: gateway,
+	}
+}
+
+// subnetCIDR returns the address in the CIDR notation, netmask might be either a prefix length or a dotted mask.
+func subnetCIDR(addr, netmask string) (string, error) {
+	if strings.Contains(addr, "/") {
+		if _, _, err := net.ParseCIDR(addr); err != nil {
+			return "", err
+		}
+
+		return addr, nil
+	}
+
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return "", fmt.Errorf("failed to parse IP address %q", addr)
+	}
+
+	var ones int
+
+	switch {
+	case netmask == "" && ip.To4() != nil:
+		ones = 32
+	case netmask == "":
+		ones = 128
+	case net.ParseIP(netmask) != nil:
+		mask := net.IPMask(net.ParseIP(netmask).To4())
+
+		var bits int
+
+		if ones, bits = mask.Size(); bits == 0 {
+			return "", fmt.Errorf("invalid netmask %q", netmask)
+		}
+	default:
+		var err error
+
+		if ones, err = strconv.Atoi(netmask); err != nil {
+			return "", fmt.Errorf("invalid netmask %q", netmask)
+		}
+	}
+
+	return fmt.Sprintf("%s/%d", ip, ones), nil
+}
+
+func parseUint32(s string) (uint32, error) {
+	v, err := strconv.ParseUint(s, 10, 32)
+
+	return uint32(v), err
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+func sortedKeys(m interface{}) []string {
+	var keys []string
+
+	switch v := m.(type) {
+	case map[string]EthernetV2:
+		for k := range v {
+			keys = append(keys, k)
+		}
+	case map[string]BondV2:
+		for k := range v {
+			keys = append(keys, k)
+		}
+	case map[string]VLANV2:
+		for k := range v {
+			keys = append(keys, k)
+		}
+	case map[string]string:
+		for k := range v {
+			keys = append(keys, k)
+		}
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
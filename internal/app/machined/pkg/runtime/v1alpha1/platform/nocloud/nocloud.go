@@ -0,0 +1,201 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package nocloud implements the cloud-init NoCloud platform.
+//
+// Configuration is read from the seed volume (e.g. ISO or CD-ROM attached by Proxmox)
+// labeled `cidata` with the `user-data`, `meta-data` and `network-config` files.
+package nocloud
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net"
+	"os"
+	"path/filepath"
+
+	"github.com/talos-systems/go-blockdevice/blockdevice/filesystem"
+	"github.com/talos-systems/go-blockdevice/blockdevice/probe"
+	"github.com/talos-systems/go-procfs/procfs"
+	"golang.org/x/sys/unix"
+	"gopkg.in/yaml.v3"
+
+	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
+	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime/v1alpha1/platform/errors"
+	"github.com/talos-systems/talos/pkg/machinery/config/configloader"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
+)
+
+const (
+	mnt = "/mnt"
+
+	userDataFile      = "user-data"
+	metaDataFile      = "meta-data"
+	networkConfigFile = "network-config"
+)
+
+// seedLabels are the volume labels of the NoCloud seed.
+var seedLabels = []string{"cidata", "CIDATA"}
+
+// MetaData holds the NoCloud meta-data.
+type MetaData struct {
+	InstanceID    string `yaml:"instance-id,omitempty"`
+	LocalHostname string `yaml:"local-hostname,omitempty"`
+	Hostname      string `yaml:"hostname,omitempty"`
+}
+
+// seed is the contents of the NoCloud seed volume.
+type seed struct {
+	userData      []byte
+	metaData      []byte
+	networkConfig []byte
+}
+
+// NoCloud is the concrete type that implements the runtime.Platform interface.
+type NoCloud struct{}
+
+// Name implements the runtime.Platform interface.
+func (n *NoCloud) Name() string {
+	return "nocloud"
+}
+
+// Configuration implements the runtime.Platform interface.
+func (n *NoCloud) Configuration(ctx context.Context) ([]byte, error) {
+	s, err := readSeed()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(s.userData) == 0 {
+		return nil, errors.ErrNoConfigSource
+	}
+
+	if len(s.networkConfig) == 0 {
+		return s.userData, nil
+	}
+
+	var networkConfig NetworkConfig
+
+	if err = yaml.Unmarshal(s.networkConfig, &networkConfig); err != nil {
+		return nil, fmt.Errorf("error unmarshaling %s: %w", networkConfigFile, err)
+	}
+
+	confProvider, err := configloader.NewFromBytes(s.userData)
+	if err != nil {
+		return nil, err
+	}
+
+	machineConfig, ok := confProvider.(*v1alpha1.Config)
+	if !ok {
+		return nil, fmt.Errorf("unable to determine machine config type")
+	}
+
+	hostInterfaces, err := net.Interfaces()
+	if err != nil {
+		return nil, fmt.Errorf("error listing host interfaces: %w", err)
+	}
+
+	if err = ApplyNetworkConfig(&networkConfig, hostInterfaces, machineConfig); err != nil {
+		return nil, fmt.Errorf("error applying %s: %w", networkConfigFile, err)
+	}
+
+	return confProvider.Bytes()
+}
+
+// Mode implements the runtime.Platform interface.
+func (n *NoCloud) Mode() runtime.Mode {
+	return runtime.ModeCloud
+}
+
+// Hostname implements the runtime.Platform interface.
+func (n *NoCloud) Hostname(context.Context) (hostname []byte, err error) {
+	s, err := readSeed()
+	if err != nil {
+		return nil, err
+	}
+
+	var metaData MetaData
+
+	if err = yaml.Unmarshal(s.metaData, &metaData); err != nil {
+		return nil, fmt.Errorf("error unmarshaling %s: %w", metaDataFile, err)
+	}
+
+	if metaData.LocalHostname != "" {
+		return []byte(metaData.LocalHostname), nil
+	}
+
+	if metaData.Hostname != "" {
+		return []byte(metaData.Hostname), nil
+	}
+
+	return nil, errors.ErrNoHostname
+}
+
+// ExternalIPs implements the runtime.Platform interface.
+func (n *NoCloud) ExternalIPs(context.Context) (addrs []net.IP, err error) {
+	return addrs, err
+}
+
+// KernelArgs implements the runtime.Platform interface.
+func (n *NoCloud) KernelArgs() procfs.Parameters {
+	return []*procfs.Parameter{
+		procfs.NewParameter("console").Append("tty1").Append("ttyS0"),
+	}
+}
+
+func readSeed() (s *seed, err error) {
+	var dev *probe.ProbedBlockDevice
+
+	for _, label := range seedLabels {
+		if dev, err = probe.GetDevWithFileSystemLabel(label); err == nil {
+			break
+		}
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to find NoCloud seed volume: %w", err)
+	}
+
+	//nolint:errcheck
+	defer dev.Close()
+
+	sb, err := filesystem.Probe(dev.Device().Name())
+	if err != nil {
+		return nil, err
+	}
+
+	if sb == nil {
+		return nil, fmt.Errorf("failed to get filesystem type")
+	}
+
+	if err = unix.Mount(dev.Device().Name(), mnt, sb.Type(), unix.MS_RDONLY, ""); err != nil {
+		return nil, fmt.Errorf("failed to mount NoCloud seed volume: %w", err)
+	}
+
+	s = &seed{}
+
+	for _, f := range []struct {
+		name string
+		dest *[]byte
+	}{
+		{userDataFile, &s.userData},
+		{metaDataFile, &s.metaData},
+		{networkConfigFile, &s.networkConfig},
+	} {
+		*f.dest, err = ioutil.ReadFile(filepath.Join(mnt, f.name))
+		if err != nil && !os.IsNotExist(err) {
+			unix.Unmount(mnt, 0) //nolint:errcheck
+
+			return nil, fmt.Errorf("failed to read %s: %w", f.name, err)
+		}
+	}
+
+	if err = unix.Unmount(mnt, 0); err != nil {
+		return nil, fmt.Errorf("failed to unmount: %w", err)
+	}
+
+	return s, nil
+}
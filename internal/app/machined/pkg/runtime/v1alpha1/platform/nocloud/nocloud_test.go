@@ -0,0 +1,303 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package nocloud_test
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime/v1alpha1/platform/nocloud"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
+)
+
+const networkConfigV1 = `version: 1
+config:
+  - type: physical
+    name: ens18
+    mac_address: "52:54:00:12:34:00"
+    mtu: 1500
+    subnets:
+      - type: static
+        address: 192.168.1.11
+        netmask: 255.255.255.0
+        gateway: 192.168.1.1
+        dns_nameservers:
+          - 192.168.1.1
+      - type: static6
+        address: 2001:db8::11/64
+  - type: physical
+    name: ens19
+    mac_address: "52:54:00:12:34:01"
+  - type: physical
+    name: ens20
+    mac_address: "52:54:00:12:34:02"
+  - type: bond
+    name: bond0
+    bond_interfaces:
+      - ens19
+      - ens20
+    params:
+      bond-mode: 802.3ad
+      bond-miimon: 100
+      bond-xmit-hash-policy: layer3+4
+    subnets:
+      - type: dhcp
+  - type: vlan
+    name: bond0.100
+    vlan_link: bond0
+    vlan_id: 100
+    subnets:
+      - type: static
+        address: 10.100.0.11/24
+        routes:
+          - network: 10.200.0.0
+            netmask: 255.255.0.0
+            gateway: 10.100.0.1
+  - type: nameserver
+    address: 1.1.1.1
+    search:
+      - example.com
+  - type: route
+    destination: 172.16.0.0/12
+    gateway: 192.168.1.254
+    metric: 100
+`
+
+const networkConfigV2 = `network:
+  version: 2
+  ethernets:
+    lan:
+      match:
+        macaddress: "52:54:00:12:34:00"
+      addresses:
+        - 192.168.1.11/24
+        - 192.168.1.12/24
+      gateway4: 192.168.1.1
+      nameservers:
+        addresses:
+          - 192.168.1.1
+        search:
+          - example.com
+    eth1: {}
+    eth2: {}
+  bonds:
+    bond0:
+      interfaces:
+        - eth1
+        - eth2
+      parameters:
+        mode: active-backup
+        mii-monitor-interval: 100
+        primary: eth1
+      dhcp4: true
+      dhcp6: true
+      mtu: 9000
+  vlans:
+    vlan100:
+      id: 100
+      link: lan
+      dhcp4: true
+      routes:
+        - to: 10.200.0.0/16
+          via: 10.100.0.1
+          metric: 50
+`
+
+func hostInterfaces(t *testing.T) []net.Interface {
+	mac0, err := net.ParseMAC("52:54:00:12:34:00")
+	require.NoError(t, err)
+
+	mac1, err := net.ParseMAC("52:54:00:12:34:01")
+	require.NoError(t, err)
+
+	return []net.Interface{
+		{Name: "lo"},
+		{Name: "eth0", HardwareAddr: mac0},
+		{Name: "eth1", HardwareAddr: mac1},
+	}
+}
+
+func TestApplyNetworkConfigV1(t *testing.T) {
+	var networkConfig nocloud.NetworkConfig
+
+	require.NoError(t, yaml.Unmarshal([]byte(networkConfigV1), &networkConfig))
+
+	cfg := &v1alpha1.Config{
+		MachineConfig: &v1alpha1.MachineConfig{},
+	}
+
+	require.NoError(t, nocloud.ApplyNetworkConfig(&networkConfig, hostInterfaces(t), cfg))
+
+	bond := &v1alpha1.Bond{
+		BondInterfaces: []string{"eth1", "ens20"},
+		BondMode:       "802.3ad",
+		BondHashPolicy: "layer3+4",
+		BondMIIMon:     100,
+	}
+
+	assert.Equal(t, []*v1alpha1.Device{
+		{
+			DeviceInterface: "eth0",
+			DeviceMTU:       1500,
+			DeviceCIDR:      "192.168.1.11/24",
+			DeviceRoutes: []*v1alpha1.Route{
+				{
+					RouteNetwork: "0.0.0.0/0",
+					RouteGateway: "192.168.1.1",
+				},
+				{
+					RouteNetwork: "172.16.0.0/12",
+					RouteGateway: "192.168.1.254",
+					RouteMetric:  100,
+				},
+			},
+		},
+		{
+			DeviceInterface: "eth0",
+			DeviceMTU:       1500,
+			DeviceCIDR:      "2001:db8::11/64",
+		},
+		{
+			DeviceInterface: "bond0",
+			DeviceBond:      bond,
+			DeviceDHCP:      true,
+			DeviceVlans: []*v1alpha1.Vlan{
+				{
+					VlanID:        100,
+					VlanAddresses: []string{"10.100.0.11/24"},
+					VlanRoutes: []*v1alpha1.Route{
+						{
+							RouteNetwork: "10.200.0.0/16",
+							RouteGateway: "10.100.0.1",
+						},
+					},
+				},
+			},
+		},
+	}, cfg.MachineConfig.MachineNetwork.NetworkInterfaces)
+
+	assert.Equal(t, []string{"192.168.1.1", "1.1.1.1"}, cfg.MachineConfig.MachineNetwork.NameServers)
+	assert.Equal(t, []string{"example.com"}, cfg.MachineConfig.MachineNetwork.NetworkSearchDomains)
+}
+
+func TestApplyNetworkConfigV2(t *testing.T) {
+	var networkConfig nocloud.NetworkConfig
+
+	require.NoError(t, yaml.Unmarshal([]byte(networkConfigV2), &networkConfig))
+
+	cfg := &v1alpha1.Config{
+		MachineConfig: &v1alpha1.MachineConfig{
+			MachineNetwork: &v1alpha1.NetworkConfig{
+				NameServers: []string{"8.8.8.8"},
+			},
+		},
+	}
+
+	require.NoError(t, nocloud.ApplyNetworkConfig(&networkConfig, hostInterfaces(t), cfg))
+
+	dhcp4, dhcp6 := true, true
+
+	assert.Equal(t, []*v1alpha1.Device{
+		{
+			DeviceInterface: "eth0",
+			DeviceCIDR:      "192.168.1.11/24",
+			DeviceRoutes: []*v1alpha1.Route{
+				{
+					RouteNetwork: "0.0.0.0/0",
+					RouteGateway: "192.168.1.1",
+				},
+			},
+			DeviceVlans: []*v1alpha1.Vlan{
+				{
+					VlanID:   100,
+					VlanDHCP: true,
+					VlanRoutes: []*v1alpha1.Route{
+						{
+							RouteNetwork: "10.200.0.0/16",
+							RouteGateway: "10.100.0.1",
+							RouteMetric:  50,
+						},
+					},
+				},
+			},
+		},
+		{
+			DeviceInterface: "eth0",
+			DeviceCIDR:      "192.168.1.12/24",
+		},
+		{
+			DeviceInterface: "bond0",
+			DeviceMTU:       9000,
+			DeviceBond: &v1alpha1.Bond{
+				BondInterfaces: []string{"eth1", "eth2"},
+				BondMode:       "active-backup",
+				BondMIIMon:     100,
+				BondPrimary:    "eth1",
+			},
+			DeviceDHCP: true,
+			DeviceDHCPOptions: &v1alpha1.DHCPOptions{
+				DHCPIPv4: &dhcp4,
+				DHCPIPv6: &dhcp6,
+			},
+		},
+	}, cfg.MachineConfig.MachineNetwork.NetworkInterfaces)
+
+	// nameservers from the machine config take precedence
+	assert.Equal(t, []string{"8.8.8.8"}, cfg.MachineConfig.MachineNetwork.NameServers)
+	assert.Equal(t, []string{"example.com"}, cfg.MachineConfig.MachineNetwork.NetworkSearchDomains)
+}
+
+func TestApplyNetworkConfigInvalid(t *testing.T) {
+	for _, tt := range []struct {
+		name          string
+		networkConfig string
+		expectedError string
+	}{
+		{
+			name:          "version",
+			networkConfig: "version: 3\n",
+			expectedError: "unsupported network config version 3",
+		},
+		{
+			name: "subnet type",
+			networkConfig: `version: 1
+config:
+  - type: physical
+    name: eth0
+    subnets:
+      - type: ipv6_slaac_magic
+`,
+			expectedError: "interface \"eth0\": unsupported subnet type \"ipv6_slaac_magic\"",
+		},
+		{
+			name: "address",
+			networkConfig: `version: 2
+ethernets:
+  eth0:
+    addresses:
+      - 192.168.1.11
+`,
+			expectedError: "interface \"eth0\": invalid CIDR address: 192.168.1.11",
+		},
+	} {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			var networkConfig nocloud.NetworkConfig
+
+			require.NoError(t, yaml.Unmarshal([]byte(tt.networkConfig), &networkConfig))
+
+			cfg := &v1alpha1.Config{
+				MachineConfig: &v1alpha1.MachineConfig{},
+			}
+
+			assert.EqualError(t, nocloud.ApplyNetworkConfig(&networkConfig, nil, cfg), tt.expectedError)
+		})
+	}
+}
@@ -0,0 +1,280 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package oracle
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"strings"
+
+	"github.com/talos-systems/go-procfs/procfs"
+
+	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
+	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime/v1alpha1/platform/errors"
+	"github.com/talos-systems/talos/pkg/download"
+	"github.com/talos-systems/talos/pkg/machinery/config/configloader"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
+)
+
+// Ref: https://docs.oracle.com/en-us/iaas/Content/Compute/Tasks/gettingmetadata.htm
+const (
+	// OracleMetadataEndpoint is the local endpoint for the instance metadata.
+	OracleMetadataEndpoint = "http://169.254.169.254/opc/v2/instance/"
+	// OracleNetworkEndpoint is the local endpoint for the VNICs of the instance.
+	OracleNetworkEndpoint = "http://169.254.169.254/opc/v2/vnics/"
+	// OracleUserDataEndpoint is the local endpoint for the config.
+	OracleUserDataEndpoint = "http://169.254.169.254/opc/v2/instance/metadata/user_data"
+
+	// iBFTPath is present if the firmware booted the instance from the iSCSI attached volume.
+	iBFTPath = "/sys/firmware/ibft"
+)
+
+// Metadata holds the instance metadata.
+type Metadata struct {
+	Hostname string `json:"hostname"`
+	Shape    string `json:"shape"`
+	Region   string `json:"canonicalRegionName"`
+}
+
+// NetworkConfig holds the VNIC info from the metadata.
+//
+// The first VNIC in the list is the primary VNIC of the instance.
+type NetworkConfig struct {
+	VNICID              string   `json:"vnicId"`
+	PrivateIP           string   `json:"privateIp"`
+	VLANTag             int      `json:"vlanTag"`
+	MAC                 string   `json:"macAddr"`
+	VirtualRouterIP     string   `json:"virtualRouterIp"`
+	SubnetCIDRBlock     string   `json:"subnetCidrBlock"`
+	IPv6Addresses       []string `json:"ipv6Addresses,omitempty"`
+	IPv6SubnetCIDRBlock string   `json:"ipv6SubnetCidrBlock,omitempty"`
+	IPv6VirtualRouterIP string   `json:"ipv6VirtualRouterIp,omitempty"`
+}
+
+// Oracle is the concrete type that implements the runtime.Platform interface.
+type Oracle struct{}
+
+// Name implements the runtime.Platform interface.
+func (o *Oracle) Name() string {
+	return "oracle"
+}
+
+// Configuration implements the runtime.Platform interface.
+func (o *Oracle) Configuration(ctx context.Context) ([]byte, error) {
+	log.Printf("fetching machine config from: %q", OracleUserDataEndpoint)
+
+	machineConfigDl, err := download.Download(ctx, OracleUserDataEndpoint,
+		download.WithHeaders(metadataHeaders()),
+		download.WithFormat("base64"),
+		download.WithErrorOnNotFound(errors.ErrNoConfigSource),
+		download.WithErrorOnEmptyResponse(errors.ErrNoConfigSource))
+	if err != nil {
+		return nil, err
+	}
+
+	metadata, err := o.metadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = os.Stat(iBFTPath); err == nil {
+		// Talos doesn't run the iSCSI initiator, so only the paravirtualized boot volumes are accessible after the boot
+		log.Printf("WARNING: instance of shape %q was booted from the iSCSI attached volume, use the paravirtualized boot volume attachment", metadata.Shape)
+	}
+
+	log.Printf("fetching oracle network config from: %q", OracleNetworkEndpoint)
+
+	networkConfigDl, err := download.Download(ctx, OracleNetworkEndpoint,
+		download.WithHeaders(metadataHeaders()))
+	if err != nil {
+		return nil, err
+	}
+
+	var networkConfig []NetworkConfig
+	if err = json.Unmarshal(networkConfigDl, &networkConfig); err != nil {
+		return nil, err
+	}
+
+	confProvider, err := configloader.NewFromBytes(machineConfigDl)
+	if err != nil {
+		return nil, err
+	}
+
+	machineConfig, ok := confProvider.(*v1alpha1.Config)
+	if !ok {
+		return nil, fmt.Errorf("unable to determine machine config type")
+	}
+
+	hostInterfaces, err := net.Interfaces()
+	if err != nil {
+		return nil, fmt.Errorf("error listing host interfaces: %w", err)
+	}
+
+	if err = o.ConfigurationNetwork(networkConfig, hostInterfaces, machineConfig); err != nil {
+		return nil, err
+	}
+
+	return confProvider.Bytes()
+}
+
+// ConfigurationNetwork appends the VNIC configuration to the machine config.
+//
+// Primary VNIC is configured with DHCP, secondary VNICs get the static addresses without the default route,
+// IPv6 addresses are configured statically on all VNICs.
+//
+//nolint:gocyclo
+func (o *Oracle) ConfigurationNetwork(networkConfig []NetworkConfig, hostInterfaces []net.Interface, machineConfig *v1alpha1.Config) error {
+	var devices []*v1alpha1.Device
+
+	for idx, vnic := range networkConfig {
+		name, ok := interfaceByMAC(hostInterfaces, vnic.MAC)
+		if !ok {
+			log.Printf("interface with MAC %q wasn't found on the host, skipping VNIC %q", vnic.MAC, vnic.VNICID)
+
+			continue
+		}
+
+		primary := idx == 0
+
+		if primary {
+			devices = append(devices, &v1alpha1.Device{
+				DeviceInterface: name,
+				DeviceDHCP:      true,
+			})
+		} else {
+			cidr, err := addressCIDR(vnic.PrivateIP, vnic.SubnetCIDRBlock)
+			if err != nil {
+				return fmt.Errorf("error configuring VNIC %q: %w", vnic.VNICID, err)
+			}
+
+			devices = append(devices, &v1alpha1.Device{
+				DeviceInterface: name,
+				DeviceCIDR:      cidr,
+			})
+		}
+
+		for _, addr := range vnic.IPv6Addresses {
+			cidr, err := addressCIDR(addr, vnic.IPv6SubnetCIDRBlock)
+			if err != nil {
+				return fmt.Errorf("error configuring VNIC %q: %w", vnic.VNICID, err)
+			}
+
+			device := &v1alpha1.Device{
+				DeviceInterface: name,
+				DeviceCIDR:      cidr,
+			}
+
+			if primary && vnic.IPv6VirtualRouterIP != "" {
+				device.DeviceRoutes = []*v1alpha1.Route{
+					{
+						RouteNetwork: "::/0",
+						RouteGateway: vnic.IPv6VirtualRouterIP,
+					},
+				}
+			}
+
+			devices = append(devices, device)
+		}
+	}
+
+	if machineConfig.MachineConfig.MachineNetwork == nil {
+		machineConfig.MachineConfig.MachineNetwork = &v1alpha1.NetworkConfig{}
+	}
+
+	machineConfig.MachineConfig.MachineNetwork.NetworkInterfaces = append(
+		machineConfig.MachineConfig.MachineNetwork.NetworkInterfaces,
+		devices...,
+	)
+
+	return nil
+}
+
+// Mode implements the runtime.Platform interface.
+func (o *Oracle) Mode() runtime.Mode {
+	return runtime.ModeCloud
+}
+
+// Hostname implements the runtime.Platform interface.
+func (o *Oracle) Hostname(ctx context.Context) (hostname []byte, err error) {
+	metadata, err := o.metadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if metadata.Hostname == "" {
+		return nil, errors.ErrNoHostname
+	}
+
+	return []byte(metadata.Hostname), nil
+}
+
+// ExternalIPs implements the runtime.Platform interface.
+//
+// Public IPs are NATed to the private IPs of the VNICs, and the metadata service doesn't expose them.
+func (o *Oracle) ExternalIPs(ctx context.Context) (addrs []net.IP, err error) {
+	return addrs, err
+}
+
+// KernelArgs implements the runtime.Platform interface.
+func (o *Oracle) KernelArgs() procfs.Parameters {
+	return []*procfs.Parameter{
+		procfs.NewParameter("console").Append("tty1").Append("ttyS0"),
+	}
+}
+
+func (o *Oracle) metadata(ctx context.Context) (*Metadata, error) {
+	log.Printf("fetching oracle metadata from: %q", OracleMetadataEndpoint)
+
+	metadataDl, err := download.Download(ctx, OracleMetadataEndpoint,
+		download.WithHeaders(metadataHeaders()))
+	if err != nil {
+		return nil, err
+	}
+
+	var metadata Metadata
+	if err = json.Unmarshal(metadataDl, &metadata); err != nil {
+		return nil, err
+	}
+
+	return &metadata, nil
+}
+
+// metadataHeaders returns the headers required by the metadata service v2.
+func metadataHeaders() map[string]string {
+	return map[string]string{
+		"Authorization": "Bearer Oracle",
+	}
+}
+
+func interfaceByMAC(hostInterfaces []net.Interface, mac string) (string, bool) {
+	for _, iface := range hostInterfaces {
+		if mac != "" && strings.EqualFold(iface.HardwareAddr.String(), mac) {
+			return iface.Name, true
+		}
+	}
+
+	return "", false
+}
+
+// addressCIDR returns the address with the prefix length of the subnet.
+func addressCIDR(addr, subnet string) (string, error) {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return "", fmt.Errorf("failed to parse IP address %q", addr)
+	}
+
+	_, ipnet, err := net.ParseCIDR(subnet)
+	if err != nil {
+		return "", err
+	}
+
+	ones, _ := ipnet.Mask.Size()
+
+	return fmt.Sprintf("%s/%d", ip, ones), nil
+}
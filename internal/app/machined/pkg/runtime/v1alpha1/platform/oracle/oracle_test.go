@@ -0,0 +1,94 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package oracle_test
+
+import (
+	"encoding/json"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime/v1alpha1/platform/oracle"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
+)
+
+const vnics = `[
+  {
+    "vnicId": "ocid1.vnic.oc1.eu-amsterdam-1.primary",
+    "privateIp": "172.16.1.11",
+    "vlanTag": 1,
+    "macAddr": "02:00:17:00:00:01",
+    "virtualRouterIp": "172.16.1.1",
+    "subnetCidrBlock": "172.16.1.0/24",
+    "ipv6SubnetCidrBlock": "2603:c020:210e:3800::/64",
+    "ipv6VirtualRouterIp": "fe80::200:17ff:fe00:1",
+    "ipv6Addresses": ["2603:c020:210e:3800::11"]
+  },
+  {
+    "vnicId": "ocid1.vnic.oc1.eu-amsterdam-1.secondary",
+    "privateIp": "172.16.2.11",
+    "vlanTag": 2,
+    "macAddr": "02:00:17:00:00:02",
+    "virtualRouterIp": "172.16.2.1",
+    "subnetCidrBlock": "172.16.2.0/24"
+  },
+  {
+    "vnicId": "ocid1.vnic.oc1.eu-amsterdam-1.missing",
+    "privateIp": "172.16.3.11",
+    "vlanTag": 3,
+    "macAddr": "02:00:17:00:00:03",
+    "virtualRouterIp": "172.16.3.1",
+    "subnetCidrBlock": "172.16.3.0/24"
+  }
+]`
+
+func TestConfigurationNetwork(t *testing.T) {
+	var networkConfig []oracle.NetworkConfig
+
+	require.NoError(t, json.Unmarshal([]byte(vnics), &networkConfig))
+
+	primaryMAC, err := net.ParseMAC("02:00:17:00:00:01")
+	require.NoError(t, err)
+
+	secondaryMAC, err := net.ParseMAC("02:00:17:00:00:02")
+	require.NoError(t, err)
+
+	hostInterfaces := []net.Interface{
+		{Name: "lo"},
+		{Name: "eth0", HardwareAddr: primaryMAC},
+		{Name: "eth1", HardwareAddr: secondaryMAC},
+	}
+
+	cfg := &v1alpha1.Config{
+		MachineConfig: &v1alpha1.MachineConfig{},
+	}
+
+	p := &oracle.Oracle{}
+
+	require.NoError(t, p.ConfigurationNetwork(networkConfig, hostInterfaces, cfg))
+
+	assert.Equal(t, []*v1alpha1.Device{
+		{
+			DeviceInterface: "eth0",
+			DeviceDHCP:      true,
+		},
+		{
+			DeviceInterface: "eth0",
+			DeviceCIDR:      "2603:c020:210e:3800::11/64",
+			DeviceRoutes: []*v1alpha1.Route{
+				{
+					RouteNetwork: "::/0",
+					RouteGateway: "fe80::200:17ff:fe00:1",
+				},
+			},
+		},
+		{
+			DeviceInterface: "eth1",
+			DeviceCIDR:      "172.16.2.11/24",
+		},
+	}, cfg.MachineConfig.MachineNetwork.NetworkInterfaces)
+}
@@ -23,9 +23,10 @@ import (
 
 // Metadata holds packet metadata info.
 type Metadata struct {
-	Hostname       string   `json:"hostname"`
-	Network        Network  `json:"network"`
-	PrivateSubnets []string `json:"private_subnets"`
+	Hostname       string        `json:"hostname"`
+	Network        Network       `json:"network"`
+	PrivateSubnets []string      `json:"private_subnets"`
+	BGPNeighbors   []BGPNeighbor `json:"bgp_neighbors"`
 }
 
 // Network holds network info from the packet metadata.
@@ -59,6 +60,19 @@ type Address struct {
 	Gateway string `json:"gateway"`
 }
 
+// BGPNeighbor holds BGP peering info from the packet metadata.
+//
+// BGP neighbors are present only if BGP is enabled for the device.
+type BGPNeighbor struct {
+	AddressFamily int      `json:"address_family"`
+	CustomerAS    int      `json:"customer_as"`
+	CustomerIP    string   `json:"customer_ip"`
+	MD5Enabled    bool     `json:"md5_enabled"`
+	Multihop      bool     `json:"multihop"`
+	PeerAS        int      `json:"peer_as"`
+	PeerIPs       []string `json:"peer_ips"`
+}
+
 const (
 	// PacketUserDataEndpoint is the local metadata endpoint for Packet.
 	PacketUserDataEndpoint = "https://metadata.platformequinix.com/userdata"
@@ -75,7 +89,6 @@ func (p *Packet) Name() string {
 }
 
 // Configuration implements the platform.Platform interface.
-//nolint:gocyclo
 func (p *Packet) Configuration(ctx context.Context) ([]byte, error) {
 	// Fetch and unmarshal both the talos machine config and the
 	// metadata about the instance from packet's metadata server
@@ -112,6 +125,22 @@ func (p *Packet) Configuration(ctx context.Context) ([]byte, error) {
 		return nil, fmt.Errorf("unable to determine machine config type")
 	}
 
+	hostInterfaces, err := net.Interfaces()
+	if err != nil {
+		return nil, fmt.Errorf("error listing host interfaces: %w", err)
+	}
+
+	if err = p.ConfigurationNetwork(&unmarshalledMetadataConfig, hostInterfaces, machineConfig); err != nil {
+		return nil, err
+	}
+
+	return confProvider.Bytes()
+}
+
+// ConfigurationNetwork appends the bond configuration from the metadata to the machine config.
+//
+//nolint:gocyclo
+func (p *Packet) ConfigurationNetwork(unmarshalledMetadataConfig *Metadata, hostInterfaces []net.Interface, machineConfig *v1alpha1.Config) error {
 	// translate the int returned from bond mode metadata to the type needed by networkd
 	bondMode := nic.BondMode(uint8(unmarshalledMetadataConfig.Network.Bonding.Mode))
 
@@ -119,18 +148,13 @@ func (p *Packet) Configuration(ctx context.Context) ([]byte, error) {
 	devicesInBond := []string{}
 	bondName := ""
 
-	hostInterfaces, err := net.Interfaces()
-	if err != nil {
-		return nil, fmt.Errorf("error listing host interfaces: %w", err)
-	}
-
 	for _, iface := range unmarshalledMetadataConfig.Network.Interfaces {
 		if iface.Bond == "" {
 			continue
 		}
 
 		if bondName != "" && iface.Bond != bondName {
-			return nil, fmt.Errorf("encountered multiple bonds. this is unexpected in the equinix metal platform")
+			return fmt.Errorf("encountered multiple bonds. this is unexpected in the equinix metal platform")
 		}
 
 		found := false
@@ -196,17 +220,46 @@ func (p *Packet) Configuration(ctx context.Context) ([]byte, error) {
 
 				bondDev.DeviceRoutes = append(bondDev.DeviceRoutes, privRoute)
 			}
+
+			// BGP peers are reachable via the private gateway
+			if addr.Family == 4 {
+				bondDev.DeviceRoutes = append(bondDev.DeviceRoutes, bgpPeerRoutes(unmarshalledMetadataConfig.BGPNeighbors, addr.Gateway)...)
+			}
 		}
 
 		packetDevices = append(packetDevices, &bondDev)
 	}
 
+	if machineConfig.MachineConfig.MachineNetwork == nil {
+		machineConfig.MachineConfig.MachineNetwork = &v1alpha1.NetworkConfig{}
+	}
+
 	machineConfig.MachineConfig.MachineNetwork.NetworkInterfaces = append(
 		machineConfig.MachineConfig.MachineNetwork.NetworkInterfaces,
 		packetDevices...,
 	)
 
-	return confProvider.Bytes()
+	return nil
+}
+
+// bgpPeerRoutes builds the host routes to the IPv4 BGP peers.
+func bgpPeerRoutes(neighbors []BGPNeighbor, gateway string) []*v1alpha1.Route {
+	var routes []*v1alpha1.Route
+
+	for _, neighbor := range neighbors {
+		if neighbor.AddressFamily != 4 {
+			continue
+		}
+
+		for _, peerIP := range neighbor.PeerIPs {
+			routes = append(routes, &v1alpha1.Route{
+				RouteNetwork: peerIP + "/32",
+				RouteGateway: gateway,
+			})
+		}
+	}
+
+	return routes
 }
 
 // Mode implements the platform.Platform interface.
@@ -4,11 +4,92 @@
 
 package packet_test
 
-import "testing"
+import (
+	"encoding/json"
+	"net"
+	"testing"
 
-func TestEmpty(t *testing.T) {
-	// added for accurate coverage estimation
-	//
-	// please remove it once any unit-test is added
-	// for this package
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime/v1alpha1/platform/packet"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
+)
+
+const metadata = `{
+  "hostname": "talos-1",
+  "network": {
+    "bonding": {"mode": 4},
+    "interfaces": [
+      {"name": "eth0", "mac": "0c:c4:7a:00:00:01", "bond": "bond0"},
+      {"name": "eth1", "mac": "0c:c4:7a:00:00:02", "bond": "bond0"}
+    ],
+    "addresses": [
+      {"public": true, "enabled": true, "cidr": 31, "address_family": 4, "address": "147.75.80.11", "gateway": "147.75.80.10"},
+      {"public": false, "enabled": true, "cidr": 31, "address_family": 4, "address": "10.67.50.3", "gateway": "10.67.50.2"}
+    ]
+  },
+  "private_subnets": ["10.0.0.0/8"],
+  "bgp_neighbors": [
+    {"address_family": 4, "customer_as": 65000, "customer_ip": "10.67.50.3", "md5_enabled": false, "multihop": true, "peer_as": 65530, "peer_ips": ["169.254.255.1", "169.254.255.2"]},
+    {"address_family": 6, "customer_as": 65000, "customer_ip": "2604:1380::3", "md5_enabled": false, "multihop": true, "peer_as": 65530, "peer_ips": ["fc00::e", "fc00::f"]}
+  ]
+}`
+
+func TestConfigurationNetwork(t *testing.T) {
+	var m packet.Metadata
+
+	require.NoError(t, json.Unmarshal([]byte(metadata), &m))
+
+	mac0, err := net.ParseMAC("0c:c4:7a:00:00:01")
+	require.NoError(t, err)
+
+	mac1, err := net.ParseMAC("0c:c4:7a:00:00:02")
+	require.NoError(t, err)
+
+	hostInterfaces := []net.Interface{
+		{Name: "enp1s0f0", HardwareAddr: mac0},
+		{Name: "enp1s0f1", HardwareAddr: mac1},
+	}
+
+	cfg := &v1alpha1.Config{
+		MachineConfig: &v1alpha1.MachineConfig{},
+	}
+
+	p := &packet.Packet{}
+
+	require.NoError(t, p.ConfigurationNetwork(&m, hostInterfaces, cfg))
+
+	devices := cfg.MachineConfig.MachineNetwork.NetworkInterfaces
+	require.Len(t, devices, 2)
+
+	for _, device := range devices {
+		assert.Equal(t, "bond0", device.DeviceInterface)
+		assert.Equal(t, "802.3ad", device.DeviceBond.BondMode)
+		assert.Equal(t, []string{"enp1s0f0", "enp1s0f1"}, device.DeviceBond.BondInterfaces)
+	}
+
+	assert.Equal(t, "147.75.80.11/31", devices[0].DeviceCIDR)
+	assert.Equal(t, []*v1alpha1.Route{
+		{
+			RouteNetwork: "0.0.0.0/0",
+			RouteGateway: "147.75.80.10",
+		},
+	}, devices[0].DeviceRoutes)
+
+	assert.Equal(t, "10.67.50.3/31", devices[1].DeviceCIDR)
+	assert.Equal(t, []*v1alpha1.Route{
+		{
+			RouteNetwork: "10.0.0.0/8",
+			RouteGateway: "10.67.50.2",
+		},
+		{
+			RouteNetwork: "169.254.255.1/32",
+			RouteGateway: "10.67.50.2",
+		},
+		{
+			RouteNetwork: "169.254.255.2/32",
+			RouteGateway: "10.67.50.2",
+		},
+	}, devices[1].DeviceRoutes)
 }
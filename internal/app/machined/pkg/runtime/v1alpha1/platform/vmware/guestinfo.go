@@ -0,0 +1,75 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package vmware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+)
+
+// OVFEnv is the OVF environment passed by vSphere via the guestinfo.ovfEnv key.
+type OVFEnv struct {
+	Properties []struct {
+		Key   string `xml:"key,attr"`
+		Value string `xml:"value,attr"`
+	} `xml:"PropertySection>Property"`
+}
+
+// ParseOVFEnv returns the vApp properties from the OVF environment.
+func ParseOVFEnv(data []byte) (map[string]string, error) {
+	var env OVFEnv
+
+	if err := xml.Unmarshal(data, &env); err != nil {
+		return nil, fmt.Errorf("failed to parse OVF environment: %w", err)
+	}
+
+	properties := make(map[string]string, len(env.Properties))
+
+	for _, property := range env.Properties {
+		properties[property.Key] = property.Value
+	}
+
+	return properties, nil
+}
+
+// DecodeConfig decodes the machine config passed via guestinfo.
+//
+// Config is always base64 encoded, gzip compressed config is detected automatically
+// if the encoding is not set.
+func DecodeConfig(val, encoding string) ([]byte, error) {
+	b, err := base64.StdEncoding.DecodeString(val)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode base64: %w", err)
+	}
+
+	switch encoding {
+	case constants.VMwareGuestInfoEncodingBase64:
+		return b, nil
+	case constants.VMwareGuestInfoEncodingGzipBase64:
+	case "":
+		// gzip magic
+		if !bytes.HasPrefix(b, []byte{0x1f, 0x8b}) {
+			return b, nil
+		}
+	default:
+		return nil, fmt.Errorf("unsupported config encoding %q", encoding)
+	}
+
+	r, err := gzip.NewReader(bytes.NewReader(b))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress gzip: %w", err)
+	}
+
+	//nolint:errcheck
+	defer r.Close()
+
+	return ioutil.ReadAll(r)
+}
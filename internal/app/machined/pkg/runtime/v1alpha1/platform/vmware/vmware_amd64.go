@@ -8,7 +8,6 @@ package vmware
 
 import (
 	"context"
-	"encoding/base64"
 	"errors"
 	"fmt"
 	"log"
@@ -41,17 +40,11 @@ func (v *VMware) Configuration(context.Context) ([]byte, error) {
 	if *option == constants.ConfigGuestInfo {
 		log.Printf("fetching machine config from: guestinfo key %q", constants.VMwareGuestInfoConfigKey)
 
-		ok, err := vmcheck.IsVirtualWorld()
+		config, err := guestInfoConfig()
 		if err != nil {
 			return nil, err
 		}
 
-		if !ok {
-			return nil, errors.New("not a virtual world")
-		}
-
-		config := rpcvmx.NewConfig()
-
 		val, err := config.String(constants.VMwareGuestInfoConfigKey, "")
 		if err != nil {
 			return nil, fmt.Errorf("failed to get guestinfo.%s: %w", constants.VMwareGuestInfoConfigKey, err)
@@ -64,13 +57,27 @@ func (v *VMware) Configuration(context.Context) ([]byte, error) {
 			}
 		}
 
+		if val == "" {
+			log.Printf("fetching machine config from: vApp property %q", constants.VMwareGuestInfoConfigKey)
+
+			val, err = vAppPropertyConfig(config)
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		if val == "" {
 			log.Printf("config is required, no value found for guestinfo: %q, %q", constants.VMwareGuestInfoConfigKey, constants.VMwareGuestInfoFallbackKey)
 
 			return nil, platformerrors.ErrNoConfigSource
 		}
 
-		b, err := base64.StdEncoding.DecodeString(val)
+		encoding, err := config.String(constants.VMwareGuestInfoConfigEncodingKey, "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to get guestinfo.%s: %w", constants.VMwareGuestInfoConfigEncodingKey, err)
+		}
+
+		b, err := DecodeConfig(val, encoding)
 		if err != nil {
 			return nil, fmt.Errorf("failed to decode guestinfo.%s: %w", constants.VMwareGuestInfoConfigKey, err)
 		}
@@ -96,6 +103,42 @@ func (v *VMware) ExternalIPs(context.Context) (addrs []net.IP, err error) {
 	return addrs, err
 }
 
+// ReportAddresses implements the runtime.AddressReporter interface.
+//
+// First IPv4 and IPv6 addresses are reported via guestinfo, so that they can be read with `govc vm.info -e`.
+func (v *VMware) ReportAddresses(ctx context.Context, addrs []net.IP) error {
+	config, err := guestInfoConfig()
+	if err != nil {
+		return err
+	}
+
+	var ipv4, ipv6 string
+
+	for _, addr := range addrs {
+		switch {
+		case addr.To4() != nil && ipv4 == "":
+			ipv4 = addr.String()
+		case addr.To4() == nil && ipv6 == "":
+			ipv6 = addr.String()
+		}
+	}
+
+	for key, val := range map[string]string{
+		constants.VMwareGuestInfoLocalIPv4Key: ipv4,
+		constants.VMwareGuestInfoLocalIPv6Key: ipv6,
+	} {
+		if val == "" {
+			continue
+		}
+
+		if err = config.SetString(key, val); err != nil {
+			return fmt.Errorf("failed to set guestinfo.%s: %w", key, err)
+		}
+	}
+
+	return nil
+}
+
 // KernelArgs implements the runtime.Platform interface.
 func (v *VMware) KernelArgs() procfs.Parameters {
 	return []*procfs.Parameter{
@@ -103,3 +146,35 @@ func (v *VMware) KernelArgs() procfs.Parameters {
 		procfs.NewParameter("earlyprintk").Append("ttyS0,115200"),
 	}
 }
+
+func guestInfoConfig() (*rpcvmx.Config, error) {
+	ok, err := vmcheck.IsVirtualWorld()
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return nil, errors.New("not a virtual world")
+	}
+
+	return rpcvmx.NewConfig(), nil
+}
+
+// vAppPropertyConfig returns the config from the vApp property passed via the OVF environment.
+func vAppPropertyConfig(config *rpcvmx.Config) (string, error) {
+	ovfEnv, err := config.String(constants.VMwareGuestInfoOVFEnvKey, "")
+	if err != nil {
+		return "", fmt.Errorf("failed to get guestinfo.%s: %w", constants.VMwareGuestInfoOVFEnvKey, err)
+	}
+
+	if ovfEnv == "" {
+		return "", nil
+	}
+
+	properties, err := ParseOVFEnv([]byte(ovfEnv))
+	if err != nil {
+		return "", err
+	}
+
+	return properties[constants.VMwareGuestInfoConfigKey], nil
+}
@@ -4,11 +4,100 @@
 
 package vmware_test
 
-import "testing"
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"testing"
 
-func TestEmpty(t *testing.T) {
-	// added for accurate coverage estimation
-	//
-	// please remove it once any unit-test is added
-	// for this package
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime/v1alpha1/platform/vmware"
+)
+
+const config = "version: v1alpha1\n"
+
+const ovfEnv = `<?xml version="1.0" encoding="UTF-8"?>
+<Environment
+     xmlns="http://schemas.dmtf.org/ovf/environment/1"
+     xmlns:oe="http://schemas.dmtf.org/ovf/environment/1"
+     oe:id="">
+   <PlatformSection>
+      <Kind>VMware ESXi</Kind>
+   </PlatformSection>
+   <PropertySection>
+         <Property oe:key="talos.config" oe:value="dmVyc2lvbjogdjFhbHBoYTEK"/>
+         <Property oe:key="talos.config.encoding" oe:value="base64"/>
+   </PropertySection>
+</Environment>
+`
+
+func gzipBase64(t *testing.T, s string) string {
+	var buf bytes.Buffer
+
+	w := gzip.NewWriter(&buf)
+
+	_, err := w.Write([]byte(s))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	return base64.StdEncoding.EncodeToString(buf.Bytes())
+}
+
+func TestDecodeConfig(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		val      string
+		encoding string
+	}{
+		{
+			name:     "base64",
+			val:      base64.StdEncoding.EncodeToString([]byte(config)),
+			encoding: "base64",
+		},
+		{
+			name:     "gzip+base64",
+			val:      gzipBase64(t, config),
+			encoding: "gzip+base64",
+		},
+		{
+			name: "detect base64",
+			val:  base64.StdEncoding.EncodeToString([]byte(config)),
+		},
+		{
+			name: "detect gzip",
+			val:  gzipBase64(t, config),
+		},
+	} {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := vmware.DecodeConfig(tt.val, tt.encoding)
+			require.NoError(t, err)
+
+			assert.Equal(t, config, string(b))
+		})
+	}
+}
+
+func TestDecodeConfigInvalid(t *testing.T) {
+	_, err := vmware.DecodeConfig("!!!", "")
+	assert.Error(t, err)
+
+	_, err = vmware.DecodeConfig(base64.StdEncoding.EncodeToString([]byte(config)), "gzip+base64")
+	assert.Error(t, err)
+
+	_, err = vmware.DecodeConfig(base64.StdEncoding.EncodeToString([]byte(config)), "zstd")
+	assert.EqualError(t, err, "unsupported config encoding \"zstd\"")
+}
+
+func TestParseOVFEnv(t *testing.T) {
+	properties, err := vmware.ParseOVFEnv([]byte(ovfEnv))
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{
+		"talos.config":          "dmVyc2lvbjogdjFhbHBoYTEK",
+		"talos.config.encoding": "base64",
+	}, properties)
 }
@@ -132,7 +132,7 @@ func (c *Controller) Run(ctx context.Context, seq runtime.Sequence, data interfa
 				return err
 			}
 		} else if c.TryLock() {
-			c.Runtime().Events().Publish(&machine.SequenceEvent{
+			c.Runtime().Events().Publish(ctx, &machine.SequenceEvent{
 				Sequence: seq.String(),
 				Action:   machine.SequenceEvent_NOOP,
 				Error: &common.Error{
@@ -164,7 +164,7 @@ func (c *Controller) Run(ctx context.Context, seq runtime.Sequence, data interfa
 
 	err = c.run(ctx, seq, phases, data)
 	if err != nil {
-		c.Runtime().Events().Publish(&machine.SequenceEvent{
+		c.Runtime().Events().Publish(ctx, &machine.SequenceEvent{
 			Sequence: seq.String(),
 			Action:   machine.SequenceEvent_NOOP,
 			Error: &common.Error{
@@ -254,12 +254,12 @@ func (c *Controller) Unlock() bool {
 }
 
 func (c *Controller) run(ctx context.Context, seq runtime.Sequence, phases []runtime.Phase, data interface{}) error {
-	c.Runtime().Events().Publish(&machine.SequenceEvent{
+	c.Runtime().Events().Publish(ctx, &machine.SequenceEvent{
 		Sequence: seq.String(),
 		Action:   machine.SequenceEvent_START,
 	})
 
-	defer c.Runtime().Events().Publish(&machine.SequenceEvent{
+	defer c.Runtime().Events().Publish(ctx, &machine.SequenceEvent{
 		Sequence: seq.String(),
 		Action:   machine.SequenceEvent_STOP,
 	})
@@ -315,12 +315,12 @@ func (c *Controller) run(ctx context.Context, seq runtime.Sequence, phases []run
 }
 
 func (c *Controller) runPhase(ctx context.Context, phase runtime.Phase, seq runtime.Sequence, data interface{}) error {
-	c.Runtime().Events().Publish(&machine.PhaseEvent{
+	c.Runtime().Events().Publish(ctx, &machine.PhaseEvent{
 		Phase:  phase.Name,
 		Action: machine.PhaseEvent_START,
 	})
 
-	defer c.Runtime().Events().Publish(&machine.PhaseEvent{
+	defer c.Runtime().Events().Publish(ctx, &machine.PhaseEvent{
 		Phase:  phase.Name,
 		Action: machine.PhaseEvent_START,
 	})
@@ -357,7 +357,7 @@ func (c *Controller) runTask(ctx context.Context, progress string, f runtime.Tas
 
 	start := time.Now()
 
-	c.Runtime().Events().Publish(&machine.TaskEvent{
+	c.Runtime().Events().Publish(ctx, &machine.TaskEvent{
 		Task:   taskName,
 		Action: machine.TaskEvent_START,
 	})
@@ -376,7 +376,7 @@ func (c *Controller) runTask(ctx context.Context, progress string, f runtime.Tas
 		}
 	}()
 
-	defer c.Runtime().Events().Publish(&machine.TaskEvent{
+	defer c.Runtime().Events().Publish(ctx, &machine.TaskEvent{
 		Task:   taskName,
 		Action: machine.TaskEvent_STOP,
 	})
@@ -413,9 +413,15 @@ func (c *Controller) phases(seq runtime.Sequence, data interface{}) ([]runtime.P
 	case runtime.SequenceInstall:
 		phases = c.s.Install(c.r)
 	case runtime.SequenceShutdown:
-		phases = c.s.Shutdown(c.r)
+		// shutdown might be triggered internally (e.g. by a power button), in which case there's no request
+		in, _ := data.(*machine.ShutdownRequest)
+
+		phases = c.s.Shutdown(c.r, in)
 	case runtime.SequenceReboot:
-		phases = c.s.Reboot(c.r)
+		// reboot is also used to finish other sequences (e.g. rollback), so the request is optional
+		in, _ := data.(*machine.RebootRequest)
+
+		phases = c.s.Reboot(c.r, in)
 	case runtime.SequenceUpgrade:
 		var (
 			in *machine.UpgradeRequest
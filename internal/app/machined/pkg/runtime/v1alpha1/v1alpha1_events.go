@@ -197,6 +197,10 @@ func (e *Events) Watch(f runtime.WatchFunc, opt ...runtime.WatchOptionFunc) erro
 
 			e.mu.Unlock()
 
+			if opts.ActorID != "" && event.ActorID != opts.ActorID {
+				continue
+			}
+
 			// send event to WatchFunc, wait for it to process the event
 			select {
 			case ch <- event:
@@ -210,7 +214,7 @@ func (e *Events) Watch(f runtime.WatchFunc, opt ...runtime.WatchOptionFunc) erro
 }
 
 // Publish implements the Events interface.
-func (e *Events) Publish(msg proto.Message) {
+func (e *Events) Publish(ctx context.Context, msg proto.Message) {
 	event := runtime.Event{
 		// In the future, we can publish `talos/runtime`, and
 		// `talos/plugin/<plugin>` (or something along those lines) events.
@@ -219,6 +223,7 @@ func (e *Events) Publish(msg proto.Message) {
 		TypeURL: fmt.Sprintf("talos/runtime/%s", msg.ProtoReflect().Descriptor().FullName()),
 		Payload: msg,
 		ID:      xid.New(),
+		ActorID: runtime.ActorIDFromContext(ctx),
 	}
 
 	e.mu.Lock()
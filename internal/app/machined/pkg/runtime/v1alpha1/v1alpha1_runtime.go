@@ -14,6 +14,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 
 	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
+	"github.com/talos-systems/talos/internal/pkg/audit"
 	"github.com/talos-systems/talos/pkg/machinery/config"
 	"github.com/talos-systems/talos/pkg/machinery/config/configloader"
 	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
@@ -25,6 +26,7 @@ type Runtime struct {
 	s runtime.State
 	e runtime.EventStream
 	l runtime.LoggingManager
+	a *audit.Log
 }
 
 // NewRuntime initializes and returns the v1alpha1 runtime.
@@ -34,6 +36,7 @@ func NewRuntime(c config.Provider, s runtime.State, e runtime.EventStream, l run
 		s: s,
 		e: e,
 		l: l,
+		a: audit.NewLog(),
 	}
 }
 
@@ -100,10 +103,31 @@ func (r *Runtime) CanApplyImmediate(b []byte) error {
 	// the config changes allowed to be applied immediately are:
 	// * cluster config
 	// * .machine.time
+	// * .machine.network, except for the settings still managed by networkd (see networkRebootSettings)
+	// * .machine.features.localAPIAllowedUIDs and .machine.features.localAPIAllowedGIDs (read by machined on each request)
 	newConfig.ClusterConfig = currentConfig.ClusterConfig
 
 	if newConfig.MachineConfig != nil && currentConfig.MachineConfig != nil {
 		newConfig.MachineConfig.MachineTime = currentConfig.MachineConfig.MachineTime
+
+		if features := newConfig.MachineConfig.MachineFeatures; features != nil {
+			features.FeaturesLocalAPIAllowedUIDs = nil
+			features.FeaturesLocalAPIAllowedGIDs = nil
+
+			if currentFeatures := currentConfig.MachineConfig.MachineFeatures; currentFeatures != nil {
+				features.FeaturesLocalAPIAllowedUIDs = currentFeatures.FeaturesLocalAPIAllowedUIDs
+				features.FeaturesLocalAPIAllowedGIDs = currentFeatures.FeaturesLocalAPIAllowedGIDs
+			} else if reflect.DeepEqual(*features, v1alpha1.FeaturesConfig{}) {
+				newConfig.MachineConfig.MachineFeatures = nil
+			}
+		}
+
+		if reflect.DeepEqual(
+			networkRebootSettings(currentConfig.MachineConfig.MachineNetwork),
+			networkRebootSettings(newConfig.MachineConfig.MachineNetwork),
+		) {
+			newConfig.MachineConfig.MachineNetwork = currentConfig.MachineConfig.MachineNetwork
+		}
 	}
 
 	if !reflect.DeepEqual(currentConfig, newConfig) {
@@ -115,6 +139,62 @@ func (r *Runtime) CanApplyImmediate(b []byte) error {
 	return nil
 }
 
+type deviceRebootSettings struct {
+	Bond        *v1alpha1.Bond
+	Wireguard   *v1alpha1.DeviceWireguardConfig
+	VIP         *v1alpha1.DeviceVIPConfig
+	DHCPOptions *v1alpha1.DHCPOptions
+	DHCP        bool
+	Ignore      bool
+	VlanDHCP    []uint16
+}
+
+// networkRebootSettings extracts the part of the network config which can't be reconciled
+// by the network controllers and requires a reboot to be applied.
+//
+// Links, addresses, routes and resolvers are converged live.
+func networkRebootSettings(cfg *v1alpha1.NetworkConfig) interface{} {
+	if cfg == nil {
+		cfg = &v1alpha1.NetworkConfig{}
+	}
+
+	devices := map[string]deviceRebootSettings{}
+
+	for _, device := range cfg.NetworkInterfaces {
+		settings := deviceRebootSettings{
+			Bond:        device.DeviceBond,
+			Wireguard:   device.DeviceWireguardConfig,
+			VIP:         device.DeviceVIPConfig,
+			DHCPOptions: device.DeviceDHCPOptions,
+			DHCP:        device.DeviceDHCP,
+			Ignore:      device.DeviceIgnore,
+		}
+
+		for _, vlan := range device.DeviceVlans {
+			if vlan.VlanDHCP {
+				settings.VlanDHCP = append(settings.VlanDHCP, vlan.VlanID)
+			}
+		}
+
+		// devices with static addressing only can be added and removed live
+		if reflect.DeepEqual(settings, deviceRebootSettings{}) {
+			continue
+		}
+
+		devices[device.DeviceInterface] = settings
+	}
+
+	return struct {
+		Hostname   string
+		ExtraHosts []*v1alpha1.ExtraHost
+		Devices    map[string]deviceRebootSettings
+	}{
+		Hostname:   cfg.NetworkHostname,
+		ExtraHosts: cfg.ExtraHostEntries,
+		Devices:    devices,
+	}
+}
+
 // State implements the Runtime interface.
 func (r *Runtime) State() runtime.State {
 	return r.s
@@ -130,6 +210,11 @@ func (r *Runtime) Logging() runtime.LoggingManager {
 	return r.l
 }
 
+// Audit implements the Runtime interface.
+func (r *Runtime) Audit() *audit.Log {
+	return r.a
+}
+
 // NodeName implements the Runtime interface.
 func (r *Runtime) NodeName() (string, error) {
 	// attempt to fetch hostname and domain name via syscalls and concat them if necessary
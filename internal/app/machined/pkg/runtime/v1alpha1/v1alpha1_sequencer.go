@@ -67,6 +67,9 @@ func (*Sequencer) ApplyConfiguration(r runtime.Runtime, req *machineapi.ApplyCon
 		StopNetworkd,
 	).AppendList(
 		stopAllPhaselist(r),
+	).Append(
+		"kexec",
+		KexecPrepare,
 	).Append(
 		"reboot",
 		Reboot,
@@ -160,6 +163,10 @@ func (*Sequencer) Install(r runtime.Runtime) []runtime.Phase {
 			).Append(
 				"containerd",
 				StartContainerd,
+			).AppendWhen(
+				r.Config().Machine().ImageCache().Enabled(),
+				"imageCache",
+				StartImageCache,
 			).Append(
 				"install",
 				Install,
@@ -204,6 +211,9 @@ func (*Sequencer) Boot(r runtime.Runtime) []runtime.Phase {
 	).Append(
 		"saveConfig",
 		SaveConfig,
+	).Append(
+		"identity",
+		LoadMachineIdentity,
 	).Append(
 		"env",
 		SetUserEnvVars,
@@ -233,6 +243,14 @@ func (*Sequencer) Boot(r runtime.Runtime) []runtime.Phase {
 		r.State().Platform().Mode() != runtime.ModeContainer,
 		"udevd",
 		StartUdevd,
+	).AppendWhen(
+		r.State().Platform().Mode() != runtime.ModeContainer && r.Config().Machine().NVIDIA().Enabled(),
+		"nvidia",
+		LoadNVIDIAKernelModules,
+	).AppendWhen(
+		r.State().Platform().Mode() != runtime.ModeContainer && r.Config().Machine().ImageCache().Enabled(),
+		"imageCache",
+		StartImageCache,
 	).AppendWhen(
 		r.State().Platform().Mode() != runtime.ModeContainer,
 		"userDisks",
@@ -248,6 +266,9 @@ func (*Sequencer) Boot(r runtime.Runtime) []runtime.Phase {
 	).Append(
 		"startEverything",
 		StartAllServices,
+	).Append(
+		"reportAddresses",
+		ReportAddresses,
 	).AppendWhen(
 		r.Config().Machine().Type() != machine.TypeJoin,
 		"labelMaster",
@@ -264,6 +285,9 @@ func (*Sequencer) Boot(r runtime.Runtime) []runtime.Phase {
 		r.Config().Machine().Type() != machine.TypeJoin,
 		"checkControlPlaneStatus",
 		CheckControlPlaneStatus,
+	).Append(
+		"reportReadiness",
+		ReportReadiness,
 	)
 
 	return phases
@@ -283,13 +307,21 @@ func (*Sequencer) Bootstrap(r runtime.Runtime) []runtime.Phase {
 }
 
 // Reboot is the reboot sequence.
-func (*Sequencer) Reboot(r runtime.Runtime) []runtime.Phase {
+func (*Sequencer) Reboot(r runtime.Runtime, in *machineapi.RebootRequest) []runtime.Phase {
 	phases := PhaseList{}.Append(
+		"reportReadiness",
+		ReportReadiness,
+	).AppendWhen(
+		in.GetDrain() && r.State().Platform().Mode() != runtime.ModeContainer,
+		"drain",
+		CordonAndDrainNode,
+	).Append(
 		"cleanup",
 		StopAllPods,
 		StopNetworkd,
 	).
 		AppendList(stopAllPhaselist(r)).
+		AppendWhen(in.GetMode() != machineapi.RebootRequest_POWERCYCLE, "kexec", KexecPrepare).
 		Append("reboot", Reboot)
 
 	return phases
@@ -315,6 +347,10 @@ func (*Sequencer) Reset(r runtime.Runtime, in runtime.ResetOptions) []runtime.Ph
 			in.GetGraceful() && (r.Config().Machine().Type() != machine.TypeJoin),
 			"leave",
 			LeaveEtcd,
+		).AppendWhen(
+			in.GetGraceful() && in.GetDeleteNode(),
+			"deleteNode",
+			DeleteKubernetesNode,
 		).AppendWhen(
 			in.GetGraceful(),
 			"cleanup",
@@ -350,15 +386,27 @@ func (*Sequencer) Reset(r runtime.Runtime, in runtime.ResetOptions) []runtime.Ph
 }
 
 // Shutdown is the shutdown sequence.
-func (*Sequencer) Shutdown(r runtime.Runtime) []runtime.Phase {
-	phases := PhaseList{}.
-		Append(
+func (*Sequencer) Shutdown(r runtime.Runtime, in *machineapi.ShutdownRequest) []runtime.Phase {
+	phases := PhaseList{}.Append(
+		"reportReadiness",
+		ReportReadiness,
+	).AppendWhen(
+		in.GetDrain() && r.State().Platform().Mode() != runtime.ModeContainer,
+		"drain",
+		CordonAndDrainNode,
+	)
+
+	// forced shutdown skips graceful stop of the workloads and services, filesystems are only synced
+	if !in.GetForce() {
+		phases = phases.Append(
 			"cleanup",
 			StopAllPods,
 			StopNetworkd,
 		).
-		AppendList(stopAllPhaselist(r)).
-		Append("shutdown", Shutdown)
+			AppendList(stopAllPhaselist(r))
+	}
+
+	phases = phases.Append("shutdown", Shutdown)
 
 	return phases
 }
@@ -381,6 +429,9 @@ func (*Sequencer) StageUpgrade(r runtime.Runtime, in *machineapi.UpgradeRequest)
 			StopNetworkd,
 		).AppendList(
 			stopAllPhaselist(r),
+		).Append(
+			"kexec",
+			KexecPrepare,
 		).Append(
 			"reboot",
 			Reboot,
@@ -441,6 +492,9 @@ func (*Sequencer) Upgrade(r runtime.Runtime, in *machineapi.UpgradeRequest) []ru
 		).Append(
 			"stopEverything",
 			StopAllServices,
+		).Append(
+			"kexec",
+			KexecPrepare,
 		).Append(
 			"reboot",
 			Reboot,
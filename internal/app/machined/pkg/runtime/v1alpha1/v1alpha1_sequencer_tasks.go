@@ -15,6 +15,7 @@ import (
 	"io"
 	"io/ioutil"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"path"
@@ -51,6 +52,8 @@ import (
 	"github.com/talos-systems/talos/internal/pkg/containers/cri/containerd"
 	"github.com/talos-systems/talos/internal/pkg/cri"
 	"github.com/talos-systems/talos/internal/pkg/etcd"
+	"github.com/talos-systems/talos/internal/pkg/identity"
+	"github.com/talos-systems/talos/internal/pkg/kernel/kmod"
 	"github.com/talos-systems/talos/internal/pkg/kernel/kspp"
 	"github.com/talos-systems/talos/internal/pkg/kmsg"
 	"github.com/talos-systems/talos/internal/pkg/mount"
@@ -64,6 +67,7 @@ import (
 	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
 	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1/machine"
 	"github.com/talos-systems/talos/pkg/machinery/constants"
+	"github.com/talos-systems/talos/pkg/resources/cluster"
 	resourcev1alpha1 "github.com/talos-systems/talos/pkg/resources/v1alpha1"
 	"github.com/talos-systems/talos/pkg/sysctl"
 	"github.com/talos-systems/talos/pkg/version"
@@ -495,6 +499,25 @@ func SaveConfig(seq runtime.Sequence, data interface{}) (runtime.TaskExecutionFu
 	}, "saveConfig"
 }
 
+// LoadMachineIdentity represents the LoadMachineIdentity task.
+func LoadMachineIdentity(seq runtime.Sequence, data interface{}) (runtime.TaskExecutionFunc, string) {
+	return func(ctx context.Context, logger *log.Logger, r runtime.Runtime) (err error) {
+		var id *identity.Identity
+
+		if id, err = identity.LoadOrGenerate(constants.MachineIdentityPath); err != nil {
+			return fmt.Errorf("failed to load machine identity: %w", err)
+		}
+
+		logger.Printf("machine identity node ID %s", id.NodeID())
+
+		return r.State().V1Alpha2().SetIdentity(cluster.IdentitySpec{
+			NodeID:     id.NodeID(),
+			PublicKey:  id.PublicKeyString(),
+			SystemUUID: identity.SystemUUID(),
+		})
+	}, "loadMachineIdentity"
+}
+
 func fetchConfig(ctx context.Context, r runtime.Runtime) (out []byte, err error) {
 	var b []byte
 
@@ -623,6 +646,43 @@ func StartUdevd(seq runtime.Sequence, data interface{}) (runtime.TaskExecutionFu
 	}, "startUdevd"
 }
 
+// nvidiaKernelModules is the list of NVIDIA kernel modules in the order of loading.
+var nvidiaKernelModules = []string{
+	"nvidia",
+	"nvidia_uvm",
+	"nvidia_modeset",
+	"nvidia_drm",
+}
+
+// LoadNVIDIAKernelModules represents the task to load NVIDIA kernel modules.
+func LoadNVIDIAKernelModules(seq runtime.Sequence, data interface{}) (runtime.TaskExecutionFunc, string) {
+	return func(ctx context.Context, logger *log.Logger, r runtime.Runtime) (err error) {
+		for _, module := range nvidiaKernelModules {
+			if err = kmod.Load(module); err != nil {
+				return fmt.Errorf("error loading NVIDIA kernel modules: %w", err)
+			}
+
+			logger.Printf("loaded kernel module %q", module)
+		}
+
+		return nil
+	}, "loadNVIDIAKernelModules"
+}
+
+// StartImageCache represents the task to start the local image cache.
+func StartImageCache(seq runtime.Sequence, data interface{}) (runtime.TaskExecutionFunc, string) {
+	return func(ctx context.Context, logger *log.Logger, r runtime.Runtime) (err error) {
+		svc := &services.ImageCache{}
+
+		system.Services(r).LoadAndStart(svc)
+
+		ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+		defer cancel()
+
+		return system.WaitForService(system.StateEventUp, svc.ID(r)).Wait(ctx)
+	}, "startImageCache"
+}
+
 // StartAllServices represents the task to start the system services.
 func StartAllServices(seq runtime.Sequence, data interface{}) (runtime.TaskExecutionFunc, string) {
 	return func(ctx context.Context, logger *log.Logger, r runtime.Runtime) (err error) {
@@ -651,17 +711,30 @@ func StartAllServices(seq runtime.Sequence, data interface{}) (runtime.TaskExecu
 			return fmt.Errorf("unexpected machine type: %s", r.Config().Machine().Type())
 		}
 
+		extensionServices, err := services.LoadExtensionServices(constants.ExtensionServicesConfigPath)
+		if err != nil {
+			logger.Printf("failed to load extension services: %s", err)
+		}
+
+		svcs.Load(extensionServices...)
+
 		system.Services(r).StartAll()
 
 		all := []conditions.Condition{}
 
-		logger.Printf("waiting for %d services", len(svcs.List()))
-
 		for _, svc := range svcs.List() {
-			cond := system.WaitForService(system.StateEventUp, svc.AsProto().GetId())
-			all = append(all, cond)
+			id := svc.AsProto().GetId()
+
+			// extension services don't block the boot
+			if services.IsExtensionService(id) {
+				continue
+			}
+
+			all = append(all, system.WaitForService(system.StateEventUp, id))
 		}
 
+		logger.Printf("waiting for %d services", len(all))
+
 		ctx, cancel := context.WithTimeout(ctx, constants.BootTimeout)
 		defer cancel()
 
@@ -669,6 +742,58 @@ func StartAllServices(seq runtime.Sequence, data interface{}) (runtime.TaskExecu
 	}, "startAllServices"
 }
 
+// ReportAddresses represents the task to report the node addresses to the platform.
+//
+// Failure to report the addresses is not fatal.
+func ReportAddresses(seq runtime.Sequence, data interface{}) (runtime.TaskExecutionFunc, string) {
+	return func(ctx context.Context, logger *log.Logger, r runtime.Runtime) (err error) {
+		reporter, ok := r.State().Platform().(runtime.AddressReporter)
+		if !ok {
+			return nil
+		}
+
+		addrs, err := net.InterfaceAddrs()
+		if err != nil {
+			return fmt.Errorf("error listing addresses: %w", err)
+		}
+
+		var ips []net.IP
+
+		for _, addr := range addrs {
+			ipnet, ok := addr.(*net.IPNet)
+			if !ok || ipnet.IP.IsLoopback() || ipnet.IP.IsLinkLocalUnicast() {
+				continue
+			}
+
+			ips = append(ips, ipnet.IP)
+		}
+
+		if err = reporter.ReportAddresses(ctx, ips); err != nil {
+			logger.Printf("failed to report addresses to the platform: %s", err)
+		}
+
+		return nil
+	}, "reportAddresses"
+}
+
+// ReportReadiness represents the task to report the node readiness to the platform.
+//
+// Node is reported as ready at the end of the boot sequence, and as not ready when it is going down.
+func ReportReadiness(seq runtime.Sequence, data interface{}) (runtime.TaskExecutionFunc, string) {
+	return func(ctx context.Context, logger *log.Logger, r runtime.Runtime) (err error) {
+		reporter, ok := r.State().Platform().(runtime.ReadinessReporter)
+		if !ok {
+			return nil
+		}
+
+		if err = reporter.ReportReadiness(ctx, seq == runtime.SequenceBoot); err != nil {
+			logger.Printf("failed to report readiness to the platform: %s", err)
+		}
+
+		return nil
+	}, "reportReadiness"
+}
+
 // StopNetworkd represents the StopNetworkd task.
 func StopNetworkd(seq runtime.Sequence, data interface{}) (runtime.TaskExecutionFunc, string) {
 	return func(ctx context.Context, logger *log.Logger, r runtime.Runtime) (err error) {
@@ -903,7 +1028,12 @@ func WriteUserFiles(seq runtime.Sequence, data interface{}) (runtime.TaskExecuti
 			return fmt.Errorf("error generating extra files: %w", err)
 		}
 
-		extra, err := containerd.GenerateRegistriesConfig(r.Config().Machine().Registries())
+		extra, err := containerd.GenerateCRIConfig(
+			r.Config().Machine().Registries(),
+			r.Config().Machine().CRI(),
+			r.Config().Machine().NVIDIA(),
+			r.Config().Machine().ImageCache().Enabled(),
+		)
 		if err != nil {
 			return err
 		}
@@ -1219,6 +1349,56 @@ func LeaveEtcd(seq runtime.Sequence, data interface{}) (runtime.TaskExecutionFun
 	}, "leaveEtcd"
 }
 
+// DeleteKubernetesNode represents the task for removing the Node object from Kubernetes.
+//
+// Kubelet is stopped first, so that it doesn't register the node again.
+// The last control plane node is not deleted, as the cluster can't run without it.
+func DeleteKubernetesNode(seq runtime.Sequence, data interface{}) (runtime.TaskExecutionFunc, string) {
+	return func(ctx context.Context, logger *log.Logger, r runtime.Runtime) (err error) {
+		var nodename string
+
+		if nodename, err = r.NodeName(); err != nil {
+			return err
+		}
+
+		var kubeHelper *kubernetes.Client
+
+		if kubeHelper, err = kubernetes.NewClientFromKubeletKubeconfig(); err != nil {
+			return err
+		}
+
+		if r.Config().Machine().Type() != machine.TypeJoin {
+			var last bool
+
+			if last, err = kubeHelper.IsLastControlPlaneNode(ctx, nodename); err != nil {
+				return err
+			}
+
+			if last {
+				logger.Printf("skipping deletion of node %q from Kubernetes, it is the last control plane node", nodename)
+
+				return nil
+			}
+		}
+
+		if err = system.Services(nil).Stop(ctx, "kubelet"); err != nil {
+			return err
+		}
+
+		if err = kubeHelper.DeleteNode(ctx, nodename); err != nil {
+			return err
+		}
+
+		logger.Printf("deleted node %q from Kubernetes", nodename)
+
+		r.Events().Publish(ctx, &machineapi.NodeDeletedEvent{
+			Node: nodename,
+		})
+
+		return nil
+	}, "deleteKubernetesNode"
+}
+
 // RemoveAllPods represents the task for stopping and removing all pods.
 func RemoveAllPods(seq runtime.Sequence, data interface{}) (runtime.TaskExecutionFunc, string) {
 	return stopAndRemoveAllPods(cri.StopAndRemove), "removeAllPods"
@@ -1369,6 +1549,10 @@ func Upgrade(seq runtime.Sequence, data interface{}) (runtime.TaskExecutionFunc,
 
 		logger.Printf("performing upgrade via %q", in.GetImage())
 
+		if err = installPreflight(ctx, r, devname, !in.GetPreserve()); err != nil {
+			return err
+		}
+
 		// We pull the installer image when we receive an upgrade request. No need
 		// to pull it again.
 		err = install.RunInstallerContainer(
@@ -1387,6 +1571,27 @@ func Upgrade(seq runtime.Sequence, data interface{}) (runtime.TaskExecutionFunc,
 	}, "upgrade"
 }
 
+// installPreflight runs the preflight checks on the install target disk before the installer container is started.
+//
+// Failed checks are published to the event stream.
+func installPreflight(ctx context.Context, r runtime.Runtime, disk string, force bool) error {
+	err := installer.Preflight(disk, force)
+
+	var preflightErr *installer.PreflightError
+
+	if errors.As(err, &preflightErr) {
+		for _, failure := range preflightErr.Failures {
+			r.Events().Publish(ctx, &machineapi.InstallPreflightEvent{
+				Disk:    preflightErr.Disk,
+				Check:   failure.Check,
+				Message: failure.Err.Error(),
+			})
+		}
+	}
+
+	return err
+}
+
 // LabelNodeAsMaster represents the LabelNodeAsMaster task.
 func LabelNodeAsMaster(seq runtime.Sequence, data interface{}) (runtime.TaskExecutionFunc, string) {
 	return func(ctx context.Context, logger *log.Logger, r runtime.Runtime) (err error) {
@@ -1439,14 +1644,93 @@ func UpdateBootloader(seq runtime.Sequence, data interface{}) (runtime.TaskExecu
 	}, "updateBootloader"
 }
 
+// KexecPrepare loads the kernel booted by default for the kexec reboot.
+//
+// Failure to load the kernel is not fatal: the node falls back to a normal reboot.
+func KexecPrepare(seq runtime.Sequence, data interface{}) (runtime.TaskExecutionFunc, string) {
+	return func(ctx context.Context, logger *log.Logger, r runtime.Runtime) (err error) {
+		if r.Config() == nil || !r.Config().Machine().Features().KexecEnabled() {
+			return nil
+		}
+
+		if err = mount.SystemPartitionMount(r, constants.BootPartitionLabel); err != nil {
+			logger.Printf("skipping kexec, failed to mount boot partition: %s", err)
+
+			return nil
+		}
+
+		//nolint:errcheck
+		defer mount.SystemPartitionUnmount(r, constants.BootPartitionLabel)
+
+		if err = kexecLoad(logger); err != nil {
+			logger.Printf("skipping kexec: %s", err)
+
+			return nil
+		}
+
+		r.State().Machine().KexecPrepared(true)
+
+		return nil
+	}, "kexecPrepare"
+}
+
+func kexecLoad(logger *log.Logger) error {
+	grubcfg, err := grub.Read(grub.GrubConfig)
+	if err != nil {
+		return fmt.Errorf("error reading bootloader config: %w", err)
+	}
+
+	label, err := grubcfg.DefaultLabel()
+	if err != nil {
+		return err
+	}
+
+	kernel, err := os.Open(filepath.Join(constants.BootMountPoint, label.Kernel))
+	if err != nil {
+		return err
+	}
+
+	defer kernel.Close() //nolint:errcheck
+
+	initrd, err := os.Open(filepath.Join(constants.BootMountPoint, label.Initrd))
+	if err != nil {
+		return err
+	}
+
+	defer initrd.Close() //nolint:errcheck
+
+	cmdline := strings.TrimSpace(label.Append)
+
+	if err = unix.KexecFileLoad(int(kernel.Fd()), int(initrd.Fd()), cmdline, 0); err != nil {
+		switch {
+		case errors.Is(err, unix.ENOSYS):
+			return fmt.Errorf("kexec is not supported by the kernel")
+		case errors.Is(err, unix.EPERM):
+			return fmt.Errorf("kexec is disabled via sysctl")
+		default:
+			return fmt.Errorf("error loading kernel: %w", err)
+		}
+	}
+
+	logger.Printf("prepared kexec: kernel %q, initrd %q, cmdline %q", label.Kernel, label.Initrd, cmdline)
+
+	return nil
+}
+
 // Reboot represents the Reboot task.
 func Reboot(seq runtime.Sequence, data interface{}) (runtime.TaskExecutionFunc, string) {
 	return func(ctx context.Context, logger *log.Logger, r runtime.Runtime) (err error) {
-		r.Events().Publish(&machineapi.RestartEvent{
-			Cmd: unix.LINUX_REBOOT_CMD_RESTART,
+		cmd := unix.LINUX_REBOOT_CMD_RESTART
+
+		if r.State().Machine().IsKexecPrepared() {
+			cmd = unix.LINUX_REBOOT_CMD_KEXEC
+		}
+
+		r.Events().Publish(ctx, &machineapi.RestartEvent{
+			Cmd: int64(cmd),
 		})
 
-		return runtime.RebootError{Cmd: unix.LINUX_REBOOT_CMD_RESTART}
+		return runtime.RebootError{Cmd: cmd}
 	}, "reboot"
 }
 
@@ -1461,7 +1745,7 @@ func Shutdown(seq runtime.Sequence, data interface{}) (runtime.TaskExecutionFunc
 			}
 		}
 
-		r.Events().Publish(&machineapi.RestartEvent{
+		r.Events().Publish(ctx, &machineapi.RestartEvent{
 			Cmd: int64(cmd),
 		})
 
@@ -1610,6 +1894,10 @@ func Install(seq runtime.Sequence, data interface{}) (runtime.TaskExecutionFunc,
 				return err
 			}
 
+			if err = installPreflight(ctx, r, disk, true); err != nil {
+				return err
+			}
+
 			err = install.RunInstallerContainer(
 				disk,
 				r.State().Platform().Name(),
@@ -1618,6 +1906,12 @@ func Install(seq runtime.Sequence, data interface{}) (runtime.TaskExecutionFunc,
 				install.WithForce(true),
 				install.WithZero(r.Config().Machine().Install().Zero()),
 				install.WithExtraKernelArgs(r.Config().Machine().Install().ExtraKernelArgs()),
+				install.WithVirtualizationKernelArgs(r.Config().Machine().Virtualization().KernelArgs()),
+				install.WithCrashDumpKernelArgs(r.Config().Machine().CrashDumps().KernelArgs()),
+				install.WithBootloaderType(r.Config().Machine().Install().BootloaderType()),
+				install.WithConsoles(r.Config().Machine().Install().Consoles()),
+				install.WithSecureBoot(r.Config().Machine().Install().SecureBoot()),
+				install.WithImageCache(r.Config().Machine().ImageCache().Enabled()),
 			)
 			if err != nil {
 				return err
@@ -1634,6 +1928,10 @@ func Install(seq runtime.Sequence, data interface{}) (runtime.TaskExecutionFunc,
 				return fmt.Errorf("error unserializing install options: %w", err)
 			}
 
+			if err = installPreflight(ctx, r, devname, options.Force); err != nil {
+				return err
+			}
+
 			logger.Printf("performing staged upgrade via %q", r.State().Machine().StagedInstallImageRef())
 
 			err = install.RunInstallerContainer(
@@ -1641,6 +1939,7 @@ func Install(seq runtime.Sequence, data interface{}) (runtime.TaskExecutionFunc,
 				r.State().Machine().StagedInstallImageRef(),
 				r.Config().Machine().Registries(),
 				install.WithOptions(options),
+				install.WithImageCache(r.Config().Machine().ImageCache().Enabled()),
 			)
 			if err != nil {
 				return err
@@ -184,24 +184,99 @@ func TestSequencer_Boot(t *testing.T) {
 	}
 }
 
+// mockRuntime provides just enough of the runtime to build the sequences.
+type mockRuntime struct {
+	runtime.Runtime
+
+	mode runtime.Mode
+}
+
+func (m mockRuntime) State() runtime.State {
+	return mockState{mode: m.mode}
+}
+
+type mockState struct {
+	runtime.State
+
+	mode runtime.Mode
+}
+
+func (m mockState) Platform() runtime.Platform {
+	return mockPlatform{mode: m.mode}
+}
+
+type mockPlatform struct {
+	runtime.Platform
+
+	mode runtime.Mode
+}
+
+func (m mockPlatform) Mode() runtime.Mode {
+	return m.mode
+}
+
+// phaseNames returns the names of the phases, as the tasks can't be compared.
+func phaseNames(phases []runtime.Phase) []string {
+	names := make([]string, 0, len(phases))
+
+	for _, phase := range phases {
+		names = append(names, phase.Name)
+	}
+
+	return names
+}
+
+var stopAllMetalPhases = []string{"stopEverything", "unmountUser", "umount", "unmountBind", "unmountSystem"}
+
 func TestSequencer_Reboot(t *testing.T) {
 	type args struct {
-		r runtime.Runtime
+		r  runtime.Runtime
+		in *machine.RebootRequest
 	}
 
 	tests := []struct {
 		name string
-		s    *Sequencer
 		args args
-		want []runtime.Phase
+		want []string
 	}{
-		// TODO: Add test cases.
+		{
+			name: "default",
+			args: args{
+				r:  mockRuntime{mode: runtime.ModeMetal},
+				in: &machine.RebootRequest{},
+			},
+			want: append(append([]string{"cleanup"}, stopAllMetalPhases...), "kexec", "reboot"),
+		},
+		{
+			name: "powercycle",
+			args: args{
+				r:  mockRuntime{mode: runtime.ModeMetal},
+				in: &machine.RebootRequest{Mode: machine.RebootRequest_POWERCYCLE},
+			},
+			want: append(append([]string{"cleanup"}, stopAllMetalPhases...), "reboot"),
+		},
+		{
+			name: "drain",
+			args: args{
+				r:  mockRuntime{mode: runtime.ModeMetal},
+				in: &machine.RebootRequest{Drain: true},
+			},
+			want: append(append([]string{"drain", "cleanup"}, stopAllMetalPhases...), "kexec", "reboot"),
+		},
+		{
+			name: "drain container",
+			args: args{
+				r:  mockRuntime{mode: runtime.ModeContainer},
+				in: &machine.RebootRequest{Drain: true, Mode: machine.RebootRequest_POWERCYCLE},
+			},
+			want: []string{"cleanup", "stopEverything", "reboot"},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &Sequencer{}
-			if got := s.Reboot(tt.args.r); !reflect.DeepEqual(got, tt.want) {
+			if got := phaseNames(s.Reboot(tt.args.r, tt.args.in)); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("Sequencer.Reboot() = %v, want %v", got, tt.want)
 			}
 		})
@@ -235,22 +310,61 @@ func TestSequencer_Reset(t *testing.T) {
 
 func TestSequencer_Shutdown(t *testing.T) {
 	type args struct {
-		r runtime.Runtime
+		r  runtime.Runtime
+		in *machine.ShutdownRequest
 	}
 
 	tests := []struct {
 		name string
-		s    *Sequencer
 		args args
-		want []runtime.Phase
+		want []string
 	}{
-		// TODO: Add test cases.
+		{
+			name: "default",
+			args: args{
+				r:  mockRuntime{mode: runtime.ModeMetal},
+				in: &machine.ShutdownRequest{},
+			},
+			want: append(append([]string{"cleanup"}, stopAllMetalPhases...), "shutdown"),
+		},
+		{
+			name: "force",
+			args: args{
+				r:  mockRuntime{mode: runtime.ModeMetal},
+				in: &machine.ShutdownRequest{Force: true},
+			},
+			want: []string{"shutdown"},
+		},
+		{
+			name: "force drain",
+			args: args{
+				r:  mockRuntime{mode: runtime.ModeMetal},
+				in: &machine.ShutdownRequest{Force: true, Drain: true},
+			},
+			want: []string{"drain", "shutdown"},
+		},
+		{
+			name: "drain",
+			args: args{
+				r:  mockRuntime{mode: runtime.ModeMetal},
+				in: &machine.ShutdownRequest{Drain: true},
+			},
+			want: append(append([]string{"drain", "cleanup"}, stopAllMetalPhases...), "shutdown"),
+		},
+		{
+			name: "drain container",
+			args: args{
+				r:  mockRuntime{mode: runtime.ModeContainer},
+				in: &machine.ShutdownRequest{Drain: true},
+			},
+			want: []string{"cleanup", "stopEverything", "shutdown"},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &Sequencer{}
-			if got := s.Shutdown(tt.args.r); !reflect.DeepEqual(got, tt.want) {
+			if got := phaseNames(s.Shutdown(tt.args.r, tt.args.in)); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("Sequencer.Shutdown() = %v, want %v", got, tt.want)
 			}
 		})
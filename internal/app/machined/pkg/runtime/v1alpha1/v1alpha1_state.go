@@ -37,6 +37,8 @@ type MachineState struct {
 	stagedInstall         bool
 	stagedInstallImageRef string
 	stagedInstallOptions  []byte
+
+	kexecPrepared bool
 }
 
 // ClusterState represents the cluster's state.
@@ -215,3 +217,13 @@ func (s *MachineState) StagedInstallImageRef() string {
 func (s *MachineState) StagedInstallOptions() []byte {
 	return s.stagedInstallOptions
 }
+
+// KexecPrepared implements the machine state interface.
+func (s *MachineState) KexecPrepared(prepared bool) {
+	s.kexecPrepared = prepared
+}
+
+// IsKexecPrepared implements the machine state interface.
+func (s *MachineState) IsKexecPrepared() bool {
+	return s.kexecPrepared
+}
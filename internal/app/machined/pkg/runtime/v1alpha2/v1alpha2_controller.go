@@ -8,15 +8,23 @@ import (
 	"context"
 	"log"
 
+	"github.com/talos-systems/go-procfs/procfs"
 	"github.com/talos-systems/os-runtime/pkg/controller"
 	osruntime "github.com/talos-systems/os-runtime/pkg/controller/runtime"
 
+	"github.com/talos-systems/talos/internal/app/machined/pkg/controllers/cluster"
 	"github.com/talos-systems/talos/internal/app/machined/pkg/controllers/config"
+	"github.com/talos-systems/talos/internal/app/machined/pkg/controllers/hardware"
 	"github.com/talos-systems/talos/internal/app/machined/pkg/controllers/k8s"
+	"github.com/talos-systems/talos/internal/app/machined/pkg/controllers/kubespan"
+	"github.com/talos-systems/talos/internal/app/machined/pkg/controllers/network"
+	runtimecontrollers "github.com/talos-systems/talos/internal/app/machined/pkg/controllers/runtime"
 	"github.com/talos-systems/talos/internal/app/machined/pkg/controllers/secrets"
+	"github.com/talos-systems/talos/internal/app/machined/pkg/controllers/siderolink"
 	"github.com/talos-systems/talos/internal/app/machined/pkg/controllers/time"
 	"github.com/talos-systems/talos/internal/app/machined/pkg/controllers/v1alpha1"
 	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
+	"github.com/talos-systems/talos/internal/app/machined/pkg/system"
 )
 
 // Controller implements runtime.V1alpha2Controller.
@@ -48,14 +56,25 @@ func NewController(v1alpha1Runtime runtime.Runtime, loggingManager runtime.Loggi
 func (ctrl *Controller) Run(ctx context.Context) error {
 	for _, c := range []controller.Controller{
 		&v1alpha1.BootstrapStatusController{},
+		&v1alpha1.ReconcileStatusController{},
 		&v1alpha1.ServiceController{
 			// V1Events
-			V1Alpha1Events: ctrl.v1alpha1Runtime.Events(),
+			V1Alpha1Events:    ctrl.v1alpha1Runtime.Events(),
+			V1Alpha1DependsOn: system.Services(ctrl.v1alpha1Runtime).DependsOn,
 		},
 		&time.SyncController{
 			V1Alpha1Mode: ctrl.v1alpha1Runtime.State().Platform().Mode(),
 		},
+		&cluster.IdentitySealController{},
+		&cluster.MemberController{},
+		&config.DriftController{},
 		&config.MachineTypeController{},
+		&hardware.KVMStatusController{
+			V1Alpha1Mode: ctrl.v1alpha1Runtime.State().Platform().Mode(),
+		},
+		&hardware.SecureBootStatusController{
+			V1Alpha1Mode: ctrl.v1alpha1Runtime.State().Platform().Mode(),
+		},
 		&config.K8sControlPlaneController{},
 		&k8s.ControlPlaneStaticPodController{},
 		&k8s.ExtraManifestController{},
@@ -63,11 +82,53 @@ func (ctrl *Controller) Run(ctx context.Context) error {
 		&k8s.ManifestController{},
 		&k8s.ManifestApplyController{},
 		&k8s.RenderSecretsStaticPodController{},
+		&kubespan.DiscoveryController{
+			V1Alpha1Mode: ctrl.v1alpha1Runtime.State().Platform().Mode(),
+		},
+		&kubespan.IdentityController{
+			V1Alpha1Mode: ctrl.v1alpha1Runtime.State().Platform().Mode(),
+		},
+		&kubespan.ManagerController{},
+		&network.ConfigController{
+			V1Alpha1Mode: ctrl.v1alpha1Runtime.State().Platform().Mode(),
+		},
+		&network.AddressSpecController{},
+		&network.DHCP6Controller{
+			V1Alpha1Mode: ctrl.v1alpha1Runtime.State().Platform().Mode(),
+		},
+		&network.DeviceSelectorController{
+			V1Alpha1Mode: ctrl.v1alpha1Runtime.State().Platform().Mode(),
+		},
+		&network.LinkSpecController{},
+		&network.NfTablesChainConfigController{
+			V1Alpha1Mode: ctrl.v1alpha1Runtime.State().Platform().Mode(),
+		},
+		&network.NfTablesChainController{},
+		&network.ResolverSpecController{},
+		&network.RouteSpecController{},
+		&network.RoutingRuleSpecController{},
+		&network.WireguardPeerStatusController{},
+		&runtimecontrollers.AuditLogController{
+			Log: ctrl.v1alpha1Runtime.Audit(),
+		},
+		&runtimecontrollers.PanicLogController{
+			V1Alpha1Mode: ctrl.v1alpha1Runtime.State().Platform().Mode(),
+		},
+		&runtimecontrollers.RegistrationController{
+			Platform: ctrl.v1alpha1Runtime.State().Platform().Name(),
+		},
+		&runtimecontrollers.TLSPolicyController{},
 		&secrets.EtcdController{},
+		&secrets.KubernetesCertSANsController{},
 		&secrets.KubernetesController{},
 		&secrets.RootController{},
+		&siderolink.ConfigController{
+			V1Alpha1Mode: ctrl.v1alpha1Runtime.State().Platform().Mode(),
+			Cmdline:      procfs.ProcCmdline(),
+		},
+		&siderolink.ManagerController{},
 	} {
-		if err := ctrl.controllerRuntime.RegisterController(c); err != nil {
+		if err := ctrl.controllerRuntime.RegisterController(instrumentedController{c}); err != nil {
 			return err
 		}
 	}
@@ -0,0 +1,75 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package v1alpha2
+
+import (
+	"context"
+	"log"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/talos-systems/os-runtime/pkg/controller"
+
+	"github.com/talos-systems/talos/internal/pkg/metrics"
+)
+
+var (
+	controllerReconciles = metrics.Factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "talos_machined_controller_reconciles_total",
+		Help: "Number of the controller reconcile events.",
+	}, []string{"controller"})
+	controllerErrors = metrics.Factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "talos_machined_controller_errors_total",
+		Help: "Number of the controller run failures.",
+	}, []string{"controller"})
+)
+
+// instrumentedController wraps controller.Controller to record reconcile and error counts.
+type instrumentedController struct {
+	controller.Controller
+}
+
+// Run implements controller.Controller.
+func (ctrl instrumentedController) Run(ctx context.Context, r controller.Runtime, logger *log.Logger) error {
+	name := ctrl.Name()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	eventCh := make(chan controller.ReconcileEvent)
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case ev := <-r.EventCh():
+				controllerReconciles.WithLabelValues(name).Inc()
+
+				select {
+				case eventCh <- ev:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	err := ctrl.Controller.Run(ctx, instrumentedRuntime{Runtime: r, eventCh: eventCh}, logger)
+	if err != nil {
+		controllerErrors.WithLabelValues(name).Inc()
+	}
+
+	return err
+}
+
+type instrumentedRuntime struct {
+	controller.Runtime
+
+	eventCh chan controller.ReconcileEvent
+}
+
+func (r instrumentedRuntime) EventCh() <-chan controller.ReconcileEvent {
+	return r.eventCh
+}
@@ -6,6 +6,7 @@ package v1alpha2
 
 import (
 	"context"
+	"time"
 
 	"github.com/talos-systems/os-runtime/pkg/resource"
 	"github.com/talos-systems/os-runtime/pkg/state"
@@ -14,16 +15,23 @@ import (
 	"github.com/talos-systems/os-runtime/pkg/state/registry"
 
 	talosconfig "github.com/talos-systems/talos/pkg/machinery/config"
+	"github.com/talos-systems/talos/pkg/resources/cluster"
 	"github.com/talos-systems/talos/pkg/resources/config"
+	"github.com/talos-systems/talos/pkg/resources/hardware"
 	"github.com/talos-systems/talos/pkg/resources/k8s"
+	"github.com/talos-systems/talos/pkg/resources/kubespan"
+	"github.com/talos-systems/talos/pkg/resources/network"
+	"github.com/talos-systems/talos/pkg/resources/runtime"
 	"github.com/talos-systems/talos/pkg/resources/secrets"
-	"github.com/talos-systems/talos/pkg/resources/time"
+	"github.com/talos-systems/talos/pkg/resources/siderolink"
+	timeresource "github.com/talos-systems/talos/pkg/resources/time"
 	"github.com/talos-systems/talos/pkg/resources/v1alpha1"
 )
 
 // State implements runtime.V1alpha2State interface.
 type State struct {
-	resources state.State
+	resources  state.State
+	timestamps *timestampedState
 
 	namespaceRegistry *registry.NamespaceRegistry
 	resourceRegistry  *registry.ResourceRegistry
@@ -35,7 +43,8 @@ func NewState() (*State, error) {
 
 	ctx := context.TODO()
 
-	s.resources = state.WrapCore(namespaced.NewState(inmem.Build))
+	s.timestamps = newTimestampedState(namespaced.NewState(inmem.Build))
+	s.resources = state.WrapCore(s.timestamps)
 	s.namespaceRegistry = registry.NewNamespaceRegistry(s.resources)
 	s.resourceRegistry = registry.NewResourceRegistry(s.resources)
 
@@ -64,22 +73,62 @@ func NewState() (*State, error) {
 		return nil, err
 	}
 
+	if err := s.namespaceRegistry.Register(ctx, cluster.NamespaceName, "Cluster membership and node identity resources."); err != nil {
+		return nil, err
+	}
+
+	if err := s.namespaceRegistry.Register(ctx, network.NamespaceName, "Network configuration specs."); err != nil {
+		return nil, err
+	}
+
+	if err := s.namespaceRegistry.Register(ctx, kubespan.NamespaceName, "KubeSpan node-to-node mesh resources."); err != nil {
+		return nil, err
+	}
+
+	if err := s.namespaceRegistry.Register(ctx, siderolink.NamespaceName, "SideroLink management tunnel resources."); err != nil {
+		return nil, err
+	}
+
 	// register Talos resources
 	for _, r := range []resource.Resource{
 		&v1alpha1.BootstrapStatus{},
+		&v1alpha1.ReconcilePause{},
+		&v1alpha1.ReconcileStatus{},
 		&v1alpha1.Service{},
+		&cluster.Affiliate{},
+		&cluster.Identity{},
+		&cluster.Member{},
 		&config.MachineConfig{},
 		&config.MachineType{},
 		&config.K8sControlPlane{},
+		&config.DriftStatus{},
+		&hardware.KVMStatus{},
+		&hardware.SecureBootStatus{},
 		&k8s.Manifest{},
 		&k8s.ManifestStatus{},
 		&k8s.StaticPod{},
 		&k8s.StaticPodStatus{},
 		&k8s.SecretsStatus{},
+		&kubespan.Identity{},
+		&kubespan.PeerSpec{},
+		&network.AddressSpec{},
+		&network.DeviceSelection{},
+		&network.NfTablesChain{},
+		&network.LinkSpec{},
+		&network.ResolverSpec{},
+		&network.RouteSpec{},
+		&network.RoutingRuleSpec{},
+		&network.WireguardPeerStatus{},
+		&runtime.PanicLog{},
+		&runtime.RegistrationStatus{},
+		&runtime.TLSPolicyStatus{},
+		&secrets.CertSAN{},
 		&secrets.Etcd{},
 		&secrets.Kubernetes{},
 		&secrets.Root{},
-		&time.Status{},
+		&siderolink.Config{},
+		&siderolink.Status{},
+		&timeresource.Status{},
 	} {
 		if err := s.resourceRegistry.Register(ctx, r); err != nil {
 			return nil, err
@@ -94,6 +143,11 @@ func (s *State) Resources() state.State {
 	return s.resources
 }
 
+// ResourceUpdated implements runtime.V1alpha2State interface.
+func (s *State) ResourceUpdated(md *resource.Metadata) time.Time {
+	return s.timestamps.Updated(md)
+}
+
 // NamespaceRegistry implements runtime.V1alpha2State interface.
 func (s *State) NamespaceRegistry() *registry.NamespaceRegistry {
 	return s.namespaceRegistry
@@ -123,3 +177,47 @@ func (s *State) SetConfig(cfg talosconfig.Provider) error {
 
 	return s.resources.Update(ctx, oldCfg.Metadata().Version(), cfgResource)
 }
+
+// SetIdentity implements runtime.V1alpha2State interface.
+func (s *State) SetIdentity(spec cluster.IdentitySpec) error {
+	identity := cluster.NewIdentity(cluster.LocalIdentity)
+	*identity.IdentitySpec() = spec
+
+	ctx := context.TODO()
+
+	oldIdentity, err := s.resources.Get(ctx, identity.Metadata())
+	if err != nil {
+		if state.IsNotFoundError(err) {
+			return s.resources.Create(ctx, identity)
+		}
+
+		return err
+	}
+
+	identity.Metadata().SetVersion(oldIdentity.Metadata().Version())
+	identity.Metadata().BumpVersion()
+
+	return s.resources.Update(ctx, oldIdentity.Metadata().Version(), identity)
+}
+
+// SetReconcilePause implements runtime.V1alpha2State interface.
+func (s *State) SetReconcilePause(until time.Time) error {
+	pause := v1alpha1.NewReconcilePause()
+	pause.Pause().Until = until
+
+	ctx := context.TODO()
+
+	oldPause, err := s.resources.Get(ctx, pause.Metadata())
+	if err != nil {
+		if state.IsNotFoundError(err) {
+			return s.resources.Create(ctx, pause)
+		}
+
+		return err
+	}
+
+	pause.Metadata().SetVersion(oldPause.Metadata().Version())
+	pause.Metadata().BumpVersion()
+
+	return s.resources.Update(ctx, oldPause.Metadata().Version(), pause)
+}
@@ -0,0 +1,88 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package v1alpha2
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/talos-systems/os-runtime/pkg/resource"
+	"github.com/talos-systems/os-runtime/pkg/state"
+)
+
+// timestampedState records the time of the last update of each resource.
+//
+// Resource metadata doesn't carry timestamps, so they are tracked next to the state.
+type timestampedState struct {
+	state.CoreState
+
+	mu      sync.Mutex
+	updated map[timestampKey]time.Time
+}
+
+type timestampKey struct {
+	namespace resource.Namespace
+	typ       resource.Type
+	id        resource.ID
+}
+
+func newTimestampedState(st state.CoreState) *timestampedState {
+	return &timestampedState{
+		CoreState: st,
+		updated:   map[timestampKey]time.Time{},
+	}
+}
+
+// Create implements state.CoreState.
+func (st *timestampedState) Create(ctx context.Context, r resource.Resource, opts ...state.CreateOption) error {
+	if err := st.CoreState.Create(ctx, r, opts...); err != nil {
+		return err
+	}
+
+	st.touch(r.Metadata().Namespace(), r.Metadata().Type(), r.Metadata().ID())
+
+	return nil
+}
+
+// Update implements state.CoreState.
+func (st *timestampedState) Update(ctx context.Context, curVersion resource.Version, r resource.Resource, opts ...state.UpdateOption) error {
+	if err := st.CoreState.Update(ctx, curVersion, r, opts...); err != nil {
+		return err
+	}
+
+	st.touch(r.Metadata().Namespace(), r.Metadata().Type(), r.Metadata().ID())
+
+	return nil
+}
+
+// Destroy implements state.CoreState.
+func (st *timestampedState) Destroy(ctx context.Context, ptr resource.Pointer, opts ...state.DestroyOption) error {
+	if err := st.CoreState.Destroy(ctx, ptr, opts...); err != nil {
+		return err
+	}
+
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	delete(st.updated, timestampKey{ptr.Namespace(), ptr.Type(), ptr.ID()})
+
+	return nil
+}
+
+func (st *timestampedState) touch(namespace resource.Namespace, typ resource.Type, id resource.ID) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	st.updated[timestampKey{namespace, typ, id}] = time.Now()
+}
+
+// Updated returns the time of the last update of the resource, zero time if not known.
+func (st *timestampedState) Updated(md *resource.Metadata) time.Time {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	return st.updated[timestampKey{md.Namespace(), md.Type(), md.ID()}]
+}
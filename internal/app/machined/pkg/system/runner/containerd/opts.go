@@ -6,10 +6,13 @@ package containerd
 
 import (
 	"context"
+	"path/filepath"
 
 	"github.com/containerd/containerd/containers"
 	"github.com/containerd/containerd/oci"
 	specs "github.com/opencontainers/runtime-spec/specs-go"
+
+	"github.com/talos-systems/talos/pkg/machinery/constants"
 )
 
 // WithMemoryLimit sets the linux resource memory limit field.
@@ -32,3 +35,36 @@ func WithRootfsPropagation(rp string) oci.SpecOpts {
 		return nil
 	}
 }
+
+// WithLocalAPISocket bind mounts the directory of the local machine API socket into the container.
+//
+// Access to the API is still subject to the peer credentials check, but root (UID 0) is always allowed,
+// so any process running as root in the container gets the full access to the machine API.
+func WithLocalAPISocket() oci.SpecOpts {
+	return oci.WithMounts([]specs.Mount{
+		{
+			Type:        "bind",
+			Destination: filepath.Dir(constants.LocalAPISocketPath),
+			Source:      filepath.Dir(constants.LocalAPISocketPath),
+			Options:     []string{"rbind", "ro"},
+		},
+	})
+}
+
+// WithAddedMaskedPaths appends the paths to the list of the masked paths.
+func WithAddedMaskedPaths(paths []string) oci.SpecOpts {
+	return func(_ context.Context, _ oci.Client, _ *containers.Container, s *specs.Spec) error {
+		s.Linux.MaskedPaths = append(s.Linux.MaskedPaths, paths...)
+
+		return nil
+	}
+}
+
+// WithAddedReadonlyPaths appends the paths to the list of the read-only paths.
+func WithAddedReadonlyPaths(paths []string) oci.SpecOpts {
+	return func(_ context.Context, _ oci.Client, _ *containers.Container, s *specs.Spec) error {
+		s.Linux.ReadonlyPaths = append(s.Linux.ReadonlyPaths, paths...)
+
+		return nil
+	}
+}
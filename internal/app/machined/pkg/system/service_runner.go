@@ -11,10 +11,13 @@ import (
 	"sync"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+
 	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
 	"github.com/talos-systems/talos/internal/app/machined/pkg/system/events"
 	"github.com/talos-systems/talos/internal/app/machined/pkg/system/health"
 	"github.com/talos-systems/talos/internal/app/machined/pkg/system/runner"
+	"github.com/talos-systems/talos/internal/pkg/metrics"
 	"github.com/talos-systems/talos/pkg/conditions"
 	machineapi "github.com/talos-systems/talos/pkg/machinery/api/machine"
 )
@@ -25,6 +28,11 @@ import (
 // Exposed here for unit-tests to override.
 var WaitConditionCheckInterval = time.Second
 
+var serviceRestarts = metrics.Factory.NewCounterVec(prometheus.CounterOpts{
+	Name: "talos_machined_service_restarts_total",
+	Help: "Number of the service restarts.",
+}, []string{"service"})
+
 // ServiceRunner wraps the state of the service (running, stopped, ...).
 type ServiceRunner struct {
 	mu sync.Mutex
@@ -78,6 +86,11 @@ func (svcrunner *ServiceRunner) UpdateState(newstate events.ServiceState, messag
 		Timestamp: time.Now(),
 	}
 
+	// restart policy puts the exited service into the waiting state before running it again
+	if svcrunner.state == events.StateRunning && newstate == events.StateWaiting {
+		serviceRestarts.WithLabelValues(svcrunner.id).Inc()
+	}
+
 	svcrunner.state = newstate
 	svcrunner.events.Push(event)
 
@@ -89,7 +102,7 @@ func (svcrunner *ServiceRunner) UpdateState(newstate events.ServiceState, messag
 	svcrunner.mu.Unlock()
 
 	if svcrunner.runtime != nil {
-		svcrunner.runtime.Events().Publish(event.AsProto(svcrunner.id))
+		svcrunner.runtime.Events().Publish(context.Background(), event.AsProto(svcrunner.id))
 	}
 
 	if isUp {
@@ -140,7 +153,7 @@ func (svcrunner *ServiceRunner) healthUpdate(change health.StateChange) {
 	}
 
 	if svcrunner.runtime != nil {
-		svcrunner.runtime.Events().Publish(event.AsProto(svcrunner.id))
+		svcrunner.runtime.Events().Publish(context.Background(), event.AsProto(svcrunner.id))
 	}
 }
 
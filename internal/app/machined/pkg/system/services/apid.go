@@ -30,6 +30,7 @@ import (
 	"github.com/talos-systems/talos/pkg/copy"
 	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1/machine"
 	"github.com/talos-systems/talos/pkg/machinery/constants"
+	"github.com/talos-systems/talos/pkg/resources/siderolink"
 	"github.com/talos-systems/talos/pkg/resources/time"
 )
 
@@ -70,6 +71,8 @@ func (o *APID) PostFunc(r runtime.Runtime, state events.ServiceState) (err error
 func (o *APID) Condition(r runtime.Runtime) conditions.Condition {
 	conds := []conditions.Condition{
 		time.NewSyncCondition(r.State().V1Alpha2().Resources()),
+		// apid certificate should include the SideroLink address, so wait for the tunnel to be up
+		siderolink.NewReadyCondition(r.State().V1Alpha2().Resources()),
 	}
 
 	if r.Config().Machine().Type() == machine.TypeJoin {
@@ -0,0 +1,253 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/containerd/containerd/oci"
+	"github.com/hashicorp/go-multierror"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+
+	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
+	"github.com/talos-systems/talos/internal/app/machined/pkg/system"
+	"github.com/talos-systems/talos/internal/app/machined/pkg/system/events"
+	"github.com/talos-systems/talos/internal/app/machined/pkg/system/health"
+	"github.com/talos-systems/talos/internal/app/machined/pkg/system/runner"
+	"github.com/talos-systems/talos/internal/app/machined/pkg/system/runner/containerd"
+	"github.com/talos-systems/talos/internal/app/machined/pkg/system/runner/restart"
+	"github.com/talos-systems/talos/pkg/conditions"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+	extservices "github.com/talos-systems/talos/pkg/machinery/extensions/services"
+)
+
+// Extension implements the Service interface for the extension services.
+//
+// Extension services run in containers with the root filesystem shipped with the system extension,
+// and they can be started, stopped and restarted via the API.
+type Extension struct {
+	Spec *extservices.Spec
+}
+
+// healthcheckedExtension is an extension service with the health check.
+type healthcheckedExtension struct {
+	*Extension
+}
+
+// NewExtension creates the service for the extension service spec.
+func NewExtension(spec *extservices.Spec) system.Service {
+	svc := &Extension{
+		Spec: spec,
+	}
+
+	if spec.HealthCheck != nil {
+		return &healthcheckedExtension{svc}
+	}
+
+	return svc
+}
+
+// LoadExtensionServices loads the extension service specs from the directory.
+//
+// Invalid specs are skipped, and the error describes all of them.
+func LoadExtensionServices(dir string) ([]system.Service, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].Name() < files[j].Name() })
+
+	var (
+		svcs   []system.Service
+		result *multierror.Error
+	)
+
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != ".yaml" {
+			continue
+		}
+
+		spec, loadErr := extservices.Load(filepath.Join(dir, file.Name()))
+		if loadErr != nil {
+			result = multierror.Append(result, loadErr)
+
+			continue
+		}
+
+		svcs = append(svcs, NewExtension(spec))
+	}
+
+	return svcs, result.ErrorOrNil()
+}
+
+// ID implements the Service interface.
+func (svc *Extension) ID(r runtime.Runtime) string {
+	return svc.Spec.ID()
+}
+
+// PreFunc implements the Service interface.
+func (svc *Extension) PreFunc(ctx context.Context, r runtime.Runtime) error {
+	if _, err := os.Stat(svc.rootfsPath()); err != nil {
+		return fmt.Errorf("extension service root filesystem is not available: %w", err)
+	}
+
+	return nil
+}
+
+// PostFunc implements the Service interface.
+func (svc *Extension) PostFunc(r runtime.Runtime, state events.ServiceState) (err error) {
+	return nil
+}
+
+// Condition implements the Service interface.
+func (svc *Extension) Condition(r runtime.Runtime) conditions.Condition {
+	var conds []conditions.Condition
+
+	for _, dependency := range svc.Spec.Depends {
+		if dependency.Path != "" {
+			conds = append(conds, conditions.WaitForFileToExist(dependency.Path))
+		}
+	}
+
+	if len(conds) == 0 {
+		return nil
+	}
+
+	return conditions.WaitForAll(conds...)
+}
+
+// DependsOn implements the Service interface.
+func (svc *Extension) DependsOn(r runtime.Runtime) []string {
+	deps := []string{"containerd"}
+
+	for _, dependency := range svc.Spec.Depends {
+		if dependency.Service != "" {
+			deps = append(deps, dependency.Service)
+		}
+	}
+
+	return deps
+}
+
+// Runner implements the Service interface.
+func (svc *Extension) Runner(r runtime.Runtime) (runner.Runner, error) {
+	args := &runner.Args{
+		ID:          svc.ID(r),
+		ProcessArgs: append([]string{svc.Spec.Container.Entrypoint}, svc.Spec.Container.Args...),
+	}
+
+	env := []string{}
+	for key, val := range r.Config().Machine().Env() {
+		env = append(env, fmt.Sprintf("%s=%s", key, val))
+	}
+
+	env = append(env, svc.Spec.Container.Environment...)
+
+	security := svc.Spec.Container.Security
+
+	ociSpecOpts := []oci.SpecOpts{
+		oci.WithHostNamespace(specs.NetworkNamespace),
+		oci.WithRootFSPath(svc.rootfsPath()),
+		oci.WithMounts(svc.Spec.Container.Mounts),
+		oci.WithAddedCapabilities(security.Capabilities),
+		containerd.WithAddedMaskedPaths(security.MaskedPaths),
+		containerd.WithAddedReadonlyPaths(security.ReadonlyPaths),
+	}
+
+	if !security.WriteableRootfs {
+		ociSpecOpts = append(ociSpecOpts, oci.WithRootFSReadonly())
+	}
+
+	if security.WriteableSysfs {
+		ociSpecOpts = append(ociSpecOpts, oci.WithWriteableSysfs)
+	}
+
+	var restartType restart.Type
+
+	switch svc.Spec.Restart {
+	case extservices.RestartNever:
+		restartType = restart.Once
+	case extservices.RestartUntilSuccess:
+		restartType = restart.UntilSuccess
+	case "", extservices.RestartAlways:
+		restartType = restart.Forever
+	}
+
+	return restart.New(containerd.NewRunner(
+		r.Config().Debug(),
+		args,
+		runner.WithLoggingManager(r.Logging()),
+		runner.WithContainerdAddress(constants.SystemContainerdAddress),
+		runner.WithEnv(env),
+		runner.WithOCISpecOpts(ociSpecOpts...),
+	),
+		restart.WithType(restartType),
+	), nil
+}
+
+// APIStartAllowed implements APIStartableService.
+func (svc *Extension) APIStartAllowed(runtime.Runtime) bool {
+	return true
+}
+
+// APIStopAllowed implements APIStoppableService.
+func (svc *Extension) APIStopAllowed(runtime.Runtime) bool {
+	return true
+}
+
+// APIRestartAllowed implements APIRestartableService.
+func (svc *Extension) APIRestartAllowed(runtime.Runtime) bool {
+	return true
+}
+
+func (svc *Extension) rootfsPath() string {
+	return filepath.Join(constants.ExtensionServicesRootfsPath, svc.Spec.Name)
+}
+
+// HealthFunc implements the HealthcheckedService interface.
+func (svc *healthcheckedExtension) HealthFunc(runtime.Runtime) health.Check {
+	return func(ctx context.Context) error {
+		var d net.Dialer
+
+		conn, err := d.DialContext(ctx, "tcp", svc.Spec.HealthCheck.TCP)
+		if err != nil {
+			return err
+		}
+
+		return conn.Close()
+	}
+}
+
+// HealthSettings implements the HealthcheckedService interface.
+func (svc *healthcheckedExtension) HealthSettings(runtime.Runtime) *health.Settings {
+	settings := health.DefaultSettings
+
+	if svc.Spec.HealthCheck.Period > 0 {
+		settings.Period = svc.Spec.HealthCheck.Period
+	}
+
+	if svc.Spec.HealthCheck.Timeout > 0 {
+		settings.Timeout = svc.Spec.HealthCheck.Timeout
+	}
+
+	return &settings
+}
+
+// IsExtensionService returns true if the service ID belongs to an extension service.
+func IsExtensionService(id string) bool {
+	return strings.HasPrefix(id, constants.ExtensionServicePrefix)
+}
@@ -0,0 +1,59 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package services_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/internal/app/machined/pkg/system"
+	"github.com/talos-systems/talos/internal/app/machined/pkg/system/services"
+	extservices "github.com/talos-systems/talos/pkg/machinery/extensions/services"
+)
+
+func TestExtensionInterfaces(t *testing.T) {
+	svc := services.NewExtension(&extservices.Spec{Name: "hello"})
+
+	assert.Implements(t, (*system.APIStartableService)(nil), svc)
+	assert.Implements(t, (*system.APIStoppableService)(nil), svc)
+	assert.Implements(t, (*system.APIRestartableService)(nil), svc)
+
+	_, healthchecked := svc.(system.HealthcheckedService)
+	assert.False(t, healthchecked)
+
+	svc = services.NewExtension(&extservices.Spec{Name: "hello", HealthCheck: &extservices.HealthCheck{TCP: "127.0.0.1:8080"}})
+
+	assert.Implements(t, (*system.HealthcheckedService)(nil), svc)
+	assert.Implements(t, (*system.APIRestartableService)(nil), svc)
+}
+
+func TestLoadExtensionServices(t *testing.T) {
+	dir, err := ioutil.TempDir("", "talos")
+	require.NoError(t, err)
+
+	defer os.RemoveAll(dir) //nolint:errcheck
+
+	svcs, err := services.LoadExtensionServices(filepath.Join(dir, "missing"))
+	require.NoError(t, err)
+	assert.Empty(t, svcs)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "hello.yaml"), []byte("name: hello\ncontainer:\n  entrypoint: /hello\n"), 0o644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "invalid.yaml"), []byte("name: invalid\ncontainer:\n  entrypoint: invalid\n"), 0o644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "README"), []byte("not a spec"), 0o644))
+
+	svcs, err = services.LoadExtensionServices(dir)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "entrypoint should be an absolute path")
+
+	require.Len(t, svcs, 1)
+	assert.Equal(t, "ext-hello", svcs[0].ID(nil))
+	assert.True(t, services.IsExtensionService(svcs[0].ID(nil)))
+	assert.Equal(t, []string{"containerd"}, svcs[0].DependsOn(nil))
+}
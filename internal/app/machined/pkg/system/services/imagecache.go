@@ -0,0 +1,122 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package services
+
+import (
+	"context"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
+	"github.com/talos-systems/talos/internal/app/machined/pkg/system/events"
+	"github.com/talos-systems/talos/internal/app/machined/pkg/system/health"
+	"github.com/talos-systems/talos/internal/app/machined/pkg/system/runner"
+	"github.com/talos-systems/talos/internal/app/machined/pkg/system/runner/goroutine"
+	"github.com/talos-systems/talos/internal/pkg/imagecache"
+	"github.com/talos-systems/talos/pkg/conditions"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+)
+
+// imageCacheMain serves the local image cache registry.
+//
+// If the image cache is not available, the registry is still served reporting all the images as missing,
+// so that the images are pulled from the registries.
+func imageCacheMain(ctx context.Context, r runtime.Runtime, logWriter io.Writer) error {
+	logger := log.New(logWriter, "imagecache ", log.Flags())
+
+	handler := &imagecache.Handler{}
+
+	dir, point, err := imagecache.Mount()
+	if err != nil {
+		logger.Printf("image cache is not available, images are pulled from the registries: %s", err)
+	} else {
+		defer point.Unmount() //nolint:errcheck
+
+		if handler.Layout, err = imagecache.OpenLayout(dir); err != nil {
+			logger.Printf("error reading image cache in %q, images are pulled from the registries: %s", dir, err)
+		} else {
+			logger.Printf("serving %d images from %q", handler.Layout.Len(), dir)
+		}
+	}
+
+	listener, err := net.Listen("tcp", constants.ImageCacheAddress)
+	if err != nil {
+		return err
+	}
+
+	server := &http.Server{
+		Handler:     handler,
+		ReadTimeout: 10 * time.Second,
+		ErrorLog:    logger,
+	}
+
+	defer server.Close() //nolint:errcheck
+
+	go func() {
+		if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			logger.Printf("error serving image cache: %s", err)
+		}
+	}()
+
+	<-ctx.Done()
+
+	return nil
+}
+
+// ImageCache implements the Service interface. It serves as the concrete type with
+// the required methods.
+type ImageCache struct{}
+
+// ID implements the Service interface.
+func (c *ImageCache) ID(r runtime.Runtime) string {
+	return "imagecache"
+}
+
+// PreFunc implements the Service interface.
+func (c *ImageCache) PreFunc(ctx context.Context, r runtime.Runtime) error {
+	return nil
+}
+
+// PostFunc implements the Service interface.
+func (c *ImageCache) PostFunc(r runtime.Runtime, state events.ServiceState) (err error) {
+	return nil
+}
+
+// Condition implements the Service interface.
+func (c *ImageCache) Condition(r runtime.Runtime) conditions.Condition {
+	return nil
+}
+
+// DependsOn implements the Service interface.
+func (c *ImageCache) DependsOn(r runtime.Runtime) []string {
+	return nil
+}
+
+// Runner implements the Service interface.
+func (c *ImageCache) Runner(r runtime.Runtime) (runner.Runner, error) {
+	return goroutine.NewRunner(r, c.ID(r), imageCacheMain, runner.WithLoggingManager(r.Logging())), nil
+}
+
+// HealthFunc implements the HealthcheckedService interface.
+func (c *ImageCache) HealthFunc(runtime.Runtime) health.Check {
+	return func(ctx context.Context) error {
+		var d net.Dialer
+
+		conn, err := d.DialContext(ctx, "tcp", constants.ImageCacheAddress)
+		if err != nil {
+			return err
+		}
+
+		return conn.Close()
+	}
+}
+
+// HealthSettings implements the HealthcheckedService interface.
+func (c *ImageCache) HealthSettings(runtime.Runtime) *health.Settings {
+	return &health.DefaultSettings
+}
@@ -13,6 +13,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 	"text/template"
 	"time"
 
@@ -32,6 +33,8 @@ import (
 	"github.com/talos-systems/talos/internal/app/machined/pkg/system/runner/containerd"
 	"github.com/talos-systems/talos/internal/app/machined/pkg/system/runner/restart"
 	"github.com/talos-systems/talos/internal/pkg/containers/image"
+	"github.com/talos-systems/talos/internal/pkg/nodeip"
+	"github.com/talos-systems/talos/internal/pkg/resolvconf"
 	"github.com/talos-systems/talos/pkg/argsbuilder"
 	"github.com/talos-systems/talos/pkg/conditions"
 	"github.com/talos-systems/talos/pkg/machinery/constants"
@@ -98,6 +101,13 @@ func (k *Kubelet) PreFunc(ctx context.Context, r runtime.Runtime) error {
 		return err
 	}
 
+	// resolv.conf for kubelet is written along with the host resolv.conf, fall back to the host one if it's missing
+	if _, err := os.Stat(constants.KubeletResolvConfPath); os.IsNotExist(err) {
+		if err = resolvconf.WriteKubeletFromHost("/etc/resolv.conf", constants.KubeletResolvConfPath); err != nil {
+			return err
+		}
+	}
+
 	if err := writeKubeletConfig(r); err != nil {
 		return err
 	}
@@ -112,7 +122,7 @@ func (k *Kubelet) PreFunc(ctx context.Context, r runtime.Runtime) error {
 	// Pull the image and unpack it.
 	containerdctx := namespaces.WithNamespace(ctx, constants.SystemContainerdNamespace)
 
-	_, err = image.Pull(containerdctx, r.Config().Machine().Registries(), client, r.Config().Machine().Kubelet().Image(), image.WithSkipIfAlreadyPulled())
+	_, err = image.Pull(containerdctx, r.Config().Machine().Registries(), client, r.Config().Machine().Kubelet().Image(), image.WithSkipIfAlreadyPulled(), image.WithEnv(r.Config().Machine().Env()), image.WithImageCache(r.Config().Machine().ImageCache().Enabled()))
 	if err != nil {
 		return err
 	}
@@ -155,6 +165,7 @@ func (k *Kubelet) Runner(r runtime.Runtime) (runner.Runner, error) {
 		{Type: "bind", Destination: "/lib/modules", Source: "/lib/modules", Options: []string{"bind", "ro"}},
 		{Type: "bind", Destination: "/etc/kubernetes", Source: "/etc/kubernetes", Options: []string{"bind", "rshared", "rw"}},
 		{Type: "bind", Destination: "/etc/os-release", Source: "/etc/os-release", Options: []string{"bind", "ro"}},
+		{Type: "bind", Destination: constants.KubeletResolvConfPath, Source: constants.KubeletResolvConfPath, Options: []string{"bind", "ro"}},
 		{Type: "bind", Destination: "/etc/cni", Source: "/etc/cni", Options: []string{"rbind", "rshared", "rw"}},
 		{Type: "bind", Destination: "/usr/libexec/kubernetes", Source: "/usr/libexec/kubernetes", Options: []string{"rbind", "rshared", "rw"}},
 		{Type: "bind", Destination: "/var/run", Source: "/run", Options: []string{"rbind", "rshared", "rw"}},
@@ -180,6 +191,22 @@ func (k *Kubelet) Runner(r runtime.Runtime) (runner.Runner, error) {
 		env = append(env, fmt.Sprintf("%s=%s", key, val))
 	}
 
+	specOpts := []oci.SpecOpts{
+		containerd.WithRootfsPropagation("shared"),
+		oci.WithMounts(mounts),
+		oci.WithHostNamespace(specs.NetworkNamespace),
+		oci.WithHostNamespace(specs.PIDNamespace),
+		oci.WithParentCgroupDevices,
+		oci.WithPrivileged,
+		oci.WithAllDevicesAllowed,
+	}
+
+	// privileged pods (e.g. node debug pods) access the local machine API via the hostPath volume,
+	// the socket authorizes root, so the mount is opt-in
+	if r.Config().Machine().Features().KubeletLocalAPIEnabled() {
+		specOpts = append(specOpts, containerd.WithLocalAPISocket())
+	}
+
 	return restart.New(containerd.NewRunner(
 		r.Config().Debug(),
 		&args,
@@ -187,15 +214,7 @@ func (k *Kubelet) Runner(r runtime.Runtime) (runner.Runner, error) {
 		runner.WithNamespace(constants.SystemContainerdNamespace),
 		runner.WithContainerImage(r.Config().Machine().Kubelet().Image()),
 		runner.WithEnv(env),
-		runner.WithOCISpecOpts(
-			containerd.WithRootfsPropagation("shared"),
-			oci.WithMounts(mounts),
-			oci.WithHostNamespace(specs.NetworkNamespace),
-			oci.WithHostNamespace(specs.PIDNamespace),
-			oci.WithParentCgroupDevices,
-			oci.WithPrivileged,
-			oci.WithAllDevicesAllowed,
-		),
+		runner.WithOCISpecOpts(specOpts...),
 	),
 		restart.WithType(restart.Forever),
 	), nil
@@ -237,6 +256,7 @@ func (k *Kubelet) HealthSettings(runtime.Runtime) *health.Settings {
 func newKubeletConfiguration(clusterDNS []string, dnsDomain string) *kubeletconfig.KubeletConfiguration {
 	f := false
 	t := true
+	resolvConf := constants.KubeletResolvConfPath
 
 	return &kubeletconfig.KubeletConfiguration{
 		TypeMeta: metav1.TypeMeta{
@@ -263,6 +283,7 @@ func newKubeletConfiguration(clusterDNS []string, dnsDomain string) *kubeletconf
 		},
 		ClusterDomain:       dnsDomain,
 		ClusterDNS:          clusterDNS,
+		ResolverConfig:      &resolvConf,
 		SerializeImagePulls: &f,
 		FailSwapOn:          &f,
 	}
@@ -302,9 +323,44 @@ func (k *Kubelet) args(r runtime.Runtime) ([]string, error) {
 		}
 	}
 
+	// kubelet detects a single node IP on its own, dual-stack clusters require an address of each IP family
+	if !extraArgs.Contains("node-ip") && strings.Contains(r.Config().Cluster().Network().PodCIDR(), ",") {
+		nodeIPs, err := kubeletNodeIPs(r.Config().Cluster().Network().PodCIDR())
+		if err != nil {
+			return nil, err
+		}
+
+		denyListArgs["node-ip"] = strings.Join(nodeIPs, ",")
+	}
+
 	return denyListArgs.Merge(extraArgs).Args(), nil
 }
 
+// kubeletNodeIPs picks the node IPs of the IP families of the cluster.
+func kubeletNodeIPs(podCIDR string) ([]string, error) {
+	ips, err := nodeip.Discover()
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover node IPs: %w", err)
+	}
+
+	picked, err := nodeip.Pick(ips, podCIDR)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(picked) == 0 {
+		return nil, fmt.Errorf("no node IPs found for cluster networks %q", podCIDR)
+	}
+
+	nodeIPs := make([]string, 0, len(picked))
+
+	for _, ip := range picked {
+		nodeIPs = append(nodeIPs, ip.String())
+	}
+
+	return nodeIPs, nil
+}
+
 func writeKubeletConfig(r runtime.Runtime) error {
 	dnsServiceIPs, err := r.Config().Cluster().Network().DNSServiceIPs()
 	if err != nil {
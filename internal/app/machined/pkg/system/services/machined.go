@@ -7,18 +7,38 @@ package services
 
 import (
 	"context"
+	"fmt"
 	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
 
+	"github.com/talos-systems/os-runtime/pkg/resource"
+	"github.com/talos-systems/os-runtime/pkg/resource/meta"
+	"google.golang.org/grpc"
+
+	"github.com/talos-systems/talos/internal/app/machined/internal/healthz"
 	v1alpha1server "github.com/talos-systems/talos/internal/app/machined/internal/server/v1alpha1"
 	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
+	"github.com/talos-systems/talos/internal/app/machined/pkg/system"
 	"github.com/talos-systems/talos/internal/app/machined/pkg/system/events"
 	"github.com/talos-systems/talos/internal/app/machined/pkg/system/runner"
 	"github.com/talos-systems/talos/internal/app/machined/pkg/system/runner/goroutine"
+	"github.com/talos-systems/talos/internal/pkg/metrics"
+	"github.com/talos-systems/talos/internal/pkg/watchdog"
 	"github.com/talos-systems/talos/pkg/conditions"
 	"github.com/talos-systems/talos/pkg/grpc/factory"
+	"github.com/talos-systems/talos/pkg/grpc/middleware/apiversion"
+	"github.com/talos-systems/talos/pkg/grpc/middleware/auth/peercred"
+	"github.com/talos-systems/talos/pkg/machinery/api/common"
+	machineapi "github.com/talos-systems/talos/pkg/machinery/api/machine"
+	"github.com/talos-systems/talos/pkg/machinery/config"
 	"github.com/talos-systems/talos/pkg/machinery/constants"
 )
 
+var apiRequestDuration = metrics.NewAPIRequestDuration("talos_machined")
+
 type machinedService struct {
 	c runtime.Controller
 }
@@ -31,6 +51,10 @@ func (s *machinedService) Main(ctx context.Context, r runtime.Runtime, logWriter
 			Controller: s.c,
 		},
 		factory.WithLog("machined ", logWriter),
+		factory.WithUnaryInterceptor(metrics.UnaryServerInterceptor(apiRequestDuration)),
+		factory.WithStreamInterceptor(metrics.StreamServerInterceptor(apiRequestDuration)),
+		factory.WithUnaryInterceptor(r.Audit().UnaryInterceptor()),
+		factory.WithStreamInterceptor(r.Audit().StreamInterceptor()),
 	)
 
 	listener, err := factory.NewListener(factory.Network("unix"), factory.SocketPath(constants.MachineSocketPath))
@@ -45,11 +69,218 @@ func (s *machinedService) Main(ctx context.Context, r runtime.Runtime, logWriter
 		server.Serve(listener)
 	}()
 
+	// Start the API server for the local clients (host-level containers and services), which is authenticated
+	// via the peer credentials: only the processes running as root or with the UIDs/GIDs from the config are allowed.
+	// The allowlist is read from the current config on each request, so the config changes apply immediately.
+	localCreds := peercred.NewServerCredentials(func() (uids, gids []uint32) {
+		uids = []uint32{0}
+
+		if cfg := r.Config(); cfg != nil {
+			uids = append(uids, cfg.Machine().Features().LocalAPIAllowedUIDs()...)
+			gids = cfg.Machine().Features().LocalAPIAllowedGIDs()
+		}
+
+		return uids, gids
+	})
+
+	localServer := factory.NewServer(
+		&v1alpha1server.Server{
+			Controller: s.c,
+		},
+		factory.WithLog("machined-local ", logWriter),
+		factory.WithUnaryInterceptor(metrics.UnaryServerInterceptor(apiRequestDuration)),
+		factory.WithStreamInterceptor(metrics.StreamServerInterceptor(apiRequestDuration)),
+		factory.WithUnaryInterceptor(r.Audit().UnaryInterceptor()),
+		factory.WithStreamInterceptor(r.Audit().StreamInterceptor()),
+		factory.WithUnaryInterceptor(localCreds.UnaryInterceptor()),
+		factory.WithStreamInterceptor(localCreds.StreamInterceptor()),
+		factory.WithUnaryInterceptor(apiversion.UnaryInterceptor()),
+		factory.WithStreamInterceptor(apiversion.StreamInterceptor()),
+		factory.ServerOptions(
+			grpc.Creds(localCreds),
+		),
+	)
+
+	localListener, err := factory.NewListener(factory.Network("unix"), factory.SocketPath(constants.LocalAPISocketPath))
+	if err != nil {
+		return err
+	}
+
+	defer localServer.Stop()
+
+	go func() {
+		//nolint:errcheck
+		localServer.Serve(localListener)
+	}()
+
+	if r.Config() != nil && (r.Config().Machine().Healthz().Enabled() || r.Config().Machine().Watchdog().Enabled()) {
+		health, err := newHealthHandler(r)
+		if err != nil {
+			return err
+		}
+
+		if r.Config().Machine().Healthz().Enabled() {
+			healthzServer := startHealthz(health, r.Config().Machine().Healthz().Port(), logWriter)
+
+			defer healthzServer.Close() //nolint:errcheck
+		}
+
+		if r.Config().Machine().Watchdog().Enabled() {
+			timer, err := startWatchdog(ctx, r, health, r.Config().Machine().Watchdog(), logWriter)
+			if err != nil {
+				return err
+			}
+
+			defer timer.Close() //nolint:errcheck
+		}
+	}
+
+	if r.Config() != nil && r.Config().Machine().Metrics().Enabled() {
+		metricsServer, err := startMetrics(logWriter)
+		if err != nil {
+			return err
+		}
+
+		defer metricsServer.Close() //nolint:errcheck
+	}
+
 	<-ctx.Done()
 
 	return nil
 }
 
+// newHealthHandler builds the node health tracker fed by the sequence events.
+func newHealthHandler(r runtime.Runtime) (*healthz.Handler, error) {
+	handler := &healthz.Handler{
+		Services: func() []healthz.ServiceStatus {
+			list := system.Services(nil).List()
+			services := make([]healthz.ServiceStatus, 0, len(list))
+
+			for _, svc := range list {
+				info := svc.AsProto()
+
+				services = append(services, healthz.ServiceStatus{
+					ID:      info.GetId(),
+					State:   info.GetState(),
+					Healthy: serviceHealthy(info),
+				})
+			}
+
+			return services
+		},
+	}
+
+	// replay the events to catch up with the sequences started before the endpoint
+	if err := r.Events().Watch(func(events <-chan runtime.Event) {
+		for event := range events {
+			msg, ok := event.Payload.(*machineapi.SequenceEvent)
+			if !ok {
+				continue
+			}
+
+			switch {
+			case msg.GetError() != nil:
+				if msg.GetError().GetCode() != common.Code_LOCKED {
+					handler.SequenceFailed(msg.GetSequence())
+				}
+			case msg.GetAction() == machineapi.SequenceEvent_START:
+				handler.SequenceStarted(msg.GetSequence())
+			case msg.GetAction() == machineapi.SequenceEvent_STOP:
+				handler.SequenceFinished(msg.GetSequence())
+			}
+		}
+	}, runtime.WithTailEvents(-1)); err != nil {
+		return nil, fmt.Errorf("error watching events: %w", err)
+	}
+
+	return handler, nil
+}
+
+// startHealthz starts the node health endpoint.
+func startHealthz(handler *healthz.Handler, port int, logWriter io.Writer) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle(healthz.Path, handler)
+
+	logger := log.New(logWriter, "healthz ", log.Flags())
+
+	server := &http.Server{
+		Addr:        ":" + strconv.Itoa(port),
+		Handler:     mux,
+		ReadTimeout: 10 * time.Second,
+		ErrorLog:    logger,
+	}
+
+	go func() {
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			logger.Printf("error serving health endpoint: %s", err)
+		}
+	}()
+
+	return server
+}
+
+// startWatchdog arms the watchdog timer which is petted while machined is responsive.
+func startWatchdog(ctx context.Context, r runtime.Runtime, handler *healthz.Handler, cfg config.Watchdog, logWriter io.Writer) (watchdog.Timer, error) {
+	logger := log.New(logWriter, "watchdog ", log.Flags())
+
+	timer, err := watchdog.Open(cfg.Device(), cfg.Timeout(), logger)
+	if err != nil {
+		return nil, fmt.Errorf("error opening watchdog: %w", err)
+	}
+
+	go watchdog.Run(ctx, timer, cfg.Timeout(), func(ctx context.Context) error {
+		if err := handler.Probe(ctx); err != nil {
+			return err
+		}
+
+		// controller runtime state is shared by all the controllers
+		_, err := r.State().V1Alpha2().Resources().List(ctx, resource.NewMetadata(meta.NamespaceName, meta.NamespaceType, "", resource.VersionUndefined))
+
+		return err
+	}, logger)
+
+	return timer, nil
+}
+
+// startMetrics serves machined metrics on the local socket, apid gathers them to serve the node metrics endpoint.
+func startMetrics(logWriter io.Writer) (*http.Server, error) {
+	logger := log.New(logWriter, "metrics ", log.Flags())
+
+	listener, err := factory.NewListener(factory.Network("unix"), factory.SocketPath(constants.MachinedMetricsSocketPath))
+	if err != nil {
+		return nil, err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(metrics.Path, metrics.LocalHandler(logger))
+
+	server := &http.Server{
+		Handler:     mux,
+		ReadTimeout: 10 * time.Second,
+		ErrorLog:    logger,
+	}
+
+	go func() {
+		if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			logger.Printf("error serving metrics: %s", err)
+		}
+	}()
+
+	return server, nil
+}
+
+// serviceHealthy reports whether the service is up: either running and passing the health checks, or finished.
+func serviceHealthy(info *machineapi.ServiceInfo) bool {
+	switch info.GetState() {
+	case events.StateFinished.String(), events.StateSkipped.String():
+		return true
+	case events.StateRunning.String():
+		return info.GetHealth().GetUnknown() || info.GetHealth().GetHealthy()
+	default:
+		return false
+	}
+}
+
 // Machined implements the Service interface. It serves as the concrete type with
 // the required methods.
 type Machined struct {
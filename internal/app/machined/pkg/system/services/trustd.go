@@ -10,6 +10,7 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"os"
 	"path/filepath"
 
 	"github.com/containerd/containerd/oci"
@@ -37,6 +38,15 @@ func (t *Trustd) ID(r runtime.Runtime) string {
 
 // PreFunc implements the Service interface.
 func (t *Trustd) PreFunc(ctx context.Context, r runtime.Runtime) error {
+	// revocation list is kept in the STATE partition
+	if err := os.MkdirAll(constants.TrustdStatePath, 0o700); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(constants.TrustdSocketPath), 0o700); err != nil {
+		return err
+	}
+
 	return prepareRootfs(t.ID(r))
 }
 
@@ -65,6 +75,8 @@ func (t *Trustd) Runner(r runtime.Runtime) (runner.Runner, error) {
 	// Set the mounts.
 	mounts := []specs.Mount{
 		{Type: "bind", Destination: "/tmp", Source: "/tmp", Options: []string{"rbind", "rshared", "rw"}},
+		{Type: "bind", Destination: constants.TrustdStatePath, Source: constants.TrustdStatePath, Options: []string{"rbind", "rw"}},
+		{Type: "bind", Destination: filepath.Dir(constants.TrustdSocketPath), Source: filepath.Dir(constants.TrustdSocketPath), Options: []string{"rbind", "rw"}},
 	}
 
 	env := []string{}
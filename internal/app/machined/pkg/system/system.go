@@ -330,6 +330,19 @@ func (s *singleton) List() (result []*ServiceRunner) {
 	return
 }
 
+// DependsOn returns the list of services the service depends on.
+func (s *singleton) DependsOn(id string) []string {
+	s.mu.Lock()
+	svcrunner, exists := s.state[id]
+	s.mu.Unlock()
+
+	if !exists {
+		return nil
+	}
+
+	return svcrunner.service.DependsOn(s.runtime)
+}
+
 // IsRunning checks service status (started/stopped).
 //
 // It doesn't check if service runner was started or not, just pure
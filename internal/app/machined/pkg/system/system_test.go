@@ -31,6 +31,20 @@ func (suite *SystemServicesSuite) TestStartShutdown() {
 	system.Services(nil).Shutdown(context.TODO())
 }
 
+func (suite *SystemServicesSuite) TestDependsOn() {
+	system.Services(nil).LoadAndStart(
+		&MockService{name: "containerd"},
+		&MockService{name: "trustd", dependencies: []string{"containerd"}},
+	)
+	time.Sleep(10 * time.Millisecond)
+
+	suite.Assert().Empty(system.Services(nil).DependsOn("containerd"))
+	suite.Assert().Equal([]string{"containerd"}, system.Services(nil).DependsOn("trustd"))
+	suite.Assert().Nil(system.Services(nil).DependsOn("notloaded"))
+
+	suite.Require().NoError(system.Services(nil).Unload(context.Background(), "containerd", "trustd"))
+}
+
 func TestSystemServicesSuite(t *testing.T) {
 	suite.Run(t, new(SystemServicesSuite))
 }
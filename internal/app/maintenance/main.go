@@ -46,7 +46,7 @@ func Run(ctx context.Context, logger *log.Logger, r runtime.Runtime) ([]byte, er
 		return nil, err
 	}
 
-	cfgCh := make(chan []byte)
+	cfgCh := make(chan []byte, 1)
 
 	s := server.New(r, logger, cfgCh)
 
@@ -93,7 +93,8 @@ func Run(ctx context.Context, logger *log.Logger, r runtime.Runtime) ([]byte, er
 	logger.Printf("\ttalosctl apply-config --insecure --nodes %s --file <config.yaml>", firstIP)
 	logger.Println("or apply configuration using talosctl interactive installer:")
 	logger.Printf("\ttalosctl apply-config --insecure --nodes %s --interactive", firstIP)
-	logger.Println("optionally with node fingerprint check:")
+	logger.Println("talosctl displays the server certificate fingerprint to be verified against the one above,")
+	logger.Println("or the fingerprint can be checked automatically:")
 	logger.Printf("\ttalosctl apply-config --insecure --nodes %s --cert-fingerprint '%s' --file <config.yaml>", firstIP, certFingerprint)
 
 	select {
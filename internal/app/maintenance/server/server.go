@@ -8,8 +8,11 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"sync"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
 	networkserver "github.com/talos-systems/talos/internal/app/networkd/pkg/server"
@@ -18,6 +21,7 @@ import (
 	"github.com/talos-systems/talos/pkg/machinery/api/machine"
 	"github.com/talos-systems/talos/pkg/machinery/api/network"
 	"github.com/talos-systems/talos/pkg/machinery/api/storage"
+	"github.com/talos-systems/talos/pkg/machinery/config"
 	"github.com/talos-systems/talos/pkg/machinery/config/configloader"
 	v1alpha1machine "github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1/machine"
 )
@@ -30,9 +34,14 @@ type Server struct {
 	logger  *log.Logger
 	cfgCh   chan []byte
 	server  *grpc.Server
+
+	mu     sync.Mutex
+	locked bool
 }
 
 // New initializes and returns a `Server`.
+//
+// Config channel should be buffered, so that the accepted config is handed over without blocking the request.
 func New(r runtime.Runtime, logger *log.Logger, cfgCh chan []byte) *Server {
 	return &Server{
 		runtime: r,
@@ -56,6 +65,35 @@ func (s *Server) ApplyConfiguration(ctx context.Context, in *machine.ApplyConfig
 		return nil, fmt.Errorf("apply configuration on reboot is not supported in maintenance mode")
 	}
 
+	reply, err = s.accept(in)
+	if err != nil {
+		return nil, err
+	}
+
+	// config is sent without holding the lock: the service is already locked, so there is only one sender
+	s.cfgCh <- in.GetData()
+
+	return reply, nil
+}
+
+// accept validates the config and locks the service.
+func (s *Server) accept(in *machine.ApplyConfigurationRequest) (*machine.ApplyConfigurationResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// unauthenticated config is accepted only once, and only if the machine has no config yet
+	if s.locked {
+		return nil, status.Error(codes.FailedPrecondition, "configuration was already applied, maintenance service is locked")
+	}
+
+	if s.runtime.Config() != nil {
+		return nil, status.Error(codes.FailedPrecondition, "machine is already configured, configuration can't be applied via maintenance service")
+	}
+
+	if in.ExpectedChecksum != "" {
+		return nil, status.Error(codes.Aborted, "machine has no configuration, expected checksum doesn't match")
+	}
+
 	cfgProvider, err := configloader.NewFromBytes(in.GetData())
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse config: %w", err)
@@ -66,17 +104,21 @@ func (s *Server) ApplyConfiguration(ctx context.Context, in *machine.ApplyConfig
 		return nil, fmt.Errorf("configuration validation failed: %w", err)
 	}
 
-	reply = &machine.ApplyConfigurationResponse{
+	checksum, err := config.Checksum(cfgProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	s.locked = true
+
+	return &machine.ApplyConfigurationResponse{
 		Messages: []*machine.ApplyConfiguration{
 			{
 				Warnings: warnings,
+				Checksum: checksum,
 			},
 		},
-	}
-
-	s.cfgCh <- in.GetData()
-
-	return reply, nil
+	}, nil
 }
 
 // GenerateConfiguration implements the machine.MachineServer interface.
@@ -0,0 +1,179 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package server_test
+
+import (
+	"context"
+	"io/ioutil"
+	"log"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
+	"github.com/talos-systems/talos/internal/app/maintenance/server"
+	"github.com/talos-systems/talos/pkg/machinery/api/machine"
+	"github.com/talos-systems/talos/pkg/machinery/config"
+	genv1alpha1 "github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1/generate"
+	v1alpha1machine "github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1/machine"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+)
+
+type mockRuntime struct {
+	runtime.Runtime
+
+	cfg config.Provider
+}
+
+func (m mockRuntime) Config() config.Provider {
+	return m.cfg
+}
+
+func (m mockRuntime) State() runtime.State {
+	return mockState{}
+}
+
+type mockState struct {
+	runtime.State
+}
+
+func (mockState) Platform() runtime.Platform {
+	return mockPlatform{}
+}
+
+type mockPlatform struct {
+	runtime.Platform
+}
+
+func (mockPlatform) Mode() runtime.Mode {
+	return runtime.ModeContainer
+}
+
+func generateConfig(t *testing.T) config.Provider {
+	secrets, err := genv1alpha1.NewSecretsBundle(genv1alpha1.NewClock())
+	require.NoError(t, err)
+
+	input, err := genv1alpha1.NewInput("test", "https://10.5.0.1:6443", constants.DefaultKubernetesVersion, secrets)
+	require.NoError(t, err)
+
+	cfg, err := genv1alpha1.Config(v1alpha1machine.TypeJoin, input)
+	require.NoError(t, err)
+
+	return cfg
+}
+
+func newServer(r runtime.Runtime) (*server.Server, chan []byte) {
+	cfgCh := make(chan []byte, 1)
+
+	return server.New(r, log.New(ioutil.Discard, "", 0), cfgCh), cfgCh
+}
+
+func TestApplyConfiguration(t *testing.T) {
+	cfgBytes, err := generateConfig(t).Bytes()
+	require.NoError(t, err)
+
+	s, cfgCh := newServer(mockRuntime{})
+
+	resp, err := s.ApplyConfiguration(context.Background(), &machine.ApplyConfigurationRequest{
+		Data: cfgBytes,
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.GetMessages(), 1)
+	assert.NotEmpty(t, resp.GetMessages()[0].GetChecksum())
+
+	assert.Equal(t, cfgBytes, <-cfgCh)
+
+	// the service is locked after the first config
+	_, err = s.ApplyConfiguration(context.Background(), &machine.ApplyConfigurationRequest{
+		Data: cfgBytes,
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	assert.Empty(t, cfgCh)
+}
+
+func TestApplyConfigurationInvalid(t *testing.T) {
+	cfgBytes, err := generateConfig(t).Bytes()
+	require.NoError(t, err)
+
+	s, cfgCh := newServer(mockRuntime{})
+
+	// invalid config doesn't lock the service
+	_, err = s.ApplyConfiguration(context.Background(), &machine.ApplyConfigurationRequest{
+		Data: []byte("version: v1alpha1\nmachine: ["),
+	})
+	require.Error(t, err)
+
+	_, err = s.ApplyConfiguration(context.Background(), &machine.ApplyConfigurationRequest{
+		Data: cfgBytes,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, cfgBytes, <-cfgCh)
+}
+
+func TestApplyConfigurationConfigured(t *testing.T) {
+	cfg := generateConfig(t)
+
+	cfgBytes, err := cfg.Bytes()
+	require.NoError(t, err)
+
+	s, cfgCh := newServer(mockRuntime{cfg: cfg})
+
+	_, err = s.ApplyConfiguration(context.Background(), &machine.ApplyConfigurationRequest{
+		Data: cfgBytes,
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	assert.Empty(t, cfgCh)
+}
+
+func TestApplyConfigurationConcurrent(t *testing.T) {
+	cfgBytes, err := generateConfig(t).Bytes()
+	require.NoError(t, err)
+
+	s, cfgCh := newServer(mockRuntime{})
+
+	const requests = 10
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		accepted int
+	)
+
+	// config channel is not read until all the requests are done
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			_, err := s.ApplyConfiguration(context.Background(), &machine.ApplyConfigurationRequest{
+				Data: cfgBytes,
+			})
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if err == nil {
+				accepted++
+			} else {
+				assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, 1, accepted)
+	assert.Equal(t, cfgBytes, <-cfgCh)
+}
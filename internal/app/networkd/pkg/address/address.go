@@ -9,6 +9,8 @@ import (
 	"log"
 	"net"
 	"time"
+
+	"github.com/talos-systems/talos/pkg/machinery/config"
 )
 
 // Addressing provides an interface for abstracting the underlying network
@@ -47,3 +49,10 @@ type Route struct {
 	// This is an integer which allows the control of priority in the case of multiple routes to the same destination.
 	Metric uint32
 }
+
+// policyRoute returns true if the route uses source, MTU or table settings.
+//
+// Such routes are installed only by the network controllers, as networkd would put them into the main table.
+func policyRoute(route config.Route) bool {
+	return route.Source() != "" || route.MTU() != 0 || route.Table() != 0
+}
@@ -147,8 +147,26 @@ func (d *DHCP4) Routes() (routes []*Route) {
 		}
 	}
 
+	if d.DHCPOptions != nil && d.DHCPOptions.IgnoreDefaultRoutes() {
+		filtered := routes[:0]
+
+		for _, route := range routes {
+			if ones, _ := route.Destination.Mask.Size(); ones == 0 {
+				continue
+			}
+
+			filtered = append(filtered, route)
+		}
+
+		routes = filtered
+	}
+
 	// append any routes that were provided in config
 	for _, route := range d.RouteList {
+		if policyRoute(route) {
+			continue
+		}
+
 		_, ipnet, err := net.ParseCIDR(route.Network())
 		if err != nil {
 			// TODO: we should at least log this failure
@@ -167,6 +185,10 @@ func (d *DHCP4) Routes() (routes []*Route) {
 
 // Resolvers returns the DNS resolvers from the DHCP offer.
 func (d *DHCP4) Resolvers() []net.IP {
+	if d.DHCPOptions != nil && d.DHCPOptions.IgnoreDNS() {
+		return nil
+	}
+
 	return d.Ack.DNS()
 }
 
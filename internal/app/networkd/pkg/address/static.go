@@ -101,6 +101,10 @@ func (s *Static) Scope() uint8 {
 // TODO: do we need to be explicit on route vs gateway?
 func (s *Static) Routes() (routes []*Route) {
 	for _, route := range s.RouteList {
+		if policyRoute(route) {
+			continue
+		}
+
 		_, ipnet, err := net.ParseCIDR(route.Network())
 		if err != nil {
 			// TODO: we should at least log the error
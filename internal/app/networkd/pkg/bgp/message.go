@@ -0,0 +1,238 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package bgp
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+)
+
+// Ref: https://tools.ietf.org/html/rfc4271
+const (
+	headerLen     = 19
+	maxMessageLen = 4096
+
+	msgOpen         = 1
+	msgUpdate       = 2
+	msgNotification = 3
+	msgKeepalive    = 4
+
+	version = 4
+
+	// asTrans is used in place of 4-octet AS numbers in the OPEN message (RFC 6793).
+	asTrans = 23456
+
+	optParamCapabilities = 2
+
+	capMultiprotocol = 1
+	capFourOctetAS   = 65
+
+	afiIPv4         = 1
+	safiUnicast     = 1
+	attrFlagTransit = 0x40
+
+	attrOrigin  = 1
+	attrASPath  = 2
+	attrNextHop = 3
+
+	originIGP     = 0
+	asSequence    = 2
+	errCease      = 6
+	errCeaseAdmin = 2
+)
+
+// message is a raw BGP message.
+type message struct {
+	typ  uint8
+	body []byte
+}
+
+// openMessage is the decoded BGP OPEN message.
+type openMessage struct {
+	as       uint32
+	holdTime uint16
+	routerID net.IP
+
+	fourOctetAS bool
+}
+
+// NotificationError is returned when the peer sends the NOTIFICATION message.
+type NotificationError struct {
+	Code    uint8
+	Subcode uint8
+}
+
+func (e *NotificationError) Error() string {
+	return fmt.Sprintf("peer sent notification: code %d, subcode %d", e.Code, e.Subcode)
+}
+
+func writeMessage(w io.Writer, typ uint8, body []byte) error {
+	buf := make([]byte, headerLen, headerLen+len(body))
+
+	for i := 0; i < 16; i++ {
+		buf[i] = 0xff
+	}
+
+	binary.BigEndian.PutUint16(buf[16:], uint16(headerLen+len(body)))
+	buf[18] = typ
+
+	_, err := w.Write(append(buf, body...))
+
+	return err
+}
+
+func readMessage(r io.Reader) (*message, error) {
+	header := make([]byte, headerLen)
+
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, err
+	}
+
+	for i := 0; i < 16; i++ {
+		if header[i] != 0xff {
+			return nil, fmt.Errorf("invalid message marker")
+		}
+	}
+
+	length := int(binary.BigEndian.Uint16(header[16:]))
+	if length < headerLen || length > maxMessageLen {
+		return nil, fmt.Errorf("invalid message length %d", length)
+	}
+
+	msg := &message{
+		typ:  header[18],
+		body: make([]byte, length-headerLen),
+	}
+
+	if _, err := io.ReadFull(r, msg.body); err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
+
+func encodeOpen(as uint32, holdTime uint16, routerID net.IP) []byte {
+	myAS := uint16(asTrans)
+	if as <= 0xffff {
+		myAS = uint16(as)
+	}
+
+	asBuf := make([]byte, 4)
+	binary.BigEndian.PutUint32(asBuf, as)
+
+	capabilities := []byte{
+		capMultiprotocol, 4, 0, afiIPv4, 0, safiUnicast,
+		capFourOctetAS, 4, asBuf[0], asBuf[1], asBuf[2], asBuf[3],
+	}
+
+	body := []byte{version, byte(myAS >> 8), byte(myAS), byte(holdTime >> 8), byte(holdTime)}
+	body = append(body, routerID.To4()...)
+	body = append(body, byte(len(capabilities)+2), optParamCapabilities, byte(len(capabilities)))
+	body = append(body, capabilities...)
+
+	return body
+}
+
+//nolint:gocyclo
+func decodeOpen(body []byte) (*openMessage, error) {
+	if len(body) < 10 {
+		return nil, fmt.Errorf("OPEN message is too short")
+	}
+
+	if body[0] != version {
+		return nil, fmt.Errorf("unsupported BGP version %d", body[0])
+	}
+
+	open := &openMessage{
+		as:       uint32(binary.BigEndian.Uint16(body[1:])),
+		holdTime: binary.BigEndian.Uint16(body[3:]),
+		routerID: net.IP(body[5:9]),
+	}
+
+	params := body[10:]
+	if len(params) != int(body[9]) {
+		return nil, fmt.Errorf("invalid OPEN optional parameters length")
+	}
+
+	for len(params) > 0 {
+		if len(params) < 2 || len(params) < 2+int(params[1]) {
+			return nil, fmt.Errorf("malformed OPEN optional parameter")
+		}
+
+		typ, value := params[0], params[2:2+int(params[1])]
+		params = params[2+int(params[1]):]
+
+		if typ != optParamCapabilities {
+			continue
+		}
+
+		for len(value) > 0 {
+			if len(value) < 2 || len(value) < 2+int(value[1]) {
+				return nil, fmt.Errorf("malformed OPEN capability")
+			}
+
+			code, capValue := value[0], value[2:2+int(value[1])]
+			value = value[2+int(value[1]):]
+
+			if code == capFourOctetAS && len(capValue) == 4 {
+				open.fourOctetAS = true
+				open.as = binary.BigEndian.Uint32(capValue)
+			}
+		}
+	}
+
+	return open, nil
+}
+
+// encodeUpdate builds the UPDATE message which announces or withdraws IPv4 prefixes.
+//
+// AS path is empty for iBGP sessions (localAS is 0).
+func encodeUpdate(prefixes []*net.IPNet, withdraw bool, localAS uint32, fourOctetAS bool, nextHop net.IP) []byte {
+	var nlri []byte
+
+	for _, prefix := range prefixes {
+		ones, _ := prefix.Mask.Size()
+
+		nlri = append(nlri, byte(ones))
+		nlri = append(nlri, prefix.IP.To4()[:(ones+7)/8]...)
+	}
+
+	if withdraw {
+		body := []byte{byte(len(nlri) >> 8), byte(len(nlri))}
+		body = append(body, nlri...)
+
+		return append(body, 0, 0)
+	}
+
+	var asPath []byte
+
+	if localAS != 0 {
+		asPath = []byte{asSequence, 1}
+
+		if fourOctetAS {
+			asPath = append(asPath, byte(localAS>>24), byte(localAS>>16), byte(localAS>>8), byte(localAS))
+		} else {
+			as := uint16(asTrans)
+			if localAS <= 0xffff {
+				as = uint16(localAS)
+			}
+
+			asPath = append(asPath, byte(as>>8), byte(as))
+		}
+	}
+
+	attrs := []byte{attrFlagTransit, attrOrigin, 1, originIGP}
+	attrs = append(attrs, attrFlagTransit, attrASPath, byte(len(asPath)))
+	attrs = append(attrs, asPath...)
+	attrs = append(attrs, attrFlagTransit, attrNextHop, 4)
+	attrs = append(attrs, nextHop.To4()...)
+
+	body := []byte{0, 0, byte(len(attrs) >> 8), byte(len(attrs))}
+	body = append(body, attrs...)
+
+	return append(body, nlri...)
+}
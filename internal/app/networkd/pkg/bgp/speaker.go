@@ -0,0 +1,276 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package bgp implements a minimal BGP speaker which announces IPv4 prefixes to a single peer.
+//
+// Speaker doesn't maintain the RIB: routes received from the peer are ignored, so it is only suitable
+// to announce the local addresses (e.g. the shared IPs) to the upstream routers.
+package bgp
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"syscall"
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+const (
+	port = 179
+
+	defaultHoldTime = 90 * time.Second
+	dialTimeout     = 10 * time.Second
+)
+
+// Speaker announces the prefixes to the BGP peer.
+type Speaker struct {
+	// LocalAS is the autonomous system number of this node.
+	LocalAS uint32
+	// PeerAS is the expected autonomous system number of the peer.
+	PeerAS uint32
+	// LocalAddress is the address of this node used for peering, it is used as router ID and the next hop.
+	LocalAddress net.IP
+	// PeerAddress is the address of the peer.
+	PeerAddress net.IP
+	// Password enables TCP MD5 signature (RFC 2385) for the session.
+	Password string
+	// Multihop sets TTL of the session to 255 for the peers which are not directly connected.
+	Multihop bool
+	// HoldTime proposed to the peer, defaults to 90 seconds.
+	HoldTime time.Duration
+
+	// Port is the peer port, used in tests.
+	Port int
+}
+
+// Run establishes the session and announces the prefixes until the context is canceled.
+//
+// On cancellation the prefixes are withdrawn and the session is closed gracefully.
+// Run returns an error if the session can't be established or it is closed by the peer.
+//
+//nolint:gocyclo,cyclop
+func (s *Speaker) Run(ctx context.Context, prefixes []*net.IPNet) error {
+	for _, prefix := range prefixes {
+		if prefix.IP.To4() == nil {
+			return fmt.Errorf("only IPv4 prefixes are supported: %s", prefix)
+		}
+	}
+
+	if s.LocalAddress.To4() == nil || s.PeerAddress.To4() == nil {
+		return fmt.Errorf("only IPv4 peering is supported")
+	}
+
+	holdTime := s.HoldTime
+	if holdTime == 0 {
+		holdTime = defaultHoldTime
+	}
+
+	conn, err := s.dial(ctx)
+	if err != nil {
+		return fmt.Errorf("error connecting to BGP peer %s: %w", s.PeerAddress, err)
+	}
+
+	//nolint:errcheck
+	defer conn.Close()
+
+	if err = conn.SetDeadline(time.Now().Add(holdTime)); err != nil {
+		return err
+	}
+
+	if err = writeMessage(conn, msgOpen, encodeOpen(s.LocalAS, uint16(holdTime/time.Second), s.LocalAddress)); err != nil {
+		return err
+	}
+
+	msg, err := readMessage(conn)
+	if err != nil {
+		return fmt.Errorf("error reading OPEN: %w", err)
+	}
+
+	if err = checkNotification(msg); err != nil {
+		return err
+	}
+
+	if msg.typ != msgOpen {
+		return fmt.Errorf("unexpected message type %d, expected OPEN", msg.typ)
+	}
+
+	open, err := decodeOpen(msg.body)
+	if err != nil {
+		return err
+	}
+
+	if open.as != s.PeerAS {
+		return fmt.Errorf("peer AS mismatch: expected %d, got %d", s.PeerAS, open.as)
+	}
+
+	negotiatedHoldTime := holdTime
+	if peerHoldTime := time.Duration(open.holdTime) * time.Second; peerHoldTime < negotiatedHoldTime {
+		negotiatedHoldTime = peerHoldTime
+	}
+
+	if err = writeMessage(conn, msgKeepalive, nil); err != nil {
+		return err
+	}
+
+	if msg, err = readMessage(conn); err != nil {
+		return fmt.Errorf("error reading KEEPALIVE: %w", err)
+	}
+
+	if err = checkNotification(msg); err != nil {
+		return err
+	}
+
+	if msg.typ != msgKeepalive {
+		return fmt.Errorf("unexpected message type %d, expected KEEPALIVE", msg.typ)
+	}
+
+	// AS path is prepended with the local AS for eBGP sessions only
+	pathAS := s.LocalAS
+	if s.LocalAS == s.PeerAS {
+		pathAS = 0
+	}
+
+	if err = writeMessage(conn, msgUpdate, encodeUpdate(prefixes, false, pathAS, open.fourOctetAS, s.LocalAddress)); err != nil {
+		return err
+	}
+
+	// established, hold timer is now driven by the reader loop
+	if err = conn.SetDeadline(time.Time{}); err != nil {
+		return err
+	}
+
+	errCh := make(chan error, 1)
+
+	go func() {
+		errCh <- readLoop(conn, negotiatedHoldTime)
+	}()
+
+	var keepalive <-chan time.Time
+
+	if negotiatedHoldTime > 0 {
+		ticker := time.NewTicker(negotiatedHoldTime / 3)
+		defer ticker.Stop()
+
+		keepalive = ticker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			//nolint:errcheck
+			conn.SetWriteDeadline(time.Now().Add(time.Second))
+
+			if err = writeMessage(conn, msgUpdate, encodeUpdate(prefixes, true, 0, false, nil)); err != nil {
+				return err
+			}
+
+			return writeMessage(conn, msgNotification, []byte{errCease, errCeaseAdmin})
+		case err = <-errCh:
+			return err
+		case <-keepalive:
+			if err = writeMessage(conn, msgKeepalive, nil); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s *Speaker) dial(ctx context.Context) (net.Conn, error) {
+	peerPort := s.Port
+	if peerPort == 0 {
+		peerPort = port
+	}
+
+	peer := net.JoinHostPort(s.PeerAddress.String(), strconv.Itoa(peerPort))
+
+	dialer := net.Dialer{
+		Timeout:   dialTimeout,
+		LocalAddr: &net.TCPAddr{IP: s.LocalAddress},
+		Control: func(network, address string, c syscall.RawConn) error {
+			var sockErr error
+
+			if err := c.Control(func(fd uintptr) {
+				if s.Multihop {
+					if sockErr = unix.SetsockoptInt(int(fd), unix.IPPROTO_IP, unix.IP_TTL, 255); sockErr != nil {
+						return
+					}
+				}
+
+				if s.Password != "" {
+					sockErr = setMD5Sig(int(fd), s.PeerAddress, s.Password)
+				}
+			}); err != nil {
+				return err
+			}
+
+			return sockErr
+		},
+	}
+
+	return dialer.DialContext(ctx, "tcp4", peer)
+}
+
+// readLoop consumes the messages from the peer until the session fails.
+func readLoop(conn net.Conn, holdTime time.Duration) error {
+	for {
+		if holdTime > 0 {
+			if err := conn.SetReadDeadline(time.Now().Add(holdTime)); err != nil {
+				return err
+			}
+		}
+
+		msg, err := readMessage(conn)
+		if err != nil {
+			return fmt.Errorf("BGP session failed: %w", err)
+		}
+
+		if err = checkNotification(msg); err != nil {
+			return err
+		}
+	}
+}
+
+func checkNotification(msg *message) error {
+	if msg.typ != msgNotification {
+		return nil
+	}
+
+	notification := &NotificationError{}
+
+	if len(msg.body) >= 2 {
+		notification.Code, notification.Subcode = msg.body[0], msg.body[1]
+	}
+
+	return notification
+}
+
+// setMD5Sig configures the TCP MD5 signature option for the peer address.
+//
+// Layout of the struct tcp_md5sig from linux/tcp.h.
+func setMD5Sig(fd int, peer net.IP, password string) error {
+	const (
+		sockaddrStorageLen = 128
+		maxKeyLen          = 80
+	)
+
+	if len(password) > maxKeyLen {
+		return fmt.Errorf("MD5 password is too long")
+	}
+
+	buf := make([]byte, sockaddrStorageLen+8+maxKeyLen)
+
+	// struct sockaddr_in, family is in the host byte order, port is unused
+	*(*uint16)(unsafe.Pointer(&buf[0])) = unix.AF_INET
+	copy(buf[4:8], peer.To4())
+
+	// flags, prefixlen are left zero, keylen (u16) and ifindex (u32, zero) follow
+	*(*uint16)(unsafe.Pointer(&buf[sockaddrStorageLen+2])) = uint16(len(password))
+	copy(buf[sockaddrStorageLen+8:], password)
+
+	return unix.SetsockoptString(fd, unix.IPPROTO_TCP, unix.TCP_MD5SIG, string(buf))
+}
@@ -0,0 +1,163 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package bgp
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpen(t *testing.T) {
+	open, err := decodeOpen(encodeOpen(4200000000, 90, net.ParseIP("10.0.0.1")))
+	require.NoError(t, err)
+
+	assert.EqualValues(t, 4200000000, open.as)
+	assert.EqualValues(t, 90, open.holdTime)
+	assert.Equal(t, "10.0.0.1", open.routerID.String())
+	assert.True(t, open.fourOctetAS)
+
+	open, err = decodeOpen([]byte{4, 0xfd, 0xea, 0, 180, 169, 254, 255, 1, 0})
+	require.NoError(t, err)
+
+	assert.EqualValues(t, 65002, open.as)
+	assert.EqualValues(t, 180, open.holdTime)
+	assert.False(t, open.fourOctetAS)
+
+	_, err = decodeOpen([]byte{3, 0xfd, 0xea, 0, 180, 169, 254, 255, 1, 0})
+	assert.EqualError(t, err, "unsupported BGP version 3")
+}
+
+func TestUpdate(t *testing.T) {
+	_, prefix, err := net.ParseCIDR("147.75.100.10/32")
+	require.NoError(t, err)
+
+	assert.Equal(t, []byte{
+		0, 0, // withdrawn routes
+		0, 18, // path attributes
+		0x40, 1, 1, 0, // ORIGIN IGP
+		0x40, 2, 4, 2, 1, 0xfd, 0xe8, // AS_PATH [65000]
+		0x40, 3, 4, 10, 67, 50, 3, // NEXT_HOP
+		32, 147, 75, 100, 10, // NLRI
+	}, encodeUpdate([]*net.IPNet{prefix}, false, 65000, false, net.ParseIP("10.67.50.3")))
+
+	assert.Equal(t, []byte{
+		0, 5, 32, 147, 75, 100, 10,
+		0, 0,
+	}, encodeUpdate([]*net.IPNet{prefix}, true, 0, false, nil))
+}
+
+//nolint:gocyclo
+func TestSpeaker(t *testing.T) {
+	l, err := net.Listen("tcp4", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	defer l.Close() //nolint:errcheck
+
+	_, prefix, err := net.ParseCIDR("147.75.100.10/32")
+	require.NoError(t, err)
+
+	speaker := &Speaker{
+		LocalAS:      65000,
+		PeerAS:       65530,
+		LocalAddress: net.ParseIP("127.0.0.1"),
+		PeerAddress:  net.ParseIP("127.0.0.1"),
+		Port:         l.Addr().(*net.TCPAddr).Port,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	errCh := make(chan error, 1)
+
+	go func() {
+		errCh <- speaker.Run(ctx, []*net.IPNet{prefix})
+	}()
+
+	conn, err := l.Accept()
+	require.NoError(t, err)
+
+	defer conn.Close() //nolint:errcheck
+
+	require.NoError(t, conn.SetDeadline(time.Now().Add(10*time.Second)))
+
+	msg, err := readMessage(conn)
+	require.NoError(t, err)
+	require.EqualValues(t, msgOpen, msg.typ)
+
+	open, err := decodeOpen(msg.body)
+	require.NoError(t, err)
+
+	assert.EqualValues(t, 65000, open.as)
+	assert.EqualValues(t, 90, open.holdTime)
+
+	require.NoError(t, writeMessage(conn, msgOpen, encodeOpen(65530, 30, net.ParseIP("169.254.255.1"))))
+	require.NoError(t, writeMessage(conn, msgKeepalive, nil))
+
+	msg, err = readMessage(conn)
+	require.NoError(t, err)
+	require.EqualValues(t, msgKeepalive, msg.typ)
+
+	msg, err = readMessage(conn)
+	require.NoError(t, err)
+	require.EqualValues(t, msgUpdate, msg.typ)
+
+	// both sides support 4-octet AS numbers
+	assert.Equal(t, encodeUpdate([]*net.IPNet{prefix}, false, 65000, true, net.ParseIP("127.0.0.1")), msg.body)
+
+	cancel()
+
+	msg, err = readMessage(conn)
+	require.NoError(t, err)
+	require.EqualValues(t, msgUpdate, msg.typ)
+
+	assert.Equal(t, encodeUpdate([]*net.IPNet{prefix}, true, 0, false, nil), msg.body)
+
+	msg, err = readMessage(conn)
+	require.NoError(t, err)
+	require.EqualValues(t, msgNotification, msg.typ)
+
+	assert.Equal(t, []byte{errCease, errCeaseAdmin}, msg.body)
+
+	require.NoError(t, <-errCh)
+}
+
+func TestSpeakerNotification(t *testing.T) {
+	l, err := net.Listen("tcp4", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	defer l.Close() //nolint:errcheck
+
+	speaker := &Speaker{
+		LocalAS:      65000,
+		PeerAS:       65530,
+		LocalAddress: net.ParseIP("127.0.0.1"),
+		PeerAddress:  net.ParseIP("127.0.0.1"),
+		Port:         l.Addr().(*net.TCPAddr).Port,
+	}
+
+	errCh := make(chan error, 1)
+
+	go func() {
+		errCh <- speaker.Run(context.Background(), nil)
+	}()
+
+	conn, err := l.Accept()
+	require.NoError(t, err)
+
+	defer conn.Close() //nolint:errcheck
+
+	_, err = readMessage(conn)
+	require.NoError(t, err)
+
+	// bad peer AS
+	require.NoError(t, writeMessage(conn, msgNotification, []byte{2, 2}))
+
+	assert.EqualError(t, <-errCh, "peer sent notification: code 2, subcode 2")
+}
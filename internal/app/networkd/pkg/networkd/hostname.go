@@ -0,0 +1,93 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package networkd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime/v1alpha1/platform"
+	"github.com/talos-systems/talos/internal/pkg/identity"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
+)
+
+// systemUUID is overridden in the tests.
+var systemUUID = identity.SystemUUID
+
+// hostnameVariables are the values of the hostname template variables.
+type hostnameVariables struct {
+	uuid      string
+	uuidShort string
+	ip        string
+}
+
+func newHostnameVariables(systemUUID string, address net.IP) hostnameVariables {
+	vars := hostnameVariables{
+		uuid: systemUUID,
+		ip:   strings.NewReplacer(".", "-", ":", "-").Replace(address.String()),
+	}
+
+	if systemUUID != "" {
+		sum := sha256.Sum256([]byte(systemUUID))
+
+		vars.uuidShort = hex.EncodeToString(sum[:])[:8]
+	}
+
+	return vars
+}
+
+// expand the hostname template.
+func (vars hostnameVariables) expand(template string) (string, error) {
+	var err error
+
+	hostname := os.Expand(template, func(variable string) string {
+		var value string
+
+		switch variable {
+		case v1alpha1.HostnameVariableUUID:
+			value = vars.uuid
+		case v1alpha1.HostnameVariableUUIDShort:
+			value = vars.uuidShort
+		case v1alpha1.HostnameVariableIP:
+			value = vars.ip
+		default:
+			err = fmt.Errorf("unknown hostname template variable %q", variable)
+
+			return ""
+		}
+
+		if value == "" {
+			err = fmt.Errorf("hostname template variable %q is not available", variable)
+		}
+
+		return value
+	})
+
+	return hostname, err
+}
+
+// platformHostname returns the hostname from the platform metadata, if available.
+func platformHostname() string {
+	ctx, ctxCancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer ctxCancel()
+
+	p, err := platform.CurrentPlatform()
+	if err != nil {
+		return ""
+	}
+
+	hostname, err := p.Hostname(ctx)
+	if err != nil {
+		return ""
+	}
+
+	return string(hostname)
+}
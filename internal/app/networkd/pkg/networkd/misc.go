@@ -6,7 +6,6 @@ package networkd
 
 import (
 	"bytes"
-	"fmt"
 	"io/ioutil"
 	"log"
 	"net"
@@ -14,12 +13,52 @@ import (
 	"text/template"
 
 	"github.com/jsimonetti/rtnetlink"
-	talosnet "github.com/talos-systems/net"
 	"golang.org/x/sys/unix"
 
+	"github.com/talos-systems/talos/internal/pkg/linkselector"
+	"github.com/talos-systems/talos/internal/pkg/resolvconf"
 	"github.com/talos-systems/talos/pkg/machinery/config"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
 )
 
+// selectDevices replaces device selectors with the names of the matching links.
+//
+// Devices with selectors which don't match any link are skipped.
+func selectDevices(logger *log.Logger, devices []config.Device) ([]config.Device, error) {
+	var links []linkselector.Link
+
+	selected := make([]config.Device, 0, len(devices))
+
+	for idx, device := range devices {
+		if device.Selector() == nil {
+			selected = append(selected, device)
+
+			continue
+		}
+
+		if links == nil {
+			var err error
+
+			if links, err = linkselector.Discover(); err != nil {
+				return selected, err
+			}
+		}
+
+		linkName, err := linkselector.Resolve(device.Selector(), links)
+		if err != nil {
+			logger.Printf("skipping interface %d: %s", idx, err)
+
+			continue
+		}
+
+		logger.Printf("device selector for interface %d picked link %q", idx, linkName)
+
+		selected = append(selected, linkselector.WithLinkName(device, linkName))
+	}
+
+	return selected, nil
+}
+
 // filterInterfaces filters network links by name so we only mange links
 // we need to.
 //
@@ -71,34 +110,16 @@ func filterInterfaces(logger *log.Logger, interfaces []net.Interface) (filtered
 	return filtered, nil
 }
 
-// writeResolvConf generates a /etc/resolv.conf with the specified nameservers.
-func writeResolvConf(logger *log.Logger, resolvers []string) (err error) {
-	var resolvconf strings.Builder
-
-	for idx, resolver := range resolvers {
-		// Only allow the first 3 nameservers since that is all that will be used
-		if idx >= 3 {
-			break
-		}
-
-		if _, err = resolvconf.WriteString(fmt.Sprintf("nameserver %s\n", resolver)); err != nil {
-			logger.Println("failed to add some resolver to resolvconf:", resolver)
-
-			return err
-		}
-	}
+// writeResolvConf generates a /etc/resolv.conf with the specified nameservers and search domains,
+// and the resolv.conf for the kubelet.
+func writeResolvConf(logger *log.Logger, resolvers, searchDomains []string) (err error) {
+	logger.Println("writing resolvconf")
 
-	if domain, err := talosnet.DomainName(); err == nil {
-		if domain != "" {
-			if _, err = resolvconf.WriteString(fmt.Sprintf("search %s\n", domain)); err != nil {
-				return fmt.Errorf("failed to add domain search line to resolvconf: %s", err)
-			}
-		}
+	if err = ioutil.WriteFile("/etc/resolv.conf", resolvconf.Render(resolvers, searchDomains), 0o644); err != nil {
+		return err
 	}
 
-	logger.Println("writing resolvconf")
-
-	return ioutil.WriteFile("/etc/resolv.conf", []byte(resolvconf.String()), 0o644)
+	return ioutil.WriteFile(constants.KubeletResolvConfPath, resolvconf.RenderKubelet(resolvers, searchDomains), 0o644)
 }
 
 const hostsTemplate = `
@@ -8,9 +8,11 @@ import (
 	"fmt"
 	"log"
 	"net"
+	"path/filepath"
 	"strings"
 
 	"github.com/talos-systems/go-procfs/procfs"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
 
 	"github.com/talos-systems/talos/internal/app/networkd/pkg/address"
 	"github.com/talos-systems/talos/internal/app/networkd/pkg/nic"
@@ -19,6 +21,17 @@ import (
 	"github.com/talos-systems/talos/pkg/machinery/constants"
 )
 
+// generatedKeyWireguardConfig overrides the private key of the Wireguard config with the key generated on the node.
+type generatedKeyWireguardConfig struct {
+	config.WireguardConfig
+
+	privateKey string
+}
+
+func (c generatedKeyWireguardConfig) PrivateKey() string {
+	return c.privateKey
+}
+
 // buildOptions translates the supplied config to nic.Option used for
 // configuring the interface.
 //nolint:gocyclo,cyclop
@@ -33,6 +46,11 @@ func buildOptions(logger *log.Logger, device config.Device, hostname string) (na
 
 	// Configure Addressing
 	switch {
+	case device.MacvtapParent():
+		// macvtap interfaces of the VMs are attached to the link, so it's only brought up
+		logger.Printf("no addressing for macvtap parent device %s", device.Interface())
+
+		opts = append(opts, nic.WithNoAddressing())
 	case device.CIDR() != "":
 		s := &address.Static{CIDR: device.CIDR(), RouteList: device.Routes(), Mtu: device.MTU()}
 
@@ -41,20 +59,20 @@ func buildOptions(logger *log.Logger, device config.Device, hostname string) (na
 		ip := s.Address().IP.String()
 		s.FQDN = fmt.Sprintf("%s-%s", "talos", strings.ReplaceAll(ip, ".", "-"))
 
-		if hostname != "" {
+		// hostname templates are expanded when the hostname is decided
+		if hostname != "" && !strings.Contains(hostname, "$") {
 			s.FQDN = hostname
 		}
 
 		opts = append(opts, nic.WithAddressing(s))
 	case device.DHCP():
+		// DHCPv6 and SLAAC are handled by the network controllers
 		if device.DHCPOptions().IPv4() {
 			d := &address.DHCP4{DHCPOptions: device.DHCPOptions(), RouteList: device.Routes(), Mtu: device.MTU()}
 			opts = append(opts, nic.WithAddressing(d))
-		}
-
-		if device.DHCPOptions().IPv6() {
-			d := &address.DHCP6{Mtu: device.MTU()}
-			opts = append(opts, nic.WithAddressing(d))
+		} else {
+			s := &address.Static{RouteList: device.Routes(), Mtu: device.MTU()}
+			opts = append(opts, nic.WithAddressing(s))
 		}
 	default:
 		// Allow master interface without any addressing if VLANs exist
@@ -73,13 +91,25 @@ func buildOptions(logger *log.Logger, device config.Device, hostname string) (na
 	// Configure Vlan interfaces
 	for _, vlan := range device.Vlans() {
 		opts = append(opts, nic.WithVlan(vlan.ID()))
-		if vlan.CIDR() != "" {
-			opts = append(opts, nic.WithVlanCIDR(vlan.ID(), vlan.CIDR(), vlan.Routes()))
+
+		for i, cidr := range vlan.Addresses() {
+			// routes are installed once, along with the first address
+			var routes []config.Route
+
+			if i == 0 {
+				routes = vlan.Routes()
+			}
+
+			opts = append(opts, nic.WithVlanCIDR(vlan.ID(), cidr, routes))
 		}
 
 		if vlan.DHCP() {
 			opts = append(opts, nic.WithVlanDhcp(vlan.ID()))
 		}
+
+		if vlan.VIPConfig() != nil {
+			opts = append(opts, nic.WithVlanVIPConfig(vlan.ID(), vlan.VIPConfig()))
+		}
 	}
 
 	// Handle dummy interface
@@ -87,14 +117,39 @@ func buildOptions(logger *log.Logger, device config.Device, hostname string) (na
 		opts = append(opts, nic.WithDummy())
 	}
 
-	if device.WireguardConfig() != nil {
-		opts = append(opts, nic.WithWireguardConfig(device.WireguardConfig()))
+	if wgConfig := device.WireguardConfig(); wgConfig != nil {
+		if wgConfig.GeneratePrivateKey() {
+			var key wgtypes.Key
+
+			key, err = nic.LoadOrGenerateWireguardKey(filepath.Join(constants.WireguardKeysPath, device.Interface()+".key"))
+			if err != nil {
+				return device.Interface(), opts, fmt.Errorf("error loading Wireguard private key for %s: %w", device.Interface(), err)
+			}
+
+			logger.Printf("wireguard interface %s public key %s", device.Interface(), key.PublicKey())
+
+			wgConfig = generatedKeyWireguardConfig{
+				WireguardConfig: wgConfig,
+				privateKey:      key.String(),
+			}
+		}
+
+		opts = append(opts, nic.WithWireguardConfig(wgConfig))
 	}
 
 	if device.VIPConfig() != nil {
 		opts = append(opts, nic.WithVIPConfig(device.VIPConfig()))
 	}
 
+	// Configure Bridge
+	if device.Bridge() != nil {
+		if len(device.Bridge().Interfaces()) == 0 {
+			return device.Interface(), opts, fmt.Errorf("invalid bridge configuration for %s: must supply interfaces for bridged interface", device.Interface())
+		}
+
+		opts = append(opts, nic.WithBridge(), nic.WithSubInterface(device.Bridge().Interfaces()...))
+	}
+
 	// Configure Bonding
 	if device.Bond() == nil {
 		return device.Interface(), opts, err
@@ -72,6 +72,68 @@ func (suite *NetconfSuite) TestKernelNetconfIncomplete() {
 	suite.Assert().Equal(len(addr.Routes()), 1)
 }
 
+func (suite *NetconfSuite) TestVlanNetconf() {
+	device := &v1alpha1.Device{
+		DeviceInterface: "eth0",
+		DeviceVlans: []*v1alpha1.Vlan{
+			{
+				VlanID:        100,
+				VlanCIDR:      "192.168.100.10/24",
+				VlanAddresses: []string{"2001:db8::10/64"},
+				VlanRoutes:    []*v1alpha1.Route{{RouteNetwork: "10.0.0.0/8", RouteGateway: "192.168.100.1"}},
+			},
+			{
+				VlanID:   200,
+				VlanDHCP: true,
+				VlanVIP: &v1alpha1.DeviceVIPConfig{
+					SharedIP: "192.168.200.100",
+				},
+			},
+		},
+	}
+
+	_, opts, err := buildOptions(log.New(os.Stderr, "", log.LstdFlags), device, "")
+	suite.Require().NoError(err)
+
+	iface, err := nic.New(opts...)
+	suite.Require().NoError(err)
+
+	suite.Require().Len(iface.Vlans, 2)
+
+	vlan := iface.Vlans[0]
+	suite.Assert().EqualValues(100, vlan.ID)
+	suite.Require().Len(vlan.AddressMethod, 2)
+	suite.Assert().Equal(net.ParseIP("192.168.100.10"), vlan.AddressMethod[0].Address().IP)
+	suite.Assert().Equal(net.ParseIP("2001:db8::10"), vlan.AddressMethod[1].Address().IP)
+	suite.Assert().Len(vlan.AddressMethod[0].Routes(), 1)
+	suite.Assert().Len(vlan.AddressMethod[1].Routes(), 0)
+
+	vlan = iface.Vlans[1]
+	suite.Assert().EqualValues(200, vlan.ID)
+	suite.Require().Len(vlan.AddressMethod, 1)
+	suite.Assert().Equal("dhcp4", vlan.AddressMethod[0].Name())
+	suite.Assert().Equal(net.ParseIP("192.168.200.100"), vlan.VirtualIP)
+	suite.Assert().NotNil(vlan.VIPHandler)
+
+	suite.Assert().Nil(iface.Vlans[0].VirtualIP)
+}
+
+func (suite *NetconfSuite) TestMacvtapParentNetconf() {
+	device := &v1alpha1.Device{
+		DeviceInterface:     "eth1",
+		DeviceMacvtapParent: true,
+	}
+
+	_, opts, err := buildOptions(log.New(os.Stderr, "", log.LstdFlags), device, "")
+	suite.Require().NoError(err)
+
+	iface, err := nic.New(opts...)
+	suite.Require().NoError(err)
+
+	suite.Assert().Empty(iface.AddressMethod)
+	suite.Assert().False(iface.IsIgnored())
+}
+
 func sampleConfig() []config.Device {
 	return []config.Device{
 		&v1alpha1.Device{
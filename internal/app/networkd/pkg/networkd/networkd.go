@@ -15,7 +15,6 @@ import (
 	"sort"
 	"strings"
 	"sync"
-	"time"
 
 	multierror "github.com/hashicorp/go-multierror"
 	"github.com/talos-systems/go-procfs/procfs"
@@ -26,7 +25,9 @@ import (
 	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime/v1alpha1/platform"
 	"github.com/talos-systems/talos/internal/app/networkd/pkg/address"
 	"github.com/talos-systems/talos/internal/app/networkd/pkg/nic"
+	"github.com/talos-systems/talos/internal/pkg/resolvconf"
 	"github.com/talos-systems/talos/pkg/machinery/config"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
 	"github.com/talos-systems/talos/pkg/machinery/constants"
 )
 
@@ -43,8 +44,12 @@ type Networkd struct {
 	Interfaces map[string]*nic.NetworkInterface
 	Config     config.Provider
 
-	hostname  string
-	resolvers []string
+	hostname        string
+	hostnameSources []string
+	resolvers       []string
+
+	searchDomains       []string
+	disableSearchDomain bool
 
 	sync.Mutex
 	ready bool
@@ -57,10 +62,14 @@ type Networkd struct {
 //nolint:gocyclo,cyclop
 func New(logger *log.Logger, config config.Provider) (*Networkd, error) {
 	var (
-		hostname  string
-		option    *string
-		result    *multierror.Error
-		resolvers []string
+		hostname        string
+		hostnameSources = v1alpha1.DefaultHostnameSources
+		option          *string
+		result          *multierror.Error
+		resolvers       []string
+
+		searchDomains       []string
+		disableSearchDomain bool
 	)
 
 	netconf := make(map[string][]nic.Option)
@@ -75,7 +84,12 @@ func New(logger *log.Logger, config config.Provider) (*Networkd, error) {
 	if config != nil {
 		logger.Println("parsing configuration file")
 
-		for _, device := range config.Machine().Network().Devices() {
+		devices, err := selectDevices(logger, config.Machine().Network().Devices())
+		if err != nil {
+			result = multierror.Append(result, err)
+		}
+
+		for _, device := range devices {
 			name, opts, err := buildOptions(logger, device, config.Machine().Network().Hostname())
 			if err != nil {
 				result = multierror.Append(result, err)
@@ -91,10 +105,14 @@ func New(logger *log.Logger, config config.Provider) (*Networkd, error) {
 		}
 
 		hostname = config.Machine().Network().Hostname()
+		hostnameSources = config.Machine().Network().HostnameSources()
 
 		if len(config.Machine().Network().Resolvers()) > 0 {
 			resolvers = config.Machine().Network().Resolvers()
 		}
+
+		searchDomains = config.Machine().Network().SearchDomains()
+		disableSearchDomain = config.Machine().Network().DisableSearchDomain()
 	}
 
 	logger.Println("discovering local interfaces")
@@ -162,15 +180,15 @@ func New(logger *log.Logger, config config.Provider) (*Networkd, error) {
 		interfaces[ifname] = netif
 	}
 
-	// Set interfaces that are part of a bond to ignored
+	// Set interfaces that are part of a bond or a bridge to ignored
 	for _, netif := range interfaces {
-		if !netif.Bonded {
+		if !netif.Bonded && !netif.Bridged {
 			continue
 		}
 
 		for _, subif := range netif.SubInterfaces {
 			if _, ok := interfaces[subif.Name]; !ok {
-				result = multierror.Append(result, fmt.Errorf("bond subinterface %s does not exist", subif.Name))
+				result = multierror.Append(result, fmt.Errorf("subinterface %s of %s does not exist", subif.Name, netif.Name))
 
 				continue
 			}
@@ -185,6 +203,11 @@ func New(logger *log.Logger, config config.Provider) (*Networkd, error) {
 		hostname:   hostname,
 		resolvers:  resolvers,
 		logger:     logger,
+
+		hostnameSources: hostnameSources,
+
+		searchDomains:       searchDomains,
+		disableSearchDomain: disableSearchDomain,
 	}, result.ErrorOrNil()
 }
 
@@ -240,7 +263,7 @@ func (n *Networkd) Configure(ctx context.Context) (err error) {
 		return err
 	}
 
-	if err = writeResolvConf(n.logger, resolvers); err != nil {
+	if err = writeResolvConf(n.logger, resolvers, resolvconf.SearchDomains(n.searchDomains, n.disableSearchDomain)); err != nil {
 		return err
 	}
 
@@ -276,14 +299,16 @@ func (n *Networkd) RunControllers(ctx context.Context, eg *errgroup.Group) error
 	return nil
 }
 
-// Hostname returns the first hostname found from the addressing methods.
+// Hostname returns the first hostname found from the hostname sources.
 // Create /etc/hosts and set hostname.
-// Priority is:
+// Default priority is:
 // 1. Config (explicitly defined by the user)
 // 2. Kernel arg
 // 3. Platform
 // 4. DHCP
 // 5. Default with the format: talos-<ip addr>.
+//
+// The order of the sources is configured with .machine.network.hostnameSources.
 func (n *Networkd) Hostname() (err error) {
 	hostname, domainname, address, err := n.decideHostname()
 	if err != nil {
@@ -313,11 +338,12 @@ func (n *Networkd) Hostname() (err error) {
 	return unix.Setdomainname([]byte(domainname))
 }
 
-//nolint:gocyclo
+//nolint:gocyclo,cyclop
 func (n *Networkd) decideHostname() (hostname, domainname string, address net.IP, err error) {
-	// Set hostname to default
+	var methodHostname string
+
+	// Set address to default
 	address = net.ParseIP("127.0.1.1")
-	hostname = fmt.Sprintf("%s-%s", "talos", strings.ReplaceAll(address.String(), ".", "-"))
 
 	// Sort interface names alphabetically so we can ensure parsing order
 	interfaceNames := make([]string, 0, len(n.Interfaces))
@@ -345,7 +371,7 @@ outer:
 			}
 
 			if method.Hostname() != "" {
-				hostname = method.Hostname()
+				methodHostname = method.Hostname()
 
 				address = method.Address().IP
 
@@ -354,29 +380,38 @@ outer:
 		}
 	}
 
-	// Platform
-	var p runtime.Platform
-
-	ctx, ctxCancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer ctxCancel()
+	vars := newHostnameVariables(systemUUID(), address)
 
-	p, err = platform.CurrentPlatform()
-	if err == nil {
-		var pHostname []byte
-
-		if pHostname, err = p.Hostname(ctx); err == nil && string(pHostname) != "" {
-			hostname = string(pHostname)
+sources:
+	for _, source := range n.hostnameSources {
+		switch source {
+		case v1alpha1.HostnameSourceConfig:
+			if n.hostname != "" {
+				if hostname, err = vars.expand(n.hostname); err != nil {
+					return "", "", net.IP{}, err
+				}
+			}
+		case v1alpha1.HostnameSourceKernel:
+			if kernelHostname := procfs.ProcCmdline().Get(constants.KernelParamHostname).First(); kernelHostname != nil {
+				hostname = *kernelHostname
+			}
+		case v1alpha1.HostnameSourcePlatform:
+			hostname = platformHostname()
+		case v1alpha1.HostnameSourceDHCP:
+			hostname = methodHostname
+		case v1alpha1.HostnameSourceStable:
+			if vars.uuidShort != "" {
+				hostname = "talos-" + vars.uuidShort
+			}
 		}
-	}
 
-	// Kernel
-	if kernelHostname := procfs.ProcCmdline().Get(constants.KernelParamHostname).First(); kernelHostname != nil {
-		hostname = *kernelHostname
+		if hostname != "" {
+			break sources
+		}
 	}
 
-	// Allow user supplied hostname to win
-	if n.hostname != "" {
-		hostname = n.hostname
+	if hostname == "" {
+		hostname = fmt.Sprintf("%s-%s", "talos", strings.ReplaceAll(address.String(), ".", "-"))
 	}
 
 	hostParts := strings.Split(hostname, ".")
@@ -420,7 +455,8 @@ func (n *Networkd) configureLinks(ctx context.Context, bonded bool) error {
 	count := 0
 
 	for _, iface := range n.Interfaces {
-		if iface.Bonded != bonded {
+		// bridges are configured along with bonds, as both require the member links to exist
+		if (iface.Bonded || iface.Bridged) != bonded {
 			continue
 		}
 
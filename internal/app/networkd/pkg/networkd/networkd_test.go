@@ -167,6 +167,101 @@ func (suite *NetworkdSuite) TestHostname() {
 	suite.Assert().Equal(addr, net.ParseIP("192.168.0.11"))
 }
 
+func (suite *NetworkdSuite) TestHostnameSources() {
+	defer func(f func() string) { systemUUID = f }(systemUUID)
+
+	systemUUID = func() string { return "4c4c4544-0048-4b10-8050-b4c04f4e4d32" }
+
+	dhcpMethod := []address.Addressing{
+		&address.DHCP4{
+			Ack: &dhcpv4.DHCPv4{
+				YourIPAddr: net.ParseIP("192.168.0.11"),
+				Options: dhcpv4.Options{
+					uint8(dhcpv4.OptionHostName):   []byte("dhcphostname"),
+					uint8(dhcpv4.OptionSubnetMask): []byte{255, 255, 255, 0},
+				},
+			},
+		},
+	}
+
+	for _, tt := range []struct {
+		name     string
+		hostname string
+		sources  []string
+		expected string
+	}{
+		{
+			name:     "default",
+			hostname: "confighostname",
+			expected: "confighostname",
+		},
+		{
+			name:     "default no config",
+			expected: "dhcphostname",
+		},
+		{
+			name:     "dhcp first",
+			hostname: "confighostname",
+			sources:  []string{v1alpha1.HostnameSourceDHCP, v1alpha1.HostnameSourceConfig},
+			expected: "dhcphostname",
+		},
+		{
+			name:     "platform not available",
+			hostname: "confighostname",
+			sources:  []string{v1alpha1.HostnameSourcePlatform, v1alpha1.HostnameSourceConfig},
+			expected: "confighostname",
+		},
+		{
+			name:     "config template",
+			hostname: "node-${ip}-${uuid-short}",
+			sources:  []string{v1alpha1.HostnameSourceConfig, v1alpha1.HostnameSourceDHCP},
+			expected: "node-192-168-0-11-38a1c1d5",
+		},
+		{
+			name:     "stable",
+			sources:  []string{v1alpha1.HostnameSourceConfig, v1alpha1.HostnameSourceStable, v1alpha1.HostnameSourceDHCP},
+			expected: "talos-38a1c1d5",
+		},
+		{
+			name:     "no sources",
+			hostname: "confighostname",
+			sources:  []string{v1alpha1.HostnameSourceKernel},
+			expected: "talos-192-168-0-11",
+		},
+	} {
+		tt := tt
+
+		suite.Run(tt.name, func() {
+			cfg := dhcpConfigFile()
+			cfg.(*v1alpha1.Config).MachineConfig.MachineNetwork.NetworkHostname = tt.hostname
+			cfg.(*v1alpha1.Config).MachineConfig.MachineNetwork.NetworkHostnameSources = tt.sources
+
+			nwd, err := New(log.New(os.Stderr, "", log.LstdFlags), cfg)
+			suite.Require().NoError(err)
+
+			nwd.Interfaces["eth0"].AddressMethod = dhcpMethod
+
+			hostname, _, _, err := nwd.decideHostname()
+			suite.Require().NoError(err)
+			suite.Assert().Equal(tt.expected, hostname)
+		})
+	}
+}
+
+func (suite *NetworkdSuite) TestHostnameTemplate() {
+	vars := newHostnameVariables("", net.ParseIP("2001:db8::1"))
+
+	hostname, err := vars.expand("node-${ip}")
+	suite.Require().NoError(err)
+	suite.Assert().Equal("node-2001-db8--1", hostname)
+
+	_, err = vars.expand("talos-${uuid-short}")
+	suite.Require().EqualError(err, `hostname template variable "uuid-short" is not available`)
+
+	_, err = vars.expand("talos-${serial}")
+	suite.Require().EqualError(err, `unknown hostname template variable "serial"`)
+}
+
 func sampleConfigFile() config.Provider {
 	return &v1alpha1.Config{
 		MachineConfig: &v1alpha1.MachineConfig{
@@ -49,6 +49,7 @@ type NetworkInterface struct {
 	Ignore          bool
 	Dummy           bool
 	Bonded          bool
+	Bridged         bool
 	Wireguard       bool
 	MTU             uint32
 	Link            *net.Interface
@@ -57,12 +58,13 @@ type NetworkInterface struct {
 	BondSettings    *netlink.AttributeEncoder
 	Vlans           []*Vlan
 	VirtualIP       net.IP
+	VIPHandler      vip.Handler
 	WireguardConfig *wgtypes.Config
 
 	rtConn   *rtnetlink.Conn
 	rtnlConn *rtnl.Conn
 
-	vipController vip.Controller
+	vipControllers []vip.Controller
 }
 
 // New returns a NetworkInterface with all of the given setter options applied.
@@ -134,6 +136,8 @@ func (n *NetworkInterface) Create() error {
 	switch {
 	case n.Bonded:
 		info = &rtnetlink.LinkInfo{Kind: "bond"}
+	case n.Bridged:
+		info = &rtnetlink.LinkInfo{Kind: "bridge"}
 	case n.Dummy:
 		info = &rtnetlink.LinkInfo{Kind: "dummy"}
 	case n.Wireguard:
@@ -204,7 +208,7 @@ func (n *NetworkInterface) CreateSub(logger *log.Logger) error {
 }
 
 // Configure is used to set the link state and configure any necessary
-// bond settings ( ex, mode ).
+// bond settings ( ex, mode ) and bridge ports.
 //nolint:gocyclo
 func (n *NetworkInterface) Configure(ctx context.Context) (err error) {
 	if n.IsIgnored() {
@@ -224,6 +228,21 @@ func (n *NetworkInterface) Configure(ctx context.Context) (err error) {
 		}
 	}
 
+	if n.Bridged {
+		bridgeIndex := proto.Uint32(uint32(n.Link.Index))
+
+		if err = n.enslaveLink(bridgeIndex, n.SubInterfaces...); err != nil {
+			return err
+		}
+
+		// unlike bond slaves, bridge ports are not brought up by the kernel
+		for _, subif := range n.SubInterfaces {
+			if err = n.rtnlConn.LinkUp(subif); err != nil {
+				return err
+			}
+		}
+	}
+
 	if n.Wireguard {
 		if err = n.configureWireguard(n.Name, n.WireguardConfig); err != nil {
 			return err
@@ -255,18 +274,39 @@ func (n *NetworkInterface) Configure(ctx context.Context) (err error) {
 // RunControllers is used to run additional controllers per interface.
 func (n *NetworkInterface) RunControllers(ctx context.Context, logger *log.Logger, eg *errgroup.Group) (err error) {
 	if n.VirtualIP != nil {
-		if n.vipController, err = vip.New(n.VirtualIP.String(), n.Link.Name); err != nil {
-			return fmt.Errorf("failed to create the VirtualIP controller for %q on %q: %w", n.VirtualIP, n.Link.Name, err)
+		if err = n.startVIPController(ctx, logger, eg, n.VirtualIP, n.VIPHandler, n.Link.Name); err != nil {
+			return err
+		}
+	}
+
+	for _, vlan := range n.Vlans {
+		if vlan.VirtualIP == nil {
+			continue
 		}
 
-		if err = n.vipController.Start(ctx, logger, eg); err != nil {
-			return fmt.Errorf("failed to start the VirtualIP controller for %q on %q: %w", n.VirtualIP, n.Link.Name, err)
+		if err = n.startVIPController(ctx, logger, eg, vlan.VirtualIP, vlan.VIPHandler, vlan.Link.Name); err != nil {
+			return err
 		}
 	}
 
 	return nil
 }
 
+func (n *NetworkInterface) startVIPController(ctx context.Context, logger *log.Logger, eg *errgroup.Group, ip net.IP, handler vip.Handler, linkName string) error {
+	vipController, err := vip.New(ip.String(), linkName, handler)
+	if err != nil {
+		return fmt.Errorf("failed to create the VirtualIP controller for %q on %q: %w", ip, linkName, err)
+	}
+
+	if err = vipController.Start(ctx, logger, eg); err != nil {
+		return fmt.Errorf("failed to start the VirtualIP controller for %q on %q: %w", ip, linkName, err)
+	}
+
+	n.vipControllers = append(n.vipControllers, vipController)
+
+	return nil
+}
+
 func (n *NetworkInterface) waitForLinkToBeUp(linkDev *net.Interface) error {
 	// Wait for link to report up
 	var link rtnetlink.LinkMessage
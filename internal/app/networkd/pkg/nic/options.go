@@ -32,6 +32,16 @@ func WithDummy() Option {
 	}
 }
 
+// WithBridge indicates that the interface should be a bridge, interfaces
+// set with WithSubInterface are attached to the bridge as ports.
+func WithBridge() Option {
+	return func(n *NetworkInterface) (err error) {
+		n.Bridged = true
+
+		return
+	}
+}
+
 // WithIgnore indicates that the interface should not be processed by talos.
 func WithIgnore() Option {
 	return func(n *NetworkInterface) (err error) {
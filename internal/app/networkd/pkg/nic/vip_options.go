@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"net"
 
+	"github.com/talos-systems/talos/internal/app/networkd/pkg/vip"
 	"github.com/talos-systems/talos/pkg/machinery/config"
 )
 
@@ -23,7 +24,29 @@ func WithVIPConfig(cfg config.VIPConfig) Option {
 		}
 
 		n.VirtualIP = sharedIP
+		n.VIPHandler = vip.NewHandler(cfg.IP(), cfg)
 
 		return nil
 	}
 }
+
+// WithVlanVIPConfig adapts a talosconfig VIP configuration to a VLAN interface configuration option.
+func WithVlanVIPConfig(id uint16, cfg config.VIPConfig) Option {
+	return func(n *NetworkInterface) (err error) {
+		sharedIP := net.ParseIP(cfg.IP())
+		if sharedIP == nil {
+			return fmt.Errorf("failed to parse shared IP %q as an IP address", cfg.IP())
+		}
+
+		for _, vlan := range n.Vlans {
+			if vlan.ID == id {
+				vlan.VirtualIP = sharedIP
+				vlan.VIPHandler = vip.NewHandler(cfg.IP(), cfg)
+
+				return nil
+			}
+		}
+
+		return fmt.Errorf("VLAN id not found for VIP setting %v given", id)
+	}
+}
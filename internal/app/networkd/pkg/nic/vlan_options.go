@@ -11,6 +11,7 @@ import (
 	"github.com/mdlayher/netlink"
 
 	"github.com/talos-systems/talos/internal/app/networkd/pkg/address"
+	"github.com/talos-systems/talos/internal/app/networkd/pkg/vip"
 	"github.com/talos-systems/talos/pkg/machinery/config"
 )
 
@@ -21,6 +22,8 @@ type Vlan struct {
 	Link          *net.Interface
 	VlanSettings  *netlink.AttributeEncoder
 	AddressMethod []address.Addressing
+	VirtualIP     net.IP
+	VIPHandler    vip.Handler
 }
 
 // WithVlan defines the VLAN id to use.
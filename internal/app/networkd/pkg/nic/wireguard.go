@@ -8,7 +8,12 @@
 package nic
 
 import (
+	"errors"
+	"io/ioutil"
 	"net"
+	"os"
+	"path/filepath"
+	"strings"
 
 	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
 
@@ -85,3 +90,26 @@ func WithWireguardConfig(cfg config.WireguardConfig) Option {
 		return nil
 	}
 }
+
+// LoadOrGenerateWireguardKey loads the Wireguard private key from the file, generating and saving a new key if the file doesn't exist.
+func LoadOrGenerateWireguardKey(path string) (wgtypes.Key, error) {
+	contents, err := ioutil.ReadFile(path)
+	if err == nil {
+		return wgtypes.ParseKey(strings.TrimSpace(string(contents)))
+	}
+
+	if !errors.Is(err, os.ErrNotExist) {
+		return wgtypes.Key{}, err
+	}
+
+	key, err := wgtypes.GeneratePrivateKey()
+	if err != nil {
+		return wgtypes.Key{}, err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return wgtypes.Key{}, err
+	}
+
+	return key, ioutil.WriteFile(path, []byte(key.String()+"\n"), 0o600)
+}
@@ -0,0 +1,176 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package vip
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const (
+	equinixMetalAPIEndpoint      = "https://api.equinix.com/metal/v1"
+	equinixMetalMetadataEndpoint = "https://metadata.platformequinix.com/metadata"
+)
+
+// EquinixMetalHandler assigns the shared IP (Equinix Metal elastic IP) to the device via the Equinix Metal API.
+type EquinixMetalHandler struct {
+	ip       string
+	apiToken string
+
+	apiEndpoint      string
+	metadataEndpoint string
+
+	deviceID string
+}
+
+// NewEquinixMetalHandler creates new EquinixMetalHandler.
+func NewEquinixMetalHandler(ip, apiToken string) *EquinixMetalHandler {
+	return &EquinixMetalHandler{
+		ip:               ip,
+		apiToken:         apiToken,
+		apiEndpoint:      equinixMetalAPIEndpoint,
+		metadataEndpoint: equinixMetalMetadataEndpoint,
+	}
+}
+
+type equinixMetalHref struct {
+	Href string `json:"href"`
+}
+
+type equinixMetalIPAssignment struct {
+	ID         string           `json:"id"`
+	AssignedTo equinixMetalHref `json:"assigned_to"`
+}
+
+type equinixMetalIPReservation struct {
+	ID          string                     `json:"id"`
+	Address     string                     `json:"address"`
+	Assignments []equinixMetalIPAssignment `json:"assignments"`
+}
+
+// Acquire implements Handler interface.
+//
+// Acquire removes the elastic IP assignments to other devices and assigns the IP to this device.
+func (handler *EquinixMetalHandler) Acquire(ctx context.Context) error {
+	if err := handler.discoverDeviceID(ctx); err != nil {
+		return err
+	}
+
+	reservation, err := handler.findReservation(ctx)
+	if err != nil {
+		return err
+	}
+
+	assigned := false
+
+	for _, assignment := range reservation.Assignments {
+		if handler.assignedToThisDevice(assignment) {
+			assigned = true
+
+			continue
+		}
+
+		if err = handler.call(ctx, http.MethodDelete, "/ips/"+assignment.ID, nil, nil); err != nil {
+			return fmt.Errorf("error removing elastic IP %q assignment to %q: %w", handler.ip, assignment.AssignedTo.Href, err)
+		}
+	}
+
+	if assigned {
+		return nil
+	}
+
+	if err = handler.call(ctx, http.MethodPost, "/devices/"+handler.deviceID+"/ips", map[string]string{"address": handler.ip}, nil); err != nil {
+		return fmt.Errorf("error assigning elastic IP %q to device %q: %w", handler.ip, handler.deviceID, err)
+	}
+
+	return nil
+}
+
+// Release implements Handler interface.
+//
+// Release removes the elastic IP assignment to this device, so that the IP doesn't stay attached to a failed leader.
+func (handler *EquinixMetalHandler) Release(ctx context.Context) error {
+	if handler.deviceID == "" {
+		// IP was never acquired
+		return nil
+	}
+
+	reservation, err := handler.findReservation(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, assignment := range reservation.Assignments {
+		if !handler.assignedToThisDevice(assignment) {
+			continue
+		}
+
+		if err = handler.call(ctx, http.MethodDelete, "/ips/"+assignment.ID, nil, nil); err != nil {
+			return fmt.Errorf("error removing elastic IP %q assignment: %w", handler.ip, err)
+		}
+	}
+
+	return nil
+}
+
+func (handler *EquinixMetalHandler) discoverDeviceID(ctx context.Context) error {
+	if handler.deviceID != "" {
+		return nil
+	}
+
+	var metadata struct {
+		ID string `json:"id"`
+	}
+
+	if err := apiCall(ctx, http.MethodGet, handler.metadataEndpoint, nil, nil, &metadata); err != nil {
+		return fmt.Errorf("error fetching device metadata: %w", err)
+	}
+
+	if metadata.ID == "" {
+		return fmt.Errorf("device ID is missing in the metadata")
+	}
+
+	handler.deviceID = metadata.ID
+
+	return nil
+}
+
+func (handler *EquinixMetalHandler) findReservation(ctx context.Context) (*equinixMetalIPReservation, error) {
+	var device struct {
+		Project struct {
+			ID string `json:"id"`
+		} `json:"project"`
+	}
+
+	if err := handler.call(ctx, http.MethodGet, "/devices/"+handler.deviceID+"?include=project", nil, &device); err != nil {
+		return nil, fmt.Errorf("error fetching device %q: %w", handler.deviceID, err)
+	}
+
+	var reservations struct {
+		IPAddresses []equinixMetalIPReservation `json:"ip_addresses"`
+	}
+
+	if err := handler.call(ctx, http.MethodGet, "/projects/"+device.Project.ID+"/ips?include=assignments", nil, &reservations); err != nil {
+		return nil, fmt.Errorf("error listing project IPs: %w", err)
+	}
+
+	for i := range reservations.IPAddresses {
+		if reservations.IPAddresses[i].Address == handler.ip {
+			return &reservations.IPAddresses[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("elastic IP %q is not reserved in project %q", handler.ip, device.Project.ID)
+}
+
+func (handler *EquinixMetalHandler) assignedToThisDevice(assignment equinixMetalIPAssignment) bool {
+	return strings.HasSuffix(assignment.AssignedTo.Href, "/devices/"+handler.deviceID)
+}
+
+func (handler *EquinixMetalHandler) call(ctx context.Context, method, path string, in, out interface{}) error {
+	return apiCall(ctx, method, handler.apiEndpoint+path, map[string]string{"X-Auth-Token": handler.apiToken}, in, out)
+}
@@ -0,0 +1,186 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package vip
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/talos-systems/talos/internal/app/networkd/pkg/bgp"
+)
+
+const bgpRetryInterval = 5 * time.Second
+
+// EquinixMetalBGPHandler announces the shared IP (Equinix Metal elastic IP) via local BGP sessions.
+//
+// Unlike EquinixMetalHandler it doesn't require the API token: the elastic IP is routed
+// to the node which announces it, BGP peering details are read from the metadata.
+type EquinixMetalBGPHandler struct {
+	ip string
+
+	metadataEndpoint string
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewEquinixMetalBGPHandler creates new EquinixMetalBGPHandler.
+func NewEquinixMetalBGPHandler(ip string) *EquinixMetalBGPHandler {
+	return &EquinixMetalBGPHandler{
+		ip:               ip,
+		metadataEndpoint: equinixMetalMetadataEndpoint,
+	}
+}
+
+type equinixMetalBGPNeighbor struct {
+	AddressFamily int      `json:"address_family"`
+	CustomerAS    uint32   `json:"customer_as"`
+	CustomerIP    string   `json:"customer_ip"`
+	MD5Enabled    bool     `json:"md5_enabled"`
+	MD5Password   string   `json:"md5_password"`
+	Multihop      bool     `json:"multihop"`
+	PeerAS        uint32   `json:"peer_as"`
+	PeerIPs       []string `json:"peer_ips"`
+}
+
+// Acquire implements Handler interface.
+//
+// Acquire starts the BGP sessions with all the IPv4 peers and announces the shared IP.
+func (handler *EquinixMetalBGPHandler) Acquire(ctx context.Context) error {
+	ip := net.ParseIP(handler.ip).To4()
+	if ip == nil {
+		return fmt.Errorf("BGP announcements are supported only for IPv4 addresses, got %q", handler.ip)
+	}
+
+	var metadata struct {
+		BGPNeighbors []equinixMetalBGPNeighbor `json:"bgp_neighbors"`
+	}
+
+	if err := apiCall(ctx, http.MethodGet, handler.metadataEndpoint, nil, nil, &metadata); err != nil {
+		return fmt.Errorf("error fetching device metadata: %w", err)
+	}
+
+	speakers, err := bgpSpeakers(metadata.BGPNeighbors)
+	if err != nil {
+		return err
+	}
+
+	prefixes := []*net.IPNet{
+		{
+			IP:   ip,
+			Mask: net.CIDRMask(32, 32),
+		},
+	}
+
+	sessionCtx, cancel := context.WithCancel(context.Background())
+	handler.cancel = cancel
+
+	for _, speaker := range speakers {
+		speaker := speaker
+
+		handler.wg.Add(1)
+
+		go func() {
+			defer handler.wg.Done()
+
+			announce(sessionCtx, speaker, prefixes)
+		}()
+	}
+
+	return nil
+}
+
+// Release implements Handler interface.
+//
+// Release withdraws the shared IP and closes the BGP sessions.
+func (handler *EquinixMetalBGPHandler) Release(ctx context.Context) error {
+	if handler.cancel == nil {
+		// IP was never acquired
+		return nil
+	}
+
+	handler.cancel()
+	handler.cancel = nil
+
+	done := make(chan struct{})
+
+	go func() {
+		handler.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("error waiting for BGP sessions to be closed: %w", ctx.Err())
+	}
+}
+
+// announce keeps the BGP session up until the context is canceled.
+func announce(ctx context.Context, speaker *bgp.Speaker, prefixes []*net.IPNet) {
+	for {
+		err := speaker.Run(ctx, prefixes)
+		if ctx.Err() != nil {
+			return
+		}
+
+		log.Printf("vip: BGP session with %s failed: %s", speaker.PeerAddress, err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(bgpRetryInterval):
+		}
+	}
+}
+
+// bgpSpeakers builds the BGP speakers for each IPv4 peer from the metadata.
+func bgpSpeakers(neighbors []equinixMetalBGPNeighbor) ([]*bgp.Speaker, error) {
+	var speakers []*bgp.Speaker
+
+	for _, neighbor := range neighbors {
+		if neighbor.AddressFamily != 4 {
+			continue
+		}
+
+		localAddress := net.ParseIP(neighbor.CustomerIP)
+		if localAddress == nil {
+			return nil, fmt.Errorf("failed to parse BGP customer IP %q", neighbor.CustomerIP)
+		}
+
+		for _, peerIP := range neighbor.PeerIPs {
+			peerAddress := net.ParseIP(peerIP)
+			if peerAddress == nil {
+				return nil, fmt.Errorf("failed to parse BGP peer IP %q", peerIP)
+			}
+
+			speaker := &bgp.Speaker{
+				LocalAS:      neighbor.CustomerAS,
+				PeerAS:       neighbor.PeerAS,
+				LocalAddress: localAddress,
+				PeerAddress:  peerAddress,
+				Multihop:     neighbor.Multihop,
+			}
+
+			if neighbor.MD5Enabled {
+				speaker.Password = neighbor.MD5Password
+			}
+
+			speakers = append(speakers, speaker)
+		}
+	}
+
+	if len(speakers) == 0 {
+		return nil, fmt.Errorf("no IPv4 BGP neighbors found in the metadata, is BGP enabled for the device?")
+	}
+
+	return speakers, nil
+}
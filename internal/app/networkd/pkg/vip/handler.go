@@ -0,0 +1,103 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package vip
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/talos-systems/talos/pkg/machinery/config"
+)
+
+// Handler implements platform-specific actions performed when the node acquires or releases the shared IP.
+//
+// Handlers are invoked in addition to the address being assigned to the local interface:
+// on layer 2 networks gratuitous ARP is enough, while cloud providers require the IP to be
+// moved to the node via the provider API.
+type Handler interface {
+	Acquire(ctx context.Context) error
+	Release(ctx context.Context) error
+}
+
+// NewHandler builds the Handler for the VIP config.
+func NewHandler(ip string, cfg config.VIPConfig) Handler {
+	switch {
+	case cfg.EquinixMetal() != nil && cfg.EquinixMetal().BGP():
+		return NewEquinixMetalBGPHandler(ip)
+	case cfg.EquinixMetal() != nil:
+		return NewEquinixMetalHandler(ip, cfg.EquinixMetal().APIToken())
+	case cfg.HCloud() != nil:
+		return NewHCloudHandler(ip, cfg.HCloud().APIToken())
+	default:
+		return nopHandler{}
+	}
+}
+
+// nopHandler is used for layer 2 shared IPs.
+type nopHandler struct{}
+
+func (nopHandler) Acquire(ctx context.Context) error {
+	return nil
+}
+
+func (nopHandler) Release(ctx context.Context) error {
+	return nil
+}
+
+// apiCall performs JSON API request, request body is encoded from `in` (if not nil), response is decoded into `out` (if not nil).
+func apiCall(ctx context.Context, method, url string, headers map[string]string, in, out interface{}) error {
+	var body io.Reader
+
+	if in != nil {
+		encoded, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+
+		body = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close() //nolint:errcheck
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("%s %s: unexpected status code %d: %s", method, url, resp.StatusCode, bytes.TrimSpace(respBody))
+	}
+
+	if out == nil || len(respBody) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(respBody, out)
+}
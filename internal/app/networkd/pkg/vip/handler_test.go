@@ -0,0 +1,167 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package vip
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type apiRecorder struct {
+	mu       sync.Mutex
+	requests []string
+
+	responses map[string]string
+}
+
+func (rec *apiRecorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := ioutil.ReadAll(req.Body) //nolint:errcheck
+
+	key := req.Method + " " + req.URL.RequestURI()
+
+	rec.mu.Lock()
+	rec.requests = append(rec.requests, fmt.Sprintf("%s %s", key, body))
+	rec.mu.Unlock()
+
+	response, ok := rec.responses[key]
+	if !ok {
+		http.NotFound(w, req)
+
+		return
+	}
+
+	fmt.Fprint(w, response) //nolint:errcheck
+}
+
+func TestEquinixMetalHandler(t *testing.T) {
+	rec := &apiRecorder{
+		responses: map[string]string{
+			"GET /metadata":                                   `{"id": "device-2"}`,
+			"GET /devices/device-2?include=project":           `{"project": {"id": "project-1"}}`,
+			"GET /projects/project-1/ips?include=assignments": `{"ip_addresses": [{"id": "ip-0", "address": "147.75.100.9"}, {"id": "ip-1", "address": "147.75.100.10", "assignments": [{"id": "assignment-1", "assigned_to": {"href": "/metal/v1/devices/device-1"}}]}]}`,
+			"DELETE /ips/assignment-1":                        ``,
+			"POST /devices/device-2/ips":                      `{}`,
+		},
+	}
+
+	srv := httptest.NewServer(rec)
+	defer srv.Close()
+
+	handler := NewEquinixMetalHandler("147.75.100.10", "token")
+	handler.apiEndpoint = srv.URL
+	handler.metadataEndpoint = srv.URL + "/metadata"
+
+	require.NoError(t, handler.Acquire(context.Background()))
+
+	assert.Equal(t, []string{
+		"GET /metadata ",
+		"GET /devices/device-2?include=project ",
+		"GET /projects/project-1/ips?include=assignments ",
+		"DELETE /ips/assignment-1 ",
+		`POST /devices/device-2/ips {"address":"147.75.100.10"}`,
+	}, rec.requests)
+
+	// release is a no-op, as the recorded reservation is still assigned to device-1
+	rec.requests = nil
+
+	require.NoError(t, handler.Release(context.Background()))
+
+	assert.Equal(t, []string{
+		"GET /devices/device-2?include=project ",
+		"GET /projects/project-1/ips?include=assignments ",
+	}, rec.requests)
+}
+
+func TestHCloudHandler(t *testing.T) {
+	rec := &apiRecorder{
+		responses: map[string]string{
+			"GET /metadata":                         "42\n",
+			"GET /floating_ips?per_page=50":         `{"floating_ips": [{"id": 1, "ip": "116.203.10.9", "server": null}, {"id": 2, "ip": "2a01:4f8::/64", "server": 42}, {"id": 3, "ip": "116.203.10.10", "server": 41}]}`,
+			"POST /floating_ips/3/actions/assign":   `{}`,
+			"POST /floating_ips/2/actions/unassign": `{}`,
+		},
+	}
+
+	srv := httptest.NewServer(rec)
+	defer srv.Close()
+
+	handler := NewHCloudHandler("116.203.10.10", "token")
+	handler.apiEndpoint = srv.URL
+	handler.metadataEndpoint = srv.URL + "/metadata"
+
+	require.NoError(t, handler.Acquire(context.Background()))
+
+	assert.Equal(t, []string{
+		"GET /metadata ",
+		"GET /floating_ips?per_page=50 ",
+		`POST /floating_ips/3/actions/assign {"server":42}`,
+	}, rec.requests)
+
+	// IPv6 floating IP is a network, already assigned to this server
+	rec.requests = nil
+
+	handler6 := NewHCloudHandler("2a01:4f8::1", "token")
+	handler6.apiEndpoint = srv.URL
+	handler6.serverID = 42
+
+	require.NoError(t, handler6.Acquire(context.Background()))
+	require.NoError(t, handler6.Release(context.Background()))
+
+	assert.Equal(t, []string{
+		"GET /floating_ips?per_page=50 ",
+		"GET /floating_ips?per_page=50 ",
+		"POST /floating_ips/2/actions/unassign ",
+	}, rec.requests)
+}
+
+func TestEquinixMetalBGPHandler(t *testing.T) {
+	rec := &apiRecorder{
+		responses: map[string]string{
+			"GET /metadata": `{"id": "device-2", "bgp_neighbors": [{"address_family": 4, "customer_as": 65000, "customer_ip": "10.67.50.3", "md5_enabled": true, "md5_password": "secret", "multihop": true, "peer_as": 65530, "peer_ips": ["169.254.255.1", "169.254.255.2"]}, {"address_family": 6, "customer_as": 65000, "customer_ip": "2604:1380::3", "peer_as": 65530, "peer_ips": ["fc00::e", "fc00::f"]}]}`,
+		},
+	}
+
+	srv := httptest.NewServer(rec)
+	defer srv.Close()
+
+	var metadata struct {
+		BGPNeighbors []equinixMetalBGPNeighbor `json:"bgp_neighbors"`
+	}
+
+	require.NoError(t, apiCall(context.Background(), http.MethodGet, srv.URL+"/metadata", nil, nil, &metadata))
+
+	speakers, err := bgpSpeakers(metadata.BGPNeighbors)
+	require.NoError(t, err)
+
+	require.Len(t, speakers, 2)
+
+	for i, peer := range []string{"169.254.255.1", "169.254.255.2"} {
+		assert.EqualValues(t, 65000, speakers[i].LocalAS)
+		assert.EqualValues(t, 65530, speakers[i].PeerAS)
+		assert.Equal(t, "10.67.50.3", speakers[i].LocalAddress.String())
+		assert.Equal(t, peer, speakers[i].PeerAddress.String())
+		assert.Equal(t, "secret", speakers[i].Password)
+		assert.True(t, speakers[i].Multihop)
+	}
+
+	// BGP is not enabled for the device
+	rec.responses["GET /metadata"] = `{"id": "device-2", "bgp_neighbors": []}`
+
+	handler := NewEquinixMetalBGPHandler("147.75.100.10")
+	handler.metadataEndpoint = srv.URL + "/metadata"
+
+	assert.EqualError(t, handler.Acquire(context.Background()), "no IPv4 BGP neighbors found in the metadata, is BGP enabled for the device?")
+	require.NoError(t, handler.Release(context.Background()))
+
+	assert.EqualError(t, NewEquinixMetalBGPHandler("2604:1380::10").Acquire(context.Background()), `BGP announcements are supported only for IPv4 addresses, got "2604:1380::10"`)
+}
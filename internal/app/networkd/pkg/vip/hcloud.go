@@ -0,0 +1,144 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package vip
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+)
+
+const (
+	hcloudAPIEndpoint      = "https://api.hetzner.cloud/v1"
+	hcloudMetadataEndpoint = "http://169.254.169.254/hetzner/v1/metadata/instance-id"
+)
+
+// HCloudHandler assigns the shared IP (Hetzner Cloud floating IP) to the server via the Hetzner Cloud API.
+type HCloudHandler struct {
+	ip       string
+	apiToken string
+
+	apiEndpoint      string
+	metadataEndpoint string
+
+	serverID int64
+}
+
+// NewHCloudHandler creates new HCloudHandler.
+func NewHCloudHandler(ip, apiToken string) *HCloudHandler {
+	return &HCloudHandler{
+		ip:               ip,
+		apiToken:         apiToken,
+		apiEndpoint:      hcloudAPIEndpoint,
+		metadataEndpoint: hcloudMetadataEndpoint,
+	}
+}
+
+type hcloudFloatingIP struct {
+	ID     int64  `json:"id"`
+	IP     string `json:"ip"`
+	Server *int64 `json:"server"`
+}
+
+// Acquire implements Handler interface.
+//
+// Assigning the floating IP moves it from the previous server, if any.
+func (handler *HCloudHandler) Acquire(ctx context.Context) error {
+	if err := handler.discoverServerID(ctx); err != nil {
+		return err
+	}
+
+	floatingIP, err := handler.findFloatingIP(ctx)
+	if err != nil {
+		return err
+	}
+
+	if floatingIP.Server != nil && *floatingIP.Server == handler.serverID {
+		return nil
+	}
+
+	if err = handler.call(ctx, http.MethodPost, handler.actionPath(floatingIP, "assign"), map[string]int64{"server": handler.serverID}, nil); err != nil {
+		return fmt.Errorf("error assigning floating IP %q to server %d: %w", handler.ip, handler.serverID, err)
+	}
+
+	return nil
+}
+
+// Release implements Handler interface.
+func (handler *HCloudHandler) Release(ctx context.Context) error {
+	if handler.serverID == 0 {
+		// IP was never acquired
+		return nil
+	}
+
+	floatingIP, err := handler.findFloatingIP(ctx)
+	if err != nil {
+		return err
+	}
+
+	if floatingIP.Server == nil || *floatingIP.Server != handler.serverID {
+		return nil
+	}
+
+	if err = handler.call(ctx, http.MethodPost, handler.actionPath(floatingIP, "unassign"), nil, nil); err != nil {
+		return fmt.Errorf("error unassigning floating IP %q: %w", handler.ip, err)
+	}
+
+	return nil
+}
+
+func (handler *HCloudHandler) discoverServerID(ctx context.Context) error {
+	if handler.serverID != 0 {
+		return nil
+	}
+
+	// metadata endpoint returns the plain server ID, which is valid JSON number
+	if err := apiCall(ctx, http.MethodGet, handler.metadataEndpoint, nil, nil, &handler.serverID); err != nil {
+		return fmt.Errorf("error fetching server ID from metadata: %w", err)
+	}
+
+	return nil
+}
+
+func (handler *HCloudHandler) findFloatingIP(ctx context.Context) (*hcloudFloatingIP, error) {
+	var response struct {
+		FloatingIPs []hcloudFloatingIP `json:"floating_ips"`
+	}
+
+	if err := handler.call(ctx, http.MethodGet, "/floating_ips?per_page=50", nil, &response); err != nil {
+		return nil, fmt.Errorf("error listing floating IPs: %w", err)
+	}
+
+	ip := net.ParseIP(handler.ip)
+
+	for i := range response.FloatingIPs {
+		floatingIP := &response.FloatingIPs[i]
+
+		// IPv6 floating IPs are /64 networks
+		if _, network, err := net.ParseCIDR(floatingIP.IP); err == nil {
+			if network.Contains(ip) {
+				return floatingIP, nil
+			}
+
+			continue
+		}
+
+		if net.ParseIP(floatingIP.IP).Equal(ip) {
+			return floatingIP, nil
+		}
+	}
+
+	return nil, fmt.Errorf("floating IP %q is not found in the project", handler.ip)
+}
+
+func (handler *HCloudHandler) actionPath(floatingIP *hcloudFloatingIP, action string) string {
+	return "/floating_ips/" + strconv.FormatInt(floatingIP.ID, 10) + "/actions/" + action
+}
+
+func (handler *HCloudHandler) call(ctx context.Context, method, path string, in, out interface{}) error {
+	return apiCall(ctx, method, handler.apiEndpoint+path, map[string]string{"Authorization": "Bearer " + handler.apiToken}, in, out)
+}
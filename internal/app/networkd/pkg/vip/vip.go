@@ -29,12 +29,15 @@ type Controller interface {
 }
 
 type vipController struct {
-	ip    net.IP
-	iface *net.Interface
+	ip      net.IP
+	iface   *net.Interface
+	handler Handler
 }
 
 // New creates a new Virtual IP controller.
-func New(ip, iface string) (Controller, error) {
+//
+// Handler is invoked when the node wins or loses the election (see NewHandler).
+func New(ip, iface string, handler Handler) (Controller, error) {
 	ipaddr := net.ParseIP(ip)
 	if ipaddr == nil {
 		return nil, fmt.Errorf("failed to parse ip %q as an IP address", ip)
@@ -45,9 +48,14 @@ func New(ip, iface string) (Controller, error) {
 		return nil, fmt.Errorf("failed to find interface %s by name: %w", iface, err)
 	}
 
+	if handler == nil {
+		handler = nopHandler{}
+	}
+
 	return &vipController{
-		ip:    ipaddr,
-		iface: netIf,
+		ip:      ipaddr,
+		iface:   netIf,
+		handler: handler,
 	}, nil
 }
 
@@ -159,6 +167,20 @@ func (c *vipController) campaign(ctx context.Context, logger *log.Logger, netCon
 		}
 	}()
 
+	if err = c.handler.Acquire(ctx); err != nil {
+		return fmt.Errorf("failed to acquire VIP %q via the platform API: %w", c.ip.String(), err)
+	}
+
+	defer func() {
+		// use a new context to release, as `ctx` might be canceled
+		releaseCtx, releaseCancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer releaseCancel()
+
+		if err = c.handler.Release(releaseCtx); err != nil {
+			logger.Printf("vip: error releasing shared IP via the platform API: %s", err)
+		}
+	}()
+
 	// ARP is only supported for IPv4
 	if c.ip.To4() != nil {
 		// Send gratuitous ARP to announce the change
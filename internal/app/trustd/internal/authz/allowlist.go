@@ -0,0 +1,76 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package authz
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// AllowlistAuthorizer allows requests matching a static list of machine identities.
+type AllowlistAuthorizer struct {
+	fingerprints map[string]struct{}
+	networks     []*net.IPNet
+}
+
+// NewAllowlistAuthorizer parses the list of identities.
+//
+// Each identity is either a `sha256:` prefixed public key fingerprint,
+// or an IP address or CIDR matched against the request source address.
+//
+// Names from the CSR are not accepted as identities, as they are chosen by the requester.
+func NewAllowlistAuthorizer(identities []string) (*AllowlistAuthorizer, error) {
+	a := &AllowlistAuthorizer{
+		fingerprints: map[string]struct{}{},
+	}
+
+	for _, identity := range identities {
+		identity = strings.TrimSpace(identity)
+
+		switch {
+		case identity == "":
+			return nil, fmt.Errorf("empty identity in the allowlist")
+		case strings.HasPrefix(identity, "sha256:"):
+			a.fingerprints[strings.ToLower(identity)] = struct{}{}
+		case strings.Contains(identity, "/"):
+			_, network, err := net.ParseCIDR(identity)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse allowlist CIDR %q: %w", identity, err)
+			}
+
+			a.networks = append(a.networks, network)
+		case net.ParseIP(identity) != nil:
+			ip := net.ParseIP(identity)
+
+			a.networks = append(a.networks, &net.IPNet{
+				IP:   ip,
+				Mask: net.CIDRMask(len(ip)*8, len(ip)*8),
+			})
+		default:
+			return nil, fmt.Errorf("unsupported allowlist identity %q: should be a fingerprint, an IP address or CIDR", identity)
+		}
+	}
+
+	return a, nil
+}
+
+// Authorize implements Authorizer interface.
+func (a *AllowlistAuthorizer) Authorize(_ context.Context, req *Request) error {
+	if _, ok := a.fingerprints[req.Fingerprint()]; ok {
+		return nil
+	}
+
+	if req.PeerAddress != nil {
+		for _, network := range a.networks {
+			if network.Contains(req.PeerAddress) {
+				return nil
+			}
+		}
+	}
+
+	return fmt.Errorf("%w: identity %s (%s) is not in the allowlist", ErrDenied, req.PeerAddress, req.Fingerprint())
+}
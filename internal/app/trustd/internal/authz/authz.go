@@ -0,0 +1,90 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package authz implements trustd certificate issuance authorization policies.
+package authz
+
+import (
+	"context"
+	"crypto/sha256"
+	stdx509 "crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/talos-systems/talos/pkg/machinery/config"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+)
+
+// ErrDenied is returned when the join policy rejects the request.
+var ErrDenied = errors.New("join request denied by policy")
+
+// Request describes a certificate signing request pending authorization.
+type Request struct {
+	CSR         *stdx509.CertificateRequest
+	CSRPEM      []byte
+	PeerAddress net.IP
+}
+
+// NewRequest parses the PEM encoded CSR and builds a Request.
+func NewRequest(csrPEM []byte, peerAddress net.IP) (*Request, error) {
+	block, _ := pem.Decode(csrPEM)
+	if block == nil {
+		return nil, fmt.Errorf("failed to decode CSR PEM")
+	}
+
+	csr, err := stdx509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CSR: %w", err)
+	}
+
+	return &Request{
+		CSR:         csr,
+		CSRPEM:      csrPEM,
+		PeerAddress: peerAddress,
+	}, nil
+}
+
+// Fingerprint returns the `sha256:` prefixed fingerprint of the CSR public key.
+func (r *Request) Fingerprint() string {
+	sum := sha256.Sum256(r.CSR.RawSubjectPublicKeyInfo)
+
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// Authorizer decides whether a certificate can be issued for the request.
+//
+// Authorizers are invoked after the token has been verified, so they can only
+// further restrict certificate issuance.
+type Authorizer interface {
+	Authorize(ctx context.Context, req *Request) error
+}
+
+// New creates an Authorizer for the join policy.
+func New(policy config.JoinPolicy) (Authorizer, error) {
+	switch policy.Mode() {
+	case constants.TrustdJoinPolicyToken:
+		return TokenAuthorizer{}, nil
+	case constants.TrustdJoinPolicyAllowlist:
+		return NewAllowlistAuthorizer(policy.AllowedIdentities())
+	case constants.TrustdJoinPolicyWebhook:
+		if policy.Webhook() == nil {
+			return nil, fmt.Errorf("join policy %q requires webhook settings", policy.Mode())
+		}
+
+		return NewWebhookAuthorizer(policy.Webhook())
+	default:
+		return nil, fmt.Errorf("unsupported join policy mode %q", policy.Mode())
+	}
+}
+
+// TokenAuthorizer allows any request which passed token authentication.
+type TokenAuthorizer struct{}
+
+// Authorize implements Authorizer interface.
+func (TokenAuthorizer) Authorize(context.Context, *Request) error {
+	return nil
+}
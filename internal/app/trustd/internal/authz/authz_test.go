@@ -0,0 +1,144 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package authz_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	stdx509 "crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/internal/app/trustd/internal/authz"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
+)
+
+func generateCSR(t *testing.T, hostname string) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	der, err := stdx509.CreateCertificateRequest(rand.Reader, &stdx509.CertificateRequest{
+		Subject:     pkix.Name{CommonName: hostname},
+		DNSNames:    []string{hostname},
+		IPAddresses: []net.IP{net.ParseIP("10.5.0.2")},
+	}, key)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der})
+}
+
+func TestAllowlistAuthorizer(t *testing.T) {
+	req, err := authz.NewRequest(generateCSR(t, "worker-1"), net.ParseIP("192.168.2.10"))
+	require.NoError(t, err)
+
+	for _, tt := range []struct {
+		name       string
+		identities []string
+		allowed    bool
+	}{
+		{
+			name:       "cidr",
+			identities: []string{"192.168.2.0/24"},
+			allowed:    true,
+		},
+		{
+			name:       "ip",
+			identities: []string{"192.168.2.10"},
+			allowed:    true,
+		},
+		{
+			name:       "fingerprint",
+			identities: []string{req.Fingerprint()},
+			allowed:    true,
+		},
+		{
+			name:       "no match",
+			identities: []string{"192.168.3.0/24", "10.5.0.2", "sha256:0000"},
+			allowed:    false,
+		},
+	} {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := authz.NewAllowlistAuthorizer(tt.identities)
+			require.NoError(t, err)
+
+			err = a.Authorize(context.Background(), req)
+
+			if tt.allowed {
+				assert.NoError(t, err)
+			} else {
+				assert.True(t, errors.Is(err, authz.ErrDenied))
+			}
+		})
+	}
+}
+
+func TestNewAllowlistAuthorizerInvalid(t *testing.T) {
+	_, err := authz.NewAllowlistAuthorizer([]string{"10.0.0.0/33"})
+	assert.Error(t, err)
+
+	_, err = authz.NewAllowlistAuthorizer([]string{""})
+	assert.Error(t, err)
+
+	_, err = authz.NewAllowlistAuthorizer([]string{"worker-1"})
+	assert.Error(t, err)
+}
+
+func TestWebhookAuthorizer(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload authz.WebhookRequest
+
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		//nolint:errcheck
+		json.NewEncoder(w).Encode(&authz.WebhookResponse{
+			Allowed: payload.CommonName == "worker-1" && payload.PeerAddress == "192.168.2.10",
+			Reason:  "unknown machine",
+		})
+	}))
+	defer srv.Close()
+
+	a, err := authz.New(&v1alpha1.JoinPolicyConfig{
+		JoinPolicyMode: "webhook",
+		JoinPolicyWebhook: &v1alpha1.JoinPolicyWebhookConfig{
+			WebhookURL: srv.URL,
+		},
+	})
+	require.NoError(t, err)
+
+	req, err := authz.NewRequest(generateCSR(t, "worker-1"), net.ParseIP("192.168.2.10"))
+	require.NoError(t, err)
+
+	assert.NoError(t, a.Authorize(context.Background(), req))
+
+	req, err = authz.NewRequest(generateCSR(t, "rogue"), net.ParseIP("192.168.2.10"))
+	require.NoError(t, err)
+
+	err = a.Authorize(context.Background(), req)
+	assert.True(t, errors.Is(err, authz.ErrDenied))
+}
+
+func TestNewTokenAuthorizer(t *testing.T) {
+	a, err := authz.New(&v1alpha1.JoinPolicyConfig{})
+	require.NoError(t, err)
+
+	assert.IsType(t, authz.TokenAuthorizer{}, a)
+}
@@ -0,0 +1,120 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package authz
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	stdx509 "crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/talos-systems/talos/pkg/machinery/config"
+)
+
+// WebhookRequest is the payload sent to the external authorizer.
+type WebhookRequest struct {
+	CSR         string   `json:"csr"`
+	CommonName  string   `json:"commonName,omitempty"`
+	DNSNames    []string `json:"dnsNames,omitempty"`
+	IPAddresses []string `json:"ipAddresses,omitempty"`
+	Fingerprint string   `json:"fingerprint"`
+	PeerAddress string   `json:"peerAddress,omitempty"`
+}
+
+// WebhookResponse is the payload expected from the external authorizer.
+type WebhookResponse struct {
+	Allowed bool   `json:"allowed"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+// WebhookAuthorizer delegates the decision to an external HTTP(S) authorizer.
+type WebhookAuthorizer struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookAuthorizer creates a WebhookAuthorizer.
+func NewWebhookAuthorizer(webhook config.JoinPolicyWebhook) (*WebhookAuthorizer, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if len(webhook.CA()) > 0 {
+		pool := stdx509.NewCertPool()
+
+		if !pool.AppendCertsFromPEM(webhook.CA()) {
+			return nil, fmt.Errorf("failed to parse join policy webhook CA")
+		}
+
+		transport.TLSClientConfig = &tls.Config{
+			RootCAs:    pool,
+			MinVersion: tls.VersionTLS12,
+		}
+	}
+
+	return &WebhookAuthorizer{
+		url: webhook.URL(),
+		client: &http.Client{
+			Transport: transport,
+			Timeout:   webhook.Timeout(),
+		},
+	}, nil
+}
+
+// Authorize implements Authorizer interface.
+func (a *WebhookAuthorizer) Authorize(ctx context.Context, req *Request) error {
+	payload := WebhookRequest{
+		CSR:         string(req.CSRPEM),
+		CommonName:  req.CSR.Subject.CommonName,
+		DNSNames:    req.CSR.DNSNames,
+		Fingerprint: req.Fingerprint(),
+	}
+
+	for _, ip := range req.CSR.IPAddresses {
+		payload.IPAddresses = append(payload.IPAddresses, ip.String())
+	}
+
+	if req.PeerAddress != nil {
+		payload.PeerAddress = req.PeerAddress.String()
+	}
+
+	body, err := json.Marshal(&payload)
+	if err != nil {
+		return err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, a.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := a.client.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("error calling join policy webhook: %w", err)
+	}
+
+	//nolint:errcheck
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: webhook responded with status %d", ErrDenied, resp.StatusCode)
+	}
+
+	var decision WebhookResponse
+
+	if err = json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&decision); err != nil {
+		return fmt.Errorf("error decoding join policy webhook response: %w", err)
+	}
+
+	if !decision.Allowed {
+		return fmt.Errorf("%w: %s", ErrDenied, decision.Reason)
+	}
+
+	return nil
+}
@@ -6,14 +6,21 @@ package reg
 
 import (
 	"context"
+	"errors"
 	"io/ioutil"
 	"log"
+	"net"
 	"os"
 	"path"
 
 	"github.com/talos-systems/crypto/x509"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 
+	"github.com/talos-systems/talos/internal/app/trustd/internal/authz"
+	"github.com/talos-systems/talos/internal/pkg/revocation"
 	securityapi "github.com/talos-systems/talos/pkg/machinery/api/security"
 	"github.com/talos-systems/talos/pkg/machinery/config"
 )
@@ -23,7 +30,9 @@ import (
 type Registrator struct {
 	securityapi.UnimplementedSecurityServiceServer
 
-	Config config.Provider
+	Config      config.Provider
+	Authorizer  authz.Authorizer
+	Revocations *revocation.Store
 }
 
 // Register implements the factory.Registrator interface.
@@ -35,8 +44,10 @@ func (r *Registrator) Register(s *grpc.Server) {
 
 // Certificate implements the securityapi.SecurityServer interface.
 func (r *Registrator) Certificate(ctx context.Context, in *securityapi.CertificateRequest) (resp *securityapi.CertificateResponse, err error) {
-	// TODO: Verify that the request is coming from the IP addresss declared in
-	// the CSR.
+	if err = r.authorize(ctx, in.Csr); err != nil {
+		return nil, err
+	}
+
 	signed, err := x509.NewCertificateFromCSRBytes(r.Config.Machine().Security().CA().Crt, r.Config.Machine().Security().CA().Key, in.Csr)
 	if err != nil {
 		return
@@ -50,6 +61,37 @@ func (r *Registrator) Certificate(ctx context.Context, in *securityapi.Certifica
 	return resp, nil
 }
 
+func (r *Registrator) authorize(ctx context.Context, csr []byte) error {
+	if r.Authorizer == nil {
+		return nil
+	}
+
+	var peerAddress net.IP
+
+	if remote, ok := peer.FromContext(ctx); ok {
+		if addr, ok := remote.Addr.(*net.TCPAddr); ok {
+			peerAddress = addr.IP
+		}
+	}
+
+	req, err := authz.NewRequest(csr, peerAddress)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err = r.Authorizer.Authorize(ctx, req); err != nil {
+		log.Printf("certificate request from %s denied: %s", peerAddress, err)
+
+		if errors.Is(err, authz.ErrDenied) {
+			return status.Error(codes.PermissionDenied, err.Error())
+		}
+
+		return status.Error(codes.Unavailable, err.Error())
+	}
+
+	return nil
+}
+
 // ReadFile implements the securityapi.SecurityServer interface.
 func (r *Registrator) ReadFile(ctx context.Context, in *securityapi.ReadFileRequest) (resp *securityapi.ReadFileResponse, err error) {
 	var b []byte
@@ -81,3 +123,62 @@ func (r *Registrator) WriteFile(ctx context.Context, in *securityapi.WriteFileRe
 
 	return resp, nil
 }
+
+// RevokeCertificate implements the securityapi.SecurityServer interface.
+//
+// The trustd port is available to every node with the machine token, so the revocations are accepted
+// only over the local socket from machined (which authorizes the request via the Talos API).
+func (r *Registrator) RevokeCertificate(ctx context.Context, in *securityapi.RevokeCertificateRequest) (*securityapi.RevokeCertificateResponse, error) {
+	if remote, ok := peer.FromContext(ctx); !ok || remote.Addr.Network() != "unix" {
+		return nil, status.Error(codes.PermissionDenied, "certificates can be revoked only via the local socket")
+	}
+
+	serial, err := revocation.ParseSerialNumber(in.SerialNumber)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	revoked, err := r.Revocations.Revoke(serial)
+	if err != nil {
+		return nil, err
+	}
+
+	if revoked {
+		log.Printf("revoked certificate with serial number %s", serial)
+	}
+
+	return &securityapi.RevokeCertificateResponse{}, nil
+}
+
+// RevocationList implements the securityapi.SecurityServer interface.
+func (r *Registrator) RevocationList(ctx context.Context, in *securityapi.RevocationListRequest) (*securityapi.RevocationListResponse, error) {
+	return &securityapi.RevocationListResponse{
+		SerialNumbers: r.Revocations.SerialNumbers(),
+	}, nil
+}
+
+// LocalRegistrator exposes only the certificate revocation methods of the Registrator.
+//
+// It is served on the local socket, so that machined can't use the socket to sign the certificates or to access the files.
+type LocalRegistrator struct {
+	securityapi.UnimplementedSecurityServiceServer
+
+	Registrator *Registrator
+}
+
+// Register implements the factory.Registrator interface.
+//
+//nolint:interfacer
+func (r *LocalRegistrator) Register(s *grpc.Server) {
+	securityapi.RegisterSecurityServiceServer(s, r)
+}
+
+// RevokeCertificate implements the securityapi.SecurityServer interface.
+func (r *LocalRegistrator) RevokeCertificate(ctx context.Context, in *securityapi.RevokeCertificateRequest) (*securityapi.RevokeCertificateResponse, error) {
+	return r.Registrator.RevokeCertificate(ctx, in)
+}
+
+// RevocationList implements the securityapi.SecurityServer interface.
+func (r *LocalRegistrator) RevocationList(ctx context.Context, in *securityapi.RevocationListRequest) (*securityapi.RevocationListResponse, error) {
+	return r.Registrator.RevocationList(ctx, in)
+}
@@ -4,11 +4,80 @@
 
 package reg_test
 
-import "testing"
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"testing"
 
-func TestEmpty(t *testing.T) {
-	// added for accurate coverage estimation
-	//
-	// please remove it once any unit-test is added
-	// for this package
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/talos-systems/talos/internal/app/trustd/internal/reg"
+	"github.com/talos-systems/talos/internal/pkg/revocation"
+	securityapi "github.com/talos-systems/talos/pkg/machinery/api/security"
+)
+
+func TestRevokeCertificate(t *testing.T) {
+	store, err := revocation.NewStore(filepath.Join(t.TempDir(), "revoked-certificates.json"))
+	require.NoError(t, err)
+
+	r := &reg.Registrator{
+		Revocations: store,
+	}
+
+	remoteCtx := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP("172.20.0.5"), Port: 34567},
+	})
+
+	_, err = r.RevokeCertificate(remoteCtx, &securityapi.RevokeCertificateRequest{SerialNumber: "12345"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	localCtx := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.UnixAddr{Name: "@", Net: "unix"},
+	})
+
+	_, err = r.RevokeCertificate(localCtx, &securityapi.RevokeCertificateRequest{SerialNumber: "12345"})
+	require.NoError(t, err)
+
+	resp, err := r.RevocationList(remoteCtx, &securityapi.RevocationListRequest{})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"12345"}, resp.SerialNumbers)
+}
+
+func TestLocalRegistrator(t *testing.T) {
+	store, err := revocation.NewStore(filepath.Join(t.TempDir(), "revoked-certificates.json"))
+	require.NoError(t, err)
+
+	r := &reg.LocalRegistrator{
+		Registrator: &reg.Registrator{
+			Revocations: store,
+		},
+	}
+
+	localCtx := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.UnixAddr{Name: "@", Net: "unix"},
+	})
+
+	_, err = r.RevokeCertificate(localCtx, &securityapi.RevokeCertificateRequest{SerialNumber: "12345"})
+	require.NoError(t, err)
+
+	resp, err := r.RevocationList(localCtx, &securityapi.RevocationListRequest{})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"12345"}, resp.SerialNumbers)
+
+	// other methods are not available on the local socket
+	_, err = r.Certificate(localCtx, &securityapi.CertificateRequest{})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+
+	_, err = r.ReadFile(localCtx, &securityapi.ReadFileRequest{Path: "/etc/hostname"})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+
+	_, err = r.WriteFile(localCtx, &securityapi.WriteFileRequest{Path: filepath.Join(t.TempDir(), "file")})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
 }
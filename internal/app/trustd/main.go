@@ -11,10 +11,14 @@ import (
 
 	"github.com/talos-systems/crypto/tls"
 	"github.com/talos-systems/net"
+	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 
+	"github.com/talos-systems/talos/internal/app/trustd/internal/authz"
 	"github.com/talos-systems/talos/internal/app/trustd/internal/reg"
+	"github.com/talos-systems/talos/internal/pkg/revocation"
+	"github.com/talos-systems/talos/internal/pkg/tlspolicy"
 	"github.com/talos-systems/talos/pkg/grpc/factory"
 	"github.com/talos-systems/talos/pkg/grpc/gen"
 	"github.com/talos-systems/talos/pkg/grpc/middleware/auth/basic"
@@ -79,29 +83,68 @@ func Main() {
 		log.Fatal(err)
 	}
 
-	tlsConfig, err := tls.New(
+	tlsOptions := []tls.ConfigOptionFunc{
 		tls.WithClientAuthType(tls.ServerOnly),
 		tls.WithCACertPEM(ca),
 		tls.WithServerCertificateProvider(provider),
-	)
+	}
+
+	if config.Machine().Features().StrictTLSEnabled() {
+		tlsOptions = append(tlsOptions, tlspolicy.Strict)
+	}
+
+	tlsConfig, err := tls.New(tlsOptions...)
 	if err != nil {
 		log.Fatalf("failed to create TLS config: %v", err)
 	}
 
+	authorizer, err := authz.New(config.Machine().Security().JoinPolicy())
+	if err != nil {
+		log.Fatalf("failed to create join policy authorizer: %v", err)
+	}
+
+	revocations, err := revocation.NewStore(constants.RevokedCertificatesPath)
+	if err != nil {
+		log.Fatalf("failed to load revocation list: %v", err)
+	}
+
 	creds := basic.NewTokenCredentials(config.Machine().Security().Token())
 
-	err = factory.ListenAndServe(
-		&reg.Registrator{Config: config},
-		factory.Port(constants.TrustdPort),
-		factory.WithDefaultLog(),
-		factory.WithUnaryInterceptor(creds.UnaryInterceptor()),
-		factory.ServerOptions(
-			grpc.Creds(
-				credentials.NewTLS(tlsConfig),
+	registrator := &reg.Registrator{
+		Config:      config,
+		Authorizer:  authorizer,
+		Revocations: revocations,
+	}
+
+	var errGroup errgroup.Group
+
+	errGroup.Go(func() error {
+		return factory.ListenAndServe(
+			registrator,
+			factory.Port(constants.TrustdPort),
+			factory.WithDefaultLog(),
+			factory.WithUnaryInterceptor(creds.UnaryInterceptor()),
+			factory.ServerOptions(
+				grpc.Creds(
+					credentials.NewTLS(tlsConfig),
+				),
 			),
-		),
-	)
-	if err != nil {
+		)
+	})
+
+	// local socket is used by machined to revoke the certificates
+	errGroup.Go(func() error {
+		return factory.ListenAndServe(
+			&reg.LocalRegistrator{
+				Registrator: registrator,
+			},
+			factory.Network("unix"),
+			factory.SocketPath(constants.TrustdSocketPath),
+			factory.WithDefaultLog(),
+		)
+	})
+
+	if err = errGroup.Wait(); err != nil {
 		log.Fatalf("listen: %v", err)
 	}
 }
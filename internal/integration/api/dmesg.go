@@ -12,8 +12,12 @@ import (
 	"io/ioutil"
 	"time"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
 	"github.com/talos-systems/talos/internal/integration/base"
 	"github.com/talos-systems/talos/pkg/machinery/api/common"
+	machineapi "github.com/talos-systems/talos/pkg/machinery/api/machine"
 	"github.com/talos-systems/talos/pkg/machinery/client"
 )
 
@@ -159,6 +163,52 @@ func (suite *DmesgSuite) TestClusterHasDmesg() {
 	}
 }
 
+// TestFiltering verifies priority filtering and tail lines.
+func (suite *DmesgSuite) TestFiltering() {
+	dmesgStream, err := suite.Client.MachineClient.Dmesg(suite.ctx, &machineapi.DmesgRequest{
+		Priority:   "info",
+		Facilities: []string{"kern"},
+		TailLines:  5,
+	})
+	suite.Require().NoError(err)
+
+	count := 0
+
+	for {
+		msg, err := dmesgStream.Recv()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+
+			suite.Require().NoError(err)
+		}
+
+		if msg.Metadata != nil {
+			suite.Assert().Empty(msg.Metadata.Error)
+		}
+
+		if msg.Bytes == nil {
+			continue
+		}
+
+		count++
+
+		suite.Assert().Regexp(`^kern: +(emerg|alert|crit|err|warning|notice|info): `, string(msg.Bytes))
+	}
+
+	suite.Assert().Equal(5, count)
+
+	dmesgStream, err = suite.Client.MachineClient.Dmesg(suite.ctx, &machineapi.DmesgRequest{
+		Priority: "verbose",
+	})
+	suite.Require().NoError(err)
+
+	_, err = dmesgStream.Recv()
+	suite.Require().Error(err)
+	suite.Assert().Equal(codes.InvalidArgument, status.Code(err))
+}
+
 func init() {
 	allSuites = append(allSuites, new(DmesgSuite))
 }
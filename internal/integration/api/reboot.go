@@ -16,6 +16,7 @@ import (
 	"github.com/talos-systems/go-retry/retry"
 
 	"github.com/talos-systems/talos/internal/integration/base"
+	machineapi "github.com/talos-systems/talos/pkg/machinery/api/machine"
 	"github.com/talos-systems/talos/pkg/machinery/client"
 )
 
@@ -67,6 +68,20 @@ func (suite *RebootSuite) TestRebootNodeByNode() {
 	}
 }
 
+// TestRebootPowercycle reboots a node skipping kexec.
+func (suite *RebootSuite) TestRebootPowercycle() {
+	if !suite.Capabilities().SupportsReboot {
+		suite.T().Skip("cluster doesn't support reboots")
+	}
+
+	node := suite.RandomDiscoveredNode()
+	suite.T().Log("power cycling node", node)
+
+	suite.AssertRebooted(suite.ctx, node, func(nodeCtx context.Context) error {
+		return base.IgnoreGRPCUnavailable(suite.Client.Reboot(nodeCtx, client.WithRebootMode(machineapi.RebootRequest_POWERCYCLE)))
+	}, 10*time.Minute)
+}
+
 // TestRebootAllNodes reboots all cluster nodes at the same time.
 //
 //nolint:gocyclo
@@ -0,0 +1,44 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// +build integration_cli
+
+package cli
+
+import (
+	"regexp"
+
+	"github.com/talos-systems/talos/internal/integration/base"
+)
+
+// CgroupsSuite verifies cgroups command.
+type CgroupsSuite struct {
+	base.CLISuite
+}
+
+// SuiteName ...
+func (suite *CgroupsSuite) SuiteName() string {
+	return "cli.CgroupsSuite"
+}
+
+// TestRoot verifies that root cgroup and its children are displayed.
+func (suite *CgroupsSuite) TestRoot() {
+	suite.RunCLI([]string{"cgroups", "--depth", "1", "--nodes", suite.RandomDiscoveredNode()},
+		base.StdoutShouldMatch(regexp.MustCompile(`NAME\s+PROCS\s+CPU-TIME`)),
+		base.StdoutShouldMatch(regexp.MustCompile(`(?m)^\S+\s+/\s+\d+`)),
+		base.StdoutShouldMatch(regexp.MustCompile(`\\_ \S+`)),
+	)
+}
+
+// TestNotFound verifies that missing cgroup is reported.
+func (suite *CgroupsSuite) TestNotFound() {
+	suite.RunCLI([]string{"cgroups", "/nonexistent", "--nodes", suite.RandomDiscoveredNode()},
+		base.ShouldFail(),
+		base.StderrShouldMatch(regexp.MustCompile(`not found`)),
+	)
+}
+
+func init() {
+	allSuites = append(allSuites, new(CgroupsSuite))
+}
@@ -0,0 +1,35 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// +build integration_cli
+
+package cli
+
+import (
+	"regexp"
+
+	"github.com/talos-systems/talos/internal/integration/base"
+)
+
+// CmdlineSuite verifies cmdline command.
+type CmdlineSuite struct {
+	base.CLISuite
+}
+
+// SuiteName ...
+func (suite *CmdlineSuite) SuiteName() string {
+	return "cli.CmdlineSuite"
+}
+
+// TestSuccess verifies that kernel command line is displayed.
+func (suite *CmdlineSuite) TestSuccess() {
+	suite.RunCLI([]string{"cmdline", "--nodes", suite.RandomDiscoveredNode()},
+		base.StdoutShouldMatch(regexp.MustCompile(`NODE\s+BOOT\s+CMDLINE`)),
+		base.StdoutShouldMatch(regexp.MustCompile(`CURRENT\s+\S+`)),
+	)
+}
+
+func init() {
+	allSuites = append(allSuites, new(CmdlineSuite))
+}
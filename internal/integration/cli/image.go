@@ -0,0 +1,62 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// +build integration_cli
+
+package cli
+
+import (
+	"regexp"
+
+	"github.com/talos-systems/talos/internal/integration/base"
+)
+
+// ImageSuite verifies image command.
+type ImageSuite struct {
+	base.CLISuite
+}
+
+// SuiteName ...
+func (suite *ImageSuite) SuiteName() string {
+	return "cli.ImageSuite"
+}
+
+// TestList verifies that images of the Kubernetes pods and Talos system services are listed.
+func (suite *ImageSuite) TestList() {
+	node := suite.RandomDiscoveredNode()
+
+	suite.RunCLI([]string{"image", "list", "--nodes", node},
+		base.StdoutShouldMatch(regexp.MustCompile(`NODE\s+IMAGE\s+DIGEST\s+SIZE\s+CREATED`)),
+		base.StdoutShouldMatch(regexp.MustCompile(`pause`)),
+	)
+
+	suite.RunCLI([]string{"image", "ls", "--namespace", "system", "--nodes", node},
+		base.StdoutShouldMatch(regexp.MustCompile(`NODE\s+IMAGE\s+DIGEST\s+SIZE\s+CREATED`)),
+	)
+}
+
+// TestPull verifies that image is pulled to the node.
+func (suite *ImageSuite) TestPull() {
+	node := suite.RandomDiscoveredNode()
+
+	suite.RunCLI([]string{"image", "pull", "docker.io/library/busybox:1.33", "--nodes", node},
+		base.StdoutShouldMatch(regexp.MustCompile(`docker.io/library/busybox:1.33\s+sha256:`)),
+	)
+
+	suite.RunCLI([]string{"image", "list", "--nodes", node},
+		base.StdoutShouldMatch(regexp.MustCompile(`docker.io/library/busybox:1.33`)),
+	)
+}
+
+// TestInvalidNamespace verifies that unsupported namespace is rejected.
+func (suite *ImageSuite) TestInvalidNamespace() {
+	suite.RunCLI([]string{"image", "list", "--namespace", "k8s.io", "--nodes", suite.RandomDiscoveredNode()},
+		base.ShouldFail(),
+		base.StderrShouldMatch(regexp.MustCompile(`unsupported namespace`)),
+	)
+}
+
+func init() {
+	allSuites = append(allSuites, new(ImageSuite))
+}
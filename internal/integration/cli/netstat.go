@@ -0,0 +1,39 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// +build integration_cli
+
+package cli
+
+import (
+	"regexp"
+
+	"github.com/talos-systems/talos/internal/integration/base"
+)
+
+// NetstatSuite verifies netstat command.
+type NetstatSuite struct {
+	base.CLISuite
+}
+
+// SuiteName ...
+func (suite *NetstatSuite) SuiteName() string {
+	return "cli.NetstatSuite"
+}
+
+// TestListening verifies that apid listener is reported.
+func (suite *NetstatSuite) TestListening() {
+	suite.RunCLI([]string{"netstat", "--listening", "--tcp", "--nodes", suite.RandomDiscoveredNode()},
+		base.StdoutShouldMatch(regexp.MustCompile(`:50000\s+.*LISTEN\s+\d+/apid`)))
+}
+
+// TestPods verifies pod attribution.
+func (suite *NetstatSuite) TestPods() {
+	suite.RunCLI([]string{"netstat", "--all", "--pods", "--nodes", suite.RandomDiscoveredNode()},
+		base.StdoutShouldMatch(regexp.MustCompile(`POD`)))
+}
+
+func init() {
+	allSuites = append(allSuites, new(NetstatSuite))
+}
@@ -0,0 +1,55 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// +build integration_cli
+
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/talos-systems/talos/internal/integration/base"
+)
+
+// PcapSuite verifies pcap command.
+type PcapSuite struct {
+	base.CLISuite
+}
+
+// SuiteName ...
+func (suite *PcapSuite) SuiteName() string {
+	return "cli.PcapSuite"
+}
+
+// TestCapture verifies that apid traffic is captured.
+func (suite *PcapSuite) TestCapture() {
+	suite.RunCLI([]string{"pcap", "--interface", "eth0", "--filter", "tcp port 50000", "--count", "5", "--duration", "10s",
+		"--nodes", suite.RandomDiscoveredNode()},
+		base.StdoutMatchFunc(func(stdout string) error {
+			// pcap file header is 24 bytes, followed by the packet records
+			if len(stdout) <= 24 {
+				return fmt.Errorf("no packets captured")
+			}
+
+			if !strings.HasPrefix(stdout, "\xd4\xc3\xb2\xa1") {
+				return fmt.Errorf("unexpected pcap magic %q", stdout[:4])
+			}
+
+			return nil
+		}))
+}
+
+// TestInvalidFilter verifies that invalid filter is rejected.
+func (suite *PcapSuite) TestInvalidFilter() {
+	suite.RunCLI([]string{"pcap", "--filter", "port foo", "--nodes", suite.RandomDiscoveredNode()},
+		base.ShouldFail(),
+		base.StdoutEmpty(),
+		base.StderrNotEmpty(),
+	)
+}
+
+func init() {
+	allSuites = append(allSuites, new(PcapSuite))
+}
@@ -28,6 +28,20 @@ func (suite *ProcessesSuite) TestSuccess() {
 		base.StdoutShouldMatch(regexp.MustCompile(`PID`)))
 }
 
+// TestTree verifies tree output.
+func (suite *ProcessesSuite) TestTree() {
+	suite.RunCLI([]string{"processes", "--tree", "--nodes", suite.RandomDiscoveredNode()},
+		base.StdoutShouldMatch(regexp.MustCompile(`FDS`)),
+		base.StdoutShouldMatch(regexp.MustCompile(`\\_ `)))
+}
+
+// TestDetails verifies process details output.
+func (suite *ProcessesSuite) TestDetails() {
+	suite.RunCLI([]string{"processes", "1", "--nodes", suite.RandomDiscoveredNode()},
+		base.StdoutShouldMatch(regexp.MustCompile(`CGROUP`)),
+		base.StdoutShouldMatch(regexp.MustCompile(`OPEN FILES`)))
+}
+
 func init() {
 	allSuites = append(allSuites, new(ProcessesSuite))
 }
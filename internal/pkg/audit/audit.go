@@ -0,0 +1,140 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package audit implements the audit log of the mutating machine API calls.
+//
+// apid identifies the client (client certificate subject and address) and passes the identity to machined
+// via the request metadata; machined records the mutating calls with the identity, the request parameters
+// and the outcome.
+package audit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/talos-systems/talos/pkg/grpc/middleware/auth/peercred"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+)
+
+// Entry describes a single mutating machine API call.
+type Entry struct {
+	Time    time.Time `json:"time"`
+	Method  string    `json:"method"`
+	Client  string    `json:"client"`
+	Peer    string    `json:"peer"`
+	Request string    `json:"request,omitempty"`
+	Error   string    `json:"error,omitempty"`
+}
+
+// mutatingMethods is the set of the machine API methods which change the state of the node.
+var mutatingMethods = map[string]struct{}{
+	"/machine.MachineService/ApplyConfiguration":           {},
+	"/machine.MachineService/Bootstrap":                    {},
+	"/machine.MachineService/Cordon":                       {},
+	"/machine.MachineService/CopyIn":                       {},
+	"/machine.MachineService/EtcdForfeitLeadership":        {},
+	"/machine.MachineService/EtcdLeaveCluster":             {},
+	"/machine.MachineService/EtcdRecover":                  {},
+	"/machine.MachineService/EtcdRemoveMember":             {},
+	"/machine.MachineService/ImagePull":                    {},
+	"/machine.MachineService/PauseReconcile":               {},
+	"/machine.MachineService/Reboot":                       {},
+	"/machine.MachineService/Recover":                      {},
+	"/machine.MachineService/RemoveBootkubeInitializedKey": {},
+	"/machine.MachineService/Reset":                        {},
+	"/machine.MachineService/Restart":                      {},
+	"/machine.MachineService/RevokeCertificate":            {},
+	"/machine.MachineService/Rollback":                     {},
+	"/machine.MachineService/ServiceRestart":               {},
+	"/machine.MachineService/ServiceStart":                 {},
+	"/machine.MachineService/ServiceStop":                  {},
+	"/machine.MachineService/Shutdown":                     {},
+	"/machine.MachineService/SyncConfiguration":            {},
+	"/machine.MachineService/Uncordon":                     {},
+	"/machine.MachineService/Upgrade":                      {},
+}
+
+// IsMutating returns true if the method is recorded in the audit log.
+func IsMutating(method string) bool {
+	_, ok := mutatingMethods[method]
+
+	return ok
+}
+
+// NewEntry builds the audit log entry for the call.
+//
+// Request is nil for the client streaming calls.
+func NewEntry(ctx context.Context, method string, req interface{}, err error) Entry {
+	entry := Entry{
+		Time:   time.Now(),
+		Method: method,
+	}
+
+	entry.Client, entry.Peer = identity(ctx)
+
+	if msg, ok := req.(proto.Message); ok {
+		entry.Request = marshalRequest(msg)
+	}
+
+	if err != nil {
+		entry.Error = err.Error()
+	}
+
+	return entry
+}
+
+// identity returns the client identity and address.
+//
+// Requests proxied by apid carry the identity in the metadata, local API clients are identified
+// by the process credentials.
+func identity(ctx context.Context) (client, peer string) {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		clients, peers := md.Get(constants.AuditClientMetadataKey), md.Get(constants.AuditPeerMetadataKey)
+
+		if len(clients) > 0 && len(peers) > 0 {
+			return clients[0], peers[0]
+		}
+	}
+
+	if info, ok := peercred.FromContext(ctx); ok {
+		return fmt.Sprintf("uid=%d gid=%d pid=%d", info.UID, info.GID, info.PID), "local"
+	}
+
+	return "unknown", "unknown"
+}
+
+// marshalRequest returns the request parameters in JSON.
+//
+// Binary fields are omitted, as they might carry the secrets (e.g. machine configuration).
+func marshalRequest(msg proto.Message) string {
+	msg = proto.Clone(msg)
+	m := msg.ProtoReflect()
+
+	var binaryFields []protoreflect.FieldDescriptor
+
+	m.Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		if fd.Kind() == protoreflect.BytesKind {
+			binaryFields = append(binaryFields, fd)
+		}
+
+		return true
+	})
+
+	for _, fd := range binaryFields {
+		m.Clear(fd)
+	}
+
+	b, err := protojson.Marshal(msg)
+	if err != nil {
+		return fmt.Sprintf("error marshaling request: %s", err)
+	}
+
+	return string(b)
+}
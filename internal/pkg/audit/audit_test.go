@@ -0,0 +1,133 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package audit_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"github.com/talos-systems/talos/internal/pkg/audit"
+	"github.com/talos-systems/talos/pkg/grpc/middleware/auth/peercred"
+	"github.com/talos-systems/talos/pkg/machinery/api/machine"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+)
+
+func TestIsMutating(t *testing.T) {
+	assert.True(t, audit.IsMutating("/machine.MachineService/ApplyConfiguration"))
+	assert.True(t, audit.IsMutating("/machine.MachineService/Reboot"))
+	assert.False(t, audit.IsMutating("/machine.MachineService/Version"))
+	assert.False(t, audit.IsMutating("/resource.ResourceService/Get"))
+}
+
+func TestNewEntry(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		constants.AuditClientMetadataKey, "O=os:admin",
+		constants.AuditPeerMetadataKey, "10.5.0.1 via 172.20.0.2",
+	))
+
+	entry := audit.NewEntry(ctx, "/machine.MachineService/ApplyConfiguration", &machine.ApplyConfigurationRequest{
+		Data:      []byte("machine:\n  token: secret\n"),
+		Immediate: true,
+	}, errors.New("config validation failed"))
+
+	assert.Equal(t, "/machine.MachineService/ApplyConfiguration", entry.Method)
+	assert.Equal(t, "O=os:admin", entry.Client)
+	assert.Equal(t, "10.5.0.1 via 172.20.0.2", entry.Peer)
+	assert.Equal(t, "config validation failed", entry.Error)
+	assert.False(t, entry.Time.IsZero())
+
+	// config contents are not recorded
+	assert.JSONEq(t, `{"immediate": true}`, entry.Request)
+}
+
+func TestNewEntryLocal(t *testing.T) {
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.UnixAddr{Net: "unix"},
+		AuthInfo: peercred.AuthInfo{
+			PID: 1234,
+			UID: 0,
+			GID: 0,
+		},
+	})
+
+	entry := audit.NewEntry(ctx, "/machine.MachineService/CopyIn", nil, nil)
+
+	assert.Equal(t, "uid=0 gid=0 pid=1234", entry.Client)
+	assert.Equal(t, "local", entry.Peer)
+	assert.Empty(t, entry.Request)
+	assert.Empty(t, entry.Error)
+}
+
+type mockServerStream struct {
+	grpc.ServerStream
+
+	ctx context.Context
+}
+
+func (s *mockServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func identityMetadata(ctx context.Context) (metadata.MD, error) {
+	var md metadata.MD
+
+	err := audit.IdentityStreamInterceptor()(nil, &mockServerStream{ctx: ctx}, &grpc.StreamServerInfo{}, func(srv interface{}, stream grpc.ServerStream) error {
+		md, _ = metadata.FromIncomingContext(stream.Context())
+
+		return nil
+	})
+
+	return md, err
+}
+
+func TestIdentityStreamInterceptor(t *testing.T) {
+	clientAddr := &net.TCPAddr{IP: net.ParseIP("10.5.0.1"), Port: 51234}
+
+	// identity sent by the client is replaced
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: clientAddr})
+	ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(
+		constants.AuditClientMetadataKey, "O=someone-else",
+		constants.AuditPeerMetadataKey, "1.2.3.4",
+		"nodes", "172.20.0.3",
+	))
+
+	md, err := identityMetadata(ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"unknown"}, md.Get(constants.AuditClientMetadataKey))
+	assert.Equal(t, []string{"10.5.0.1"}, md.Get(constants.AuditPeerMetadataKey))
+	assert.Equal(t, []string{"172.20.0.3"}, md.Get("nodes"))
+
+	// request proxied by another node keeps the identity
+	ctx = peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("172.20.0.2"), Port: 42345}})
+	ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(
+		constants.AuditClientMetadataKey, "O=os:admin",
+		constants.AuditPeerMetadataKey, "10.5.0.1",
+		"proxyfrom", "172.20.0.2",
+	))
+
+	md, err = identityMetadata(ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"O=os:admin"}, md.Get(constants.AuditClientMetadataKey))
+	assert.Equal(t, []string{"10.5.0.1 via 172.20.0.2"}, md.Get(constants.AuditPeerMetadataKey))
+
+	// local socket
+	ctx = peer.NewContext(context.Background(), &peer.Peer{Addr: &net.UnixAddr{Net: "unix"}})
+
+	md, err = identityMetadata(ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"local"}, md.Get(constants.AuditClientMetadataKey))
+	assert.Equal(t, []string{"local"}, md.Get(constants.AuditPeerMetadataKey))
+}
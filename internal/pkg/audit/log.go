@@ -0,0 +1,138 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"sync"
+
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+)
+
+// Log keeps the most recent audit log entries in memory.
+//
+// Entries are persisted separately (as the STATE partition is not mounted when the API starts),
+// the persisted entries are restored into the log once loaded.
+type Log struct {
+	mu       sync.Mutex
+	entries  []Entry
+	appended uint64
+	notifyCh chan struct{}
+}
+
+// NewLog initializes the Log.
+func NewLog() *Log {
+	return &Log{
+		notifyCh: make(chan struct{}, 1),
+	}
+}
+
+// Append records the entry.
+func (l *Log) Append(entry Entry) {
+	l.mu.Lock()
+	l.entries = trim(append(l.entries, entry))
+	l.appended++
+	l.mu.Unlock()
+
+	select {
+	case l.notifyCh <- struct{}{}:
+	default:
+	}
+}
+
+// Notify returns the channel which is signaled when new entries are appended.
+func (l *Log) Notify() <-chan struct{} {
+	return l.notifyCh
+}
+
+// Restore puts the persisted entries before the entries appended since the start.
+func (l *Log) Restore(persisted []Entry) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.entries = trim(append(append([]Entry(nil), persisted...), l.entries...))
+}
+
+// Entries returns up to tail most recent entries, all the entries are returned if tail is zero.
+func (l *Log) Entries(tail int) []Entry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entries := l.entries
+
+	if tail > 0 && tail < len(entries) {
+		entries = entries[len(entries)-tail:]
+	}
+
+	return append([]Entry(nil), entries...)
+}
+
+// Snapshot returns all the entries, and the total number of the appended entries.
+func (l *Log) Snapshot() ([]Entry, uint64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return append([]Entry(nil), l.entries...), l.appended
+}
+
+// Since returns the entries appended after the first n appended entries, and the total number of the appended entries.
+//
+// Entries which were trimmed from memory are skipped.
+func (l *Log) Since(n uint64) ([]Entry, uint64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	count := l.appended - n
+	if count > uint64(len(l.entries)) {
+		count = uint64(len(l.entries))
+	}
+
+	return append([]Entry(nil), l.entries[uint64(len(l.entries))-count:]...), l.appended
+}
+
+func trim(entries []Entry) []Entry {
+	if len(entries) > constants.MaxAuditLogEntries {
+		entries = append([]Entry(nil), entries[len(entries)-constants.MaxAuditLogEntries:]...)
+	}
+
+	return entries
+}
+
+// ReadEntries reads the persisted entries (one JSON object per line).
+//
+// Malformed lines (e.g. the last line truncated on power loss) are skipped.
+func ReadEntries(r io.Reader) ([]Entry, error) {
+	var entries []Entry
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1024*1024)
+
+	for scanner.Scan() {
+		var entry Entry
+
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			continue
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, scanner.Err()
+}
+
+// WriteEntries persists the entries (one JSON object per line).
+func WriteEntries(w io.Writer, entries []Entry) error {
+	encoder := json.NewEncoder(w)
+
+	for _, entry := range entries {
+		if err := encoder.Encode(entry); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package audit_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/internal/pkg/audit"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+)
+
+func entry(i int) audit.Entry {
+	return audit.Entry{
+		Time:   time.Date(2021, 6, 1, 0, 0, i, 0, time.UTC),
+		Method: fmt.Sprintf("/machine.MachineService/Method%d", i),
+		Client: "O=os:admin",
+		Peer:   "172.20.0.1",
+	}
+}
+
+func TestLog(t *testing.T) {
+	l := audit.NewLog()
+
+	l.Append(entry(1))
+	l.Append(entry(2))
+
+	select {
+	case <-l.Notify():
+	default:
+		t.Fatal("log should notify about the appended entries")
+	}
+
+	entries, appended := l.Since(0)
+	assert.Equal(t, []audit.Entry{entry(1), entry(2)}, entries)
+	assert.EqualValues(t, 2, appended)
+
+	// persisted entries go before the entries appended since the start
+	l.Restore([]audit.Entry{entry(-1), entry(0)})
+
+	assert.Equal(t, []audit.Entry{entry(-1), entry(0), entry(1), entry(2)}, l.Entries(0))
+	assert.Equal(t, []audit.Entry{entry(1), entry(2)}, l.Entries(2))
+
+	l.Append(entry(3))
+
+	entries, appended = l.Since(2)
+	assert.Equal(t, []audit.Entry{entry(3)}, entries)
+	assert.EqualValues(t, 3, appended)
+
+	entries, _ = l.Since(3)
+	assert.Empty(t, entries)
+}
+
+func TestLogTrim(t *testing.T) {
+	l := audit.NewLog()
+
+	for i := 0; i < constants.MaxAuditLogEntries+10; i++ {
+		l.Append(entry(i))
+	}
+
+	entries := l.Entries(0)
+	require.Len(t, entries, constants.MaxAuditLogEntries)
+	assert.Equal(t, entry(10), entries[0])
+
+	// trimmed entries are skipped
+	entries, appended := l.Since(0)
+	assert.Len(t, entries, constants.MaxAuditLogEntries)
+	assert.EqualValues(t, constants.MaxAuditLogEntries+10, appended)
+}
+
+func TestReadWriteEntries(t *testing.T) {
+	var buf bytes.Buffer
+
+	entries := []audit.Entry{entry(1), entry(2)}
+	entries[1].Request = `{"mode":"REBOOT"}`
+	entries[1].Error = "rpc error: code = FailedPrecondition desc = locked"
+
+	require.NoError(t, audit.WriteEntries(&buf, entries))
+
+	// truncated line
+	buf.WriteString(`{"time":"2021-06-01T00:00:03Z","meth`)
+
+	read, err := audit.ReadEntries(&buf)
+	require.NoError(t, err)
+
+	assert.Equal(t, entries, read)
+}
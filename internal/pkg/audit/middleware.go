@@ -0,0 +1,118 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package audit
+
+import (
+	"context"
+	"net"
+
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+)
+
+// UnaryInterceptor returns grpc UnaryServerInterceptor which records the mutating calls.
+func (l *Log) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !IsMutating(info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		resp, err := handler(ctx, req)
+
+		l.Append(NewEntry(ctx, info.FullMethod, req, err))
+
+		return resp, err
+	}
+}
+
+// StreamInterceptor returns grpc StreamServerInterceptor which records the mutating calls.
+//
+// Request parameters of the streaming calls are not recorded.
+func (l *Log) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !IsMutating(info.FullMethod) {
+			return handler(srv, stream)
+		}
+
+		err := handler(srv, stream)
+
+		l.Append(NewEntry(stream.Context(), info.FullMethod, nil, err))
+
+		return err
+	}
+}
+
+// IdentityStreamInterceptor returns grpc StreamServerInterceptor which passes the client identity to the backends in the metadata.
+//
+// The identity sent by the client is replaced with the identity of the authenticated peer, unless the request
+// is proxied by another node: then the address of the proxying node is appended to the client address.
+func IdentityStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		wrapped := grpc_middleware.WrapServerStream(stream)
+		wrapped.WrappedContext = withIdentity(stream.Context())
+
+		return handler(srv, wrapped)
+	}
+}
+
+func withIdentity(ctx context.Context) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	md = md.Copy()
+
+	address := peerAddress(ctx)
+
+	_, proxied := md["proxyfrom"]
+	clients, peers := md.Get(constants.AuditClientMetadataKey), md.Get(constants.AuditPeerMetadataKey)
+
+	if proxied && len(clients) > 0 && len(peers) > 0 {
+		md.Set(constants.AuditClientMetadataKey, clients[0])
+		md.Set(constants.AuditPeerMetadataKey, peers[0]+" via "+address)
+	} else {
+		md.Set(constants.AuditClientMetadataKey, clientIdentity(ctx))
+		md.Set(constants.AuditPeerMetadataKey, address)
+	}
+
+	return metadata.NewIncomingContext(ctx, md)
+}
+
+func peerAddress(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return "unknown"
+	}
+
+	if p.Addr.Network() == "unix" {
+		return "local"
+	}
+
+	if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+		return host
+	}
+
+	return p.Addr.String()
+}
+
+func clientIdentity(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return "unknown"
+	}
+
+	if p.Addr != nil && p.Addr.Network() == "unix" {
+		return "local"
+	}
+
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.PeerCertificates) == 0 {
+		return "unknown"
+	}
+
+	return info.State.PeerCertificates[0].Subject.String()
+}
@@ -0,0 +1,352 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package cgroups implements inspection of the cgroup hierarchy resource usage and limits.
+package cgroups
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// DefaultRoot is the default cgroup filesystem mount point.
+const DefaultRoot = "/sys/fs/cgroup"
+
+// Version of the cgroup hierarchy.
+type Version int
+
+// Cgroup versions.
+const (
+	// V1 is the legacy hierarchy with a mount point per controller.
+	V1 Version = 1
+	// V2 is the unified hierarchy.
+	V2 Version = 2
+)
+
+// v1LimitUnlimited is the threshold for cgroup v1 memory limits which are considered to be unlimited.
+//
+// Kernel reports unlimited memory limit as page-aligned max int64 value.
+const v1LimitUnlimited = 1 << 62
+
+// Stat describes resource usage and limits of a single cgroup.
+type Stat struct {
+	// Path relative to the hierarchy root, root cgroup is `/`.
+	Path string
+	// Processes is the number of processes in the cgroup (not including child cgroups).
+	Processes int
+	// CPUUsage is the cumulative CPU time in nanoseconds.
+	CPUUsage uint64
+	// CPUQuota and CPUPeriod describe CPU bandwidth limit in microseconds, CPUQuota is 0 if not limited.
+	CPUQuota  uint64
+	CPUPeriod uint64
+	// CPUWeight is cpu.weight for cgroup v2 and cpu.shares for cgroup v1.
+	CPUWeight uint64
+	// MemoryUsage in bytes.
+	MemoryUsage uint64
+	// MemoryLimit in bytes, 0 if not limited.
+	MemoryLimit uint64
+	// IOReadBytes and IOWriteBytes are the cumulative number of bytes read from and written to block devices.
+	IOReadBytes  uint64
+	IOWriteBytes uint64
+}
+
+// DetectVersion detects the version of the cgroup hierarchy mounted at root.
+func DetectVersion(root string) Version {
+	if _, err := os.Stat(filepath.Join(root, "cgroup.controllers")); err == nil {
+		return V2
+	}
+
+	return V1
+}
+
+// Walk reads resource usage and limits of the cgroup at path (relative to the hierarchy root) and its descendants.
+//
+// Cgroups are returned in lexical order of the path, so that parent always precedes its children.
+// If maxDepth is positive, descendants deeper than maxDepth levels below path are skipped.
+func Walk(root, path string, maxDepth int) (Version, []Stat, error) {
+	version := DetectVersion(root)
+
+	// for cgroup v1, memory controller hierarchy defines the cgroup tree
+	hierarchy := root
+	if version == V1 {
+		hierarchy = filepath.Join(root, "memory")
+	}
+
+	path = filepath.Join("/", path)
+	start := filepath.Join(hierarchy, path)
+
+	var stats []Stat
+
+	err := filepath.Walk(start, func(dir string, info os.FileInfo, err error) error {
+		if err != nil {
+			if dir != start && os.IsNotExist(err) {
+				// cgroup was removed while walking
+				return nil
+			}
+
+			return err
+		}
+
+		if !info.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(hierarchy, dir)
+		if err != nil {
+			return err
+		}
+
+		rel = filepath.Join("/", rel)
+
+		if maxDepth > 0 && depth(path, rel) > maxDepth {
+			return filepath.SkipDir
+		}
+
+		var stat Stat
+
+		switch version {
+		case V1:
+			stat, err = readV1(root, rel)
+		case V2:
+			stat, err = readV2(root, rel)
+		}
+
+		if err != nil {
+			return fmt.Errorf("error reading cgroup %q: %w", rel, err)
+		}
+
+		stats = append(stats, stat)
+
+		return nil
+	})
+
+	return version, stats, err
+}
+
+func depth(base, path string) int {
+	rel := strings.Trim(strings.TrimPrefix(path, base), "/")
+	if rel == "" {
+		return 0
+	}
+
+	return strings.Count(rel, "/") + 1
+}
+
+func readV2(root, path string) (Stat, error) {
+	dir := filepath.Join(root, path)
+
+	stat := Stat{Path: path}
+
+	var err error
+
+	if stat.Processes, err = countLines(filepath.Join(dir, "cgroup.procs")); err != nil {
+		return stat, err
+	}
+
+	cpuStat, err := readKeyValues(filepath.Join(dir, "cpu.stat"))
+	if err != nil {
+		return stat, err
+	}
+
+	// usage is reported in microseconds
+	stat.CPUUsage = cpuStat["usage_usec"] * 1000
+
+	cpuMax, err := readFields(filepath.Join(dir, "cpu.max"))
+	if err != nil {
+		return stat, err
+	}
+
+	if len(cpuMax) == 2 {
+		if cpuMax[0] != "max" {
+			stat.CPUQuota, _ = strconv.ParseUint(cpuMax[0], 10, 64) //nolint:errcheck
+		}
+
+		stat.CPUPeriod, _ = strconv.ParseUint(cpuMax[1], 10, 64) //nolint:errcheck
+	}
+
+	if stat.CPUWeight, err = readUint(filepath.Join(dir, "cpu.weight")); err != nil {
+		return stat, err
+	}
+
+	if stat.MemoryUsage, err = readUint(filepath.Join(dir, "memory.current")); err != nil {
+		return stat, err
+	}
+
+	if stat.MemoryLimit, err = readUint(filepath.Join(dir, "memory.max")); err != nil {
+		return stat, err
+	}
+
+	contents, err := readFile(filepath.Join(dir, "io.stat"))
+	if err != nil {
+		return stat, err
+	}
+
+	// 8:0 rbytes=1459200 wbytes=314773504 rios=192 wios=353 dbytes=0 dios=0
+	for _, line := range strings.Split(contents, "\n") {
+		for _, field := range strings.Fields(line) {
+			kv := strings.SplitN(field, "=", 2)
+			if len(kv) != 2 {
+				continue
+			}
+
+			v, err := strconv.ParseUint(kv[1], 10, 64)
+			if err != nil {
+				continue
+			}
+
+			switch kv[0] {
+			case "rbytes":
+				stat.IOReadBytes += v
+			case "wbytes":
+				stat.IOWriteBytes += v
+			}
+		}
+	}
+
+	return stat, nil
+}
+
+func readV1(root, path string) (Stat, error) {
+	controller := func(name, file string) string {
+		return filepath.Join(root, name, path, file)
+	}
+
+	stat := Stat{Path: path}
+
+	var err error
+
+	if stat.Processes, err = countLines(controller("memory", "cgroup.procs")); err != nil {
+		return stat, err
+	}
+
+	if stat.CPUUsage, err = readUint(controller("cpuacct", "cpuacct.usage")); err != nil {
+		return stat, err
+	}
+
+	quota, err := readFields(controller("cpu", "cpu.cfs_quota_us"))
+	if err != nil {
+		return stat, err
+	}
+
+	// quota is -1 if not limited
+	if len(quota) == 1 && !strings.HasPrefix(quota[0], "-") {
+		stat.CPUQuota, _ = strconv.ParseUint(quota[0], 10, 64) //nolint:errcheck
+	}
+
+	if stat.CPUPeriod, err = readUint(controller("cpu", "cpu.cfs_period_us")); err != nil {
+		return stat, err
+	}
+
+	if stat.CPUWeight, err = readUint(controller("cpu", "cpu.shares")); err != nil {
+		return stat, err
+	}
+
+	if stat.MemoryUsage, err = readUint(controller("memory", "memory.usage_in_bytes")); err != nil {
+		return stat, err
+	}
+
+	if stat.MemoryLimit, err = readUint(controller("memory", "memory.limit_in_bytes")); err != nil {
+		return stat, err
+	}
+
+	if stat.MemoryLimit >= v1LimitUnlimited {
+		stat.MemoryLimit = 0
+	}
+
+	contents, err := readFile(controller("blkio", "blkio.throttle.io_service_bytes"))
+	if err != nil {
+		return stat, err
+	}
+
+	// 8:0 Read 1459200
+	for _, line := range strings.Split(contents, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 3 {
+			// skip `Total` line
+			continue
+		}
+
+		v, err := strconv.ParseUint(fields[2], 10, 64)
+		if err != nil {
+			continue
+		}
+
+		switch fields[1] {
+		case "Read":
+			stat.IOReadBytes += v
+		case "Write":
+			stat.IOWriteBytes += v
+		}
+	}
+
+	return stat, nil
+}
+
+// readFile returns file contents, missing file (e.g. controller is not enabled) is not an error.
+func readFile(path string) (string, error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+
+		return "", err
+	}
+
+	return string(contents), nil
+}
+
+func readFields(path string) ([]string, error) {
+	contents, err := readFile(path)
+
+	return strings.Fields(contents), err
+}
+
+// readUint reads a single unsigned value, `max` is read as 0.
+func readUint(path string) (uint64, error) {
+	fields, err := readFields(path)
+	if err != nil || len(fields) != 1 || fields[0] == "max" {
+		return 0, err
+	}
+
+	v, err := strconv.ParseUint(fields[0], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("error parsing %q: %w", path, err)
+	}
+
+	return v, nil
+}
+
+func readKeyValues(path string) (map[string]uint64, error) {
+	contents, err := readFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	values := map[string]uint64{}
+
+	for _, line := range strings.Split(contents, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+
+		if v, err := strconv.ParseUint(fields[1], 10, 64); err == nil {
+			values[fields[0]] = v
+		}
+	}
+
+	return values, nil
+}
+
+// countLines returns the number of entries in the file with one entry per line.
+func countLines(path string) (int, error) {
+	fields, err := readFields(path)
+
+	return len(fields), err
+}
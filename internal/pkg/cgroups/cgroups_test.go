@@ -0,0 +1,101 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package cgroups_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/internal/pkg/cgroups"
+)
+
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	for path, contents := range files {
+		path = filepath.Join(root, path)
+
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, ioutil.WriteFile(path, []byte(contents), 0o644))
+	}
+}
+
+func TestWalkV2(t *testing.T) {
+	root := t.TempDir()
+
+	writeFiles(t, root, map[string]string{
+		"cgroup.controllers":                    "cpu io memory pids\n",
+		"cgroup.procs":                          "1\n",
+		"cpu.stat":                              "usage_usec 2000000\nuser_usec 1500000\nsystem_usec 500000\n",
+		"kubepods/cgroup.procs":                 "",
+		"kubepods/cpu.stat":                     "usage_usec 1000\n",
+		"kubepods/cpu.max":                      "max 100000\n",
+		"kubepods/cpu.weight":                   "100\n",
+		"kubepods/memory.current":               "1048576\n",
+		"kubepods/memory.max":                   "max\n",
+		"kubepods/io.stat":                      "8:0 rbytes=1024 wbytes=2048 rios=1 wios=2\n8:16 rbytes=1 wbytes=2 rios=1 wios=1\n",
+		"kubepods/besteffort/cpu.max":           "50000 100000\n",
+		"kubepods/besteffort/memory.max":        "536870912\n",
+		"kubepods/besteffort/pod1/cgroup.procs": "10\n11\n",
+		"system/cgroup.procs":                   "20\n",
+	})
+
+	version, stats, err := cgroups.Walk(root, "", 0)
+	require.NoError(t, err)
+
+	assert.Equal(t, cgroups.V2, version)
+	assert.Equal(t, []cgroups.Stat{
+		{Path: "/", Processes: 1, CPUUsage: 2000000000},
+		{Path: "/kubepods", CPUUsage: 1000000, CPUPeriod: 100000, CPUWeight: 100, MemoryUsage: 1048576, IOReadBytes: 1025, IOWriteBytes: 2050},
+		{Path: "/kubepods/besteffort", CPUQuota: 50000, CPUPeriod: 100000, MemoryLimit: 536870912},
+		{Path: "/kubepods/besteffort/pod1", Processes: 2},
+		{Path: "/system", Processes: 1},
+	}, stats)
+
+	_, stats, err = cgroups.Walk(root, "/kubepods", 1)
+	require.NoError(t, err)
+
+	paths := make([]string, 0, len(stats))
+
+	for _, stat := range stats {
+		paths = append(paths, stat.Path)
+	}
+
+	assert.Equal(t, []string{"/kubepods", "/kubepods/besteffort"}, paths)
+
+	_, _, err = cgroups.Walk(root, "/missing", 0)
+	assert.Error(t, err)
+}
+
+func TestWalkV1(t *testing.T) {
+	root := t.TempDir()
+
+	writeFiles(t, root, map[string]string{
+		"memory/cgroup.procs":                     "1\n2\n",
+		"memory/memory.usage_in_bytes":            "4096\n",
+		"memory/memory.limit_in_bytes":            "9223372036854771712\n",
+		"cpuacct/cpuacct.usage":                   "123456789\n",
+		"cpu/cpu.cfs_quota_us":                    "-1\n",
+		"cpu/cpu.cfs_period_us":                   "100000\n",
+		"cpu/cpu.shares":                          "1024\n",
+		"blkio/blkio.throttle.io_service_bytes":   "8:0 Read 100\n8:0 Write 200\n8:0 Sync 300\n8:0 Async 0\n8:0 Total 300\nTotal 300\n",
+		"memory/podruntime/cgroup.procs":          "3\n",
+		"memory/podruntime/memory.limit_in_bytes": "268435456\n",
+		"cpu/podruntime/cpu.cfs_quota_us":         "200000\n",
+		"cpu/podruntime/cpu.cfs_period_us":        "100000\n",
+	})
+
+	version, stats, err := cgroups.Walk(root, "/", 0)
+	require.NoError(t, err)
+
+	assert.Equal(t, cgroups.V1, version)
+	assert.Equal(t, []cgroups.Stat{
+		{Path: "/", Processes: 2, CPUUsage: 123456789, CPUPeriod: 100000, CPUWeight: 1024, MemoryUsage: 4096, IOReadBytes: 100, IOWriteBytes: 200},
+		{Path: "/podruntime", Processes: 1, CPUQuota: 200000, CPUPeriod: 100000, MemoryLimit: 268435456},
+	}, stats)
+}
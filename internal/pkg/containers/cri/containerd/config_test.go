@@ -7,6 +7,7 @@ package containerd_test
 import (
 	"testing"
 
+	"github.com/BurntSushi/toml"
 	"github.com/stretchr/testify/suite"
 	"github.com/talos-systems/crypto/x509"
 
@@ -74,7 +75,7 @@ func (suite *ConfigSuite) TestGenerateRegistriesConfig() {
 		},
 	}
 
-	files, err := containerd.GenerateRegistriesConfig(cfg)
+	files, err := containerd.GenerateCRIConfig(cfg, &v1alpha1.CRIConfig{}, &v1alpha1.NVIDIAConfig{}, false)
 	suite.Require().NoError(err)
 	suite.Assert().Equal([]config.File{
 		&v1alpha1.MachineFile{
@@ -98,6 +99,10 @@ func (suite *ConfigSuite) TestGenerateRegistriesConfig() {
 		&v1alpha1.MachineFile{
 			FileContent: `[plugins]
   [plugins.cri]
+    [plugins.cri.containerd]
+      [plugins.cri.containerd.runtimes]
+        [plugins.cri.containerd.runtimes.runc]
+          runtime_type = "io.containerd.runc.v2"
     [plugins.cri.registry]
       [plugins.cri.registry.mirrors]
         [plugins.cri.registry.mirrors."docker.io"]
@@ -122,6 +127,149 @@ func (suite *ConfigSuite) TestGenerateRegistriesConfig() {
 	}, files)
 }
 
+func (suite *ConfigSuite) TestGenerateRegistriesConfigImageCache() {
+	cfg := &mockConfig{
+		mirrors: map[string]*v1alpha1.RegistryMirrorConfig{
+			"docker.io": {
+				MirrorEndpoints: []string{"https://registry-1.docker.io"},
+			},
+		},
+	}
+
+	files, err := containerd.GenerateCRIConfig(cfg, &v1alpha1.CRIConfig{}, &v1alpha1.NVIDIAConfig{}, true)
+	suite.Require().NoError(err)
+	suite.Assert().Equal([]config.File{
+		&v1alpha1.MachineFile{
+			FileContent: `[plugins]
+  [plugins.cri]
+    [plugins.cri.containerd]
+      [plugins.cri.containerd.runtimes]
+        [plugins.cri.containerd.runtimes.runc]
+          runtime_type = "io.containerd.runc.v2"
+    [plugins.cri.registry]
+      [plugins.cri.registry.mirrors]
+        [plugins.cri.registry.mirrors."*"]
+          endpoint = ["http://127.0.0.1:50007"]
+        [plugins.cri.registry.mirrors."docker.io"]
+          endpoint = ["http://127.0.0.1:50007", "https://registry-1.docker.io"]
+      [plugins.cri.registry.configs]
+`,
+			FilePermissions: 0o644,
+			FilePath:        constants.CRIContainerdConfig,
+			FileOp:          "append",
+		},
+	}, files)
+}
+
+func (suite *ConfigSuite) TestGenerateCRIConfigSandboxImage() {
+	files, err := containerd.GenerateCRIConfig(&mockConfig{}, &v1alpha1.CRIConfig{
+		CRISandboxImage: "registry.example.com/pause:3.5",
+	}, &v1alpha1.NVIDIAConfig{}, false)
+	suite.Require().NoError(err)
+	suite.Require().Len(files, 1)
+	suite.Assert().Equal(`[plugins]
+  [plugins.cri]
+    sandbox_image = "registry.example.com/pause:3.5"
+    [plugins.cri.containerd]
+      [plugins.cri.containerd.runtimes]
+        [plugins.cri.containerd.runtimes.runc]
+          runtime_type = "io.containerd.runc.v2"
+    [plugins.cri.registry]
+      [plugins.cri.registry.mirrors]
+      [plugins.cri.registry.configs]
+`, files[0].Content())
+}
+
+func (suite *ConfigSuite) TestGenerateCRIConfigPatches() {
+	cfg := &mockConfig{
+		mirrors: map[string]*v1alpha1.RegistryMirrorConfig{
+			"docker.io": {
+				MirrorEndpoints: []string{"https://registry-1.docker.io"},
+			},
+		},
+	}
+
+	files, err := containerd.GenerateCRIConfig(cfg, &v1alpha1.CRIConfig{
+		CRIConfigPatches: []string{
+			`[plugins.cri.containerd.runtimes.runc.options]
+SystemdCgroup = true
+`,
+			`[plugins.cri]
+max_container_log_line_size = 32768
+
+[plugins.cri.registry.mirrors."docker.io"]
+endpoint = ["https://mirror.example.com"]
+`,
+		},
+	}, &v1alpha1.NVIDIAConfig{}, false)
+	suite.Require().NoError(err)
+	suite.Require().Len(files, 1)
+
+	var patched struct {
+		Plugins struct {
+			CRI struct {
+				MaxContainerLogLineSize int `toml:"max_container_log_line_size"`
+				Containerd              struct {
+					Runtimes map[string]struct {
+						RuntimeType string `toml:"runtime_type"`
+						Options     struct {
+							SystemdCgroup bool
+						} `toml:"options"`
+					} `toml:"runtimes"`
+				} `toml:"containerd"`
+				Registry struct {
+					Mirrors map[string]struct {
+						Endpoints []string `toml:"endpoint"`
+					} `toml:"mirrors"`
+				} `toml:"registry"`
+			} `toml:"cri"`
+		} `toml:"plugins"`
+	}
+
+	_, err = toml.Decode(files[0].Content(), &patched)
+	suite.Require().NoError(err)
+
+	suite.Assert().Equal(32768, patched.Plugins.CRI.MaxContainerLogLineSize)
+	suite.Assert().Equal("io.containerd.runc.v2", patched.Plugins.CRI.Containerd.Runtimes["runc"].RuntimeType)
+	suite.Assert().True(patched.Plugins.CRI.Containerd.Runtimes["runc"].Options.SystemdCgroup)
+	suite.Assert().Equal([]string{"https://mirror.example.com"}, patched.Plugins.CRI.Registry.Mirrors["docker.io"].Endpoints)
+}
+
+func (suite *ConfigSuite) TestGenerateCRIConfigInvalidPatch() {
+	_, err := containerd.GenerateCRIConfig(&mockConfig{}, &v1alpha1.CRIConfig{
+		CRIConfigPatches: []string{
+			`[plugins.cri]
+sandbox_image = "registry.example.com/pause:3.5"
+`,
+			`[plugins.cri`,
+		},
+	}, &v1alpha1.NVIDIAConfig{}, false)
+	suite.Require().Error(err)
+	suite.Assert().Contains(err.Error(), "error decoding CRI config patch 1")
+}
+
+func (suite *ConfigSuite) TestGenerateCRIConfigNVIDIA() {
+	files, err := containerd.GenerateCRIConfig(&mockConfig{}, &v1alpha1.CRIConfig{}, &v1alpha1.NVIDIAConfig{
+		NVIDIAEnabled: true,
+	}, false)
+	suite.Require().NoError(err)
+	suite.Require().Len(files, 1)
+	suite.Assert().Equal(`[plugins]
+  [plugins.cri]
+    [plugins.cri.containerd]
+      [plugins.cri.containerd.runtimes]
+        [plugins.cri.containerd.runtimes.nvidia]
+          runtime_type = "io.containerd.runc.v2"
+          [plugins.cri.containerd.runtimes.nvidia.options]
+            BinaryName = "/usr/local/bin/nvidia-container-runtime"
+        [plugins.cri.containerd.runtimes.runc]
+          runtime_type = "io.containerd.runc.v2"
+    [plugins.cri.registry]
+      [plugins.cri.registry.mirrors]
+      [plugins.cri.registry.configs]
+`, files[0].Content())
+}
+
 func TestConfigSuite(t *testing.T) {
 	suite.Run(t, new(ConfigSuite))
 }
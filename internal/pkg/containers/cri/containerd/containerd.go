@@ -6,39 +6,52 @@
 package containerd
 
 import (
-	"bytes"
 	"fmt"
 	"path/filepath"
 
-	"github.com/BurntSushi/toml"
-
 	"github.com/talos-systems/talos/pkg/machinery/config"
+	"github.com/talos-systems/talos/pkg/machinery/config/criconfig"
 	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
 	"github.com/talos-systems/talos/pkg/machinery/constants"
 )
 
-// GenerateRegistriesConfig returns a list of extra files.
+// GenerateCRIConfig returns a list of extra files with the CRI plugin configuration.
+//
+// If imageCache is set, the local image cache is put in front of the mirrors for every registry.
+// If NVIDIA support is enabled, the NVIDIA container runtime is configured as an additional runtime.
+// CRI config patches are merged into the generated configuration in order.
 //
-//nolint:gocyclo
-func GenerateRegistriesConfig(r config.Registries) ([]config.File, error) {
+//nolint:gocyclo,cyclop
+func GenerateCRIConfig(r config.Registries, cri config.CRI, nvidia config.NVIDIA, imageCache bool) ([]config.File, error) {
 	caPath := filepath.Join("/var", filepath.Dir(constants.CRIContainerdConfig), "ca")
 	clientPath := filepath.Join("/var", filepath.Dir(constants.CRIContainerdConfig), "client")
 
-	var ctrdCfg Config
-	ctrdCfg.Plugins.CRI.Registry.Mirrors = make(map[string]Mirror)
-	ctrdCfg.Plugins.CRI.Registry.Configs = make(map[string]RegistryConfig)
+	ctrdCfg := criconfig.New(cri, nvidia)
+
+	imageCacheEndpoint := "http://" + constants.ImageCacheAddress
 
 	for mirrorName, mirrorConfig := range r.Mirrors() {
-		ctrdCfg.Plugins.CRI.Registry.Mirrors[mirrorName] = Mirror{Endpoints: mirrorConfig.Endpoints()}
+		endpoints := mirrorConfig.Endpoints()
+
+		if imageCache {
+			endpoints = append([]string{imageCacheEndpoint}, endpoints...)
+		}
+
+		ctrdCfg.Plugins.CRI.Registry.Mirrors[mirrorName] = criconfig.Mirror{Endpoints: endpoints}
+	}
+
+	if _, ok := ctrdCfg.Plugins.CRI.Registry.Mirrors["*"]; imageCache && !ok {
+		// CRI plugin falls back to the default registry endpoint if the image is missing from the mirrors
+		ctrdCfg.Plugins.CRI.Registry.Mirrors["*"] = criconfig.Mirror{Endpoints: []string{imageCacheEndpoint}}
 	}
 
 	var extraFiles []config.File
 
 	for registryHost, hostConfig := range r.Config() {
-		cfg := RegistryConfig{}
+		cfg := criconfig.RegistryConfig{}
 
 		if hostConfig.Auth() != nil {
-			cfg.Auth = &AuthConfig{
+			cfg.Auth = &criconfig.AuthConfig{
 				Username:      hostConfig.Auth().Username(),
 				Password:      hostConfig.Auth().Password(),
 				Auth:          hostConfig.Auth().Auth(),
@@ -47,7 +60,7 @@ func GenerateRegistriesConfig(r config.Registries) ([]config.File, error) {
 		}
 
 		if hostConfig.TLS() != nil {
-			cfg.TLS = &TLSConfig{
+			cfg.TLS = &criconfig.TLSConfig{
 				InsecureSkipVerify: hostConfig.TLS().InsecureSkipVerify(),
 			}
 
@@ -96,9 +109,8 @@ func GenerateRegistriesConfig(r config.Registries) ([]config.File, error) {
 		}
 	}
 
-	var buf bytes.Buffer
-
-	if err := toml.NewEncoder(&buf).Encode(&ctrdCfg); err != nil {
+	content, err := criconfig.Encode(ctrdCfg, cri.ConfigPatches())
+	if err != nil {
 		return nil, err
 	}
 
@@ -106,7 +118,7 @@ func GenerateRegistriesConfig(r config.Registries) ([]config.File, error) {
 	// so we have to append CRI plugin to the main config, as it already contains
 	// configuration pieces for CRI plugin
 	return append(extraFiles, &v1alpha1.MachineFile{
-		FileContent:     buf.String(),
+		FileContent:     string(content),
 		FilePermissions: 0o644,
 		FilePath:        constants.CRIContainerdConfig,
 		FileOp:          "append",
@@ -38,6 +38,8 @@ type PullOption func(*PullOptions)
 // PullOptions configure Pull function.
 type PullOptions struct {
 	SkipIfAlreadyPulled bool
+	Env                 config.Env
+	ImageCache          bool
 }
 
 // WithSkipIfAlreadyPulled skips pulling if image is already pulled and unpacked.
@@ -47,6 +49,20 @@ func WithSkipIfAlreadyPulled() PullOption {
 	}
 }
 
+// WithEnv sets the environment variables of the machine configuration to pick up the proxy settings from.
+func WithEnv(env config.Env) PullOption {
+	return func(opts *PullOptions) {
+		opts.Env = env
+	}
+}
+
+// WithImageCache tries the local image cache before pulling from the registries if enabled.
+func WithImageCache(enabled bool) PullOption {
+	return func(opts *PullOptions) {
+		opts.ImageCache = enabled
+	}
+}
+
 // Pull is a convenience function that wraps the containerd image pull func with
 // retry functionality.
 func Pull(ctx context.Context, reg config.Registries, client *containerd.Client, ref string, opt ...PullOption) (img containerd.Image, err error) {
@@ -68,7 +84,7 @@ func Pull(ctx context.Context, reg config.Registries, client *containerd.Client,
 		}
 	}
 
-	resolver := NewResolver(reg)
+	resolver := NewResolver(reg, opts.Env, opts.ImageCache)
 
 	err = retry.Exponential(PullTimeout, retry.WithUnits(PullRetryInterval), retry.WithErrorLogging(true)).Retry(func() error {
 		if img, err = client.Pull(ctx, ref, containerd.WithPullUnpack, containerd.WithResolver(resolver)); err != nil {
@@ -15,22 +15,32 @@ import (
 
 	"github.com/containerd/containerd/remotes"
 	"github.com/containerd/containerd/remotes/docker"
-	"golang.org/x/net/http/httpproxy"
 
+	"github.com/talos-systems/talos/internal/pkg/proxy"
 	"github.com/talos-systems/talos/pkg/machinery/config"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
 )
 
 // NewResolver builds registry resolver based on Talos configuration.
-func NewResolver(reg config.Registries) remotes.Resolver {
+//
+// Proxy settings are taken from the environment variables of the machine configuration (env).
+// If imageCache is set, the local image cache is tried first for every registry.
+func NewResolver(reg config.Registries, env config.Env, imageCache bool) remotes.Resolver {
+	hosts := RegistryHosts(reg, env)
+
+	if imageCache {
+		hosts = CachedRegistryHosts(hosts)
+	}
+
 	return docker.NewResolver(docker.ResolverOptions{
-		Hosts: RegistryHosts(reg),
+		Hosts: hosts,
 	})
 }
 
 // RegistryHosts returns host configuration per registry.
 //
 //nolint:gocyclo
-func RegistryHosts(reg config.Registries) docker.RegistryHosts {
+func RegistryHosts(reg config.Registries, env config.Env) docker.RegistryHosts {
 	return func(host string) ([]docker.RegistryHost, error) {
 		var registries []docker.RegistryHost
 
@@ -45,7 +55,7 @@ func RegistryHosts(reg config.Registries) docker.RegistryHosts {
 				return nil, fmt.Errorf("error parsing endpoint %q for host %q: %w", endpoint, host, err)
 			}
 
-			transport := newTransport()
+			transport := newTransport(env)
 			client := &http.Client{Transport: transport}
 
 			registryConfig := reg.Config()[u.Host]
@@ -89,6 +99,53 @@ func RegistryHosts(reg config.Registries) docker.RegistryHosts {
 	}
 }
 
+// CachedRegistryHosts puts the local image cache in front of the registry hosts.
+//
+// Images missing from the cache are reported as not found, so that the next host is tried.
+func CachedRegistryHosts(hosts docker.RegistryHosts) docker.RegistryHosts {
+	return func(host string) ([]docker.RegistryHost, error) {
+		registries, err := hosts(host)
+		if err != nil {
+			return nil, err
+		}
+
+		cache := docker.RegistryHost{
+			Client: &http.Client{
+				Transport: &namespaceTransport{
+					namespace: host,
+					transport: &http.Transport{
+						MaxIdleConns:    10,
+						IdleConnTimeout: 30 * time.Second,
+					},
+				},
+			},
+			Host:         constants.ImageCacheAddress,
+			Scheme:       "http",
+			Path:         "/v2",
+			Capabilities: docker.HostCapabilityResolve | docker.HostCapabilityPull,
+		}
+
+		return append([]docker.RegistryHost{cache}, registries...), nil
+	}
+}
+
+// namespaceTransport passes the registry host to the image cache as the `ns` query parameter.
+type namespaceTransport struct {
+	namespace string
+	transport http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *namespaceTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+
+	query := req.URL.Query()
+	query.Set("ns", t.namespace)
+	req.URL.RawQuery = query.Encode()
+
+	return t.transport.RoundTrip(req)
+}
+
 // RegistryEndpoints returns registry endpoints per host using reg.
 func RegistryEndpoints(reg config.Registries, host string) ([]string, error) {
 	var endpoints []string
@@ -158,12 +215,10 @@ func PrepareAuth(auth config.RegistryAuthConfig, host, expectedHost string) (str
 }
 
 // newTransport creates HTTP transport with default settings.
-func newTransport() *http.Transport {
+func newTransport(env config.Env) *http.Transport {
 	return &http.Transport{
 		// work around for  proxy.Do once bug.
-		Proxy: func(req *http.Request) (*url.URL, error) {
-			return httpproxy.FromEnvironment().ProxyFunc()(req.URL)
-		},
+		Proxy: proxy.Func(env),
 		DialContext: (&net.Dialer{
 			Timeout:   30 * time.Second,
 			KeepAlive: 30 * time.Second,
@@ -15,6 +15,7 @@ import (
 	"github.com/talos-systems/talos/internal/pkg/containers/image"
 	"github.com/talos-systems/talos/pkg/machinery/config"
 	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
 )
 
 type mockConfig struct {
@@ -139,7 +140,7 @@ func (suite *ResolverSuite) TestPrepareAuth() {
 }
 
 func (suite *ResolverSuite) TestRegistryHosts() {
-	registryHosts, err := image.RegistryHosts(&mockConfig{})("docker.io")
+	registryHosts, err := image.RegistryHosts(&mockConfig{}, nil)("docker.io")
 	suite.Require().NoError(err)
 	suite.Assert().Len(registryHosts, 1)
 	suite.Assert().Equal("https", registryHosts[0].Scheme)
@@ -155,7 +156,7 @@ func (suite *ResolverSuite) TestRegistryHosts() {
 		},
 	}
 
-	registryHosts, err = image.RegistryHosts(cfg)("docker.io")
+	registryHosts, err = image.RegistryHosts(cfg, nil)("docker.io")
 	suite.Require().NoError(err)
 	suite.Assert().Len(registryHosts, 2)
 	suite.Assert().Equal("http", registryHosts[0].Scheme)
@@ -187,7 +188,7 @@ func (suite *ResolverSuite) TestRegistryHosts() {
 		},
 	}
 
-	registryHosts, err = image.RegistryHosts(cfg)("docker.io")
+	registryHosts, err = image.RegistryHosts(cfg, nil)("docker.io")
 	suite.Require().NoError(err)
 	suite.Assert().Len(registryHosts, 1)
 	suite.Assert().Equal("https", registryHosts[0].Scheme)
@@ -215,6 +216,69 @@ func (suite *ResolverSuite) TestRegistryHosts() {
 	suite.Assert().Equal("Basic cm9vdDpzZWNyZXQ=", req.Header.Get("Authorization"))
 }
 
+func (suite *ResolverSuite) TestCachedRegistryHosts() {
+	cfg := &mockConfig{
+		mirrors: map[string]*v1alpha1.RegistryMirrorConfig{
+			"docker.io": {
+				MirrorEndpoints: []string{"https://some.host"},
+			},
+		},
+	}
+
+	registryHosts, err := image.CachedRegistryHosts(image.RegistryHosts(cfg, nil))("docker.io")
+	suite.Require().NoError(err)
+	suite.Require().Len(registryHosts, 2)
+	suite.Assert().Equal("http", registryHosts[0].Scheme)
+	suite.Assert().Equal(constants.ImageCacheAddress, registryHosts[0].Host)
+	suite.Assert().Equal("/v2", registryHosts[0].Path)
+	suite.Assert().Nil(registryHosts[0].Authorizer)
+	suite.Assert().Equal("https", registryHosts[1].Scheme)
+	suite.Assert().Equal("some.host", registryHosts[1].Host)
+
+	registryHosts, err = image.CachedRegistryHosts(image.RegistryHosts(cfg, nil))("quay.io")
+	suite.Require().NoError(err)
+	suite.Require().Len(registryHosts, 2)
+	suite.Assert().Equal(constants.ImageCacheAddress, registryHosts[0].Host)
+	suite.Assert().Equal("quay.io", registryHosts[1].Host)
+}
+
+func (suite *ResolverSuite) TestRegistryHostsProxy() {
+	cfg := &mockConfig{
+		mirrors: map[string]*v1alpha1.RegistryMirrorConfig{
+			"docker.io": {
+				MirrorEndpoints: []string{"http://10.5.0.1:5000", "https://mirror.internal.example.com", "https://registry-1.docker.io"},
+			},
+		},
+	}
+
+	env := map[string]string{
+		"HTTP_PROXY":  "http://proxy.example.com:3128",
+		"HTTPS_PROXY": "http://proxy.example.com:3128",
+		"NO_PROXY":    "10.0.0.0/8,.internal.example.com",
+	}
+
+	registryHosts, err := image.RegistryHosts(cfg, env)("docker.io")
+	suite.Require().NoError(err)
+	suite.Require().Len(registryHosts, 3)
+
+	for i, expected := range []string{"", "", "http://proxy.example.com:3128"} {
+		registryHost := registryHosts[i]
+
+		req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s://%s%s", registryHost.Scheme, registryHost.Host, registryHost.Path), nil) //nolint:noctx
+		suite.Require().NoError(err)
+
+		proxyURL, err := registryHost.Client.Transport.(*http.Transport).Proxy(req)
+		suite.Require().NoError(err)
+
+		if expected == "" {
+			suite.Assert().Nil(proxyURL, registryHost.Host)
+		} else {
+			suite.Require().NotNil(proxyURL, registryHost.Host)
+			suite.Assert().Equal(expected, proxyURL.String())
+		}
+	}
+}
+
 func TestResolverSuite(t *testing.T) {
 	suite.Run(t, new(ResolverSuite))
 }
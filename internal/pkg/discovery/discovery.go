@@ -0,0 +1,253 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package discovery implements a client of the discovery service used to exchange node information between the cluster members.
+//
+// Discovery service stores opaque affiliate records grouped by the cluster ID:
+//
+//	PUT {endpoint}/v1/clusters/{clusterID}/affiliates/{affiliateID}  {"data": "...", "ttl": 300} -> {"reflectedAddress": "..."}
+//	GET {endpoint}/v1/clusters/{clusterID}/affiliates                -> {"affiliates": [{"id": "...", "data": "..."}]}
+//
+// Affiliate data is encrypted with the key derived from the cluster secret, so the service can't read it,
+// and the service cluster ID is derived from the cluster ID. Both the cluster ID and the secret are never rotated,
+// so that the cluster members always find each other.
+// Records expire after the TTL (in seconds) unless refreshed. Service reports the address the request came from,
+// which is the public address of the node if it's behind NAT. Any response status other than 200 is an error.
+//
+// Talos doesn't ship the discovery service, the protocol is described in the KubeSpan guide.
+package discovery
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"time"
+)
+
+// Affiliate describes a cluster member.
+type Affiliate struct {
+	NodeID   string `json:"nodeId"`
+	Hostname string `json:"hostname"`
+
+	// MachineType is the Talos machine type (init, controlplane or join).
+	MachineType string `json:"machineType,omitempty"`
+	// OperatingSystem is the OS name and version, e.g. "Talos (v0.10.0)".
+	OperatingSystem string `json:"operatingSystem,omitempty"`
+	// KubernetesVersion is the version of the kubelet.
+	KubernetesVersion string `json:"kubernetesVersion,omitempty"`
+
+	// NodeAddresses are the addresses of the node on the regular networks.
+	NodeAddresses []string `json:"nodeAddresses"`
+
+	// KubeSpan mesh settings of the node.
+	KubeSpanPublicKey string   `json:"kubespanPublicKey"`
+	KubeSpanAddress   string   `json:"kubespanAddress"`
+	KubeSpanEndpoints []string `json:"kubespanEndpoints"`
+}
+
+type updateRequest struct {
+	Data string `json:"data"`
+	TTL  int    `json:"ttl"`
+}
+
+type updateResponse struct {
+	ReflectedAddress string `json:"reflectedAddress"`
+}
+
+type listResponse struct {
+	Affiliates []struct {
+		ID   string `json:"id"`
+		Data string `json:"data"`
+	} `json:"affiliates"`
+}
+
+// Client of the discovery service.
+type Client struct {
+	endpoint   *url.URL
+	clusterID  string
+	aead       cipher.AEAD
+	httpClient *http.Client
+}
+
+// NewClient creates a discovery service client for the cluster identified by the cluster ID.
+func NewClient(endpoint, clusterID, clusterSecret string) (*Client, error) {
+	if endpoint == "" {
+		return nil, fmt.Errorf("discovery endpoint is not set")
+	}
+
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing discovery endpoint: %w", err)
+	}
+
+	if clusterID == "" || clusterSecret == "" {
+		return nil, fmt.Errorf("cluster ID and secret are not set")
+	}
+
+	key := deriveKey("discovery cluster key", []byte(clusterSecret))
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		endpoint:  u,
+		clusterID: ClusterID(clusterID),
+		aead:      aead,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}, nil
+}
+
+// ClusterID derives the discovery service cluster ID from the cluster ID.
+func ClusterID(clusterID string) string {
+	return base64.RawURLEncoding.EncodeToString(deriveKey("discovery cluster id", []byte(clusterID)))
+}
+
+func deriveKey(purpose string, secret []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte(purpose)) //nolint:errcheck
+	h.Write(secret)          //nolint:errcheck
+
+	return h.Sum(nil)
+}
+
+func (c *Client) affiliatesURL(elem ...string) string {
+	u := *c.endpoint
+	u.Path = path.Join(append([]string{u.Path, "v1", "clusters", c.clusterID, "affiliates"}, elem...)...)
+
+	return u.String()
+}
+
+// Update publishes the affiliate record of this node, and returns the address the service sees the request from.
+func (c *Client) Update(ctx context.Context, affiliate *Affiliate, ttl time.Duration) (string, error) {
+	data, err := c.encrypt(affiliate)
+	if err != nil {
+		return "", err
+	}
+
+	body, err := json.Marshal(updateRequest{
+		Data: data,
+		TTL:  int(ttl / time.Second),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	var resp updateResponse
+
+	if err = c.do(ctx, http.MethodPut, c.affiliatesURL(affiliate.NodeID), bytes.NewReader(body), &resp); err != nil {
+		return "", err
+	}
+
+	return resp.ReflectedAddress, nil
+}
+
+// List returns the affiliates of the cluster.
+//
+// Records which can't be decrypted are skipped.
+func (c *Client) List(ctx context.Context) ([]*Affiliate, error) {
+	var resp listResponse
+
+	if err := c.do(ctx, http.MethodGet, c.affiliatesURL(), nil, &resp); err != nil {
+		return nil, err
+	}
+
+	affiliates := make([]*Affiliate, 0, len(resp.Affiliates))
+
+	for _, record := range resp.Affiliates {
+		affiliate, err := c.decrypt(record.Data)
+		if err != nil || affiliate.NodeID != record.ID {
+			continue
+		}
+
+		affiliates = append(affiliates, affiliate)
+	}
+
+	return affiliates, nil
+}
+
+func (c *Client) do(ctx context.Context, method, reqURL string, body io.Reader, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, body)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close() //nolint:errcheck
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("discovery service %s %s: unexpected status %s: %s", method, reqURL, resp.Status, bytes.TrimSpace(respBody))
+	}
+
+	return json.Unmarshal(respBody, out)
+}
+
+func (c *Client) encrypt(affiliate *Affiliate) (string, error) {
+	plaintext, err := json.Marshal(affiliate)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, c.aead.NonceSize())
+
+	if _, err = io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(c.aead.Seal(nonce, nonce, plaintext, []byte(c.clusterID))), nil
+}
+
+func (c *Client) decrypt(data string) (*Affiliate, error) {
+	ciphertext, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ciphertext) < c.aead.NonceSize() {
+		return nil, fmt.Errorf("ciphertext is too short")
+	}
+
+	plaintext, err := c.aead.Open(nil, ciphertext[:c.aead.NonceSize()], ciphertext[c.aead.NonceSize():], []byte(c.clusterID))
+	if err != nil {
+		return nil, err
+	}
+
+	var affiliate Affiliate
+
+	if err = json.Unmarshal(plaintext, &affiliate); err != nil {
+		return nil, err
+	}
+
+	return &affiliate, nil
+}
@@ -0,0 +1,170 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package discovery_test
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/internal/pkg/discovery"
+)
+
+// mockService is a minimal in-memory discovery service.
+type mockService struct {
+	mu       sync.Mutex
+	clusters map[string]map[string]string
+}
+
+func (svc *mockService) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	// /v1/clusters/{clusterID}/affiliates[/{affiliateID}]
+	parts := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	if len(parts) < 4 || parts[0] != "v1" || parts[1] != "clusters" || parts[3] != "affiliates" {
+		http.NotFound(w, req)
+
+		return
+	}
+
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+
+	affiliates := svc.clusters[parts[2]]
+
+	switch {
+	case req.Method == http.MethodPut && len(parts) == 5:
+		var body struct {
+			Data string `json:"data"`
+		}
+
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		if affiliates == nil {
+			affiliates = map[string]string{}
+			svc.clusters[parts[2]] = affiliates
+		}
+
+		affiliates[parts[4]] = body.Data
+
+		host, _, _ := net.SplitHostPort(req.RemoteAddr) //nolint:errcheck
+
+		json.NewEncoder(w).Encode(map[string]string{"reflectedAddress": host}) //nolint:errcheck
+	case req.Method == http.MethodGet && len(parts) == 4:
+		type record struct {
+			ID   string `json:"id"`
+			Data string `json:"data"`
+		}
+
+		resp := struct {
+			Affiliates []record `json:"affiliates"`
+		}{
+			Affiliates: []record{},
+		}
+
+		for id, data := range affiliates {
+			resp.Affiliates = append(resp.Affiliates, record{ID: id, Data: data})
+		}
+
+		json.NewEncoder(w).Encode(resp) //nolint:errcheck
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func TestClient(t *testing.T) {
+	svc := &mockService{
+		clusters: map[string]map[string]string{},
+	}
+
+	srv := httptest.NewServer(svc)
+	defer srv.Close()
+
+	ctx := context.Background()
+
+	client1, err := discovery.NewClient(srv.URL, "cluster-id", "cluster-secret")
+	require.NoError(t, err)
+
+	client2, err := discovery.NewClient(srv.URL+"/", "cluster-id", "cluster-secret")
+	require.NoError(t, err)
+
+	otherCluster, err := discovery.NewClient(srv.URL, "other-cluster-id", "cluster-secret")
+	require.NoError(t, err)
+
+	// same cluster ID, but the data can't be decrypted
+	wrongSecret, err := discovery.NewClient(srv.URL, "cluster-id", "wrong-secret")
+	require.NoError(t, err)
+
+	_, err = discovery.NewClient("", "cluster-id", "cluster-secret")
+	require.Error(t, err)
+
+	_, err = discovery.NewClient(srv.URL, "cluster-id", "")
+	require.Error(t, err)
+
+	affiliate1 := &discovery.Affiliate{
+		NodeID:            "node-1",
+		Hostname:          "node-1.example.com",
+		MachineType:       "controlplane",
+		OperatingSystem:   "Talos (v0.10.0)",
+		KubernetesVersion: "v1.21.1",
+		NodeAddresses:     []string{"10.0.0.1"},
+		KubeSpanPublicKey: "key1",
+		KubeSpanAddress:   "fd50:1::1",
+		KubeSpanEndpoints: []string{"10.0.0.1:51820"},
+	}
+
+	reflected, err := client1.Update(ctx, affiliate1, 5*time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, "127.0.0.1", reflected)
+
+	_, err = client2.Update(ctx, &discovery.Affiliate{
+		NodeID:            "node-2",
+		KubeSpanPublicKey: "key2",
+	}, 5*time.Minute)
+	require.NoError(t, err)
+
+	_, err = otherCluster.Update(ctx, &discovery.Affiliate{
+		NodeID: "node-3",
+	}, 5*time.Minute)
+	require.NoError(t, err)
+
+	affiliates, err := client2.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, affiliates, 2)
+
+	for _, affiliate := range affiliates {
+		if affiliate.NodeID == "node-1" {
+			assert.Equal(t, affiliate1, affiliate)
+		} else {
+			assert.Equal(t, "key2", affiliate.KubeSpanPublicKey)
+		}
+	}
+
+	// service stores only encrypted data
+	for _, data := range svc.clusters[discovery.ClusterID("cluster-id")] {
+		assert.NotContains(t, data, "node-1.example.com")
+	}
+
+	affiliates, err = otherCluster.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, affiliates, 1)
+	assert.Equal(t, "node-3", affiliates[0].NodeID)
+
+	// records encrypted with another secret are skipped
+	affiliates, err = wrongSecret.List(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, affiliates)
+}
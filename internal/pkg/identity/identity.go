@@ -0,0 +1,335 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package identity manages the persistent machine identity.
+//
+// The identity is an ed25519 key pair generated on the first boot and stored
+// on the STATE partition. The node ID is derived from the public key, so it is
+// stable across reboots and upgrades, and changes only when STATE is wiped.
+//
+// The private key is not used to sign anything yet, it is kept to let the node prove
+// the ownership of the node ID later on.
+//
+// The private key might be sealed with the KMS plugin. The sealed key is stored
+// along with the public key, so the node ID is available without the KMS.
+package identity
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	stdx509 "crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/uuid"
+
+	kmsapi "github.com/talos-systems/talos/internal/pkg/kms/api/v2"
+)
+
+const systemUUIDPath = "/sys/class/dmi/id/product_uuid"
+
+const (
+	pemTypePrivateKey       = "PRIVATE KEY"
+	pemTypeSealedPrivateKey = "SEALED PRIVATE KEY"
+
+	headerPublicKey        = "Public-Key"
+	headerKeyID            = "Key-Id"
+	headerAnnotationPrefix = "Annotation-"
+)
+
+// nodeIDNamespace is the namespace of the name-based node ID UUIDs.
+var nodeIDNamespace = uuid.MustParse("dd287121-529a-44bc-8cba-2e8bd4992a9b")
+
+// Identity is the machine identity.
+type Identity struct {
+	// PrivateKey is nil if the key is sealed and it wasn't unsealed yet.
+	PrivateKey ed25519.PrivateKey
+
+	// Sealed is the private key sealed with the KMS, nil if the key is stored in the plaintext.
+	Sealed *SealedKey
+
+	publicKey ed25519.PublicKey
+}
+
+// SealedKey is the private key (PKCS #8) encrypted by the KMS plugin.
+type SealedKey struct {
+	Ciphertext  []byte
+	KeyID       string
+	Annotations map[string][]byte
+}
+
+// KMS is the subset of the KMS plugin API used to seal the identity.
+type KMS interface {
+	Encrypt(ctx context.Context, req *kmsapi.EncryptRequest) (*kmsapi.EncryptResponse, error)
+	Decrypt(ctx context.Context, req *kmsapi.DecryptRequest) (*kmsapi.DecryptResponse, error)
+}
+
+// Generate a new identity.
+func Generate() (*Identity, error) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Identity{
+		PrivateKey: key,
+	}, nil
+}
+
+// Load the identity from the PEM encoded file.
+//
+// The sealed key is not unsealed, see Unseal.
+func Load(path string) (*Identity, error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(contents)
+	if block == nil {
+		return nil, fmt.Errorf("failed to decode identity PEM %q", path)
+	}
+
+	switch block.Type {
+	case pemTypePrivateKey:
+		key, err := parsePrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+
+		return &Identity{
+			PrivateKey: key,
+		}, nil
+	case pemTypeSealedPrivateKey:
+		return parseSealed(block)
+	default:
+		return nil, fmt.Errorf("unexpected identity PEM block type %q", block.Type)
+	}
+}
+
+func parsePrivateKey(der []byte) (ed25519.PrivateKey, error) {
+	key, err := stdx509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse identity key: %w", err)
+	}
+
+	ed25519Key, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("unexpected identity key type %T", key)
+	}
+
+	return ed25519Key, nil
+}
+
+func parseSealed(block *pem.Block) (*Identity, error) {
+	publicKey, err := base64.StdEncoding.DecodeString(block.Headers[headerPublicKey])
+	if err != nil || len(publicKey) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("invalid sealed identity public key")
+	}
+
+	sealed := &SealedKey{
+		Ciphertext: block.Bytes,
+		KeyID:      block.Headers[headerKeyID],
+	}
+
+	for header, value := range block.Headers {
+		if !strings.HasPrefix(header, headerAnnotationPrefix) {
+			continue
+		}
+
+		if sealed.Annotations == nil {
+			sealed.Annotations = map[string][]byte{}
+		}
+
+		if sealed.Annotations[strings.TrimPrefix(header, headerAnnotationPrefix)], err = base64.StdEncoding.DecodeString(value); err != nil {
+			return nil, fmt.Errorf("invalid sealed identity annotation %q: %w", header, err)
+		}
+	}
+
+	return &Identity{
+		Sealed:    sealed,
+		publicKey: publicKey,
+	}, nil
+}
+
+// LoadOrGenerate loads the identity from the file, generating and
+// saving a new one if the file doesn't exist.
+func LoadOrGenerate(path string) (*Identity, error) {
+	id, err := Load(path)
+	if err == nil {
+		return id, nil
+	}
+
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	if id, err = Generate(); err != nil {
+		return nil, err
+	}
+
+	return id, id.Save(path)
+}
+
+// Save the identity to the file as PEM encoded PKCS #8 private key.
+//
+// The sealed key is saved as the ciphertext with the public key, KMS key ID and annotations in the PEM headers.
+// The file is replaced atomically, so the identity is never lost half-written.
+func (id *Identity) Save(path string) error {
+	block, err := id.pemBlock()
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+
+	if err = pem.Encode(&buf, block); err != nil {
+		return err
+	}
+
+	tmpPath := path + ".tmp"
+
+	if err = ioutil.WriteFile(tmpPath, buf.Bytes(), 0o600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpPath, path)
+}
+
+func (id *Identity) pemBlock() (*pem.Block, error) {
+	if id.Sealed == nil {
+		der, err := stdx509.MarshalPKCS8PrivateKey(id.PrivateKey)
+		if err != nil {
+			return nil, err
+		}
+
+		return &pem.Block{Type: pemTypePrivateKey, Bytes: der}, nil
+	}
+
+	headers := map[string]string{
+		headerPublicKey: id.PublicKeyString(),
+		headerKeyID:     id.Sealed.KeyID,
+	}
+
+	for name, value := range id.Sealed.Annotations {
+		headers[headerAnnotationPrefix+name] = base64.StdEncoding.EncodeToString(value)
+	}
+
+	return &pem.Block{Type: pemTypeSealedPrivateKey, Headers: headers, Bytes: id.Sealed.Ciphertext}, nil
+}
+
+// Seal the private key with the KMS.
+//
+// The node ID is passed to the KMS as the request UID, so that the KMS audit log shows the machine.
+// The sealed key is unsealed back before it replaces the plaintext, so a misbehaving KMS can't lock the identity out.
+func (id *Identity) Seal(ctx context.Context, k KMS) error {
+	if id.PrivateKey == nil {
+		return fmt.Errorf("identity private key is not available")
+	}
+
+	der, err := stdx509.MarshalPKCS8PrivateKey(id.PrivateKey)
+	if err != nil {
+		return err
+	}
+
+	resp, err := k.Encrypt(ctx, &kmsapi.EncryptRequest{
+		Plaintext: der,
+		Uid:       id.NodeID(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to seal identity: %w", err)
+	}
+
+	sealed := &Identity{
+		Sealed: &SealedKey{
+			Ciphertext:  resp.Ciphertext,
+			KeyID:       resp.KeyId,
+			Annotations: resp.Annotations,
+		},
+		publicKey: id.PublicKey(),
+	}
+
+	if err = sealed.Unseal(ctx, k); err != nil {
+		return fmt.Errorf("failed to verify sealed identity: %w", err)
+	}
+
+	id.Sealed = sealed.Sealed
+
+	return nil
+}
+
+// Unseal the private key with the KMS.
+//
+// Unsealed key is verified against the stored public key, so the node ID never changes.
+func (id *Identity) Unseal(ctx context.Context, k KMS) error {
+	if id.Sealed == nil {
+		return nil
+	}
+
+	resp, err := k.Decrypt(ctx, &kmsapi.DecryptRequest{
+		Ciphertext:  id.Sealed.Ciphertext,
+		Uid:         id.NodeID(),
+		KeyId:       id.Sealed.KeyID,
+		Annotations: id.Sealed.Annotations,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to unseal identity: %w", err)
+	}
+
+	key, err := parsePrivateKey(resp.Plaintext)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(key.Public().(ed25519.PublicKey), id.PublicKey()) {
+		return fmt.Errorf("unsealed identity key doesn't match the public key")
+	}
+
+	id.PrivateKey = key
+
+	return nil
+}
+
+// PublicKey returns the public part of the identity.
+func (id *Identity) PublicKey() ed25519.PublicKey {
+	if id.PrivateKey == nil {
+		return id.publicKey
+	}
+
+	return id.PrivateKey.Public().(ed25519.PublicKey)
+}
+
+// PublicKeyString returns base64 encoded public key.
+func (id *Identity) PublicKeyString() string {
+	return base64.StdEncoding.EncodeToString(id.PublicKey())
+}
+
+// NodeID returns the node UUID derived from the public key.
+//
+// The UUID is a name-based UUID version 5 (SHA-1) of the public key in the Talos node ID namespace.
+func (id *Identity) NodeID() string {
+	return uuid.NewSHA1(nodeIDNamespace, id.PublicKey()).String()
+}
+
+// SystemUUID returns the SMBIOS system UUID, if available.
+func SystemUUID() string {
+	contents, err := ioutil.ReadFile(systemUUIDPath)
+	if err != nil {
+		return ""
+	}
+
+	return strings.ToLower(strings.TrimSpace(string(contents)))
+}
@@ -0,0 +1,138 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package identity_test
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/internal/pkg/identity"
+	kmsapi "github.com/talos-systems/talos/internal/pkg/kms/api/v2"
+)
+
+func TestLoadOrGenerate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "identity")
+	require.NoError(t, err)
+
+	defer os.RemoveAll(dir) //nolint:errcheck
+
+	path := filepath.Join(dir, "identity.pem")
+
+	id, err := identity.LoadOrGenerate(path)
+	require.NoError(t, err)
+
+	assert.Regexp(t, regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-5[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`), id.NodeID())
+
+	loaded, err := identity.LoadOrGenerate(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, id.NodeID(), loaded.NodeID())
+	assert.Equal(t, id.PublicKeyString(), loaded.PublicKeyString())
+
+	other, err := identity.Generate()
+	require.NoError(t, err)
+
+	assert.NotEqual(t, id.NodeID(), other.NodeID())
+}
+
+func TestLoadInvalid(t *testing.T) {
+	dir, err := ioutil.TempDir("", "identity")
+	require.NoError(t, err)
+
+	defer os.RemoveAll(dir) //nolint:errcheck
+
+	path := filepath.Join(dir, "identity.pem")
+
+	require.NoError(t, ioutil.WriteFile(path, []byte("garbage"), 0o600))
+
+	_, err = identity.LoadOrGenerate(path)
+	assert.Error(t, err)
+}
+
+// fakeKMS "encrypts" by XORing the plaintext with the key byte.
+type fakeKMS struct {
+	key byte
+}
+
+func (k fakeKMS) xor(b []byte) []byte {
+	r := make([]byte, len(b))
+
+	for i := range b {
+		r[i] = b[i] ^ k.key
+	}
+
+	return r
+}
+
+func (k fakeKMS) Encrypt(_ context.Context, req *kmsapi.EncryptRequest) (*kmsapi.EncryptResponse, error) {
+	return &kmsapi.EncryptResponse{
+		Ciphertext:  k.xor(req.Plaintext),
+		KeyId:       "key-1",
+		Annotations: map[string][]byte{"kms.example.com/uid": []byte(req.Uid)},
+	}, nil
+}
+
+func (k fakeKMS) Decrypt(_ context.Context, req *kmsapi.DecryptRequest) (*kmsapi.DecryptResponse, error) {
+	if req.KeyId != "key-1" || string(req.Annotations["kms.example.com/uid"]) != req.Uid {
+		return nil, fmt.Errorf("unexpected key ID or annotations")
+	}
+
+	return &kmsapi.DecryptResponse{
+		Plaintext: k.xor(req.Ciphertext),
+	}, nil
+}
+
+func TestSealUnseal(t *testing.T) {
+	dir, err := ioutil.TempDir("", "identity")
+	require.NoError(t, err)
+
+	defer os.RemoveAll(dir) //nolint:errcheck
+
+	path := filepath.Join(dir, "identity.pem")
+
+	id, err := identity.LoadOrGenerate(path)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	require.NoError(t, id.Seal(ctx, fakeKMS{key: 0x5a}))
+	require.NoError(t, id.Save(path))
+
+	contents, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+
+	assert.NotContains(t, string(contents), "BEGIN PRIVATE KEY")
+
+	sealed, err := identity.Load(path)
+	require.NoError(t, err)
+
+	assert.Nil(t, sealed.PrivateKey)
+	assert.Equal(t, id.NodeID(), sealed.NodeID())
+	assert.Equal(t, id.PublicKeyString(), sealed.PublicKeyString())
+
+	assert.Error(t, sealed.Unseal(ctx, fakeKMS{key: 0x42}))
+	assert.Nil(t, sealed.PrivateKey)
+
+	require.NoError(t, sealed.Unseal(ctx, fakeKMS{key: 0x5a}))
+	assert.Equal(t, id.PrivateKey, sealed.PrivateKey)
+
+	// back to the plaintext
+	sealed.Sealed = nil
+	require.NoError(t, sealed.Save(path))
+
+	loaded, err := identity.Load(path)
+	require.NoError(t, err)
+
+	assert.Nil(t, loaded.Sealed)
+	assert.Equal(t, id.PrivateKey, loaded.PrivateKey)
+}
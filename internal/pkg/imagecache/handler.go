@@ -0,0 +1,162 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package imagecache
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	digest "github.com/opencontainers/go-digest"
+)
+
+// Handler serves the images from the image cache via the read-only subset of the registry HTTP API.
+//
+// If the layout is not set, every image is reported as missing, so that the images are pulled from the registries.
+type Handler struct {
+	Layout *Layout
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Docker-Distribution-API-Version", "registry/2.0")
+
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		writeError(w, http.StatusMethodNotAllowed, "UNSUPPORTED", "image cache is read-only")
+
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/v2/")
+
+	switch {
+	case path == r.URL.Path:
+		writeError(w, http.StatusNotFound, "UNSUPPORTED", "unsupported API version")
+	case path == "":
+		w.WriteHeader(http.StatusOK)
+	default:
+		// registry host is passed by containerd to the mirrors as the `ns` query parameter
+		host := r.URL.Query().Get("ns")
+
+		if name, ref, ok := splitPath(path, "manifests"); ok {
+			h.serveManifest(w, r, host, name, ref)
+
+			return
+		}
+
+		if _, ref, ok := splitPath(path, "blobs"); ok {
+			h.serveBlob(w, r, ref)
+
+			return
+		}
+
+		writeError(w, http.StatusNotFound, "NAME_UNKNOWN", "unsupported request")
+	}
+}
+
+func (h *Handler) serveManifest(w http.ResponseWriter, r *http.Request, host, name, ref string) {
+	if h.Layout == nil {
+		writeError(w, http.StatusNotFound, "MANIFEST_UNKNOWN", ErrNotFound.Error())
+
+		return
+	}
+
+	desc, err := h.Layout.Resolve(host, name, ref)
+	if err != nil {
+		writeLookupError(w, "MANIFEST_UNKNOWN", err)
+
+		return
+	}
+
+	f, err := h.Layout.OpenBlob(desc.Digest)
+	if err != nil {
+		writeLookupError(w, "MANIFEST_UNKNOWN", err)
+
+		return
+	}
+
+	defer f.Close() //nolint:errcheck
+
+	w.Header().Set("Content-Type", desc.MediaType)
+	w.Header().Set("Docker-Content-Digest", desc.Digest.String())
+
+	http.ServeContent(w, r, "", time.Time{}, f)
+}
+
+func (h *Handler) serveBlob(w http.ResponseWriter, r *http.Request, ref string) {
+	if h.Layout == nil {
+		writeError(w, http.StatusNotFound, "BLOB_UNKNOWN", ErrNotFound.Error())
+
+		return
+	}
+
+	dgst, err := digest.Parse(ref)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "DIGEST_INVALID", err.Error())
+
+		return
+	}
+
+	f, err := h.Layout.OpenBlob(dgst)
+	if err != nil {
+		writeLookupError(w, "BLOB_UNKNOWN", err)
+
+		return
+	}
+
+	defer f.Close() //nolint:errcheck
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Docker-Content-Digest", dgst.String())
+
+	http.ServeContent(w, r, "", time.Time{}, f)
+}
+
+// splitPath splits `<name>/<kind>/<reference>` path, repository name might contain slashes.
+func splitPath(path, kind string) (name, ref string, ok bool) {
+	idx := strings.LastIndex(path, "/"+kind+"/")
+	if idx <= 0 {
+		return "", "", false
+	}
+
+	name, ref = path[:idx], path[idx+len(kind)+2:]
+
+	return name, ref, ref != "" && !strings.Contains(ref, "/")
+}
+
+func writeLookupError(w http.ResponseWriter, code string, err error) {
+	if errors.Is(err, ErrNotFound) || os.IsNotExist(err) {
+		writeError(w, http.StatusNotFound, code, err.Error())
+
+		return
+	}
+
+	writeError(w, http.StatusInternalServerError, "UNKNOWN", err.Error())
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	type registryError struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	//nolint:errcheck
+	json.NewEncoder(w).Encode(struct {
+		Errors []registryError `json:"errors"`
+	}{
+		Errors: []registryError{
+			{
+				Code:    code,
+				Message: message,
+			},
+		},
+	})
+}
@@ -0,0 +1,262 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package imagecache_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	digest "github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/internal/pkg/imagecache"
+)
+
+func writeBlob(t *testing.T, dir string, contents []byte) digest.Digest {
+	dgst := digest.FromBytes(contents)
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "blobs", "sha256"), 0o755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "blobs", "sha256", dgst.Encoded()), contents, 0o644))
+
+	return dgst
+}
+
+func writeJSON(t *testing.T, dir string, v interface{}) ocispec.Descriptor {
+	contents, err := json.Marshal(v)
+	require.NoError(t, err)
+
+	return ocispec.Descriptor{
+		Digest: writeBlob(t, dir, contents),
+		Size:   int64(len(contents)),
+	}
+}
+
+// buildLayout builds the OCI image layout with two images: single-platform image and multi-platform image index.
+func buildLayout(t *testing.T) (dir string, layer, manifest, platformManifest digest.Digest) {
+	dir = t.TempDir()
+
+	layerContents := []byte("layer")
+	layer = writeBlob(t, dir, layerContents)
+
+	config := writeJSON(t, dir, ocispec.Image{})
+	config.MediaType = ocispec.MediaTypeImageConfig
+
+	manifestDesc := writeJSON(t, dir, ocispec.Manifest{
+		Config: config,
+		Layers: []ocispec.Descriptor{
+			{
+				MediaType: ocispec.MediaTypeImageLayerGzip,
+				Digest:    layer,
+				Size:      int64(len(layerContents)),
+			},
+		},
+	})
+	manifestDesc.MediaType = ocispec.MediaTypeImageManifest
+	manifest = manifestDesc.Digest
+
+	platformManifestDesc := writeJSON(t, dir, ocispec.Manifest{
+		Config: config,
+	})
+	platformManifestDesc.MediaType = ocispec.MediaTypeImageManifest
+	platformManifestDesc.Platform = &ocispec.Platform{
+		Architecture: "amd64",
+		OS:           "linux",
+	}
+	platformManifest = platformManifestDesc.Digest
+
+	indexDesc := writeJSON(t, dir, ocispec.Index{
+		Manifests: []ocispec.Descriptor{platformManifestDesc},
+	})
+	indexDesc.MediaType = ocispec.MediaTypeImageIndex
+
+	busybox := manifestDesc
+	busybox.Annotations = map[string]string{
+		ocispec.AnnotationRefName: "busybox:1.33",
+	}
+
+	installer := indexDesc
+	installer.Annotations = map[string]string{
+		imagecache.AnnotationImageName: "ghcr.io/talos-systems/installer:v0.10.0",
+		ocispec.AnnotationRefName:      "v0.10.0",
+	}
+
+	index, err := json.Marshal(ocispec.Index{
+		Manifests: []ocispec.Descriptor{busybox, installer},
+	})
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "index.json"), index, 0o644))
+
+	return dir, layer, manifest, platformManifest
+}
+
+func TestLayout(t *testing.T) {
+	dir, _, manifest, platformManifest := buildLayout(t)
+
+	layout, err := imagecache.OpenLayout(dir)
+	require.NoError(t, err)
+	assert.Equal(t, 2, layout.Len())
+
+	desc, err := layout.Resolve("", "library/busybox", "1.33")
+	require.NoError(t, err)
+	assert.Equal(t, manifest, desc.Digest)
+
+	desc, err = layout.Resolve("docker.io", "library/busybox", "1.33")
+	require.NoError(t, err)
+	assert.Equal(t, manifest, desc.Digest)
+
+	_, err = layout.Resolve("quay.io", "library/busybox", "1.33")
+	assert.ErrorIs(t, err, imagecache.ErrNotFound)
+
+	_, err = layout.Resolve("", "library/busybox", "latest")
+	assert.ErrorIs(t, err, imagecache.ErrNotFound)
+
+	desc, err = layout.Resolve("ghcr.io", "talos-systems/installer", "v0.10.0")
+	require.NoError(t, err)
+	assert.Equal(t, ocispec.MediaTypeImageIndex, desc.MediaType)
+
+	// child manifest of the image index is looked up by the digest
+	desc, err = layout.Resolve("ghcr.io", "talos-systems/installer", platformManifest.String())
+	require.NoError(t, err)
+	assert.Equal(t, ocispec.MediaTypeImageManifest, desc.MediaType)
+	assert.Equal(t, platformManifest, desc.Digest)
+
+	_, err = layout.OpenBlob(digest.Digest("sha256:../../index.json"))
+	assert.Error(t, err)
+
+	_, err = imagecache.OpenLayout(filepath.Join(dir, "missing"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestHandler(t *testing.T) {
+	dir, layer, manifest, platformManifest := buildLayout(t)
+
+	layout, err := imagecache.OpenLayout(dir)
+	require.NoError(t, err)
+
+	srv := httptest.NewServer(&imagecache.Handler{Layout: layout})
+	defer srv.Close()
+
+	for _, tt := range []struct {
+		name   string
+		method string
+		path   string
+
+		expectedStatus      int
+		expectedContentType string
+		expectedDigest      string
+		expectedBody        string
+	}{
+		{
+			name:           "ping",
+			path:           "/v2/",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:                "manifest by tag",
+			path:                "/v2/library/busybox/manifests/1.33?ns=docker.io",
+			expectedStatus:      http.StatusOK,
+			expectedContentType: ocispec.MediaTypeImageManifest,
+			expectedDigest:      manifest.String(),
+		},
+		{
+			name:                "manifest by tag without registry host",
+			method:              http.MethodHead,
+			path:                "/v2/library/busybox/manifests/1.33",
+			expectedStatus:      http.StatusOK,
+			expectedContentType: ocispec.MediaTypeImageManifest,
+			expectedDigest:      manifest.String(),
+		},
+		{
+			name:                "manifest by digest",
+			path:                "/v2/talos-systems/installer/manifests/" + platformManifest.String() + "?ns=ghcr.io",
+			expectedStatus:      http.StatusOK,
+			expectedContentType: ocispec.MediaTypeImageManifest,
+			expectedDigest:      platformManifest.String(),
+		},
+		{
+			name:           "missing manifest",
+			path:           "/v2/library/alpine/manifests/3.13?ns=docker.io",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:                "blob",
+			path:                "/v2/library/busybox/blobs/" + layer.String(),
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "application/octet-stream",
+			expectedDigest:      layer.String(),
+			expectedBody:        "layer",
+		},
+		{
+			name:           "missing blob",
+			path:           "/v2/library/busybox/blobs/" + digest.FromString("missing").String(),
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "invalid digest",
+			path:           "/v2/library/busybox/blobs/sha256:1234",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "push",
+			method:         http.MethodPost,
+			path:           "/v2/library/busybox/blobs/uploads/",
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+	} {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			method := tt.method
+			if method == "" {
+				method = http.MethodGet
+			}
+
+			req, err := http.NewRequest(method, srv.URL+tt.path, nil)
+			require.NoError(t, err)
+
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+
+			defer resp.Body.Close() //nolint:errcheck
+
+			body, err := ioutil.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+			assert.Equal(t, "registry/2.0", resp.Header.Get("Docker-Distribution-API-Version"))
+
+			if tt.expectedContentType != "" {
+				assert.Equal(t, tt.expectedContentType, resp.Header.Get("Content-Type"))
+			}
+
+			if tt.expectedDigest != "" {
+				assert.Equal(t, tt.expectedDigest, resp.Header.Get("Docker-Content-Digest"))
+			}
+
+			if tt.expectedBody != "" {
+				assert.Equal(t, tt.expectedBody, string(body))
+			}
+		})
+	}
+}
+
+func TestHandlerNoLayout(t *testing.T) {
+	srv := httptest.NewServer(&imagecache.Handler{})
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/v2/library/busybox/manifests/1.33") //nolint:noctx
+	require.NoError(t, err)
+
+	defer resp.Body.Close() //nolint:errcheck
+
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
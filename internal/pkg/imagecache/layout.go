@@ -0,0 +1,180 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package imagecache implements the local registry serving container images from the OCI image layout.
+package imagecache
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/docker/distribution/reference"
+	digest "github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// ErrNotFound is returned when the image or blob is missing from the cache.
+var ErrNotFound = errors.New("not found in the image cache")
+
+// AnnotationImageName is the annotation containerd (`ctr images export`) sets to the full image reference.
+const AnnotationImageName = "io.containerd.image.name"
+
+// indexFile is the name of the OCI image layout index.
+const indexFile = "index.json"
+
+type image struct {
+	domain string
+	path   string
+	tag    string
+	desc   ocispec.Descriptor
+}
+
+// Layout is the OCI image layout used as the image cache.
+//
+// Images are looked up by the reference from the `io.containerd.image.name` or
+// `org.opencontainers.image.ref.name` annotations of the layout index.
+type Layout struct {
+	dir    string
+	images []image
+}
+
+// OpenLayout reads the index of the OCI image layout in dir.
+func OpenLayout(dir string) (*Layout, error) {
+	f, err := os.Open(filepath.Join(dir, indexFile))
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close() //nolint:errcheck
+
+	var index ocispec.Index
+
+	if err = json.NewDecoder(f).Decode(&index); err != nil {
+		return nil, fmt.Errorf("error decoding image layout index: %w", err)
+	}
+
+	layout := &Layout{
+		dir: dir,
+	}
+
+	for _, desc := range index.Manifests {
+		name := desc.Annotations[AnnotationImageName]
+		if name == "" {
+			name = desc.Annotations[ocispec.AnnotationRefName]
+		}
+
+		// references which are not image names (e.g. bare tags) are skipped
+		named, err := reference.ParseNormalizedNamed(name)
+		if err != nil {
+			continue
+		}
+
+		tagged, ok := reference.TagNameOnly(named).(reference.Tagged)
+		if !ok {
+			continue
+		}
+
+		layout.images = append(layout.images, image{
+			domain: reference.Domain(named),
+			path:   reference.Path(named),
+			tag:    tagged.Tag(),
+			desc:   desc,
+		})
+	}
+
+	return layout, nil
+}
+
+// Len returns the number of images in the cache.
+func (l *Layout) Len() int {
+	return len(l.images)
+}
+
+// Resolve returns the descriptor of the image manifest (or index) by the repository name and tag or digest.
+//
+// Registry host is optional, as containerd doesn't always pass it to the registry mirrors:
+// if it's not set, the first image with the matching repository name is returned.
+func (l *Layout) Resolve(host, name, ref string) (ocispec.Descriptor, error) {
+	if dgst, err := digest.Parse(ref); err == nil {
+		return l.resolveDigest(dgst)
+	}
+
+	for _, img := range l.images {
+		if img.path == name && img.tag == ref && (host == "" || img.domain == host) {
+			return img.desc, nil
+		}
+	}
+
+	return ocispec.Descriptor{}, ErrNotFound
+}
+
+// resolveDigest looks up the manifest by the digest.
+//
+// Manifests of the multi-platform images are not listed in the layout index, so the media type is read from the blob.
+func (l *Layout) resolveDigest(dgst digest.Digest) (ocispec.Descriptor, error) {
+	for _, img := range l.images {
+		if img.desc.Digest == dgst {
+			return img.desc, nil
+		}
+	}
+
+	f, err := l.OpenBlob(dgst)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+
+	defer f.Close() //nolint:errcheck
+
+	st, err := f.Stat()
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+
+	var manifest struct {
+		MediaType string          `json:"mediaType"`
+		Manifests json.RawMessage `json:"manifests"`
+	}
+
+	if err = json.NewDecoder(f).Decode(&manifest); err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("error decoding manifest %s: %w", dgst, err)
+	}
+
+	mediaType := manifest.MediaType
+
+	if mediaType == "" {
+		if manifest.Manifests != nil {
+			mediaType = ocispec.MediaTypeImageIndex
+		} else {
+			mediaType = ocispec.MediaTypeImageManifest
+		}
+	}
+
+	return ocispec.Descriptor{
+		MediaType: mediaType,
+		Digest:    dgst,
+		Size:      st.Size(),
+	}, nil
+}
+
+// OpenBlob opens the blob by the digest.
+func (l *Layout) OpenBlob(dgst digest.Digest) (*os.File, error) {
+	// validation also makes sure the digest can't escape the blobs directory
+	if err := dgst.Validate(); err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(filepath.Join(l.dir, "blobs", dgst.Algorithm().String(), dgst.Encoded()))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	return f, nil
+}
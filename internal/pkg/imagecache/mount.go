@@ -0,0 +1,99 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package imagecache
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/talos-systems/go-blockdevice/blockdevice/filesystem"
+	"github.com/talos-systems/go-blockdevice/blockdevice/probe"
+	"golang.org/x/sys/unix"
+
+	"github.com/talos-systems/talos/internal/pkg/mount"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+)
+
+// Mount discovers the image cache and mounts it read-only.
+//
+// The `IMAGECACHE` partition holds the OCI image layout in the root of the filesystem,
+// the Talos ISO holds it in the `imagecache` directory.
+// Mount returns the path to the OCI image layout and the mount point to unmount once the cache is no longer needed.
+func Mount() (string, *mount.Point, error) {
+	point, partErr := partitionMountPoint()
+	if partErr == nil {
+		if err := point.Mount(); err != nil {
+			return "", nil, fmt.Errorf("error mounting %s partition: %w", constants.ImageCachePartitionLabel, err)
+		}
+
+		return constants.ImageCacheMountPoint, point, nil
+	}
+
+	point, isoErr := isoMountPoint()
+	if isoErr != nil {
+		return "", nil, fmt.Errorf("image cache not found: %s partition: %s, ISO: %w", constants.ImageCachePartitionLabel, partErr, isoErr)
+	}
+
+	if err := point.Mount(); err != nil {
+		return "", nil, fmt.Errorf("error mounting ISO: %w", err)
+	}
+
+	dir := filepath.Join(constants.ImageCacheMountPoint, constants.ImageCacheISOPath)
+
+	if _, err := os.Stat(dir); err != nil {
+		point.Unmount() //nolint:errcheck
+
+		return "", nil, fmt.Errorf("image cache not found in the ISO: %w", err)
+	}
+
+	return dir, point, nil
+}
+
+func partitionMountPoint() (*mount.Point, error) {
+	dev, err := probe.GetDevWithPartitionName(constants.ImageCachePartitionLabel)
+	if err != nil {
+		return nil, err
+	}
+
+	defer dev.Close() //nolint:errcheck
+
+	part, err := dev.GetPartition(constants.ImageCachePartitionLabel)
+	if err != nil {
+		return nil, err
+	}
+
+	fsType, err := part.Filesystem()
+	if err != nil {
+		return nil, err
+	}
+
+	partPath, err := part.Path()
+	if err != nil {
+		return nil, err
+	}
+
+	return mount.NewMountPoint(partPath, constants.ImageCacheMountPoint, fsType, unix.MS_NOATIME|unix.MS_RDONLY, ""), nil
+}
+
+func isoMountPoint() (*mount.Point, error) {
+	dev, err := probe.GetDevWithFileSystemLabel(constants.ISOFilesystemLabel)
+	if err != nil {
+		return nil, err
+	}
+
+	defer dev.Close() //nolint:errcheck
+
+	sb, err := filesystem.Probe(dev.Device().Name())
+	if err != nil {
+		return nil, err
+	}
+
+	if sb == nil {
+		return nil, fmt.Errorf("failed to get filesystem type")
+	}
+
+	return mount.NewMountPoint(dev.Device().Name(), constants.ImageCacheMountPoint, sb.Type(), unix.MS_RDONLY, ""), nil
+}
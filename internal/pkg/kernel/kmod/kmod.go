@@ -0,0 +1,101 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package kmod provides loading of the kernel modules.
+package kmod
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/sys/unix"
+)
+
+// ModulesPath is the path to the kernel modules.
+const ModulesPath = "/lib/modules"
+
+// ErrNotFound is returned when the kernel module can't be found.
+var ErrNotFound = errors.New("kernel module not found")
+
+var errFound = errors.New("found")
+
+// Find looks up the kernel module file by the module name in the directory tree.
+//
+// Dashes and underscores in the module name are interchangeable.
+func Find(root, name string) (string, error) {
+	filename := normalize(name) + ".ko"
+
+	var path string
+
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			return nil
+		}
+
+		if normalize(d.Name()) == filename {
+			path = p
+
+			return errFound
+		}
+
+		return nil
+	})
+	if err != nil && !errors.Is(err, errFound) && !errors.Is(err, fs.ErrNotExist) {
+		return "", err
+	}
+
+	if path == "" {
+		return "", fmt.Errorf("%w: %q", ErrNotFound, name)
+	}
+
+	return path, nil
+}
+
+// Load the kernel module by name from the modules directory of the running kernel.
+//
+// Loading already loaded module is not an error.
+func Load(name string) error {
+	var utsname unix.Utsname
+
+	if err := unix.Uname(&utsname); err != nil {
+		return err
+	}
+
+	path, err := Find(filepath.Join(ModulesPath, unix.ByteSliceToString(utsname.Release[:])), name)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+
+	defer f.Close() //nolint:errcheck
+
+	if err = unix.FinitModule(int(f.Fd()), "", 0); err != nil && !errors.Is(err, unix.EEXIST) {
+		return fmt.Errorf("error loading kernel module %q: %w", name, err)
+	}
+
+	return nil
+}
+
+func normalize(name string) string {
+	b := []byte(name)
+
+	for i := range b {
+		if b[i] == '-' {
+			b[i] = '_'
+		}
+	}
+
+	return string(b)
+}
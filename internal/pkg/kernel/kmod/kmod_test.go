@@ -0,0 +1,48 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package kmod_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/internal/pkg/kernel/kmod"
+)
+
+func TestFind(t *testing.T) {
+	root, err := ioutil.TempDir("", "talos")
+	require.NoError(t, err)
+
+	defer os.RemoveAll(root) //nolint:errcheck
+
+	for _, path := range []string{
+		"kernel/drivers/net/dummy.ko",
+		"extras/nvidia/nvidia.ko",
+		"extras/nvidia/nvidia-uvm.ko",
+		"modules.dep",
+	} {
+		require.NoError(t, os.MkdirAll(filepath.Join(root, filepath.Dir(path)), 0o755))
+		require.NoError(t, ioutil.WriteFile(filepath.Join(root, path), nil, 0o644))
+	}
+
+	path, err := kmod.Find(root, "nvidia")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(root, "extras/nvidia/nvidia.ko"), path)
+
+	path, err = kmod.Find(root, "nvidia_uvm")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(root, "extras/nvidia/nvidia-uvm.ko"), path)
+
+	_, err = kmod.Find(root, "nvidia_drm")
+	assert.ErrorIs(t, err, kmod.ErrNotFound)
+
+	_, err = kmod.Find(filepath.Join(root, "missing"), "dummy")
+	assert.ErrorIs(t, err, kmod.ErrNotFound)
+}
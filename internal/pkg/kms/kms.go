@@ -0,0 +1,92 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package kms implements a client of the Kubernetes KMS v2 plugin API.
+//
+// The API types are generated from the upstream k8s.io/kms/apis/v2/api.proto (see api/vendor).
+package kms
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+
+	kmsapi "github.com/talos-systems/talos/internal/pkg/kms/api/v2"
+	"github.com/talos-systems/talos/pkg/grpc/dialer"
+)
+
+// HealthzOK is the healthz status reported by a healthy plugin.
+const HealthzOK = "ok"
+
+// Client is the KMS v2 plugin client.
+type Client struct {
+	conn    *grpc.ClientConn
+	client  kmsapi.KeyManagementServiceClient
+	timeout time.Duration
+}
+
+// NewClient builds a client of the plugin listening on the unix socket endpoint (unix:///path/to/socket).
+//
+// Every call is limited by the timeout.
+func NewClient(endpoint string, timeout time.Duration) (*Client, error) {
+	conn, err := grpc.Dial(endpoint,
+		grpc.WithInsecure(),
+		grpc.WithContextDialer(dialer.DialUnix()),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to KMS plugin: %w", err)
+	}
+
+	return &Client{
+		conn:    conn,
+		client:  kmsapi.NewKeyManagementServiceClient(conn),
+		timeout: timeout,
+	}, nil
+}
+
+// Close the connection.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// Status returns the plugin status.
+func (c *Client) Status(ctx context.Context) (*kmsapi.StatusResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	resp, err := c.client.Status(ctx, &kmsapi.StatusRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("KMS plugin Status call failed: %w", err)
+	}
+
+	return resp, nil
+}
+
+// Encrypt the plaintext with the current plugin key.
+func (c *Client) Encrypt(ctx context.Context, req *kmsapi.EncryptRequest) (*kmsapi.EncryptResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	resp, err := c.client.Encrypt(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("KMS plugin Encrypt call failed: %w", err)
+	}
+
+	return resp, nil
+}
+
+// Decrypt the ciphertext returned by Encrypt.
+func (c *Client) Decrypt(ctx context.Context, req *kmsapi.DecryptRequest) (*kmsapi.DecryptResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	resp, err := c.client.Decrypt(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("KMS plugin Decrypt call failed: %w", err)
+	}
+
+	return resp, nil
+}
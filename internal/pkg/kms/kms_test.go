@@ -0,0 +1,131 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package kms_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/talos-systems/talos/internal/pkg/kms"
+	kmsapi "github.com/talos-systems/talos/internal/pkg/kms/api/v2"
+)
+
+// fakePlugin "encrypts" by reversing the plaintext.
+type fakePlugin struct {
+	kmsapi.UnimplementedKeyManagementServiceServer
+}
+
+func reverse(b []byte) []byte {
+	r := make([]byte, len(b))
+
+	for i := range b {
+		r[len(b)-1-i] = b[i]
+	}
+
+	return r
+}
+
+func (fakePlugin) Status(context.Context, *kmsapi.StatusRequest) (*kmsapi.StatusResponse, error) {
+	return &kmsapi.StatusResponse{Version: "v2", Healthz: kms.HealthzOK, KeyId: "key-1"}, nil
+}
+
+func (fakePlugin) Encrypt(_ context.Context, req *kmsapi.EncryptRequest) (*kmsapi.EncryptResponse, error) {
+	return &kmsapi.EncryptResponse{
+		Ciphertext:  reverse(req.Plaintext),
+		KeyId:       "key-1",
+		Annotations: map[string][]byte{"kms.example.com/uid": []byte(req.Uid)},
+	}, nil
+}
+
+func (fakePlugin) Decrypt(_ context.Context, req *kmsapi.DecryptRequest) (*kmsapi.DecryptResponse, error) {
+	if req.KeyId != "key-1" || !bytes.Equal(req.Annotations["kms.example.com/uid"], []byte(req.Uid)) {
+		return nil, fmt.Errorf("unexpected key or annotations")
+	}
+
+	return &kmsapi.DecryptResponse{Plaintext: reverse(req.Ciphertext)}, nil
+}
+
+func serve(t *testing.T) string {
+	server := grpc.NewServer()
+	kmsapi.RegisterKeyManagementServiceServer(server, fakePlugin{})
+
+	socketPath := filepath.Join(t.TempDir(), "kms.sock")
+
+	listener, err := net.Listen("unix", socketPath)
+	require.NoError(t, err)
+
+	go server.Serve(listener) //nolint:errcheck
+
+	t.Cleanup(server.Stop)
+
+	return "unix://" + socketPath
+}
+
+func TestClient(t *testing.T) {
+	client, err := kms.NewClient(serve(t), 5*time.Second)
+	require.NoError(t, err)
+
+	defer client.Close() //nolint:errcheck
+
+	ctx := context.Background()
+
+	status, err := client.Status(ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, "v2", status.Version)
+	assert.Equal(t, kms.HealthzOK, status.Healthz)
+	assert.Equal(t, "key-1", status.KeyId)
+
+	encrypted, err := client.Encrypt(ctx, &kmsapi.EncryptRequest{Plaintext: []byte("secret"), Uid: "node"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []byte("terces"), encrypted.Ciphertext)
+	assert.Equal(t, "key-1", encrypted.KeyId)
+
+	decrypted, err := client.Decrypt(ctx, &kmsapi.DecryptRequest{
+		Ciphertext:  encrypted.Ciphertext,
+		Uid:         "node",
+		KeyId:       encrypted.KeyId,
+		Annotations: encrypted.Annotations,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []byte("secret"), decrypted.Plaintext)
+
+	_, err = client.Decrypt(ctx, &kmsapi.DecryptRequest{Ciphertext: encrypted.Ciphertext, Uid: "other", KeyId: encrypted.KeyId})
+	assert.Error(t, err)
+}
+
+func TestMessagesRoundTrip(t *testing.T) {
+	// the plugins serve the upstream service name
+	assert.Equal(t, "v2.KeyManagementService", kmsapi.KeyManagementService_ServiceDesc.ServiceName)
+
+	for _, msg := range []proto.Message{
+		&kmsapi.StatusResponse{Version: "v2", Healthz: "ok", KeyId: "1"},
+		&kmsapi.EncryptRequest{Plaintext: []byte{0, 1, 2}, Uid: "uid"},
+		&kmsapi.EncryptResponse{Ciphertext: []byte{3}, KeyId: "1", Annotations: map[string][]byte{"a.example.com": {4}, "b.example.com": {6}}},
+		&kmsapi.DecryptRequest{Ciphertext: []byte{3}, Uid: "uid", KeyId: "1", Annotations: map[string][]byte{"a.example.com": {4}}},
+		&kmsapi.DecryptResponse{Plaintext: []byte{5}},
+	} {
+		b, err := proto.Marshal(msg)
+		require.NoError(t, err)
+
+		out := msg.ProtoReflect().New().Interface()
+
+		require.NoError(t, proto.Unmarshal(b, out))
+
+		assert.True(t, proto.Equal(msg, out), "%T doesn't match after round-trip", msg)
+	}
+}
@@ -39,14 +39,27 @@ const (
 	Local7
 )
 
+var facilityNames = [...]string{
+	"kern", "user", "mail", "daemon",
+	"auth", "syslog", "lpr", "news", "uucp",
+	"cron", "authpriv",
+	"local0", "local1", "local2", "local3",
+	"local4", "local5", "local6", "local7",
+}
+
 func (f Facility) String() string {
-	return [...]string{
-		"kern", "user", "mail", "daemon",
-		"auth", "syslog", "lpr", "news", "uucp",
-		"cron", "authpriv",
-		"local0", "local1", "local2", "local3",
-		"local4", "local5", "local6", "local7",
-	}[f]
+	return facilityNames[f]
+}
+
+// ParseFacility parses facility name (e.g. `kern`).
+func ParseFacility(s string) (Facility, error) {
+	for f, name := range facilityNames {
+		if name == s {
+			return Facility(f), nil
+		}
+	}
+
+	return 0, fmt.Errorf("unknown facility %q", s)
 }
 
 // Priority is an attribute of kernel log message.
@@ -64,8 +77,21 @@ const (
 	Debug
 )
 
+var priorityNames = [...]string{"emerg", "alert", "crit", "err", "warning", "notice", "info", "debug"}
+
 func (p Priority) String() string {
-	return [...]string{"emerg", "alert", "crit", "err", "warning", "notice", "info", "debug"}[p]
+	return priorityNames[p]
+}
+
+// ParsePriority parses priority name (e.g. `warning`).
+func ParsePriority(s string) (Priority, error) {
+	for p, name := range priorityNames {
+		if name == s {
+			return Priority(p), nil
+		}
+	}
+
+	return 0, fmt.Errorf("unknown priority %q", s)
 }
 
 // Message is a parsed kernel log message.
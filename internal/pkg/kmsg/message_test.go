@@ -58,3 +58,25 @@ func TestParseMessage(t *testing.T) {
 		assert.Equal(t, testCase.expected, message)
 	}
 }
+
+func TestParsePriority(t *testing.T) {
+	for p := kmsg.Emerg; p <= kmsg.Debug; p++ {
+		parsed, err := kmsg.ParsePriority(p.String())
+		assert.NoError(t, err)
+		assert.Equal(t, p, parsed)
+	}
+
+	_, err := kmsg.ParsePriority("verbose")
+	assert.Error(t, err)
+}
+
+func TestParseFacility(t *testing.T) {
+	for f := kmsg.Kern; f <= kmsg.Local7; f++ {
+		parsed, err := kmsg.ParseFacility(f.String())
+		assert.NoError(t, err)
+		assert.Equal(t, f, parsed)
+	}
+
+	_, err := kmsg.ParseFacility("kernel")
+	assert.Error(t, err)
+}
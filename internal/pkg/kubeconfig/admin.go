@@ -51,6 +51,11 @@ type GenerateAdminInput interface {
 
 // GenerateAdmin generates admin kubeconfig for the cluster.
 func GenerateAdmin(config GenerateAdminInput, out io.Writer) error {
+	return GenerateAdminWithCertLifetime(config, config.AdminKubeconfig().CertLifetime(), out)
+}
+
+// GenerateAdminWithCertLifetime generates admin kubeconfig for the cluster with the specified admin certificate lifetime.
+func GenerateAdminWithCertLifetime(config GenerateAdminInput, certLifetime time.Duration, out io.Writer) error {
 	tpl, err := template.New("kubeconfig").Parse(adminKubeConfigTemplate)
 	if err != nil {
 		return fmt.Errorf("error parsing kubeconfig template: %w", err)
@@ -64,7 +69,7 @@ func GenerateAdmin(config GenerateAdminInput, out io.Writer) error {
 	adminCert, err := x509.NewKeyPair(k8sCA,
 		x509.CommonName(constants.KubernetesAdminCertCommonName),
 		x509.Organization(constants.KubernetesAdminCertOrganization),
-		x509.NotAfter(time.Now().Add(config.AdminKubeconfig().CertLifetime())))
+		x509.NotAfter(time.Now().Add(certLifetime)))
 	if err != nil {
 		return fmt.Errorf("error generating admin certificate: %w", err)
 	}
@@ -9,6 +9,7 @@ import (
 	"io"
 	"reflect"
 	"strings"
+	"text/template"
 
 	"k8s.io/client-go/tools/clientcmd"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
@@ -30,9 +31,23 @@ func Load(path string) (*Merger, error) {
 // MergeOptions controls Merge process.
 type MergeOptions struct {
 	ForceContextName string
-	ActivateContext  bool
-	ConflictHandler  func(ConfigComponent, string) (ConflictDecision, error)
-	OutputWriter     io.Writer
+	// ContextNameTemplate is a text/template for the merged context name, see ContextNameInput for the available fields.
+	//
+	// ForceContextName takes precedence over the template.
+	ContextNameTemplate string
+	ActivateContext     bool
+	ConflictHandler     func(ConfigComponent, string) (ConflictDecision, error)
+	OutputWriter        io.Writer
+}
+
+// ContextNameInput is passed to the context name template.
+type ContextNameInput struct {
+	// Original context name.
+	Context string
+	// Name of the cluster (after renames on conflicts).
+	Cluster string
+	// Name of the auth info (after renames on conflicts).
+	User string
 }
 
 // ConfigComponent identifies part of kubeconfig.
@@ -117,6 +132,17 @@ func (merger *Merger) Merge(config *clientcmdapi.Config, options MergeOptions) e
 		mappedAuthInfos[name] = mergedName
 	}
 
+	var contextNameTemplate *template.Template
+
+	if options.ContextNameTemplate != "" && options.ForceContextName == "" {
+		var err error
+
+		contextNameTemplate, err = template.New("context").Option("missingkey=error").Parse(options.ContextNameTemplate)
+		if err != nil {
+			return fmt.Errorf("error parsing context name template: %w", err)
+		}
+	}
+
 	for name, newContext := range config.Contexts {
 		mergedName := name
 
@@ -125,26 +151,48 @@ func (merger *Merger) Merge(config *clientcmdapi.Config, options MergeOptions) e
 			mergedName = strings.ReplaceAll(mergedName, oldName, newName)
 		}
 
-		if options.ForceContextName != "" {
+		switch {
+		case options.ForceContextName != "":
 			mergedName = options.ForceContextName
+		case contextNameTemplate != nil:
+			var buf strings.Builder
+
+			if err := contextNameTemplate.Execute(&buf, ContextNameInput{
+				Context: name,
+				Cluster: mappedClusters[newContext.Cluster],
+				User:    mappedAuthInfos[newContext.AuthInfo],
+			}); err != nil {
+				return fmt.Errorf("error rendering context name template: %w", err)
+			}
+
+			mergedName = buf.String()
+
+			if mergedName == "" {
+				return fmt.Errorf("context name template %q rendered empty context name", options.ContextNameTemplate)
+			}
 		}
 
-		oldContext, exists := merger.Clusters[mergedName]
+		oldContext, exists := merger.Contexts[mergedName]
 
 		newContext.LocationOfOrigin = ""
 
+		// compare contexts with cluster and auth info renames applied
+		newContextCopy := *newContext
+		newContextCopy.Cluster = mappedClusters[newContextCopy.Cluster]
+		newContextCopy.AuthInfo = mappedAuthInfos[newContextCopy.AuthInfo]
+
 		if oldContext != nil {
 			oldContext.LocationOfOrigin = ""
 		}
 
-		if exists && !reflect.DeepEqual(oldContext, newContext) {
-			decision, err := options.ConflictHandler(Cluster, name)
+		if exists && !reflect.DeepEqual(oldContext, &newContextCopy) {
+			decision, err := options.ConflictHandler(Context, mergedName)
 			if err != nil {
 				return err
 			}
 
 			if decision == RenameDecision {
-				mergedName = merger.rename(Cluster, mergedName)
+				mergedName = merger.rename(Context, mergedName)
 			}
 		}
 
@@ -357,6 +357,131 @@ func TestMerger(t *testing.T) {
 				OutputWriter:    os.Stdout,
 			},
 		},
+		{ // MergeContextNameTemplate
+			name: "MergeContextNameTemplate",
+			initial: clientcmdapi.Config{
+				AuthInfos: map[string]*clientcmdapi.AuthInfo{},
+				Clusters:  map[string]*clientcmdapi.Cluster{},
+				Contexts:  map[string]*clientcmdapi.Context{},
+			},
+			new: clientcmdapi.Config{
+				AuthInfos: map[string]*clientcmdapi.AuthInfo{
+					"fiz@buzz": {
+						ClientCertificate: "cert2",
+					},
+				},
+				Clusters: map[string]*clientcmdapi.Cluster{
+					"buzz": {
+						Server: "another.com",
+					},
+				},
+				Contexts: map[string]*clientcmdapi.Context{
+					"fiz@buzz": {
+						Cluster:  "buzz",
+						AuthInfo: "fiz@buzz",
+					},
+				},
+			},
+			expected: clientcmdapi.Config{
+				AuthInfos: map[string]*clientcmdapi.AuthInfo{
+					"fiz@buzz": {
+						ClientCertificate: "cert2",
+					},
+				},
+				Clusters: map[string]*clientcmdapi.Cluster{
+					"buzz": {
+						Server: "another.com",
+					},
+				},
+				Contexts: map[string]*clientcmdapi.Context{
+					"talos-buzz": {
+						Cluster:  "buzz",
+						AuthInfo: "fiz@buzz",
+					},
+				},
+				CurrentContext: "talos-buzz",
+			},
+			options: kubeconfig.MergeOptions{
+				ContextNameTemplate: "talos-{{ .Cluster }}",
+				ActivateContext:     true,
+				ConflictHandler:     errorAlways,
+				OutputWriter:        os.Stdout,
+			},
+		},
+		{ // MergeContextConflict
+			name: "MergeContextConflict",
+			initial: clientcmdapi.Config{
+				AuthInfos: map[string]*clientcmdapi.AuthInfo{
+					"foo@bar": {
+						ClientCertificate: "cert1",
+					},
+				},
+				Clusters: map[string]*clientcmdapi.Cluster{
+					"bar": {
+						Server: "example.com",
+					},
+				},
+				Contexts: map[string]*clientcmdapi.Context{
+					"admin": {
+						Cluster:  "bar",
+						AuthInfo: "foo@bar",
+					},
+				},
+				CurrentContext: "admin",
+			},
+			new: clientcmdapi.Config{
+				AuthInfos: map[string]*clientcmdapi.AuthInfo{
+					"fiz@buzz": {
+						ClientCertificate: "cert2",
+					},
+				},
+				Clusters: map[string]*clientcmdapi.Cluster{
+					"buzz": {
+						Server: "another.com",
+					},
+				},
+				Contexts: map[string]*clientcmdapi.Context{
+					"fiz@buzz": {
+						Cluster:  "buzz",
+						AuthInfo: "fiz@buzz",
+					},
+				},
+			},
+			expected: clientcmdapi.Config{
+				AuthInfos: map[string]*clientcmdapi.AuthInfo{
+					"foo@bar": {
+						ClientCertificate: "cert1",
+					},
+					"fiz@buzz": {
+						ClientCertificate: "cert2",
+					},
+				},
+				Clusters: map[string]*clientcmdapi.Cluster{
+					"bar": {
+						Server: "example.com",
+					},
+					"buzz": {
+						Server: "another.com",
+					},
+				},
+				Contexts: map[string]*clientcmdapi.Context{
+					"admin": {
+						Cluster:  "bar",
+						AuthInfo: "foo@bar",
+					},
+					"admin-1": {
+						Cluster:  "buzz",
+						AuthInfo: "fiz@buzz",
+					},
+				},
+				CurrentContext: "admin",
+			},
+			options: kubeconfig.MergeOptions{
+				ForceContextName: "admin",
+				ConflictHandler:  renameAlways,
+				OutputWriter:     os.Stdout,
+			},
+		},
 	} {
 		tt := tt
 
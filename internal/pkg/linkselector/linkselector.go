@@ -0,0 +1,146 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package linkselector resolves network device selectors from the machine configuration to the link names.
+package linkselector
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/talos-systems/talos/pkg/machinery/config"
+)
+
+const sysClassNet = "/sys/class/net"
+
+// Link describes the properties of the physical link which can be matched by the selector.
+type Link struct {
+	Name string
+
+	// Permanent hardware address of the link.
+	HardwareAddr net.HardwareAddr
+	BusPath      string
+	Driver       string
+	// Speed in Mbit/s, zero if unknown (e.g. link is down).
+	Speed uint32
+}
+
+// Discover returns the list of physical links on the node.
+//
+// Virtual links (bonds, bridges, VLANs, etc.) are skipped, as they don't have an underlying device.
+func Discover() ([]Link, error) {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return nil, fmt.Errorf("error listing links: %w", err)
+	}
+
+	links := make([]Link, 0, len(ifaces))
+
+	for _, iface := range ifaces {
+		devicePath, err := filepath.EvalSymlinks(filepath.Join(sysClassNet, iface.Name, "device"))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+
+			return nil, fmt.Errorf("error reading device of link %q: %w", iface.Name, err)
+		}
+
+		link := Link{
+			Name:         iface.Name,
+			HardwareAddr: iface.HardwareAddr,
+			BusPath:      filepath.Base(devicePath),
+		}
+
+		if permAddr, err := permanentAddr(iface.Name); err == nil && permAddr != nil {
+			link.HardwareAddr = permAddr
+		}
+
+		if driverPath, err := filepath.EvalSymlinks(filepath.Join(devicePath, "driver")); err == nil {
+			link.Driver = filepath.Base(driverPath)
+		}
+
+		// reading speed fails with EINVAL if the link is down
+		if contents, err := ioutil.ReadFile(filepath.Join(sysClassNet, iface.Name, "speed")); err == nil {
+			if speed, err := strconv.ParseInt(strings.TrimSpace(string(contents)), 10, 64); err == nil && speed > 0 {
+				link.Speed = uint32(speed)
+			}
+		}
+
+		links = append(links, link)
+	}
+
+	return links, nil
+}
+
+// Match returns true if the link matches all the fields set in the selector.
+func Match(selector config.NetworkDeviceSelector, link Link) bool {
+	if selector.HardwareAddress() != "" {
+		hwAddr, err := net.ParseMAC(selector.HardwareAddress())
+		if err != nil || !bytes.Equal(hwAddr, link.HardwareAddr) {
+			return false
+		}
+	}
+
+	if selector.BusPath() != "" && selector.BusPath() != link.BusPath {
+		return false
+	}
+
+	if selector.KernelDriver() != "" && selector.KernelDriver() != link.Driver {
+		return false
+	}
+
+	if selector.Speed() != 0 && selector.Speed() != link.Speed {
+		return false
+	}
+
+	return true
+}
+
+// Resolve returns the name of the single link matching the selector.
+func Resolve(selector config.NetworkDeviceSelector, links []Link) (string, error) {
+	var matched []string
+
+	for _, link := range links {
+		if Match(selector, link) {
+			matched = append(matched, link.Name)
+		}
+	}
+
+	switch len(matched) {
+	case 0:
+		return "", fmt.Errorf("no link matches the device selector")
+	case 1:
+		return matched[0], nil
+	default:
+		sort.Strings(matched)
+
+		return "", fmt.Errorf("device selector matches multiple links: %s", strings.Join(matched, ", "))
+	}
+}
+
+// WithLinkName returns the device with the interface name replaced by the resolved link name.
+func WithLinkName(device config.Device, linkName string) config.Device {
+	return selectedDevice{
+		Device:   device,
+		linkName: linkName,
+	}
+}
+
+type selectedDevice struct {
+	config.Device
+
+	linkName string
+}
+
+func (d selectedDevice) Interface() string {
+	return d.linkName
+}
@@ -0,0 +1,123 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package linkselector_test
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/internal/pkg/linkselector"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
+)
+
+func mustParseMAC(t *testing.T, s string) net.HardwareAddr {
+	addr, err := net.ParseMAC(s)
+	require.NoError(t, err)
+
+	return addr
+}
+
+func TestResolve(t *testing.T) {
+	links := []linkselector.Link{
+		{
+			Name:         "eth0",
+			HardwareAddr: mustParseMAC(t, "00:1a:2b:3c:f0:aa"),
+			BusPath:      "0000:00:03.0",
+			Driver:       "virtio_net",
+		},
+		{
+			Name:         "eth1",
+			HardwareAddr: mustParseMAC(t, "00:1a:2b:3c:f0:ab"),
+			BusPath:      "0000:00:04.0",
+			Driver:       "virtio_net",
+			Speed:        10000,
+		},
+		{
+			Name:         "enp1s0",
+			HardwareAddr: mustParseMAC(t, "00:1a:2b:3c:f0:ac"),
+			BusPath:      "0000:01:00.0",
+			Driver:       "ixgbe",
+			Speed:        10000,
+		},
+	}
+
+	for _, tt := range []struct {
+		name     string
+		selector v1alpha1.NetworkDeviceSelector
+		expected string
+		err      string
+	}{
+		{
+			name: "hardware address",
+			selector: v1alpha1.NetworkDeviceSelector{
+				NetworkDeviceHardwareAddress: "00:1A:2B:3C:F0:AB",
+			},
+			expected: "eth1",
+		},
+		{
+			name: "bus path",
+			selector: v1alpha1.NetworkDeviceSelector{
+				NetworkDeviceBusPath: "0000:00:03.0",
+			},
+			expected: "eth0",
+		},
+		{
+			name: "driver and speed",
+			selector: v1alpha1.NetworkDeviceSelector{
+				NetworkDeviceKernelDriver: "virtio_net",
+				NetworkDeviceSpeed:        10000,
+			},
+			expected: "eth1",
+		},
+		{
+			name: "ambiguous",
+			selector: v1alpha1.NetworkDeviceSelector{
+				NetworkDeviceSpeed: 10000,
+			},
+			err: "device selector matches multiple links: enp1s0, eth1",
+		},
+		{
+			name: "no match",
+			selector: v1alpha1.NetworkDeviceSelector{
+				NetworkDeviceKernelDriver: "ixgbe",
+				NetworkDeviceBusPath:      "0000:00:03.0",
+			},
+			err: "no link matches the device selector",
+		},
+	} {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			linkName, err := linkselector.Resolve(&tt.selector, links)
+
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, linkName)
+		})
+	}
+}
+
+func TestWithLinkName(t *testing.T) {
+	device := &v1alpha1.Device{
+		DeviceSelector: &v1alpha1.NetworkDeviceSelector{
+			NetworkDeviceBusPath: "0000:00:03.0",
+		},
+		DeviceDHCP: true,
+	}
+
+	selected := linkselector.WithLinkName(device, "eth0")
+
+	assert.Equal(t, "eth0", selected.Interface())
+	assert.True(t, selected.DHCP())
+	assert.Equal(t, "0000:00:03.0", selected.Selector().BusPath())
+}
@@ -0,0 +1,75 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package linkselector
+
+import (
+	"net"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+const (
+	ethtoolGPermAddr = 0x20 // ETHTOOL_GPERMADDR
+	maxAddrLen       = 32   // MAX_ADDR_LEN
+)
+
+// ethtoolPermAddr is struct ethtool_perm_addr with the inline buffer for the address.
+type ethtoolPermAddr struct {
+	cmd  uint32
+	size uint32
+	data [maxAddrLen]byte
+}
+
+// ifreq is struct ifreq with the ifr_data member of the union.
+type ifreq struct {
+	name [unix.IFNAMSIZ]byte
+	data unsafe.Pointer
+	_    [16]byte
+}
+
+// permanentAddr returns the permanent hardware address of the link via ethtool.
+//
+// Current hardware address of the link might be different, e.g. for the links enslaved to a bond.
+// Nil address is returned if the driver doesn't report the permanent address.
+func permanentAddr(linkName string) (net.HardwareAddr, error) {
+	fd, err := unix.Socket(unix.AF_INET, unix.SOCK_DGRAM|unix.SOCK_CLOEXEC, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	//nolint:errcheck
+	defer unix.Close(fd)
+
+	permAddr := ethtoolPermAddr{
+		cmd:  ethtoolGPermAddr,
+		size: maxAddrLen,
+	}
+
+	req := ifreq{
+		data: unsafe.Pointer(&permAddr),
+	}
+
+	copy(req.name[:unix.IFNAMSIZ-1], linkName)
+
+	if _, _, errno := unix.Syscall(unix.SYS_IOCTL, uintptr(fd), unix.SIOCETHTOOL, uintptr(unsafe.Pointer(&req))); errno != 0 {
+		return nil, errno
+	}
+
+	if permAddr.size == 0 || permAddr.size > maxAddrLen {
+		return nil, nil
+	}
+
+	addr := net.HardwareAddr(append([]byte(nil), permAddr.data[:permAddr.size]...))
+
+	// some drivers report all-zero permanent address
+	for _, b := range addr {
+		if b != 0 {
+			return addr, nil
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,49 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// NewAPIRequestDuration registers the histogram of the API request latencies partitioned by the method and the status code.
+func NewAPIRequestDuration(prefix string) *prometheus.HistogramVec {
+	return Factory.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    prefix + "_api_request_duration_seconds",
+		Help:    "API request latencies in seconds.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "code"})
+}
+
+// UnaryServerInterceptor returns grpc UnaryServerInterceptor which observes the request latencies.
+func UnaryServerInterceptor(h *prometheus.HistogramVec) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		startTime := time.Now()
+
+		resp, err := handler(ctx, req)
+
+		h.WithLabelValues(info.FullMethod, status.Code(err).String()).Observe(time.Since(startTime).Seconds())
+
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor returns grpc StreamServerInterceptor which observes the request latencies.
+func StreamServerInterceptor(h *prometheus.HistogramVec) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		startTime := time.Now()
+
+		err := handler(srv, stream)
+
+		h.WithLabelValues(info.FullMethod, status.Code(err).String()).Observe(time.Since(startTime).Seconds())
+
+		return err
+	}
+}
@@ -0,0 +1,108 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package metrics
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+)
+
+// Path is the HTTP path of the metrics endpoint.
+const Path = "/metrics"
+
+// Handler serves the metrics collected from the gatherers to the requests carrying the bearer token.
+//
+// Authentication is mandatory: if the token is empty, all the requests are rejected.
+// Failing gatherers are skipped, so that the metrics of the process are served even if
+// another process is not available.
+func Handler(logger *log.Logger, token string, gatherers ...prometheus.Gatherer) http.Handler {
+	handler := promhttp.HandlerFor(prometheus.Gatherers(gatherers), promhttp.HandlerOpts{
+		ErrorLog:      logger,
+		ErrorHandling: promhttp.ContinueOnError,
+	})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		provided := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+
+		if token == "" || subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		handler.ServeHTTP(w, req)
+	})
+}
+
+// LocalHandler serves the metrics of the Default registry without authentication.
+//
+// LocalHandler should be used only on the unix sockets.
+func LocalHandler(logger *log.Logger) http.Handler {
+	return promhttp.HandlerFor(Default, promhttp.HandlerOpts{
+		ErrorLog: logger,
+	})
+}
+
+// Remote gathers the metrics served by another process over HTTP on the unix socket.
+type Remote struct {
+	SocketPath string
+}
+
+// Gather implements prometheus.Gatherer.
+func (r *Remote) Gather() ([]*dto.MetricFamily, error) {
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var d net.Dialer
+
+				return d.DialContext(ctx, "unix", r.SocketPath)
+			},
+		},
+		Timeout: 5 * time.Second,
+	}
+
+	defer client.CloseIdleConnections()
+
+	resp, err := client.Get("http://unix" + Path)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching metrics from %q: %w", r.SocketPath, err)
+	}
+
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error fetching metrics from %q: unexpected status %s", r.SocketPath, resp.Status)
+	}
+
+	var parser expfmt.TextParser
+
+	families, err := parser.TextToMetricFamilies(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing metrics from %q: %w", r.SocketPath, err)
+	}
+
+	result := make([]*dto.MetricFamily, 0, len(families))
+
+	for _, family := range families {
+		result = append(result, family)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].GetName() < result[j].GetName() })
+
+	return result, nil
+}
@@ -0,0 +1,17 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package metrics provides the registry of the Talos process metrics and the HTTP handlers to serve them.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Default is the registry of the process metrics.
+var Default = prometheus.NewRegistry()
+
+// Factory registers new metrics in the Default registry.
+var Factory = promauto.With(Default)
@@ -0,0 +1,129 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package metrics_test
+
+import (
+	"io/ioutil"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/internal/pkg/metrics"
+)
+
+func TestHandlerAuth(t *testing.T) {
+	r := prometheus.NewRegistry()
+
+	counter := prometheus.NewCounter(prometheus.CounterOpts{Name: "test_total", Help: "Test."})
+	counter.Inc()
+	r.MustRegister(counter)
+
+	for _, tt := range []struct {
+		name          string
+		token         string
+		authorization string
+		expectedCode  int
+	}{
+		{
+			name:         "no credentials",
+			token:        "secret",
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:          "wrong token",
+			token:         "secret",
+			authorization: "Bearer secre",
+			expectedCode:  http.StatusUnauthorized,
+		},
+		{
+			name:          "empty token",
+			token:         "",
+			authorization: "Bearer ",
+			expectedCode:  http.StatusUnauthorized,
+		},
+		{
+			name:          "valid token",
+			token:         "secret",
+			authorization: "Bearer secret",
+			expectedCode:  http.StatusOK,
+		},
+	} {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			handler := metrics.Handler(log.New(ioutil.Discard, "", 0), tt.token, r)
+
+			req := httptest.NewRequest(http.MethodGet, metrics.Path, nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+
+			if tt.expectedCode == http.StatusOK {
+				assert.Equal(t, "# HELP test_total Test.\n# TYPE test_total counter\ntest_total 1\n", rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestRemote(t *testing.T) {
+	dir, err := ioutil.TempDir("", "metrics")
+	require.NoError(t, err)
+
+	socketPath := filepath.Join(dir, "metrics.sock")
+
+	listener, err := net.Listen("unix", socketPath)
+	require.NoError(t, err)
+
+	remote := prometheus.NewRegistry()
+
+	restarts := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "remote_restarts_total", Help: "Number of \"restarts\"."}, []string{"service"})
+	restarts.WithLabelValues("a\"b\\c\nd").Inc()
+	remote.MustRegister(restarts)
+
+	mux := http.NewServeMux()
+	mux.Handle(metrics.Path, promhttp.HandlerFor(remote, promhttp.HandlerOpts{}))
+
+	server := &http.Server{Handler: mux}
+
+	go server.Serve(listener) //nolint:errcheck
+
+	defer server.Close() //nolint:errcheck
+
+	local := prometheus.NewRegistry()
+
+	counter := prometheus.NewCounter(prometheus.CounterOpts{Name: "local_total", Help: "Local."})
+	local.MustRegister(counter)
+
+	handler := metrics.Handler(log.New(ioutil.Discard, "", 0), "secret", local, &metrics.Remote{SocketPath: socketPath}, &metrics.Remote{SocketPath: filepath.Join(dir, "nonexistent.sock")})
+
+	req := httptest.NewRequest(http.MethodGet, metrics.Path, nil)
+	req.Header.Set("Authorization", "Bearer secret")
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	// unavailable remote is skipped, label values survive the round trip with escaping
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `# HELP local_total Local.
+# TYPE local_total counter
+local_total 0
+# HELP remote_restarts_total Number of "restarts".
+# TYPE remote_restarts_total counter
+remote_restarts_total{service="a\"b\\c\nd"} 1
+`, rec.Body.String())
+}
@@ -5,7 +5,11 @@
 package mount
 
 import (
+	"os"
+
 	"golang.org/x/sys/unix"
+
+	"github.com/talos-systems/talos/pkg/machinery/constants"
 )
 
 // PseudoMountPoints returns the mountpoints required to boot the system.
@@ -29,5 +33,15 @@ func PseudoSubMountPoints() (mountpoints *Points, err error) {
 	pseudo.Set("hugetlb", NewMountPoint("hugetlbfs", "/dev/hugepages", "hugetlbfs", 0, ""))
 	pseudo.Set("securityfs", NewMountPoint("securityfs", "/sys/kernel/security", "securityfs", unix.MS_NOSUID|unix.MS_NOEXEC|unix.MS_NODEV|unix.MS_RELATIME, ""))
 
+	// efivarfs is only available when booted via EFI, it's mounted read-only to prevent accidental firmware variable changes
+	if _, err := os.Stat(constants.EFIVarsMountPoint); err == nil {
+		pseudo.Set("efivars", NewMountPoint("efivarfs", constants.EFIVarsMountPoint, "efivarfs", unix.MS_NOSUID|unix.MS_NOEXEC|unix.MS_NODEV|unix.MS_RELATIME|unix.MS_RDONLY, ""))
+	}
+
+	// pstore is only available if the kernel has pstore support built in
+	if _, err := os.Stat(constants.PstoreMountPoint); err == nil {
+		pseudo.Set("pstore", NewMountPoint("pstore", constants.PstoreMountPoint, "pstore", unix.MS_NOSUID|unix.MS_NOEXEC|unix.MS_NODEV|unix.MS_RELATIME, ""))
+	}
+
 	return pseudo, nil
 }
@@ -0,0 +1,116 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package nodeip picks the node IP addresses advertised to Kubernetes.
+package nodeip
+
+import (
+	"fmt"
+	"net"
+	"sort"
+
+	talosnet "github.com/talos-systems/net"
+
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+)
+
+// Discover returns the global unicast addresses of the node.
+//
+// Loopback links, links which are down and the KubeSpan link are skipped.
+func Discover() ([]net.IP, error) {
+	links, err := net.Interfaces()
+	if err != nil {
+		return nil, fmt.Errorf("error listing links: %w", err)
+	}
+
+	var ips []net.IP
+
+	for _, link := range links {
+		if link.Flags&net.FlagLoopback != 0 || link.Flags&net.FlagUp == 0 || link.Name == constants.KubeSpanLinkName {
+			continue
+		}
+
+		addrs, err := link.Addrs()
+		if err != nil {
+			return nil, fmt.Errorf("error listing addresses of %q: %w", link.Name, err)
+		}
+
+		for _, addr := range addrs {
+			ipnet, ok := addr.(*net.IPNet)
+			if !ok {
+				continue
+			}
+
+			if ipnet.IP.IsGlobalUnicast() {
+				ips = append(ips, ipnet.IP)
+			}
+		}
+	}
+
+	return ips, nil
+}
+
+// Pick returns one node address per IP family of the cluster networks, in the order of the cluster networks.
+//
+// Cluster networks are passed as comma-separated CIDRs (e.g. PodCIDR), the first one defines the primary IP family.
+// Addresses are picked in the order they are listed, addresses inside the cluster networks are skipped.
+func Pick(ips []net.IP, clusterCIDRs string) ([]net.IP, error) {
+	cidrs, err := talosnet.SplitCIDRs(clusterCIDRs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to process cluster CIDRs: %w", err)
+	}
+
+	candidates := make([]net.IP, 0, len(ips))
+
+outer:
+	for _, ip := range ips {
+		for _, cidr := range cidrs {
+			if cidr.Contains(ip) {
+				continue outer
+			}
+		}
+
+		candidates = append(candidates, ip)
+	}
+
+	// sort the addresses by the family, following the order of the cluster networks
+	familyIndex := func(ip net.IP) int {
+		for i, cidr := range cidrs {
+			if isIPv4(cidr.IP) == isIPv4(ip) {
+				return i
+			}
+		}
+
+		return len(cidrs)
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return familyIndex(candidates[i]) < familyIndex(candidates[j])
+	})
+
+	result := make([]net.IP, 0, len(cidrs))
+	picked := map[int]struct{}{}
+
+	for _, ip := range candidates {
+		idx := familyIndex(ip)
+
+		if idx == len(cidrs) {
+			continue
+		}
+
+		if _, ok := picked[idx]; ok {
+			continue
+		}
+
+		picked[idx] = struct{}{}
+
+		result = append(result, ip)
+	}
+
+	return result, nil
+}
+
+func isIPv4(ip net.IP) bool {
+	return ip.To4() != nil
+}
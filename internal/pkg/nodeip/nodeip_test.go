@@ -0,0 +1,74 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package nodeip_test
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/internal/pkg/nodeip"
+)
+
+func TestPick(t *testing.T) {
+	ips := []net.IP{
+		net.ParseIP("2001:db8::10"),
+		net.ParseIP("10.244.1.1"),
+		net.ParseIP("10.5.0.2"),
+		net.ParseIP("2001:db8::11"),
+		net.ParseIP("172.20.0.2"),
+	}
+
+	for _, tt := range []struct {
+		name         string
+		clusterCIDRs string
+		expected     []string
+	}{
+		{
+			name:         "IPv4",
+			clusterCIDRs: "10.244.0.0/16",
+			expected:     []string{"10.5.0.2"},
+		},
+		{
+			name:         "IPv6",
+			clusterCIDRs: "fc00:db8:10::/56",
+			expected:     []string{"2001:db8::10"},
+		},
+		{
+			name:         "DualStack",
+			clusterCIDRs: "10.244.0.0/16,fc00:db8:10::/56",
+			expected:     []string{"10.5.0.2", "2001:db8::10"},
+		},
+		{
+			name:         "DualStackIPv6Primary",
+			clusterCIDRs: "fc00:db8:10::/56,10.244.0.0/16",
+			expected:     []string{"2001:db8::10", "10.5.0.2"},
+		},
+	} {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			picked, err := nodeip.Pick(ips, tt.clusterCIDRs)
+			require.NoError(t, err)
+
+			result := make([]string, 0, len(picked))
+
+			for _, ip := range picked {
+				result = append(result, ip.String())
+			}
+
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+
+	picked, err := nodeip.Pick([]net.IP{net.ParseIP("2001:db8::10")}, "10.244.0.0/16,fc00:db8:10::/56")
+	require.NoError(t, err)
+	assert.Equal(t, []net.IP{net.ParseIP("2001:db8::10")}, picked)
+
+	_, err = nodeip.Pick(ips, "10.244.0.0")
+	require.Error(t, err)
+}
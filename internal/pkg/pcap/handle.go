@@ -0,0 +1,218 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package pcap
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// DefaultSnapLen is the default maximum number of bytes captured for each packet.
+const DefaultSnapLen = 65536
+
+// readTimeout is the socket read timeout, it defines how often ReadPacket returns when there are no packets.
+const readTimeout = 100 * time.Millisecond
+
+// ErrTimeout is returned by ReadPacket if no packet was received within read timeout.
+var ErrTimeout = errors.New("read timeout")
+
+// Option configures Handle.
+type Option func(*options)
+
+type options struct {
+	promiscuous bool
+	snapLen     int
+	filter      []unix.SockFilter
+}
+
+// WithPromiscuous enables promiscuous mode on the interface while the capture is running.
+func WithPromiscuous(promiscuous bool) Option {
+	return func(o *options) {
+		o.promiscuous = promiscuous
+	}
+}
+
+// WithSnapLen sets maximum number of bytes captured for each packet.
+func WithSnapLen(snapLen int) Option {
+	return func(o *options) {
+		if snapLen > 0 {
+			o.snapLen = snapLen
+		}
+	}
+}
+
+// WithFilter sets the BPF program to filter captured packets.
+func WithFilter(filter []unix.SockFilter) Option {
+	return func(o *options) {
+		o.filter = filter
+	}
+}
+
+// Handle captures packets on the network interface using AF_PACKET socket.
+type Handle struct {
+	options
+
+	fd       int
+	linkType LinkType
+	buf      []byte
+}
+
+// Open starts packet capture on the interface.
+func Open(ifName string, opts ...Option) (*Handle, error) {
+	h := &Handle{
+		options: options{
+			snapLen: DefaultSnapLen,
+		},
+	}
+
+	for _, o := range opts {
+		o(&h.options)
+	}
+
+	iface, err := net.InterfaceByName(ifName)
+	if err != nil {
+		return nil, err
+	}
+
+	h.linkType, err = linkType(ifName)
+	if err != nil {
+		return nil, err
+	}
+
+	// socket is created with zero protocol so that it doesn't receive any packets
+	// until it is bound to the interface, filter is attached before that
+	h.fd, err = unix.Socket(unix.AF_PACKET, unix.SOCK_RAW|unix.SOCK_CLOEXEC, 0)
+	if err != nil {
+		return nil, fmt.Errorf("error creating packet socket: %w", err)
+	}
+
+	if err = h.setup(iface); err != nil {
+		h.Close() //nolint:errcheck
+
+		return nil, err
+	}
+
+	h.buf = make([]byte, h.snapLen)
+
+	return h, nil
+}
+
+func (h *Handle) setup(iface *net.Interface) error {
+	if len(h.filter) > 0 {
+		if err := unix.SetsockoptSockFprog(h.fd, unix.SOL_SOCKET, unix.SO_ATTACH_FILTER, &unix.SockFprog{
+			Len:    uint16(len(h.filter)),
+			Filter: &h.filter[0],
+		}); err != nil {
+			return fmt.Errorf("error attaching filter: %w", err)
+		}
+	}
+
+	tv := unix.NsecToTimeval(readTimeout.Nanoseconds())
+
+	if err := unix.SetsockoptTimeval(h.fd, unix.SOL_SOCKET, unix.SO_RCVTIMEO, &tv); err != nil {
+		return fmt.Errorf("error setting read timeout: %w", err)
+	}
+
+	if err := unix.Bind(h.fd, &unix.SockaddrLinklayer{
+		Protocol: htons(unix.ETH_P_ALL),
+		Ifindex:  iface.Index,
+	}); err != nil {
+		return fmt.Errorf("error binding to interface %q: %w", iface.Name, err)
+	}
+
+	if h.promiscuous {
+		// membership is dropped automatically when the socket is closed
+		if err := unix.SetsockoptPacketMreq(h.fd, unix.SOL_PACKET, unix.PACKET_ADD_MEMBERSHIP, &unix.PacketMreq{
+			Ifindex: int32(iface.Index),
+			Type:    unix.PACKET_MR_PROMISC,
+		}); err != nil {
+			return fmt.Errorf("error enabling promiscuous mode: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// LinkType returns link-layer header type of the captured packets.
+func (h *Handle) LinkType() LinkType {
+	return h.linkType
+}
+
+// SnapLen returns maximum number of bytes captured for each packet.
+func (h *Handle) SnapLen() int {
+	return h.snapLen
+}
+
+// ReadPacket reads next captured packet.
+//
+// Returned data is valid only until the next call to ReadPacket.
+// ErrTimeout is returned if there are no packets within the read timeout.
+func (h *Handle) ReadPacket() ([]byte, CaptureInfo, error) {
+	for {
+		// MSG_TRUNC makes recvfrom return the original length of the packet
+		n, _, err := unix.Recvfrom(h.fd, h.buf, unix.MSG_TRUNC)
+
+		switch {
+		case err == unix.EINTR:
+			continue
+		case err == unix.EAGAIN:
+			return nil, CaptureInfo{}, ErrTimeout
+		case err != nil:
+			return nil, CaptureInfo{}, os.NewSyscallError("recvfrom", err)
+		}
+
+		captureLength := n
+		if captureLength > len(h.buf) {
+			captureLength = len(h.buf)
+		}
+
+		return h.buf[:captureLength], CaptureInfo{
+			Timestamp:     time.Now(),
+			CaptureLength: captureLength,
+			Length:        n,
+		}, nil
+	}
+}
+
+// Close stops the capture.
+func (h *Handle) Close() error {
+	return unix.Close(h.fd)
+}
+
+func htons(v uint16) uint16 {
+	return v<<8 | v>>8
+}
+
+// linkType derives capture link type from the interface hardware type.
+func linkType(ifName string) (LinkType, error) {
+	contents, err := ioutil.ReadFile(filepath.Join("/sys/class/net", ifName, "type"))
+	if err != nil {
+		return 0, err
+	}
+
+	hwType, err := strconv.Atoi(strings.TrimSpace(string(contents)))
+	if err != nil {
+		return 0, fmt.Errorf("error parsing interface type: %w", err)
+	}
+
+	switch hwType {
+	case unix.ARPHRD_ETHER, unix.ARPHRD_LOOPBACK:
+		return LinkTypeEthernet, nil
+	case unix.ARPHRD_NONE:
+		// e.g. Wireguard or tun interfaces
+		return LinkTypeRaw, nil
+	default:
+		return 0, fmt.Errorf("unsupported interface hardware type %d", hwType)
+	}
+}
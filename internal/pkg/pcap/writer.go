@@ -0,0 +1,90 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package pcap
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"time"
+)
+
+// LinkType is the link-layer header type of the capture.
+type LinkType uint32
+
+// Supported link types.
+const (
+	LinkTypeEthernet LinkType = 1
+	LinkTypeRaw      LinkType = 101
+)
+
+const (
+	magicMicroseconds = 0xa1b2c3d4
+	versionMajor      = 2
+	versionMinor      = 4
+)
+
+// CaptureInfo describes the captured packet.
+type CaptureInfo struct {
+	Timestamp time.Time
+	// CaptureLength is the number of bytes captured.
+	CaptureLength int
+	// Length is the original length of the packet.
+	Length int
+}
+
+// Writer writes packets in pcap file format.
+type Writer struct {
+	w   io.Writer
+	buf [24]byte
+}
+
+// NewWriter initializes new pcap Writer.
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{
+		w: w,
+	}
+}
+
+// WriteFileHeader writes pcap file header, it should be called once before writing any packets.
+func (w *Writer) WriteFileHeader(snapLen uint32, linkType LinkType) error {
+	binary.LittleEndian.PutUint32(w.buf[0:], magicMicroseconds)
+	binary.LittleEndian.PutUint16(w.buf[4:], versionMajor)
+	binary.LittleEndian.PutUint16(w.buf[6:], versionMinor)
+	binary.LittleEndian.PutUint32(w.buf[8:], 0)  // thiszone
+	binary.LittleEndian.PutUint32(w.buf[12:], 0) // sigfigs
+	binary.LittleEndian.PutUint32(w.buf[16:], snapLen)
+	binary.LittleEndian.PutUint32(w.buf[20:], uint32(linkType))
+
+	_, err := w.w.Write(w.buf[:24])
+
+	return err
+}
+
+// WritePacket writes packet record.
+func (w *Writer) WritePacket(ci CaptureInfo, data []byte) error {
+	if ci.CaptureLength != len(data) {
+		return fmt.Errorf("capture length %d doesn't match data length %d", ci.CaptureLength, len(data))
+	}
+
+	if ci.CaptureLength > ci.Length {
+		return fmt.Errorf("capture length %d is greater than packet length %d", ci.CaptureLength, ci.Length)
+	}
+
+	usec := ci.Timestamp.UnixNano() / int64(time.Microsecond)
+
+	binary.LittleEndian.PutUint32(w.buf[0:], uint32(usec/1e6))
+	binary.LittleEndian.PutUint32(w.buf[4:], uint32(usec%1e6))
+	binary.LittleEndian.PutUint32(w.buf[8:], uint32(ci.CaptureLength))
+	binary.LittleEndian.PutUint32(w.buf[12:], uint32(ci.Length))
+
+	if _, err := w.w.Write(w.buf[:16]); err != nil {
+		return err
+	}
+
+	_, err := w.w.Write(data)
+
+	return err
+}
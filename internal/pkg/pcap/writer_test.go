@@ -0,0 +1,45 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package pcap_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/internal/pkg/pcap"
+)
+
+func TestWriter(t *testing.T) {
+	var buf bytes.Buffer
+
+	w := pcap.NewWriter(&buf)
+
+	require.NoError(t, w.WriteFileHeader(65536, pcap.LinkTypeEthernet))
+
+	require.NoError(t, w.WritePacket(pcap.CaptureInfo{
+		Timestamp:     time.Unix(1617181920, 123456789),
+		CaptureLength: 4,
+		Length:        60,
+	}, []byte{1, 2, 3, 4}))
+
+	assert.Error(t, w.WritePacket(pcap.CaptureInfo{CaptureLength: 2, Length: 2}, []byte{1, 2, 3}))
+	assert.Error(t, w.WritePacket(pcap.CaptureInfo{CaptureLength: 3, Length: 2}, []byte{1, 2, 3}))
+
+	assert.Equal(t, []byte{
+		// file header
+		0xd4, 0xc3, 0xb2, 0xa1, 0x02, 0x00, 0x04, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x01, 0x00, 0x01, 0x00, 0x00, 0x00,
+		// record header
+		0xe0, 0x3c, 0x64, 0x60, 0x40, 0xe2, 0x01, 0x00,
+		0x04, 0x00, 0x00, 0x00, 0x3c, 0x00, 0x00, 0x00,
+		// data
+		0x01, 0x02, 0x03, 0x04,
+	}, buf.Bytes())
+}
@@ -0,0 +1,85 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package proxy builds HTTP proxy settings from the environment variables of the machine configuration.
+package proxy
+
+import (
+	"net/http"
+	"net/url"
+	"os"
+
+	"golang.org/x/net/http/httpproxy"
+
+	"github.com/talos-systems/talos/pkg/machinery/config"
+)
+
+// Proxy environment variables, lowercase variants are also accepted.
+const (
+	HTTPProxy  = "HTTP_PROXY"
+	HTTPSProxy = "HTTPS_PROXY"
+	NoProxy    = "NO_PROXY"
+)
+
+var lowercase = map[string]string{
+	HTTPProxy:  "http_proxy",
+	HTTPSProxy: "https_proxy",
+	NoProxy:    "no_proxy",
+}
+
+// lookup returns the value of the variable, machine configuration takes precedence over the process environment.
+func lookup(env config.Env, name string) string {
+	for _, key := range []string{name, lowercase[name]} {
+		if val, ok := env[key]; ok {
+			return val
+		}
+	}
+
+	for _, key := range []string{name, lowercase[name]} {
+		if val := os.Getenv(key); val != "" {
+			return val
+		}
+	}
+
+	return ""
+}
+
+// Config builds proxy configuration from the environment variables.
+//
+// NO_PROXY supports host names, domain suffixes and CIDRs (matched against the IP address hosts).
+func Config(env config.Env) *httpproxy.Config {
+	return &httpproxy.Config{
+		HTTPProxy:  lookup(env, HTTPProxy),
+		HTTPSProxy: lookup(env, HTTPSProxy),
+		NoProxy:    lookup(env, NoProxy),
+	}
+}
+
+// Func returns the function to be used as http.Transport.Proxy.
+//
+// Proxy settings are re-read for each request, so that the environment changes are picked up.
+func Func(env config.Env) func(*http.Request) (*url.URL, error) {
+	return func(req *http.Request) (*url.URL, error) {
+		return Config(env).ProxyFunc()(req.URL)
+	}
+}
+
+// Env returns the proxy variables set in the environment, or nil if there are none.
+func Env(env config.Env) map[string]string {
+	var result map[string]string
+
+	for _, name := range []string{HTTPProxy, HTTPSProxy, NoProxy} {
+		for _, key := range []string{name, lowercase[name]} {
+			if val, ok := env[key]; ok {
+				if result == nil {
+					result = map[string]string{}
+				}
+
+				result[key] = val
+			}
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,62 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package proxy_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/internal/pkg/proxy"
+)
+
+func TestFunc(t *testing.T) {
+	proxyFunc := proxy.Func(map[string]string{
+		"https_proxy": "http://proxy.example.com:3128",
+		"NO_PROXY":    "10.0.0.0/8,.internal.example.com,registry.local",
+	})
+
+	for _, tt := range []struct {
+		url      string
+		expected string
+	}{
+		{"https://registry-1.docker.io/v2", "http://proxy.example.com:3128"},
+		{"https://10.5.0.1:5000/v2", ""},
+		{"https://172.16.0.1:5000/v2", "http://proxy.example.com:3128"},
+		{"https://mirror.internal.example.com/v2", ""},
+		{"https://registry.local/v2", ""},
+		{"https://127.0.0.1:5000/v2", ""},
+	} {
+		req, err := http.NewRequest(http.MethodGet, tt.url, nil) //nolint:noctx
+		require.NoError(t, err)
+
+		proxyURL, err := proxyFunc(req)
+		require.NoError(t, err)
+
+		if tt.expected == "" {
+			assert.Nil(t, proxyURL, tt.url)
+		} else {
+			require.NotNil(t, proxyURL, tt.url)
+			assert.Equal(t, tt.expected, proxyURL.String(), tt.url)
+		}
+	}
+}
+
+func TestEnv(t *testing.T) {
+	assert.Equal(t, map[string]string{
+		"https_proxy": "http://proxy.example.com:3128",
+		"NO_PROXY":    "10.0.0.0/8",
+	}, proxy.Env(map[string]string{
+		"https_proxy": "http://proxy.example.com:3128",
+		"NO_PROXY":    "10.0.0.0/8",
+		"GRPC_GO_LOG": "99",
+	}))
+
+	assert.Nil(t, proxy.Env(map[string]string{
+		"GRPC_GO_LOG": "99",
+	}))
+}
@@ -0,0 +1,206 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package pstore implements reading kernel log dumps captured by the pstore backends (ramoops, EFI variables).
+package pstore
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TimestampFormat is the format of the dump timestamp used in the dump ID.
+const TimestampFormat = "20060102T150405Z"
+
+// Dump is a kernel log dump of the crashed boot.
+//
+// Single dump might be split by the backend into multiple pstore records.
+type Dump struct {
+	// Backend is the pstore backend which captured the dump (e.g. `ramoops`, `efi`).
+	Backend string
+	// Kind is the reason of the dump as reported by the kernel (e.g. `Panic`, `Oops`).
+	Kind string
+	// Timestamp is the time the dump was captured.
+	Timestamp time.Time
+	// Log is the captured kernel log.
+	Log []byte
+
+	records []string
+}
+
+// ID returns the unique identifier of the dump which can be used as a file name.
+func (d *Dump) ID() string {
+	return fmt.Sprintf("%s-%s-%s", d.Timestamp.UTC().Format(TimestampFormat), d.Backend, strings.ToLower(d.Kind))
+}
+
+// ParseID parses the dump ID into the timestamp, backend and kind.
+func ParseID(id string) (timestamp time.Time, backend, kind string, err error) {
+	parts := strings.SplitN(id, "-", 3)
+	if len(parts) != 3 {
+		return timestamp, "", "", fmt.Errorf("invalid dump ID %q", id)
+	}
+
+	timestamp, err = time.Parse(TimestampFormat, parts[0])
+	if err != nil {
+		return timestamp, "", "", fmt.Errorf("invalid dump ID %q: %w", id, err)
+	}
+
+	return timestamp, parts[1], parts[2], nil
+}
+
+// Reason returns the panic message (or the first line describing the failure) from the kernel log.
+func (d *Dump) Reason() string {
+	return Reason(d.Log)
+}
+
+// Remove the pstore records of the dump, so that the backend storage is freed.
+func (d *Dump) Remove(dir string) error {
+	for _, name := range d.records {
+		if err := os.Remove(filepath.Join(dir, name)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// headerRegexp matches the header kernel writes at the beginning of each record, e.g. `Panic#1 Part1`.
+var headerRegexp = regexp.MustCompile(`^(\w+)#(\d+) Part(\d+)$`)
+
+type record struct {
+	name      string
+	backend   string
+	kind      string
+	count     int
+	part      int
+	timestamp time.Time
+	contents  []byte
+}
+
+// ReadDumps reads the kernel log dumps from the pstore filesystem mounted at dir.
+//
+// Only `dmesg` records are read (console and userspace logs are ignored).
+// Dumps are returned sorted by the timestamp.
+func ReadDumps(dir string) ([]Dump, error) {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	var records []record
+
+	for _, entry := range entries {
+		// dmesg-<backend>-<id>
+		parts := strings.SplitN(entry.Name(), "-", 3)
+		if len(parts) != 3 || parts[0] != "dmesg" || !entry.Mode().IsRegular() {
+			continue
+		}
+
+		contents, err := ioutil.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		rec := record{
+			name:      entry.Name(),
+			backend:   parts[1],
+			kind:      "Unknown",
+			part:      1,
+			timestamp: entry.ModTime().Truncate(time.Second),
+			contents:  contents,
+		}
+
+		if idx := bytes.IndexByte(contents, '\n'); idx > 0 {
+			if matches := headerRegexp.FindSubmatch(contents[:idx]); matches != nil {
+				rec.kind = string(matches[1])
+				rec.count, _ = strconv.Atoi(string(matches[2])) //nolint:errcheck
+				rec.part, _ = strconv.Atoi(string(matches[3]))  //nolint:errcheck
+				rec.contents = contents[idx+1:]
+			}
+		}
+
+		records = append(records, rec)
+	}
+
+	// Part1 holds the end of the log, so parts are joined in the reverse order
+	sort.SliceStable(records, func(i, j int) bool {
+		if !records[i].timestamp.Equal(records[j].timestamp) {
+			return records[i].timestamp.Before(records[j].timestamp)
+		}
+
+		if records[i].backend != records[j].backend {
+			return records[i].backend < records[j].backend
+		}
+
+		if records[i].count != records[j].count {
+			return records[i].count < records[j].count
+		}
+
+		return records[i].part > records[j].part
+	})
+
+	var dumps []Dump
+
+	for i, rec := range records {
+		if i > 0 {
+			prev := records[i-1]
+
+			if prev.backend == rec.backend && prev.kind == rec.kind && prev.count == rec.count && prev.timestamp.Equal(rec.timestamp) && prev.part > rec.part {
+				last := &dumps[len(dumps)-1]
+
+				last.Log = append(last.Log, rec.contents...)
+				last.records = append(last.records, rec.name)
+
+				continue
+			}
+		}
+
+		dumps = append(dumps, Dump{
+			Backend:   rec.backend,
+			Kind:      rec.kind,
+			Timestamp: rec.timestamp,
+			Log:       append([]byte(nil), rec.contents...),
+			records:   []string{rec.name},
+		})
+	}
+
+	return dumps, nil
+}
+
+// kmsgPrefixRegexp matches the log level and timestamp prefix of the kernel log line, e.g. `<0>[   12.345678] `.
+var kmsgPrefixRegexp = regexp.MustCompile(`^(<\d+>)?\[\s*\d+\.\d+\]\s*`)
+
+// Reason returns the panic message (or the first line describing the failure) from the kernel log.
+func Reason(log []byte) string {
+	var fallback string
+
+	scanner := bufio.NewScanner(bytes.NewReader(log))
+
+	for scanner.Scan() {
+		line := kmsgPrefixRegexp.ReplaceAllString(scanner.Text(), "")
+
+		if strings.HasPrefix(line, "Kernel panic - not syncing: ") {
+			return strings.TrimSpace(line)
+		}
+
+		if fallback == "" && (strings.HasPrefix(line, "BUG: ") || strings.HasPrefix(line, "Oops: ") || strings.HasPrefix(line, "general protection fault")) {
+			fallback = strings.TrimSpace(line)
+		}
+	}
+
+	return fallback
+}
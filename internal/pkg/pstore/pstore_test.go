@@ -0,0 +1,76 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package pstore_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/internal/pkg/pstore"
+)
+
+func writeRecord(t *testing.T, dir, name, contents string, timestamp time.Time) {
+	path := filepath.Join(dir, name)
+
+	require.NoError(t, ioutil.WriteFile(path, []byte(contents), 0o644))
+	require.NoError(t, os.Chtimes(path, timestamp, timestamp))
+}
+
+func TestReadDumps(t *testing.T) {
+	dir := t.TempDir()
+
+	panicTime := time.Date(2021, 6, 1, 10, 15, 0, 0, time.UTC)
+	oopsTime := panicTime.Add(-time.Hour)
+
+	writeRecord(t, dir, "dmesg-efi-162254250002001", "Panic#1 Part2\n<6>[    1.000000] first line\n", panicTime)
+	writeRecord(t, dir, "dmesg-efi-162254250001001", "Panic#1 Part1\n<0>[    2.000000] Kernel panic - not syncing: sysrq triggered crash\n", panicTime)
+	writeRecord(t, dir, "dmesg-ramoops-0", "Oops#1 Part1\n<1>[    3.000000] BUG: kernel NULL pointer dereference, address: 0000000000000000\n", oopsTime)
+	writeRecord(t, dir, "console-ramoops-0", "console log\n", panicTime)
+
+	dumps, err := pstore.ReadDumps(dir)
+	require.NoError(t, err)
+	require.Len(t, dumps, 2)
+
+	assert.Equal(t, "ramoops", dumps[0].Backend)
+	assert.Equal(t, "Oops", dumps[0].Kind)
+	assert.Equal(t, "20210601T091500Z-ramoops-oops", dumps[0].ID())
+	assert.Equal(t, "BUG: kernel NULL pointer dereference, address: 0000000000000000", dumps[0].Reason())
+
+	assert.Equal(t, "efi", dumps[1].Backend)
+	assert.Equal(t, "Panic", dumps[1].Kind)
+	assert.Equal(t, "20210601T101500Z-efi-panic", dumps[1].ID())
+	assert.Equal(t, "<6>[    1.000000] first line\n<0>[    2.000000] Kernel panic - not syncing: sysrq triggered crash\n", string(dumps[1].Log))
+	assert.Equal(t, "Kernel panic - not syncing: sysrq triggered crash", dumps[1].Reason())
+
+	timestamp, backend, kind, err := pstore.ParseID(dumps[1].ID())
+	require.NoError(t, err)
+	assert.Equal(t, panicTime, timestamp)
+	assert.Equal(t, "efi", backend)
+	assert.Equal(t, "panic", kind)
+
+	require.NoError(t, dumps[1].Remove(dir))
+
+	dumps, err = pstore.ReadDumps(dir)
+	require.NoError(t, err)
+	require.Len(t, dumps, 1)
+
+	dumps, err = pstore.ReadDumps(filepath.Join(dir, "missing"))
+	require.NoError(t, err)
+	assert.Empty(t, dumps)
+}
+
+func TestParseIDInvalid(t *testing.T) {
+	_, _, _, err := pstore.ParseID("ramoops-panic")
+	assert.Error(t, err)
+
+	_, _, _, err = pstore.ParseID("2021-ramoops-panic")
+	assert.Error(t, err)
+}
@@ -0,0 +1,148 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package registration implements a client of the external inventory API which keeps track of the nodes.
+//
+// Node registers (or updates) its facts, and sends heartbeats while the facts don't change:
+//
+//	PUT  {endpoint}/v1/nodes/{nodeId}            {"nodeId": "...", "hostname": "...", "platform": "...", ...}
+//	POST {endpoint}/v1/nodes/{nodeId}/heartbeat
+//
+// Heartbeat responds with 404 if the inventory doesn't know the node (e.g. the inventory was reset),
+// so the node registers again.
+package registration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"time"
+)
+
+// ErrNotRegistered is returned by the heartbeat if the node is not known to the inventory.
+var ErrNotRegistered = errors.New("node is not registered")
+
+// Node describes the facts reported to the inventory.
+type Node struct {
+	NodeID      string   `json:"nodeId"`
+	SystemUUID  string   `json:"systemUUID,omitempty"`
+	Hostname    string   `json:"hostname"`
+	MachineType string   `json:"machineType"`
+	Platform    string   `json:"platform"`
+	Version     string   `json:"version"`
+	Addresses   []string `json:"addresses"`
+}
+
+// Equal returns true if the facts are the same.
+func (n *Node) Equal(other *Node) bool {
+	if n.NodeID != other.NodeID ||
+		n.SystemUUID != other.SystemUUID ||
+		n.Hostname != other.Hostname ||
+		n.MachineType != other.MachineType ||
+		n.Platform != other.Platform ||
+		n.Version != other.Version ||
+		len(n.Addresses) != len(other.Addresses) {
+		return false
+	}
+
+	for i := range n.Addresses {
+		if n.Addresses[i] != other.Addresses[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Client of the inventory API.
+type Client struct {
+	endpoint   *url.URL
+	token      string
+	httpClient *http.Client
+}
+
+// NewClient creates a client for the inventory API URL.
+func NewClient(endpoint, token string) (*Client, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing registration endpoint: %w", err)
+	}
+
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("registration endpoint should be an http(s) URL: %q", endpoint)
+	}
+
+	return &Client{
+		endpoint: u,
+		token:    token,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}, nil
+}
+
+// Register creates or updates the node in the inventory.
+func (c *Client) Register(ctx context.Context, node *Node) error {
+	body, err := json.Marshal(node)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.do(ctx, http.MethodPut, bytes.NewReader(body), "v1", "nodes", node.NodeID)
+
+	return err
+}
+
+// Heartbeat notifies the inventory that the node is alive.
+func (c *Client) Heartbeat(ctx context.Context, nodeID string) error {
+	status, err := c.do(ctx, http.MethodPost, nil, "v1", "nodes", nodeID, "heartbeat")
+	if status == http.StatusNotFound {
+		return ErrNotRegistered
+	}
+
+	return err
+}
+
+func (c *Client) do(ctx context.Context, method string, body io.Reader, elem ...string) (int, error) {
+	u := *c.endpoint
+	u.Path = path.Join(append([]string{u.Path}, elem...)...)
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return 0, err
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+
+	defer resp.Body.Close() //nolint:errcheck
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return resp.StatusCode, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("%s %s: unexpected status %s: %s", method, u.String(), resp.Status, bytes.TrimSpace(respBody))
+	}
+
+	return resp.StatusCode, nil
+}
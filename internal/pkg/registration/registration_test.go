@@ -0,0 +1,123 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package registration_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/internal/pkg/registration"
+)
+
+type mockInventory struct {
+	mu    sync.Mutex
+	nodes map[string]registration.Node
+	beats map[string]int
+}
+
+func (inv *mockInventory) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Header.Get("Authorization") != "Bearer secret" {
+		http.Error(w, "invalid token", http.StatusUnauthorized)
+
+		return
+	}
+
+	inv.mu.Lock()
+	defer inv.mu.Unlock()
+
+	parts := strings.Split(strings.TrimPrefix(req.URL.Path, "/api/v1/nodes/"), "/")
+
+	switch {
+	case req.Method == http.MethodPut && len(parts) == 1:
+		var node registration.Node
+
+		if err := json.NewDecoder(req.Body).Decode(&node); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		inv.nodes[parts[0]] = node
+	case req.Method == http.MethodPost && len(parts) == 2 && parts[1] == "heartbeat":
+		if _, ok := inv.nodes[parts[0]]; !ok {
+			http.NotFound(w, req)
+
+			return
+		}
+
+		inv.beats[parts[0]]++
+	default:
+		http.Error(w, "unexpected request", http.StatusBadRequest)
+	}
+}
+
+func TestRegistration(t *testing.T) {
+	inv := &mockInventory{
+		nodes: map[string]registration.Node{},
+		beats: map[string]int{},
+	}
+
+	srv := httptest.NewServer(inv)
+	defer srv.Close()
+
+	client, err := registration.NewClient(srv.URL+"/api/", "secret")
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	assert.Equal(t, registration.ErrNotRegistered, client.Heartbeat(ctx, "node-1"))
+
+	node := registration.Node{
+		NodeID:      "node-1",
+		Hostname:    "talos-worker-1",
+		MachineType: "worker",
+		Platform:    "metal",
+		Version:     "v0.10.0",
+		Addresses:   []string{"172.20.0.2"},
+	}
+
+	require.NoError(t, client.Register(ctx, &node))
+	require.NoError(t, client.Heartbeat(ctx, "node-1"))
+	require.NoError(t, client.Heartbeat(ctx, "node-1"))
+
+	assert.Equal(t, map[string]registration.Node{"node-1": node}, inv.nodes)
+	assert.Equal(t, 2, inv.beats["node-1"])
+
+	client, err = registration.NewClient(srv.URL+"/api/", "")
+	require.NoError(t, err)
+
+	assert.EqualError(t, client.Register(ctx, &node), "PUT "+srv.URL+"/api/v1/nodes/node-1: unexpected status 401 Unauthorized: invalid token")
+}
+
+func TestNewClientInvalid(t *testing.T) {
+	_, err := registration.NewClient("inventory.example.com", "")
+	assert.EqualError(t, err, `registration endpoint should be an http(s) URL: "inventory.example.com"`)
+}
+
+func TestNodeEqual(t *testing.T) {
+	node := registration.Node{
+		NodeID:    "node-1",
+		Hostname:  "talos-worker-1",
+		Addresses: []string{"172.20.0.2", "fd00::2"},
+	}
+
+	other := node
+	assert.True(t, node.Equal(&other))
+
+	other.Addresses = []string{"172.20.0.2"}
+	assert.False(t, node.Equal(&other))
+
+	other = node
+	other.Hostname = "talos-worker-2"
+	assert.False(t, node.Equal(&other))
+}
@@ -0,0 +1,116 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package resolvconf renders resolv.conf for the host and for the kubelet.
+package resolvconf
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"strings"
+
+	talosnet "github.com/talos-systems/net"
+)
+
+const (
+	// maxNameservers is the number of nameservers the libc resolver uses.
+	maxNameservers = 3
+
+	// maxKubeletSearchDomains is the number of host search domains passed to the pods.
+	//
+	// Kubelet allows up to 6 search domains in the pod resolv.conf, and 3 of them are taken by the cluster domains.
+	maxKubeletSearchDomains = 3
+)
+
+// SearchDomains returns the search domains to use.
+//
+// If no search domains are set, the domain of the hostname is used unless disabled.
+func SearchDomains(searchDomains []string, disableDefault bool) []string {
+	if len(searchDomains) > 0 || disableDefault {
+		return searchDomains
+	}
+
+	if domain, err := talosnet.DomainName(); err == nil && domain != "" {
+		return []string{domain}
+	}
+
+	return nil
+}
+
+// RenderKubelet builds the resolv.conf contents used by the kubelet for the pods.
+//
+// Loopback nameservers are not reachable from the pods, so they are skipped.
+func RenderKubelet(servers, searchDomains []string) []byte {
+	filtered := make([]string, 0, len(servers))
+
+	for _, server := range servers {
+		if ip := net.ParseIP(server); ip != nil && ip.IsLoopback() {
+			continue
+		}
+
+		filtered = append(filtered, server)
+	}
+
+	if len(searchDomains) > maxKubeletSearchDomains {
+		searchDomains = searchDomains[:maxKubeletSearchDomains]
+	}
+
+	return Render(filtered, searchDomains)
+}
+
+// Parse extracts the nameservers and search domains from the resolv.conf contents.
+func Parse(contents []byte) (servers, searchDomains []string) {
+	scanner := bufio.NewScanner(bytes.NewReader(contents))
+
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+
+		if len(fields) < 2 {
+			continue
+		}
+
+		switch fields[0] {
+		case "nameserver":
+			servers = append(servers, fields[1])
+		case "search":
+			searchDomains = fields[1:]
+		}
+	}
+
+	return servers, searchDomains
+}
+
+// WriteKubeletFromHost generates kubelet resolv.conf from the host resolv.conf.
+func WriteKubeletFromHost(hostPath, kubeletPath string) error {
+	contents, err := ioutil.ReadFile(hostPath)
+	if err != nil {
+		return fmt.Errorf("error reading %q: %w", hostPath, err)
+	}
+
+	servers, searchDomains := Parse(contents)
+
+	return ioutil.WriteFile(kubeletPath, RenderKubelet(servers, searchDomains), 0o644)
+}
+
+// Render builds the host resolv.conf contents.
+func Render(servers, searchDomains []string) []byte {
+	var resolvconf strings.Builder
+
+	for idx, server := range servers {
+		if idx >= maxNameservers {
+			break
+		}
+
+		fmt.Fprintf(&resolvconf, "nameserver %s\n", server)
+	}
+
+	if len(searchDomains) > 0 {
+		fmt.Fprintf(&resolvconf, "search %s\n", strings.Join(searchDomains, " "))
+	}
+
+	return []byte(resolvconf.String())
+}
@@ -0,0 +1,40 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package resolvconf_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/talos-systems/talos/internal/pkg/resolvconf"
+)
+
+func TestRender(t *testing.T) {
+	servers := []string{"127.0.0.53", "10.0.0.1", "2001:db8::1", "1.1.1.1"}
+	searchDomains := []string{"a.example.com", "b.example.com", "c.example.com", "d.example.com"}
+
+	assert.Equal(t,
+		"nameserver 127.0.0.53\nnameserver 10.0.0.1\nnameserver 2001:db8::1\nsearch a.example.com b.example.com c.example.com d.example.com\n",
+		string(resolvconf.Render(servers, searchDomains)),
+	)
+
+	assert.Equal(t,
+		"nameserver 10.0.0.1\nnameserver 2001:db8::1\nnameserver 1.1.1.1\nsearch a.example.com b.example.com c.example.com\n",
+		string(resolvconf.RenderKubelet(servers, searchDomains)),
+	)
+
+	assert.Equal(t, "nameserver 10.0.0.1\n", string(resolvconf.Render([]string{"10.0.0.1"}, nil)))
+}
+
+func TestParse(t *testing.T) {
+	servers, searchDomains := resolvconf.Parse([]byte("# comment\nnameserver 10.0.0.1\nnameserver 2001:db8::1\nsearch example.com example.org\noptions ndots:5\n"))
+
+	assert.Equal(t, []string{"10.0.0.1", "2001:db8::1"}, servers)
+	assert.Equal(t, []string{"example.com", "example.org"}, searchDomains)
+
+	assert.Equal(t, []string{"example.com"}, resolvconf.SearchDomains([]string{"example.com"}, false))
+	assert.Equal(t, []string(nil), resolvconf.SearchDomains(nil, true))
+}
@@ -0,0 +1,100 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package revocation
+
+import (
+	"crypto/x509"
+	"fmt"
+	"log"
+	"math/big"
+	"sync"
+)
+
+// Checker rejects the revoked client certificates.
+//
+// Revocation lists are kept per source (control plane node), a certificate is revoked
+// if it's found in any of the lists.
+type Checker struct {
+	mu      sync.RWMutex
+	lists   map[string][]string
+	revoked map[string]struct{}
+}
+
+// NewChecker initializes the Checker.
+func NewChecker() *Checker {
+	return &Checker{
+		lists:   map[string][]string{},
+		revoked: map[string]struct{}{},
+	}
+}
+
+// Update replaces the revocation list fetched from the source.
+func (c *Checker) Update(source string, serials []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.lists[source] = serials
+
+	c.rebuild()
+}
+
+// Retain drops the revocation lists of the sources which are not listed.
+func (c *Checker) Retain(sources []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	keep := make(map[string]struct{}, len(sources))
+
+	for _, source := range sources {
+		keep[source] = struct{}{}
+	}
+
+	for source := range c.lists {
+		if _, ok := keep[source]; !ok {
+			delete(c.lists, source)
+		}
+	}
+
+	c.rebuild()
+}
+
+func (c *Checker) rebuild() {
+	c.revoked = map[string]struct{}{}
+
+	for _, serials := range c.lists {
+		for _, serial := range serials {
+			c.revoked[serial] = struct{}{}
+		}
+	}
+}
+
+// IsRevoked returns true if the certificate with the serial number is revoked.
+func (c *Checker) IsRevoked(serial *big.Int) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	_, revoked := c.revoked[serial.String()]
+
+	return revoked
+}
+
+// VerifyPeerCertificate implements tls.Config.VerifyPeerCertificate hook.
+//
+// Connection is rejected if the verified client certificate is revoked.
+func (c *Checker) VerifyPeerCertificate(rawCerts [][]byte, verifiedChains [][]*x509.Certificate) error {
+	if len(verifiedChains) == 0 || len(verifiedChains[0]) == 0 {
+		return nil
+	}
+
+	leaf := verifiedChains[0][0]
+
+	if c.IsRevoked(leaf.SerialNumber) {
+		log.Printf("rejected revoked client certificate %q (serial number %s)", leaf.Subject, leaf.SerialNumber)
+
+		return fmt.Errorf("certificate %s is revoked", leaf.SerialNumber)
+	}
+
+	return nil
+}
@@ -0,0 +1,42 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package revocation implements the revocation of the client certificates.
+//
+// trustd keeps the list of the revoked certificate serial numbers in the STATE partition,
+// apid fetches the lists from trustd on the control plane nodes and rejects the revoked client certificates.
+package revocation
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// ParseSerialNumber parses the certificate serial number.
+//
+// Serial number is either decimal, hex with the `0x` prefix or colon-separated hex (as printed by `openssl x509 -text`).
+func ParseSerialNumber(s string) (*big.Int, error) {
+	s = strings.TrimSpace(s)
+
+	var (
+		serial = new(big.Int)
+		ok     bool
+	)
+
+	switch {
+	case strings.Contains(s, ":"):
+		_, ok = serial.SetString(strings.ReplaceAll(s, ":", ""), 16)
+	case strings.HasPrefix(s, "0x"), strings.HasPrefix(s, "0X"):
+		_, ok = serial.SetString(s[2:], 16)
+	default:
+		_, ok = serial.SetString(s, 10)
+	}
+
+	if !ok || serial.Sign() <= 0 {
+		return nil, fmt.Errorf("invalid certificate serial number %q", s)
+	}
+
+	return serial, nil
+}
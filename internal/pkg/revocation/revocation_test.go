@@ -0,0 +1,69 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package revocation_test
+
+import (
+	"crypto/x509"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/internal/pkg/revocation"
+)
+
+func TestParseSerialNumber(t *testing.T) {
+	for _, tt := range []struct {
+		in       string
+		expected int64
+	}{
+		{"4660", 4660},
+		{" 4660\n", 4660},
+		{"0x1234", 4660},
+		{"0X1234", 4660},
+		{"12:34", 4660},
+		{"00:12:34", 4660},
+	} {
+		serial, err := revocation.ParseSerialNumber(tt.in)
+		require.NoError(t, err, tt.in)
+
+		assert.Equal(t, big.NewInt(tt.expected), serial, tt.in)
+	}
+
+	for _, in := range []string{"", "0", "-1", "1234abcd", "0x", "12:zz"} {
+		_, err := revocation.ParseSerialNumber(in)
+		assert.Error(t, err, in)
+	}
+}
+
+func TestChecker(t *testing.T) {
+	checker := revocation.NewChecker()
+
+	assert.False(t, checker.IsRevoked(big.NewInt(1)))
+
+	checker.Update("172.20.0.2", []string{"1", "2"})
+	checker.Update("172.20.0.3", []string{"2", "3"})
+
+	for _, serial := range []int64{1, 2, 3} {
+		assert.True(t, checker.IsRevoked(big.NewInt(serial)), serial)
+	}
+
+	assert.False(t, checker.IsRevoked(big.NewInt(4)))
+
+	checker.Update("172.20.0.2", []string{"4"})
+
+	assert.False(t, checker.IsRevoked(big.NewInt(1)))
+	assert.True(t, checker.IsRevoked(big.NewInt(4)))
+
+	checker.Retain([]string{"172.20.0.2"})
+
+	assert.False(t, checker.IsRevoked(big.NewInt(3)))
+	assert.True(t, checker.IsRevoked(big.NewInt(4)))
+
+	assert.NoError(t, checker.VerifyPeerCertificate(nil, nil))
+	assert.NoError(t, checker.VerifyPeerCertificate(nil, [][]*x509.Certificate{{{SerialNumber: big.NewInt(5)}}}))
+	assert.Error(t, checker.VerifyPeerCertificate(nil, [][]*x509.Certificate{{{SerialNumber: big.NewInt(4)}}}))
+}
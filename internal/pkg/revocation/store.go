@@ -0,0 +1,109 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package revocation
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"sync"
+	"time"
+)
+
+// Entry describes a revoked certificate.
+type Entry struct {
+	SerialNumber string    `json:"serialNumber"`
+	RevokedAt    time.Time `json:"revokedAt"`
+}
+
+// Store keeps the list of the revoked certificates persisted in a file.
+type Store struct {
+	path string
+
+	mu      sync.Mutex
+	entries []Entry
+}
+
+// NewStore loads the revocation list from the file, the file is created on the first revocation.
+func NewStore(path string) (*Store, error) {
+	s := &Store{
+		path: path,
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return s, nil
+		}
+
+		return nil, err
+	}
+
+	if err = json.Unmarshal(b, &s.entries); err != nil {
+		return nil, fmt.Errorf("error decoding revocation list %q: %w", path, err)
+	}
+
+	return s, nil
+}
+
+// Revoke adds the certificate to the revocation list.
+//
+// Revoke returns false if the certificate is already revoked.
+func (s *Store) Revoke(serial *big.Int) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	serialNumber := serial.String()
+
+	for _, entry := range s.entries {
+		if entry.SerialNumber == serialNumber {
+			return false, nil
+		}
+	}
+
+	entries := append(append([]Entry(nil), s.entries...), Entry{
+		SerialNumber: serialNumber,
+		RevokedAt:    time.Now(),
+	})
+
+	if err := s.write(entries); err != nil {
+		return false, err
+	}
+
+	s.entries = entries
+
+	return true, nil
+}
+
+// SerialNumbers returns the serial numbers of the revoked certificates (decimal).
+func (s *Store) SerialNumbers() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	serials := make([]string, 0, len(s.entries))
+
+	for _, entry := range s.entries {
+		serials = append(serials, entry.SerialNumber)
+	}
+
+	return serials
+}
+
+func (s *Store) write(entries []Entry) error {
+	b, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+
+	tmpPath := s.path + ".tmp"
+
+	if err = ioutil.WriteFile(tmpPath, b, 0o600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpPath, s.path)
+}
@@ -0,0 +1,56 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package revocation_test
+
+import (
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/internal/pkg/revocation"
+)
+
+func TestStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "talos")
+	require.NoError(t, err)
+
+	defer os.RemoveAll(dir) //nolint:errcheck
+
+	path := filepath.Join(dir, "revoked.json")
+
+	store, err := revocation.NewStore(path)
+	require.NoError(t, err)
+
+	assert.Empty(t, store.SerialNumbers())
+
+	revoked, err := store.Revoke(big.NewInt(42))
+	require.NoError(t, err)
+	assert.True(t, revoked)
+
+	revoked, err = store.Revoke(big.NewInt(42))
+	require.NoError(t, err)
+	assert.False(t, revoked)
+
+	revoked, err = store.Revoke(big.NewInt(43))
+	require.NoError(t, err)
+	assert.True(t, revoked)
+
+	assert.Equal(t, []string{"42", "43"}, store.SerialNumbers())
+
+	store, err = revocation.NewStore(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"42", "43"}, store.SerialNumbers())
+
+	require.NoError(t, ioutil.WriteFile(path, []byte("garbage"), 0o600))
+
+	_, err = revocation.NewStore(path)
+	assert.Error(t, err)
+}
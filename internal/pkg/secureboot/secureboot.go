@@ -0,0 +1,114 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package secureboot provides helpers to build signed Unified Kernel Images
+// and to inspect the SecureBoot state of the running system.
+package secureboot
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// globalVariableGUID is the EFI global variable vendor GUID.
+	globalVariableGUID = "8be4df61-93ca-11d2-aa0d-00e098032b8c"
+
+	// loaderVariableGUID is the vendor GUID used by systemd-boot and systemd-stub.
+	loaderVariableGUID = "4a67b082-0a4c-41cf-b6c7-440b29bb8c4f"
+
+	// pcrPath is the path to the PCR bank exposed by the kernel.
+	pcrPath = "/sys/class/tpm/tpm0/pcr-sha256"
+
+	// SecureBootPolicyPCR is the PCR which measures the SecureBoot policy (PK, KEK, db, dbx).
+	SecureBootPolicyPCR = 7
+
+	// KernelImagePCR is the PCR where systemd-stub measures the UKI sections.
+	KernelImagePCR = 11
+)
+
+// Status describes the SecureBoot state of the system.
+type Status struct {
+	// SecureBoot indicates that the firmware enforces SecureBoot.
+	SecureBoot bool
+	// SetupMode indicates that the firmware has no platform key enrolled.
+	SetupMode bool
+	// UKI indicates that the system was booted from a Unified Kernel Image.
+	UKI bool
+}
+
+// ReadStatus reads SecureBoot state from EFI variables at the specified efivarfs path.
+//
+// If the system was not booted via EFI, an empty status is returned.
+func ReadStatus(efivarsPath string) (Status, error) {
+	var (
+		status Status
+		err    error
+	)
+
+	if _, err = os.Stat(efivarsPath); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return status, nil
+		}
+
+		return status, err
+	}
+
+	if status.SecureBoot, err = readBoolVariable(efivarsPath, "SecureBoot", globalVariableGUID); err != nil {
+		return status, err
+	}
+
+	if status.SetupMode, err = readBoolVariable(efivarsPath, "SetupMode", globalVariableGUID); err != nil {
+		return status, err
+	}
+
+	if _, err = os.Stat(variablePath(efivarsPath, "StubInfo", loaderVariableGUID)); err == nil {
+		status.UKI = true
+	}
+
+	return status, nil
+}
+
+// ReadPCR returns the hex encoded SHA256 value of the PCR.
+//
+// Empty value is returned if the TPM PCR bank is not exposed by the kernel.
+func ReadPCR(index int) (string, error) {
+	contents, err := ioutil.ReadFile(filepath.Join(pcrPath, fmt.Sprintf("%d", index)))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", nil
+		}
+
+		return "", err
+	}
+
+	return strings.ToLower(strings.TrimSpace(string(contents))), nil
+}
+
+func variablePath(efivarsPath, name, guid string) string {
+	return filepath.Join(efivarsPath, name+"-"+guid)
+}
+
+func readBoolVariable(efivarsPath, name, guid string) (bool, error) {
+	contents, err := ioutil.ReadFile(variablePath(efivarsPath, name, guid))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	// first 4 bytes are variable attributes
+	if len(contents) < 5 {
+		return false, fmt.Errorf("unexpected EFI variable %s length: %s", name, hex.EncodeToString(contents))
+	}
+
+	return contents[4] == 1, nil
+}
@@ -0,0 +1,41 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package secureboot_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/internal/pkg/secureboot"
+)
+
+func TestReadStatus(t *testing.T) {
+	dir, err := ioutil.TempDir("", "efivars")
+	require.NoError(t, err)
+
+	defer os.RemoveAll(dir) //nolint:errcheck
+
+	status, err := secureboot.ReadStatus(filepath.Join(dir, "missing"))
+	require.NoError(t, err)
+	assert.Equal(t, secureboot.Status{}, status)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "SecureBoot-8be4df61-93ca-11d2-aa0d-00e098032b8c"), []byte{0x06, 0, 0, 0, 1}, 0o600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "SetupMode-8be4df61-93ca-11d2-aa0d-00e098032b8c"), []byte{0x06, 0, 0, 0, 0}, 0o600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "StubInfo-4a67b082-0a4c-41cf-b6c7-440b29bb8c4f"), []byte{0x06, 0, 0, 0}, 0o600))
+
+	status, err = secureboot.ReadStatus(dir)
+	require.NoError(t, err)
+	assert.Equal(t, secureboot.Status{SecureBoot: true, UKI: true}, status)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "SetupMode-8be4df61-93ca-11d2-aa0d-00e098032b8c"), []byte{0x06}, 0o600))
+
+	_, err = secureboot.ReadStatus(dir)
+	assert.Error(t, err)
+}
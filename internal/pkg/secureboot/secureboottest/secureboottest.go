@@ -0,0 +1,122 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package secureboottest provides helpers for testing Unified Kernel Image assembly and signing.
+package secureboottest
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"debug/pe"
+	"encoding/binary"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// RequireTool skips the test if the tool is not available.
+func RequireTool(t *testing.T, name string) {
+	if _, err := exec.LookPath(name); err != nil {
+		t.Skipf("%s binary is not available, skipping the test", name)
+	}
+}
+
+// WriteStub writes a minimal PE32+ EFI application which can be used in place of systemd-stub.
+func WriteStub(t *testing.T, path string) {
+	const (
+		fileAlignment = 0x200
+		headersSize   = 0x400
+	)
+
+	var buf bytes.Buffer
+
+	dosHeader := make([]byte, 0x40)
+	copy(dosHeader, "MZ")
+	binary.LittleEndian.PutUint32(dosHeader[0x3c:], uint32(len(dosHeader)))
+
+	buf.Write(dosHeader)
+	buf.WriteString("PE\x00\x00")
+
+	for _, header := range []interface{}{
+		pe.FileHeader{
+			Machine:              pe.IMAGE_FILE_MACHINE_AMD64,
+			NumberOfSections:     1,
+			SizeOfOptionalHeader: uint16(binary.Size(pe.OptionalHeader64{})),
+			Characteristics:      pe.IMAGE_FILE_EXECUTABLE_IMAGE | pe.IMAGE_FILE_LARGE_ADDRESS_AWARE,
+		},
+		pe.OptionalHeader64{
+			Magic:               0x20b,
+			SizeOfCode:          fileAlignment,
+			AddressOfEntryPoint: 0x1000,
+			BaseOfCode:          0x1000,
+			SectionAlignment:    0x1000,
+			FileAlignment:       fileAlignment,
+			SizeOfImage:         0x2000,
+			SizeOfHeaders:       headersSize,
+			Subsystem:           pe.IMAGE_SUBSYSTEM_EFI_APPLICATION,
+			NumberOfRvaAndSizes: 16,
+		},
+		pe.SectionHeader32{
+			Name:             [8]uint8{'.', 't', 'e', 'x', 't'},
+			VirtualSize:      0x10,
+			VirtualAddress:   0x1000,
+			SizeOfRawData:    fileAlignment,
+			PointerToRawData: headersSize,
+			Characteristics:  pe.IMAGE_SCN_CNT_CODE | pe.IMAGE_SCN_MEM_EXECUTE | pe.IMAGE_SCN_MEM_READ,
+		},
+	} {
+		require.NoError(t, binary.Write(&buf, binary.LittleEndian, header))
+	}
+
+	buf.Write(make([]byte, headersSize+fileAlignment-buf.Len()))
+
+	require.NoError(t, ioutil.WriteFile(path, buf.Bytes(), 0o644))
+}
+
+// WriteSigningKey generates the SecureBoot signing key and the self-signed certificate in PEM format in the directory.
+func WriteSigningKey(t *testing.T, dir string) (key, cert string) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "Test SecureBoot Signing Key"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &privateKey.PublicKey, privateKey)
+	require.NoError(t, err)
+
+	key = filepath.Join(dir, "signing-key.pem")
+	cert = filepath.Join(dir, "signing-cert.pem")
+
+	require.NoError(t, ioutil.WriteFile(key, pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(privateKey)}), 0o600))
+	require.NoError(t, ioutil.WriteFile(cert, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+
+	return key, cert
+}
+
+// AssertSigned verifies that the EFI binary carries an Authenticode signature.
+func AssertSigned(t *testing.T, path string) {
+	f, err := pe.Open(path)
+	require.NoError(t, err)
+
+	defer f.Close() //nolint:errcheck
+
+	header, ok := f.OptionalHeader.(*pe.OptionalHeader64)
+	require.True(t, ok)
+
+	require.NotZero(t, header.DataDirectory[pe.IMAGE_DIRECTORY_ENTRY_SECURITY].Size, "%q is not signed", path)
+}
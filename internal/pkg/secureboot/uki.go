@@ -0,0 +1,142 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package secureboot
+
+import (
+	"debug/pe"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// UKI describes the contents of a Unified Kernel Image.
+type UKI struct {
+	// Stub is the path to the EFI stub (systemd-stub).
+	Stub string
+	// Kernel is the path to the kernel image.
+	Kernel string
+	// Initrd is the path to the initramfs.
+	Initrd string
+	// OSRelease is the path to the os-release file.
+	OSRelease string
+	// Cmdline is the kernel command line embedded into the image.
+	//
+	// With SecureBoot enabled the stub ignores any command line passed by the firmware.
+	Cmdline string
+}
+
+// section offsets follow the layout documented for systemd-stub.
+var sections = []struct {
+	name string
+	vma  string
+}{
+	{".osrel", "0x20000"},
+	{".cmdline", "0x30000"},
+	{".linux", "0x2000000"},
+	{".initrd", "0x3000000"},
+}
+
+// Build assembles the Unified Kernel Image at the output path.
+func (u *UKI) Build(output string) error {
+	tmpDir, err := ioutil.TempDir("", "uki")
+	if err != nil {
+		return err
+	}
+
+	defer os.RemoveAll(tmpDir) //nolint:errcheck
+
+	cmdline := filepath.Join(tmpDir, "cmdline")
+
+	if err = ioutil.WriteFile(cmdline, []byte(u.Cmdline), 0o600); err != nil {
+		return err
+	}
+
+	paths := map[string]string{
+		".osrel":   u.OSRelease,
+		".cmdline": cmdline,
+		".linux":   u.Kernel,
+		".initrd":  u.Initrd,
+	}
+
+	args := []string{}
+
+	for _, section := range sections {
+		args = append(args,
+			"--add-section", section.name+"="+paths[section.name],
+			"--change-section-vma", section.name+"="+section.vma,
+		)
+	}
+
+	args = append(args, u.Stub, output)
+
+	return run("objcopy", args...)
+}
+
+// ReadCmdline reads the kernel command line embedded into the Unified Kernel Image.
+func ReadCmdline(path string) (string, error) {
+	f, err := pe.Open(path)
+	if err != nil {
+		return "", err
+	}
+
+	defer f.Close() //nolint:errcheck
+
+	section := f.Section(".cmdline")
+	if section == nil {
+		return "", fmt.Errorf("%q has no .cmdline section", path)
+	}
+
+	b, err := section.Data()
+	if err != nil {
+		return "", err
+	}
+
+	// section data is padded to the file alignment
+	return strings.TrimSpace(strings.TrimRight(string(b), "\x00")), nil
+}
+
+// Sign signs the EFI binary in place with the key and the certificate.
+func Sign(path, key, cert string) error {
+	return run("sbsign", "--key", key, "--cert", cert, "--output", path, path)
+}
+
+// WriteCertificateDER converts PEM encoded certificate to DER format
+// which is accepted by the firmware setup for the `db` key enrollment.
+func WriteCertificateDER(cert, output string) error {
+	contents, err := ioutil.ReadFile(cert)
+	if err != nil {
+		return err
+	}
+
+	block, _ := pem.Decode(contents)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return fmt.Errorf("failed to decode PEM certificate %q", cert)
+	}
+
+	if err = os.MkdirAll(filepath.Dir(output), 0o700); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(output, block.Bytes, 0o600)
+}
+
+func run(name string, args ...string) error {
+	log.Printf("executing: %s %s", name, strings.Join(args, " "))
+
+	cmd := exec.Command(name, args...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to run %s: %w", name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package secureboot_test
+
+import (
+	"bytes"
+	"crypto/x509"
+	"debug/pe"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/internal/pkg/secureboot"
+	"github.com/talos-systems/talos/internal/pkg/secureboot/secureboottest"
+)
+
+func buildUKI(t *testing.T, dir string) (string, *secureboot.UKI) {
+	uki := &secureboot.UKI{
+		Stub:      filepath.Join(dir, "stub.efi"),
+		Kernel:    filepath.Join(dir, "vmlinuz"),
+		Initrd:    filepath.Join(dir, "initramfs.xz"),
+		OSRelease: filepath.Join(dir, "os-release"),
+		Cmdline:   "talos.platform=metal console=ttyS0",
+	}
+
+	secureboottest.WriteStub(t, uki.Stub)
+
+	require.NoError(t, ioutil.WriteFile(uki.Kernel, bytes.Repeat([]byte{0xaa}, 5000), 0o644))
+	require.NoError(t, ioutil.WriteFile(uki.Initrd, bytes.Repeat([]byte{0xbb}, 3000), 0o644))
+	require.NoError(t, ioutil.WriteFile(uki.OSRelease, []byte("NAME=\"Talos\"\n"), 0o644))
+
+	output := filepath.Join(dir, "uki.efi")
+
+	require.NoError(t, uki.Build(output))
+
+	return output, uki
+}
+
+func TestBuild(t *testing.T) {
+	secureboottest.RequireTool(t, "objcopy")
+
+	output, uki := buildUKI(t, t.TempDir())
+
+	cmdline, err := secureboot.ReadCmdline(output)
+	require.NoError(t, err)
+	assert.Equal(t, uki.Cmdline, cmdline)
+
+	f, err := pe.Open(output)
+	require.NoError(t, err)
+
+	defer f.Close() //nolint:errcheck
+
+	for _, tt := range []struct {
+		section string
+		vma     uint32
+		path    string
+	}{
+		{".osrel", 0x20000, uki.OSRelease},
+		{".linux", 0x2000000, uki.Kernel},
+		{".initrd", 0x3000000, uki.Initrd},
+	} {
+		section := f.Section(tt.section)
+		require.NotNil(t, section, tt.section)
+
+		assert.Equal(t, tt.vma, section.VirtualAddress, tt.section)
+
+		expected, err := ioutil.ReadFile(tt.path)
+		require.NoError(t, err)
+
+		data, err := section.Data()
+		require.NoError(t, err)
+
+		assert.Equal(t, expected, data[:section.VirtualSize], tt.section)
+	}
+
+	_, err = secureboot.ReadCmdline(uki.Stub)
+	assert.Error(t, err)
+}
+
+func TestSign(t *testing.T) {
+	secureboottest.RequireTool(t, "objcopy")
+	secureboottest.RequireTool(t, "sbsign")
+
+	dir := t.TempDir()
+
+	output, uki := buildUKI(t, dir)
+	key, cert := secureboottest.WriteSigningKey(t, dir)
+
+	require.NoError(t, secureboot.Sign(output, key, cert))
+
+	secureboottest.AssertSigned(t, output)
+
+	// signing doesn't change the embedded command line
+	cmdline, err := secureboot.ReadCmdline(output)
+	require.NoError(t, err)
+	assert.Equal(t, uki.Cmdline, cmdline)
+
+	assert.Error(t, secureboot.Sign(output, filepath.Join(dir, "missing.pem"), cert))
+}
+
+func TestWriteCertificateDER(t *testing.T) {
+	dir := t.TempDir()
+
+	key, cert := secureboottest.WriteSigningKey(t, dir)
+
+	output := filepath.Join(dir, "keys", "db.der")
+
+	require.NoError(t, secureboot.WriteCertificateDER(cert, output))
+
+	der, err := ioutil.ReadFile(output)
+	require.NoError(t, err)
+
+	_, err = x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	assert.Error(t, secureboot.WriteCertificateDER(key, output))
+}
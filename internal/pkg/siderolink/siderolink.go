@@ -0,0 +1,153 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package siderolink implements a client of the SideroLink management server provisioning API.
+//
+// Node registers its Wireguard public key with the management server, and the server responds
+// with its own Wireguard endpoint and key, and the address assigned to the node in the tunnel:
+//
+//	POST {apiURL}/v1/provision  {"nodeUUID": "...", "nodePublicKey": "..."}
+//	  -> {"serverEndpoint": "host:port", "serverPublicKey": "...", "serverAddress": "fd00::1", "nodeAddressPrefix": "fd00::2/64"}
+//
+// Request is authenticated with the join token which is passed as `jointoken` query parameter of the API URL,
+// and sent to the server as the bearer token (not as part of the URL).
+package siderolink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"path"
+	"time"
+)
+
+// JoinTokenParam is the API URL query parameter which carries the join token.
+const JoinTokenParam = "jointoken"
+
+// ProvisionRequest registers the node with the management server.
+type ProvisionRequest struct {
+	NodeUUID      string `json:"nodeUUID"`
+	NodePublicKey string `json:"nodePublicKey"`
+}
+
+// ProvisionResponse describes the tunnel assigned to the node.
+type ProvisionResponse struct {
+	// ServerEndpoint is the Wireguard endpoint of the management server (host:port).
+	ServerEndpoint string `json:"serverEndpoint"`
+	// ServerPublicKey is the Wireguard public key of the management server.
+	ServerPublicKey string `json:"serverPublicKey"`
+	// ServerAddress is the address of the management server in the tunnel.
+	ServerAddress string `json:"serverAddress"`
+	// NodeAddressPrefix is the address of the node in the tunnel (with the prefix length).
+	NodeAddressPrefix string `json:"nodeAddressPrefix"`
+}
+
+// Validate checks that the response contains valid tunnel settings.
+func (resp *ProvisionResponse) Validate() error {
+	if _, _, err := net.SplitHostPort(resp.ServerEndpoint); err != nil {
+		return fmt.Errorf("invalid server endpoint %q: %w", resp.ServerEndpoint, err)
+	}
+
+	if resp.ServerPublicKey == "" {
+		return fmt.Errorf("server public key is missing")
+	}
+
+	if net.ParseIP(resp.ServerAddress) == nil {
+		return fmt.Errorf("invalid server address %q", resp.ServerAddress)
+	}
+
+	if _, _, err := net.ParseCIDR(resp.NodeAddressPrefix); err != nil {
+		return fmt.Errorf("invalid node address prefix %q: %w", resp.NodeAddressPrefix, err)
+	}
+
+	return nil
+}
+
+// Client of the management server provisioning API.
+type Client struct {
+	endpoint   *url.URL
+	joinToken  string
+	httpClient *http.Client
+}
+
+// NewClient creates a client for the API URL, the join token is extracted from the URL.
+func NewClient(apiURL string) (*Client, error) {
+	u, err := url.Parse(apiURL)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing SideroLink API URL: %w", err)
+	}
+
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("SideroLink API URL should be an http(s) URL: %q", apiURL)
+	}
+
+	query := u.Query()
+	joinToken := query.Get(JoinTokenParam)
+
+	query.Del(JoinTokenParam)
+	u.RawQuery = query.Encode()
+
+	return &Client{
+		endpoint:  u,
+		joinToken: joinToken,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}, nil
+}
+
+// Provision registers the node public key and returns the tunnel settings.
+func (c *Client) Provision(ctx context.Context, req *ProvisionRequest) (*ProvisionResponse, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	u := *c.endpoint
+	u.Path = path.Join(u.Path, "v1", "provision")
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	if c.joinToken != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+c.joinToken)
+	}
+
+	httpResp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+
+	defer httpResp.Body.Close() //nolint:errcheck
+
+	respBody, err := ioutil.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("SideroLink provision %s: unexpected status %s: %s", u.String(), httpResp.Status, bytes.TrimSpace(respBody))
+	}
+
+	var resp ProvisionResponse
+
+	if err = json.Unmarshal(respBody, &resp); err != nil {
+		return nil, fmt.Errorf("error decoding SideroLink provision response: %w", err)
+	}
+
+	if err = resp.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
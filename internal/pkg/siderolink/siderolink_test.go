@@ -0,0 +1,108 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package siderolink_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/internal/pkg/siderolink"
+)
+
+func mockServer(t *testing.T, resp siderolink.ProvisionResponse) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost || req.URL.Path != "/api/v1/provision" {
+			http.NotFound(w, req)
+
+			return
+		}
+
+		if req.Header.Get("Authorization") != "Bearer secret" || req.URL.Query().Get(siderolink.JoinTokenParam) != "" {
+			http.Error(w, "invalid join token", http.StatusUnauthorized)
+
+			return
+		}
+
+		var provisionReq siderolink.ProvisionRequest
+
+		if err := json.NewDecoder(req.Body).Decode(&provisionReq); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		assert.Equal(t, "0000-0000", provisionReq.NodeUUID)
+		assert.Equal(t, "nodekey", provisionReq.NodePublicKey)
+
+		json.NewEncoder(w).Encode(resp) //nolint:errcheck
+	}))
+}
+
+func TestProvision(t *testing.T) {
+	expected := siderolink.ProvisionResponse{
+		ServerEndpoint:    "siderolink.example.com:51821",
+		ServerPublicKey:   "serverkey",
+		ServerAddress:     "fdae:41e4:649b:9303::1",
+		NodeAddressPrefix: "fdae:41e4:649b:9303::2/64",
+	}
+
+	srv := mockServer(t, expected)
+	defer srv.Close()
+
+	client, err := siderolink.NewClient(srv.URL + "/api?jointoken=secret")
+	require.NoError(t, err)
+
+	resp, err := client.Provision(context.Background(), &siderolink.ProvisionRequest{
+		NodeUUID:      "0000-0000",
+		NodePublicKey: "nodekey",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &expected, resp)
+
+	client, err = siderolink.NewClient(srv.URL + "/api?jointoken=wrong")
+	require.NoError(t, err)
+
+	_, err = client.Provision(context.Background(), &siderolink.ProvisionRequest{
+		NodeUUID:      "0000-0000",
+		NodePublicKey: "nodekey",
+	})
+	assert.Error(t, err)
+}
+
+func TestProvisionInvalidResponse(t *testing.T) {
+	srv := mockServer(t, siderolink.ProvisionResponse{
+		ServerEndpoint:    "siderolink.example.com:51821",
+		ServerPublicKey:   "serverkey",
+		ServerAddress:     "fdae:41e4:649b:9303::1",
+		NodeAddressPrefix: "fdae:41e4:649b:9303::2",
+	})
+	defer srv.Close()
+
+	client, err := siderolink.NewClient(srv.URL + "/api?jointoken=secret")
+	require.NoError(t, err)
+
+	_, err = client.Provision(context.Background(), &siderolink.ProvisionRequest{
+		NodeUUID:      "0000-0000",
+		NodePublicKey: "nodekey",
+	})
+	assert.EqualError(t, err, "invalid node address prefix \"fdae:41e4:649b:9303::2\": invalid CIDR address: fdae:41e4:649b:9303::2")
+}
+
+func TestNewClient(t *testing.T) {
+	for _, apiURL := range []string{
+		"siderolink.example.com:8080",
+		"grpc://siderolink.example.com:8080",
+		"https://",
+	} {
+		_, err := siderolink.NewClient(apiURL)
+		assert.Error(t, err, apiURL)
+	}
+}
@@ -0,0 +1,152 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package tlspolicy implements the strict TLS policy of the Talos API endpoints.
+//
+// Strict policy allows only TLS 1.3 with the AES-GCM cipher suites and the P-256/P-384 key exchange curves,
+// connections with the peers which don't support the policy are rejected.
+package tlspolicy
+
+import (
+	"crypto/tls"
+	"fmt"
+	"log"
+)
+
+const (
+	// MinVersion is the TLS version allowed by the strict policy.
+	MinVersion = tls.VersionTLS13
+
+	// DefaultMinVersion is the minimum TLS version accepted by the endpoints if the strict policy is not enforced.
+	DefaultMinVersion = tls.VersionTLS12
+)
+
+// CipherSuites is the list of the cipher suites allowed by the strict policy.
+var CipherSuites = []uint16{
+	tls.TLS_AES_256_GCM_SHA384,
+	tls.TLS_AES_128_GCM_SHA256,
+}
+
+// Curves is the list of the key exchange curves allowed by the strict policy.
+var Curves = []tls.CurveID{
+	tls.CurveP384,
+	tls.CurveP256,
+}
+
+var curveNames = map[tls.CurveID]string{
+	tls.CurveP256: "P-256",
+	tls.CurveP384: "P-384",
+}
+
+// Strict applies the strict policy to the TLS config.
+//
+// Strict can be used as github.com/talos-systems/crypto/tls.ConfigOptionFunc.
+func Strict(cfg *tls.Config) error {
+	cfg.MinVersion = MinVersion
+	cfg.MaxVersion = MinVersion
+	cfg.CurvePreferences = append([]tls.CurveID(nil), Curves...)
+	// TLS 1.3 cipher suites are not configurable, they are checked once the handshake is done
+	cfg.CipherSuites = append([]uint16(nil), CipherSuites...)
+
+	cfg.GetConfigForClient = checkClientHello
+	cfg.VerifyConnection = verifyConnection
+
+	return nil
+}
+
+// checkClientHello rejects the clients which don't support the policy before the handshake.
+func checkClientHello(hello *tls.ClientHelloInfo) (*tls.Config, error) {
+	if err := checkHello(hello); err != nil {
+		log.Printf("rejected TLS connection from %s: %s", remoteAddr(hello), err)
+
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func checkHello(hello *tls.ClientHelloInfo) error {
+	if !containsVersion(hello.SupportedVersions, MinVersion) {
+		return fmt.Errorf("peer doesn't support %s", VersionName(MinVersion))
+	}
+
+	for _, curve := range hello.SupportedCurves {
+		if _, ok := curveNames[curve]; ok {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("peer doesn't support any of the key exchange curves %v", CurveNames())
+}
+
+// verifyConnection checks the negotiated connection parameters.
+func verifyConnection(state tls.ConnectionState) error {
+	if state.Version != MinVersion {
+		return fmt.Errorf("TLS version %s is not allowed by the strict TLS policy", VersionName(state.Version))
+	}
+
+	for _, suite := range CipherSuites {
+		if state.CipherSuite == suite {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("cipher suite %s is not allowed by the strict TLS policy", tls.CipherSuiteName(state.CipherSuite))
+}
+
+// CipherSuiteNames returns the names of the cipher suites allowed by the strict policy.
+func CipherSuiteNames() []string {
+	names := make([]string, 0, len(CipherSuites))
+
+	for _, suite := range CipherSuites {
+		names = append(names, tls.CipherSuiteName(suite))
+	}
+
+	return names
+}
+
+// CurveNames returns the names of the key exchange curves allowed by the strict policy.
+func CurveNames() []string {
+	names := make([]string, 0, len(Curves))
+
+	for _, curve := range Curves {
+		names = append(names, curveNames[curve])
+	}
+
+	return names
+}
+
+// VersionName returns the human-readable TLS version.
+func VersionName(version uint16) string {
+	switch version {
+	case tls.VersionTLS10:
+		return "TLS 1.0"
+	case tls.VersionTLS11:
+		return "TLS 1.1"
+	case tls.VersionTLS12:
+		return "TLS 1.2"
+	case tls.VersionTLS13:
+		return "TLS 1.3"
+	default:
+		return fmt.Sprintf("0x%04x", version)
+	}
+}
+
+func containsVersion(versions []uint16, version uint16) bool {
+	for _, v := range versions {
+		if v == version {
+			return true
+		}
+	}
+
+	return false
+}
+
+func remoteAddr(hello *tls.ClientHelloInfo) string {
+	if hello.Conn == nil {
+		return "unknown"
+	}
+
+	return hello.Conn.RemoteAddr().String()
+}
@@ -0,0 +1,152 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package tlspolicy_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/internal/pkg/tlspolicy"
+)
+
+func generateCertificate(t *testing.T) (tls.Certificate, *x509.CertPool) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "talos"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, pool
+}
+
+// handshake runs the handshake over the TCP connection and returns the client and server errors.
+func handshake(t *testing.T, serverConfig, clientConfig *tls.Config) (clientErr, serverErr error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	defer listener.Close() //nolint:errcheck
+
+	serverErrCh := make(chan error, 1)
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			serverErrCh <- err
+
+			return
+		}
+
+		defer conn.Close() //nolint:errcheck
+
+		serverErrCh <- tls.Server(conn, serverConfig).Handshake()
+	}()
+
+	conn, err := tls.Dial("tcp", listener.Addr().String(), clientConfig)
+	if err == nil {
+		conn.Close() //nolint:errcheck
+	}
+
+	return err, <-serverErrCh
+}
+
+func TestStrict(t *testing.T) {
+	cert, pool := generateCertificate(t)
+
+	strictServer := &tls.Config{Certificates: []tls.Certificate{cert}}
+	require.NoError(t, tlspolicy.Strict(strictServer))
+
+	for _, tt := range []struct {
+		name    string
+		client  *tls.Config
+		strict  bool
+		success bool
+	}{
+		{
+			name:    "default",
+			client:  &tls.Config{RootCAs: pool},
+			success: true,
+		},
+		{
+			name:    "strict",
+			client:  &tls.Config{RootCAs: pool},
+			strict:  true,
+			success: true,
+		},
+		{
+			name:   "TLS 1.2",
+			client: &tls.Config{RootCAs: pool, MaxVersion: tls.VersionTLS12},
+		},
+		{
+			name:   "X25519",
+			client: &tls.Config{RootCAs: pool, CurvePreferences: []tls.CurveID{tls.X25519}},
+		},
+	} {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.strict {
+				require.NoError(t, tlspolicy.Strict(tt.client))
+			}
+
+			clientErr, serverErr := handshake(t, strictServer, tt.client)
+
+			if tt.success {
+				assert.NoError(t, clientErr)
+				assert.NoError(t, serverErr)
+			} else {
+				assert.Error(t, clientErr)
+				assert.Error(t, serverErr)
+			}
+		})
+	}
+}
+
+func TestStrictClient(t *testing.T) {
+	cert, pool := generateCertificate(t)
+
+	client := &tls.Config{RootCAs: pool}
+	require.NoError(t, tlspolicy.Strict(client))
+
+	// server doesn't support TLS 1.3
+	clientErr, serverErr := handshake(t, &tls.Config{Certificates: []tls.Certificate{cert}, MaxVersion: tls.VersionTLS12}, client)
+
+	assert.Error(t, clientErr)
+	assert.Error(t, serverErr)
+}
+
+func TestNames(t *testing.T) {
+	assert.Equal(t, []string{"TLS_AES_256_GCM_SHA384", "TLS_AES_128_GCM_SHA256"}, tlspolicy.CipherSuiteNames())
+	assert.Equal(t, []string{"P-384", "P-256"}, tlspolicy.CurveNames())
+	assert.Equal(t, "TLS 1.3", tlspolicy.VersionName(tlspolicy.MinVersion))
+}
@@ -0,0 +1,203 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package watchdog implements hardware and software watchdog timers.
+package watchdog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// Timer is a watchdog timer which fires unless petted within the timeout.
+type Timer interface {
+	// Pet resets the watchdog timer.
+	Pet() error
+	// Close disarms the watchdog timer.
+	Close() error
+}
+
+// Open opens the hardware watchdog device, falling back to the software watchdog if the device is not available.
+//
+// Software watchdog reboots the node when it fires.
+func Open(device string, timeout time.Duration, logger *log.Logger) (Timer, error) {
+	timer, err := OpenDevice(device, timeout)
+	if err == nil {
+		return timer, nil
+	}
+
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	logger.Printf("watchdog device %q is not available, using software watchdog", device)
+
+	return NewSoftware(timeout, func() {
+		logger.Printf("watchdog timer expired, rebooting")
+
+		unix.Sync()
+
+		if err := unix.Reboot(unix.LINUX_REBOOT_CMD_RESTART); err != nil {
+			logger.Printf("error rebooting: %s", err)
+		}
+	}), nil
+}
+
+// Device is the hardware watchdog timer.
+type Device struct {
+	f *os.File
+}
+
+// OpenDevice opens the hardware watchdog device and sets the timeout.
+//
+// Watchdog is armed as soon as the device is opened.
+func OpenDevice(device string, timeout time.Duration) (*Device, error) {
+	f, err := os.OpenFile(device, os.O_WRONLY, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = unix.IoctlSetPointerInt(int(f.Fd()), unix.WDIOC_SETTIMEOUT, int(timeout.Seconds())); err != nil {
+		// magic close to disarm the watchdog
+		f.Write([]byte("V")) //nolint:errcheck
+		f.Close()            //nolint:errcheck
+
+		return nil, fmt.Errorf("error setting watchdog timeout: %w", err)
+	}
+
+	return &Device{f: f}, nil
+}
+
+// Pet implements Timer.
+func (d *Device) Pet() error {
+	_, err := d.f.Write([]byte{0})
+
+	return err
+}
+
+// Close implements Timer.
+//
+// Close writes the magic character so that the driver disarms the watchdog.
+func (d *Device) Close() error {
+	if _, err := d.f.Write([]byte("V")); err != nil {
+		d.f.Close() //nolint:errcheck
+
+		return err
+	}
+
+	return d.f.Close()
+}
+
+// Software is the watchdog timer which calls a function when it fires.
+type Software struct {
+	mu    sync.Mutex
+	timer *time.Timer
+
+	timeout time.Duration
+}
+
+// NewSoftware creates the software watchdog timer.
+func NewSoftware(timeout time.Duration, expired func()) *Software {
+	return &Software{
+		timer:   time.AfterFunc(timeout, expired),
+		timeout: timeout,
+	}
+}
+
+// Pet implements Timer.
+func (s *Software) Pet() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.timer == nil {
+		return fmt.Errorf("watchdog is closed")
+	}
+
+	if !s.timer.Stop() {
+		return fmt.Errorf("watchdog has already expired")
+	}
+
+	s.timer.Reset(s.timeout)
+
+	return nil
+}
+
+// Close implements Timer.
+func (s *Software) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.timer != nil {
+		s.timer.Stop()
+		s.timer = nil
+	}
+
+	return nil
+}
+
+// Run pets the watchdog timer while the probe succeeds until the context is canceled.
+//
+// Probe is run every quarter of the timeout, and it should return within that interval,
+// so the watchdog fires if the probe keeps failing (or hangs) for the timeout.
+func Run(ctx context.Context, timer Timer, timeout time.Duration, probe func(ctx context.Context) error, logger *log.Logger) {
+	interval := timeout / 4
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	wasLive := true
+
+	for {
+		err := runProbe(ctx, interval, probe)
+		isLive := err == nil
+
+		if isLive {
+			if err = timer.Pet(); err != nil {
+				logger.Printf("error petting watchdog: %s", err)
+			}
+		}
+
+		if isLive != wasLive {
+			if isLive {
+				logger.Printf("node is responsive, petting the watchdog")
+			} else {
+				logger.Printf("node is not responsive, watchdog fires in %s unless node recovers: %s", timeout, err)
+			}
+
+			wasLive = isLive
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runProbe runs the probe with a deadline, a probe which doesn't return in time is abandoned.
+func runProbe(ctx context.Context, timeout time.Duration, probe func(ctx context.Context) error) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	errCh := make(chan error, 1)
+
+	go func() {
+		errCh <- probe(ctx)
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("probe timed out: %w", ctx.Err())
+	}
+}
@@ -0,0 +1,94 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package watchdog_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/internal/pkg/watchdog"
+)
+
+func TestSoftware(t *testing.T) {
+	var expired int32
+
+	timer := watchdog.NewSoftware(100*time.Millisecond, func() { atomic.StoreInt32(&expired, 1) })
+
+	for i := 0; i < 5; i++ {
+		time.Sleep(50 * time.Millisecond)
+
+		require.NoError(t, timer.Pet())
+	}
+
+	assert.EqualValues(t, 0, atomic.LoadInt32(&expired))
+
+	time.Sleep(200 * time.Millisecond)
+
+	assert.EqualValues(t, 1, atomic.LoadInt32(&expired))
+	assert.Error(t, timer.Pet())
+	assert.NoError(t, timer.Close())
+}
+
+func TestRun(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		probe func(ctx context.Context) error
+	}{
+		{
+			name: "failing",
+			probe: func(ctx context.Context) error {
+				return fmt.Errorf("not responsive")
+			},
+		},
+		{
+			name: "hanging",
+			probe: func(ctx context.Context) error {
+				// ignores the context and blocks past the interval
+				time.Sleep(time.Second)
+
+				return nil
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				expired int32
+				live    int32 = 1
+			)
+
+			timer := watchdog.NewSoftware(100*time.Millisecond, func() { atomic.StoreInt32(&expired, 1) })
+
+			defer timer.Close() //nolint:errcheck
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			go watchdog.Run(ctx, timer, 100*time.Millisecond, func(ctx context.Context) error {
+				if atomic.LoadInt32(&live) == 1 {
+					return nil
+				}
+
+				return tt.probe(ctx)
+			}, log.New(log.Writer(), "", 0))
+
+			time.Sleep(300 * time.Millisecond)
+
+			assert.EqualValues(t, 0, atomic.LoadInt32(&expired))
+
+			atomic.StoreInt32(&live, 0)
+
+			time.Sleep(300 * time.Millisecond)
+
+			assert.EqualValues(t, 1, atomic.LoadInt32(&expired))
+		})
+	}
+}
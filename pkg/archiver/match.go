@@ -0,0 +1,110 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package archiver
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// globStar matches any number (including zero) of path components.
+const globStar = "**"
+
+// Match reports whether the relative path matches the shell pattern.
+//
+// Pattern syntax is the same as for filepath.Match, with the addition of the `**`
+// path component which matches any number of path components (including none).
+// Malformed patterns never match.
+func Match(pattern, relPath string) bool {
+	return matchComponents(splitComponents(pattern), splitComponents(relPath))
+}
+
+// HasMeta reports whether the path contains any of the pattern special characters.
+func HasMeta(path string) bool {
+	return strings.ContainsAny(path, `*?[\`)
+}
+
+// SplitPattern splits the path into the longest leading part which contains no pattern
+// special characters and the remaining pattern (relative to the leading part).
+//
+// If the path contains no pattern special characters, pattern is empty.
+func SplitPattern(path string) (root, pattern string) {
+	components := strings.Split(path, OSPathSeparator)
+
+	for i, component := range components {
+		if !HasMeta(component) {
+			continue
+		}
+
+		root = strings.Join(components[:i], OSPathSeparator)
+		if root == "" && strings.HasPrefix(path, OSPathSeparator) {
+			root = OSPathSeparator
+		}
+
+		return root, strings.Join(components[i:], OSPathSeparator)
+	}
+
+	return path, ""
+}
+
+// PatternDepth returns the maximum recursion depth required to match the pattern.
+//
+// Value of -1 means that the depth is not limited (pattern contains `**`).
+func PatternDepth(pattern string) int {
+	components := splitComponents(pattern)
+
+	for _, component := range components {
+		if component == globStar {
+			return -1
+		}
+	}
+
+	return len(components)
+}
+
+func splitComponents(path string) []string {
+	path = strings.Trim(path, OSPathSeparator)
+	if path == "" || path == "." {
+		return nil
+	}
+
+	return strings.Split(path, OSPathSeparator)
+}
+
+func matchComponents(pattern, components []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == globStar {
+			// collapse repeated `**`
+			for len(pattern) > 0 && pattern[0] == globStar {
+				pattern = pattern[1:]
+			}
+
+			if len(pattern) == 0 {
+				return true
+			}
+
+			for i := range components {
+				if matchComponents(pattern, components[i:]) {
+					return true
+				}
+			}
+
+			return false
+		}
+
+		if len(components) == 0 {
+			return false
+		}
+
+		matched, err := filepath.Match(pattern[0], components[0])
+		if err != nil || !matched {
+			return false
+		}
+
+		pattern, components = pattern[1:], components[1:]
+	}
+
+	return len(components) == 0
+}
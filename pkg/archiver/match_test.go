@@ -0,0 +1,59 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package archiver_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/talos-systems/talos/pkg/archiver"
+)
+
+func TestMatch(t *testing.T) {
+	for _, tt := range []struct {
+		pattern string
+		path    string
+		matches bool
+	}{
+		{"lib", "lib", true},
+		{"dev/*", "dev/random", true},
+		{"dev/*", "dev", false},
+		{"*", "dev/random", false},
+		{"**", "dev/random", true},
+		{"**/*.log", "kubelet.log", true},
+		{"**/*.log", "pods/foo/kubelet.log", true},
+		{"**/*.log", "pods/foo/kubelet.txt", false},
+		{"pods/**/0.log", "pods/0.log", true},
+		{"pods/**/0.log", "pods/a/b/0.log", true},
+		{"pods/**/0.log", "logs/a/0.log", false},
+		{"pods/**/**/0.log", "pods/a/0.log", true},
+		{"[", "[", false},
+	} {
+		assert.Equal(t, tt.matches, archiver.Match(tt.pattern, tt.path), "pattern %q, path %q", tt.pattern, tt.path)
+	}
+}
+
+func TestSplitPattern(t *testing.T) {
+	for _, tt := range []struct {
+		path    string
+		root    string
+		pattern string
+		depth   int
+	}{
+		{"/var/log", "/var/log", "", 0},
+		{"/var/lib/kubelet/**/*.log", "/var/lib/kubelet", "**/*.log", -1},
+		{"/var/log/*.log", "/var/log", "*.log", 1},
+		{"/var/log/pods/*/*/*.log", "/var/log/pods", "*/*/*.log", 3},
+		{"/*", "/", "*", 1},
+		{"*.log", "", "*.log", 1},
+	} {
+		root, pattern := archiver.SplitPattern(tt.path)
+
+		assert.Equal(t, tt.root, root, "path %q", tt.path)
+		assert.Equal(t, tt.pattern, pattern, "path %q", tt.path)
+		assert.Equal(t, tt.depth, archiver.PatternDepth(pattern), "path %q", tt.path)
+	}
+}
@@ -11,14 +11,28 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/talos-systems/talos/pkg/safepath"
 )
 
 // Untar extracts .tar archive from r into filesystem under rootPath.
 //
-//nolint:gocyclo
+// Archive entries are never written outside of rootPath: symlinks and hardlinks pointing
+// outside of rootPath are rejected, and the directory of every entry is checked
+// after resolving symlinks.
+//
+// Archive might be extracted into the existing directory tree: existing directories are kept as is,
+// existing files and symlinks are replaced with the archive entries (symlinks are never followed),
+// but existing directories are never replaced with the other entries.
+//
+//nolint:gocyclo,cyclop
 func Untar(ctx context.Context, r io.Reader, rootPath string) error {
+	rootPath, err := filepath.EvalSymlinks(rootPath)
+	if err != nil {
+		return fmt.Errorf("error resolving root path: %w", err)
+	}
+
 	tr := tar.NewReader(r)
 
 	for {
@@ -44,12 +58,34 @@ func Untar(ctx context.Context, r io.Reader, rootPath string) error {
 
 		path := filepath.Join(rootPath, hdrPath)
 
+		// parent directory might be a symlink created by the previous entries (or existing on the filesystem)
+		parentPath, err := filepath.EvalSymlinks(filepath.Dir(path))
+		if err != nil {
+			return fmt.Errorf("error resolving parent directory of %q: %w", path, err)
+		}
+
+		if !isWithin(rootPath, parentPath) {
+			return fmt.Errorf("path %q resolves outside of %q", hdr.Name, rootPath)
+		}
+
+		path = filepath.Join(parentPath, filepath.Base(path))
+
+		if hdr.Typeflag != tar.TypeDir {
+			if err = removeExisting(path); err != nil {
+				return err
+			}
+		}
+
 		switch hdr.Typeflag {
 		case tar.TypeDir:
 			mode := hdr.FileInfo().Mode()
 			mode |= 0o700 // make rwx for the owner
 
 			if err = os.Mkdir(path, mode); err != nil {
+				if st, statErr := os.Lstat(path); statErr == nil && st.IsDir() {
+					continue
+				}
+
 				return fmt.Errorf("error creating directory %q mode %s: %w", path, mode, err)
 			}
 
@@ -58,10 +94,33 @@ func Untar(ctx context.Context, r io.Reader, rootPath string) error {
 			}
 
 		case tar.TypeSymlink:
+			target := hdr.Linkname
+			if !filepath.IsAbs(target) {
+				target = filepath.Join(parentPath, target)
+			}
+
+			if !isWithin(rootPath, filepath.Clean(target)) {
+				return fmt.Errorf("symlink %q -> %q points outside of %q", hdr.Name, hdr.Linkname, rootPath)
+			}
+
 			if err = os.Symlink(hdr.Linkname, path); err != nil {
 				return fmt.Errorf("error creating symlink %q -> %q: %w", path, hdr.Linkname, err)
 			}
 
+		case tar.TypeLink:
+			target, err := filepath.EvalSymlinks(filepath.Join(rootPath, safepath.CleanPath(hdr.Linkname)))
+			if err != nil {
+				return fmt.Errorf("error resolving hardlink target %q: %w", hdr.Linkname, err)
+			}
+
+			if !isWithin(rootPath, target) {
+				return fmt.Errorf("hardlink %q -> %q points outside of %q", hdr.Name, hdr.Linkname, rootPath)
+			}
+
+			if err = os.Link(target, path); err != nil {
+				return fmt.Errorf("error creating hardlink %q -> %q: %w", path, target, err)
+			}
+
 		default:
 			mode := hdr.FileInfo().Mode()
 
@@ -87,3 +146,35 @@ func Untar(ctx context.Context, r io.Reader, rootPath string) error {
 
 	return nil
 }
+
+// removeExisting removes the file or symlink at path, so that the archive entry replaces it.
+func removeExisting(path string) error {
+	st, err := os.Lstat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+
+		return fmt.Errorf("error checking %q: %w", path, err)
+	}
+
+	if st.IsDir() {
+		return fmt.Errorf("error replacing %q: existing directory can't be replaced", path)
+	}
+
+	if err = os.Remove(path); err != nil {
+		return fmt.Errorf("error replacing %q: %w", path, err)
+	}
+
+	return nil
+}
+
+// isWithin checks whether path is rootPath itself or lexically a descendant of it.
+func isWithin(rootPath, path string) bool {
+	rel, err := filepath.Rel(rootPath, path)
+	if err != nil {
+		return false
+	}
+
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(os.PathSeparator))
+}
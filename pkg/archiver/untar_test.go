@@ -0,0 +1,207 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package archiver_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/talos-systems/talos/pkg/archiver"
+)
+
+type UntarSuite struct {
+	suite.Suite
+
+	tmpDir  string
+	rootDir string
+}
+
+type tarEntry struct {
+	hdr      tar.Header
+	contents string
+}
+
+func (suite *UntarSuite) SetupTest() {
+	var err error
+
+	suite.tmpDir, err = ioutil.TempDir("", "untar")
+	suite.Require().NoError(err)
+
+	suite.rootDir = filepath.Join(suite.tmpDir, "var")
+	suite.Require().NoError(os.Mkdir(suite.rootDir, 0o755))
+
+	// file outside of the root which malicious archives try to overwrite
+	suite.Require().NoError(os.Mkdir(filepath.Join(suite.tmpDir, "etc"), 0o755))
+	suite.Require().NoError(ioutil.WriteFile(filepath.Join(suite.tmpDir, "etc", "shadow"), []byte("root:x"), 0o600))
+}
+
+func (suite *UntarSuite) TearDownTest() {
+	suite.Require().NoError(os.RemoveAll(suite.tmpDir))
+}
+
+func (suite *UntarSuite) archive(entries ...tarEntry) *bytes.Buffer {
+	var buf bytes.Buffer
+
+	tw := tar.NewWriter(&buf)
+
+	for _, entry := range entries {
+		hdr := entry.hdr
+		hdr.Size = int64(len(entry.contents))
+
+		if hdr.Mode == 0 {
+			hdr.Mode = 0o644
+		}
+
+		suite.Require().NoError(tw.WriteHeader(&hdr))
+
+		_, err := tw.Write([]byte(entry.contents))
+		suite.Require().NoError(err)
+	}
+
+	suite.Require().NoError(tw.Close())
+
+	return &buf
+}
+
+func (suite *UntarSuite) assertShadowIntact() {
+	contents, err := ioutil.ReadFile(filepath.Join(suite.tmpDir, "etc", "shadow"))
+	suite.Require().NoError(err)
+	suite.Assert().Equal("root:x", string(contents))
+
+	_, err = os.Stat(filepath.Join(suite.tmpDir, "etc", "passwd"))
+	suite.Assert().True(os.IsNotExist(err))
+}
+
+func (suite *UntarSuite) TestExtract() {
+	buf := suite.archive(
+		tarEntry{hdr: tar.Header{Name: "dir/", Typeflag: tar.TypeDir, Mode: 0o755}},
+		tarEntry{hdr: tar.Header{Name: "dir/file", Typeflag: tar.TypeReg}, contents: "data"},
+		tarEntry{hdr: tar.Header{Name: "dir/link", Typeflag: tar.TypeSymlink, Linkname: "file"}},
+		tarEntry{hdr: tar.Header{Name: "dir/hardlink", Typeflag: tar.TypeLink, Linkname: "dir/file"}},
+	)
+
+	suite.Require().NoError(archiver.Untar(context.Background(), buf, suite.rootDir))
+
+	for _, name := range []string{"file", "link", "hardlink"} {
+		contents, err := ioutil.ReadFile(filepath.Join(suite.rootDir, "dir", name))
+		suite.Require().NoError(err)
+		suite.Assert().Equal("data", string(contents))
+	}
+}
+
+func (suite *UntarSuite) TestMalicious() {
+	for _, tt := range []struct {
+		name    string
+		entries []tarEntry
+	}{
+		{
+			name: "absolute symlink",
+			entries: []tarEntry{
+				{hdr: tar.Header{Name: "x", Typeflag: tar.TypeSymlink, Linkname: filepath.Join(suite.tmpDir, "etc")}},
+				{hdr: tar.Header{Name: "x/passwd", Typeflag: tar.TypeReg}, contents: "evil"},
+			},
+		},
+		{
+			name: "relative symlink",
+			entries: []tarEntry{
+				{hdr: tar.Header{Name: "x", Typeflag: tar.TypeSymlink, Linkname: "../etc"}},
+				{hdr: tar.Header{Name: "x/passwd", Typeflag: tar.TypeReg}, contents: "evil"},
+			},
+		},
+		{
+			name: "nested relative symlink",
+			entries: []tarEntry{
+				{hdr: tar.Header{Name: "a/", Typeflag: tar.TypeDir, Mode: 0o755}},
+				{hdr: tar.Header{Name: "a/x", Typeflag: tar.TypeSymlink, Linkname: "../../etc/shadow"}},
+			},
+		},
+		{
+			name: "hardlink",
+			entries: []tarEntry{
+				{hdr: tar.Header{Name: "x", Typeflag: tar.TypeLink, Linkname: "../etc/shadow"}},
+			},
+		},
+	} {
+		suite.Run(tt.name, func() {
+			suite.Require().Error(archiver.Untar(context.Background(), suite.archive(tt.entries...), suite.rootDir))
+
+			suite.assertShadowIntact()
+		})
+	}
+}
+
+func (suite *UntarSuite) TestDotDot() {
+	buf := suite.archive(
+		tarEntry{hdr: tar.Header{Name: "../passwd", Typeflag: tar.TypeReg}, contents: "evil"},
+	)
+
+	// path is sanitized to stay within the root
+	suite.Require().NoError(archiver.Untar(context.Background(), buf, suite.rootDir))
+
+	suite.assertShadowIntact()
+
+	_, err := os.Stat(filepath.Join(suite.rootDir, "passwd"))
+	suite.Require().NoError(err)
+}
+
+func (suite *UntarSuite) TestExistingSymlink() {
+	// symlink pointing outside of the root already exists in the destination
+	suite.Require().NoError(os.Symlink(filepath.Join(suite.tmpDir, "etc"), filepath.Join(suite.rootDir, "x")))
+
+	buf := suite.archive(
+		tarEntry{hdr: tar.Header{Name: "x/passwd", Typeflag: tar.TypeReg}, contents: "evil"},
+	)
+
+	suite.Require().Error(archiver.Untar(context.Background(), buf, suite.rootDir))
+
+	suite.assertShadowIntact()
+}
+
+func (suite *UntarSuite) TestExistingDestination() {
+	suite.Require().NoError(os.Mkdir(filepath.Join(suite.rootDir, "dir"), 0o700))
+	suite.Require().NoError(ioutil.WriteFile(filepath.Join(suite.rootDir, "dir", "file"), []byte("old"), 0o644))
+	// existing symlink is replaced, not followed
+	suite.Require().NoError(os.Symlink(filepath.Join(suite.tmpDir, "etc", "shadow"), filepath.Join(suite.rootDir, "dir", "link")))
+
+	buf := suite.archive(
+		tarEntry{hdr: tar.Header{Name: "dir/", Typeflag: tar.TypeDir, Mode: 0o755}},
+		tarEntry{hdr: tar.Header{Name: "dir/file", Typeflag: tar.TypeReg}, contents: "new"},
+		tarEntry{hdr: tar.Header{Name: "dir/link", Typeflag: tar.TypeReg}, contents: "evil"},
+	)
+
+	suite.Require().NoError(archiver.Untar(context.Background(), buf, suite.rootDir))
+
+	suite.assertShadowIntact()
+
+	st, err := os.Stat(filepath.Join(suite.rootDir, "dir"))
+	suite.Require().NoError(err)
+	suite.Assert().Equal(os.FileMode(0o700), st.Mode().Perm())
+
+	contents, err := ioutil.ReadFile(filepath.Join(suite.rootDir, "dir", "file"))
+	suite.Require().NoError(err)
+	suite.Assert().Equal("new", string(contents))
+
+	st, err = os.Lstat(filepath.Join(suite.rootDir, "dir", "link"))
+	suite.Require().NoError(err)
+	suite.Assert().True(st.Mode().IsRegular())
+
+	// directory is never replaced with a file
+	buf = suite.archive(
+		tarEntry{hdr: tar.Header{Name: "dir", Typeflag: tar.TypeReg}, contents: "data"},
+	)
+
+	suite.Require().Error(archiver.Untar(context.Background(), buf, suite.rootDir))
+}
+
+func TestUntarSuite(t *testing.T) {
+	suite.Run(t, new(UntarSuite))
+}
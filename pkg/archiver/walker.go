@@ -58,6 +58,7 @@ func WithMaxRecurseDepth(maxDepth int) WalkerOption {
 
 // WithFnmatchPatterns filters results to match the patterns.
 //
+// Patterns are matched against the path relative to the root with Match.
 // Default is not to do any filtering.
 func WithFnmatchPatterns(patterns ...string) WalkerOption {
 	return func(o *walkerOptions) {
@@ -119,59 +120,36 @@ func Walker(ctx context.Context, rootPath string, options ...WalkerOption) (<-ch
 				item.RelPath, item.Error = filepath.Rel(rootPath, path)
 			}
 
-			// TODO: refactor all those `if item.Error == nil &&` conditions
+			emit := item.Error != nil || matchesFileTypes(opts.types, fileInfo)
 
-			if item.Error == nil && len(opts.types) > 0 {
-				var matches bool
-
-				for t := range opts.types {
-					switch t {
-					case RegularFileType:
-						matches = fileInfo.Mode()&os.ModeType == 0
-					case DirectoryFileType:
-						matches = fileInfo.Mode()&os.ModeDir != 0
-					case SymlinkFileType:
-						matches = fileInfo.Mode()&os.ModeSymlink != 0
-					}
-					if matches {
-						break
-					}
-				}
-
-				if !matches {
-					return nil
-				}
-			}
-
-			if item.Error == nil && path == rootPath && opts.skipRoot && fileInfo.IsDir() {
+			if emit && item.Error == nil && path == rootPath && opts.skipRoot && fileInfo.IsDir() {
 				// skip containing directory
-				return nil
+				emit = false
 			}
 
-			if item.Error == nil && fileInfo.Mode()&os.ModeSymlink == os.ModeSymlink {
+			if emit && item.Error == nil && fileInfo.Mode()&os.ModeSymlink == os.ModeSymlink {
 				item.Link, item.Error = os.Readlink(path)
 			}
 
-			if item.Error == nil && len(opts.fnmatchPatterns) > 0 {
-				var matches bool
+			if emit && item.Error == nil && len(opts.fnmatchPatterns) > 0 {
+				emit = false
 
 				for _, pattern := range opts.fnmatchPatterns {
-					if matches, _ = filepath.Match(pattern, item.RelPath); matches { //nolint:errcheck
+					if emit = Match(pattern, item.RelPath); emit {
 						break
 					}
 				}
-
-				if !matches {
-					return nil
-				}
 			}
 
-			select {
-			case <-ctx.Done():
-				return ctx.Err()
-			case ch <- item:
+			if emit {
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case ch <- item:
+				}
 			}
 
+			// filtered out directories are still subject to the depth limit
 			if item.Error == nil && fileInfo.IsDir() && atMaxDepth(opts.maxRecurseDepth, rootPath, path) {
 				return filepath.SkipDir
 			}
@@ -189,6 +167,31 @@ func Walker(ctx context.Context, rootPath string, options ...WalkerOption) (<-ch
 	return ch, nil
 }
 
+func matchesFileTypes(types map[FileType]struct{}, fileInfo os.FileInfo) bool {
+	if len(types) == 0 {
+		return true
+	}
+
+	for t := range types {
+		switch t {
+		case RegularFileType:
+			if fileInfo.Mode()&os.ModeType == 0 {
+				return true
+			}
+		case DirectoryFileType:
+			if fileInfo.Mode()&os.ModeDir != 0 {
+				return true
+			}
+		case SymlinkFileType:
+			if fileInfo.Mode()&os.ModeSymlink != 0 {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
 // OSPathSeparator is the string version of the os.PathSeparator.
 const OSPathSeparator = string(os.PathSeparator)
 
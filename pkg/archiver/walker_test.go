@@ -156,6 +156,41 @@ func (suite *WalkerSuite) TestIterationTypes() {
 		relPaths)
 }
 
+func (suite *WalkerSuite) TestIterationGlobStar() {
+	ch, err := archiver.Walker(context.Background(), suite.tmpDir, archiver.WithFnmatchPatterns("**/*.crt", "**/cp"))
+	suite.Require().NoError(err)
+
+	relPaths := []string(nil)
+
+	for fi := range ch {
+		suite.Require().NoError(fi.Error)
+		relPaths = append(relPaths, fi.RelPath)
+	}
+
+	suite.Assert().Equal([]string{
+		"etc/certs/ca.crt", "usr/bin/cp",
+	},
+		relPaths)
+}
+
+func (suite *WalkerSuite) TestIterationFilterMaxRecurseDepth() {
+	ch, err := archiver.Walker(context.Background(), suite.tmpDir,
+		archiver.WithMaxRecurseDepth(2), archiver.WithFileTypes(archiver.RegularFileType))
+	suite.Require().NoError(err)
+
+	relPaths := []string(nil)
+
+	for fi := range ch {
+		suite.Require().NoError(fi.Error)
+		relPaths = append(relPaths, fi.RelPath)
+	}
+
+	suite.Assert().Equal([]string{
+		"dev/random", "etc/hostname", "lib/dynalib.so",
+	},
+		relPaths)
+}
+
 func TestWalkerSuite(t *testing.T) {
 	suite.Run(t, new(WalkerSuite))
 }
@@ -0,0 +1,275 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package archive implements cluster export archive used for disaster recovery handoffs.
+//
+// The archive is a gzipped tarball with the following layout:
+//
+//	manifest.yaml       cluster metadata, member inventory and SHA-256 of every other file
+//	manifest.sig        ed25519 signature of manifest.yaml
+//	configs/<node>.yaml machine configuration of each member with secrets redacted
+//	secrets.enc         secrets bundle and talosconfig encrypted to the operator RSA key
+package archive
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1/generate"
+)
+
+// CurrentVersion is the version of the archive format.
+const CurrentVersion = 1
+
+const (
+	manifestName  = "manifest.yaml"
+	signatureName = "manifest.sig"
+	secretsName   = "secrets.enc"
+	configsDir    = "configs"
+
+	// maxFileSize limits the size of a single file read from the archive.
+	maxFileSize = 16 * 1024 * 1024
+)
+
+// ErrInvalidSignature is returned when the archive signature doesn't match.
+var ErrInvalidSignature = errors.New("archive signature is invalid")
+
+// Manifest describes the exported cluster.
+type Manifest struct {
+	Version       int               `yaml:"version"`
+	ClusterName   string            `yaml:"clusterName"`
+	Context       string            `yaml:"context"`
+	CreatedAt     time.Time         `yaml:"createdAt"`
+	ClientVersion string            `yaml:"clientVersion"`
+	Members       []Member          `yaml:"members"`
+	Files         map[string]string `yaml:"files"`
+}
+
+// Member describes a single cluster node.
+type Member struct {
+	Node              string `yaml:"node"`
+	Hostname          string `yaml:"hostname"`
+	MachineType       string `yaml:"machineType"`
+	TalosVersion      string `yaml:"talosVersion"`
+	KubernetesVersion string `yaml:"kubernetesVersion,omitempty"`
+}
+
+// Secrets is the sensitive part of the archive which is stored encrypted.
+type Secrets struct {
+	Bundle      *Bundle `yaml:"bundle"`
+	Talosconfig string  `yaml:"talosconfig"`
+}
+
+// Bundle is the serializable form of generate.SecretsBundle.
+type Bundle struct {
+	Secrets    *generate.Secrets    `yaml:"secrets"`
+	TrustdInfo *generate.TrustdInfo `yaml:"trustdinfo"`
+	Certs      *generate.Certs      `yaml:"certs"`
+}
+
+// NewBundle converts generate.SecretsBundle to Bundle.
+func NewBundle(in *generate.SecretsBundle) *Bundle {
+	return &Bundle{
+		Secrets:    in.Secrets,
+		TrustdInfo: in.TrustdInfo,
+		Certs:      in.Certs,
+	}
+}
+
+// SecretsBundle converts Bundle back to generate.SecretsBundle.
+func (b *Bundle) SecretsBundle() *generate.SecretsBundle {
+	return &generate.SecretsBundle{
+		Clock:      generate.NewClock(),
+		Secrets:    b.Secrets,
+		TrustdInfo: b.TrustdInfo,
+		Certs:      b.Certs,
+	}
+}
+
+// Archive is the decoded cluster archive.
+type Archive struct {
+	Manifest Manifest
+	// Configs maps member node to the redacted machine configuration.
+	Configs map[string][]byte
+	Secrets Secrets
+}
+
+// Write the archive signing it with the signing key and encrypting secrets to the recipient.
+func (a *Archive) Write(w io.Writer, signingKey ed25519.PrivateKey, recipient *rsa.PublicKey) error {
+	secretsData, err := yaml.Marshal(&a.Secrets)
+	if err != nil {
+		return err
+	}
+
+	encryptedSecrets, err := encrypt(recipient, secretsData)
+	if err != nil {
+		return fmt.Errorf("error encrypting secrets: %w", err)
+	}
+
+	files := map[string][]byte{
+		secretsName: encryptedSecrets,
+	}
+
+	for node, cfg := range a.Configs {
+		files[path.Join(configsDir, node+".yaml")] = cfg
+	}
+
+	manifest := a.Manifest
+	manifest.Version = CurrentVersion
+	manifest.Files = map[string]string{}
+
+	for name, contents := range files {
+		manifest.Files[name] = checksum(contents)
+	}
+
+	manifestData, err := yaml.Marshal(&manifest)
+	if err != nil {
+		return err
+	}
+
+	files[manifestName] = manifestData
+	files[signatureName] = ed25519.Sign(signingKey, manifestData)
+
+	names := make([]string, 0, len(files))
+
+	for name := range files {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	zw := gzip.NewWriter(w)
+	tw := tar.NewWriter(zw)
+
+	for _, name := range names {
+		if err = tw.WriteHeader(&tar.Header{
+			Name:    name,
+			Mode:    0o600,
+			Size:    int64(len(files[name])),
+			ModTime: manifest.CreatedAt,
+		}); err != nil {
+			return err
+		}
+
+		if _, err = tw.Write(files[name]); err != nil {
+			return err
+		}
+	}
+
+	if err = tw.Close(); err != nil {
+		return err
+	}
+
+	return zw.Close()
+}
+
+// Read the archive verifying the signature with the signer key and decrypting secrets with the identity.
+//
+//nolint:gocyclo
+func Read(r io.Reader, signer ed25519.PublicKey, identity *rsa.PrivateKey) (*Archive, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	//nolint:errcheck
+	defer zr.Close()
+
+	files := map[string][]byte{}
+	tr := tar.NewReader(zr)
+
+	for {
+		var hdr *tar.Header
+
+		hdr, err = tr.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			return nil, err
+		}
+
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		if hdr.Size > maxFileSize {
+			return nil, fmt.Errorf("file %q in the archive is too large", hdr.Name)
+		}
+
+		if files[hdr.Name], err = ioutil.ReadAll(io.LimitReader(tr, maxFileSize)); err != nil {
+			return nil, err
+		}
+	}
+
+	manifestData, signature := files[manifestName], files[signatureName]
+
+	if manifestData == nil || signature == nil {
+		return nil, fmt.Errorf("archive is missing the manifest")
+	}
+
+	if !ed25519.Verify(signer, manifestData, signature) {
+		return nil, ErrInvalidSignature
+	}
+
+	archive := &Archive{
+		Configs: map[string][]byte{},
+	}
+
+	if err = yaml.Unmarshal(manifestData, &archive.Manifest); err != nil {
+		return nil, fmt.Errorf("error decoding manifest: %w", err)
+	}
+
+	if archive.Manifest.Version != CurrentVersion {
+		return nil, fmt.Errorf("unsupported archive version %d", archive.Manifest.Version)
+	}
+
+	for name, sum := range archive.Manifest.Files {
+		contents, ok := files[name]
+		if !ok {
+			return nil, fmt.Errorf("file %q is missing from the archive", name)
+		}
+
+		if checksum(contents) != sum {
+			return nil, fmt.Errorf("%w: checksum mismatch for %q", ErrInvalidSignature, name)
+		}
+
+		if dir, file := path.Split(name); path.Clean(dir) == configsDir {
+			archive.Configs[strings.TrimSuffix(file, ".yaml")] = contents
+		}
+	}
+
+	secretsData, err := decrypt(identity, files[secretsName])
+	if err != nil {
+		return nil, fmt.Errorf("error decrypting secrets: %w", err)
+	}
+
+	if err = yaml.NewDecoder(bytes.NewReader(secretsData)).Decode(&archive.Secrets); err != nil {
+		return nil, fmt.Errorf("error decoding secrets: %w", err)
+	}
+
+	return archive, nil
+}
+
+func checksum(contents []byte) string {
+	sum := sha256.Sum256(contents)
+
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,80 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package archive_test
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/pkg/cluster/archive"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1/generate"
+)
+
+func TestArchiveRoundTrip(t *testing.T) {
+	signerPub, signer, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	identity, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	bundle, err := generate.NewSecretsBundle(generate.NewClock())
+	require.NoError(t, err)
+
+	a := &archive.Archive{
+		Manifest: archive.Manifest{
+			ClusterName: "test",
+			Context:     "test",
+			CreatedAt:   time.Now().UTC().Truncate(time.Second),
+			Members: []archive.Member{
+				{
+					Node:         "10.5.0.2",
+					Hostname:     "master-1",
+					MachineType:  "controlplane",
+					TalosVersion: "v0.10.0",
+				},
+			},
+		},
+		Configs: map[string][]byte{
+			"10.5.0.2": []byte("version: v1alpha1\n"),
+		},
+		Secrets: archive.Secrets{
+			Bundle:      archive.NewBundle(bundle),
+			Talosconfig: "context: test\n",
+		},
+	}
+
+	var buf bytes.Buffer
+
+	require.NoError(t, a.Write(&buf, signer, &identity.PublicKey))
+
+	decoded, err := archive.Read(bytes.NewReader(buf.Bytes()), signerPub, identity)
+	require.NoError(t, err)
+
+	assert.Equal(t, a.Manifest.Members, decoded.Manifest.Members)
+	assert.Equal(t, a.Configs, decoded.Configs)
+	assert.Equal(t, a.Secrets.Talosconfig, decoded.Secrets.Talosconfig)
+	assert.Equal(t, bundle.Secrets, decoded.Secrets.Bundle.Secrets)
+	assert.Equal(t, bundle.Certs.OS, decoded.Secrets.Bundle.Certs.OS)
+
+	otherSignerPub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	_, err = archive.Read(bytes.NewReader(buf.Bytes()), otherSignerPub, identity)
+	assert.True(t, errors.Is(err, archive.ErrInvalidSignature))
+
+	otherIdentity, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	_, err = archive.Read(bytes.NewReader(buf.Bytes()), signerPub, otherIdentity)
+	assert.Error(t, err)
+}
@@ -0,0 +1,180 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package archive
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	stdx509 "crypto/x509"
+	"encoding/binary"
+	"encoding/pem"
+	"fmt"
+	"io"
+)
+
+// encrypt data with a random AES-256-GCM key wrapped with RSA-OAEP for the recipient.
+//
+// Output format: uint16 wrapped key length | wrapped key | nonce | ciphertext.
+func encrypt(recipient *rsa.PublicKey, data []byte) ([]byte, error) {
+	key := make([]byte, 32)
+
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, err
+	}
+
+	wrappedKey, err := rsa.EncryptOAEP(sha256.New(), rand.Reader, recipient, key, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+
+	if _, err = io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, 2, 2+len(wrappedKey)+len(nonce)+len(data)+aead.Overhead())
+	binary.BigEndian.PutUint16(out, uint16(len(wrappedKey)))
+
+	out = append(out, wrappedKey...)
+	out = append(out, nonce...)
+
+	return aead.Seal(out, nonce, data, nil), nil
+}
+
+func decrypt(identity *rsa.PrivateKey, data []byte) ([]byte, error) {
+	if len(data) < 2 {
+		return nil, fmt.Errorf("encrypted data is too short")
+	}
+
+	keyLen := int(binary.BigEndian.Uint16(data))
+	data = data[2:]
+
+	if len(data) < keyLen {
+		return nil, fmt.Errorf("encrypted data is too short")
+	}
+
+	key, err := rsa.DecryptOAEP(sha256.New(), rand.Reader, identity, data[:keyLen], nil)
+	if err != nil {
+		return nil, err
+	}
+
+	data = data[keyLen:]
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) < aead.NonceSize() {
+		return nil, fmt.Errorf("encrypted data is too short")
+	}
+
+	return aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], nil)
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// ParseSigningKey parses PEM encoded PKCS #8 ed25519 private key.
+func ParseSigningKey(data []byte) (ed25519.PrivateKey, error) {
+	key, err := parsePrivateKey(data)
+	if err != nil {
+		return nil, err
+	}
+
+	ed25519Key, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("expected ed25519 signing key, got %T", key)
+	}
+
+	return ed25519Key, nil
+}
+
+// ParseIdentity parses PEM encoded PKCS #1 or PKCS #8 RSA private key.
+func ParseIdentity(data []byte) (*rsa.PrivateKey, error) {
+	key, err := parsePrivateKey(data)
+	if err != nil {
+		return nil, err
+	}
+
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("expected RSA key, got %T", key)
+	}
+
+	return rsaKey, nil
+}
+
+// ParseSignerKey parses PEM encoded ed25519 public key.
+func ParseSignerKey(data []byte) (ed25519.PublicKey, error) {
+	key, err := parsePublicKey(data)
+	if err != nil {
+		return nil, err
+	}
+
+	ed25519Key, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("expected ed25519 public key, got %T", key)
+	}
+
+	return ed25519Key, nil
+}
+
+// ParseRecipient parses PEM encoded RSA public key.
+func ParseRecipient(data []byte) (*rsa.PublicKey, error) {
+	key, err := parsePublicKey(data)
+	if err != nil {
+		return nil, err
+	}
+
+	rsaKey, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("expected RSA public key, got %T", key)
+	}
+
+	return rsaKey, nil
+}
+
+func parsePrivateKey(data []byte) (interface{}, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("failed to decode PEM private key")
+	}
+
+	if block.Type == "RSA PRIVATE KEY" {
+		return stdx509.ParsePKCS1PrivateKey(block.Bytes)
+	}
+
+	return stdx509.ParsePKCS8PrivateKey(block.Bytes)
+}
+
+func parsePublicKey(data []byte) (interface{}, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("failed to decode PEM public key")
+	}
+
+	if block.Type == "RSA PUBLIC KEY" {
+		return stdx509.ParsePKCS1PublicKey(block.Bytes)
+	}
+
+	return stdx509.ParsePKIXPublicKey(block.Bytes)
+}
@@ -0,0 +1,89 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package archive
+
+import (
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
+)
+
+// RedactedValue replaces redacted secret values.
+const RedactedValue = "******"
+
+// Redact removes secret material from the machine configuration in place.
+//
+// Cluster PKI and tokens are stored in the secrets bundle, so the configuration
+// can be regenerated from the redacted one and the bundle.
+//
+//nolint:gocyclo,cyclop
+func Redact(cfg *v1alpha1.Config) {
+	if machine := cfg.MachineConfig; machine != nil {
+		machine.MachineToken = RedactedValue
+
+		if machine.MachineCA != nil {
+			machine.MachineCA.Key = nil
+		}
+
+		for _, registry := range machine.MachineRegistries.RegistryConfig {
+			if registry == nil {
+				continue
+			}
+
+			if registry.RegistryAuth != nil {
+				registry.RegistryAuth = &v1alpha1.RegistryAuthConfig{
+					RegistryUsername: registry.RegistryAuth.RegistryUsername,
+					RegistryPassword: RedactedValue,
+				}
+			}
+
+			if registry.RegistryTLS != nil && registry.RegistryTLS.TLSClientIdentity != nil {
+				registry.RegistryTLS.TLSClientIdentity.Key = nil
+			}
+		}
+
+		if machine.MachineNetwork != nil {
+			for _, device := range machine.MachineNetwork.NetworkInterfaces {
+				if device != nil && device.DeviceWireguardConfig != nil {
+					device.DeviceWireguardConfig.WireguardPrivateKey = RedactedValue
+				}
+			}
+		}
+
+		if encryption := machine.MachineSystemDiskEncryption; encryption != nil {
+			for _, partition := range []*v1alpha1.EncryptionConfig{encryption.StatePartition, encryption.EphemeralPartition} {
+				if partition == nil {
+					continue
+				}
+
+				for _, key := range partition.EncryptionKeys {
+					if key != nil && key.KeyStatic != nil {
+						key.KeyStatic.KeyData = RedactedValue
+					}
+				}
+			}
+		}
+	}
+
+	if cluster := cfg.ClusterConfig; cluster != nil {
+		cluster.BootstrapToken = RedactedValue
+		cluster.ClusterAESCBCEncryptionSecret = RedactedValue
+		cluster.ClusterSecret = RedactedValue
+
+		if cluster.ClusterCA != nil {
+			cluster.ClusterCA.Key = nil
+		}
+
+		if cluster.ClusterAggregatorCA != nil {
+			cluster.ClusterAggregatorCA.Key = nil
+		}
+
+		if cluster.ClusterServiceAccount != nil {
+			cluster.ClusterServiceAccount.Key = nil
+		}
+
+		if cluster.EtcdConfig != nil && cluster.EtcdConfig.RootCA != nil {
+			cluster.EtcdConfig.RootCA.Key = nil
+		}
+	}
+}
@@ -0,0 +1,95 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"k8s.io/client-go/tools/clientcmd"
+
+	k8s "github.com/talos-systems/talos/pkg/kubernetes"
+	"github.com/talos-systems/talos/pkg/machinery/client"
+)
+
+// Drainer cordons and drains Kubernetes nodes of the Talos nodes before disruptive actions.
+//
+// Node is cordoned via Talos API, so that Talos uncordons it once it boots back,
+// pods are evicted via Kubernetes API respecting PodDisruptionBudgets.
+type Drainer struct {
+	// K8sProvider builds Kubernetes client, e.g. KubernetesClient builds it from the admin kubeconfig
+	// fetched via Talos API from a control plane node.
+	K8sProvider K8sProvider
+
+	// Kubeconfig is the path to the kubeconfig to use instead of K8sProvider.
+	Kubeconfig string
+
+	// Out receives the progress messages.
+	Out io.Writer
+
+	kubeHelper *k8s.Client
+}
+
+// Drain cordons and drains the Kubernetes node of the Talos node.
+//
+// Kubernetes client is built with the context which shouldn't target any nodes
+// (the admin kubeconfig is served by the control plane nodes).
+// If the drain fails, the node is left cordoned.
+func (d *Drainer) Drain(ctx context.Context, c *client.Client, node string) error {
+	kubeHelper, err := d.kubernetesClient(ctx)
+	if err != nil {
+		return fmt.Errorf("error building Kubernetes client: %w", err)
+	}
+
+	resp, err := c.Cordon(client.WithNodes(ctx, node))
+	if err != nil {
+		return fmt.Errorf("error cordoning node: %w", err)
+	}
+
+	if len(resp.GetMessages()) == 0 {
+		return fmt.Errorf("empty cordon response")
+	}
+
+	nodeName := resp.GetMessages()[0].GetNodeName()
+
+	fmt.Fprintf(d.Out, "%s: cordoned Kubernetes node %q, evicting pods\n", node, nodeName)
+
+	if err = kubeHelper.DrainStrict(ctx, nodeName); err != nil {
+		return fmt.Errorf("error draining Kubernetes node %q: %w", nodeName, err)
+	}
+
+	fmt.Fprintf(d.Out, "%s: drained Kubernetes node %q\n", node, nodeName)
+
+	return nil
+}
+
+func (d *Drainer) kubernetesClient(ctx context.Context) (*k8s.Client, error) {
+	if d.kubeHelper != nil {
+		return d.kubeHelper, nil
+	}
+
+	if d.Kubeconfig == "" {
+		kubeHelper, err := d.K8sProvider.K8sHelper(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		d.kubeHelper = kubeHelper
+
+		return d.kubeHelper, nil
+	}
+
+	config, err := clientcmd.BuildConfigFromFlags("", d.Kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if d.kubeHelper, err = k8s.NewForConfig(config); err != nil {
+		return nil, err
+	}
+
+	return d.kubeHelper, nil
+}
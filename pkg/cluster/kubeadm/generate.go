@@ -0,0 +1,80 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package kubeadm
+
+import (
+	"strings"
+
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1/generate"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+)
+
+// SecretsBundle generates Talos secrets bundle reusing the kubeadm cluster CAs.
+//
+// Talos OS CA and the tokens are generated, as kubeadm has no equivalent for them.
+func (pki *PKI) SecretsBundle(clock generate.Clock) (*generate.SecretsBundle, error) {
+	bundle, err := generate.NewSecretsBundle(clock)
+	if err != nil {
+		return nil, err
+	}
+
+	bundle.Certs.K8s = pki.CA
+	bundle.Certs.K8sAggregator = pki.FrontProxyCA
+	bundle.Certs.K8sServiceAccount = pki.ServiceAccountKey
+	bundle.Certs.Etcd = pki.EtcdCA
+
+	return bundle, nil
+}
+
+// Input builds the Talos config generation input matching the kubeadm cluster configuration.
+//
+// CNI is set to "custom", as the CNI deployed to the kubeadm cluster keeps running after the migration.
+func (cluster *Cluster) Input(bundle *generate.SecretsBundle, opts ...generate.GenOption) (*generate.Input, error) {
+	opts = append([]generate.GenOption{
+		generate.WithAdditionalSubjectAltNames(cluster.Config.APIServer.CertSANs),
+		generate.WithClusterCNIConfig(&v1alpha1.CNIConfig{
+			CNIName: constants.CustomCNI,
+		}),
+	}, opts...)
+
+	if cluster.Config.Networking.DNSDomain != "" {
+		opts = append(opts, generate.WithDNSDomain(cluster.Config.Networking.DNSDomain))
+	}
+
+	input, err := generate.NewInput(
+		cluster.Config.ClusterName,
+		cluster.Endpoint,
+		strings.TrimPrefix(cluster.Config.KubernetesVersion, "v"),
+		bundle,
+		opts...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if cluster.Config.Networking.PodSubnet != "" {
+		input.PodNet = splitSubnets(cluster.Config.Networking.PodSubnet)
+	}
+
+	if cluster.Config.Networking.ServiceSubnet != "" {
+		input.ServiceNet = splitSubnets(cluster.Config.Networking.ServiceSubnet)
+	}
+
+	return input, nil
+}
+
+// splitSubnets splits dual-stack kubeadm subnets list.
+func splitSubnets(subnets string) []string {
+	var result []string
+
+	for _, subnet := range strings.Split(subnets, ",") {
+		if subnet = strings.TrimSpace(subnet); subnet != "" {
+			result = append(result, subnet)
+		}
+	}
+
+	return result
+}
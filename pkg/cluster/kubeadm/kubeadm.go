@@ -0,0 +1,229 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package kubeadm implements migration of the kubeadm-managed clusters to Talos.
+//
+// The existing cluster is inspected via the Kubernetes API (kubeadm-config, cluster-info,
+// nodes), the cluster PKI is loaded either from the copy of /etc/kubernetes/pki or from the
+// kubeadm-certs secret, and Talos machine configs are generated reusing the cluster CAs,
+// so that Talos nodes can join the existing control plane and replace kubeadm nodes one by one.
+package kubeadm
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const (
+	kubeadmConfigMap        = "kubeadm-config"
+	clusterConfigurationKey = "ClusterConfiguration"
+	clusterInfoConfigMap    = "cluster-info"
+	clusterInfoKubeconfig   = "kubeconfig"
+
+	labelMaster       = "node-role.kubernetes.io/master"
+	labelControlPlane = "node-role.kubernetes.io/control-plane"
+
+	defaultAPIServerPort = "6443"
+)
+
+// ClusterConfiguration is the subset of kubeadm ClusterConfiguration used for the migration.
+type ClusterConfiguration struct {
+	ClusterName          string     `yaml:"clusterName"`
+	ControlPlaneEndpoint string     `yaml:"controlPlaneEndpoint"`
+	KubernetesVersion    string     `yaml:"kubernetesVersion"`
+	Networking           Networking `yaml:"networking"`
+	APIServer            APIServer  `yaml:"apiServer"`
+	Etcd                 Etcd       `yaml:"etcd"`
+}
+
+// Networking is kubeadm networking configuration.
+type Networking struct {
+	DNSDomain     string `yaml:"dnsDomain"`
+	PodSubnet     string `yaml:"podSubnet"`
+	ServiceSubnet string `yaml:"serviceSubnet"`
+}
+
+// APIServer is kubeadm API server configuration.
+type APIServer struct {
+	CertSANs  []string          `yaml:"certSANs"`
+	ExtraArgs map[string]string `yaml:"extraArgs"`
+}
+
+// Etcd is kubeadm etcd configuration.
+type Etcd struct {
+	External *struct {
+		Endpoints []string `yaml:"endpoints"`
+	} `yaml:"external"`
+}
+
+// Node is a node of the kubeadm cluster.
+type Node struct {
+	Name           string
+	Address        string
+	ControlPlane   bool
+	KubeletVersion string
+}
+
+// Cluster is the state of the kubeadm cluster.
+type Cluster struct {
+	Config ClusterConfiguration
+	// Endpoint is the control plane endpoint URL.
+	Endpoint string
+	Nodes    []Node
+}
+
+// ParseClusterConfiguration parses kubeadm ClusterConfiguration.
+func ParseClusterConfiguration(data []byte) (*ClusterConfiguration, error) {
+	var cfg ClusterConfiguration
+
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("error parsing kubeadm ClusterConfiguration: %w", err)
+	}
+
+	if cfg.Etcd.External != nil {
+		return nil, fmt.Errorf("clusters with external etcd are not supported, Talos manages etcd on the control plane nodes")
+	}
+
+	if cfg.ClusterName == "" {
+		cfg.ClusterName = "kubernetes"
+	}
+
+	return &cfg, nil
+}
+
+// Inspect reads the kubeadm cluster configuration and the list of nodes.
+func Inspect(ctx context.Context, clientset kubernetes.Interface) (*Cluster, error) {
+	cm, err := clientset.CoreV1().ConfigMaps(metav1.NamespaceSystem).Get(ctx, kubeadmConfigMap, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s config map, is it a kubeadm cluster?: %w", kubeadmConfigMap, err)
+	}
+
+	cfg, err := ParseClusterConfiguration([]byte(cm.Data[clusterConfigurationKey]))
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := cfg.ControlPlaneEndpoint
+
+	if endpoint == "" {
+		// single control plane node clusters don't have the endpoint set, use the server from cluster-info
+		var clusterInfo *corev1.ConfigMap
+
+		clusterInfo, err = clientset.CoreV1().ConfigMaps(metav1.NamespacePublic).Get(ctx, clusterInfoConfigMap, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("error reading %s config map: %w", clusterInfoConfigMap, err)
+		}
+
+		if endpoint, err = serverFromKubeconfig([]byte(clusterInfo.Data[clusterInfoKubeconfig])); err != nil {
+			return nil, err
+		}
+	}
+
+	cluster := &Cluster{
+		Config:   *cfg,
+		Endpoint: EndpointURL(endpoint),
+	}
+
+	nodes, err := clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing nodes: %w", err)
+	}
+
+	for i := range nodes.Items {
+		cluster.Nodes = append(cluster.Nodes, nodeInfo(&nodes.Items[i]))
+	}
+
+	// control plane nodes go first
+	sort.SliceStable(cluster.Nodes, func(i, j int) bool {
+		if cluster.Nodes[i].ControlPlane != cluster.Nodes[j].ControlPlane {
+			return cluster.Nodes[i].ControlPlane
+		}
+
+		return cluster.Nodes[i].Name < cluster.Nodes[j].Name
+	})
+
+	return cluster, nil
+}
+
+// ControlPlaneNodes returns the list of control plane nodes.
+func (cluster *Cluster) ControlPlaneNodes() []Node {
+	return cluster.filterNodes(true)
+}
+
+// WorkerNodes returns the list of worker nodes.
+func (cluster *Cluster) WorkerNodes() []Node {
+	return cluster.filterNodes(false)
+}
+
+func (cluster *Cluster) filterNodes(controlPlane bool) []Node {
+	var nodes []Node
+
+	for _, node := range cluster.Nodes {
+		if node.ControlPlane == controlPlane {
+			nodes = append(nodes, node)
+		}
+	}
+
+	return nodes
+}
+
+// EndpointURL converts kubeadm controlPlaneEndpoint (host[:port]) into the endpoint URL.
+func EndpointURL(endpoint string) string {
+	if strings.HasPrefix(endpoint, "https://") {
+		return endpoint
+	}
+
+	if _, _, err := net.SplitHostPort(endpoint); err != nil {
+		endpoint = net.JoinHostPort(strings.Trim(endpoint, "[]"), defaultAPIServerPort)
+	}
+
+	return (&url.URL{Scheme: "https", Host: endpoint}).String()
+}
+
+func serverFromKubeconfig(data []byte) (string, error) {
+	cfg, err := clientcmd.Load(data)
+	if err != nil {
+		return "", fmt.Errorf("error parsing cluster-info kubeconfig: %w", err)
+	}
+
+	for _, cluster := range cfg.Clusters {
+		if cluster.Server != "" {
+			return cluster.Server, nil
+		}
+	}
+
+	return "", fmt.Errorf("cluster-info kubeconfig doesn't contain the server endpoint")
+}
+
+func nodeInfo(node *corev1.Node) Node {
+	info := Node{
+		Name:           node.Name,
+		KubeletVersion: node.Status.NodeInfo.KubeletVersion,
+	}
+
+	_, master := node.Labels[labelMaster]
+	_, controlPlane := node.Labels[labelControlPlane]
+
+	info.ControlPlane = master || controlPlane
+
+	for _, address := range node.Status.Addresses {
+		if address.Type == corev1.NodeInternalIP {
+			info.Address = address.Address
+
+			break
+		}
+	}
+
+	return info
+}
@@ -0,0 +1,163 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package kubeadm_test
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/talos-systems/talos/pkg/cluster/kubeadm"
+)
+
+const clusterConfiguration = `apiServer:
+  certSANs:
+  - k8s.example.com
+  extraArgs:
+    authorization-mode: Node,RBAC
+apiVersion: kubeadm.k8s.io/v1beta2
+clusterName: prod
+controlPlaneEndpoint: k8s.example.com
+etcd:
+  local:
+    dataDir: /var/lib/etcd
+kind: ClusterConfiguration
+kubernetesVersion: v1.21.1
+networking:
+  dnsDomain: cluster.local
+  podSubnet: 10.244.0.0/16,fd00:10:244::/56
+  serviceSubnet: 10.96.0.0/12
+`
+
+func TestParseClusterConfiguration(t *testing.T) {
+	cfg, err := kubeadm.ParseClusterConfiguration([]byte(clusterConfiguration))
+	require.NoError(t, err)
+
+	assert.Equal(t, "prod", cfg.ClusterName)
+	assert.Equal(t, "k8s.example.com", cfg.ControlPlaneEndpoint)
+	assert.Equal(t, "v1.21.1", cfg.KubernetesVersion)
+	assert.Equal(t, "10.96.0.0/12", cfg.Networking.ServiceSubnet)
+	assert.Equal(t, []string{"k8s.example.com"}, cfg.APIServer.CertSANs)
+
+	cfg, err = kubeadm.ParseClusterConfiguration([]byte("kubernetesVersion: v1.20.0\n"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "kubernetes", cfg.ClusterName)
+
+	_, err = kubeadm.ParseClusterConfiguration([]byte("etcd:\n  external:\n    endpoints:\n    - https://10.0.0.1:2379\n"))
+	assert.EqualError(t, err, "clusters with external etcd are not supported, Talos manages etcd on the control plane nodes")
+}
+
+func TestEndpointURL(t *testing.T) {
+	for _, tt := range []struct {
+		endpoint string
+		expected string
+	}{
+		{"k8s.example.com", "https://k8s.example.com:6443"},
+		{"k8s.example.com:443", "https://k8s.example.com:443"},
+		{"10.0.0.1", "https://10.0.0.1:6443"},
+		{"[2001:db8::1]", "https://[2001:db8::1]:6443"},
+		{"https://10.0.0.1:6443", "https://10.0.0.1:6443"},
+	} {
+		assert.Equal(t, tt.expected, kubeadm.EndpointURL(tt.endpoint), tt.endpoint)
+	}
+}
+
+func TestInspect(t *testing.T) {
+	node := func(name, address string, labels map[string]string) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: labels,
+			},
+			Status: corev1.NodeStatus{
+				Addresses: []corev1.NodeAddress{
+					{Type: corev1.NodeHostName, Address: name},
+					{Type: corev1.NodeInternalIP, Address: address},
+				},
+				NodeInfo: corev1.NodeSystemInfo{
+					KubeletVersion: "v1.21.1",
+				},
+			},
+		}
+	}
+
+	clientset := fake.NewSimpleClientset(
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: metav1.NamespaceSystem,
+				Name:      "kubeadm-config",
+			},
+			Data: map[string]string{
+				"ClusterConfiguration": clusterConfiguration,
+			},
+		},
+		node("worker-1", "10.0.0.11", nil),
+		node("master-2", "10.0.0.2", map[string]string{"node-role.kubernetes.io/master": ""}),
+		node("master-1", "10.0.0.1", map[string]string{"node-role.kubernetes.io/control-plane": ""}),
+	)
+
+	cluster, err := kubeadm.Inspect(context.Background(), clientset)
+	require.NoError(t, err)
+
+	assert.Equal(t, "https://k8s.example.com:6443", cluster.Endpoint)
+	assert.Equal(t, []kubeadm.Node{
+		{Name: "master-1", Address: "10.0.0.1", ControlPlane: true, KubeletVersion: "v1.21.1"},
+		{Name: "master-2", Address: "10.0.0.2", ControlPlane: true, KubeletVersion: "v1.21.1"},
+	}, cluster.ControlPlaneNodes())
+	assert.Equal(t, []kubeadm.Node{
+		{Name: "worker-1", Address: "10.0.0.11", KubeletVersion: "v1.21.1"},
+	}, cluster.WorkerNodes())
+
+	plan, err := cluster.Plan(kubeadm.PlanOptions{
+		ControlPlaneConfig: "controlplane.yaml",
+		WorkerConfig:       "join.yaml",
+		Talosconfig:        "talosconfig",
+	})
+	require.NoError(t, err)
+
+	assert.Contains(t, string(plan), "## 2. Replace control plane node master-1 (10.0.0.1)")
+	assert.Contains(t, string(plan), "## 3. Replace control plane node master-2 (10.0.0.2)")
+	assert.Contains(t, string(plan), "## 4. Replace worker node worker-1 (10.0.0.11)")
+	assert.Contains(t, string(plan), "## 5. Finish")
+	assert.Contains(t, string(plan), "  * authorization-mode=Node,RBAC")
+}
+
+func TestDecryptSecretData(t *testing.T) {
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+
+	// encrypt the same way kubeadm does
+	block, err := aes.NewCipher(key)
+	require.NoError(t, err)
+
+	gcm, err := cipher.NewGCM(block)
+	require.NoError(t, err)
+
+	nonce := make([]byte, gcm.NonceSize())
+	_, err = rand.Read(nonce)
+	require.NoError(t, err)
+
+	encrypted := gcm.Seal(nonce, nonce, []byte("secret"), nil)
+
+	decrypted, err := kubeadm.DecryptSecretData(encrypted, key)
+	require.NoError(t, err)
+
+	assert.Equal(t, []byte("secret"), decrypted)
+
+	key[0]++
+
+	_, err = kubeadm.DecryptSecretData(encrypted, key)
+	assert.Error(t, err)
+}
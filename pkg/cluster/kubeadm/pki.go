@@ -0,0 +1,132 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package kubeadm
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/talos-systems/crypto/x509"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// kubeadmCertsSecret is the secret created by `kubeadm init --upload-certs`.
+const kubeadmCertsSecret = "kubeadm-certs"
+
+// PKI files relative to the kubeadm PKI directory (/etc/kubernetes/pki).
+const (
+	caCrt           = "ca.crt"
+	caKey           = "ca.key"
+	frontProxyCACrt = "front-proxy-ca.crt"
+	frontProxyCAKey = "front-proxy-ca.key"
+	etcdCACrt       = "etcd/ca.crt"
+	etcdCAKey       = "etcd/ca.key"
+	saKey           = "sa.key"
+)
+
+// PKI is the kubeadm cluster PKI which is reused by Talos.
+type PKI struct {
+	CA                *x509.PEMEncodedCertificateAndKey
+	FrontProxyCA      *x509.PEMEncodedCertificateAndKey
+	EtcdCA            *x509.PEMEncodedCertificateAndKey
+	ServiceAccountKey *x509.PEMEncodedKey
+}
+
+// LoadPKIFromDir loads the PKI from the copy of the kubeadm PKI directory of a control plane node.
+func LoadPKIFromDir(dir string) (*PKI, error) {
+	return loadPKI(func(name string) ([]byte, error) {
+		return ioutil.ReadFile(filepath.Join(dir, name))
+	})
+}
+
+// LoadPKIFromSecret loads the PKI from the kubeadm-certs secret.
+//
+// The secret is encrypted with the certificate key printed by `kubeadm init --upload-certs`
+// (or `kubeadm init phase upload-certs --upload-certs`), and the secret is removed by kubeadm after two hours.
+func LoadPKIFromSecret(ctx context.Context, clientset kubernetes.Interface, certificateKey string) (*PKI, error) {
+	secret, err := clientset.CoreV1().Secrets(metav1.NamespaceSystem).Get(ctx, kubeadmCertsSecret, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s secret (upload the certificates with `kubeadm init phase upload-certs --upload-certs`): %w", kubeadmCertsSecret, err)
+	}
+
+	key, err := hex.DecodeString(certificateKey)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding certificate key: %w", err)
+	}
+
+	return loadPKI(func(name string) ([]byte, error) {
+		// kubeadm flattens the paths in the secret keys
+		data, ok := secret.Data[strings.ReplaceAll(name, "/", "-")]
+		if !ok {
+			return nil, fmt.Errorf("%q is missing in the %s secret", name, kubeadmCertsSecret)
+		}
+
+		return DecryptSecretData(data, key)
+	})
+}
+
+// DecryptSecretData decrypts the data encrypted by kubeadm with the certificate key (AES-GCM, nonce prepended).
+func DecryptSecretData(data, key []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	nonceSize := gcm.NonceSize()
+
+	if len(data) < nonceSize {
+		return nil, fmt.Errorf("encrypted data is too short")
+	}
+
+	plaintext, err := gcm.Open(nil, data[:nonceSize], data[nonceSize:], nil)
+	if err != nil {
+		return nil, fmt.Errorf("error decrypting data, is the certificate key correct?: %w", err)
+	}
+
+	return plaintext, nil
+}
+
+func loadPKI(read func(name string) ([]byte, error)) (*PKI, error) {
+	files := map[string][]byte{}
+
+	for _, name := range []string{caCrt, caKey, frontProxyCACrt, frontProxyCAKey, etcdCACrt, etcdCAKey, saKey} {
+		data, err := read(name)
+		if err != nil {
+			return nil, fmt.Errorf("error loading %q: %w", name, err)
+		}
+
+		files[name] = data
+	}
+
+	return &PKI{
+		CA: &x509.PEMEncodedCertificateAndKey{
+			Crt: files[caCrt],
+			Key: files[caKey],
+		},
+		FrontProxyCA: &x509.PEMEncodedCertificateAndKey{
+			Crt: files[frontProxyCACrt],
+			Key: files[frontProxyCAKey],
+		},
+		EtcdCA: &x509.PEMEncodedCertificateAndKey{
+			Crt: files[etcdCACrt],
+			Key: files[etcdCAKey],
+		},
+		ServiceAccountKey: &x509.PEMEncodedKey{
+			Key: files[saKey],
+		},
+	}, nil
+}
@@ -0,0 +1,125 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package kubeadm
+
+import (
+	"bytes"
+	"text/template"
+)
+
+// PlanOptions configures the migration plan.
+type PlanOptions struct {
+	// ControlPlaneConfig and WorkerConfig are the paths to the generated machine configs.
+	ControlPlaneConfig string
+	WorkerConfig       string
+	Talosconfig        string
+}
+
+const planTemplate = `# Migration of the cluster {{ .Cluster.Config.ClusterName | printf "%q" }} from kubeadm to Talos
+
+Control plane endpoint: {{ .Cluster.Endpoint }}
+Kubernetes version: {{ .Cluster.Config.KubernetesVersion }}
+
+Control plane nodes are replaced one by one: a Talos control plane node joins the existing etcd cluster
+and the Kubernetes control plane (Talos machine configs reuse kubeadm CAs), and only after that a kubeadm node is removed,
+so that etcd quorum is preserved at every step.
+
+Make sure that the control plane endpoint ({{ .Cluster.Endpoint }}) keeps pointing to the healthy control plane nodes
+(update the load balancer or DNS records as nodes are added and removed).
+
+## 1. Prepare
+
+* back up etcd on one of the kubeadm control plane nodes:
+
+      ETCDCTL_API=3 etcdctl --endpoints https://127.0.0.1:2379 \
+        --cacert /etc/kubernetes/pki/etcd/ca.crt \
+        --cert /etc/kubernetes/pki/etcd/healthcheck-client.crt \
+        --key /etc/kubernetes/pki/etcd/healthcheck-client.key \
+        snapshot save etcd-backup.db
+
+* back up /etc/kubernetes/pki of one of the kubeadm control plane nodes
+* review {{ .Options.ControlPlaneConfig }} and {{ .Options.WorkerConfig }} (install disk, network configuration, extra arguments)
+{{- with .Cluster.Config.APIServer.ExtraArgs }}
+* kube-apiserver extra arguments are not migrated automatically, add them to cluster.apiServer.extraArgs if needed:
+{{- range $key, $value := . }}
+  * {{ $key }}={{ $value }}
+{{- end }}
+{{- end }}
+{{ $step := 2 }}
+{{- range .ControlPlane }}
+## {{ $step }}. Replace control plane node {{ .Name }}{{ with .Address }} ({{ . }}){{ end }}
+{{ $step = add $step 1 }}
+* boot a new machine with Talos and apply the control plane config:
+
+      talosctl apply-config --insecure --nodes <new node IP> --file {{ $.Options.ControlPlaneConfig }}
+
+* wait for the new node to join etcd and to become Ready:
+
+      talosctl --talosconfig {{ $.Options.Talosconfig }} --nodes <new node IP> etcd members
+      kubectl get nodes
+
+* drain and reset the kubeadm node:
+
+      kubectl drain {{ .Name }} --ignore-daemonsets --delete-emptydir-data
+      ssh {{ with .Address }}{{ . }}{{ else }}{{ .Name }}{{ end }} kubeadm reset --force
+
+* make sure the etcd member of the kubeadm node was removed (kubeadm reset removes it), otherwise remove it:
+
+      talosctl --talosconfig {{ $.Options.Talosconfig }} --nodes <new node IP> etcd members
+      talosctl --talosconfig {{ $.Options.Talosconfig }} --nodes <new node IP> etcd remove-member {{ .Name }}
+
+* delete the node:
+
+      kubectl delete node {{ .Name }}
+{{ end }}
+{{- range .Workers }}
+## {{ $step }}. Replace worker node {{ .Name }}{{ with .Address }} ({{ . }}){{ end }}
+{{ $step = add $step 1 }}
+* drain the kubeadm node:
+
+      kubectl drain {{ .Name }} --ignore-daemonsets --delete-emptydir-data
+
+* boot a new machine with Talos (or reinstall the node) and apply the worker config:
+
+      talosctl apply-config --insecure --nodes <new node IP> --file {{ $.Options.WorkerConfig }}
+
+* wait for the new node to become Ready and delete the old node:
+
+      kubectl delete node {{ .Name }}
+{{ end }}
+## {{ $step }}. Finish
+
+* kube-proxy and CoreDNS manifests are now managed by Talos, the CNI deployed to the cluster is left as is
+* kubeadm-config and kubeadm-certs resources in the kube-system namespace are no longer used and can be removed
+* use {{ .Options.Talosconfig }} to manage the Talos nodes
+`
+
+// Plan renders the step-by-step migration plan in markdown.
+func (cluster *Cluster) Plan(opts PlanOptions) ([]byte, error) {
+	tmpl, err := template.New("plan").Funcs(template.FuncMap{
+		"add": func(a, b int) int { return a + b },
+	}).Parse(planTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+
+	if err = tmpl.Execute(&buf, struct {
+		Cluster      *Cluster
+		Options      PlanOptions
+		ControlPlane []Node
+		Workers      []Node
+	}{
+		Cluster:      cluster,
+		Options:      opts,
+		ControlPlane: cluster.ControlPlaneNodes(),
+		Workers:      cluster.WorkerNodes(),
+	}); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
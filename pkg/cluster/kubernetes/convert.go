@@ -298,30 +298,9 @@ func patchNodeConfig(ctx context.Context, cluster ConvertProvider, node string,
 
 	ctx = client.WithNodes(ctx, node)
 
-	resources, err := c.Resources.Get(ctx, config.NamespaceName, config.MachineConfigType, config.V1Alpha1ID)
+	cfg, err := fetchNodeConfig(ctx, c)
 	if err != nil {
-		return fmt.Errorf("error fetching config resource: %w", err)
-	}
-
-	if len(resources) != 1 {
-		return fmt.Errorf("expected 1 instance of config resource, got %d", len(resources))
-	}
-
-	r := resources[0]
-
-	yamlConfig, err := yaml.Marshal(r.Resource.Spec())
-	if err != nil {
-		return fmt.Errorf("error getting YAML config: %w", err)
-	}
-
-	config, err := configloader.NewFromBytes(yamlConfig)
-	if err != nil {
-		return fmt.Errorf("error loading config: %w", err)
-	}
-
-	cfg, ok := config.(*v1alpha1config.Config)
-	if !ok {
-		return fmt.Errorf("config is not v1alpha1 config")
+		return err
 	}
 
 	if !cfg.Persist() {
@@ -348,6 +327,37 @@ func patchNodeConfig(ctx context.Context, cluster ConvertProvider, node string,
 	return nil
 }
 
+// fetchNodeConfig reads the current machine config of the node.
+func fetchNodeConfig(ctx context.Context, c *client.Client) (*v1alpha1config.Config, error) {
+	resources, err := c.Resources.Get(ctx, config.NamespaceName, config.MachineConfigType, config.V1Alpha1ID)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching config resource: %w", err)
+	}
+
+	if len(resources) != 1 {
+		return nil, fmt.Errorf("expected 1 instance of config resource, got %d", len(resources))
+	}
+
+	r := resources[0]
+
+	yamlConfig, err := yaml.Marshal(r.Resource.Spec())
+	if err != nil {
+		return nil, fmt.Errorf("error getting YAML config: %w", err)
+	}
+
+	config, err := configloader.NewFromBytes(yamlConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error loading config: %w", err)
+	}
+
+	cfg, ok := config.(*v1alpha1config.Config)
+	if !ok {
+		return nil, fmt.Errorf("config is not v1alpha1 config")
+	}
+
+	return cfg, nil
+}
+
 // waitResourcesReady waits for manifests and static pod definitions to be generated.
 //
 //nolint:gocyclo
@@ -0,0 +1,569 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/talos-systems/go-retry/retry"
+	"github.com/talos-systems/os-runtime/pkg/resource"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+
+	"github.com/talos-systems/talos/pkg/kubernetes"
+	"github.com/talos-systems/talos/pkg/machinery/client"
+	v1alpha1config "github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1/generate"
+	machinetype "github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1/machine"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+	"github.com/talos-systems/talos/pkg/resources/k8s"
+)
+
+// RotateOptions represents the secrets rotation settings.
+type RotateOptions struct {
+	BootstrapToken   bool
+	EncryptionSecret bool
+
+	masterNodes []string
+	workerNodes []string
+}
+
+// RotateSecrets rotates the bootstrap token and the key for the encryption of secret data at rest.
+//
+// Node configs are updated one node at a time, each control plane node is updated only after
+// kube-apiserver on the previous node is restarted with the new secrets.
+//
+//nolint:gocyclo
+func RotateSecrets(ctx context.Context, cluster UpgradeProvider, options RotateOptions) error {
+	k8sClient, err := cluster.K8sHelper(ctx)
+	if err != nil {
+		return fmt.Errorf("error building kubernetes client: %w", err)
+	}
+
+	options.masterNodes, err = k8sClient.NodeIPs(ctx, machinetype.TypeControlPlane)
+	if err != nil {
+		return fmt.Errorf("error fetching master nodes: %w", err)
+	}
+
+	if len(options.masterNodes) == 0 {
+		return fmt.Errorf("no master nodes discovered")
+	}
+
+	options.workerNodes, err = k8sClient.NodeIPs(ctx, machinetype.TypeJoin)
+	if err != nil {
+		return fmt.Errorf("error fetching worker nodes: %w", err)
+	}
+
+	fmt.Printf("discovered master nodes %q\n", options.masterNodes)
+	fmt.Printf("discovered worker nodes %q\n", options.workerNodes)
+
+	masterConfigs, err := fetchClusterConfigs(ctx, cluster, options.masterNodes)
+	if err != nil {
+		return err
+	}
+
+	if options.BootstrapToken {
+		var (
+			workerConfigs []*v1alpha1config.ClusterConfig
+			rotation      *BootstrapTokenRotation
+		)
+
+		workerConfigs, err = fetchClusterConfigs(ctx, cluster, options.workerNodes)
+		if err != nil {
+			return err
+		}
+
+		// workers are checked as well, the previous rotation might have been interrupted while updating the workers
+		rotation, err = NewBootstrapTokenRotation(append(workerConfigs, masterConfigs...)...)
+		if err != nil {
+			return err
+		}
+
+		if err = rotateBootstrapToken(ctx, cluster, options, rotation); err != nil {
+			return fmt.Errorf("error rotating bootstrap token: %w", err)
+		}
+	}
+
+	if options.EncryptionSecret {
+		if err = rotateEncryptionSecret(ctx, cluster, options, masterConfigs); err != nil {
+			return fmt.Errorf("error rotating encryption secret: %w", err)
+		}
+	}
+
+	fmt.Println("secrets rotation completed successfully")
+
+	return nil
+}
+
+// fetchClusterConfigs returns the cluster config of the nodes.
+func fetchClusterConfigs(ctx context.Context, cluster UpgradeProvider, nodes []string) ([]*v1alpha1config.ClusterConfig, error) {
+	c, err := cluster.Client()
+	if err != nil {
+		return nil, fmt.Errorf("error building Talos API client: %w", err)
+	}
+
+	configs := make([]*v1alpha1config.ClusterConfig, 0, len(nodes))
+
+	for _, node := range nodes {
+		cfg, err := fetchNodeConfig(client.WithNodes(ctx, node), c)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching config of node %q: %w", node, err)
+		}
+
+		if cfg.ClusterConfig == nil {
+			return nil, fmt.Errorf("node %q has no cluster config", node)
+		}
+
+		configs = append(configs, cfg.ClusterConfig)
+	}
+
+	return configs, nil
+}
+
+// BootstrapTokenRotation describes the new bootstrap token and the tokens it replaces.
+type BootstrapTokenRotation struct {
+	Token       string
+	PreviousIDs []string
+}
+
+// NewBootstrapTokenRotation generates the new bootstrap token.
+//
+// If the previous rotation was interrupted, the nodes have different tokens, and all of them are replaced
+// with the new token.
+func NewBootstrapTokenRotation(configs ...*v1alpha1config.ClusterConfig) (*BootstrapTokenRotation, error) {
+	token, err := generate.NewBootstrapToken()
+	if err != nil {
+		return nil, err
+	}
+
+	rotation := &BootstrapTokenRotation{
+		Token: token,
+	}
+
+	seen := map[string]struct{}{}
+
+	for _, cfg := range configs {
+		id := cfg.Token().ID()
+
+		if _, ok := seen[id]; ok || id == "" {
+			continue
+		}
+
+		seen[id] = struct{}{}
+
+		rotation.PreviousIDs = append(rotation.PreviousIDs, id)
+	}
+
+	return rotation, nil
+}
+
+// ID returns the ID of the new bootstrap token.
+func (rotation *BootstrapTokenRotation) ID() string {
+	return strings.SplitN(rotation.Token, ".", 2)[0]
+}
+
+// Secret returns the secret of the new bootstrap token.
+func (rotation *BootstrapTokenRotation) Secret() string {
+	return strings.SplitN(rotation.Token, ".", 2)[1]
+}
+
+// Patch replaces the bootstrap token in the node config.
+func (rotation *BootstrapTokenRotation) Patch(config *v1alpha1config.Config) error {
+	if config.ClusterConfig == nil {
+		config.ClusterConfig = &v1alpha1config.ClusterConfig{}
+	}
+
+	if config.ClusterConfig.BootstrapToken == rotation.Token {
+		return errUpdateSkipped
+	}
+
+	config.ClusterConfig.BootstrapToken = rotation.Token
+
+	return nil
+}
+
+func rotateBootstrapToken(ctx context.Context, cluster UpgradeProvider, options RotateOptions, rotation *BootstrapTokenRotation) error {
+	newID := rotation.ID()
+
+	fmt.Printf("rotating bootstrap tokens %q -> %q\n", rotation.PreviousIDs, newID)
+
+	for _, node := range options.masterNodes {
+		if err := patchControlPlaneNode(ctx, cluster, node, rotation.Patch); err != nil {
+			return fmt.Errorf("error updating node %q: %w", node, err)
+		}
+	}
+
+	for _, node := range options.workerNodes {
+		fmt.Printf(" > updating node %q\n", node)
+
+		if err := patchNodeConfig(ctx, cluster, node, rotation.Patch); err != nil && !errors.Is(err, errUpdateSkipped) {
+			return fmt.Errorf("error updating node %q: %w", node, err)
+		}
+	}
+
+	k8sClient, err := cluster.K8sHelper(ctx)
+	if err != nil {
+		return fmt.Errorf("error building kubernetes client: %w", err)
+	}
+
+	secrets := k8sClient.CoreV1().Secrets(namespace)
+
+	fmt.Printf("waiting for bootstrap token %q to be created\n", newID)
+
+	if err = retry.Constant(3*time.Minute, retry.WithUnits(10*time.Second), retry.WithErrorLogging(true)).Retry(func() error {
+		secret, err := secrets.Get(ctx, bootstrapTokenSecretName(newID), v1.GetOptions{})
+		if err != nil {
+			if apierrors.IsNotFound(err) || kubernetes.IsRetryableError(err) {
+				return retry.ExpectedError(err)
+			}
+
+			return retry.UnexpectedError(err)
+		}
+
+		if string(secret.Data["token-secret"]) != rotation.Secret() {
+			return retry.UnexpectedError(fmt.Errorf("bootstrap token %q secret mismatch", newID))
+		}
+
+		return nil
+	}); err != nil {
+		return fmt.Errorf("error waiting for the new bootstrap token (is the manifests reconciliation paused?): %w", err)
+	}
+
+	for _, oldID := range rotation.PreviousIDs {
+		fmt.Printf("removing bootstrap token %q\n", oldID)
+
+		if err = removeBootstrapToken(ctx, secrets, oldID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func removeBootstrapToken(ctx context.Context, secrets typedcorev1.SecretInterface, id string) error {
+	if err := secrets.Delete(ctx, bootstrapTokenSecretName(id), v1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("error removing the previous bootstrap token %q: %w", id, err)
+	}
+
+	_, err := secrets.Get(ctx, bootstrapTokenSecretName(id), v1.GetOptions{})
+
+	switch {
+	case err == nil:
+		return fmt.Errorf("previous bootstrap token %q is still present", id)
+	case apierrors.IsNotFound(err):
+		return nil
+	default:
+		return fmt.Errorf("error checking the previous bootstrap token %q: %w", id, err)
+	}
+}
+
+func bootstrapTokenSecretName(id string) string {
+	return "bootstrap-token-" + id
+}
+
+// rotateEncryptionSecret runs the rotation steps recommended for the encryption at rest:
+// the new key is added for decryption first, so that any kube-apiserver can read the data written with it,
+// then the new key is used for encryption, the secrets are re-encrypted, and the previous keys are dropped.
+func rotateEncryptionSecret(ctx context.Context, cluster UpgradeProvider, options RotateOptions, configs []*v1alpha1config.ClusterConfig) error {
+	for _, cfg := range configs {
+		if provider := cfg.SecretsEncryption().Provider(); provider != constants.SecretsEncryptionProviderAESCBC {
+			return fmt.Errorf("encryption secret rotation is supported only for the %q provider, the data is encrypted with the %q provider",
+				constants.SecretsEncryptionProviderAESCBC, provider)
+		}
+	}
+
+	rotation, err := NewEncryptionKeyRotation(configs...)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("rotating encryption key to %q\n", rotation.Name)
+
+	for _, step := range []struct {
+		description string
+		patch       func(config *v1alpha1config.Config) error
+	}{
+		{"adding the new encryption key", rotation.AddKey},
+		{"switching to the new encryption key", rotation.SwitchKey},
+	} {
+		fmt.Println(step.description)
+
+		for _, node := range options.masterNodes {
+			if err = patchControlPlaneNode(ctx, cluster, node, step.patch); err != nil {
+				return fmt.Errorf("error updating node %q: %w", node, err)
+			}
+		}
+	}
+
+	fmt.Println("re-encrypting secrets")
+
+	count, err := reencryptSecrets(ctx, cluster)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("re-encrypted %d secrets\n", count)
+
+	fmt.Println("removing the previous encryption keys")
+
+	for _, node := range options.masterNodes {
+		if err = patchControlPlaneNode(ctx, cluster, node, rotation.DropPreviousKeys); err != nil {
+			return fmt.Errorf("error updating node %q: %w", node, err)
+		}
+	}
+
+	fmt.Println("verifying that all the secrets can be decrypted")
+
+	return retry.Constant(time.Minute, retry.WithUnits(10*time.Second), retry.WithErrorLogging(true)).Retry(func() error {
+		_, err := listSecrets(ctx, cluster, func(*corev1.Secret) error { return nil })
+		if err != nil {
+			if kubernetes.IsRetryableError(err) {
+				return retry.ExpectedError(err)
+			}
+
+			return retry.UnexpectedError(err)
+		}
+
+		return nil
+	})
+}
+
+// EncryptionKeyRotation describes the new key for the encryption of secret data at rest.
+type EncryptionKeyRotation struct {
+	Name   string
+	Secret string
+}
+
+// NewEncryptionKeyRotation generates the new key, the key is named after the keys already in use.
+//
+// If the previous rotation was interrupted, the key with the highest number is not the encryption key on some
+// of the nodes yet, and the rotation is resumed with that key.
+func NewEncryptionKeyRotation(configs ...*v1alpha1config.ClusterConfig) (*EncryptionKeyRotation, error) {
+	secrets := map[string]string{}
+	encryptionKeys := map[string]struct{}{}
+
+	last, lastName := 0, ""
+
+	for _, cfg := range configs {
+		encryptionKeys[cfg.AESCBCEncryptionKeyName()] = struct{}{}
+
+		keys := append([]v1alpha1config.AESCBCEncryptionKeyConfig{
+			{
+				KeyName:   cfg.AESCBCEncryptionKeyName(),
+				KeySecret: cfg.AESCBCEncryptionSecret(),
+			},
+		}, cfg.ClusterAESCBCAdditionalEncryptionKeys...)
+
+		for _, key := range keys {
+			if secret, ok := secrets[key.KeyName]; ok && secret != key.KeySecret {
+				return nil, fmt.Errorf("encryption key %q has different secrets on the nodes, update the node configs before the rotation", key.KeyName)
+			}
+
+			secrets[key.KeyName] = key.KeySecret
+
+			if n, err := strconv.Atoi(strings.TrimPrefix(key.KeyName, "key")); err == nil && n > last {
+				last, lastName = n, key.KeyName
+			}
+		}
+	}
+
+	if _, ok := encryptionKeys[lastName]; lastName != "" && (!ok || len(encryptionKeys) > 1) {
+		return &EncryptionKeyRotation{
+			Name:   lastName,
+			Secret: secrets[lastName],
+		}, nil
+	}
+
+	if len(encryptionKeys) > 1 {
+		return nil, fmt.Errorf("nodes have different encryption keys, update the node configs before the rotation")
+	}
+
+	secret, err := generate.NewAESCBCEncryptionSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	return &EncryptionKeyRotation{
+		Name:   fmt.Sprintf("key%d", last+1),
+		Secret: secret,
+	}, nil
+}
+
+// AddKey adds the new key as the decryption-only key.
+func (rotation *EncryptionKeyRotation) AddKey(config *v1alpha1config.Config) error {
+	if config.ClusterConfig.AESCBCEncryptionKeyName() == rotation.Name {
+		return errUpdateSkipped
+	}
+
+	for _, key := range config.ClusterConfig.ClusterAESCBCAdditionalEncryptionKeys {
+		if key.KeyName == rotation.Name {
+			return errUpdateSkipped
+		}
+	}
+
+	config.ClusterConfig.ClusterAESCBCAdditionalEncryptionKeys = append(config.ClusterConfig.ClusterAESCBCAdditionalEncryptionKeys, v1alpha1config.AESCBCEncryptionKeyConfig{
+		KeyName:   rotation.Name,
+		KeySecret: rotation.Secret,
+	})
+
+	return nil
+}
+
+// SwitchKey makes the new key the encryption key, the previous key is kept for decryption.
+func (rotation *EncryptionKeyRotation) SwitchKey(config *v1alpha1config.Config) error {
+	cluster := config.ClusterConfig
+
+	if cluster.AESCBCEncryptionKeyName() == rotation.Name {
+		return errUpdateSkipped
+	}
+
+	keys := []v1alpha1config.AESCBCEncryptionKeyConfig{
+		{
+			KeyName:   cluster.AESCBCEncryptionKeyName(),
+			KeySecret: cluster.ClusterAESCBCEncryptionSecret,
+		},
+	}
+
+	for _, key := range cluster.ClusterAESCBCAdditionalEncryptionKeys {
+		if key.KeyName != rotation.Name {
+			keys = append(keys, key)
+		}
+	}
+
+	cluster.ClusterAESCBCEncryptionKeyName = rotation.Name
+	cluster.ClusterAESCBCEncryptionSecret = rotation.Secret
+	cluster.ClusterAESCBCAdditionalEncryptionKeys = keys
+
+	return nil
+}
+
+// DropPreviousKeys removes the decryption-only keys.
+func (rotation *EncryptionKeyRotation) DropPreviousKeys(config *v1alpha1config.Config) error {
+	if len(config.ClusterConfig.ClusterAESCBCAdditionalEncryptionKeys) == 0 {
+		return errUpdateSkipped
+	}
+
+	config.ClusterConfig.ClusterAESCBCAdditionalEncryptionKeys = nil
+
+	return nil
+}
+
+// patchControlPlaneNode updates the node config and waits for kube-apiserver to be restarted with the updated secrets.
+func patchControlPlaneNode(ctx context.Context, cluster UpgradeProvider, node string, patchFunc func(config *v1alpha1config.Config) error) error {
+	c, err := cluster.Client()
+	if err != nil {
+		return fmt.Errorf("error building Talos API client: %w", err)
+	}
+
+	fmt.Printf(" > updating node %q\n", node)
+
+	nodeCtx := client.WithNodes(ctx, node)
+
+	initialVersion, err := secretsVersion(nodeCtx, c)
+	if err != nil {
+		return err
+	}
+
+	if err = patchNodeConfig(ctx, cluster, node, patchFunc); err != nil {
+		if !errors.Is(err, errUpdateSkipped) {
+			return fmt.Errorf("error patching node config: %w", err)
+		}
+
+		// config is already updated, but kube-apiserver might still be restarting
+		initialVersion = ""
+	}
+
+	return retry.Constant(3*time.Minute, retry.WithUnits(10*time.Second), retry.WithErrorLogging(true)).Retry(func() error {
+		version, err := secretsVersion(nodeCtx, c)
+		if err != nil {
+			return retry.UnexpectedError(err)
+		}
+
+		if version == initialVersion {
+			return retry.ExpectedError(fmt.Errorf("secrets are not updated yet"))
+		}
+
+		return checkPodStatus(ctx, cluster, kubeAPIServer, node, constants.AnnotationStaticPodSecretsVersion, version)
+	})
+}
+
+// secretsVersion returns the version of the secrets rendered for the control plane static pods.
+func secretsVersion(ctx context.Context, c *client.Client) (string, error) {
+	resources, err := c.Resources.Get(ctx, k8s.ControlPlaneNamespaceName, k8s.SecretsStatusType, k8s.StaticPodSecretsStaticPodID)
+	if err != nil {
+		return "", fmt.Errorf("error fetching secrets status resource: %w", err)
+	}
+
+	if len(resources) != 1 {
+		return "", fmt.Errorf("expected 1 instance of secrets status resource, got %d", len(resources))
+	}
+
+	version, _ := resources[0].Resource.(*resource.Any).Value().(map[string]interface{})["version"].(string) //nolint:errcheck
+
+	return version, nil
+}
+
+// reencryptSecrets updates all the secrets without changes, so that kube-apiserver stores them with the current encryption key.
+func reencryptSecrets(ctx context.Context, cluster UpgradeProvider) (int, error) {
+	k8sClient, err := cluster.K8sHelper(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("error building kubernetes client: %w", err)
+	}
+
+	return listSecrets(ctx, cluster, func(secret *corev1.Secret) error {
+		_, err := k8sClient.CoreV1().Secrets(secret.Namespace).Update(ctx, secret, v1.UpdateOptions{})
+
+		switch {
+		case err == nil:
+			return nil
+		case apierrors.IsNotFound(err), apierrors.IsConflict(err):
+			// secret was removed or updated since it was listed, so it's already stored with the current key
+			return nil
+		default:
+			return fmt.Errorf("error updating secret %s/%s: %w", secret.Namespace, secret.Name, err)
+		}
+	})
+}
+
+// listSecrets calls f for each secret in all the namespaces, and returns the number of the secrets.
+func listSecrets(ctx context.Context, cluster UpgradeProvider, f func(secret *corev1.Secret) error) (int, error) {
+	k8sClient, err := cluster.K8sHelper(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("error building kubernetes client: %w", err)
+	}
+
+	count := 0
+	opts := v1.ListOptions{
+		Limit: 100,
+	}
+
+	for {
+		secrets, err := k8sClient.CoreV1().Secrets("").List(ctx, opts)
+		if err != nil {
+			return count, fmt.Errorf("error listing secrets: %w", err)
+		}
+
+		for i := range secrets.Items {
+			if err = f(&secrets.Items[i]); err != nil {
+				return count, err
+			}
+
+			count++
+		}
+
+		if secrets.Continue == "" {
+			return count, nil
+		}
+
+		opts.Continue = secrets.Continue
+	}
+}
@@ -0,0 +1,227 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package kubernetes_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/pkg/cluster/kubernetes"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
+)
+
+func TestEncryptionKeyRotation(t *testing.T) {
+	t.Parallel()
+
+	config := &v1alpha1.Config{
+		ClusterConfig: &v1alpha1.ClusterConfig{
+			ClusterAESCBCEncryptionSecret: "z01mye6j16bspJYtTB/5SFX8j7Ph4JXxM2Xuu4vsBPM=",
+		},
+	}
+
+	rotation, err := kubernetes.NewEncryptionKeyRotation(config.ClusterConfig)
+	require.NoError(t, err)
+
+	assert.Equal(t, "key2", rotation.Name)
+	assert.NotEmpty(t, rotation.Secret)
+
+	require.NoError(t, rotation.AddKey(config))
+	assert.Error(t, rotation.AddKey(config))
+
+	assert.Equal(t, "key1", config.ClusterConfig.AESCBCEncryptionKeyName())
+	assert.Equal(t, "z01mye6j16bspJYtTB/5SFX8j7Ph4JXxM2Xuu4vsBPM=", config.ClusterConfig.AESCBCEncryptionSecret())
+	assert.Equal(t, []v1alpha1.AESCBCEncryptionKeyConfig{
+		{
+			KeyName:   "key2",
+			KeySecret: rotation.Secret,
+		},
+	}, config.ClusterConfig.ClusterAESCBCAdditionalEncryptionKeys)
+
+	require.NoError(t, rotation.SwitchKey(config))
+	assert.Error(t, rotation.SwitchKey(config))
+
+	assert.Equal(t, "key2", config.ClusterConfig.AESCBCEncryptionKeyName())
+	assert.Equal(t, rotation.Secret, config.ClusterConfig.AESCBCEncryptionSecret())
+	assert.Equal(t, []v1alpha1.AESCBCEncryptionKeyConfig{
+		{
+			KeyName:   "key1",
+			KeySecret: "z01mye6j16bspJYtTB/5SFX8j7Ph4JXxM2Xuu4vsBPM=",
+		},
+	}, config.ClusterConfig.ClusterAESCBCAdditionalEncryptionKeys)
+
+	require.NoError(t, rotation.DropPreviousKeys(config))
+	assert.Error(t, rotation.DropPreviousKeys(config))
+
+	assert.Empty(t, config.ClusterConfig.ClusterAESCBCAdditionalEncryptionKeys)
+
+	// next rotation picks the name not used by any of the keys
+	config.ClusterConfig.ClusterAESCBCEncryptionKeyName = "key5"
+	config.ClusterConfig.ClusterAESCBCAdditionalEncryptionKeys = []v1alpha1.AESCBCEncryptionKeyConfig{
+		{
+			KeyName:   "key3",
+			KeySecret: "0VfPGSFlGnTR5f2EZwv7GYpyFNNy0Wp8uAhmB4VK4BM=",
+		},
+	}
+
+	rotation, err = kubernetes.NewEncryptionKeyRotation(config.ClusterConfig)
+	require.NoError(t, err)
+
+	assert.Equal(t, "key6", rotation.Name)
+}
+
+func TestEncryptionKeyRotationResume(t *testing.T) {
+	t.Parallel()
+
+	const (
+		secret1 = "z01mye6j16bspJYtTB/5SFX8j7Ph4JXxM2Xuu4vsBPM="
+		secret2 = "0VfPGSFlGnTR5f2EZwv7GYpyFNNy0Wp8uAhmB4VK4BM="
+	)
+
+	key1 := v1alpha1.AESCBCEncryptionKeyConfig{KeyName: "key1", KeySecret: secret1}
+	key2 := v1alpha1.AESCBCEncryptionKeyConfig{KeyName: "key2", KeySecret: secret2}
+
+	node := func(encryptionKey v1alpha1.AESCBCEncryptionKeyConfig, additionalKeys ...v1alpha1.AESCBCEncryptionKeyConfig) *v1alpha1.ClusterConfig {
+		return &v1alpha1.ClusterConfig{
+			ClusterAESCBCEncryptionKeyName:        encryptionKey.KeyName,
+			ClusterAESCBCEncryptionSecret:         encryptionKey.KeySecret,
+			ClusterAESCBCAdditionalEncryptionKeys: additionalKeys,
+		}
+	}
+
+	for _, tt := range []struct {
+		name          string
+		configs       []*v1alpha1.ClusterConfig
+		expectedName  string
+		resumed       bool
+		expectedError string
+	}{
+		{
+			name:         "not rotated",
+			configs:      []*v1alpha1.ClusterConfig{node(key1), node(key1)},
+			expectedName: "key2",
+		},
+		{
+			name:         "interrupted while adding the key",
+			configs:      []*v1alpha1.ClusterConfig{node(key1, key2), node(key1)},
+			expectedName: "key2",
+			resumed:      true,
+		},
+		{
+			name:         "interrupted before switching the key",
+			configs:      []*v1alpha1.ClusterConfig{node(key1, key2), node(key1, key2)},
+			expectedName: "key2",
+			resumed:      true,
+		},
+		{
+			name:         "interrupted while switching the key",
+			configs:      []*v1alpha1.ClusterConfig{node(key2, key1), node(key1, key2)},
+			expectedName: "key2",
+			resumed:      true,
+		},
+		{
+			name:         "interrupted while removing the previous keys",
+			configs:      []*v1alpha1.ClusterConfig{node(key2), node(key2, key1)},
+			expectedName: "key3",
+		},
+		{
+			name: "different secrets",
+			configs: []*v1alpha1.ClusterConfig{
+				node(key1, key2),
+				node(key1, v1alpha1.AESCBCEncryptionKeyConfig{KeyName: "key2", KeySecret: secret1}),
+			},
+			expectedError: `encryption key "key2" has different secrets on the nodes`,
+		},
+	} {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rotation, err := kubernetes.NewEncryptionKeyRotation(tt.configs...)
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expectedName, rotation.Name)
+
+			if tt.resumed {
+				assert.Equal(t, secret2, rotation.Secret)
+			} else {
+				assert.NotEqual(t, secret2, rotation.Secret)
+			}
+
+			// re-run completes the rotation on all the nodes
+			for _, cfg := range tt.configs {
+				config := &v1alpha1.Config{ClusterConfig: cfg}
+
+				for _, step := range []func(*v1alpha1.Config) error{rotation.AddKey, rotation.SwitchKey, rotation.DropPreviousKeys} {
+					if err = step(config); err != nil {
+						assert.EqualError(t, err, "update skipped")
+					}
+				}
+
+				assert.Equal(t, rotation.Name, cfg.AESCBCEncryptionKeyName())
+				assert.Equal(t, rotation.Secret, cfg.AESCBCEncryptionSecret())
+				assert.Empty(t, cfg.ClusterAESCBCAdditionalEncryptionKeys)
+			}
+		})
+	}
+}
+
+func TestBootstrapTokenRotation(t *testing.T) {
+	t.Parallel()
+
+	node := func(token string) *v1alpha1.ClusterConfig {
+		return &v1alpha1.ClusterConfig{
+			BootstrapToken: token,
+		}
+	}
+
+	for _, tt := range []struct {
+		name        string
+		configs     []*v1alpha1.ClusterConfig
+		previousIDs []string
+	}{
+		{
+			name:        "not rotated",
+			configs:     []*v1alpha1.ClusterConfig{node("abcdef.0123456789abcdef"), node("abcdef.0123456789abcdef")},
+			previousIDs: []string{"abcdef"},
+		},
+		{
+			name:        "interrupted",
+			configs:     []*v1alpha1.ClusterConfig{node("ghijkl.0123456789abcdef"), node("abcdef.0123456789abcdef"), node("ghijkl.0123456789abcdef")},
+			previousIDs: []string{"ghijkl", "abcdef"},
+		},
+	} {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rotation, err := kubernetes.NewBootstrapTokenRotation(tt.configs...)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.previousIDs, rotation.PreviousIDs)
+			assert.NotContains(t, tt.previousIDs, rotation.ID())
+			assert.Len(t, rotation.Secret(), 16)
+
+			for _, cfg := range tt.configs {
+				config := &v1alpha1.Config{ClusterConfig: cfg}
+
+				require.NoError(t, rotation.Patch(config))
+				assert.Error(t, rotation.Patch(config))
+
+				assert.Equal(t, rotation.ID(), cfg.Token().ID())
+			}
+		})
+	}
+}
@@ -155,7 +155,7 @@ func upgradeNodeConfigPatch(ctx context.Context, cluster UpgradeProvider, option
 	}
 
 	return retry.Constant(3*time.Minute, retry.WithUnits(10*time.Second), retry.WithErrorLogging(true)).Retry(func() error {
-		return checkPodStatus(ctx, cluster, service, node, expectedConfigVersion)
+		return checkPodStatus(ctx, cluster, service, node, constants.AnnotationStaticPodConfigVersion, expectedConfigVersion)
 	})
 }
 
@@ -216,8 +216,11 @@ func upgradeConfigPatcher(options UpgradeOptions, service string, configResource
 	}
 }
 
+// checkPodStatus checks that the static pod on the node is ready, and it's running with the expected version
+// of the config or secrets (as specified by the annotation).
+//
 //nolint:gocyclo
-func checkPodStatus(ctx context.Context, cluster UpgradeProvider, service, node, configVersion string) error {
+func checkPodStatus(ctx context.Context, cluster UpgradeProvider, service, node, annotation, version string) error {
 	k8sClient, err := cluster.K8sHelper(ctx)
 	if err != nil {
 		return retry.UnexpectedError(fmt.Errorf("error building kubernetes client: %w", err))
@@ -243,8 +246,8 @@ func checkPodStatus(ctx context.Context, cluster UpgradeProvider, service, node,
 
 		podFound = true
 
-		if pod.Annotations[constants.AnnotationStaticPodConfigVersion] != configVersion {
-			return retry.ExpectedError(fmt.Errorf("config version mismatch: got %q, expected %q", pod.Annotations[constants.AnnotationStaticPodConfigVersion], configVersion))
+		if pod.Annotations[annotation] != version {
+			return retry.ExpectedError(fmt.Errorf("%s mismatch: got %q, expected %q", annotation, pod.Annotations[annotation], version))
 		}
 
 		ready := false
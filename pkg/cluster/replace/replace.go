@@ -0,0 +1,154 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package replace implements machine replacement: the state of the node being replaced
+// is captured into an escrow, and the configuration for the replacement hardware is derived
+// from it, so that the replacement registers in Kubernetes under the same node name and labels.
+package replace
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
+	"github.com/talos-systems/talos/pkg/resources/cluster"
+)
+
+// Escrow is the state of the replaced node captured before it is fenced.
+type Escrow struct {
+	Node        string                `yaml:"node"`
+	NodeName    string                `yaml:"nodeName"`
+	MachineType string                `yaml:"machineType"`
+	CapturedAt  time.Time             `yaml:"capturedAt"`
+	Identity    *cluster.IdentitySpec `yaml:"identity,omitempty"`
+	Labels      map[string]string     `yaml:"labels,omitempty"`
+	Taints      []string              `yaml:"taints,omitempty"`
+	EtcdMember  string                `yaml:"etcdMember,omitempty"`
+}
+
+// labels which are set by the kubelet itself.
+var kubeletManagedLabels = map[string]struct{}{
+	"kubernetes.io/hostname":  {},
+	"kubernetes.io/arch":      {},
+	"kubernetes.io/os":        {},
+	"beta.kubernetes.io/arch": {},
+	"beta.kubernetes.io/os":   {},
+}
+
+// labels in the restricted namespaces which kubelet is still allowed to set.
+var kubeletAllowedLabels = map[string]struct{}{
+	"beta.kubernetes.io/instance-type":         {},
+	"node.kubernetes.io/instance-type":         {},
+	"failure-domain.beta.kubernetes.io/region": {},
+	"failure-domain.beta.kubernetes.io/zone":   {},
+	"topology.kubernetes.io/region":            {},
+	"topology.kubernetes.io/zone":              {},
+}
+
+// KubeletLabels splits node labels into the labels which kubelet can register the node with
+// and the labels which are rejected by the NodeRestriction admission plugin and should be
+// re-applied manually (e.g. node-role.kubernetes.io/*).
+func KubeletLabels(labels map[string]string) (kept, skipped map[string]string) {
+	kept = map[string]string{}
+	skipped = map[string]string{}
+
+	for key, value := range labels {
+		if _, ok := kubeletManagedLabels[key]; ok {
+			continue
+		}
+
+		if kubeletCanSet(key) {
+			kept[key] = value
+		} else {
+			skipped[key] = value
+		}
+	}
+
+	return kept, skipped
+}
+
+func kubeletCanSet(key string) bool {
+	if _, ok := kubeletAllowedLabels[key]; ok {
+		return true
+	}
+
+	idx := strings.Index(key, "/")
+	if idx < 0 {
+		return true
+	}
+
+	domain := key[:idx]
+
+	inDomain := func(d string) bool {
+		return domain == d || strings.HasSuffix(domain, "."+d)
+	}
+
+	if inDomain("kubelet.kubernetes.io") || inDomain("node.kubernetes.io") {
+		return true
+	}
+
+	return !inDomain("kubernetes.io") && !inDomain("k8s.io")
+}
+
+// FormatTaints converts node taints into kubelet --register-with-taints format.
+func FormatTaints(taints []corev1.Taint) []string {
+	result := make([]string, 0, len(taints))
+
+	for _, taint := range taints {
+		// taints managed by the node lifecycle controller
+		if strings.HasPrefix(taint.Key, "node.kubernetes.io/") {
+			continue
+		}
+
+		result = append(result, fmt.Sprintf("%s=%s:%s", taint.Key, taint.Value, taint.Effect))
+	}
+
+	return result
+}
+
+// ApplyEscrow updates the config of the replacement node so that it registers
+// as the replaced node: same node name, labels and taints.
+func ApplyEscrow(cfg *v1alpha1.Config, escrow *Escrow, labels map[string]string) {
+	if cfg.MachineConfig == nil {
+		cfg.MachineConfig = &v1alpha1.MachineConfig{}
+	}
+
+	machine := cfg.MachineConfig
+
+	if machine.MachineNetwork == nil {
+		machine.MachineNetwork = &v1alpha1.NetworkConfig{}
+	}
+
+	if machine.MachineKubelet == nil {
+		machine.MachineKubelet = &v1alpha1.KubeletConfig{}
+	}
+
+	if machine.MachineKubelet.KubeletExtraArgs == nil {
+		machine.MachineKubelet.KubeletExtraArgs = map[string]string{}
+	}
+
+	// Kubernetes node name is derived from the hostname, FQDN node names require registerWithFQDN
+	machine.MachineNetwork.NetworkHostname = escrow.NodeName
+	machine.MachineKubelet.KubeletRegisterWithFQDN = strings.Contains(escrow.NodeName, ".")
+
+	if len(labels) > 0 {
+		pairs := make([]string, 0, len(labels))
+
+		for key, value := range labels {
+			pairs = append(pairs, key+"="+value)
+		}
+
+		sort.Strings(pairs)
+
+		machine.MachineKubelet.KubeletExtraArgs["node-labels"] = strings.Join(pairs, ",")
+	}
+
+	if len(escrow.Taints) > 0 {
+		machine.MachineKubelet.KubeletExtraArgs["register-with-taints"] = strings.Join(escrow.Taints, ",")
+	}
+}
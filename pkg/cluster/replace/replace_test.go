@@ -0,0 +1,80 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package replace_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/talos-systems/talos/pkg/cluster/replace"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
+)
+
+func TestKubeletLabels(t *testing.T) {
+	kept, skipped := replace.KubeletLabels(map[string]string{
+		"kubernetes.io/hostname":           "worker-1",
+		"kubernetes.io/os":                 "linux",
+		"node-role.kubernetes.io/storage":  "",
+		"node.kubernetes.io/pool":          "fast",
+		"topology.kubernetes.io/zone":      "us-east-1a",
+		"example.com/rack":                 "r12",
+		"disktype":                         "ssd",
+		"foo.k8s.io/bar":                   "baz",
+		"kubelet.kubernetes.io/managed-by": "talos",
+	})
+
+	assert.Equal(t, map[string]string{
+		"node.kubernetes.io/pool":          "fast",
+		"topology.kubernetes.io/zone":      "us-east-1a",
+		"example.com/rack":                 "r12",
+		"disktype":                         "ssd",
+		"kubelet.kubernetes.io/managed-by": "talos",
+	}, kept)
+
+	assert.Equal(t, map[string]string{
+		"node-role.kubernetes.io/storage": "",
+		"foo.k8s.io/bar":                  "baz",
+	}, skipped)
+}
+
+func TestFormatTaints(t *testing.T) {
+	assert.Equal(t, []string{"dedicated=storage:NoSchedule"}, replace.FormatTaints([]corev1.Taint{
+		{
+			Key:    "dedicated",
+			Value:  "storage",
+			Effect: corev1.TaintEffectNoSchedule,
+		},
+		{
+			Key:    "node.kubernetes.io/unschedulable",
+			Effect: corev1.TaintEffectNoSchedule,
+		},
+	}))
+}
+
+func TestApplyEscrow(t *testing.T) {
+	cfg := &v1alpha1.Config{
+		ConfigVersion: "v1alpha1",
+		MachineConfig: &v1alpha1.MachineConfig{
+			MachineType: "join",
+		},
+	}
+
+	replace.ApplyEscrow(cfg, &replace.Escrow{
+		NodeName: "worker-1.example.com",
+		Taints:   []string{"dedicated=storage:NoSchedule"},
+	}, map[string]string{
+		"example.com/rack": "r12",
+		"disktype":         "ssd",
+	})
+
+	assert.Equal(t, "worker-1.example.com", cfg.MachineConfig.MachineNetwork.NetworkHostname)
+	assert.True(t, cfg.MachineConfig.MachineKubelet.KubeletRegisterWithFQDN)
+	assert.Equal(t, map[string]string{
+		"node-labels":          "disktype=ssd,example.com/rack=r12",
+		"register-with-taints": "dedicated=storage:NoSchedule",
+	}, cfg.MachineConfig.MachineKubelet.KubeletExtraArgs)
+}
@@ -18,6 +18,8 @@ import (
 	"github.com/vmware-tanzu/sonobuoy/pkg/client"
 	"github.com/vmware-tanzu/sonobuoy/pkg/config"
 	sonodynamic "github.com/vmware-tanzu/sonobuoy/pkg/dynamic"
+	"golang.org/x/sync/errgroup"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/talos-systems/talos/pkg/cluster"
 	"github.com/talos-systems/talos/pkg/machinery/constants"
@@ -33,8 +35,28 @@ type Options struct {
 	KubernetesVersion string
 
 	UseSpinner bool
+
+	// ResultsPath is the directory to store the sonobuoy results tarball in (results are not retrieved if empty).
+	ResultsPath string
 }
 
+// Test focus expressions for the quick mode.
+var (
+	// smokeTests cover basic Kubernetes operations: workloads, DNS and service connectivity.
+	smokeTests = []string{
+		"Pods should be submitted and removed",
+		"Services should serve a basic endpoint from pods",
+		"Services should be able to change the type from ExternalName to ClusterIP",
+		"DNS should provide DNS for services",
+		"DNS should provide DNS for the cluster",
+	}
+
+	// storageTests require the default storage class.
+	storageTests = []string{
+		"Dynamic Provisioning DynamicProvisioner Default should create and delete default persistent volumes",
+	}
+)
+
 // DefaultOptions with hand-picked tests, timeouts, etc.
 func DefaultOptions() *Options {
 	return &Options{
@@ -51,6 +73,70 @@ func DefaultOptions() *Options {
 	}
 }
 
+// QuickOptions runs the smoke tests, including the storage tests if the cluster has the default storage class.
+func QuickOptions(withStorage bool) *Options {
+	options := DefaultOptions()
+	options.RunTests = append([]string(nil), smokeTests...)
+
+	if withStorage {
+		options.RunTests = append(options.RunTests, storageTests...)
+	}
+
+	return options
+}
+
+// CertifiedOptions runs the full Kubernetes conformance suite.
+func CertifiedOptions() *Options {
+	options := DefaultOptions()
+	options.RunTests = []string{`\[Conformance\]`}
+	options.RunTimeout = 2 * time.Hour
+
+	return options
+}
+
+// HasDefaultStorageClass returns true if the cluster has the default storage class.
+func HasDefaultStorageClass(ctx context.Context, cluster cluster.K8sProvider) (bool, error) {
+	clientset, err := cluster.K8sClient(ctx)
+	if err != nil {
+		return false, fmt.Errorf("error building kubernetes client: %w", err)
+	}
+
+	storageClasses, err := clientset.StorageV1().StorageClasses().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return false, fmt.Errorf("error listing storage classes: %w", err)
+	}
+
+	for _, storageClass := range storageClasses.Items {
+		if storageClass.Annotations["storageclass.kubernetes.io/is-default-class"] == "true" {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// DetectKubernetesVersion returns the version of the Kubernetes API server (without the leading 'v').
+func DetectKubernetesVersion(ctx context.Context, cluster cluster.K8sProvider) (string, error) {
+	clientset, err := cluster.K8sClient(ctx)
+	if err != nil {
+		return "", fmt.Errorf("error building kubernetes client: %w", err)
+	}
+
+	serverVersion, err := clientset.Discovery().ServerVersion()
+	if err != nil {
+		return "", fmt.Errorf("error getting server version: %w", err)
+	}
+
+	// conformance image is tagged with the upstream version, so drop any build metadata
+	version := strings.TrimPrefix(serverVersion.GitVersion, "v")
+
+	if idx := strings.IndexAny(version, "-+"); idx >= 0 {
+		version = version[:idx]
+	}
+
+	return version, nil
+}
+
 // Run the e2e test against cluster with provided options.
 //
 //nolint:gocyclo
@@ -135,6 +221,12 @@ func Run(ctx context.Context, cluster cluster.K8sProvider, options *Options) err
 		return fmt.Errorf("error getting test status: %w", err)
 	}
 
+	if options.ResultsPath != "" {
+		if err = retrieveResults(sclient, options.ResultsPath); err != nil {
+			return err
+		}
+	}
+
 	for _, pluginStatus := range status.Plugins {
 		if pluginStatus.Plugin == "e2e" {
 			fmt.Print("e2e status ")
@@ -166,3 +258,35 @@ func Run(ctx context.Context, cluster cluster.K8sProvider, options *Options) err
 
 	return cleanup()
 }
+
+func retrieveResults(sclient *client.SonobuoyClient, resultsPath string) error {
+	if err := os.MkdirAll(resultsPath, 0o755); err != nil {
+		return err
+	}
+
+	reader, errCh, err := sclient.RetrieveResults(&client.RetrieveConfig{
+		Namespace: config.DefaultNamespace,
+		Path:      config.AggregatorResultsPath,
+	})
+	if err != nil {
+		return fmt.Errorf("error retrieving results: %w", err)
+	}
+
+	var eg errgroup.Group
+
+	eg.Go(func() error { return <-errCh })
+	eg.Go(func() error {
+		files, err := client.UntarAll(reader, resultsPath, "")
+		if err != nil {
+			return fmt.Errorf("error extracting results: %w", err)
+		}
+
+		for _, file := range files {
+			fmt.Printf("results saved to %s\n", file)
+		}
+
+		return nil
+	})
+
+	return eg.Wait()
+}
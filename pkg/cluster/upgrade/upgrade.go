@@ -0,0 +1,141 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package upgrade sequences Talos upgrades across the nodes of the cluster.
+//
+// Control plane nodes are upgraded first, one by one, and only while all of them are healthy
+// (so that etcd keeps the quorum while the node is down). Worker nodes are upgraded next
+// in batches of the configured size.
+package upgrade
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// NodeFunc performs an action on a single node.
+type NodeFunc func(ctx context.Context, node string) error
+
+// Options configure the cluster upgrade.
+type Options struct {
+	// ControlPlaneNodes are upgraded first, one by one.
+	ControlPlaneNodes []string
+	// WorkerNodes are upgraded in batches of Concurrency nodes.
+	WorkerNodes []string
+	// Concurrency is the size of the batch of worker nodes, defaults to 1.
+	Concurrency int
+
+	// Upgrade upgrades the node and waits for it to come back with the new version.
+	Upgrade NodeFunc
+	// Drain drains the node before the upgrade, optional.
+	//
+	// Drain is never called concurrently.
+	Drain NodeFunc
+	// Health waits for the node to become healthy.
+	//
+	// For control plane nodes, it should verify that etcd is healthy and has the quorum.
+	Health NodeFunc
+
+	// Out receives the progress messages.
+	Out io.Writer
+}
+
+// Batches splits worker nodes into the batches which are upgraded concurrently.
+func (options *Options) Batches() [][]string {
+	size := options.Concurrency
+	if size < 1 {
+		size = 1
+	}
+
+	var batches [][]string
+
+	for i := 0; i < len(options.WorkerNodes); i += size {
+		end := i + size
+		if end > len(options.WorkerNodes) {
+			end = len(options.WorkerNodes)
+		}
+
+		batches = append(batches, options.WorkerNodes[i:end])
+	}
+
+	return batches
+}
+
+// Run upgrades the nodes of the cluster.
+//
+// Upgrade is aborted on the first failure.
+func Run(ctx context.Context, options *Options) error {
+	if options.Upgrade == nil || options.Health == nil {
+		return fmt.Errorf("upgrade and health functions are required")
+	}
+
+	for _, node := range options.ControlPlaneNodes {
+		// taking down the node is only safe if the rest of the control plane is healthy
+		for _, other := range options.ControlPlaneNodes {
+			if err := options.Health(ctx, other); err != nil {
+				return fmt.Errorf("control plane node %q is not healthy: %w", other, err)
+			}
+		}
+
+		fmt.Fprintf(options.Out, "upgrading control plane node %s\n", node)
+
+		if err := upgradeNode(ctx, options, node); err != nil {
+			return fmt.Errorf("control plane node %q: %w", node, err)
+		}
+	}
+
+	for _, batch := range options.Batches() {
+		fmt.Fprintf(options.Out, "upgrading worker nodes %v\n", batch)
+
+		// nodes are drained one by one, as Drain is not required to be safe for concurrent use
+		if options.Drain != nil {
+			for _, node := range batch {
+				if err := options.Drain(ctx, node); err != nil {
+					return fmt.Errorf("worker node %q: %w", node, err)
+				}
+			}
+		}
+
+		eg, egCtx := errgroup.WithContext(ctx)
+
+		for _, node := range batch {
+			node := node
+
+			eg.Go(func() error {
+				if err := options.Upgrade(egCtx, node); err != nil {
+					return fmt.Errorf("worker node %q: %w", node, err)
+				}
+
+				if err := options.Health(egCtx, node); err != nil {
+					return fmt.Errorf("worker node %q: %w", node, err)
+				}
+
+				return nil
+			})
+		}
+
+		if err := eg.Wait(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func upgradeNode(ctx context.Context, options *Options, node string) error {
+	if options.Drain != nil {
+		if err := options.Drain(ctx, node); err != nil {
+			return err
+		}
+	}
+
+	if err := options.Upgrade(ctx, node); err != nil {
+		return err
+	}
+
+	return options.Health(ctx, node)
+}
@@ -0,0 +1,121 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package upgrade_test
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/pkg/cluster/upgrade"
+)
+
+func TestBatches(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		nodes       []string
+		concurrency int
+		expected    [][]string
+	}{
+		{
+			name:     "empty",
+			expected: nil,
+		},
+		{
+			name:     "default",
+			nodes:    []string{"w1", "w2"},
+			expected: [][]string{{"w1"}, {"w2"}},
+		},
+		{
+			name:        "uneven",
+			nodes:       []string{"w1", "w2", "w3", "w4", "w5"},
+			concurrency: 2,
+			expected:    [][]string{{"w1", "w2"}, {"w3", "w4"}, {"w5"}},
+		},
+		{
+			name:        "large",
+			nodes:       []string{"w1", "w2"},
+			concurrency: 5,
+			expected:    [][]string{{"w1", "w2"}},
+		},
+	} {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			options := upgrade.Options{
+				WorkerNodes: tt.nodes,
+				Concurrency: tt.concurrency,
+			}
+
+			assert.Equal(t, tt.expected, options.Batches())
+		})
+	}
+}
+
+type recorder struct {
+	mu      sync.Mutex
+	actions []string
+	fail    string
+}
+
+func (r *recorder) record(action string) upgrade.NodeFunc {
+	return func(ctx context.Context, node string) error {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		entry := action + " " + node
+
+		r.actions = append(r.actions, entry)
+
+		if entry == r.fail {
+			return fmt.Errorf("failed")
+		}
+
+		return nil
+	}
+}
+
+func TestRun(t *testing.T) {
+	r := &recorder{}
+
+	err := upgrade.Run(context.Background(), &upgrade.Options{
+		ControlPlaneNodes: []string{"cp1", "cp2"},
+		WorkerNodes:       []string{"w1"},
+		Upgrade:           r.record("upgrade"),
+		Drain:             r.record("drain"),
+		Health:            r.record("health"),
+		Out:               ioutil.Discard,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"health cp1", "health cp2", "drain cp1", "upgrade cp1", "health cp1",
+		"health cp1", "health cp2", "drain cp2", "upgrade cp2", "health cp2",
+		"drain w1", "upgrade w1", "health w1",
+	}, r.actions)
+}
+
+func TestRunAbort(t *testing.T) {
+	r := &recorder{
+		fail: "health cp2",
+	}
+
+	err := upgrade.Run(context.Background(), &upgrade.Options{
+		ControlPlaneNodes: []string{"cp1", "cp2"},
+		WorkerNodes:       []string{"w1"},
+		Upgrade:           r.record("upgrade"),
+		Health:            r.record("health"),
+		Out:               ioutil.Discard,
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `control plane node "cp2" is not healthy`)
+
+	assert.Equal(t, []string{"health cp1", "health cp2"}, r.actions)
+}
@@ -0,0 +1,97 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package apiversion provides grpc middleware which negotiates the API version with the clients.
+package apiversion
+
+import (
+	"context"
+	"strconv"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+)
+
+// ClientVersion returns the API version sent by the client.
+//
+// Zero is returned if the client doesn't send the API version (clients released before the API versioning).
+func ClientVersion(ctx context.Context) int {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return 0
+	}
+
+	values := md.Get(constants.APIVersionMetadataKey)
+	if len(values) == 0 {
+		return 0
+	}
+
+	version, err := strconv.Atoi(values[0])
+	if err != nil {
+		return 0
+	}
+
+	return version
+}
+
+// UnaryInterceptor returns grpc UnaryServerInterceptor.
+func UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		clientVersion := ClientVersion(ctx)
+
+		if err := checkVersion(clientVersion); err != nil {
+			return nil, err
+		}
+
+		grpc.SetHeader(ctx, header()) //nolint:errcheck
+
+		resp, err := handler(ctx, req)
+
+		return resp, wrapUnimplemented(info.FullMethod, clientVersion, err)
+	}
+}
+
+// StreamInterceptor returns grpc StreamServerInterceptor.
+func StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		clientVersion := ClientVersion(stream.Context())
+
+		if err := checkVersion(clientVersion); err != nil {
+			return err
+		}
+
+		stream.SetHeader(header()) //nolint:errcheck
+
+		return wrapUnimplemented(info.FullMethod, clientVersion, handler(srv, stream))
+	}
+}
+
+func header() metadata.MD {
+	return metadata.Pairs(constants.APIVersionMetadataKey, strconv.Itoa(constants.APIVersion))
+}
+
+func checkVersion(clientVersion int) error {
+	// clients which don't send the version are let through, as they predate the API versioning
+	if clientVersion == 0 || clientVersion >= constants.MinAPIVersion {
+		return nil
+	}
+
+	return status.Errorf(codes.FailedPrecondition,
+		"unsupported API: client API version %d is not supported, minimum supported version is %d (server API version %d), please upgrade the client",
+		clientVersion, constants.MinAPIVersion, constants.APIVersion)
+}
+
+func wrapUnimplemented(method string, clientVersion int, err error) error {
+	if status.Code(err) != codes.Unimplemented || clientVersion <= constants.APIVersion {
+		return err
+	}
+
+	return status.Errorf(codes.Unimplemented,
+		"unsupported API: method %s is not supported by the server (server API version %d, client API version %d): %s",
+		method, constants.APIVersion, clientVersion, status.Convert(err).Message())
+}
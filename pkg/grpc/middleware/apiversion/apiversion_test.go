@@ -0,0 +1,77 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package apiversion_test
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/talos-systems/talos/pkg/grpc/middleware/apiversion"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+)
+
+func TestClientVersion(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		md       metadata.MD
+		expected int
+	}{
+		{
+			name:     "empty",
+			md:       metadata.MD{},
+			expected: 0,
+		},
+		{
+			name:     "regular",
+			md:       metadata.Pairs(constants.APIVersionMetadataKey, "3"),
+			expected: 3,
+		},
+		{
+			name:     "invalid",
+			md:       metadata.Pairs(constants.APIVersionMetadataKey, "v3"),
+			expected: 0,
+		},
+	} {
+		ctx := metadata.NewIncomingContext(context.Background(), test.md)
+
+		assert.Equal(t, test.expected, apiversion.ClientVersion(ctx), test.name)
+	}
+}
+
+func TestUnaryInterceptor(t *testing.T) {
+	interceptor := apiversion.UnaryInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/machine.MachineService/Foo"}
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, status.Error(codes.Unimplemented, "unknown method Foo")
+	}
+
+	// legacy client
+	_, err := interceptor(context.Background(), nil, info, handler)
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+	assert.Equal(t, "unknown method Foo", status.Convert(err).Message())
+
+	// newer client
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(constants.APIVersionMetadataKey, strconv.Itoa(constants.APIVersion+1)))
+
+	_, err = interceptor(ctx, nil, info, handler)
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+	assert.True(t, strings.HasPrefix(status.Convert(err).Message(), "unsupported API: method /machine.MachineService/Foo is not supported by the server"))
+
+	// successful call
+	resp, err := interceptor(ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", resp)
+}
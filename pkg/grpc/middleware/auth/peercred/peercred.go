@@ -0,0 +1,181 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package peercred provides grpc transport credentials which authenticate Unix socket peers
+// via the peer process credentials (SO_PEERCRED).
+package peercred
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"golang.org/x/sys/unix"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// AuthInfo describes the credentials of the peer process.
+type AuthInfo struct {
+	credentials.CommonAuthInfo
+
+	PID int32
+	UID uint32
+	GID uint32
+}
+
+// AuthType implements credentials.AuthInfo interface.
+func (AuthInfo) AuthType() string {
+	return "peercred"
+}
+
+// AllowFunc returns the UIDs and primary GIDs of the peers allowed to access the server.
+type AllowFunc func() (uids, gids []uint32)
+
+// Credentials implements credentials.TransportCredentials for the Unix socket servers.
+//
+// Connections from the processes running with UIDs and primary GIDs not returned by Allowed are rejected.
+// Allowed is called for each connection, and for each request by the interceptors, so that the requests
+// over the connections established earlier are rejected once the peer is no longer allowed.
+// Credentials don't encrypt the traffic, so they should be used only with Unix sockets.
+type Credentials struct {
+	Allowed AllowFunc
+}
+
+// NewServerCredentials initializes Credentials which accept only the peers running with the UIDs or GIDs allowed.
+func NewServerCredentials(allowed AllowFunc) *Credentials {
+	return &Credentials{
+		Allowed: allowed,
+	}
+}
+
+// ClientHandshake implements credentials.TransportCredentials interface.
+func (c *Credentials) ClientHandshake(ctx context.Context, authority string, conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return nil, nil, fmt.Errorf("peercred: client handshake is not supported")
+}
+
+// ServerHandshake implements credentials.TransportCredentials interface.
+func (c *Credentials) ServerHandshake(conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	unixConn, ok := conn.(*net.UnixConn)
+	if !ok {
+		return nil, nil, fmt.Errorf("peercred: unsupported connection type %T", conn)
+	}
+
+	rawConn, err := unixConn.SyscallConn()
+	if err != nil {
+		return nil, nil, fmt.Errorf("peercred: %w", err)
+	}
+
+	var (
+		cred    *unix.Ucred
+		credErr error
+	)
+
+	if err = rawConn.Control(func(fd uintptr) {
+		cred, credErr = unix.GetsockoptUcred(int(fd), unix.SOL_SOCKET, unix.SO_PEERCRED)
+	}); err != nil {
+		return nil, nil, fmt.Errorf("peercred: %w", err)
+	}
+
+	if credErr != nil {
+		return nil, nil, fmt.Errorf("peercred: error reading peer credentials: %w", credErr)
+	}
+
+	if !c.allowed(cred.Uid, cred.Gid) {
+		return nil, nil, fmt.Errorf("peercred: access denied for UID %d GID %d (PID %d)", cred.Uid, cred.Gid, cred.Pid)
+	}
+
+	// peer is authenticated, but the transport itself provides no integrity or privacy guarantees
+	return conn, AuthInfo{
+		CommonAuthInfo: credentials.CommonAuthInfo{SecurityLevel: credentials.NoSecurity},
+		PID:            cred.Pid,
+		UID:            cred.Uid,
+		GID:            cred.Gid,
+	}, nil
+}
+
+func (c *Credentials) allowed(uid, gid uint32) bool {
+	uids, gids := c.Allowed()
+
+	return contains(uids, uid) || contains(gids, gid)
+}
+
+func contains(ids []uint32, id uint32) bool {
+	for _, allowed := range ids {
+		if id == allowed {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Info implements credentials.TransportCredentials interface.
+func (c *Credentials) Info() credentials.ProtocolInfo {
+	return credentials.ProtocolInfo{
+		SecurityProtocol: "peercred",
+	}
+}
+
+// Clone implements credentials.TransportCredentials interface.
+func (c *Credentials) Clone() credentials.TransportCredentials {
+	return &Credentials{
+		Allowed: c.Allowed,
+	}
+}
+
+// OverrideServerName implements credentials.TransportCredentials interface.
+func (c *Credentials) OverrideServerName(string) error {
+	return nil
+}
+
+// FromContext returns the peer credentials of the request.
+func FromContext(ctx context.Context) (AuthInfo, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return AuthInfo{}, false
+	}
+
+	info, ok := p.AuthInfo.(AuthInfo)
+
+	return info, ok
+}
+
+// UnaryInterceptor rejects the requests from the peers which are not allowed anymore.
+func (c *Credentials) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := c.authorize(ctx); err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamInterceptor rejects the requests from the peers which are not allowed anymore.
+func (c *Credentials) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := c.authorize(stream.Context()); err != nil {
+			return err
+		}
+
+		return handler(srv, stream)
+	}
+}
+
+func (c *Credentials) authorize(ctx context.Context) error {
+	info, ok := FromContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "peer credentials are not available")
+	}
+
+	if !c.allowed(info.UID, info.GID) {
+		return status.Errorf(codes.PermissionDenied, "access denied for UID %d GID %d (PID %d)", info.UID, info.GID, info.PID)
+	}
+
+	return nil
+}
@@ -0,0 +1,128 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package peercred_test
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/talos-systems/talos/pkg/grpc/middleware/auth/peercred"
+)
+
+func TestServerHandshake(t *testing.T) {
+	listener, err := net.Listen("unix", filepath.Join(t.TempDir(), "test.sock"))
+	require.NoError(t, err)
+
+	defer listener.Close() //nolint:errcheck
+
+	for _, test := range []struct {
+		name        string
+		allowedUIDs []uint32
+		allowedGIDs []uint32
+		expectedErr bool
+	}{
+		{
+			name:        "allowed",
+			allowedUIDs: []uint32{uint32(os.Getuid())},
+		},
+		{
+			name:        "allowed by GID",
+			allowedUIDs: []uint32{uint32(os.Getuid()) + 1},
+			allowedGIDs: []uint32{uint32(os.Getgid())},
+		},
+		{
+			name:        "denied",
+			allowedUIDs: []uint32{uint32(os.Getuid()) + 1},
+			allowedGIDs: []uint32{uint32(os.Getgid()) + 1},
+			expectedErr: true,
+		},
+	} {
+		clientConn, err := net.Dial("unix", listener.Addr().String())
+		require.NoError(t, err)
+
+		serverConn, err := listener.Accept()
+		require.NoError(t, err)
+
+		_, authInfo, err := peercred.NewServerCredentials(func() ([]uint32, []uint32) {
+			return test.allowedUIDs, test.allowedGIDs
+		}).ServerHandshake(serverConn)
+
+		if test.expectedErr {
+			assert.Error(t, err, test.name)
+		} else {
+			require.NoError(t, err, test.name)
+
+			assert.Equal(t, uint32(os.Getuid()), authInfo.(peercred.AuthInfo).UID, test.name)
+			assert.Equal(t, int32(os.Getpid()), authInfo.(peercred.AuthInfo).PID, test.name)
+			assert.Equal(t, credentials.NoSecurity, authInfo.(peercred.AuthInfo).SecurityLevel, test.name)
+		}
+
+		assert.NoError(t, clientConn.Close())
+		assert.NoError(t, serverConn.Close())
+	}
+}
+
+type mockServerStream struct {
+	grpc.ServerStream
+
+	ctx context.Context
+}
+
+func (s mockServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestInterceptors(t *testing.T) {
+	allowedUIDs := []uint32{1000}
+
+	creds := peercred.NewServerCredentials(func() ([]uint32, []uint32) {
+		return allowedUIDs, nil
+	})
+
+	unary := func(ctx context.Context) error {
+		_, err := creds.UnaryInterceptor()(ctx, nil, &grpc.UnaryServerInfo{}, func(context.Context, interface{}) (interface{}, error) {
+			return nil, nil
+		})
+
+		return err
+	}
+
+	stream := func(ctx context.Context) error {
+		return creds.StreamInterceptor()(nil, mockServerStream{ctx: ctx}, &grpc.StreamServerInfo{}, func(interface{}, grpc.ServerStream) error {
+			return nil
+		})
+	}
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: peercred.AuthInfo{
+			PID: 1234,
+			UID: 1000,
+			GID: 1000,
+		},
+	})
+
+	assert.NoError(t, unary(ctx))
+	assert.NoError(t, stream(ctx))
+
+	// allowlist is updated, the requests over the established connections are rejected
+	allowedUIDs = nil
+
+	assert.Equal(t, codes.PermissionDenied, status.Code(unary(ctx)))
+	assert.Equal(t, codes.PermissionDenied, status.Code(stream(ctx)))
+
+	assert.Equal(t, codes.Unauthenticated, status.Code(unary(context.Background())))
+	assert.Equal(t, codes.Unauthenticated, status.Code(stream(context.Background())))
+}
@@ -0,0 +1,183 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package imager builds Talos installation media (ISO, disk images, PXE artifacts)
+// from the kernel, initramfs, extensions and kernel args described by the profile.
+package imager
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/talos-systems/go-procfs/procfs"
+
+	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime/v1alpha1/platform"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+	"github.com/talos-systems/talos/pkg/machinery/kernel"
+)
+
+// Imager builds the installation media described by the profile.
+type Imager struct {
+	prof Profile
+
+	tempDir string
+
+	kernelPath    string
+	initramfsPath string
+	cmdline       string
+}
+
+// New validates the profile and initializes the Imager.
+func New(prof Profile) (*Imager, error) {
+	prof.FillDefaults()
+
+	if err := prof.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid profile: %w", err)
+	}
+
+	return &Imager{
+		prof: prof,
+	}, nil
+}
+
+// Profile returns the profile with the defaults filled in.
+func (i *Imager) Profile() Profile {
+	return i.prof
+}
+
+// Execute builds the installation media into the output directory.
+//
+// Artifacts are listed with their checksums in the output manifest, which is written
+// to the output directory as well.
+func (i *Imager) Execute(ctx context.Context, outputDir string) (*Manifest, error) {
+	if err := os.MkdirAll(outputDir, 0o755); err != nil {
+		return nil, err
+	}
+
+	var err error
+
+	i.tempDir, err = ioutil.TempDir("", "imager")
+	if err != nil {
+		return nil, err
+	}
+
+	defer os.RemoveAll(i.tempDir) //nolint:errcheck
+
+	manifest := &Manifest{
+		Profile: i.prof,
+	}
+
+	if manifest.Inputs, err = i.inputs(); err != nil {
+		return nil, err
+	}
+
+	i.kernelPath = i.prof.KernelPath()
+
+	if i.initramfsPath, err = i.buildInitramfs(); err != nil {
+		return nil, fmt.Errorf("error building initramfs: %w", err)
+	}
+
+	if i.cmdline, err = i.buildCmdline(); err != nil {
+		return nil, fmt.Errorf("error building kernel command line: %w", err)
+	}
+
+	manifest.Cmdline = i.cmdline
+
+	if err = ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	log.Printf("building %s for %s/%s", i.prof.Output.Kind, i.prof.Platform, i.prof.Arch)
+
+	var artifacts []string
+
+	switch i.prof.Output.Kind {
+	case OutputISO:
+		artifacts, err = i.outISO(ctx, outputDir)
+	case OutputImage:
+		artifacts, err = i.outImage(ctx, outputDir)
+	case OutputPXE:
+		artifacts, err = i.outPXE(outputDir)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, path := range artifacts {
+		artifact, err := newArtifact(outputDir, path)
+		if err != nil {
+			return nil, err
+		}
+
+		manifest.Artifacts = append(manifest.Artifacts, artifact)
+	}
+
+	if err = manifest.Write(outputDir); err != nil {
+		return nil, err
+	}
+
+	return manifest, nil
+}
+
+// inputs returns the checksums of the kernel, initramfs and extensions.
+func (i *Imager) inputs() ([]Artifact, error) {
+	paths := append([]string{i.prof.KernelPath(), i.prof.InitramfsPath()}, i.prof.ExtensionPaths()...)
+
+	inputs := make([]Artifact, 0, len(paths))
+
+	for _, path := range paths {
+		artifact, err := newArtifact("", path)
+		if err != nil {
+			return nil, err
+		}
+
+		inputs = append(inputs, artifact)
+	}
+
+	return inputs, nil
+}
+
+// buildCmdline builds the kernel command line the same way the installer does for the boot entries.
+func (i *Imager) buildCmdline() (string, error) {
+	p, err := platform.NewPlatform(i.prof.Platform)
+	if err != nil {
+		return "", err
+	}
+
+	cmdline := procfs.NewCmdline("")
+	cmdline.Append(constants.KernelParamPlatform, p.Name())
+
+	if i.prof.ConfigSource != "" {
+		cmdline.Append(constants.KernelParamConfig, i.prof.ConfigSource)
+	}
+
+	cmdline.SetAll(p.KernelArgs().Strings())
+
+	if err = cmdline.AppendAll(kernel.DefaultArgs); err != nil {
+		return "", err
+	}
+
+	extraKernelArgs, removed := kernel.SplitRemovedArgs(i.prof.ExtraKernelArgs)
+
+	if err = cmdline.AppendAll(extraKernelArgs, procfs.WithOverwriteArgs("console")); err != nil {
+		return "", err
+	}
+
+	b, err := i.prof.board()
+	if err != nil {
+		return "", err
+	}
+
+	if b != nil {
+		cmdline.Append(constants.KernelParamBoard, b.Name())
+		cmdline.SetAll(b.KernelArgs().Strings())
+	}
+
+	return strings.Join(kernel.RemoveArgs(cmdline.Strings(), removed), " "), nil
+}
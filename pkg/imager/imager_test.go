@@ -0,0 +1,189 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package imager_test
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/pkg/imager"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+)
+
+func TestProfileValidate(t *testing.T) {
+	for _, tt := range []struct {
+		name          string
+		profile       imager.Profile
+		expectedError string
+	}{
+		{
+			name: "pxe",
+			profile: imager.Profile{
+				Platform: "metal",
+				Arch:     "arm64",
+				Output: imager.Output{
+					Kind: imager.OutputPXE,
+				},
+			},
+		},
+		{
+			name: "unknown",
+			profile: imager.Profile{
+				Platform: "mainframe",
+				Arch:     "s390x",
+				Output: imager.Output{
+					Kind: "floppy",
+				},
+			},
+			expectedError: "3 errors occurred:\n\t* unknown platform: \"mainframe\"\n\t* unsupported arch \"s390x\"\n\t* unknown output kind \"floppy\"\n\n",
+		},
+		{
+			name: "image format",
+			profile: imager.Profile{
+				Platform: "aws",
+				Output: imager.Output{
+					Kind:        imager.OutputPXE,
+					ImageFormat: imager.ImageFormatQCOW2,
+					ImageCache:  "/cache",
+				},
+			},
+			expectedError: "2 errors occurred:\n\t* image format is supported only for image output\n\t* image cache is supported only for iso output\n\n",
+		},
+		{
+			name: "ova",
+			profile: imager.Profile{
+				Platform: "aws",
+				Output: imager.Output{
+					Kind:        imager.OutputImage,
+					ImageFormat: imager.ImageFormatOVA,
+				},
+			},
+			expectedError: "1 error occurred:\n\t* ova image format is supported only for vmware platform\n\n",
+		},
+		{
+			name: "pxe base url",
+			profile: imager.Profile{
+				Platform: "metal",
+				Output: imager.Output{
+					Kind:       imager.OutputPXE,
+					PXEBaseURL: "ftp://192.168.1.1/",
+				},
+			},
+			expectedError: "1 error occurred:\n\t* invalid PXE base URL \"ftp://192.168.1.1/\"\n\n",
+		},
+		{
+			name: "board",
+			profile: imager.Profile{
+				Platform: "aws",
+				Board:    constants.BoardRPi4,
+				Output: imager.Output{
+					Kind: imager.OutputImage,
+				},
+			},
+			expectedError: "1 error occurred:\n\t* board is supported only for metal image output\n\n",
+		},
+	} {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			tt.profile.FillDefaults()
+
+			err := tt.profile.Validate()
+
+			if tt.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedError)
+			}
+		})
+	}
+}
+
+func TestProfileDefaults(t *testing.T) {
+	prof := imager.Profile{
+		Platform: "azure",
+		Arch:     "arm64",
+		Kernel:   "_out/" + constants.KernelAssetWithArch,
+		Output: imager.Output{
+			Kind: imager.OutputImage,
+		},
+	}
+
+	prof.FillDefaults()
+
+	assert.Equal(t, imager.ImageFormatVHD, prof.Output.ImageFormat)
+	assert.Equal(t, constants.ConfigNone, prof.ConfigSource)
+	assert.Equal(t, constants.BoardNone, prof.Board)
+	assert.Equal(t, "_out/vmlinuz-arm64", prof.KernelPath())
+	assert.Equal(t, constants.InitramfsAssetPath, prof.InitramfsPath())
+}
+
+func TestExecutePXE(t *testing.T) {
+	inputDir := t.TempDir()
+	outputDir := t.TempDir()
+
+	for name, contents := range map[string]string{
+		"vmlinuz":      "kernel",
+		"initramfs.xz": "initramfs",
+		"ext.cpio.xz":  "extension",
+	} {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(inputDir, name), []byte(contents), 0o644))
+	}
+
+	img, err := imager.New(imager.Profile{
+		Platform:        "metal",
+		Arch:            "arm64",
+		Kernel:          filepath.Join(inputDir, "vmlinuz"),
+		Initramfs:       filepath.Join(inputDir, "initramfs.xz"),
+		Extensions:      []string{filepath.Join(inputDir, "ext.cpio.xz")},
+		ExtraKernelArgs: []string{"talos.config=https://example.com/config.yaml", "-pti", "console=ttyAMA0"},
+		Output: imager.Output{
+			Kind:       imager.OutputPXE,
+			PXEBaseURL: "http://192.168.1.1/talos",
+		},
+	})
+	require.NoError(t, err)
+
+	manifest, err := img.Execute(context.Background(), outputDir)
+	require.NoError(t, err)
+
+	assert.Contains(t, manifest.Cmdline, "talos.platform=metal")
+	assert.Contains(t, manifest.Cmdline, "talos.config=https://example.com/config.yaml")
+	assert.Contains(t, manifest.Cmdline, "console=ttyAMA0")
+	assert.NotContains(t, manifest.Cmdline, "console=tty0")
+	assert.NotContains(t, manifest.Cmdline, "pti=")
+
+	assert.Len(t, manifest.Inputs, 3)
+
+	paths := make([]string, 0, len(manifest.Artifacts))
+
+	for _, artifact := range manifest.Artifacts {
+		paths = append(paths, artifact.Path)
+	}
+
+	assert.Equal(t, []string{"vmlinuz-arm64", "initramfs-arm64.xz", "cmdline-arm64", "boot-arm64.ipxe"}, paths)
+
+	initramfs, err := ioutil.ReadFile(filepath.Join(outputDir, "initramfs-arm64.xz"))
+	require.NoError(t, err)
+	assert.Equal(t, "initramfsextension", string(initramfs))
+
+	cmdline, err := ioutil.ReadFile(filepath.Join(outputDir, "cmdline-arm64"))
+	require.NoError(t, err)
+	assert.Equal(t, manifest.Cmdline+"\n", string(cmdline))
+
+	script, err := ioutil.ReadFile(filepath.Join(outputDir, "boot-arm64.ipxe"))
+	require.NoError(t, err)
+	assert.Equal(t, "#!ipxe\nkernel http://192.168.1.1/talos/vmlinuz-arm64 "+manifest.Cmdline+" initrd=initramfs-arm64.xz\ninitrd http://192.168.1.1/talos/initramfs-arm64.xz\nboot\n", string(script))
+
+	// the manifest profile can be used to repeat the build
+	prof, err := imager.LoadProfile(filepath.Join(outputDir, imager.ManifestName))
+	require.NoError(t, err)
+	assert.Equal(t, img.Profile(), *prof)
+}
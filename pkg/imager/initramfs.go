@@ -0,0 +1,77 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package imager
+
+import (
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+)
+
+// buildInitramfs appends the extensions to the initramfs.
+//
+// Kernel unpacks concatenated (compressed) cpio archives in order, so the files
+// of the extensions are layered on top of the base initramfs.
+func (i *Imager) buildInitramfs() (string, error) {
+	extensions := i.prof.ExtensionPaths()
+
+	if len(extensions) == 0 {
+		return i.prof.InitramfsPath(), nil
+	}
+
+	path := filepath.Join(i.tempDir, constants.InitramfsAsset)
+
+	out, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o644)
+	if err != nil {
+		return "", err
+	}
+
+	defer out.Close() //nolint:errcheck
+
+	for _, src := range append([]string{i.prof.InitramfsPath()}, extensions...) {
+		log.Printf("appending %s to initramfs", src)
+
+		if err = appendFile(out, src); err != nil {
+			return "", err
+		}
+	}
+
+	return path, out.Close()
+}
+
+func appendFile(w io.Writer, src string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+
+	defer in.Close() //nolint:errcheck
+
+	_, err = io.Copy(w, in)
+
+	return err
+}
+
+func copyFile(src, dst string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return err
+	}
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+
+	defer out.Close() //nolint:errcheck
+
+	if err = appendFile(out, src); err != nil {
+		return err
+	}
+
+	return out.Close()
+}
@@ -0,0 +1,69 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package imager
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ManifestName is the name of the output manifest in the output directory.
+const ManifestName = "manifest.yaml"
+
+// Manifest describes the inputs and the artifacts of the build.
+//
+// The profile recorded in the manifest can be used to repeat the build.
+type Manifest struct {
+	Profile   Profile    `yaml:"profile"`
+	Cmdline   string     `yaml:"cmdline"`
+	Inputs    []Artifact `yaml:"inputs"`
+	Artifacts []Artifact `yaml:"artifacts"`
+}
+
+// Artifact is a file consumed or produced by the build.
+type Artifact struct {
+	// Path is relative to the output directory for the artifacts.
+	Path   string `yaml:"path"`
+	Size   int64  `yaml:"size"`
+	SHA256 string `yaml:"sha256"`
+}
+
+func newArtifact(dir, path string) (Artifact, error) {
+	f, err := os.Open(filepath.Join(dir, path))
+	if err != nil {
+		return Artifact{}, err
+	}
+
+	defer f.Close() //nolint:errcheck
+
+	hash := sha256.New()
+
+	size, err := io.Copy(hash, f)
+	if err != nil {
+		return Artifact{}, err
+	}
+
+	return Artifact{
+		Path:   path,
+		Size:   size,
+		SHA256: hex.EncodeToString(hash.Sum(nil)),
+	}, nil
+}
+
+// Write writes the manifest to the output directory.
+func (m *Manifest) Write(outputDir string) error {
+	b, err := yaml.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(filepath.Join(outputDir, ManifestName), b, 0o644)
+}
@@ -0,0 +1,287 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package imager
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/talos-systems/go-cmd/pkg/cmd"
+
+	"github.com/talos-systems/talos/cmd/installer/pkg"
+	"github.com/talos-systems/talos/cmd/installer/pkg/install"
+	"github.com/talos-systems/talos/cmd/installer/pkg/ova"
+	"github.com/talos-systems/talos/cmd/installer/pkg/qemuimg"
+	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
+	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime/v1alpha1/platform"
+	"github.com/talos-systems/talos/pkg/copy"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+)
+
+const isoGrubCfgTpl = `set default=0
+set timeout=3
+
+insmod all_video
+
+terminal_input console
+terminal_output console
+
+menuentry "Talos ISO" {
+	set gfxmode=auto
+	set gfxpayload=text
+	linux /boot/{{ .Kernel }} {{ .Cmdline }}
+	initrd /boot/{{ .Initramfs }}
+}
+`
+
+// iPXE expands `${uuid}`, `${serial}` and `${mac}` in the kernel command line with the same values
+// Talos uses for the `talos.config` substitution.
+//
+// `initrd=` is required for the EFI stub to pick up the initramfs.
+const ipxeScriptTpl = `#!ipxe
+kernel {{ .BaseURL }}{{ .Kernel }} {{ .Cmdline }} initrd={{ .Initramfs }}
+initrd {{ .BaseURL }}{{ .Initramfs }}
+boot
+`
+
+// outPXE copies the kernel and the initramfs, writes the kernel command line to be set by the PXE bootloader
+// and the iPXE script booting them.
+func (i *Imager) outPXE(outputDir string) ([]string, error) {
+	kernel := strings.ReplaceAll(constants.KernelAssetWithArch, constants.ArchVariable, i.prof.Arch)
+	initramfs := strings.ReplaceAll(constants.InitramfsAssetWithArch, constants.ArchVariable, i.prof.Arch)
+	cmdline := "cmdline-" + i.prof.Arch
+	script := fmt.Sprintf("boot-%s.ipxe", i.prof.Arch)
+
+	if err := copyFile(i.kernelPath, filepath.Join(outputDir, kernel)); err != nil {
+		return nil, err
+	}
+
+	if err := copyFile(i.initramfsPath, filepath.Join(outputDir, initramfs)); err != nil {
+		return nil, err
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(outputDir, cmdline), []byte(i.cmdline+"\n"), 0o644); err != nil {
+		return nil, err
+	}
+
+	baseURL := i.prof.Output.PXEBaseURL
+	if baseURL != "" && !strings.HasSuffix(baseURL, "/") {
+		baseURL += "/"
+	}
+
+	var buf bytes.Buffer
+
+	if err := template.Must(template.New("ipxe").Parse(ipxeScriptTpl)).Execute(&buf, struct {
+		BaseURL   string
+		Kernel    string
+		Initramfs string
+		Cmdline   string
+	}{
+		BaseURL:   baseURL,
+		Kernel:    kernel,
+		Initramfs: initramfs,
+		Cmdline:   i.cmdline,
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(outputDir, script), buf.Bytes(), 0o644); err != nil {
+		return nil, err
+	}
+
+	return []string{kernel, initramfs, cmdline, script}, nil
+}
+
+// outISO builds the ISO with GRUB booting the kernel with the profile kernel command line.
+func (i *Imager) outISO(ctx context.Context, outputDir string) ([]string, error) {
+	isoDir := filepath.Join(i.tempDir, "iso")
+
+	if err := copyFile(i.kernelPath, filepath.Join(isoDir, "boot", constants.KernelAsset)); err != nil {
+		return nil, err
+	}
+
+	if err := copyFile(i.initramfsPath, filepath.Join(isoDir, "boot", constants.InitramfsAsset)); err != nil {
+		return nil, err
+	}
+
+	if i.prof.Output.ImageCache != "" {
+		log.Printf("copying image cache from %s", i.prof.Output.ImageCache)
+
+		if err := copy.Dir(i.prof.Output.ImageCache, filepath.Join(isoDir, constants.ImageCacheISOPath)); err != nil {
+			return nil, err
+		}
+	}
+
+	var buf bytes.Buffer
+
+	if err := template.Must(template.New("grub").Parse(isoGrubCfgTpl)).Execute(&buf, struct {
+		Kernel    string
+		Initramfs string
+		Cmdline   string
+	}{
+		Kernel:    constants.KernelAsset,
+		Initramfs: constants.InitramfsAsset,
+		Cmdline:   i.cmdline,
+	}); err != nil {
+		return nil, err
+	}
+
+	cfgPath := filepath.Join(isoDir, "boot", "grub", "grub.cfg")
+
+	if err := os.MkdirAll(filepath.Dir(cfgPath), 0o755); err != nil {
+		return nil, err
+	}
+
+	if err := ioutil.WriteFile(cfgPath, buf.Bytes(), 0o644); err != nil {
+		return nil, err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	iso := fmt.Sprintf("talos-%s.iso", i.prof.Arch)
+
+	log.Printf("creating %s", iso)
+
+	if err := pkg.CreateISO(filepath.Join(outputDir, iso), isoDir); err != nil {
+		return nil, err
+	}
+
+	return []string{iso}, nil
+}
+
+// outImage installs Talos to the raw disk image attached to a loop device, and converts
+// the disk image to the requested format.
+//
+//nolint:gocyclo
+func (i *Imager) outImage(ctx context.Context, outputDir string) ([]string, error) {
+	p, err := platform.NewPlatform(i.prof.Platform)
+	if err != nil {
+		return nil, err
+	}
+
+	img := filepath.Join(i.tempDir, "disk.raw")
+
+	if err = createRawDisk(img); err != nil {
+		return nil, err
+	}
+
+	log.Print("attaching loopback device")
+
+	disk, err := pkg.Loattach(img)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = install.Install(p, runtime.SequenceNoop, &install.Options{
+		ConfigSource:    i.prof.ConfigSource,
+		Disk:            disk,
+		Platform:        i.prof.Platform,
+		Board:           i.prof.Board,
+		BoardOverlay:    i.prof.withArch(i.prof.BoardOverlay),
+		ExtraKernelArgs: i.prof.ExtraKernelArgs,
+		Bootloader:      true,
+		KernelPath:      i.kernelPath,
+		InitramfsPath:   i.initramfsPath,
+	}); err != nil {
+		pkg.Lodetach(disk) //nolint:errcheck
+
+		return nil, err
+	}
+
+	log.Print("detaching loopback device")
+
+	if err = pkg.Lodetach(disk); err != nil {
+		return nil, err
+	}
+
+	if err = ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	name := fmt.Sprintf("%s-%s", i.prof.Platform, i.prof.Arch)
+
+	switch i.prof.Output.ImageFormat {
+	case ImageFormatRaw:
+		var b runtime.Board
+
+		if b, err = i.prof.board(); err != nil {
+			return nil, err
+		}
+
+		if b != nil {
+			out := fmt.Sprintf("metal-%s-%s.img", b.Name(), i.prof.Arch)
+
+			if err = os.Rename(img, filepath.Join(outputDir, out)); err != nil {
+				return nil, err
+			}
+
+			log.Println("compressing image")
+
+			if _, err = cmd.RunContext(ctx, "xz", "-0", "-f", filepath.Join(outputDir, out)); err != nil {
+				return nil, err
+			}
+
+			return []string{out + ".xz"}, nil
+		}
+
+		return tarImage(ctx, outputDir, name+".tar.gz", img)
+	case ImageFormatQCOW2:
+		out := name + ".qcow2"
+
+		if err = qemuimg.Convert("raw", "qcow2", "compat=1.1", img, filepath.Join(outputDir, out)); err != nil {
+			return nil, err
+		}
+
+		return []string{out}, nil
+	case ImageFormatVHD:
+		vhd := filepath.Join(i.tempDir, "disk.vhd")
+
+		if err = qemuimg.Convert("raw", "vpc", "subformat=fixed,force_size", img, vhd); err != nil {
+			return nil, err
+		}
+
+		return tarImage(ctx, outputDir, name+".tar.gz", vhd)
+	case ImageFormatOVA:
+		if err = ova.CreateOVAFromRAW("disk", img, outputDir); err != nil {
+			return nil, err
+		}
+
+		return []string{fmt.Sprintf("vmware-%s.ova", i.prof.Arch)}, nil
+	default:
+		return nil, fmt.Errorf("unknown image format %q", i.prof.Output.ImageFormat)
+	}
+}
+
+func createRawDisk(img string) error {
+	f, err := os.Create(img)
+	if err != nil {
+		return err
+	}
+
+	defer f.Close() //nolint:errcheck
+
+	if err = f.Truncate(pkg.RAWDiskSize * 1024 * 1024); err != nil {
+		return fmt.Errorf("failed to create RAW disk: %w", err)
+	}
+
+	return f.Close()
+}
+
+func tarImage(ctx context.Context, outputDir, out, img string) ([]string, error) {
+	if _, err := cmd.RunContext(ctx, "tar", "-czf", filepath.Join(outputDir, out), "-C", filepath.Dir(img), filepath.Base(img)); err != nil {
+		return nil, err
+	}
+
+	return []string{out}, nil
+}
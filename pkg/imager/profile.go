@@ -0,0 +1,251 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package imager
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	goruntime "runtime"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	"gopkg.in/yaml.v3"
+
+	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
+	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime/v1alpha1/board"
+	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime/v1alpha1/platform"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+)
+
+// OutputKind is the kind of the installation media.
+type OutputKind string
+
+// Output kinds.
+const (
+	OutputISO   OutputKind = "iso"
+	OutputImage OutputKind = "image"
+	OutputPXE   OutputKind = "pxe"
+)
+
+// ImageFormat is the format of the disk image.
+type ImageFormat string
+
+// Disk image formats.
+const (
+	ImageFormatRaw   ImageFormat = "raw"
+	ImageFormatQCOW2 ImageFormat = "qcow2"
+	ImageFormatVHD   ImageFormat = "vhd"
+	ImageFormatOVA   ImageFormat = "ova"
+)
+
+// Profile describes the installation media to build.
+//
+// Kernel, initramfs, extension and board overlay paths might contain constants.ArchVariable,
+// which is replaced with the profile arch.
+type Profile struct {
+	// Platform is the value of the `talos.platform` kernel argument.
+	Platform string `yaml:"platform"`
+	// Arch is the architecture of the kernel and initramfs.
+	Arch string `yaml:"arch"`
+	// Board is the single board computer disk image is built for.
+	Board string `yaml:"board,omitempty"`
+	// BoardOverlay is the path to the overlay directory defining the board, it takes precedence over Board.
+	BoardOverlay string `yaml:"boardOverlay,omitempty"`
+	// Kernel is the path to the kernel image.
+	Kernel string `yaml:"kernel"`
+	// Initramfs is the path to the initramfs.
+	Initramfs string `yaml:"initramfs"`
+	// Extensions are paths to the (compressed) cpio archives appended to the initramfs.
+	Extensions []string `yaml:"extensions,omitempty"`
+	// ExtraKernelArgs are appended to (or with the `-` prefix removed from) the kernel command line.
+	ExtraKernelArgs []string `yaml:"extraKernelArgs,omitempty"`
+	// ConfigSource is the value of the `talos.config` kernel argument.
+	ConfigSource string `yaml:"configSource,omitempty"`
+	// Output describes the installation media.
+	Output Output `yaml:"output"`
+}
+
+// Output describes the kind and the format of the installation media.
+type Output struct {
+	Kind OutputKind `yaml:"kind"`
+	// ImageFormat is the disk image format, defaults to the format expected by the platform.
+	ImageFormat ImageFormat `yaml:"imageFormat,omitempty"`
+	// ImageCache is the path to the OCI image layout bundled into the ISO.
+	ImageCache string `yaml:"imageCache,omitempty"`
+	// PXEBaseURL is the URL the PXE artifacts are served from, used in the iPXE script.
+	//
+	// By default the artifacts are loaded relative to the iPXE script URL.
+	PXEBaseURL string `yaml:"pxeBaseURL,omitempty"`
+}
+
+// LoadProfile loads the profile from the YAML file.
+//
+// The file might be the output manifest of the earlier build, so that the build can be repeated.
+func LoadProfile(path string) (*Profile, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var manifest struct {
+		Profile *Profile `yaml:"profile"`
+	}
+
+	if err = yaml.Unmarshal(b, &manifest); err == nil && manifest.Profile != nil {
+		return manifest.Profile, nil
+	}
+
+	var prof Profile
+
+	if err = yaml.Unmarshal(b, &prof); err != nil {
+		return nil, fmt.Errorf("error parsing profile %q: %w", path, err)
+	}
+
+	return &prof, nil
+}
+
+// FillDefaults fills in the defaults for the unset fields.
+func (p *Profile) FillDefaults() {
+	if p.Arch == "" {
+		p.Arch = goruntime.GOARCH
+	}
+
+	if p.Board == "" {
+		p.Board = constants.BoardNone
+	}
+
+	if p.Kernel == "" {
+		p.Kernel = constants.KernelAssetPath
+	}
+
+	if p.Initramfs == "" {
+		p.Initramfs = constants.InitramfsAssetPath
+	}
+
+	if p.Output.Kind == OutputImage && p.Output.ImageFormat == "" {
+		switch p.Platform {
+		case "azure":
+			p.Output.ImageFormat = ImageFormatVHD
+		case "oracle":
+			p.Output.ImageFormat = ImageFormatQCOW2
+		case "vmware":
+			p.Output.ImageFormat = ImageFormatOVA
+		default:
+			p.Output.ImageFormat = ImageFormatRaw
+		}
+	}
+
+	if p.ConfigSource == "" {
+		switch p.Platform {
+		case "aws", "azure", "digital-ocean", "gcp", "hcloud", "nocloud", "oracle":
+			p.ConfigSource = constants.ConfigNone
+		case "vmware":
+			p.ConfigSource = constants.ConfigGuestInfo
+		}
+	}
+}
+
+// Validate validates the profile.
+//
+//nolint:gocyclo
+func (p *Profile) Validate() error {
+	var result *multierror.Error
+
+	if _, err := platform.NewPlatform(p.Platform); err != nil {
+		result = multierror.Append(result, err)
+	}
+
+	switch p.Arch {
+	case "amd64", "arm64":
+	default:
+		result = multierror.Append(result, fmt.Errorf("unsupported arch %q", p.Arch))
+	}
+
+	switch p.Output.Kind {
+	case OutputISO, OutputImage:
+		// bootloader is installed with the host tools, so the media can be built only for the host arch
+		if p.Arch != goruntime.GOARCH {
+			result = multierror.Append(result, fmt.Errorf("%s can be built only for %s arch", p.Output.Kind, goruntime.GOARCH))
+		}
+	case OutputPXE:
+	default:
+		result = multierror.Append(result, fmt.Errorf("unknown output kind %q", p.Output.Kind))
+	}
+
+	if p.Output.Kind == OutputImage {
+		switch p.Output.ImageFormat {
+		case ImageFormatRaw, ImageFormatQCOW2, ImageFormatVHD, ImageFormatOVA:
+		default:
+			result = multierror.Append(result, fmt.Errorf("unknown image format %q", p.Output.ImageFormat))
+		}
+
+		if p.Output.ImageFormat == ImageFormatOVA && p.Platform != "vmware" {
+			result = multierror.Append(result, fmt.Errorf("%s image format is supported only for vmware platform", ImageFormatOVA))
+		}
+	} else if p.Output.ImageFormat != "" {
+		result = multierror.Append(result, fmt.Errorf("image format is supported only for %s output", OutputImage))
+	}
+
+	if p.Output.ImageCache != "" && p.Output.Kind != OutputISO {
+		result = multierror.Append(result, fmt.Errorf("image cache is supported only for %s output", OutputISO))
+	}
+
+	if p.Output.PXEBaseURL != "" {
+		if p.Output.Kind != OutputPXE {
+			result = multierror.Append(result, fmt.Errorf("PXE base URL is supported only for %s output", OutputPXE))
+		} else if u, err := url.Parse(p.Output.PXEBaseURL); err != nil || (u.Scheme != "http" && u.Scheme != "https" && u.Scheme != "tftp") {
+			result = multierror.Append(result, fmt.Errorf("invalid PXE base URL %q", p.Output.PXEBaseURL))
+		}
+	}
+
+	b, err := p.board()
+	if err != nil {
+		result = multierror.Append(result, err)
+	}
+
+	if (b != nil || err != nil) && (p.Output.Kind != OutputImage || p.Platform != "metal") {
+		result = multierror.Append(result, fmt.Errorf("board is supported only for metal %s output", OutputImage))
+	}
+
+	return result.ErrorOrNil()
+}
+
+// KernelPath returns the path to the kernel image for the profile arch.
+func (p *Profile) KernelPath() string {
+	return p.withArch(p.Kernel)
+}
+
+// InitramfsPath returns the path to the initramfs for the profile arch.
+func (p *Profile) InitramfsPath() string {
+	return p.withArch(p.Initramfs)
+}
+
+// ExtensionPaths returns the paths to the extensions for the profile arch.
+func (p *Profile) ExtensionPaths() []string {
+	paths := make([]string, len(p.Extensions))
+
+	for i := range p.Extensions {
+		paths[i] = p.withArch(p.Extensions[i])
+	}
+
+	return paths
+}
+
+// board returns the board the disk image is built for, or nil if the image is not for a specific board.
+func (p *Profile) board() (runtime.Board, error) {
+	switch {
+	case p.BoardOverlay != "":
+		return board.NewBoardFromOverlay(p.withArch(p.BoardOverlay))
+	case p.Board != "" && p.Board != constants.BoardNone:
+		return board.NewBoard(p.Board)
+	default:
+		return nil, nil
+	}
+}
+
+func (p *Profile) withArch(path string) string {
+	return strings.ReplaceAll(path, constants.ArchVariable, p.Arch)
+}
@@ -366,8 +366,80 @@ func (h *Client) Uncordon(ctx context.Context, name string, force bool) error {
 	return nil
 }
 
+// DeleteNode removes the Node object from Kubernetes.
+//
+// Node which doesn't exist is not considered to be an error.
+func (h *Client) DeleteNode(ctx context.Context, name string) error {
+	return deleteNode(ctx, h.Clientset, name)
+}
+
+func deleteNode(ctx context.Context, clientset kubernetes.Interface, name string) error {
+	err := retry.Exponential(time.Minute, retry.WithUnits(250*time.Millisecond), retry.WithJitter(50*time.Millisecond)).RetryWithContext(ctx, func(ctx context.Context) error {
+		attemptCtx, attemptCtxCancel := context.WithTimeout(ctx, 10*time.Second)
+		defer attemptCtxCancel()
+
+		err := clientset.CoreV1().Nodes().Delete(attemptCtx, name, metav1.DeleteOptions{})
+		if err == nil || apierrors.IsNotFound(err) {
+			return nil
+		}
+
+		if IsRetryableError(err) {
+			return retry.ExpectedError(err)
+		}
+
+		return retry.UnexpectedError(err)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete node %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// IsLastControlPlaneNode returns true if there are no control plane nodes registered in Kubernetes other than the node.
+func (h *Client) IsLastControlPlaneNode(ctx context.Context, name string) (bool, error) {
+	return isLastControlPlaneNode(ctx, h.Clientset, name)
+}
+
+func isLastControlPlaneNode(ctx context.Context, clientset kubernetes.Interface, name string) (bool, error) {
+	nodes, err := clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return false, fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	for _, node := range nodes.Items {
+		if node.Name == name {
+			continue
+		}
+
+		_, labelMaster := node.Labels[constants.LabelNodeRoleMaster]
+		_, labelControlPlane := node.Labels[constants.LabelNodeRoleControlPlane]
+
+		if labelMaster || labelControlPlane {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
 // Drain evicts all pods on a given node.
+//
+// Eviction failures are logged, but not returned.
 func (h *Client) Drain(ctx context.Context, node string) error {
+	return drain(ctx, h.Clientset, node, false)
+}
+
+// DrainStrict evicts all pods on a given node, returning an error if any of the pods can't be evicted.
+//
+// Evictions respect PodDisruptionBudgets, so the drain fails if the budgets don't allow
+// the pods to be evicted within DrainTimeout.
+func (h *Client) DrainStrict(ctx context.Context, node string) error {
+	return drain(ctx, h.Clientset, node, true)
+}
+
+//nolint:gocyclo
+func drain(ctx context.Context, clientset kubernetes.Interface, node string, strict bool) error {
 	ctx, cancel := context.WithTimeout(ctx, DrainTimeout)
 	defer cancel()
 
@@ -375,7 +447,7 @@ func (h *Client) Drain(ctx context.Context, node string) error {
 		FieldSelector: fields.SelectorFromSet(fields.Set{"spec.nodeName": node}).String(),
 	}
 
-	pods, err := h.CoreV1().Pods(metav1.NamespaceAll).List(ctx, opts)
+	pods, err := clientset.CoreV1().Pods(metav1.NamespaceAll).List(ctx, opts)
 	if err != nil {
 		return fmt.Errorf("cannot get pods for node %s: %w", node, err)
 	}
@@ -412,7 +484,11 @@ func (h *Client) Drain(ctx context.Context, node string) error {
 				log.Printf("skipping deleted pod %s/%s\n", p.GetNamespace(), p.GetName())
 			}
 
-			if err := h.evict(ctx, p, int64(60)); err != nil {
+			if err := evict(ctx, clientset, p, int64(60)); err != nil {
+				if strict {
+					return err
+				}
+
 				log.Printf("WARNING: failed to evict pod: %v", err)
 			}
 
@@ -423,23 +499,27 @@ func (h *Client) Drain(ctx context.Context, node string) error {
 	return eg.Wait()
 }
 
-func (h *Client) evict(ctx context.Context, p corev1.Pod, gracePeriod int64) error {
+func evict(ctx context.Context, clientset kubernetes.Interface, p corev1.Pod, gracePeriod int64) error {
 	for {
 		pol := &policy.Eviction{
 			ObjectMeta:    metav1.ObjectMeta{Namespace: p.GetNamespace(), Name: p.GetName()},
 			DeleteOptions: &metav1.DeleteOptions{GracePeriodSeconds: &gracePeriod},
 		}
-		err := h.CoreV1().Pods(p.GetNamespace()).Evict(ctx, pol)
+		err := clientset.CoreV1().Pods(p.GetNamespace()).Evict(ctx, pol)
 
 		switch {
 		case apierrors.IsTooManyRequests(err):
-			time.Sleep(5 * time.Second)
+			select {
+			case <-ctx.Done():
+				return fmt.Errorf("failed to evict pod %s/%s: %w", p.GetNamespace(), p.GetName(), err)
+			case <-time.After(5 * time.Second):
+			}
 		case apierrors.IsNotFound(err):
 			return nil
 		case err != nil:
 			return fmt.Errorf("failed to evict pod %s/%s: %w", p.GetNamespace(), p.GetName(), err)
 		default:
-			if err = h.waitForPodDeleted(ctx, &p); err != nil {
+			if err = waitForPodDeleted(ctx, clientset, &p); err != nil {
 				return fmt.Errorf("failed waiting on pod %s/%s to be deleted: %w", p.GetNamespace(), p.GetName(), err)
 			}
 
@@ -448,9 +528,9 @@ func (h *Client) evict(ctx context.Context, p corev1.Pod, gracePeriod int64) err
 	}
 }
 
-func (h *Client) waitForPodDeleted(ctx context.Context, p *corev1.Pod) error {
+func waitForPodDeleted(ctx context.Context, clientset kubernetes.Interface, p *corev1.Pod) error {
 	return retry.Constant(time.Minute, retry.WithUnits(3*time.Second)).RetryWithContext(ctx, func(ctx context.Context) error {
-		pod, err := h.CoreV1().Pods(p.GetNamespace()).Get(ctx, p.GetName(), metav1.GetOptions{})
+		pod, err := clientset.CoreV1().Pods(p.GetNamespace()).Get(ctx, p.GetName(), metav1.GetOptions{})
 		switch {
 		case apierrors.IsNotFound(err):
 			return nil
@@ -0,0 +1,224 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	policy "k8s.io/api/policy/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+)
+
+func node(name string, labels ...string) *corev1.Node {
+	n := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{},
+		},
+	}
+
+	for _, label := range labels {
+		n.Labels[label] = ""
+	}
+
+	return n
+}
+
+func TestDeleteNode(t *testing.T) {
+	clientset := fake.NewSimpleClientset(node("worker-1"), node("worker-2"))
+
+	require.NoError(t, deleteNode(context.Background(), clientset, "worker-1"))
+
+	nodes, err := clientset.CoreV1().Nodes().List(context.Background(), metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, nodes.Items, 1)
+	assert.Equal(t, "worker-2", nodes.Items[0].Name)
+
+	// node which doesn't exist is not an error
+	require.NoError(t, deleteNode(context.Background(), clientset, "worker-1"))
+}
+
+func TestDeleteNodeError(t *testing.T) {
+	clientset := fake.NewSimpleClientset(node("worker-1"))
+
+	clientset.PrependReactor("delete", "nodes", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewForbidden(corev1.Resource("nodes"), "worker-1", errors.New("not allowed"))
+	})
+
+	err := deleteNode(context.Background(), clientset, "worker-1")
+	require.Error(t, err)
+	assert.EqualError(t, err, `failed to delete node worker-1: nodes "worker-1" is forbidden: not allowed`)
+}
+
+func TestIsLastControlPlaneNode(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		nodes    []runtime.Object
+		expected bool
+	}{
+		{
+			name: "single control plane node",
+			nodes: []runtime.Object{
+				node("master-1", constants.LabelNodeRoleMaster, constants.LabelNodeRoleControlPlane),
+				node("worker-1"),
+			},
+			expected: true,
+		},
+		{
+			name: "other master node",
+			nodes: []runtime.Object{
+				node("master-1", constants.LabelNodeRoleMaster, constants.LabelNodeRoleControlPlane),
+				node("master-2", constants.LabelNodeRoleMaster),
+			},
+		},
+		{
+			name: "other control plane node",
+			nodes: []runtime.Object{
+				node("master-1", constants.LabelNodeRoleMaster, constants.LabelNodeRoleControlPlane),
+				node("master-2", constants.LabelNodeRoleControlPlane),
+			},
+		},
+		{
+			name: "node not registered",
+			nodes: []runtime.Object{
+				node("worker-1"),
+			},
+			expected: true,
+		},
+	} {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			last, err := isLastControlPlaneNode(context.Background(), fake.NewSimpleClientset(tt.nodes...), "master-1")
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expected, last)
+		})
+	}
+}
+
+func pod(name, ownerKind string, annotations map[string]string) *corev1.Pod {
+	p := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "kube-system",
+			Name:        name,
+			Annotations: annotations,
+		},
+		Spec: corev1.PodSpec{
+			NodeName: "worker-1",
+		},
+	}
+
+	if ownerKind != "" {
+		controller := true
+
+		p.OwnerReferences = []metav1.OwnerReference{
+			{
+				Kind:       ownerKind,
+				Name:       name + "-owner",
+				Controller: &controller,
+			},
+		}
+	}
+
+	return p
+}
+
+// evictionReactor deletes the evicted pods unless the eviction is blocked by the disruption budget.
+func evictionReactor(clientset *fake.Clientset, blocked string) k8stesting.ReactionFunc {
+	return func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "eviction" {
+			return false, nil, nil
+		}
+
+		eviction := action.(k8stesting.CreateAction).GetObject().(*policy.Eviction)
+
+		if eviction.Name == blocked {
+			return true, nil, apierrors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 0)
+		}
+
+		return true, nil, clientset.Tracker().Delete(corev1.SchemeGroupVersion.WithResource("pods"), eviction.Namespace, eviction.Name)
+	}
+}
+
+func TestDrain(t *testing.T) {
+	for _, tt := range []struct {
+		name          string
+		strict        bool
+		blocked       string
+		expectedPods  []string
+		expectedError string
+	}{
+		{
+			name:         "strict",
+			strict:       true,
+			expectedPods: []string{"debug", "kube-apiserver-worker-1", "kube-proxy-6nlgx"},
+		},
+		{
+			name:          "strict blocked by disruption budget",
+			strict:        true,
+			blocked:       "coredns-2",
+			expectedPods:  []string{"coredns-2", "debug", "kube-apiserver-worker-1", "kube-proxy-6nlgx"},
+			expectedError: "failed to evict pod kube-system/coredns-2",
+		},
+		{
+			name:         "blocked by disruption budget",
+			blocked:      "coredns-2",
+			expectedPods: []string{"coredns-2", "debug", "kube-apiserver-worker-1", "kube-proxy-6nlgx"},
+		},
+	} {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			clientset := fake.NewSimpleClientset(
+				pod("coredns-1", "ReplicaSet", nil),
+				pod("coredns-2", "ReplicaSet", nil),
+				pod("kube-proxy-6nlgx", "DaemonSet", nil),
+				pod("kube-apiserver-worker-1", "Node", map[string]string{corev1.MirrorPodAnnotationKey: "mirror"}),
+				pod("debug", "", nil),
+			)
+
+			clientset.PrependReactor("create", "pods", evictionReactor(clientset, tt.blocked))
+
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+
+			err := drain(ctx, clientset, "worker-1", tt.strict)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+
+			pods, err := clientset.CoreV1().Pods("kube-system").List(context.Background(), metav1.ListOptions{})
+			require.NoError(t, err)
+
+			names := make([]string, 0, len(pods.Items))
+
+			for _, p := range pods.Items {
+				names = append(names, p.Name)
+			}
+
+			sort.Strings(names)
+
+			assert.Equal(t, tt.expectedPods, names)
+		})
+	}
+}
@@ -115,6 +115,57 @@ func (ContainerDriver) EnumDescriptor() ([]byte, []int) {
 	return file_common_common_proto_rawDescGZIP(), []int{1}
 }
 
+type ContainerdNamespace int32
+
+const (
+	ContainerdNamespace_NS_UNKNOWN ContainerdNamespace = 0
+	// Talos system services and images.
+	ContainerdNamespace_NS_SYSTEM ContainerdNamespace = 1
+	// Kubernetes (CRI) pods and images.
+	ContainerdNamespace_NS_CRI ContainerdNamespace = 2
+)
+
+// Enum value maps for ContainerdNamespace.
+var (
+	ContainerdNamespace_name = map[int32]string{
+		0: "NS_UNKNOWN",
+		1: "NS_SYSTEM",
+		2: "NS_CRI",
+	}
+	ContainerdNamespace_value = map[string]int32{
+		"NS_UNKNOWN": 0,
+		"NS_SYSTEM":  1,
+		"NS_CRI":     2,
+	}
+)
+
+func (x ContainerdNamespace) Enum() *ContainerdNamespace {
+	p := new(ContainerdNamespace)
+	*p = x
+	return p
+}
+
+func (x ContainerdNamespace) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ContainerdNamespace) Descriptor() protoreflect.EnumDescriptor {
+	return file_common_common_proto_enumTypes[2].Descriptor()
+}
+
+func (ContainerdNamespace) Type() protoreflect.EnumType {
+	return &file_common_common_proto_enumTypes[2]
+}
+
+func (x ContainerdNamespace) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ContainerdNamespace.Descriptor instead.
+func (ContainerdNamespace) EnumDescriptor() ([]byte, []int) {
+	return file_common_common_proto_rawDescGZIP(), []int{2}
+}
+
 type Error struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -484,11 +535,15 @@ var file_common_common_proto_rawDesc = []byte{
 	0x4c, 0x4f, 0x43, 0x4b, 0x45, 0x44, 0x10, 0x01, 0x2a, 0x2a, 0x0a, 0x0f, 0x43, 0x6f, 0x6e, 0x74,
 	0x61, 0x69, 0x6e, 0x65, 0x72, 0x44, 0x72, 0x69, 0x76, 0x65, 0x72, 0x12, 0x0e, 0x0a, 0x0a, 0x43,
 	0x4f, 0x4e, 0x54, 0x41, 0x49, 0x4e, 0x45, 0x52, 0x44, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x43,
-	0x52, 0x49, 0x10, 0x01, 0x42, 0x39, 0x5a, 0x37, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
-	0x6f, 0x6d, 0x2f, 0x74, 0x61, 0x6c, 0x6f, 0x73, 0x2d, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x73,
-	0x2f, 0x74, 0x61, 0x6c, 0x6f, 0x73, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x6d, 0x61, 0x63, 0x68, 0x69,
-	0x6e, 0x65, 0x72, 0x79, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x62,
-	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x52, 0x49, 0x10, 0x01, 0x2a, 0x40, 0x0a, 0x13, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65,
+	0x72, 0x64, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x0e, 0x0a, 0x0a, 0x4e,
+	0x53, 0x5f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x4e,
+	0x53, 0x5f, 0x53, 0x59, 0x53, 0x54, 0x45, 0x4d, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x4e, 0x53,
+	0x5f, 0x43, 0x52, 0x49, 0x10, 0x02, 0x42, 0x39, 0x5a, 0x37, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x74, 0x61, 0x6c, 0x6f, 0x73, 0x2d, 0x73, 0x79, 0x73, 0x74, 0x65,
+	0x6d, 0x73, 0x2f, 0x74, 0x61, 0x6c, 0x6f, 0x73, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x6d, 0x61, 0x63,
+	0x68, 0x69, 0x6e, 0x65, 0x72, 0x79, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f,
+	0x6e, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -504,35 +559,36 @@ func file_common_common_proto_rawDescGZIP() []byte {
 }
 
 var (
-	file_common_common_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+	file_common_common_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
 	file_common_common_proto_msgTypes  = make([]protoimpl.MessageInfo, 6)
 	file_common_common_proto_goTypes   = []interface{}{
-		(Code)(0),             // 0: common.Code
-		(ContainerDriver)(0),  // 1: common.ContainerDriver
-		(*Error)(nil),         // 2: common.Error
-		(*Metadata)(nil),      // 3: common.Metadata
-		(*Data)(nil),          // 4: common.Data
-		(*DataResponse)(nil),  // 5: common.DataResponse
-		(*Empty)(nil),         // 6: common.Empty
-		(*EmptyResponse)(nil), // 7: common.EmptyResponse
-		(*anypb.Any)(nil),     // 8: google.protobuf.Any
-		(*status.Status)(nil), // 9: google.rpc.Status
+		(Code)(0),                // 0: common.Code
+		(ContainerDriver)(0),     // 1: common.ContainerDriver
+		(ContainerdNamespace)(0), // 2: common.ContainerdNamespace
+		(*Error)(nil),            // 3: common.Error
+		(*Metadata)(nil),         // 4: common.Metadata
+		(*Data)(nil),             // 5: common.Data
+		(*DataResponse)(nil),     // 6: common.DataResponse
+		(*Empty)(nil),            // 7: common.Empty
+		(*EmptyResponse)(nil),    // 8: common.EmptyResponse
+		(*anypb.Any)(nil),        // 9: google.protobuf.Any
+		(*status.Status)(nil),    // 10: google.rpc.Status
 	}
 )
 
 var file_common_common_proto_depIdxs = []int32{
-	0, // 0: common.Error.code:type_name -> common.Code
-	8, // 1: common.Error.details:type_name -> google.protobuf.Any
-	9, // 2: common.Metadata.status:type_name -> google.rpc.Status
-	3, // 3: common.Data.metadata:type_name -> common.Metadata
-	4, // 4: common.DataResponse.messages:type_name -> common.Data
-	3, // 5: common.Empty.metadata:type_name -> common.Metadata
-	6, // 6: common.EmptyResponse.messages:type_name -> common.Empty
-	7, // [7:7] is the sub-list for method output_type
-	7, // [7:7] is the sub-list for method input_type
-	7, // [7:7] is the sub-list for extension type_name
-	7, // [7:7] is the sub-list for extension extendee
-	0, // [0:7] is the sub-list for field type_name
+	0,  // 0: common.Error.code:type_name -> common.Code
+	9,  // 1: common.Error.details:type_name -> google.protobuf.Any
+	10, // 2: common.Metadata.status:type_name -> google.rpc.Status
+	4,  // 3: common.Data.metadata:type_name -> common.Metadata
+	5,  // 4: common.DataResponse.messages:type_name -> common.Data
+	4,  // 5: common.Empty.metadata:type_name -> common.Metadata
+	7,  // 6: common.EmptyResponse.messages:type_name -> common.Empty
+	7,  // [7:7] is the sub-list for method output_type
+	7,  // [7:7] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
 }
 
 func init() { file_common_common_proto_init() }
@@ -619,7 +675,7 @@ func file_common_common_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_common_common_proto_rawDesc,
-			NumEnums:      2,
+			NumEnums:      3,
 			NumMessages:   6,
 			NumExtensions: 0,
 			NumServices:   0,
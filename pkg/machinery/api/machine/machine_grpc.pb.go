@@ -23,9 +23,24 @@ const _ = grpc.SupportPackageIsVersion7
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type MachineServiceClient interface {
 	ApplyConfiguration(ctx context.Context, in *ApplyConfigurationRequest, opts ...grpc.CallOption) (*ApplyConfigurationResponse, error)
+	// AuditLog method returns the log of the mutating machine API calls
+	// (who, what, when, from where, with which parameters).
+	AuditLog(ctx context.Context, in *AuditLogRequest, opts ...grpc.CallOption) (*AuditLogResponse, error)
 	Bootstrap(ctx context.Context, in *BootstrapRequest, opts ...grpc.CallOption) (*BootstrapResponse, error)
+	// Cgroups method walks the cgroup hierarchy and returns resource usage
+	// and limits of each cgroup.
+	Cgroups(ctx context.Context, in *CgroupsRequest, opts ...grpc.CallOption) (*CgroupsResponse, error)
 	Containers(ctx context.Context, in *ContainersRequest, opts ...grpc.CallOption) (*ContainersResponse, error)
+	// Cordon method marks the Kubernetes node of the machine as unschedulable.
+	//
+	// Kubernetes API is accessed with the kubelet credentials.
+	Cordon(ctx context.Context, in *CordonRequest, opts ...grpc.CallOption) (*CordonResponse, error)
 	Copy(ctx context.Context, in *CopyRequest, opts ...grpc.CallOption) (MachineService_CopyClient, error)
+	// CopyIn method uploads .tar.gz archive to the node and extracts it
+	// into the destination directory.
+	//
+	// Destination directory should be under one of the allowed paths (/var).
+	CopyIn(ctx context.Context, opts ...grpc.CallOption) (MachineService_CopyInClient, error)
 	CPUInfo(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*CPUInfoResponse, error)
 	DiskStats(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*DiskStatsResponse, error)
 	Dmesg(ctx context.Context, in *DmesgRequest, opts ...grpc.CallOption) (MachineService_DmesgClient, error)
@@ -46,7 +61,23 @@ type MachineServiceClient interface {
 	EtcdSnapshot(ctx context.Context, in *EtcdSnapshotRequest, opts ...grpc.CallOption) (MachineService_EtcdSnapshotClient, error)
 	GenerateConfiguration(ctx context.Context, in *GenerateConfigurationRequest, opts ...grpc.CallOption) (*GenerateConfigurationResponse, error)
 	Hostname(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*HostnameResponse, error)
-	Kubeconfig(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (MachineService_KubeconfigClient, error)
+	// ImageList method lists the images in the containerd namespace.
+	ImageList(ctx context.Context, in *ImageListRequest, opts ...grpc.CallOption) (MachineService_ImageListClient, error)
+	// ImagePull method pulls the image into the containerd namespace
+	// using the registry mirrors and authentication from the machine config.
+	ImagePull(ctx context.Context, in *ImagePullRequest, opts ...grpc.CallOption) (*ImagePullResponse, error)
+	// KernelCmdline method returns the kernel command line of the running kernel
+	// and the one configured for the next boot.
+	KernelCmdline(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*KernelCmdlineResponse, error)
+	// Kubeconfig method returns the admin kubeconfig of the cluster
+	// with the admin certificate issued on each request.
+	Kubeconfig(ctx context.Context, in *KubeconfigRequest, opts ...grpc.CallOption) (MachineService_KubeconfigClient, error)
+	// KubernetesTunnelStream method tunnels the raw TCP stream to the local
+	// Kubernetes API server endpoint of the control plane node.
+	//
+	// Client sends the data written to the connection, server sends back
+	// the data read from the connection.
+	KubernetesTunnelStream(ctx context.Context, opts ...grpc.CallOption) (MachineService_KubernetesTunnelStreamClient, error)
 	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (MachineService_ListClient, error)
 	DiskUsage(ctx context.Context, in *DiskUsageRequest, opts ...grpc.CallOption) (MachineService_DiskUsageClient, error)
 	LoadAvg(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*LoadAvgResponse, error)
@@ -54,21 +85,45 @@ type MachineServiceClient interface {
 	Memory(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*MemoryResponse, error)
 	Mounts(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*MountsResponse, error)
 	NetworkDeviceStats(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*NetworkDeviceStatsResponse, error)
+	// Netstat method returns TCP and UDP socket tables with the owning processes.
+	Netstat(ctx context.Context, in *NetstatRequest, opts ...grpc.CallOption) (*NetstatResponse, error)
+	// PauseReconcile method pauses automatic reconciliation of the cluster state
+	// (manifests, control plane static pods) on the node for the specified duration.
+	//
+	// Zero duration resumes reconciliation.
+	PauseReconcile(ctx context.Context, in *PauseReconcileRequest, opts ...grpc.CallOption) (*PauseReconcileResponse, error)
+	// PacketCapture method runs packet capture on the network interface
+	// and streams back captured packets in pcap format.
+	//
+	// Capture stops when the duration or packet count limit is reached,
+	// or when the client cancels the request.
+	PacketCapture(ctx context.Context, in *PacketCaptureRequest, opts ...grpc.CallOption) (MachineService_PacketCaptureClient, error)
 	Processes(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ProcessesResponse, error)
+	// ProcessDetails method returns detailed information about a single process.
+	ProcessDetails(ctx context.Context, in *ProcessDetailsRequest, opts ...grpc.CallOption) (*ProcessDetailsResponse, error)
 	Read(ctx context.Context, in *ReadRequest, opts ...grpc.CallOption) (MachineService_ReadClient, error)
-	Reboot(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*RebootResponse, error)
+	Reboot(ctx context.Context, in *RebootRequest, opts ...grpc.CallOption) (*RebootResponse, error)
 	Restart(ctx context.Context, in *RestartRequest, opts ...grpc.CallOption) (*RestartResponse, error)
 	Rollback(ctx context.Context, in *RollbackRequest, opts ...grpc.CallOption) (*RollbackResponse, error)
 	Reset(ctx context.Context, in *ResetRequest, opts ...grpc.CallOption) (*ResetResponse, error)
 	Recover(ctx context.Context, in *RecoverRequest, opts ...grpc.CallOption) (*RecoverResponse, error)
 	RemoveBootkubeInitializedKey(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*RemoveBootkubeInitializedKeyResponse, error)
+	// RevokeCertificate adds the client certificate to the revocation list maintained by trustd.
+	//
+	// Revocation list is kept on each control plane node, so the certificate should be revoked on all control plane nodes.
+	RevokeCertificate(ctx context.Context, in *RevokeCertificateRequest, opts ...grpc.CallOption) (*RevokeCertificateResponse, error)
 	ServiceList(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ServiceListResponse, error)
 	ServiceRestart(ctx context.Context, in *ServiceRestartRequest, opts ...grpc.CallOption) (*ServiceRestartResponse, error)
 	ServiceStart(ctx context.Context, in *ServiceStartRequest, opts ...grpc.CallOption) (*ServiceStartResponse, error)
 	ServiceStop(ctx context.Context, in *ServiceStopRequest, opts ...grpc.CallOption) (*ServiceStopResponse, error)
-	Shutdown(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ShutdownResponse, error)
+	Shutdown(ctx context.Context, in *ShutdownRequest, opts ...grpc.CallOption) (*ShutdownResponse, error)
 	Stats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (*StatsResponse, error)
+	// SyncConfiguration writes the active machine configuration to the STATE partition,
+	// overwriting the stored configuration if it drifted.
+	SyncConfiguration(ctx context.Context, in *SyncConfigurationRequest, opts ...grpc.CallOption) (*SyncConfigurationResponse, error)
 	SystemStat(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*SystemStatResponse, error)
+	// Uncordon method marks the Kubernetes node of the machine as schedulable.
+	Uncordon(ctx context.Context, in *UncordonRequest, opts ...grpc.CallOption) (*UncordonResponse, error)
 	Upgrade(ctx context.Context, in *UpgradeRequest, opts ...grpc.CallOption) (*UpgradeResponse, error)
 	Version(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*VersionResponse, error)
 }
@@ -90,6 +145,15 @@ func (c *machineServiceClient) ApplyConfiguration(ctx context.Context, in *Apply
 	return out, nil
 }
 
+func (c *machineServiceClient) AuditLog(ctx context.Context, in *AuditLogRequest, opts ...grpc.CallOption) (*AuditLogResponse, error) {
+	out := new(AuditLogResponse)
+	err := c.cc.Invoke(ctx, "/machine.MachineService/AuditLog", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *machineServiceClient) Bootstrap(ctx context.Context, in *BootstrapRequest, opts ...grpc.CallOption) (*BootstrapResponse, error) {
 	out := new(BootstrapResponse)
 	err := c.cc.Invoke(ctx, "/machine.MachineService/Bootstrap", in, out, opts...)
@@ -99,6 +163,15 @@ func (c *machineServiceClient) Bootstrap(ctx context.Context, in *BootstrapReque
 	return out, nil
 }
 
+func (c *machineServiceClient) Cgroups(ctx context.Context, in *CgroupsRequest, opts ...grpc.CallOption) (*CgroupsResponse, error) {
+	out := new(CgroupsResponse)
+	err := c.cc.Invoke(ctx, "/machine.MachineService/Cgroups", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *machineServiceClient) Containers(ctx context.Context, in *ContainersRequest, opts ...grpc.CallOption) (*ContainersResponse, error) {
 	out := new(ContainersResponse)
 	err := c.cc.Invoke(ctx, "/machine.MachineService/Containers", in, out, opts...)
@@ -108,6 +181,15 @@ func (c *machineServiceClient) Containers(ctx context.Context, in *ContainersReq
 	return out, nil
 }
 
+func (c *machineServiceClient) Cordon(ctx context.Context, in *CordonRequest, opts ...grpc.CallOption) (*CordonResponse, error) {
+	out := new(CordonResponse)
+	err := c.cc.Invoke(ctx, "/machine.MachineService/Cordon", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *machineServiceClient) Copy(ctx context.Context, in *CopyRequest, opts ...grpc.CallOption) (MachineService_CopyClient, error) {
 	stream, err := c.cc.NewStream(ctx, &MachineService_ServiceDesc.Streams[0], "/machine.MachineService/Copy", opts...)
 	if err != nil {
@@ -140,6 +222,40 @@ func (x *machineServiceCopyClient) Recv() (*common.Data, error) {
 	return m, nil
 }
 
+func (c *machineServiceClient) CopyIn(ctx context.Context, opts ...grpc.CallOption) (MachineService_CopyInClient, error) {
+	stream, err := c.cc.NewStream(ctx, &MachineService_ServiceDesc.Streams[1], "/machine.MachineService/CopyIn", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &machineServiceCopyInClient{stream}
+	return x, nil
+}
+
+type MachineService_CopyInClient interface {
+	Send(*CopyInRequest) error
+	CloseAndRecv() (*CopyInResponse, error)
+	grpc.ClientStream
+}
+
+type machineServiceCopyInClient struct {
+	grpc.ClientStream
+}
+
+func (x *machineServiceCopyInClient) Send(m *CopyInRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *machineServiceCopyInClient) CloseAndRecv() (*CopyInResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(CopyInResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *machineServiceClient) CPUInfo(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*CPUInfoResponse, error) {
 	out := new(CPUInfoResponse)
 	err := c.cc.Invoke(ctx, "/machine.MachineService/CPUInfo", in, out, opts...)
@@ -159,7 +275,7 @@ func (c *machineServiceClient) DiskStats(ctx context.Context, in *emptypb.Empty,
 }
 
 func (c *machineServiceClient) Dmesg(ctx context.Context, in *DmesgRequest, opts ...grpc.CallOption) (MachineService_DmesgClient, error) {
-	stream, err := c.cc.NewStream(ctx, &MachineService_ServiceDesc.Streams[1], "/machine.MachineService/Dmesg", opts...)
+	stream, err := c.cc.NewStream(ctx, &MachineService_ServiceDesc.Streams[2], "/machine.MachineService/Dmesg", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -191,7 +307,7 @@ func (x *machineServiceDmesgClient) Recv() (*common.Data, error) {
 }
 
 func (c *machineServiceClient) Events(ctx context.Context, in *EventsRequest, opts ...grpc.CallOption) (MachineService_EventsClient, error) {
-	stream, err := c.cc.NewStream(ctx, &MachineService_ServiceDesc.Streams[2], "/machine.MachineService/Events", opts...)
+	stream, err := c.cc.NewStream(ctx, &MachineService_ServiceDesc.Streams[3], "/machine.MachineService/Events", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -259,7 +375,7 @@ func (c *machineServiceClient) EtcdForfeitLeadership(ctx context.Context, in *Et
 }
 
 func (c *machineServiceClient) EtcdRecover(ctx context.Context, opts ...grpc.CallOption) (MachineService_EtcdRecoverClient, error) {
-	stream, err := c.cc.NewStream(ctx, &MachineService_ServiceDesc.Streams[3], "/machine.MachineService/EtcdRecover", opts...)
+	stream, err := c.cc.NewStream(ctx, &MachineService_ServiceDesc.Streams[4], "/machine.MachineService/EtcdRecover", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -293,7 +409,7 @@ func (x *machineServiceEtcdRecoverClient) CloseAndRecv() (*EtcdRecoverResponse,
 }
 
 func (c *machineServiceClient) EtcdSnapshot(ctx context.Context, in *EtcdSnapshotRequest, opts ...grpc.CallOption) (MachineService_EtcdSnapshotClient, error) {
-	stream, err := c.cc.NewStream(ctx, &MachineService_ServiceDesc.Streams[4], "/machine.MachineService/EtcdSnapshot", opts...)
+	stream, err := c.cc.NewStream(ctx, &MachineService_ServiceDesc.Streams[5], "/machine.MachineService/EtcdSnapshot", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -342,8 +458,58 @@ func (c *machineServiceClient) Hostname(ctx context.Context, in *emptypb.Empty,
 	return out, nil
 }
 
-func (c *machineServiceClient) Kubeconfig(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (MachineService_KubeconfigClient, error) {
-	stream, err := c.cc.NewStream(ctx, &MachineService_ServiceDesc.Streams[5], "/machine.MachineService/Kubeconfig", opts...)
+func (c *machineServiceClient) ImageList(ctx context.Context, in *ImageListRequest, opts ...grpc.CallOption) (MachineService_ImageListClient, error) {
+	stream, err := c.cc.NewStream(ctx, &MachineService_ServiceDesc.Streams[6], "/machine.MachineService/ImageList", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &machineServiceImageListClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type MachineService_ImageListClient interface {
+	Recv() (*ImageListResponse, error)
+	grpc.ClientStream
+}
+
+type machineServiceImageListClient struct {
+	grpc.ClientStream
+}
+
+func (x *machineServiceImageListClient) Recv() (*ImageListResponse, error) {
+	m := new(ImageListResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *machineServiceClient) ImagePull(ctx context.Context, in *ImagePullRequest, opts ...grpc.CallOption) (*ImagePullResponse, error) {
+	out := new(ImagePullResponse)
+	err := c.cc.Invoke(ctx, "/machine.MachineService/ImagePull", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *machineServiceClient) KernelCmdline(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*KernelCmdlineResponse, error) {
+	out := new(KernelCmdlineResponse)
+	err := c.cc.Invoke(ctx, "/machine.MachineService/KernelCmdline", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *machineServiceClient) Kubeconfig(ctx context.Context, in *KubeconfigRequest, opts ...grpc.CallOption) (MachineService_KubeconfigClient, error) {
+	stream, err := c.cc.NewStream(ctx, &MachineService_ServiceDesc.Streams[7], "/machine.MachineService/Kubeconfig", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -374,8 +540,39 @@ func (x *machineServiceKubeconfigClient) Recv() (*common.Data, error) {
 	return m, nil
 }
 
+func (c *machineServiceClient) KubernetesTunnelStream(ctx context.Context, opts ...grpc.CallOption) (MachineService_KubernetesTunnelStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &MachineService_ServiceDesc.Streams[8], "/machine.MachineService/KubernetesTunnelStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &machineServiceKubernetesTunnelStreamClient{stream}
+	return x, nil
+}
+
+type MachineService_KubernetesTunnelStreamClient interface {
+	Send(*common.Data) error
+	Recv() (*common.Data, error)
+	grpc.ClientStream
+}
+
+type machineServiceKubernetesTunnelStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *machineServiceKubernetesTunnelStreamClient) Send(m *common.Data) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *machineServiceKubernetesTunnelStreamClient) Recv() (*common.Data, error) {
+	m := new(common.Data)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *machineServiceClient) List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (MachineService_ListClient, error) {
-	stream, err := c.cc.NewStream(ctx, &MachineService_ServiceDesc.Streams[6], "/machine.MachineService/List", opts...)
+	stream, err := c.cc.NewStream(ctx, &MachineService_ServiceDesc.Streams[9], "/machine.MachineService/List", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -407,7 +604,7 @@ func (x *machineServiceListClient) Recv() (*FileInfo, error) {
 }
 
 func (c *machineServiceClient) DiskUsage(ctx context.Context, in *DiskUsageRequest, opts ...grpc.CallOption) (MachineService_DiskUsageClient, error) {
-	stream, err := c.cc.NewStream(ctx, &MachineService_ServiceDesc.Streams[7], "/machine.MachineService/DiskUsage", opts...)
+	stream, err := c.cc.NewStream(ctx, &MachineService_ServiceDesc.Streams[10], "/machine.MachineService/DiskUsage", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -448,7 +645,7 @@ func (c *machineServiceClient) LoadAvg(ctx context.Context, in *emptypb.Empty, o
 }
 
 func (c *machineServiceClient) Logs(ctx context.Context, in *LogsRequest, opts ...grpc.CallOption) (MachineService_LogsClient, error) {
-	stream, err := c.cc.NewStream(ctx, &MachineService_ServiceDesc.Streams[8], "/machine.MachineService/Logs", opts...)
+	stream, err := c.cc.NewStream(ctx, &MachineService_ServiceDesc.Streams[11], "/machine.MachineService/Logs", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -506,6 +703,56 @@ func (c *machineServiceClient) NetworkDeviceStats(ctx context.Context, in *empty
 	return out, nil
 }
 
+func (c *machineServiceClient) Netstat(ctx context.Context, in *NetstatRequest, opts ...grpc.CallOption) (*NetstatResponse, error) {
+	out := new(NetstatResponse)
+	err := c.cc.Invoke(ctx, "/machine.MachineService/Netstat", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *machineServiceClient) PauseReconcile(ctx context.Context, in *PauseReconcileRequest, opts ...grpc.CallOption) (*PauseReconcileResponse, error) {
+	out := new(PauseReconcileResponse)
+	err := c.cc.Invoke(ctx, "/machine.MachineService/PauseReconcile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *machineServiceClient) PacketCapture(ctx context.Context, in *PacketCaptureRequest, opts ...grpc.CallOption) (MachineService_PacketCaptureClient, error) {
+	stream, err := c.cc.NewStream(ctx, &MachineService_ServiceDesc.Streams[12], "/machine.MachineService/PacketCapture", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &machineServicePacketCaptureClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type MachineService_PacketCaptureClient interface {
+	Recv() (*common.Data, error)
+	grpc.ClientStream
+}
+
+type machineServicePacketCaptureClient struct {
+	grpc.ClientStream
+}
+
+func (x *machineServicePacketCaptureClient) Recv() (*common.Data, error) {
+	m := new(common.Data)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *machineServiceClient) Processes(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ProcessesResponse, error) {
 	out := new(ProcessesResponse)
 	err := c.cc.Invoke(ctx, "/machine.MachineService/Processes", in, out, opts...)
@@ -515,8 +762,17 @@ func (c *machineServiceClient) Processes(ctx context.Context, in *emptypb.Empty,
 	return out, nil
 }
 
+func (c *machineServiceClient) ProcessDetails(ctx context.Context, in *ProcessDetailsRequest, opts ...grpc.CallOption) (*ProcessDetailsResponse, error) {
+	out := new(ProcessDetailsResponse)
+	err := c.cc.Invoke(ctx, "/machine.MachineService/ProcessDetails", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *machineServiceClient) Read(ctx context.Context, in *ReadRequest, opts ...grpc.CallOption) (MachineService_ReadClient, error) {
-	stream, err := c.cc.NewStream(ctx, &MachineService_ServiceDesc.Streams[9], "/machine.MachineService/Read", opts...)
+	stream, err := c.cc.NewStream(ctx, &MachineService_ServiceDesc.Streams[13], "/machine.MachineService/Read", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -547,7 +803,7 @@ func (x *machineServiceReadClient) Recv() (*common.Data, error) {
 	return m, nil
 }
 
-func (c *machineServiceClient) Reboot(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*RebootResponse, error) {
+func (c *machineServiceClient) Reboot(ctx context.Context, in *RebootRequest, opts ...grpc.CallOption) (*RebootResponse, error) {
 	out := new(RebootResponse)
 	err := c.cc.Invoke(ctx, "/machine.MachineService/Reboot", in, out, opts...)
 	if err != nil {
@@ -601,6 +857,15 @@ func (c *machineServiceClient) RemoveBootkubeInitializedKey(ctx context.Context,
 	return out, nil
 }
 
+func (c *machineServiceClient) RevokeCertificate(ctx context.Context, in *RevokeCertificateRequest, opts ...grpc.CallOption) (*RevokeCertificateResponse, error) {
+	out := new(RevokeCertificateResponse)
+	err := c.cc.Invoke(ctx, "/machine.MachineService/RevokeCertificate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *machineServiceClient) ServiceList(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ServiceListResponse, error) {
 	out := new(ServiceListResponse)
 	err := c.cc.Invoke(ctx, "/machine.MachineService/ServiceList", in, out, opts...)
@@ -637,7 +902,7 @@ func (c *machineServiceClient) ServiceStop(ctx context.Context, in *ServiceStopR
 	return out, nil
 }
 
-func (c *machineServiceClient) Shutdown(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ShutdownResponse, error) {
+func (c *machineServiceClient) Shutdown(ctx context.Context, in *ShutdownRequest, opts ...grpc.CallOption) (*ShutdownResponse, error) {
 	out := new(ShutdownResponse)
 	err := c.cc.Invoke(ctx, "/machine.MachineService/Shutdown", in, out, opts...)
 	if err != nil {
@@ -655,6 +920,15 @@ func (c *machineServiceClient) Stats(ctx context.Context, in *StatsRequest, opts
 	return out, nil
 }
 
+func (c *machineServiceClient) SyncConfiguration(ctx context.Context, in *SyncConfigurationRequest, opts ...grpc.CallOption) (*SyncConfigurationResponse, error) {
+	out := new(SyncConfigurationResponse)
+	err := c.cc.Invoke(ctx, "/machine.MachineService/SyncConfiguration", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *machineServiceClient) SystemStat(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*SystemStatResponse, error) {
 	out := new(SystemStatResponse)
 	err := c.cc.Invoke(ctx, "/machine.MachineService/SystemStat", in, out, opts...)
@@ -664,6 +938,15 @@ func (c *machineServiceClient) SystemStat(ctx context.Context, in *emptypb.Empty
 	return out, nil
 }
 
+func (c *machineServiceClient) Uncordon(ctx context.Context, in *UncordonRequest, opts ...grpc.CallOption) (*UncordonResponse, error) {
+	out := new(UncordonResponse)
+	err := c.cc.Invoke(ctx, "/machine.MachineService/Uncordon", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *machineServiceClient) Upgrade(ctx context.Context, in *UpgradeRequest, opts ...grpc.CallOption) (*UpgradeResponse, error) {
 	out := new(UpgradeResponse)
 	err := c.cc.Invoke(ctx, "/machine.MachineService/Upgrade", in, out, opts...)
@@ -687,9 +970,24 @@ func (c *machineServiceClient) Version(ctx context.Context, in *emptypb.Empty, o
 // for forward compatibility
 type MachineServiceServer interface {
 	ApplyConfiguration(context.Context, *ApplyConfigurationRequest) (*ApplyConfigurationResponse, error)
+	// AuditLog method returns the log of the mutating machine API calls
+	// (who, what, when, from where, with which parameters).
+	AuditLog(context.Context, *AuditLogRequest) (*AuditLogResponse, error)
 	Bootstrap(context.Context, *BootstrapRequest) (*BootstrapResponse, error)
+	// Cgroups method walks the cgroup hierarchy and returns resource usage
+	// and limits of each cgroup.
+	Cgroups(context.Context, *CgroupsRequest) (*CgroupsResponse, error)
 	Containers(context.Context, *ContainersRequest) (*ContainersResponse, error)
+	// Cordon method marks the Kubernetes node of the machine as unschedulable.
+	//
+	// Kubernetes API is accessed with the kubelet credentials.
+	Cordon(context.Context, *CordonRequest) (*CordonResponse, error)
 	Copy(*CopyRequest, MachineService_CopyServer) error
+	// CopyIn method uploads .tar.gz archive to the node and extracts it
+	// into the destination directory.
+	//
+	// Destination directory should be under one of the allowed paths (/var).
+	CopyIn(MachineService_CopyInServer) error
 	CPUInfo(context.Context, *emptypb.Empty) (*CPUInfoResponse, error)
 	DiskStats(context.Context, *emptypb.Empty) (*DiskStatsResponse, error)
 	Dmesg(*DmesgRequest, MachineService_DmesgServer) error
@@ -710,7 +1008,23 @@ type MachineServiceServer interface {
 	EtcdSnapshot(*EtcdSnapshotRequest, MachineService_EtcdSnapshotServer) error
 	GenerateConfiguration(context.Context, *GenerateConfigurationRequest) (*GenerateConfigurationResponse, error)
 	Hostname(context.Context, *emptypb.Empty) (*HostnameResponse, error)
-	Kubeconfig(*emptypb.Empty, MachineService_KubeconfigServer) error
+	// ImageList method lists the images in the containerd namespace.
+	ImageList(*ImageListRequest, MachineService_ImageListServer) error
+	// ImagePull method pulls the image into the containerd namespace
+	// using the registry mirrors and authentication from the machine config.
+	ImagePull(context.Context, *ImagePullRequest) (*ImagePullResponse, error)
+	// KernelCmdline method returns the kernel command line of the running kernel
+	// and the one configured for the next boot.
+	KernelCmdline(context.Context, *emptypb.Empty) (*KernelCmdlineResponse, error)
+	// Kubeconfig method returns the admin kubeconfig of the cluster
+	// with the admin certificate issued on each request.
+	Kubeconfig(*KubeconfigRequest, MachineService_KubeconfigServer) error
+	// KubernetesTunnelStream method tunnels the raw TCP stream to the local
+	// Kubernetes API server endpoint of the control plane node.
+	//
+	// Client sends the data written to the connection, server sends back
+	// the data read from the connection.
+	KubernetesTunnelStream(MachineService_KubernetesTunnelStreamServer) error
 	List(*ListRequest, MachineService_ListServer) error
 	DiskUsage(*DiskUsageRequest, MachineService_DiskUsageServer) error
 	LoadAvg(context.Context, *emptypb.Empty) (*LoadAvgResponse, error)
@@ -718,21 +1032,45 @@ type MachineServiceServer interface {
 	Memory(context.Context, *emptypb.Empty) (*MemoryResponse, error)
 	Mounts(context.Context, *emptypb.Empty) (*MountsResponse, error)
 	NetworkDeviceStats(context.Context, *emptypb.Empty) (*NetworkDeviceStatsResponse, error)
+	// Netstat method returns TCP and UDP socket tables with the owning processes.
+	Netstat(context.Context, *NetstatRequest) (*NetstatResponse, error)
+	// PauseReconcile method pauses automatic reconciliation of the cluster state
+	// (manifests, control plane static pods) on the node for the specified duration.
+	//
+	// Zero duration resumes reconciliation.
+	PauseReconcile(context.Context, *PauseReconcileRequest) (*PauseReconcileResponse, error)
+	// PacketCapture method runs packet capture on the network interface
+	// and streams back captured packets in pcap format.
+	//
+	// Capture stops when the duration or packet count limit is reached,
+	// or when the client cancels the request.
+	PacketCapture(*PacketCaptureRequest, MachineService_PacketCaptureServer) error
 	Processes(context.Context, *emptypb.Empty) (*ProcessesResponse, error)
+	// ProcessDetails method returns detailed information about a single process.
+	ProcessDetails(context.Context, *ProcessDetailsRequest) (*ProcessDetailsResponse, error)
 	Read(*ReadRequest, MachineService_ReadServer) error
-	Reboot(context.Context, *emptypb.Empty) (*RebootResponse, error)
+	Reboot(context.Context, *RebootRequest) (*RebootResponse, error)
 	Restart(context.Context, *RestartRequest) (*RestartResponse, error)
 	Rollback(context.Context, *RollbackRequest) (*RollbackResponse, error)
 	Reset(context.Context, *ResetRequest) (*ResetResponse, error)
 	Recover(context.Context, *RecoverRequest) (*RecoverResponse, error)
 	RemoveBootkubeInitializedKey(context.Context, *emptypb.Empty) (*RemoveBootkubeInitializedKeyResponse, error)
+	// RevokeCertificate adds the client certificate to the revocation list maintained by trustd.
+	//
+	// Revocation list is kept on each control plane node, so the certificate should be revoked on all control plane nodes.
+	RevokeCertificate(context.Context, *RevokeCertificateRequest) (*RevokeCertificateResponse, error)
 	ServiceList(context.Context, *emptypb.Empty) (*ServiceListResponse, error)
 	ServiceRestart(context.Context, *ServiceRestartRequest) (*ServiceRestartResponse, error)
 	ServiceStart(context.Context, *ServiceStartRequest) (*ServiceStartResponse, error)
 	ServiceStop(context.Context, *ServiceStopRequest) (*ServiceStopResponse, error)
-	Shutdown(context.Context, *emptypb.Empty) (*ShutdownResponse, error)
+	Shutdown(context.Context, *ShutdownRequest) (*ShutdownResponse, error)
 	Stats(context.Context, *StatsRequest) (*StatsResponse, error)
+	// SyncConfiguration writes the active machine configuration to the STATE partition,
+	// overwriting the stored configuration if it drifted.
+	SyncConfiguration(context.Context, *SyncConfigurationRequest) (*SyncConfigurationResponse, error)
 	SystemStat(context.Context, *emptypb.Empty) (*SystemStatResponse, error)
+	// Uncordon method marks the Kubernetes node of the machine as schedulable.
+	Uncordon(context.Context, *UncordonRequest) (*UncordonResponse, error)
 	Upgrade(context.Context, *UpgradeRequest) (*UpgradeResponse, error)
 	Version(context.Context, *emptypb.Empty) (*VersionResponse, error)
 	mustEmbedUnimplementedMachineServiceServer()
@@ -746,18 +1084,34 @@ func (UnimplementedMachineServiceServer) ApplyConfiguration(context.Context, *Ap
 	return nil, status.Errorf(codes.Unimplemented, "method ApplyConfiguration not implemented")
 }
 
+func (UnimplementedMachineServiceServer) AuditLog(context.Context, *AuditLogRequest) (*AuditLogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AuditLog not implemented")
+}
+
 func (UnimplementedMachineServiceServer) Bootstrap(context.Context, *BootstrapRequest) (*BootstrapResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Bootstrap not implemented")
 }
 
+func (UnimplementedMachineServiceServer) Cgroups(context.Context, *CgroupsRequest) (*CgroupsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Cgroups not implemented")
+}
+
 func (UnimplementedMachineServiceServer) Containers(context.Context, *ContainersRequest) (*ContainersResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Containers not implemented")
 }
 
+func (UnimplementedMachineServiceServer) Cordon(context.Context, *CordonRequest) (*CordonResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Cordon not implemented")
+}
+
 func (UnimplementedMachineServiceServer) Copy(*CopyRequest, MachineService_CopyServer) error {
 	return status.Errorf(codes.Unimplemented, "method Copy not implemented")
 }
 
+func (UnimplementedMachineServiceServer) CopyIn(MachineService_CopyInServer) error {
+	return status.Errorf(codes.Unimplemented, "method CopyIn not implemented")
+}
+
 func (UnimplementedMachineServiceServer) CPUInfo(context.Context, *emptypb.Empty) (*CPUInfoResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CPUInfo not implemented")
 }
@@ -806,9 +1160,24 @@ func (UnimplementedMachineServiceServer) Hostname(context.Context, *emptypb.Empt
 	return nil, status.Errorf(codes.Unimplemented, "method Hostname not implemented")
 }
 
-func (UnimplementedMachineServiceServer) Kubeconfig(*emptypb.Empty, MachineService_KubeconfigServer) error {
+func (UnimplementedMachineServiceServer) ImageList(*ImageListRequest, MachineService_ImageListServer) error {
+	return status.Errorf(codes.Unimplemented, "method ImageList not implemented")
+}
+
+func (UnimplementedMachineServiceServer) ImagePull(context.Context, *ImagePullRequest) (*ImagePullResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImagePull not implemented")
+}
+
+func (UnimplementedMachineServiceServer) KernelCmdline(context.Context, *emptypb.Empty) (*KernelCmdlineResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method KernelCmdline not implemented")
+}
+
+func (UnimplementedMachineServiceServer) Kubeconfig(*KubeconfigRequest, MachineService_KubeconfigServer) error {
 	return status.Errorf(codes.Unimplemented, "method Kubeconfig not implemented")
 }
+func (UnimplementedMachineServiceServer) KubernetesTunnelStream(MachineService_KubernetesTunnelStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method KubernetesTunnelStream not implemented")
+}
 
 func (UnimplementedMachineServiceServer) List(*ListRequest, MachineService_ListServer) error {
 	return status.Errorf(codes.Unimplemented, "method List not implemented")
@@ -838,15 +1207,31 @@ func (UnimplementedMachineServiceServer) NetworkDeviceStats(context.Context, *em
 	return nil, status.Errorf(codes.Unimplemented, "method NetworkDeviceStats not implemented")
 }
 
+func (UnimplementedMachineServiceServer) Netstat(context.Context, *NetstatRequest) (*NetstatResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Netstat not implemented")
+}
+
+func (UnimplementedMachineServiceServer) PauseReconcile(context.Context, *PauseReconcileRequest) (*PauseReconcileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PauseReconcile not implemented")
+}
+
+func (UnimplementedMachineServiceServer) PacketCapture(*PacketCaptureRequest, MachineService_PacketCaptureServer) error {
+	return status.Errorf(codes.Unimplemented, "method PacketCapture not implemented")
+}
+
 func (UnimplementedMachineServiceServer) Processes(context.Context, *emptypb.Empty) (*ProcessesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Processes not implemented")
 }
 
+func (UnimplementedMachineServiceServer) ProcessDetails(context.Context, *ProcessDetailsRequest) (*ProcessDetailsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProcessDetails not implemented")
+}
+
 func (UnimplementedMachineServiceServer) Read(*ReadRequest, MachineService_ReadServer) error {
 	return status.Errorf(codes.Unimplemented, "method Read not implemented")
 }
 
-func (UnimplementedMachineServiceServer) Reboot(context.Context, *emptypb.Empty) (*RebootResponse, error) {
+func (UnimplementedMachineServiceServer) Reboot(context.Context, *RebootRequest) (*RebootResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Reboot not implemented")
 }
 
@@ -870,6 +1255,10 @@ func (UnimplementedMachineServiceServer) RemoveBootkubeInitializedKey(context.Co
 	return nil, status.Errorf(codes.Unimplemented, "method RemoveBootkubeInitializedKey not implemented")
 }
 
+func (UnimplementedMachineServiceServer) RevokeCertificate(context.Context, *RevokeCertificateRequest) (*RevokeCertificateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeCertificate not implemented")
+}
+
 func (UnimplementedMachineServiceServer) ServiceList(context.Context, *emptypb.Empty) (*ServiceListResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ServiceList not implemented")
 }
@@ -886,7 +1275,7 @@ func (UnimplementedMachineServiceServer) ServiceStop(context.Context, *ServiceSt
 	return nil, status.Errorf(codes.Unimplemented, "method ServiceStop not implemented")
 }
 
-func (UnimplementedMachineServiceServer) Shutdown(context.Context, *emptypb.Empty) (*ShutdownResponse, error) {
+func (UnimplementedMachineServiceServer) Shutdown(context.Context, *ShutdownRequest) (*ShutdownResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Shutdown not implemented")
 }
 
@@ -894,10 +1283,18 @@ func (UnimplementedMachineServiceServer) Stats(context.Context, *StatsRequest) (
 	return nil, status.Errorf(codes.Unimplemented, "method Stats not implemented")
 }
 
+func (UnimplementedMachineServiceServer) SyncConfiguration(context.Context, *SyncConfigurationRequest) (*SyncConfigurationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SyncConfiguration not implemented")
+}
+
 func (UnimplementedMachineServiceServer) SystemStat(context.Context, *emptypb.Empty) (*SystemStatResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SystemStat not implemented")
 }
 
+func (UnimplementedMachineServiceServer) Uncordon(context.Context, *UncordonRequest) (*UncordonResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Uncordon not implemented")
+}
+
 func (UnimplementedMachineServiceServer) Upgrade(context.Context, *UpgradeRequest) (*UpgradeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Upgrade not implemented")
 }
@@ -936,6 +1333,24 @@ func _MachineService_ApplyConfiguration_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MachineService_AuditLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuditLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MachineServiceServer).AuditLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/machine.MachineService/AuditLog",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MachineServiceServer).AuditLog(ctx, req.(*AuditLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MachineService_Bootstrap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(BootstrapRequest)
 	if err := dec(in); err != nil {
@@ -954,6 +1369,24 @@ func _MachineService_Bootstrap_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MachineService_Cgroups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CgroupsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MachineServiceServer).Cgroups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/machine.MachineService/Cgroups",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MachineServiceServer).Cgroups(ctx, req.(*CgroupsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MachineService_Containers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ContainersRequest)
 	if err := dec(in); err != nil {
@@ -972,6 +1405,24 @@ func _MachineService_Containers_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MachineService_Cordon_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CordonRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MachineServiceServer).Cordon(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/machine.MachineService/Cordon",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MachineServiceServer).Cordon(ctx, req.(*CordonRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MachineService_Copy_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(CopyRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -993,6 +1444,32 @@ func (x *machineServiceCopyServer) Send(m *common.Data) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _MachineService_CopyIn_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(MachineServiceServer).CopyIn(&machineServiceCopyInServer{stream})
+}
+
+type MachineService_CopyInServer interface {
+	SendAndClose(*CopyInResponse) error
+	Recv() (*CopyInRequest, error)
+	grpc.ServerStream
+}
+
+type machineServiceCopyInServer struct {
+	grpc.ServerStream
+}
+
+func (x *machineServiceCopyInServer) SendAndClose(m *CopyInResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *machineServiceCopyInServer) Recv() (*CopyInRequest, error) {
+	m := new(CopyInRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func _MachineService_CPUInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
@@ -1226,8 +1703,65 @@ func _MachineService_Hostname_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MachineService_ImageList_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ImageListRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MachineServiceServer).ImageList(m, &machineServiceImageListServer{stream})
+}
+
+type MachineService_ImageListServer interface {
+	Send(*ImageListResponse) error
+	grpc.ServerStream
+}
+
+type machineServiceImageListServer struct {
+	grpc.ServerStream
+}
+
+func (x *machineServiceImageListServer) Send(m *ImageListResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _MachineService_ImagePull_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImagePullRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MachineServiceServer).ImagePull(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/machine.MachineService/ImagePull",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MachineServiceServer).ImagePull(ctx, req.(*ImagePullRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MachineService_KernelCmdline_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MachineServiceServer).KernelCmdline(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/machine.MachineService/KernelCmdline",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MachineServiceServer).KernelCmdline(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MachineService_Kubeconfig_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(emptypb.Empty)
+	m := new(KubeconfigRequest)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
@@ -1247,6 +1781,32 @@ func (x *machineServiceKubeconfigServer) Send(m *common.Data) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _MachineService_KubernetesTunnelStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(MachineServiceServer).KubernetesTunnelStream(&machineServiceKubernetesTunnelStreamServer{stream})
+}
+
+type MachineService_KubernetesTunnelStreamServer interface {
+	Send(*common.Data) error
+	Recv() (*common.Data, error)
+	grpc.ServerStream
+}
+
+type machineServiceKubernetesTunnelStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *machineServiceKubernetesTunnelStreamServer) Send(m *common.Data) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *machineServiceKubernetesTunnelStreamServer) Recv() (*common.Data, error) {
+	m := new(common.Data)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func _MachineService_List_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(ListRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -1382,6 +1942,63 @@ func _MachineService_NetworkDeviceStats_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MachineService_Netstat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NetstatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MachineServiceServer).Netstat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/machine.MachineService/Netstat",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MachineServiceServer).Netstat(ctx, req.(*NetstatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MachineService_PauseReconcile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PauseReconcileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MachineServiceServer).PauseReconcile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/machine.MachineService/PauseReconcile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MachineServiceServer).PauseReconcile(ctx, req.(*PauseReconcileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MachineService_PacketCapture_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(PacketCaptureRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MachineServiceServer).PacketCapture(m, &machineServicePacketCaptureServer{stream})
+}
+
+type MachineService_PacketCaptureServer interface {
+	Send(*common.Data) error
+	grpc.ServerStream
+}
+
+type machineServicePacketCaptureServer struct {
+	grpc.ServerStream
+}
+
+func (x *machineServicePacketCaptureServer) Send(m *common.Data) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _MachineService_Processes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
@@ -1400,6 +2017,24 @@ func _MachineService_Processes_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MachineService_ProcessDetails_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProcessDetailsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MachineServiceServer).ProcessDetails(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/machine.MachineService/ProcessDetails",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MachineServiceServer).ProcessDetails(ctx, req.(*ProcessDetailsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MachineService_Read_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(ReadRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -1422,7 +2057,7 @@ func (x *machineServiceReadServer) Send(m *common.Data) error {
 }
 
 func _MachineService_Reboot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(emptypb.Empty)
+	in := new(RebootRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -1434,7 +2069,7 @@ func _MachineService_Reboot_Handler(srv interface{}, ctx context.Context, dec fu
 		FullMethod: "/machine.MachineService/Reboot",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MachineServiceServer).Reboot(ctx, req.(*emptypb.Empty))
+		return srv.(MachineServiceServer).Reboot(ctx, req.(*RebootRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -1529,6 +2164,24 @@ func _MachineService_RemoveBootkubeInitializedKey_Handler(srv interface{}, ctx c
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MachineService_RevokeCertificate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeCertificateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MachineServiceServer).RevokeCertificate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/machine.MachineService/RevokeCertificate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MachineServiceServer).RevokeCertificate(ctx, req.(*RevokeCertificateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MachineService_ServiceList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
@@ -1602,7 +2255,7 @@ func _MachineService_ServiceStop_Handler(srv interface{}, ctx context.Context, d
 }
 
 func _MachineService_Shutdown_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(emptypb.Empty)
+	in := new(ShutdownRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -1614,7 +2267,7 @@ func _MachineService_Shutdown_Handler(srv interface{}, ctx context.Context, dec
 		FullMethod: "/machine.MachineService/Shutdown",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MachineServiceServer).Shutdown(ctx, req.(*emptypb.Empty))
+		return srv.(MachineServiceServer).Shutdown(ctx, req.(*ShutdownRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -1637,6 +2290,24 @@ func _MachineService_Stats_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MachineService_SyncConfiguration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SyncConfigurationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MachineServiceServer).SyncConfiguration(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/machine.MachineService/SyncConfiguration",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MachineServiceServer).SyncConfiguration(ctx, req.(*SyncConfigurationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MachineService_SystemStat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
@@ -1655,6 +2326,24 @@ func _MachineService_SystemStat_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MachineService_Uncordon_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UncordonRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MachineServiceServer).Uncordon(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/machine.MachineService/Uncordon",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MachineServiceServer).Uncordon(ctx, req.(*UncordonRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MachineService_Upgrade_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UpgradeRequest)
 	if err := dec(in); err != nil {
@@ -1702,14 +2391,26 @@ var MachineService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ApplyConfiguration",
 			Handler:    _MachineService_ApplyConfiguration_Handler,
 		},
+		{
+			MethodName: "AuditLog",
+			Handler:    _MachineService_AuditLog_Handler,
+		},
 		{
 			MethodName: "Bootstrap",
 			Handler:    _MachineService_Bootstrap_Handler,
 		},
+		{
+			MethodName: "Cgroups",
+			Handler:    _MachineService_Cgroups_Handler,
+		},
 		{
 			MethodName: "Containers",
 			Handler:    _MachineService_Containers_Handler,
 		},
+		{
+			MethodName: "Cordon",
+			Handler:    _MachineService_Cordon_Handler,
+		},
 		{
 			MethodName: "CPUInfo",
 			Handler:    _MachineService_CPUInfo_Handler,
@@ -1742,6 +2443,14 @@ var MachineService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Hostname",
 			Handler:    _MachineService_Hostname_Handler,
 		},
+		{
+			MethodName: "ImagePull",
+			Handler:    _MachineService_ImagePull_Handler,
+		},
+		{
+			MethodName: "KernelCmdline",
+			Handler:    _MachineService_KernelCmdline_Handler,
+		},
 		{
 			MethodName: "LoadAvg",
 			Handler:    _MachineService_LoadAvg_Handler,
@@ -1758,10 +2467,22 @@ var MachineService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "NetworkDeviceStats",
 			Handler:    _MachineService_NetworkDeviceStats_Handler,
 		},
+		{
+			MethodName: "Netstat",
+			Handler:    _MachineService_Netstat_Handler,
+		},
+		{
+			MethodName: "PauseReconcile",
+			Handler:    _MachineService_PauseReconcile_Handler,
+		},
 		{
 			MethodName: "Processes",
 			Handler:    _MachineService_Processes_Handler,
 		},
+		{
+			MethodName: "ProcessDetails",
+			Handler:    _MachineService_ProcessDetails_Handler,
+		},
 		{
 			MethodName: "Reboot",
 			Handler:    _MachineService_Reboot_Handler,
@@ -1786,6 +2507,10 @@ var MachineService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RemoveBootkubeInitializedKey",
 			Handler:    _MachineService_RemoveBootkubeInitializedKey_Handler,
 		},
+		{
+			MethodName: "RevokeCertificate",
+			Handler:    _MachineService_RevokeCertificate_Handler,
+		},
 		{
 			MethodName: "ServiceList",
 			Handler:    _MachineService_ServiceList_Handler,
@@ -1810,10 +2535,18 @@ var MachineService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Stats",
 			Handler:    _MachineService_Stats_Handler,
 		},
+		{
+			MethodName: "SyncConfiguration",
+			Handler:    _MachineService_SyncConfiguration_Handler,
+		},
 		{
 			MethodName: "SystemStat",
 			Handler:    _MachineService_SystemStat_Handler,
 		},
+		{
+			MethodName: "Uncordon",
+			Handler:    _MachineService_Uncordon_Handler,
+		},
 		{
 			MethodName: "Upgrade",
 			Handler:    _MachineService_Upgrade_Handler,
@@ -1829,6 +2562,11 @@ var MachineService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _MachineService_Copy_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "CopyIn",
+			Handler:       _MachineService_CopyIn_Handler,
+			ClientStreams: true,
+		},
 		{
 			StreamName:    "Dmesg",
 			Handler:       _MachineService_Dmesg_Handler,
@@ -1849,11 +2587,22 @@ var MachineService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _MachineService_EtcdSnapshot_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "ImageList",
+			Handler:       _MachineService_ImageList_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "Kubeconfig",
 			Handler:       _MachineService_Kubeconfig_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "KubernetesTunnelStream",
+			Handler:       _MachineService_KubernetesTunnelStream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 		{
 			StreamName:    "List",
 			Handler:       _MachineService_List_Handler,
@@ -1869,6 +2618,11 @@ var MachineService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _MachineService_Logs_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "PacketCapture",
+			Handler:       _MachineService_PacketCapture_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "Read",
 			Handler:       _MachineService_Read_Handler,
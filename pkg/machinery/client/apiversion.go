@@ -0,0 +1,139 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+)
+
+// UnsupportedAPIError is returned when the server doesn't implement the method called by the client.
+//
+// Usually it means that the server runs an older version of Talos, so the client might fall back
+// to the older API.
+type UnsupportedAPIError struct {
+	Method string
+	// ServerAPIVersion is zero if the server doesn't report the API version.
+	ServerAPIVersion int
+	Err              error
+}
+
+// unsupportedAPIPrefix is the prefix of the error messages returned by the servers aware of the API versioning.
+const unsupportedAPIPrefix = "unsupported API:"
+
+func (e *UnsupportedAPIError) Error() string {
+	// newer servers return detailed errors themselves
+	if msg := status.Convert(e.Err).Message(); strings.HasPrefix(msg, unsupportedAPIPrefix) {
+		return msg
+	}
+
+	if e.ServerAPIVersion == 0 {
+		return fmt.Sprintf("unsupported API: method %s is not supported by the server (server doesn't report API version, it probably runs an older version of Talos, client API version %d): %s",
+			e.Method, constants.APIVersion, status.Convert(e.Err).Message())
+	}
+
+	return fmt.Sprintf("unsupported API: method %s is not supported by the server (server API version %d, client API version %d): %s",
+		e.Method, e.ServerAPIVersion, constants.APIVersion, status.Convert(e.Err).Message())
+}
+
+func (e *UnsupportedAPIError) Unwrap() error {
+	return e.Err
+}
+
+// GRPCStatus implements the interface used by grpc status package, so that the error code is preserved.
+func (e *UnsupportedAPIError) GRPCStatus() *status.Status {
+	return status.New(codes.Unimplemented, e.Error())
+}
+
+// IsUnsupportedAPI checks whether the error is caused by the server not supporting the API method.
+func IsUnsupportedAPI(err error) bool {
+	var apiErr *UnsupportedAPIError
+
+	return errors.As(err, &apiErr)
+}
+
+// ServerAPIVersion returns the API version reported by the server in the response headers.
+//
+// Zero is returned if the server doesn't report the API version.
+func ServerAPIVersion(md metadata.MD) int {
+	values := md.Get(constants.APIVersionMetadataKey)
+	if len(values) == 0 {
+		return 0
+	}
+
+	version, err := strconv.Atoi(values[0])
+	if err != nil {
+		return 0
+	}
+
+	return version
+}
+
+func withAPIVersion(ctx context.Context) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, constants.APIVersionMetadataKey, strconv.Itoa(constants.APIVersion))
+}
+
+func wrapUnimplemented(method string, header metadata.MD, err error) error {
+	if status.Code(err) != codes.Unimplemented {
+		return err
+	}
+
+	return &UnsupportedAPIError{
+		Method:           method,
+		ServerAPIVersion: ServerAPIVersion(header),
+		Err:              err,
+	}
+}
+
+// apiVersionUnaryInterceptor sends the client API version with every request and converts
+// unimplemented method errors into UnsupportedAPIError.
+func apiVersionUnaryInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	var header metadata.MD
+
+	err := invoker(withAPIVersion(ctx), method, req, reply, cc, append(opts, grpc.Header(&header))...)
+
+	return wrapUnimplemented(method, header, err)
+}
+
+// apiVersionStreamInterceptor is the streaming version of apiVersionUnaryInterceptor.
+func apiVersionStreamInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	stream, err := streamer(withAPIVersion(ctx), desc, cc, method, opts...)
+	if err != nil {
+		return nil, wrapUnimplemented(method, nil, err)
+	}
+
+	return &apiVersionClientStream{
+		ClientStream: stream,
+		method:       method,
+	}, nil
+}
+
+type apiVersionClientStream struct {
+	grpc.ClientStream
+
+	method string
+}
+
+func (s *apiVersionClientStream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+	if status.Code(err) != codes.Unimplemented {
+		return err
+	}
+
+	// stream is already finished, so the headers (if any) are available
+	header, _ := s.ClientStream.Header() //nolint:errcheck
+
+	return wrapUnimplemented(s.method, header, err)
+}
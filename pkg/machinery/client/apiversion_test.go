@@ -0,0 +1,44 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package client_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/talos-systems/talos/pkg/machinery/client"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+)
+
+func TestServerAPIVersion(t *testing.T) {
+	assert.Equal(t, 0, client.ServerAPIVersion(nil))
+	assert.Equal(t, 0, client.ServerAPIVersion(metadata.Pairs(constants.APIVersionMetadataKey, "foo")))
+	assert.Equal(t, 2, client.ServerAPIVersion(metadata.Pairs(constants.APIVersionMetadataKey, "2")))
+}
+
+func TestUnsupportedAPIError(t *testing.T) {
+	err := fmt.Errorf("error getting foo: %w", &client.UnsupportedAPIError{
+		Method: "/machine.MachineService/Foo",
+		Err:    status.Error(codes.Unimplemented, "unknown method Foo for service machine.MachineService"),
+	})
+
+	assert.True(t, client.IsUnsupportedAPI(err))
+	assert.False(t, client.IsUnsupportedAPI(status.Error(codes.Unimplemented, "unknown method")))
+	assert.Contains(t, err.Error(), "unsupported API: method /machine.MachineService/Foo is not supported by the server (server doesn't report API version")
+
+	apiErr := &client.UnsupportedAPIError{
+		Method:           "/machine.MachineService/Foo",
+		ServerAPIVersion: 1,
+		Err:              status.Error(codes.Unimplemented, "unsupported API: method /machine.MachineService/Foo is not supported by the server (server API version 1, client API version 2)"),
+	}
+
+	assert.Equal(t, "unsupported API: method /machine.MachineService/Foo is not supported by the server (server API version 1, client API version 2)", apiErr.Error())
+	assert.Equal(t, codes.Unimplemented, status.Code(apiErr))
+}
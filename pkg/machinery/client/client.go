@@ -166,6 +166,17 @@ func New(ctx context.Context, opts ...OptionFunc) (c *Client, err error) {
 	return c, nil
 }
 
+// NewLocal returns a new Client connected to the machine API of the local node via the local Unix socket.
+//
+// Local socket is available to the processes running as root on the node (e.g. privileged host-level containers),
+// requests are served by the local node directly, so that the nodes set in the context are ignored.
+func NewLocal(ctx context.Context, opts ...OptionFunc) (*Client, error) {
+	return New(ctx, append([]OptionFunc{
+		WithUnixSocket(constants.LocalAPISocketPath),
+		WithGRPCDialOptions(grpc.WithInsecure()),
+	}, opts...)...)
+}
+
 func (c *Client) getConn(ctx context.Context, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
 	endpoints := c.getEndpoints()
 
@@ -215,6 +226,11 @@ func (c *Client) getConn(ctx context.Context, opts ...grpc.DialOption) (*grpc.Cl
 		)
 	}
 
+	dialOpts = append(dialOpts,
+		grpc.WithChainUnaryInterceptor(apiVersionUnaryInterceptor),
+		grpc.WithChainStreamInterceptor(apiVersionStreamInterceptor),
+	)
+
 	dialOpts = append(dialOpts, c.options.grpcDialOptions...)
 
 	dialOpts = append(dialOpts, opts...)
@@ -318,9 +334,25 @@ func (c *Client) Close() error {
 	return c.conn.Close()
 }
 
+// KubeconfigOptionFunc defines the options for the Kubeconfig API.
+type KubeconfigOptionFunc func(req *machineapi.KubeconfigRequest)
+
+// WithKubeconfigCertTTL requests the admin certificate with the lifetime shorter than the default one.
+func WithKubeconfigCertTTL(ttl time.Duration) KubeconfigOptionFunc {
+	return func(req *machineapi.KubeconfigRequest) {
+		req.CertTtl = durationpb.New(ttl)
+	}
+}
+
 // KubeconfigRaw returns K8s client config (kubeconfig).
-func (c *Client) KubeconfigRaw(ctx context.Context) (io.ReadCloser, <-chan error, error) {
-	stream, err := c.MachineClient.Kubeconfig(ctx, &empty.Empty{})
+func (c *Client) KubeconfigRaw(ctx context.Context, opts ...KubeconfigOptionFunc) (io.ReadCloser, <-chan error, error) {
+	var req machineapi.KubeconfigRequest
+
+	for _, opt := range opts {
+		opt(&req)
+	}
+
+	stream, err := c.MachineClient.Kubeconfig(ctx, &req)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -365,8 +397,8 @@ func (c *Client) extractKubeconfig(r io.ReadCloser) ([]byte, error) {
 }
 
 // Kubeconfig returns K8s client config (kubeconfig).
-func (c *Client) Kubeconfig(ctx context.Context) ([]byte, error) {
-	r, errCh, err := c.KubeconfigRaw(ctx)
+func (c *Client) Kubeconfig(ctx context.Context, opts ...KubeconfigOptionFunc) ([]byte, error) {
+	r, errCh, err := c.KubeconfigRaw(ctx, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -404,6 +436,88 @@ func (c *Client) GenerateConfiguration(ctx context.Context, req *machineapi.Gene
 	return
 }
 
+// SyncConfiguration implements proto.MachineServiceClient interface.
+func (c *Client) SyncConfiguration(ctx context.Context, req *machineapi.SyncConfigurationRequest, callOptions ...grpc.CallOption) (resp *machineapi.SyncConfigurationResponse, err error) {
+	resp, err = c.MachineClient.SyncConfiguration(ctx, req, callOptions...)
+
+	var filtered interface{}
+	filtered, err = FilterMessages(resp, err)
+	resp, _ = filtered.(*machineapi.SyncConfigurationResponse) //nolint:errcheck
+
+	return
+}
+
+// Cordon marks the Kubernetes node of the machine as unschedulable.
+func (c *Client) Cordon(ctx context.Context, callOptions ...grpc.CallOption) (resp *machineapi.CordonResponse, err error) {
+	resp, err = c.MachineClient.Cordon(ctx, &machineapi.CordonRequest{}, callOptions...)
+
+	var filtered interface{}
+	filtered, err = FilterMessages(resp, err)
+	resp, _ = filtered.(*machineapi.CordonResponse) //nolint:errcheck
+
+	return
+}
+
+// PauseReconcile pauses automatic reconciliation of the cluster state on the node for the specified duration.
+//
+// Zero duration resumes reconciliation.
+func (c *Client) PauseReconcile(ctx context.Context, duration time.Duration, callOptions ...grpc.CallOption) (resp *machineapi.PauseReconcileResponse, err error) {
+	resp, err = c.MachineClient.PauseReconcile(ctx, &machineapi.PauseReconcileRequest{
+		Duration: durationpb.New(duration),
+	}, callOptions...)
+
+	var filtered interface{}
+	filtered, err = FilterMessages(resp, err)
+	resp, _ = filtered.(*machineapi.PauseReconcileResponse) //nolint:errcheck
+
+	return
+}
+
+// AuditLog returns the audit log of the mutating machine API calls.
+//
+// If tailEntries is set, only the most recent entries are returned.
+func (c *Client) AuditLog(ctx context.Context, tailEntries int32, callOptions ...grpc.CallOption) (resp *machineapi.AuditLogResponse, err error) {
+	resp, err = c.MachineClient.AuditLog(ctx, &machineapi.AuditLogRequest{
+		TailEntries: tailEntries,
+	}, callOptions...)
+
+	var filtered interface{}
+	filtered, err = FilterMessages(resp, err)
+	resp, _ = filtered.(*machineapi.AuditLogResponse) //nolint:errcheck
+
+	return
+}
+
+// RevokeCertificate adds the client certificate to the revocation list.
+//
+// Serial number is decimal.
+func (c *Client) RevokeCertificate(ctx context.Context, serialNumber string, callOptions ...grpc.CallOption) (resp *machineapi.RevokeCertificateResponse, err error) {
+	resp, err = c.MachineClient.RevokeCertificate(ctx, &machineapi.RevokeCertificateRequest{
+		SerialNumber: serialNumber,
+	}, callOptions...)
+
+	var filtered interface{}
+	filtered, err = FilterMessages(resp, err)
+	resp, _ = filtered.(*machineapi.RevokeCertificateResponse) //nolint:errcheck
+
+	return
+}
+
+// Uncordon marks the Kubernetes node of the machine as schedulable.
+//
+// If force is set, the node is uncordoned even if it was not cordoned by Talos.
+func (c *Client) Uncordon(ctx context.Context, force bool, callOptions ...grpc.CallOption) (resp *machineapi.UncordonResponse, err error) {
+	resp, err = c.MachineClient.Uncordon(ctx, &machineapi.UncordonRequest{
+		Force: force,
+	}, callOptions...)
+
+	var filtered interface{}
+	filtered, err = FilterMessages(resp, err)
+	resp, _ = filtered.(*machineapi.UncordonResponse) //nolint:errcheck
+
+	return
+}
+
 // Disks returns the list of block devices.
 func (c *Client) Disks(ctx context.Context, callOptions ...grpc.CallOption) (resp *storageapi.DisksResponse, err error) {
 	resp, err = c.StorageClient.Disks(ctx, &empty.Empty{}, callOptions...)
@@ -487,9 +601,32 @@ func (c *Client) ResetGeneric(ctx context.Context, req *machineapi.ResetRequest)
 	return
 }
 
+// RebootOptionFunc defines the options for the Reboot API.
+type RebootOptionFunc func(req *machineapi.RebootRequest)
+
+// WithRebootMode sets the reboot mode (kexec-capable default or a full power cycle).
+func WithRebootMode(mode machineapi.RebootRequest_Mode) RebootOptionFunc {
+	return func(req *machineapi.RebootRequest) {
+		req.Mode = mode
+	}
+}
+
+// WithRebootDrain cordons and drains the node before rebooting it.
+func WithRebootDrain() RebootOptionFunc {
+	return func(req *machineapi.RebootRequest) {
+		req.Drain = true
+	}
+}
+
 // Reboot implements the proto.MachineServiceClient interface.
-func (c *Client) Reboot(ctx context.Context) (err error) {
-	resp, err := c.MachineClient.Reboot(ctx, &empty.Empty{})
+func (c *Client) Reboot(ctx context.Context, opts ...RebootOptionFunc) (err error) {
+	var req machineapi.RebootRequest
+
+	for _, opt := range opts {
+		opt(&req)
+	}
+
+	resp, err := c.MachineClient.Reboot(ctx, &req)
 
 	if err == nil {
 		_, err = FilterMessages(resp, err)
@@ -531,9 +668,32 @@ func (c *Client) Bootstrap(ctx context.Context, req *machineapi.BootstrapRequest
 	return
 }
 
+// ShutdownOptionFunc defines the options for the Shutdown API.
+type ShutdownOptionFunc func(req *machineapi.ShutdownRequest)
+
+// WithShutdownForce skips the graceful stop of services and powers the node off right away.
+func WithShutdownForce() ShutdownOptionFunc {
+	return func(req *machineapi.ShutdownRequest) {
+		req.Force = true
+	}
+}
+
+// WithShutdownDrain cordons and drains the node before shutting it down.
+func WithShutdownDrain() ShutdownOptionFunc {
+	return func(req *machineapi.ShutdownRequest) {
+		req.Drain = true
+	}
+}
+
 // Shutdown implements the proto.MachineServiceClient interface.
-func (c *Client) Shutdown(ctx context.Context) (err error) {
-	resp, err := c.MachineClient.Shutdown(ctx, &empty.Empty{})
+func (c *Client) Shutdown(ctx context.Context, opts ...ShutdownOptionFunc) (err error) {
+	var req machineapi.ShutdownRequest
+
+	for _, opt := range opts {
+		opt(&req)
+	}
+
+	resp, err := c.MachineClient.Shutdown(ctx, &req)
 
 	if err == nil {
 		_, err = FilterMessages(resp, err)
@@ -623,6 +783,63 @@ func (c *Client) Processes(ctx context.Context, callOptions ...grpc.CallOption)
 	return
 }
 
+// Netstat implements the proto.MachineServiceClient interface.
+func (c *Client) Netstat(ctx context.Context, req *machineapi.NetstatRequest, callOptions ...grpc.CallOption) (resp *machineapi.NetstatResponse, err error) {
+	resp, err = c.MachineClient.Netstat(
+		ctx,
+		req,
+		callOptions...,
+	)
+
+	var filtered interface{}
+	filtered, err = FilterMessages(resp, err)
+	resp, _ = filtered.(*machineapi.NetstatResponse) //nolint:errcheck
+
+	return
+}
+
+// Cgroups implements the proto.MachineServiceClient interface.
+func (c *Client) Cgroups(ctx context.Context, req *machineapi.CgroupsRequest, callOptions ...grpc.CallOption) (resp *machineapi.CgroupsResponse, err error) {
+	resp, err = c.MachineClient.Cgroups(
+		ctx,
+		req,
+		callOptions...,
+	)
+
+	var filtered interface{}
+	filtered, err = FilterMessages(resp, err)
+	resp, _ = filtered.(*machineapi.CgroupsResponse) //nolint:errcheck
+
+	return
+}
+
+// PacketCapture runs packet capture on the node and returns the stream of captured packets in pcap format.
+func (c *Client) PacketCapture(ctx context.Context, req *machineapi.PacketCaptureRequest) (io.ReadCloser, <-chan error, error) {
+	stream, err := c.MachineClient.PacketCapture(ctx, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return ReadStream(stream)
+}
+
+// ProcessDetails implements the proto.MachineServiceClient interface.
+func (c *Client) ProcessDetails(ctx context.Context, pid int32, callOptions ...grpc.CallOption) (resp *machineapi.ProcessDetailsResponse, err error) {
+	resp, err = c.MachineClient.ProcessDetails(
+		ctx,
+		&machineapi.ProcessDetailsRequest{
+			Pid: pid,
+		},
+		callOptions...,
+	)
+
+	var filtered interface{}
+	filtered, err = FilterMessages(resp, err)
+	resp, _ = filtered.(*machineapi.ProcessDetailsResponse) //nolint:errcheck
+
+	return
+}
+
 // Memory implements the proto.MachineServiceClient interface.
 func (c *Client) Memory(ctx context.Context, callOptions ...grpc.CallOption) (resp *machineapi.MemoryResponse, err error) {
 	resp, err = c.MachineClient.Memory(
@@ -638,6 +855,51 @@ func (c *Client) Memory(ctx context.Context, callOptions ...grpc.CallOption) (re
 	return
 }
 
+// SystemStat implements the proto.MachineServiceClient interface.
+func (c *Client) SystemStat(ctx context.Context, callOptions ...grpc.CallOption) (resp *machineapi.SystemStatResponse, err error) {
+	resp, err = c.MachineClient.SystemStat(
+		ctx,
+		&empty.Empty{},
+		callOptions...,
+	)
+
+	var filtered interface{}
+	filtered, err = FilterMessages(resp, err)
+	resp, _ = filtered.(*machineapi.SystemStatResponse) //nolint:errcheck
+
+	return
+}
+
+// DiskStats implements the proto.MachineServiceClient interface.
+func (c *Client) DiskStats(ctx context.Context, callOptions ...grpc.CallOption) (resp *machineapi.DiskStatsResponse, err error) {
+	resp, err = c.MachineClient.DiskStats(
+		ctx,
+		&empty.Empty{},
+		callOptions...,
+	)
+
+	var filtered interface{}
+	filtered, err = FilterMessages(resp, err)
+	resp, _ = filtered.(*machineapi.DiskStatsResponse) //nolint:errcheck
+
+	return
+}
+
+// LoadAvg implements the proto.MachineServiceClient interface.
+func (c *Client) LoadAvg(ctx context.Context, callOptions ...grpc.CallOption) (resp *machineapi.LoadAvgResponse, err error) {
+	resp, err = c.MachineClient.LoadAvg(
+		ctx,
+		&empty.Empty{},
+		callOptions...,
+	)
+
+	var filtered interface{}
+	filtered, err = FilterMessages(resp, err)
+	resp, _ = filtered.(*machineapi.LoadAvgResponse) //nolint:errcheck
+
+	return
+}
+
 // Mounts implements the proto.MachineServiceClient interface.
 func (c *Client) Mounts(ctx context.Context, callOptions ...grpc.CallOption) (resp *machineapi.MountsResponse, err error) {
 	resp, err = c.MachineClient.Mounts(
@@ -663,6 +925,46 @@ func (c *Client) DiskUsage(ctx context.Context, req *machineapi.DiskUsageRequest
 	return c.MachineClient.DiskUsage(ctx, req)
 }
 
+// ImageList lists the images in the containerd namespace.
+func (c *Client) ImageList(ctx context.Context, namespace common.ContainerdNamespace) (stream machineapi.MachineService_ImageListClient, err error) {
+	return c.MachineClient.ImageList(ctx, &machineapi.ImageListRequest{
+		Namespace: namespace,
+	})
+}
+
+// ImagePull pulls the image into the containerd namespace.
+func (c *Client) ImagePull(ctx context.Context, namespace common.ContainerdNamespace, reference string, callOptions ...grpc.CallOption) (resp *machineapi.ImagePullResponse, err error) {
+	resp, err = c.MachineClient.ImagePull(
+		ctx,
+		&machineapi.ImagePullRequest{
+			Namespace: namespace,
+			Reference: reference,
+		},
+		callOptions...,
+	)
+
+	var filtered interface{}
+	filtered, err = FilterMessages(resp, err)
+	resp, _ = filtered.(*machineapi.ImagePullResponse) //nolint:errcheck
+
+	return
+}
+
+// KernelCmdline returns the kernel command line of the running kernel and the one configured for the next boot.
+func (c *Client) KernelCmdline(ctx context.Context, callOptions ...grpc.CallOption) (resp *machineapi.KernelCmdlineResponse, err error) {
+	resp, err = c.MachineClient.KernelCmdline(
+		ctx,
+		&empty.Empty{},
+		callOptions...,
+	)
+
+	var filtered interface{}
+	filtered, err = FilterMessages(resp, err)
+	resp, _ = filtered.(*machineapi.KernelCmdlineResponse) //nolint:errcheck
+
+	return
+}
+
 // Copy implements the proto.MachineServiceClient interface.
 func (c *Client) Copy(ctx context.Context, rootPath string) (io.ReadCloser, <-chan error, error) {
 	stream, err := c.MachineClient.Copy(ctx, &machineapi.CopyRequest{
@@ -675,6 +977,50 @@ func (c *Client) Copy(ctx context.Context, rootPath string) (io.ReadCloser, <-ch
 	return ReadStream(stream)
 }
 
+// CopyIn uploads .tar.gz archive read from r to the node and extracts it under rootPath.
+func (c *Client) CopyIn(ctx context.Context, rootPath string, r io.Reader, callOptions ...grpc.CallOption) (resp *machineapi.CopyInResponse, err error) {
+	stream, err := c.MachineClient.CopyIn(ctx, callOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = stream.Send(&machineapi.CopyInRequest{
+		RootPath: rootPath,
+	}); err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, 64*1024)
+
+	for {
+		n, readErr := r.Read(buf)
+		if n > 0 {
+			if err = stream.Send(&machineapi.CopyInRequest{
+				Data: buf[:n],
+			}); err != nil {
+				break
+			}
+		}
+
+		if readErr != nil {
+			if readErr != io.EOF {
+				return nil, readErr
+			}
+
+			break
+		}
+	}
+
+	// on send error the actual status is returned by CloseAndRecv
+	resp, err = stream.CloseAndRecv()
+
+	var filtered interface{}
+	filtered, err = FilterMessages(resp, err)
+	resp, _ = filtered.(*machineapi.CopyInResponse) //nolint:errcheck
+
+	return
+}
+
 // Upgrade initiates a Talos upgrade ... and implements the proto.MachineServiceClient
 // interface.
 func (c *Client) Upgrade(ctx context.Context, image string, preserve, stage, force bool, callOptions ...grpc.CallOption) (resp *machineapi.UpgradeResponse, err error) {
@@ -976,7 +1322,20 @@ func ReadStream(stream MachineStream) (io.ReadCloser, <-chan error, error) {
 			}
 
 			if data.Metadata != nil && data.Metadata.Error != "" {
-				errCh <- errors.New(data.Metadata.Error)
+				err = errors.New(data.Metadata.Error)
+
+				if data.Metadata.Status != nil {
+					err = status.FromProto(data.Metadata.Status).Err()
+				}
+
+				if data.Metadata.Hostname != "" {
+					err = &NodeError{
+						Node: data.Metadata.Hostname,
+						Err:  err,
+					}
+				}
+
+				errCh <- err
 			}
 		}
 	}()
@@ -45,6 +45,15 @@ func WithTailDuration(dur time.Duration) EventsOptionFunc {
 	}
 }
 
+// WithActorID sets up Events API to return only the events of the operation with the specified actor ID.
+//
+// Actor ID is returned in the response of the long-running operations (e.g. upgrade, reboot, reset).
+func WithActorID(actorID string) EventsOptionFunc {
+	return func(opts *machineapi.EventsRequest) {
+		opts.WithActorId = actorID
+	}
+}
+
 // Events implements the proto.OSClient interface.
 func (c *Client) Events(ctx context.Context, opts ...EventsOptionFunc) (stream machineapi.MachineService_EventsClient, err error) {
 	var req machineapi.EventsRequest
@@ -61,6 +70,7 @@ type Event struct {
 	Node    string
 	TypeURL string
 	ID      string
+	ActorID string
 	Payload proto.Message
 }
 
@@ -113,6 +123,8 @@ func (c *Client) EventsWatch(ctx context.Context, watchFunc func(<-chan Event),
 			&machineapi.PhaseEvent{},
 			&machineapi.TaskEvent{},
 			&machineapi.ServiceStateEvent{},
+			&machineapi.InstallPreflightEvent{},
+			&machineapi.NodeDeletedEvent{},
 		} {
 			if typeURL == "talos/runtime/"+string(eventType.ProtoReflect().Descriptor().FullName()) {
 				msg = eventType
@@ -136,6 +148,7 @@ func (c *Client) EventsWatch(ctx context.Context, watchFunc func(<-chan Event),
 			Node:    defaultNode,
 			TypeURL: typeURL,
 			ID:      event.Id,
+			ActorID: event.ActorId,
 			Payload: msg,
 		}
 
@@ -0,0 +1,94 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package client
+
+import (
+	"context"
+	"sync"
+
+	"github.com/hashicorp/go-multierror"
+	"google.golang.org/grpc/metadata"
+)
+
+// NodesFromContext returns the list of nodes attached to the context with WithNodes.
+func NodesFromContext(ctx context.Context) []string {
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	return md.Get("nodes")
+}
+
+// NodeFunc is called by ForEachNode for every node.
+//
+// Context passed to the function targets the single node.
+type NodeFunc func(ctx context.Context, node string) error
+
+// ForEachNode calls f concurrently for every node attached to the context.
+//
+// Errors returned by f are wrapped with NodeError and combined into a multierror,
+// so that the failure of a single node doesn't abort the calls to other nodes.
+// If there are no nodes attached to the context, f is called once with the original
+// context and an empty node name (the request goes to the endpoint itself).
+func ForEachNode(ctx context.Context, f NodeFunc) error {
+	return forEachNode(ctx, f, true)
+}
+
+// ForEachNodeSequential is same as ForEachNode, but f is called for the nodes one by one in order.
+//
+// It should be used when f is interactive.
+func ForEachNodeSequential(ctx context.Context, f NodeFunc) error {
+	return forEachNode(ctx, f, false)
+}
+
+func forEachNode(ctx context.Context, f NodeFunc, concurrent bool) error {
+	nodes := NodesFromContext(ctx)
+
+	if len(nodes) == 0 {
+		return f(ctx, "")
+	}
+
+	errs := make([]error, len(nodes))
+
+	var wg sync.WaitGroup
+
+	for i, node := range nodes {
+		i, node := i, node
+
+		call := func() {
+			errs[i] = f(WithNodes(ctx, node), node)
+		}
+
+		if !concurrent {
+			call()
+
+			continue
+		}
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			call()
+		}()
+	}
+
+	wg.Wait()
+
+	var multiErr *multierror.Error
+
+	for i, err := range errs {
+		if err != nil {
+			multiErr = multierror.Append(multiErr, &NodeError{
+				Node: nodes[i],
+				Err:  err,
+			})
+		}
+	}
+
+	return multiErr.ErrorOrNil()
+}
@@ -0,0 +1,88 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package client_test
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/talos-systems/talos/pkg/machinery/client"
+)
+
+func TestNodesFromContext(t *testing.T) {
+	assert.Empty(t, client.NodesFromContext(context.Background()))
+	assert.Equal(t, []string{"10.5.0.2", "10.5.0.3"}, client.NodesFromContext(client.WithNodes(context.Background(), "10.5.0.2", "10.5.0.3")))
+}
+
+func TestForEachNode(t *testing.T) {
+	ctx := client.WithNodes(context.Background(), "10.5.0.2", "10.5.0.3", "10.5.0.4")
+
+	var (
+		mu    sync.Mutex
+		nodes []string
+	)
+
+	err := client.ForEachNode(ctx, func(ctx context.Context, node string) error {
+		assert.Equal(t, []string{node}, client.NodesFromContext(ctx))
+
+		mu.Lock()
+		nodes = append(nodes, node)
+		mu.Unlock()
+
+		if node == "10.5.0.3" {
+			return errors.New("failed")
+		}
+
+		return nil
+	})
+
+	sort.Strings(nodes)
+
+	assert.Equal(t, []string{"10.5.0.2", "10.5.0.3", "10.5.0.4"}, nodes)
+	assert.EqualError(t, err, "1 error occurred:\n\t* 10.5.0.3: failed\n\n")
+
+	var nodeErr *client.NodeError
+
+	assert.True(t, errors.As(err, &nodeErr))
+	assert.Equal(t, "10.5.0.3", nodeErr.Node)
+}
+
+func TestForEachNodeNoNodes(t *testing.T) {
+	calls := 0
+
+	assert.NoError(t, client.ForEachNode(context.Background(), func(ctx context.Context, node string) error {
+		calls++
+
+		assert.Empty(t, node)
+
+		return nil
+	}))
+
+	assert.Equal(t, 1, calls)
+}
+
+func TestForEachNodeSequential(t *testing.T) {
+	ctx := client.WithNodes(context.Background(), "10.5.0.2", "10.5.0.3", "10.5.0.4")
+
+	var nodes []string
+
+	err := client.ForEachNodeSequential(ctx, func(ctx context.Context, node string) error {
+		nodes = append(nodes, node)
+
+		if node != "10.5.0.3" {
+			return errors.New("failed")
+		}
+
+		return nil
+	})
+
+	assert.Equal(t, []string{"10.5.0.2", "10.5.0.3", "10.5.0.4"}, nodes)
+	assert.EqualError(t, err, "2 errors occurred:\n\t* 10.5.0.2: failed\n\t* 10.5.0.4: failed\n\n")
+}
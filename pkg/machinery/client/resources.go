@@ -6,6 +6,7 @@ package client
 
 import (
 	"context"
+	"time"
 
 	"github.com/talos-systems/os-runtime/pkg/resource"
 	"github.com/talos-systems/os-runtime/pkg/state"
@@ -25,6 +26,8 @@ type ResourceResponse struct {
 	Metadata   *common.Metadata
 	Definition resource.Resource
 	Resource   resource.Resource
+	// Updated is the time of the last resource update, zero if not reported by the node.
+	Updated time.Time
 }
 
 // WatchResponse is a parsed resource watch response.
@@ -72,6 +75,8 @@ func (c *ResourcesClient) Get(ctx context.Context, resourceNamespace, resourceTy
 			if e != nil {
 				return nil, e
 			}
+
+			resourceResp.Updated = updatedFromProto(msg.GetResource().GetMetadata())
 		}
 
 		items = append(items, resourceResp)
@@ -112,6 +117,8 @@ func (client *ResourceListClient) Recv() (ResourceResponse, error) {
 		if e != nil {
 			return resourceResp, e
 		}
+
+		resourceResp.Updated = updatedFromProto(msg.GetResource().GetMetadata())
 	}
 
 	return resourceResp, nil
@@ -161,6 +168,8 @@ func (client *ResourceWatchClient) Recv() (WatchResponse, error) {
 		if e != nil {
 			return watchResp, e
 		}
+
+		watchResp.Updated = updatedFromProto(msg.GetResource().GetMetadata())
 	}
 
 	switch msg.GetEventType() {
@@ -187,3 +196,11 @@ func (c *ResourcesClient) Watch(ctx context.Context, resourceNamespace, resource
 		grpcClient: client,
 	}, err
 }
+
+func updatedFromProto(md *resourceapi.Metadata) time.Time {
+	if md.GetUpdated() == nil {
+		return time.Time{}
+	}
+
+	return md.GetUpdated().AsTime()
+}
@@ -0,0 +1,103 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/talos-systems/talos/pkg/machinery/api/common"
+	machineapi "github.com/talos-systems/talos/pkg/machinery/api/machine"
+)
+
+// TunnelConn is a raw TCP connection tunneled over the Talos API.
+type TunnelConn struct {
+	stream machineapi.MachineService_KubernetesTunnelStreamClient
+	cancel context.CancelFunc
+
+	readMu sync.Mutex
+	buf    []byte
+}
+
+// KubernetesTunnel opens the connection to the Kubernetes API server of the control plane node via Talos API.
+//
+// Connection is closed when the context is canceled.
+func (c *Client) KubernetesTunnel(ctx context.Context, callOptions ...grpc.CallOption) (*TunnelConn, error) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	stream, err := c.MachineClient.KubernetesTunnelStream(ctx, callOptions...)
+	if err != nil {
+		cancel()
+
+		return nil, err
+	}
+
+	return newTunnelConn(stream, cancel), nil
+}
+
+func newTunnelConn(stream machineapi.MachineService_KubernetesTunnelStreamClient, cancel context.CancelFunc) *TunnelConn {
+	return &TunnelConn{
+		stream: stream,
+		cancel: cancel,
+	}
+}
+
+// Read implements io.Reader.
+func (conn *TunnelConn) Read(p []byte) (int, error) {
+	conn.readMu.Lock()
+	defer conn.readMu.Unlock()
+
+	for len(conn.buf) == 0 {
+		data, err := conn.stream.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) || status.Code(err) == codes.Canceled {
+				return 0, io.EOF
+			}
+
+			return 0, err
+		}
+
+		if data.Metadata != nil && data.Metadata.Error != "" {
+			return 0, errors.New(data.Metadata.Error)
+		}
+
+		conn.buf = data.Bytes
+	}
+
+	n := copy(p, conn.buf)
+	conn.buf = conn.buf[n:]
+
+	return n, nil
+}
+
+// Write implements io.Writer.
+func (conn *TunnelConn) Write(p []byte) (int, error) {
+	// gRPC might hold the message after Send returns, so the buffer can't be reused
+	if err := conn.stream.Send(&common.Data{Bytes: append([]byte(nil), p...)}); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// CloseWrite shuts down the writing side of the connection.
+func (conn *TunnelConn) CloseWrite() error {
+	return conn.stream.CloseSend()
+}
+
+// Close implements io.Closer.
+//
+// Close aborts the stream, so it is safe to call it concurrently with Read and Write.
+func (conn *TunnelConn) Close() error {
+	conn.cancel()
+
+	return nil
+}
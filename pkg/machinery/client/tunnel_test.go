@@ -0,0 +1,102 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package client
+
+import (
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/talos-systems/talos/pkg/machinery/api/common"
+)
+
+type mockTunnelStream struct {
+	grpc.ClientStream
+
+	recv       []*common.Data
+	sent       [][]byte
+	closedSend bool
+}
+
+func (m *mockTunnelStream) Send(data *common.Data) error {
+	m.sent = append(m.sent, data.Bytes)
+
+	return nil
+}
+
+func (m *mockTunnelStream) Recv() (*common.Data, error) {
+	if len(m.recv) == 0 {
+		return nil, io.EOF
+	}
+
+	data := m.recv[0]
+	m.recv = m.recv[1:]
+
+	return data, nil
+}
+
+func (m *mockTunnelStream) CloseSend() error {
+	m.closedSend = true
+
+	return nil
+}
+
+func TestTunnelConn(t *testing.T) {
+	stream := &mockTunnelStream{
+		recv: []*common.Data{
+			{Bytes: []byte("HTTP/1.1 ")},
+			{},
+			{Bytes: []byte("200 OK")},
+		},
+	}
+
+	canceled := false
+
+	conn := newTunnelConn(stream, func() { canceled = true })
+
+	buf := []byte("GET /")
+
+	n, err := conn.Write(buf)
+	require.NoError(t, err)
+	assert.Equal(t, 5, n)
+
+	// written data should be copied
+	buf[0] = 'P'
+
+	assert.Equal(t, [][]byte{[]byte("GET /")}, stream.sent)
+
+	small := make([]byte, 4)
+
+	n, err = conn.Read(small)
+	require.NoError(t, err)
+	assert.Equal(t, "HTTP", string(small[:n]))
+
+	rest, err := ioutil.ReadAll(conn)
+	require.NoError(t, err)
+	assert.Equal(t, "/1.1 200 OK", string(rest))
+
+	require.NoError(t, conn.CloseWrite())
+	assert.True(t, stream.closedSend)
+
+	require.NoError(t, conn.Close())
+	assert.True(t, canceled)
+}
+
+func TestTunnelConnError(t *testing.T) {
+	stream := &mockTunnelStream{
+		recv: []*common.Data{
+			{Metadata: &common.Metadata{Error: "connection refused"}},
+		},
+	}
+
+	conn := newTunnelConn(stream, func() {})
+
+	_, err := conn.Read(make([]byte, 16))
+	assert.EqualError(t, err, "connection refused")
+}
@@ -0,0 +1,25 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// Checksum returns the SHA-256 checksum of the config.
+//
+// Config is marshaled in the canonical form, so that formatting and comments don't affect the checksum:
+// the config fetched by the client and parsed back has the same checksum as the config on the node.
+func Checksum(cfg Provider) (string, error) {
+	b, err := cfg.Bytes()
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(b)
+
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,57 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/pkg/machinery/config"
+	"github.com/talos-systems/talos/pkg/machinery/config/configloader"
+)
+
+func TestChecksum(t *testing.T) {
+	cfg, err := configloader.NewFromBytes([]byte(`version: v1alpha1
+machine:
+  type: join
+  network:
+    hostname: worker-1
+`))
+	require.NoError(t, err)
+
+	// same config with comments and different formatting
+	reformatted, err := configloader.NewFromBytes([]byte(`# worker config
+machine:
+    network: {hostname: worker-1} # set by the operator
+    type: join
+version: v1alpha1
+`))
+	require.NoError(t, err)
+
+	changed, err := configloader.NewFromBytes([]byte(`version: v1alpha1
+machine:
+  type: join
+  network:
+    hostname: worker-2
+`))
+	require.NoError(t, err)
+
+	checksum, err := config.Checksum(cfg)
+	require.NoError(t, err)
+
+	assert.Len(t, checksum, 64)
+
+	reformattedChecksum, err := config.Checksum(reformatted)
+	require.NoError(t, err)
+
+	assert.Equal(t, checksum, reformattedChecksum)
+
+	changedChecksum, err := config.Checksum(changed)
+	require.NoError(t, err)
+
+	assert.NotEqual(t, checksum, changedChecksum)
+}
@@ -0,0 +1,132 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1/machine"
+)
+
+var kubernetesVersionRegexp = regexp.MustCompile(`^v?1\.(\d+)\.\d+`)
+
+// SupportedKubernetesVersions returns the range of Kubernetes minor versions supported by the Talos version.
+func (contract *VersionContract) SupportedKubernetesVersions() (min, max string) {
+	minMinor, maxMinor := contract.kubernetesMinorVersions()
+
+	return fmt.Sprintf("1.%d", minMinor), fmt.Sprintf("1.%d", maxMinor)
+}
+
+func (contract *VersionContract) kubernetesMinorVersions() (min, max int) {
+	switch {
+	case contract.Greater(TalosVersion0_9):
+		return 20, 21
+	case contract.Greater(TalosVersion0_8):
+		return 19, 21
+	default:
+		return 19, 20
+	}
+}
+
+// CheckVersionCompatibility checks that the versions of the install image and Kubernetes components
+// are compatible with each other and with the Talos version.
+//
+// If the contract is nil, the Talos version of the install image is used, falling back to the current version.
+// Config should be validated before the compatibility check.
+//
+//nolint:gocyclo,cyclop
+func CheckVersionCompatibility(cfg Provider, contract *VersionContract) ([]string, error) {
+	var (
+		warnings []string
+		result   *multierror.Error
+	)
+
+	if installImage := cfg.Machine().Install().Image(); installImage != "" {
+		installContract, err := ParseContractFromVersion(imageTag(installImage))
+
+		switch {
+		case err != nil:
+			warnings = append(warnings, fmt.Sprintf("failed to detect Talos version of the install image %q", installImage))
+		case !installContract.Known():
+			result = multierror.Append(result, fmt.Errorf("install image %q: Talos %s is not supported by this version of machinery", installImage, installContract))
+		case contract == nil:
+			contract = installContract
+		case *contract != *installContract:
+			result = multierror.Append(result, fmt.Errorf("install image %q doesn't match Talos %s", installImage, contract))
+		}
+	}
+
+	if contract == nil {
+		contract = TalosVersion0_10
+	}
+
+	minMinor, maxMinor := contract.kubernetesMinorVersions()
+
+	components := []struct {
+		name  string
+		image string
+	}{
+		{"kubelet", cfg.Machine().Kubelet().Image()},
+	}
+
+	if cfg.Machine().Type() != machine.TypeJoin {
+		components = append(components, []struct {
+			name  string
+			image string
+		}{
+			{"kube-apiserver", cfg.Cluster().APIServer().Image()},
+			{"kube-controller-manager", cfg.Cluster().ControllerManager().Image()},
+			{"kube-scheduler", cfg.Cluster().Scheduler().Image()},
+			{"kube-proxy", cfg.Cluster().Proxy().Image()},
+		}...)
+	}
+
+	minors := map[string]int{}
+
+	for _, component := range components {
+		matches := kubernetesVersionRegexp.FindStringSubmatch(imageTag(component.image))
+		if matches == nil {
+			warnings = append(warnings, fmt.Sprintf("failed to detect Kubernetes version of %s image %q", component.name, component.image))
+
+			continue
+		}
+
+		minor, _ := strconv.Atoi(matches[1]) //nolint:errcheck
+		minors[component.name] = minor
+
+		if minor < minMinor || minor > maxMinor {
+			result = multierror.Append(result, fmt.Errorf("%s image %q: Kubernetes 1.%d is not supported by Talos %s (supported versions 1.%d-1.%d)",
+				component.name, component.image, minor, contract, minMinor, maxMinor))
+		}
+	}
+
+	kubeletMinor, kubeletOk := minors["kubelet"]
+	apiServerMinor, apiServerOk := minors["kube-apiserver"]
+
+	if kubeletOk && apiServerOk && kubeletMinor > apiServerMinor {
+		result = multierror.Append(result, fmt.Errorf("kubelet version 1.%d is newer than kube-apiserver version 1.%d", kubeletMinor, apiServerMinor))
+	}
+
+	return warnings, result.ErrorOrNil()
+}
+
+// imageTag returns the tag of the container image reference.
+func imageTag(image string) string {
+	if idx := strings.Index(image, "@"); idx != -1 {
+		image = image[:idx]
+	}
+
+	idx := strings.LastIndex(image, ":")
+	if idx == -1 || strings.Contains(image[idx+1:], "/") {
+		return ""
+	}
+
+	return image[idx+1:]
+}
@@ -0,0 +1,108 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/talos-systems/talos/pkg/machinery/config"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
+)
+
+func TestSupportedKubernetesVersions(t *testing.T) {
+	min, max := config.TalosVersion0_10.SupportedKubernetesVersions()
+	assert.Equal(t, "1.20", min)
+	assert.Equal(t, "1.21", max)
+
+	min, max = config.TalosVersion0_8.SupportedKubernetesVersions()
+	assert.Equal(t, "1.19", min)
+	assert.Equal(t, "1.20", max)
+}
+
+func TestCheckVersionCompatibility(t *testing.T) {
+	for _, tt := range []struct {
+		name             string
+		machineType      string
+		installImage     string
+		kubeletImage     string
+		apiServerImage   string
+		contract         *config.VersionContract
+		expectedWarnings []string
+		expectedError    string
+	}{
+		{
+			name:         "defaults",
+			machineType:  "controlplane",
+			installImage: "ghcr.io/talos-systems/installer:v0.10.0",
+		},
+		{
+			name:             "untagged install image",
+			machineType:      "join",
+			installImage:     "ghcr.io/talos-systems/installer:latest",
+			expectedWarnings: []string{"failed to detect Talos version of the install image \"ghcr.io/talos-systems/installer:latest\""},
+		},
+		{
+			name:          "newer install image",
+			machineType:   "join",
+			installImage:  "ghcr.io/talos-systems/installer:v0.11.0",
+			expectedError: "1 error occurred:\n\t* install image \"ghcr.io/talos-systems/installer:v0.11.0\": Talos v0.11 is not supported by this version of machinery\n\n",
+		},
+		{
+			name:          "install image mismatch",
+			machineType:   "join",
+			installImage:  "ghcr.io/talos-systems/installer:v0.9.1",
+			contract:      config.TalosVersion0_10,
+			expectedError: "1 error occurred:\n\t* install image \"ghcr.io/talos-systems/installer:v0.9.1\" doesn't match Talos v0.10\n\n",
+		},
+		{
+			name:          "kubernetes version from install image",
+			machineType:   "join",
+			installImage:  "ghcr.io/talos-systems/installer:v0.8.4",
+			kubeletImage:  "ghcr.io/talos-systems/kubelet:v1.21.0",
+			expectedError: "1 error occurred:\n\t* kubelet image \"ghcr.io/talos-systems/kubelet:v1.21.0\": Kubernetes 1.21 is not supported by Talos v0.8 (supported versions 1.19-1.20)\n\n",
+		},
+		{
+			name:           "kubelet skew",
+			machineType:    "controlplane",
+			kubeletImage:   "ghcr.io/talos-systems/kubelet:v1.21.0",
+			apiServerImage: "k8s.gcr.io/kube-apiserver:v1.20.5",
+			expectedError:  "1 error occurred:\n\t* kubelet version 1.21 is newer than kube-apiserver version 1.20\n\n",
+		},
+	} {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: tt.machineType,
+					MachineInstall: &v1alpha1.InstallConfig{
+						InstallImage: tt.installImage,
+					},
+					MachineKubelet: &v1alpha1.KubeletConfig{
+						KubeletImage: tt.kubeletImage,
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					APIServerConfig: &v1alpha1.APIServerConfig{
+						ContainerImage: tt.apiServerImage,
+					},
+				},
+			}
+
+			warnings, err := config.CheckVersionCompatibility(cfg, tt.contract)
+
+			assert.Equal(t, tt.expectedWarnings, warnings)
+
+			if tt.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedError)
+			}
+		})
+	}
+}
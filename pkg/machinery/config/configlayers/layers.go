@@ -0,0 +1,175 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package configlayers provides layered machine config format: base document,
+// role overlays and node overlays merged in order.
+package configlayers
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Layers describes a layered machine config.
+//
+// The config for a node is rendered by merging (in order):
+//   - the base document;
+//   - the overlays of the node role (in the order of the list);
+//   - the node overlays (in the order of the list).
+//
+// Paths are relative to the directory of the layers file.
+type Layers struct {
+	// Base is the path to the base machine config document.
+	Base string `yaml:"base"`
+	// Roles maps role name to the list of role overlays.
+	Roles map[string][]string `yaml:"roles,omitempty"`
+	// Nodes maps node name (address or hostname, as used with --nodes) to the node layers.
+	Nodes map[string]Node `yaml:"nodes,omitempty"`
+
+	dir string
+}
+
+// Node describes the layers of a single node.
+type Node struct {
+	// Role is the name of the role overlays to apply.
+	Role string `yaml:"role,omitempty"`
+	// Overlays is the list of node-specific overlays.
+	Overlays []string `yaml:"overlays,omitempty"`
+}
+
+// Load reads the layers file.
+func Load(path string) (*Layers, error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	layers, err := Parse(contents)
+	if err != nil {
+		return nil, fmt.Errorf("error loading %q: %w", path, err)
+	}
+
+	layers.dir = filepath.Dir(path)
+
+	return layers, nil
+}
+
+// Parse decodes and validates the layers file contents.
+//
+// Paths in the parsed layers are relative to the current directory.
+func Parse(contents []byte) (*Layers, error) {
+	dec := yaml.NewDecoder(bytes.NewReader(contents))
+	dec.KnownFields(true)
+
+	var layers Layers
+
+	if err := dec.Decode(&layers); err != nil {
+		return nil, err
+	}
+
+	if err := layers.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &layers, nil
+}
+
+// Validate checks that the layers are consistent.
+func (layers *Layers) Validate() error {
+	if layers.Base == "" {
+		return fmt.Errorf("base document is required")
+	}
+
+	for _, name := range layers.NodeNames() {
+		node := layers.Nodes[name]
+
+		if node.Role == "" {
+			continue
+		}
+
+		if _, ok := layers.Roles[node.Role]; !ok {
+			return fmt.Errorf("node %q refers to unknown role %q", name, node.Role)
+		}
+	}
+
+	return nil
+}
+
+// NodeNames returns sorted list of the nodes.
+func (layers *Layers) NodeNames() []string {
+	names := make([]string, 0, len(layers.Nodes))
+
+	for name := range layers.Nodes {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// Paths returns the list of the documents to merge for the node in order.
+func (layers *Layers) Paths(nodeName string) ([]string, error) {
+	node, ok := layers.Nodes[nodeName]
+	if !ok {
+		return nil, fmt.Errorf("node %q is not defined in the layers", nodeName)
+	}
+
+	paths := []string{layers.path(layers.Base)}
+
+	if node.Role != "" {
+		for _, overlay := range layers.Roles[node.Role] {
+			paths = append(paths, layers.path(overlay))
+		}
+	}
+
+	for _, overlay := range node.Overlays {
+		paths = append(paths, layers.path(overlay))
+	}
+
+	return paths, nil
+}
+
+// Render merges the layers of the node into the machine config document.
+func (layers *Layers) Render(nodeName string) ([]byte, error) {
+	paths, err := layers.Paths(nodeName)
+	if err != nil {
+		return nil, err
+	}
+
+	var merged []byte
+
+	for i, path := range paths {
+		var document []byte
+
+		if document, err = ioutil.ReadFile(path); err != nil {
+			return nil, err
+		}
+
+		if i == 0 {
+			merged, err = Merge(document)
+		} else {
+			merged, err = Merge(merged, document)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("error rendering node %q: %q: %w", nodeName, path, err)
+		}
+	}
+
+	return merged, nil
+}
+
+func (layers *Layers) path(p string) string {
+	if filepath.IsAbs(p) {
+		return p
+	}
+
+	return filepath.Join(layers.dir, p)
+}
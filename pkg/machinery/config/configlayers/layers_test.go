@@ -0,0 +1,94 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package configlayers_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/pkg/machinery/config/configlayers"
+)
+
+func TestLayersRender(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "talos")
+	require.NoError(t, err)
+
+	defer os.RemoveAll(dir) //nolint:errcheck
+
+	for name, contents := range map[string]string{
+		"layers.yaml": `base: base.yaml
+roles:
+  controlplane:
+    - roles/controlplane.yaml
+nodes:
+  10.5.0.2:
+    role: controlplane
+    overlays:
+      - nodes/cp-1.yaml
+  10.5.0.3: {}
+`,
+		"base.yaml": `machine:
+  type: join
+  install:
+    disk: /dev/sda
+`,
+		"roles/controlplane.yaml": `machine:
+  type: controlplane
+`,
+		"nodes/cp-1.yaml": `machine:
+  install:
+    disk: /dev/nvme0n1
+`,
+	} {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, filepath.Dir(name)), 0o755))
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(contents), 0o644))
+	}
+
+	layers, err := configlayers.Load(filepath.Join(dir, "layers.yaml"))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"10.5.0.2", "10.5.0.3"}, layers.NodeNames())
+
+	rendered, err := layers.Render("10.5.0.2")
+	require.NoError(t, err)
+
+	assert.Equal(t, `machine:
+    type: controlplane
+    install:
+        disk: /dev/nvme0n1
+`, string(rendered))
+
+	rendered, err = layers.Render("10.5.0.3")
+	require.NoError(t, err)
+
+	assert.Equal(t, `machine:
+    type: join
+    install:
+        disk: /dev/sda
+`, string(rendered))
+
+	_, err = layers.Render("10.5.0.4")
+	assert.EqualError(t, err, "node \"10.5.0.4\" is not defined in the layers")
+}
+
+func TestLayersParseErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := configlayers.Parse([]byte("roles: {}\n"))
+	assert.EqualError(t, err, "base document is required")
+
+	_, err = configlayers.Parse([]byte("base: base.yaml\nnodes:\n  node-1:\n    role: worker\n"))
+	assert.EqualError(t, err, "node \"node-1\" refers to unknown role \"worker\"")
+
+	_, err = configlayers.Parse([]byte("base: base.yaml\noverlays: []\n"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,319 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package configlayers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// DirectiveKey is the key of the merge directive in the overlay mappings.
+	DirectiveKey = "$patch"
+	// DirectiveReplace replaces the mapping (or the list element) instead of merging it.
+	DirectiveReplace = "replace"
+	// DirectiveDelete removes the list element with the matching merge key.
+	DirectiveDelete = "delete"
+)
+
+// MergeKeys are the keys which identify list elements.
+//
+// Lists of mappings are merged element by element if all the elements of both lists
+// have the same merge key (first match in the list wins), other lists are replaced.
+var MergeKeys = []string{"interface", "vlanId", "device", "path", "name"}
+
+// Merge merges overlays on top of the base machine config document.
+//
+// Merge semantics:
+//   - mappings are merged recursively, the overlay values win;
+//   - `null` value in the overlay removes the key;
+//   - lists of mappings with a common merge key (see MergeKeys) are merged by key,
+//     elements which are not in the base are appended;
+//   - other lists and scalars are replaced;
+//   - `$patch: replace` in an overlay mapping replaces the base mapping instead of merging it;
+//   - `$patch: delete` in a keyed list element removes the matching element from the base.
+func Merge(base []byte, overlays ...[]byte) ([]byte, error) {
+	merged, err := parse(base)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing base: %w", err)
+	}
+
+	for i, overlay := range overlays {
+		var node *yaml.Node
+
+		node, err = parse(overlay)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing overlay %d: %w", i, err)
+		}
+
+		if node == nil {
+			continue
+		}
+
+		if merged == nil {
+			merged = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		}
+
+		if merged, err = mergeNode(merged, node); err != nil {
+			return nil, fmt.Errorf("error merging overlay %d: %w", i, err)
+		}
+	}
+
+	if merged == nil {
+		return nil, errors.New("merged config is empty")
+	}
+
+	var buf bytes.Buffer
+
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(4)
+
+	if err = enc.Encode(merged); err != nil {
+		return nil, err
+	}
+
+	if err = enc.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// parse decodes a single YAML document, empty document is returned as nil.
+func parse(in []byte) (*yaml.Node, error) {
+	dec := yaml.NewDecoder(bytes.NewReader(in))
+
+	var doc yaml.Node
+
+	if err := dec.Decode(&doc); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	var extra yaml.Node
+
+	if err := dec.Decode(&extra); !errors.Is(err, io.EOF) {
+		return nil, errors.New("multiple documents are not supported")
+	}
+
+	if len(doc.Content) == 0 {
+		return nil, nil
+	}
+
+	root := doc.Content[0]
+
+	if root.Kind != yaml.MappingNode {
+		return nil, errors.New("expected a mapping at the top level")
+	}
+
+	return root, nil
+}
+
+//nolint:gocyclo
+func mergeNode(base, overlay *yaml.Node) (*yaml.Node, error) {
+	if overlay.Kind == yaml.AliasNode || base.Kind == yaml.AliasNode {
+		return nil, errors.New("YAML aliases are not supported")
+	}
+
+	switch {
+	case overlay.Kind == yaml.MappingNode:
+		directive, err := popDirective(overlay)
+		if err != nil {
+			return nil, err
+		}
+
+		switch directive {
+		case "":
+		case DirectiveReplace:
+			return overlay, nil
+		default:
+			return nil, fmt.Errorf("unsupported directive %q on line %d", directive, overlay.Line)
+		}
+
+		if base.Kind != yaml.MappingNode {
+			// merge into an empty mapping to strip the directives from the overlay subtree
+			base = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		}
+
+		return mergeMapping(base, overlay)
+	case overlay.Kind == yaml.SequenceNode && base.Kind == yaml.SequenceNode:
+		if key := commonMergeKey(base, overlay); key != "" {
+			return mergeKeyedSequence(base, overlay, key)
+		}
+
+		return overlay, nil
+	default:
+		return overlay, nil
+	}
+}
+
+func mergeMapping(base, overlay *yaml.Node) (*yaml.Node, error) {
+	for i := 0; i < len(overlay.Content); i += 2 {
+		key, value := overlay.Content[i], overlay.Content[i+1]
+
+		idx := mappingIndex(base, key.Value)
+
+		if isNull(value) {
+			if idx >= 0 {
+				base.Content = append(base.Content[:idx], base.Content[idx+2:]...)
+			}
+
+			continue
+		}
+
+		if idx < 0 {
+			if value.Kind == yaml.MappingNode {
+				var err error
+
+				if value, err = mergeNode(&yaml.Node{}, value); err != nil {
+					return nil, fmt.Errorf("%s: %w", key.Value, err)
+				}
+			}
+
+			base.Content = append(base.Content, key, value)
+
+			continue
+		}
+
+		merged, err := mergeNode(base.Content[idx+1], value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", key.Value, err)
+		}
+
+		base.Content[idx+1] = merged
+	}
+
+	return base, nil
+}
+
+//nolint:gocyclo
+func mergeKeyedSequence(base, overlay *yaml.Node, key string) (*yaml.Node, error) {
+	for _, element := range overlay.Content {
+		id := mappingValue(element, key)
+
+		directive, err := popDirective(element)
+		if err != nil {
+			return nil, err
+		}
+
+		idx := -1
+
+		for i, existing := range base.Content {
+			if mappingValue(existing, key) == id {
+				idx = i
+
+				break
+			}
+		}
+
+		switch directive {
+		case DirectiveDelete:
+			if idx >= 0 {
+				base.Content = append(base.Content[:idx], base.Content[idx+1:]...)
+			}
+
+			continue
+		case DirectiveReplace:
+			if idx >= 0 {
+				base.Content[idx] = element
+			} else {
+				base.Content = append(base.Content, element)
+			}
+
+			continue
+		case "":
+		default:
+			return nil, fmt.Errorf("unsupported directive %q on line %d", directive, element.Line)
+		}
+
+		if idx < 0 {
+			base.Content = append(base.Content, element)
+
+			continue
+		}
+
+		merged, err := mergeNode(base.Content[idx], element)
+		if err != nil {
+			return nil, fmt.Errorf("[%s=%s]: %w", key, id, err)
+		}
+
+		base.Content[idx] = merged
+	}
+
+	return base, nil
+}
+
+// commonMergeKey returns the first merge key which is present in all the elements of both lists.
+func commonMergeKey(base, overlay *yaml.Node) string {
+	elements := append(append([]*yaml.Node{}, base.Content...), overlay.Content...)
+
+	if len(elements) == 0 {
+		return ""
+	}
+
+outer:
+	for _, key := range MergeKeys {
+		for _, element := range elements {
+			if element.Kind != yaml.MappingNode || mappingIndex(element, key) < 0 {
+				continue outer
+			}
+		}
+
+		return key
+	}
+
+	return ""
+}
+
+// popDirective removes the merge directive from the mapping and returns its value.
+func popDirective(node *yaml.Node) (string, error) {
+	if node.Kind != yaml.MappingNode {
+		return "", nil
+	}
+
+	idx := mappingIndex(node, DirectiveKey)
+	if idx < 0 {
+		return "", nil
+	}
+
+	value := node.Content[idx+1]
+	if value.Kind != yaml.ScalarNode {
+		return "", fmt.Errorf("directive on line %d should be a string", value.Line)
+	}
+
+	node.Content = append(node.Content[:idx], node.Content[idx+2:]...)
+
+	return value.Value, nil
+}
+
+func mappingIndex(node *yaml.Node, key string) int {
+	for i := 0; i < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func mappingValue(node *yaml.Node, key string) string {
+	idx := mappingIndex(node, key)
+	if idx < 0 {
+		return ""
+	}
+
+	return node.Content[idx+1].Value
+}
+
+func isNull(node *yaml.Node) bool {
+	return node.Kind == yaml.ScalarNode && node.ShortTag() == "!!null"
+}
@@ -0,0 +1,205 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package configlayers_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/pkg/machinery/config/configlayers"
+)
+
+const baseConfig = `version: v1alpha1
+machine:
+    type: join
+    kubelet:
+        extraArgs:
+            cloud-provider: external
+            node-labels: zone=a
+    network:
+        interfaces:
+            - interface: eth0
+              dhcp: true
+            - interface: eth1
+              cidr: 10.0.0.2/24
+    certSANs:
+        - 10.0.0.1
+cluster:
+    clusterName: test
+`
+
+func TestMerge(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range []struct {
+		name     string
+		overlays []string
+		expected string
+	}{
+		{
+			name:     "NoOverlays",
+			expected: baseConfig,
+		},
+		{
+			name: "MappingsAndScalars",
+			overlays: []string{
+				`machine:
+  type: controlplane
+  kubelet:
+    extraArgs:
+      node-labels: zone=b
+      rotate-server-certificates: "true"
+`,
+			},
+			expected: `version: v1alpha1
+machine:
+    type: controlplane
+    kubelet:
+        extraArgs:
+            cloud-provider: external
+            node-labels: zone=b
+            rotate-server-certificates: "true"
+    network:
+        interfaces:
+            - interface: eth0
+              dhcp: true
+            - interface: eth1
+              cidr: 10.0.0.2/24
+    certSANs:
+        - 10.0.0.1
+cluster:
+    clusterName: test
+`,
+		},
+		{
+			name: "NullRemovesKey",
+			overlays: []string{
+				`machine:
+  kubelet:
+    extraArgs:
+      cloud-provider: null
+`,
+			},
+			expected: `version: v1alpha1
+machine:
+    type: join
+    kubelet:
+        extraArgs:
+            node-labels: zone=a
+    network:
+        interfaces:
+            - interface: eth0
+              dhcp: true
+            - interface: eth1
+              cidr: 10.0.0.2/24
+    certSANs:
+        - 10.0.0.1
+cluster:
+    clusterName: test
+`,
+		},
+		{
+			name: "KeyedLists",
+			overlays: []string{
+				`machine:
+  network:
+    interfaces:
+      - interface: eth1
+        cidr: 10.0.0.3/24
+      - interface: eth2
+        dhcp: true
+  certSANs:
+    - 10.0.0.5
+`,
+				`machine:
+  network:
+    interfaces:
+      - interface: eth0
+        $patch: delete
+`,
+			},
+			expected: `version: v1alpha1
+machine:
+    type: join
+    kubelet:
+        extraArgs:
+            cloud-provider: external
+            node-labels: zone=a
+    network:
+        interfaces:
+            - interface: eth1
+              cidr: 10.0.0.3/24
+            - interface: eth2
+              dhcp: true
+    certSANs:
+        - 10.0.0.5
+cluster:
+    clusterName: test
+`,
+		},
+		{
+			name: "Replace",
+			overlays: []string{
+				`machine:
+  kubelet:
+    $patch: replace
+    image: ghcr.io/talos-systems/kubelet:v1.21.0
+  network:
+    interfaces:
+      - interface: eth0
+        $patch: replace
+        cidr: 10.0.1.2/24
+`,
+			},
+			expected: `version: v1alpha1
+machine:
+    type: join
+    kubelet:
+        image: ghcr.io/talos-systems/kubelet:v1.21.0
+    network:
+        interfaces:
+            - interface: eth0
+              cidr: 10.0.1.2/24
+            - interface: eth1
+              cidr: 10.0.0.2/24
+    certSANs:
+        - 10.0.0.1
+cluster:
+    clusterName: test
+`,
+		},
+	} {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			overlays := make([][]byte, len(test.overlays))
+			for i := range test.overlays {
+				overlays[i] = []byte(test.overlays[i])
+			}
+
+			merged, err := configlayers.Merge([]byte(baseConfig), overlays...)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, string(merged))
+		})
+	}
+}
+
+func TestMergeErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := configlayers.Merge([]byte(baseConfig), []byte("machine:\n  kubelet:\n    $patch: merge\n"))
+	assert.EqualError(t, err, "error merging overlay 0: machine: kubelet: unsupported directive \"merge\" on line 3")
+
+	_, err = configlayers.Merge([]byte(baseConfig), []byte("machine: {}\n---\ncluster: {}\n"))
+	assert.EqualError(t, err, "error parsing overlay 0: multiple documents are not supported")
+
+	_, err = configlayers.Merge([]byte("- a\n- b\n"))
+	assert.EqualError(t, err, "error parsing base: expected a mapping at the top level")
+}
@@ -66,3 +66,73 @@ func TestJSON6902(t *testing.T) {
 		})
 	}
 }
+
+const strategicMergeConfig = `machine:
+  certSANs:
+    - 10.0.0.1
+  kubelet:
+    extraArgs:
+      rotate-server-certificates: "true"
+  type: join
+`
+
+const strategicMergePatched = `machine:
+  certSANs:
+  - 10.0.0.1
+  - 10.0.0.2
+  kubelet:
+    extraArgs:
+      cloud-provider: external
+  type: join
+`
+
+func TestStrategicMerge(t *testing.T) {
+	patch, err := LoadPatch([]byte(`machine:
+  certSANs:
+    - 10.0.0.2
+  kubelet:
+    extraArgs:
+      rotate-server-certificates: null
+      cloud-provider: external
+`))
+	if err != nil {
+		t.Fatalf("LoadPatch error: %v", err)
+	}
+
+	if _, ok := patch.(StrategicMergePatch); !ok {
+		t.Fatalf("LoadPatch got %T, but wanted StrategicMergePatch", patch)
+	}
+
+	got, err := Apply([]byte(strategicMergeConfig), []Patch{patch})
+	if err != nil {
+		t.Fatalf("Apply error: %v", err)
+	}
+
+	if string(got) != strategicMergePatched {
+		t.Errorf("StrategicMerge got: \n%v\n but wanted: \n%v", string(got), strategicMergePatched)
+	}
+}
+
+func TestLoadPatch(t *testing.T) {
+	patch, err := LoadPatch([]byte(`- op: add
+  path: /machine/kubelet/extraArgs
+  value:
+    cloud-provider: external
+`))
+	if err != nil {
+		t.Fatalf("LoadPatch error: %v", err)
+	}
+
+	got, err := patch.Apply([]byte(dummyConfig))
+	if err != nil {
+		t.Fatalf("Apply error: %v", err)
+	}
+
+	if string(got) != cloudProviderPatched {
+		t.Errorf("JSON6902 got: \n%v\n but wanted: \n%v", string(got), cloudProviderPatched)
+	}
+
+	if _, err = LoadPatch([]byte(`"string"`)); err == nil {
+		t.Errorf("LoadPatch expected error for the scalar patch")
+	}
+}
@@ -0,0 +1,170 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package configpatcher
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	ghodssyaml "github.com/ghodss/yaml"
+)
+
+// Patch is a Talos config patch.
+type Patch interface {
+	Apply(talosMachineConfig []byte) ([]byte, error)
+}
+
+// JSON6902Patch is a JSON patch (RFC6902).
+type JSON6902Patch jsonpatch.Patch
+
+// Apply implements Patch.
+func (p JSON6902Patch) Apply(talosMachineConfig []byte) ([]byte, error) {
+	return JSON6902(talosMachineConfig, jsonpatch.Patch(p))
+}
+
+// StrategicMergePatch is a partial Talos config merged into the config.
+type StrategicMergePatch map[string]interface{}
+
+// Apply implements Patch.
+func (p StrategicMergePatch) Apply(talosMachineConfig []byte) ([]byte, error) {
+	return StrategicMerge(talosMachineConfig, p)
+}
+
+// LoadPatch loads the patch in JSON or YAML format.
+//
+// List of operations is loaded as JSON patch, object is loaded as strategic merge patch.
+func LoadPatch(in []byte) (Patch, error) {
+	jsonData, err := ghodssyaml.YAMLToJSON(in)
+	if err != nil {
+		return nil, fmt.Errorf("failure converting patch to json: %s", err)
+	}
+
+	jsonData = bytes.TrimSpace(jsonData)
+
+	switch {
+	case bytes.HasPrefix(jsonData, []byte("[")):
+		var patch jsonpatch.Patch
+
+		if patch, err = jsonpatch.DecodePatch(jsonData); err != nil {
+			return nil, fmt.Errorf("failure decoding rfc6902 patch: %s", err)
+		}
+
+		return JSON6902Patch(patch), nil
+	case bytes.HasPrefix(jsonData, []byte("{")):
+		var patch StrategicMergePatch
+
+		if err = json.Unmarshal(jsonData, &patch); err != nil {
+			return nil, fmt.Errorf("failure decoding strategic merge patch: %s", err)
+		}
+
+		return patch, nil
+	default:
+		return nil, fmt.Errorf("patch should be either a list of rfc6902 operations or a partial config")
+	}
+}
+
+// LoadPatches loads the patches, patches starting with `@` are read from the files.
+func LoadPatches(in []string) ([]Patch, error) {
+	patches := make([]Patch, 0, len(in))
+
+	for _, spec := range in {
+		data := []byte(spec)
+
+		if strings.HasPrefix(spec, "@") {
+			var err error
+
+			if data, err = ioutil.ReadFile(spec[1:]); err != nil {
+				return nil, err
+			}
+		}
+
+		patch, err := LoadPatch(data)
+		if err != nil {
+			return nil, err
+		}
+
+		patches = append(patches, patch)
+	}
+
+	return patches, nil
+}
+
+// Apply applies the patches in order.
+func Apply(talosMachineConfig []byte, patches []Patch) ([]byte, error) {
+	var err error
+
+	for _, patch := range patches {
+		if talosMachineConfig, err = patch.Apply(talosMachineConfig); err != nil {
+			return nil, err
+		}
+	}
+
+	return talosMachineConfig, nil
+}
+
+// StrategicMerge merges the partial config into the Talos config.
+//
+// Maps are merged recursively, lists are appended, other values are replaced, `null` removes the key.
+func StrategicMerge(talosMachineConfig []byte, patch map[string]interface{}) ([]byte, error) {
+	jsonDecodedData, err := ghodssyaml.YAMLToJSON(talosMachineConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failure converting talos machine config to json: %s", err)
+	}
+
+	var config map[string]interface{}
+
+	if err = json.Unmarshal(jsonDecodedData, &config); err != nil {
+		return nil, fmt.Errorf("failure decoding talos machine config: %s", err)
+	}
+
+	merged := mergeValue(config, map[string]interface{}(patch))
+
+	jsonDecodedData, err = json.Marshal(merged)
+	if err != nil {
+		return nil, fmt.Errorf("failure encoding merged talos machine config: %s", err)
+	}
+
+	talosMachineConfig, err = ghodssyaml.JSONToYAML(jsonDecodedData)
+	if err != nil {
+		return nil, fmt.Errorf("failure converting talos machine config from json to yaml: %s", err)
+	}
+
+	return talosMachineConfig, nil
+}
+
+func mergeValue(dst, src interface{}) interface{} {
+	switch s := src.(type) {
+	case map[string]interface{}:
+		d, ok := dst.(map[string]interface{})
+		if !ok || d == nil {
+			d = map[string]interface{}{}
+		}
+
+		for k, v := range s {
+			if v == nil {
+				delete(d, k)
+
+				continue
+			}
+
+			d[k] = mergeValue(d[k], v)
+		}
+
+		return d
+	case []interface{}:
+		d, ok := dst.([]interface{})
+		if !ok {
+			return s
+		}
+
+		return append(d, s...)
+	default:
+		return src
+	}
+}
@@ -24,6 +24,7 @@ type VersionContract struct {
 // Well-known Talos version contracts.
 var (
 	TalosVersionCurrent = (*VersionContract)(nil)
+	TalosVersion0_10    = &VersionContract{0, 10}
 	TalosVersion0_9     = &VersionContract{0, 9}
 	TalosVersion0_8     = &VersionContract{0, 8}
 )
@@ -45,6 +46,22 @@ func ParseContractFromVersion(version string) (*VersionContract, error) {
 	return &contract, nil
 }
 
+// String implements fmt.Stringer.
+func (contract *VersionContract) String() string {
+	if contract == nil {
+		return "current"
+	}
+
+	return fmt.Sprintf("v%d.%d", contract.Major, contract.Minor)
+}
+
+// Known returns true if the contract describes a version of Talos this machinery package knows about.
+//
+// Contracts for the versions released after this package can't be checked against.
+func (contract *VersionContract) Known() bool {
+	return contract == nil || !contract.Greater(TalosVersion0_10)
+}
+
 // Greater compares contract to another contract.
 func (contract *VersionContract) Greater(other *VersionContract) bool {
 	if contract == nil {
@@ -72,3 +89,148 @@ func (contract *VersionContract) SupportsAggregatorCA() bool {
 func (contract *VersionContract) SupportsServiceAccount() bool {
 	return contract.Greater(TalosVersion0_8)
 }
+
+// SupportsSystemDiskEncryption returns true if version of Talos supports .machine.systemDiskEncryption in the config.
+func (contract *VersionContract) SupportsSystemDiskEncryption() bool {
+	return contract.Greater(TalosVersion0_8)
+}
+
+// SupportsSharedIP returns true if version of Talos supports virtual (shared) IP on network interfaces.
+func (contract *VersionContract) SupportsSharedIP() bool {
+	return contract.Greater(TalosVersion0_8)
+}
+
+// SupportsWireguard returns true if version of Talos supports Wireguard network interfaces.
+func (contract *VersionContract) SupportsWireguard() bool {
+	return contract.Greater(TalosVersion0_8)
+}
+
+// SupportsInstallDiskSelector returns true if version of Talos supports .machine.install.diskSelector in the config.
+func (contract *VersionContract) SupportsInstallDiskSelector() bool {
+	return contract.Greater(TalosVersion0_9)
+}
+
+// SupportsJoinPolicy returns true if version of Talos supports .machine.joinPolicy in the config.
+func (contract *VersionContract) SupportsJoinPolicy() bool {
+	return contract.Greater(TalosVersion0_9)
+}
+
+// SupportsVirtualization returns true if version of Talos supports .machine.virtualization in the config.
+func (contract *VersionContract) SupportsVirtualization() bool {
+	return contract.Greater(TalosVersion0_9)
+}
+
+// SupportsBridgeInterfaces returns true if version of Talos supports bridge network interfaces.
+func (contract *VersionContract) SupportsBridgeInterfaces() bool {
+	return contract.Greater(TalosVersion0_9)
+}
+
+// SupportsWireguardKeyGeneration returns true if version of Talos supports generating Wireguard private keys on the node.
+func (contract *VersionContract) SupportsWireguardKeyGeneration() bool {
+	return contract.Greater(TalosVersion0_9)
+}
+
+// SupportsVlanVIP returns true if version of Talos supports virtual (shared) IP on VLAN interfaces.
+func (contract *VersionContract) SupportsVlanVIP() bool {
+	return contract.Greater(TalosVersion0_9)
+}
+
+// SupportsVIPCloudProviders returns true if version of Talos supports managing virtual (shared) IP via cloud provider APIs.
+func (contract *VersionContract) SupportsVIPCloudProviders() bool {
+	return contract.Greater(TalosVersion0_9)
+}
+
+// SupportsDHCPExtendedOptions returns true if version of Talos supports DUID, SLAAC and route/DNS ignoring in the DHCP options.
+func (contract *VersionContract) SupportsDHCPExtendedOptions() bool {
+	return contract.Greater(TalosVersion0_9)
+}
+
+// SupportsDeviceSelectors returns true if version of Talos supports picking network devices with a selector.
+func (contract *VersionContract) SupportsDeviceSelectors() bool {
+	return contract.Greater(TalosVersion0_9)
+}
+
+// SupportsEthernetConfig returns true if version of Talos supports ethernet hardware settings (Wake-on-LAN, offloads, rings).
+func (contract *VersionContract) SupportsEthernetConfig() bool {
+	return contract.Greater(TalosVersion0_9)
+}
+
+// SupportsKubeSpan returns true if version of Talos supports KubeSpan feature.
+func (contract *VersionContract) SupportsKubeSpan() bool {
+	return contract.Greater(TalosVersion0_9)
+}
+
+// SupportsFirewall returns true if version of Talos supports ingress firewall.
+func (contract *VersionContract) SupportsFirewall() bool {
+	return contract.Greater(TalosVersion0_9)
+}
+
+// SupportsSideroLink returns true if version of Talos supports SideroLink management tunnel.
+func (contract *VersionContract) SupportsSideroLink() bool {
+	return contract.Greater(TalosVersion0_9)
+}
+
+// SupportsCRIConfig returns true if version of Talos supports CRI sandbox image and config patches.
+func (contract *VersionContract) SupportsCRIConfig() bool {
+	return contract.Greater(TalosVersion0_9)
+}
+
+// SupportsNVIDIA returns true if version of Talos supports NVIDIA GPU configuration.
+func (contract *VersionContract) SupportsNVIDIA() bool {
+	return contract.Greater(TalosVersion0_9)
+}
+
+// SupportsReconcile returns true if version of Talos supports .machine.reconcile in the config.
+func (contract *VersionContract) SupportsReconcile() bool {
+	return contract.Greater(TalosVersion0_9)
+}
+
+// SupportsRegistration returns true if version of Talos supports node registration with the inventory API.
+func (contract *VersionContract) SupportsRegistration() bool {
+	return contract.Greater(TalosVersion0_9)
+}
+
+// SupportsHostnameSources returns true if version of Talos supports hostname templates and hostname sources.
+func (contract *VersionContract) SupportsHostnameSources() bool {
+	return contract.Greater(TalosVersion0_9)
+}
+
+// SupportsHealthz returns true if version of Talos supports node health endpoint.
+func (contract *VersionContract) SupportsHealthz() bool {
+	return contract.Greater(TalosVersion0_9)
+}
+
+// SupportsMetrics returns true if version of Talos supports node metrics endpoint.
+func (contract *VersionContract) SupportsMetrics() bool {
+	return contract.Greater(TalosVersion0_9)
+}
+
+// SupportsWatchdog returns true if version of Talos supports watchdog timer.
+func (contract *VersionContract) SupportsWatchdog() bool {
+	return contract.Greater(TalosVersion0_9)
+}
+
+// SupportsCrashDumps returns true if version of Talos supports kernel crash dumps capture.
+func (contract *VersionContract) SupportsCrashDumps() bool {
+	return contract.Greater(TalosVersion0_9)
+}
+
+// SupportsImageCache returns true if version of Talos supports local image cache.
+func (contract *VersionContract) SupportsImageCache() bool {
+	return contract.Greater(TalosVersion0_9)
+}
+
+// SupportsBootloaderSettings returns true if version of Talos supports bootloader type and console settings.
+func (contract *VersionContract) SupportsBootloaderSettings() bool {
+	return contract.Greater(TalosVersion0_9)
+}
+
+// SupportsFeatures returns true if version of Talos supports .machine.features in the config.
+func (contract *VersionContract) SupportsFeatures() bool {
+	return contract.Greater(TalosVersion0_9)
+}
+
+// SupportsPolicyRouting returns true if version of Talos supports routing rules and route source, MTU and table settings.
+func (contract *VersionContract) SupportsPolicyRouting() bool {
+	return contract.Greater(TalosVersion0_9)
+}
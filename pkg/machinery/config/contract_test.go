@@ -57,3 +57,68 @@ func TestContract0_8(t *testing.T) {
 	assert.False(t, config.TalosVersion0_8.SupportsECDSAKeys())
 	assert.False(t, config.TalosVersion0_8.SupportsServiceAccount())
 }
+
+func TestContractKnown(t *testing.T) {
+	assert.True(t, config.TalosVersionCurrent.Known())
+	assert.True(t, config.TalosVersion0_10.Known())
+	assert.True(t, config.TalosVersion0_8.Known())
+
+	assert.False(t, (&config.VersionContract{Major: 0, Minor: 11}).Known())
+	assert.False(t, (&config.VersionContract{Major: 1, Minor: 0}).Known())
+}
+
+func TestContract0_10Features(t *testing.T) {
+	assert.True(t, config.TalosVersion0_10.SupportsJoinPolicy())
+	assert.True(t, config.TalosVersion0_10.SupportsInstallDiskSelector())
+	assert.True(t, config.TalosVersion0_10.SupportsWireguardKeyGeneration())
+	assert.True(t, config.TalosVersion0_10.SupportsVlanVIP())
+	assert.True(t, config.TalosVersion0_10.SupportsVIPCloudProviders())
+	assert.True(t, config.TalosVersion0_10.SupportsDHCPExtendedOptions())
+	assert.True(t, config.TalosVersion0_10.SupportsPolicyRouting())
+	assert.True(t, config.TalosVersion0_10.SupportsDeviceSelectors())
+	assert.True(t, config.TalosVersion0_10.SupportsEthernetConfig())
+	assert.True(t, config.TalosVersion0_10.SupportsKubeSpan())
+	assert.True(t, config.TalosVersion0_10.SupportsFirewall())
+	assert.True(t, config.TalosVersion0_10.SupportsSideroLink())
+	assert.True(t, config.TalosVersion0_10.SupportsCRIConfig())
+	assert.True(t, config.TalosVersion0_10.SupportsNVIDIA())
+	assert.True(t, config.TalosVersion0_10.SupportsReconcile())
+	assert.True(t, config.TalosVersion0_10.SupportsRegistration())
+	assert.True(t, config.TalosVersion0_10.SupportsHostnameSources())
+	assert.True(t, config.TalosVersion0_10.SupportsHealthz())
+	assert.True(t, config.TalosVersion0_10.SupportsMetrics())
+	assert.True(t, config.TalosVersion0_10.SupportsWatchdog())
+	assert.True(t, config.TalosVersion0_10.SupportsCrashDumps())
+	assert.True(t, config.TalosVersion0_10.SupportsImageCache())
+	assert.True(t, config.TalosVersion0_10.SupportsBootloaderSettings())
+	assert.True(t, config.TalosVersion0_10.SupportsFeatures())
+
+	assert.False(t, config.TalosVersion0_9.SupportsJoinPolicy())
+	assert.False(t, config.TalosVersion0_9.SupportsInstallDiskSelector())
+	assert.False(t, config.TalosVersion0_9.SupportsWireguardKeyGeneration())
+	assert.False(t, config.TalosVersion0_9.SupportsVlanVIP())
+	assert.False(t, config.TalosVersion0_9.SupportsVIPCloudProviders())
+	assert.False(t, config.TalosVersion0_9.SupportsDHCPExtendedOptions())
+	assert.False(t, config.TalosVersion0_9.SupportsPolicyRouting())
+	assert.False(t, config.TalosVersion0_9.SupportsDeviceSelectors())
+	assert.False(t, config.TalosVersion0_9.SupportsEthernetConfig())
+	assert.False(t, config.TalosVersion0_9.SupportsKubeSpan())
+	assert.False(t, config.TalosVersion0_9.SupportsFirewall())
+	assert.False(t, config.TalosVersion0_9.SupportsSideroLink())
+	assert.False(t, config.TalosVersion0_9.SupportsCRIConfig())
+	assert.False(t, config.TalosVersion0_9.SupportsNVIDIA())
+	assert.False(t, config.TalosVersion0_9.SupportsReconcile())
+	assert.False(t, config.TalosVersion0_9.SupportsRegistration())
+	assert.False(t, config.TalosVersion0_9.SupportsHostnameSources())
+	assert.False(t, config.TalosVersion0_9.SupportsHealthz())
+	assert.False(t, config.TalosVersion0_9.SupportsMetrics())
+	assert.False(t, config.TalosVersion0_9.SupportsWatchdog())
+	assert.False(t, config.TalosVersion0_9.SupportsCrashDumps())
+	assert.False(t, config.TalosVersion0_9.SupportsImageCache())
+	assert.False(t, config.TalosVersion0_9.SupportsBootloaderSettings())
+	assert.False(t, config.TalosVersion0_9.SupportsFeatures())
+	assert.True(t, config.TalosVersion0_9.SupportsSharedIP())
+
+	assert.False(t, config.TalosVersion0_8.SupportsSharedIP())
+	assert.False(t, config.TalosVersion0_8.SupportsSystemDiskEncryption())
+}
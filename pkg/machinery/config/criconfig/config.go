@@ -2,7 +2,7 @@
 // License, v. 2.0. If a copy of the MPL was not distributed with this
 // file, You can obtain one at http://mozilla.org/MPL/2.0/.
 
-package containerd
+package criconfig
 
 // Mirror represents a registry mirror.
 type Mirror struct {
@@ -37,9 +37,27 @@ type Registry struct {
 	Configs map[string]RegistryConfig `toml:"configs"`
 }
 
+// Runtime represents a CRI runtime.
+type Runtime struct {
+	RuntimeType string          `toml:"runtime_type"`
+	Options     *RuntimeOptions `toml:"options,omitempty"`
+}
+
+// RuntimeOptions represents the runc shim options.
+type RuntimeOptions struct {
+	BinaryName string `toml:"BinaryName"`
+}
+
+// ContainerdConfig represents the CRI containerd options.
+type ContainerdConfig struct {
+	Runtimes map[string]Runtime `toml:"runtimes"`
+}
+
 // CRIConfig represents the CRI config.
 type CRIConfig struct {
-	Registry Registry `toml:"registry"`
+	SandboxImage string           `toml:"sandbox_image,omitempty"`
+	Containerd   ContainerdConfig `toml:"containerd"`
+	Registry     Registry         `toml:"registry"`
 }
 
 // PluginsConfig represents the CRI plugins config.
@@ -47,7 +65,7 @@ type PluginsConfig struct {
 	CRI CRIConfig `toml:"cri"`
 }
 
-// Config represnts the containerd config.
+// Config represents the containerd config.
 type Config struct {
 	Plugins PluginsConfig `toml:"plugins"`
 }
@@ -0,0 +1,122 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package criconfig renders the containerd CRI plugin configuration and applies the CRI config patches.
+package criconfig
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+
+	"github.com/talos-systems/talos/pkg/machinery/config"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+)
+
+// New builds the base CRI plugin configuration: sandbox image and runtimes.
+//
+// Registry mirrors and configs are empty, they are filled in by the caller.
+func New(cri config.CRI, nvidia config.NVIDIA) *Config {
+	var cfg Config
+
+	cfg.Plugins.CRI.SandboxImage = cri.SandboxImage()
+	cfg.Plugins.CRI.Containerd.Runtimes = map[string]Runtime{
+		"runc": {
+			RuntimeType: "io.containerd.runc.v2",
+		},
+	}
+
+	if nvidia.Enabled() {
+		cfg.Plugins.CRI.Containerd.Runtimes[constants.NVIDIARuntimeHandler] = Runtime{
+			RuntimeType: "io.containerd.runc.v2",
+			Options: &RuntimeOptions{
+				BinaryName: constants.NVIDIAContainerRuntimePath,
+			},
+		}
+	}
+
+	cfg.Plugins.CRI.Registry.Mirrors = make(map[string]Mirror)
+	cfg.Plugins.CRI.Registry.Configs = make(map[string]RegistryConfig)
+
+	return &cfg
+}
+
+// Encode renders the configuration as TOML and merges the patches into it.
+func Encode(cfg *Config, patches []string) ([]byte, error) {
+	var buf bytes.Buffer
+
+	if err := toml.NewEncoder(&buf).Encode(cfg); err != nil {
+		return nil, err
+	}
+
+	if len(patches) == 0 {
+		return buf.Bytes(), nil
+	}
+
+	return Patch(buf.Bytes(), patches)
+}
+
+// Patch merges TOML patches into the config in order.
+//
+// Tables are merged recursively, any other values set by the patch replace the existing values.
+// A table can't be replaced with a value and vice versa.
+func Patch(cfg []byte, patches []string) ([]byte, error) {
+	var merged map[string]interface{}
+
+	if _, err := toml.Decode(string(cfg), &merged); err != nil {
+		return nil, err
+	}
+
+	for i, patch := range patches {
+		var patchCfg map[string]interface{}
+
+		if _, err := toml.Decode(patch, &patchCfg); err != nil {
+			return nil, fmt.Errorf("error decoding CRI config patch %d: %w", i, err)
+		}
+
+		if err := mergeTables(nil, merged, patchCfg); err != nil {
+			return nil, fmt.Errorf("error applying CRI config patch %d: %w", i, err)
+		}
+	}
+
+	var buf bytes.Buffer
+
+	if err := toml.NewEncoder(&buf).Encode(merged); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func mergeTables(path []string, dst, src map[string]interface{}) error {
+	for key, value := range src {
+		keyPath := append(path[:len(path):len(path)], key)
+
+		srcTable, srcIsTable := value.(map[string]interface{})
+
+		existing, ok := dst[key]
+		if !ok {
+			dst[key] = value
+
+			continue
+		}
+
+		dstTable, dstIsTable := existing.(map[string]interface{})
+
+		switch {
+		case srcIsTable && dstIsTable:
+			if err := mergeTables(keyPath, dstTable, srcTable); err != nil {
+				return err
+			}
+		case srcIsTable != dstIsTable:
+			return fmt.Errorf("%q: table and value can't replace each other", strings.Join(keyPath, "."))
+		default:
+			dst[key] = value
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,93 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package criconfig_test
+
+import (
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/pkg/machinery/config/criconfig"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
+)
+
+func TestPatch(t *testing.T) {
+	cfg := criconfig.New(&v1alpha1.CRIConfig{
+		CRISandboxImage: "registry.example.com/pause:3.5",
+	}, &v1alpha1.NVIDIAConfig{})
+
+	patched, err := criconfig.Encode(cfg, []string{
+		`[plugins.cri.containerd.runtimes.runc.options]
+SystemdCgroup = true
+`,
+		`[plugins.cri]
+sandbox_image = "registry.example.com/pause:3.6"
+max_container_log_line_size = 32768
+`,
+	})
+	require.NoError(t, err)
+
+	var decoded struct {
+		Plugins struct {
+			CRI struct {
+				SandboxImage            string `toml:"sandbox_image"`
+				MaxContainerLogLineSize int    `toml:"max_container_log_line_size"`
+				Containerd              struct {
+					Runtimes map[string]struct {
+						RuntimeType string `toml:"runtime_type"`
+						Options     struct {
+							SystemdCgroup bool
+						} `toml:"options"`
+					} `toml:"runtimes"`
+				} `toml:"containerd"`
+			} `toml:"cri"`
+		} `toml:"plugins"`
+	}
+
+	_, err = toml.Decode(string(patched), &decoded)
+	require.NoError(t, err)
+
+	assert.Equal(t, "registry.example.com/pause:3.6", decoded.Plugins.CRI.SandboxImage)
+	assert.Equal(t, 32768, decoded.Plugins.CRI.MaxContainerLogLineSize)
+	assert.Equal(t, "io.containerd.runc.v2", decoded.Plugins.CRI.Containerd.Runtimes["runc"].RuntimeType)
+	assert.True(t, decoded.Plugins.CRI.Containerd.Runtimes["runc"].Options.SystemdCgroup)
+}
+
+func TestPatchErrors(t *testing.T) {
+	base := criconfig.New(&v1alpha1.CRIConfig{}, &v1alpha1.NVIDIAConfig{})
+
+	for _, tt := range []struct {
+		name          string
+		patches       []string
+		expectedError string
+	}{
+		{
+			name:          "invalid TOML",
+			patches:       []string{"[plugins.cri]\n", "[plugins.cri"},
+			expectedError: "error decoding CRI config patch 1",
+		},
+		{
+			name:          "value replaces table",
+			patches:       []string{"[plugins.cri.containerd]\nruntimes = \"runc\"\n"},
+			expectedError: `error applying CRI config patch 0: "plugins.cri.containerd.runtimes": table and value can't replace each other`,
+		},
+		{
+			name:          "table replaces value",
+			patches:       []string{"[plugins.cri.containerd.runtimes.runc.runtime_type]\nname = \"runc\"\n"},
+			expectedError: `error applying CRI config patch 0: "plugins.cri.containerd.runtimes.runc.runtime_type": table and value can't replace each other`,
+		},
+	} {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := criconfig.Encode(base, tt.patches)
+			require.Error(t, err)
+
+			assert.Contains(t, err.Error(), tt.expectedError)
+		})
+	}
+}
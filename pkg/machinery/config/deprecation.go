@@ -0,0 +1,29 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package config
+
+import (
+	"fmt"
+
+	"github.com/talos-systems/talos/pkg/machinery/config/encoder"
+)
+
+// Deprecation describes the use of a deprecated config field.
+type Deprecation struct {
+	encoder.Deprecation
+
+	// Path to the field in the config, e.g. `machine.network.interfaces[0].vlans[0].cidr`.
+	Path string
+}
+
+// String implements fmt.Stringer.
+func (d Deprecation) String() string {
+	hint := d.Deprecation.String()
+	if hint == "" {
+		return fmt.Sprintf("%q is deprecated", d.Path)
+	}
+
+	return fmt.Sprintf("%q is deprecated, %s", d.Path, hint)
+}
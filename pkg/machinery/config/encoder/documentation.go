@@ -5,6 +5,7 @@
 package encoder
 
 import (
+	"fmt"
 	"reflect"
 	"regexp"
 	"strings"
@@ -42,6 +43,31 @@ type Doc struct {
 	Note string
 	// AppearsIn describes back references for the type.
 	AppearsIn []Appearance
+	// Deprecated is set if the field is deprecated.
+	Deprecated *Deprecation
+}
+
+// Deprecation describes the deprecated field.
+type Deprecation struct {
+	// Replacement is the name of the field which replaces the deprecated field, if any.
+	Replacement string
+	// Removal is the version of Talos the field is going to be removed in, if known.
+	Removal string
+}
+
+// String returns the deprecation hint.
+func (d *Deprecation) String() string {
+	var hints []string
+
+	if d.Replacement != "" {
+		hints = append(hints, fmt.Sprintf("use %q instead", d.Replacement))
+	}
+
+	if d.Removal != "" {
+		hints = append(hints, fmt.Sprintf("will be removed in Talos %s", d.Removal))
+	}
+
+	return strings.Join(hints, ", ")
 }
 
 // AddExample adds a new example snippet to the doc.
@@ -61,6 +61,10 @@ Appears in:
 
 {{ $field.Description }}
 
+{{ if $field.Deprecated }}
+> Deprecated: {{ $field.Deprecated }}.
+{{ end -}}
+
 {{ if $field.Values }}
 Valid values:
 
@@ -28,6 +28,8 @@ type Provider interface {
 	Cluster() ClusterConfig
 	// Validate checks configuration and returns warnings and fatal errors (as multierror).
 	Validate(RuntimeMode, ...ValidationOption) ([]string, error)
+	// Deprecations returns the deprecated fields set in the config.
+	Deprecations() []Deprecation
 	ApplyDynamicConfig(context.Context, DynamicConfigProvider) error
 	String(encoderOptions ...encoder.Option) (string, error)
 	Bytes(encoderOptions ...encoder.Option) ([]byte, error)
@@ -46,8 +48,20 @@ type MachineConfig interface {
 	Type() machine.Type
 	Kubelet() Kubelet
 	Sysctls() map[string]string
+	Virtualization() Virtualization
 	Registries() Registries
 	SystemDiskEncryption() SystemDiskEncryption
+	Healthz() Healthz
+	Metrics() Metrics
+	Watchdog() Watchdog
+	CrashDumps() CrashDumps
+	ImageCache() ImageCache
+	Features() Features
+	CRI() CRI
+	NVIDIA() NVIDIA
+	Reconcile() Reconcile
+	Registration() Registration
+	Identity() Identity
 }
 
 // Disk represents the options available for partitioning, formatting, and
@@ -82,6 +96,22 @@ type Install interface {
 	ExtraKernelArgs() []string
 	Zero() bool
 	WithBootloader() bool
+	BootloaderType() string
+	Consoles() []InstallConsole
+	SecureBoot() InstallSecureBoot
+}
+
+// InstallSecureBoot defines the SecureBoot signing options.
+type InstallSecureBoot interface {
+	SigningKey() string
+	SigningCert() string
+}
+
+// InstallConsole defines the console device set in the boot entries.
+type InstallConsole interface {
+	Device() string
+	BaudRate() int
+	KernelArg() string
 }
 
 // Security defines the requirements for a config that pertains to security
@@ -90,15 +120,77 @@ type Security interface {
 	CA() *x509.PEMEncodedCertificateAndKey
 	Token() string
 	CertSANs() []string
+	JoinPolicy() JoinPolicy
+}
+
+// JoinPolicy defines the requirements for a config that pertains to trustd
+// certificate issuance authorization.
+type JoinPolicy interface {
+	Mode() string
+	AllowedIdentities() []string
+	Webhook() JoinPolicyWebhook
+}
+
+// JoinPolicyWebhook defines the settings for the external join authorizer.
+type JoinPolicyWebhook interface {
+	URL() string
+	CA() []byte
+	Timeout() time.Duration
 }
 
 // MachineNetwork defines the requirements for a config that pertains to network
 // related options.
 type MachineNetwork interface {
 	Hostname() string
+	HostnameSources() []string
 	Resolvers() []string
+	SearchDomains() []string
+	DisableSearchDomain() bool
 	Devices() []Device
 	ExtraHosts() []ExtraHost
+	Rules() []RoutingRule
+	KubeSpan() KubeSpan
+	Firewall() Firewall
+	SideroLink() SideroLink
+}
+
+// KubeSpan configures KubeSpan feature.
+type KubeSpan interface {
+	Enabled() bool
+	DiscoveryEndpoint() string
+}
+
+// SideroLink configures the management tunnel.
+type SideroLink interface {
+	APIURL() string
+}
+
+// Firewall configures the ingress firewall.
+type Firewall interface {
+	DefaultAction() string
+	Rules() []FirewallRule
+}
+
+// FirewallRule accepts the traffic to the ports from the source networks.
+type FirewallRule interface {
+	Name() string
+	Protocol() string
+	Ports() []PortRange
+	Subnets() []string
+}
+
+// PortRange is an inclusive range of ports.
+type PortRange struct {
+	Lo, Hi uint16
+}
+
+// RoutingRule represents a policy routing rule.
+type RoutingRule interface {
+	From() string
+	To() string
+	FwMark() uint32
+	Table() uint32
+	Priority() uint32
 }
 
 // ExtraHost represents a host entry in /etc/hosts.
@@ -107,37 +199,169 @@ type ExtraHost interface {
 	Aliases() []string
 }
 
+// Healthz defines the requirements for a config that pertains to the node health endpoint.
+type Healthz interface {
+	Enabled() bool
+	Port() int
+}
+
+// Metrics defines the requirements for a config that pertains to the node metrics endpoint.
+type Metrics interface {
+	Enabled() bool
+	Port() int
+	BearerToken() string
+}
+
+// Watchdog defines the requirements for a config that pertains to the watchdog timer.
+type Watchdog interface {
+	Enabled() bool
+	Device() string
+	Timeout() time.Duration
+}
+
+// CrashDumps defines the requirements for a config that pertains to the kernel crash dumps (panic logs) capture.
+type CrashDumps interface {
+	Destination() string
+	KernelArgs() []string
+}
+
+// ImageCache defines the requirements for a config that pertains to the local image cache.
+type ImageCache interface {
+	Enabled() bool
+}
+
+// CRI defines the requirements for a config that pertains to the CRI (containerd) settings.
+type CRI interface {
+	SandboxImage() string
+	ConfigPatches() []string
+}
+
+// NVIDIA defines the requirements for a config that pertains to the NVIDIA GPU support.
+type NVIDIA interface {
+	Enabled() bool
+}
+
+// Reconcile defines the requirements for a config that pertains to the automatic reconciliation
+// of the cluster state (manifests, static pods).
+type Reconcile interface {
+	Paused() bool
+	MaintenanceWindows() []MaintenanceWindow
+}
+
+// MaintenanceWindow defines the period of time when the automatic reconciliation is paused.
+type MaintenanceWindow interface {
+	Start() time.Time
+	Duration() time.Duration
+}
+
+// Registration defines the requirements for a config that pertains to the node registration
+// with the external inventory API.
+type Registration interface {
+	Endpoint() string
+	Token() string
+	HeartbeatInterval() time.Duration
+}
+
+// Identity defines the requirements for a config that pertains to the machine identity.
+type Identity interface {
+	// KMS returns the KMS plugin the identity key is sealed with, nil if not configured.
+	KMS() IdentityKMS
+}
+
+// IdentityKMS describes the KMS v2 plugin the machine identity is sealed with.
+type IdentityKMS interface {
+	Endpoint() string
+	Timeout() time.Duration
+}
+
+// Features defines the requirements for a config that pertains to the optional Talos features.
+type Features interface {
+	KexecEnabled() bool
+	LocalAPIAllowedUIDs() []uint32
+	LocalAPIAllowedGIDs() []uint32
+	KubeletLocalAPIEnabled() bool
+	StrictTLSEnabled() bool
+}
+
+// Virtualization defines the requirements for a config that pertains to hardware
+// virtualization (KVM) options.
+type Virtualization interface {
+	Nested() bool
+	KVMOptions() map[string]string
+	KernelArgs() []string
+}
+
 // Device represents a network interface.
 type Device interface {
 	Interface() string
+	Selector() NetworkDeviceSelector
 	CIDR() string
 	Routes() []Route
 	Bond() Bond
+	Bridge() Bridge
 	Vlans() []Vlan
 	MTU() int
+	Ethernet() EthernetConfig
 	DHCP() bool
 	Ignore() bool
 	Dummy() bool
+	MacvtapParent() bool
 	DHCPOptions() DHCPOptions
 	VIPConfig() VIPConfig
 	WireguardConfig() WireguardConfig
 }
 
+// EthernetConfig contains ethernet hardware settings of the device.
+type EthernetConfig interface {
+	WakeOnLAN() []string
+	TSO() *bool
+	GRO() *bool
+	LRO() *bool
+	RingsRX() uint32
+	RingsTX() uint32
+}
+
+// NetworkDeviceSelector defines the set of fields that can be used to pick the network device.
+type NetworkDeviceSelector interface {
+	BusPath() string
+	HardwareAddress() string
+	KernelDriver() string
+	Speed() uint32
+}
+
 // DHCPOptions represents a set of DHCP options.
 type DHCPOptions interface {
 	RouteMetric() uint32
 	IPv4() bool
 	IPv6() bool
+	IgnoreDefaultRoutes() bool
+	IgnoreDNS() bool
+	DUIDv6() string
+	SLAAC() bool
 }
 
 // VIPConfig contains settings for the Virtual (shared) IP setup.
 type VIPConfig interface {
 	IP() string
+	EquinixMetal() VIPEquinixMetal
+	HCloud() VIPHCloud
+}
+
+// VIPEquinixMetal contains Equinix Metal API VIP settings.
+type VIPEquinixMetal interface {
+	APIToken() string
+	BGP() bool
+}
+
+// VIPHCloud contains Hetzner Cloud API VIP settings.
+type VIPHCloud interface {
+	APIToken() string
 }
 
 // WireguardConfig contains settings for configuring Wireguard network interface.
 type WireguardConfig interface {
 	PrivateKey() string
+	GeneratePrivateKey() bool
 	ListenPort() int
 	FirewallMark() int
 	Peers() []WireguardPeer
@@ -183,12 +407,31 @@ type Bond interface {
 	PeerNotifyDelay() uint32
 }
 
+// Bridge contains the options for configuring a bridged interface.
+type Bridge interface {
+	Interfaces() []string
+	STP() STP
+	VLAN() BridgeVLAN
+}
+
+// STP contains the Spanning Tree Protocol settings of a bridge.
+type STP interface {
+	Enabled() bool
+}
+
+// BridgeVLAN contains the VLAN settings of a bridge.
+type BridgeVLAN interface {
+	FilteringEnabled() bool
+}
+
 // Vlan represents vlan settings for a device.
 type Vlan interface {
 	CIDR() string
+	Addresses() []string
 	Routes() []Route
 	DHCP() bool
 	ID() uint16
+	VIPConfig() VIPConfig
 }
 
 // Route represents a network route.
@@ -196,6 +439,9 @@ type Route interface {
 	Network() string
 	Gateway() string
 	Metric() uint32
+	Source() string
+	MTU() uint32
+	Table() uint32
 }
 
 // Time defines the requirements for a config that pertains to time related
@@ -252,6 +498,10 @@ type RegistryTLSConfig interface {
 // ClusterConfig defines the requirements for a config that pertains to cluster
 // related options.
 type ClusterConfig interface {
+	// ID returns the globally unique identifier of the cluster.
+	ID() string
+	// Secret returns the shared secret of the cluster.
+	Secret() string
 	Name() string
 	APIServer() APIServer
 	ControllerManager() ControllerManager
@@ -264,6 +514,12 @@ type ClusterConfig interface {
 	AggregatorCA() *x509.PEMEncodedCertificateAndKey
 	ServiceAccount() *x509.PEMEncodedKey
 	AESCBCEncryptionSecret() string
+	// AESCBCEncryptionKeyName returns the name of the AESCBCEncryptionSecret key.
+	AESCBCEncryptionKeyName() string
+	// AESCBCAdditionalEncryptionKeys returns the keys used only for decryption.
+	AESCBCAdditionalEncryptionKeys() []AESCBCEncryptionKey
+	// SecretsEncryption returns the settings of the encryption of secret data at rest.
+	SecretsEncryption() SecretsEncryption
 	Config(machine.Type) (string, error)
 	Etcd() Etcd
 	Network() ClusterNetwork
@@ -273,6 +529,7 @@ type ClusterConfig interface {
 	ExternalCloudProvider() ExternalCloudProvider
 	ExtraManifestURLs() []string
 	ExtraManifestHeaderMap() map[string]string
+	ManifestsSync() ManifestsSync
 	AdminKubeconfig() AdminKubeconfig
 	ScheduleOnMasters() bool
 }
@@ -369,6 +626,12 @@ type AdminKubeconfig interface {
 	CertLifetime() time.Duration
 }
 
+// ManifestsSync defines settings for the continuous reconciliation of the bootstrap manifests.
+type ManifestsSync interface {
+	Interval() time.Duration
+	UnmanagedManifests() []string
+}
+
 // EncryptionKey defines settings for the partition encryption key handling.
 type EncryptionKey interface {
 	Static() EncryptionKeyStatic
@@ -396,6 +659,35 @@ type SystemDiskEncryption interface {
 	Get(label string) Encryption
 }
 
+// AESCBCEncryptionKey describes the key for the encryption of secret data at rest.
+type AESCBCEncryptionKey interface {
+	Name() string
+	Secret() string
+}
+
+// SecretsEncryption describes the encryption of secret data at rest.
+type SecretsEncryption interface {
+	// Provider returns the provider used to encrypt the data.
+	Provider() string
+	// Secretbox returns the secretbox provider key, nil if not configured.
+	Secretbox() SecretboxEncryption
+	// KMS returns the KMS plugin provider, nil if not configured.
+	KMS() KMSEncryption
+}
+
+// SecretboxEncryption describes the key of the secretbox provider.
+type SecretboxEncryption interface {
+	Name() string
+	Secret() string
+}
+
+// KMSEncryption describes the KMS v2 plugin provider.
+type KMSEncryption interface {
+	Name() string
+	Endpoint() string
+	Timeout() time.Duration
+}
+
 // VolumeMount describes extra volume mount for the static pods.
 type VolumeMount interface {
 	Name() string
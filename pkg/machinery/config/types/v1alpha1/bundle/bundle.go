@@ -60,8 +60,8 @@ func NewConfigBundle(opts ...Option) (*v1alpha1.ConfigBundle, error) {
 			}
 		}
 
-		if err := bundle.ApplyJSONPatch(options.JSONPatch); err != nil {
-			return nil, fmt.Errorf("error patching configs: %w", err)
+		if err := applyPatches(bundle, &options); err != nil {
+			return nil, err
 		}
 
 		// Pull existing talosconfig
@@ -124,8 +124,8 @@ func NewConfigBundle(opts ...Option) (*v1alpha1.ConfigBundle, error) {
 		}
 	}
 
-	if err = bundle.ApplyJSONPatch(options.JSONPatch); err != nil {
-		return nil, fmt.Errorf("error patching configs: %w", err)
+	if err = applyPatches(bundle, &options); err != nil {
+		return nil, err
 	}
 
 	bundle.TalosCfg, err = generate.Talosconfig(input, options.InputOptions.GenOptions...)
@@ -135,3 +135,29 @@ func NewConfigBundle(opts ...Option) (*v1alpha1.ConfigBundle, error) {
 
 	return bundle, nil
 }
+
+func applyPatches(bundle *v1alpha1.ConfigBundle, options *Options) error {
+	if err := bundle.ApplyJSONPatch(options.JSONPatch, true, true); err != nil {
+		return fmt.Errorf("error patching configs: %w", err)
+	}
+
+	if err := bundle.ApplyPatches(options.Patches, true, true); err != nil {
+		return fmt.Errorf("error patching configs: %w", err)
+	}
+
+	if err := bundle.ApplyPatches(options.PatchesControlPlane, true, false); err != nil {
+		return fmt.Errorf("error patching controlplane configs: %w", err)
+	}
+
+	if err := bundle.ApplyPatches(options.PatchesJoin, false, true); err != nil {
+		return fmt.Errorf("error patching join config: %w", err)
+	}
+
+	for name, patches := range options.WorkerClasses {
+		if err := bundle.AddWorkerClass(name, patches); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package bundle_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/pkg/machinery/config/configpatcher"
+	"github.com/talos-systems/talos/pkg/machinery/config/encoder"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1/bundle"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1/machine"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+)
+
+func TestWorkerClasses(t *testing.T) {
+	patches, err := configpatcher.LoadPatches([]string{`machine:
+  kubelet:
+    extraArgs:
+      node-labels: class=gpu
+`})
+	require.NoError(t, err)
+
+	b, err := bundle.NewConfigBundle(
+		bundle.WithInputOptions(
+			&bundle.InputOptions{
+				ClusterName: "talos-default",
+				Endpoint:    "https://10.5.0.1:6443",
+				KubeVersion: constants.DefaultKubernetesVersion,
+			},
+		),
+		bundle.WithVerbose(false),
+		bundle.WithWorkerClass("gpu", patches),
+	)
+	require.NoError(t, err)
+
+	assert.Nil(t, b.WorkerClass("storage"))
+	assert.Empty(t, b.Join().Machine().Kubelet().ExtraArgs())
+
+	gpu := b.WorkerClass("gpu")
+	require.NotNil(t, gpu)
+
+	assert.Equal(t, machine.TypeJoin, gpu.Machine().Type())
+	assert.Equal(t, map[string]string{"node-labels": "class=gpu"}, gpu.Machine().Kubelet().ExtraArgs())
+	assert.Equal(t, b.Join().Machine().Security().CA(), gpu.Machine().Security().CA())
+	assert.Equal(t, b.Join().Cluster().Token().ID(), gpu.Cluster().Token().ID())
+
+	dir, err := ioutil.TempDir("", "talos")
+	require.NoError(t, err)
+
+	defer os.RemoveAll(dir) //nolint:errcheck
+
+	require.NoError(t, b.Write(dir, encoder.CommentsDisabled, machine.TypeJoin))
+
+	assert.FileExists(t, filepath.Join(dir, "join.yaml"))
+	assert.FileExists(t, filepath.Join(dir, "join-gpu.yaml"))
+}
+
+func TestWorkerClassInvalid(t *testing.T) {
+	for _, name := range []string{"", "GPU", "gpu/a", "-gpu"} {
+		_, err := bundle.NewConfigBundle(bundle.WithWorkerClass(name, nil))
+		assert.Error(t, err, name)
+	}
+
+	_, err := bundle.NewConfigBundle(bundle.WithWorkerClass("gpu", nil), bundle.WithWorkerClass("gpu", nil))
+	assert.EqualError(t, err, "duplicate worker class \"gpu\"")
+}
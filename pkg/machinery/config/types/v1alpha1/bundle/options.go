@@ -5,8 +5,12 @@
 package bundle
 
 import (
+	"fmt"
+	"regexp"
+
 	jsonpatch "github.com/evanphx/json-patch"
 
+	"github.com/talos-systems/talos/pkg/machinery/config/configpatcher"
 	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1/generate"
 )
 
@@ -27,6 +31,14 @@ type Options struct {
 	Verbose         bool   // wheither to write any logs during generate
 	InputOptions    *InputOptions
 	JSONPatch       jsonpatch.Patch
+
+	// Patches applied to every config, init and controlplane configs, join config.
+	Patches             []configpatcher.Patch
+	PatchesControlPlane []configpatcher.Patch
+	PatchesJoin         []configpatcher.Patch
+
+	// WorkerClasses are patches applied on top of the join config to generate a config per worker class.
+	WorkerClasses map[string][]configpatcher.Patch
 }
 
 // DefaultOptions returns default options.
@@ -71,3 +83,77 @@ func WithJSONPatch(patch jsonpatch.Patch) Option {
 		return nil
 	}
 }
+
+// WithJSONPatchControlPlane allows patching init and controlplane configs in a bundle with a patch.
+func WithJSONPatchControlPlane(patch jsonpatch.Patch) Option {
+	return func(o *Options) error {
+		if len(patch) > 0 {
+			o.PatchesControlPlane = append(o.PatchesControlPlane, configpatcher.JSON6902Patch(patch))
+		}
+
+		return nil
+	}
+}
+
+// WithJSONPatchJoin allows patching join config in a bundle with a patch.
+func WithJSONPatchJoin(patch jsonpatch.Patch) Option {
+	return func(o *Options) error {
+		if len(patch) > 0 {
+			o.PatchesJoin = append(o.PatchesJoin, configpatcher.JSON6902Patch(patch))
+		}
+
+		return nil
+	}
+}
+
+// WithPatch allows patching every config in a bundle with the patches.
+func WithPatch(patches []configpatcher.Patch) Option {
+	return func(o *Options) error {
+		o.Patches = append(o.Patches, patches...)
+
+		return nil
+	}
+}
+
+// WithPatchControlPlane allows patching init and controlplane configs in a bundle with the patches.
+func WithPatchControlPlane(patches []configpatcher.Patch) Option {
+	return func(o *Options) error {
+		o.PatchesControlPlane = append(o.PatchesControlPlane, patches...)
+
+		return nil
+	}
+}
+
+// WithPatchJoin allows patching join config in a bundle with the patches.
+func WithPatchJoin(patches []configpatcher.Patch) Option {
+	return func(o *Options) error {
+		o.PatchesJoin = append(o.PatchesJoin, patches...)
+
+		return nil
+	}
+}
+
+var workerClassRegexp = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// WithWorkerClass generates an additional join config for the worker class with the patches applied.
+//
+// Worker class config is based on the join config with all the patches for the join config applied.
+func WithWorkerClass(name string, patches []configpatcher.Patch) Option {
+	return func(o *Options) error {
+		if !workerClassRegexp.MatchString(name) {
+			return fmt.Errorf("invalid worker class name %q: should consist of lower case alphanumeric characters or '-'", name)
+		}
+
+		if _, exists := o.WorkerClasses[name]; exists {
+			return fmt.Errorf("duplicate worker class %q", name)
+		}
+
+		if o.WorkerClasses == nil {
+			o.WorkerClasses = map[string][]configpatcher.Patch{}
+		}
+
+		o.WorkerClasses[name] = patches
+
+		return nil
+	}
+}
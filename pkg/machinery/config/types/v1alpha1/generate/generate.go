@@ -138,6 +138,8 @@ type Certs struct {
 type Secrets struct {
 	BootstrapToken         string
 	AESCBCEncryptionSecret string
+	ClusterID              string
+	ClusterSecret          string
 }
 
 // TrustdInfo holds the trustd credentials.
@@ -237,16 +239,28 @@ func NewSecretsBundle(clock Clock, opts ...GenOption) (*SecretsBundle, error) {
 	kubeadmTokens = &Secrets{}
 
 	// Gen trustd token strings
-	kubeadmTokens.BootstrapToken, err = genToken(6, 16)
+	kubeadmTokens.BootstrapToken, err = NewBootstrapToken()
 	if err != nil {
 		return nil, err
 	}
 
-	kubeadmTokens.AESCBCEncryptionSecret, err = cis.CreateEncryptionToken()
+	kubeadmTokens.AESCBCEncryptionSecret, err = NewAESCBCEncryptionSecret()
 	if err != nil {
 		return nil, err
 	}
 
+	if options.VersionContract.SupportsKubeSpan() {
+		kubeadmTokens.ClusterID, err = NewClusterSecret()
+		if err != nil {
+			return nil, err
+		}
+
+		kubeadmTokens.ClusterSecret, err = NewClusterSecret()
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	trustdInfo = &TrustdInfo{}
 
 	// Gen trustd token strings
@@ -314,6 +328,8 @@ func NewSecretsBundleFromConfig(clock Clock, c config.Provider) *SecretsBundle {
 	secrets := &Secrets{
 		AESCBCEncryptionSecret: c.Cluster().AESCBCEncryptionSecret(),
 		BootstrapToken:         bootstrapToken,
+		ClusterID:              c.Cluster().ID(),
+		ClusterSecret:          c.Cluster().Secret(),
 	}
 
 	return &SecretsBundle{
@@ -381,6 +397,21 @@ func NewTalosCA(currentTime time.Time) (ca *x509.CertificateAuthority, err error
 	return x509.NewSelfSignedCertificateAuthority(opts...)
 }
 
+// NewBootstrapToken generates the Kubernetes bootstrap token.
+func NewBootstrapToken() (string, error) {
+	return genToken(6, 16)
+}
+
+// NewAESCBCEncryptionSecret generates the key for the encryption of secret data at rest.
+func NewAESCBCEncryptionSecret() (string, error) {
+	return cis.CreateEncryptionToken()
+}
+
+// NewClusterSecret generates the random cluster identifier or the shared cluster secret.
+func NewClusterSecret() (string, error) {
+	return cis.CreateEncryptionToken()
+}
+
 // NewAdminCertificateAndKey generates the admin Talos certifiate and key.
 func NewAdminCertificateAndKey(currentTime time.Time, ca *x509.PEMEncodedCertificateAndKey, loopback string) (p *x509.PEMEncodedCertificateAndKey, err error) {
 	ips := []net.IP{net.ParseIP(loopback)}
@@ -90,6 +90,8 @@ func initUd(in *Input) (*v1alpha1.Config, error) {
 		ClusterServiceAccount:         in.Certs.K8sServiceAccount,
 		BootstrapToken:                in.Secrets.BootstrapToken,
 		ClusterAESCBCEncryptionSecret: in.Secrets.AESCBCEncryptionSecret,
+		ClusterID:                     in.Secrets.ClusterID,
+		ClusterSecret:                 in.Secrets.ClusterSecret,
 	}
 
 	config.MachineConfig = machine
@@ -60,6 +60,8 @@ func workerUd(in *Input) (*v1alpha1.Config, error) {
 	cluster := &v1alpha1.ClusterConfig{
 		ClusterCA:      &x509.PEMEncodedCertificateAndKey{Crt: in.Certs.K8s.Crt},
 		BootstrapToken: in.Secrets.BootstrapToken,
+		ClusterID:      in.Secrets.ClusterID,
+		ClusterSecret:  in.Secrets.ClusterSecret,
 		ControlPlane: &v1alpha1.ControlPlaneConfig{
 			Endpoint: &v1alpha1.Endpoint{URL: controlPlaneURL},
 		},
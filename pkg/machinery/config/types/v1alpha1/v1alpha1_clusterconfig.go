@@ -18,7 +18,7 @@ import (
 	"github.com/talos-systems/talos/pkg/machinery/constants"
 )
 
-// ClusterConfig implements config.ClusterConfig, config.Token, and config.ClusterNetwork interfaces.
+// ClusterConfig implements config.ClusterConfig and config.ClusterNetwork interfaces.
 
 // Name implements the config.ClusterConfig interface.
 func (c *ClusterConfig) Name() string {
@@ -68,7 +68,7 @@ func (c *ClusterConfig) Endpoint() *url.URL {
 
 // Token implements the config.ClusterConfig interface.
 func (c *ClusterConfig) Token() config.Token {
-	return c
+	return bootstrapToken(c.BootstrapToken)
 }
 
 // CertSANs implements the config.ClusterConfig interface.
@@ -91,11 +91,50 @@ func (c *ClusterConfig) ServiceAccount() *x509.PEMEncodedKey {
 	return c.ClusterServiceAccount
 }
 
+// ID implements the config.ClusterConfig interface.
+func (c *ClusterConfig) ID() string {
+	return c.ClusterID
+}
+
+// Secret implements the config.ClusterConfig interface.
+func (c *ClusterConfig) Secret() string {
+	return c.ClusterSecret
+}
+
 // AESCBCEncryptionSecret implements the config.ClusterConfig interface.
 func (c *ClusterConfig) AESCBCEncryptionSecret() string {
 	return c.ClusterAESCBCEncryptionSecret
 }
 
+// AESCBCEncryptionKeyName implements the config.ClusterConfig interface.
+func (c *ClusterConfig) AESCBCEncryptionKeyName() string {
+	if c.ClusterAESCBCEncryptionKeyName == "" {
+		return constants.DefaultAESCBCEncryptionKeyName
+	}
+
+	return c.ClusterAESCBCEncryptionKeyName
+}
+
+// AESCBCAdditionalEncryptionKeys implements the config.ClusterConfig interface.
+func (c *ClusterConfig) AESCBCAdditionalEncryptionKeys() []config.AESCBCEncryptionKey {
+	keys := make([]config.AESCBCEncryptionKey, 0, len(c.ClusterAESCBCAdditionalEncryptionKeys))
+
+	for _, key := range c.ClusterAESCBCAdditionalEncryptionKeys {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
+
+// SecretsEncryption implements the config.ClusterConfig interface.
+func (c *ClusterConfig) SecretsEncryption() config.SecretsEncryption {
+	if c.SecretsEncryptionConfig == nil {
+		return &SecretsEncryptionConfig{}
+	}
+
+	return c.SecretsEncryptionConfig
+}
+
 // Config implements the config.ClusterConfig interface.
 func (c *ClusterConfig) Config(t machine.Type) (string, error) {
 	return "", nil
@@ -152,6 +191,15 @@ func (c *ClusterConfig) ExtraManifestHeaderMap() map[string]string {
 	return c.ExtraManifestHeaders
 }
 
+// ManifestsSync implements the config.ClusterConfig interface.
+func (c *ClusterConfig) ManifestsSync() config.ManifestsSync {
+	if c.ManifestsSyncConfig == nil {
+		return &ManifestsSyncConfig{}
+	}
+
+	return c.ManifestsSyncConfig
+}
+
 // AdminKubeconfig implements the config.ClusterConfig interface.
 func (c *ClusterConfig) AdminKubeconfig() config.AdminKubeconfig {
 	if c.AdminKubeconfigConfig == nil {
@@ -166,9 +214,12 @@ func (c *ClusterConfig) ScheduleOnMasters() bool {
 	return c.AllowSchedulingOnMasters
 }
 
+// bootstrapToken implements the config.Token interface.
+type bootstrapToken string
+
 // ID implements the config.Token interface.
-func (c *ClusterConfig) ID() string {
-	parts := strings.Split(c.BootstrapToken, ".")
+func (t bootstrapToken) ID() string {
+	parts := strings.Split(string(t), ".")
 	if len(parts) != 2 {
 		return ""
 	}
@@ -177,8 +228,8 @@ func (c *ClusterConfig) ID() string {
 }
 
 // Secret implements the config.Token interface.
-func (c *ClusterConfig) Secret() string {
-	parts := strings.Split(c.BootstrapToken, ".")
+func (t bootstrapToken) Secret() string {
+	parts := strings.Split(string(t), ".")
 	if len(parts) != 2 {
 		return ""
 	}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	jsonpatch "github.com/evanphx/json-patch"
@@ -27,6 +28,9 @@ type ConfigBundle struct {
 	ControlPlaneCfg *Config
 	JoinCfg         *Config
 	TalosCfg        *clientconfig.Config
+
+	// WorkerClassCfgs are join configs for the worker classes, keyed by the class name.
+	WorkerClassCfgs map[string]*Config
 }
 
 // Init implements the ConfiguratorBundle interface.
@@ -44,12 +48,26 @@ func (c *ConfigBundle) Join() config.Provider {
 	return c.JoinCfg
 }
 
+// WorkerClass returns the join config for the worker class, nil if the class is not defined.
+func (c *ConfigBundle) WorkerClass(name string) config.Provider {
+	cfg, ok := c.WorkerClassCfgs[name]
+	if !ok {
+		return nil
+	}
+
+	return cfg
+}
+
 // TalosConfig implements the ConfiguratorBundle interface.
 func (c *ConfigBundle) TalosConfig() *clientconfig.Config {
 	return c.TalosCfg
 }
 
 // Write config files to output directory.
+//
+// Worker class configs are written along with the join config as `join-<class>.yaml`.
+//
+//nolint:gocyclo
 func (c *ConfigBundle) Write(outputDir string, commentsFlags encoder.CommentsFlags, types ...machine.Type) error {
 	for _, t := range types {
 		name := strings.ToLower(t.String()) + ".yaml"
@@ -83,54 +101,120 @@ func (c *ConfigBundle) Write(outputDir string, commentsFlags encoder.CommentsFla
 		}
 
 		fmt.Printf("created %s\n", fullFilePath)
+
+		if t == machine.TypeJoin {
+			if err = c.writeWorkerClasses(outputDir, commentsFlags); err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
 }
 
-// ApplyJSONPatch patches every config type with a patch.
-func (c *ConfigBundle) ApplyJSONPatch(patch jsonpatch.Patch) error {
-	if len(patch) == 0 {
-		return nil
+func (c *ConfigBundle) writeWorkerClasses(outputDir string, commentsFlags encoder.CommentsFlags) error {
+	classes := make([]string, 0, len(c.WorkerClassCfgs))
+
+	for class := range c.WorkerClassCfgs {
+		classes = append(classes, class)
 	}
 
-	apply := func(in *Config) (out *Config, err error) {
-		var marshaled []byte
+	sort.Strings(classes)
 
-		marshaled, err = in.Bytes()
+	for _, class := range classes {
+		configString, err := c.WorkerClassCfgs[class].String(encoder.WithComments(commentsFlags))
 		if err != nil {
-			return nil, err
+			return err
 		}
 
-		var patched []byte
+		fullFilePath := filepath.Join(outputDir, strings.ToLower(machine.TypeJoin.String())+"-"+class+".yaml")
 
-		patched, err = configpatcher.JSON6902(marshaled, patch)
-		if err != nil {
-			return nil, err
+		if err = ioutil.WriteFile(fullFilePath, []byte(configString), 0o644); err != nil {
+			return err
 		}
 
-		out = &Config{}
-		err = yaml.Unmarshal(patched, out)
+		fmt.Printf("created %s\n", fullFilePath)
+	}
+
+	return nil
+}
+
+// AddWorkerClass adds a worker class config derived from the join config with the patches applied in order.
+func (c *ConfigBundle) AddWorkerClass(name string, patches []configpatcher.Patch) error {
+	if c.JoinCfg == nil {
+		return fmt.Errorf("join config is required to generate worker class %q", name)
+	}
+
+	cfg, err := patchConfig(c.JoinCfg, patches)
+	if err != nil {
+		return fmt.Errorf("error patching worker class %q config: %w", name, err)
+	}
+
+	if c.WorkerClassCfgs == nil {
+		c.WorkerClassCfgs = map[string]*Config{}
+	}
+
+	c.WorkerClassCfgs[name] = cfg
+
+	return nil
+}
 
-		return out, err
+// ApplyJSONPatch patches the config types with a patch.
+//
+// Init and controlplane configs are patched if patchControlPlane is set, join config is patched if patchJoin is set.
+func (c *ConfigBundle) ApplyJSONPatch(patch jsonpatch.Patch, patchControlPlane, patchJoin bool) error {
+	if len(patch) == 0 {
+		return nil
+	}
+
+	return c.ApplyPatches([]configpatcher.Patch{configpatcher.JSON6902Patch(patch)}, patchControlPlane, patchJoin)
+}
+
+// ApplyPatches patches the config types with the patches applied in order.
+//
+// Init and controlplane configs are patched if patchControlPlane is set, join config is patched if patchJoin is set.
+func (c *ConfigBundle) ApplyPatches(patches []configpatcher.Patch, patchControlPlane, patchJoin bool) error {
+	if len(patches) == 0 {
+		return nil
 	}
 
 	var err error
 
-	c.InitCfg, err = apply(c.InitCfg)
-	if err != nil {
-		return err
+	if patchControlPlane {
+		c.InitCfg, err = patchConfig(c.InitCfg, patches)
+		if err != nil {
+			return err
+		}
+
+		c.ControlPlaneCfg, err = patchConfig(c.ControlPlaneCfg, patches)
+		if err != nil {
+			return err
+		}
+	}
+
+	if patchJoin {
+		c.JoinCfg, err = patchConfig(c.JoinCfg, patches)
+		if err != nil {
+			return err
+		}
 	}
 
-	c.ControlPlaneCfg, err = apply(c.ControlPlaneCfg)
+	return nil
+}
+
+func patchConfig(in *Config, patches []configpatcher.Patch) (*Config, error) {
+	marshaled, err := in.Bytes()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	c.JoinCfg, err = apply(c.JoinCfg)
+	patched, err := configpatcher.Apply(marshaled, patches)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	out := &Config{}
+	err = yaml.Unmarshal(patched, out)
+
+	return out, err
 }
@@ -0,0 +1,181 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package v1alpha1
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/talos-systems/talos/pkg/machinery/config"
+	"github.com/talos-systems/talos/pkg/machinery/config/encoder"
+)
+
+// Deprecations implements the config.Provider interface.
+func (c *Config) Deprecations() []config.Deprecation {
+	var deprecations []config.Deprecation
+
+	walkDeprecated(reflect.ValueOf(c), "", func(path string, _ reflect.Value, _ int, deprecation *encoder.Deprecation) {
+		deprecations = append(deprecations, config.Deprecation{
+			Deprecation: *deprecation,
+			Path:        path,
+		})
+	})
+
+	return deprecations
+}
+
+// MigrateDeprecated moves the values of the deprecated fields to their replacements where possible.
+//
+// Deprecated field is migrated if the replacement is a field of the same struct, and either the replacement
+// is of the same type and is not set, or the replacement is a slice of the deprecated field type.
+// Deprecated fields which were migrated are returned.
+func (c *Config) MigrateDeprecated() []config.Deprecation {
+	var migrated []config.Deprecation
+
+	walkDeprecated(reflect.ValueOf(c), "", func(path string, parent reflect.Value, field int, deprecation *encoder.Deprecation) {
+		if deprecation.Replacement == "" || !parent.CanAddr() {
+			return
+		}
+
+		replacement, ok := fieldByYAMLName(parent, deprecation.Replacement)
+		if !ok {
+			return
+		}
+
+		deprecated := parent.Field(field)
+
+		switch {
+		case replacement.Type() == deprecated.Type() && replacement.IsZero():
+			replacement.Set(deprecated)
+		case replacement.Kind() == reflect.Slice && replacement.Type().Elem() == deprecated.Type():
+			// deprecated value goes first, as it was the first one before the replacement was introduced
+			values := reflect.MakeSlice(replacement.Type(), 0, replacement.Len()+1)
+			values = reflect.Append(values, deprecated)
+			values = reflect.AppendSlice(values, replacement)
+
+			replacement.Set(values)
+		default:
+			return
+		}
+
+		deprecated.Set(reflect.Zero(deprecated.Type()))
+
+		migrated = append(migrated, config.Deprecation{
+			Deprecation: *deprecation,
+			Path:        path,
+		})
+	})
+
+	return migrated
+}
+
+type deprecatedFieldFunc func(path string, parent reflect.Value, field int, deprecation *encoder.Deprecation)
+
+// walkDeprecated calls fn for every deprecated field which is set.
+func walkDeprecated(v reflect.Value, path string, fn deprecatedFieldFunc) {
+	switch v.Kind() { //nolint:exhaustive
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return
+		}
+
+		walkDeprecated(v.Elem(), path, fn)
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			walkDeprecated(v.Index(i), fmt.Sprintf("%s[%d]", path, i), fn)
+		}
+	case reflect.Map:
+		keys := v.MapKeys()
+
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+		})
+
+		for _, key := range keys {
+			walkDeprecated(v.MapIndex(key), fmt.Sprintf("%s[%q]", path, fmt.Sprint(key)), fn)
+		}
+	case reflect.Struct:
+		var doc *encoder.Doc
+
+		if documented, ok := v.Interface().(encoder.Documented); ok {
+			doc = documented.Doc()
+		}
+
+		for i := 0; i < v.NumField(); i++ {
+			structField := v.Type().Field(i)
+
+			if structField.PkgPath != "" {
+				continue
+			}
+
+			name, inline := yamlName(structField)
+			if name == "-" {
+				continue
+			}
+
+			fieldPath := path
+
+			if !inline {
+				fieldPath = joinPath(path, name)
+
+				if fieldDoc := docField(doc, name); fieldDoc != nil && fieldDoc.Deprecated != nil && !v.Field(i).IsZero() {
+					fn(fieldPath, v, i, fieldDoc.Deprecated)
+				}
+			}
+
+			walkDeprecated(v.Field(i), fieldPath, fn)
+		}
+	}
+}
+
+func yamlName(field reflect.StructField) (name string, inline bool) {
+	parts := strings.Split(field.Tag.Get("yaml"), ",")
+
+	for _, option := range parts[1:] {
+		if option == "inline" {
+			return "", true
+		}
+	}
+
+	if parts[0] == "" {
+		return strings.ToLower(field.Name), false
+	}
+
+	return parts[0], false
+}
+
+func fieldByYAMLName(v reflect.Value, name string) (reflect.Value, bool) {
+	for i := 0; i < v.NumField(); i++ {
+		if fieldName, inline := yamlName(v.Type().Field(i)); !inline && fieldName == name {
+			return v.Field(i), true
+		}
+	}
+
+	return reflect.Value{}, false
+}
+
+func docField(doc *encoder.Doc, name string) *encoder.Doc {
+	if doc == nil {
+		return nil
+	}
+
+	for i := range doc.Fields {
+		if doc.Fields[i].Name == name {
+			return &doc.Fields[i]
+		}
+	}
+
+	return nil
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+
+	return path + "." + name
+}
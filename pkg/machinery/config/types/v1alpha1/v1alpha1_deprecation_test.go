@@ -0,0 +1,107 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package v1alpha1_test
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/pkg/machinery/config"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
+)
+
+func deprecatedVlanConfig() *v1alpha1.Config {
+	return &v1alpha1.Config{
+		MachineConfig: &v1alpha1.MachineConfig{
+			MachineNetwork: &v1alpha1.NetworkConfig{
+				NetworkInterfaces: []*v1alpha1.Device{
+					{
+						DeviceInterface: "eth0",
+						DeviceVlans: []*v1alpha1.Vlan{
+							{
+								VlanID:   100,
+								VlanCIDR: "192.168.100.10/24",
+							},
+							{
+								VlanID:        200,
+								VlanCIDR:      "192.168.200.10/24",
+								VlanAddresses: []string{"2001:db8:200::10/64"},
+							},
+							{
+								VlanID:        300,
+								VlanAddresses: []string{"192.168.30.10/24"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestDeprecations(t *testing.T) {
+	t.Parallel()
+
+	cfg := deprecatedVlanConfig()
+
+	deprecations := cfg.Deprecations()
+	require.Len(t, deprecations, 2)
+
+	assert.Equal(t, "machine.network.interfaces[0].vlans[0].cidr", deprecations[0].Path)
+	assert.Equal(t, "machine.network.interfaces[0].vlans[1].cidr", deprecations[1].Path)
+	assert.Equal(t, "addresses", deprecations[0].Replacement)
+	assert.Equal(t, `"machine.network.interfaces[0].vlans[0].cidr" is deprecated, use "addresses" instead, will be removed in Talos v0.12`, deprecations[0].String())
+
+	assert.Empty(t, (&v1alpha1.Config{}).Deprecations())
+}
+
+func TestValidateDeprecations(t *testing.T) {
+	t.Parallel()
+
+	endpointURL, err := url.Parse("https://localhost:6443/")
+	require.NoError(t, err)
+
+	cfg := deprecatedVlanConfig()
+	cfg.ConfigVersion = "v1alpha1"
+	cfg.MachineConfig.MachineType = "join"
+	cfg.ClusterConfig = &v1alpha1.ClusterConfig{
+		ControlPlane: &v1alpha1.ControlPlaneConfig{
+			Endpoint: &v1alpha1.Endpoint{
+				endpointURL,
+			},
+		},
+	}
+
+	warnings, err := cfg.Validate(runtimeMode{false})
+	require.NoError(t, err)
+	assert.Contains(t, warnings, `"machine.network.interfaces[0].vlans[0].cidr" is deprecated, use "addresses" instead, will be removed in Talos v0.12`)
+
+	_, err = cfg.Validate(runtimeMode{false}, config.WithStrict())
+	assert.Error(t, err)
+}
+
+func TestMigrateDeprecated(t *testing.T) {
+	t.Parallel()
+
+	cfg := deprecatedVlanConfig()
+
+	migrated := cfg.MigrateDeprecated()
+	require.Len(t, migrated, 2)
+
+	vlans := cfg.MachineConfig.MachineNetwork.NetworkInterfaces[0].DeviceVlans
+
+	assert.Empty(t, vlans[0].VlanCIDR)
+	assert.Equal(t, []string{"192.168.100.10/24"}, vlans[0].VlanAddresses)
+
+	assert.Empty(t, vlans[1].VlanCIDR)
+	assert.Equal(t, []string{"192.168.200.10/24", "2001:db8:200::10/64"}, vlans[1].VlanAddresses)
+
+	assert.Equal(t, []string{"192.168.30.10/24"}, vlans[2].VlanAddresses)
+
+	assert.Empty(t, cfg.Deprecations())
+}
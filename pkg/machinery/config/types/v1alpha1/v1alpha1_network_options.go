@@ -79,6 +79,21 @@ func WithNetworkInterfaceDHCPv6(iface string, enable bool) NetworkConfigOption {
 	}
 }
 
+// WithNetworkInterfaceSLAAC enables IPv6 stateless address autoconfiguration for the interface.
+func WithNetworkInterfaceSLAAC(iface string, enable bool) NetworkConfigOption {
+	return func(_ machine.Type, cfg *NetworkConfig) error {
+		dev := cfg.getDevice(iface)
+
+		if dev.DeviceDHCPOptions == nil {
+			dev.DeviceDHCPOptions = &DHCPOptions{}
+		}
+
+		dev.DeviceDHCPOptions.DHCPSLAAC = pointer.ToBool(enable)
+
+		return nil
+	}
+}
+
 // WithNetworkInterfaceCIDR configures interface for static addressing.
 func WithNetworkInterfaceCIDR(iface, cidr string) NetworkConfigOption {
 	return func(_ machine.Type, cfg *NetworkConfig) error {
@@ -12,6 +12,8 @@ import (
 	"log"
 	"net"
 	"os"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -90,7 +92,10 @@ func (c *Config) ApplyDynamicConfig(ctx context.Context, dynamicProvider config.
 			c.MachineConfig.MachineNetwork = &NetworkConfig{}
 		}
 
-		c.MachineConfig.MachineNetwork.NetworkHostname = string(hostname)
+		// with the explicit hostname sources the platform hostname is picked up by networkd according to the precedence
+		if len(c.MachineConfig.MachineNetwork.NetworkHostnameSources) == 0 {
+			c.MachineConfig.MachineNetwork.NetworkHostname = string(hostname)
+		}
 	}
 
 	addrs, err := dynamicProvider.ExternalIPs(ctx)
@@ -100,9 +105,18 @@ func (c *Config) ApplyDynamicConfig(ctx context.Context, dynamicProvider config.
 
 	if c.MachineConfig.MachineNetwork != nil {
 		for _, nc := range c.MachineConfig.MachineNetwork.NetworkInterfaces {
-			if nc.VIPConfig() != nil {
-				sharedIP := net.ParseIP(nc.VIPConfig().IP())
-				if sharedIP != nil {
+			vips := []config.VIPConfig{nc.VIPConfig()}
+
+			for _, vlan := range nc.DeviceVlans {
+				vips = append(vips, vlan.VIPConfig())
+			}
+
+			for _, vip := range vips {
+				if vip == nil {
+					continue
+				}
+
+				if sharedIP := net.ParseIP(vip.IP()); sharedIP != nil {
 					addrs = append(addrs, sharedIP)
 				}
 			}
@@ -244,6 +258,346 @@ func (m *MachineConfig) Sysctls() map[string]string {
 	return m.MachineSysctls
 }
 
+// Healthz implements the config.Provider interface.
+func (m *MachineConfig) Healthz() config.Healthz {
+	if m.MachineHealthz == nil {
+		return &HealthzConfig{}
+	}
+
+	return m.MachineHealthz
+}
+
+// Enabled implements the config.Healthz interface.
+func (h *HealthzConfig) Enabled() bool {
+	return h.HealthzEnabled
+}
+
+// Port implements the config.Healthz interface.
+func (h *HealthzConfig) Port() int {
+	if h.HealthzPort == 0 {
+		return constants.DefaultHealthzPort
+	}
+
+	return h.HealthzPort
+}
+
+// Metrics implements the config.Provider interface.
+func (m *MachineConfig) Metrics() config.Metrics {
+	if m.MachineMetrics == nil {
+		return &MetricsConfig{}
+	}
+
+	return m.MachineMetrics
+}
+
+// Enabled implements the config.Metrics interface.
+func (m *MetricsConfig) Enabled() bool {
+	return m.MetricsEnabled
+}
+
+// Port implements the config.Metrics interface.
+func (m *MetricsConfig) Port() int {
+	if m.MetricsPort == 0 {
+		return constants.DefaultMetricsPort
+	}
+
+	return m.MetricsPort
+}
+
+// BearerToken implements the config.Metrics interface.
+func (m *MetricsConfig) BearerToken() string {
+	return m.MetricsBearerToken
+}
+
+// Watchdog implements the config.Provider interface.
+func (m *MachineConfig) Watchdog() config.Watchdog {
+	if m.MachineWatchdog == nil {
+		return &WatchdogConfig{}
+	}
+
+	return m.MachineWatchdog
+}
+
+// Enabled implements the config.Watchdog interface.
+func (w *WatchdogConfig) Enabled() bool {
+	return w.WatchdogEnabled
+}
+
+// Device implements the config.Watchdog interface.
+func (w *WatchdogConfig) Device() string {
+	if w.WatchdogDevice == "" {
+		return constants.DefaultWatchdogDevice
+	}
+
+	return w.WatchdogDevice
+}
+
+// Timeout implements the config.Watchdog interface.
+func (w *WatchdogConfig) Timeout() time.Duration {
+	if w.WatchdogTimeout == 0 {
+		return constants.DefaultWatchdogTimeout
+	}
+
+	return w.WatchdogTimeout
+}
+
+// Features implements the config.Provider interface.
+func (m *MachineConfig) Features() config.Features {
+	if m.MachineFeatures == nil {
+		return &FeaturesConfig{}
+	}
+
+	return m.MachineFeatures
+}
+
+// KexecEnabled implements the config.Features interface.
+func (f *FeaturesConfig) KexecEnabled() bool {
+	return f.FeaturesKexec
+}
+
+// LocalAPIAllowedUIDs implements the config.Features interface.
+func (f *FeaturesConfig) LocalAPIAllowedUIDs() []uint32 {
+	return f.FeaturesLocalAPIAllowedUIDs
+}
+
+// LocalAPIAllowedGIDs implements the config.Features interface.
+func (f *FeaturesConfig) LocalAPIAllowedGIDs() []uint32 {
+	return f.FeaturesLocalAPIAllowedGIDs
+}
+
+// KubeletLocalAPIEnabled implements the config.Features interface.
+func (f *FeaturesConfig) KubeletLocalAPIEnabled() bool {
+	return f.FeaturesKubeletLocalAPI
+}
+
+// StrictTLSEnabled implements the config.Features interface.
+func (f *FeaturesConfig) StrictTLSEnabled() bool {
+	return f.FeaturesStrictTLS
+}
+
+// CrashDumps implements the config.Provider interface.
+func (m *MachineConfig) CrashDumps() config.CrashDumps {
+	if m.MachineCrashDumps == nil {
+		return &CrashDumpsConfig{}
+	}
+
+	return m.MachineCrashDumps
+}
+
+// Destination implements the config.CrashDumps interface.
+func (c *CrashDumpsConfig) Destination() string {
+	return c.CrashDumpsDestination
+}
+
+// KernelArgs implements the config.CrashDumps interface.
+func (c *CrashDumpsConfig) KernelArgs() []string {
+	if c.CrashDumpsRamoops == nil {
+		return nil
+	}
+
+	args := []string{
+		"ramoops.mem_address=" + c.CrashDumpsRamoops.RamoopsMemAddress,
+		"ramoops.mem_size=" + c.CrashDumpsRamoops.RamoopsMemSize,
+	}
+
+	if c.CrashDumpsRamoops.RamoopsRecordSize != "" {
+		args = append(args, "ramoops.record_size="+c.CrashDumpsRamoops.RamoopsRecordSize)
+	}
+
+	if c.CrashDumpsRamoops.RamoopsConsoleSize != "" {
+		args = append(args, "ramoops.console_size="+c.CrashDumpsRamoops.RamoopsConsoleSize)
+	}
+
+	return args
+}
+
+// ImageCache implements the config.Provider interface.
+func (m *MachineConfig) ImageCache() config.ImageCache {
+	if m.MachineImageCache == nil {
+		return &ImageCacheConfig{}
+	}
+
+	return m.MachineImageCache
+}
+
+// CRI implements the config.Provider interface.
+func (m *MachineConfig) CRI() config.CRI {
+	if m.MachineCRI == nil {
+		return &CRIConfig{}
+	}
+
+	return m.MachineCRI
+}
+
+// SandboxImage implements the config.CRI interface.
+func (c *CRIConfig) SandboxImage() string {
+	return c.CRISandboxImage
+}
+
+// ConfigPatches implements the config.CRI interface.
+func (c *CRIConfig) ConfigPatches() []string {
+	return c.CRIConfigPatches
+}
+
+// NVIDIA implements the config.Provider interface.
+func (m *MachineConfig) NVIDIA() config.NVIDIA {
+	if m.MachineNVIDIA == nil {
+		return &NVIDIAConfig{}
+	}
+
+	return m.MachineNVIDIA
+}
+
+// Enabled implements the config.NVIDIA interface.
+func (n *NVIDIAConfig) Enabled() bool {
+	return n.NVIDIAEnabled
+}
+
+// Reconcile implements the config.Provider interface.
+func (m *MachineConfig) Reconcile() config.Reconcile {
+	if m.MachineReconcile == nil {
+		return &ReconcileConfig{}
+	}
+
+	return m.MachineReconcile
+}
+
+// Paused implements the config.Reconcile interface.
+func (r *ReconcileConfig) Paused() bool {
+	return r.ReconcilePaused
+}
+
+// MaintenanceWindows implements the config.Reconcile interface.
+func (r *ReconcileConfig) MaintenanceWindows() []config.MaintenanceWindow {
+	windows := make([]config.MaintenanceWindow, len(r.ReconcileMaintenanceWindows))
+
+	for i, w := range r.ReconcileMaintenanceWindows {
+		windows[i] = w
+	}
+
+	return windows
+}
+
+// Registration implements the config.Provider interface.
+func (m *MachineConfig) Registration() config.Registration {
+	if m.MachineRegistration == nil {
+		return &RegistrationConfig{}
+	}
+
+	return m.MachineRegistration
+}
+
+// Endpoint implements the config.Registration interface.
+func (r *RegistrationConfig) Endpoint() string {
+	return r.RegistrationEndpoint
+}
+
+// Token implements the config.Registration interface.
+func (r *RegistrationConfig) Token() string {
+	return r.RegistrationToken
+}
+
+// HeartbeatInterval implements the config.Registration interface.
+func (r *RegistrationConfig) HeartbeatInterval() time.Duration {
+	if r.RegistrationHeartbeatInterval == 0 {
+		return constants.RegistrationDefaultHeartbeatInterval
+	}
+
+	return r.RegistrationHeartbeatInterval
+}
+
+// Identity implements the config.Provider interface.
+func (m *MachineConfig) Identity() config.Identity {
+	if m.MachineIdentity == nil {
+		return &IdentityConfig{}
+	}
+
+	return m.MachineIdentity
+}
+
+// KMS implements the config.Identity interface.
+func (i *IdentityConfig) KMS() config.IdentityKMS {
+	if i.IdentityKMS == nil {
+		return nil
+	}
+
+	return i.IdentityKMS
+}
+
+// Endpoint implements the config.IdentityKMS interface.
+func (k *IdentityKMSConfig) Endpoint() string {
+	return k.KMSEndpoint
+}
+
+// Timeout implements the config.IdentityKMS interface.
+func (k *IdentityKMSConfig) Timeout() time.Duration {
+	if k.KMSTimeout == 0 {
+		return constants.DefaultKMSEncryptionTimeout
+	}
+
+	return k.KMSTimeout
+}
+
+// Start implements the config.MaintenanceWindow interface.
+func (w *MaintenanceWindowConfig) Start() time.Time {
+	// start is validated in the config
+	start, _ := time.Parse(time.RFC3339, w.WindowStart) //nolint:errcheck
+
+	return start
+}
+
+// Duration implements the config.MaintenanceWindow interface.
+func (w *MaintenanceWindowConfig) Duration() time.Duration {
+	return w.WindowDuration
+}
+
+// Enabled implements the config.ImageCache interface.
+func (i *ImageCacheConfig) Enabled() bool {
+	return i.ImageCacheEnabled
+}
+
+// Virtualization implements the config.Provider interface.
+func (m *MachineConfig) Virtualization() config.Virtualization {
+	if m.MachineVirtualization == nil {
+		return &VirtualizationConfig{}
+	}
+
+	return m.MachineVirtualization
+}
+
+// Nested implements the config.Provider interface.
+func (v *VirtualizationConfig) Nested() bool {
+	return v.VirtualizationNested
+}
+
+// KVMOptions implements the config.Provider interface.
+func (v *VirtualizationConfig) KVMOptions() map[string]string {
+	return v.VirtualizationKVMOptions
+}
+
+// KernelArgs implements the config.Provider interface.
+func (v *VirtualizationConfig) KernelArgs() []string {
+	var args []string
+
+	if v.VirtualizationNested {
+		args = append(args, "kvm_intel.nested=1", "kvm_amd.nested=1")
+	}
+
+	keys := make([]string, 0, len(v.VirtualizationKVMOptions))
+
+	for key := range v.VirtualizationKVMOptions {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		args = append(args, fmt.Sprintf("kvm.%s=%s", key, v.VirtualizationKVMOptions[key]))
+	}
+
+	return args
+}
+
 // CA implements the config.Provider interface.
 func (m *MachineConfig) CA() *x509.PEMEncodedCertificateAndKey {
 	return m.MachineCA
@@ -259,6 +613,57 @@ func (m *MachineConfig) CertSANs() []string {
 	return m.MachineCertSANs
 }
 
+// JoinPolicy implements the config.Provider interface.
+func (m *MachineConfig) JoinPolicy() config.JoinPolicy {
+	if m.MachineJoinPolicy == nil {
+		return &JoinPolicyConfig{}
+	}
+
+	return m.MachineJoinPolicy
+}
+
+// Mode implements the config.Provider interface.
+func (j *JoinPolicyConfig) Mode() string {
+	if j.JoinPolicyMode == "" {
+		return constants.TrustdJoinPolicyToken
+	}
+
+	return j.JoinPolicyMode
+}
+
+// AllowedIdentities implements the config.Provider interface.
+func (j *JoinPolicyConfig) AllowedIdentities() []string {
+	return j.JoinPolicyAllowedIdentities
+}
+
+// Webhook implements the config.Provider interface.
+func (j *JoinPolicyConfig) Webhook() config.JoinPolicyWebhook {
+	if j.JoinPolicyWebhook == nil {
+		return nil
+	}
+
+	return j.JoinPolicyWebhook
+}
+
+// URL implements the config.Provider interface.
+func (w *JoinPolicyWebhookConfig) URL() string {
+	return w.WebhookURL
+}
+
+// CA implements the config.Provider interface.
+func (w *JoinPolicyWebhookConfig) CA() []byte {
+	return w.WebhookCA
+}
+
+// Timeout implements the config.Provider interface.
+func (w *JoinPolicyWebhookConfig) Timeout() time.Duration {
+	if w.WebhookTimeout == 0 {
+		return constants.TrustdJoinPolicyWebhookDefaultTimeout
+	}
+
+	return w.WebhookTimeout
+}
+
 // Registries implements the config.Provider interface.
 func (m *MachineConfig) Registries() config.Registries {
 	return &m.MachineRegistries
@@ -418,6 +823,15 @@ func (n *NetworkConfig) Hostname() string {
 	return n.NetworkHostname
 }
 
+// HostnameSources implements the config.Provider interface.
+func (n *NetworkConfig) HostnameSources() []string {
+	if len(n.NetworkHostnameSources) == 0 {
+		return append([]string(nil), DefaultHostnameSources...)
+	}
+
+	return n.NetworkHostnameSources
+}
+
 // Devices implements the config.Provider interface.
 func (n *NetworkConfig) Devices() []config.Device {
 	interfaces := make([]config.Device, len(n.NetworkInterfaces))
@@ -453,6 +867,16 @@ func (n *NetworkConfig) Resolvers() []string {
 	return n.NameServers
 }
 
+// SearchDomains implements the config.Provider interface.
+func (n *NetworkConfig) SearchDomains() []string {
+	return n.NetworkSearchDomains
+}
+
+// DisableSearchDomain implements the config.Provider interface.
+func (n *NetworkConfig) DisableSearchDomain() bool {
+	return n.NetworkDisableSearchDomain
+}
+
 // ExtraHosts implements the config.Provider interface.
 func (n *NetworkConfig) ExtraHosts() []config.ExtraHost {
 	hosts := make([]config.ExtraHost, len(n.ExtraHostEntries))
@@ -464,6 +888,151 @@ func (n *NetworkConfig) ExtraHosts() []config.ExtraHost {
 	return hosts
 }
 
+// Rules implements the config.Provider interface.
+func (n *NetworkConfig) Rules() []config.RoutingRule {
+	rules := make([]config.RoutingRule, len(n.NetworkRules))
+
+	for i := 0; i < len(n.NetworkRules); i++ {
+		rules[i] = n.NetworkRules[i]
+	}
+
+	return rules
+}
+
+// KubeSpan implements the config.Provider interface.
+func (n *NetworkConfig) KubeSpan() config.KubeSpan {
+	if n.NetworkKubeSpan == nil {
+		return &KubeSpan{}
+	}
+
+	return n.NetworkKubeSpan
+}
+
+// Enabled implements the config.KubeSpan interface.
+func (k *KubeSpan) Enabled() bool {
+	return k.KubeSpanEnabled
+}
+
+// DiscoveryEndpoint implements the config.KubeSpan interface.
+func (k *KubeSpan) DiscoveryEndpoint() string {
+	return k.KubeSpanDiscoveryEndpoint
+}
+
+// SideroLink implements the config.Provider interface.
+func (n *NetworkConfig) SideroLink() config.SideroLink {
+	if n.NetworkSideroLink == nil {
+		return &SideroLink{}
+	}
+
+	return n.NetworkSideroLink
+}
+
+// APIURL implements the config.SideroLink interface.
+func (s *SideroLink) APIURL() string {
+	return s.SideroLinkAPIURL
+}
+
+// Firewall implements the config.Provider interface.
+func (n *NetworkConfig) Firewall() config.Firewall {
+	if n.NetworkFirewall == nil {
+		return nil
+	}
+
+	return n.NetworkFirewall
+}
+
+// DefaultAction implements the config.Firewall interface.
+func (f *FirewallConfig) DefaultAction() string {
+	if f.FirewallDefaultAction == "" {
+		return FirewallActionAccept
+	}
+
+	return f.FirewallDefaultAction
+}
+
+// Rules implements the config.Firewall interface.
+func (f *FirewallConfig) Rules() []config.FirewallRule {
+	rules := make([]config.FirewallRule, len(f.FirewallRules))
+
+	for i := range f.FirewallRules {
+		rules[i] = f.FirewallRules[i]
+	}
+
+	return rules
+}
+
+// Name implements the config.FirewallRule interface.
+func (r *FirewallRule) Name() string {
+	return r.FirewallRuleName
+}
+
+// Protocol implements the config.FirewallRule interface.
+func (r *FirewallRule) Protocol() string {
+	if r.FirewallRulePortSelector == nil {
+		return ""
+	}
+
+	return r.FirewallRulePortSelector.PortSelectorProtocol
+}
+
+// Ports implements the config.FirewallRule interface.
+//
+// Invalid port ranges are skipped (they are rejected by the validation).
+func (r *FirewallRule) Ports() []config.PortRange {
+	if r.FirewallRulePortSelector == nil {
+		return nil
+	}
+
+	ports := make([]config.PortRange, 0, len(r.FirewallRulePortSelector.PortSelectorPorts))
+
+	for _, port := range r.FirewallRulePortSelector.PortSelectorPorts {
+		portRange, err := parsePortRange(port)
+		if err != nil {
+			continue
+		}
+
+		ports = append(ports, portRange)
+	}
+
+	return ports
+}
+
+// Subnets implements the config.FirewallRule interface.
+func (r *FirewallRule) Subnets() []string {
+	subnets := make([]string, 0, len(r.FirewallRuleIngress))
+
+	for _, ingress := range r.FirewallRuleIngress {
+		subnets = append(subnets, ingress.IngressSubnet)
+	}
+
+	return subnets
+}
+
+// parsePortRange parses a port (`50000`) or an inclusive port range (`2379-2380`).
+func parsePortRange(s string) (config.PortRange, error) {
+	lo, hi := s, s
+
+	if idx := strings.IndexByte(s, '-'); idx >= 0 {
+		lo, hi = s[:idx], s[idx+1:]
+	}
+
+	loPort, err := strconv.ParseUint(strings.TrimSpace(lo), 10, 16)
+	if err != nil {
+		return config.PortRange{}, err
+	}
+
+	hiPort, err := strconv.ParseUint(strings.TrimSpace(hi), 10, 16)
+	if err != nil {
+		return config.PortRange{}, err
+	}
+
+	if loPort == 0 || loPort > hiPort {
+		return config.PortRange{}, fmt.Errorf("invalid port range %q", s)
+	}
+
+	return config.PortRange{Lo: uint16(loPort), Hi: uint16(hiPort)}, nil
+}
+
 // IP implements the MachineNetwork interface.
 func (e *ExtraHost) IP() string {
 	return e.HostIP
@@ -479,6 +1048,15 @@ func (d *Device) Interface() string {
 	return d.DeviceInterface
 }
 
+// Selector implements the MachineNetwork interface.
+func (d *Device) Selector() config.NetworkDeviceSelector {
+	if d.DeviceSelector == nil {
+		return nil
+	}
+
+	return d.DeviceSelector
+}
+
 // CIDR implements the MachineNetwork interface.
 func (d *Device) CIDR() string {
 	return d.DeviceCIDR
@@ -504,6 +1082,15 @@ func (d *Device) Bond() config.Bond {
 	return d.DeviceBond
 }
 
+// Bridge implements the MachineNetwork interface.
+func (d *Device) Bridge() config.Bridge {
+	if d.DeviceBridge == nil {
+		return nil
+	}
+
+	return d.DeviceBridge
+}
+
 // Vlans implements the MachineNetwork interface.
 func (d *Device) Vlans() []config.Vlan {
 	vlans := make([]config.Vlan, len(d.DeviceVlans))
@@ -520,6 +1107,15 @@ func (d *Device) MTU() int {
 	return d.DeviceMTU
 }
 
+// Ethernet implements the MachineNetwork interface.
+func (d *Device) Ethernet() config.EthernetConfig {
+	if d.DeviceEthernet == nil {
+		return nil
+	}
+
+	return d.DeviceEthernet
+}
+
 // DHCP implements the MachineNetwork interface.
 func (d *Device) DHCP() bool {
 	return d.DeviceDHCP
@@ -535,6 +1131,11 @@ func (d *Device) Dummy() bool {
 	return d.DeviceDummy
 }
 
+// MacvtapParent implements the MachineNetwork interface.
+func (d *Device) MacvtapParent() bool {
+	return d.DeviceMacvtapParent
+}
+
 // DHCPOptions implements the MachineNetwork interface.
 func (d *Device) DHCPOptions() config.DHCPOptions {
 	// Default route metric on systemd is 1024. This sets the same.
@@ -561,6 +1162,39 @@ func (d *DeviceVIPConfig) IP() string {
 	return d.SharedIP
 }
 
+// EquinixMetal implements the config.VIPConfig interface.
+func (d *DeviceVIPConfig) EquinixMetal() config.VIPEquinixMetal {
+	if d.EquinixMetalConfig == nil {
+		return nil
+	}
+
+	return d.EquinixMetalConfig
+}
+
+// HCloud implements the config.VIPConfig interface.
+func (d *DeviceVIPConfig) HCloud() config.VIPHCloud {
+	if d.HCloudConfig == nil {
+		return nil
+	}
+
+	return d.HCloudConfig
+}
+
+// APIToken implements the config.VIPEquinixMetal interface.
+func (v *VIPEquinixMetalConfig) APIToken() string {
+	return v.EquinixMetalAPIToken
+}
+
+// BGP implements the config.VIPEquinixMetal interface.
+func (v *VIPEquinixMetalConfig) BGP() bool {
+	return v.EquinixMetalBGP
+}
+
+// APIToken implements the config.VIPHCloud interface.
+func (v *VIPHCloudConfig) APIToken() string {
+	return v.HCloudAPIToken
+}
+
 // WireguardConfig implements the MachineNetwork interface.
 func (d *Device) WireguardConfig() config.WireguardConfig {
 	if d.DeviceWireguardConfig == nil {
@@ -570,6 +1204,76 @@ func (d *Device) WireguardConfig() config.WireguardConfig {
 	return d.DeviceWireguardConfig
 }
 
+// WakeOnLAN implements config.EthernetConfig interface.
+func (e *EthernetConfig) WakeOnLAN() []string {
+	return e.EthernetWakeOnLAN
+}
+
+// TSO implements config.EthernetConfig interface.
+func (e *EthernetConfig) TSO() *bool {
+	if e.EthernetOffload == nil {
+		return nil
+	}
+
+	return e.EthernetOffload.OffloadTSO
+}
+
+// GRO implements config.EthernetConfig interface.
+func (e *EthernetConfig) GRO() *bool {
+	if e.EthernetOffload == nil {
+		return nil
+	}
+
+	return e.EthernetOffload.OffloadGRO
+}
+
+// LRO implements config.EthernetConfig interface.
+func (e *EthernetConfig) LRO() *bool {
+	if e.EthernetOffload == nil {
+		return nil
+	}
+
+	return e.EthernetOffload.OffloadLRO
+}
+
+// RingsRX implements config.EthernetConfig interface.
+func (e *EthernetConfig) RingsRX() uint32 {
+	if e.EthernetRings == nil {
+		return 0
+	}
+
+	return e.EthernetRings.RingsRX
+}
+
+// RingsTX implements config.EthernetConfig interface.
+func (e *EthernetConfig) RingsTX() uint32 {
+	if e.EthernetRings == nil {
+		return 0
+	}
+
+	return e.EthernetRings.RingsTX
+}
+
+// BusPath implements config.NetworkDeviceSelector interface.
+func (s *NetworkDeviceSelector) BusPath() string {
+	return s.NetworkDeviceBusPath
+}
+
+// HardwareAddress implements config.NetworkDeviceSelector interface.
+func (s *NetworkDeviceSelector) HardwareAddress() string {
+	return s.NetworkDeviceHardwareAddress
+}
+
+// KernelDriver implements config.NetworkDeviceSelector interface.
+func (s *NetworkDeviceSelector) KernelDriver() string {
+	return s.NetworkDeviceKernelDriver
+}
+
+// Speed implements config.NetworkDeviceSelector interface.
+func (s *NetworkDeviceSelector) Speed() uint32 {
+	return s.NetworkDeviceSpeed
+}
+
 // RouteMetric implements the DHCPOptions interface.
 func (d *DHCPOptions) RouteMetric() uint32 {
 	return d.DHCPRouteMetric
@@ -593,11 +1297,40 @@ func (d *DHCPOptions) IPv6() bool {
 	return *d.DHCPIPv6
 }
 
+// IgnoreDefaultRoutes implements the DHCPOptions interface.
+func (d *DHCPOptions) IgnoreDefaultRoutes() bool {
+	return d.DHCPIgnoreDefaultRoutes
+}
+
+// IgnoreDNS implements the DHCPOptions interface.
+func (d *DHCPOptions) IgnoreDNS() bool {
+	return d.DHCPIgnoreDNS
+}
+
+// DUIDv6 implements the DHCPOptions interface.
+func (d *DHCPOptions) DUIDv6() string {
+	return d.DHCPUIDv6
+}
+
+// SLAAC implements the DHCPOptions interface.
+func (d *DHCPOptions) SLAAC() bool {
+	if d.DHCPSLAAC == nil {
+		return false
+	}
+
+	return *d.DHCPSLAAC
+}
+
 // PrivateKey implements the MachineNetwork interface.
 func (wc *DeviceWireguardConfig) PrivateKey() string {
 	return wc.WireguardPrivateKey
 }
 
+// GeneratePrivateKey implements the MachineNetwork interface.
+func (wc *DeviceWireguardConfig) GeneratePrivateKey() bool {
+	return wc.WireguardGeneratePrivateKey
+}
+
 // ListenPort implements the MachineNetwork interface.
 func (wc *DeviceWireguardConfig) ListenPort() int {
 	return wc.WireguardListenPort
@@ -654,6 +1387,46 @@ func (r *Route) Metric() uint32 {
 	return r.RouteMetric
 }
 
+// Source implements the MachineNetwork interface.
+func (r *Route) Source() string {
+	return r.RouteSource
+}
+
+// MTU implements the MachineNetwork interface.
+func (r *Route) MTU() uint32 {
+	return r.RouteMTU
+}
+
+// Table implements the MachineNetwork interface.
+func (r *Route) Table() uint32 {
+	return r.RouteTable
+}
+
+// From implements the config.RoutingRule interface.
+func (r *RoutingRule) From() string {
+	return r.RuleFrom
+}
+
+// To implements the config.RoutingRule interface.
+func (r *RoutingRule) To() string {
+	return r.RuleTo
+}
+
+// FwMark implements the config.RoutingRule interface.
+func (r *RoutingRule) FwMark() uint32 {
+	return r.RuleFwMark
+}
+
+// Table implements the config.RoutingRule interface.
+func (r *RoutingRule) Table() uint32 {
+	return r.RuleTable
+}
+
+// Priority implements the config.RoutingRule interface.
+func (r *RoutingRule) Priority() uint32 {
+	return r.RulePriority
+}
+
 // Interfaces implements the MachineNetwork interface.
 func (b *Bond) Interfaces() []string {
 	if b == nil {
@@ -797,11 +1570,57 @@ func (b *Bond) PeerNotifyDelay() uint32 {
 	return b.BondPeerNotifyDelay
 }
 
+// Interfaces implements the MachineNetwork interface.
+func (b *Bridge) Interfaces() []string {
+	return b.BridgedInterfaces
+}
+
+// STP implements the MachineNetwork interface.
+func (b *Bridge) STP() config.STP {
+	if b.BridgeSTP == nil {
+		return &STP{}
+	}
+
+	return b.BridgeSTP
+}
+
+// VLAN implements the MachineNetwork interface.
+func (b *Bridge) VLAN() config.BridgeVLAN {
+	if b.BridgeVLAN == nil {
+		return &BridgeVLAN{}
+	}
+
+	return b.BridgeVLAN
+}
+
+// Enabled implements the MachineNetwork interface.
+func (s *STP) Enabled() bool {
+	return s.STPEnabled
+}
+
+// FilteringEnabled implements the MachineNetwork interface.
+func (v *BridgeVLAN) FilteringEnabled() bool {
+	return v.BridgeVLANFiltering
+}
+
 // CIDR implements the MachineNetwork interface.
 func (v *Vlan) CIDR() string {
 	return v.VlanCIDR
 }
 
+// Addresses implements the MachineNetwork interface.
+//
+// Addresses returns the static addresses of the VLAN, including the legacy `cidr` field.
+func (v *Vlan) Addresses() []string {
+	addresses := make([]string, 0, len(v.VlanAddresses)+1)
+
+	if v.VlanCIDR != "" {
+		addresses = append(addresses, v.VlanCIDR)
+	}
+
+	return append(addresses, v.VlanAddresses...)
+}
+
 // Routes implements the MachineNetwork interface.
 func (v *Vlan) Routes() []config.Route {
 	routes := make([]config.Route, len(v.VlanRoutes))
@@ -823,6 +1642,15 @@ func (v *Vlan) ID() uint16 {
 	return v.VlanID
 }
 
+// VIPConfig implements the MachineNetwork interface.
+func (v *Vlan) VIPConfig() config.VIPConfig {
+	if v.VlanVIP == nil {
+		return nil
+	}
+
+	return v.VlanVIP
+}
+
 // Disabled implements the config.Provider interface.
 func (t *TimeConfig) Disabled() bool {
 	return t.TimeDisabled
@@ -916,6 +1744,64 @@ func (i *InstallConfig) WithBootloader() bool {
 	return i.InstallBootloader
 }
 
+// BootloaderType implements the config.Provider interface.
+func (i *InstallConfig) BootloaderType() string {
+	if i.InstallBootloaderType == "" {
+		return constants.BootloaderGRUB
+	}
+
+	return i.InstallBootloaderType
+}
+
+// Consoles implements the config.Provider interface.
+func (i *InstallConfig) Consoles() []config.InstallConsole {
+	consoles := make([]config.InstallConsole, len(i.InstallConsoles))
+
+	for j, c := range i.InstallConsoles {
+		consoles[j] = c
+	}
+
+	return consoles
+}
+
+// SecureBoot implements the config.Provider interface.
+func (i *InstallConfig) SecureBoot() config.InstallSecureBoot {
+	if i.InstallSecureBoot == nil {
+		return nil
+	}
+
+	return i.InstallSecureBoot
+}
+
+// SigningKey implements the config.Provider interface.
+func (s *InstallSecureBootConfig) SigningKey() string {
+	return s.SecureBootSigningKey
+}
+
+// SigningCert implements the config.Provider interface.
+func (s *InstallSecureBootConfig) SigningCert() string {
+	return s.SecureBootSigningCert
+}
+
+// Device implements the config.Provider interface.
+func (c *InstallConsole) Device() string {
+	return c.ConsoleDevice
+}
+
+// BaudRate implements the config.Provider interface.
+func (c *InstallConsole) BaudRate() int {
+	return c.ConsoleBaudRate
+}
+
+// KernelArg implements the config.Provider interface.
+func (c *InstallConsole) KernelArg() string {
+	if c.ConsoleBaudRate == 0 {
+		return c.ConsoleDevice
+	}
+
+	return fmt.Sprintf("%s,%d", c.ConsoleDevice, c.ConsoleBaudRate)
+}
+
 // Image implements the config.Provider interface.
 func (c *CoreDNS) Image() string {
 	coreDNSImage := fmt.Sprintf("%s:%s", constants.CoreDNSImage, constants.DefaultCoreDNSVersion)
@@ -936,6 +1822,20 @@ func (a *AdminKubeconfigConfig) CertLifetime() time.Duration {
 	return a.AdminKubeconfigCertLifetime
 }
 
+// Interval implements the config.ManifestsSync interface.
+func (m *ManifestsSyncConfig) Interval() time.Duration {
+	if m.SyncInterval == 0 {
+		return constants.DefaultManifestsSyncInterval
+	}
+
+	return m.SyncInterval
+}
+
+// UnmanagedManifests implements the config.ManifestsSync interface.
+func (m *ManifestsSyncConfig) UnmanagedManifests() []string {
+	return m.SyncUnmanagedManifests
+}
+
 // Endpoints implements the config.Provider interface.
 func (r *RegistryMirrorConfig) Endpoints() []string {
 	return r.MirrorEndpoints
@@ -1056,6 +1956,76 @@ func (e *SystemDiskEncryptionConfig) Get(label string) config.Encryption {
 	return nil
 }
 
+// Name implements the config.AESCBCEncryptionKey interface.
+func (k AESCBCEncryptionKeyConfig) Name() string {
+	return k.KeyName
+}
+
+// Secret implements the config.AESCBCEncryptionKey interface.
+func (k AESCBCEncryptionKeyConfig) Secret() string {
+	return k.KeySecret
+}
+
+// Provider implements the config.SecretsEncryption interface.
+func (e *SecretsEncryptionConfig) Provider() string {
+	if e.EncryptionProvider == "" {
+		return constants.SecretsEncryptionProviderAESCBC
+	}
+
+	return e.EncryptionProvider
+}
+
+// Secretbox implements the config.SecretsEncryption interface.
+func (e *SecretsEncryptionConfig) Secretbox() config.SecretboxEncryption {
+	if e.EncryptionSecretbox == nil {
+		return nil
+	}
+
+	return e.EncryptionSecretbox
+}
+
+// KMS implements the config.SecretsEncryption interface.
+func (e *SecretsEncryptionConfig) KMS() config.KMSEncryption {
+	if e.EncryptionKMS == nil {
+		return nil
+	}
+
+	return e.EncryptionKMS
+}
+
+// Name implements the config.SecretboxEncryption interface.
+func (s *SecretboxEncryptionConfig) Name() string {
+	if s.SecretboxKeyName == "" {
+		return constants.DefaultSecretboxEncryptionKeyName
+	}
+
+	return s.SecretboxKeyName
+}
+
+// Secret implements the config.SecretboxEncryption interface.
+func (s *SecretboxEncryptionConfig) Secret() string {
+	return s.SecretboxSecret
+}
+
+// Name implements the config.KMSEncryption interface.
+func (k *KMSEncryptionConfig) Name() string {
+	return k.KMSName
+}
+
+// Endpoint implements the config.KMSEncryption interface.
+func (k *KMSEncryptionConfig) Endpoint() string {
+	return k.KMSEndpoint
+}
+
+// Timeout implements the config.KMSEncryption interface.
+func (k *KMSEncryptionConfig) Timeout() time.Duration {
+	if k.KMSTimeout == 0 {
+		return constants.DefaultKMSEncryptionTimeout
+	}
+
+	return k.KMSTimeout
+}
+
 // HostPath implements the config.VolumeMount interface.
 func (v VolumeMountConfig) HostPath() string {
 	return v.VolumeHostPath
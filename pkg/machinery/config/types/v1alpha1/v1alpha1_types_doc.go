@@ -14,12 +14,31 @@ var (
 	ConfigDoc                      encoder.Doc
 	MachineConfigDoc               encoder.Doc
 	ClusterConfigDoc               encoder.Doc
+	JoinPolicyConfigDoc            encoder.Doc
+	JoinPolicyWebhookConfigDoc     encoder.Doc
 	KubeletConfigDoc               encoder.Doc
 	NetworkConfigDoc               encoder.Doc
 	InstallConfigDoc               encoder.Doc
 	InstallDiskSizeMatcherDoc      encoder.Doc
 	InstallDiskSelectorDoc         encoder.Doc
+	InstallConsoleDoc              encoder.Doc
+	InstallSecureBootConfigDoc     encoder.Doc
 	TimeConfigDoc                  encoder.Doc
+	VirtualizationConfigDoc        encoder.Doc
+	HealthzConfigDoc               encoder.Doc
+	MetricsConfigDoc               encoder.Doc
+	WatchdogConfigDoc              encoder.Doc
+	CrashDumpsConfigDoc            encoder.Doc
+	RamoopsConfigDoc               encoder.Doc
+	ImageCacheConfigDoc            encoder.Doc
+	FeaturesConfigDoc              encoder.Doc
+	CRIConfigDoc                   encoder.Doc
+	NVIDIAConfigDoc                encoder.Doc
+	ReconcileConfigDoc             encoder.Doc
+	MaintenanceWindowConfigDoc     encoder.Doc
+	RegistrationConfigDoc          encoder.Doc
+	IdentityConfigDoc              encoder.Doc
+	IdentityKMSConfigDoc           encoder.Doc
 	RegistriesConfigDoc            encoder.Doc
 	PodCheckpointerDoc             encoder.Doc
 	CoreDNSDoc                     encoder.Doc
@@ -34,6 +53,11 @@ var (
 	CNIConfigDoc                   encoder.Doc
 	ExternalCloudProviderConfigDoc encoder.Doc
 	AdminKubeconfigConfigDoc       encoder.Doc
+	AESCBCEncryptionKeyConfigDoc   encoder.Doc
+	SecretsEncryptionConfigDoc     encoder.Doc
+	SecretboxEncryptionConfigDoc   encoder.Doc
+	KMSEncryptionConfigDoc         encoder.Doc
+	ManifestsSyncConfigDoc         encoder.Doc
 	MachineDiskDoc                 encoder.Doc
 	DiskPartitionDoc               encoder.Doc
 	EncryptionConfigDoc            encoder.Doc
@@ -43,13 +67,29 @@ var (
 	MachineFileDoc                 encoder.Doc
 	ExtraHostDoc                   encoder.Doc
 	DeviceDoc                      encoder.Doc
+	EthernetConfigDoc              encoder.Doc
+	EthernetOffloadConfigDoc       encoder.Doc
+	EthernetRingsConfigDoc         encoder.Doc
+	NetworkDeviceSelectorDoc       encoder.Doc
 	DHCPOptionsDoc                 encoder.Doc
 	DeviceWireguardConfigDoc       encoder.Doc
 	DeviceWireguardPeerDoc         encoder.Doc
 	DeviceVIPConfigDoc             encoder.Doc
+	VIPEquinixMetalConfigDoc       encoder.Doc
+	VIPHCloudConfigDoc             encoder.Doc
 	BondDoc                        encoder.Doc
+	BridgeDoc                      encoder.Doc
+	STPDoc                         encoder.Doc
+	BridgeVLANDoc                  encoder.Doc
 	VlanDoc                        encoder.Doc
 	RouteDoc                       encoder.Doc
+	KubeSpanDoc                    encoder.Doc
+	SideroLinkDoc                  encoder.Doc
+	FirewallConfigDoc              encoder.Doc
+	FirewallRuleDoc                encoder.Doc
+	FirewallPortSelectorDoc        encoder.Doc
+	FirewallIngressRuleDoc         encoder.Doc
+	RoutingRuleDoc                 encoder.Doc
 	RegistryMirrorConfigDoc        encoder.Doc
 	RegistryConfigDoc              encoder.Doc
 	RegistryAuthConfigDoc          encoder.Doc
@@ -117,7 +157,7 @@ func init() {
 			FieldName: "machine",
 		},
 	}
-	MachineConfigDoc.Fields = make([]encoder.Doc, 14)
+	MachineConfigDoc.Fields = make([]encoder.Doc, 27)
 	MachineConfigDoc.Fields[0].Name = "type"
 	MachineConfigDoc.Fields[0].Type = "string"
 	MachineConfigDoc.Fields[0].Note = ""
@@ -149,87 +189,180 @@ func init() {
 	MachineConfigDoc.Fields[3].Comments[encoder.LineComment] = "Extra certificate subject alternative names for the machine's certificate."
 
 	MachineConfigDoc.Fields[3].AddExample("Uncomment this to enable SANs.", []string{"10.0.0.10", "172.16.0.10", "192.168.0.10"})
-	MachineConfigDoc.Fields[4].Name = "kubelet"
-	MachineConfigDoc.Fields[4].Type = "KubeletConfig"
+	MachineConfigDoc.Fields[4].Name = "joinPolicy"
+	MachineConfigDoc.Fields[4].Type = "JoinPolicyConfig"
 	MachineConfigDoc.Fields[4].Note = ""
-	MachineConfigDoc.Fields[4].Description = "Used to provide additional options to the kubelet."
-	MachineConfigDoc.Fields[4].Comments[encoder.LineComment] = "Used to provide additional options to the kubelet."
+	MachineConfigDoc.Fields[4].Description = "Configures how trustd authorizes certificate signing requests from joining machines.\nBy default, any machine presenting a valid `token` is issued a certificate."
+	MachineConfigDoc.Fields[4].Comments[encoder.LineComment] = "Configures how trustd authorizes certificate signing requests from joining machines."
 
-	MachineConfigDoc.Fields[4].AddExample("Kubelet definition example.", machineKubeletExample)
-	MachineConfigDoc.Fields[5].Name = "network"
-	MachineConfigDoc.Fields[5].Type = "NetworkConfig"
+	MachineConfigDoc.Fields[4].AddExample("Allowlist join policy example.", machineJoinPolicyAllowlistExample)
+
+	MachineConfigDoc.Fields[4].AddExample("Webhook join policy example.", machineJoinPolicyWebhookExample)
+	MachineConfigDoc.Fields[5].Name = "kubelet"
+	MachineConfigDoc.Fields[5].Type = "KubeletConfig"
 	MachineConfigDoc.Fields[5].Note = ""
-	MachineConfigDoc.Fields[5].Description = "Provides machine specific network configuration options."
-	MachineConfigDoc.Fields[5].Comments[encoder.LineComment] = "Provides machine specific network configuration options."
-
-	MachineConfigDoc.Fields[5].AddExample("Network definition example.", machineNetworkConfigExample)
-	MachineConfigDoc.Fields[6].Name = "disks"
-	MachineConfigDoc.Fields[6].Type = "[]MachineDisk"
-	MachineConfigDoc.Fields[6].Note = "Note: `size` is in units of bytes.\n"
-	MachineConfigDoc.Fields[6].Description = "Used to partition, format and mount additional disks.\nSince the rootfs is read only with the exception of `/var`, mounts are only valid if they are under `/var`.\nNote that the partitioning and formating is done only once, if and only if no existing partitions are found.\nIf `size:` is omitted, the partition is sized to occupy the full disk."
-	MachineConfigDoc.Fields[6].Comments[encoder.LineComment] = "Used to partition, format and mount additional disks."
-
-	MachineConfigDoc.Fields[6].AddExample("MachineDisks list example.", machineDisksExample)
-	MachineConfigDoc.Fields[7].Name = "install"
-	MachineConfigDoc.Fields[7].Type = "InstallConfig"
-	MachineConfigDoc.Fields[7].Note = ""
-	MachineConfigDoc.Fields[7].Description = "Used to provide instructions for installations."
-	MachineConfigDoc.Fields[7].Comments[encoder.LineComment] = "Used to provide instructions for installations."
-
-	MachineConfigDoc.Fields[7].AddExample("MachineInstall config usage example.", machineInstallExample)
-	MachineConfigDoc.Fields[8].Name = "files"
-	MachineConfigDoc.Fields[8].Type = "[]MachineFile"
-	MachineConfigDoc.Fields[8].Note = "Note: The specified `path` is relative to `/var`.\n"
-	MachineConfigDoc.Fields[8].Description = "Allows the addition of user specified files.\nThe value of `op` can be `create`, `overwrite`, or `append`.\nIn the case of `create`, `path` must not exist.\nIn the case of `overwrite`, and `append`, `path` must be a valid file.\nIf an `op` value of `append` is used, the existing file will be appended.\nNote that the file contents are not required to be base64 encoded."
-	MachineConfigDoc.Fields[8].Comments[encoder.LineComment] = "Allows the addition of user specified files."
-
-	MachineConfigDoc.Fields[8].AddExample("MachineFiles usage example.", machineFilesExample)
-	MachineConfigDoc.Fields[9].Name = "env"
-	MachineConfigDoc.Fields[9].Type = "Env"
-	MachineConfigDoc.Fields[9].Note = ""
-	MachineConfigDoc.Fields[9].Description = "The `env` field allows for the addition of environment variables.\nAll environment variables are set on PID 1 in addition to every service."
-	MachineConfigDoc.Fields[9].Comments[encoder.LineComment] = "The `env` field allows for the addition of environment variables."
-
-	MachineConfigDoc.Fields[9].AddExample("Environment variables definition examples.", machineEnvExamples[0])
-
-	MachineConfigDoc.Fields[9].AddExample("", machineEnvExamples[1])
-
-	MachineConfigDoc.Fields[9].AddExample("", machineEnvExamples[2])
-	MachineConfigDoc.Fields[9].Values = []string{
+	MachineConfigDoc.Fields[5].Description = "Used to provide additional options to the kubelet."
+	MachineConfigDoc.Fields[5].Comments[encoder.LineComment] = "Used to provide additional options to the kubelet."
+
+	MachineConfigDoc.Fields[5].AddExample("Kubelet definition example.", machineKubeletExample)
+	MachineConfigDoc.Fields[6].Name = "network"
+	MachineConfigDoc.Fields[6].Type = "NetworkConfig"
+	MachineConfigDoc.Fields[6].Note = ""
+	MachineConfigDoc.Fields[6].Description = "Provides machine specific network configuration options."
+	MachineConfigDoc.Fields[6].Comments[encoder.LineComment] = "Provides machine specific network configuration options."
+
+	MachineConfigDoc.Fields[6].AddExample("Network definition example.", machineNetworkConfigExample)
+	MachineConfigDoc.Fields[7].Name = "disks"
+	MachineConfigDoc.Fields[7].Type = "[]MachineDisk"
+	MachineConfigDoc.Fields[7].Note = "Note: `size` is in units of bytes.\n"
+	MachineConfigDoc.Fields[7].Description = "Used to partition, format and mount additional disks.\nSince the rootfs is read only with the exception of `/var`, mounts are only valid if they are under `/var`.\nNote that the partitioning and formating is done only once, if and only if no existing partitions are found.\nIf `size:` is omitted, the partition is sized to occupy the full disk."
+	MachineConfigDoc.Fields[7].Comments[encoder.LineComment] = "Used to partition, format and mount additional disks."
+
+	MachineConfigDoc.Fields[7].AddExample("MachineDisks list example.", machineDisksExample)
+	MachineConfigDoc.Fields[8].Name = "install"
+	MachineConfigDoc.Fields[8].Type = "InstallConfig"
+	MachineConfigDoc.Fields[8].Note = ""
+	MachineConfigDoc.Fields[8].Description = "Used to provide instructions for installations."
+	MachineConfigDoc.Fields[8].Comments[encoder.LineComment] = "Used to provide instructions for installations."
+
+	MachineConfigDoc.Fields[8].AddExample("MachineInstall config usage example.", machineInstallExample)
+	MachineConfigDoc.Fields[9].Name = "files"
+	MachineConfigDoc.Fields[9].Type = "[]MachineFile"
+	MachineConfigDoc.Fields[9].Note = "Note: The specified `path` is relative to `/var`.\n"
+	MachineConfigDoc.Fields[9].Description = "Allows the addition of user specified files.\nThe value of `op` can be `create`, `overwrite`, or `append`.\nIn the case of `create`, `path` must not exist.\nIn the case of `overwrite`, and `append`, `path` must be a valid file.\nIf an `op` value of `append` is used, the existing file will be appended.\nNote that the file contents are not required to be base64 encoded."
+	MachineConfigDoc.Fields[9].Comments[encoder.LineComment] = "Allows the addition of user specified files."
+
+	MachineConfigDoc.Fields[9].AddExample("MachineFiles usage example.", machineFilesExample)
+	MachineConfigDoc.Fields[10].Name = "env"
+	MachineConfigDoc.Fields[10].Type = "Env"
+	MachineConfigDoc.Fields[10].Note = ""
+	MachineConfigDoc.Fields[10].Description = "The `env` field allows for the addition of environment variables.\nAll environment variables are set on PID 1 in addition to every service."
+	MachineConfigDoc.Fields[10].Comments[encoder.LineComment] = "The `env` field allows for the addition of environment variables."
+
+	MachineConfigDoc.Fields[10].AddExample("Environment variables definition examples.", machineEnvExamples[0])
+
+	MachineConfigDoc.Fields[10].AddExample("", machineEnvExamples[1])
+
+	MachineConfigDoc.Fields[10].AddExample("", machineEnvExamples[2])
+	MachineConfigDoc.Fields[10].Values = []string{
 		"`GRPC_GO_LOG_VERBOSITY_LEVEL`",
 		"`GRPC_GO_LOG_SEVERITY_LEVEL`",
 		"`http_proxy`",
 		"`https_proxy`",
 		"`no_proxy`",
 	}
-	MachineConfigDoc.Fields[10].Name = "time"
-	MachineConfigDoc.Fields[10].Type = "TimeConfig"
-	MachineConfigDoc.Fields[10].Note = ""
-	MachineConfigDoc.Fields[10].Description = "Used to configure the machine's time settings."
-	MachineConfigDoc.Fields[10].Comments[encoder.LineComment] = "Used to configure the machine's time settings."
-
-	MachineConfigDoc.Fields[10].AddExample("Example configuration for cloudflare ntp server.", machineTimeExample)
-	MachineConfigDoc.Fields[11].Name = "sysctls"
-	MachineConfigDoc.Fields[11].Type = "map[string]string"
+	MachineConfigDoc.Fields[11].Name = "time"
+	MachineConfigDoc.Fields[11].Type = "TimeConfig"
 	MachineConfigDoc.Fields[11].Note = ""
-	MachineConfigDoc.Fields[11].Description = "Used to configure the machine's sysctls."
-	MachineConfigDoc.Fields[11].Comments[encoder.LineComment] = "Used to configure the machine's sysctls."
+	MachineConfigDoc.Fields[11].Description = "Used to configure the machine's time settings."
+	MachineConfigDoc.Fields[11].Comments[encoder.LineComment] = "Used to configure the machine's time settings."
 
-	MachineConfigDoc.Fields[11].AddExample("MachineSysctls usage example.", machineSysctlsExample)
-	MachineConfigDoc.Fields[12].Name = "registries"
-	MachineConfigDoc.Fields[12].Type = "RegistriesConfig"
+	MachineConfigDoc.Fields[11].AddExample("Example configuration for cloudflare ntp server.", machineTimeExample)
+	MachineConfigDoc.Fields[12].Name = "sysctls"
+	MachineConfigDoc.Fields[12].Type = "map[string]string"
 	MachineConfigDoc.Fields[12].Note = ""
-	MachineConfigDoc.Fields[12].Description = "Used to configure the machine's container image registry mirrors.\n\nAutomatically generates matching CRI configuration for registry mirrors.\n\nThe `mirrors` section allows to redirect requests for images to non-default registry,\nwhich might be local registry or caching mirror.\n\nThe `config` section provides a way to authenticate to the registry with TLS client\nidentity, provide registry CA, or authentication information.\nAuthentication information has same meaning with the corresponding field in `.docker/config.json`.\n\nSee also matching configuration for [CRI containerd plugin](https://github.com/containerd/cri/blob/master/docs/registry.md)."
-	MachineConfigDoc.Fields[12].Comments[encoder.LineComment] = "Used to configure the machine's container image registry mirrors."
+	MachineConfigDoc.Fields[12].Description = "Used to configure the machine's sysctls."
+	MachineConfigDoc.Fields[12].Comments[encoder.LineComment] = "Used to configure the machine's sysctls."
 
-	MachineConfigDoc.Fields[12].AddExample("", machineConfigRegistriesExample)
-	MachineConfigDoc.Fields[13].Name = "systemDiskEncryption"
-	MachineConfigDoc.Fields[13].Type = "SystemDiskEncryptionConfig"
+	MachineConfigDoc.Fields[12].AddExample("MachineSysctls usage example.", machineSysctlsExample)
+	MachineConfigDoc.Fields[13].Name = "virtualization"
+	MachineConfigDoc.Fields[13].Type = "VirtualizationConfig"
 	MachineConfigDoc.Fields[13].Note = ""
-	MachineConfigDoc.Fields[13].Description = "Machine system disk encryption configuration.\nDefines each system partition encryption parameters."
-	MachineConfigDoc.Fields[13].Comments[encoder.LineComment] = "Machine system disk encryption configuration."
-
-	MachineConfigDoc.Fields[13].AddExample("", machineSystemDiskEncryptionExample)
+	MachineConfigDoc.Fields[13].Description = "Used to configure the machine's hardware virtualization (KVM) settings."
+	MachineConfigDoc.Fields[13].Comments[encoder.LineComment] = "Used to configure the machine's hardware virtualization (KVM) settings."
+
+	MachineConfigDoc.Fields[13].AddExample("MachineVirtualization usage example.", machineVirtualizationExample)
+	MachineConfigDoc.Fields[14].Name = "registries"
+	MachineConfigDoc.Fields[14].Type = "RegistriesConfig"
+	MachineConfigDoc.Fields[14].Note = ""
+	MachineConfigDoc.Fields[14].Description = "Used to configure the machine's container image registry mirrors.\n\nAutomatically generates matching CRI configuration for registry mirrors.\n\nThe `mirrors` section allows to redirect requests for images to non-default registry,\nwhich might be local registry or caching mirror.\n\nThe `config` section provides a way to authenticate to the registry with TLS client\nidentity, provide registry CA, or authentication information.\nAuthentication information has same meaning with the corresponding field in `.docker/config.json`.\n\nSee also matching configuration for [CRI containerd plugin](https://github.com/containerd/cri/blob/master/docs/registry.md)."
+	MachineConfigDoc.Fields[14].Comments[encoder.LineComment] = "Used to configure the machine's container image registry mirrors."
+
+	MachineConfigDoc.Fields[14].AddExample("", machineConfigRegistriesExample)
+	MachineConfigDoc.Fields[15].Name = "systemDiskEncryption"
+	MachineConfigDoc.Fields[15].Type = "SystemDiskEncryptionConfig"
+	MachineConfigDoc.Fields[15].Note = ""
+	MachineConfigDoc.Fields[15].Description = "Machine system disk encryption configuration.\nDefines each system partition encryption parameters."
+	MachineConfigDoc.Fields[15].Comments[encoder.LineComment] = "Machine system disk encryption configuration."
+
+	MachineConfigDoc.Fields[15].AddExample("", machineSystemDiskEncryptionExample)
+	MachineConfigDoc.Fields[16].Name = "healthz"
+	MachineConfigDoc.Fields[16].Type = "HealthzConfig"
+	MachineConfigDoc.Fields[16].Note = ""
+	MachineConfigDoc.Fields[16].Description = "Node health endpoint configuration.\nThe endpoint reports the boot stage, the health of the services and the node readiness over HTTP,\nand can be used as a health check by the external load balancers."
+	MachineConfigDoc.Fields[16].Comments[encoder.LineComment] = "Node health endpoint configuration."
+
+	MachineConfigDoc.Fields[16].AddExample("", machineHealthzExample)
+	MachineConfigDoc.Fields[17].Name = "metrics"
+	MachineConfigDoc.Fields[17].Type = "MetricsConfig"
+	MachineConfigDoc.Fields[17].Note = ""
+	MachineConfigDoc.Fields[17].Description = "Node metrics endpoint configuration.\nMetrics of `machined` and `apid` (service restarts, controller reconciles, API request latencies, etc.)\nare served in the Prometheus text format."
+	MachineConfigDoc.Fields[17].Comments[encoder.LineComment] = "Node metrics endpoint configuration."
+
+	MachineConfigDoc.Fields[17].AddExample("", machineMetricsExample)
+	MachineConfigDoc.Fields[18].Name = "watchdog"
+	MachineConfigDoc.Fields[18].Type = "WatchdogConfig"
+	MachineConfigDoc.Fields[18].Note = ""
+	MachineConfigDoc.Fields[18].Description = "Watchdog timer configuration.\nThe watchdog is petted by `machined` only while `machined` is responsive, so that a hung node is restarted automatically."
+	MachineConfigDoc.Fields[18].Comments[encoder.LineComment] = "Watchdog timer configuration."
+
+	MachineConfigDoc.Fields[18].AddExample("", machineWatchdogExample)
+	MachineConfigDoc.Fields[19].Name = "crashDumps"
+	MachineConfigDoc.Fields[19].Type = "CrashDumpsConfig"
+	MachineConfigDoc.Fields[19].Note = ""
+	MachineConfigDoc.Fields[19].Description = "Kernel crash dumps capture configuration.\nKernel logs of the crashed boot are captured via pstore, collected on the next boot and stored in the STATE partition."
+	MachineConfigDoc.Fields[19].Comments[encoder.LineComment] = "Kernel crash dumps capture configuration."
+
+	MachineConfigDoc.Fields[19].AddExample("", machineCrashDumpsExample)
+	MachineConfigDoc.Fields[20].Name = "imageCache"
+	MachineConfigDoc.Fields[20].Type = "ImageCacheConfig"
+	MachineConfigDoc.Fields[20].Note = ""
+	MachineConfigDoc.Fields[20].Description = "Local image cache configuration.\nImages are served from the OCI image layout bundled into the installation media or stored in the `IMAGECACHE` partition,\nthe registries are only used for the images missing from the cache."
+	MachineConfigDoc.Fields[20].Comments[encoder.LineComment] = "Local image cache configuration."
+
+	MachineConfigDoc.Fields[20].AddExample("", machineImageCacheExample)
+	MachineConfigDoc.Fields[21].Name = "features"
+	MachineConfigDoc.Fields[21].Type = "FeaturesConfig"
+	MachineConfigDoc.Fields[21].Note = ""
+	MachineConfigDoc.Fields[21].Description = "Optional features of Talos."
+	MachineConfigDoc.Fields[21].Comments[encoder.LineComment] = "Optional features of Talos."
+
+	MachineConfigDoc.Fields[21].AddExample("", machineFeaturesExample)
+	MachineConfigDoc.Fields[22].Name = "cri"
+	MachineConfigDoc.Fields[22].Type = "CRIConfig"
+	MachineConfigDoc.Fields[22].Note = ""
+	MachineConfigDoc.Fields[22].Description = "CRI (containerd) configuration.\nAllows to override the sandbox image and to patch the containerd CRI configuration generated by Talos."
+	MachineConfigDoc.Fields[22].Comments[encoder.LineComment] = "CRI (containerd) configuration."
+
+	MachineConfigDoc.Fields[22].AddExample("", machineCRIExample)
+	MachineConfigDoc.Fields[23].Name = "nvidia"
+	MachineConfigDoc.Fields[23].Type = "NVIDIAConfig"
+	MachineConfigDoc.Fields[23].Note = ""
+	MachineConfigDoc.Fields[23].Description = "NVIDIA GPU support.\nRequires the NVIDIA extension (kernel modules and container toolkit) to be included into the Talos image."
+	MachineConfigDoc.Fields[23].Comments[encoder.LineComment] = "NVIDIA GPU support."
+
+	MachineConfigDoc.Fields[23].AddExample("", machineNVIDIAExample)
+	MachineConfigDoc.Fields[24].Name = "reconcile"
+	MachineConfigDoc.Fields[24].Type = "ReconcileConfig"
+	MachineConfigDoc.Fields[24].Note = ""
+	MachineConfigDoc.Fields[24].Description = "Pauses the automatic reconciliation of the cluster state during maintenance.\nWhile paused, Talos doesn't re-apply bootstrap manifests and doesn't re-render control plane static pods."
+	MachineConfigDoc.Fields[24].Comments[encoder.LineComment] = "Pauses the automatic reconciliation of the cluster state during maintenance."
+
+	MachineConfigDoc.Fields[24].AddExample("", machineReconcileExample)
+	MachineConfigDoc.Fields[25].Name = "registration"
+	MachineConfigDoc.Fields[25].Type = "RegistrationConfig"
+	MachineConfigDoc.Fields[25].Note = ""
+	MachineConfigDoc.Fields[25].Description = "Registers the node with the external inventory (management) API.\nNode reports its identity, platform and network facts on boot and whenever they change,\nand sends heartbeats in between."
+	MachineConfigDoc.Fields[25].Comments[encoder.LineComment] = "Registers the node with the external inventory (management) API."
+
+	MachineConfigDoc.Fields[25].AddExample("", machineRegistrationExample)
+	MachineConfigDoc.Fields[26].Name = "identity"
+	MachineConfigDoc.Fields[26].Type = "IdentityConfig"
+	MachineConfigDoc.Fields[26].Note = ""
+	MachineConfigDoc.Fields[26].Description = "Configures the persistent machine identity (the key pair the node ID is derived from)."
+	MachineConfigDoc.Fields[26].Comments[encoder.LineComment] = "Configures the persistent machine identity (the key pair the node ID is derived from)."
+
+	MachineConfigDoc.Fields[26].AddExample("", machineIdentityExample)
 
 	ClusterConfigDoc.Type = "ClusterConfig"
 	ClusterConfigDoc.Comments[encoder.LineComment] = "ClusterConfig represents the cluster-wide config values."
@@ -242,7 +375,7 @@ func init() {
 			FieldName: "cluster",
 		},
 	}
-	ClusterConfigDoc.Fields = make([]encoder.Doc, 20)
+	ClusterConfigDoc.Fields = make([]encoder.Doc, 26)
 	ClusterConfigDoc.Fields[0].Name = "controlPlane"
 	ClusterConfigDoc.Fields[0].Type = "ControlPlaneConfig"
 	ClusterConfigDoc.Fields[0].Note = ""
@@ -255,143 +388,248 @@ func init() {
 	ClusterConfigDoc.Fields[1].Note = ""
 	ClusterConfigDoc.Fields[1].Description = "Configures the cluster's name."
 	ClusterConfigDoc.Fields[1].Comments[encoder.LineComment] = "Configures the cluster's name."
-	ClusterConfigDoc.Fields[2].Name = "network"
-	ClusterConfigDoc.Fields[2].Type = "ClusterNetworkConfig"
+	ClusterConfigDoc.Fields[2].Name = "id"
+	ClusterConfigDoc.Fields[2].Type = "string"
 	ClusterConfigDoc.Fields[2].Note = ""
-	ClusterConfigDoc.Fields[2].Description = "Provides cluster specific network configuration options."
-	ClusterConfigDoc.Fields[2].Comments[encoder.LineComment] = "Provides cluster specific network configuration options."
+	ClusterConfigDoc.Fields[2].Description = "Globally unique identifier of the cluster (base64 encoded random 32 bytes).\n\nThe identifier should be the same on all the nodes of the cluster, and it should never be changed:\nKubeSpan discovery service cluster ID and the mesh subnet are derived from it."
+	ClusterConfigDoc.Fields[2].Comments[encoder.LineComment] = "Globally unique identifier of the cluster (base64 encoded random 32 bytes)."
 
-	ClusterConfigDoc.Fields[2].AddExample("Configuring with flannel CNI and setting up subnets.", clusterNetworkExample)
-	ClusterConfigDoc.Fields[3].Name = "token"
+	ClusterConfigDoc.Fields[2].AddExample("Cluster ID example (do not use in production!).", "lkQf6BzqCVgNfYAkdd6wRGSO8R0ZHJ7sp8EZzqG9eVw=")
+	ClusterConfigDoc.Fields[3].Name = "secret"
 	ClusterConfigDoc.Fields[3].Type = "string"
 	ClusterConfigDoc.Fields[3].Note = ""
-	ClusterConfigDoc.Fields[3].Description = "The [bootstrap token](https://kubernetes.io/docs/reference/access-authn-authz/bootstrap-tokens/) used to join the cluster."
-	ClusterConfigDoc.Fields[3].Comments[encoder.LineComment] = "The [bootstrap token](https://kubernetes.io/docs/reference/access-authn-authz/bootstrap-tokens/) used to join the cluster."
+	ClusterConfigDoc.Fields[3].Description = "Shared secret of the cluster (base64 encoded random 32 bytes).\n\nThe secret should be the same on all the nodes of the cluster, and it should never be changed:\nKubeSpan discovery data is encrypted with the key derived from it. The secret is never sent over the network."
+	ClusterConfigDoc.Fields[3].Comments[encoder.LineComment] = "Shared secret of the cluster (base64 encoded random 32 bytes)."
 
-	ClusterConfigDoc.Fields[3].AddExample("Bootstrap token example (do not use in production!).", "wlzjyw.bei2zfylhs2by0wd")
-	ClusterConfigDoc.Fields[4].Name = "aescbcEncryptionSecret"
-	ClusterConfigDoc.Fields[4].Type = "string"
+	ClusterConfigDoc.Fields[3].AddExample("Cluster secret example (do not use in production!).", "6Uua3fLyrFwddHSn2GAvsQ1Pe5TbFfmaUcUKVNSmSnw=")
+	ClusterConfigDoc.Fields[4].Name = "network"
+	ClusterConfigDoc.Fields[4].Type = "ClusterNetworkConfig"
 	ClusterConfigDoc.Fields[4].Note = ""
-	ClusterConfigDoc.Fields[4].Description = "The key used for the [encryption of secret data at rest](https://kubernetes.io/docs/tasks/administer-cluster/encrypt-data/)."
-	ClusterConfigDoc.Fields[4].Comments[encoder.LineComment] = "The key used for the [encryption of secret data at rest](https://kubernetes.io/docs/tasks/administer-cluster/encrypt-data/)."
+	ClusterConfigDoc.Fields[4].Description = "Provides cluster specific network configuration options."
+	ClusterConfigDoc.Fields[4].Comments[encoder.LineComment] = "Provides cluster specific network configuration options."
 
-	ClusterConfigDoc.Fields[4].AddExample("Decryption secret example (do not use in production!).", "z01mye6j16bspJYtTB/5SFX8j7Ph4JXxM2Xuu4vsBPM=")
-	ClusterConfigDoc.Fields[5].Name = "ca"
-	ClusterConfigDoc.Fields[5].Type = "PEMEncodedCertificateAndKey"
+	ClusterConfigDoc.Fields[4].AddExample("Configuring with flannel CNI and setting up subnets.", clusterNetworkExample)
+	ClusterConfigDoc.Fields[5].Name = "token"
+	ClusterConfigDoc.Fields[5].Type = "string"
 	ClusterConfigDoc.Fields[5].Note = ""
-	ClusterConfigDoc.Fields[5].Description = "The base64 encoded root certificate authority used by Kubernetes."
-	ClusterConfigDoc.Fields[5].Comments[encoder.LineComment] = "The base64 encoded root certificate authority used by Kubernetes."
+	ClusterConfigDoc.Fields[5].Description = "The [bootstrap token](https://kubernetes.io/docs/reference/access-authn-authz/bootstrap-tokens/) used to join the cluster."
+	ClusterConfigDoc.Fields[5].Comments[encoder.LineComment] = "The [bootstrap token](https://kubernetes.io/docs/reference/access-authn-authz/bootstrap-tokens/) used to join the cluster."
 
-	ClusterConfigDoc.Fields[5].AddExample("ClusterCA example.", pemEncodedCertificateExample)
-	ClusterConfigDoc.Fields[6].Name = "aggregatorCA"
-	ClusterConfigDoc.Fields[6].Type = "PEMEncodedCertificateAndKey"
+	ClusterConfigDoc.Fields[5].AddExample("Bootstrap token example (do not use in production!).", "wlzjyw.bei2zfylhs2by0wd")
+	ClusterConfigDoc.Fields[6].Name = "aescbcEncryptionSecret"
+	ClusterConfigDoc.Fields[6].Type = "string"
 	ClusterConfigDoc.Fields[6].Note = ""
-	ClusterConfigDoc.Fields[6].Description = "The base64 encoded aggregator certificate authority used by Kubernetes for front-proxy certificate generation.\n\nThis CA can be self-signed."
-	ClusterConfigDoc.Fields[6].Comments[encoder.LineComment] = "The base64 encoded aggregator certificate authority used by Kubernetes for front-proxy certificate generation."
+	ClusterConfigDoc.Fields[6].Description = "The key used for the [encryption of secret data at rest](https://kubernetes.io/docs/tasks/administer-cluster/encrypt-data/)."
+	ClusterConfigDoc.Fields[6].Comments[encoder.LineComment] = "The key used for the [encryption of secret data at rest](https://kubernetes.io/docs/tasks/administer-cluster/encrypt-data/)."
 
-	ClusterConfigDoc.Fields[6].AddExample("AggregatorCA example.", pemEncodedCertificateExample)
-	ClusterConfigDoc.Fields[7].Name = "serviceAccount"
-	ClusterConfigDoc.Fields[7].Type = "PEMEncodedKey"
+	ClusterConfigDoc.Fields[6].AddExample("Decryption secret example (do not use in production!).", "z01mye6j16bspJYtTB/5SFX8j7Ph4JXxM2Xuu4vsBPM=")
+	ClusterConfigDoc.Fields[7].Name = "aescbcEncryptionKeyName"
+	ClusterConfigDoc.Fields[7].Type = "string"
 	ClusterConfigDoc.Fields[7].Note = ""
-	ClusterConfigDoc.Fields[7].Description = "The base64 encoded private key for service account token generation."
-	ClusterConfigDoc.Fields[7].Comments[encoder.LineComment] = "The base64 encoded private key for service account token generation."
+	ClusterConfigDoc.Fields[7].Description = "The name of the `aescbcEncryptionSecret` key in the encryption configuration, defaults to `key1`.\n\nKubernetes stores the name of the key along with the encrypted data, so the name should be changed\ntogether with the secret when the key is rotated."
+	ClusterConfigDoc.Fields[7].Comments[encoder.LineComment] = "The name of the `aescbcEncryptionSecret` key in the encryption configuration, defaults to `key1`."
 
-	ClusterConfigDoc.Fields[7].AddExample("AggregatorCA example.", pemEncodedKeyExample)
-	ClusterConfigDoc.Fields[8].Name = "apiServer"
-	ClusterConfigDoc.Fields[8].Type = "APIServerConfig"
+	ClusterConfigDoc.Fields[7].AddExample("", "key2")
+	ClusterConfigDoc.Fields[8].Name = "aescbcAdditionalEncryptionKeys"
+	ClusterConfigDoc.Fields[8].Type = "[]AESCBCEncryptionKeyConfig"
 	ClusterConfigDoc.Fields[8].Note = ""
-	ClusterConfigDoc.Fields[8].Description = "API server specific configuration options."
-	ClusterConfigDoc.Fields[8].Comments[encoder.LineComment] = "API server specific configuration options."
+	ClusterConfigDoc.Fields[8].Description = "Additional keys which are used only to decrypt the secret data at rest.\n\nThe previous key is kept here while the key is rotated, until all the secrets are re-encrypted with the new key."
+	ClusterConfigDoc.Fields[8].Comments[encoder.LineComment] = "Additional keys which are used only to decrypt the secret data at rest."
 
-	ClusterConfigDoc.Fields[8].AddExample("", clusterAPIServerExample)
-	ClusterConfigDoc.Fields[9].Name = "controllerManager"
-	ClusterConfigDoc.Fields[9].Type = "ControllerManagerConfig"
+	ClusterConfigDoc.Fields[8].AddExample("", clusterAESCBCAdditionalEncryptionKeysExample)
+	ClusterConfigDoc.Fields[9].Name = "secretsEncryption"
+	ClusterConfigDoc.Fields[9].Type = "SecretsEncryptionConfig"
 	ClusterConfigDoc.Fields[9].Note = ""
-	ClusterConfigDoc.Fields[9].Description = "Controller manager server specific configuration options."
-	ClusterConfigDoc.Fields[9].Comments[encoder.LineComment] = "Controller manager server specific configuration options."
+	ClusterConfigDoc.Fields[9].Description = "The provider for the encryption of secret data at rest, and the configuration of the providers other than `aescbc`.\n\nAll the configured providers (and `aescbc`) are used to decrypt the data, so the provider can be changed\nwithout losing access to the data encrypted by the previous provider."
+	ClusterConfigDoc.Fields[9].Comments[encoder.LineComment] = "The provider for the encryption of secret data at rest, and the configuration of the providers other than `aescbc`."
 
-	ClusterConfigDoc.Fields[9].AddExample("", clusterControllerManagerExample)
-	ClusterConfigDoc.Fields[10].Name = "proxy"
-	ClusterConfigDoc.Fields[10].Type = "ProxyConfig"
+	ClusterConfigDoc.Fields[9].AddExample("", clusterSecretsEncryptionExample)
+	ClusterConfigDoc.Fields[10].Name = "ca"
+	ClusterConfigDoc.Fields[10].Type = "PEMEncodedCertificateAndKey"
 	ClusterConfigDoc.Fields[10].Note = ""
-	ClusterConfigDoc.Fields[10].Description = "Kube-proxy server-specific configuration options"
-	ClusterConfigDoc.Fields[10].Comments[encoder.LineComment] = "Kube-proxy server-specific configuration options"
+	ClusterConfigDoc.Fields[10].Description = "The base64 encoded root certificate authority used by Kubernetes."
+	ClusterConfigDoc.Fields[10].Comments[encoder.LineComment] = "The base64 encoded root certificate authority used by Kubernetes."
 
-	ClusterConfigDoc.Fields[10].AddExample("", clusterProxyExample)
-	ClusterConfigDoc.Fields[11].Name = "scheduler"
-	ClusterConfigDoc.Fields[11].Type = "SchedulerConfig"
+	ClusterConfigDoc.Fields[10].AddExample("ClusterCA example.", pemEncodedCertificateExample)
+	ClusterConfigDoc.Fields[11].Name = "aggregatorCA"
+	ClusterConfigDoc.Fields[11].Type = "PEMEncodedCertificateAndKey"
 	ClusterConfigDoc.Fields[11].Note = ""
-	ClusterConfigDoc.Fields[11].Description = "Scheduler server specific configuration options."
-	ClusterConfigDoc.Fields[11].Comments[encoder.LineComment] = "Scheduler server specific configuration options."
+	ClusterConfigDoc.Fields[11].Description = "The base64 encoded aggregator certificate authority used by Kubernetes for front-proxy certificate generation.\n\nThis CA can be self-signed."
+	ClusterConfigDoc.Fields[11].Comments[encoder.LineComment] = "The base64 encoded aggregator certificate authority used by Kubernetes for front-proxy certificate generation."
 
-	ClusterConfigDoc.Fields[11].AddExample("", clusterSchedulerExample)
-	ClusterConfigDoc.Fields[12].Name = "etcd"
-	ClusterConfigDoc.Fields[12].Type = "EtcdConfig"
+	ClusterConfigDoc.Fields[11].AddExample("AggregatorCA example.", pemEncodedCertificateExample)
+	ClusterConfigDoc.Fields[12].Name = "serviceAccount"
+	ClusterConfigDoc.Fields[12].Type = "PEMEncodedKey"
 	ClusterConfigDoc.Fields[12].Note = ""
-	ClusterConfigDoc.Fields[12].Description = "Etcd specific configuration options."
-	ClusterConfigDoc.Fields[12].Comments[encoder.LineComment] = "Etcd specific configuration options."
+	ClusterConfigDoc.Fields[12].Description = "The base64 encoded private key for service account token generation."
+	ClusterConfigDoc.Fields[12].Comments[encoder.LineComment] = "The base64 encoded private key for service account token generation."
 
-	ClusterConfigDoc.Fields[12].AddExample("", clusterEtcdExample)
-	ClusterConfigDoc.Fields[13].Name = "podCheckpointer"
-	ClusterConfigDoc.Fields[13].Type = "PodCheckpointer"
+	ClusterConfigDoc.Fields[12].AddExample("AggregatorCA example.", pemEncodedKeyExample)
+	ClusterConfigDoc.Fields[13].Name = "apiServer"
+	ClusterConfigDoc.Fields[13].Type = "APIServerConfig"
 	ClusterConfigDoc.Fields[13].Note = ""
-	ClusterConfigDoc.Fields[13].Description = "Pod Checkpointer specific configuration options."
-	ClusterConfigDoc.Fields[13].Comments[encoder.LineComment] = "Pod Checkpointer specific configuration options."
+	ClusterConfigDoc.Fields[13].Description = "API server specific configuration options."
+	ClusterConfigDoc.Fields[13].Comments[encoder.LineComment] = "API server specific configuration options."
 
-	ClusterConfigDoc.Fields[13].AddExample("", clusterPodCheckpointerExample)
-	ClusterConfigDoc.Fields[14].Name = "coreDNS"
-	ClusterConfigDoc.Fields[14].Type = "CoreDNS"
+	ClusterConfigDoc.Fields[13].AddExample("", clusterAPIServerExample)
+	ClusterConfigDoc.Fields[14].Name = "controllerManager"
+	ClusterConfigDoc.Fields[14].Type = "ControllerManagerConfig"
 	ClusterConfigDoc.Fields[14].Note = ""
-	ClusterConfigDoc.Fields[14].Description = "Core DNS specific configuration options."
-	ClusterConfigDoc.Fields[14].Comments[encoder.LineComment] = "Core DNS specific configuration options."
+	ClusterConfigDoc.Fields[14].Description = "Controller manager server specific configuration options."
+	ClusterConfigDoc.Fields[14].Comments[encoder.LineComment] = "Controller manager server specific configuration options."
 
-	ClusterConfigDoc.Fields[14].AddExample("", clusterCoreDNSExample)
-	ClusterConfigDoc.Fields[15].Name = "externalCloudProvider"
-	ClusterConfigDoc.Fields[15].Type = "ExternalCloudProviderConfig"
+	ClusterConfigDoc.Fields[14].AddExample("", clusterControllerManagerExample)
+	ClusterConfigDoc.Fields[15].Name = "proxy"
+	ClusterConfigDoc.Fields[15].Type = "ProxyConfig"
 	ClusterConfigDoc.Fields[15].Note = ""
-	ClusterConfigDoc.Fields[15].Description = "External cloud provider configuration."
-	ClusterConfigDoc.Fields[15].Comments[encoder.LineComment] = "External cloud provider configuration."
+	ClusterConfigDoc.Fields[15].Description = "Kube-proxy server-specific configuration options"
+	ClusterConfigDoc.Fields[15].Comments[encoder.LineComment] = "Kube-proxy server-specific configuration options"
 
-	ClusterConfigDoc.Fields[15].AddExample("", clusterExternalCloudProviderConfigExample)
-	ClusterConfigDoc.Fields[16].Name = "extraManifests"
-	ClusterConfigDoc.Fields[16].Type = "[]string"
+	ClusterConfigDoc.Fields[15].AddExample("", clusterProxyExample)
+	ClusterConfigDoc.Fields[16].Name = "scheduler"
+	ClusterConfigDoc.Fields[16].Type = "SchedulerConfig"
 	ClusterConfigDoc.Fields[16].Note = ""
-	ClusterConfigDoc.Fields[16].Description = "A list of urls that point to additional manifests.\nThese will get automatically deployed as part of the bootstrap."
-	ClusterConfigDoc.Fields[16].Comments[encoder.LineComment] = "A list of urls that point to additional manifests."
+	ClusterConfigDoc.Fields[16].Description = "Scheduler server specific configuration options."
+	ClusterConfigDoc.Fields[16].Comments[encoder.LineComment] = "Scheduler server specific configuration options."
+
+	ClusterConfigDoc.Fields[16].AddExample("", clusterSchedulerExample)
+	ClusterConfigDoc.Fields[17].Name = "etcd"
+	ClusterConfigDoc.Fields[17].Type = "EtcdConfig"
+	ClusterConfigDoc.Fields[17].Note = ""
+	ClusterConfigDoc.Fields[17].Description = "Etcd specific configuration options."
+	ClusterConfigDoc.Fields[17].Comments[encoder.LineComment] = "Etcd specific configuration options."
 
-	ClusterConfigDoc.Fields[16].AddExample("", []string{
+	ClusterConfigDoc.Fields[17].AddExample("", clusterEtcdExample)
+	ClusterConfigDoc.Fields[18].Name = "podCheckpointer"
+	ClusterConfigDoc.Fields[18].Type = "PodCheckpointer"
+	ClusterConfigDoc.Fields[18].Note = ""
+	ClusterConfigDoc.Fields[18].Description = "Pod Checkpointer specific configuration options."
+	ClusterConfigDoc.Fields[18].Comments[encoder.LineComment] = "Pod Checkpointer specific configuration options."
+
+	ClusterConfigDoc.Fields[18].AddExample("", clusterPodCheckpointerExample)
+	ClusterConfigDoc.Fields[19].Name = "coreDNS"
+	ClusterConfigDoc.Fields[19].Type = "CoreDNS"
+	ClusterConfigDoc.Fields[19].Note = ""
+	ClusterConfigDoc.Fields[19].Description = "Core DNS specific configuration options."
+	ClusterConfigDoc.Fields[19].Comments[encoder.LineComment] = "Core DNS specific configuration options."
+
+	ClusterConfigDoc.Fields[19].AddExample("", clusterCoreDNSExample)
+	ClusterConfigDoc.Fields[20].Name = "externalCloudProvider"
+	ClusterConfigDoc.Fields[20].Type = "ExternalCloudProviderConfig"
+	ClusterConfigDoc.Fields[20].Note = ""
+	ClusterConfigDoc.Fields[20].Description = "External cloud provider configuration."
+	ClusterConfigDoc.Fields[20].Comments[encoder.LineComment] = "External cloud provider configuration."
+
+	ClusterConfigDoc.Fields[20].AddExample("", clusterExternalCloudProviderConfigExample)
+	ClusterConfigDoc.Fields[21].Name = "extraManifests"
+	ClusterConfigDoc.Fields[21].Type = "[]string"
+	ClusterConfigDoc.Fields[21].Note = ""
+	ClusterConfigDoc.Fields[21].Description = "A list of urls that point to additional manifests.\nThese will get automatically deployed as part of the bootstrap."
+	ClusterConfigDoc.Fields[21].Comments[encoder.LineComment] = "A list of urls that point to additional manifests."
+
+	ClusterConfigDoc.Fields[21].AddExample("", []string{
 		"https://www.example.com/manifest1.yaml",
 		"https://www.example.com/manifest2.yaml",
 	})
-	ClusterConfigDoc.Fields[17].Name = "extraManifestHeaders"
-	ClusterConfigDoc.Fields[17].Type = "map[string]string"
-	ClusterConfigDoc.Fields[17].Note = ""
-	ClusterConfigDoc.Fields[17].Description = "A map of key value pairs that will be added while fetching the extraManifests."
-	ClusterConfigDoc.Fields[17].Comments[encoder.LineComment] = "A map of key value pairs that will be added while fetching the extraManifests."
+	ClusterConfigDoc.Fields[22].Name = "extraManifestHeaders"
+	ClusterConfigDoc.Fields[22].Type = "map[string]string"
+	ClusterConfigDoc.Fields[22].Note = ""
+	ClusterConfigDoc.Fields[22].Description = "A map of key value pairs that will be added while fetching the extraManifests."
+	ClusterConfigDoc.Fields[22].Comments[encoder.LineComment] = "A map of key value pairs that will be added while fetching the extraManifests."
 
-	ClusterConfigDoc.Fields[17].AddExample("", map[string]string{
+	ClusterConfigDoc.Fields[22].AddExample("", map[string]string{
 		"Token":       "1234567",
 		"X-ExtraInfo": "info",
 	})
-	ClusterConfigDoc.Fields[18].Name = "adminKubeconfig"
-	ClusterConfigDoc.Fields[18].Type = "AdminKubeconfigConfig"
-	ClusterConfigDoc.Fields[18].Note = ""
-	ClusterConfigDoc.Fields[18].Description = "Settings for admin kubeconfig generation.\nCertificate lifetime can be configured."
-	ClusterConfigDoc.Fields[18].Comments[encoder.LineComment] = "Settings for admin kubeconfig generation."
-
-	ClusterConfigDoc.Fields[18].AddExample("", clusterAdminKubeconfigExample)
-	ClusterConfigDoc.Fields[19].Name = "allowSchedulingOnMasters"
-	ClusterConfigDoc.Fields[19].Type = "bool"
-	ClusterConfigDoc.Fields[19].Note = ""
-	ClusterConfigDoc.Fields[19].Description = "Allows running workload on master nodes."
-	ClusterConfigDoc.Fields[19].Comments[encoder.LineComment] = "Allows running workload on master nodes."
-	ClusterConfigDoc.Fields[19].Values = []string{
+	ClusterConfigDoc.Fields[23].Name = "manifestsSync"
+	ClusterConfigDoc.Fields[23].Type = "ManifestsSyncConfig"
+	ClusterConfigDoc.Fields[23].Note = ""
+	ClusterConfigDoc.Fields[23].Description = "Settings of the continuous reconciliation of the bootstrap manifests (including extra manifests).\n\nTalos creates the missing objects of the manifests and repairs the drift of the objects (with server-side apply),\nunless the manifest is listed in `unmanagedManifests`."
+	ClusterConfigDoc.Fields[23].Comments[encoder.LineComment] = "Settings of the continuous reconciliation of the bootstrap manifests (including extra manifests)."
+
+	ClusterConfigDoc.Fields[23].AddExample("", clusterManifestsSyncExample)
+	ClusterConfigDoc.Fields[24].Name = "adminKubeconfig"
+	ClusterConfigDoc.Fields[24].Type = "AdminKubeconfigConfig"
+	ClusterConfigDoc.Fields[24].Note = ""
+	ClusterConfigDoc.Fields[24].Description = "Settings for admin kubeconfig generation.\nCertificate lifetime can be configured."
+	ClusterConfigDoc.Fields[24].Comments[encoder.LineComment] = "Settings for admin kubeconfig generation."
+
+	ClusterConfigDoc.Fields[24].AddExample("", clusterAdminKubeconfigExample)
+	ClusterConfigDoc.Fields[25].Name = "allowSchedulingOnMasters"
+	ClusterConfigDoc.Fields[25].Type = "bool"
+	ClusterConfigDoc.Fields[25].Note = ""
+	ClusterConfigDoc.Fields[25].Description = "Allows running workload on master nodes."
+	ClusterConfigDoc.Fields[25].Comments[encoder.LineComment] = "Allows running workload on master nodes."
+	ClusterConfigDoc.Fields[25].Values = []string{
 		"true",
 		"yes",
 		"false",
 		"no",
 	}
 
+	JoinPolicyConfigDoc.Type = "JoinPolicyConfig"
+	JoinPolicyConfigDoc.Comments[encoder.LineComment] = "JoinPolicyConfig represents the trustd join authorization policy."
+	JoinPolicyConfigDoc.Description = "JoinPolicyConfig represents the trustd join authorization policy."
+
+	JoinPolicyConfigDoc.AddExample("Allowlist join policy example.", machineJoinPolicyAllowlistExample)
+
+	JoinPolicyConfigDoc.AddExample("Webhook join policy example.", machineJoinPolicyWebhookExample)
+	JoinPolicyConfigDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "MachineConfig",
+			FieldName: "joinPolicy",
+		},
+	}
+	JoinPolicyConfigDoc.Fields = make([]encoder.Doc, 3)
+	JoinPolicyConfigDoc.Fields[0].Name = "mode"
+	JoinPolicyConfigDoc.Fields[0].Type = "string"
+	JoinPolicyConfigDoc.Fields[0].Note = ""
+	JoinPolicyConfigDoc.Fields[0].Description = "Authorization mode for certificate signing requests.\n\n`token` issues a certificate to any machine presenting a valid `token`.\n`allowlist` additionally requires the CSR to match one of `allowedIdentities`.\n`webhook` additionally requires the external authorizer to approve the CSR."
+	JoinPolicyConfigDoc.Fields[0].Comments[encoder.LineComment] = "Authorization mode for certificate signing requests."
+	JoinPolicyConfigDoc.Fields[0].Values = []string{
+		"token",
+		"allowlist",
+		"webhook",
+	}
+	JoinPolicyConfigDoc.Fields[1].Name = "allowedIdentities"
+	JoinPolicyConfigDoc.Fields[1].Type = "[]string"
+	JoinPolicyConfigDoc.Fields[1].Note = ""
+	JoinPolicyConfigDoc.Fields[1].Description = "List of machine identities which are allowed to join the cluster (`allowlist` mode only).\nEach entry is either an IP address or CIDR (matched against the source address of the request),\nor a `sha256:` prefixed hex fingerprint of the CSR public key."
+	JoinPolicyConfigDoc.Fields[1].Comments[encoder.LineComment] = "List of machine identities which are allowed to join the cluster (`allowlist` mode only)."
+	JoinPolicyConfigDoc.Fields[2].Name = "webhook"
+	JoinPolicyConfigDoc.Fields[2].Type = "JoinPolicyWebhookConfig"
+	JoinPolicyConfigDoc.Fields[2].Note = ""
+	JoinPolicyConfigDoc.Fields[2].Description = "External authorizer configuration (`webhook` mode only)."
+	JoinPolicyConfigDoc.Fields[2].Comments[encoder.LineComment] = "External authorizer configuration (`webhook` mode only)."
+
+	JoinPolicyWebhookConfigDoc.Type = "JoinPolicyWebhookConfig"
+	JoinPolicyWebhookConfigDoc.Comments[encoder.LineComment] = "JoinPolicyWebhookConfig represents the external join authorizer settings."
+	JoinPolicyWebhookConfigDoc.Description = "JoinPolicyWebhookConfig represents the external join authorizer settings."
+	JoinPolicyWebhookConfigDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "JoinPolicyConfig",
+			FieldName: "webhook",
+		},
+	}
+	JoinPolicyWebhookConfigDoc.Fields = make([]encoder.Doc, 3)
+	JoinPolicyWebhookConfigDoc.Fields[0].Name = "url"
+	JoinPolicyWebhookConfigDoc.Fields[0].Type = "string"
+	JoinPolicyWebhookConfigDoc.Fields[0].Note = ""
+	JoinPolicyWebhookConfigDoc.Fields[0].Description = "The URL of the external authorizer.\ntrustd sends a `POST` request with the JSON encoded CSR details,\nand the authorizer should respond with `{\"allowed\": true}` to approve the request."
+	JoinPolicyWebhookConfigDoc.Fields[0].Comments[encoder.LineComment] = "The URL of the external authorizer."
+
+	JoinPolicyWebhookConfigDoc.Fields[0].AddExample("", "https://authorizer.example.com/join")
+	JoinPolicyWebhookConfigDoc.Fields[1].Name = "ca"
+	JoinPolicyWebhookConfigDoc.Fields[1].Type = "Base64Bytes"
+	JoinPolicyWebhookConfigDoc.Fields[1].Note = ""
+	JoinPolicyWebhookConfigDoc.Fields[1].Description = "CA certificate to verify the authorizer TLS certificate.\nCertificate should be base64-encoded."
+	JoinPolicyWebhookConfigDoc.Fields[1].Comments[encoder.LineComment] = "CA certificate to verify the authorizer TLS certificate."
+	JoinPolicyWebhookConfigDoc.Fields[2].Name = "timeout"
+	JoinPolicyWebhookConfigDoc.Fields[2].Type = "Duration"
+	JoinPolicyWebhookConfigDoc.Fields[2].Note = ""
+	JoinPolicyWebhookConfigDoc.Fields[2].Description = "Timeout for the authorizer request (default is 10 seconds).\nField format accepts any Go time.Duration format ('1h' for one hour, '10m' for ten minutes)."
+	JoinPolicyWebhookConfigDoc.Fields[2].Comments[encoder.LineComment] = "Timeout for the authorizer request (default is 10 seconds)."
+
 	KubeletConfigDoc.Type = "KubeletConfig"
 	KubeletConfigDoc.Comments[encoder.LineComment] = "KubeletConfig represents the kubelet config values."
 	KubeletConfigDoc.Description = "KubeletConfig represents the kubelet config values."
@@ -423,7 +661,7 @@ func init() {
 	KubeletConfigDoc.Fields[2].Name = "extraMounts"
 	KubeletConfigDoc.Fields[2].Type = "[]Mount"
 	KubeletConfigDoc.Fields[2].Note = ""
-	KubeletConfigDoc.Fields[2].Description = "The `extraMounts` field is used to add additional mounts to the kubelet container."
+	KubeletConfigDoc.Fields[2].Description = "The `extraMounts` field is used to add additional mounts to the kubelet container.\nUse `rshared` propagation for the host paths which are used by the storage providers (e.g. OpenEBS, Rook),\nso that the mounts created by the storage provider are visible to the kubelet."
 	KubeletConfigDoc.Fields[2].Comments[encoder.LineComment] = "The `extraMounts` field is used to add additional mounts to the kubelet container."
 
 	KubeletConfigDoc.Fields[2].AddExample("", kubeletExtraMountsExample)
@@ -450,33 +688,89 @@ func init() {
 			FieldName: "network",
 		},
 	}
-	NetworkConfigDoc.Fields = make([]encoder.Doc, 4)
+	NetworkConfigDoc.Fields = make([]encoder.Doc, 11)
 	NetworkConfigDoc.Fields[0].Name = "hostname"
 	NetworkConfigDoc.Fields[0].Type = "string"
 	NetworkConfigDoc.Fields[0].Note = ""
-	NetworkConfigDoc.Fields[0].Description = "Used to statically set the hostname for the machine."
+	NetworkConfigDoc.Fields[0].Description = "Used to statically set the hostname for the machine.\n\nHostname might be a template with the variables `${uuid}` (SMBIOS system UUID),\n`${uuid-short}` (first 8 characters of the SHA-256 hash of the system UUID, stable across reinstalls)\nand `${ip}` (node address with dashes, e.g. `192-168-0-10`)."
 	NetworkConfigDoc.Fields[0].Comments[encoder.LineComment] = "Used to statically set the hostname for the machine."
-	NetworkConfigDoc.Fields[1].Name = "interfaces"
-	NetworkConfigDoc.Fields[1].Type = "[]Device"
-	NetworkConfigDoc.Fields[1].Note = ""
-	NetworkConfigDoc.Fields[1].Description = "`interfaces` is used to define the network interface configuration.\nBy default all network interfaces will attempt a DHCP discovery.\nThis can be further tuned through this configuration parameter."
-	NetworkConfigDoc.Fields[1].Comments[encoder.LineComment] = "`interfaces` is used to define the network interface configuration."
 
-	NetworkConfigDoc.Fields[1].AddExample("", machineNetworkConfigExample.NetworkInterfaces)
-	NetworkConfigDoc.Fields[2].Name = "nameservers"
-	NetworkConfigDoc.Fields[2].Type = "[]string"
+	NetworkConfigDoc.Fields[0].AddExample("", "talos-${uuid-short}")
+	NetworkConfigDoc.Fields[1].Name = "hostnameSources"
+	NetworkConfigDoc.Fields[1].Type = "[]string"
+	NetworkConfigDoc.Fields[1].Note = ""
+	NetworkConfigDoc.Fields[1].Description = "Sources of the hostname in the order of precedence: the first source which provides the hostname is used.\n`config` is the `hostname` above, `kernel` is the `talos.hostname` kernel argument,\n`platform` is the platform metadata, `dhcp` is the hostname received via DHCP (or generated from the node address),\n`stable` generates the hostname `talos-${uuid-short}`.\nDefaults to `config`, `kernel`, `platform`, `dhcp`.\nIf none of the sources provides the hostname, `talos-<ip>` is used.\n\nWhen set, the platform hostname doesn't override the `hostname` in the machine config."
+	NetworkConfigDoc.Fields[1].Comments[encoder.LineComment] = "Sources of the hostname in the order of precedence: the first source which provides the hostname is used."
+
+	NetworkConfigDoc.Fields[1].AddExample("", []string{"platform", "config", "stable"})
+	NetworkConfigDoc.Fields[1].Values = []string{
+		"config",
+		"kernel",
+		"platform",
+		"dhcp",
+		"stable",
+	}
+	NetworkConfigDoc.Fields[2].Name = "interfaces"
+	NetworkConfigDoc.Fields[2].Type = "[]Device"
 	NetworkConfigDoc.Fields[2].Note = ""
-	NetworkConfigDoc.Fields[2].Description = "Used to statically set the nameservers for the machine.\nDefaults to `1.1.1.1` and `8.8.8.8`"
-	NetworkConfigDoc.Fields[2].Comments[encoder.LineComment] = "Used to statically set the nameservers for the machine."
+	NetworkConfigDoc.Fields[2].Description = "`interfaces` is used to define the network interface configuration.\nBy default all network interfaces will attempt a DHCP discovery.\nThis can be further tuned through this configuration parameter."
+	NetworkConfigDoc.Fields[2].Comments[encoder.LineComment] = "`interfaces` is used to define the network interface configuration."
 
-	NetworkConfigDoc.Fields[2].AddExample("", []string{"8.8.8.8", "1.1.1.1"})
-	NetworkConfigDoc.Fields[3].Name = "extraHostEntries"
-	NetworkConfigDoc.Fields[3].Type = "[]ExtraHost"
+	NetworkConfigDoc.Fields[2].AddExample("", machineNetworkConfigExample.NetworkInterfaces)
+	NetworkConfigDoc.Fields[3].Name = "nameservers"
+	NetworkConfigDoc.Fields[3].Type = "[]string"
 	NetworkConfigDoc.Fields[3].Note = ""
-	NetworkConfigDoc.Fields[3].Description = "Allows for extra entries to be added to the `/etc/hosts` file"
-	NetworkConfigDoc.Fields[3].Comments[encoder.LineComment] = "Allows for extra entries to be added to the `/etc/hosts` file"
-
-	NetworkConfigDoc.Fields[3].AddExample("", networkConfigExtraHostsExample)
+	NetworkConfigDoc.Fields[3].Description = "Used to statically set the nameservers for the machine.\nDefaults to `1.1.1.1` and `8.8.8.8`"
+	NetworkConfigDoc.Fields[3].Comments[encoder.LineComment] = "Used to statically set the nameservers for the machine."
+
+	NetworkConfigDoc.Fields[3].AddExample("", []string{"8.8.8.8", "1.1.1.1"})
+	NetworkConfigDoc.Fields[4].Name = "searchDomains"
+	NetworkConfigDoc.Fields[4].Type = "[]string"
+	NetworkConfigDoc.Fields[4].Note = ""
+	NetworkConfigDoc.Fields[4].Description = "Used to statically set the DNS search domains for the machine.\nSearch domains received via DHCP are used only if no search domains are configured."
+	NetworkConfigDoc.Fields[4].Comments[encoder.LineComment] = "Used to statically set the DNS search domains for the machine."
+
+	NetworkConfigDoc.Fields[4].AddExample("", []string{"example.org", "example.com"})
+	NetworkConfigDoc.Fields[5].Name = "disableSearchDomain"
+	NetworkConfigDoc.Fields[5].Type = "bool"
+	NetworkConfigDoc.Fields[5].Note = ""
+	NetworkConfigDoc.Fields[5].Description = "Disable generating a default search domain in `/etc/resolv.conf` based on the machine hostname.\nDefaults to `false`."
+	NetworkConfigDoc.Fields[5].Comments[encoder.LineComment] = "Disable generating a default search domain in `/etc/resolv.conf` based on the machine hostname."
+	NetworkConfigDoc.Fields[6].Name = "extraHostEntries"
+	NetworkConfigDoc.Fields[6].Type = "[]ExtraHost"
+	NetworkConfigDoc.Fields[6].Note = ""
+	NetworkConfigDoc.Fields[6].Description = "Allows for extra entries to be added to the `/etc/hosts` file"
+	NetworkConfigDoc.Fields[6].Comments[encoder.LineComment] = "Allows for extra entries to be added to the `/etc/hosts` file"
+
+	NetworkConfigDoc.Fields[6].AddExample("", networkConfigExtraHostsExample)
+	NetworkConfigDoc.Fields[7].Name = "rules"
+	NetworkConfigDoc.Fields[7].Type = "[]RoutingRule"
+	NetworkConfigDoc.Fields[7].Note = ""
+	NetworkConfigDoc.Fields[7].Description = "Policy routing rules.\nRules select the routing table based on the source/destination address or the firewall mark of the traffic,\nroutes are put into the non-default tables via the `table` field of the route."
+	NetworkConfigDoc.Fields[7].Comments[encoder.LineComment] = "Policy routing rules."
+
+	NetworkConfigDoc.Fields[7].AddExample("", networkConfigRulesExample)
+	NetworkConfigDoc.Fields[8].Name = "kubespan"
+	NetworkConfigDoc.Fields[8].Type = "KubeSpan"
+	NetworkConfigDoc.Fields[8].Note = ""
+	NetworkConfigDoc.Fields[8].Description = "Configures KubeSpan feature: full mesh Wireguard network between the cluster nodes.\nNode public keys and endpoints are exchanged via the discovery service (encrypted with the key derived from the cluster secrets),\nand the traffic to the other nodes' addresses is routed over the mesh."
+	NetworkConfigDoc.Fields[8].Comments[encoder.LineComment] = "Configures KubeSpan feature: full mesh Wireguard network between the cluster nodes."
+
+	NetworkConfigDoc.Fields[8].AddExample("", networkKubeSpanExample)
+	NetworkConfigDoc.Fields[9].Name = "firewall"
+	NetworkConfigDoc.Fields[9].Type = "FirewallConfig"
+	NetworkConfigDoc.Fields[9].Note = ""
+	NetworkConfigDoc.Fields[9].Description = "Ingress firewall configuration.\nRules are programmed into the host nftables and restrict access to the node ports (e.g. Talos API, kubelet, etcd)\nby the source network."
+	NetworkConfigDoc.Fields[9].Comments[encoder.LineComment] = "Ingress firewall configuration."
+
+	NetworkConfigDoc.Fields[9].AddExample("", networkFirewallExample)
+	NetworkConfigDoc.Fields[10].Name = "sideroLink"
+	NetworkConfigDoc.Fields[10].Type = "SideroLink"
+	NetworkConfigDoc.Fields[10].Note = ""
+	NetworkConfigDoc.Fields[10].Description = "Configures SideroLink: Wireguard management tunnel to the management server.\nNode registers with the management server via the provisioning API and brings up the tunnel,\nso that Talos API is reachable from the management server over the tunnel address.\n\nCan also be set with the `siderolink.api` kernel argument, machine configuration takes precedence."
+	NetworkConfigDoc.Fields[10].Comments[encoder.LineComment] = "Configures SideroLink: Wireguard management tunnel to the management server."
+
+	NetworkConfigDoc.Fields[10].AddExample("", networkSideroLinkExample)
 
 	InstallConfigDoc.Type = "InstallConfig"
 	InstallConfigDoc.Comments[encoder.LineComment] = "InstallConfig represents the installation options for preparing a node."
@@ -489,7 +783,7 @@ func init() {
 			FieldName: "install",
 		},
 	}
-	InstallConfigDoc.Fields = make([]encoder.Doc, 6)
+	InstallConfigDoc.Fields = make([]encoder.Doc, 9)
 	InstallConfigDoc.Fields[0].Name = "disk"
 	InstallConfigDoc.Fields[0].Type = "string"
 	InstallConfigDoc.Fields[0].Note = ""
@@ -509,10 +803,12 @@ func init() {
 	InstallConfigDoc.Fields[2].Name = "extraKernelArgs"
 	InstallConfigDoc.Fields[2].Type = "[]string"
 	InstallConfigDoc.Fields[2].Note = ""
-	InstallConfigDoc.Fields[2].Description = "Allows for supplying extra kernel args via the bootloader."
+	InstallConfigDoc.Fields[2].Description = "Allows for supplying extra kernel args via the bootloader.\nArguments prefixed with `-` are removed from the kernel command line: `-key` removes all the values of the argument,\n`-key=value` removes only the specified value.\n\nOn upgrade, kernel args of the currently installed boot entry are carried over unless the argument with the same key\nis set by Talos or by the extra kernel args."
 	InstallConfigDoc.Fields[2].Comments[encoder.LineComment] = "Allows for supplying extra kernel args via the bootloader."
 
 	InstallConfigDoc.Fields[2].AddExample("", []string{"talos.platform=metal", "reboot=k"})
+
+	InstallConfigDoc.Fields[2].AddExample("Disable CPU mitigations and remove the default `pti` argument.", []string{"mitigations=off", "-pti"})
 	InstallConfigDoc.Fields[3].Name = "image"
 	InstallConfigDoc.Fields[3].Type = "string"
 	InstallConfigDoc.Fields[3].Note = ""
@@ -531,12 +827,35 @@ func init() {
 		"false",
 		"no",
 	}
-	InstallConfigDoc.Fields[5].Name = "wipe"
-	InstallConfigDoc.Fields[5].Type = "bool"
+	InstallConfigDoc.Fields[5].Name = "bootloaderType"
+	InstallConfigDoc.Fields[5].Type = "string"
 	InstallConfigDoc.Fields[5].Note = ""
-	InstallConfigDoc.Fields[5].Description = "Indicates if the installation disk should be wiped at installation time.\nDefaults to `true`."
-	InstallConfigDoc.Fields[5].Comments[encoder.LineComment] = "Indicates if the installation disk should be wiped at installation time."
+	InstallConfigDoc.Fields[5].Description = "The bootloader to install.\n`sd-boot` installs systemd-boot and the Unified Kernel Images to the EFI partition,\nit can be used only on UEFI systems.\nDefaults to `grub`."
+	InstallConfigDoc.Fields[5].Comments[encoder.LineComment] = "The bootloader to install."
 	InstallConfigDoc.Fields[5].Values = []string{
+		"grub",
+		"sd-boot",
+	}
+	InstallConfigDoc.Fields[6].Name = "consoles"
+	InstallConfigDoc.Fields[6].Type = "[]InstallConsole"
+	InstallConfigDoc.Fields[6].Note = ""
+	InstallConfigDoc.Fields[6].Description = "The console devices set in the boot entries.\nEach console is passed to the kernel as the `console` argument, the last one is used for `/dev/console`.\nThe first serial console (`ttyS*`) is also used for the GRUB menu."
+	InstallConfigDoc.Fields[6].Comments[encoder.LineComment] = "The console devices set in the boot entries."
+
+	InstallConfigDoc.Fields[6].AddExample("", machineInstallConsolesExample)
+	InstallConfigDoc.Fields[7].Name = "secureboot"
+	InstallConfigDoc.Fields[7].Type = "InstallSecureBootConfig"
+	InstallConfigDoc.Fields[7].Note = ""
+	InstallConfigDoc.Fields[7].Description = "Installs signed Unified Kernel Images and systemd-boot for SecureBoot, requires `bootloaderType: sd-boot`.\nThe signing key and certificate are read from the installer image, so a custom installer image with the keys\nshould be used both for the installation and for the upgrades."
+	InstallConfigDoc.Fields[7].Comments[encoder.LineComment] = "Installs signed Unified Kernel Images and systemd-boot for SecureBoot, requires `bootloaderType: sd-boot`."
+
+	InstallConfigDoc.Fields[7].AddExample("", machineInstallSecureBootExample)
+	InstallConfigDoc.Fields[8].Name = "wipe"
+	InstallConfigDoc.Fields[8].Type = "bool"
+	InstallConfigDoc.Fields[8].Note = ""
+	InstallConfigDoc.Fields[8].Description = "Indicates if the installation disk should be wiped at installation time.\nDefaults to `true`."
+	InstallConfigDoc.Fields[8].Comments[encoder.LineComment] = "Indicates if the installation disk should be wiped at installation time."
+	InstallConfigDoc.Fields[8].Values = []string{
 		"true",
 		"yes",
 		"false",
@@ -625,6 +944,52 @@ func init() {
 		"sd",
 	}
 
+	InstallConsoleDoc.Type = "InstallConsole"
+	InstallConsoleDoc.Comments[encoder.LineComment] = "InstallConsole represents the console device set in the boot entries."
+	InstallConsoleDoc.Description = "InstallConsole represents the console device set in the boot entries."
+
+	InstallConsoleDoc.AddExample("", machineInstallConsolesExample)
+	InstallConsoleDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "InstallConfig",
+			FieldName: "consoles",
+		},
+	}
+	InstallConsoleDoc.Fields = make([]encoder.Doc, 2)
+	InstallConsoleDoc.Fields[0].Name = "device"
+	InstallConsoleDoc.Fields[0].Type = "string"
+	InstallConsoleDoc.Fields[0].Note = ""
+	InstallConsoleDoc.Fields[0].Description = "Console device name (e.g. `ttyS0`, `tty0`)."
+	InstallConsoleDoc.Fields[0].Comments[encoder.LineComment] = "Console device name (e.g. `ttyS0`, `tty0`)."
+	InstallConsoleDoc.Fields[1].Name = "baudRate"
+	InstallConsoleDoc.Fields[1].Type = "int"
+	InstallConsoleDoc.Fields[1].Note = ""
+	InstallConsoleDoc.Fields[1].Description = "Console baud rate.\nIf not set, the kernel default is used."
+	InstallConsoleDoc.Fields[1].Comments[encoder.LineComment] = "Console baud rate."
+
+	InstallSecureBootConfigDoc.Type = "InstallSecureBootConfig"
+	InstallSecureBootConfigDoc.Comments[encoder.LineComment] = "InstallSecureBootConfig describes the SecureBoot signing options."
+	InstallSecureBootConfigDoc.Description = "InstallSecureBootConfig describes the SecureBoot signing options."
+
+	InstallSecureBootConfigDoc.AddExample("", machineInstallSecureBootExample)
+	InstallSecureBootConfigDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "InstallConfig",
+			FieldName: "secureboot",
+		},
+	}
+	InstallSecureBootConfigDoc.Fields = make([]encoder.Doc, 2)
+	InstallSecureBootConfigDoc.Fields[0].Name = "signingKey"
+	InstallSecureBootConfigDoc.Fields[0].Type = "string"
+	InstallSecureBootConfigDoc.Fields[0].Note = ""
+	InstallSecureBootConfigDoc.Fields[0].Description = "The path to the SecureBoot signing key (PEM) in the installer image."
+	InstallSecureBootConfigDoc.Fields[0].Comments[encoder.LineComment] = "The path to the SecureBoot signing key (PEM) in the installer image."
+	InstallSecureBootConfigDoc.Fields[1].Name = "signingCert"
+	InstallSecureBootConfigDoc.Fields[1].Type = "string"
+	InstallSecureBootConfigDoc.Fields[1].Note = ""
+	InstallSecureBootConfigDoc.Fields[1].Description = "The path to the SecureBoot signing certificate (PEM) in the installer image.\nThe certificate is placed on the EFI partition as `keys/db.der` to be enrolled via the firmware setup."
+	InstallSecureBootConfigDoc.Fields[1].Comments[encoder.LineComment] = "The path to the SecureBoot signing certificate (PEM) in the installer image."
+
 	TimeConfigDoc.Type = "TimeConfig"
 	TimeConfigDoc.Comments[encoder.LineComment] = "TimeConfig represents the options for configuring time on a machine."
 	TimeConfigDoc.Description = "TimeConfig represents the options for configuring time on a machine."
@@ -648,6 +1013,378 @@ func init() {
 	TimeConfigDoc.Fields[1].Description = "Specifies time (NTP) servers to use for setting the system time.\nDefaults to `pool.ntp.org`"
 	TimeConfigDoc.Fields[1].Comments[encoder.LineComment] = "Specifies time (NTP) servers to use for setting the system time."
 
+	VirtualizationConfigDoc.Type = "VirtualizationConfig"
+	VirtualizationConfigDoc.Comments[encoder.LineComment] = "VirtualizationConfig represents the options for configuring hardware virtualization on a machine."
+	VirtualizationConfigDoc.Description = "VirtualizationConfig represents the options for configuring hardware virtualization on a machine."
+
+	VirtualizationConfigDoc.AddExample("MachineVirtualization usage example.", machineVirtualizationExample)
+	VirtualizationConfigDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "MachineConfig",
+			FieldName: "virtualization",
+		},
+	}
+	VirtualizationConfigDoc.Fields = make([]encoder.Doc, 2)
+	VirtualizationConfigDoc.Fields[0].Name = "nested"
+	VirtualizationConfigDoc.Fields[0].Type = "bool"
+	VirtualizationConfigDoc.Fields[0].Note = ""
+	VirtualizationConfigDoc.Fields[0].Description = "Enables nested virtualization for the `kvm_intel` and `kvm_amd` modules.\nNested virtualization is configured via kernel arguments, so the change takes effect after the next install or upgrade."
+	VirtualizationConfigDoc.Fields[0].Comments[encoder.LineComment] = "Enables nested virtualization for the `kvm_intel` and `kvm_amd` modules."
+	VirtualizationConfigDoc.Fields[1].Name = "kvmOptions"
+	VirtualizationConfigDoc.Fields[1].Type = "map[string]string"
+	VirtualizationConfigDoc.Fields[1].Note = ""
+	VirtualizationConfigDoc.Fields[1].Description = "Extra `kvm` module parameters.\nParameters are passed as kernel arguments, parameters writable at runtime are applied immediately."
+	VirtualizationConfigDoc.Fields[1].Comments[encoder.LineComment] = "Extra `kvm` module parameters."
+
+	VirtualizationConfigDoc.Fields[1].AddExample("", map[string]string{
+		"ignore_msrs": "1",
+	})
+
+	HealthzConfigDoc.Type = "HealthzConfig"
+	HealthzConfigDoc.Comments[encoder.LineComment] = "HealthzConfig represents the node health endpoint options."
+	HealthzConfigDoc.Description = "HealthzConfig represents the node health endpoint options."
+
+	HealthzConfigDoc.AddExample("", machineHealthzExample)
+	HealthzConfigDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "MachineConfig",
+			FieldName: "healthz",
+		},
+	}
+	HealthzConfigDoc.Fields = make([]encoder.Doc, 2)
+	HealthzConfigDoc.Fields[0].Name = "enabled"
+	HealthzConfigDoc.Fields[0].Type = "bool"
+	HealthzConfigDoc.Fields[0].Note = ""
+	HealthzConfigDoc.Fields[0].Description = "Enables the health endpoint `/healthz`."
+	HealthzConfigDoc.Fields[0].Comments[encoder.LineComment] = "Enables the health endpoint `/healthz`."
+	HealthzConfigDoc.Fields[1].Name = "port"
+	HealthzConfigDoc.Fields[1].Type = "int"
+	HealthzConfigDoc.Fields[1].Note = ""
+	HealthzConfigDoc.Fields[1].Description = "TCP port to serve the health endpoint on (all the node addresses).\nDefaults to `50005`."
+	HealthzConfigDoc.Fields[1].Comments[encoder.LineComment] = "TCP port to serve the health endpoint on (all the node addresses)."
+
+	MetricsConfigDoc.Type = "MetricsConfig"
+	MetricsConfigDoc.Comments[encoder.LineComment] = "MetricsConfig represents the node metrics endpoint options."
+	MetricsConfigDoc.Description = "MetricsConfig represents the node metrics endpoint options."
+
+	MetricsConfigDoc.AddExample("", machineMetricsExample)
+	MetricsConfigDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "MachineConfig",
+			FieldName: "metrics",
+		},
+	}
+	MetricsConfigDoc.Fields = make([]encoder.Doc, 3)
+	MetricsConfigDoc.Fields[0].Name = "enabled"
+	MetricsConfigDoc.Fields[0].Type = "bool"
+	MetricsConfigDoc.Fields[0].Note = ""
+	MetricsConfigDoc.Fields[0].Description = "Enables the metrics endpoint `/metrics`."
+	MetricsConfigDoc.Fields[0].Comments[encoder.LineComment] = "Enables the metrics endpoint `/metrics`."
+	MetricsConfigDoc.Fields[1].Name = "port"
+	MetricsConfigDoc.Fields[1].Type = "int"
+	MetricsConfigDoc.Fields[1].Note = ""
+	MetricsConfigDoc.Fields[1].Description = "TCP port to serve the metrics endpoint on (all the node addresses, HTTPS with the machine API certificate).\nDefaults to `50006`."
+	MetricsConfigDoc.Fields[1].Comments[encoder.LineComment] = "TCP port to serve the metrics endpoint on (all the node addresses, HTTPS with the machine API certificate)."
+	MetricsConfigDoc.Fields[2].Name = "bearerToken"
+	MetricsConfigDoc.Fields[2].Type = "string"
+	MetricsConfigDoc.Fields[2].Note = ""
+	MetricsConfigDoc.Fields[2].Description = "Bearer token required to access the metrics endpoint.\nThe token is required if the endpoint is enabled."
+	MetricsConfigDoc.Fields[2].Comments[encoder.LineComment] = "Bearer token required to access the metrics endpoint."
+
+	WatchdogConfigDoc.Type = "WatchdogConfig"
+	WatchdogConfigDoc.Comments[encoder.LineComment] = "WatchdogConfig represents the watchdog timer options."
+	WatchdogConfigDoc.Description = "WatchdogConfig represents the watchdog timer options."
+
+	WatchdogConfigDoc.AddExample("", machineWatchdogExample)
+	WatchdogConfigDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "MachineConfig",
+			FieldName: "watchdog",
+		},
+	}
+	WatchdogConfigDoc.Fields = make([]encoder.Doc, 3)
+	WatchdogConfigDoc.Fields[0].Name = "enabled"
+	WatchdogConfigDoc.Fields[0].Type = "bool"
+	WatchdogConfigDoc.Fields[0].Note = ""
+	WatchdogConfigDoc.Fields[0].Description = "Enables the watchdog timer."
+	WatchdogConfigDoc.Fields[0].Comments[encoder.LineComment] = "Enables the watchdog timer."
+	WatchdogConfigDoc.Fields[1].Name = "device"
+	WatchdogConfigDoc.Fields[1].Type = "string"
+	WatchdogConfigDoc.Fields[1].Note = ""
+	WatchdogConfigDoc.Fields[1].Description = "Path to the hardware watchdog device (default is `/dev/watchdog`).\nIf the device is not available, the software watchdog is used:\nthe node is rebooted by `machined` if `machined` stays unresponsive for the timeout."
+	WatchdogConfigDoc.Fields[1].Comments[encoder.LineComment] = "Path to the hardware watchdog device (default is `/dev/watchdog`)."
+	WatchdogConfigDoc.Fields[2].Name = "timeout"
+	WatchdogConfigDoc.Fields[2].Type = "Duration"
+	WatchdogConfigDoc.Fields[2].Note = ""
+	WatchdogConfigDoc.Fields[2].Description = "Time `machined` is allowed to stay unresponsive before the node is restarted (default is 1 minute).\nField format accepts any Go time.Duration format ('1h' for one hour, '10m' for ten minutes)."
+	WatchdogConfigDoc.Fields[2].Comments[encoder.LineComment] = "Time `machined` is allowed to stay unresponsive before the node is restarted (default is 1 minute)."
+
+	CrashDumpsConfigDoc.Type = "CrashDumpsConfig"
+	CrashDumpsConfigDoc.Comments[encoder.LineComment] = "CrashDumpsConfig represents the kernel crash dumps capture options."
+	CrashDumpsConfigDoc.Description = "CrashDumpsConfig represents the kernel crash dumps capture options."
+
+	CrashDumpsConfigDoc.AddExample("", machineCrashDumpsExample)
+	CrashDumpsConfigDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "MachineConfig",
+			FieldName: "crashDumps",
+		},
+	}
+	CrashDumpsConfigDoc.Fields = make([]encoder.Doc, 2)
+	CrashDumpsConfigDoc.Fields[0].Name = "ramoops"
+	CrashDumpsConfigDoc.Fields[0].Type = "RamoopsConfig"
+	CrashDumpsConfigDoc.Fields[0].Note = ""
+	CrashDumpsConfigDoc.Fields[0].Description = "Configures the `ramoops` pstore backend which keeps kernel logs of the crashed boot in the reserved memory region.\nOptions are passed as kernel arguments, so the change takes effect after the next install or upgrade.\nIf not set, pstore backends available by default (e.g. EFI variables) are used."
+	CrashDumpsConfigDoc.Fields[0].Comments[encoder.LineComment] = "Configures the `ramoops` pstore backend which keeps kernel logs of the crashed boot in the reserved memory region."
+	CrashDumpsConfigDoc.Fields[1].Name = "destination"
+	CrashDumpsConfigDoc.Fields[1].Type = "string"
+	CrashDumpsConfigDoc.Fields[1].Note = ""
+	CrashDumpsConfigDoc.Fields[1].Description = "Destination to ship the collected panic logs to, `tcp://host:port` and `udp://host:port` are supported.\nEach panic log is sent as a single JSON message terminated with a newline."
+	CrashDumpsConfigDoc.Fields[1].Comments[encoder.LineComment] = "Destination to ship the collected panic logs to, `tcp://host:port` and `udp://host:port` are supported."
+
+	RamoopsConfigDoc.Type = "RamoopsConfig"
+	RamoopsConfigDoc.Comments[encoder.LineComment] = "RamoopsConfig represents the ramoops pstore backend options."
+	RamoopsConfigDoc.Description = "RamoopsConfig represents the ramoops pstore backend options."
+	RamoopsConfigDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "CrashDumpsConfig",
+			FieldName: "ramoops",
+		},
+	}
+	RamoopsConfigDoc.Fields = make([]encoder.Doc, 4)
+	RamoopsConfigDoc.Fields[0].Name = "memAddress"
+	RamoopsConfigDoc.Fields[0].Type = "string"
+	RamoopsConfigDoc.Fields[0].Note = ""
+	RamoopsConfigDoc.Fields[0].Description = "Physical address of the memory region reserved for ramoops (e.g. `0x8000000`).\nThe region should be reserved from the kernel use, e.g. with `memmap=` kernel argument."
+	RamoopsConfigDoc.Fields[0].Comments[encoder.LineComment] = "Physical address of the memory region reserved for ramoops (e.g. `0x8000000`)."
+	RamoopsConfigDoc.Fields[1].Name = "memSize"
+	RamoopsConfigDoc.Fields[1].Type = "string"
+	RamoopsConfigDoc.Fields[1].Note = ""
+	RamoopsConfigDoc.Fields[1].Description = "Size of the reserved memory region (e.g. `0x100000`)."
+	RamoopsConfigDoc.Fields[1].Comments[encoder.LineComment] = "Size of the reserved memory region (e.g. `0x100000`)."
+	RamoopsConfigDoc.Fields[2].Name = "recordSize"
+	RamoopsConfigDoc.Fields[2].Type = "string"
+	RamoopsConfigDoc.Fields[2].Note = ""
+	RamoopsConfigDoc.Fields[2].Description = "Size of each kernel log record (default is `0x1000`)."
+	RamoopsConfigDoc.Fields[2].Comments[encoder.LineComment] = "Size of each kernel log record (default is `0x1000`)."
+	RamoopsConfigDoc.Fields[3].Name = "consoleSize"
+	RamoopsConfigDoc.Fields[3].Type = "string"
+	RamoopsConfigDoc.Fields[3].Note = ""
+	RamoopsConfigDoc.Fields[3].Description = "Size of the console log of the previous boot (default is not to capture the console log)."
+	RamoopsConfigDoc.Fields[3].Comments[encoder.LineComment] = "Size of the console log of the previous boot (default is not to capture the console log)."
+
+	ImageCacheConfigDoc.Type = "ImageCacheConfig"
+	ImageCacheConfigDoc.Comments[encoder.LineComment] = "ImageCacheConfig represents the local image cache options."
+	ImageCacheConfigDoc.Description = "ImageCacheConfig represents the local image cache options."
+
+	ImageCacheConfigDoc.AddExample("", machineImageCacheExample)
+	ImageCacheConfigDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "MachineConfig",
+			FieldName: "imageCache",
+		},
+	}
+	ImageCacheConfigDoc.Fields = make([]encoder.Doc, 1)
+	ImageCacheConfigDoc.Fields[0].Name = "enabled"
+	ImageCacheConfigDoc.Fields[0].Type = "bool"
+	ImageCacheConfigDoc.Fields[0].Note = ""
+	ImageCacheConfigDoc.Fields[0].Description = "Enables the local image cache.\nThe cache is tried first for the images pulled by Talos and by the Kubernetes (CRI)."
+	ImageCacheConfigDoc.Fields[0].Comments[encoder.LineComment] = "Enables the local image cache."
+
+	FeaturesConfigDoc.Type = "FeaturesConfig"
+	FeaturesConfigDoc.Comments[encoder.LineComment] = "FeaturesConfig represents the optional features of Talos."
+	FeaturesConfigDoc.Description = "FeaturesConfig represents the optional features of Talos."
+
+	FeaturesConfigDoc.AddExample("", machineFeaturesExample)
+	FeaturesConfigDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "MachineConfig",
+			FieldName: "features",
+		},
+	}
+	FeaturesConfigDoc.Fields = make([]encoder.Doc, 5)
+	FeaturesConfigDoc.Fields[0].Name = "kexec"
+	FeaturesConfigDoc.Fields[0].Type = "bool"
+	FeaturesConfigDoc.Fields[0].Note = ""
+	FeaturesConfigDoc.Fields[0].Description = "Use kexec for reboots and upgrades: the new kernel is started directly, without going through the firmware.\nIf kexec is not supported by the kernel or fails, the node falls back to a normal reboot."
+	FeaturesConfigDoc.Fields[0].Comments[encoder.LineComment] = "Use kexec for reboots and upgrades: the new kernel is started directly, without going through the firmware."
+	FeaturesConfigDoc.Fields[1].Name = "localAPIAllowedUIDs"
+	FeaturesConfigDoc.Fields[1].Type = "[]uint32"
+	FeaturesConfigDoc.Fields[1].Note = ""
+	FeaturesConfigDoc.Fields[1].Description = "List of UIDs allowed to access the machine API over the local socket (in addition to root)."
+	FeaturesConfigDoc.Fields[1].Comments[encoder.LineComment] = "List of UIDs allowed to access the machine API over the local socket (in addition to root)."
+	FeaturesConfigDoc.Fields[2].Name = "localAPIAllowedGIDs"
+	FeaturesConfigDoc.Fields[2].Type = "[]uint32"
+	FeaturesConfigDoc.Fields[2].Note = ""
+	FeaturesConfigDoc.Fields[2].Description = "List of GIDs allowed to access the machine API over the local socket.\nProcess is allowed if its primary GID matches one of the listed GIDs."
+	FeaturesConfigDoc.Fields[2].Comments[encoder.LineComment] = "List of GIDs allowed to access the machine API over the local socket."
+	FeaturesConfigDoc.Fields[3].Name = "kubeletLocalAPI"
+	FeaturesConfigDoc.Fields[3].Type = "bool"
+	FeaturesConfigDoc.Fields[3].Note = ""
+	FeaturesConfigDoc.Fields[3].Description = "Mount the directory of the local machine API socket into the kubelet, so that privileged pods\ncan access the socket with the `hostPath` volume (e.g. node debug pods).\n\nWarning: the socket grants the full access to the machine API to any process running as root (UID 0),\nand every privileged pod (or any pod running as root with the `hostPath` volume) gets the access to the node\nwith this option enabled.\nDisabled by default."
+	FeaturesConfigDoc.Fields[3].Comments[encoder.LineComment] = "Mount the directory of the local machine API socket into the kubelet, so that privileged pods"
+	FeaturesConfigDoc.Fields[4].Name = "strictTLS"
+	FeaturesConfigDoc.Fields[4].Type = "bool"
+	FeaturesConfigDoc.Fields[4].Note = ""
+	FeaturesConfigDoc.Fields[4].Description = "Restrict TLS on the Talos API endpoints (apid, trustd and the metrics endpoint) to TLS 1.3\nwith the approved cipher suites (AES-GCM) and key exchange curves (P-256, P-384).\nPeers which don't support the restricted settings are rejected."
+	FeaturesConfigDoc.Fields[4].Comments[encoder.LineComment] = "Restrict TLS on the Talos API endpoints (apid, trustd and the metrics endpoint) to TLS 1.3"
+
+	CRIConfigDoc.Type = "CRIConfig"
+	CRIConfigDoc.Comments[encoder.LineComment] = "CRIConfig represents the CRI (containerd) options."
+	CRIConfigDoc.Description = "CRIConfig represents the CRI (containerd) options."
+
+	CRIConfigDoc.AddExample("", machineCRIExample)
+	CRIConfigDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "MachineConfig",
+			FieldName: "cri",
+		},
+	}
+	CRIConfigDoc.Fields = make([]encoder.Doc, 2)
+	CRIConfigDoc.Fields[0].Name = "sandboxImage"
+	CRIConfigDoc.Fields[0].Type = "string"
+	CRIConfigDoc.Fields[0].Note = ""
+	CRIConfigDoc.Fields[0].Description = "Image of the sandbox (pause) container, overrides the containerd default."
+	CRIConfigDoc.Fields[0].Comments[encoder.LineComment] = "Image of the sandbox (pause) container, overrides the containerd default."
+	CRIConfigDoc.Fields[1].Name = "configPatches"
+	CRIConfigDoc.Fields[1].Type = "[]string"
+	CRIConfigDoc.Fields[1].Note = ""
+	CRIConfigDoc.Fields[1].Description = "Patches to the containerd configuration, in TOML format.\nPatches are merged in order into the configuration generated by Talos (registries, runtimes, etc.),\nthe values set by the patches take precedence.\nA table can't be replaced with a value (and vice versa), patches are validated when the configuration is applied."
+	CRIConfigDoc.Fields[1].Comments[encoder.LineComment] = "Patches to the containerd configuration, in TOML format."
+
+	NVIDIAConfigDoc.Type = "NVIDIAConfig"
+	NVIDIAConfigDoc.Comments[encoder.LineComment] = "NVIDIAConfig represents the NVIDIA GPU support options."
+	NVIDIAConfigDoc.Description = "NVIDIAConfig represents the NVIDIA GPU support options."
+
+	NVIDIAConfigDoc.AddExample("", machineNVIDIAExample)
+	NVIDIAConfigDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "MachineConfig",
+			FieldName: "nvidia",
+		},
+	}
+	NVIDIAConfigDoc.Fields = make([]encoder.Doc, 1)
+	NVIDIAConfigDoc.Fields[0].Name = "enabled"
+	NVIDIAConfigDoc.Fields[0].Type = "bool"
+	NVIDIAConfigDoc.Fields[0].Note = ""
+	NVIDIAConfigDoc.Fields[0].Description = "Enables NVIDIA GPU support.\nNVIDIA kernel modules are loaded on boot, and the `nvidia` runtime is configured in the CRI,\nit can be used by the pods via the `RuntimeClass` with the `nvidia` handler.\nValidation fails if the NVIDIA extension is not installed."
+	NVIDIAConfigDoc.Fields[0].Comments[encoder.LineComment] = "Enables NVIDIA GPU support."
+
+	ReconcileConfigDoc.Type = "ReconcileConfig"
+	ReconcileConfigDoc.Comments[encoder.LineComment] = "ReconcileConfig represents the options of the automatic reconciliation of the cluster state."
+	ReconcileConfigDoc.Description = "ReconcileConfig represents the options of the automatic reconciliation of the cluster state."
+
+	ReconcileConfigDoc.AddExample("", machineReconcileExample)
+	ReconcileConfigDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "MachineConfig",
+			FieldName: "reconcile",
+		},
+	}
+	ReconcileConfigDoc.Fields = make([]encoder.Doc, 2)
+	ReconcileConfigDoc.Fields[0].Name = "paused"
+	ReconcileConfigDoc.Fields[0].Type = "bool"
+	ReconcileConfigDoc.Fields[0].Note = ""
+	ReconcileConfigDoc.Fields[0].Description = "Pauses the reconciliation until the setting is removed."
+	ReconcileConfigDoc.Fields[0].Comments[encoder.LineComment] = "Pauses the reconciliation until the setting is removed."
+	ReconcileConfigDoc.Fields[1].Name = "maintenanceWindows"
+	ReconcileConfigDoc.Fields[1].Type = "[]MaintenanceWindowConfig"
+	ReconcileConfigDoc.Fields[1].Note = ""
+	ReconcileConfigDoc.Fields[1].Description = "Maintenance windows, the reconciliation is paused while any of the windows is active."
+	ReconcileConfigDoc.Fields[1].Comments[encoder.LineComment] = "Maintenance windows, the reconciliation is paused while any of the windows is active."
+
+	MaintenanceWindowConfigDoc.Type = "MaintenanceWindowConfig"
+	MaintenanceWindowConfigDoc.Comments[encoder.LineComment] = "MaintenanceWindowConfig represents the maintenance window."
+	MaintenanceWindowConfigDoc.Description = "MaintenanceWindowConfig represents the maintenance window."
+
+	MaintenanceWindowConfigDoc.AddExample("", machineReconcileExample.ReconcileMaintenanceWindows)
+	MaintenanceWindowConfigDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "ReconcileConfig",
+			FieldName: "maintenanceWindows",
+		},
+	}
+	MaintenanceWindowConfigDoc.Fields = make([]encoder.Doc, 2)
+	MaintenanceWindowConfigDoc.Fields[0].Name = "start"
+	MaintenanceWindowConfigDoc.Fields[0].Type = "string"
+	MaintenanceWindowConfigDoc.Fields[0].Note = ""
+	MaintenanceWindowConfigDoc.Fields[0].Description = "Start of the window in RFC3339 format."
+	MaintenanceWindowConfigDoc.Fields[0].Comments[encoder.LineComment] = "Start of the window in RFC3339 format."
+	MaintenanceWindowConfigDoc.Fields[1].Name = "duration"
+	MaintenanceWindowConfigDoc.Fields[1].Type = "Duration"
+	MaintenanceWindowConfigDoc.Fields[1].Note = ""
+	MaintenanceWindowConfigDoc.Fields[1].Description = "Duration of the window.\nField format accepts any Go time.Duration format ('1h' for one hour, '10m' for ten minutes)."
+	MaintenanceWindowConfigDoc.Fields[1].Comments[encoder.LineComment] = "Duration of the window."
+
+	RegistrationConfigDoc.Type = "RegistrationConfig"
+	RegistrationConfigDoc.Comments[encoder.LineComment] = "RegistrationConfig represents the node registration with the external inventory API."
+	RegistrationConfigDoc.Description = "RegistrationConfig represents the node registration with the external inventory API."
+
+	RegistrationConfigDoc.AddExample("", machineRegistrationExample)
+	RegistrationConfigDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "MachineConfig",
+			FieldName: "registration",
+		},
+	}
+	RegistrationConfigDoc.Fields = make([]encoder.Doc, 3)
+	RegistrationConfigDoc.Fields[0].Name = "endpoint"
+	RegistrationConfigDoc.Fields[0].Type = "string"
+	RegistrationConfigDoc.Fields[0].Note = ""
+	RegistrationConfigDoc.Fields[0].Description = "Inventory API URL, the node registers itself with `PUT {endpoint}/v1/nodes/{nodeId}`."
+	RegistrationConfigDoc.Fields[0].Comments[encoder.LineComment] = "Inventory API URL, the node registers itself with `PUT {endpoint}/v1/nodes/{nodeId}`."
+
+	RegistrationConfigDoc.Fields[0].AddExample("", "https://inventory.example.com/api/")
+	RegistrationConfigDoc.Fields[1].Name = "token"
+	RegistrationConfigDoc.Fields[1].Type = "string"
+	RegistrationConfigDoc.Fields[1].Note = ""
+	RegistrationConfigDoc.Fields[1].Description = "Bearer token sent with the inventory API requests."
+	RegistrationConfigDoc.Fields[1].Comments[encoder.LineComment] = "Bearer token sent with the inventory API requests."
+	RegistrationConfigDoc.Fields[2].Name = "heartbeatInterval"
+	RegistrationConfigDoc.Fields[2].Type = "Duration"
+	RegistrationConfigDoc.Fields[2].Note = ""
+	RegistrationConfigDoc.Fields[2].Description = "Interval between the heartbeats (defaults to one minute).\nField format accepts any Go time.Duration format ('1h' for one hour, '10m' for ten minutes)."
+	RegistrationConfigDoc.Fields[2].Comments[encoder.LineComment] = "Interval between the heartbeats (defaults to one minute)."
+
+	IdentityConfigDoc.Type = "IdentityConfig"
+	IdentityConfigDoc.Comments[encoder.LineComment] = "IdentityConfig represents the machine identity options."
+	IdentityConfigDoc.Description = "IdentityConfig represents the machine identity options."
+
+	IdentityConfigDoc.AddExample("", machineIdentityExample)
+	IdentityConfigDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "MachineConfig",
+			FieldName: "identity",
+		},
+	}
+	IdentityConfigDoc.Fields = make([]encoder.Doc, 1)
+	IdentityConfigDoc.Fields[0].Name = "kms"
+	IdentityConfigDoc.Fields[0].Type = "IdentityKMSConfig"
+	IdentityConfigDoc.Fields[0].Note = ""
+	IdentityConfigDoc.Fields[0].Description = "Seals the identity private key with the [KMS v2 plugin](https://kubernetes.io/docs/tasks/administer-cluster/kms-provider/).\n\nThe identity is generated on the first boot before the KMS plugin is available, so the key is sealed\nas soon as the plugin becomes reachable.\nThe public key stays readable, so the node ID is available on boot without the KMS.\nRemoving the option doesn't unseal the key."
+	IdentityConfigDoc.Fields[0].Comments[encoder.LineComment] = "Seals the identity private key with the [KMS v2 plugin](https://kubernetes.io/docs/tasks/administer-cluster/kms-provider/)."
+
+	IdentityKMSConfigDoc.Type = "IdentityKMSConfig"
+	IdentityKMSConfigDoc.Comments[encoder.LineComment] = "IdentityKMSConfig describes the KMS v2 plugin the machine identity is sealed with."
+	IdentityKMSConfigDoc.Description = "IdentityKMSConfig describes the KMS v2 plugin the machine identity is sealed with."
+	IdentityKMSConfigDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "IdentityConfig",
+			FieldName: "kms",
+		},
+	}
+	IdentityKMSConfigDoc.Fields = make([]encoder.Doc, 2)
+	IdentityKMSConfigDoc.Fields[0].Name = "endpoint"
+	IdentityKMSConfigDoc.Fields[0].Type = "string"
+	IdentityKMSConfigDoc.Fields[0].Note = ""
+	IdentityKMSConfigDoc.Fields[0].Description = "The gRPC endpoint of the KMS plugin, only unix sockets are supported (`unix:///path/to/socket`)."
+	IdentityKMSConfigDoc.Fields[0].Comments[encoder.LineComment] = "The gRPC endpoint of the KMS plugin, only unix sockets are supported (`unix:///path/to/socket`)."
+	IdentityKMSConfigDoc.Fields[1].Name = "timeout"
+	IdentityKMSConfigDoc.Fields[1].Type = "Duration"
+	IdentityKMSConfigDoc.Fields[1].Note = ""
+	IdentityKMSConfigDoc.Fields[1].Description = "The timeout of the calls to the KMS plugin (default is 3 seconds).\nField format accepts any Go time.Duration format ('1h' for one hour, '10m' for ten minutes)."
+	IdentityKMSConfigDoc.Fields[1].Comments[encoder.LineComment] = "The timeout of the calls to the KMS plugin (default is 3 seconds)."
+
 	RegistriesConfigDoc.Type = "RegistriesConfig"
 	RegistriesConfigDoc.Comments[encoder.LineComment] = "RegistriesConfig represents the image pull options."
 	RegistriesConfigDoc.Description = "RegistriesConfig represents the image pull options."
@@ -785,7 +1522,7 @@ func init() {
 	APIServerConfigDoc.Fields[3].Name = "certSANs"
 	APIServerConfigDoc.Fields[3].Type = "[]string"
 	APIServerConfigDoc.Fields[3].Note = ""
-	APIServerConfigDoc.Fields[3].Description = "Extra certificate subject alternative names for the API server's certificate."
+	APIServerConfigDoc.Fields[3].Description = "Extra certificate subject alternative names for the API server's certificate.\nThe cluster endpoint, the addresses of the node and the VIPs are added to the certificate's SANs automatically,\nand the certificate is regenerated when the node addresses change."
 	APIServerConfigDoc.Fields[3].Comments[encoder.LineComment] = "Extra certificate subject alternative names for the API server's certificate."
 
 	ControllerManagerConfigDoc.Type = "ControllerManagerConfig"
@@ -946,18 +1683,22 @@ func init() {
 	ClusterNetworkConfigDoc.Fields[2].Name = "podSubnets"
 	ClusterNetworkConfigDoc.Fields[2].Type = "[]string"
 	ClusterNetworkConfigDoc.Fields[2].Note = ""
-	ClusterNetworkConfigDoc.Fields[2].Description = "The pod subnet CIDR."
+	ClusterNetworkConfigDoc.Fields[2].Description = "The pod subnet CIDR.\nDual-stack clusters use one IPv4 and one IPv6 subnet, the first subnet defines the primary IP family."
 	ClusterNetworkConfigDoc.Fields[2].Comments[encoder.LineComment] = "The pod subnet CIDR."
 
 	ClusterNetworkConfigDoc.Fields[2].AddExample("", []string{"10.244.0.0/16"})
+
+	ClusterNetworkConfigDoc.Fields[2].AddExample("", []string{"10.244.0.0/16", "fc00:db8:10::/56"})
 	ClusterNetworkConfigDoc.Fields[3].Name = "serviceSubnets"
 	ClusterNetworkConfigDoc.Fields[3].Type = "[]string"
 	ClusterNetworkConfigDoc.Fields[3].Note = ""
-	ClusterNetworkConfigDoc.Fields[3].Description = "The service subnet CIDR."
+	ClusterNetworkConfigDoc.Fields[3].Description = "The service subnet CIDR.\nService subnets should have the same IP families in the same order as the pod subnets."
 	ClusterNetworkConfigDoc.Fields[3].Comments[encoder.LineComment] = "The service subnet CIDR."
 
 	ClusterNetworkConfigDoc.Fields[3].AddExample("", []string{"10.96.0.0/12"})
 
+	ClusterNetworkConfigDoc.Fields[3].AddExample("", []string{"10.96.0.0/12", "fc00:db8:20::/112"})
+
 	CNIConfigDoc.Type = "CNIConfig"
 	CNIConfigDoc.Comments[encoder.LineComment] = "CNIConfig represents the CNI configuration options."
 	CNIConfigDoc.Description = "CNIConfig represents the CNI configuration options."
@@ -1033,6 +1774,136 @@ func init() {
 	AdminKubeconfigConfigDoc.Fields[0].Description = "Admin kubeconfig certificate lifetime (default is 1 year).\nField format accepts any Go time.Duration format ('1h' for one hour, '10m' for ten minutes)."
 	AdminKubeconfigConfigDoc.Fields[0].Comments[encoder.LineComment] = "Admin kubeconfig certificate lifetime (default is 1 year)."
 
+	AESCBCEncryptionKeyConfigDoc.Type = "AESCBCEncryptionKeyConfig"
+	AESCBCEncryptionKeyConfigDoc.Comments[encoder.LineComment] = "AESCBCEncryptionKeyConfig describes the key for the encryption of secret data at rest."
+	AESCBCEncryptionKeyConfigDoc.Description = "AESCBCEncryptionKeyConfig describes the key for the encryption of secret data at rest."
+
+	AESCBCEncryptionKeyConfigDoc.AddExample("", clusterAESCBCAdditionalEncryptionKeysExample)
+	AESCBCEncryptionKeyConfigDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "ClusterConfig",
+			FieldName: "aescbcAdditionalEncryptionKeys",
+		},
+	}
+	AESCBCEncryptionKeyConfigDoc.Fields = make([]encoder.Doc, 2)
+	AESCBCEncryptionKeyConfigDoc.Fields[0].Name = "name"
+	AESCBCEncryptionKeyConfigDoc.Fields[0].Type = "string"
+	AESCBCEncryptionKeyConfigDoc.Fields[0].Note = ""
+	AESCBCEncryptionKeyConfigDoc.Fields[0].Description = "The name of the key."
+	AESCBCEncryptionKeyConfigDoc.Fields[0].Comments[encoder.LineComment] = "The name of the key."
+	AESCBCEncryptionKeyConfigDoc.Fields[1].Name = "secret"
+	AESCBCEncryptionKeyConfigDoc.Fields[1].Type = "string"
+	AESCBCEncryptionKeyConfigDoc.Fields[1].Note = ""
+	AESCBCEncryptionKeyConfigDoc.Fields[1].Description = "The key (32 random bytes, base64 encoded)."
+	AESCBCEncryptionKeyConfigDoc.Fields[1].Comments[encoder.LineComment] = "The key (32 random bytes, base64 encoded)."
+
+	SecretsEncryptionConfigDoc.Type = "SecretsEncryptionConfig"
+	SecretsEncryptionConfigDoc.Comments[encoder.LineComment] = "SecretsEncryptionConfig describes the encryption of secret data at rest."
+	SecretsEncryptionConfigDoc.Description = "SecretsEncryptionConfig describes the encryption of secret data at rest."
+
+	SecretsEncryptionConfigDoc.AddExample("", clusterSecretsEncryptionExample)
+	SecretsEncryptionConfigDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "ClusterConfig",
+			FieldName: "secretsEncryption",
+		},
+	}
+	SecretsEncryptionConfigDoc.Fields = make([]encoder.Doc, 3)
+	SecretsEncryptionConfigDoc.Fields[0].Name = "provider"
+	SecretsEncryptionConfigDoc.Fields[0].Type = "string"
+	SecretsEncryptionConfigDoc.Fields[0].Note = ""
+	SecretsEncryptionConfigDoc.Fields[0].Description = "The provider used to encrypt the data, defaults to `aescbc`."
+	SecretsEncryptionConfigDoc.Fields[0].Comments[encoder.LineComment] = "The provider used to encrypt the data, defaults to `aescbc`."
+	SecretsEncryptionConfigDoc.Fields[0].Values = []string{
+		"aescbc",
+		"secretbox",
+		"kms",
+	}
+	SecretsEncryptionConfigDoc.Fields[1].Name = "secretbox"
+	SecretsEncryptionConfigDoc.Fields[1].Type = "SecretboxEncryptionConfig"
+	SecretsEncryptionConfigDoc.Fields[1].Note = ""
+	SecretsEncryptionConfigDoc.Fields[1].Description = "The configuration of the `secretbox` provider."
+	SecretsEncryptionConfigDoc.Fields[1].Comments[encoder.LineComment] = "The configuration of the `secretbox` provider."
+	SecretsEncryptionConfigDoc.Fields[2].Name = "kms"
+	SecretsEncryptionConfigDoc.Fields[2].Type = "KMSEncryptionConfig"
+	SecretsEncryptionConfigDoc.Fields[2].Note = ""
+	SecretsEncryptionConfigDoc.Fields[2].Description = "The configuration of the [KMS v2 plugin](https://kubernetes.io/docs/tasks/administer-cluster/kms-provider/) provider.\n\nKMS v2 plugins require Kubernetes 1.25 or later (Kubernetes 1.25 and 1.26 require the `KMSv2` feature gate\nto be enabled with `.cluster.apiServer.extraArgs`)."
+	SecretsEncryptionConfigDoc.Fields[2].Comments[encoder.LineComment] = "The configuration of the [KMS v2 plugin](https://kubernetes.io/docs/tasks/administer-cluster/kms-provider/) provider."
+
+	SecretsEncryptionConfigDoc.Fields[2].AddExample("", clusterSecretsEncryptionKMSExample)
+
+	SecretboxEncryptionConfigDoc.Type = "SecretboxEncryptionConfig"
+	SecretboxEncryptionConfigDoc.Comments[encoder.LineComment] = "SecretboxEncryptionConfig describes the key of the secretbox provider."
+	SecretboxEncryptionConfigDoc.Description = "SecretboxEncryptionConfig describes the key of the secretbox provider."
+	SecretboxEncryptionConfigDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "SecretsEncryptionConfig",
+			FieldName: "secretbox",
+		},
+	}
+	SecretboxEncryptionConfigDoc.Fields = make([]encoder.Doc, 2)
+	SecretboxEncryptionConfigDoc.Fields[0].Name = "name"
+	SecretboxEncryptionConfigDoc.Fields[0].Type = "string"
+	SecretboxEncryptionConfigDoc.Fields[0].Note = ""
+	SecretboxEncryptionConfigDoc.Fields[0].Description = "The name of the key, defaults to `key1`."
+	SecretboxEncryptionConfigDoc.Fields[0].Comments[encoder.LineComment] = "The name of the key, defaults to `key1`."
+	SecretboxEncryptionConfigDoc.Fields[1].Name = "secret"
+	SecretboxEncryptionConfigDoc.Fields[1].Type = "string"
+	SecretboxEncryptionConfigDoc.Fields[1].Note = ""
+	SecretboxEncryptionConfigDoc.Fields[1].Description = "The key (32 random bytes, base64 encoded)."
+	SecretboxEncryptionConfigDoc.Fields[1].Comments[encoder.LineComment] = "The key (32 random bytes, base64 encoded)."
+
+	KMSEncryptionConfigDoc.Type = "KMSEncryptionConfig"
+	KMSEncryptionConfigDoc.Comments[encoder.LineComment] = "KMSEncryptionConfig describes the KMS v2 plugin provider."
+	KMSEncryptionConfigDoc.Description = "KMSEncryptionConfig describes the KMS v2 plugin provider."
+
+	KMSEncryptionConfigDoc.AddExample("", clusterSecretsEncryptionKMSExample)
+	KMSEncryptionConfigDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "SecretsEncryptionConfig",
+			FieldName: "kms",
+		},
+	}
+	KMSEncryptionConfigDoc.Fields = make([]encoder.Doc, 3)
+	KMSEncryptionConfigDoc.Fields[0].Name = "name"
+	KMSEncryptionConfigDoc.Fields[0].Type = "string"
+	KMSEncryptionConfigDoc.Fields[0].Note = ""
+	KMSEncryptionConfigDoc.Fields[0].Description = "The name of the KMS plugin.\n\nKubernetes stores the name of the plugin along with the encrypted data, so the name should not be changed."
+	KMSEncryptionConfigDoc.Fields[0].Comments[encoder.LineComment] = "The name of the KMS plugin."
+	KMSEncryptionConfigDoc.Fields[1].Name = "endpoint"
+	KMSEncryptionConfigDoc.Fields[1].Type = "string"
+	KMSEncryptionConfigDoc.Fields[1].Note = ""
+	KMSEncryptionConfigDoc.Fields[1].Description = "The gRPC endpoint of the KMS plugin, only unix sockets are supported (`unix:///path/to/socket`).\n\nThe directory of the socket is mounted into the `kube-apiserver` static pod."
+	KMSEncryptionConfigDoc.Fields[1].Comments[encoder.LineComment] = "The gRPC endpoint of the KMS plugin, only unix sockets are supported (`unix:///path/to/socket`)."
+	KMSEncryptionConfigDoc.Fields[2].Name = "timeout"
+	KMSEncryptionConfigDoc.Fields[2].Type = "Duration"
+	KMSEncryptionConfigDoc.Fields[2].Note = ""
+	KMSEncryptionConfigDoc.Fields[2].Description = "The timeout of the calls to the KMS plugin (default is 3 seconds).\nField format accepts any Go time.Duration format ('1h' for one hour, '10m' for ten minutes)."
+	KMSEncryptionConfigDoc.Fields[2].Comments[encoder.LineComment] = "The timeout of the calls to the KMS plugin (default is 3 seconds)."
+
+	ManifestsSyncConfigDoc.Type = "ManifestsSyncConfig"
+	ManifestsSyncConfigDoc.Comments[encoder.LineComment] = "ManifestsSyncConfig represents the options of the continuous reconciliation of the bootstrap manifests."
+	ManifestsSyncConfigDoc.Description = "ManifestsSyncConfig represents the options of the continuous reconciliation of the bootstrap manifests."
+
+	ManifestsSyncConfigDoc.AddExample("", clusterManifestsSyncExample)
+	ManifestsSyncConfigDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "ClusterConfig",
+			FieldName: "manifestsSync",
+		},
+	}
+	ManifestsSyncConfigDoc.Fields = make([]encoder.Doc, 2)
+	ManifestsSyncConfigDoc.Fields[0].Name = "interval"
+	ManifestsSyncConfigDoc.Fields[0].Type = "Duration"
+	ManifestsSyncConfigDoc.Fields[0].Note = ""
+	ManifestsSyncConfigDoc.Fields[0].Description = "The interval between the reconciliations of the manifests (default is 5 minutes).\nField format accepts any Go time.Duration format ('1h' for one hour, '10m' for ten minutes)."
+	ManifestsSyncConfigDoc.Fields[0].Comments[encoder.LineComment] = "The interval between the reconciliations of the manifests (default is 5 minutes)."
+	ManifestsSyncConfigDoc.Fields[1].Name = "unmanagedManifests"
+	ManifestsSyncConfigDoc.Fields[1].Type = "[]string"
+	ManifestsSyncConfigDoc.Fields[1].Note = ""
+	ManifestsSyncConfigDoc.Fields[1].Description = "IDs of the manifests which are never applied by Talos, so that the objects can be managed by the user\n(see `talosctl get manifests`)."
+	ManifestsSyncConfigDoc.Fields[1].Comments[encoder.LineComment] = "IDs of the manifests which are never applied by Talos, so that the objects can be managed by the user"
+
 	MachineDiskDoc.Type = "MachineDisk"
 	MachineDiskDoc.Comments[encoder.LineComment] = "MachineDisk represents the options available for partitioning, formatting, and"
 	MachineDiskDoc.Description = "MachineDisk represents the options available for partitioning, formatting, and\nmounting extra disks.\n"
@@ -1238,98 +2109,269 @@ func init() {
 			FieldName: "interfaces",
 		},
 	}
-	DeviceDoc.Fields = make([]encoder.Doc, 12)
+	DeviceDoc.Fields = make([]encoder.Doc, 16)
 	DeviceDoc.Fields[0].Name = "interface"
 	DeviceDoc.Fields[0].Type = "string"
 	DeviceDoc.Fields[0].Note = ""
-	DeviceDoc.Fields[0].Description = "The interface name."
+	DeviceDoc.Fields[0].Description = "The interface name.\nMutually exclusive with `deviceSelector`."
 	DeviceDoc.Fields[0].Comments[encoder.LineComment] = "The interface name."
 
 	DeviceDoc.Fields[0].AddExample("", "eth0")
-	DeviceDoc.Fields[1].Name = "cidr"
-	DeviceDoc.Fields[1].Type = "string"
+	DeviceDoc.Fields[1].Name = "deviceSelector"
+	DeviceDoc.Fields[1].Type = "NetworkDeviceSelector"
 	DeviceDoc.Fields[1].Note = ""
-	DeviceDoc.Fields[1].Description = "Assigns a static IP address to the interface.\nThis should be in proper CIDR notation.\n\n> Note: This option is mutually exclusive with DHCP option."
-	DeviceDoc.Fields[1].Comments[encoder.LineComment] = "Assigns a static IP address to the interface."
+	DeviceDoc.Fields[1].Description = "Picks a network device using the selector.\nThe selector is resolved to the link name on the node, so that the configuration\ndoesn't depend on the link names assigned by the kernel.\nMutually exclusive with `interface`."
+	DeviceDoc.Fields[1].Comments[encoder.LineComment] = "Picks a network device using the selector."
 
-	DeviceDoc.Fields[1].AddExample("", "10.5.0.0/16")
-	DeviceDoc.Fields[2].Name = "routes"
-	DeviceDoc.Fields[2].Type = "[]Route"
+	DeviceDoc.Fields[1].AddExample("select a device by the bus path", networkDeviceSelectorExamples[0])
+
+	DeviceDoc.Fields[1].AddExample("select a device by the hardware address and the kernel driver", networkDeviceSelectorExamples[1])
+	DeviceDoc.Fields[2].Name = "cidr"
+	DeviceDoc.Fields[2].Type = "string"
 	DeviceDoc.Fields[2].Note = ""
-	DeviceDoc.Fields[2].Description = "A list of routes associated with the interface.\nIf used in combination with DHCP, these routes will be appended to routes returned by DHCP server."
-	DeviceDoc.Fields[2].Comments[encoder.LineComment] = "A list of routes associated with the interface."
+	DeviceDoc.Fields[2].Description = "Assigns a static IP address to the interface.\nThis should be in proper CIDR notation.\n\n> Note: This option is mutually exclusive with DHCP option."
+	DeviceDoc.Fields[2].Comments[encoder.LineComment] = "Assigns a static IP address to the interface."
 
-	DeviceDoc.Fields[2].AddExample("", networkConfigRoutesExample)
-	DeviceDoc.Fields[3].Name = "bond"
-	DeviceDoc.Fields[3].Type = "Bond"
+	DeviceDoc.Fields[2].AddExample("", "10.5.0.0/16")
+	DeviceDoc.Fields[3].Name = "routes"
+	DeviceDoc.Fields[3].Type = "[]Route"
 	DeviceDoc.Fields[3].Note = ""
-	DeviceDoc.Fields[3].Description = "Bond specific options."
-	DeviceDoc.Fields[3].Comments[encoder.LineComment] = "Bond specific options."
+	DeviceDoc.Fields[3].Description = "A list of routes associated with the interface.\nIf used in combination with DHCP, these routes will be appended to routes returned by DHCP server."
+	DeviceDoc.Fields[3].Comments[encoder.LineComment] = "A list of routes associated with the interface."
+
+	DeviceDoc.Fields[3].AddExample("", networkConfigRoutesExample)
 
-	DeviceDoc.Fields[3].AddExample("", networkConfigBondExample)
-	DeviceDoc.Fields[4].Name = "vlans"
-	DeviceDoc.Fields[4].Type = "[]Vlan"
+	DeviceDoc.Fields[3].AddExample("policy routing example", networkConfigPolicyRoutesExample)
+	DeviceDoc.Fields[4].Name = "bond"
+	DeviceDoc.Fields[4].Type = "Bond"
 	DeviceDoc.Fields[4].Note = ""
-	DeviceDoc.Fields[4].Description = "VLAN specific options."
-	DeviceDoc.Fields[4].Comments[encoder.LineComment] = "VLAN specific options."
-	DeviceDoc.Fields[5].Name = "mtu"
-	DeviceDoc.Fields[5].Type = "int"
+	DeviceDoc.Fields[4].Description = "Bond specific options."
+	DeviceDoc.Fields[4].Comments[encoder.LineComment] = "Bond specific options."
+
+	DeviceDoc.Fields[4].AddExample("", networkConfigBondExample)
+	DeviceDoc.Fields[5].Name = "bridge"
+	DeviceDoc.Fields[5].Type = "Bridge"
 	DeviceDoc.Fields[5].Note = ""
-	DeviceDoc.Fields[5].Description = "The interface's MTU.\nIf used in combination with DHCP, this will override any MTU settings returned from DHCP server."
-	DeviceDoc.Fields[5].Comments[encoder.LineComment] = "The interface's MTU."
-	DeviceDoc.Fields[6].Name = "dhcp"
-	DeviceDoc.Fields[6].Type = "bool"
+	DeviceDoc.Fields[5].Description = "Bridge specific options.\nBridges can be used as a parent interface for VM traffic (e.g. KubeVirt bridge or macvtap networking)."
+	DeviceDoc.Fields[5].Comments[encoder.LineComment] = "Bridge specific options."
+
+	DeviceDoc.Fields[5].AddExample("", networkConfigBridgeExample)
+	DeviceDoc.Fields[6].Name = "vlans"
+	DeviceDoc.Fields[6].Type = "[]Vlan"
 	DeviceDoc.Fields[6].Note = ""
-	DeviceDoc.Fields[6].Description = "Indicates if DHCP should be used to configure the interface.\nThe following DHCP options are supported:\n\n- `OptionClasslessStaticRoute`\n- `OptionDomainNameServer`\n- `OptionDNSDomainSearchList`\n- `OptionHostName`\n\n> Note: This option is mutually exclusive with CIDR.\n>\n> Note: To configure an interface with *only* IPv6 SLAAC addressing, CIDR should be set to \"\" and DHCP to false\n> in order for Talos to skip configuration of addresses.\n> All other options will still apply."
-	DeviceDoc.Fields[6].Comments[encoder.LineComment] = "Indicates if DHCP should be used to configure the interface."
+	DeviceDoc.Fields[6].Description = "VLAN specific options.\nEach VLAN creates a tagged sub-interface named `<interface>.<vlanId>` (e.g. `eth0.100`) over the device,\nwhich can be a physical link or a bond."
+	DeviceDoc.Fields[6].Comments[encoder.LineComment] = "VLAN specific options."
 
-	DeviceDoc.Fields[6].AddExample("", true)
-	DeviceDoc.Fields[7].Name = "ignore"
-	DeviceDoc.Fields[7].Type = "bool"
+	DeviceDoc.Fields[6].AddExample("", networkConfigVlansExample)
+	DeviceDoc.Fields[7].Name = "mtu"
+	DeviceDoc.Fields[7].Type = "int"
 	DeviceDoc.Fields[7].Note = ""
-	DeviceDoc.Fields[7].Description = "Indicates if the interface should be ignored (skips configuration)."
-	DeviceDoc.Fields[7].Comments[encoder.LineComment] = "Indicates if the interface should be ignored (skips configuration)."
-	DeviceDoc.Fields[8].Name = "dummy"
-	DeviceDoc.Fields[8].Type = "bool"
+	DeviceDoc.Fields[7].Description = "The interface's MTU.\nIf used in combination with DHCP, this will override any MTU settings returned from DHCP server."
+	DeviceDoc.Fields[7].Comments[encoder.LineComment] = "The interface's MTU."
+	DeviceDoc.Fields[8].Name = "ethernet"
+	DeviceDoc.Fields[8].Type = "EthernetConfig"
 	DeviceDoc.Fields[8].Note = ""
-	DeviceDoc.Fields[8].Description = "Indicates if the interface is a dummy interface.\n`dummy` is used to specify that this interface should be a virtual-only, dummy interface."
-	DeviceDoc.Fields[8].Comments[encoder.LineComment] = "Indicates if the interface is a dummy interface."
-	DeviceDoc.Fields[9].Name = "dhcpOptions"
-	DeviceDoc.Fields[9].Type = "DHCPOptions"
+	DeviceDoc.Fields[8].Description = "Ethernet hardware settings of the interface (Wake-on-LAN, offloads, ring buffers).\nSettings are applied via ethtool netlink interface, the settings which are not specified are not changed."
+	DeviceDoc.Fields[8].Comments[encoder.LineComment] = "Ethernet hardware settings of the interface (Wake-on-LAN, offloads, ring buffers)."
+
+	DeviceDoc.Fields[8].AddExample("", networkConfigEthernetExample)
+	DeviceDoc.Fields[9].Name = "dhcp"
+	DeviceDoc.Fields[9].Type = "bool"
 	DeviceDoc.Fields[9].Note = ""
-	DeviceDoc.Fields[9].Description = "DHCP specific options.\n`dhcp` *must* be set to true for these to take effect."
-	DeviceDoc.Fields[9].Comments[encoder.LineComment] = "DHCP specific options."
+	DeviceDoc.Fields[9].Description = "Indicates if DHCP should be used to configure the interface.\nThe following DHCP options are supported:\n\n- `OptionClasslessStaticRoute`\n- `OptionDomainNameServer`\n- `OptionDNSDomainSearchList`\n- `OptionHostName`\n\n> Note: This option is mutually exclusive with CIDR.\n>\n> Note: To configure an interface with *only* IPv6 SLAAC addressing, CIDR should be set to \"\" and DHCP to false\n> in order for Talos to skip configuration of addresses.\n> All other options will still apply."
+	DeviceDoc.Fields[9].Comments[encoder.LineComment] = "Indicates if DHCP should be used to configure the interface."
 
-	DeviceDoc.Fields[9].AddExample("", networkConfigDHCPOptionsExample)
-	DeviceDoc.Fields[10].Name = "wireguard"
-	DeviceDoc.Fields[10].Type = "DeviceWireguardConfig"
+	DeviceDoc.Fields[9].AddExample("", true)
+	DeviceDoc.Fields[10].Name = "ignore"
+	DeviceDoc.Fields[10].Type = "bool"
 	DeviceDoc.Fields[10].Note = ""
-	DeviceDoc.Fields[10].Description = "Wireguard specific configuration.\nIncludes things like private key, listen port, peers."
-	DeviceDoc.Fields[10].Comments[encoder.LineComment] = "Wireguard specific configuration."
+	DeviceDoc.Fields[10].Description = "Indicates if the interface should be ignored (skips configuration)."
+	DeviceDoc.Fields[10].Comments[encoder.LineComment] = "Indicates if the interface should be ignored (skips configuration)."
+	DeviceDoc.Fields[11].Name = "dummy"
+	DeviceDoc.Fields[11].Type = "bool"
+	DeviceDoc.Fields[11].Note = ""
+	DeviceDoc.Fields[11].Description = "Indicates if the interface is a dummy interface.\n`dummy` is used to specify that this interface should be a virtual-only, dummy interface."
+	DeviceDoc.Fields[11].Comments[encoder.LineComment] = "Indicates if the interface is a dummy interface."
+	DeviceDoc.Fields[12].Name = "macvtapParent"
+	DeviceDoc.Fields[12].Type = "bool"
+	DeviceDoc.Fields[12].Note = ""
+	DeviceDoc.Fields[12].Description = "Indicates if the interface is a parent (lower) interface for the macvtap interfaces of the virtual machines\n(e.g. KubeVirt macvtap networking).\nThe interface is brought up without any addressing, so `cidr` and `dhcp` can't be used with this option."
+	DeviceDoc.Fields[12].Comments[encoder.LineComment] = "Indicates if the interface is a parent (lower) interface for the macvtap interfaces of the virtual machines"
+	DeviceDoc.Fields[13].Name = "dhcpOptions"
+	DeviceDoc.Fields[13].Type = "DHCPOptions"
+	DeviceDoc.Fields[13].Note = ""
+	DeviceDoc.Fields[13].Description = "DHCP specific options.\n`dhcp` *must* be set to true for these to take effect."
+	DeviceDoc.Fields[13].Comments[encoder.LineComment] = "DHCP specific options."
 
-	DeviceDoc.Fields[10].AddExample("wireguard server example", networkConfigWireguardHostExample)
+	DeviceDoc.Fields[13].AddExample("", networkConfigDHCPOptionsExample)
 
-	DeviceDoc.Fields[10].AddExample("wireguard peer example", networkConfigWireguardPeerExample)
-	DeviceDoc.Fields[11].Name = "vip"
-	DeviceDoc.Fields[11].Type = "DeviceVIPConfig"
-	DeviceDoc.Fields[11].Note = ""
-	DeviceDoc.Fields[11].Description = "Virtual (shared) IP address configuration."
-	DeviceDoc.Fields[11].Comments[encoder.LineComment] = "Virtual (shared) IP address configuration."
+	DeviceDoc.Fields[13].AddExample("DHCPv6 and SLAAC example", networkConfigDHCPv6OptionsExample)
+	DeviceDoc.Fields[14].Name = "wireguard"
+	DeviceDoc.Fields[14].Type = "DeviceWireguardConfig"
+	DeviceDoc.Fields[14].Note = ""
+	DeviceDoc.Fields[14].Description = "Wireguard specific configuration.\nIncludes things like private key, listen port, peers."
+	DeviceDoc.Fields[14].Comments[encoder.LineComment] = "Wireguard specific configuration."
+
+	DeviceDoc.Fields[14].AddExample("wireguard server example", networkConfigWireguardHostExample)
+
+	DeviceDoc.Fields[14].AddExample("wireguard peer example", networkConfigWireguardPeerExample)
+
+	DeviceDoc.Fields[14].AddExample("wireguard with generated private key example", networkConfigWireguardGeneratedKeyExample)
+	DeviceDoc.Fields[15].Name = "vip"
+	DeviceDoc.Fields[15].Type = "DeviceVIPConfig"
+	DeviceDoc.Fields[15].Note = ""
+	DeviceDoc.Fields[15].Description = "Virtual (shared) IP address configuration.\nThe address is owned by one of the control plane nodes elected via etcd, and moved to another node on failure."
+	DeviceDoc.Fields[15].Comments[encoder.LineComment] = "Virtual (shared) IP address configuration."
+
+	DeviceDoc.Fields[15].AddExample("", networkConfigVIPLayer2Example)
+
+	DeviceDoc.Fields[15].AddExample("", networkConfigVIPEquinixMetalExample)
+
+	DeviceDoc.Fields[15].AddExample("", networkConfigVIPEquinixMetalBGPExample)
+
+	DeviceDoc.Fields[15].AddExample("", networkConfigVIPHCloudExample)
 
-	DeviceDoc.Fields[11].AddExample("", networkConfigVIPLayer2Example)
+	EthernetConfigDoc.Type = "EthernetConfig"
+	EthernetConfigDoc.Comments[encoder.LineComment] = "EthernetConfig contains ethernet hardware settings of the interface."
+	EthernetConfigDoc.Description = "EthernetConfig contains ethernet hardware settings of the interface."
+
+	EthernetConfigDoc.AddExample("", networkConfigEthernetExample)
+	EthernetConfigDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "Device",
+			FieldName: "ethernet",
+		},
+	}
+	EthernetConfigDoc.Fields = make([]encoder.Doc, 3)
+	EthernetConfigDoc.Fields[0].Name = "wakeOnLAN"
+	EthernetConfigDoc.Fields[0].Type = "[]string"
+	EthernetConfigDoc.Fields[0].Note = ""
+	EthernetConfigDoc.Fields[0].Description = "Wake-on-LAN modes to enable.\n`disabled` turns Wake-on-LAN off, and can't be combined with other modes."
+	EthernetConfigDoc.Fields[0].Comments[encoder.LineComment] = "Wake-on-LAN modes to enable."
+	EthernetConfigDoc.Fields[0].Values = []string{
+		"phy",
+		"ucast",
+		"mcast",
+		"bcast",
+		"arp",
+		"magic",
+		"disabled",
+	}
+	EthernetConfigDoc.Fields[1].Name = "offload"
+	EthernetConfigDoc.Fields[1].Type = "EthernetOffloadConfig"
+	EthernetConfigDoc.Fields[1].Note = ""
+	EthernetConfigDoc.Fields[1].Description = "Offload features of the interface."
+	EthernetConfigDoc.Fields[1].Comments[encoder.LineComment] = "Offload features of the interface."
+	EthernetConfigDoc.Fields[2].Name = "rings"
+	EthernetConfigDoc.Fields[2].Type = "EthernetRingsConfig"
+	EthernetConfigDoc.Fields[2].Note = ""
+	EthernetConfigDoc.Fields[2].Description = "Ring buffer sizes of the interface."
+	EthernetConfigDoc.Fields[2].Comments[encoder.LineComment] = "Ring buffer sizes of the interface."
+
+	EthernetOffloadConfigDoc.Type = "EthernetOffloadConfig"
+	EthernetOffloadConfigDoc.Comments[encoder.LineComment] = "EthernetOffloadConfig contains offload features of the interface."
+	EthernetOffloadConfigDoc.Description = "EthernetOffloadConfig contains offload features of the interface."
+	EthernetOffloadConfigDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "EthernetConfig",
+			FieldName: "offload",
+		},
+	}
+	EthernetOffloadConfigDoc.Fields = make([]encoder.Doc, 3)
+	EthernetOffloadConfigDoc.Fields[0].Name = "tso"
+	EthernetOffloadConfigDoc.Fields[0].Type = "bool"
+	EthernetOffloadConfigDoc.Fields[0].Note = ""
+	EthernetOffloadConfigDoc.Fields[0].Description = "TCP segmentation offload (TSO)."
+	EthernetOffloadConfigDoc.Fields[0].Comments[encoder.LineComment] = "TCP segmentation offload (TSO)."
+	EthernetOffloadConfigDoc.Fields[1].Name = "gro"
+	EthernetOffloadConfigDoc.Fields[1].Type = "bool"
+	EthernetOffloadConfigDoc.Fields[1].Note = ""
+	EthernetOffloadConfigDoc.Fields[1].Description = "Generic receive offload (GRO)."
+	EthernetOffloadConfigDoc.Fields[1].Comments[encoder.LineComment] = "Generic receive offload (GRO)."
+	EthernetOffloadConfigDoc.Fields[2].Name = "lro"
+	EthernetOffloadConfigDoc.Fields[2].Type = "bool"
+	EthernetOffloadConfigDoc.Fields[2].Note = ""
+	EthernetOffloadConfigDoc.Fields[2].Description = "Large receive offload (LRO)."
+	EthernetOffloadConfigDoc.Fields[2].Comments[encoder.LineComment] = "Large receive offload (LRO)."
+
+	EthernetRingsConfigDoc.Type = "EthernetRingsConfig"
+	EthernetRingsConfigDoc.Comments[encoder.LineComment] = "EthernetRingsConfig contains ring buffer sizes of the interface."
+	EthernetRingsConfigDoc.Description = "EthernetRingsConfig contains ring buffer sizes of the interface."
+	EthernetRingsConfigDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "EthernetConfig",
+			FieldName: "rings",
+		},
+	}
+	EthernetRingsConfigDoc.Fields = make([]encoder.Doc, 2)
+	EthernetRingsConfigDoc.Fields[0].Name = "rx"
+	EthernetRingsConfigDoc.Fields[0].Type = "uint32"
+	EthernetRingsConfigDoc.Fields[0].Note = ""
+	EthernetRingsConfigDoc.Fields[0].Description = "Number of entries in the RX ring."
+	EthernetRingsConfigDoc.Fields[0].Comments[encoder.LineComment] = "Number of entries in the RX ring."
+	EthernetRingsConfigDoc.Fields[1].Name = "tx"
+	EthernetRingsConfigDoc.Fields[1].Type = "uint32"
+	EthernetRingsConfigDoc.Fields[1].Note = ""
+	EthernetRingsConfigDoc.Fields[1].Description = "Number of entries in the TX ring."
+	EthernetRingsConfigDoc.Fields[1].Comments[encoder.LineComment] = "Number of entries in the TX ring."
+
+	NetworkDeviceSelectorDoc.Type = "NetworkDeviceSelector"
+	NetworkDeviceSelectorDoc.Comments[encoder.LineComment] = "NetworkDeviceSelector struct describes network device selector."
+	NetworkDeviceSelectorDoc.Description = "NetworkDeviceSelector struct describes network device selector.\n\nAll the specified fields should match the link for the selector to match.\n"
+
+	NetworkDeviceSelectorDoc.AddExample("select a device by the bus path", networkDeviceSelectorExamples[0])
+
+	NetworkDeviceSelectorDoc.AddExample("select a device by the hardware address and the kernel driver", networkDeviceSelectorExamples[1])
+	NetworkDeviceSelectorDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "Device",
+			FieldName: "deviceSelector",
+		},
+	}
+	NetworkDeviceSelectorDoc.Fields = make([]encoder.Doc, 4)
+	NetworkDeviceSelectorDoc.Fields[0].Name = "busPath"
+	NetworkDeviceSelectorDoc.Fields[0].Type = "string"
+	NetworkDeviceSelectorDoc.Fields[0].Note = ""
+	NetworkDeviceSelectorDoc.Fields[0].Description = "PCI, USB bus path of the device (as reported by `/sys/class/net/<link>/device`)."
+	NetworkDeviceSelectorDoc.Fields[0].Comments[encoder.LineComment] = "PCI, USB bus path of the device (as reported by `/sys/class/net/<link>/device`)."
+
+	NetworkDeviceSelectorDoc.Fields[0].AddExample("", "0000:00:03.0")
+	NetworkDeviceSelectorDoc.Fields[1].Name = "hardwareAddr"
+	NetworkDeviceSelectorDoc.Fields[1].Type = "string"
+	NetworkDeviceSelectorDoc.Fields[1].Note = ""
+	NetworkDeviceSelectorDoc.Fields[1].Description = "Permanent hardware address of the device."
+	NetworkDeviceSelectorDoc.Fields[1].Comments[encoder.LineComment] = "Permanent hardware address of the device."
+
+	NetworkDeviceSelectorDoc.Fields[1].AddExample("", "00:1a:2b:3c:f0:ab")
+	NetworkDeviceSelectorDoc.Fields[2].Name = "driver"
+	NetworkDeviceSelectorDoc.Fields[2].Type = "string"
+	NetworkDeviceSelectorDoc.Fields[2].Note = ""
+	NetworkDeviceSelectorDoc.Fields[2].Description = "Kernel driver of the device."
+	NetworkDeviceSelectorDoc.Fields[2].Comments[encoder.LineComment] = "Kernel driver of the device."
+
+	NetworkDeviceSelectorDoc.Fields[2].AddExample("", "virtio_net")
+	NetworkDeviceSelectorDoc.Fields[3].Name = "speed"
+	NetworkDeviceSelectorDoc.Fields[3].Type = "uint32"
+	NetworkDeviceSelectorDoc.Fields[3].Note = ""
+	NetworkDeviceSelectorDoc.Fields[3].Description = "Link speed in Mbit/s.\nThe speed is reported only for the links which are up, so the selector might not match right after the boot."
+	NetworkDeviceSelectorDoc.Fields[3].Comments[encoder.LineComment] = "Link speed in Mbit/s."
+
+	NetworkDeviceSelectorDoc.Fields[3].AddExample("", 10000)
 
 	DHCPOptionsDoc.Type = "DHCPOptions"
 	DHCPOptionsDoc.Comments[encoder.LineComment] = "DHCPOptions contains options for configuring the DHCP settings for a given interface."
 	DHCPOptionsDoc.Description = "DHCPOptions contains options for configuring the DHCP settings for a given interface."
 
 	DHCPOptionsDoc.AddExample("", networkConfigDHCPOptionsExample)
+
+	DHCPOptionsDoc.AddExample("DHCPv6 and SLAAC example", networkConfigDHCPv6OptionsExample)
 	DHCPOptionsDoc.AppearsIn = []encoder.Appearance{
 		{
 			TypeName:  "Device",
 			FieldName: "dhcpOptions",
 		},
 	}
-	DHCPOptionsDoc.Fields = make([]encoder.Doc, 3)
+	DHCPOptionsDoc.Fields = make([]encoder.Doc, 7)
 	DHCPOptionsDoc.Fields[0].Name = "routeMetric"
 	DHCPOptionsDoc.Fields[0].Type = "uint32"
 	DHCPOptionsDoc.Fields[0].Note = ""
@@ -1345,6 +2387,26 @@ func init() {
 	DHCPOptionsDoc.Fields[2].Note = ""
 	DHCPOptionsDoc.Fields[2].Description = "Enables DHCPv6 protocol for the interface (default is disabled)."
 	DHCPOptionsDoc.Fields[2].Comments[encoder.LineComment] = "Enables DHCPv6 protocol for the interface (default is disabled)."
+	DHCPOptionsDoc.Fields[3].Name = "ignoreDefaultRoutes"
+	DHCPOptionsDoc.Fields[3].Type = "bool"
+	DHCPOptionsDoc.Fields[3].Note = ""
+	DHCPOptionsDoc.Fields[3].Description = "Ignore default routes received via DHCP (and IPv6 router advertisements, if `slaac` is enabled)."
+	DHCPOptionsDoc.Fields[3].Comments[encoder.LineComment] = "Ignore default routes received via DHCP (and IPv6 router advertisements, if `slaac` is enabled)."
+	DHCPOptionsDoc.Fields[4].Name = "ignoreDNS"
+	DHCPOptionsDoc.Fields[4].Type = "bool"
+	DHCPOptionsDoc.Fields[4].Note = ""
+	DHCPOptionsDoc.Fields[4].Description = "Ignore DNS servers received via DHCP."
+	DHCPOptionsDoc.Fields[4].Comments[encoder.LineComment] = "Ignore DNS servers received via DHCP."
+	DHCPOptionsDoc.Fields[5].Name = "duidv6"
+	DHCPOptionsDoc.Fields[5].Type = "string"
+	DHCPOptionsDoc.Fields[5].Note = ""
+	DHCPOptionsDoc.Fields[5].Description = "DHCPv6 client DUID (hex encoded), by default DUID is generated from the link hardware address."
+	DHCPOptionsDoc.Fields[5].Comments[encoder.LineComment] = "DHCPv6 client DUID (hex encoded), by default DUID is generated from the link hardware address."
+	DHCPOptionsDoc.Fields[6].Name = "slaac"
+	DHCPOptionsDoc.Fields[6].Type = "bool"
+	DHCPOptionsDoc.Fields[6].Note = ""
+	DHCPOptionsDoc.Fields[6].Description = "Enables IPv6 stateless address autoconfiguration (SLAAC) for the interface (default is disabled).\nRouter advertisements are accepted even if IP forwarding is enabled."
+	DHCPOptionsDoc.Fields[6].Comments[encoder.LineComment] = "Enables IPv6 stateless address autoconfiguration (SLAAC) for the interface (default is disabled)."
 
 	DeviceWireguardConfigDoc.Type = "DeviceWireguardConfig"
 	DeviceWireguardConfigDoc.Comments[encoder.LineComment] = "DeviceWireguardConfig contains settings for configuring Wireguard network interface."
@@ -1353,33 +2415,40 @@ func init() {
 	DeviceWireguardConfigDoc.AddExample("wireguard server example", networkConfigWireguardHostExample)
 
 	DeviceWireguardConfigDoc.AddExample("wireguard peer example", networkConfigWireguardPeerExample)
+
+	DeviceWireguardConfigDoc.AddExample("wireguard with generated private key example", networkConfigWireguardGeneratedKeyExample)
 	DeviceWireguardConfigDoc.AppearsIn = []encoder.Appearance{
 		{
 			TypeName:  "Device",
 			FieldName: "wireguard",
 		},
 	}
-	DeviceWireguardConfigDoc.Fields = make([]encoder.Doc, 4)
+	DeviceWireguardConfigDoc.Fields = make([]encoder.Doc, 5)
 	DeviceWireguardConfigDoc.Fields[0].Name = "privateKey"
 	DeviceWireguardConfigDoc.Fields[0].Type = "string"
 	DeviceWireguardConfigDoc.Fields[0].Note = ""
 	DeviceWireguardConfigDoc.Fields[0].Description = "Specifies a private key configuration (base64 encoded).\nCan be generated by `wg genkey`."
 	DeviceWireguardConfigDoc.Fields[0].Comments[encoder.LineComment] = "Specifies a private key configuration (base64 encoded)."
-	DeviceWireguardConfigDoc.Fields[1].Name = "listenPort"
-	DeviceWireguardConfigDoc.Fields[1].Type = "int"
+	DeviceWireguardConfigDoc.Fields[1].Name = "generatePrivateKey"
+	DeviceWireguardConfigDoc.Fields[1].Type = "bool"
 	DeviceWireguardConfigDoc.Fields[1].Note = ""
-	DeviceWireguardConfigDoc.Fields[1].Description = "Specifies a device's listening port."
-	DeviceWireguardConfigDoc.Fields[1].Comments[encoder.LineComment] = "Specifies a device's listening port."
-	DeviceWireguardConfigDoc.Fields[2].Name = "firewallMark"
+	DeviceWireguardConfigDoc.Fields[1].Description = "Generate the private key on the node instead of specifying it in the config.\nThe key is generated on the first boot and persisted in the STATE partition,\npublic key of the node is reported by networkd in the logs."
+	DeviceWireguardConfigDoc.Fields[1].Comments[encoder.LineComment] = "Generate the private key on the node instead of specifying it in the config."
+	DeviceWireguardConfigDoc.Fields[2].Name = "listenPort"
 	DeviceWireguardConfigDoc.Fields[2].Type = "int"
 	DeviceWireguardConfigDoc.Fields[2].Note = ""
-	DeviceWireguardConfigDoc.Fields[2].Description = "Specifies a device's firewall mark."
-	DeviceWireguardConfigDoc.Fields[2].Comments[encoder.LineComment] = "Specifies a device's firewall mark."
-	DeviceWireguardConfigDoc.Fields[3].Name = "peers"
-	DeviceWireguardConfigDoc.Fields[3].Type = "[]DeviceWireguardPeer"
+	DeviceWireguardConfigDoc.Fields[2].Description = "Specifies a device's listening port."
+	DeviceWireguardConfigDoc.Fields[2].Comments[encoder.LineComment] = "Specifies a device's listening port."
+	DeviceWireguardConfigDoc.Fields[3].Name = "firewallMark"
+	DeviceWireguardConfigDoc.Fields[3].Type = "int"
 	DeviceWireguardConfigDoc.Fields[3].Note = ""
-	DeviceWireguardConfigDoc.Fields[3].Description = "Specifies a list of peer configurations to apply to a device."
-	DeviceWireguardConfigDoc.Fields[3].Comments[encoder.LineComment] = "Specifies a list of peer configurations to apply to a device."
+	DeviceWireguardConfigDoc.Fields[3].Description = "Specifies a device's firewall mark."
+	DeviceWireguardConfigDoc.Fields[3].Comments[encoder.LineComment] = "Specifies a device's firewall mark."
+	DeviceWireguardConfigDoc.Fields[4].Name = "peers"
+	DeviceWireguardConfigDoc.Fields[4].Type = "[]DeviceWireguardPeer"
+	DeviceWireguardConfigDoc.Fields[4].Note = ""
+	DeviceWireguardConfigDoc.Fields[4].Description = "Specifies a list of peer configurations to apply to a device."
+	DeviceWireguardConfigDoc.Fields[4].Comments[encoder.LineComment] = "Specifies a list of peer configurations to apply to a device."
 
 	DeviceWireguardPeerDoc.Type = "DeviceWireguardPeer"
 	DeviceWireguardPeerDoc.Comments[encoder.LineComment] = "DeviceWireguardPeer a WireGuard device peer configuration."
@@ -1417,18 +2486,75 @@ func init() {
 	DeviceVIPConfigDoc.Description = "DeviceVIPConfig contains settings for configuring a Virtual Shared IP on an interface."
 
 	DeviceVIPConfigDoc.AddExample("", networkConfigVIPLayer2Example)
+
+	DeviceVIPConfigDoc.AddExample("", networkConfigVIPEquinixMetalExample)
+
+	DeviceVIPConfigDoc.AddExample("", networkConfigVIPEquinixMetalBGPExample)
+
+	DeviceVIPConfigDoc.AddExample("", networkConfigVIPHCloudExample)
 	DeviceVIPConfigDoc.AppearsIn = []encoder.Appearance{
 		{
 			TypeName:  "Device",
 			FieldName: "vip",
 		},
+		{
+			TypeName:  "Vlan",
+			FieldName: "vip",
+		},
 	}
-	DeviceVIPConfigDoc.Fields = make([]encoder.Doc, 1)
+	DeviceVIPConfigDoc.Fields = make([]encoder.Doc, 3)
 	DeviceVIPConfigDoc.Fields[0].Name = "ip"
 	DeviceVIPConfigDoc.Fields[0].Type = "string"
 	DeviceVIPConfigDoc.Fields[0].Note = ""
 	DeviceVIPConfigDoc.Fields[0].Description = "Specifies the IP address to be used."
 	DeviceVIPConfigDoc.Fields[0].Comments[encoder.LineComment] = "Specifies the IP address to be used."
+	DeviceVIPConfigDoc.Fields[1].Name = "equinixMetal"
+	DeviceVIPConfigDoc.Fields[1].Type = "VIPEquinixMetalConfig"
+	DeviceVIPConfigDoc.Fields[1].Note = ""
+	DeviceVIPConfigDoc.Fields[1].Description = "Assign the IP as Equinix Metal elastic IP via the Equinix Metal API.\nThe elastic IP should be reserved in the project of the nodes."
+	DeviceVIPConfigDoc.Fields[1].Comments[encoder.LineComment] = "Assign the IP as Equinix Metal elastic IP via the Equinix Metal API."
+	DeviceVIPConfigDoc.Fields[2].Name = "hcloud"
+	DeviceVIPConfigDoc.Fields[2].Type = "VIPHCloudConfig"
+	DeviceVIPConfigDoc.Fields[2].Note = ""
+	DeviceVIPConfigDoc.Fields[2].Description = "Assign the IP as Hetzner Cloud floating IP via the Hetzner Cloud API.\nThe floating IP should be created in the project of the nodes."
+	DeviceVIPConfigDoc.Fields[2].Comments[encoder.LineComment] = "Assign the IP as Hetzner Cloud floating IP via the Hetzner Cloud API."
+
+	VIPEquinixMetalConfigDoc.Type = "VIPEquinixMetalConfig"
+	VIPEquinixMetalConfigDoc.Comments[encoder.LineComment] = "VIPEquinixMetalConfig contains settings for Equinix Metal VIP management."
+	VIPEquinixMetalConfigDoc.Description = "VIPEquinixMetalConfig contains settings for Equinix Metal VIP management."
+	VIPEquinixMetalConfigDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "DeviceVIPConfig",
+			FieldName: "equinixMetal",
+		},
+	}
+	VIPEquinixMetalConfigDoc.Fields = make([]encoder.Doc, 2)
+	VIPEquinixMetalConfigDoc.Fields[0].Name = "apiToken"
+	VIPEquinixMetalConfigDoc.Fields[0].Type = "string"
+	VIPEquinixMetalConfigDoc.Fields[0].Note = ""
+	VIPEquinixMetalConfigDoc.Fields[0].Description = "Specifies the Equinix Metal API Token."
+	VIPEquinixMetalConfigDoc.Fields[0].Comments[encoder.LineComment] = "Specifies the Equinix Metal API Token."
+	VIPEquinixMetalConfigDoc.Fields[1].Name = "bgp"
+	VIPEquinixMetalConfigDoc.Fields[1].Type = "bool"
+	VIPEquinixMetalConfigDoc.Fields[1].Note = ""
+	VIPEquinixMetalConfigDoc.Fields[1].Description = "Announce the elastic IP via BGP instead of assigning it via the Equinix Metal API.\nLocal BGP should be enabled for the project and the node, BGP peering details are read from the metadata."
+	VIPEquinixMetalConfigDoc.Fields[1].Comments[encoder.LineComment] = "Announce the elastic IP via BGP instead of assigning it via the Equinix Metal API."
+
+	VIPHCloudConfigDoc.Type = "VIPHCloudConfig"
+	VIPHCloudConfigDoc.Comments[encoder.LineComment] = "VIPHCloudConfig contains settings for Hetzner Cloud VIP management."
+	VIPHCloudConfigDoc.Description = "VIPHCloudConfig contains settings for Hetzner Cloud VIP management."
+	VIPHCloudConfigDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "DeviceVIPConfig",
+			FieldName: "hcloud",
+		},
+	}
+	VIPHCloudConfigDoc.Fields = make([]encoder.Doc, 1)
+	VIPHCloudConfigDoc.Fields[0].Name = "apiToken"
+	VIPHCloudConfigDoc.Fields[0].Type = "string"
+	VIPHCloudConfigDoc.Fields[0].Note = ""
+	VIPHCloudConfigDoc.Fields[0].Description = "Specifies the Hetzner Cloud API Token."
+	VIPHCloudConfigDoc.Fields[0].Comments[encoder.LineComment] = "Specifies the Hetzner Cloud API Token."
 
 	BondDoc.Type = "Bond"
 	BondDoc.Comments[encoder.LineComment] = "Bond contains the various options for configuring a bonded interface."
@@ -1578,42 +2704,124 @@ func init() {
 	BondDoc.Fields[26].Description = "A bond option.\nPlease see the official kernel documentation."
 	BondDoc.Fields[26].Comments[encoder.LineComment] = "A bond option."
 
+	BridgeDoc.Type = "Bridge"
+	BridgeDoc.Comments[encoder.LineComment] = "Bridge contains the options for configuring a bridged interface."
+	BridgeDoc.Description = "Bridge contains the options for configuring a bridged interface."
+
+	BridgeDoc.AddExample("", networkConfigBridgeExample)
+	BridgeDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "Device",
+			FieldName: "bridge",
+		},
+	}
+	BridgeDoc.Fields = make([]encoder.Doc, 3)
+	BridgeDoc.Fields[0].Name = "interfaces"
+	BridgeDoc.Fields[0].Type = "[]string"
+	BridgeDoc.Fields[0].Note = ""
+	BridgeDoc.Fields[0].Description = "The interfaces that make up the bridge."
+	BridgeDoc.Fields[0].Comments[encoder.LineComment] = "The interfaces that make up the bridge."
+	BridgeDoc.Fields[1].Name = "stp"
+	BridgeDoc.Fields[1].Type = "STP"
+	BridgeDoc.Fields[1].Note = ""
+	BridgeDoc.Fields[1].Description = "Spanning Tree Protocol (STP) settings of the bridge.\nSTP is disabled by default."
+	BridgeDoc.Fields[1].Comments[encoder.LineComment] = "Spanning Tree Protocol (STP) settings of the bridge."
+	BridgeDoc.Fields[2].Name = "vlan"
+	BridgeDoc.Fields[2].Type = "BridgeVLAN"
+	BridgeDoc.Fields[2].Note = ""
+	BridgeDoc.Fields[2].Description = "VLAN settings of the bridge."
+	BridgeDoc.Fields[2].Comments[encoder.LineComment] = "VLAN settings of the bridge."
+
+	BridgeDoc.Fields[2].AddExample("", networkConfigBridgeVLANExample)
+
+	STPDoc.Type = "STP"
+	STPDoc.Comments[encoder.LineComment] = "STP contains the Spanning Tree Protocol settings of a bridge."
+	STPDoc.Description = "STP contains the Spanning Tree Protocol settings of a bridge."
+	STPDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "Bridge",
+			FieldName: "stp",
+		},
+	}
+	STPDoc.Fields = make([]encoder.Doc, 1)
+	STPDoc.Fields[0].Name = "enabled"
+	STPDoc.Fields[0].Type = "bool"
+	STPDoc.Fields[0].Note = ""
+	STPDoc.Fields[0].Description = "Indicates if STP should be enabled on the bridge."
+	STPDoc.Fields[0].Comments[encoder.LineComment] = "Indicates if STP should be enabled on the bridge."
+
+	BridgeVLANDoc.Type = "BridgeVLAN"
+	BridgeVLANDoc.Comments[encoder.LineComment] = "BridgeVLAN contains the VLAN settings of a bridge."
+	BridgeVLANDoc.Description = "BridgeVLAN contains the VLAN settings of a bridge."
+
+	BridgeVLANDoc.AddExample("", networkConfigBridgeVLANExample)
+	BridgeVLANDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "Bridge",
+			FieldName: "vlan",
+		},
+	}
+	BridgeVLANDoc.Fields = make([]encoder.Doc, 1)
+	BridgeVLANDoc.Fields[0].Name = "vlanFiltering"
+	BridgeVLANDoc.Fields[0].Type = "bool"
+	BridgeVLANDoc.Fields[0].Note = ""
+	BridgeVLANDoc.Fields[0].Description = "Indicates if VLAN filtering should be enabled on the bridge.\nWith VLAN filtering enabled, the bridge only forwards frames according to the VLAN membership of the ports."
+	BridgeVLANDoc.Fields[0].Comments[encoder.LineComment] = "Indicates if VLAN filtering should be enabled on the bridge."
+
 	VlanDoc.Type = "Vlan"
 	VlanDoc.Comments[encoder.LineComment] = "Vlan represents vlan settings for a device."
 	VlanDoc.Description = "Vlan represents vlan settings for a device."
+
+	VlanDoc.AddExample("", networkConfigVlansExample)
 	VlanDoc.AppearsIn = []encoder.Appearance{
 		{
 			TypeName:  "Device",
 			FieldName: "vlans",
 		},
 	}
-	VlanDoc.Fields = make([]encoder.Doc, 4)
+	VlanDoc.Fields = make([]encoder.Doc, 6)
 	VlanDoc.Fields[0].Name = "cidr"
 	VlanDoc.Fields[0].Type = "string"
 	VlanDoc.Fields[0].Note = ""
 	VlanDoc.Fields[0].Description = "The CIDR to use."
 	VlanDoc.Fields[0].Comments[encoder.LineComment] = "The CIDR to use."
-	VlanDoc.Fields[1].Name = "routes"
-	VlanDoc.Fields[1].Type = "[]Route"
+	VlanDoc.Fields[0].Deprecated = &encoder.Deprecation{
+		Replacement: "addresses",
+		Removal:     "v0.12",
+	}
+	VlanDoc.Fields[1].Name = "addresses"
+	VlanDoc.Fields[1].Type = "[]string"
 	VlanDoc.Fields[1].Note = ""
-	VlanDoc.Fields[1].Description = "A list of routes associated with the VLAN."
-	VlanDoc.Fields[1].Comments[encoder.LineComment] = "A list of routes associated with the VLAN."
-	VlanDoc.Fields[2].Name = "dhcp"
-	VlanDoc.Fields[2].Type = "bool"
+	VlanDoc.Fields[1].Description = "A list of static addresses (in CIDR notation) to assign to the VLAN interface.\nAddresses can't be combined with `dhcp`."
+	VlanDoc.Fields[1].Comments[encoder.LineComment] = "A list of static addresses (in CIDR notation) to assign to the VLAN interface."
+	VlanDoc.Fields[2].Name = "routes"
+	VlanDoc.Fields[2].Type = "[]Route"
 	VlanDoc.Fields[2].Note = ""
-	VlanDoc.Fields[2].Description = "Indicates if DHCP should be used."
-	VlanDoc.Fields[2].Comments[encoder.LineComment] = "Indicates if DHCP should be used."
-	VlanDoc.Fields[3].Name = "vlanId"
-	VlanDoc.Fields[3].Type = "uint16"
+	VlanDoc.Fields[2].Description = "A list of routes associated with the VLAN."
+	VlanDoc.Fields[2].Comments[encoder.LineComment] = "A list of routes associated with the VLAN."
+	VlanDoc.Fields[3].Name = "dhcp"
+	VlanDoc.Fields[3].Type = "bool"
 	VlanDoc.Fields[3].Note = ""
-	VlanDoc.Fields[3].Description = "The VLAN's ID."
-	VlanDoc.Fields[3].Comments[encoder.LineComment] = "The VLAN's ID."
+	VlanDoc.Fields[3].Description = "Indicates if DHCP should be used."
+	VlanDoc.Fields[3].Comments[encoder.LineComment] = "Indicates if DHCP should be used."
+	VlanDoc.Fields[4].Name = "vlanId"
+	VlanDoc.Fields[4].Type = "uint16"
+	VlanDoc.Fields[4].Note = ""
+	VlanDoc.Fields[4].Description = "The VLAN's ID."
+	VlanDoc.Fields[4].Comments[encoder.LineComment] = "The VLAN's ID."
+	VlanDoc.Fields[5].Name = "vip"
+	VlanDoc.Fields[5].Type = "DeviceVIPConfig"
+	VlanDoc.Fields[5].Note = ""
+	VlanDoc.Fields[5].Description = "Virtual (shared) IP address configuration for the VLAN interface."
+	VlanDoc.Fields[5].Comments[encoder.LineComment] = "Virtual (shared) IP address configuration for the VLAN interface."
 
 	RouteDoc.Type = "Route"
 	RouteDoc.Comments[encoder.LineComment] = "Route represents a network route."
 	RouteDoc.Description = "Route represents a network route."
 
 	RouteDoc.AddExample("", networkConfigRoutesExample)
+
+	RouteDoc.AddExample("policy routing example", networkConfigPolicyRoutesExample)
 	RouteDoc.AppearsIn = []encoder.Appearance{
 		{
 			TypeName:  "Device",
@@ -1624,7 +2832,7 @@ func init() {
 			FieldName: "routes",
 		},
 	}
-	RouteDoc.Fields = make([]encoder.Doc, 3)
+	RouteDoc.Fields = make([]encoder.Doc, 6)
 	RouteDoc.Fields[0].Name = "network"
 	RouteDoc.Fields[0].Type = "string"
 	RouteDoc.Fields[0].Note = ""
@@ -1640,6 +2848,204 @@ func init() {
 	RouteDoc.Fields[2].Note = ""
 	RouteDoc.Fields[2].Description = "The optional metric for the route."
 	RouteDoc.Fields[2].Comments[encoder.LineComment] = "The optional metric for the route."
+	RouteDoc.Fields[3].Name = "source"
+	RouteDoc.Fields[3].Type = "string"
+	RouteDoc.Fields[3].Note = ""
+	RouteDoc.Fields[3].Description = "The optional source address hint for the route (preferred source address of the outgoing traffic)."
+	RouteDoc.Fields[3].Comments[encoder.LineComment] = "The optional source address hint for the route (preferred source address of the outgoing traffic)."
+	RouteDoc.Fields[4].Name = "mtu"
+	RouteDoc.Fields[4].Type = "uint32"
+	RouteDoc.Fields[4].Note = ""
+	RouteDoc.Fields[4].Description = "The optional MTU for the route."
+	RouteDoc.Fields[4].Comments[encoder.LineComment] = "The optional MTU for the route."
+	RouteDoc.Fields[5].Name = "table"
+	RouteDoc.Fields[5].Type = "uint32"
+	RouteDoc.Fields[5].Note = ""
+	RouteDoc.Fields[5].Description = "The optional routing table for the route.\nDefault is the main table (254), other tables are used with policy routing rules."
+	RouteDoc.Fields[5].Comments[encoder.LineComment] = "The optional routing table for the route."
+
+	KubeSpanDoc.Type = "KubeSpan"
+	KubeSpanDoc.Comments[encoder.LineComment] = "KubeSpan struct describes KubeSpan configuration."
+	KubeSpanDoc.Description = "KubeSpan struct describes KubeSpan configuration."
+
+	KubeSpanDoc.AddExample("", networkKubeSpanExample)
+	KubeSpanDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "NetworkConfig",
+			FieldName: "kubespan",
+		},
+	}
+	KubeSpanDoc.Fields = make([]encoder.Doc, 2)
+	KubeSpanDoc.Fields[0].Name = "enabled"
+	KubeSpanDoc.Fields[0].Type = "bool"
+	KubeSpanDoc.Fields[0].Note = ""
+	KubeSpanDoc.Fields[0].Description = "Enable the KubeSpan feature."
+	KubeSpanDoc.Fields[0].Comments[encoder.LineComment] = "Enable the KubeSpan feature."
+	KubeSpanDoc.Fields[1].Name = "discoveryEndpoint"
+	KubeSpanDoc.Fields[1].Type = "string"
+	KubeSpanDoc.Fields[1].Note = ""
+	KubeSpanDoc.Fields[1].Description = "Discovery service endpoint used to exchange the node information, required if KubeSpan is enabled.\n\nThe discovery service should implement the protocol described in the KubeSpan guide."
+	KubeSpanDoc.Fields[1].Comments[encoder.LineComment] = "Discovery service endpoint used to exchange the node information, required if KubeSpan is enabled."
+
+	KubeSpanDoc.Fields[1].AddExample("", "https://discovery.example.com/")
+
+	SideroLinkDoc.Type = "SideroLink"
+	SideroLinkDoc.Comments[encoder.LineComment] = "SideroLink struct describes SideroLink configuration."
+	SideroLinkDoc.Description = "SideroLink struct describes SideroLink configuration."
+
+	SideroLinkDoc.AddExample("", networkSideroLinkExample)
+	SideroLinkDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "NetworkConfig",
+			FieldName: "sideroLink",
+		},
+	}
+	SideroLinkDoc.Fields = make([]encoder.Doc, 1)
+	SideroLinkDoc.Fields[0].Name = "apiUrl"
+	SideroLinkDoc.Fields[0].Type = "string"
+	SideroLinkDoc.Fields[0].Note = ""
+	SideroLinkDoc.Fields[0].Description = "Provisioning API URL of the management server.\nJoin token is passed as the `jointoken` query parameter."
+	SideroLinkDoc.Fields[0].Comments[encoder.LineComment] = "Provisioning API URL of the management server."
+
+	SideroLinkDoc.Fields[0].AddExample("", "https://siderolink.example.com:8099/?jointoken=secret")
+
+	FirewallConfigDoc.Type = "FirewallConfig"
+	FirewallConfigDoc.Comments[encoder.LineComment] = "FirewallConfig describes the ingress firewall."
+	FirewallConfigDoc.Description = "FirewallConfig describes the ingress firewall."
+
+	FirewallConfigDoc.AddExample("", networkFirewallExample)
+	FirewallConfigDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "NetworkConfig",
+			FieldName: "firewall",
+		},
+	}
+	FirewallConfigDoc.Fields = make([]encoder.Doc, 2)
+	FirewallConfigDoc.Fields[0].Name = "defaultAction"
+	FirewallConfigDoc.Fields[0].Type = "string"
+	FirewallConfigDoc.Fields[0].Note = ""
+	FirewallConfigDoc.Fields[0].Description = "Action applied to the ingress traffic not matched by any rule.\n\nWith `block`, established connections, loopback traffic and ICMP are still accepted."
+	FirewallConfigDoc.Fields[0].Comments[encoder.LineComment] = "Action applied to the ingress traffic not matched by any rule."
+	FirewallConfigDoc.Fields[0].Values = []string{
+		"accept",
+		"block",
+	}
+	FirewallConfigDoc.Fields[1].Name = "rules"
+	FirewallConfigDoc.Fields[1].Type = "[]FirewallRule"
+	FirewallConfigDoc.Fields[1].Note = ""
+	FirewallConfigDoc.Fields[1].Description = "Rules accepting the ingress traffic to the node ports."
+	FirewallConfigDoc.Fields[1].Comments[encoder.LineComment] = "Rules accepting the ingress traffic to the node ports."
+
+	FirewallRuleDoc.Type = "FirewallRule"
+	FirewallRuleDoc.Comments[encoder.LineComment] = "FirewallRule describes the ports and the source networks allowed to access them."
+	FirewallRuleDoc.Description = "FirewallRule describes the ports and the source networks allowed to access them."
+	FirewallRuleDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "FirewallConfig",
+			FieldName: "rules",
+		},
+	}
+	FirewallRuleDoc.Fields = make([]encoder.Doc, 3)
+	FirewallRuleDoc.Fields[0].Name = "name"
+	FirewallRuleDoc.Fields[0].Type = "string"
+	FirewallRuleDoc.Fields[0].Note = ""
+	FirewallRuleDoc.Fields[0].Description = "Name of the rule."
+	FirewallRuleDoc.Fields[0].Comments[encoder.LineComment] = "Name of the rule."
+	FirewallRuleDoc.Fields[1].Name = "portSelector"
+	FirewallRuleDoc.Fields[1].Type = "FirewallPortSelector"
+	FirewallRuleDoc.Fields[1].Note = ""
+	FirewallRuleDoc.Fields[1].Description = "Ports and protocol matched by the rule."
+	FirewallRuleDoc.Fields[1].Comments[encoder.LineComment] = "Ports and protocol matched by the rule."
+
+	FirewallRuleDoc.Fields[1].AddExample("", networkFirewallPortSelectorExample)
+	FirewallRuleDoc.Fields[2].Name = "ingress"
+	FirewallRuleDoc.Fields[2].Type = "[]FirewallIngressRule"
+	FirewallRuleDoc.Fields[2].Note = ""
+	FirewallRuleDoc.Fields[2].Description = "Source networks allowed to access the ports."
+	FirewallRuleDoc.Fields[2].Comments[encoder.LineComment] = "Source networks allowed to access the ports."
+
+	FirewallPortSelectorDoc.Type = "FirewallPortSelector"
+	FirewallPortSelectorDoc.Comments[encoder.LineComment] = "FirewallPortSelector selects the ports by the number and the protocol."
+	FirewallPortSelectorDoc.Description = "FirewallPortSelector selects the ports by the number and the protocol."
+
+	FirewallPortSelectorDoc.AddExample("", networkFirewallPortSelectorExample)
+	FirewallPortSelectorDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "FirewallRule",
+			FieldName: "portSelector",
+		},
+	}
+	FirewallPortSelectorDoc.Fields = make([]encoder.Doc, 2)
+	FirewallPortSelectorDoc.Fields[0].Name = "ports"
+	FirewallPortSelectorDoc.Fields[0].Type = "[]string"
+	FirewallPortSelectorDoc.Fields[0].Note = ""
+	FirewallPortSelectorDoc.Fields[0].Description = "List of ports or port ranges (e.g. `50000`, `2379-2380`)."
+	FirewallPortSelectorDoc.Fields[0].Comments[encoder.LineComment] = "List of ports or port ranges (e.g. `50000`, `2379-2380`)."
+	FirewallPortSelectorDoc.Fields[1].Name = "protocol"
+	FirewallPortSelectorDoc.Fields[1].Type = "string"
+	FirewallPortSelectorDoc.Fields[1].Note = ""
+	FirewallPortSelectorDoc.Fields[1].Description = "Protocol of the ports."
+	FirewallPortSelectorDoc.Fields[1].Comments[encoder.LineComment] = "Protocol of the ports."
+	FirewallPortSelectorDoc.Fields[1].Values = []string{
+		"tcp",
+		"udp",
+	}
+
+	FirewallIngressRuleDoc.Type = "FirewallIngressRule"
+	FirewallIngressRuleDoc.Comments[encoder.LineComment] = "FirewallIngressRule describes the source network allowed by the rule."
+	FirewallIngressRuleDoc.Description = "FirewallIngressRule describes the source network allowed by the rule."
+	FirewallIngressRuleDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "FirewallRule",
+			FieldName: "ingress",
+		},
+	}
+	FirewallIngressRuleDoc.Fields = make([]encoder.Doc, 1)
+	FirewallIngressRuleDoc.Fields[0].Name = "subnet"
+	FirewallIngressRuleDoc.Fields[0].Type = "string"
+	FirewallIngressRuleDoc.Fields[0].Note = ""
+	FirewallIngressRuleDoc.Fields[0].Description = "Source network in CIDR notation."
+	FirewallIngressRuleDoc.Fields[0].Comments[encoder.LineComment] = "Source network in CIDR notation."
+
+	FirewallIngressRuleDoc.Fields[0].AddExample("", "10.0.0.0/8")
+
+	RoutingRuleDoc.Type = "RoutingRule"
+	RoutingRuleDoc.Comments[encoder.LineComment] = "RoutingRule represents a policy routing rule."
+	RoutingRuleDoc.Description = "RoutingRule represents a policy routing rule."
+
+	RoutingRuleDoc.AddExample("", networkConfigRulesExample)
+	RoutingRuleDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "NetworkConfig",
+			FieldName: "rules",
+		},
+	}
+	RoutingRuleDoc.Fields = make([]encoder.Doc, 5)
+	RoutingRuleDoc.Fields[0].Name = "from"
+	RoutingRuleDoc.Fields[0].Type = "string"
+	RoutingRuleDoc.Fields[0].Note = ""
+	RoutingRuleDoc.Fields[0].Description = "Source network (in CIDR notation) of the traffic matched by the rule."
+	RoutingRuleDoc.Fields[0].Comments[encoder.LineComment] = "Source network (in CIDR notation) of the traffic matched by the rule."
+	RoutingRuleDoc.Fields[1].Name = "to"
+	RoutingRuleDoc.Fields[1].Type = "string"
+	RoutingRuleDoc.Fields[1].Note = ""
+	RoutingRuleDoc.Fields[1].Description = "Destination network (in CIDR notation) of the traffic matched by the rule."
+	RoutingRuleDoc.Fields[1].Comments[encoder.LineComment] = "Destination network (in CIDR notation) of the traffic matched by the rule."
+	RoutingRuleDoc.Fields[2].Name = "fwMark"
+	RoutingRuleDoc.Fields[2].Type = "uint32"
+	RoutingRuleDoc.Fields[2].Note = ""
+	RoutingRuleDoc.Fields[2].Description = "Firewall mark of the traffic matched by the rule."
+	RoutingRuleDoc.Fields[2].Comments[encoder.LineComment] = "Firewall mark of the traffic matched by the rule."
+	RoutingRuleDoc.Fields[3].Name = "table"
+	RoutingRuleDoc.Fields[3].Type = "uint32"
+	RoutingRuleDoc.Fields[3].Note = ""
+	RoutingRuleDoc.Fields[3].Description = "Routing table to look up for the traffic matched by the rule."
+	RoutingRuleDoc.Fields[3].Comments[encoder.LineComment] = "Routing table to look up for the traffic matched by the rule."
+	RoutingRuleDoc.Fields[4].Name = "priority"
+	RoutingRuleDoc.Fields[4].Type = "uint32"
+	RoutingRuleDoc.Fields[4].Note = ""
+	RoutingRuleDoc.Fields[4].Description = "Priority of the rule, rules with lower priority are evaluated first.\nIf not set, priority is picked by the kernel."
+	RoutingRuleDoc.Fields[4].Comments[encoder.LineComment] = "Priority of the rule, rules with lower priority are evaluated first."
 
 	RegistryMirrorConfigDoc.Type = "RegistryMirrorConfig"
 	RegistryMirrorConfigDoc.Comments[encoder.LineComment] = "RegistryMirrorConfig represents mirror configuration for a registry."
@@ -1829,6 +3235,14 @@ func (_ ClusterConfig) Doc() *encoder.Doc {
 	return &ClusterConfigDoc
 }
 
+func (_ JoinPolicyConfig) Doc() *encoder.Doc {
+	return &JoinPolicyConfigDoc
+}
+
+func (_ JoinPolicyWebhookConfig) Doc() *encoder.Doc {
+	return &JoinPolicyWebhookConfigDoc
+}
+
 func (_ KubeletConfig) Doc() *encoder.Doc {
 	return &KubeletConfigDoc
 }
@@ -1849,10 +3263,78 @@ func (_ InstallDiskSelector) Doc() *encoder.Doc {
 	return &InstallDiskSelectorDoc
 }
 
+func (_ InstallConsole) Doc() *encoder.Doc {
+	return &InstallConsoleDoc
+}
+
+func (_ InstallSecureBootConfig) Doc() *encoder.Doc {
+	return &InstallSecureBootConfigDoc
+}
+
 func (_ TimeConfig) Doc() *encoder.Doc {
 	return &TimeConfigDoc
 }
 
+func (_ VirtualizationConfig) Doc() *encoder.Doc {
+	return &VirtualizationConfigDoc
+}
+
+func (_ HealthzConfig) Doc() *encoder.Doc {
+	return &HealthzConfigDoc
+}
+
+func (_ MetricsConfig) Doc() *encoder.Doc {
+	return &MetricsConfigDoc
+}
+
+func (_ WatchdogConfig) Doc() *encoder.Doc {
+	return &WatchdogConfigDoc
+}
+
+func (_ CrashDumpsConfig) Doc() *encoder.Doc {
+	return &CrashDumpsConfigDoc
+}
+
+func (_ RamoopsConfig) Doc() *encoder.Doc {
+	return &RamoopsConfigDoc
+}
+
+func (_ ImageCacheConfig) Doc() *encoder.Doc {
+	return &ImageCacheConfigDoc
+}
+
+func (_ FeaturesConfig) Doc() *encoder.Doc {
+	return &FeaturesConfigDoc
+}
+
+func (_ CRIConfig) Doc() *encoder.Doc {
+	return &CRIConfigDoc
+}
+
+func (_ NVIDIAConfig) Doc() *encoder.Doc {
+	return &NVIDIAConfigDoc
+}
+
+func (_ ReconcileConfig) Doc() *encoder.Doc {
+	return &ReconcileConfigDoc
+}
+
+func (_ MaintenanceWindowConfig) Doc() *encoder.Doc {
+	return &MaintenanceWindowConfigDoc
+}
+
+func (_ RegistrationConfig) Doc() *encoder.Doc {
+	return &RegistrationConfigDoc
+}
+
+func (_ IdentityConfig) Doc() *encoder.Doc {
+	return &IdentityConfigDoc
+}
+
+func (_ IdentityKMSConfig) Doc() *encoder.Doc {
+	return &IdentityKMSConfigDoc
+}
+
 func (_ RegistriesConfig) Doc() *encoder.Doc {
 	return &RegistriesConfigDoc
 }
@@ -1909,6 +3391,26 @@ func (_ AdminKubeconfigConfig) Doc() *encoder.Doc {
 	return &AdminKubeconfigConfigDoc
 }
 
+func (_ AESCBCEncryptionKeyConfig) Doc() *encoder.Doc {
+	return &AESCBCEncryptionKeyConfigDoc
+}
+
+func (_ SecretsEncryptionConfig) Doc() *encoder.Doc {
+	return &SecretsEncryptionConfigDoc
+}
+
+func (_ SecretboxEncryptionConfig) Doc() *encoder.Doc {
+	return &SecretboxEncryptionConfigDoc
+}
+
+func (_ KMSEncryptionConfig) Doc() *encoder.Doc {
+	return &KMSEncryptionConfigDoc
+}
+
+func (_ ManifestsSyncConfig) Doc() *encoder.Doc {
+	return &ManifestsSyncConfigDoc
+}
+
 func (_ MachineDisk) Doc() *encoder.Doc {
 	return &MachineDiskDoc
 }
@@ -1945,6 +3447,22 @@ func (_ Device) Doc() *encoder.Doc {
 	return &DeviceDoc
 }
 
+func (_ EthernetConfig) Doc() *encoder.Doc {
+	return &EthernetConfigDoc
+}
+
+func (_ EthernetOffloadConfig) Doc() *encoder.Doc {
+	return &EthernetOffloadConfigDoc
+}
+
+func (_ EthernetRingsConfig) Doc() *encoder.Doc {
+	return &EthernetRingsConfigDoc
+}
+
+func (_ NetworkDeviceSelector) Doc() *encoder.Doc {
+	return &NetworkDeviceSelectorDoc
+}
+
 func (_ DHCPOptions) Doc() *encoder.Doc {
 	return &DHCPOptionsDoc
 }
@@ -1961,10 +3479,30 @@ func (_ DeviceVIPConfig) Doc() *encoder.Doc {
 	return &DeviceVIPConfigDoc
 }
 
+func (_ VIPEquinixMetalConfig) Doc() *encoder.Doc {
+	return &VIPEquinixMetalConfigDoc
+}
+
+func (_ VIPHCloudConfig) Doc() *encoder.Doc {
+	return &VIPHCloudConfigDoc
+}
+
 func (_ Bond) Doc() *encoder.Doc {
 	return &BondDoc
 }
 
+func (_ Bridge) Doc() *encoder.Doc {
+	return &BridgeDoc
+}
+
+func (_ STP) Doc() *encoder.Doc {
+	return &STPDoc
+}
+
+func (_ BridgeVLAN) Doc() *encoder.Doc {
+	return &BridgeVLANDoc
+}
+
 func (_ Vlan) Doc() *encoder.Doc {
 	return &VlanDoc
 }
@@ -1973,6 +3511,34 @@ func (_ Route) Doc() *encoder.Doc {
 	return &RouteDoc
 }
 
+func (_ KubeSpan) Doc() *encoder.Doc {
+	return &KubeSpanDoc
+}
+
+func (_ SideroLink) Doc() *encoder.Doc {
+	return &SideroLinkDoc
+}
+
+func (_ FirewallConfig) Doc() *encoder.Doc {
+	return &FirewallConfigDoc
+}
+
+func (_ FirewallRule) Doc() *encoder.Doc {
+	return &FirewallRuleDoc
+}
+
+func (_ FirewallPortSelector) Doc() *encoder.Doc {
+	return &FirewallPortSelectorDoc
+}
+
+func (_ FirewallIngressRule) Doc() *encoder.Doc {
+	return &FirewallIngressRuleDoc
+}
+
+func (_ RoutingRule) Doc() *encoder.Doc {
+	return &RoutingRuleDoc
+}
+
 func (_ RegistryMirrorConfig) Doc() *encoder.Doc {
 	return &RegistryMirrorConfigDoc
 }
@@ -2006,12 +3572,31 @@ func GetConfigurationDoc() *encoder.FileDoc {
 			&ConfigDoc,
 			&MachineConfigDoc,
 			&ClusterConfigDoc,
+			&JoinPolicyConfigDoc,
+			&JoinPolicyWebhookConfigDoc,
 			&KubeletConfigDoc,
 			&NetworkConfigDoc,
 			&InstallConfigDoc,
 			&InstallDiskSizeMatcherDoc,
 			&InstallDiskSelectorDoc,
+			&InstallConsoleDoc,
+			&InstallSecureBootConfigDoc,
 			&TimeConfigDoc,
+			&VirtualizationConfigDoc,
+			&HealthzConfigDoc,
+			&MetricsConfigDoc,
+			&WatchdogConfigDoc,
+			&CrashDumpsConfigDoc,
+			&RamoopsConfigDoc,
+			&ImageCacheConfigDoc,
+			&FeaturesConfigDoc,
+			&CRIConfigDoc,
+			&NVIDIAConfigDoc,
+			&ReconcileConfigDoc,
+			&MaintenanceWindowConfigDoc,
+			&RegistrationConfigDoc,
+			&IdentityConfigDoc,
+			&IdentityKMSConfigDoc,
 			&RegistriesConfigDoc,
 			&PodCheckpointerDoc,
 			&CoreDNSDoc,
@@ -2026,6 +3611,11 @@ func GetConfigurationDoc() *encoder.FileDoc {
 			&CNIConfigDoc,
 			&ExternalCloudProviderConfigDoc,
 			&AdminKubeconfigConfigDoc,
+			&AESCBCEncryptionKeyConfigDoc,
+			&SecretsEncryptionConfigDoc,
+			&SecretboxEncryptionConfigDoc,
+			&KMSEncryptionConfigDoc,
+			&ManifestsSyncConfigDoc,
 			&MachineDiskDoc,
 			&DiskPartitionDoc,
 			&EncryptionConfigDoc,
@@ -2035,13 +3625,29 @@ func GetConfigurationDoc() *encoder.FileDoc {
 			&MachineFileDoc,
 			&ExtraHostDoc,
 			&DeviceDoc,
+			&EthernetConfigDoc,
+			&EthernetOffloadConfigDoc,
+			&EthernetRingsConfigDoc,
+			&NetworkDeviceSelectorDoc,
 			&DHCPOptionsDoc,
 			&DeviceWireguardConfigDoc,
 			&DeviceWireguardPeerDoc,
 			&DeviceVIPConfigDoc,
+			&VIPEquinixMetalConfigDoc,
+			&VIPHCloudConfigDoc,
 			&BondDoc,
+			&BridgeDoc,
+			&STPDoc,
+			&BridgeVLANDoc,
 			&VlanDoc,
 			&RouteDoc,
+			&KubeSpanDoc,
+			&SideroLinkDoc,
+			&FirewallConfigDoc,
+			&FirewallRuleDoc,
+			&FirewallPortSelectorDoc,
+			&FirewallIngressRuleDoc,
+			&RoutingRuleDoc,
 			&RegistryMirrorConfigDoc,
 			&RegistryConfigDoc,
 			&RegistryAuthConfigDoc,
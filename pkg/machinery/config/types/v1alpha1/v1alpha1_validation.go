@@ -6,19 +6,29 @@
 package v1alpha1
 
 import (
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"math"
 	"net"
+	"net/url"
 	"os"
+	"path/filepath"
+	"regexp"
 	"strconv"
+	"strings"
+	"time"
 
 	valid "github.com/asaskevich/govalidator"
 	"github.com/hashicorp/go-multierror"
 	talosnet "github.com/talos-systems/net"
 
 	"github.com/talos-systems/talos/pkg/machinery/config"
+	"github.com/talos-systems/talos/pkg/machinery/config/criconfig"
 	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1/machine"
 	"github.com/talos-systems/talos/pkg/machinery/constants"
+	"github.com/talos-systems/talos/pkg/machinery/kernel"
 )
 
 var (
@@ -56,6 +66,7 @@ var (
 type NetworkDeviceCheck func(*Device) error
 
 // Validate implements the config.Provider interface.
+//
 //nolint:gocyclo,cyclop
 func (c *Config) Validate(mode config.RuntimeMode, options ...config.ValidationOption) ([]string, error) {
 	var (
@@ -101,6 +112,40 @@ func (c *Config) Validate(mode config.RuntimeMode, options ...config.ValidationO
 		}
 	}
 
+	if c.MachineConfig.MachineInstall != nil {
+		for _, arg := range c.MachineConfig.MachineInstall.InstallExtraKernelArgs {
+			if strings.HasPrefix(arg, kernel.RemoveArgPrefix) && kernel.ArgKey(strings.TrimPrefix(arg, kernel.RemoveArgPrefix)) == "" {
+				result = multierror.Append(result, fmt.Errorf("invalid extra kernel arg %q: argument key to remove is required", arg))
+			}
+		}
+
+		switch c.MachineConfig.MachineInstall.InstallBootloaderType {
+		case "", constants.BootloaderGRUB, constants.BootloaderSDBoot:
+		default:
+			result = multierror.Append(result, fmt.Errorf("invalid bootloader type %q: expected %q or %q", c.MachineConfig.MachineInstall.InstallBootloaderType, constants.BootloaderGRUB, constants.BootloaderSDBoot))
+		}
+
+		if secureBoot := c.MachineConfig.MachineInstall.InstallSecureBoot; secureBoot != nil {
+			if c.MachineConfig.MachineInstall.InstallBootloaderType != constants.BootloaderSDBoot {
+				result = multierror.Append(result, fmt.Errorf("SecureBoot requires bootloader type %q", constants.BootloaderSDBoot))
+			}
+
+			if secureBoot.SecureBootSigningKey == "" || secureBoot.SecureBootSigningCert == "" {
+				result = multierror.Append(result, fmt.Errorf("SecureBoot requires signing key and certificate"))
+			}
+		}
+
+		for _, console := range c.MachineConfig.MachineInstall.InstallConsoles {
+			if !consoleDeviceRegexp.MatchString(console.ConsoleDevice) {
+				result = multierror.Append(result, fmt.Errorf("invalid console device %q", console.ConsoleDevice))
+			}
+
+			if console.ConsoleBaudRate < 0 {
+				result = multierror.Append(result, fmt.Errorf("invalid console %q baud rate %d", console.ConsoleDevice, console.ConsoleBaudRate))
+			}
+		}
+	}
+
 	// TODO rework machine type validation https://github.com/talos-systems/talos/issues/3413
 
 	if c.MachineConfig.MachineType == "" {
@@ -123,17 +168,171 @@ func (c *Config) Validate(mode config.RuntimeMode, options ...config.ValidationO
 			if d.VIPConfig() != nil {
 				result = multierror.Append(result, errors.New("virtual (shared) IP is not allowed on non-controlplane nodes"))
 			}
+
+			for _, vlan := range d.Vlans() {
+				if vlan.VIPConfig() != nil {
+					result = multierror.Append(result, errors.New("virtual (shared) IP is not allowed on non-controlplane nodes"))
+				}
+			}
+		}
+	}
+
+	if c.MachineConfig.MachineKubelet != nil {
+		for _, mount := range c.MachineConfig.MachineKubelet.KubeletExtraMounts {
+			if !filepath.IsAbs(mount.Destination) {
+				result = multierror.Append(result, fmt.Errorf("[%s] %q: destination should be an absolute path", "machine.kubelet.extraMounts", mount.Destination))
+			}
+
+			if !filepath.IsAbs(mount.Source) {
+				result = multierror.Append(result, fmt.Errorf("[%s] %q: source should be an absolute path", "machine.kubelet.extraMounts", mount.Source))
+			}
+		}
+	}
+
+	if c.MachineConfig.MachineNVIDIA != nil && c.MachineConfig.MachineNVIDIA.NVIDIAEnabled && !opts.Local {
+		if _, err := os.Stat(constants.NVIDIAContainerRuntimePath); os.IsNotExist(err) {
+			result = multierror.Append(result, fmt.Errorf("[%s] NVIDIA container runtime %q is not installed, NVIDIA extension is required", "machine.nvidia", constants.NVIDIAContainerRuntimePath))
+		}
+	}
+
+	if c.MachineConfig.MachineCRI != nil && len(c.MachineConfig.MachineCRI.CRIConfigPatches) > 0 {
+		// patches are merged into the CRI plugin configuration generated from the machine configuration
+		if _, err := criconfig.Encode(criconfig.New(c.MachineConfig.CRI(), c.MachineConfig.NVIDIA()), c.MachineConfig.MachineCRI.CRIConfigPatches); err != nil {
+			result = multierror.Append(result, fmt.Errorf("[%s] %w", "machine.cri.configPatches", err))
+		}
+	}
+
+	if c.MachineConfig.MachineReconcile != nil {
+		for _, window := range c.MachineConfig.MachineReconcile.ReconcileMaintenanceWindows {
+			if _, err := time.Parse(time.RFC3339, window.WindowStart); err != nil {
+				result = multierror.Append(result, fmt.Errorf("[%s] %q: invalid start time: %w", "machine.reconcile.maintenanceWindows", window.WindowStart, err))
+			}
+
+			if window.WindowDuration <= 0 {
+				result = multierror.Append(result, fmt.Errorf("[%s] %q: duration should be positive", "machine.reconcile.maintenanceWindows", window.WindowStart))
+			}
+		}
+	}
+
+	if registration := c.MachineConfig.MachineRegistration; registration != nil {
+		if u, err := url.Parse(registration.RegistrationEndpoint); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			result = multierror.Append(result, fmt.Errorf("[%s] %q: registration endpoint should be an http(s) URL", "machine.registration.endpoint", registration.RegistrationEndpoint))
+		}
+
+		if registration.RegistrationHeartbeatInterval < 0 {
+			result = multierror.Append(result, fmt.Errorf("[%s] %q: heartbeat interval should not be negative", "machine.registration.heartbeatInterval", registration.RegistrationHeartbeatInterval.String()))
+		}
+	}
+
+	if identity := c.MachineConfig.MachineIdentity; identity != nil && identity.IdentityKMS != nil {
+		kms := identity.IdentityKMS
+
+		if path := strings.TrimPrefix(kms.KMSEndpoint, "unix://"); path == kms.KMSEndpoint || !filepath.IsAbs(path) {
+			result = multierror.Append(result, fmt.Errorf("[%s] %q: endpoint should be an absolute path to the unix socket (unix:///path/to/socket)", "machine.identity.kms.endpoint", kms.KMSEndpoint))
+		}
+
+		if kms.KMSTimeout < 0 {
+			result = multierror.Append(result, fmt.Errorf("[%s] %s: timeout should be positive", "machine.identity.kms.timeout", kms.KMSTimeout))
 		}
 	}
 
 	if c.MachineConfig.MachineNetwork != nil {
 		for _, device := range c.MachineConfig.MachineNetwork.NetworkInterfaces {
-			if err := ValidateNetworkDevices(device, CheckDeviceInterface, CheckDeviceAddressing); err != nil {
+			if err := ValidateNetworkDevices(device, CheckDeviceInterface, CheckDeviceAddressing, CheckDeviceRoutes, CheckDeviceVlans, CheckDeviceBond, CheckDeviceWireguard, CheckDeviceEthernet); err != nil {
+				result = multierror.Append(result, err)
+			}
+		}
+
+		if err := c.MachineConfig.MachineNetwork.validateHostname(); err != nil {
+			result = multierror.Append(result, err)
+		}
+
+		for idx, rule := range c.MachineConfig.MachineNetwork.NetworkRules {
+			if err := checkRoutingRule(idx, rule); err != nil {
+				result = multierror.Append(result, err)
+			}
+		}
+
+		for _, server := range c.MachineConfig.MachineNetwork.NameServers {
+			if net.ParseIP(server) == nil {
+				result = multierror.Append(result, fmt.Errorf("[%s] %q: nameserver should be an IP address", "networking.os.nameservers", server))
+			}
+		}
+
+		for _, domain := range c.MachineConfig.MachineNetwork.NetworkSearchDomains {
+			if !valid.IsDNSName(domain) {
+				result = multierror.Append(result, fmt.Errorf("[%s] %q: search domain should be a valid DNS name", "networking.os.searchDomains", domain))
+			}
+		}
+
+		if kubespan := c.MachineConfig.MachineNetwork.NetworkKubeSpan; kubespan != nil {
+			switch {
+			case kubespan.KubeSpanDiscoveryEndpoint != "":
+				if u, err := url.Parse(kubespan.KubeSpanDiscoveryEndpoint); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+					result = multierror.Append(result, fmt.Errorf("[%s] %q: discovery endpoint should be an http(s) URL", "networking.os.kubespan.discoveryEndpoint", kubespan.KubeSpanDiscoveryEndpoint))
+				}
+			case kubespan.KubeSpanEnabled:
+				result = multierror.Append(result, fmt.Errorf("[%s] discovery endpoint is required if KubeSpan is enabled", "networking.os.kubespan.discoveryEndpoint"))
+			}
+
+			if kubespan.KubeSpanEnabled && (c.ClusterConfig == nil || c.ClusterConfig.ClusterID == "" || c.ClusterConfig.ClusterSecret == "") {
+				result = multierror.Append(result, fmt.Errorf("[%s] cluster ID and secret are required if KubeSpan is enabled", "cluster.id"))
+			}
+		}
+
+		if sideroLink := c.MachineConfig.MachineNetwork.NetworkSideroLink; sideroLink != nil {
+			if u, err := url.Parse(sideroLink.SideroLinkAPIURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				result = multierror.Append(result, fmt.Errorf("[%s] %q: SideroLink API URL should be an http(s) URL", "networking.os.sideroLink.apiUrl", sideroLink.SideroLinkAPIURL))
+			}
+		}
+
+		if firewall := c.MachineConfig.MachineNetwork.NetworkFirewall; firewall != nil {
+			if err := firewall.Validate(); err != nil {
 				result = multierror.Append(result, err)
 			}
 		}
 	}
 
+	if c.MachineConfig.MachineJoinPolicy != nil {
+		if err := c.MachineConfig.MachineJoinPolicy.Validate(); err != nil {
+			result = multierror.Append(result, err)
+		}
+	}
+
+	if c.MachineConfig.MachineHealthz != nil && (c.MachineConfig.MachineHealthz.HealthzPort < 0 || c.MachineConfig.MachineHealthz.HealthzPort > math.MaxUint16) {
+		result = multierror.Append(result, fmt.Errorf("invalid healthz port %d", c.MachineConfig.MachineHealthz.HealthzPort))
+	}
+
+	if c.MachineConfig.MachineMetrics != nil && (c.MachineConfig.MachineMetrics.MetricsPort < 0 || c.MachineConfig.MachineMetrics.MetricsPort > math.MaxUint16) {
+		result = multierror.Append(result, fmt.Errorf("invalid metrics port %d", c.MachineConfig.MachineMetrics.MetricsPort))
+	}
+
+	if c.MachineConfig.MachineMetrics != nil && c.MachineConfig.MachineMetrics.MetricsEnabled && c.MachineConfig.MachineMetrics.MetricsBearerToken == "" {
+		result = multierror.Append(result, fmt.Errorf("metrics endpoint requires a bearer token"))
+	}
+
+	if c.MachineConfig.MachineWatchdog != nil {
+		if c.MachineConfig.MachineWatchdog.WatchdogTimeout != 0 && c.MachineConfig.MachineWatchdog.WatchdogTimeout < constants.MinWatchdogTimeout {
+			result = multierror.Append(result, fmt.Errorf("watchdog timeout should be at least %s", constants.MinWatchdogTimeout))
+		}
+
+		if c.MachineConfig.MachineWatchdog.WatchdogDevice != "" && !filepath.IsAbs(c.MachineConfig.MachineWatchdog.WatchdogDevice) {
+			result = multierror.Append(result, fmt.Errorf("watchdog device %q should be an absolute path", c.MachineConfig.MachineWatchdog.WatchdogDevice))
+		}
+	}
+
+	if c.MachineConfig.MachineCrashDumps != nil {
+		if err := c.MachineConfig.MachineCrashDumps.Validate(); err != nil {
+			result = multierror.Append(result, err)
+		}
+	}
+
+	if c.MachineConfig.MachineVirtualization != nil {
+		if err := c.MachineConfig.MachineVirtualization.Validate(); err != nil {
+			result = multierror.Append(result, err)
+		}
+	}
+
 	if c.MachineConfig.MachineDisks != nil {
 		for _, disk := range c.MachineConfig.MachineDisks {
 			for i, pt := range disk.DiskPartitions {
@@ -166,6 +365,16 @@ func (c *Config) Validate(mode config.RuntimeMode, options ...config.ValidationO
 		}
 	}
 
+	if opts.VersionContract != nil {
+		if err := c.ValidateContract(opts.VersionContract); err != nil {
+			result = multierror.Append(result, err)
+		}
+	}
+
+	for _, deprecation := range c.Deprecations() {
+		warnings = append(warnings, deprecation.String())
+	}
+
 	if opts.Strict {
 		for _, w := range warnings {
 			result = multierror.Append(result, fmt.Errorf("warning: %s", w))
@@ -177,6 +386,204 @@ func (c *Config) Validate(mode config.RuntimeMode, options ...config.ValidationO
 	return warnings, result.ErrorOrNil()
 }
 
+// ValidateContract checks that the config only uses features supported by the Talos version described by the contract.
+//
+//nolint:gocyclo,cyclop
+func (c *Config) ValidateContract(contract *config.VersionContract) error {
+	var result *multierror.Error
+
+	if !contract.Known() {
+		return fmt.Errorf("config can't be checked against Talos %s: version is newer than the supported contracts", contract)
+	}
+
+	unsupported := func(field string) {
+		result = multierror.Append(result, fmt.Errorf("%q is not supported by Talos %s", field, contract))
+	}
+
+	if c.ClusterConfig != nil {
+		if c.ClusterConfig.ClusterAggregatorCA != nil && !contract.SupportsAggregatorCA() {
+			unsupported(".cluster.aggregatorCA")
+		}
+
+		if c.ClusterConfig.ClusterServiceAccount != nil && !contract.SupportsServiceAccount() {
+			unsupported(".cluster.serviceAccount")
+		}
+
+		if c.ClusterConfig.ClusterID != "" && !contract.SupportsKubeSpan() {
+			unsupported(".cluster.id")
+		}
+
+		if c.ClusterConfig.ClusterSecret != "" && !contract.SupportsKubeSpan() {
+			unsupported(".cluster.secret")
+		}
+	}
+
+	if c.MachineConfig == nil {
+		return result.ErrorOrNil()
+	}
+
+	if c.MachineConfig.MachineSystemDiskEncryption != nil && !contract.SupportsSystemDiskEncryption() {
+		unsupported(".machine.systemDiskEncryption")
+	}
+
+	if c.MachineConfig.MachineInstall != nil && c.MachineConfig.MachineInstall.InstallDiskSelector != nil && !contract.SupportsInstallDiskSelector() {
+		unsupported(".machine.install.diskSelector")
+	}
+
+	if c.MachineConfig.MachineInstall != nil && !contract.SupportsBootloaderSettings() {
+		if c.MachineConfig.MachineInstall.InstallBootloaderType != "" {
+			unsupported(".machine.install.bootloaderType")
+		}
+
+		if len(c.MachineConfig.MachineInstall.InstallConsoles) > 0 {
+			unsupported(".machine.install.consoles")
+		}
+
+		if c.MachineConfig.MachineInstall.InstallSecureBoot != nil {
+			unsupported(".machine.install.secureboot")
+		}
+	}
+
+	if c.MachineConfig.MachineJoinPolicy != nil && !contract.SupportsJoinPolicy() {
+		unsupported(".machine.joinPolicy")
+	}
+
+	if c.MachineConfig.MachineVirtualization != nil && !contract.SupportsVirtualization() {
+		unsupported(".machine.virtualization")
+	}
+
+	if c.MachineConfig.MachineHealthz != nil && !contract.SupportsHealthz() {
+		unsupported(".machine.healthz")
+	}
+
+	if c.MachineConfig.MachineMetrics != nil && !contract.SupportsMetrics() {
+		unsupported(".machine.metrics")
+	}
+
+	if c.MachineConfig.MachineWatchdog != nil && !contract.SupportsWatchdog() {
+		unsupported(".machine.watchdog")
+	}
+
+	if c.MachineConfig.MachineCrashDumps != nil && !contract.SupportsCrashDumps() {
+		unsupported(".machine.crashDumps")
+	}
+
+	if c.MachineConfig.MachineImageCache != nil && !contract.SupportsImageCache() {
+		unsupported(".machine.imageCache")
+	}
+
+	if c.MachineConfig.MachineFeatures != nil && !contract.SupportsFeatures() {
+		unsupported(".machine.features")
+	}
+
+	if c.MachineConfig.MachineCRI != nil && !contract.SupportsCRIConfig() {
+		unsupported(".machine.cri")
+	}
+
+	if c.MachineConfig.MachineNVIDIA != nil && !contract.SupportsNVIDIA() {
+		unsupported(".machine.nvidia")
+	}
+
+	if c.MachineConfig.MachineReconcile != nil && !contract.SupportsReconcile() {
+		unsupported(".machine.reconcile")
+	}
+
+	if c.MachineConfig.MachineRegistration != nil && !contract.SupportsRegistration() {
+		unsupported(".machine.registration")
+	}
+
+	if c.MachineConfig.MachineNetwork != nil {
+		if (len(c.MachineConfig.MachineNetwork.NetworkHostnameSources) > 0 || strings.Contains(c.MachineConfig.MachineNetwork.NetworkHostname, "${")) &&
+			!contract.SupportsHostnameSources() {
+			unsupported(".machine.network.hostnameSources")
+		}
+
+		if c.MachineConfig.MachineNetwork.NetworkKubeSpan != nil && !contract.SupportsKubeSpan() {
+			unsupported(".machine.network.kubespan")
+		}
+
+		if c.MachineConfig.MachineNetwork.NetworkFirewall != nil && !contract.SupportsFirewall() {
+			unsupported(".machine.network.firewall")
+		}
+
+		if c.MachineConfig.MachineNetwork.NetworkSideroLink != nil && !contract.SupportsSideroLink() {
+			unsupported(".machine.network.sideroLink")
+		}
+
+		if len(c.MachineConfig.MachineNetwork.NetworkRules) > 0 && !contract.SupportsPolicyRouting() {
+			unsupported(".machine.network.rules")
+		}
+
+		for deviceIdx, device := range c.MachineConfig.MachineNetwork.NetworkInterfaces {
+			if device.DeviceEthernet != nil && !contract.SupportsEthernetConfig() {
+				unsupported(fmt.Sprintf(".machine.network.interfaces[%d].ethernet", deviceIdx))
+			}
+
+			if device.DeviceSelector != nil && !contract.SupportsDeviceSelectors() {
+				unsupported(fmt.Sprintf(".machine.network.interfaces[%d].deviceSelector", deviceIdx))
+			}
+
+			if !contract.SupportsPolicyRouting() {
+				for idx, route := range device.DeviceRoutes {
+					if route.RouteSource != "" || route.RouteMTU != 0 || route.RouteTable != 0 {
+						unsupported(fmt.Sprintf(".machine.network.interfaces[%q].routes[%d]", device.DeviceInterface, idx))
+					}
+				}
+			}
+
+			if device.DeviceVIPConfig != nil && !contract.SupportsSharedIP() {
+				unsupported(fmt.Sprintf(".machine.network.interfaces[%q].vip", device.DeviceInterface))
+			}
+
+			if device.DeviceWireguardConfig != nil && !contract.SupportsWireguard() {
+				unsupported(fmt.Sprintf(".machine.network.interfaces[%q].wireguard", device.DeviceInterface))
+			}
+
+			if device.DeviceWireguardConfig != nil && device.DeviceWireguardConfig.WireguardGeneratePrivateKey && !contract.SupportsWireguardKeyGeneration() {
+				unsupported(fmt.Sprintf(".machine.network.interfaces[%q].wireguard.generatePrivateKey", device.DeviceInterface))
+			}
+
+			if device.DeviceVIPConfig != nil && !contract.SupportsVIPCloudProviders() {
+				if device.DeviceVIPConfig.EquinixMetalConfig != nil {
+					unsupported(fmt.Sprintf(".machine.network.interfaces[%q].vip.equinixMetal", device.DeviceInterface))
+				}
+
+				if device.DeviceVIPConfig.HCloudConfig != nil {
+					unsupported(fmt.Sprintf(".machine.network.interfaces[%q].vip.hcloud", device.DeviceInterface))
+				}
+			}
+
+			if opts := device.DeviceDHCPOptions; opts != nil && !contract.SupportsDHCPExtendedOptions() {
+				for _, option := range []struct {
+					field string
+					set   bool
+				}{
+					{"ignoreDefaultRoutes", opts.DHCPIgnoreDefaultRoutes},
+					{"ignoreDNS", opts.DHCPIgnoreDNS},
+					{"duidv6", opts.DHCPUIDv6 != ""},
+					{"slaac", opts.DHCPSLAAC != nil},
+				} {
+					if option.set {
+						unsupported(fmt.Sprintf(".machine.network.interfaces[%q].dhcpOptions.%s", device.DeviceInterface, option.field))
+					}
+				}
+			}
+
+			for _, vlan := range device.DeviceVlans {
+				if vlan.VlanVIP != nil && !contract.SupportsVlanVIP() {
+					unsupported(fmt.Sprintf(".machine.network.interfaces[%q].vlans[%d].vip", device.DeviceInterface, vlan.VlanID))
+				}
+			}
+
+			if device.DeviceBridge != nil && !contract.SupportsBridgeInterfaces() {
+				unsupported(fmt.Sprintf(".machine.network.interfaces[%q].bridge", device.DeviceInterface))
+			}
+		}
+	}
+
+	return result.ErrorOrNil()
+}
+
 // Validate validates the config.
 func (c *ClusterConfig) Validate() error {
 	var result *multierror.Error
@@ -197,97 +604,816 @@ func (c *ClusterConfig) Validate() error {
 		result = multierror.Append(result, fmt.Errorf("%q is not a valid DNS name", c.ClusterNetwork.DNSDomain))
 	}
 
+	if c.ClusterNetwork != nil {
+		result = multierror.Append(result, c.ClusterNetwork.validateSubnets())
+	}
+
 	if ecp := c.ExternalCloudProviderConfig; ecp != nil {
 		result = multierror.Append(result, ecp.Validate())
 	}
 
-	return result.ErrorOrNil()
-}
+	result = multierror.Append(result, c.validateEncryptionKeys())
 
-// Validate validates external cloud provider configuration.
-func (ecp *ExternalCloudProviderConfig) Validate() error {
-	if !ecp.ExternalEnabled && (len(ecp.ExternalManifests) != 0) {
-		return fmt.Errorf("external cloud provider is disabled, but manifests are provided")
+	if sync := c.ManifestsSyncConfig; sync != nil && sync.SyncInterval < 0 {
+		result = multierror.Append(result, fmt.Errorf("[cluster.manifestsSync.interval] %s: interval should be positive", sync.SyncInterval))
 	}
 
-	var result *multierror.Error
-
-	for _, url := range ecp.ExternalManifests {
-		if err := talosnet.ValidateEndpointURI(url); err != nil {
-			err = fmt.Errorf("invalid external cloud provider manifest url %q: %w", url, err)
-			result = multierror.Append(result, err)
-		}
+	if sec := c.SecretsEncryptionConfig; sec != nil {
+		result = multierror.Append(result, sec.Validate(c.APIServer().Image()))
 	}
 
 	return result.ErrorOrNil()
 }
 
-// ValidateNetworkDevices runs the specified validation checks specific to the
-// network devices.
-func ValidateNetworkDevices(d *Device, checks ...NetworkDeviceCheck) error {
+// validateEncryptionKeys checks that the additional encryption keys are named uniquely,
+// as Kubernetes picks the key to decrypt the data by the name.
+func (c *ClusterConfig) validateEncryptionKeys() error {
 	var result *multierror.Error
 
-	if d == nil {
-		return fmt.Errorf("empty device")
+	names := map[string]struct{}{
+		c.AESCBCEncryptionKeyName(): {},
 	}
 
-	if d.DeviceIgnore {
-		return result.ErrorOrNil()
-	}
+	for _, key := range c.ClusterAESCBCAdditionalEncryptionKeys {
+		if key.KeyName == "" {
+			result = multierror.Append(result, fmt.Errorf("[cluster.aescbcAdditionalEncryptionKeys] key name is required"))
 
-	for _, check := range checks {
-		result = multierror.Append(result, check(d))
+			continue
+		}
+
+		if key.KeySecret == "" {
+			result = multierror.Append(result, fmt.Errorf("[cluster.aescbcAdditionalEncryptionKeys] %q: key secret is required", key.KeyName))
+		}
+
+		if _, ok := names[key.KeyName]; ok {
+			result = multierror.Append(result, fmt.Errorf("[cluster.aescbcAdditionalEncryptionKeys] %q: duplicate key name", key.KeyName))
+		}
+
+		names[key.KeyName] = struct{}{}
 	}
 
 	return result.ErrorOrNil()
 }
 
-// CheckDeviceInterface ensures that the interface has been specified.
-func CheckDeviceInterface(d *Device) error {
-	var result *multierror.Error
+// kmsV2MinKubernetesVersion is the first Kubernetes version which talks to the KMS v2 plugins.
+var kmsV2MinKubernetesVersion = struct{ major, minor int }{1, 25}
 
-	if d == nil {
-		return fmt.Errorf("empty device")
-	}
+// imageVersionRegexp matches the version in the image tag, e.g. `k8s.gcr.io/kube-apiserver:v1.21.1`.
+var imageVersionRegexp = regexp.MustCompile(`:v?(\d+)\.(\d+)[^:/@]*(@.*)?$`)
 
-	if d.DeviceInterface == "" {
-		result = multierror.Append(result, fmt.Errorf("[%s]: %w", "networking.os.device.interface", ErrRequiredSection))
+// imageVersion returns the major and minor version from the image tag.
+//
+// If the tag is not a version (e.g. `latest`), ok is false.
+func imageVersion(image string) (major, minor int, ok bool) {
+	matches := imageVersionRegexp.FindStringSubmatch(image)
+	if matches == nil {
+		return 0, 0, false
 	}
 
-	return result.ErrorOrNil()
+	major, _ = strconv.Atoi(matches[1]) //nolint:errcheck
+	minor, _ = strconv.Atoi(matches[2]) //nolint:errcheck
+
+	return major, minor, true
 }
 
-// CheckDeviceAddressing ensures that an appropriate addressing method.
-// has been specified.
-func CheckDeviceAddressing(d *Device) error {
+// Validate checks that the selected provider for the encryption of secret data at rest is configured.
+//
+// The KMS v2 plugins are only supported by the Kubernetes API server 1.25+, the version is taken from the API server image tag.
+func (e *SecretsEncryptionConfig) Validate(apiServerImage string) error {
 	var result *multierror.Error
 
-	if d == nil {
-		return fmt.Errorf("empty device")
+	switch e.Provider() {
+	case constants.SecretsEncryptionProviderAESCBC:
+	case constants.SecretsEncryptionProviderSecretbox:
+		if e.EncryptionSecretbox == nil {
+			result = multierror.Append(result, fmt.Errorf("[cluster.secretsEncryption.secretbox] secretbox provider is selected, but not configured"))
+		}
+	case constants.SecretsEncryptionProviderKMS:
+		if e.EncryptionKMS == nil {
+			result = multierror.Append(result, fmt.Errorf("[cluster.secretsEncryption.kms] kms provider is selected, but not configured"))
+		}
+	default:
+		result = multierror.Append(result, fmt.Errorf("[cluster.secretsEncryption.provider] %q: unsupported provider", e.EncryptionProvider))
 	}
 
-	// Test for both dhcp and cidr specified
-	if d.DeviceDHCP && d.DeviceCIDR != "" {
-		result = multierror.Append(result, fmt.Errorf("[%s] %q: %w", "networking.os.device", d.DeviceInterface, ErrBadAddressing))
+	if sb := e.EncryptionSecretbox; sb != nil {
+		if secret, err := base64.StdEncoding.DecodeString(sb.SecretboxSecret); err != nil || len(secret) != 32 {
+			result = multierror.Append(result, fmt.Errorf("[cluster.secretsEncryption.secretbox.secret] secret should be 32 bytes, base64 encoded"))
+		}
 	}
 
-	// ensure cidr is a valid address
-	if d.DeviceCIDR != "" {
+	if kms := e.EncryptionKMS; kms != nil {
+		if kms.KMSName == "" {
+			result = multierror.Append(result, fmt.Errorf("[cluster.secretsEncryption.kms.name] name is required"))
+		}
+
+		if path := strings.TrimPrefix(kms.KMSEndpoint, "unix://"); path == kms.KMSEndpoint || !filepath.IsAbs(path) {
+			result = multierror.Append(result, fmt.Errorf("[cluster.secretsEncryption.kms.endpoint] %q: endpoint should be an absolute path to the unix socket (unix:///path/to/socket)", kms.KMSEndpoint))
+		}
+
+		if kms.KMSTimeout < 0 {
+			result = multierror.Append(result, fmt.Errorf("[cluster.secretsEncryption.kms.timeout] %s: timeout should be positive", kms.KMSTimeout))
+		}
+
+		// the plugin is used for decryption even if it's not the selected provider
+		if major, minor, ok := imageVersion(apiServerImage); ok &&
+			(major < kmsV2MinKubernetesVersion.major || (major == kmsV2MinKubernetesVersion.major && minor < kmsV2MinKubernetesVersion.minor)) {
+			result = multierror.Append(result, fmt.Errorf("[cluster.secretsEncryption.kms] KMS v2 plugins require Kubernetes %d.%d or later, API server image is %q",
+				kmsV2MinKubernetesVersion.major, kmsV2MinKubernetesVersion.minor, apiServerImage))
+		}
+	}
+
+	return result.ErrorOrNil()
+}
+
+// validateSubnets checks that pod and service subnets are either single-stack or dual-stack (one subnet per IP family),
+// and that both lists have the same IP families in the same order.
+func (c *ClusterNetworkConfig) validateSubnets() error {
+	var result *multierror.Error
+
+	podFamilies, err := subnetFamilies("cluster.network.podSubnets", c.PodSubnet)
+	if err != nil {
+		result = multierror.Append(result, err)
+	}
+
+	serviceFamilies, err := subnetFamilies("cluster.network.serviceSubnets", c.ServiceSubnet)
+	if err != nil {
+		result = multierror.Append(result, err)
+	}
+
+	if result == nil && len(c.PodSubnet) > 0 && len(c.ServiceSubnet) > 0 && podFamilies != serviceFamilies {
+		result = multierror.Append(result, fmt.Errorf("[cluster.network] pod subnets %q and service subnets %q should have the same IP families in the same order", c.PodSubnet, c.ServiceSubnet))
+	}
+
+	return result.ErrorOrNil()
+}
+
+// subnetFamilies validates the list of subnets and returns their IP families, e.g. "ipv4,ipv6".
+func subnetFamilies(field string, subnets []string) (string, error) {
+	var result *multierror.Error
+
+	if len(subnets) > 2 {
+		result = multierror.Append(result, fmt.Errorf("[%s] too many subnets: at most one IPv4 and one IPv6 subnet are supported", field))
+	}
+
+	families := make([]string, 0, len(subnets))
+
+	for _, subnet := range subnets {
+		ip, _, err := net.ParseCIDR(subnet)
+		if err != nil {
+			result = multierror.Append(result, fmt.Errorf("[%s] %q: invalid subnet: %w", field, subnet, err))
+
+			continue
+		}
+
+		family := "ipv6"
+		if ip.To4() != nil {
+			family = "ipv4"
+		}
+
+		for _, f := range families {
+			if f == family {
+				result = multierror.Append(result, fmt.Errorf("[%s] %q: dual-stack requires one IPv4 and one IPv6 subnet", field, subnet))
+			}
+		}
+
+		families = append(families, family)
+	}
+
+	return strings.Join(families, ","), result.ErrorOrNil()
+}
+
+// Validate validates external cloud provider configuration.
+func (ecp *ExternalCloudProviderConfig) Validate() error {
+	if !ecp.ExternalEnabled && (len(ecp.ExternalManifests) != 0) {
+		return fmt.Errorf("external cloud provider is disabled, but manifests are provided")
+	}
+
+	var result *multierror.Error
+
+	for _, url := range ecp.ExternalManifests {
+		if err := talosnet.ValidateEndpointURI(url); err != nil {
+			err = fmt.Errorf("invalid external cloud provider manifest url %q: %w", url, err)
+			result = multierror.Append(result, err)
+		}
+	}
+
+	return result.ErrorOrNil()
+}
+
+// Validate validates trustd join policy configuration.
+func (j *JoinPolicyConfig) Validate() error {
+	var result *multierror.Error
+
+	switch j.Mode() {
+	case constants.TrustdJoinPolicyToken:
+	case constants.TrustdJoinPolicyAllowlist:
+		if len(j.JoinPolicyAllowedIdentities) == 0 {
+			result = multierror.Append(result, fmt.Errorf("join policy %q requires at least one allowed identity", j.Mode()))
+		}
+
+		for _, identity := range j.JoinPolicyAllowedIdentities {
+			if strings.HasPrefix(identity, "sha256:") || net.ParseIP(identity) != nil {
+				continue
+			}
+
+			if _, _, err := net.ParseCIDR(identity); err == nil {
+				continue
+			}
+
+			result = multierror.Append(result, fmt.Errorf("join policy allowed identity %q should be a fingerprint, an IP address or CIDR", identity))
+		}
+	case constants.TrustdJoinPolicyWebhook:
+		if j.JoinPolicyWebhook == nil {
+			result = multierror.Append(result, fmt.Errorf("join policy %q requires webhook settings", j.Mode()))
+
+			break
+		}
+
+		if err := talosnet.ValidateEndpointURI(j.JoinPolicyWebhook.WebhookURL); err != nil {
+			result = multierror.Append(result, fmt.Errorf("invalid join policy webhook url %q: %w", j.JoinPolicyWebhook.WebhookURL, err))
+		}
+	default:
+		result = multierror.Append(result, fmt.Errorf("unsupported join policy mode %q", j.Mode()))
+	}
+
+	return result.ErrorOrNil()
+}
+
+var kernelModuleParameterRegexp = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+var consoleDeviceRegexp = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9]*$`)
+
+// Validate validates virtualization configuration.
+func (v *VirtualizationConfig) Validate() error {
+	var result *multierror.Error
+
+	for key, value := range v.VirtualizationKVMOptions {
+		if !kernelModuleParameterRegexp.MatchString(key) {
+			result = multierror.Append(result, fmt.Errorf("invalid kvm module parameter name %q", key))
+		}
+
+		if value == "" || strings.ContainsAny(value, " \t\n") {
+			result = multierror.Append(result, fmt.Errorf("invalid value %q for kvm module parameter %q", value, key))
+		}
+	}
+
+	return result.ErrorOrNil()
+}
+
+// Validate validates crash dumps configuration.
+func (c *CrashDumpsConfig) Validate() error {
+	var result *multierror.Error
+
+	if c.CrashDumpsRamoops != nil {
+		for _, option := range []struct {
+			name     string
+			value    string
+			required bool
+		}{
+			{"memAddress", c.CrashDumpsRamoops.RamoopsMemAddress, true},
+			{"memSize", c.CrashDumpsRamoops.RamoopsMemSize, true},
+			{"recordSize", c.CrashDumpsRamoops.RamoopsRecordSize, false},
+			{"consoleSize", c.CrashDumpsRamoops.RamoopsConsoleSize, false},
+		} {
+			if option.value == "" {
+				if option.required {
+					result = multierror.Append(result, fmt.Errorf("ramoops %s is required", option.name))
+				}
+
+				continue
+			}
+
+			if _, err := strconv.ParseUint(option.value, 0, 64); err != nil {
+				result = multierror.Append(result, fmt.Errorf("invalid ramoops %s %q", option.name, option.value))
+			}
+		}
+	}
+
+	if c.CrashDumpsDestination != "" {
+		u, err := url.Parse(c.CrashDumpsDestination)
+
+		switch {
+		case err != nil:
+			result = multierror.Append(result, fmt.Errorf("invalid crash dumps destination %q: %w", c.CrashDumpsDestination, err))
+		case u.Scheme != "tcp" && u.Scheme != "udp":
+			result = multierror.Append(result, fmt.Errorf("unsupported crash dumps destination scheme %q", u.Scheme))
+		case u.Hostname() == "" || u.Port() == "":
+			result = multierror.Append(result, fmt.Errorf("crash dumps destination %q should specify host and port", c.CrashDumpsDestination))
+		}
+	}
+
+	return result.ErrorOrNil()
+}
+
+// ValidateNetworkDevices runs the specified validation checks specific to the
+// network devices.
+func ValidateNetworkDevices(d *Device, checks ...NetworkDeviceCheck) error {
+	var result *multierror.Error
+
+	if d == nil {
+		return fmt.Errorf("empty device")
+	}
+
+	if d.DeviceIgnore {
+		return result.ErrorOrNil()
+	}
+
+	for _, check := range checks {
+		result = multierror.Append(result, check(d))
+	}
+
+	return result.ErrorOrNil()
+}
+
+// CheckDeviceInterface ensures that the interface has been specified.
+func CheckDeviceInterface(d *Device) error {
+	var result *multierror.Error
+
+	if d == nil {
+		return fmt.Errorf("empty device")
+	}
+
+	switch {
+	case d.DeviceInterface == "" && d.DeviceSelector == nil:
+		result = multierror.Append(result, fmt.Errorf("[%s]: %w", "networking.os.device.interface", ErrRequiredSection))
+	case d.DeviceInterface != "" && d.DeviceSelector != nil:
+		result = multierror.Append(result, fmt.Errorf("[%s] %q: interface and deviceSelector are mutually exclusive", "networking.os.device.interface", d.DeviceInterface))
+	case d.DeviceSelector != nil:
+		result = multierror.Append(result, checkDeviceSelector(d))
+	}
+
+	return result.ErrorOrNil()
+}
+
+func checkDeviceSelector(d *Device) error {
+	var result *multierror.Error
+
+	selector := d.DeviceSelector
+
+	if *selector == (NetworkDeviceSelector{}) {
+		result = multierror.Append(result, fmt.Errorf("[%s]: %w", "networking.os.device.deviceSelector", ErrRequiredSection))
+	}
+
+	if selector.NetworkDeviceHardwareAddress != "" {
+		if _, err := net.ParseMAC(selector.NetworkDeviceHardwareAddress); err != nil {
+			result = multierror.Append(result, fmt.Errorf("[%s]: %w", "networking.os.device.deviceSelector.hardwareAddr", err))
+		}
+	}
+
+	// selectors pick existing physical links, virtual links are created by name
+	if d.DeviceBond != nil || d.DeviceBridge != nil || d.DeviceWireguardConfig != nil || d.DeviceDummy {
+		result = multierror.Append(result, fmt.Errorf("[%s]: deviceSelector can't be used with bond, bridge, wireguard or dummy interfaces", "networking.os.device.deviceSelector"))
+	}
+
+	return result.ErrorOrNil()
+}
+
+// CheckDeviceAddressing ensures that an appropriate addressing method.
+// has been specified.
+func CheckDeviceAddressing(d *Device) error {
+	var result *multierror.Error
+
+	if d == nil {
+		return fmt.Errorf("empty device")
+	}
+
+	// Test for both dhcp and cidr specified
+	if d.DeviceDHCP && d.DeviceCIDR != "" {
+		result = multierror.Append(result, fmt.Errorf("[%s] %q: %w", "networking.os.device", d.DeviceInterface, ErrBadAddressing))
+	}
+
+	// ensure cidr is a valid address
+	if d.DeviceCIDR != "" {
 		if _, _, err := net.ParseCIDR(d.DeviceCIDR); err != nil {
 			result = multierror.Append(result, fmt.Errorf("[%s] %q: %w", "networking.os.device.CIDR", d.DeviceInterface, err))
 		}
 	}
 
+	// macvtap parents are brought up without addressing
+	if d.DeviceMacvtapParent && (d.DeviceDHCP || d.DeviceCIDR != "") {
+		result = multierror.Append(result, fmt.Errorf("[%s] %q: macvtap parent interface can't be configured with cidr or dhcp", "networking.os.device.macvtapParent", d.DeviceInterface))
+	}
+
+	if d.DeviceMacvtapParent && (d.DeviceDummy || d.DeviceWireguardConfig != nil) {
+		result = multierror.Append(result, fmt.Errorf("[%s] %q: dummy and wireguard interfaces can't be macvtap parents", "networking.os.device.macvtapParent", d.DeviceInterface))
+	}
+
+	// bridged interfaces should be listed
+	if d.DeviceBridge != nil && len(d.DeviceBridge.BridgedInterfaces) == 0 {
+		result = multierror.Append(result, fmt.Errorf("[%s] %q: no bridged interfaces specified", "networking.os.device.bridge", d.DeviceInterface))
+	}
+
 	// check VIP IP is valid
-	if d.DeviceVIPConfig != nil {
-		if ip := net.ParseIP(d.DeviceVIPConfig.IP()); ip == nil {
-			result = multierror.Append(result, fmt.Errorf("[%s] failed to parse %q as IP address", "networking.os.device.vip", d.DeviceVIPConfig.IP()))
+	result = multierror.Append(result, checkVIP("networking.os.device.vip", d.DeviceVIPConfig))
+
+	if d.DeviceDHCPOptions != nil && d.DeviceDHCPOptions.DHCPUIDv6 != "" {
+		if err := checkDUID(d.DeviceDHCPOptions.DHCPUIDv6); err != nil {
+			result = multierror.Append(result, fmt.Errorf("[%s] %q: %w", "networking.os.device.dhcpOptions.duidv6", d.DeviceInterface, err))
+		}
+	}
+
+	return result.ErrorOrNil()
+}
+
+// DUID is 2 bytes of type followed by up to 128 bytes of data (RFC 8415).
+const (
+	duidMinLength = 3
+	duidMaxLength = 130
+)
+
+func checkDUID(duid string) error {
+	b, err := hex.DecodeString(duid)
+	if err != nil {
+		return fmt.Errorf("DUID should be hex encoded: %w", err)
+	}
+
+	if len(b) < duidMinLength || len(b) > duidMaxLength {
+		return fmt.Errorf("DUID length should be %d-%d bytes, got %d", duidMinLength, duidMaxLength, len(b))
+	}
+
+	return nil
+}
+
+// VLAN ID limits, 0 and 4095 are reserved by 802.1Q.
+const (
+	vlanMinID = 1
+	vlanMaxID = 4094
+
+	// maxLinkNameLength is IFNAMSIZ without the trailing NUL.
+	maxLinkNameLength = 15
+)
+
+// CheckDeviceVlans ensures that the VLAN IDs are valid and unique, and that
+// each VLAN uses a single addressing method.
+func CheckDeviceVlans(d *Device) error {
+	var result *multierror.Error
+
+	if d == nil {
+		return fmt.Errorf("empty device")
+	}
+
+	seen := map[uint16]struct{}{}
+
+	for _, vlan := range d.DeviceVlans {
+		if vlan.VlanID < vlanMinID || vlan.VlanID > vlanMaxID {
+			result = multierror.Append(result, fmt.Errorf("[%s] %q: invalid VLAN ID %d, expected %d-%d", "networking.os.device.vlan", d.DeviceInterface, vlan.VlanID, vlanMinID, vlanMaxID))
+
+			continue
+		}
+
+		if _, ok := seen[vlan.VlanID]; ok {
+			result = multierror.Append(result, fmt.Errorf("[%s] %q: duplicate VLAN ID %d", "networking.os.device.vlan", d.DeviceInterface, vlan.VlanID))
+		}
+
+		seen[vlan.VlanID] = struct{}{}
+
+		name := fmt.Sprintf("%s.%d", d.DeviceInterface, vlan.VlanID)
+
+		if len(name) > maxLinkNameLength {
+			result = multierror.Append(result, fmt.Errorf("[%s] %q: VLAN interface name %q is longer than %d characters", "networking.os.device.vlan", d.DeviceInterface, name, maxLinkNameLength))
+		}
+
+		addresses := vlan.Addresses()
+
+		if vlan.VlanDHCP && len(addresses) > 0 {
+			result = multierror.Append(result, fmt.Errorf("[%s] %q: %w", "networking.os.device.vlan", name, ErrBadAddressing))
 		}
+
+		for _, cidr := range addresses {
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				result = multierror.Append(result, fmt.Errorf("[%s] %q: %w", "networking.os.device.vlan.addresses", name, err))
+			}
+		}
+
+		result = multierror.Append(result, checkVIP("networking.os.device.vlan.vip", vlan.VlanVIP))
 	}
 
 	return result.ErrorOrNil()
 }
 
+// checkVIP ensures that the shared IP is valid and at most one cloud provider is configured.
+func checkVIP(field string, vip *DeviceVIPConfig) error {
+	var result *multierror.Error
+
+	if vip == nil {
+		return nil
+	}
+
+	if ip := net.ParseIP(vip.IP()); ip == nil {
+		result = multierror.Append(result, fmt.Errorf("[%s] failed to parse %q as IP address", field, vip.IP()))
+	}
+
+	if vip.EquinixMetalConfig != nil && vip.HCloudConfig != nil {
+		result = multierror.Append(result, fmt.Errorf("[%s] %q: equinixMetal and hcloud are mutually exclusive", field, vip.IP()))
+	}
+
+	if vip.EquinixMetalConfig != nil {
+		switch {
+		case vip.EquinixMetalConfig.EquinixMetalBGP:
+			if ip := net.ParseIP(vip.IP()); ip != nil && ip.To4() == nil {
+				result = multierror.Append(result, fmt.Errorf("[%s] %q: BGP announcements are supported only for IPv4 addresses", field+".equinixMetal.bgp", vip.IP()))
+			}
+		case vip.EquinixMetalConfig.EquinixMetalAPIToken == "":
+			result = multierror.Append(result, fmt.Errorf("[%s] %q: %w", field+".equinixMetal.apiToken", vip.IP(), ErrRequiredSection))
+		}
+	}
+
+	if vip.HCloudConfig != nil && vip.HCloudConfig.HCloudAPIToken == "" {
+		result = multierror.Append(result, fmt.Errorf("[%s] %q: %w", field+".hcloud.apiToken", vip.IP(), ErrRequiredSection))
+	}
+
+	return result.ErrorOrNil()
+}
+
+// wireguardKeyLength is the length of Wireguard keys (Curve25519).
+const wireguardKeyLength = 32
+
+const (
+	// minimum and maximum MTU accepted by networkd.
+	minMTU = 68
+	maxMTU = 65536
+
+	wakeOnLANDisabled = "disabled"
+)
+
+// wakeOnLANModes is the list of Wake-on-LAN modes as named by ethtool.
+var wakeOnLANModes = map[string]struct{}{
+	"phy":   {},
+	"ucast": {},
+	"mcast": {},
+	"bcast": {},
+	"arp":   {},
+	"magic": {},
+}
+
+// CheckDeviceEthernet ensures that the MTU and ethernet hardware settings are valid.
+func CheckDeviceEthernet(d *Device) error {
+	var result *multierror.Error
+
+	if d == nil {
+		return fmt.Errorf("empty device")
+	}
+
+	if d.DeviceMTU != 0 && (d.DeviceMTU < minMTU || d.DeviceMTU > maxMTU) {
+		result = multierror.Append(result, fmt.Errorf("[%s] %q: MTU %d is out of range %d-%d", "networking.os.device.mtu", d.DeviceInterface, d.DeviceMTU, minMTU, maxMTU))
+	}
+
+	if d.DeviceEthernet == nil {
+		return result.ErrorOrNil()
+	}
+
+	for _, mode := range d.DeviceEthernet.EthernetWakeOnLAN {
+		if mode == wakeOnLANDisabled {
+			if len(d.DeviceEthernet.EthernetWakeOnLAN) > 1 {
+				result = multierror.Append(result, fmt.Errorf("[%s] %q: %q can't be combined with other modes", "networking.os.device.ethernet.wakeOnLAN", d.DeviceInterface, mode))
+			}
+
+			continue
+		}
+
+		if _, ok := wakeOnLANModes[mode]; !ok {
+			result = multierror.Append(result, fmt.Errorf("[%s] %q: unknown mode %q", "networking.os.device.ethernet.wakeOnLAN", d.DeviceInterface, mode))
+		}
+	}
+
+	return result.ErrorOrNil()
+}
+
+// CheckDeviceWireguard ensures that the Wireguard keys, ports and peers are valid.
+//
+//nolint:gocyclo
+func CheckDeviceWireguard(d *Device) error {
+	var result *multierror.Error
+
+	if d == nil {
+		return fmt.Errorf("empty device")
+	}
+
+	wg := d.DeviceWireguardConfig
+	if wg == nil {
+		return nil
+	}
+
+	switch {
+	case wg.WireguardPrivateKey != "" && wg.WireguardGeneratePrivateKey:
+		result = multierror.Append(result, fmt.Errorf("[%s] %q: privateKey and generatePrivateKey are mutually exclusive", "networking.os.device.wireguard", d.DeviceInterface))
+	case wg.WireguardPrivateKey == "" && !wg.WireguardGeneratePrivateKey:
+		result = multierror.Append(result, fmt.Errorf("[%s] %q: %w", "networking.os.device.wireguard.privateKey", d.DeviceInterface, ErrRequiredSection))
+	case wg.WireguardPrivateKey != "":
+		if err := checkWireguardKey(wg.WireguardPrivateKey); err != nil {
+			result = multierror.Append(result, fmt.Errorf("[%s] %q: %w", "networking.os.device.wireguard.privateKey", d.DeviceInterface, err))
+		}
+	}
+
+	if wg.WireguardListenPort < 0 || wg.WireguardListenPort > math.MaxUint16 {
+		result = multierror.Append(result, fmt.Errorf("[%s] %q: invalid listen port %d", "networking.os.device.wireguard.listenPort", d.DeviceInterface, wg.WireguardListenPort))
+	}
+
+	for idx, peer := range wg.WireguardPeers {
+		field := "networking.os.device.wireguard.peers[" + strconv.Itoa(idx) + "]"
+
+		if err := checkWireguardKey(peer.WireguardPublicKey); err != nil {
+			result = multierror.Append(result, fmt.Errorf("[%s] %q: %w", field+".publicKey", d.DeviceInterface, err))
+		}
+
+		if peer.WireguardEndpoint != "" {
+			if _, _, err := net.SplitHostPort(peer.WireguardEndpoint); err != nil {
+				result = multierror.Append(result, fmt.Errorf("[%s] %q: %w", field+".endpoint", d.DeviceInterface, err))
+			}
+		}
+
+		if peer.WireguardPersistentKeepaliveInterval < 0 {
+			result = multierror.Append(result, fmt.Errorf("[%s] %q: negative keepalive interval", field+".persistentKeepaliveInterval", d.DeviceInterface))
+		}
+
+		for _, cidr := range peer.WireguardAllowedIPs {
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				result = multierror.Append(result, fmt.Errorf("[%s] %q: %w", field+".allowedIPs", d.DeviceInterface, err))
+			}
+		}
+	}
+
+	return result.ErrorOrNil()
+}
+
+func checkWireguardKey(key string) error {
+	if key == "" {
+		return ErrRequiredSection
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return fmt.Errorf("key is not base64 encoded: %w", err)
+	}
+
+	if len(decoded) != wireguardKeyLength {
+		return fmt.Errorf("key should be %d bytes long, got %d", wireguardKeyLength, len(decoded))
+	}
+
+	return nil
+}
+
+// Bond modes, see https://www.kernel.org/doc/Documentation/networking/bonding.txt.
+const (
+	bondModeBalanceRR    = "balance-rr"
+	bondModeActiveBackup = "active-backup"
+	bondModeBalanceXOR   = "balance-xor"
+	bondModeBroadcast    = "broadcast"
+	bondMode8023AD       = "802.3ad"
+	bondModeBalanceTLB   = "balance-tlb"
+	bondModeBalanceALB   = "balance-alb"
+
+	bondMaxARPIPTargets    = 16
+	bondMaxPacketsPerSlave = 65535
+)
+
+var (
+	bondModes            = []string{bondModeBalanceRR, bondModeActiveBackup, bondModeBalanceXOR, bondModeBroadcast, bondMode8023AD, bondModeBalanceTLB, bondModeBalanceALB}
+	bondXmitHashPolicies = []string{"layer2", "layer3+4", "layer2+3", "encap2+3", "encap3+4"}
+	bondLACPRates        = []string{"slow", "fast"}
+	bondADSelects        = []string{"stable", "bandwidth", "count"}
+	bondARPValidates     = []string{"none", "active", "backup", "all"}
+	bondARPAllTargets    = []string{"any", "all"}
+	bondPrimaryReselects = []string{"always", "better", "failure"}
+	bondFailOverMACs     = []string{"none", "active", "follow"}
+)
+
+// CheckDeviceBond ensures that the bond settings are valid and the options
+// are supported by the selected bond mode.
+//
+//nolint:gocyclo,cyclop
+func CheckDeviceBond(d *Device) error {
+	var result *multierror.Error
+
+	if d == nil {
+		return fmt.Errorf("empty device")
+	}
+
+	bond := d.DeviceBond
+	if bond == nil {
+		return nil
+	}
+
+	fail := func(format string, args ...interface{}) {
+		result = multierror.Append(result, fmt.Errorf("[%s] %q: %s", "networking.os.device.bond", d.DeviceInterface, fmt.Sprintf(format, args...)))
+	}
+
+	checkValue := func(option, value string, allowed []string) {
+		if value != "" && !inList(value, allowed) {
+			fail("invalid %s %q, expected one of %q", option, value, allowed)
+		}
+	}
+
+	// onlyIn reports an option which is set, but not supported in the bond mode
+	onlyIn := func(option string, set bool, modes ...string) {
+		if set && !inList(bond.BondMode, modes) {
+			fail("%s is only supported in %s mode(s)", option, strings.Join(modes, ", "))
+		}
+	}
+
+	if len(bond.BondInterfaces) == 0 {
+		fail("no bonded interfaces specified")
+	}
+
+	if bond.BondMode == "" {
+		fail("mode is required")
+	} else if !inList(bond.BondMode, bondModes) {
+		fail("invalid mode %q, expected one of %q", bond.BondMode, bondModes)
+
+		// mode-specific checks make no sense for the unknown mode
+		return result.ErrorOrNil()
+	}
+
+	checkValue("xmitHashPolicy", bond.BondHashPolicy, bondXmitHashPolicies)
+	checkValue("lacpRate", bond.BondLACPRate, bondLACPRates)
+	checkValue("adSelect", bond.BondADSelect, bondADSelects)
+	checkValue("arpValidate", bond.BondARPValidate, bondARPValidates)
+	checkValue("arpAllTargets", bond.BondARPAllTargets, bondARPAllTargets)
+	checkValue("primaryReselect", bond.BondPrimaryReselect, bondPrimaryReselects)
+	checkValue("failOverMac", bond.BondFailOverMac, bondFailOverMACs)
+
+	onlyIn("xmitHashPolicy", bond.BondHashPolicy != "", bondModeBalanceXOR, bondMode8023AD, bondModeBalanceTLB)
+	onlyIn("lacpRate", bond.BondLACPRate != "", bondMode8023AD)
+	onlyIn("adSelect", bond.BondADSelect != "", bondMode8023AD)
+	onlyIn("adActorSystem", bond.BondADActorSystem != "", bondMode8023AD)
+	onlyIn("adActorSysPrio", bond.BondADActorSysPrio != 0, bondMode8023AD)
+	onlyIn("adUserPortKey", bond.BondADUserPortKey != 0, bondMode8023AD)
+	onlyIn("minLinks", bond.BondMinLinks != 0, bondMode8023AD)
+	onlyIn("primary", bond.BondPrimary != "", bondModeActiveBackup, bondModeBalanceTLB, bondModeBalanceALB)
+	onlyIn("primaryReselect", bond.BondPrimaryReselect != "", bondModeActiveBackup, bondModeBalanceTLB, bondModeBalanceALB)
+	onlyIn("failOverMac", bond.BondFailOverMac != "", bondModeActiveBackup)
+	onlyIn("tlbDynamicLb", bond.BondTLBDynamicLB != 0, bondModeBalanceTLB)
+	onlyIn("packetsPerSlave", bond.BondPacketsPerSlave != 0, bondModeBalanceRR)
+	onlyIn("arpInterval", bond.BondARPInterval != 0, bondModeBalanceRR, bondModeActiveBackup, bondModeBalanceXOR, bondModeBroadcast)
+	onlyIn("arpValidate", bond.BondARPValidate != "", bondModeBalanceRR, bondModeActiveBackup, bondModeBalanceXOR, bondModeBroadcast)
+
+	if bond.BondADActorSystem != "" {
+		if _, err := net.ParseMAC(bond.BondADActorSystem); err != nil {
+			fail("invalid adActorSystem %q: %s", bond.BondADActorSystem, err)
+		}
+	}
+
+	if bond.BondPrimary != "" && !inList(bond.BondPrimary, bond.BondInterfaces) {
+		fail("primary interface %q is not one of the bonded interfaces", bond.BondPrimary)
+	}
+
+	if bond.BondTLBDynamicLB > 1 {
+		fail("tlbDynamicLb should be 0 or 1")
+	}
+
+	if bond.BondAllSlavesActive > 1 {
+		fail("allSlavesActive should be 0 or 1")
+	}
+
+	if bond.BondPacketsPerSlave > bondMaxPacketsPerSlave {
+		fail("packetsPerSlave should be in range 0-%d", bondMaxPacketsPerSlave)
+	}
+
+	// link monitoring: MII and ARP monitoring are mutually exclusive
+	if bond.BondMIIMon != 0 && bond.BondARPInterval != 0 {
+		fail("miimon and arpInterval are mutually exclusive")
+	}
+
+	if bond.BondUpDelay != 0 || bond.BondDownDelay != 0 {
+		switch {
+		case bond.BondMIIMon == 0:
+			fail("updelay and downdelay require miimon")
+		case bond.BondUpDelay%bond.BondMIIMon != 0 || bond.BondDownDelay%bond.BondMIIMon != 0:
+			fail("updelay and downdelay should be multiples of miimon (%d)", bond.BondMIIMon)
+		}
+	}
+
+	if bond.BondARPInterval != 0 && len(bond.BondARPIPTarget) == 0 {
+		fail("arpInterval requires arpIPTarget")
+	}
+
+	if len(bond.BondARPIPTarget) > bondMaxARPIPTargets {
+		fail("at most %d arpIPTarget addresses are supported", bondMaxARPIPTargets)
+	}
+
+	for _, target := range bond.BondARPIPTarget {
+		if ip := net.ParseIP(target); ip == nil || ip.To4() == nil {
+			fail("invalid arpIPTarget %q: %s", target, ErrInvalidAddress)
+		}
+	}
+
+	return result.ErrorOrNil()
+}
+
+func inList(value string, list []string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// routeTableLocal is the ID of the kernel-managed local routing table.
+const routeTableLocal = 255
+
 // CheckDeviceRoutes ensures that the specified routes are valid.
 func CheckDeviceRoutes(d *Device) error {
 	var result *multierror.Error
@@ -301,12 +1427,142 @@ func CheckDeviceRoutes(d *Device) error {
 	}
 
 	for idx, route := range d.DeviceRoutes {
-		if _, _, err := net.ParseCIDR(route.Network()); err != nil {
+		_, network, err := net.ParseCIDR(route.Network())
+		if err != nil {
 			result = multierror.Append(result, fmt.Errorf("[%s] %q: %w", "networking.os.device.route["+strconv.Itoa(idx)+"].Network", route.Network(), ErrInvalidAddress))
 		}
 
-		if ip := net.ParseIP(route.Gateway()); ip == nil {
-			result = multierror.Append(result, fmt.Errorf("[%s] %q: %w", "networking.os.device.route["+strconv.Itoa(idx)+"].Gateway", route.Gateway(), ErrInvalidAddress))
+		// empty gateway is a link-scoped route
+		if route.Gateway() != "" {
+			if ip := net.ParseIP(route.Gateway()); ip == nil {
+				result = multierror.Append(result, fmt.Errorf("[%s] %q: %w", "networking.os.device.route["+strconv.Itoa(idx)+"].Gateway", route.Gateway(), ErrInvalidAddress))
+			}
+		}
+
+		if route.Source() != "" {
+			ip := net.ParseIP(route.Source())
+
+			switch {
+			case ip == nil:
+				result = multierror.Append(result, fmt.Errorf("[%s] %q: %w", "networking.os.device.route["+strconv.Itoa(idx)+"].Source", route.Source(), ErrInvalidAddress))
+			case network != nil && (ip.To4() == nil) != (network.IP.To4() == nil):
+				result = multierror.Append(result, fmt.Errorf("[%s] %q: source address family doesn't match the route network", "networking.os.device.route["+strconv.Itoa(idx)+"].Source", route.Source()))
+			}
+		}
+
+		if route.Table() == routeTableLocal {
+			result = multierror.Append(result, fmt.Errorf("[%s] %d: local routing table is managed by the kernel", "networking.os.device.route["+strconv.Itoa(idx)+"].Table", route.Table()))
+		}
+	}
+
+	return result.ErrorOrNil()
+}
+
+// checkRoutingRule ensures that the policy routing rule is valid.
+func checkRoutingRule(idx int, rule *RoutingRule) error {
+	var result *multierror.Error
+
+	field := "networking.os.rule[" + strconv.Itoa(idx) + "]"
+
+	var from, to *net.IPNet
+
+	if rule.RuleFrom != "" {
+		var err error
+
+		if _, from, err = net.ParseCIDR(rule.RuleFrom); err != nil {
+			result = multierror.Append(result, fmt.Errorf("[%s] %q: %w", field+".From", rule.RuleFrom, ErrInvalidAddress))
+		}
+	}
+
+	if rule.RuleTo != "" {
+		var err error
+
+		if _, to, err = net.ParseCIDR(rule.RuleTo); err != nil {
+			result = multierror.Append(result, fmt.Errorf("[%s] %q: %w", field+".To", rule.RuleTo, ErrInvalidAddress))
+		}
+	}
+
+	if from != nil && to != nil && (from.IP.To4() == nil) != (to.IP.To4() == nil) {
+		result = multierror.Append(result, fmt.Errorf("[%s] from and to address families don't match", field))
+	}
+
+	if rule.RuleTable == 0 {
+		result = multierror.Append(result, fmt.Errorf("[%s]: %w", field+".Table", ErrRequiredSection))
+	}
+
+	return result.ErrorOrNil()
+}
+
+// validateHostname validates the hostname template and the hostname sources.
+func (n *NetworkConfig) validateHostname() error {
+	var result *multierror.Error
+
+	os.Expand(n.NetworkHostname, func(variable string) string {
+		switch variable {
+		case HostnameVariableUUID, HostnameVariableUUIDShort, HostnameVariableIP:
+		default:
+			result = multierror.Append(result, fmt.Errorf("[%s] %q: unknown hostname template variable %q", "networking.os.hostname", n.NetworkHostname, variable))
+		}
+
+		return ""
+	})
+
+	seen := map[string]struct{}{}
+
+	for _, source := range n.NetworkHostnameSources {
+		switch source {
+		case HostnameSourceConfig, HostnameSourceKernel, HostnameSourcePlatform, HostnameSourceDHCP, HostnameSourceStable:
+		default:
+			result = multierror.Append(result, fmt.Errorf("[%s] %q: unknown hostname source", "networking.os.hostnameSources", source))
+		}
+
+		if _, duplicate := seen[source]; duplicate {
+			result = multierror.Append(result, fmt.Errorf("[%s] %q: duplicate hostname source", "networking.os.hostnameSources", source))
+		}
+
+		seen[source] = struct{}{}
+	}
+
+	return result.ErrorOrNil()
+}
+
+// Validate validates the firewall configuration.
+//
+//nolint:gocyclo
+func (f *FirewallConfig) Validate() error {
+	var result *multierror.Error
+
+	switch f.FirewallDefaultAction {
+	case "", FirewallActionAccept, FirewallActionBlock:
+	default:
+		result = multierror.Append(result, fmt.Errorf("[%s] %q: default action should be either %q or %q", "networking.os.firewall.defaultAction", f.FirewallDefaultAction, FirewallActionAccept, FirewallActionBlock))
+	}
+
+	for idx, rule := range f.FirewallRules {
+		if rule.FirewallRulePortSelector == nil || len(rule.FirewallRulePortSelector.PortSelectorPorts) == 0 {
+			result = multierror.Append(result, fmt.Errorf("[%s] %q: port selector should list at least one port", fmt.Sprintf("networking.os.firewall.rules[%d].portSelector", idx), rule.FirewallRuleName))
+		} else {
+			switch rule.FirewallRulePortSelector.PortSelectorProtocol {
+			case "tcp", "udp":
+			default:
+				result = multierror.Append(result, fmt.Errorf("[%s] %q: protocol should be either %q or %q", fmt.Sprintf("networking.os.firewall.rules[%d].portSelector.protocol", idx), rule.FirewallRulePortSelector.PortSelectorProtocol, "tcp", "udp"))
+			}
+
+			for _, port := range rule.FirewallRulePortSelector.PortSelectorPorts {
+				if _, err := parsePortRange(port); err != nil {
+					result = multierror.Append(result, fmt.Errorf("[%s] %q: invalid port or port range", fmt.Sprintf("networking.os.firewall.rules[%d].portSelector.ports", idx), port))
+				}
+			}
+		}
+
+		if len(rule.FirewallRuleIngress) == 0 {
+			result = multierror.Append(result, fmt.Errorf("[%s] %q: rule should have at least one ingress subnet", fmt.Sprintf("networking.os.firewall.rules[%d].ingress", idx), rule.FirewallRuleName))
+		}
+
+		for _, ingress := range rule.FirewallRuleIngress {
+			if _, _, err := net.ParseCIDR(ingress.IngressSubnet); err != nil {
+				result = multierror.Append(result, fmt.Errorf("[%s] %q: subnet should be in CIDR notation", fmt.Sprintf("networking.os.firewall.rules[%d].ingress", idx), ingress.IngressSubnet))
+			}
 		}
 	}
 
@@ -8,7 +8,10 @@ import (
 	"fmt"
 	"net/url"
 	"testing"
+	"time"
 
+	"github.com/AlekSi/pointer"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -39,6 +42,7 @@ func TestValidate(t *testing.T) {
 		config           *v1alpha1.Config
 		requiresInstall  bool
 		strict           bool
+		versionContract  *config.VersionContract
 		expectedWarnings []string
 		expectedError    string
 	}{
@@ -136,7 +140,336 @@ func TestValidate(t *testing.T) {
 			},
 			requiresInstall: true,
 		},
+		{
+			name: "MachineInstallExtraKernelArgs",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "join",
+					MachineInstall: &v1alpha1.InstallConfig{
+						InstallDisk:            "/dev/vda",
+						InstallExtraKernelArgs: []string{"mitigations=off", "-pti", "-console=ttyS0", "-=off"},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "1 error occurred:\n\t* invalid extra kernel arg \"-=off\": argument key to remove is required\n\n",
+		},
+		{
+			name: "MachineInstallBootloaderSettings",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "join",
+					MachineInstall: &v1alpha1.InstallConfig{
+						InstallDisk:           "/dev/vda",
+						InstallBootloaderType: "sd-boot",
+						InstallConsoles: []*v1alpha1.InstallConsole{
+							{
+								ConsoleDevice:   "ttyS0",
+								ConsoleBaudRate: 115200,
+							},
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "MachineInstallBootloaderSettingsInvalid",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "join",
+					MachineInstall: &v1alpha1.InstallConfig{
+						InstallDisk:           "/dev/vda",
+						InstallBootloaderType: "lilo",
+						InstallConsoles: []*v1alpha1.InstallConsole{
+							{
+								ConsoleDevice: "/dev/ttyS0",
+							},
+							{
+								ConsoleDevice:   "ttyS1",
+								ConsoleBaudRate: -1,
+							},
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "3 errors occurred:\n\t* invalid bootloader type \"lilo\": expected \"grub\" or \"sd-boot\"\n\t* invalid console device \"/dev/ttyS0\"\n\t* invalid console \"ttyS1\" baud rate -1\n\n",
+		},
+		{
+			name: "MachineInstallSecureBoot",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "join",
+					MachineInstall: &v1alpha1.InstallConfig{
+						InstallDisk:           "/dev/vda",
+						InstallBootloaderType: "sd-boot",
+						InstallSecureBoot: &v1alpha1.InstallSecureBootConfig{
+							SecureBootSigningKey:  "/secureboot/db.key",
+							SecureBootSigningCert: "/secureboot/db.crt",
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "MachineInstallSecureBootInvalid",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "join",
+					MachineInstall: &v1alpha1.InstallConfig{
+						InstallDisk: "/dev/vda",
+						InstallSecureBoot: &v1alpha1.InstallSecureBootConfig{
+							SecureBootSigningKey: "/secureboot/db.key",
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "2 errors occurred:\n\t* SecureBoot requires bootloader type \"sd-boot\"\n\t* SecureBoot requires signing key and certificate\n\n",
+		},
 
+		{
+			name: "AESCBCAdditionalEncryptionKeys",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "join",
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+					ClusterAESCBCEncryptionSecret:  "z01mye6j16bspJYtTB/5SFX8j7Ph4JXxM2Xuu4vsBPM=",
+					ClusterAESCBCEncryptionKeyName: "key2",
+					ClusterAESCBCAdditionalEncryptionKeys: []v1alpha1.AESCBCEncryptionKeyConfig{
+						{
+							KeyName:   "key1",
+							KeySecret: "0VfPGSFlGnTR5f2EZwv7GYpyFNNy0Wp8uAhmB4VK4BM=",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "AESCBCAdditionalEncryptionKeysDuplicate",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "join",
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+					ClusterAESCBCEncryptionSecret: "z01mye6j16bspJYtTB/5SFX8j7Ph4JXxM2Xuu4vsBPM=",
+					ClusterAESCBCAdditionalEncryptionKeys: []v1alpha1.AESCBCEncryptionKeyConfig{
+						{
+							KeyName:   "key1",
+							KeySecret: "0VfPGSFlGnTR5f2EZwv7GYpyFNNy0Wp8uAhmB4VK4BM=",
+						},
+						{
+							KeyName: "key2",
+						},
+					},
+				},
+			},
+			expectedError: "2 errors occurred:\n\t* [cluster.aescbcAdditionalEncryptionKeys] \"key1\": duplicate key name\n\t* [cluster.aescbcAdditionalEncryptionKeys] \"key2\": key secret is required\n\n",
+		},
+		{
+			name: "SecretsEncryptionSecretbox",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "join",
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+					APIServerConfig: &v1alpha1.APIServerConfig{
+						ContainerImage: "k8s.gcr.io/kube-apiserver:v1.25.0",
+					},
+					SecretsEncryptionConfig: &v1alpha1.SecretsEncryptionConfig{
+						EncryptionProvider: "secretbox",
+						EncryptionSecretbox: &v1alpha1.SecretboxEncryptionConfig{
+							SecretboxSecret: "rHvHXTv4qDXbgQqbxYY5RRAx4wK1Cd8Xu7MS9T3qaOs=",
+						},
+						EncryptionKMS: &v1alpha1.KMSEncryptionConfig{
+							KMSName:     "vault",
+							KMSEndpoint: "unix:///var/run/kms/vault.sock",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "SecretsEncryptionNotConfigured",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "join",
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+					APIServerConfig: &v1alpha1.APIServerConfig{
+						ContainerImage: "k8s.gcr.io/kube-apiserver:v1.25.0",
+					},
+					SecretsEncryptionConfig: &v1alpha1.SecretsEncryptionConfig{
+						EncryptionProvider: "kms",
+						EncryptionSecretbox: &v1alpha1.SecretboxEncryptionConfig{
+							SecretboxSecret: "Zm9v",
+						},
+					},
+				},
+			},
+			expectedError: "2 errors occurred:\n\t* [cluster.secretsEncryption.kms] kms provider is selected, but not configured\n\t* [cluster.secretsEncryption.secretbox.secret] secret should be 32 bytes, base64 encoded\n\n",
+		},
+		{
+			name: "SecretsEncryptionKMS",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "join",
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+					APIServerConfig: &v1alpha1.APIServerConfig{
+						ContainerImage: "k8s.gcr.io/kube-apiserver:v1.26.1@sha256:0123456789abcdef",
+					},
+					SecretsEncryptionConfig: &v1alpha1.SecretsEncryptionConfig{
+						EncryptionProvider: "kms",
+						EncryptionKMS: &v1alpha1.KMSEncryptionConfig{
+							KMSName:     "vault",
+							KMSEndpoint: "unix:///var/run/kms/vault.sock",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "SecretsEncryptionKMSKubernetesVersion",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "join",
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+					APIServerConfig: &v1alpha1.APIServerConfig{
+						ContainerImage: "k8s.gcr.io/kube-apiserver:v1.21.1",
+					},
+					SecretsEncryptionConfig: &v1alpha1.SecretsEncryptionConfig{
+						EncryptionProvider: "aescbc",
+						EncryptionKMS: &v1alpha1.KMSEncryptionConfig{
+							KMSName:     "vault",
+							KMSEndpoint: "unix:///var/run/kms/vault.sock",
+						},
+					},
+				},
+			},
+			expectedError: "1 error occurred:\n\t* [cluster.secretsEncryption.kms] KMS v2 plugins require Kubernetes 1.25 or later, API server image is \"k8s.gcr.io/kube-apiserver:v1.21.1\"\n\n",
+		},
+		{
+			name: "SecretsEncryptionInvalidKMS",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "join",
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+					APIServerConfig: &v1alpha1.APIServerConfig{
+						ContainerImage: "k8s.gcr.io/kube-apiserver:v1.25.0",
+					},
+					SecretsEncryptionConfig: &v1alpha1.SecretsEncryptionConfig{
+						EncryptionProvider: "aesgcm",
+						EncryptionKMS: &v1alpha1.KMSEncryptionConfig{
+							KMSEndpoint: "/var/run/kms/vault.sock",
+						},
+					},
+				},
+			},
+			expectedError: "3 errors occurred:\n\t* [cluster.secretsEncryption.provider] \"aesgcm\": unsupported provider\n\t* [cluster.secretsEncryption.kms.name] name is required\n\t* [cluster.secretsEncryption.kms.endpoint] \"/var/run/kms/vault.sock\": endpoint should be an absolute path to the unix socket (unix:///path/to/socket)\n\n",
+		},
+		{
+			name: "ManifestsSyncNegativeInterval",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "join",
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+					ManifestsSyncConfig: &v1alpha1.ManifestsSyncConfig{
+						SyncInterval: -time.Minute,
+					},
+				},
+			},
+			expectedError: "1 error occurred:\n\t* [cluster.manifestsSync.interval] -1m0s: interval should be positive\n\n",
+		},
 		{
 			name: "ExternalCloudProviderEnabled",
 			config: &v1alpha1.Config{
@@ -242,15 +575,1385 @@ func TestValidate(t *testing.T) {
 			},
 			expectedError: "1 error occurred:\n\t* invalid external cloud provider manifest url \"/manifest.yaml\": hostname must not be blank\n\n",
 		},
-	} {
-		test := test
-
-		t.Run(test.name, func(t *testing.T) {
-			t.Parallel()
-
-			opts := []config.ValidationOption{config.WithLocal()}
-			if test.strict {
-				opts = append(opts, config.WithStrict())
+		{
+			name: "Virtualization",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "join",
+					MachineVirtualization: &v1alpha1.VirtualizationConfig{
+						VirtualizationNested: true,
+						VirtualizationKVMOptions: map[string]string{
+							"ignore_msrs": "1",
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "VirtualizationInvalidOptions",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "join",
+					MachineVirtualization: &v1alpha1.VirtualizationConfig{
+						VirtualizationKVMOptions: map[string]string{
+							"ignore msrs": "1",
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "1 error occurred:\n\t* invalid kvm module parameter name \"ignore msrs\"\n\n",
+		},
+		{
+			name: "Bond",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "join",
+					MachineNetwork: &v1alpha1.NetworkConfig{
+						NetworkInterfaces: []*v1alpha1.Device{
+							{
+								DeviceInterface: "bond0",
+								DeviceDHCP:      true,
+								DeviceBond: &v1alpha1.Bond{
+									BondMode:       "802.3ad",
+									BondLACPRate:   "fast",
+									BondHashPolicy: "layer3+4",
+									BondInterfaces: []string{"eth0", "eth1"},
+									BondMIIMon:     100,
+									BondUpDelay:    200,
+									BondDownDelay:  200,
+								},
+							},
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "BondInvalidModeOptions",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "join",
+					MachineNetwork: &v1alpha1.NetworkConfig{
+						NetworkInterfaces: []*v1alpha1.Device{
+							{
+								DeviceInterface: "bond0",
+								DeviceDHCP:      true,
+								DeviceBond: &v1alpha1.Bond{
+									BondMode:       "active-backup",
+									BondLACPRate:   "fast",
+									BondInterfaces: []string{"eth0", "eth1"},
+									BondPrimary:    "eth2",
+									BondMIIMon:     100,
+									BondUpDelay:    150,
+								},
+							},
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "3 errors occurred:\n\t* [networking.os.device.bond] \"bond0\": lacpRate is only supported in 802.3ad mode(s)\n\t* [networking.os.device.bond] \"bond0\": primary interface \"eth2\" is not one of the bonded interfaces\n\t* [networking.os.device.bond] \"bond0\": updelay and downdelay should be multiples of miimon (100)\n\n",
+		},
+		{
+			name: "BondInvalidMode",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "join",
+					MachineNetwork: &v1alpha1.NetworkConfig{
+						NetworkInterfaces: []*v1alpha1.Device{
+							{
+								DeviceInterface: "bond0",
+								DeviceDHCP:      true,
+								DeviceBond: &v1alpha1.Bond{
+									BondMode:       "lacp",
+									BondInterfaces: []string{"eth0"},
+								},
+							},
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "1 error occurred:\n\t* [networking.os.device.bond] \"bond0\": invalid mode \"lacp\", expected one of [\"balance-rr\" \"active-backup\" \"balance-xor\" \"broadcast\" \"802.3ad\" \"balance-tlb\" \"balance-alb\"]\n\n",
+		},
+		{
+			name: "Vlans",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "join",
+					MachineNetwork: &v1alpha1.NetworkConfig{
+						NetworkInterfaces: []*v1alpha1.Device{
+							{
+								DeviceInterface: "bond0",
+								DeviceVlans: []*v1alpha1.Vlan{
+									{
+										VlanID:        100,
+										VlanAddresses: []string{"192.168.100.10/24", "2001:db8::10/64"},
+									},
+									{
+										VlanID:   200,
+										VlanDHCP: true,
+									},
+								},
+							},
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "VlansInvalid",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "join",
+					MachineNetwork: &v1alpha1.NetworkConfig{
+						NetworkInterfaces: []*v1alpha1.Device{
+							{
+								DeviceInterface: "enp0s20f0u1",
+								DeviceVlans: []*v1alpha1.Vlan{
+									{
+										VlanID: 0,
+									},
+									{
+										VlanID:        100,
+										VlanDHCP:      true,
+										VlanAddresses: []string{"192.168.100.10/24"},
+									},
+									{
+										VlanID:        100,
+										VlanAddresses: []string{"192.168.100.10"},
+									},
+									{
+										VlanID: 1000,
+									},
+								},
+							},
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "5 errors occurred:\n\t* [networking.os.device.vlan] \"enp0s20f0u1\": invalid VLAN ID 0, expected 1-4094\n\t* [networking.os.device.vlan] \"enp0s20f0u1.100\": invalid network device addressing method\n\t* [networking.os.device.vlan] \"enp0s20f0u1\": duplicate VLAN ID 100\n\t* [networking.os.device.vlan.addresses] \"enp0s20f0u1.100\": invalid CIDR address: 192.168.100.10\n\t* [networking.os.device.vlan] \"enp0s20f0u1\": VLAN interface name \"enp0s20f0u1.1000\" is longer than 15 characters\n\n",
+		},
+		{
+			name: "Wireguard",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "join",
+					MachineNetwork: &v1alpha1.NetworkConfig{
+						NetworkInterfaces: []*v1alpha1.Device{
+							{
+								DeviceInterface: "wg0",
+								DeviceCIDR:      "192.168.2.1/24",
+								DeviceWireguardConfig: &v1alpha1.DeviceWireguardConfig{
+									WireguardPrivateKey: "IGyhPBbR86sbBJagAUIacfJPyo1pGHg3GPY4jLSVGVg=",
+									WireguardListenPort: 51111,
+									WireguardPeers: []*v1alpha1.DeviceWireguardPeer{
+										{
+											WireguardPublicKey:                   "I5ICf6b7xt1rAy7hnlbjJ5Xtk4QheN57jabAmPoRuuw=",
+											WireguardEndpoint:                    "192.168.1.2:51111",
+											WireguardPersistentKeepaliveInterval: 10 * time.Second,
+											WireguardAllowedIPs:                  []string{"192.168.2.0/24"},
+										},
+									},
+								},
+							},
+							{
+								DeviceInterface: "wg1",
+								DeviceWireguardConfig: &v1alpha1.DeviceWireguardConfig{
+									WireguardGeneratePrivateKey: true,
+								},
+							},
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "WireguardInvalid",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "join",
+					MachineNetwork: &v1alpha1.NetworkConfig{
+						NetworkInterfaces: []*v1alpha1.Device{
+							{
+								DeviceInterface: "wg0",
+								DeviceWireguardConfig: &v1alpha1.DeviceWireguardConfig{
+									WireguardPrivateKey: "a2V5",
+									WireguardListenPort: 70000,
+									WireguardPeers: []*v1alpha1.DeviceWireguardPeer{
+										{
+											WireguardEndpoint:   "192.168.1.2",
+											WireguardAllowedIPs: []string{"192.168.2.0"},
+										},
+									},
+								},
+							},
+							{
+								DeviceInterface: "wg1",
+								DeviceWireguardConfig: &v1alpha1.DeviceWireguardConfig{
+									WireguardPrivateKey:         "IGyhPBbR86sbBJagAUIacfJPyo1pGHg3GPY4jLSVGVg=",
+									WireguardGeneratePrivateKey: true,
+								},
+							},
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "6 errors occurred:\n\t* [networking.os.device.wireguard.privateKey] \"wg0\": key should be 32 bytes long, got 3\n\t* [networking.os.device.wireguard.listenPort] \"wg0\": invalid listen port 70000\n\t* [networking.os.device.wireguard.peers[0].publicKey] \"wg0\": required config section\n\t* [networking.os.device.wireguard.peers[0].endpoint] \"wg0\": address 192.168.1.2: missing port in address\n\t* [networking.os.device.wireguard.peers[0].allowedIPs] \"wg0\": invalid CIDR address: 192.168.2.0\n\t* [networking.os.device.wireguard] \"wg1\": privateKey and generatePrivateKey are mutually exclusive\n\n",
+		},
+		{
+			name: "VIPs",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "controlplane",
+					MachineNetwork: &v1alpha1.NetworkConfig{
+						NetworkInterfaces: []*v1alpha1.Device{
+							{
+								DeviceInterface: "eth0",
+								DeviceCIDR:      "192.168.1.2/24",
+								DeviceVIPConfig: &v1alpha1.DeviceVIPConfig{
+									SharedIP: "192.168.1.10",
+									EquinixMetalConfig: &v1alpha1.VIPEquinixMetalConfig{
+										EquinixMetalAPIToken: "token",
+									},
+								},
+								DeviceVlans: []*v1alpha1.Vlan{
+									{
+										VlanID:        100,
+										VlanAddresses: []string{"192.168.100.2/24"},
+										VlanVIP: &v1alpha1.DeviceVIPConfig{
+											SharedIP: "192.168.100.10",
+											EquinixMetalConfig: &v1alpha1.VIPEquinixMetalConfig{
+												EquinixMetalBGP: true,
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "VIPsInvalid",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "controlplane",
+					MachineNetwork: &v1alpha1.NetworkConfig{
+						NetworkInterfaces: []*v1alpha1.Device{
+							{
+								DeviceInterface: "eth0",
+								DeviceCIDR:      "192.168.1.2/24",
+								DeviceVIPConfig: &v1alpha1.DeviceVIPConfig{
+									SharedIP:           "192.168.1.10",
+									EquinixMetalConfig: &v1alpha1.VIPEquinixMetalConfig{},
+									HCloudConfig:       &v1alpha1.VIPHCloudConfig{},
+								},
+								DeviceVlans: []*v1alpha1.Vlan{
+									{
+										VlanID:        100,
+										VlanAddresses: []string{"192.168.100.2/24"},
+										VlanVIP: &v1alpha1.DeviceVIPConfig{
+											SharedIP: "fake",
+										},
+									},
+									{
+										VlanID:        200,
+										VlanAddresses: []string{"fd00::2/64"},
+										VlanVIP: &v1alpha1.DeviceVIPConfig{
+											SharedIP: "fd00::10",
+											EquinixMetalConfig: &v1alpha1.VIPEquinixMetalConfig{
+												EquinixMetalBGP: true,
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "5 errors occurred:\n\t* [networking.os.device.vip] \"192.168.1.10\": equinixMetal and hcloud are mutually exclusive\n\t* [networking.os.device.vip.equinixMetal.apiToken] \"192.168.1.10\": required config section\n\t* [networking.os.device.vip.hcloud.apiToken] \"192.168.1.10\": required config section\n\t* [networking.os.device.vlan.vip] failed to parse \"fake\" as IP address\n\t* [networking.os.device.vlan.vip.equinixMetal.bgp] \"fd00::10\": BGP announcements are supported only for IPv4 addresses\n\n",
+		},
+		{
+			name: "DHCPOptionsInvalid",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "join",
+					MachineNetwork: &v1alpha1.NetworkConfig{
+						NetworkInterfaces: []*v1alpha1.Device{
+							{
+								DeviceInterface: "eth0",
+								DeviceDHCP:      true,
+								DeviceDHCPOptions: &v1alpha1.DHCPOptions{
+									DHCPUIDv6: "zz",
+								},
+							},
+							{
+								DeviceInterface: "eth1",
+								DeviceDHCP:      true,
+								DeviceDHCPOptions: &v1alpha1.DHCPOptions{
+									DHCPUIDv6: "0001",
+								},
+							},
+							{
+								DeviceInterface: "eth2",
+								DeviceDHCP:      true,
+								DeviceDHCPOptions: &v1alpha1.DHCPOptions{
+									DHCPIPv6:  pointer.ToBool(true),
+									DHCPSLAAC: pointer.ToBool(true),
+									DHCPUIDv6: "00030001525400123456",
+								},
+							},
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "2 errors occurred:\n\t* [networking.os.device.dhcpOptions.duidv6] \"eth0\": DUID should be hex encoded: encoding/hex: invalid byte: U+007A 'z'\n\t* [networking.os.device.dhcpOptions.duidv6] \"eth1\": DUID length should be 3-130 bytes, got 2\n\n",
+		},
+		{
+			name: "RoutingInvalid",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "join",
+					MachineNetwork: &v1alpha1.NetworkConfig{
+						NetworkInterfaces: []*v1alpha1.Device{
+							{
+								DeviceInterface: "eth0",
+								DeviceCIDR:      "10.0.0.10/8",
+								DeviceRoutes: []*v1alpha1.Route{
+									{
+										RouteNetwork: "10.0.0.0/8",
+										RouteGateway: "10.0.0.1",
+										RouteSource:  "fd00::1",
+									},
+									{
+										RouteNetwork: "fake",
+										RouteTable:   255,
+									},
+									{
+										RouteNetwork: "0.0.0.0/0",
+										RouteGateway: "10.0.0.1",
+										RouteSource:  "10.0.0.10",
+										RouteMTU:     1450,
+										RouteTable:   100,
+									},
+								},
+							},
+						},
+						NetworkRules: []*v1alpha1.RoutingRule{
+							{
+								RuleFrom: "10.0.0.0/8",
+								RuleTo:   "fd00::/64",
+							},
+							{
+								RuleFrom:  "10.0.0.0/8",
+								RuleTable: 100,
+							},
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "5 errors occurred:\n\t* [networking.os.device.route[0].Source] \"fd00::1\": source address family doesn't match the route network\n\t* [networking.os.device.route[1].Network] \"fake\": invalid network address\n\t* [networking.os.device.route[1].Table] 255: local routing table is managed by the kernel\n\t* [networking.os.rule[0]] from and to address families don't match\n\t* [networking.os.rule[0].Table]: required config section\n\n",
+		},
+		{
+			name: "DeviceSelectorInvalid",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "join",
+					MachineNetwork: &v1alpha1.NetworkConfig{
+						NetworkInterfaces: []*v1alpha1.Device{
+							{
+								DeviceInterface: "eth0",
+								DeviceSelector: &v1alpha1.NetworkDeviceSelector{
+									NetworkDeviceKernelDriver: "virtio_net",
+								},
+								DeviceDHCP: true,
+							},
+							{
+								DeviceSelector: &v1alpha1.NetworkDeviceSelector{},
+								DeviceDHCP:     true,
+							},
+							{
+								DeviceSelector: &v1alpha1.NetworkDeviceSelector{
+									NetworkDeviceHardwareAddress: "fake",
+								},
+								DeviceDummy: true,
+							},
+							{
+								DeviceSelector: &v1alpha1.NetworkDeviceSelector{
+									NetworkDeviceBusPath: "0000:00:03.0",
+									NetworkDeviceSpeed:   10000,
+								},
+								DeviceDHCP: true,
+							},
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "4 errors occurred:\n\t* [networking.os.device.interface] \"eth0\": interface and deviceSelector are mutually exclusive\n\t* [networking.os.device.deviceSelector]: required config section\n\t* [networking.os.device.deviceSelector.hardwareAddr]: address fake: invalid MAC address\n\t* [networking.os.device.deviceSelector]: deviceSelector can't be used with bond, bridge, wireguard or dummy interfaces\n\n",
+		},
+		{
+			name: "MacvtapParentInvalid",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "join",
+					MachineNetwork: &v1alpha1.NetworkConfig{
+						NetworkInterfaces: []*v1alpha1.Device{
+							{
+								DeviceInterface:     "eth1",
+								DeviceMacvtapParent: true,
+							},
+							{
+								DeviceInterface:     "eth2",
+								DeviceMacvtapParent: true,
+								DeviceDHCP:          true,
+							},
+							{
+								DeviceInterface:     "dummy0",
+								DeviceMacvtapParent: true,
+								DeviceDummy:         true,
+							},
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "2 errors occurred:\n\t* [networking.os.device.macvtapParent] \"eth2\": macvtap parent interface can't be configured with cidr or dhcp\n\t* [networking.os.device.macvtapParent] \"dummy0\": dummy and wireguard interfaces can't be macvtap parents\n\n",
+		},
+		{
+			name: "EthernetInvalid",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "join",
+					MachineNetwork: &v1alpha1.NetworkConfig{
+						NetworkInterfaces: []*v1alpha1.Device{
+							{
+								DeviceInterface: "eth0",
+								DeviceDHCP:      true,
+								DeviceMTU:       10,
+								DeviceEthernet: &v1alpha1.EthernetConfig{
+									EthernetWakeOnLAN: []string{"magic", "disabled", "fake"},
+								},
+							},
+							{
+								DeviceInterface: "eth1",
+								DeviceDHCP:      true,
+								DeviceMTU:       9000,
+								DeviceEthernet: &v1alpha1.EthernetConfig{
+									EthernetWakeOnLAN: []string{"magic", "phy"},
+									EthernetOffload: &v1alpha1.EthernetOffloadConfig{
+										OffloadGRO: pointer.ToBool(false),
+									},
+									EthernetRings: &v1alpha1.EthernetRingsConfig{
+										RingsRX: 4096,
+									},
+								},
+							},
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "3 errors occurred:\n\t* [networking.os.device.mtu] \"eth0\": MTU 10 is out of range 68-65536\n\t* [networking.os.device.ethernet.wakeOnLAN] \"eth0\": \"disabled\" can't be combined with other modes\n\t* [networking.os.device.ethernet.wakeOnLAN] \"eth0\": unknown mode \"fake\"\n\n",
+		},
+		{
+			name: "KubeSpanInvalidEndpoint",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "join",
+					MachineNetwork: &v1alpha1.NetworkConfig{
+						NetworkKubeSpan: &v1alpha1.KubeSpan{
+							KubeSpanEnabled:           true,
+							KubeSpanDiscoveryEndpoint: "discovery.example.com",
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ClusterID:     "cluster-id",
+					ClusterSecret: "cluster-secret",
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "1 error occurred:\n\t* [networking.os.kubespan.discoveryEndpoint] \"discovery.example.com\": discovery endpoint should be an http(s) URL\n\n",
+		},
+		{
+			name: "KubeSpanNoEndpoint",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "join",
+					MachineNetwork: &v1alpha1.NetworkConfig{
+						NetworkKubeSpan: &v1alpha1.KubeSpan{
+							KubeSpanEnabled: true,
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ClusterID:     "cluster-id",
+					ClusterSecret: "cluster-secret",
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "1 error occurred:\n\t* [networking.os.kubespan.discoveryEndpoint] discovery endpoint is required if KubeSpan is enabled\n\n",
+		},
+		{
+			name: "KubeSpanNoClusterSecret",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "join",
+					MachineNetwork: &v1alpha1.NetworkConfig{
+						NetworkKubeSpan: &v1alpha1.KubeSpan{
+							KubeSpanEnabled:           true,
+							KubeSpanDiscoveryEndpoint: "https://discovery.example.com/",
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ClusterID: "cluster-id",
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "1 error occurred:\n\t* [cluster.id] cluster ID and secret are required if KubeSpan is enabled\n\n",
+		},
+		{
+			name: "CRIConfigPatches",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "join",
+					MachineCRI: &v1alpha1.CRIConfig{
+						CRIConfigPatches: []string{
+							"[plugins.cri.containerd.runtimes.runc.options]\nSystemdCgroup = true\n",
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "CRIConfigPatchesConflict",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "join",
+					MachineCRI: &v1alpha1.CRIConfig{
+						CRIConfigPatches: []string{
+							"[plugins.cri]\nmax_container_log_line_size = 32768\n",
+							"[plugins.cri.containerd]\nruntimes = \"runc\"\n",
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "1 error occurred:\n\t* [machine.cri.configPatches] error applying CRI config patch 1: \"plugins.cri.containerd.runtimes\": table and value can't replace each other\n\n",
+		},
+		{
+			name: "RegistrationInvalid",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "join",
+					MachineRegistration: &v1alpha1.RegistrationConfig{
+						RegistrationEndpoint:          "inventory.example.com",
+						RegistrationHeartbeatInterval: -time.Minute,
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "2 errors occurred:\n\t* [machine.registration.endpoint] \"inventory.example.com\": registration endpoint should be an http(s) URL\n\t* [machine.registration.heartbeatInterval] \"-1m0s\": heartbeat interval should not be negative\n\n",
+		},
+		{
+			name: "IdentityKMSInvalid",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "join",
+					MachineIdentity: &v1alpha1.IdentityConfig{
+						IdentityKMS: &v1alpha1.IdentityKMSConfig{
+							KMSEndpoint: "/var/run/kms/vault.sock",
+							KMSTimeout:  -time.Second,
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "2 errors occurred:\n\t* [machine.identity.kms.endpoint] \"/var/run/kms/vault.sock\": endpoint should be an absolute path to the unix socket (unix:///path/to/socket)\n\t* [machine.identity.kms.timeout] -1s: timeout should be positive\n\n",
+		},
+		{
+			name: "SideroLinkInvalidAPIURL",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "join",
+					MachineNetwork: &v1alpha1.NetworkConfig{
+						NetworkSideroLink: &v1alpha1.SideroLink{
+							SideroLinkAPIURL: "siderolink.example.com:8099",
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "1 error occurred:\n\t* [networking.os.sideroLink.apiUrl] \"siderolink.example.com:8099\": SideroLink API URL should be an http(s) URL\n\n",
+		},
+		{
+			name: "FirewallInvalid",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "join",
+					MachineNetwork: &v1alpha1.NetworkConfig{
+						NetworkFirewall: &v1alpha1.FirewallConfig{
+							FirewallDefaultAction: "drop",
+							FirewallRules: []*v1alpha1.FirewallRule{
+								{
+									FirewallRuleName: "kubelet",
+									FirewallRulePortSelector: &v1alpha1.FirewallPortSelector{
+										PortSelectorPorts:    []string{"10250", "80-70"},
+										PortSelectorProtocol: "icmp",
+									},
+									FirewallRuleIngress: []*v1alpha1.FirewallIngressRule{
+										{
+											IngressSubnet: "10.0.0.0/8",
+										},
+										{
+											IngressSubnet: "10.0.0.1",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "4 errors occurred:\n\t* [networking.os.firewall.defaultAction] \"drop\": default action should be either \"accept\" or \"block\"\n\t* [networking.os.firewall.rules[0].portSelector.protocol] \"icmp\": protocol should be either \"tcp\" or \"udp\"\n\t* [networking.os.firewall.rules[0].portSelector.ports] \"80-70\": invalid port or port range\n\t* [networking.os.firewall.rules[0].ingress] \"10.0.0.1\": subnet should be in CIDR notation\n\n",
+		},
+		{
+			name: "ResolversInvalid",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "join",
+					MachineNetwork: &v1alpha1.NetworkConfig{
+						NameServers:          []string{"1.1.1.1", "dns.example.com"},
+						NetworkSearchDomains: []string{"example.com", "-example"},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "2 errors occurred:\n\t* [networking.os.nameservers] \"dns.example.com\": nameserver should be an IP address\n\t* [networking.os.searchDomains] \"-example\": search domain should be a valid DNS name\n\n",
+		},
+		{
+			name: "HealthzInvalidPort",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "join",
+					MachineHealthz: &v1alpha1.HealthzConfig{
+						HealthzEnabled: true,
+						HealthzPort:    70000,
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "1 error occurred:\n\t* invalid healthz port 70000\n\n",
+		},
+		{
+			name: "MetricsInvalidPort",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "join",
+					MachineMetrics: &v1alpha1.MetricsConfig{
+						MetricsEnabled: true,
+						MetricsPort:    -1,
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "2 errors occurred:\n\t* invalid metrics port -1\n\t* metrics endpoint requires a bearer token\n\n",
+		},
+		{
+			name: "KubeletExtraMountsInvalid",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "join",
+					MachineKubelet: &v1alpha1.KubeletConfig{
+						KubeletExtraMounts: []specs.Mount{
+							{
+								Source:      "/var/openebs/local",
+								Destination: "/var/openebs/local",
+								Type:        "bind",
+								Options:     []string{"rbind", "rshared", "rw"},
+							},
+							{
+								Source:      "var/lib/example",
+								Destination: "example",
+								Type:        "bind",
+								Options:     []string{"rbind", "rw"},
+							},
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "2 errors occurred:\n\t* [machine.kubelet.extraMounts] \"example\": destination should be an absolute path\n\t* [machine.kubelet.extraMounts] \"var/lib/example\": source should be an absolute path\n\n",
+		},
+		{
+			name: "ReconcileMaintenanceWindowsInvalid",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "join",
+					MachineReconcile: &v1alpha1.ReconcileConfig{
+						ReconcileMaintenanceWindows: []*v1alpha1.MaintenanceWindowConfig{
+							{
+								WindowStart:    "2021-06-05T22:00:00Z",
+								WindowDuration: time.Hour,
+							},
+							{
+								WindowStart: "tomorrow",
+							},
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "2 errors occurred:\n\t* [machine.reconcile.maintenanceWindows] \"tomorrow\": invalid start time: parsing time \"tomorrow\" as \"2006-01-02T15:04:05Z07:00\": cannot parse \"tomorrow\" as \"2006\"\n\t* [machine.reconcile.maintenanceWindows] \"tomorrow\": duration should be positive\n\n",
+		},
+		{
+			name: "HostnameTemplate",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "join",
+					MachineNetwork: &v1alpha1.NetworkConfig{
+						NetworkHostname:        "talos-${uuid-short}",
+						NetworkHostnameSources: []string{"platform", "config", "stable"},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "HostnameTemplateInvalid",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "join",
+					MachineNetwork: &v1alpha1.NetworkConfig{
+						NetworkHostname:        "talos-${serial}",
+						NetworkHostnameSources: []string{"dhcp", "metadata", "dhcp"},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "3 errors occurred:\n\t* [networking.os.hostname] \"talos-${serial}\": unknown hostname template variable \"serial\"\n" +
+				"\t* [networking.os.hostnameSources] \"metadata\": unknown hostname source\n" +
+				"\t* [networking.os.hostnameSources] \"dhcp\": duplicate hostname source\n\n",
+		},
+		{
+			name: "WatchdogInvalid",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "join",
+					MachineWatchdog: &v1alpha1.WatchdogConfig{
+						WatchdogEnabled: true,
+						WatchdogDevice:  "watchdog0",
+						WatchdogTimeout: time.Second,
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "2 errors occurred:\n\t* watchdog timeout should be at least 10s\n\t* watchdog device \"watchdog0\" should be an absolute path\n\n",
+		},
+		{
+			name: "CrashDumps",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "join",
+					MachineCrashDumps: &v1alpha1.CrashDumpsConfig{
+						CrashDumpsRamoops: &v1alpha1.RamoopsConfig{
+							RamoopsMemAddress: "0x8000000",
+							RamoopsMemSize:    "0x100000",
+						},
+						CrashDumpsDestination: "udp://10.5.0.1:5514",
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "CrashDumpsInvalid",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "join",
+					MachineCrashDumps: &v1alpha1.CrashDumpsConfig{
+						CrashDumpsRamoops: &v1alpha1.RamoopsConfig{
+							RamoopsMemSize: "1M",
+						},
+						CrashDumpsDestination: "http://10.5.0.1:5514",
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "3 errors occurred:\n\t* ramoops memAddress is required\n\t* invalid ramoops memSize \"1M\"\n\t* unsupported crash dumps destination scheme \"http\"\n\n",
+		},
+		{
+			name: "DualStack",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "join",
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+					ClusterNetwork: &v1alpha1.ClusterNetworkConfig{
+						DNSDomain:     "cluster.local",
+						PodSubnet:     []string{"10.244.0.0/16", "fc00:db8:10::/56"},
+						ServiceSubnet: []string{"10.96.0.0/12", "fc00:db8:20::/112"},
+					},
+				},
+			},
+		},
+		{
+			name: "DualStackInvalid",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "join",
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+					ClusterNetwork: &v1alpha1.ClusterNetworkConfig{
+						DNSDomain:     "cluster.local",
+						PodSubnet:     []string{"10.244.0.0/16", "10.245.0.0/16"},
+						ServiceSubnet: []string{"10.96.0.0/12", "fc00:db8:20::"},
+					},
+				},
+			},
+			expectedError: "2 errors occurred:\n\t* [cluster.network.podSubnets] \"10.245.0.0/16\": dual-stack requires one IPv4 and one IPv6 subnet\n\t* [cluster.network.serviceSubnets] \"fc00:db8:20::\": invalid subnet: invalid CIDR address: fc00:db8:20::\n\n",
+		},
+		{
+			name: "DualStackFamilyMismatch",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "join",
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+					ClusterNetwork: &v1alpha1.ClusterNetworkConfig{
+						DNSDomain:     "cluster.local",
+						PodSubnet:     []string{"10.244.0.0/16", "fc00:db8:10::/56"},
+						ServiceSubnet: []string{"fc00:db8:20::/112", "10.96.0.0/12"},
+					},
+				},
+			},
+			expectedError: "1 error occurred:\n\t* [cluster.network] pod subnets [\"10.244.0.0/16\" \"fc00:db8:10::/56\"] and service subnets [\"fc00:db8:20::/112\" \"10.96.0.0/12\"] should have the same IP families in the same order\n\n",
+		},
+		{
+			name: "JoinPolicyHostname",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "join",
+					MachineJoinPolicy: &v1alpha1.JoinPolicyConfig{
+						JoinPolicyMode:              "allowlist",
+						JoinPolicyAllowedIdentities: []string{"worker-1", "10.5.0.2", "sha256:5c2b3f9a1e4d"},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "1 error occurred:\n\t* join policy allowed identity \"worker-1\" should be a fingerprint, an IP address or CIDR\n\n",
+		},
+		{
+			name: "ContractCurrent",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "join",
+					MachineJoinPolicy: &v1alpha1.JoinPolicyConfig{
+						JoinPolicyMode:              "allowlist",
+						JoinPolicyAllowedIdentities: []string{"192.168.2.0/24"},
+					},
+					MachineNetwork: &v1alpha1.NetworkConfig{
+						NetworkInterfaces: []*v1alpha1.Device{
+							{
+								DeviceInterface: "br0",
+								DeviceBridge: &v1alpha1.Bridge{
+									BridgedInterfaces: []string{"eth0"},
+								},
+							},
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			versionContract: config.TalosVersion0_10,
+		},
+		{
+			name: "ContractOlder",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "join",
+					MachineJoinPolicy: &v1alpha1.JoinPolicyConfig{
+						JoinPolicyMode:              "allowlist",
+						JoinPolicyAllowedIdentities: []string{"192.168.2.0/24"},
+					},
+					MachineNetwork: &v1alpha1.NetworkConfig{
+						NetworkInterfaces: []*v1alpha1.Device{
+							{
+								DeviceInterface: "br0",
+								DeviceBridge: &v1alpha1.Bridge{
+									BridgedInterfaces: []string{"eth0"},
+								},
+							},
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			versionContract: config.TalosVersion0_9,
+			expectedError:   "2 errors occurred:\n\t* \".machine.joinPolicy\" is not supported by Talos v0.9\n\t* \".machine.network.interfaces[\\\"br0\\\"].bridge\" is not supported by Talos v0.9\n\n",
+		},
+		{
+			name: "ContractOlderDHCPOptions",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "join",
+					MachineNetwork: &v1alpha1.NetworkConfig{
+						NetworkInterfaces: []*v1alpha1.Device{
+							{
+								DeviceInterface: "eth0",
+								DeviceDHCP:      true,
+								DeviceDHCPOptions: &v1alpha1.DHCPOptions{
+									DHCPIPv6:      pointer.ToBool(true),
+									DHCPSLAAC:     pointer.ToBool(true),
+									DHCPIgnoreDNS: true,
+								},
+							},
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			versionContract: config.TalosVersion0_9,
+			expectedError:   "2 errors occurred:\n\t* \".machine.network.interfaces[\\\"eth0\\\"].dhcpOptions.ignoreDNS\" is not supported by Talos v0.9\n\t* \".machine.network.interfaces[\\\"eth0\\\"].dhcpOptions.slaac\" is not supported by Talos v0.9\n\n",
+		},
+		{
+			name: "ContractOlderPolicyRouting",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "join",
+					MachineNetwork: &v1alpha1.NetworkConfig{
+						NetworkInterfaces: []*v1alpha1.Device{
+							{
+								DeviceInterface: "eth0",
+								DeviceCIDR:      "10.0.0.10/8",
+								DeviceRoutes: []*v1alpha1.Route{
+									{
+										RouteNetwork: "0.0.0.0/0",
+										RouteGateway: "10.0.0.1",
+										RouteTable:   100,
+									},
+								},
+							},
+						},
+						NetworkRules: []*v1alpha1.RoutingRule{
+							{
+								RuleFrom:  "10.0.0.0/8",
+								RuleTable: 100,
+							},
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			versionContract: config.TalosVersion0_9,
+			expectedError:   "2 errors occurred:\n\t* \".machine.network.rules\" is not supported by Talos v0.9\n\t* \".machine.network.interfaces[\\\"eth0\\\"].routes[0]\" is not supported by Talos v0.9\n\n",
+		},
+		{
+			name: "ContractOlderDeviceSelector",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "join",
+					MachineNetwork: &v1alpha1.NetworkConfig{
+						NetworkInterfaces: []*v1alpha1.Device{
+							{
+								DeviceSelector: &v1alpha1.NetworkDeviceSelector{
+									NetworkDeviceHardwareAddress: "00:1a:2b:3c:f0:ab",
+								},
+								DeviceDHCP: true,
+							},
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			versionContract: config.TalosVersion0_9,
+			expectedError:   "1 error occurred:\n\t* \".machine.network.interfaces[0].deviceSelector\" is not supported by Talos v0.9\n\n",
+		},
+		{
+			name: "ContractOlderFirewall",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "join",
+					MachineNetwork: &v1alpha1.NetworkConfig{
+						NetworkFirewall: &v1alpha1.FirewallConfig{
+							FirewallDefaultAction: v1alpha1.FirewallActionBlock,
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			versionContract: config.TalosVersion0_9,
+			expectedError:   "1 error occurred:\n\t* \".machine.network.firewall\" is not supported by Talos v0.9\n\n",
+		},
+		{
+			name: "ContractUnknown",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "join",
+					MachineJoinPolicy: &v1alpha1.JoinPolicyConfig{
+						JoinPolicyMode:              "allowlist",
+						JoinPolicyAllowedIdentities: []string{"192.168.2.0/24"},
+					},
+					MachineNetwork: &v1alpha1.NetworkConfig{
+						NetworkInterfaces: []*v1alpha1.Device{
+							{
+								DeviceInterface: "br0",
+								DeviceBridge: &v1alpha1.Bridge{
+									BridgedInterfaces: []string{"eth0"},
+								},
+							},
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			versionContract: &config.VersionContract{Major: 1, Minor: 0},
+			expectedError:   "1 error occurred:\n\t* config can't be checked against Talos v1.0: version is newer than the supported contracts\n\n",
+		},
+	} {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts := []config.ValidationOption{config.WithLocal()}
+			if test.strict {
+				opts = append(opts, config.WithStrict())
+			}
+
+			if test.versionContract != nil {
+				opts = append(opts, config.WithVersionContract(test.versionContract))
 			}
 
 			warnings, errrors := test.config.Validate(runtimeMode{test.requiresInstall}, opts...)
@@ -10,6 +10,10 @@ type ValidationOptions struct {
 	Local bool
 	// Strict mode returns warnings as errors.
 	Strict bool
+	// VersionContract validates the config against the feature set of the specified Talos version.
+	//
+	// Nil value validates against the current version of Talos.
+	VersionContract *VersionContract
 }
 
 // ValidationOption represents an additional validation parameter for the config Validate method.
@@ -38,3 +42,10 @@ func WithStrict() ValidationOption {
 		opts.Strict = true
 	}
 }
+
+// WithVersionContract validates the config against the specified Talos version.
+func WithVersionContract(contract *VersionContract) ValidationOption {
+	return func(opts *ValidationOptions) {
+		opts.VersionContract = contract
+	}
+}
@@ -20,6 +20,10 @@ const (
 	// to the config.
 	KernelParamConfig = "talos.config"
 
+	// KernelParamConfigHeader is the kernel parameter name for specifying the
+	// HTTP header (`Name:value`) sent when downloading the config, may be repeated.
+	KernelParamConfigHeader = "talos.config.header"
+
 	// ConfigNone indicates no config is required.
 	ConfigNone = "none"
 
@@ -49,6 +53,10 @@ const (
 	// BoardRockpi4 is the name of the Radxa Rock pi 4.
 	BoardRockpi4 = "rockpi_4"
 
+	// BoardOverlaysPath is the path to the board overlays bundled into the installer,
+	// each overlay is a directory named after the board.
+	BoardOverlaysPath = "/usr/install/overlays"
+
 	// KernelParamHostname is the kernel parameter name for specifying the
 	// hostname.
 	KernelParamHostname = "talos.hostname"
@@ -67,6 +75,13 @@ const (
 	// KernelParamPanic is the kernel parameter name for specifying the time to wait until rebooting after kernel panic (0 disables reboot).
 	KernelParamPanic = "panic"
 
+	// KernelParamSideroLink is the kernel parameter name for specifying the SideroLink management server API URL.
+	KernelParamSideroLink = "siderolink.api"
+
+	// KernelParamHCloudFloatingIPs is the kernel parameter name for specifying the comma-separated
+	// list of the floating IPs assigned to the server on the hcloud platform.
+	KernelParamHCloudFloatingIPs = "talos.hcloud.floating-ips"
+
 	// KernelCurrentRoot is the kernel parameter name for specifying the
 	// current root partition.
 	//
@@ -176,6 +191,24 @@ const (
 	// KubernetesRunUser defines UID to run control plane components.
 	KubernetesRunUser = 65534
 
+	// DefaultAESCBCEncryptionKeyName is the default name of the key for the encryption of secret data at rest.
+	DefaultAESCBCEncryptionKeyName = "key1"
+
+	// DefaultSecretboxEncryptionKeyName is the default name of the secretbox provider key.
+	DefaultSecretboxEncryptionKeyName = "key1"
+
+	// SecretsEncryptionProviderAESCBC is the aescbc provider for the encryption of secret data at rest.
+	SecretsEncryptionProviderAESCBC = "aescbc"
+
+	// SecretsEncryptionProviderSecretbox is the secretbox provider for the encryption of secret data at rest.
+	SecretsEncryptionProviderSecretbox = "secretbox"
+
+	// SecretsEncryptionProviderKMS is the KMS v2 plugin provider for the encryption of secret data at rest.
+	SecretsEncryptionProviderKMS = "kms"
+
+	// DefaultKMSEncryptionTimeout is the default timeout of the calls to the KMS plugin.
+	DefaultKMSEncryptionTimeout = 3 * time.Second
+
 	// KubeletBootstrapKubeconfig is the path to the kubeconfig required to
 	// bootstrap the kubelet.
 	KubeletBootstrapKubeconfig = "/etc/kubernetes/bootstrap-kubeconfig"
@@ -234,6 +267,9 @@ const (
 	// KubeletKubeconfig is the generated kubeconfig for kubelet.
 	KubeletKubeconfig = "/etc/kubernetes/kubeconfig-kubelet"
 
+	// KubeletResolvConfPath is the resolv.conf generated for kubelet (used as the upstream resolv.conf for the pods).
+	KubeletResolvConfPath = SystemEtcPath + "/resolv-kubelet.conf"
+
 	// DefaultEtcdVersion is the default target version of etcd.
 	DefaultEtcdVersion = "v3.4.15"
 
@@ -246,6 +282,9 @@ const (
 	// EtcdTalosManifestApplyMutex is the etcd election .
 	EtcdTalosManifestApplyMutex = EtcdRootTalosKey + ":manifestApplyMutex"
 
+	// DefaultManifestsSyncInterval is the default interval between the reconciliations of the bootstrap manifests.
+	DefaultManifestsSyncInterval = 5 * time.Minute
+
 	// EtcdImage is the reposistory for the etcd image.
 	EtcdImage = "gcr.io/etcd-development/etcd"
 
@@ -261,6 +300,41 @@ const (
 	// ConfigPath is the path to the downloaded config.
 	ConfigPath = StateMountPoint + "/config.yaml"
 
+	// MachineIdentityPath is the path to the persistent machine identity key.
+	MachineIdentityPath = StateMountPoint + "/identity.pem"
+
+	// WireguardKeysPath is the path to the Wireguard private keys generated on the node.
+	WireguardKeysPath = StateMountPoint + "/wireguard"
+
+	// KubeSpanKeyPath is the path to the KubeSpan Wireguard private key generated on the node.
+	KubeSpanKeyPath = WireguardKeysPath + "/kubespan.key"
+
+	// KubeSpanLinkName is the name of the KubeSpan Wireguard link.
+	KubeSpanLinkName = "kubespan"
+
+	// KubeSpanLinkMTU is the MTU of the KubeSpan link (leaves room for the Wireguard overhead over IPv6).
+	KubeSpanLinkMTU = 1420
+
+	// KubeSpanDefaultPort is the default Wireguard listen port of KubeSpan.
+	KubeSpanDefaultPort = 51820
+
+	// KubeSpanDefaultFirewallMark is the firewall mark of the KubeSpan Wireguard traffic.
+	//
+	// Marked traffic bypasses the KubeSpan routing table, so that encrypted packets are sent via the regular links.
+	KubeSpanDefaultFirewallMark = 0x51820
+
+	// KubeSpanDefaultRoutingTable is the routing table with the routes to the KubeSpan peers.
+	KubeSpanDefaultRoutingTable = 180
+
+	// SideroLinkName is the name of the SideroLink Wireguard link.
+	SideroLinkName = "siderolink"
+
+	// SideroLinkMTU is the MTU of the SideroLink link (the minimum IPv6 MTU, as the tunnel might go over any network).
+	SideroLinkMTU = 1280
+
+	// SideroLinkDefaultFirewallMark is the firewall mark of the SideroLink Wireguard traffic.
+	SideroLinkDefaultFirewallMark = 0x51821
+
 	// MetalConfigISOLabel is the volume label for ISO based configuration.
 	MetalConfigISOLabel = "metal-iso"
 
@@ -273,6 +347,24 @@ const (
 	// VMwareGuestInfoFallbackKey is the fallback guestinfo key used to provide a config file.
 	VMwareGuestInfoFallbackKey = "userdata"
 
+	// VMwareGuestInfoConfigEncodingKey is the guestinfo key used to specify the encoding of the config.
+	VMwareGuestInfoConfigEncodingKey = "talos.config.encoding"
+
+	// VMwareGuestInfoOVFEnvKey is the guestinfo key with the OVF environment (vApp properties).
+	VMwareGuestInfoOVFEnvKey = "ovfEnv"
+
+	// VMwareGuestInfoLocalIPv4Key is the guestinfo key used to report the node IPv4 address.
+	VMwareGuestInfoLocalIPv4Key = "local-ipv4"
+
+	// VMwareGuestInfoLocalIPv6Key is the guestinfo key used to report the node IPv6 address.
+	VMwareGuestInfoLocalIPv6Key = "local-ipv6"
+
+	// VMwareGuestInfoEncodingBase64 is the base64 encoding of the guestinfo config.
+	VMwareGuestInfoEncodingBase64 = "base64"
+
+	// VMwareGuestInfoEncodingGzipBase64 is the gzip+base64 encoding of the guestinfo config.
+	VMwareGuestInfoEncodingGzipBase64 = "gzip+base64"
+
 	// AuditPolicyPath is the path to the audit-policy.yaml relative to initramfs.
 	AuditPolicyPath = "/etc/kubernetes/audit-policy.yaml"
 
@@ -288,6 +380,69 @@ const (
 	// TrustdPort is the port for the trustd service.
 	TrustdPort = 50001
 
+	// DefaultHealthzPort is the default port of the node health endpoint served by machined.
+	DefaultHealthzPort = 50005
+
+	// DefaultMetricsPort is the default port of the node metrics endpoint served by apid.
+	DefaultMetricsPort = 50006
+
+	// TrustdJoinPolicyToken is the trustd join policy which only requires a valid token.
+	TrustdJoinPolicyToken = "token"
+
+	// TrustdJoinPolicyAllowlist is the trustd join policy which requires the CSR to match a static allowlist.
+	TrustdJoinPolicyAllowlist = "allowlist"
+
+	// TrustdJoinPolicyWebhook is the trustd join policy which delegates the decision to an external authorizer.
+	TrustdJoinPolicyWebhook = "webhook"
+
+	// DefaultWatchdogDevice is the default hardware watchdog device.
+	DefaultWatchdogDevice = "/dev/watchdog"
+
+	// DefaultWatchdogTimeout is the default time the node is allowed to stay unhealthy before the watchdog restarts it.
+	DefaultWatchdogTimeout = time.Minute
+
+	// MinWatchdogTimeout is the minimum supported watchdog timeout.
+	MinWatchdogTimeout = 10 * time.Second
+
+	// PstoreMountPoint is the mount point for the pstore filesystem.
+	PstoreMountPoint = "/sys/fs/pstore"
+
+	// PanicLogsPath is the path to the kernel panic logs collected from pstore.
+	PanicLogsPath = StateMountPoint + "/panics"
+
+	// MaxPanicLogs is the maximum number of the panic logs kept in the STATE partition.
+	MaxPanicLogs = 10
+
+	// AuditLogPath is the path to the audit log of the mutating machine API calls in the STATE partition.
+	AuditLogPath = StateMountPoint + "/audit.log"
+
+	// MaxAuditLogEntries is the number of the most recent audit log entries kept.
+	MaxAuditLogEntries = 1000
+
+	// TrustdStatePath is the path to the directory in the STATE partition holding the trustd state.
+	TrustdStatePath = StateMountPoint + "/trustd"
+
+	// RevokedCertificatesPath is the path to the list of the revoked client certificates maintained by trustd.
+	RevokedCertificatesPath = TrustdStatePath + "/revoked-certificates.json"
+
+	// RegistrationDefaultHeartbeatInterval is the default interval between the heartbeats sent to the inventory API.
+	RegistrationDefaultHeartbeatInterval = time.Minute
+
+	// ImageCachePartitionLabel is the label of the partition holding the image cache.
+	ImageCachePartitionLabel = "IMAGECACHE"
+
+	// ImageCacheISOPath is the path to the image cache in the Talos ISO file system.
+	ImageCacheISOPath = "imagecache"
+
+	// ImageCacheMountPoint is the mount point of the image cache partition (or the Talos ISO).
+	ImageCacheMountPoint = SystemPath + "/imagecache"
+
+	// ImageCacheAddress is the address the local image cache registry is served on.
+	ImageCacheAddress = "127.0.0.1:50007"
+
+	// TrustdJoinPolicyWebhookDefaultTimeout is the default timeout for the trustd join policy webhook.
+	TrustdJoinPolicyWebhookDefaultTimeout = 10 * time.Second
+
 	// DefaultContainerdVersion is the default container runtime version.
 	DefaultContainerdVersion = "1.4.4"
 
@@ -300,18 +455,55 @@ const (
 	// CRIContainerdConfig is the path to the config for the containerd instance that provides the CRI.
 	CRIContainerdConfig = "/etc/cri/containerd.toml"
 
+	// NVIDIAContainerRuntimePath is the path to the NVIDIA container runtime installed by the NVIDIA extension.
+	NVIDIAContainerRuntimePath = "/usr/local/bin/nvidia-container-runtime"
+
+	// NVIDIARuntimeHandler is the name of the CRI runtime handler for the NVIDIA container runtime.
+	NVIDIARuntimeHandler = "nvidia"
+
 	// TalosConfigEnvVar is the environment variable for setting the Talos configuration file path.
 	TalosConfigEnvVar = "TALOSCONFIG"
 
+	// APIVersion is the version of the Talos API implemented by this version of Talos.
+	//
+	// APIVersion should be bumped when the API changes in a way the clients should be aware of (e.g. new methods are added).
+	APIVersion = 9
+
+	// MinAPIVersion is the oldest API version of the clients supported by this version of Talos.
+	MinAPIVersion = 1
+
+	// APIVersionMetadataKey is the gRPC metadata key which carries the API version: the client sends it with the request,
+	// and the server returns it in the response headers.
+	APIVersionMetadataKey = "talos-api-version"
+
+	// AuditClientMetadataKey is the gRPC metadata key which carries the client identity recorded in the audit log:
+	// apid sets it for the requests it forwards.
+	AuditClientMetadataKey = "talos-audit-client"
+
+	// AuditPeerMetadataKey is the gRPC metadata key which carries the client address (and the addresses of the proxying nodes)
+	// recorded in the audit log.
+	AuditPeerMetadataKey = "talos-audit-peer"
+
 	// APISocketPath is the path to file socket of apid.
 	APISocketPath = SystemRunPath + "/apid/apid.sock"
 
+	// MachinedMetricsSocketPath is the path to the socket serving machined metrics (gathered by apid).
+	MachinedMetricsSocketPath = SystemRunPath + "/machined/metrics.sock"
+
 	// MachineSocketPath is the path to file socket of machine API.
 	MachineSocketPath = SystemRunPath + "/machined/machine.sock"
 
+	// LocalAPISocketPath is the path to file socket of machine API for the local clients (host-level containers and services).
+	//
+	// The socket is authenticated via the peer credentials of the client process.
+	LocalAPISocketPath = SystemRunPath + "/local-api/machine.sock"
+
 	// NetworkSocketPath is the path to file socket of network API.
 	NetworkSocketPath = SystemRunPath + "/networkd/networkd.sock"
 
+	// TrustdSocketPath is the path to file socket of trustd, it is used by machined to revoke the certificates.
+	TrustdSocketPath = SystemRunPath + "/trustd/trustd.sock"
+
 	// ArchVariable is replaced automatically by the target cluster arch.
 	ArchVariable = "${ARCH}"
 
@@ -333,6 +525,21 @@ const (
 	// InitramfsAssetPath is the path to the initramfs on disk.
 	InitramfsAssetPath = "/usr/install/" + InitramfsAsset
 
+	// UKIStubPath is the path to the EFI stub used to assemble Unified Kernel Images.
+	UKIStubPath = "/usr/lib/systemd/boot/efi/linuxx64.efi.stub"
+
+	// SDBootPath is the path to the systemd-boot EFI binary.
+	SDBootPath = "/usr/lib/systemd/boot/efi/systemd-bootx64.efi"
+
+	// BootloaderGRUB is the GRUB bootloader type.
+	BootloaderGRUB = "grub"
+
+	// BootloaderSDBoot is the systemd-boot bootloader type.
+	BootloaderSDBoot = "sd-boot"
+
+	// EFIVarsMountPoint is the mount point for the efivarfs.
+	EFIVarsMountPoint = "/sys/firmware/efi/efivars"
+
 	// RootfsAsset defines a well known name for our rootfs filename.
 	RootfsAsset = "rootfs.sqsh"
 
@@ -358,6 +565,15 @@ const (
 	// SystemLibexecPath is the path to the system libexec directory.
 	SystemLibexecPath = SystemPath + "/libexec"
 
+	// ExtensionServicesConfigPath is the directory with the extension service specs.
+	ExtensionServicesConfigPath = "/usr/local/etc/containers"
+
+	// ExtensionServicesRootfsPath is the directory with the root filesystems of the extension services.
+	ExtensionServicesRootfsPath = "/usr/local/lib/containers"
+
+	// ExtensionServicePrefix is the prefix of the extension service IDs.
+	ExtensionServicePrefix = "ext-"
+
 	// DefaultCNI is the default CNI.
 	DefaultCNI = "flannel"
 
@@ -0,0 +1,242 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package services provides the specification of the extension services.
+//
+// Extension services are shipped with the system extensions: the spec is placed
+// into constants.ExtensionServicesConfigPath as <name>.yaml, and the root filesystem
+// of the service container into constants.ExtensionServicesRootfsPath/<name>.
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"gopkg.in/yaml.v3"
+
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+)
+
+// Spec describes an extension service.
+type Spec struct {
+	// Name of the service, service ID is the name with the constants.ExtensionServicePrefix.
+	Name string `yaml:"name"`
+	// Container to run.
+	Container Container `yaml:"container"`
+	// Depends lists the conditions the service waits for before starting.
+	Depends []Dependency `yaml:"depends,omitempty"`
+	// Restart policy of the service, defaults to RestartAlways.
+	Restart RestartKind `yaml:"restart,omitempty"`
+	// HealthCheck of the service, if not set the service is healthy while it's running.
+	HealthCheck *HealthCheck `yaml:"healthCheck,omitempty"`
+}
+
+// Container describes the service container.
+type Container struct {
+	// Entrypoint is the absolute path to the executable in the container root filesystem.
+	Entrypoint string `yaml:"entrypoint"`
+	// Args are the arguments of the entrypoint.
+	Args []string `yaml:"args,omitempty"`
+	// Environment variables in the KEY=value format.
+	Environment []string `yaml:"environment,omitempty"`
+	// Mounts of the container.
+	Mounts []specs.Mount `yaml:"mounts,omitempty"`
+	// Security settings of the container.
+	Security Security `yaml:"security,omitempty"`
+}
+
+// Security describes the security settings of the service container.
+type Security struct {
+	// Capabilities added on top of the default set of capabilities, e.g. CAP_NET_ADMIN.
+	Capabilities []string `yaml:"capabilities,omitempty"`
+	// MaskedPaths are not accessible in the container.
+	MaskedPaths []string `yaml:"maskedPaths,omitempty"`
+	// ReadonlyPaths are mounted read-only in the container.
+	ReadonlyPaths []string `yaml:"readonlyPaths,omitempty"`
+	// WriteableRootfs mounts the container root filesystem read-write.
+	WriteableRootfs bool `yaml:"writeableRootfs,omitempty"`
+	// WriteableSysfs mounts /sys read-write.
+	WriteableSysfs bool `yaml:"writeableSysfs,omitempty"`
+}
+
+// Dependency describes a condition the service waits for: either another service is up,
+// or a path exists.
+type Dependency struct {
+	Service string `yaml:"service,omitempty"`
+	Path    string `yaml:"path,omitempty"`
+}
+
+// HealthCheck describes the service health check.
+//
+// Service is healthy when the TCP connection to the address succeeds.
+type HealthCheck struct {
+	TCP     string        `yaml:"tcp"`
+	Period  time.Duration `yaml:"period,omitempty"`
+	Timeout time.Duration `yaml:"timeout,omitempty"`
+}
+
+// RestartKind is the restart policy of the service.
+type RestartKind string
+
+// Restart policies.
+const (
+	RestartAlways       RestartKind = "always"
+	RestartNever        RestartKind = "never"
+	RestartUntilSuccess RestartKind = "untilSuccess"
+)
+
+var nameRegexp = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?$`)
+
+// capabilities is the set of the known Linux capabilities.
+var capabilities = map[string]struct{}{}
+
+func init() {
+	for _, capability := range []string{
+		"CAP_CHOWN", "CAP_DAC_OVERRIDE", "CAP_DAC_READ_SEARCH", "CAP_FOWNER", "CAP_FSETID", "CAP_KILL",
+		"CAP_SETGID", "CAP_SETUID", "CAP_SETPCAP", "CAP_LINUX_IMMUTABLE", "CAP_NET_BIND_SERVICE",
+		"CAP_NET_BROADCAST", "CAP_NET_ADMIN", "CAP_NET_RAW", "CAP_IPC_LOCK", "CAP_IPC_OWNER",
+		"CAP_SYS_MODULE", "CAP_SYS_RAWIO", "CAP_SYS_CHROOT", "CAP_SYS_PTRACE", "CAP_SYS_PACCT",
+		"CAP_SYS_ADMIN", "CAP_SYS_BOOT", "CAP_SYS_NICE", "CAP_SYS_RESOURCE", "CAP_SYS_TIME",
+		"CAP_SYS_TTY_CONFIG", "CAP_MKNOD", "CAP_LEASE", "CAP_AUDIT_WRITE", "CAP_AUDIT_CONTROL",
+		"CAP_SETFCAP", "CAP_MAC_OVERRIDE", "CAP_MAC_ADMIN", "CAP_SYSLOG", "CAP_WAKE_ALARM",
+		"CAP_BLOCK_SUSPEND", "CAP_AUDIT_READ", "CAP_PERFMON", "CAP_BPF", "CAP_CHECKPOINT_RESTORE",
+	} {
+		capabilities[capability] = struct{}{}
+	}
+}
+
+// Load reads and validates the extension service spec.
+func Load(path string) (*Spec, error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var spec Spec
+
+	dec := yaml.NewDecoder(bytes.NewReader(contents))
+	dec.KnownFields(true)
+
+	if err = dec.Decode(&spec); err != nil {
+		return nil, fmt.Errorf("error decoding extension service spec %q: %w", path, err)
+	}
+
+	if err = spec.Validate(); err != nil {
+		return nil, fmt.Errorf("extension service spec %q is invalid: %w", path, err)
+	}
+
+	return &spec, nil
+}
+
+// ID returns the service ID.
+func (spec *Spec) ID() string {
+	return constants.ExtensionServicePrefix + spec.Name
+}
+
+// Validate the extension service spec.
+//
+//nolint:gocyclo,cyclop
+func (spec *Spec) Validate() error {
+	var result *multierror.Error
+
+	if !nameRegexp.MatchString(spec.Name) {
+		result = multierror.Append(result, fmt.Errorf("[name] %q: name should consist of lowercase letters, digits and dashes", spec.Name))
+	}
+
+	if !filepath.IsAbs(spec.Container.Entrypoint) {
+		result = multierror.Append(result, fmt.Errorf("[container.entrypoint] %q: entrypoint should be an absolute path", spec.Container.Entrypoint))
+	}
+
+	for _, env := range spec.Container.Environment {
+		if !strings.Contains(env, "=") {
+			result = multierror.Append(result, fmt.Errorf("[container.environment] %q: environment variable should be in the KEY=value format", env))
+		}
+	}
+
+	for _, mount := range spec.Container.Mounts {
+		if !filepath.IsAbs(mount.Destination) {
+			result = multierror.Append(result, fmt.Errorf("[container.mounts] %q: mount destination should be an absolute path", mount.Destination))
+		}
+
+		if !isBindMount(mount) {
+			continue
+		}
+
+		if !filepath.IsAbs(mount.Source) {
+			result = multierror.Append(result, fmt.Errorf("[container.mounts] %q: bind mount source should be an absolute path", mount.Source))
+
+			continue
+		}
+
+		if source := filepath.Clean(mount.Source); source == constants.SystemPath || strings.HasPrefix(source, constants.SystemPath+"/") {
+			result = multierror.Append(result, fmt.Errorf("[container.mounts] %q: bind mounts of %q are not allowed", mount.Source, constants.SystemPath))
+		}
+	}
+
+	for _, capability := range spec.Container.Security.Capabilities {
+		if _, ok := capabilities[capability]; !ok {
+			result = multierror.Append(result, fmt.Errorf("[container.security.capabilities] %q: unknown capability", capability))
+		}
+	}
+
+	for _, path := range append(append([]string(nil), spec.Container.Security.MaskedPaths...), spec.Container.Security.ReadonlyPaths...) {
+		if !filepath.IsAbs(path) {
+			result = multierror.Append(result, fmt.Errorf("[container.security] %q: path should be absolute", path))
+		}
+	}
+
+	for _, dependency := range spec.Depends {
+		switch {
+		case dependency.Service != "" && dependency.Path != "":
+			result = multierror.Append(result, fmt.Errorf("[depends] %q: dependency should be either a service or a path", dependency.Service))
+		case dependency.Service != "":
+		case dependency.Path != "":
+			if !filepath.IsAbs(dependency.Path) {
+				result = multierror.Append(result, fmt.Errorf("[depends] %q: path should be absolute", dependency.Path))
+			}
+		default:
+			result = multierror.Append(result, fmt.Errorf("[depends]: dependency should specify a service or a path"))
+		}
+	}
+
+	switch spec.Restart {
+	case "", RestartAlways, RestartNever, RestartUntilSuccess:
+	default:
+		result = multierror.Append(result, fmt.Errorf("[restart] %q: restart policy should be one of %q, %q, %q", spec.Restart, RestartAlways, RestartNever, RestartUntilSuccess))
+	}
+
+	if spec.HealthCheck != nil {
+		if _, _, err := net.SplitHostPort(spec.HealthCheck.TCP); err != nil {
+			result = multierror.Append(result, fmt.Errorf("[healthCheck.tcp] %q: %w", spec.HealthCheck.TCP, err))
+		}
+
+		if spec.HealthCheck.Period < 0 || spec.HealthCheck.Timeout < 0 {
+			result = multierror.Append(result, fmt.Errorf("[healthCheck]: period and timeout should not be negative"))
+		}
+	}
+
+	return result.ErrorOrNil()
+}
+
+func isBindMount(mount specs.Mount) bool {
+	if mount.Type == "bind" {
+		return true
+	}
+
+	for _, option := range mount.Options {
+		if option == "bind" || option == "rbind" {
+			return true
+		}
+	}
+
+	return false
+}
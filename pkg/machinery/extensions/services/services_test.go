@@ -0,0 +1,187 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package services_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/pkg/machinery/extensions/services"
+)
+
+func TestLoad(t *testing.T) {
+	dir, err := ioutil.TempDir("", "talos")
+	require.NoError(t, err)
+
+	defer os.RemoveAll(dir) //nolint:errcheck
+
+	path := filepath.Join(dir, "hello.yaml")
+
+	require.NoError(t, ioutil.WriteFile(path, []byte(`name: hello
+container:
+  entrypoint: /hello
+  args:
+    - --port=8080
+  mounts:
+    - source: /var/lib/hello
+      destination: /var/lib/hello
+      type: bind
+      options:
+        - rbind
+        - rw
+  security:
+    capabilities:
+      - CAP_NET_ADMIN
+depends:
+  - service: cri
+restart: untilSuccess
+healthCheck:
+  tcp: 127.0.0.1:8080
+`), 0o644))
+
+	spec, err := services.Load(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, "ext-hello", spec.ID())
+	assert.Equal(t, "/hello", spec.Container.Entrypoint)
+	assert.Equal(t, []string{"--port=8080"}, spec.Container.Args)
+	assert.Equal(t, []specs.Mount{
+		{
+			Source:      "/var/lib/hello",
+			Destination: "/var/lib/hello",
+			Type:        "bind",
+			Options:     []string{"rbind", "rw"},
+		},
+	}, spec.Container.Mounts)
+	assert.Equal(t, []string{"CAP_NET_ADMIN"}, spec.Container.Security.Capabilities)
+	assert.Equal(t, []services.Dependency{{Service: "cri"}}, spec.Depends)
+	assert.Equal(t, services.RestartUntilSuccess, spec.Restart)
+	assert.Equal(t, "127.0.0.1:8080", spec.HealthCheck.TCP)
+
+	require.NoError(t, ioutil.WriteFile(path, []byte(`name: hello
+container:
+  entrypoint: /hello
+  privileged: true
+`), 0o644))
+
+	_, err = services.Load(path)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "field privileged not found")
+}
+
+func TestValidate(t *testing.T) {
+	for _, tt := range []struct {
+		name          string
+		spec          services.Spec
+		expectedError string
+	}{
+		{
+			name: "valid",
+			spec: services.Spec{
+				Name: "hello-world",
+				Container: services.Container{
+					Entrypoint: "/hello",
+				},
+			},
+		},
+		{
+			name: "invalid name and entrypoint",
+			spec: services.Spec{
+				Name: "Hello/World",
+				Container: services.Container{
+					Entrypoint: "hello",
+				},
+			},
+			expectedError: "2 errors occurred:\n\t* [name] \"Hello/World\": name should consist of lowercase letters, digits and dashes\n" +
+				"\t* [container.entrypoint] \"hello\": entrypoint should be an absolute path\n\n",
+		},
+		{
+			name: "mounts",
+			spec: services.Spec{
+				Name: "hello",
+				Container: services.Container{
+					Entrypoint: "/hello",
+					Mounts: []specs.Mount{
+						{
+							Source:      "/system/secrets",
+							Destination: "/secrets",
+							Options:     []string{"rbind", "ro"},
+						},
+						{
+							Source:      "var",
+							Destination: "/var",
+							Type:        "bind",
+						},
+						{
+							Source:      "tmpfs",
+							Destination: "tmp",
+							Type:        "tmpfs",
+						},
+					},
+				},
+			},
+			expectedError: "3 errors occurred:\n\t* [container.mounts] \"/system/secrets\": bind mounts of \"/system\" are not allowed\n" +
+				"\t* [container.mounts] \"var\": bind mount source should be an absolute path\n" +
+				"\t* [container.mounts] \"tmp\": mount destination should be an absolute path\n\n",
+		},
+		{
+			name: "security",
+			spec: services.Spec{
+				Name: "hello",
+				Container: services.Container{
+					Entrypoint: "/hello",
+					Security: services.Security{
+						Capabilities:  []string{"CAP_SYS_ADMIN", "CAP_WHATEVER"},
+						ReadonlyPaths: []string{"proc"},
+					},
+				},
+			},
+			expectedError: "2 errors occurred:\n\t* [container.security.capabilities] \"CAP_WHATEVER\": unknown capability\n" +
+				"\t* [container.security] \"proc\": path should be absolute\n\n",
+		},
+		{
+			name: "depends, restart and health check",
+			spec: services.Spec{
+				Name: "hello",
+				Container: services.Container{
+					Entrypoint:  "/hello",
+					Environment: []string{"FOO"},
+				},
+				Depends: []services.Dependency{
+					{},
+					{Path: "run/hello.sock"},
+				},
+				Restart: "sometimes",
+				HealthCheck: &services.HealthCheck{
+					TCP: "localhost",
+				},
+			},
+			expectedError: "5 errors occurred:\n\t* [container.environment] \"FOO\": environment variable should be in the KEY=value format\n" +
+				"\t* [depends]: dependency should specify a service or a path\n" +
+				"\t* [depends] \"run/hello.sock\": path should be absolute\n" +
+				"\t* [restart] \"sometimes\": restart policy should be one of \"always\", \"never\", \"untilSuccess\"\n" +
+				"\t* [healthCheck.tcp] \"localhost\": address localhost: missing port in address\n\n",
+		},
+	} {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.spec.Validate()
+
+			if tt.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.Equal(t, tt.expectedError, err.Error())
+			}
+		})
+	}
+}
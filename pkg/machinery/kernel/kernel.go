@@ -4,6 +4,8 @@
 
 package kernel
 
+import "strings"
+
 // DefaultArgs returns the Talos default kernel commandline options.
 var DefaultArgs = []string{
 	"init_on_alloc=1",
@@ -20,3 +22,46 @@ var DefaultArgs = []string{
 	"ima_appraise=fix",
 	"ima_hash=sha512",
 }
+
+// RemoveArgPrefix marks the extra kernel argument which removes the argument from the kernel command line.
+//
+// `-key` removes all the values of the argument, `-key=value` removes only the specified value.
+const RemoveArgPrefix = "-"
+
+// ArgKey returns the key of the kernel argument.
+func ArgKey(arg string) string {
+	return strings.SplitN(arg, "=", 2)[0]
+}
+
+// SplitRemovedArgs splits the extra kernel arguments into the arguments to append and the arguments to remove.
+//
+// Arguments to remove are returned without the RemoveArgPrefix.
+func SplitRemovedArgs(args []string) (appended, removed []string) {
+	for _, arg := range args {
+		if strings.HasPrefix(arg, RemoveArgPrefix) {
+			removed = append(removed, strings.TrimPrefix(arg, RemoveArgPrefix))
+		} else {
+			appended = append(appended, arg)
+		}
+	}
+
+	return appended, removed
+}
+
+// RemoveArgs returns the kernel arguments with the removed arguments filtered out.
+func RemoveArgs(args, removed []string) []string {
+	result := make([]string, 0, len(args))
+
+outer:
+	for _, arg := range args {
+		for _, remove := range removed {
+			if arg == remove || ArgKey(arg) == remove {
+				continue outer
+			}
+		}
+
+		result = append(result, arg)
+	}
+
+	return result
+}
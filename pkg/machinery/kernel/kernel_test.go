@@ -0,0 +1,51 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package kernel_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/talos-systems/talos/pkg/machinery/kernel"
+)
+
+func TestSplitRemovedArgs(t *testing.T) {
+	appended, removed := kernel.SplitRemovedArgs([]string{"mitigations=off", "-console", "-pti=on", "nosmt"})
+
+	assert.Equal(t, []string{"mitigations=off", "nosmt"}, appended)
+	assert.Equal(t, []string{"console", "pti=on"}, removed)
+}
+
+func TestRemoveArgs(t *testing.T) {
+	args := []string{"console=tty0", "console=ttyS0", "pti=on", "slab_nomerge=", "nosmt", "printk.devkmsg=on"}
+
+	for _, tt := range []struct {
+		name     string
+		removed  []string
+		expected []string
+	}{
+		{
+			name:     "none",
+			expected: args,
+		},
+		{
+			name:     "all values",
+			removed:  []string{"console", "slab_nomerge", "nosmt"},
+			expected: []string{"pti=on", "printk.devkmsg=on"},
+		},
+		{
+			name:     "single value",
+			removed:  []string{"console=ttyS0", "pti=off"},
+			expected: []string{"console=tty0", "pti=on", "slab_nomerge=", "nosmt", "printk.devkmsg=on"},
+		},
+	} {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, kernel.RemoveArgs(args, tt.removed))
+		})
+	}
+}
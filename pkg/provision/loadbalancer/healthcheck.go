@@ -0,0 +1,64 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package loadbalancer
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+)
+
+// TCPHealthCheck considers the upstream healthy if it accepts TCP connections.
+func TCPHealthCheck(ctx context.Context, upstream string) error {
+	var d net.Dialer
+
+	conn, err := d.DialContext(ctx, "tcp", upstream)
+	if err != nil {
+		return err
+	}
+
+	return conn.Close()
+}
+
+// HTTPSHealthCheck considers the upstream healthy if it responds with 200 OK to the HTTPS GET request to the path.
+//
+// Server certificate is not verified, as the check is performed against the upstream address directly.
+// The check is suitable for kube-apiserver `/readyz` endpoint which is accessible without authentication.
+func HTTPSHealthCheck(path string) HealthCheck {
+	client := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				InsecureSkipVerify: true, //nolint:gosec
+			},
+			DisableKeepAlives: true,
+		},
+	}
+
+	return func(ctx context.Context, upstream string) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("https://%s%s", upstream, path), nil)
+		if err != nil {
+			return err
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+
+		defer resp.Body.Close() //nolint:errcheck
+
+		_, _ = io.Copy(ioutil.Discard, resp.Body) //nolint:errcheck
+
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+		}
+
+		return nil
+	}
+}
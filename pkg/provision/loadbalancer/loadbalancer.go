@@ -0,0 +1,315 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package loadbalancer implements TCP load balancer with health-checked upstreams for local clusters.
+package loadbalancer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"sync"
+	"time"
+)
+
+// TCP load balancer proxies connections to the healthy upstreams of the route.
+//
+// Upstreams which fail health checks are excluded from the rotation until the checks pass again,
+// if no upstream is healthy, connections are proxied to any upstream which accepts them.
+type TCP struct {
+	routes []*route
+
+	ctx       context.Context
+	ctxCancel context.CancelFunc
+
+	wg sync.WaitGroup
+}
+
+// AddRoute adds the route which proxies connections on the listen address to the upstreams.
+func (lb *TCP) AddRoute(listenAddr string, upstreams []string, opts ...RouteOption) error {
+	if len(upstreams) == 0 {
+		return fmt.Errorf("no upstreams for route %q", listenAddr)
+	}
+
+	r := &route{
+		listenAddr: listenAddr,
+		options:    defaultRouteOptions(),
+	}
+
+	for _, opt := range opts {
+		opt(&r.options)
+	}
+
+	for _, addr := range upstreams {
+		r.upstreams = append(r.upstreams, &upstream{addr: addr})
+	}
+
+	lb.routes = append(lb.routes, r)
+
+	return nil
+}
+
+// Start starts listening on the routes and running the health checks.
+func (lb *TCP) Start() error {
+	lb.ctx, lb.ctxCancel = context.WithCancel(context.Background())
+
+	for _, r := range lb.routes {
+		var err error
+
+		if r.listener, err = net.Listen("tcp", r.listenAddr); err != nil {
+			lb.Close() //nolint:errcheck
+
+			return fmt.Errorf("error listening on %q: %w", r.listenAddr, err)
+		}
+	}
+
+	for _, r := range lb.routes {
+		r := r
+
+		lb.wg.Add(2)
+
+		go func() {
+			defer lb.wg.Done()
+
+			r.healthCheckLoop(lb.ctx)
+		}()
+
+		go func() {
+			defer lb.wg.Done()
+
+			r.acceptLoop(lb.ctx, &lb.wg)
+		}()
+	}
+
+	return nil
+}
+
+// Wait for the load balancer to be closed.
+func (lb *TCP) Wait() {
+	lb.wg.Wait()
+}
+
+// Close stops the load balancer.
+func (lb *TCP) Close() error {
+	if lb.ctxCancel != nil {
+		lb.ctxCancel()
+	}
+
+	for _, r := range lb.routes {
+		if r.listener != nil {
+			r.listener.Close() //nolint:errcheck
+		}
+	}
+
+	return nil
+}
+
+// Run starts the load balancer and waits for it to be closed.
+func (lb *TCP) Run() error {
+	if err := lb.Start(); err != nil {
+		return err
+	}
+
+	lb.Wait()
+
+	return nil
+}
+
+type upstream struct {
+	addr string
+
+	mu      sync.Mutex
+	healthy bool
+	checked bool
+}
+
+func (u *upstream) setHealthy(healthy bool) (changed bool) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	changed = !u.checked || u.healthy != healthy
+
+	u.healthy = healthy
+	u.checked = true
+
+	return changed
+}
+
+// isHealthy returns true if the upstream passed the last health check, upstreams are healthy until checked.
+func (u *upstream) isHealthy() bool {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	return !u.checked || u.healthy
+}
+
+type route struct {
+	listenAddr string
+	upstreams  []*upstream
+	options    RouteOptions
+
+	listener net.Listener
+
+	mu      sync.Mutex
+	current int
+}
+
+// pickUpstreams returns the upstreams to try in the order of preference.
+//
+// Healthy upstreams are picked in round-robin fashion, unhealthy upstreams are tried last.
+func (r *route) pickUpstreams() []*upstream {
+	r.mu.Lock()
+	start := r.current
+	r.current = (r.current + 1) % len(r.upstreams)
+	r.mu.Unlock()
+
+	healthy := make([]*upstream, 0, len(r.upstreams))
+	unhealthy := make([]*upstream, 0, len(r.upstreams))
+
+	for i := range r.upstreams {
+		u := r.upstreams[(start+i)%len(r.upstreams)]
+
+		if u.isHealthy() {
+			healthy = append(healthy, u)
+		} else {
+			unhealthy = append(unhealthy, u)
+		}
+	}
+
+	return append(healthy, unhealthy...)
+}
+
+func (r *route) healthCheckLoop(ctx context.Context) {
+	ticker := time.NewTicker(r.options.HealthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		var wg sync.WaitGroup
+
+		for _, u := range r.upstreams {
+			u := u
+
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				checkCtx, checkCtxCancel := context.WithTimeout(ctx, r.options.HealthCheckTimeout)
+				defer checkCtxCancel()
+
+				err := r.options.HealthCheck(checkCtx, u.addr)
+
+				if ctx.Err() != nil {
+					return
+				}
+
+				if u.setHealthy(err == nil) {
+					if err == nil {
+						log.Printf("%s: upstream %q is healthy", r.listenAddr, u.addr)
+					} else {
+						log.Printf("%s: upstream %q is unhealthy: %s", r.listenAddr, u.addr, err)
+					}
+				}
+			}()
+		}
+
+		wg.Wait()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *route) acceptLoop(ctx context.Context, wg *sync.WaitGroup) {
+	for {
+		conn, err := r.listener.Accept()
+		if err != nil {
+			if ctx.Err() == nil && !errors.Is(err, net.ErrClosed) {
+				log.Printf("%s: error accepting connection: %s", r.listenAddr, err)
+			}
+
+			return
+		}
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			r.proxy(ctx, conn)
+		}()
+	}
+}
+
+func (r *route) proxy(ctx context.Context, conn net.Conn) {
+	defer conn.Close() //nolint:errcheck
+
+	var (
+		upstreamConn net.Conn
+		err          error
+	)
+
+	for _, u := range r.pickUpstreams() {
+		dialer := net.Dialer{Timeout: r.options.DialTimeout}
+
+		upstreamConn, err = dialer.DialContext(ctx, "tcp", u.addr)
+		if err == nil {
+			break
+		}
+
+		log.Printf("%s: error connecting to upstream %q: %s", r.listenAddr, u.addr, err)
+	}
+
+	if upstreamConn == nil {
+		return
+	}
+
+	defer upstreamConn.Close() //nolint:errcheck
+
+	// close both connections when the load balancer is stopped
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()         //nolint:errcheck
+			upstreamConn.Close() //nolint:errcheck
+		case <-stopCh:
+		}
+	}()
+
+	errCh := make(chan error, 2)
+
+	go func() {
+		errCh <- pipe(upstreamConn, conn)
+	}()
+
+	go func() {
+		errCh <- pipe(conn, upstreamConn)
+	}()
+
+	// wait for both directions to finish
+	<-errCh
+	<-errCh
+}
+
+// pipe copies data from src to dst, closing the write side of dst when src is exhausted.
+func pipe(dst, src net.Conn) error {
+	_, err := io.Copy(dst, src)
+
+	if tcpConn, ok := dst.(*net.TCPConn); ok {
+		tcpConn.CloseWrite() //nolint:errcheck
+	} else {
+		dst.Close() //nolint:errcheck
+	}
+
+	return err
+}
@@ -0,0 +1,158 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package loadbalancer_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/pkg/provision/loadbalancer"
+)
+
+// startUpstream starts TCP server which responds with its name and closes the connection.
+func startUpstream(t *testing.T, name string) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	t.Cleanup(func() { l.Close() }) //nolint:errcheck
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+
+			conn.Write([]byte(name)) //nolint:errcheck
+			conn.Close()             //nolint:errcheck
+		}
+	}()
+
+	return l.Addr().String()
+}
+
+func freeAddr(t *testing.T) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	addr := l.Addr().String()
+
+	require.NoError(t, l.Close())
+
+	return addr
+}
+
+func readFrom(t *testing.T, addr string) string {
+	conn, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+
+	defer conn.Close() //nolint:errcheck
+
+	b, err := ioutil.ReadAll(conn)
+	require.NoError(t, err)
+
+	return string(b)
+}
+
+func TestBalancing(t *testing.T) {
+	upstream1 := startUpstream(t, "upstream1")
+	upstream2 := startUpstream(t, "upstream2")
+
+	var (
+		mu        sync.Mutex
+		unhealthy = map[string]bool{}
+	)
+
+	check := func(ctx context.Context, upstream string) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if unhealthy[upstream] {
+			return errors.New("not ready")
+		}
+
+		return nil
+	}
+
+	listenAddr := freeAddr(t)
+
+	var lb loadbalancer.TCP
+
+	require.NoError(t, lb.AddRoute(listenAddr, []string{upstream1, upstream2},
+		loadbalancer.WithHealthCheck(check),
+		loadbalancer.WithHealthCheckInterval(10*time.Millisecond),
+	))
+
+	require.NoError(t, lb.Start())
+
+	defer func() {
+		assert.NoError(t, lb.Close())
+
+		lb.Wait()
+	}()
+
+	// both upstreams are healthy, connections are balanced
+	responses := map[string]int{}
+
+	for i := 0; i < 4; i++ {
+		responses[readFrom(t, listenAddr)]++
+	}
+
+	assert.Equal(t, map[string]int{"upstream1": 2, "upstream2": 2}, responses)
+
+	// upstream1 goes down for health checks, it should be removed from the rotation
+	mu.Lock()
+	unhealthy[upstream1] = true
+	mu.Unlock()
+
+	time.Sleep(100 * time.Millisecond)
+
+	for i := 0; i < 4; i++ {
+		assert.Equal(t, "upstream2", readFrom(t, listenAddr))
+	}
+}
+
+func TestAllUnhealthy(t *testing.T) {
+	upstream1 := startUpstream(t, "upstream1")
+
+	// upstream which doesn't accept connections
+	upstream2 := freeAddr(t)
+
+	listenAddr := freeAddr(t)
+
+	var lb loadbalancer.TCP
+
+	require.NoError(t, lb.AddRoute(listenAddr, []string{upstream2, upstream1},
+		loadbalancer.WithHealthCheck(func(ctx context.Context, upstream string) error { return errors.New("not ready") }),
+		loadbalancer.WithHealthCheckInterval(10*time.Millisecond),
+	))
+
+	require.NoError(t, lb.Start())
+
+	defer func() {
+		assert.NoError(t, lb.Close())
+
+		lb.Wait()
+	}()
+
+	// no healthy upstreams, connection goes to any upstream accepting connections
+	for i := 0; i < 2; i++ {
+		assert.Equal(t, "upstream1", readFrom(t, listenAddr))
+	}
+}
+
+func TestNoUpstreams(t *testing.T) {
+	var lb loadbalancer.TCP
+
+	assert.EqualError(t, lb.AddRoute("127.0.0.1:0", nil), `no upstreams for route "127.0.0.1:0"`)
+}
@@ -0,0 +1,62 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package loadbalancer
+
+import (
+	"context"
+	"time"
+)
+
+// HealthCheck checks the health of the upstream, nil error means upstream is healthy.
+type HealthCheck func(ctx context.Context, upstream string) error
+
+// RouteOptions configures the route of the load balancer.
+type RouteOptions struct {
+	HealthCheck         HealthCheck
+	HealthCheckInterval time.Duration
+	HealthCheckTimeout  time.Duration
+
+	DialTimeout time.Duration
+}
+
+// RouteOption configures RouteOptions.
+type RouteOption func(o *RouteOptions)
+
+// WithHealthCheck sets the upstream health check (TCP connect check by default).
+func WithHealthCheck(check HealthCheck) RouteOption {
+	return func(o *RouteOptions) {
+		o.HealthCheck = check
+	}
+}
+
+// WithHealthCheckInterval sets the interval between the upstream health checks.
+func WithHealthCheckInterval(interval time.Duration) RouteOption {
+	return func(o *RouteOptions) {
+		o.HealthCheckInterval = interval
+	}
+}
+
+// WithHealthCheckTimeout sets the timeout of a single upstream health check.
+func WithHealthCheckTimeout(timeout time.Duration) RouteOption {
+	return func(o *RouteOptions) {
+		o.HealthCheckTimeout = timeout
+	}
+}
+
+// WithDialTimeout sets the timeout to connect to the upstream.
+func WithDialTimeout(timeout time.Duration) RouteOption {
+	return func(o *RouteOptions) {
+		o.DialTimeout = timeout
+	}
+}
+
+func defaultRouteOptions() RouteOptions {
+	return RouteOptions{
+		HealthCheck:         TCPHealthCheck,
+		HealthCheckInterval: 5 * time.Second,
+		HealthCheckTimeout:  2 * time.Second,
+		DialTimeout:         5 * time.Second,
+	}
+}
@@ -70,6 +70,15 @@ func WithUEFI(enabled bool) Option {
 	}
 }
 
+// WithTPM2 enables or disables TPM2 emulation (QEMU provisioner only).
+func WithTPM2(enabled bool) Option {
+	return func(o *Options) error {
+		o.TPM2Enabled = enabled
+
+		return nil
+	}
+}
+
 // WithTargetArch specifies target architecture for the cluster.
 func WithTargetArch(arch string) Option {
 	return func(o *Options) error {
@@ -97,6 +106,26 @@ func WithDockerPortsHostIP(hostIP string) Option {
 	}
 }
 
+// WithDockerMounts mounts host paths into the nodes in docker provisioner.
+//
+// Mounts are in the format <host path>:<container path>[:ro].
+func WithDockerMounts(mounts []string) Option {
+	return func(o *Options) error {
+		o.DockerMounts = mounts
+
+		return nil
+	}
+}
+
+// WithForceDestroy removes the cluster state even if some of the cluster resources failed to be destroyed.
+func WithForceDestroy(force bool) Option {
+	return func(o *Options) error {
+		o.ForceDestroy = force
+
+		return nil
+	}
+}
+
 // Options describes Provisioner parameters.
 type Options struct {
 	LogWriter     io.Writer
@@ -111,9 +140,18 @@ type Options struct {
 	// Enable UEFI (for amd64), arm64 can only boot UEFI
 	UEFIEnabled bool
 
+	// Enable TPM2 emulation with swtpm
+	TPM2Enabled bool
+
 	// Expose ports to worker machines in docker provisioner
 	DockerPorts       []string
 	DockerPortsHostIP string
+
+	// Mount host paths into the machines in docker provisioner
+	DockerMounts []string
+
+	// Remove cluster state even if destroying some of the resources failed
+	ForceDestroy bool
 }
 
 // DefaultOptions returns default options.
@@ -0,0 +1,228 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package provision
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"time"
+
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1/generate"
+)
+
+// Provider is the infrastructure provider which implements the steps of the cluster lifecycle.
+//
+// Provider is the stable API for the provisioners implemented outside of Talos source tree
+// (e.g. Proxmox, vSphere, libvirt), NewProvisioner turns Provider into Provisioner.
+//
+// Cluster creation runs PrepareNetwork, CreateNodes, then optional Bootstrap and PostCheck steps.
+// Provider might implement optional interfaces Bootstrapper, PostChecker, CrashDumper, NodeLogger
+// and io.Closer to hook into the corresponding steps.
+type Provider interface {
+	// Name of the provider.
+	Name() string
+
+	// PrepareNetwork creates the cluster network and fills in cluster network information.
+	PrepareNetwork(ctx context.Context, cluster *ProviderCluster, request NetworkRequest, options *Options) error
+	// CreateNodes creates and launches the nodes adding them to the cluster.
+	CreateNodes(ctx context.Context, cluster *ProviderCluster, requests NodeRequests, options *Options) error
+
+	// Destroy removes all the resources of the cluster.
+	Destroy(ctx context.Context, cluster Cluster, options *Options) error
+	// Reflect restores the cluster from the provider state.
+	Reflect(ctx context.Context, clusterName, stateDirectory string) (Cluster, error)
+
+	GenOptions(NetworkRequest) []generate.GenOption
+	GetLoadBalancers(NetworkRequest) (internalEndpoint, externalEndpoint string)
+	GetFirstInterface() string
+	UserDiskName(index int) string
+}
+
+// Bootstrapper is implemented by providers which require additional steps once all the nodes are created
+// (e.g. waiting for the nodes to acquire addresses, registering DNS records).
+type Bootstrapper interface {
+	Bootstrap(ctx context.Context, cluster *ProviderCluster, options *Options) error
+}
+
+// PostChecker is implemented by providers which verify the provisioned infrastructure after the bootstrap.
+type PostChecker interface {
+	PostCheck(ctx context.Context, cluster *ProviderCluster, options *Options) error
+}
+
+// CrashDumper is implemented by providers which can produce debug information on failures.
+type CrashDumper interface {
+	CrashDump(ctx context.Context, cluster Cluster, out io.Writer)
+}
+
+// NodeLogger is implemented by providers which provide access to the node console logs.
+type NodeLogger interface {
+	NodeLogs(ctx context.Context, cluster Cluster, nodeName string, tailLines int32, follow bool, out io.Writer) error
+}
+
+// ProviderMetadata describes the cluster created by the Provider.
+type ProviderMetadata struct {
+	// Name of the provider which created the cluster.
+	Provider string
+	// Time the cluster creation was started.
+	CreatedAt time.Time
+	// Provider-specific details (e.g. IDs of the infrastructure resources).
+	Annotations map[string]string
+}
+
+// ProviderCluster is the cluster built by the Provider lifecycle steps.
+type ProviderCluster struct {
+	ClusterInfo ClusterInfo
+	Metadata    ProviderMetadata
+
+	// Path to the cluster state directory, empty if the provider doesn't keep local state.
+	StateDirectory string
+}
+
+// Provisioner implements Cluster.
+func (cluster *ProviderCluster) Provisioner() string {
+	return cluster.Metadata.Provider
+}
+
+// StatePath implements Cluster.
+func (cluster *ProviderCluster) StatePath() (string, error) {
+	if cluster.StateDirectory == "" {
+		return "", fmt.Errorf("state path is not set")
+	}
+
+	return cluster.StateDirectory, nil
+}
+
+// Info implements Cluster.
+func (cluster *ProviderCluster) Info() ClusterInfo {
+	return cluster.ClusterInfo
+}
+
+// NewProvisioner builds Provisioner which runs the Provider through the cluster lifecycle.
+func NewProvisioner(provider Provider) Provisioner {
+	return &providerProvisioner{
+		provider: provider,
+	}
+}
+
+type providerProvisioner struct {
+	provider Provider
+}
+
+func (p *providerProvisioner) Create(ctx context.Context, request ClusterRequest, opts ...Option) (Cluster, error) {
+	options := DefaultOptions()
+
+	for _, opt := range opts {
+		if err := opt(&options); err != nil {
+			return nil, err
+		}
+	}
+
+	cluster := &ProviderCluster{
+		ClusterInfo: ClusterInfo{
+			ClusterName: request.Name,
+		},
+		Metadata: ProviderMetadata{
+			Provider:    p.provider.Name(),
+			CreatedAt:   time.Now(),
+			Annotations: map[string]string{},
+		},
+	}
+
+	if request.StateDirectory != "" {
+		cluster.StateDirectory = filepath.Join(request.StateDirectory, request.Name)
+	}
+
+	fmt.Fprintln(options.LogWriter, "creating network", request.Network.Name)
+
+	if err := p.provider.PrepareNetwork(ctx, cluster, request.Network, &options); err != nil {
+		return cluster, fmt.Errorf("error preparing network: %w", err)
+	}
+
+	fmt.Fprintln(options.LogWriter, "creating nodes")
+
+	if err := p.provider.CreateNodes(ctx, cluster, request.Nodes, &options); err != nil {
+		return cluster, fmt.Errorf("error creating nodes: %w", err)
+	}
+
+	if bootstrapper, ok := p.provider.(Bootstrapper); ok {
+		fmt.Fprintln(options.LogWriter, "bootstrapping infrastructure")
+
+		if err := bootstrapper.Bootstrap(ctx, cluster, &options); err != nil {
+			return cluster, fmt.Errorf("error bootstrapping: %w", err)
+		}
+	}
+
+	if checker, ok := p.provider.(PostChecker); ok {
+		fmt.Fprintln(options.LogWriter, "running post-create checks")
+
+		if err := checker.PostCheck(ctx, cluster, &options); err != nil {
+			return cluster, fmt.Errorf("post-create checks failed: %w", err)
+		}
+	}
+
+	return cluster, nil
+}
+
+func (p *providerProvisioner) Destroy(ctx context.Context, cluster Cluster, opts ...Option) error {
+	options := DefaultOptions()
+
+	for _, opt := range opts {
+		if err := opt(&options); err != nil {
+			return err
+		}
+	}
+
+	return p.provider.Destroy(ctx, cluster, &options)
+}
+
+func (p *providerProvisioner) CrashDump(ctx context.Context, cluster Cluster, out io.Writer) {
+	dumper, ok := p.provider.(CrashDumper)
+	if !ok {
+		fmt.Fprintf(out, "crash dump is not supported by the provider %q\n", p.provider.Name())
+
+		return
+	}
+
+	dumper.CrashDump(ctx, cluster, out)
+}
+
+func (p *providerProvisioner) NodeLogs(ctx context.Context, cluster Cluster, nodeName string, tailLines int32, follow bool, out io.Writer) error {
+	logger, ok := p.provider.(NodeLogger)
+	if !ok {
+		return fmt.Errorf("node logs are not supported by the provider %q", p.provider.Name())
+	}
+
+	return logger.NodeLogs(ctx, cluster, nodeName, tailLines, follow, out)
+}
+
+func (p *providerProvisioner) Reflect(ctx context.Context, clusterName, stateDirectory string) (Cluster, error) {
+	return p.provider.Reflect(ctx, clusterName, stateDirectory)
+}
+
+func (p *providerProvisioner) GenOptions(networkReq NetworkRequest) []generate.GenOption {
+	return p.provider.GenOptions(networkReq)
+}
+
+func (p *providerProvisioner) GetLoadBalancers(networkReq NetworkRequest) (internalEndpoint, externalEndpoint string) {
+	return p.provider.GetLoadBalancers(networkReq)
+}
+
+func (p *providerProvisioner) GetFirstInterface() string {
+	return p.provider.GetFirstInterface()
+}
+
+func (p *providerProvisioner) UserDiskName(index int) string {
+	return p.provider.UserDiskName(index)
+}
+
+func (p *providerProvisioner) Close() error {
+	if closer, ok := p.provider.(io.Closer); ok {
+		return closer.Close()
+	}
+
+	return nil
+}
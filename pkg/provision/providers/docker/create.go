@@ -27,6 +27,16 @@ func (p *provisioner) Create(ctx context.Context, request provision.ClusterReque
 		return nil, err
 	}
 
+	for _, node := range request.Nodes {
+		if node.Image == "" {
+			continue
+		}
+
+		if err = p.ensureImageExists(ctx, node.Image, &options); err != nil {
+			return nil, err
+		}
+	}
+
 	fmt.Fprintln(options.LogWriter, "creating network", request.Network.Name)
 
 	if err = p.createNetwork(ctx, request.Network); err != nil {
@@ -56,8 +66,8 @@ func (p *provisioner) Create(ctx context.Context, request provision.ClusterReque
 			ClusterName: request.Name,
 			Network: provision.NetworkInfo{
 				Name:         request.Network.Name,
-				CIDRs:        request.Network.CIDRs[:1],
-				GatewayAddrs: request.Network.GatewayAddrs[:1],
+				CIDRs:        request.Network.CIDRs,
+				GatewayAddrs: request.Network.GatewayAddrs,
 				MTU:          request.Network.MTU,
 			},
 			Nodes: nodeInfo,
@@ -8,6 +8,8 @@ import (
 	"context"
 	"fmt"
 
+	multierror "github.com/hashicorp/go-multierror"
+
 	"github.com/talos-systems/talos/pkg/provision"
 )
 
@@ -23,11 +25,19 @@ func (p *provisioner) Destroy(ctx context.Context, cluster provision.Cluster, op
 		}
 	}
 
+	// keep going on errors, so that partially created or destroyed clusters are cleaned up as much as possible
+	var result *multierror.Error
+
 	if err := p.destroyNodes(ctx, cluster.Info().ClusterName, &options); err != nil {
-		return err
+		result = multierror.Append(result, err)
 	}
 
-	fmt.Println("destroying network", cluster.Info().Network.Name)
+	// network is looked up by the cluster name, as it might be already partially destroyed
+	fmt.Fprintln(options.LogWriter, "destroying network", cluster.Info().ClusterName)
+
+	if err := p.destroyNetwork(ctx, cluster.Info().ClusterName); err != nil {
+		result = multierror.Append(result, err)
+	}
 
-	return p.destroyNetwork(ctx, cluster.Info().Network.Name)
+	return result.ErrorOrNil()
 }
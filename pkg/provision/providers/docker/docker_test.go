@@ -2,13 +2,77 @@
 // License, v. 2.0. If a copy of the MPL was not distributed with this
 // file, You can obtain one at http://mozilla.org/MPL/2.0/.
 
-package docker_test
+package docker
 
-import "testing"
+import (
+	"testing"
 
-func TestEmpty(t *testing.T) {
-	// added for accurate coverage estimation
-	//
-	// please remove it once any unit-test is added
-	// for this package
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/go-connections/nat"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenPortMap(t *testing.T) {
+	ports, err := genPortMap([]string{"50000:50000/tcp", "127.0.0.1:8080:80", "9000-9001:9000-9001/udp", "443"}, "0.0.0.0")
+	require.NoError(t, err)
+
+	assert.Equal(t, nat.PortSet{
+		"50000/tcp": {},
+		"80/tcp":    {},
+		"9000/udp":  {},
+		"9001/udp":  {},
+		"443/tcp":   {},
+	}, ports.exposedPorts)
+
+	assert.Equal(t, nat.PortMap{
+		"50000/tcp": {{HostIP: "0.0.0.0", HostPort: "50000"}},
+		"80/tcp":    {{HostIP: "127.0.0.1", HostPort: "8080"}},
+		"9000/udp":  {{HostIP: "0.0.0.0", HostPort: "9000"}},
+		"9001/udp":  {{HostIP: "0.0.0.0", HostPort: "9001"}},
+		"443/tcp":   {{HostIP: "0.0.0.0", HostPort: ""}},
+	}, ports.portBindings)
+
+	_, err = genPortMap([]string{"80:80/sctp-over-udp"}, "0.0.0.0")
+	assert.Error(t, err)
+}
+
+func TestParseMounts(t *testing.T) {
+	mounts, err := parseMounts([]string{"/var/lib/images:/var/lib/images:ro", "/tmp/talos:/var/mnt/talos"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []mount.Mount{
+		{
+			Type:     mount.TypeBind,
+			Source:   "/var/lib/images",
+			Target:   "/var/lib/images",
+			ReadOnly: true,
+		},
+		{
+			Type:   mount.TypeBind,
+			Source: "/tmp/talos",
+			Target: "/var/mnt/talos",
+		},
+	}, mounts)
+
+	for _, tt := range []struct {
+		spec string
+		err  string
+	}{
+		{
+			spec: "/var/lib/images",
+			err:  "incorrect format for mount \"/var/lib/images\"",
+		},
+		{
+			spec: "/var/lib/images:images",
+			err:  "mount \"/var/lib/images:images\" target should be an absolute path",
+		},
+		{
+			spec: "/var/lib/images:/var/lib/images:rshared",
+			err:  "unsupported mount \"/var/lib/images:/var/lib/images:rshared\" mode \"rshared\"",
+		},
+	} {
+		_, err = parseMounts([]string{tt.spec})
+		assert.EqualError(t, err, tt.err)
+	}
 }
@@ -0,0 +1,56 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package docker
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/stdcopy"
+
+	"github.com/talos-systems/talos/pkg/provision"
+)
+
+// NodeLogs writes the logs of the node container to out.
+func (p *provisioner) NodeLogs(ctx context.Context, cluster provision.Cluster, nodeName string, tailLines int32, follow bool, out io.Writer) error {
+	containers, err := p.listNodes(ctx, cluster.Info().ClusterName)
+	if err != nil {
+		return fmt.Errorf("error listing containers: %w", err)
+	}
+
+	for _, container := range containers {
+		if container.Names[0][1:] != nodeName {
+			continue
+		}
+
+		tail := "all"
+		if tailLines >= 0 {
+			tail = strconv.Itoa(int(tailLines))
+		}
+
+		var logs io.ReadCloser
+
+		logs, err = p.client.ContainerLogs(ctx, container.ID, types.ContainerLogsOptions{
+			ShowStdout: true,
+			ShowStderr: true,
+			Follow:     follow,
+			Tail:       tail,
+		})
+		if err != nil {
+			return fmt.Errorf("error querying container logs: %w", err)
+		}
+
+		defer logs.Close() //nolint:errcheck
+
+		_, err = stdcopy.StdCopy(out, out, logs)
+
+		return err
+	}
+
+	return fmt.Errorf("node %q not found in cluster %q", nodeName, cluster.Info().ClusterName)
+}
@@ -34,18 +34,28 @@ func (p *provisioner) createNetwork(ctx context.Context, req provision.NetworkRe
 		return nil
 	}
 
+	ipamConfig := make([]network.IPAMConfig, 0, len(req.CIDRs))
+	enableIPv6 := false
+
+	for _, cidr := range req.CIDRs {
+		ipamConfig = append(ipamConfig, network.IPAMConfig{
+			Subnet: cidr.String(),
+		})
+
+		if cidr.IP.To4() == nil {
+			enableIPv6 = true
+		}
+	}
+
 	// Create new net
 	options := types.NetworkCreate{
+		EnableIPv6: enableIPv6,
 		Labels: map[string]string{
 			"talos.owned":        "true",
 			"talos.cluster.name": req.Name,
 		},
 		IPAM: &network.IPAM{
-			Config: []network.IPAMConfig{
-				{
-					Subnet: req.CIDRs[0].String(),
-				},
-			},
+			Config: ipamConfig,
 		},
 		Options: map[string]string{
 			"com.docker.network.driver.mtu": strconv.Itoa(req.MTU),
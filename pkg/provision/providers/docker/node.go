@@ -10,11 +10,13 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"path/filepath"
 	"strings"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/mount"
 	"github.com/docker/docker/api/types/network"
 	"github.com/docker/go-connections/nat"
 	"github.com/hashicorp/go-multierror"
@@ -74,10 +76,15 @@ func (p *provisioner) createNode(ctx context.Context, clusterReq provision.Clust
 		env = append(env, "USERDATA="+base64.StdEncoding.EncodeToString([]byte(cfg)))
 	}
 
+	image := clusterReq.Image
+	if nodeReq.Image != "" {
+		image = nodeReq.Image
+	}
+
 	// Create the container config.
 	containerConfig := &container.Config{
 		Hostname: nodeReq.Name,
-		Image:    clusterReq.Image,
+		Image:    image,
 		Env:      env,
 		Labels: map[string]string{
 			"talos.owned":        "true",
@@ -95,7 +102,13 @@ func (p *provisioner) createNode(ctx context.Context, clusterReq provision.Clust
 
 	// Create the host config.
 
+	mounts, err := parseMounts(options.DockerMounts)
+	if err != nil {
+		return provision.NodeInfo{}, err
+	}
+
 	hostConfig := &container.HostConfig{
+		Mounts:      mounts,
 		Privileged:  true,
 		SecurityOpt: []string{"seccomp:unconfined"},
 		Resources: container.Resources{
@@ -123,7 +136,9 @@ func (p *provisioner) createNode(ctx context.Context, clusterReq provision.Clust
 			portsToOpen = append(portsToOpen, options.DockerPorts...)
 		}
 
-		generatedPortMap, err := genPortMap(portsToOpen, options.DockerPortsHostIP)
+		var generatedPortMap portMap
+
+		generatedPortMap, err = genPortMap(portsToOpen, options.DockerPortsHostIP)
 		if err != nil {
 			return provision.NodeInfo{}, err
 		}
@@ -140,7 +155,17 @@ func (p *provisioner) createNode(ctx context.Context, clusterReq provision.Clust
 	}
 
 	if nodeReq.IPs != nil {
-		networkConfig.EndpointsConfig[clusterReq.Network.Name].IPAMConfig = &network.EndpointIPAMConfig{IPv4Address: nodeReq.IPs[0].String()}
+		ipamConfig := &network.EndpointIPAMConfig{}
+
+		for _, ip := range nodeReq.IPs {
+			if ip.To4() != nil {
+				ipamConfig.IPv4Address = ip.String()
+			} else {
+				ipamConfig.IPv6Address = ip.String()
+			}
+		}
+
+		networkConfig.EndpointsConfig[clusterReq.Network.Name].IPAMConfig = ipamConfig
 	}
 
 	// Create the container.
@@ -161,6 +186,18 @@ func (p *provisioner) createNode(ctx context.Context, clusterReq provision.Clust
 		return provision.NodeInfo{}, err
 	}
 
+	endpoint := info.NetworkSettings.Networks[clusterReq.Network.Name]
+
+	ips := make([]net.IP, 0, len(clusterReq.Network.CIDRs))
+
+	for _, cidr := range clusterReq.Network.CIDRs {
+		if cidr.IP.To4() != nil {
+			ips = append(ips, net.ParseIP(endpoint.IPAddress))
+		} else {
+			ips = append(ips, net.ParseIP(endpoint.GlobalIPv6Address))
+		}
+	}
+
 	nodeInfo := provision.NodeInfo{
 		ID:   info.ID,
 		Name: info.Name,
@@ -169,7 +206,7 @@ func (p *provisioner) createNode(ctx context.Context, clusterReq provision.Clust
 		NanoCPUs: nodeReq.NanoCPUs,
 		Memory:   nodeReq.Memory,
 
-		IPs: []net.IP{net.ParseIP(info.NetworkSettings.Networks[clusterReq.Network.Name].IPAddress)},
+		IPs: ips,
 	}
 
 	return nodeInfo, nil
@@ -208,36 +245,64 @@ func (p *provisioner) destroyNodes(ctx context.Context, clusterName string, opti
 	return multiErr.ErrorOrNil()
 }
 
+// genPortMap parses the port specs in the docker format ([<host IP>:][<host port>:]<container port>[/<protocol>]),
+// host ports might be ranges, or omitted to pick random host ports.
 func genPortMap(portList []string, hostIP string) (portMap, error) {
-	portSetRet := nat.PortSet{}
-	portMapRet := nat.PortMap{}
+	exposedPorts, portBindings, err := nat.ParsePortSpecs(portList)
+	if err != nil {
+		return portMap{}, err
+	}
+
+	for _, bindings := range portBindings {
+		for i := range bindings {
+			if bindings[i].HostIP == "" {
+				bindings[i].HostIP = hostIP
+			}
+		}
+	}
 
-	for _, port := range portList {
-		explodedPortAndProtocol := strings.Split(port, "/")
+	return portMap{exposedPorts, portBindings}, nil
+}
 
-		if len(explodedPortAndProtocol) != 2 {
-			return portMap{}, errors.New("incorrect format for exposed port/protocols")
+// parseMounts parses the bind mounts in the format <host path>:<container path>[:ro|rw].
+func parseMounts(specs []string) ([]mount.Mount, error) {
+	mounts := make([]mount.Mount, 0, len(specs))
+
+	for _, spec := range specs {
+		parts := strings.Split(spec, ":")
+
+		if len(parts) < 2 || len(parts) > 3 || parts[0] == "" {
+			return nil, fmt.Errorf("incorrect format for mount %q", spec)
+		}
+
+		if !filepath.IsAbs(parts[1]) {
+			return nil, fmt.Errorf("mount %q target should be an absolute path", spec)
 		}
 
-		explodedPort := strings.Split(explodedPortAndProtocol[0], ":")
+		readOnly := false
 
-		if len(explodedPort) != 2 {
-			return portMap{}, errors.New("incorrect format for exposed ports")
+		if len(parts) == 3 {
+			switch parts[2] {
+			case "ro":
+				readOnly = true
+			case "rw":
+			default:
+				return nil, fmt.Errorf("unsupported mount %q mode %q", spec, parts[2])
+			}
 		}
 
-		natPort, err := nat.NewPort(explodedPortAndProtocol[1], explodedPort[1])
+		source, err := filepath.Abs(parts[0])
 		if err != nil {
-			return portMap{}, err
+			return nil, err
 		}
 
-		portSetRet[natPort] = struct{}{}
-		portMapRet[natPort] = []nat.PortBinding{
-			{
-				HostIP:   hostIP,
-				HostPort: explodedPort[0],
-			},
-		}
+		mounts = append(mounts, mount.Mount{
+			Type:     mount.TypeBind,
+			Source:   source,
+			Target:   parts[1],
+			ReadOnly: readOnly,
+		})
 	}
 
-	return portMap{portSetRet, portMapRet}, nil
+	return mounts, nil
 }
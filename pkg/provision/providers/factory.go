@@ -7,13 +7,55 @@ package providers
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"github.com/talos-systems/talos/pkg/provision"
 	"github.com/talos-systems/talos/pkg/provision/providers/docker"
 )
 
+// ProviderFactory instantiates out-of-tree provision provider.
+type ProviderFactory func(ctx context.Context) (provision.Provider, error)
+
+var (
+	registeredMu sync.Mutex
+	registered   = map[string]ProviderFactory{}
+)
+
+// Register makes out-of-tree provision provider available by name.
+//
+// Register is supposed to be called from the init() of the provider package,
+// it panics if the name is already registered or clashes with the built-in provisioner.
+func Register(name string, factory ProviderFactory) {
+	registeredMu.Lock()
+	defer registeredMu.Unlock()
+
+	switch name {
+	case "docker", "firecracker", "qemu":
+		panic(fmt.Sprintf("provisioner %q is built-in", name))
+	}
+
+	if _, exists := registered[name]; exists {
+		panic(fmt.Sprintf("provisioner %q is already registered", name))
+	}
+
+	registered[name] = factory
+}
+
 // Factory instantiates provision provider by name.
 func Factory(ctx context.Context, name string) (provision.Provisioner, error) {
+	registeredMu.Lock()
+	factory, ok := registered[name]
+	registeredMu.Unlock()
+
+	if ok {
+		provider, err := factory(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return provision.NewProvisioner(provider), nil
+	}
+
 	switch name {
 	case "docker":
 		return docker.NewProvisioner(ctx)
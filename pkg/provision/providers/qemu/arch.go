@@ -61,6 +61,18 @@ func (arch Arch) Console() string {
 	}
 }
 
+// TPMDevice defines the qemu device model of the emulated TPM.
+func (arch Arch) TPMDevice() string {
+	switch arch {
+	case ArchAmd64:
+		return "tpm-tis"
+	case ArchArm64:
+		return "tpm-tis-device"
+	default:
+		panic("unsupported architecture")
+	}
+}
+
 // PFlash for UEFI boot.
 type PFlash struct {
 	Size        int64
@@ -9,6 +9,8 @@ import (
 	"fmt"
 	"os"
 
+	multierror "github.com/hashicorp/go-multierror"
+
 	"github.com/talos-systems/talos/pkg/provision"
 	"github.com/talos-systems/talos/pkg/provision/providers/vm"
 )
@@ -23,41 +25,60 @@ func (p *provisioner) Destroy(ctx context.Context, cluster provision.Cluster, op
 		}
 	}
 
+	state, ok := cluster.(*vm.State)
+	if !ok {
+		return fmt.Errorf("error inspecting qemu state, %#+v", cluster)
+	}
+
+	// keep going on errors, so that partially created or destroyed clusters are cleaned up as much as possible
+	var result *multierror.Error
+
 	fmt.Fprintln(options.LogWriter, "stopping VMs")
 
 	if err := p.DestroyNodes(cluster.Info(), &options); err != nil {
-		return err
-	}
-
-	state, ok := cluster.(*vm.State)
-	if !ok {
-		return fmt.Errorf("error inspecting firecracker state, %#+v", cluster)
+		result = multierror.Append(result, err)
 	}
 
 	fmt.Fprintln(options.LogWriter, "removing dhcpd")
 
 	if err := p.DestroyDHCPd(state); err != nil {
-		return fmt.Errorf("error stopping dhcpd: %w", err)
+		result = multierror.Append(result, fmt.Errorf("error stopping dhcpd: %w", err))
 	}
 
 	fmt.Fprintln(options.LogWriter, "removing load balancer")
 
 	if err := p.DestroyLoadBalancer(state); err != nil {
-		return fmt.Errorf("error stopping loadbalancer: %w", err)
+		result = multierror.Append(result, fmt.Errorf("error stopping loadbalancer: %w", err))
+	}
+
+	fmt.Fprintln(options.LogWriter, "removing firewall rules")
+
+	if err := p.DestroyFirewall(state); err != nil {
+		result = multierror.Append(result, fmt.Errorf("error removing firewall rules: %w", err))
 	}
 
 	fmt.Fprintln(options.LogWriter, "removing network")
 
 	if err := p.DestroyNetwork(state); err != nil {
-		return err
+		result = multierror.Append(result, err)
+	}
+
+	if result.ErrorOrNil() != nil && !options.ForceDestroy {
+		fmt.Fprintln(options.LogWriter, "keeping state directory, as some resources failed to be destroyed")
+
+		return result.ErrorOrNil()
 	}
 
 	fmt.Fprintln(options.LogWriter, "removing state directory")
 
 	stateDirectoryPath, err := cluster.StatePath()
 	if err != nil {
-		return err
+		return multierror.Append(result, err).ErrorOrNil()
+	}
+
+	if err = os.RemoveAll(stateDirectoryPath); err != nil {
+		result = multierror.Append(result, err)
 	}
 
-	return os.RemoveAll(stateDirectoryPath)
+	return result.ErrorOrNil()
 }
@@ -12,8 +12,10 @@ import (
 	"net"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/containernetworking/cni/libcni"
 	"github.com/containernetworking/cni/pkg/types/current"
@@ -43,6 +45,8 @@ type LaunchConfig struct {
 	PFlashImages      []string
 	KernelArgs        string
 	MachineType       string
+	TPMDevice         string
+	TPM2StateDir      string
 	MonitorPath       string
 	EnableKVM         bool
 	BootloaderEnabled bool
@@ -283,6 +287,25 @@ func launchVM(config *LaunchConfig) error {
 		}
 	}
 
+	if config.TPM2StateDir != "" {
+		var tpm2 *exec.Cmd
+
+		if tpm2, err = startTPM2Emulator(config); err != nil {
+			return err
+		}
+
+		defer func() {
+			tpm2.Process.Kill() //nolint:errcheck
+			tpm2.Wait()         //nolint:errcheck
+		}()
+
+		args = append(args,
+			"-chardev", fmt.Sprintf("socket,id=chrtpm,path=%s", filepath.Join(config.TPM2StateDir, "swtpm.sock")),
+			"-tpmdev", "emulator,id=tpm0,chardev=chrtpm",
+			"-device", fmt.Sprintf("%s,tpmdev=tpm0", config.TPMDevice),
+		)
+	}
+
 	fmt.Fprintf(os.Stderr, "starting qemu with args:\n%s\n", strings.Join(args, " "))
 	cmd := exec.Command(
 		config.QemuExecutable,
@@ -337,6 +360,45 @@ func launchVM(config *LaunchConfig) error {
 	}
 }
 
+// startTPM2Emulator starts swtpm persisting the TPM state in the node state directory,
+// so that the TPM survives VM restarts.
+func startTPM2Emulator(config *LaunchConfig) (*exec.Cmd, error) {
+	socketPath := filepath.Join(config.TPM2StateDir, "swtpm.sock")
+
+	// stale socket from the previous run
+	if err := os.Remove(socketPath); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	cmd := exec.Command("swtpm", "socket",
+		"--tpmstate", fmt.Sprintf("dir=%s,mode=0644", config.TPM2StateDir),
+		"--ctrl", fmt.Sprintf("type=unixio,path=%s", socketPath),
+		"--tpm2",
+		"--log", "level=0",
+	)
+
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("error starting swtpm: %w", err)
+	}
+
+	// wait for swtpm to create the socket before qemu connects to it
+	for i := 0; i < 50; i++ {
+		if _, err := os.Stat(socketPath); err == nil {
+			return cmd, nil
+		}
+
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	cmd.Process.Kill() //nolint:errcheck
+	cmd.Wait()         //nolint:errcheck
+
+	return nil, fmt.Errorf("timed out waiting for swtpm socket %q", socketPath)
+}
+
 // Launch a control process around qemu VM manager.
 //
 // This function is invoked from 'talosctl qemu-launch' hidden command
@@ -100,6 +100,16 @@ func (p *provisioner) createNode(state *vm.State, clusterReq provision.ClusterRe
 		return provision.NodeInfo{}, fmt.Errorf("error finding listen address for the API: %w", err)
 	}
 
+	var tpm2StateDir string
+
+	if opts.TPM2Enabled {
+		tpm2StateDir = state.GetRelativePath(fmt.Sprintf("%s-tpm2", nodeReq.Name))
+
+		if err = os.MkdirAll(tpm2StateDir, 0o755); err != nil {
+			return provision.NodeInfo{}, err
+		}
+	}
+
 	launchConfig := LaunchConfig{
 		QemuExecutable:    arch.QemuExecutable(),
 		DiskPaths:         diskPaths,
@@ -107,6 +117,8 @@ func (p *provisioner) createNode(state *vm.State, clusterReq provision.ClusterRe
 		MemSize:           memSize,
 		KernelArgs:        cmdline.String(),
 		MachineType:       arch.QemuMachine(),
+		TPMDevice:         arch.TPMDevice(),
+		TPM2StateDir:      tpm2StateDir,
 		PFlashImages:      pflashImages,
 		MonitorPath:       state.GetRelativePath(fmt.Sprintf("%s.monitor", nodeReq.Name)),
 		EnableKVM:         opts.TargetArch == runtime.GOARCH,
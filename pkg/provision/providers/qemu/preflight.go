@@ -31,6 +31,7 @@ func (p *provisioner) preflightChecks(ctx context.Context, request provision.Clu
 		checkContext.checkKVM,
 		checkContext.qemuExecutable,
 		checkContext.checkFlashImages,
+		checkContext.swtpmExecutable,
 		checkContext.cniDirectories,
 		checkContext.cniBundle,
 		checkContext.checkIptables,
@@ -95,6 +96,18 @@ func (check *preflightCheckContext) checkFlashImages(ctx context.Context) error
 	return nil
 }
 
+func (check *preflightCheckContext) swtpmExecutable(ctx context.Context) error {
+	if !check.options.TPM2Enabled {
+		return nil
+	}
+
+	if _, err := cmd.Run("swtpm", "--version"); err != nil {
+		return fmt.Errorf("error running swtpm, please install swtpm with package manager: %w", err)
+	}
+
+	return nil
+}
+
 func (check *preflightCheckContext) cniDirectories(ctx context.Context) error {
 	cniDirs := append(check.request.Network.CNI.BinPath, check.request.Network.CNI.CacheDir, check.request.Network.CNI.ConfDir)
 
@@ -0,0 +1,200 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package vm
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/coreos/go-iptables/iptables"
+	multierror "github.com/hashicorp/go-multierror"
+)
+
+// CNI chain which firewall plugin uses to allow forwarding to the VMs.
+const cniForwardChain = "CNI-FORWARD"
+
+// DestroyFirewall removes iptables rules left behind by CNI plugins for the cluster network.
+//
+// Rules are normally removed when VM launcher tears down CNI networking, but they might leak
+// if the launcher was killed or failed, so rules for any address in the cluster CIDRs are removed.
+//
+//nolint:gocyclo
+func (p *Provisioner) DestroyFirewall(state *State) error {
+	var result *multierror.Error
+
+	for _, cidr := range state.ClusterInfo.Network.CIDRs {
+		cidr := cidr
+
+		proto := iptables.ProtocolIPv4
+		if cidr.IP.To4() == nil {
+			proto = iptables.ProtocolIPv6
+		}
+
+		ipt, err := iptables.NewWithProtocol(proto)
+		if err != nil {
+			result = multierror.Append(result, fmt.Errorf("error accessing iptables: %w", err))
+
+			continue
+		}
+
+		// bridge plugin masquerade rules jump to per-VM chains from POSTROUTING
+		chains, err := deleteRulesForCIDR(ipt, "nat", "POSTROUTING", &cidr)
+		if err != nil {
+			result = multierror.Append(result, err)
+		}
+
+		removed := map[string]struct{}{}
+
+		for _, chain := range chains {
+			if _, ok := removed[chain]; ok || !strings.HasPrefix(chain, "CNI-") {
+				continue
+			}
+
+			removed[chain] = struct{}{}
+
+			if err = ipt.ClearChain("nat", chain); err != nil {
+				result = multierror.Append(result, fmt.Errorf("error clearing chain %q: %w", chain, err))
+
+				continue
+			}
+
+			if err = ipt.DeleteChain("nat", chain); err != nil {
+				result = multierror.Append(result, fmt.Errorf("error deleting chain %q: %w", chain, err))
+			}
+		}
+
+		// firewall plugin accepts forwarded traffic per VM IP
+		exists, err := ipt.ChainExists("filter", cniForwardChain)
+		if err != nil {
+			result = multierror.Append(result, fmt.Errorf("error checking chain %q: %w", cniForwardChain, err))
+
+			continue
+		}
+
+		if !exists {
+			continue
+		}
+
+		if _, err = deleteRulesForCIDR(ipt, "filter", cniForwardChain, &cidr); err != nil {
+			result = multierror.Append(result, err)
+		}
+	}
+
+	return result.ErrorOrNil()
+}
+
+// deleteRulesForCIDR removes rules in the chain matching source or destination address in the CIDR.
+//
+// Jump targets of the removed rules are returned.
+func deleteRulesForCIDR(ipt *iptables.IPTables, table, chain string, cidr *net.IPNet) ([]string, error) {
+	rules, err := ipt.List(table, chain)
+	if err != nil {
+		return nil, fmt.Errorf("error listing rules in %s/%s: %w", table, chain, err)
+	}
+
+	var (
+		targets []string
+		result  *multierror.Error
+	)
+
+	for _, rule := range rules {
+		args := splitRule(rule)
+
+		// rules are listed as `-A <chain> <rulespec>`, chain policy is listed as `-P <chain> <policy>`
+		if len(args) < 3 || args[0] != "-A" {
+			continue
+		}
+
+		spec := args[2:]
+
+		if !ruleMatchesCIDR(spec, cidr) {
+			continue
+		}
+
+		if err = ipt.Delete(table, chain, spec...); err != nil {
+			result = multierror.Append(result, fmt.Errorf("error deleting rule %q in %s/%s: %w", rule, table, chain, err))
+
+			continue
+		}
+
+		for i := 0; i < len(spec)-1; i++ {
+			if spec[i] == "-j" {
+				targets = append(targets, spec[i+1])
+			}
+		}
+	}
+
+	return targets, result.ErrorOrNil()
+}
+
+func ruleMatchesCIDR(spec []string, cidr *net.IPNet) bool {
+	for i := 0; i < len(spec)-1; i++ {
+		if spec[i] != "-s" && spec[i] != "-d" {
+			continue
+		}
+
+		// negated matches (`! -d`) don't reference the VM
+		if i > 0 && spec[i-1] == "!" {
+			continue
+		}
+
+		ip, _, err := net.ParseCIDR(spec[i+1])
+		if err != nil {
+			ip = net.ParseIP(spec[i+1])
+		}
+
+		if ip != nil && cidr.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// splitRule splits the rule as printed by `iptables -S` into the arguments.
+//
+// Double-quoted strings (e.g. comments) are kept as a single argument with the quoting removed.
+func splitRule(rule string) []string {
+	var (
+		args    []string
+		current strings.Builder
+		inQuote bool
+		escaped bool
+		inArg   bool
+	)
+
+	for _, r := range rule {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+
+			escaped = false
+		case r == '\\':
+			escaped = true
+			inArg = true
+		case r == '"':
+			inQuote = !inQuote
+			inArg = true
+		case r == ' ' && !inQuote:
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+
+			inArg = true
+		}
+	}
+
+	if inArg {
+		args = append(args, current.String())
+	}
+
+	return args
+}
@@ -0,0 +1,33 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package vm
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitRule(t *testing.T) {
+	assert.Equal(t,
+		[]string{"-A", "POSTROUTING", "-s", "10.5.0.2/32", "-m", "comment", "--comment", `name: "talos-default" id: "abcd"`, "-j", "CNI-0123"},
+		splitRule(`-A POSTROUTING -s 10.5.0.2/32 -m comment --comment "name: \"talos-default\" id: \"abcd\"" -j CNI-0123`),
+	)
+
+	assert.Equal(t, []string{"-P", "FORWARD", "ACCEPT"}, splitRule("-P FORWARD ACCEPT"))
+}
+
+func TestRuleMatchesCIDR(t *testing.T) {
+	_, cidr, err := net.ParseCIDR("10.5.0.0/24")
+	require.NoError(t, err)
+
+	assert.True(t, ruleMatchesCIDR([]string{"-s", "10.5.0.2/32", "-j", "CNI-0123"}, cidr))
+	assert.True(t, ruleMatchesCIDR([]string{"-d", "10.5.0.3/32", "-m", "conntrack", "--ctstate", "RELATED,ESTABLISHED", "-j", "ACCEPT"}, cidr))
+	assert.False(t, ruleMatchesCIDR([]string{"-s", "10.6.0.2/32", "-j", "ACCEPT"}, cidr))
+	assert.False(t, ruleMatchesCIDR([]string{"!", "-d", "10.5.0.0/24", "-j", "MASQUERADE"}, cidr))
+	assert.False(t, ruleMatchesCIDR([]string{"-j", "CNI-ADMIN"}, cidr))
+}
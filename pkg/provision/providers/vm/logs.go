@@ -0,0 +1,64 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package vm
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/talos-systems/talos/pkg/follow"
+	"github.com/talos-systems/talos/pkg/provision"
+	"github.com/talos-systems/talos/pkg/tail"
+)
+
+// NodeLogs writes the console log of the node to out.
+func (p *Provisioner) NodeLogs(ctx context.Context, cluster provision.Cluster, nodeName string, tailLines int32, followLogs bool, out io.Writer) error {
+	state, ok := cluster.(*State)
+	if !ok {
+		return fmt.Errorf("error inspecting %s state, %#+v", p.Name, cluster)
+	}
+
+	found := false
+
+	for _, node := range append(state.ClusterInfo.Nodes, state.ClusterInfo.ExtraNodes...) {
+		if node.Name == nodeName {
+			found = true
+
+			break
+		}
+	}
+
+	if !found {
+		return fmt.Errorf("node %q not found in cluster %q", nodeName, state.ClusterInfo.ClusterName)
+	}
+
+	f, err := os.Open(state.GetRelativePath(fmt.Sprintf("%s.log", nodeName)))
+	if err != nil {
+		return fmt.Errorf("error opening node log: %w", err)
+	}
+
+	defer f.Close() //nolint:errcheck
+
+	if tailLines >= 0 {
+		if err = tail.SeekLines(f, int(tailLines)); err != nil {
+			return fmt.Errorf("error seeking to the tail: %w", err)
+		}
+	}
+
+	var r io.Reader = f
+
+	if followLogs {
+		followReader := follow.NewReader(ctx, f)
+		defer followReader.Close() //nolint:errcheck
+
+		r = followReader
+	}
+
+	_, err = io.Copy(out, r)
+
+	return err
+}
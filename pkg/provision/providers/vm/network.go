@@ -136,6 +136,11 @@ func (p *Provisioner) CreateNetwork(ctx context.Context, state *State, network p
 func (p *Provisioner) DestroyNetwork(state *State) error {
 	iface, err := net.InterfaceByName(state.BridgeName)
 	if err != nil {
+		// bridge might be already removed by the previous destroy attempt
+		if strings.Contains(err.Error(), "no such network interface") {
+			return nil
+		}
+
 		return fmt.Errorf("error looking up bridge interface %q: %w", state.BridgeName, err)
 	}
 
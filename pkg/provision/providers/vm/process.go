@@ -11,6 +11,12 @@ import (
 	"syscall"
 )
 
+const (
+	stateRunning = "running"
+	stateStopped = "stopped"
+	stateUnknown = "unknown"
+)
+
 func stopProcessByPidfile(pidPath string) error {
 	pidFile, err := os.Open(pidPath)
 	if err != nil {
@@ -52,3 +58,30 @@ func stopProcessByPidfile(pidPath string) error {
 
 	return nil
 }
+
+// processStateByPidfile returns the state of the process referenced by the PID file.
+func processStateByPidfile(pidPath string) string {
+	pidFile, err := os.Open(pidPath)
+	if err != nil {
+		return stateStopped
+	}
+
+	defer pidFile.Close() //nolint:errcheck
+
+	var pid int
+
+	if _, err = fmt.Fscanf(pidFile, "%d", &pid); err != nil {
+		return stateUnknown
+	}
+
+	proc, err := os.FindProcess(pid)
+	if err != nil {
+		return stateUnknown
+	}
+
+	if err = proc.Signal(syscall.Signal(0)); err != nil {
+		return stateStopped
+	}
+
+	return stateRunning
+}
@@ -51,5 +51,11 @@ func (p *Provisioner) Reflect(ctx context.Context, clusterName, stateDirectory s
 
 	state.statePath = statePath
 
+	for _, nodes := range [][]provision.NodeInfo{state.ClusterInfo.Nodes, state.ClusterInfo.ExtraNodes} {
+		for i := range nodes {
+			nodes[i].State = processStateByPidfile(nodes[i].ID) // node.ID stores PID path for control process
+		}
+	}
+
 	return state, nil
 }
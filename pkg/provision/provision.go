@@ -19,6 +19,8 @@ type Provisioner interface {
 
 	CrashDump(context.Context, Cluster, io.Writer)
 
+	NodeLogs(ctx context.Context, cluster Cluster, nodeName string, tailLines int32, follow bool, out io.Writer) error
+
 	Reflect(ctx context.Context, clusterName, stateDirectory string) (Cluster, error)
 
 	GenOptions(NetworkRequest) []generate.GenOption
@@ -4,11 +4,163 @@
 
 package provision_test
 
-import "testing"
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"testing"
 
-func TestEmpty(t *testing.T) {
-	// added for accurate coverage estimation
-	//
-	// please remove it once any unit-test is added
-	// for this package
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1/generate"
+	"github.com/talos-systems/talos/pkg/provision"
+)
+
+type testProvider struct {
+	steps []string
+
+	failNodes bool
+}
+
+func (p *testProvider) Name() string {
+	return "test"
+}
+
+func (p *testProvider) PrepareNetwork(ctx context.Context, cluster *provision.ProviderCluster, request provision.NetworkRequest, options *provision.Options) error {
+	p.steps = append(p.steps, "network")
+
+	cluster.ClusterInfo.Network = provision.NetworkInfo{
+		Name:  request.Name,
+		CIDRs: request.CIDRs,
+		MTU:   request.MTU,
+	}
+
+	cluster.Metadata.Annotations["network-id"] = "net-1"
+
+	return nil
+}
+
+func (p *testProvider) CreateNodes(ctx context.Context, cluster *provision.ProviderCluster, requests provision.NodeRequests, options *provision.Options) error {
+	p.steps = append(p.steps, "nodes")
+
+	if p.failNodes {
+		return errors.New("out of capacity")
+	}
+
+	for _, req := range requests {
+		cluster.ClusterInfo.Nodes = append(cluster.ClusterInfo.Nodes, provision.NodeInfo{
+			Name: req.Name,
+			IPs:  req.IPs,
+		})
+	}
+
+	return nil
+}
+
+func (p *testProvider) Bootstrap(ctx context.Context, cluster *provision.ProviderCluster, options *provision.Options) error {
+	p.steps = append(p.steps, "bootstrap")
+
+	return nil
+}
+
+func (p *testProvider) PostCheck(ctx context.Context, cluster *provision.ProviderCluster, options *provision.Options) error {
+	p.steps = append(p.steps, "postcheck")
+
+	return nil
+}
+
+func (p *testProvider) Destroy(ctx context.Context, cluster provision.Cluster, options *provision.Options) error {
+	p.steps = append(p.steps, "destroy")
+
+	return nil
+}
+
+func (p *testProvider) Reflect(ctx context.Context, clusterName, stateDirectory string) (provision.Cluster, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (p *testProvider) GenOptions(provision.NetworkRequest) []generate.GenOption {
+	return nil
+}
+
+func (p *testProvider) GetLoadBalancers(provision.NetworkRequest) (internalEndpoint, externalEndpoint string) {
+	return "10.5.0.1", "127.0.0.1"
+}
+
+func (p *testProvider) GetFirstInterface() string {
+	return "eth0"
+}
+
+func (p *testProvider) UserDiskName(index int) string {
+	return "/dev/sdb"
+}
+
+func testRequest() provision.ClusterRequest {
+	_, cidr, _ := net.ParseCIDR("10.5.0.0/24") //nolint:errcheck
+
+	return provision.ClusterRequest{
+		Name: "test-cluster",
+		Network: provision.NetworkRequest{
+			Name:  "test-cluster",
+			CIDRs: []net.IPNet{*cidr},
+			MTU:   1500,
+		},
+		Nodes: provision.NodeRequests{
+			{
+				Name: "master-1",
+				IPs:  []net.IP{net.ParseIP("10.5.0.2")},
+			},
+		},
+		StateDirectory: "/tmp/clusters",
+	}
+}
+
+func TestProviderLifecycle(t *testing.T) {
+	ctx := context.Background()
+	provider := &testProvider{}
+	provisioner := provision.NewProvisioner(provider)
+
+	var logs bytes.Buffer
+
+	cluster, err := provisioner.Create(ctx, testRequest(), provision.WithLogWriter(&logs))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"network", "nodes", "bootstrap", "postcheck"}, provider.steps)
+
+	assert.Equal(t, "test", cluster.Provisioner())
+	assert.Equal(t, "test-cluster", cluster.Info().ClusterName)
+	assert.Equal(t, "test-cluster", cluster.Info().Network.Name)
+	require.Len(t, cluster.Info().Nodes, 1)
+	assert.Equal(t, "master-1", cluster.Info().Nodes[0].Name)
+
+	statePath, err := cluster.StatePath()
+	require.NoError(t, err)
+	assert.Equal(t, "/tmp/clusters/test-cluster", statePath)
+
+	require.IsType(t, &provision.ProviderCluster{}, cluster)
+	assert.Equal(t, map[string]string{"network-id": "net-1"}, cluster.(*provision.ProviderCluster).Metadata.Annotations)
+
+	require.NoError(t, provisioner.Destroy(ctx, cluster, provision.WithLogWriter(&logs)))
+	assert.Equal(t, "destroy", provider.steps[len(provider.steps)-1])
+
+	assert.EqualError(t, provisioner.NodeLogs(ctx, cluster, "master-1", -1, false, &logs), `node logs are not supported by the provider "test"`)
+	assert.NoError(t, provisioner.Close())
+}
+
+func TestProviderLifecycleFailure(t *testing.T) {
+	provider := &testProvider{failNodes: true}
+	provisioner := provision.NewProvisioner(provider)
+
+	var logs bytes.Buffer
+
+	cluster, err := provisioner.Create(context.Background(), testRequest(), provision.WithLogWriter(&logs))
+	assert.EqualError(t, err, "error creating nodes: out of capacity")
+
+	// partially created cluster is returned, so that it can be destroyed
+	require.NotNil(t, cluster)
+	assert.Equal(t, "test-cluster", cluster.Info().Network.Name)
+
+	assert.Equal(t, []string{"network", "nodes"}, provider.steps)
 }
@@ -141,6 +141,8 @@ type NodeRequest struct {
 	Disks []*Disk
 	// Ports
 	Ports []string
+	// Image overrides the cluster image for the node (container-based provisioners)
+	Image string
 	// SkipInjectingConfig disables reading configuration from http server
 	SkipInjectingConfig bool
 
@@ -58,4 +58,7 @@ type NodeInfo struct {
 	IPs []net.IP
 
 	APIPort int
+
+	// State of the node (e.g. running, stopped), filled in on Reflect
+	State string `yaml:"-"`
 }
@@ -0,0 +1,102 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package vmware provides helpers to pass the machine configuration to the VMware VMs.
+package vmware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+)
+
+const guestInfoPrefix = "guestinfo."
+
+// GuestInfo returns the guestinfo properties which pass the machine config to the VM.
+//
+// Config is gzip compressed and base64 encoded, as the size of the guestinfo properties is limited.
+func GuestInfo(config []byte) (map[string]string, error) {
+	encoded, err := encodeConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]string{
+		guestInfoPrefix + constants.VMwareGuestInfoConfigKey:         encoded,
+		guestInfoPrefix + constants.VMwareGuestInfoConfigEncodingKey: constants.VMwareGuestInfoEncodingGzipBase64,
+	}, nil
+}
+
+// GovcChangeArgs returns the `govc vm.change` arguments which set the guestinfo properties of the VM.
+func GovcChangeArgs(vm string, guestInfo map[string]string) []string {
+	args := []string{"vm.change", "-vm", vm}
+
+	keys := make([]string, 0, len(guestInfo))
+
+	for key := range guestInfo {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		args = append(args, "-e", fmt.Sprintf("%s=%s", key, guestInfo[key]))
+	}
+
+	return args
+}
+
+// ImportOptions is the subset of the `govc import.ova -options` spec.
+type ImportOptions struct {
+	Name            string            `json:"Name,omitempty"`
+	PowerOn         bool              `json:"PowerOn"`
+	PropertyMapping []PropertyMapping `json:"PropertyMapping"`
+}
+
+// PropertyMapping is the vApp property value.
+type PropertyMapping struct {
+	Key   string `json:"Key"`
+	Value string `json:"Value"`
+}
+
+// GovcImportOptions returns the `govc import.ova -options` spec which passes the machine config
+// via the vApp property of the Talos OVA.
+func GovcImportOptions(name string, config []byte, powerOn bool) ([]byte, error) {
+	encoded, err := encodeConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(ImportOptions{
+		Name:    name,
+		PowerOn: powerOn,
+		PropertyMapping: []PropertyMapping{
+			{
+				Key:   constants.VMwareGuestInfoConfigKey,
+				Value: encoded,
+			},
+		},
+	})
+}
+
+func encodeConfig(config []byte) (string, error) {
+	var buf bytes.Buffer
+
+	w := gzip.NewWriter(&buf)
+
+	if _, err := w.Write(config); err != nil {
+		return "", err
+	}
+
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
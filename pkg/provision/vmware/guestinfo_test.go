@@ -0,0 +1,59 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package vmware_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	platform "github.com/talos-systems/talos/internal/app/machined/pkg/runtime/v1alpha1/platform/vmware"
+	"github.com/talos-systems/talos/pkg/provision/vmware"
+)
+
+const config = `version: v1alpha1
+machine:
+  type: init
+`
+
+func TestGuestInfo(t *testing.T) {
+	guestInfo, err := vmware.GuestInfo([]byte(config))
+	require.NoError(t, err)
+
+	assert.Equal(t, "gzip+base64", guestInfo["guestinfo.talos.config.encoding"])
+
+	decoded, err := platform.DecodeConfig(guestInfo["guestinfo.talos.config"], guestInfo["guestinfo.talos.config.encoding"])
+	require.NoError(t, err)
+
+	assert.Equal(t, config, string(decoded))
+
+	assert.Equal(t, []string{
+		"vm.change", "-vm", "control-plane-1",
+		"-e", "guestinfo.talos.config=" + guestInfo["guestinfo.talos.config"],
+		"-e", "guestinfo.talos.config.encoding=gzip+base64",
+	}, vmware.GovcChangeArgs("control-plane-1", guestInfo))
+}
+
+func TestGovcImportOptions(t *testing.T) {
+	b, err := vmware.GovcImportOptions("control-plane-1", []byte(config), true)
+	require.NoError(t, err)
+
+	var options vmware.ImportOptions
+
+	require.NoError(t, json.Unmarshal(b, &options))
+
+	assert.Equal(t, "control-plane-1", options.Name)
+	assert.True(t, options.PowerOn)
+	require.Len(t, options.PropertyMapping, 1)
+	assert.Equal(t, "talos.config", options.PropertyMapping[0].Key)
+
+	// gzip is detected automatically
+	decoded, err := platform.DecodeConfig(options.PropertyMapping[0].Value, "")
+	require.NoError(t, err)
+
+	assert.Equal(t, config, string(decoded))
+}
@@ -0,0 +1,108 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package cluster
+
+import (
+	"fmt"
+
+	"github.com/talos-systems/os-runtime/pkg/resource"
+	"github.com/talos-systems/os-runtime/pkg/resource/meta"
+)
+
+// AffiliateType is type of Affiliate resource.
+const AffiliateType = resource.Type("Affiliates.cluster.talos.dev")
+
+// Affiliate resource describes a node record found via the discovery service.
+//
+// Affiliate resource ID is the node ID of the affiliate, affiliates are aggregated into the cluster members.
+type Affiliate struct {
+	md   resource.Metadata
+	spec AffiliateSpec
+}
+
+// AffiliateSpec describes a node record found via the discovery service.
+type AffiliateSpec struct {
+	// NodeID is the node ID of the affiliate.
+	NodeID string `yaml:"nodeId"`
+
+	// Hostname is the hostname of the affiliate.
+	Hostname string `yaml:"hostname"`
+
+	// MachineType is the Talos machine type of the affiliate.
+	MachineType string `yaml:"machineType"`
+
+	// OperatingSystem is the OS name and version of the affiliate.
+	OperatingSystem string `yaml:"operatingSystem"`
+
+	// KubernetesVersion is the kubelet version of the affiliate.
+	KubernetesVersion string `yaml:"kubernetesVersion"`
+
+	// Addresses is the list of the node addresses of the affiliate.
+	Addresses []string `yaml:"addresses"`
+}
+
+// NewAffiliate initializes an Affiliate resource.
+func NewAffiliate(id resource.ID) *Affiliate {
+	r := &Affiliate{
+		md: resource.NewMetadata(NamespaceName, AffiliateType, id, resource.VersionUndefined),
+	}
+
+	r.md.BumpVersion()
+
+	return r
+}
+
+// Metadata implements resource.Resource.
+func (r *Affiliate) Metadata() *resource.Metadata {
+	return &r.md
+}
+
+// Spec implements resource.Resource.
+func (r *Affiliate) Spec() interface{} {
+	return r.spec
+}
+
+func (r *Affiliate) String() string {
+	return fmt.Sprintf("cluster.Affiliate(%q)", r.md.ID())
+}
+
+// DeepCopy implements resource.Resource.
+func (r *Affiliate) DeepCopy() resource.Resource {
+	spec := r.spec
+	spec.Addresses = append([]string(nil), r.spec.Addresses...)
+
+	return &Affiliate{
+		md:   r.md,
+		spec: spec,
+	}
+}
+
+// ResourceDefinition implements meta.ResourceDefinitionProvider interface.
+func (r *Affiliate) ResourceDefinition() meta.ResourceDefinitionSpec {
+	return meta.ResourceDefinitionSpec{
+		Type:             AffiliateType,
+		Aliases:          []resource.Type{"affiliate", "affiliates"},
+		DefaultNamespace: NamespaceName,
+		PrintColumns: []meta.PrintColumn{
+			{
+				Name:     "Hostname",
+				JSONPath: "{.hostname}",
+			},
+			{
+				Name:     "Machine Type",
+				JSONPath: "{.machineType}",
+			},
+			{
+				Name:     "Addresses",
+				JSONPath: "{.addresses}",
+			},
+		},
+	}
+}
+
+// AffiliateSpec returns .spec.
+func (r *Affiliate) AffiliateSpec() *AffiliateSpec {
+	return &r.spec
+}
@@ -0,0 +1,11 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package cluster provides resources which describe the node as a cluster member.
+package cluster
+
+import "github.com/talos-systems/os-runtime/pkg/resource"
+
+// NamespaceName contains resources related to cluster membership.
+const NamespaceName resource.Namespace = "cluster"
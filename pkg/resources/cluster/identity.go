@@ -0,0 +1,97 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package cluster
+
+import (
+	"fmt"
+
+	"github.com/talos-systems/os-runtime/pkg/resource"
+	"github.com/talos-systems/os-runtime/pkg/resource/meta"
+)
+
+// IdentityType is type of Identity resource.
+const IdentityType = resource.Type("Identities.cluster.talos.dev")
+
+// LocalIdentity is the resource ID for this node identity.
+const LocalIdentity = resource.ID("local")
+
+// Identity resource holds the persistent identity of the node.
+type Identity struct {
+	md   resource.Metadata
+	spec IdentitySpec
+}
+
+// IdentitySpec describes the node identity.
+//
+// The private key never leaves the STATE partition, only the public part is exposed.
+// Nothing is signed with the private key yet: it is kept (and sealed with the KMS, if configured),
+// so that the node can prove the ownership of the node ID, e.g. by signing the data published to the discovery service.
+type IdentitySpec struct {
+	// NodeID is a stable UUID derived from the identity public key.
+	NodeID string `yaml:"nodeId"`
+
+	// PublicKey is the base64 encoded ed25519 public key.
+	PublicKey string `yaml:"publicKey"`
+
+	// SystemUUID is the hardware (SMBIOS) UUID of the node, if available.
+	SystemUUID string `yaml:"systemUUID,omitempty"`
+}
+
+// NewIdentity initializes an Identity resource.
+func NewIdentity(id resource.ID) *Identity {
+	r := &Identity{
+		md: resource.NewMetadata(NamespaceName, IdentityType, id, resource.VersionUndefined),
+	}
+
+	r.md.BumpVersion()
+
+	return r
+}
+
+// Metadata implements resource.Resource.
+func (r *Identity) Metadata() *resource.Metadata {
+	return &r.md
+}
+
+// Spec implements resource.Resource.
+func (r *Identity) Spec() interface{} {
+	return r.spec
+}
+
+func (r *Identity) String() string {
+	return fmt.Sprintf("cluster.Identity(%q)", r.md.ID())
+}
+
+// DeepCopy implements resource.Resource.
+func (r *Identity) DeepCopy() resource.Resource {
+	return &Identity{
+		md:   r.md,
+		spec: r.spec,
+	}
+}
+
+// ResourceDefinition implements meta.ResourceDefinitionProvider interface.
+func (r *Identity) ResourceDefinition() meta.ResourceDefinitionSpec {
+	return meta.ResourceDefinitionSpec{
+		Type:             IdentityType,
+		Aliases:          []resource.Type{"identity", "identities"},
+		DefaultNamespace: NamespaceName,
+		PrintColumns: []meta.PrintColumn{
+			{
+				Name:     "Node ID",
+				JSONPath: "{.nodeId}",
+			},
+			{
+				Name:     "System UUID",
+				JSONPath: "{.systemUUID}",
+			},
+		},
+	}
+}
+
+// IdentitySpec returns .spec.
+func (r *Identity) IdentitySpec() *IdentitySpec {
+	return &r.spec
+}
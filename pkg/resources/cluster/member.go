@@ -0,0 +1,109 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package cluster
+
+import (
+	"fmt"
+
+	"github.com/talos-systems/os-runtime/pkg/resource"
+	"github.com/talos-systems/os-runtime/pkg/resource/meta"
+)
+
+// MemberType is type of Member resource.
+const MemberType = resource.Type("Members.cluster.talos.dev")
+
+// Member resource describes a cluster member as seen via the discovery service.
+//
+// Member resource ID is the node ID of the member, members are built from the affiliates.
+type Member struct {
+	md   resource.Metadata
+	spec MemberSpec
+}
+
+// MemberSpec describes a cluster member.
+type MemberSpec struct {
+	// Hostname is the hostname of the member, it can be used as the node name in the API requests.
+	Hostname string `yaml:"hostname"`
+
+	// MachineType is the Talos machine type of the member.
+	MachineType string `yaml:"machineType"`
+
+	// OperatingSystem is the OS name and version of the member.
+	OperatingSystem string `yaml:"operatingSystem"`
+
+	// KubernetesVersion is the kubelet version of the member.
+	KubernetesVersion string `yaml:"kubernetesVersion"`
+
+	// Addresses is the list of the node addresses of the member.
+	Addresses []string `yaml:"addresses"`
+}
+
+// NewMember initializes a Member resource.
+func NewMember(id resource.ID) *Member {
+	r := &Member{
+		md: resource.NewMetadata(NamespaceName, MemberType, id, resource.VersionUndefined),
+	}
+
+	r.md.BumpVersion()
+
+	return r
+}
+
+// Metadata implements resource.Resource.
+func (r *Member) Metadata() *resource.Metadata {
+	return &r.md
+}
+
+// Spec implements resource.Resource.
+func (r *Member) Spec() interface{} {
+	return r.spec
+}
+
+func (r *Member) String() string {
+	return fmt.Sprintf("cluster.Member(%q)", r.md.ID())
+}
+
+// DeepCopy implements resource.Resource.
+func (r *Member) DeepCopy() resource.Resource {
+	spec := r.spec
+	spec.Addresses = append([]string(nil), r.spec.Addresses...)
+
+	return &Member{
+		md:   r.md,
+		spec: spec,
+	}
+}
+
+// ResourceDefinition implements meta.ResourceDefinitionProvider interface.
+func (r *Member) ResourceDefinition() meta.ResourceDefinitionSpec {
+	return meta.ResourceDefinitionSpec{
+		Type:             MemberType,
+		Aliases:          []resource.Type{"member", "members"},
+		DefaultNamespace: NamespaceName,
+		PrintColumns: []meta.PrintColumn{
+			{
+				Name:     "Hostname",
+				JSONPath: "{.hostname}",
+			},
+			{
+				Name:     "Machine Type",
+				JSONPath: "{.machineType}",
+			},
+			{
+				Name:     "OS",
+				JSONPath: "{.operatingSystem}",
+			},
+			{
+				Name:     "Addresses",
+				JSONPath: "{.addresses}",
+			},
+		},
+	}
+}
+
+// MemberSpec returns .spec.
+func (r *Member) MemberSpec() *MemberSpec {
+	return &r.spec
+}
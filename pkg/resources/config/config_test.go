@@ -27,6 +27,7 @@ func TestRegisterResource(t *testing.T) {
 	for _, resource := range []resource.Resource{
 		&config.K8sControlPlane{},
 		&config.MachineType{},
+		&config.DriftStatus{},
 		&config.MachineConfig{},
 	} {
 		assert.NoError(t, resourceRegistry.Register(ctx, resource))
@@ -0,0 +1,98 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package config
+
+import (
+	"fmt"
+
+	"github.com/talos-systems/os-runtime/pkg/resource"
+	"github.com/talos-systems/os-runtime/pkg/resource/meta"
+)
+
+// DriftStatusType is type of DriftStatus resource.
+const DriftStatusType = resource.Type("DriftStatuses.config.talos.dev")
+
+// DriftStatusID is singleton resource ID.
+const DriftStatusID = resource.ID("drift")
+
+// DriftStatus describes whether the stored machine config matches the active one.
+type DriftStatus struct {
+	md   resource.Metadata
+	spec DriftStatusSpec
+}
+
+// DriftStatusSpec describes the config drift status.
+type DriftStatusSpec struct {
+	// InSync is true when the stored config matches the active config.
+	InSync bool `yaml:"inSync"`
+	// ActiveChecksum is the checksum of the active (in-memory) config.
+	ActiveChecksum string `yaml:"activeChecksum"`
+	// StoredChecksum is the checksum of the config stored on disk.
+	StoredChecksum string `yaml:"storedChecksum"`
+	// Error is set when the stored config can't be read or parsed.
+	Error string `yaml:"error,omitempty"`
+}
+
+// NewDriftStatus initializes a DriftStatus resource.
+func NewDriftStatus() *DriftStatus {
+	r := &DriftStatus{
+		md:   resource.NewMetadata(NamespaceName, DriftStatusType, DriftStatusID, resource.VersionUndefined),
+		spec: DriftStatusSpec{},
+	}
+
+	r.md.BumpVersion()
+
+	return r
+}
+
+// Metadata implements resource.Resource.
+func (r *DriftStatus) Metadata() *resource.Metadata {
+	return &r.md
+}
+
+// Spec implements resource.Resource.
+func (r *DriftStatus) Spec() interface{} {
+	return r.spec
+}
+
+func (r *DriftStatus) String() string {
+	return fmt.Sprintf("config.DriftStatus(%q)", r.md.ID())
+}
+
+// DeepCopy implements resource.Resource.
+func (r *DriftStatus) DeepCopy() resource.Resource {
+	return &DriftStatus{
+		md:   r.md,
+		spec: r.spec,
+	}
+}
+
+// ResourceDefinition implements meta.ResourceDefinitionProvider interface.
+func (r *DriftStatus) ResourceDefinition() meta.ResourceDefinitionSpec {
+	return meta.ResourceDefinitionSpec{
+		Type:             DriftStatusType,
+		Aliases:          []resource.Type{"drift"},
+		DefaultNamespace: NamespaceName,
+		PrintColumns: []meta.PrintColumn{
+			{
+				Name:     "In Sync",
+				JSONPath: "{.inSync}",
+			},
+			{
+				Name:     "Active",
+				JSONPath: "{.activeChecksum}",
+			},
+			{
+				Name:     "Stored",
+				JSONPath: "{.storedChecksum}",
+			},
+		},
+	}
+}
+
+// TypedSpec allows to access the spec with the proper type.
+func (r *DriftStatus) TypedSpec() *DriftStatusSpec {
+	return &r.spec
+}
@@ -6,6 +6,7 @@ package config
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/talos-systems/os-runtime/pkg/resource"
 	"github.com/talos-systems/os-runtime/pkg/resource/meta"
@@ -96,6 +97,9 @@ type K8sManifestsSpec struct {
 	FlannelEnabled  bool   `yaml:"flannelEnabled"`
 	FlannelImage    string `yaml:"flannelImage"`
 	FlannelCNIImage string `yaml:"flannelCNIImage"`
+
+	SyncInterval       time.Duration `yaml:"syncInterval"`
+	UnmanagedManifests []string      `yaml:"unmanagedManifests"`
 }
 
 // ExtraManifest defines a single extra manifest to download.
@@ -108,6 +112,8 @@ type ExtraManifest struct {
 // K8sExtraManifestsSpec is a configuration for extra manifests.
 type K8sExtraManifestsSpec struct {
 	ExtraManifests []ExtraManifest `yaml:"extraManifests"`
+	// ProxyEnv holds the proxy settings from the machine configuration environment used to download the manifests.
+	ProxyEnv map[string]string `yaml:"proxyEnv,omitempty"`
 }
 
 // NewK8sControlPlaneAPIServer initializes a K8sControlPlane resource.
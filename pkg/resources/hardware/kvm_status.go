@@ -0,0 +1,105 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package hardware
+
+import (
+	"fmt"
+
+	"github.com/talos-systems/os-runtime/pkg/resource"
+	"github.com/talos-systems/os-runtime/pkg/resource/meta"
+
+	"github.com/talos-systems/talos/pkg/resources/v1alpha1"
+)
+
+// KVMStatusType is type of KVMStatus resource.
+const KVMStatusType = resource.Type("KVMStatuses.v1alpha1.talos.dev")
+
+// KVMStatusID is the ID of the singletone resource.
+const KVMStatusID = resource.ID("kvm")
+
+// KVMStatus describes hardware virtualization support on the node.
+type KVMStatus struct {
+	md   resource.Metadata
+	spec KVMStatusSpec
+}
+
+// KVMStatusSpec describes KVM state.
+type KVMStatusSpec struct {
+	// Available indicates whether /dev/kvm is present.
+	Available bool `yaml:"available"`
+
+	// Vendor is the loaded KVM vendor module (intel or amd).
+	Vendor string `yaml:"vendor,omitempty"`
+
+	// Nested indicates whether nested virtualization is enabled in the vendor module.
+	Nested bool `yaml:"nested"`
+}
+
+// NewKVMStatus initializes a KVMStatus resource.
+func NewKVMStatus() *KVMStatus {
+	r := &KVMStatus{
+		md:   resource.NewMetadata(v1alpha1.NamespaceName, KVMStatusType, KVMStatusID, resource.VersionUndefined),
+		spec: KVMStatusSpec{},
+	}
+
+	r.md.BumpVersion()
+
+	return r
+}
+
+// Metadata implements resource.Resource.
+func (r *KVMStatus) Metadata() *resource.Metadata {
+	return &r.md
+}
+
+// Spec implements resource.Resource.
+func (r *KVMStatus) Spec() interface{} {
+	return r.spec
+}
+
+func (r *KVMStatus) String() string {
+	return fmt.Sprintf("hardware.KVMStatus(%q)", r.md.ID())
+}
+
+// DeepCopy implements resource.Resource.
+func (r *KVMStatus) DeepCopy() resource.Resource {
+	return &KVMStatus{
+		md:   r.md,
+		spec: r.spec,
+	}
+}
+
+// ResourceDefinition implements meta.ResourceDefinitionProvider interface.
+func (r *KVMStatus) ResourceDefinition() meta.ResourceDefinitionSpec {
+	return meta.ResourceDefinitionSpec{
+		Type:             KVMStatusType,
+		Aliases:          []resource.Type{"kvm"},
+		DefaultNamespace: v1alpha1.NamespaceName,
+		PrintColumns: []meta.PrintColumn{
+			{
+				Name:     "Available",
+				JSONPath: "{.available}",
+			},
+			{
+				Name:     "Vendor",
+				JSONPath: "{.vendor}",
+			},
+			{
+				Name:     "Nested",
+				JSONPath: "{.nested}",
+			},
+		},
+	}
+}
+
+// SetStatus changes .spec.
+func (r *KVMStatus) SetStatus(status KVMStatusSpec) {
+	r.spec = status
+}
+
+// Status returns .spec.
+func (r *KVMStatus) Status() KVMStatusSpec {
+	return r.spec
+}
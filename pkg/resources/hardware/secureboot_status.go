@@ -0,0 +1,111 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package hardware
+
+import (
+	"fmt"
+
+	"github.com/talos-systems/os-runtime/pkg/resource"
+	"github.com/talos-systems/os-runtime/pkg/resource/meta"
+
+	"github.com/talos-systems/talos/pkg/resources/v1alpha1"
+)
+
+// SecureBootStatusType is type of SecureBootStatus resource.
+const SecureBootStatusType = resource.Type("SecureBootStatuses.v1alpha1.talos.dev")
+
+// SecureBootStatusID is the ID of the singletone resource.
+const SecureBootStatusID = resource.ID("secureboot")
+
+// SecureBootStatus describes SecureBoot state of the node.
+type SecureBootStatus struct {
+	md   resource.Metadata
+	spec SecureBootStatusSpec
+}
+
+// SecureBootStatusSpec describes SecureBoot state.
+type SecureBootStatusSpec struct {
+	// Enabled indicates whether the firmware enforces SecureBoot.
+	Enabled bool `yaml:"enabled"`
+
+	// SetupMode indicates whether the firmware has no platform key enrolled.
+	SetupMode bool `yaml:"setupMode"`
+
+	// UKI indicates whether the node was booted from a Unified Kernel Image.
+	UKI bool `yaml:"uki"`
+
+	// PCR7 is the SHA256 PCR value measuring the SecureBoot policy.
+	PCR7 string `yaml:"pcr7,omitempty"`
+
+	// PCR11 is the SHA256 PCR value measuring the UKI sections.
+	PCR11 string `yaml:"pcr11,omitempty"`
+}
+
+// NewSecureBootStatus initializes a SecureBootStatus resource.
+func NewSecureBootStatus() *SecureBootStatus {
+	r := &SecureBootStatus{
+		md:   resource.NewMetadata(v1alpha1.NamespaceName, SecureBootStatusType, SecureBootStatusID, resource.VersionUndefined),
+		spec: SecureBootStatusSpec{},
+	}
+
+	r.md.BumpVersion()
+
+	return r
+}
+
+// Metadata implements resource.Resource.
+func (r *SecureBootStatus) Metadata() *resource.Metadata {
+	return &r.md
+}
+
+// Spec implements resource.Resource.
+func (r *SecureBootStatus) Spec() interface{} {
+	return r.spec
+}
+
+func (r *SecureBootStatus) String() string {
+	return fmt.Sprintf("hardware.SecureBootStatus(%q)", r.md.ID())
+}
+
+// DeepCopy implements resource.Resource.
+func (r *SecureBootStatus) DeepCopy() resource.Resource {
+	return &SecureBootStatus{
+		md:   r.md,
+		spec: r.spec,
+	}
+}
+
+// ResourceDefinition implements meta.ResourceDefinitionProvider interface.
+func (r *SecureBootStatus) ResourceDefinition() meta.ResourceDefinitionSpec {
+	return meta.ResourceDefinitionSpec{
+		Type:             SecureBootStatusType,
+		Aliases:          []resource.Type{"secureboot"},
+		DefaultNamespace: v1alpha1.NamespaceName,
+		PrintColumns: []meta.PrintColumn{
+			{
+				Name:     "Enabled",
+				JSONPath: "{.enabled}",
+			},
+			{
+				Name:     "Setup Mode",
+				JSONPath: "{.setupMode}",
+			},
+			{
+				Name:     "UKI",
+				JSONPath: "{.uki}",
+			},
+		},
+	}
+}
+
+// SetStatus changes .spec.
+func (r *SecureBootStatus) SetStatus(status SecureBootStatusSpec) {
+	r.spec = status
+}
+
+// Status returns .spec.
+func (r *SecureBootStatus) Status() SecureBootStatusSpec {
+	return r.spec
+}
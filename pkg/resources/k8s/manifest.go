@@ -92,9 +92,13 @@ func (r *Manifest) ResourceDefinition() meta.ResourceDefinitionSpec {
 }
 
 // SetYAML parses manifest from YAML.
+//
+// SetYAML replaces the objects of the manifest.
 func (r *Manifest) SetYAML(yamlBytes []byte) error {
 	reader := yaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(yamlBytes)))
 
+	r.spec.Items = nil
+
 	for {
 		yamlManifest, err := reader.Read()
 		if err != nil {
@@ -6,6 +6,7 @@ package k8s
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/talos-systems/os-runtime/pkg/resource"
 	"github.com/talos-systems/os-runtime/pkg/resource/meta"
@@ -25,7 +26,14 @@ type ManifestStatus struct {
 
 // ManifestStatusSpec describes manifest application status.
 type ManifestStatusSpec struct {
-	ManifestsApplied []string `yaml:"manifestsApplied"`
+	ManifestsApplied   []string `yaml:"manifestsApplied"`
+	ManifestsUnmanaged []string `yaml:"manifestsUnmanaged,omitempty"`
+
+	// LastSyncTime is the time of the last reconciliation of the manifests,
+	// ObjectsCreated and ObjectsUpdated list the objects changed by the last reconciliation.
+	LastSyncTime   time.Time `yaml:"lastSyncTime"`
+	ObjectsCreated []string  `yaml:"objectsCreated,omitempty"`
+	ObjectsUpdated []string  `yaml:"objectsUpdated,omitempty"`
 }
 
 // NewManifestStatus initializes an empty ManifestStatus resource.
@@ -56,9 +64,16 @@ func (r *ManifestStatus) String() string {
 
 // DeepCopy implements resource.Resource.
 func (r *ManifestStatus) DeepCopy() resource.Resource {
+	spec := r.spec
+
+	spec.ManifestsApplied = append([]string(nil), r.spec.ManifestsApplied...)
+	spec.ManifestsUnmanaged = append([]string(nil), r.spec.ManifestsUnmanaged...)
+	spec.ObjectsCreated = append([]string(nil), r.spec.ObjectsCreated...)
+	spec.ObjectsUpdated = append([]string(nil), r.spec.ObjectsUpdated...)
+
 	return &ManifestStatus{
 		md:   r.md,
-		spec: r.spec,
+		spec: spec,
 	}
 }
 
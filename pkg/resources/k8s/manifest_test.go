@@ -47,3 +47,18 @@ rules:
 	assert.Len(t, manifest.Objects(), 1)
 	assert.Equal(t, manifest.Objects()[0].GetKind(), "Policy")
 }
+
+func TestManifestSetYAMLReplace(t *testing.T) {
+	manifest := k8s.NewManifest(k8s.ControlPlaneNamespaceName, "test")
+
+	for i := 0; i < 2; i++ {
+		require.NoError(t, manifest.SetYAML([]byte(strings.TrimSpace(`
+apiVersion: audit.k8s.io/v1beta1
+kind: Policy
+rules:
+- level: Metadata
+`))))
+	}
+
+	assert.Len(t, manifest.Objects(), 1)
+}
@@ -0,0 +1,94 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package kubespan
+
+import (
+	"fmt"
+
+	"github.com/talos-systems/os-runtime/pkg/resource"
+	"github.com/talos-systems/os-runtime/pkg/resource/meta"
+)
+
+// IdentityType is type of Identity resource.
+const IdentityType = resource.Type("KubeSpanIdentities.kubespan.talos.dev")
+
+// LocalIdentity is the resource ID for the KubeSpan identity of this node.
+const LocalIdentity = resource.ID("local")
+
+// Identity resource holds the KubeSpan identity of the node.
+type Identity struct {
+	md   resource.Metadata
+	spec IdentitySpec
+}
+
+// IdentitySpec describes the KubeSpan identity.
+//
+// Wireguard private key is kept in the STATE partition, only the public key is exposed.
+type IdentitySpec struct {
+	// PublicKey is the Wireguard public key of the node.
+	PublicKey string `yaml:"publicKey"`
+	// Address is the address of the node in the KubeSpan mesh (with the subnet prefix length).
+	Address string `yaml:"address"`
+	// Subnet is the KubeSpan mesh subnet derived from the cluster secrets.
+	Subnet string `yaml:"subnet"`
+}
+
+// NewIdentity initializes an Identity resource.
+func NewIdentity(id resource.ID) *Identity {
+	r := &Identity{
+		md:   resource.NewMetadata(NamespaceName, IdentityType, id, resource.VersionUndefined),
+		spec: IdentitySpec{},
+	}
+
+	r.md.BumpVersion()
+
+	return r
+}
+
+// Metadata implements resource.Resource.
+func (r *Identity) Metadata() *resource.Metadata {
+	return &r.md
+}
+
+// Spec implements resource.Resource.
+func (r *Identity) Spec() interface{} {
+	return r.spec
+}
+
+func (r *Identity) String() string {
+	return fmt.Sprintf("kubespan.Identity(%q)", r.md.ID())
+}
+
+// DeepCopy implements resource.Resource.
+func (r *Identity) DeepCopy() resource.Resource {
+	return &Identity{
+		md:   r.md,
+		spec: r.spec,
+	}
+}
+
+// ResourceDefinition implements meta.ResourceDefinitionProvider interface.
+func (r *Identity) ResourceDefinition() meta.ResourceDefinitionSpec {
+	return meta.ResourceDefinitionSpec{
+		Type:             IdentityType,
+		Aliases:          []resource.Type{"kubespanidentity", "kubespanidentities"},
+		DefaultNamespace: NamespaceName,
+		PrintColumns: []meta.PrintColumn{
+			{
+				Name:     "Address",
+				JSONPath: "{.address}",
+			},
+			{
+				Name:     "PublicKey",
+				JSONPath: "{.publicKey}",
+			},
+		},
+	}
+}
+
+// TypedSpec returns .spec.
+func (r *Identity) TypedSpec() *IdentitySpec {
+	return &r.spec
+}
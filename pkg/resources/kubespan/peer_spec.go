@@ -0,0 +1,98 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package kubespan
+
+import (
+	"fmt"
+
+	"github.com/talos-systems/os-runtime/pkg/resource"
+	"github.com/talos-systems/os-runtime/pkg/resource/meta"
+)
+
+// PeerSpecType is type of PeerSpec resource.
+const PeerSpecType = resource.Type("KubeSpanPeerSpecs.kubespan.talos.dev")
+
+// PeerSpec describes the KubeSpan peer discovered via the discovery service.
+//
+// Resource ID is the Wireguard public key of the peer.
+type PeerSpec struct {
+	md   resource.Metadata
+	spec PeerSpecSpec
+}
+
+// PeerSpecSpec describes the KubeSpan peer.
+type PeerSpecSpec struct {
+	// Label is the human-readable name of the peer (hostname).
+	Label string `yaml:"label"`
+	// Address is the address of the peer in the KubeSpan mesh.
+	Address string `yaml:"address"`
+	// AllowedIPs is the list of CIDRs routed to the peer over the mesh (mesh address and node addresses).
+	AllowedIPs []string `yaml:"allowedIPs"`
+	// Endpoints is the list of the Wireguard endpoints of the peer (ip:port), tried in order.
+	Endpoints []string `yaml:"endpoints"`
+}
+
+// NewPeerSpec initializes a PeerSpec resource.
+func NewPeerSpec(id resource.ID) *PeerSpec {
+	r := &PeerSpec{
+		md:   resource.NewMetadata(NamespaceName, PeerSpecType, id, resource.VersionUndefined),
+		spec: PeerSpecSpec{},
+	}
+
+	r.md.BumpVersion()
+
+	return r
+}
+
+// Metadata implements resource.Resource.
+func (r *PeerSpec) Metadata() *resource.Metadata {
+	return &r.md
+}
+
+// Spec implements resource.Resource.
+func (r *PeerSpec) Spec() interface{} {
+	return r.spec
+}
+
+func (r *PeerSpec) String() string {
+	return fmt.Sprintf("kubespan.PeerSpec(%q)", r.md.ID())
+}
+
+// DeepCopy implements resource.Resource.
+func (r *PeerSpec) DeepCopy() resource.Resource {
+	return &PeerSpec{
+		md: r.md,
+		spec: PeerSpecSpec{
+			Label:      r.spec.Label,
+			Address:    r.spec.Address,
+			AllowedIPs: append([]string(nil), r.spec.AllowedIPs...),
+			Endpoints:  append([]string(nil), r.spec.Endpoints...),
+		},
+	}
+}
+
+// ResourceDefinition implements meta.ResourceDefinitionProvider interface.
+func (r *PeerSpec) ResourceDefinition() meta.ResourceDefinitionSpec {
+	return meta.ResourceDefinitionSpec{
+		Type:             PeerSpecType,
+		Aliases:          []resource.Type{"kubespanpeerspec", "kubespanpeerspecs"},
+		DefaultNamespace: NamespaceName,
+		PrintColumns: []meta.PrintColumn{
+			{
+				Name:     "Label",
+				JSONPath: "{.label}",
+			},
+			{
+				Name:     "Address",
+				JSONPath: "{.address}",
+			},
+		},
+	}
+}
+
+// TypedSpec returns .spec.
+func (r *PeerSpec) TypedSpec() *PeerSpecSpec {
+	return &r.spec
+}
@@ -0,0 +1,93 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package network
+
+import (
+	"fmt"
+
+	"github.com/talos-systems/os-runtime/pkg/resource"
+	"github.com/talos-systems/os-runtime/pkg/resource/meta"
+)
+
+// AddressSpecType is type of AddressSpec resource.
+const AddressSpecType = resource.Type("AddressSpecs.net.talos.dev")
+
+// AddressSpec describes desired address assigned to the link.
+type AddressSpec struct {
+	md   resource.Metadata
+	spec AddressSpecSpec
+}
+
+// AddressSpecSpec describes the address.
+type AddressSpecSpec struct {
+	// Address in CIDR notation.
+	Address string `yaml:"address"`
+
+	// LinkName is the name of the link the address is assigned to.
+	LinkName string `yaml:"linkName"`
+}
+
+// AddressID builds ID of the AddressSpec resource.
+func AddressID(linkName, address string) resource.ID {
+	return fmt.Sprintf("%s/%s", linkName, address)
+}
+
+// NewAddressSpec initializes an AddressSpec resource.
+func NewAddressSpec(id resource.ID) *AddressSpec {
+	r := &AddressSpec{
+		md:   resource.NewMetadata(NamespaceName, AddressSpecType, id, resource.VersionUndefined),
+		spec: AddressSpecSpec{},
+	}
+
+	r.md.BumpVersion()
+
+	return r
+}
+
+// Metadata implements resource.Resource.
+func (r *AddressSpec) Metadata() *resource.Metadata {
+	return &r.md
+}
+
+// Spec implements resource.Resource.
+func (r *AddressSpec) Spec() interface{} {
+	return r.spec
+}
+
+func (r *AddressSpec) String() string {
+	return fmt.Sprintf("network.AddressSpec(%q)", r.md.ID())
+}
+
+// DeepCopy implements resource.Resource.
+func (r *AddressSpec) DeepCopy() resource.Resource {
+	return &AddressSpec{
+		md:   r.md,
+		spec: r.spec,
+	}
+}
+
+// ResourceDefinition implements meta.ResourceDefinitionProvider interface.
+func (r *AddressSpec) ResourceDefinition() meta.ResourceDefinitionSpec {
+	return meta.ResourceDefinitionSpec{
+		Type:             AddressSpecType,
+		Aliases:          []resource.Type{"addressspec", "addressspecs"},
+		DefaultNamespace: NamespaceName,
+		PrintColumns: []meta.PrintColumn{
+			{
+				Name:     "Address",
+				JSONPath: "{.address}",
+			},
+			{
+				Name:     "Link",
+				JSONPath: "{.linkName}",
+			},
+		},
+	}
+}
+
+// TypedSpec returns .spec.
+func (r *AddressSpec) TypedSpec() *AddressSpecSpec {
+	return &r.spec
+}
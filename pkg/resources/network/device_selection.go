@@ -0,0 +1,86 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package network
+
+import (
+	"fmt"
+
+	"github.com/talos-systems/os-runtime/pkg/resource"
+	"github.com/talos-systems/os-runtime/pkg/resource/meta"
+)
+
+// DeviceSelectionType is type of DeviceSelection resource.
+const DeviceSelectionType = resource.Type("DeviceSelections.net.talos.dev")
+
+// DeviceSelectionID builds ID of the device selection for the device with the index in the machine configuration.
+func DeviceSelectionID(deviceIdx int) resource.ID {
+	return fmt.Sprintf("interfaces/%d", deviceIdx)
+}
+
+// DeviceSelection describes the link picked by the device selector from the machine configuration.
+type DeviceSelection struct {
+	md   resource.Metadata
+	spec DeviceSelectionSpec
+}
+
+// DeviceSelectionSpec describes the link picked by the device selector.
+type DeviceSelectionSpec struct {
+	// LinkName is the name of the link matching the selector.
+	LinkName string `yaml:"linkName"`
+}
+
+// NewDeviceSelection initializes a DeviceSelection resource.
+func NewDeviceSelection(id resource.ID) *DeviceSelection {
+	r := &DeviceSelection{
+		md:   resource.NewMetadata(NamespaceName, DeviceSelectionType, id, resource.VersionUndefined),
+		spec: DeviceSelectionSpec{},
+	}
+
+	r.md.BumpVersion()
+
+	return r
+}
+
+// Metadata implements resource.Resource.
+func (r *DeviceSelection) Metadata() *resource.Metadata {
+	return &r.md
+}
+
+// Spec implements resource.Resource.
+func (r *DeviceSelection) Spec() interface{} {
+	return r.spec
+}
+
+func (r *DeviceSelection) String() string {
+	return fmt.Sprintf("network.DeviceSelection(%q)", r.md.ID())
+}
+
+// DeepCopy implements resource.Resource.
+func (r *DeviceSelection) DeepCopy() resource.Resource {
+	return &DeviceSelection{
+		md:   r.md,
+		spec: r.spec,
+	}
+}
+
+// ResourceDefinition implements meta.ResourceDefinitionProvider interface.
+func (r *DeviceSelection) ResourceDefinition() meta.ResourceDefinitionSpec {
+	return meta.ResourceDefinitionSpec{
+		Type:             DeviceSelectionType,
+		Aliases:          []resource.Type{"deviceselection", "deviceselections"},
+		DefaultNamespace: NamespaceName,
+		PrintColumns: []meta.PrintColumn{
+			{
+				Name:     "Link",
+				JSONPath: "{.linkName}",
+			},
+		},
+	}
+}
+
+// TypedSpec returns .spec.
+func (r *DeviceSelection) TypedSpec() *DeviceSelectionSpec {
+	return &r.spec
+}
@@ -0,0 +1,180 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package network
+
+import (
+	"fmt"
+
+	"github.com/talos-systems/os-runtime/pkg/resource"
+	"github.com/talos-systems/os-runtime/pkg/resource/meta"
+)
+
+// LinkSpecType is type of LinkSpec resource.
+const LinkSpecType = resource.Type("LinkSpecs.net.talos.dev")
+
+// Link kinds which can be created by the LinkSpec controller.
+const (
+	LinkKindDummy     = "dummy"
+	LinkKindBridge    = "bridge"
+	LinkKindVLAN      = "vlan"
+	LinkKindWireguard = "wireguard"
+)
+
+// LinkSpec describes desired state of the network link.
+type LinkSpec struct {
+	md   resource.Metadata
+	spec LinkSpecSpec
+}
+
+// LinkSpecSpec describes the link.
+type LinkSpecSpec struct {
+	// Name is the name of the link.
+	Name string `yaml:"name"`
+
+	// Logical links are created (and removed) by Talos, physical links should already exist.
+	Logical bool `yaml:"logical"`
+
+	// Kind of the logical link.
+	Kind string `yaml:"kind,omitempty"`
+
+	// Up brings the link administratively up.
+	Up bool `yaml:"up"`
+
+	// MTU of the link, zero leaves the current value.
+	MTU uint32 `yaml:"mtu,omitempty"`
+
+	// ParentName is the name of the parent link for VLANs.
+	ParentName string `yaml:"parentName,omitempty"`
+
+	// VLANID is the VLAN tag for VLAN links.
+	VLANID uint16 `yaml:"vlanID,omitempty"`
+
+	// MasterName is the name of the bridge the link is attached to.
+	MasterName string `yaml:"masterName,omitempty"`
+
+	// BridgeMaster contains the settings of bridge links.
+	BridgeMaster BridgeMasterSpec `yaml:"bridgeMaster,omitempty"`
+
+	// SLAAC enables IPv6 stateless address autoconfiguration via router advertisements.
+	SLAAC bool `yaml:"slaac,omitempty"`
+
+	// SLAACIgnoreDefaultRoute ignores the default route from router advertisements.
+	SLAACIgnoreDefaultRoute bool `yaml:"slaacIgnoreDefaultRoute,omitempty"`
+
+	// Ethernet contains hardware settings of the physical links.
+	Ethernet EthernetSpec `yaml:"ethernet,omitempty"`
+}
+
+// WakeOnLANDisabled disables Wake-on-LAN, if used as the only mode.
+const WakeOnLANDisabled = "disabled"
+
+// EthernetSpec describes ethernet hardware settings applied via ethtool.
+type EthernetSpec struct {
+	// WakeOnLAN is the list of Wake-on-LAN modes (ethtool names), empty list leaves the current modes.
+	WakeOnLAN []string `yaml:"wakeOnLAN,omitempty"`
+
+	// Features maps ethtool feature names to the desired state, features not in the map are not changed.
+	Features map[string]bool `yaml:"features,omitempty"`
+
+	// RingsRX and RingsTX are the ring buffer sizes, zero leaves the current value.
+	RingsRX uint32 `yaml:"ringsRX,omitempty"`
+	RingsTX uint32 `yaml:"ringsTX,omitempty"`
+}
+
+// IsZero returns true if no ethernet settings are set.
+func (spec EthernetSpec) IsZero() bool {
+	return len(spec.WakeOnLAN) == 0 && len(spec.Features) == 0 && spec.RingsRX == 0 && spec.RingsTX == 0
+}
+
+// DeepCopy returns a copy of the spec.
+func (spec EthernetSpec) DeepCopy() EthernetSpec {
+	cp := spec
+
+	cp.WakeOnLAN = append([]string(nil), spec.WakeOnLAN...)
+
+	if spec.Features != nil {
+		cp.Features = make(map[string]bool, len(spec.Features))
+
+		for k, v := range spec.Features {
+			cp.Features[k] = v
+		}
+	}
+
+	return cp
+}
+
+// BridgeMasterSpec describes bridge settings.
+type BridgeMasterSpec struct {
+	// STPEnabled enables Spanning Tree Protocol on the bridge.
+	STPEnabled bool `yaml:"stpEnabled"`
+
+	// VLANFiltering enables VLAN filtering on the bridge.
+	VLANFiltering bool `yaml:"vlanFiltering"`
+}
+
+// NewLinkSpec initializes a LinkSpec resource.
+func NewLinkSpec(id resource.ID) *LinkSpec {
+	r := &LinkSpec{
+		md:   resource.NewMetadata(NamespaceName, LinkSpecType, id, resource.VersionUndefined),
+		spec: LinkSpecSpec{},
+	}
+
+	r.md.BumpVersion()
+
+	return r
+}
+
+// Metadata implements resource.Resource.
+func (r *LinkSpec) Metadata() *resource.Metadata {
+	return &r.md
+}
+
+// Spec implements resource.Resource.
+func (r *LinkSpec) Spec() interface{} {
+	return r.spec
+}
+
+func (r *LinkSpec) String() string {
+	return fmt.Sprintf("network.LinkSpec(%q)", r.md.ID())
+}
+
+// DeepCopy implements resource.Resource.
+func (r *LinkSpec) DeepCopy() resource.Resource {
+	spec := r.spec
+	spec.Ethernet = r.spec.Ethernet.DeepCopy()
+
+	return &LinkSpec{
+		md:   r.md,
+		spec: spec,
+	}
+}
+
+// ResourceDefinition implements meta.ResourceDefinitionProvider interface.
+func (r *LinkSpec) ResourceDefinition() meta.ResourceDefinitionSpec {
+	return meta.ResourceDefinitionSpec{
+		Type:             LinkSpecType,
+		Aliases:          []resource.Type{"linkspec", "linkspecs"},
+		DefaultNamespace: NamespaceName,
+		PrintColumns: []meta.PrintColumn{
+			{
+				Name:     "Kind",
+				JSONPath: "{.kind}",
+			},
+			{
+				Name:     "Up",
+				JSONPath: "{.up}",
+			},
+			{
+				Name:     "MTU",
+				JSONPath: "{.mtu}",
+			},
+		},
+	}
+}
+
+// TypedSpec returns .spec.
+func (r *LinkSpec) TypedSpec() *LinkSpecSpec {
+	return &r.spec
+}
@@ -0,0 +1,11 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package network provides resources describing desired network configuration of the node.
+package network
+
+import "github.com/talos-systems/os-runtime/pkg/resource"
+
+// NamespaceName contains network configuration resources.
+const NamespaceName resource.Namespace = "network"
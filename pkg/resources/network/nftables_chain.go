@@ -0,0 +1,128 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package network
+
+import (
+	"fmt"
+
+	"github.com/talos-systems/os-runtime/pkg/resource"
+	"github.com/talos-systems/os-runtime/pkg/resource/meta"
+)
+
+// NfTablesChainType is type of NfTablesChain resource.
+const NfTablesChainType = resource.Type("NfTablesChains.net.talos.dev")
+
+// IngressChainID is the ID of the ingress firewall chain.
+const IngressChainID = resource.ID("ingress")
+
+// Firewall verdicts.
+const (
+	VerdictAccept = "accept"
+	VerdictDrop   = "drop"
+)
+
+// NfTablesChain describes desired state of the host nftables chain.
+type NfTablesChain struct {
+	md   resource.Metadata
+	spec NfTablesChainSpec
+}
+
+// NfTablesChainSpec describes the chain and the rules in it.
+type NfTablesChainSpec struct {
+	// Policy is the verdict for the traffic not matched by any rule.
+	Policy string `yaml:"policy"`
+
+	// Rules accept the traffic, they are evaluated in order.
+	Rules []NfTablesRule `yaml:"rules"`
+}
+
+// NfTablesRule accepts the traffic to the destination ports from the source networks.
+type NfTablesRule struct {
+	// Name of the rule.
+	Name string `yaml:"name"`
+
+	// Protocol is the transport protocol (tcp or udp).
+	Protocol string `yaml:"protocol"`
+
+	// PortRanges is the list of the destination port ranges.
+	PortRanges []PortRange `yaml:"portRanges"`
+
+	// SourceSubnets is the list of the source networks in CIDR notation.
+	SourceSubnets []string `yaml:"sourceSubnets"`
+}
+
+// PortRange is an inclusive range of ports.
+type PortRange struct {
+	Lo uint16 `yaml:"lo"`
+	Hi uint16 `yaml:"hi"`
+}
+
+// NewNfTablesChain initializes a NfTablesChain resource.
+func NewNfTablesChain(id resource.ID) *NfTablesChain {
+	r := &NfTablesChain{
+		md:   resource.NewMetadata(NamespaceName, NfTablesChainType, id, resource.VersionUndefined),
+		spec: NfTablesChainSpec{},
+	}
+
+	r.md.BumpVersion()
+
+	return r
+}
+
+// Metadata implements resource.Resource.
+func (r *NfTablesChain) Metadata() *resource.Metadata {
+	return &r.md
+}
+
+// Spec implements resource.Resource.
+func (r *NfTablesChain) Spec() interface{} {
+	return r.spec
+}
+
+func (r *NfTablesChain) String() string {
+	return fmt.Sprintf("network.NfTablesChain(%q)", r.md.ID())
+}
+
+// DeepCopy implements resource.Resource.
+func (r *NfTablesChain) DeepCopy() resource.Resource {
+	rules := make([]NfTablesRule, len(r.spec.Rules))
+
+	for i, rule := range r.spec.Rules {
+		rules[i] = NfTablesRule{
+			Name:          rule.Name,
+			Protocol:      rule.Protocol,
+			PortRanges:    append([]PortRange(nil), rule.PortRanges...),
+			SourceSubnets: append([]string(nil), rule.SourceSubnets...),
+		}
+	}
+
+	return &NfTablesChain{
+		md: r.md,
+		spec: NfTablesChainSpec{
+			Policy: r.spec.Policy,
+			Rules:  rules,
+		},
+	}
+}
+
+// ResourceDefinition implements meta.ResourceDefinitionProvider interface.
+func (r *NfTablesChain) ResourceDefinition() meta.ResourceDefinitionSpec {
+	return meta.ResourceDefinitionSpec{
+		Type:             NfTablesChainType,
+		Aliases:          []resource.Type{"chain", "chains", "nftableschain", "nftableschains"},
+		DefaultNamespace: NamespaceName,
+		PrintColumns: []meta.PrintColumn{
+			{
+				Name:     "Policy",
+				JSONPath: "{.policy}",
+			},
+		},
+	}
+}
+
+// TypedSpec returns .spec.
+func (r *NfTablesChain) TypedSpec() *NfTablesChainSpec {
+	return &r.spec
+}
@@ -0,0 +1,103 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package network
+
+import (
+	"fmt"
+
+	"github.com/talos-systems/os-runtime/pkg/resource"
+	"github.com/talos-systems/os-runtime/pkg/resource/meta"
+)
+
+// ResolverSpecType is type of ResolverSpec resource.
+const ResolverSpecType = resource.Type("ResolverSpecs.net.talos.dev")
+
+// ResolverID is the ID of the resolver resource built from the machine configuration.
+//
+// Resolvers and search domains from the machine configuration take precedence over the ones discovered via DHCP.
+const ResolverID = resource.ID("resolvers")
+
+// DHCP6ResolverID builds ID of the resolver resource discovered via DHCPv6 on the link.
+func DHCP6ResolverID(linkName string) resource.ID {
+	return fmt.Sprintf("dhcp6/%s", linkName)
+}
+
+// ResolverSpec describes desired DNS resolvers.
+type ResolverSpec struct {
+	md   resource.Metadata
+	spec ResolverSpecSpec
+}
+
+// ResolverSpecSpec describes the DNS resolvers.
+type ResolverSpecSpec struct {
+	// DNSServers is the list of DNS server addresses.
+	DNSServers []string `yaml:"dnsServers"`
+	// SearchDomains is the list of DNS search domains.
+	SearchDomains []string `yaml:"searchDomains,omitempty"`
+	// DisableSearchDomain disables the default search domain derived from the hostname.
+	DisableSearchDomain bool `yaml:"disableSearchDomain,omitempty"`
+}
+
+// NewResolverSpec initializes a ResolverSpec resource.
+func NewResolverSpec(id resource.ID) *ResolverSpec {
+	r := &ResolverSpec{
+		md:   resource.NewMetadata(NamespaceName, ResolverSpecType, id, resource.VersionUndefined),
+		spec: ResolverSpecSpec{},
+	}
+
+	r.md.BumpVersion()
+
+	return r
+}
+
+// Metadata implements resource.Resource.
+func (r *ResolverSpec) Metadata() *resource.Metadata {
+	return &r.md
+}
+
+// Spec implements resource.Resource.
+func (r *ResolverSpec) Spec() interface{} {
+	return r.spec
+}
+
+func (r *ResolverSpec) String() string {
+	return fmt.Sprintf("network.ResolverSpec(%q)", r.md.ID())
+}
+
+// DeepCopy implements resource.Resource.
+func (r *ResolverSpec) DeepCopy() resource.Resource {
+	return &ResolverSpec{
+		md: r.md,
+		spec: ResolverSpecSpec{
+			DNSServers:          append([]string(nil), r.spec.DNSServers...),
+			SearchDomains:       append([]string(nil), r.spec.SearchDomains...),
+			DisableSearchDomain: r.spec.DisableSearchDomain,
+		},
+	}
+}
+
+// ResourceDefinition implements meta.ResourceDefinitionProvider interface.
+func (r *ResolverSpec) ResourceDefinition() meta.ResourceDefinitionSpec {
+	return meta.ResourceDefinitionSpec{
+		Type:             ResolverSpecType,
+		Aliases:          []resource.Type{"resolverspec", "resolverspecs", "resolvers"},
+		DefaultNamespace: NamespaceName,
+		PrintColumns: []meta.PrintColumn{
+			{
+				Name:     "Servers",
+				JSONPath: "{.dnsServers}",
+			},
+			{
+				Name:     "Search Domains",
+				JSONPath: "{.searchDomains}",
+			},
+		},
+	}
+}
+
+// TypedSpec returns .spec.
+func (r *ResolverSpec) TypedSpec() *ResolverSpecSpec {
+	return &r.spec
+}
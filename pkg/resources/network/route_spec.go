@@ -0,0 +1,126 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package network
+
+import (
+	"fmt"
+
+	"github.com/talos-systems/os-runtime/pkg/resource"
+	"github.com/talos-systems/os-runtime/pkg/resource/meta"
+)
+
+// RouteSpecType is type of RouteSpec resource.
+const RouteSpecType = resource.Type("RouteSpecs.net.talos.dev")
+
+// RouteSpec describes desired route.
+type RouteSpec struct {
+	md   resource.Metadata
+	spec RouteSpecSpec
+}
+
+// RouteSpecSpec describes the route.
+type RouteSpecSpec struct {
+	// Destination in CIDR notation.
+	Destination string `yaml:"destination"`
+
+	// Gateway is the next hop, might be empty for link-scoped routes.
+	Gateway string `yaml:"gateway,omitempty"`
+
+	// OutLinkName is the name of the link the route goes through.
+	OutLinkName string `yaml:"outLinkName"`
+
+	// Metric (priority) of the route.
+	Metric uint32 `yaml:"metric"`
+
+	// Source is the preferred source address of the outgoing traffic.
+	Source string `yaml:"source,omitempty"`
+
+	// MTU of the route, zero means the link MTU.
+	MTU uint32 `yaml:"mtu,omitempty"`
+
+	// Table is the routing table of the route, zero means the main table.
+	Table uint32 `yaml:"table,omitempty"`
+}
+
+// RouteID builds ID of the RouteSpec resource.
+//
+// Table is omitted for the routes in the main table.
+func RouteID(table uint32, linkName, destination, gateway string) resource.ID {
+	if table == 0 {
+		return fmt.Sprintf("%s/%s/%s", linkName, destination, gateway)
+	}
+
+	return fmt.Sprintf("%s/%s/%s/%d", linkName, destination, gateway, table)
+}
+
+// NewRouteSpec initializes a RouteSpec resource.
+func NewRouteSpec(id resource.ID) *RouteSpec {
+	r := &RouteSpec{
+		md:   resource.NewMetadata(NamespaceName, RouteSpecType, id, resource.VersionUndefined),
+		spec: RouteSpecSpec{},
+	}
+
+	r.md.BumpVersion()
+
+	return r
+}
+
+// Metadata implements resource.Resource.
+func (r *RouteSpec) Metadata() *resource.Metadata {
+	return &r.md
+}
+
+// Spec implements resource.Resource.
+func (r *RouteSpec) Spec() interface{} {
+	return r.spec
+}
+
+func (r *RouteSpec) String() string {
+	return fmt.Sprintf("network.RouteSpec(%q)", r.md.ID())
+}
+
+// DeepCopy implements resource.Resource.
+func (r *RouteSpec) DeepCopy() resource.Resource {
+	return &RouteSpec{
+		md:   r.md,
+		spec: r.spec,
+	}
+}
+
+// ResourceDefinition implements meta.ResourceDefinitionProvider interface.
+func (r *RouteSpec) ResourceDefinition() meta.ResourceDefinitionSpec {
+	return meta.ResourceDefinitionSpec{
+		Type:             RouteSpecType,
+		Aliases:          []resource.Type{"routespec", "routespecs"},
+		DefaultNamespace: NamespaceName,
+		PrintColumns: []meta.PrintColumn{
+			{
+				Name:     "Destination",
+				JSONPath: "{.destination}",
+			},
+			{
+				Name:     "Gateway",
+				JSONPath: "{.gateway}",
+			},
+			{
+				Name:     "Link",
+				JSONPath: "{.outLinkName}",
+			},
+			{
+				Name:     "Metric",
+				JSONPath: "{.metric}",
+			},
+			{
+				Name:     "Table",
+				JSONPath: "{.table}",
+			},
+		},
+	}
+}
+
+// TypedSpec returns .spec.
+func (r *RouteSpec) TypedSpec() *RouteSpecSpec {
+	return &r.spec
+}
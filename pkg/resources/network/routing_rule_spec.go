@@ -0,0 +1,123 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package network
+
+import (
+	"fmt"
+
+	"github.com/talos-systems/os-runtime/pkg/resource"
+	"github.com/talos-systems/os-runtime/pkg/resource/meta"
+)
+
+// RoutingRuleSpecType is type of RoutingRuleSpec resource.
+const RoutingRuleSpecType = resource.Type("RoutingRuleSpecs.net.talos.dev")
+
+// Address families of the routing rules.
+const (
+	FamilyInet4 = "inet4"
+	FamilyInet6 = "inet6"
+)
+
+// RoutingRuleSpec describes desired policy routing rule.
+type RoutingRuleSpec struct {
+	md   resource.Metadata
+	spec RoutingRuleSpecSpec
+}
+
+// RoutingRuleSpecSpec describes the policy routing rule.
+type RoutingRuleSpecSpec struct {
+	// Family is the address family of the rule (inet4 or inet6).
+	Family string `yaml:"family"`
+
+	// From is the source network in CIDR notation, empty matches any source.
+	From string `yaml:"from,omitempty"`
+
+	// To is the destination network in CIDR notation, empty matches any destination.
+	To string `yaml:"to,omitempty"`
+
+	// FwMark is the firewall mark of the matched traffic.
+	FwMark uint32 `yaml:"fwMark,omitempty"`
+
+	// Table is the routing table to look up.
+	Table uint32 `yaml:"table"`
+
+	// Priority of the rule, zero lets the kernel pick the priority.
+	Priority uint32 `yaml:"priority,omitempty"`
+}
+
+// RoutingRuleID builds ID of the RoutingRuleSpec resource.
+func RoutingRuleID(spec RoutingRuleSpecSpec) resource.ID {
+	return fmt.Sprintf("%s/%d/%s/%s/%d/%d", spec.Family, spec.Priority, spec.From, spec.To, spec.FwMark, spec.Table)
+}
+
+// NewRoutingRuleSpec initializes a RoutingRuleSpec resource.
+func NewRoutingRuleSpec(id resource.ID) *RoutingRuleSpec {
+	r := &RoutingRuleSpec{
+		md:   resource.NewMetadata(NamespaceName, RoutingRuleSpecType, id, resource.VersionUndefined),
+		spec: RoutingRuleSpecSpec{},
+	}
+
+	r.md.BumpVersion()
+
+	return r
+}
+
+// Metadata implements resource.Resource.
+func (r *RoutingRuleSpec) Metadata() *resource.Metadata {
+	return &r.md
+}
+
+// Spec implements resource.Resource.
+func (r *RoutingRuleSpec) Spec() interface{} {
+	return r.spec
+}
+
+func (r *RoutingRuleSpec) String() string {
+	return fmt.Sprintf("network.RoutingRuleSpec(%q)", r.md.ID())
+}
+
+// DeepCopy implements resource.Resource.
+func (r *RoutingRuleSpec) DeepCopy() resource.Resource {
+	return &RoutingRuleSpec{
+		md:   r.md,
+		spec: r.spec,
+	}
+}
+
+// ResourceDefinition implements meta.ResourceDefinitionProvider interface.
+func (r *RoutingRuleSpec) ResourceDefinition() meta.ResourceDefinitionSpec {
+	return meta.ResourceDefinitionSpec{
+		Type:             RoutingRuleSpecType,
+		Aliases:          []resource.Type{"routingrulespec", "routingrulespecs", "rulespecs"},
+		DefaultNamespace: NamespaceName,
+		PrintColumns: []meta.PrintColumn{
+			{
+				Name:     "Priority",
+				JSONPath: "{.priority}",
+			},
+			{
+				Name:     "From",
+				JSONPath: "{.from}",
+			},
+			{
+				Name:     "To",
+				JSONPath: "{.to}",
+			},
+			{
+				Name:     "FwMark",
+				JSONPath: "{.fwMark}",
+			},
+			{
+				Name:     "Table",
+				JSONPath: "{.table}",
+			},
+		},
+	}
+}
+
+// TypedSpec returns .spec.
+func (r *RoutingRuleSpec) TypedSpec() *RoutingRuleSpecSpec {
+	return &r.spec
+}
@@ -0,0 +1,111 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package network
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/talos-systems/os-runtime/pkg/resource"
+	"github.com/talos-systems/os-runtime/pkg/resource/meta"
+)
+
+// WireguardPeerStatusType is type of WireguardPeerStatus resource.
+const WireguardPeerStatusType = resource.Type("WireguardPeerStatuses.net.talos.dev")
+
+// WireguardPeerStatus describes the state of the Wireguard peer as reported by the kernel.
+type WireguardPeerStatus struct {
+	md   resource.Metadata
+	spec WireguardPeerStatusSpec
+}
+
+// WireguardPeerStatusSpec describes the Wireguard peer state.
+type WireguardPeerStatusSpec struct {
+	// LinkName is the name of the Wireguard link.
+	LinkName string `yaml:"linkName"`
+	// PublicKey is the public key of the peer.
+	PublicKey string `yaml:"publicKey"`
+	// Endpoint is the current endpoint of the peer (might be learned from the incoming traffic).
+	Endpoint string `yaml:"endpoint,omitempty"`
+	// AllowedIPs is the list of CIDRs routed to the peer.
+	AllowedIPs []string `yaml:"allowedIPs"`
+	// LastHandshakeTime is the time of the last successful handshake, zero if there was no handshake.
+	LastHandshakeTime time.Time `yaml:"lastHandshakeTime"`
+	// PersistentKeepaliveInterval is the keepalive interval configured for the peer.
+	PersistentKeepaliveInterval time.Duration `yaml:"persistentKeepaliveInterval,omitempty"`
+	// ReceiveBytes and TransmitBytes are traffic counters of the peer.
+	ReceiveBytes  int64 `yaml:"receiveBytes"`
+	TransmitBytes int64 `yaml:"transmitBytes"`
+}
+
+// WireguardPeerID builds the ID of the WireguardPeerStatus resource.
+func WireguardPeerID(linkName, publicKey string) resource.ID {
+	return linkName + "/" + publicKey
+}
+
+// NewWireguardPeerStatus initializes a WireguardPeerStatus resource.
+func NewWireguardPeerStatus(id resource.ID) *WireguardPeerStatus {
+	r := &WireguardPeerStatus{
+		md:   resource.NewMetadata(NamespaceName, WireguardPeerStatusType, id, resource.VersionUndefined),
+		spec: WireguardPeerStatusSpec{},
+	}
+
+	r.md.BumpVersion()
+
+	return r
+}
+
+// Metadata implements resource.Resource.
+func (r *WireguardPeerStatus) Metadata() *resource.Metadata {
+	return &r.md
+}
+
+// Spec implements resource.Resource.
+func (r *WireguardPeerStatus) Spec() interface{} {
+	return r.spec
+}
+
+func (r *WireguardPeerStatus) String() string {
+	return fmt.Sprintf("network.WireguardPeerStatus(%q)", r.md.ID())
+}
+
+// DeepCopy implements resource.Resource.
+func (r *WireguardPeerStatus) DeepCopy() resource.Resource {
+	spec := r.spec
+	spec.AllowedIPs = append([]string(nil), r.spec.AllowedIPs...)
+
+	return &WireguardPeerStatus{
+		md:   r.md,
+		spec: spec,
+	}
+}
+
+// ResourceDefinition implements meta.ResourceDefinitionProvider interface.
+func (r *WireguardPeerStatus) ResourceDefinition() meta.ResourceDefinitionSpec {
+	return meta.ResourceDefinitionSpec{
+		Type:             WireguardPeerStatusType,
+		Aliases:          []resource.Type{"wireguardpeer", "wireguardpeers", "wgpeers"},
+		DefaultNamespace: NamespaceName,
+		PrintColumns: []meta.PrintColumn{
+			{
+				Name:     "Link",
+				JSONPath: "{.linkName}",
+			},
+			{
+				Name:     "Endpoint",
+				JSONPath: "{.endpoint}",
+			},
+			{
+				Name:     "Last Handshake",
+				JSONPath: "{.lastHandshakeTime}",
+			},
+		},
+	}
+}
+
+// TypedSpec returns .spec.
+func (r *WireguardPeerStatus) TypedSpec() *WireguardPeerStatusSpec {
+	return &r.spec
+}
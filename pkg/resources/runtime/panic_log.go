@@ -0,0 +1,104 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/talos-systems/os-runtime/pkg/resource"
+	"github.com/talos-systems/os-runtime/pkg/resource/meta"
+
+	"github.com/talos-systems/talos/pkg/resources/v1alpha1"
+)
+
+// PanicLogType is type of PanicLog resource.
+const PanicLogType = resource.Type("PanicLogs.v1alpha1.talos.dev")
+
+// PanicLog resource describes a kernel panic log of the previous boots collected from pstore.
+type PanicLog struct {
+	md   resource.Metadata
+	spec PanicLogSpec
+}
+
+// PanicLogSpec describes the collected panic log.
+type PanicLogSpec struct {
+	// Timestamp is the time the log was captured by the pstore backend.
+	Timestamp time.Time `yaml:"timestamp"`
+	// Backend is the pstore backend which captured the log (e.g. `ramoops`, `efi`).
+	Backend string `yaml:"backend"`
+	// Kind is the reason of the dump as reported by the kernel (e.g. `panic`, `oops`).
+	Kind string `yaml:"kind"`
+	// Reason is the panic message extracted from the log.
+	Reason string `yaml:"reason,omitempty"`
+	// Path is the path to the full log in the STATE partition.
+	Path string `yaml:"path"`
+	// Size of the log in bytes.
+	Size int `yaml:"size"`
+	// Shipped indicates whether the log was sent to the configured destination.
+	Shipped bool `yaml:"shipped"`
+}
+
+// NewPanicLog initializes a PanicLog resource.
+func NewPanicLog(id resource.ID) *PanicLog {
+	r := &PanicLog{
+		md:   resource.NewMetadata(v1alpha1.NamespaceName, PanicLogType, id, resource.VersionUndefined),
+		spec: PanicLogSpec{},
+	}
+
+	r.md.BumpVersion()
+
+	return r
+}
+
+// Metadata implements resource.Resource.
+func (r *PanicLog) Metadata() *resource.Metadata {
+	return &r.md
+}
+
+// Spec implements resource.Resource.
+func (r *PanicLog) Spec() interface{} {
+	return r.spec
+}
+
+func (r *PanicLog) String() string {
+	return fmt.Sprintf("runtime.PanicLog(%q)", r.md.ID())
+}
+
+// DeepCopy implements resource.Resource.
+func (r *PanicLog) DeepCopy() resource.Resource {
+	return &PanicLog{
+		md:   r.md,
+		spec: r.spec,
+	}
+}
+
+// ResourceDefinition implements meta.ResourceDefinitionProvider interface.
+func (r *PanicLog) ResourceDefinition() meta.ResourceDefinitionSpec {
+	return meta.ResourceDefinitionSpec{
+		Type:             PanicLogType,
+		Aliases:          []resource.Type{"panic", "panics"},
+		DefaultNamespace: v1alpha1.NamespaceName,
+		PrintColumns: []meta.PrintColumn{
+			{
+				Name:     "Kind",
+				JSONPath: "{.kind}",
+			},
+			{
+				Name:     "Reason",
+				JSONPath: "{.reason}",
+			},
+			{
+				Name:     "Shipped",
+				JSONPath: "{.shipped}",
+			},
+		},
+	}
+}
+
+// TypedSpec returns .spec.
+func (r *PanicLog) TypedSpec() *PanicLogSpec {
+	return &r.spec
+}
@@ -0,0 +1,105 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/talos-systems/os-runtime/pkg/resource"
+	"github.com/talos-systems/os-runtime/pkg/resource/meta"
+
+	"github.com/talos-systems/talos/pkg/resources/v1alpha1"
+)
+
+// RegistrationStatusType is type of RegistrationStatus resource.
+const RegistrationStatusType = resource.Type("RegistrationStatuses.v1alpha1.talos.dev")
+
+// RegistrationStatusID is the ID of the singleton RegistrationStatus resource.
+const RegistrationStatusID = resource.ID("registration")
+
+// RegistrationStatus resource describes the node registration with the inventory API.
+type RegistrationStatus struct {
+	md   resource.Metadata
+	spec RegistrationStatusSpec
+}
+
+// RegistrationStatusSpec describes the registration state.
+type RegistrationStatusSpec struct {
+	// Endpoint is the inventory API URL.
+	Endpoint string `yaml:"endpoint"`
+	// NodeID is the ID the node is registered with.
+	NodeID string `yaml:"nodeId"`
+	// Registered indicates whether the last registration or heartbeat succeeded.
+	Registered bool `yaml:"registered"`
+	// LastRegistration is the time the node facts were last sent to the inventory.
+	LastRegistration time.Time `yaml:"lastRegistration,omitempty"`
+	// LastHeartbeat is the time of the last successful heartbeat.
+	LastHeartbeat time.Time `yaml:"lastHeartbeat,omitempty"`
+	// LastError is the error of the last failed request.
+	LastError string `yaml:"lastError,omitempty"`
+}
+
+// NewRegistrationStatus initializes a RegistrationStatus resource.
+func NewRegistrationStatus(id resource.ID) *RegistrationStatus {
+	r := &RegistrationStatus{
+		md:   resource.NewMetadata(v1alpha1.NamespaceName, RegistrationStatusType, id, resource.VersionUndefined),
+		spec: RegistrationStatusSpec{},
+	}
+
+	r.md.BumpVersion()
+
+	return r
+}
+
+// Metadata implements resource.Resource.
+func (r *RegistrationStatus) Metadata() *resource.Metadata {
+	return &r.md
+}
+
+// Spec implements resource.Resource.
+func (r *RegistrationStatus) Spec() interface{} {
+	return r.spec
+}
+
+func (r *RegistrationStatus) String() string {
+	return fmt.Sprintf("runtime.RegistrationStatus(%q)", r.md.ID())
+}
+
+// DeepCopy implements resource.Resource.
+func (r *RegistrationStatus) DeepCopy() resource.Resource {
+	return &RegistrationStatus{
+		md:   r.md,
+		spec: r.spec,
+	}
+}
+
+// ResourceDefinition implements meta.ResourceDefinitionProvider interface.
+func (r *RegistrationStatus) ResourceDefinition() meta.ResourceDefinitionSpec {
+	return meta.ResourceDefinitionSpec{
+		Type:             RegistrationStatusType,
+		Aliases:          []resource.Type{"registration", "registrations"},
+		DefaultNamespace: v1alpha1.NamespaceName,
+		PrintColumns: []meta.PrintColumn{
+			{
+				Name:     "Endpoint",
+				JSONPath: "{.endpoint}",
+			},
+			{
+				Name:     "Registered",
+				JSONPath: "{.registered}",
+			},
+			{
+				Name:     "Last Heartbeat",
+				JSONPath: "{.lastHeartbeat}",
+			},
+		},
+	}
+}
+
+// TypedSpec returns .spec.
+func (r *RegistrationStatus) TypedSpec() *RegistrationStatusSpec {
+	return &r.spec
+}
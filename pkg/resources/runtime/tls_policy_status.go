@@ -0,0 +1,104 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"fmt"
+
+	"github.com/talos-systems/os-runtime/pkg/resource"
+	"github.com/talos-systems/os-runtime/pkg/resource/meta"
+
+	"github.com/talos-systems/talos/pkg/resources/v1alpha1"
+)
+
+// TLSPolicyStatusType is type of TLSPolicyStatus resource.
+const TLSPolicyStatusType = resource.Type("TLSPolicyStatuses.v1alpha1.talos.dev")
+
+// TLSPolicyStatusID is the ID of the singleton TLSPolicyStatus resource.
+const TLSPolicyStatusID = resource.ID("tls")
+
+// TLSPolicyStatus resource describes the TLS policy of the Talos API endpoints.
+type TLSPolicyStatus struct {
+	md   resource.Metadata
+	spec TLSPolicyStatusSpec
+}
+
+// TLSPolicyStatusSpec describes the TLS policy.
+type TLSPolicyStatusSpec struct {
+	// Strict indicates whether the strict TLS policy is enforced.
+	Strict bool `yaml:"strict"`
+	// MinVersion is the minimum TLS version accepted by the endpoints.
+	MinVersion string `yaml:"minVersion"`
+	// CipherSuites is the list of the allowed cipher suites (empty if not restricted).
+	CipherSuites []string `yaml:"cipherSuites,omitempty"`
+	// Curves is the list of the allowed key exchange curves (empty if not restricted).
+	Curves []string `yaml:"curves,omitempty"`
+	// Endpoints is the list of the endpoints the policy applies to.
+	Endpoints []string `yaml:"endpoints"`
+}
+
+// NewTLSPolicyStatus initializes a TLSPolicyStatus resource.
+func NewTLSPolicyStatus(id resource.ID) *TLSPolicyStatus {
+	r := &TLSPolicyStatus{
+		md:   resource.NewMetadata(v1alpha1.NamespaceName, TLSPolicyStatusType, id, resource.VersionUndefined),
+		spec: TLSPolicyStatusSpec{},
+	}
+
+	r.md.BumpVersion()
+
+	return r
+}
+
+// Metadata implements resource.Resource.
+func (r *TLSPolicyStatus) Metadata() *resource.Metadata {
+	return &r.md
+}
+
+// Spec implements resource.Resource.
+func (r *TLSPolicyStatus) Spec() interface{} {
+	return r.spec
+}
+
+func (r *TLSPolicyStatus) String() string {
+	return fmt.Sprintf("runtime.TLSPolicyStatus(%q)", r.md.ID())
+}
+
+// DeepCopy implements resource.Resource.
+func (r *TLSPolicyStatus) DeepCopy() resource.Resource {
+	return &TLSPolicyStatus{
+		md: r.md,
+		spec: TLSPolicyStatusSpec{
+			Strict:       r.spec.Strict,
+			MinVersion:   r.spec.MinVersion,
+			CipherSuites: append([]string(nil), r.spec.CipherSuites...),
+			Curves:       append([]string(nil), r.spec.Curves...),
+			Endpoints:    append([]string(nil), r.spec.Endpoints...),
+		},
+	}
+}
+
+// ResourceDefinition implements meta.ResourceDefinitionProvider interface.
+func (r *TLSPolicyStatus) ResourceDefinition() meta.ResourceDefinitionSpec {
+	return meta.ResourceDefinitionSpec{
+		Type:             TLSPolicyStatusType,
+		Aliases:          []resource.Type{"tlspolicy", "tlspolicies"},
+		DefaultNamespace: v1alpha1.NamespaceName,
+		PrintColumns: []meta.PrintColumn{
+			{
+				Name:     "Strict",
+				JSONPath: "{.strict}",
+			},
+			{
+				Name:     "Min Version",
+				JSONPath: "{.minVersion}",
+			},
+		},
+	}
+}
+
+// TypedSpec returns .spec.
+func (r *TLSPolicyStatus) TypedSpec() *TLSPolicyStatusSpec {
+	return &r.spec
+}
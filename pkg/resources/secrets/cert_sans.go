@@ -0,0 +1,84 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package secrets
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/talos-systems/os-runtime/pkg/resource"
+	"github.com/talos-systems/os-runtime/pkg/resource/meta"
+)
+
+// CertSANType is type of CertSAN resource.
+const CertSANType = resource.Type("CertSANs.secrets.talos.dev")
+
+// CertSANKubernetesID is a resource ID of the kube-apiserver certificate SANs.
+const CertSANKubernetesID = resource.ID("k8s")
+
+// CertSAN contains the alternative names of the generated certificates.
+type CertSAN struct {
+	md   resource.Metadata
+	spec *CertSANSpec
+}
+
+// CertSANSpec describes the certificate alternative names.
+type CertSANSpec struct {
+	IPs      []net.IP `yaml:"ips"`
+	DNSNames []string `yaml:"dnsNames"`
+}
+
+// NewCertSAN initializes a CertSAN resource.
+func NewCertSAN(id resource.ID) *CertSAN {
+	r := &CertSAN{
+		md:   resource.NewMetadata(NamespaceName, CertSANType, id, resource.VersionUndefined),
+		spec: &CertSANSpec{},
+	}
+
+	r.md.BumpVersion()
+
+	return r
+}
+
+// Metadata implements resource.Resource.
+func (r *CertSAN) Metadata() *resource.Metadata {
+	return &r.md
+}
+
+// Spec implements resource.Resource.
+func (r *CertSAN) Spec() interface{} {
+	return r.spec
+}
+
+func (r *CertSAN) String() string {
+	return fmt.Sprintf("secrets.CertSAN(%q)", r.md.ID())
+}
+
+// DeepCopy implements resource.Resource.
+func (r *CertSAN) DeepCopy() resource.Resource {
+	specCopy := CertSANSpec{
+		IPs:      append([]net.IP(nil), r.spec.IPs...),
+		DNSNames: append([]string(nil), r.spec.DNSNames...),
+	}
+
+	return &CertSAN{
+		md:   r.md,
+		spec: &specCopy,
+	}
+}
+
+// ResourceDefinition implements meta.ResourceDefinitionProvider interface.
+func (r *CertSAN) ResourceDefinition() meta.ResourceDefinitionSpec {
+	return meta.ResourceDefinitionSpec{
+		Type:             CertSANType,
+		Aliases:          []resource.Type{"certsan", "certsans"},
+		DefaultNamespace: NamespaceName,
+	}
+}
+
+// TypedSpec returns .spec.
+func (r *CertSAN) TypedSpec() *CertSANSpec {
+	return r.spec
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"net"
 	"net/url"
+	"time"
 
 	"github.com/talos-systems/crypto/x509"
 	"github.com/talos-systems/os-runtime/pkg/resource"
@@ -46,12 +47,34 @@ type RootKubernetesSpec struct {
 	ServiceAccount *x509.PEMEncodedKey               `yaml:"serviceAccount"`
 	AggregatorCA   *x509.PEMEncodedCertificateAndKey `yaml:"aggregatorCA"`
 
-	AESCBCEncryptionSecret string `yaml:"aesCBCEncryptionSecret"`
+	AESCBCEncryptionSecret         string                `yaml:"aesCBCEncryptionSecret"`
+	AESCBCEncryptionKeyName        string                `yaml:"aesCBCEncryptionKeyName"`
+	AESCBCAdditionalEncryptionKeys []AESCBCEncryptionKey `yaml:"aesCBCAdditionalEncryptionKeys"`
+
+	// SecretsEncryptionProviders lists the configured providers for the encryption of secret data at rest,
+	// the first provider encrypts the data, the other providers are used only for decryption.
+	SecretsEncryptionProviders []string       `yaml:"secretsEncryptionProviders"`
+	SecretboxEncryptionKeyName string         `yaml:"secretboxEncryptionKeyName"`
+	SecretboxEncryptionSecret  string         `yaml:"secretboxEncryptionSecret"`
+	KMSEncryption              *KMSEncryption `yaml:"kmsEncryption"`
 
 	BootstrapTokenID     string `yaml:"bootstrapTokenID"`
 	BootstrapTokenSecret string `yaml:"bootstrapTokenSecret"`
 }
 
+// AESCBCEncryptionKey describes the key used only to decrypt the secret data at rest.
+type AESCBCEncryptionKey struct {
+	Name   string `yaml:"name"`
+	Secret string `yaml:"secret"`
+}
+
+// KMSEncryption describes the KMS v2 plugin provider for the encryption of secret data at rest.
+type KMSEncryption struct {
+	Name     string        `yaml:"name"`
+	Endpoint string        `yaml:"endpoint"`
+	Timeout  time.Duration `yaml:"timeout"`
+}
+
 // NewRoot initializes a Root resource.
 func NewRoot(id resource.ID) *Root {
 	r := &Root{
@@ -0,0 +1,68 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package siderolink
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/talos-systems/os-runtime/pkg/resource"
+	"github.com/talos-systems/os-runtime/pkg/state"
+)
+
+// readyTimeout is the maximum time to wait for the tunnel to be up.
+const readyTimeout = time.Minute
+
+// ReadyCondition implements condition which waits for the SideroLink tunnel to be up (if SideroLink is enabled).
+//
+// Condition gives up waiting after the timeout, so that the services depending on it are still started
+// if the management server is not reachable.
+type ReadyCondition struct {
+	state state.State
+}
+
+// NewReadyCondition builds a condition which waits for the SideroLink tunnel to be up.
+func NewReadyCondition(state state.State) *ReadyCondition {
+	return &ReadyCondition{
+		state: state,
+	}
+}
+
+func (condition *ReadyCondition) String() string {
+	return "siderolink"
+}
+
+// Wait implements condition interface.
+func (condition *ReadyCondition) Wait(ctx context.Context) error {
+	cfg, err := condition.state.WatchFor(
+		ctx,
+		resource.NewMetadata(NamespaceName, ConfigType, ConfigID, resource.VersionUndefined),
+	)
+	if err != nil {
+		return err
+	}
+
+	if cfg.(*Config).TypedSpec().APIEndpoint == "" {
+		return nil
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, readyTimeout)
+	defer cancel()
+
+	_, err = condition.state.WatchFor(
+		timeoutCtx,
+		resource.NewMetadata(NamespaceName, StatusType, StatusID, resource.VersionUndefined),
+		state.WithCondition(func(r resource.Resource) (bool, error) {
+			return r.(*Status).TypedSpec().Up, nil
+		}),
+	)
+
+	if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+		return nil
+	}
+
+	return err
+}
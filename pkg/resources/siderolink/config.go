@@ -0,0 +1,79 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package siderolink
+
+import (
+	"fmt"
+
+	"github.com/talos-systems/os-runtime/pkg/resource"
+	"github.com/talos-systems/os-runtime/pkg/resource/meta"
+)
+
+// ConfigType is type of Config resource.
+const ConfigType = resource.Type("SideroLinkConfigs.siderolink.talos.dev")
+
+// ConfigID is the resource ID for the SideroLink configuration.
+const ConfigID = resource.ID("siderolink")
+
+// Config resource holds the SideroLink configuration.
+type Config struct {
+	md   resource.Metadata
+	spec ConfigSpec
+}
+
+// ConfigSpec describes the SideroLink configuration.
+type ConfigSpec struct {
+	// APIEndpoint is the provisioning API URL of the management server, SideroLink is disabled if empty.
+	APIEndpoint string `yaml:"apiEndpoint"`
+}
+
+// NewConfig initializes a Config resource.
+func NewConfig(id resource.ID) *Config {
+	r := &Config{
+		md:   resource.NewMetadata(NamespaceName, ConfigType, id, resource.VersionUndefined),
+		spec: ConfigSpec{},
+	}
+
+	r.md.BumpVersion()
+
+	return r
+}
+
+// Metadata implements resource.Resource.
+func (r *Config) Metadata() *resource.Metadata {
+	return &r.md
+}
+
+// Spec implements resource.Resource.
+func (r *Config) Spec() interface{} {
+	return r.spec
+}
+
+func (r *Config) String() string {
+	return fmt.Sprintf("siderolink.Config(%q)", r.md.ID())
+}
+
+// DeepCopy implements resource.Resource.
+func (r *Config) DeepCopy() resource.Resource {
+	return &Config{
+		md:   r.md,
+		spec: r.spec,
+	}
+}
+
+// ResourceDefinition implements meta.ResourceDefinitionProvider interface.
+func (r *Config) ResourceDefinition() meta.ResourceDefinitionSpec {
+	return meta.ResourceDefinitionSpec{
+		Type:             ConfigType,
+		Aliases:          []resource.Type{"siderolinkconfig", "siderolinkconfigs"},
+		DefaultNamespace: NamespaceName,
+		PrintColumns:     []meta.PrintColumn{},
+	}
+}
+
+// TypedSpec returns .spec.
+func (r *Config) TypedSpec() *ConfigSpec {
+	return &r.spec
+}
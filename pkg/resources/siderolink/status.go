@@ -0,0 +1,100 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package siderolink
+
+import (
+	"fmt"
+
+	"github.com/talos-systems/os-runtime/pkg/resource"
+	"github.com/talos-systems/os-runtime/pkg/resource/meta"
+)
+
+// StatusType is type of Status resource.
+const StatusType = resource.Type("SideroLinkStatuses.siderolink.talos.dev")
+
+// StatusID is the resource ID for the SideroLink tunnel status.
+const StatusID = resource.ID("siderolink")
+
+// Status resource holds the status of the SideroLink tunnel.
+type Status struct {
+	md   resource.Metadata
+	spec StatusSpec
+}
+
+// StatusSpec describes the SideroLink tunnel.
+type StatusSpec struct {
+	// NodeAddress is the address of the node in the tunnel (with the prefix length).
+	NodeAddress string `yaml:"nodeAddress"`
+	// ServerAddress is the address of the management server in the tunnel.
+	ServerAddress string `yaml:"serverAddress"`
+	// ServerEndpoint is the Wireguard endpoint of the management server.
+	ServerEndpoint string `yaml:"serverEndpoint"`
+	// PublicKey is the Wireguard public key of the node.
+	PublicKey string `yaml:"publicKey"`
+	// Up is true when the tunnel is configured.
+	Up bool `yaml:"up"`
+}
+
+// NewStatus initializes a Status resource.
+func NewStatus(id resource.ID) *Status {
+	r := &Status{
+		md:   resource.NewMetadata(NamespaceName, StatusType, id, resource.VersionUndefined),
+		spec: StatusSpec{},
+	}
+
+	r.md.BumpVersion()
+
+	return r
+}
+
+// Metadata implements resource.Resource.
+func (r *Status) Metadata() *resource.Metadata {
+	return &r.md
+}
+
+// Spec implements resource.Resource.
+func (r *Status) Spec() interface{} {
+	return r.spec
+}
+
+func (r *Status) String() string {
+	return fmt.Sprintf("siderolink.Status(%q)", r.md.ID())
+}
+
+// DeepCopy implements resource.Resource.
+func (r *Status) DeepCopy() resource.Resource {
+	return &Status{
+		md:   r.md,
+		spec: r.spec,
+	}
+}
+
+// ResourceDefinition implements meta.ResourceDefinitionProvider interface.
+func (r *Status) ResourceDefinition() meta.ResourceDefinitionSpec {
+	return meta.ResourceDefinitionSpec{
+		Type:             StatusType,
+		Aliases:          []resource.Type{"siderolinkstatus", "siderolinkstatuses"},
+		DefaultNamespace: NamespaceName,
+		PrintColumns: []meta.PrintColumn{
+			{
+				Name:     "Address",
+				JSONPath: "{.nodeAddress}",
+			},
+			{
+				Name:     "Server",
+				JSONPath: "{.serverEndpoint}",
+			},
+			{
+				Name:     "Up",
+				JSONPath: "{.up}",
+			},
+		},
+	}
+}
+
+// TypedSpec returns .spec.
+func (r *Status) TypedSpec() *StatusSpec {
+	return &r.spec
+}
@@ -0,0 +1,166 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package v1alpha1
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/talos-systems/os-runtime/pkg/resource"
+	"github.com/talos-systems/os-runtime/pkg/resource/meta"
+)
+
+// ReconcilePauseType is type of ReconcilePause resource.
+const ReconcilePauseType = resource.Type("ReconcilePauses.v1alpha1.talos.dev")
+
+// ReconcilePauseID is a singleton instance ID.
+const ReconcilePauseID = resource.ID("api")
+
+// ReconcilePause describes the pause of the automatic reconciliation requested via the API.
+type ReconcilePause struct {
+	md   resource.Metadata
+	spec ReconcilePauseSpec
+}
+
+// ReconcilePauseSpec describes the pause requested via the API.
+type ReconcilePauseSpec struct {
+	Until time.Time `yaml:"until"`
+}
+
+// NewReconcilePause initializes a ReconcilePause resource.
+func NewReconcilePause() *ReconcilePause {
+	r := &ReconcilePause{
+		md:   resource.NewMetadata(NamespaceName, ReconcilePauseType, ReconcilePauseID, resource.VersionUndefined),
+		spec: ReconcilePauseSpec{},
+	}
+
+	r.md.BumpVersion()
+
+	return r
+}
+
+// Metadata implements resource.Resource.
+func (r *ReconcilePause) Metadata() *resource.Metadata {
+	return &r.md
+}
+
+// Spec implements resource.Resource.
+func (r *ReconcilePause) Spec() interface{} {
+	return r.spec
+}
+
+func (r *ReconcilePause) String() string {
+	return fmt.Sprintf("v1alpha1.ReconcilePause(%q)", r.md.ID())
+}
+
+// DeepCopy implements resource.Resource.
+func (r *ReconcilePause) DeepCopy() resource.Resource {
+	return &ReconcilePause{
+		md:   r.md,
+		spec: r.spec,
+	}
+}
+
+// ResourceDefinition implements meta.ResourceDefinitionProvider interface.
+func (r *ReconcilePause) ResourceDefinition() meta.ResourceDefinitionSpec {
+	return meta.ResourceDefinitionSpec{
+		Type:             ReconcilePauseType,
+		Aliases:          []resource.Type{},
+		DefaultNamespace: NamespaceName,
+		PrintColumns: []meta.PrintColumn{
+			{
+				Name:     "Until",
+				JSONPath: "{.until}",
+			},
+		},
+	}
+}
+
+// Pause returns .spec.
+func (r *ReconcilePause) Pause() *ReconcilePauseSpec {
+	return &r.spec
+}
+
+// ReconcileStatusType is type of ReconcileStatus resource.
+const ReconcileStatusType = resource.Type("ReconcileStatuses.v1alpha1.talos.dev")
+
+// ReconcileStatusID is a singleton instance ID.
+const ReconcileStatusID = resource.ID("reconcile")
+
+// ReconcileStatus describes whether the automatic reconciliation of the cluster state is paused.
+type ReconcileStatus struct {
+	md   resource.Metadata
+	spec ReconcileStatusSpec
+}
+
+// ReconcileStatusSpec describes the reconciliation status.
+type ReconcileStatusSpec struct {
+	Paused bool `yaml:"paused"`
+	// Until is the end of the pause, zero if the pause is not limited in time.
+	Until  time.Time `yaml:"until,omitempty"`
+	Reason string    `yaml:"reason,omitempty"`
+}
+
+// NewReconcileStatus initializes a ReconcileStatus resource.
+func NewReconcileStatus() *ReconcileStatus {
+	r := &ReconcileStatus{
+		md:   resource.NewMetadata(NamespaceName, ReconcileStatusType, ReconcileStatusID, resource.VersionUndefined),
+		spec: ReconcileStatusSpec{},
+	}
+
+	r.md.BumpVersion()
+
+	return r
+}
+
+// Metadata implements resource.Resource.
+func (r *ReconcileStatus) Metadata() *resource.Metadata {
+	return &r.md
+}
+
+// Spec implements resource.Resource.
+func (r *ReconcileStatus) Spec() interface{} {
+	return r.spec
+}
+
+func (r *ReconcileStatus) String() string {
+	return fmt.Sprintf("v1alpha1.ReconcileStatus(%q)", r.md.ID())
+}
+
+// DeepCopy implements resource.Resource.
+func (r *ReconcileStatus) DeepCopy() resource.Resource {
+	return &ReconcileStatus{
+		md:   r.md,
+		spec: r.spec,
+	}
+}
+
+// ResourceDefinition implements meta.ResourceDefinitionProvider interface.
+func (r *ReconcileStatus) ResourceDefinition() meta.ResourceDefinitionSpec {
+	return meta.ResourceDefinitionSpec{
+		Type:             ReconcileStatusType,
+		Aliases:          []resource.Type{},
+		DefaultNamespace: NamespaceName,
+		PrintColumns: []meta.PrintColumn{
+			{
+				Name:     "Paused",
+				JSONPath: "{.paused}",
+			},
+			{
+				Name:     "Until",
+				JSONPath: "{.until}",
+			},
+			{
+				Name:     "Reason",
+				JSONPath: "{.reason}",
+			},
+		},
+	}
+}
+
+// Status returns .spec.
+func (r *ReconcileStatus) Status() *ReconcileStatusSpec {
+	return &r.spec
+}
@@ -22,8 +22,11 @@ type Service struct {
 
 // ServiceSpec describe service state.
 type ServiceSpec struct {
-	Running bool `yaml:"running"`
-	Healthy bool `yaml:"healthy"`
+	State     string   `yaml:"state"`
+	Running   bool     `yaml:"running"`
+	Healthy   bool     `yaml:"healthy"`
+	Message   string   `yaml:"message"`
+	DependsOn []string `yaml:"dependsOn"`
 }
 
 // NewService initializes a Service resource.
@@ -54,9 +57,12 @@ func (r *Service) String() string {
 
 // DeepCopy implements resource.Resource.
 func (r *Service) DeepCopy() resource.Resource {
+	spec := r.spec
+	spec.DependsOn = append([]string(nil), r.spec.DependsOn...)
+
 	return &Service{
 		md:   r.md,
-		spec: r.spec,
+		spec: spec,
 	}
 }
 
@@ -67,6 +73,10 @@ func (r *Service) ResourceDefinition() meta.ResourceDefinitionSpec {
 		Aliases:          []resource.Type{"svc"},
 		DefaultNamespace: NamespaceName,
 		PrintColumns: []meta.PrintColumn{
+			{
+				Name:     "State",
+				JSONPath: "{.state}",
+			},
 			{
 				Name:     "Running",
 				JSONPath: "{.running}",
@@ -98,3 +108,29 @@ func (r *Service) Running() bool {
 func (r *Service) Healthy() bool {
 	return r.spec.Healthy
 }
+
+// SetState changes .spec.state and .spec.message.
+func (r *Service) SetState(state, message string) {
+	r.spec.State = state
+	r.spec.Message = message
+}
+
+// SetDependsOn changes .spec.dependsOn.
+func (r *Service) SetDependsOn(dependsOn []string) {
+	r.spec.DependsOn = dependsOn
+}
+
+// State returns .spec.state.
+func (r *Service) State() string {
+	return r.spec.State
+}
+
+// Message returns .spec.message.
+func (r *Service) Message() string {
+	return r.spec.Message
+}
+
+// DependsOn returns .spec.dependsOn.
+func (r *Service) DependsOn() []string {
+	return r.spec.DependsOn
+}
@@ -26,6 +26,8 @@ func TestRegisterResource(t *testing.T) {
 
 	for _, resource := range []resource.Resource{
 		&v1alpha1.BootstrapStatus{},
+		&v1alpha1.ReconcilePause{},
+		&v1alpha1.ReconcileStatus{},
 		&v1alpha1.Service{},
 	} {
 		assert.NoError(t, resourceRegistry.Register(ctx, resource))